@@ -0,0 +1,232 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package autotune
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/storemanager"
+)
+
+const (
+	defaultInterval = time.Hour
+	// Each round moves the level of a kind by this much, so it takes four rounds to go from one bound to the other
+	levelStep = 0.25
+	// Growth between these shares of the target is left alone, so the levels do not flip every round
+	loosenBelow  = 0.7
+	tightenAbove = 1.0
+)
+
+var (
+	metricAutoTuneProjectedBytes = promauto.NewGauge(prometheus.GaugeOpts{Name: "sloop_autotune_projected_bytes"})
+	metricAutoTuneTargetBytes    = promauto.NewGauge(prometheus.GaugeOpts{Name: "sloop_autotune_target_bytes"})
+	metricAutoTuneLevel          = promauto.NewGaugeVec(prometheus.GaugeOpts{Name: "sloop_autotune_level"}, []string{"kind"})
+	metricAutoTuneResync         = promauto.NewGaugeVec(prometheus.GaugeOpts{Name: "sloop_autotune_resync_sec"}, []string{"kind"})
+	metricAutoTuneSampling       = promauto.NewGaugeVec(prometheus.GaugeOpts{Name: "sloop_autotune_sampling_sec"}, []string{"kind"})
+)
+
+// How far the resync interval and sampling interval of a kind can be moved.  A kind is only tuned between the
+// bounds it has, and a max of 0 leaves that knob alone
+type Bounds struct {
+	MinResync   time.Duration `json:"minResync"`
+	MaxResync   time.Duration `json:"maxResync"`
+	MinSampling time.Duration `json:"minSampling"`
+	MaxSampling time.Duration `json:"maxSampling"`
+}
+
+type Config struct {
+	// How often the growth of the store is measured, over the same window.  Default 1h
+	Interval time.Duration `json:"interval"`
+	// Size of the watch table over max-look-back to aim for.  0 = half of max-disk-mb, the rest of the disk is the
+	// derived tables and Badger
+	TargetMb int `json:"targetMb"`
+	// Kind -> bounds.  No kinds turns auto tuning off
+	Kinds map[string]Bounds `json:"kinds"`
+}
+
+func (c Config) Enabled() bool {
+	return len(c.Kinds) > 0
+}
+
+func (c Config) Validate() error {
+	if c.Interval < 0 {
+		return fmt.Errorf("autoTune interval can not be < 0")
+	}
+	if c.TargetMb < 0 {
+		return fmt.Errorf("autoTune targetMb can not be < 0")
+	}
+	for kind, bounds := range c.Kinds {
+		if bounds.MinResync < 0 || bounds.MinSampling < 0 {
+			return fmt.Errorf("autoTune bounds of kind %v can not be < 0", kind)
+		}
+		if bounds.MaxResync > 0 && bounds.MaxResync < bounds.MinResync {
+			return fmt.Errorf("autoTune maxResync of kind %v is less than its minResync", kind)
+		}
+		if bounds.MaxSampling > 0 && bounds.MaxSampling < bounds.MinSampling {
+			return fmt.Errorf("autoTune maxSampling of kind %v is less than its minSampling", kind)
+		}
+		if bounds.MaxResync == 0 && bounds.MaxSampling == 0 {
+			return fmt.Errorf("autoTune kind %v needs a maxResync or maxSampling", kind)
+		}
+	}
+	return nil
+}
+
+// ingress.ResyncThrottle
+type ResyncSetter interface {
+	SetResyncInterval(kind string, interval time.Duration)
+}
+
+// processing.Runner
+type SamplingSetter interface {
+	SetSamplingInterval(kind string, interval time.Duration)
+}
+
+// What a round saw and set
+type Round struct {
+	StoredPerHour  float64 `json:"storedPerHour"`
+	ProjectedBytes int64   `json:"projectedBytes"`
+	TargetBytes    int64   `json:"targetBytes"`
+	// Kind -> level, 0 is at the min bounds and 1 at the max bounds
+	Levels map[string]float64 `json:"levels"`
+}
+
+/*
+A feedback controller that keeps the size of the store stable on clusters whose churn changes.  Each round it takes
+the bytes written to the watch table over the last interval, projects them over the retention, and compares that
+to the target.  Above the target the tuned kinds that wrote anything get longer resync intervals and more sampling,
+well below it they all get shorter ones again, down to their min bounds
+*/
+type Controller struct {
+	config      Config
+	tables      typed.Tables
+	retention   time.Duration
+	targetBytes int64
+	resync      ResyncSetter
+	sampling    SamplingSetter
+	levels      map[string]float64
+	done        chan bool
+	wg          *sync.WaitGroup
+}
+
+// The kinds start at their min bounds
+func NewController(config Config, tables typed.Tables, retention time.Duration, targetBytes int64, resync ResyncSetter, sampling SamplingSetter) *Controller {
+	if config.Interval <= 0 {
+		config.Interval = defaultInterval
+	}
+	c := &Controller{config: config, tables: tables, retention: retention, targetBytes: targetBytes, resync: resync, sampling: sampling, levels: map[string]float64{}, done: make(chan bool), wg: &sync.WaitGroup{}}
+	for kind := range config.Kinds {
+		c.apply(kind)
+	}
+	return c
+}
+
+func (c *Controller) Start() {
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		for {
+			select {
+			case <-c.done:
+				return
+			case <-time.After(c.config.Interval):
+			}
+			_, err := c.Step(time.Now())
+			if err != nil {
+				glog.Errorf("Auto tuning failed: %v", err)
+			}
+		}
+	}()
+}
+
+func (c *Controller) Stop() {
+	close(c.done)
+	c.wg.Wait()
+}
+
+// Runs one round of the controller at now
+func (c *Controller) Step(now time.Time) (Round, error) {
+	report, err := storemanager.GenerateBudgetReport(c.tables, now, c.config.Interval)
+	if err != nil {
+		return Round{}, err
+	}
+	storedPerHour := float64(report.Total.StoredBytes) / c.config.Interval.Hours()
+	round := Round{
+		StoredPerHour:  storedPerHour,
+		ProjectedBytes: int64(storedPerHour * c.retention.Hours()),
+		TargetBytes:    c.targetBytes,
+		Levels:         map[string]float64{},
+	}
+	metricAutoTuneProjectedBytes.Set(float64(round.ProjectedBytes))
+	metricAutoTuneTargetBytes.Set(float64(round.TargetBytes))
+
+	storedByKind := map[string]int64{}
+	for _, kind := range report.Kinds {
+		storedByKind[kind.Kind] = kind.StoredBytes
+	}
+	ratio := float64(round.ProjectedBytes) / float64(c.targetBytes)
+	for _, kind := range c.kinds() {
+		level := c.levels[kind]
+		switch {
+		case ratio > tightenAbove && storedByKind[kind] > 0:
+			level += levelStep
+		case ratio < loosenBelow:
+			level -= levelStep
+		}
+		if level < 0 {
+			level = 0
+		}
+		if level > 1 {
+			level = 1
+		}
+		if level != c.levels[kind] {
+			glog.Infof("Auto tuning moved kind %v from level %v to %v, projected %v bytes over %v against a target of %v", kind, c.levels[kind], level, round.ProjectedBytes, c.retention, c.targetBytes)
+			c.levels[kind] = level
+			c.apply(kind)
+		}
+		round.Levels[kind] = level
+	}
+	return round, nil
+}
+
+func (c *Controller) kinds() []string {
+	kinds := []string{}
+	for kind := range c.config.Kinds {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+func (c *Controller) apply(kind string) {
+	bounds := c.config.Kinds[kind]
+	level := c.levels[kind]
+	metricAutoTuneLevel.WithLabelValues(kind).Set(level)
+	if bounds.MaxResync > 0 && c.resync != nil {
+		interval := between(bounds.MinResync, bounds.MaxResync, level)
+		c.resync.SetResyncInterval(kind, interval)
+		metricAutoTuneResync.WithLabelValues(kind).Set(interval.Seconds())
+	}
+	if bounds.MaxSampling > 0 && c.sampling != nil {
+		interval := between(bounds.MinSampling, bounds.MaxSampling, level)
+		c.sampling.SetSamplingInterval(kind, interval)
+		metricAutoTuneSampling.WithLabelValues(kind).Set(interval.Seconds())
+	}
+}
+
+func between(min time.Duration, max time.Duration, level float64) time.Duration {
+	return min + time.Duration(float64(max-min)*level)
+}
@@ -0,0 +1,93 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package autotune
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+var someTs = time.Date(2019, 3, 4, 5, 0, 0, 0, time.UTC)
+
+type fakeSetter map[string]time.Duration
+
+func (f fakeSetter) SetResyncInterval(kind string, interval time.Duration) {
+	f[kind] = interval
+}
+
+func (f fakeSetter) SetSamplingInterval(kind string, interval time.Duration) {
+	f[kind] = interval
+}
+
+func Test_Config_Validate(t *testing.T) {
+	assert.Nil(t, Config{}.Validate())
+	assert.Nil(t, Config{Kinds: map[string]Bounds{"Pod": {MinResync: time.Hour, MaxResync: 4 * time.Hour}}}.Validate())
+	assert.NotNil(t, Config{Kinds: map[string]Bounds{"Pod": {MinResync: time.Hour, MaxResync: time.Minute}}}.Validate())
+	assert.NotNil(t, Config{Kinds: map[string]Bounds{"Pod": {MinSampling: time.Minute}}}.Validate())
+	assert.NotNil(t, Config{Interval: -time.Hour}.Validate())
+}
+
+func Test_Controller_Step(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		for i := 0; i < 10; i++ {
+			key := typed.NewWatchTableKey(untyped.GetPartitionId(someTs), "Pod", "ns", fmt.Sprintf("p%v", i), someTs)
+			err := tables.WatchTable().Set(txn, key.String(), &typed.KubeWatchResult{Kind: "Pod", Payload: `{"metadata": {"name": "x"}}`})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+
+	config := Config{Interval: time.Hour, Kinds: map[string]Bounds{
+		"Pod":  {MinResync: time.Hour, MaxResync: 5 * time.Hour, MinSampling: 0, MaxSampling: 4 * time.Minute},
+		"Node": {MinResync: time.Hour, MaxResync: 5 * time.Hour},
+	}}
+	resync := fakeSetter{}
+	sampling := fakeSetter{}
+	controller := NewController(config, tables, 24*time.Hour, 1, resync, sampling)
+	assert.Equal(t, time.Hour, resync["Pod"])
+	assert.Equal(t, time.Duration(0), sampling["Pod"])
+
+	// Growing past the target only tightens the kinds that wrote something
+	round, err := controller.Step(someTs.Add(30 * time.Minute))
+	assert.Nil(t, err)
+	assert.True(t, round.ProjectedBytes > round.TargetBytes)
+	assert.Equal(t, map[string]float64{"Node": 0, "Pod": 0.25}, round.Levels)
+	assert.Equal(t, 2*time.Hour, resync["Pod"])
+	assert.Equal(t, time.Minute, sampling["Pod"])
+	assert.Equal(t, time.Hour, resync["Node"])
+
+	for i := 0; i < 5; i++ {
+		_, err = controller.Step(someTs.Add(30 * time.Minute))
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, 5*time.Hour, resync["Pod"])
+	assert.Equal(t, 4*time.Minute, sampling["Pod"])
+
+	// Well below the target everything loosens again
+	controller.targetBytes = 1024 * 1024 * 1024
+	round, err = controller.Step(someTs.Add(30 * time.Minute))
+	assert.Nil(t, err)
+	assert.Equal(t, 0.75, round.Levels["Pod"])
+	assert.Equal(t, 4*time.Hour, resync["Pod"])
+	assert.Equal(t, 3*time.Minute, sampling["Pod"])
+}
@@ -0,0 +1,636 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/salesforce/sloop/pkg/sloop/queries"
+)
+
+/*
+Calls the query API of a running sloop, for tools that want its history without scraping the UI.  Responses are
+decoded into the output types of the queries package, so the client and server can not drift apart.
+*/
+
+const (
+	dataPath            = "/data"
+	resourceAtPath      = "/api/v1/resource/at"
+	eventHeatMapPath    = "/api/v1/eventheatmap"
+	structuredQueryPath = "/api/v1/query"
+	healthzPath         = "/healthz"
+
+	// Same layout the server expects for end_time combined with lookback
+	endTimeLayout = "2006-01-02T15:04:05"
+
+	defaultTimeout    = time.Minute
+	defaultRetryDelay = time.Second
+)
+
+type Config struct {
+	// Url of sloop including the cluster context, for example http://sloop:8080/mycluster
+	BaseUrl string
+	// Defaults to a client with a one minute timeout
+	HttpClient *http.Client
+	// How many more times to try a request that failed to connect or got a 429, 502, 503 or 504.  sloop answers a
+	// query that failed with 500, which is not retried because running it again gives the same answer
+	Retries int
+	// Wait before the first retry, doubled for each one after.  Defaults to a second
+	RetryDelay time.Duration
+	// Added to every request, for example the tenant header of an authenticating proxy
+	Headers map[string]string
+	// Called when the time range of a query started before the oldest data sloop still has, with the warning and
+	// the time the retained data starts at.  Results before it are missing, not quiet
+	OnRetentionWarning func(url string, warning string, retainedSince time.Time)
+	// Called when sloop is a query-only frontend of shards and some of them failed.  The results only have what the
+	// other shards returned
+	OnShardErrors func(url string, shardErrors []queries.ShardErrorOutput)
+}
+
+type Client struct {
+	baseUrl    string
+	httpClient *http.Client
+	retries    int
+	retryDelay time.Duration
+	headers    map[string]string
+
+	onRetentionWarning func(url string, warning string, retainedSince time.Time)
+	onShardErrors      func(url string, shardErrors []queries.ShardErrorOutput)
+}
+
+func NewClient(config Config) (*Client, error) {
+	parsed, err := url.Parse(config.BaseUrl)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid base url %q", config.BaseUrl)
+	}
+	if parsed.Scheme == "" || parsed.Host == "" {
+		return nil, fmt.Errorf("base url %q needs a scheme and host", config.BaseUrl)
+	}
+	if config.Retries < 0 {
+		return nil, fmt.Errorf("retries can not be negative")
+	}
+	c := &Client{
+		baseUrl:    strings.TrimSuffix(config.BaseUrl, "/"),
+		httpClient: config.HttpClient,
+		retries:    config.Retries,
+		retryDelay: config.RetryDelay,
+		headers:    config.Headers,
+
+		onRetentionWarning: config.OnRetentionWarning,
+		onShardErrors:      config.OnShardErrors,
+	}
+	if c.httpClient == nil {
+		c.httpClient = &http.Client{Timeout: defaultTimeout}
+	}
+	if c.retryDelay <= 0 {
+		c.retryDelay = defaultRetryDelay
+	}
+	return c, nil
+}
+
+// Returned for responses that are not 2xx, so callers can tell a bad query from sloop being unavailable
+type StatusError struct {
+	Url        string
+	StatusCode int
+	Body       string
+	// From the queries.ErrorEnvelope of the body, empty when sloop did not return one
+	Code    queries.ErrorCode
+	Message string
+}
+
+func (e *StatusError) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("%v returned status %v (%v): %v", e.Url, e.StatusCode, e.Code, e.Message)
+	}
+	return fmt.Sprintf("%v returned status %v: %v", e.Url, e.StatusCode, e.Body)
+}
+
+// Returned when sloop cut the response at the maximum response size of the query, see -max-query-response-bytes.  A
+// shorter time range or a narrower filter gets a complete one
+type TruncatedError struct {
+	Url              string
+	ResponseBytes    int64
+	MaxResponseBytes int64
+}
+
+func (e *TruncatedError) Error() string {
+	return fmt.Sprintf("%v returned a response of %v bytes, which was truncated to %v bytes", e.Url, e.ResponseBytes, e.MaxResponseBytes)
+}
+
+/*
+Parameters shared by the queries.  The time range is either Lookback, ending at EndTime or at the newest data sloop
+has when EndTime is not set, or both StartTime and EndTime.  Fields a query does not use are ignored by it
+*/
+type Filter struct {
+	Namespace string
+	Kind      string
+	Name      string
+	// Substring match on the name
+	NameMatch string
+	Uuid      string
+	Lookback  time.Duration
+	StartTime time.Time
+	EndTime   time.Time
+	Sort      string
+	// Node condition type for GetNodeHealth, like Ready
+	Condition string
+	// "current" keeps only the newest incarnation of each namespace
+	NamespaceEpoch string
+	// low, medium or high for GetFlappingResources and GetWriterConflicts
+	Sensitivity string
+	Ip          string
+	Node        string
+	// Leaves out the payloads of GetResPayload and GetCurrentState, which then only have their summary lines
+	SummaryOnly bool
+}
+
+func (f Filter) values() (url.Values, error) {
+	params := url.Values{}
+	switch {
+	case f.Lookback > 0 && !f.StartTime.IsZero():
+		return nil, fmt.Errorf("set either Lookback or StartTime, not both")
+	case f.Lookback > 0:
+		params.Set(queries.LookbackParam, f.Lookback.String())
+		if !f.EndTime.IsZero() {
+			params.Set(queries.EndTimeParam, f.EndTime.UTC().Format(endTimeLayout))
+		}
+	case !f.StartTime.IsZero() && !f.EndTime.IsZero():
+		params.Set(queries.StartTimeParam, strconv.FormatInt(f.StartTime.Unix(), 10))
+		params.Set(queries.EndTimeParam, strconv.FormatInt(f.EndTime.Unix(), 10))
+	default:
+		return nil, fmt.Errorf("the time range needs Lookback or both StartTime and EndTime")
+	}
+
+	optional := map[string]string{
+		queries.NamespaceParam:      f.Namespace,
+		queries.KindParam:           f.Kind,
+		queries.NameParam:           f.Name,
+		queries.NameMatchParam:      f.NameMatch,
+		queries.UuidParam:           f.Uuid,
+		queries.SortParam:           f.Sort,
+		queries.ConditionParam:      f.Condition,
+		queries.NamespaceEpochParam: f.NamespaceEpoch,
+		queries.SensitivityParam:    f.Sensitivity,
+		queries.IpParam:             f.Ip,
+		queries.NodeParam:           f.Node,
+	}
+	for key, value := range optional {
+		if value != "" {
+			params.Set(key, value)
+		}
+	}
+	if f.SummaryOnly {
+		params.Set(queries.SummaryOnlyParam, "true")
+	}
+	return params, nil
+}
+
+// Runs the named query and decodes its result into out.  Some queries answer an empty body when nothing matched,
+// which leaves out as it was
+func (c *Client) Query(ctx context.Context, query string, filter Filter, out interface{}) error {
+	params, err := filter.values()
+	if err != nil {
+		return err
+	}
+	params.Set(queries.QueryParam, query)
+	return c.get(ctx, dataPath, params, out)
+}
+
+func (c *Client) Namespaces(ctx context.Context, filter Filter) ([]string, error) {
+	output := []string{}
+	err := c.Query(ctx, "Namespaces", filter, &output)
+	return output, err
+}
+
+func (c *Client) Kinds(ctx context.Context, filter Filter) ([]string, error) {
+	output := []string{}
+	err := c.Query(ctx, "Kinds", filter, &output)
+	return output, err
+}
+
+func (c *Client) EventHeatMap(ctx context.Context, filter Filter) (*queries.TimelineRoot, error) {
+	output := &queries.TimelineRoot{}
+	err := c.Query(ctx, "EventHeatMap", filter, output)
+	return output, err
+}
+
+func (c *Client) GetEventData(ctx context.Context, filter Filter) ([]queries.EventOutput, error) {
+	output := []queries.EventOutput{}
+	err := c.Query(ctx, "GetEventData", filter, &output)
+	return output, err
+}
+
+// Payloads are sent as patches from the one before them and rebuilt here, so they are equal as json to the stored ones
+// but may order their keys differently
+func (c *Client) GetResPayload(ctx context.Context, filter Filter) ([]queries.PayloadOuput, error) {
+	params, err := filter.values()
+	if err != nil {
+		return nil, err
+	}
+	params.Set(queries.QueryParam, "GetResPayload")
+	params.Set(queries.PayloadFormatParam, queries.PayloadFormatPatch)
+	output := []queries.PayloadOuput{}
+	err = c.get(ctx, dataPath, params, &output)
+	if err != nil {
+		return output, err
+	}
+	return output, queries.ApplyPayloadPatches(output)
+}
+
+// Returns nil when sloop has no summary for the resource
+func (c *Client) GetResSummaryData(ctx context.Context, filter Filter) (*queries.ResSummaryOutput, error) {
+	output := &queries.ResSummaryOutput{}
+	err := c.Query(ctx, "GetResSummaryData", filter, output)
+	if err != nil || output.IsEmpty() {
+		return nil, err
+	}
+	return output, nil
+}
+
+func (c *Client) GetPodLifecycle(ctx context.Context, filter Filter) ([]*queries.PodLifecycleOutput, error) {
+	output := []*queries.PodLifecycleOutput{}
+	err := c.Query(ctx, "GetPodLifecycle", filter, &output)
+	return output, err
+}
+
+func (c *Client) GetNodeHealth(ctx context.Context, filter Filter) ([]queries.NodeHealthOutput, error) {
+	output := []queries.NodeHealthOutput{}
+	err := c.Query(ctx, "GetNodeHealth", filter, &output)
+	return output, err
+}
+
+func (c *Client) GetOwnerTree(ctx context.Context, filter Filter) (*queries.OwnerTreeOutput, error) {
+	output := &queries.OwnerTreeOutput{}
+	err := c.Query(ctx, "GetOwnerTree", filter, output)
+	return output, err
+}
+
+func (c *Client) GetServiceBackends(ctx context.Context, filter Filter) (*queries.ServiceBackendsOutput, error) {
+	output := &queries.ServiceBackendsOutput{}
+	err := c.Query(ctx, "GetServiceBackends", filter, output)
+	return output, err
+}
+
+func (c *Client) GetVolumeBinding(ctx context.Context, filter Filter) (*queries.VolumeBindingOutput, error) {
+	output := &queries.VolumeBindingOutput{}
+	err := c.Query(ctx, "GetVolumeBinding", filter, output)
+	return output, err
+}
+
+func (c *Client) GetCurrentState(ctx context.Context, filter Filter) ([]queries.CurrentStateOutput, error) {
+	output := []queries.CurrentStateOutput{}
+	err := c.Query(ctx, "GetCurrentState", filter, &output)
+	return output, err
+}
+
+func (c *Client) GetNamespaceEpochs(ctx context.Context, filter Filter) ([]queries.NamespaceEpochOutput, error) {
+	output := []queries.NamespaceEpochOutput{}
+	err := c.Query(ctx, "GetNamespaceEpochs", filter, &output)
+	return output, err
+}
+
+func (c *Client) GetIngestAnnotations(ctx context.Context, filter Filter) ([]queries.IngestAnnotationOutput, error) {
+	output := []queries.IngestAnnotationOutput{}
+	err := c.Query(ctx, "GetIngestAnnotations", filter, &output)
+	return output, err
+}
+
+func (c *Client) GetFlappingResources(ctx context.Context, filter Filter) ([]queries.FlappingResourceOutput, error) {
+	output := []queries.FlappingResourceOutput{}
+	err := c.Query(ctx, "GetFlappingResources", filter, &output)
+	return output, err
+}
+
+func (c *Client) GetWriterConflicts(ctx context.Context, filter Filter) ([]queries.WriterConflictOutput, error) {
+	output := []queries.WriterConflictOutput{}
+	err := c.Query(ctx, "GetWriterConflicts", filter, &output)
+	return output, err
+}
+
+func (c *Client) GetQuotaUtilization(ctx context.Context, filter Filter) ([]queries.QuotaUtilizationOutput, error) {
+	output := []queries.QuotaUtilizationOutput{}
+	err := c.Query(ctx, "GetQuotaUtilization", filter, &output)
+	return output, err
+}
+
+func (c *Client) GetCredentialUsage(ctx context.Context, filter Filter) ([]queries.CredentialUsageOutput, error) {
+	output := []queries.CredentialUsageOutput{}
+	err := c.Query(ctx, "GetCredentialUsage", filter, &output)
+	return output, err
+}
+
+func (c *Client) GetNetworkReferences(ctx context.Context, filter Filter) ([]queries.NetworkReferenceOutput, error) {
+	output := []queries.NetworkReferenceOutput{}
+	err := c.Query(ctx, "GetNetworkReferences", filter, &output)
+	return output, err
+}
+
+func (c *Client) GetDeploymentRollouts(ctx context.Context, filter Filter) ([]queries.DeploymentRolloutOutput, error) {
+	output := []queries.DeploymentRolloutOutput{}
+	err := c.Query(ctx, "GetDeploymentRollouts", filter, &output)
+	return output, err
+}
+
+func (c *Client) GetEventBreakdown(ctx context.Context, filter Filter) ([]queries.EventBreakdownOutput, error) {
+	output := []queries.EventBreakdownOutput{}
+	err := c.Query(ctx, "GetEventBreakdown", filter, &output)
+	return output, err
+}
+
+// Compares the resources at Filter.StartTime with the ones at Filter.EndTime
+func (c *Client) GetSnapshotDiff(ctx context.Context, filter Filter) (*queries.SnapshotDiffOutput, error) {
+	output := &queries.SnapshotDiffOutput{}
+	err := c.Query(ctx, "GetSnapshotDiff", filter, output)
+	return output, err
+}
+
+// The api versions each kind was stored with in the time range and the resources that changed api version
+func (c *Client) GetApiVersionMigrations(ctx context.Context, filter Filter) (*queries.ApiVersionsOutput, error) {
+	output := &queries.ApiVersionsOutput{}
+	err := c.Query(ctx, "GetApiVersionMigrations", filter, output)
+	return output, err
+}
+
+// Compares the resources of filter.Namespace with the ones of otherNamespace at the end of the time range.  They are
+// matched by name, or by the value of matchLabel when it is not empty
+func (c *Client) GetNamespaceComparison(ctx context.Context, filter Filter, otherNamespace string, matchLabel string) (*queries.NamespaceComparisonOutput, error) {
+	params, err := filter.values()
+	if err != nil {
+		return nil, err
+	}
+	params.Set(queries.QueryParam, "GetNamespaceComparison")
+	params.Set(queries.OtherNamespaceParam, otherNamespace)
+	if matchLabel != "" {
+		params.Set(queries.MatchLabelParam, matchLabel)
+	}
+	output := &queries.NamespaceComparisonOutput{}
+	err = c.get(ctx, dataPath, params, output)
+	return output, err
+}
+
+// Samples of the resources sloop itself used in the time range
+func (c *Client) GetSelfFootprint(ctx context.Context, filter Filter) (*queries.SelfFootprintOutput, error) {
+	output := &queries.SelfFootprintOutput{}
+	err := c.Query(ctx, "GetSelfFootprint", filter, output)
+	return output, err
+}
+
+// Filter.Name limits it to one APIService
+func (c *Client) GetApiServiceAvailability(ctx context.Context, filter Filter) ([]queries.ApiServiceAvailabilityOutput, error) {
+	output := []queries.ApiServiceAvailabilityOutput{}
+	err := c.Query(ctx, "GetApiServiceAvailability", filter, &output)
+	return output, err
+}
+
+// granularity is minute, hour or day, empty picks one from the length of the time range
+func (c *Client) GetEventTrend(ctx context.Context, filter Filter, granularity string) (*queries.EventTrendOutput, error) {
+	params, err := filter.values()
+	if err != nil {
+		return nil, err
+	}
+	params.Set(queries.QueryParam, "GetEventTrend")
+	if granularity != "" {
+		params.Set(queries.GranularityParam, granularity)
+	}
+	output := &queries.EventTrendOutput{}
+	err = c.get(ctx, dataPath, params, output)
+	return output, err
+}
+
+func (c *Client) GetDeletionCascade(ctx context.Context, filter Filter) (*queries.DeletionCascadeOutput, error) {
+	output := &queries.DeletionCascadeOutput{}
+	err := c.Query(ctx, "GetDeletionCascade", filter, output)
+	return output, err
+}
+
+// groupBy are node pool attributes like zone, instanceType or arch, empty groups by zone
+func (c *Client) GetNodePoolPods(ctx context.Context, filter Filter, groupBy ...string) (*queries.NodePoolOutput, error) {
+	params, err := filter.values()
+	if err != nil {
+		return nil, err
+	}
+	params.Set(queries.QueryParam, "GetNodePoolPods")
+	if len(groupBy) > 0 {
+		params.Set(queries.GroupByParam, strings.Join(groupBy, ","))
+	}
+	output := &queries.NodePoolOutput{}
+	err = c.get(ctx, dataPath, params, output)
+	return output, err
+}
+
+// A limit above 0 sets when each series reaches that many objects
+func (c *Client) GetResourceCounts(ctx context.Context, filter Filter, limit int) (*queries.ResourceCountOutput, error) {
+	params, err := filter.values()
+	if err != nil {
+		return nil, err
+	}
+	params.Set(queries.QueryParam, "GetResourceCounts")
+	if limit > 0 {
+		params.Set(queries.LimitParam, strconv.Itoa(limit))
+	}
+	output := &queries.ResourceCountOutput{}
+	err = c.get(ctx, dataPath, params, output)
+	return output, err
+}
+
+// Changes to a Secret are not stored by sloop, pass them as changeTimes
+func (c *Client) GetConfigImpact(ctx context.Context, filter Filter, changeTimes ...time.Time) (*queries.ConfigImpactOutput, error) {
+	params, err := filter.values()
+	if err != nil {
+		return nil, err
+	}
+	params.Set(queries.QueryParam, "GetConfigImpact")
+	for _, changeTime := range changeTimes {
+		params.Add(queries.ChangeTimeParam, strconv.FormatInt(changeTime.Unix(), 10))
+	}
+	output := &queries.ConfigImpactOutput{}
+	err = c.get(ctx, dataPath, params, output)
+	return output, err
+}
+
+// Returns the plan of the named query instead of running it
+func (c *Client) Explain(ctx context.Context, query string, filter Filter) (*queries.ExplainOutput, error) {
+	params, err := filter.values()
+	if err != nil {
+		return nil, err
+	}
+	params.Set(queries.QueryParam, query)
+	params.Set(queries.ExplainParam, "true")
+	output := &queries.ExplainOutput{}
+	err = c.get(ctx, dataPath, params, output)
+	return output, err
+}
+
+// Returns the resource as it was stored at or before at, or nil when sloop has no version of it that old
+func (c *Client) ResourceAt(ctx context.Context, kind string, namespace string, name string, at time.Time) (*queries.ResourceAtOutput, error) {
+	return c.resourceAt(ctx, kind, namespace, name, at, false)
+}
+
+// Like ResourceAt, with the config maps, secrets, service account and claims its pod spec refers to as they were at
+// the same time in References
+func (c *Client) ResourceAtResolved(ctx context.Context, kind string, namespace string, name string, at time.Time) (*queries.ResourceAtOutput, error) {
+	return c.resourceAt(ctx, kind, namespace, name, at, true)
+}
+
+func (c *Client) resourceAt(ctx context.Context, kind string, namespace string, name string, at time.Time, resolve bool) (*queries.ResourceAtOutput, error) {
+	params := url.Values{}
+	params.Set(queries.KindParam, kind)
+	params.Set(queries.NamespaceParam, namespace)
+	params.Set(queries.NameParam, name)
+	params.Set("t", strconv.FormatInt(at.Unix(), 10))
+	if resolve {
+		params.Set("resolve", "true")
+	}
+	output := &queries.ResourceAtOutput{}
+	err := c.get(ctx, resourceAtPath, params, output)
+	if statusErr, ok := err.(*StatusError); ok && statusErr.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+// Event counts of each namespace by time bucket and event type.  bucket is a multiple of 5m, 0 picks one from the
+// length of the time range.  Only the time range and namespace of the filter are used
+func (c *Client) GetNamespaceEventHeatMap(ctx context.Context, filter Filter, bucket time.Duration) (*queries.NamespaceHeatMapOutput, error) {
+	params, err := filter.values()
+	if err != nil {
+		return nil, err
+	}
+	if bucket > 0 {
+		params.Set(queries.BucketParam, bucket.String())
+	}
+	output := &queries.NamespaceHeatMapOutput{}
+	err = c.get(ctx, eventHeatMapPath, params, output)
+	return output, err
+}
+
+// Runs a query written in the query language, see queries.ParseStructuredQuery
+func (c *Client) StructuredQuery(ctx context.Context, query string) (*queries.StructuredQueryOutput, error) {
+	params := url.Values{}
+	params.Set("q", query)
+	output := &queries.StructuredQueryOutput{}
+	err := c.get(ctx, structuredQueryPath, params, output)
+	return output, err
+}
+
+// Returns nil when sloop is serving
+func (c *Client) Healthz(ctx context.Context) error {
+	_, _, err := c.doWithRetries(ctx, healthzPath, nil)
+	return err
+}
+
+func (c *Client) get(ctx context.Context, path string, params url.Values, out interface{}) error {
+	target, body, err := c.doWithRetries(ctx, path, params)
+	if err != nil {
+		return err
+	}
+	if len(strings.TrimSpace(string(body))) == 0 {
+		return nil
+	}
+	err = json.Unmarshal(body, out)
+	if err != nil {
+		return errors.Wrapf(err, "failed to unmarshal response from %v", target)
+	}
+	return nil
+}
+
+func (c *Client) doWithRetries(ctx context.Context, path string, params url.Values) (string, []byte, error) {
+	target := c.baseUrl + path
+	if len(params) > 0 {
+		target += "?" + params.Encode()
+	}
+	delay := c.retryDelay
+	for attempt := 0; ; attempt++ {
+		body, err := c.do(ctx, target)
+		if err == nil || attempt >= c.retries || !retryable(err) || ctx.Err() != nil {
+			return target, body, err
+		}
+		select {
+		case <-ctx.Done():
+			return target, nil, errors.Wrapf(ctx.Err(), "gave up calling %v after: %v", target, err)
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+func (c *Client) do(ctx context.Context, target string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to build request for %v", target)
+	}
+	req.Header.Set("accept", "application/json")
+	for key, value := range c.headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to call %v", target)
+	}
+	defer resp.Body.Close()
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read response from %v", target)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		statusErr := &StatusError{Url: target, StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(respBody))}
+		envelope := queries.ErrorEnvelope{}
+		if json.Unmarshal(respBody, &envelope) == nil {
+			statusErr.Code = envelope.Error.Code
+			statusErr.Message = envelope.Error.Message
+		}
+		return nil, statusErr
+	}
+	if resp.Header.Get(queries.TruncatedHeader) != "" {
+		responseBytes, _ := strconv.ParseInt(resp.Header.Get(queries.ResponseBytesHeader), 10, 64)
+		maxResponseBytes, _ := strconv.ParseInt(resp.Header.Get(queries.MaxResponseBytesHeader), 10, 64)
+		return nil, &TruncatedError{Url: target, ResponseBytes: responseBytes, MaxResponseBytes: maxResponseBytes}
+	}
+	if warning := resp.Header.Get(queries.RetentionWarningHeader); warning != "" && c.onRetentionWarning != nil {
+		retainedSince, _ := strconv.ParseInt(resp.Header.Get(queries.RetainedSinceHeader), 10, 64)
+		c.onRetentionWarning(target, warning, time.Unix(retainedSince, 0).UTC())
+	}
+	if header := resp.Header.Get(queries.ShardErrorsHeader); header != "" && c.onShardErrors != nil {
+		shardErrors := []queries.ShardErrorOutput{}
+		if json.Unmarshal([]byte(header), &shardErrors) == nil {
+			c.onShardErrors(target, shardErrors)
+		}
+	}
+	return respBody, nil
+}
+
+// Failing to connect or to read the response is worth another try, as are the statuses of an overloaded or
+// restarting sloop
+func retryable(err error) bool {
+	if _, ok := err.(*TruncatedError); ok {
+		return false
+	}
+	statusErr, ok := err.(*StatusError)
+	if !ok {
+		return true
+	}
+	switch statusErr.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
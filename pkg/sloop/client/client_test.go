@@ -0,0 +1,294 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/salesforce/sloop/pkg/sloop/queries"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+var someTs = time.Date(2019, 3, 4, 3, 4, 0, 0, time.UTC)
+
+const somePod = `{"metadata":{"name":"pod1","namespace":"ns","uid":"uid1"},"spec":{"nodeName":"node1"},"status":{"podIP":"10.0.0.1"}}`
+
+// Serves the query API over a store holding somePod, the way the webserver does under the context ctx
+func helper_server(t *testing.T) *httptest.Server {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	pts, _ := ptypes.TimestampProto(someTs)
+	key := typed.NewWatchTableKey(untyped.GetPartitionId(someTs), "Pod", "ns", "pod1", someTs)
+	err = tables.Db().Update(func(txn badgerwrap.Txn) error {
+		return tables.WatchTable().Set(txn, key.String(), &typed.KubeWatchResult{Kind: "Pod", Timestamp: pts, Payload: somePod})
+	})
+	assert.Nil(t, err)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ctx/data", func(w http.ResponseWriter, r *http.Request) {
+		data, err := queries.RunQuery(r.URL.Query().Get(queries.QueryParam), r.URL.Query(), tables, 24*time.Hour, "")
+		if err != nil {
+			code := queries.ErrorCodeOf(err)
+			w.WriteHeader(code.Status())
+			json.NewEncoder(w).Encode(queries.ErrorEnvelope{Error: queries.ErrorBody{Code: code, Message: err.Error()}})
+			return
+		}
+		w.Write(data)
+	})
+	mux.HandleFunc("/ctx/api/v1/resource/at", func(w http.ResponseWriter, r *http.Request) {
+		seconds, _ := strconv.ParseInt(r.URL.Query().Get("t"), 10, 64)
+		output, err := queries.GetResourceAt(tables, r.URL.Query().Get(queries.KindParam), r.URL.Query().Get(queries.NamespaceParam), r.URL.Query().Get(queries.NameParam), time.Unix(seconds, 0))
+		assert.Nil(t, err)
+		if output == nil {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		data, _ := json.Marshal(output)
+		w.Write(data)
+	})
+	return httptest.NewServer(mux)
+}
+
+func helper_client(t *testing.T, baseUrl string, retries int) *Client {
+	c, err := NewClient(Config{BaseUrl: baseUrl, Retries: retries, RetryDelay: time.Millisecond})
+	assert.Nil(t, err)
+	return c
+}
+
+func Test_NewClient_BadBaseUrl(t *testing.T) {
+	_, err := NewClient(Config{BaseUrl: "sloop:8080"})
+	assert.NotNil(t, err)
+	_, err = NewClient(Config{BaseUrl: "http://sloop:8080/ctx", Retries: -1})
+	assert.NotNil(t, err)
+}
+
+func Test_Filter_Values(t *testing.T) {
+	params, err := Filter{Lookback: time.Hour, EndTime: someTs, Namespace: "ns"}.values()
+	assert.Nil(t, err)
+	assert.Equal(t, "1h0m0s", params.Get(queries.LookbackParam))
+	assert.Equal(t, "ns", params.Get(queries.NamespaceParam))
+	assert.Equal(t, "", params.Get(queries.SummaryOnlyParam))
+	assert.Equal(t, "2019-03-04T03:04:00", params.Get(queries.EndTimeParam))
+
+	params, err = Filter{StartTime: someTs, EndTime: someTs.Add(time.Hour), SummaryOnly: true}.values()
+	assert.Nil(t, err)
+	assert.Equal(t, "true", params.Get(queries.SummaryOnlyParam))
+	assert.Equal(t, "1551668640", params.Get(queries.StartTimeParam))
+	assert.Equal(t, "1551672240", params.Get(queries.EndTimeParam))
+
+	_, err = Filter{}.values()
+	assert.NotNil(t, err)
+	_, err = Filter{Lookback: time.Hour, StartTime: someTs}.values()
+	assert.NotNil(t, err)
+}
+
+func Test_Client_TypedQuery(t *testing.T) {
+	server := helper_server(t)
+	defer server.Close()
+	c := helper_client(t, server.URL+"/ctx/", 0)
+
+	filter := Filter{StartTime: someTs.Add(-time.Hour), EndTime: someTs.Add(time.Minute), Namespace: queries.AllNamespaces, Ip: "10.0.0.1"}
+	refs, err := c.GetNetworkReferences(context.Background(), filter)
+	assert.Nil(t, err)
+	assert.Equal(t, []queries.NetworkReferenceOutput{{Kind: "Pod", Namespace: "ns", Name: "pod1", Fields: []string{"status.podIP"}, From: someTs.Unix(), To: someTs.Add(time.Minute).Unix(), Current: true}}, refs)
+
+	plan, err := c.Explain(context.Background(), "GetNetworkReferences", filter)
+	assert.Nil(t, err)
+	assert.Equal(t, "GetNetworkReferences", plan.Query)
+}
+
+func Test_Client_GetResPayload_AppliesPatches(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, queries.PayloadFormatPatch, r.URL.Query().Get(queries.PayloadFormatParam))
+		w.Write([]byte(`[{"payloadKey":"a","payload":"{\"v\":1}"},{"payloadKey":"b","patch":[{"op":"replace","path":"/v","value":2}]}]`))
+	}))
+	defer server.Close()
+	c := helper_client(t, server.URL, 0)
+
+	payloads, err := c.GetResPayload(context.Background(), Filter{Lookback: time.Hour})
+	assert.Nil(t, err)
+	assert.Len(t, payloads, 2)
+	assert.Equal(t, `{"v":2}`, payloads[1].Payload)
+	assert.Nil(t, payloads[1].Patch)
+}
+
+func Test_Client_EmptyBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	c := helper_client(t, server.URL, 0)
+
+	summary, err := c.GetResSummaryData(context.Background(), Filter{Lookback: time.Hour})
+	assert.Nil(t, err)
+	assert.Nil(t, summary)
+	events, err := c.GetEventData(context.Background(), Filter{Lookback: time.Hour})
+	assert.Nil(t, err)
+	assert.Equal(t, []queries.EventOutput{}, events)
+}
+
+func Test_Client_QueryErrorIsNotRetried(t *testing.T) {
+	server := helper_server(t)
+	defer server.Close()
+	c := helper_client(t, server.URL+"/ctx", 3)
+
+	_, err := c.GetNetworkReferences(context.Background(), Filter{Lookback: time.Hour})
+	statusErr, ok := err.(*StatusError)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusBadRequest, statusErr.StatusCode)
+	assert.Equal(t, queries.ErrorCodeBadParams, statusErr.Code)
+	assert.Contains(t, statusErr.Message, "one of ip or node is required")
+}
+
+func Test_Client_TruncatedIsNotRetried(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set(queries.TruncatedHeader, "true")
+		w.Header().Set(queries.ResponseBytesHeader, "100")
+		w.Header().Set(queries.MaxResponseBytesHeader, "10")
+		w.Write([]byte(`[{"name":`))
+	}))
+	defer server.Close()
+	c := helper_client(t, server.URL, 3)
+
+	_, err := c.GetEventData(context.Background(), Filter{Lookback: time.Hour})
+	truncatedErr, ok := err.(*TruncatedError)
+	assert.True(t, ok)
+	assert.Equal(t, int64(100), truncatedErr.ResponseBytes)
+	assert.Equal(t, int64(10), truncatedErr.MaxResponseBytes)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func Test_Client_OnRetentionWarning(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(queries.RetentionWarningHeader, "data before 2019-03-01T03:00Z has been purged")
+		w.Header().Set(queries.RetainedSinceHeader, "1551409200")
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+	var warnings []string
+	var retainedSince time.Time
+	c, err := NewClient(Config{BaseUrl: server.URL, OnRetentionWarning: func(url string, warning string, since time.Time) {
+		warnings = append(warnings, warning)
+		retainedSince = since
+	}})
+	assert.Nil(t, err)
+
+	_, err = c.GetEventData(context.Background(), Filter{Lookback: time.Hour})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"data before 2019-03-01T03:00Z has been purged"}, warnings)
+	assert.Equal(t, time.Date(2019, 3, 1, 3, 0, 0, 0, time.UTC), retainedSince)
+}
+
+func Test_Client_OnShardErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(queries.ShardErrorsHeader, `[{"shard":"b","code":"internal","message":"boom"}]`)
+		w.Write([]byte(`["Pod"]`))
+	}))
+	defer server.Close()
+	var shardErrors []queries.ShardErrorOutput
+	c, err := NewClient(Config{BaseUrl: server.URL, OnShardErrors: func(url string, errs []queries.ShardErrorOutput) {
+		shardErrors = errs
+	}})
+	assert.Nil(t, err)
+
+	kinds, err := c.Kinds(context.Background(), Filter{Lookback: time.Hour})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Pod"}, kinds)
+	assert.Equal(t, []queries.ShardErrorOutput{{Shard: "b", Code: queries.ErrorCodeInternal, Message: "boom"}}, shardErrors)
+}
+
+func Test_Client_RetriesUnavailable(t *testing.T) {
+	var calls int32
+	var tenant atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tenant.Store(r.Header.Get("X-Tenant"))
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`["_all","ns"]`))
+	}))
+	defer server.Close()
+
+	c, err := NewClient(Config{BaseUrl: server.URL, Retries: 2, RetryDelay: time.Millisecond, Headers: map[string]string{"X-Tenant": "team-a"}})
+	assert.Nil(t, err)
+	namespaces, err := c.Namespaces(context.Background(), Filter{Lookback: time.Hour})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"_all", "ns"}, namespaces)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	assert.Equal(t, "team-a", tenant.Load())
+
+	atomic.StoreInt32(&calls, 0)
+	c = helper_client(t, server.URL, 1)
+	_, err = c.Namespaces(context.Background(), Filter{Lookback: time.Hour})
+	assert.NotNil(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func Test_Client_CancelStopsRetries(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	c, err := NewClient(Config{BaseUrl: server.URL, Retries: 10, RetryDelay: time.Hour})
+	assert.Nil(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = c.Kinds(ctx, Filter{Lookback: time.Hour})
+	assert.NotNil(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func Test_Client_ResourceAt(t *testing.T) {
+	server := helper_server(t)
+	defer server.Close()
+	c := helper_client(t, server.URL+"/ctx", 0)
+
+	resource, err := c.ResourceAt(context.Background(), "Pod", "ns", "pod1", someTs.Add(time.Minute))
+	assert.Nil(t, err)
+	assert.Equal(t, "pod1", resource.Name)
+	assert.Equal(t, someTs.Unix(), resource.Timestamp)
+	assert.JSONEq(t, somePod, string(resource.Payload))
+
+	resource, err = c.ResourceAt(context.Background(), "Pod", "ns", "pod1", someTs.Add(-time.Hour))
+	assert.Nil(t, err)
+	assert.Nil(t, resource)
+}
+
+func Test_Client_GetNamespaceEventHeatMap(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/ctx/api/v1/eventheatmap", r.URL.Path)
+		assert.Equal(t, "ns", r.URL.Query().Get(queries.NamespaceParam))
+		assert.Equal(t, "1h0m0s", r.URL.Query().Get(queries.BucketParam))
+		w.Write([]byte(`{"bucketSeconds":3600,"namespaces":[{"namespace":"ns","total":2,"cells":[{"timestamp":1551668400,"counts":{"Warning":2}}]}]}`))
+	}))
+	defer server.Close()
+	c := helper_client(t, server.URL+"/ctx", 0)
+
+	output, err := c.GetNamespaceEventHeatMap(context.Background(), Filter{Lookback: time.Hour, Namespace: "ns"}, time.Hour)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3600), output.BucketSeconds)
+	assert.Equal(t, map[string]int64{"Warning": 2}, output.Namespaces[0].Cells[0].Counts)
+}
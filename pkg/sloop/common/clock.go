@@ -0,0 +1,84 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package common
+
+import (
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+)
+
+/*
+Source of the current time for the code that decides which partition a watch result goes to, which partitions are
+old enough for GC and what now is for queries.  Durations, like how long something took, still use the wall clock.
+A frozen clock makes tests of retention and GC deterministic, and keeps a playback looking like it was just recorded
+*/
+type Clock interface {
+	Now() time.Time
+}
+
+type wallClock struct{}
+
+func (wallClock) Now() time.Time {
+	return time.Now()
+}
+
+// Stays at its time until it is set or advanced
+type FrozenClock struct {
+	lock sync.Mutex
+	now  time.Time
+}
+
+func NewFrozenClock(now time.Time) *FrozenClock {
+	return &FrozenClock{now: now}
+}
+
+func (c *FrozenClock) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.now
+}
+
+func (c *FrozenClock) Set(now time.Time) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.now = now
+}
+
+func (c *FrozenClock) Advance(duration time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.now = c.now.Add(duration)
+}
+
+var (
+	clockLock = &sync.RWMutex{}
+	clock     = Clock(wallClock{})
+)
+
+// Replaces the clock of the process and returns the one it replaced, so tests can put it back
+func SetClock(newClock Clock) Clock {
+	clockLock.Lock()
+	defer clockLock.Unlock()
+	previous := clock
+	clock = newClock
+	return previous
+}
+
+func Now() time.Time {
+	clockLock.RLock()
+	defer clockLock.RUnlock()
+	return clock.Now()
+}
+
+func TimestampNow() *timestamp.Timestamp {
+	ts, _ := ptypes.TimestampProto(Now())
+	return ts
+}
@@ -0,0 +1,40 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+)
+
+var someClockTime = time.Date(2019, 1, 2, 3, 4, 5, 6, time.UTC)
+
+func Test_FrozenClock_SetAndAdvance(t *testing.T) {
+	frozen := NewFrozenClock(someClockTime)
+	defer SetClock(SetClock(frozen))
+
+	assert.Equal(t, someClockTime, Now())
+	assert.Equal(t, someClockTime, Now())
+	frozen.Advance(time.Hour)
+	assert.Equal(t, someClockTime.Add(time.Hour), Now())
+	ts, err := ptypes.Timestamp(TimestampNow())
+	assert.Nil(t, err)
+	assert.Equal(t, someClockTime.Add(time.Hour), ts)
+	frozen.Set(someClockTime)
+	assert.Equal(t, someClockTime, Now())
+}
+
+func Test_SetClock_ReturnsPrevious(t *testing.T) {
+	frozen := NewFrozenClock(someClockTime)
+	previous := SetClock(frozen)
+	assert.Equal(t, Clock(frozen), SetClock(previous))
+	assert.WithinDuration(t, time.Now(), Now(), time.Minute)
+}
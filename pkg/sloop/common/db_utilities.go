@@ -28,6 +28,12 @@ func deleteKeys(db badgerwrap.DB, keysForDelete [][]byte) (error, uint64) {
 
 // deletes the keys with a given prefix
 func DeleteKeysWithPrefix(keyPrefix string, db badgerwrap.DB, deletionBatchSize int, numOfKeysToDelete uint64) (error, uint64, uint64) {
+	return DeleteKeysWithPrefixPaced(keyPrefix, db, deletionBatchSize, numOfKeysToDelete, nil)
+}
+
+// Like DeleteKeysWithPrefix, but calls afterBatch with the number of keys deleted after each batch, which can sleep to
+// pace the deletes.  Deletion stops early when afterBatch returns false
+func DeleteKeysWithPrefixPaced(keyPrefix string, db badgerwrap.DB, deletionBatchSize int, numOfKeysToDelete uint64, afterBatch func(uint64) bool) (error, uint64, uint64) {
 
 	// as deletion does not lock db there is a possibility that the keys for a given prefix are added while old ones are deleted. In this case it can get into a race condition.
 	// In order to avoid this, count of existing keys is used which match the given prefix and deletion ends when this number of keys have been deleted
@@ -57,14 +63,19 @@ func DeleteKeysWithPrefix(keyPrefix string, db badgerwrap.DB, deletionBatchSize
 			return nil
 		})
 
+		// Nothing is left to delete even though fewer keys were found than counted before
+		if len(keysThisBatch) == 0 {
+			break
+		}
 		// deleting the keys in batch
-		if len(keysThisBatch) > 0 {
-			err, deletedKeysInThisBatch := deleteKeys(db, keysThisBatch)
-			numOfKeysDeleted += deletedKeysInThisBatch
-			if err != nil {
-				glog.Errorf("Error encountered while deleting keys with prefix: '%v', numberOfKeysDeleted: '%v' numOfKeysToDelete: '%v'", keyPrefix, numOfKeysDeleted, numOfKeysToDelete)
-				return err, numOfKeysDeleted, numOfKeysToDelete
-			}
+		err, deletedKeysInThisBatch := deleteKeys(db, keysThisBatch)
+		numOfKeysDeleted += deletedKeysInThisBatch
+		if err != nil {
+			glog.Errorf("Error encountered while deleting keys with prefix: '%v', numberOfKeysDeleted: '%v' numOfKeysToDelete: '%v'", keyPrefix, numOfKeysDeleted, numOfKeysToDelete)
+			return err, numOfKeysDeleted, numOfKeysToDelete
+		}
+		if afterBatch != nil && numOfKeysDeleted < numOfKeysToDelete && !afterBatch(deletedKeysInThisBatch) {
+			break
 		}
 	}
 	return nil, numOfKeysDeleted, numOfKeysToDelete
@@ -89,3 +100,20 @@ func GetTotalKeyCount(db badgerwrap.DB, keyPrefix string) uint64 {
 	})
 	return totalKeyCount
 }
+
+// deletes the given keys in transactions of at most deletionBatchSize keys
+func DeleteKeysInBatches(db badgerwrap.DB, keys [][]byte, deletionBatchSize int) (error, uint64) {
+	var numOfKeysDeleted uint64 = 0
+	for start := 0; start < len(keys); start += deletionBatchSize {
+		end := start + deletionBatchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+		err, deletedKeysInThisBatch := deleteKeys(db, keys[start:end])
+		numOfKeysDeleted += deletedKeysInThisBatch
+		if err != nil {
+			return err, numOfKeysDeleted
+		}
+	}
+	return nil, numOfKeysDeleted
+}
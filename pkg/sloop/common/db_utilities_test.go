@@ -38,6 +38,42 @@ func Test_Db_Utilities_DeleteKeysWithPrefix_DeleteSomeKeys(t *testing.T) {
 	assert.Equal(t, uint64(4), numOfKeysToDelete)
 }
 
+func Test_Db_Utilities_DeleteKeysWithPrefixPaced_CallsAfterEachBatch(t *testing.T) {
+	db := helper_get_db(t)
+	helper_add_keys_to_db(t, db, helper_testKeys_with_common_prefix(commonPrefix))
+	var batches []uint64
+	err, numOfDeletedKeys, numOfKeysToDelete := DeleteKeysWithPrefixPaced(commonPrefix, db, 3, 4, func(deleted uint64) bool {
+		batches = append(batches, deleted)
+		return true
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(4), numOfDeletedKeys)
+	assert.Equal(t, uint64(4), numOfKeysToDelete)
+	// Not called after the last batch
+	assert.Equal(t, []uint64{3}, batches)
+}
+
+func Test_Db_Utilities_DeleteKeysWithPrefixPaced_Stops(t *testing.T) {
+	db := helper_get_db(t)
+	helper_add_keys_to_db(t, db, helper_testKeys_with_common_prefix(commonPrefix))
+	err, numOfDeletedKeys, numOfKeysToDelete := DeleteKeysWithPrefixPaced(commonPrefix, db, 1, 4, func(deleted uint64) bool {
+		return false
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), numOfDeletedKeys)
+	assert.Equal(t, uint64(4), numOfKeysToDelete)
+	assert.Equal(t, uint64(3), GetTotalKeyCount(db, commonPrefix))
+}
+
+func Test_Db_Utilities_DeleteKeysWithPrefix_FewerKeysThanCounted(t *testing.T) {
+	db := helper_get_db(t)
+	helper_add_keys_to_db(t, db, helper_testKeys_with_common_prefix(commonPrefix))
+	err, numOfDeletedKeys, numOfKeysToDelete := DeleteKeysWithPrefix(commonPrefix, db, 10, 6)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(4), numOfDeletedKeys)
+	assert.Equal(t, uint64(6), numOfKeysToDelete)
+}
+
 func helper_get_db(t *testing.T) badgerwrap.DB {
 	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
 	assert.Nil(t, err)
@@ -0,0 +1,234 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// One operation of an RFC 6902 JSON Patch.  Only add, remove and replace are created and applied
+type JsonPatchOp struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+func newJsonPatchOp(op string, path string, value interface{}) JsonPatchOp {
+	patchOp := JsonPatchOp{Op: op, Path: path}
+	if op != "remove" {
+		// Values come from decoded json, so they always marshal
+		patchOp.Value, _ = json.Marshal(value)
+	}
+	return patchOp
+}
+
+/*
+Returns the RFC 6902 JSON Patch that turns the prev json into the cur json.  Objects are compared key by key, arrays
+of the same length element by element and an array that only grew gets its new elements added at the end.  Anything
+else that differs, like an array that shrank, is replaced as a whole.  Operations are sorted by path within each
+object, so the same two documents always give the same patch
+*/
+func CreateJsonPatch(prev string, cur string) ([]JsonPatchOp, error) {
+	prevDoc, err := decodeJsonDoc(prev)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse previous json")
+	}
+	curDoc, err := decodeJsonDoc(cur)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse new json")
+	}
+	ops := []JsonPatchOp{}
+	diffJson("", prevDoc, curDoc, &ops)
+	return ops, nil
+}
+
+// Numbers are kept as they are written, so large integers like uids of some resources do not lose precision
+func decodeJsonDoc(doc string) (interface{}, error) {
+	decoder := json.NewDecoder(strings.NewReader(doc))
+	decoder.UseNumber()
+	var value interface{}
+	err := decoder.Decode(&value)
+	return value, err
+}
+
+func diffJson(path string, prev interface{}, cur interface{}, ops *[]JsonPatchOp) {
+	prevMap, prevIsMap := prev.(map[string]interface{})
+	curMap, curIsMap := cur.(map[string]interface{})
+	if prevIsMap && curIsMap {
+		keys := []string{}
+		for key := range prevMap {
+			keys = append(keys, key)
+		}
+		for key := range curMap {
+			if _, ok := prevMap[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			prevValue, inPrev := prevMap[key]
+			curValue, inCur := curMap[key]
+			keyPath := path + "/" + escapeJsonPointer(key)
+			switch {
+			case !inCur:
+				*ops = append(*ops, newJsonPatchOp("remove", keyPath, nil))
+			case !inPrev:
+				*ops = append(*ops, newJsonPatchOp("add", keyPath, curValue))
+			default:
+				diffJson(keyPath, prevValue, curValue, ops)
+			}
+		}
+		return
+	}
+	prevList, prevIsList := prev.([]interface{})
+	curList, curIsList := cur.([]interface{})
+	if prevIsList && curIsList && len(curList) >= len(prevList) {
+		for idx := range prevList {
+			diffJson(path+"/"+strconv.Itoa(idx), prevList[idx], curList[idx], ops)
+		}
+		for idx := len(prevList); idx < len(curList); idx++ {
+			*ops = append(*ops, newJsonPatchOp("add", path+"/-", curList[idx]))
+		}
+		return
+	}
+	if !reflect.DeepEqual(prev, cur) {
+		*ops = append(*ops, newJsonPatchOp("replace", path, cur))
+	}
+}
+
+// Applies a patch made by CreateJsonPatch to a json document and returns the result, which is equal as json to the
+// document the patch was made for, but may order its keys differently
+func ApplyJsonPatch(doc string, ops []JsonPatchOp) (string, error) {
+	root, err := decodeJsonDoc(doc)
+	if err != nil {
+		return "", errors.Wrap(err, "could not parse json")
+	}
+	for _, op := range ops {
+		root, err = applyJsonPatchOp(root, op)
+		if err != nil {
+			return "", err
+		}
+	}
+	bytes, err := json.Marshal(root)
+	return string(bytes), err
+}
+
+func applyJsonPatchOp(root interface{}, op JsonPatchOp) (interface{}, error) {
+	if op.Op != "add" && op.Op != "remove" && op.Op != "replace" {
+		return nil, fmt.Errorf("unsupported json patch op %q", op.Op)
+	}
+	var value interface{}
+	if op.Op != "remove" {
+		var err error
+		value, err = decodeJsonDoc(string(op.Value))
+		if err != nil {
+			return nil, fmt.Errorf("json patch path %q: invalid value: %v", op.Path, err)
+		}
+	}
+	if op.Path == "" {
+		return value, nil
+	}
+	if !strings.HasPrefix(op.Path, "/") {
+		return nil, fmt.Errorf("invalid json patch path %q", op.Path)
+	}
+	tokens := strings.Split(op.Path[1:], "/")
+	parentTokens, last := tokens[:len(tokens)-1], unescapeJsonPointer(tokens[len(tokens)-1])
+
+	// Walks down to the parent, keeping the way back up so arrays that change length can be set in their parents
+	parents := []interface{}{root}
+	for _, token := range parentTokens {
+		child, err := jsonPointerChild(parents[len(parents)-1], unescapeJsonPointer(token))
+		if err != nil {
+			return nil, fmt.Errorf("json patch path %q: %v", op.Path, err)
+		}
+		parents = append(parents, child)
+	}
+
+	var updated interface{}
+	switch parent := parents[len(parents)-1].(type) {
+	case map[string]interface{}:
+		if op.Op == "remove" {
+			delete(parent, last)
+		} else {
+			parent[last] = value
+		}
+		updated = parent
+	case []interface{}:
+		idx := len(parent)
+		if last != "-" {
+			var err error
+			idx, err = strconv.Atoi(last)
+			if err != nil || idx < 0 || idx > len(parent) {
+				return nil, fmt.Errorf("json patch path %q: invalid index", op.Path)
+			}
+		}
+		switch {
+		case op.Op == "add":
+			parent = append(parent, nil)
+			copy(parent[idx+1:], parent[idx:])
+			parent[idx] = value
+		case idx == len(parent):
+			return nil, fmt.Errorf("json patch path %q: index out of range", op.Path)
+		case op.Op == "remove":
+			parent = append(parent[:idx], parent[idx+1:]...)
+		default:
+			parent[idx] = value
+		}
+		updated = parent
+	default:
+		return nil, fmt.Errorf("json patch path %q: parent is not an object or array", op.Path)
+	}
+
+	// Put the possibly reallocated array back up the way
+	for level := len(parents) - 1; level > 0; level-- {
+		switch grand := parents[level-1].(type) {
+		case map[string]interface{}:
+			grand[unescapeJsonPointer(parentTokens[level-1])] = updated
+		case []interface{}:
+			idx, _ := strconv.Atoi(parentTokens[level-1])
+			grand[idx] = updated
+		}
+		updated = parents[level-1]
+	}
+	return updated, nil
+}
+
+func jsonPointerChild(node interface{}, token string) (interface{}, error) {
+	switch value := node.(type) {
+	case map[string]interface{}:
+		child, ok := value[token]
+		if !ok {
+			return nil, fmt.Errorf("no key %q", token)
+		}
+		return child, nil
+	case []interface{}:
+		idx, err := strconv.Atoi(token)
+		if err != nil || idx < 0 || idx >= len(value) {
+			return nil, fmt.Errorf("invalid index %q", token)
+		}
+		return value[idx], nil
+	default:
+		return nil, fmt.Errorf("%q is not in an object or array", token)
+	}
+}
+
+// RFC 6901
+func escapeJsonPointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+func unescapeJsonPointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+}
@@ -0,0 +1,82 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package common
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CreateJsonPatch(t *testing.T) {
+	prev := `{"metadata":{"name":"a","labels":{"x":"1","a/b":"2"}},"spec":{"replicas":1,"ports":[80,443],"args":["a","b"]}}`
+	cur := `{"metadata":{"name":"a","labels":{"x":"2"}},"spec":{"replicas":2,"ports":[80,443,8080],"args":["a"]},"status":{}}`
+	ops, err := CreateJsonPatch(prev, cur)
+	assert.Nil(t, err)
+	bytes, err := json.Marshal(ops)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `[
+		{"op":"remove","path":"/metadata/labels/a~1b"},
+		{"op":"replace","path":"/metadata/labels/x","value":"2"},
+		{"op":"replace","path":"/spec/args","value":["a"]},
+		{"op":"add","path":"/spec/ports/-","value":8080},
+		{"op":"replace","path":"/spec/replicas","value":2},
+		{"op":"add","path":"/status","value":{}}
+	]`, string(bytes))
+
+	patched, err := ApplyJsonPatch(prev, ops)
+	assert.Nil(t, err)
+	assert.JSONEq(t, cur, patched)
+
+	ops, err = CreateJsonPatch(prev, prev)
+	assert.Nil(t, err)
+	assert.Len(t, ops, 0)
+
+	_, err = CreateJsonPatch(prev, "{")
+	assert.NotNil(t, err)
+}
+
+func Test_ApplyJsonPatch_RoundTrips(t *testing.T) {
+	for _, docs := range [][2]string{
+		{`{"a":[{"b":1},{"b":2}]}`, `{"a":[{"b":1},{"b":3,"c":null},{"b":4}]}`},
+		{`{"a":[[1],[2]]}`, `{"a":[[1,5],[2]]}`},
+		{`{"a":1}`, `[1,2]`},
+		{`{"n":12345678901234567890}`, `{"n":12345678901234567891}`},
+		{`{"a~b":{"c/d":true}}`, `{"a~b":{"c/d":false}}`},
+	} {
+		ops, err := CreateJsonPatch(docs[0], docs[1])
+		assert.Nil(t, err)
+		patched, err := ApplyJsonPatch(docs[0], ops)
+		assert.Nil(t, err)
+		assert.Equal(t, compactJson(t, docs[1]), patched, docs[1])
+	}
+}
+
+func Test_ApplyJsonPatch_Invalid(t *testing.T) {
+	doc := `{"a":[1]}`
+	for _, ops := range [][]JsonPatchOp{
+		{{Op: "move", Path: "/a"}},
+		{{Op: "replace", Path: "a", Value: json.RawMessage(`1`)}},
+		{{Op: "replace", Path: "/b/c", Value: json.RawMessage(`1`)}},
+		{{Op: "replace", Path: "/a/1", Value: json.RawMessage(`1`)}},
+		{{Op: "add", Path: "/a/x", Value: json.RawMessage(`1`)}},
+		{{Op: "add", Path: "/a/0", Value: json.RawMessage(`{`)}},
+	} {
+		_, err := ApplyJsonPatch(doc, ops)
+		assert.NotNil(t, err, ops[0].Path)
+	}
+}
+
+func compactJson(t *testing.T, doc string) string {
+	value, err := decodeJsonDoc(doc)
+	assert.Nil(t, err)
+	bytes, err := json.Marshal(value)
+	assert.Nil(t, err)
+	return string(bytes)
+}
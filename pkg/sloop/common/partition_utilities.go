@@ -1,6 +1,9 @@
 package common
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/dgraph-io/badger/v2"
 	"github.com/golang/glog"
 	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
@@ -12,12 +15,13 @@ type SloopKey struct {
 	PartitionID string
 }
 
-// returns TableName, PartitionId, error.
+// returns TableName, PartitionId, error.  Only the table and partition are parsed, so this also works for tables
+// whose keys have more or fewer parts than the usual /table/partition/kind/namespace/name/x
 func GetSloopKey(item badgerwrap.Item) (SloopKey, error) {
-	key := item.Key()
-	err, parts := ParseKey(string(key))
-	if err != nil {
-		return SloopKey{}, err
+	key := string(item.Key())
+	parts := strings.SplitN(key, "/", 4)
+	if len(parts) < 4 || parts[0] != "" {
+		return SloopKey{}, fmt.Errorf("key should start with /<table>/<partition>/: %v", key)
 	}
 
 	var tableName = parts[1]
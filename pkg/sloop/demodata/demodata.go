@@ -0,0 +1,95 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package demodata
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+
+	"github.com/salesforce/sloop/pkg/sloop/processing"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+/*
+Builds a store from a made up cluster, so sloop can be tried out and the UI worked on without access to a cluster or a
+recording of one.  The watch results go through processing like real ones, so every table and query has data.
+*/
+
+// Same as the default -max-look-back of the server
+const maxLookback = 14 * 24 * time.Hour
+
+// Runs the watch results through processing into tables, oldest first
+func Write(tables typed.Tables, results []typed.KubeWatchResult) {
+	watchChan := make(chan typed.KubeWatchResult, 1000)
+	processor := processing.NewProcessing(watchChan, tables, false, maxLookback, 0, nil, 0, nil)
+	processor.Start()
+	for _, result := range results {
+		watchChan <- result
+	}
+	close(watchChan)
+	processor.Wait()
+}
+
+// Entry point of `sloop demo-data`
+func RealMain(args []string) error {
+	defer glog.Flush()
+	fs := flag.NewFlagSet("demo-data", flag.ContinueOnError)
+	// Keep the glog flags working
+	flag.CommandLine.VisitAll(func(f *flag.Flag) { fs.Var(f.Value, f.Name, f.Usage) })
+	storeRoot := fs.String("store-root", "./data", "Path to write the store to, the same as -store-root of sloop")
+	context := fs.String("context", "demo", "Context to write the store for, run sloop with the same -context to see it")
+	duration := fs.Duration("duration", 6*time.Hour, "How much history to generate, ending now")
+	nodes := fs.Int("nodes", 3, "Number of nodes")
+	namespaces := fs.Int("namespaces", 3, "Number of namespaces")
+	deployments := fs.Int("deployments", 4, "Number of deployments per namespace")
+	seed := fs.Int64("seed", 1, "Seed for the random choices, the same seed gives the same cluster")
+	err := fs.Parse(args)
+	if err != nil {
+		return err
+	}
+	// glog complains about every line it logs until the default flag set is parsed
+	_ = flag.CommandLine.Parse(nil)
+	if *duration < time.Hour || *duration > maxLookback {
+		return fmt.Errorf("duration should be between 1h and %v", maxLookback)
+	}
+	if *nodes < 1 || *namespaces < 1 || *deployments < 1 {
+		return fmt.Errorf("nodes, namespaces and deployments should be at least 1")
+	}
+
+	// Mixing made up history into a real one would only confuse
+	storePath := path.Join(*storeRoot, *context)
+	files, _ := ioutil.ReadDir(storePath)
+	if len(files) > 0 {
+		return fmt.Errorf("%v already has a store, remove it or pick another context", storePath)
+	}
+	db, err := untyped.OpenStore(&badgerwrap.BadgerFactory{}, &untyped.Config{RootPath: storePath, ConfigPartitionDuration: time.Hour})
+	if err != nil {
+		return errors.Wrap(err, "failed to open store")
+	}
+	defer untyped.CloseStore(db)
+
+	results := Generate(Config{
+		EndTime:     time.Now().UTC(),
+		Duration:    *duration,
+		Nodes:       *nodes,
+		Namespaces:  *namespaces,
+		Deployments: *deployments,
+		Seed:        *seed,
+	})
+	Write(typed.NewTableList(db), results)
+	glog.Infof("Wrote %v watch results to %v.  To see them run: sloop -disable-kube-watch -store-root=%v -context=%v", len(results), storePath, *storeRoot, *context)
+	return nil
+}
@@ -0,0 +1,99 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package demodata
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+var someEndTime = time.Date(2019, 3, 4, 12, 0, 0, 0, time.UTC)
+
+func helper_config() Config {
+	return Config{EndTime: someEndTime, Duration: 6 * time.Hour, Nodes: 3, Namespaces: 2, Deployments: 3, Seed: 1}
+}
+
+func Test_Generate_SortedInTimeRange(t *testing.T) {
+	results := Generate(helper_config())
+	assert.NotEmpty(t, results)
+	kinds := map[string]bool{}
+	last := someEndTime.Add(-6 * time.Hour)
+	for _, result := range results {
+		ts, err := ptypes.Timestamp(result.Timestamp)
+		assert.Nil(t, err)
+		assert.False(t, ts.Before(last), "results should be oldest first")
+		assert.False(t, ts.After(someEndTime))
+		last = ts
+		kinds[result.Kind] = true
+
+		metadata, err := kubeextractor.ExtractMetadata(result.Payload)
+		assert.Nil(t, err)
+		assert.NotEmpty(t, metadata.Name)
+		assert.NotEmpty(t, metadata.Uid)
+	}
+	for _, kind := range []string{"Namespace", "Node", "Deployment", "ReplicaSet", "Pod", "Service", "Endpoint", "Event"} {
+		assert.True(t, kinds[kind], "missing %v", kind)
+	}
+}
+
+func Test_Generate_SameSeedSameCluster(t *testing.T) {
+	assert.Equal(t, Generate(helper_config()), Generate(helper_config()))
+	config := helper_config()
+	config.Seed = 2
+	assert.NotEqual(t, Generate(helper_config()), Generate(config))
+}
+
+func Test_Generate_Failures(t *testing.T) {
+	reasons := map[string]bool{}
+	for _, result := range Generate(helper_config()) {
+		if result.Kind != kubeextractor.EventKind {
+			continue
+		}
+		info, err := kubeextractor.ExtractEventInfo(result.Payload)
+		assert.Nil(t, err)
+		reasons[info.Reason] = true
+	}
+	for _, reason := range []string{"Scheduled", "Started", "BackOff", "Failed", "DeploymentRollback", "NodeNotReady"} {
+		assert.True(t, reasons[reason], "missing %v events", reason)
+	}
+}
+
+func Test_Write(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	results := Generate(helper_config())
+	Write(tables, results)
+
+	counts := map[string]int{}
+	err = db.View(func(txn badgerwrap.Txn) error {
+		itr := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer itr.Close()
+		for itr.Rewind(); itr.Valid(); itr.Next() {
+			counts[strings.Split(string(itr.Item().Key()), "/")[1]]++
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, len(results), counts["watch"])
+	for _, table := range []string{"ressum", "eventcount", "podlifecycle", "nodecondition", "ownergraph", "servicebackends"} {
+		assert.NotZero(t, counts[table], "nothing in %v", table)
+	}
+	assert.Zero(t, counts["quarantine"])
+	assert.Zero(t, counts["deadletter"])
+}
@@ -0,0 +1,495 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package demodata
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+)
+
+// Made up names, so nothing in the demo data comes from a real cluster
+var (
+	namespaceNames = []string{"checkout", "payments", "search", "inventory", "frontend", "analytics"}
+	appNames       = []string{"api", "worker", "web", "cache", "gateway", "scheduler"}
+)
+
+const (
+	replicas        = 2
+	maxCrashBackOff = 5 * time.Minute
+)
+
+type Config struct {
+	EndTime time.Time
+	// How much history to generate, ending at EndTime
+	Duration    time.Duration
+	Nodes       int
+	Namespaces  int
+	Deployments int // per namespace
+	// The same seed always gives the same resources, only moved to the time range
+	Seed int64
+}
+
+type object = map[string]interface{}
+
+type deployment struct {
+	namespace  string
+	name       string
+	object     object
+	replicaSet object
+	pods       []object
+	service    object
+	endpoints  object
+	revision   int
+}
+
+type generator struct {
+	config          Config
+	start           time.Time
+	rand            *rand.Rand
+	results         []typed.KubeWatchResult
+	resourceVersion int
+	uids            int
+	podIps          int
+	nodes           []object
+	// Involved object, reason and message to the event, so repeats bump its count like the event recorder does
+	events map[string]object
+}
+
+/*
+Returns the watch results of a made up cluster over the time range, oldest first.  Every namespace has a few
+deployments with their replica sets, pods, services and endpoints.  Along the way deployments are rolled out, one
+rollout in the first namespace pulls an image that does not exist and is rolled back, one deployment per namespace goes
+into a crash loop for a while and one node goes NotReady, all with the events kubernetes would record for them
+*/
+func Generate(config Config) []typed.KubeWatchResult {
+	g := &generator{
+		config: config,
+		start:  config.EndTime.Add(-config.Duration),
+		rand:   rand.New(rand.NewSource(config.Seed)),
+		events: map[string]object{},
+	}
+	for idx := 0; idx < config.Nodes; idx++ {
+		g.addNode(fmt.Sprintf("demo-node-%v", idx+1))
+	}
+	for idx := 0; idx < config.Namespaces; idx++ {
+		namespace := namespaceNames[idx%len(namespaceNames)]
+		if idx >= len(namespaceNames) {
+			namespace = fmt.Sprintf("%v-%v", namespace, idx/len(namespaceNames)+1)
+		}
+		g.addNamespace(namespace)
+		for appIdx := 0; appIdx < config.Deployments; appIdx++ {
+			d := g.addDeployment(namespace, appNames[appIdx%len(appNames)], g.randomTime(0, 0.05))
+			switch {
+			case appIdx == 0:
+				from := g.randomTime(0.2, 0.6)
+				g.crashLoop(d, from, from.Add(config.Duration/4))
+			case appIdx == 1 && idx == 0:
+				g.rollout(d, g.randomTime(0.3, 0.7), true)
+			case g.rand.Intn(2) == 0:
+				g.rollout(d, g.randomTime(0.1, 0.9), false)
+			}
+		}
+	}
+	if len(g.nodes) > 0 {
+		from := g.randomTime(0.5, 0.8)
+		g.nodeOutage(g.nodes[len(g.nodes)-1], from, from.Add(20*time.Minute))
+	}
+
+	sort.SliceStable(g.results, func(i, j int) bool {
+		iTime, _ := ptypes.Timestamp(g.results[i].Timestamp)
+		jTime, _ := ptypes.Timestamp(g.results[j].Timestamp)
+		return iTime.Before(jTime)
+	})
+	return g.results
+}
+
+// Somewhere between the fractions from and to of the time range
+func (g *generator) randomTime(from float64, to float64) time.Time {
+	fraction := from + g.rand.Float64()*(to-from)
+	return g.start.Add(time.Duration(fraction * float64(g.config.Duration))).Truncate(time.Second)
+}
+
+func (g *generator) randomSuffix(length int) string {
+	const letters = "bcdfghjklmnpqrstvwxz2456789"
+	suffix := make([]byte, length)
+	for idx := range suffix {
+		suffix[idx] = letters[g.rand.Intn(len(letters))]
+	}
+	return string(suffix)
+}
+
+// Anything after the end of the time range has not happened yet.  Objects from informers have no type meta, so it is
+// left out of the payload
+func (g *generator) emit(kind string, watchType typed.KubeWatchResult_WatchType, ts time.Time, resource object) {
+	if ts.After(g.config.EndTime) {
+		return
+	}
+	g.resourceVersion++
+	resource["metadata"].(object)["resourceVersion"] = fmt.Sprint(g.resourceVersion)
+	withoutTypeMeta := object{}
+	for field, value := range resource {
+		if field != "kind" && field != "apiVersion" {
+			withoutTypeMeta[field] = value
+		}
+	}
+	payload, err := json.Marshal(withoutTypeMeta)
+	if err != nil {
+		panic(err)
+	}
+	timestamp, err := ptypes.TimestampProto(ts)
+	if err != nil {
+		panic(err)
+	}
+	g.results = append(g.results, typed.KubeWatchResult{Kind: kind, WatchType: watchType, Timestamp: timestamp, Payload: string(payload)})
+}
+
+func (g *generator) metadata(name string, namespace string, created time.Time, owner object) object {
+	g.uids++
+	metadata := object{
+		"name":              name,
+		"uid":               fmt.Sprintf("00000000-0000-4000-8000-%012x", g.uids),
+		"creationTimestamp": created.UTC().Format(time.RFC3339),
+	}
+	if namespace != "" {
+		metadata["namespace"] = namespace
+	}
+	if owner != nil {
+		ownerMetadata := owner["metadata"].(object)
+		metadata["ownerReferences"] = []object{{
+			"apiVersion": owner["apiVersion"],
+			"kind":       owner["kind"],
+			"name":       ownerMetadata["name"],
+			"uid":        ownerMetadata["uid"],
+			"controller": true,
+		}}
+	}
+	return metadata
+}
+
+func (g *generator) addNamespace(name string) {
+	namespace := object{
+		"kind":       kubeextractor.NamespaceKind,
+		"apiVersion": "v1",
+		"metadata":   g.metadata(name, "", g.start, nil),
+		"status":     object{"phase": "Active"},
+	}
+	g.emit(kubeextractor.NamespaceKind, typed.KubeWatchResult_ADD, g.start, namespace)
+}
+
+func (g *generator) addNode(name string) {
+	node := object{
+		"kind":       kubeextractor.NodeKind,
+		"apiVersion": "v1",
+		"metadata":   g.metadata(name, "", g.start, nil),
+		"status": object{
+			"addresses": []object{
+				{"type": "InternalIP", "address": fmt.Sprintf("10.0.0.%v", len(g.nodes)+1)},
+				{"type": "Hostname", "address": name},
+			},
+		},
+	}
+	g.setNodeReady(node, true, g.start)
+	g.emit(kubeextractor.NodeKind, typed.KubeWatchResult_ADD, g.start, node)
+	g.nodes = append(g.nodes, node)
+}
+
+func (g *generator) setNodeReady(node object, ready bool, ts time.Time) {
+	status, reason := "True", "KubeletReady"
+	if !ready {
+		status, reason = "Unknown", "NodeStatusUnknown"
+	}
+	node["status"].(object)["conditions"] = []object{
+		{"type": "MemoryPressure", "status": "False", "reason": "KubeletHasSufficientMemory", "lastTransitionTime": g.start.UTC().Format(time.RFC3339)},
+		{"type": "Ready", "status": status, "reason": reason, "lastTransitionTime": ts.UTC().Format(time.RFC3339)},
+	}
+}
+
+func nodeName(node object) string {
+	return node["metadata"].(object)["name"].(string)
+}
+
+// The node controller marks the node as unknown when the kubelet stops reporting, and the kubelet marks it ready again
+func (g *generator) nodeOutage(node object, from time.Time, to time.Time) {
+	g.setNodeReady(node, false, from)
+	g.emit(kubeextractor.NodeKind, typed.KubeWatchResult_UPDATE, from, node)
+	g.event("default", node, kubeextractor.NodeKind, "NodeNotReady", "Node "+nodeName(node)+" status is now: NodeNotReady", "Normal", from)
+
+	g.setNodeReady(node, true, to)
+	g.emit(kubeextractor.NodeKind, typed.KubeWatchResult_UPDATE, to, node)
+	g.event("default", node, kubeextractor.NodeKind, "NodeReady", "Node "+nodeName(node)+" status is now: NodeReady", "Normal", to)
+}
+
+// Records an event about resource, or bumps the count of the same one recorded before
+func (g *generator) event(namespace string, resource object, kind string, reason string, message string, eventType string, ts time.Time) {
+	metadata := resource["metadata"].(object)
+	key := fmt.Sprintf("%v/%v/%v", metadata["uid"], reason, message)
+	if event, ok := g.events[key]; ok {
+		event["count"] = event["count"].(int) + 1
+		event["lastTimestamp"] = ts.UTC().Format(time.RFC3339)
+		g.emit(kubeextractor.EventKind, typed.KubeWatchResult_UPDATE, ts, event)
+		return
+	}
+	involvedObject := object{"kind": kind, "name": metadata["name"], "uid": metadata["uid"], "apiVersion": resource["apiVersion"]}
+	if resourceNamespace, ok := metadata["namespace"]; ok {
+		involvedObject["namespace"] = resourceNamespace
+	}
+	event := object{
+		"kind":           kubeextractor.EventKind,
+		"apiVersion":     "v1",
+		"metadata":       g.metadata(fmt.Sprintf("%v.%x", metadata["name"], g.rand.Int63()), namespace, ts, nil),
+		"involvedObject": involvedObject,
+		"reason":         reason,
+		"message":        message,
+		"type":           eventType,
+		"count":          1,
+		"firstTimestamp": ts.UTC().Format(time.RFC3339),
+		"lastTimestamp":  ts.UTC().Format(time.RFC3339),
+	}
+	g.events[key] = event
+	g.emit(kubeextractor.EventKind, typed.KubeWatchResult_ADD, ts, event)
+}
+
+func image(name string, revision int) string {
+	return fmt.Sprintf("registry.example.com/demo/%v:1.%v.0", name, revision)
+}
+
+func (g *generator) addDeployment(namespace string, name string, ts time.Time) *deployment {
+	d := &deployment{namespace: namespace, name: name, revision: 1}
+	d.object = object{
+		"kind":       "Deployment",
+		"apiVersion": "apps/v1",
+		"metadata":   g.metadata(name, namespace, ts, nil),
+		"spec":       object{"replicas": replicas, "selector": object{"matchLabels": object{"app": name}}},
+	}
+	g.setDeploymentRevision(d, image(name, 1))
+	g.emit("Deployment", typed.KubeWatchResult_ADD, ts, d.object)
+
+	d.service = object{
+		"kind":       "Service",
+		"apiVersion": "v1",
+		"metadata":   g.metadata(name, namespace, ts, nil),
+		"spec": object{
+			"selector":  object{"app": name},
+			"clusterIP": fmt.Sprintf("10.96.%v.%v", g.uids/250, g.uids%250+1),
+			"ports":     []object{{"port": 80, "targetPort": 8080, "protocol": "TCP"}},
+		},
+	}
+	g.emit("Service", typed.KubeWatchResult_ADD, ts, d.service)
+	d.endpoints = object{"kind": "Endpoints", "apiVersion": "v1", "metadata": g.metadata(name, namespace, ts, nil)}
+
+	d.replicaSet = g.addReplicaSet(d, ts)
+	for idx := 0; idx < replicas; idx++ {
+		d.pods = append(d.pods, g.startPod(d, d.replicaSet, ts.Add(time.Duration(idx)*time.Second), true))
+	}
+	g.updateEndpoints(d, ts.Add(30*time.Second), nil)
+	g.setDeploymentStatus(d, replicas, replicas, replicas, ts.Add(30*time.Second))
+	return d
+}
+
+func (g *generator) setDeploymentRevision(d *deployment, containerImage string) {
+	d.object["metadata"].(object)["annotations"] = object{"deployment.kubernetes.io/revision": fmt.Sprint(d.revision)}
+	// Only the pod template changes, so there is one generation per revision
+	d.object["metadata"].(object)["generation"] = d.revision
+	d.object["spec"].(object)["template"] = object{
+		"metadata": object{"labels": object{"app": d.name}},
+		"spec":     object{"containers": []object{{"name": d.name, "image": containerImage}}},
+	}
+}
+
+// Counts of all pods of the deployment, those of the current revision and those available, like the deployment
+// controller reports them
+func (g *generator) setDeploymentStatus(d *deployment, total int, updated int, available int, ts time.Time) {
+	d.object["status"] = object{"observedGeneration": d.revision, "replicas": total, "updatedReplicas": updated, "readyReplicas": available, "availableReplicas": available}
+	g.emit("Deployment", typed.KubeWatchResult_UPDATE, ts, d.object)
+}
+
+func (g *generator) addReplicaSet(d *deployment, ts time.Time) object {
+	replicaSet := object{
+		"kind":       "ReplicaSet",
+		"apiVersion": "apps/v1",
+		"metadata":   g.metadata(d.name+"-"+g.randomSuffix(10), d.namespace, ts, d.object),
+		"spec":       object{"replicas": replicas, "template": d.object["spec"].(object)["template"]},
+	}
+	replicaSet["metadata"].(object)["annotations"] = object{"deployment.kubernetes.io/revision": fmt.Sprint(d.revision)}
+	g.emit("ReplicaSet", typed.KubeWatchResult_ADD, ts, replicaSet)
+	g.event(d.namespace, d.object, "Deployment", "ScalingReplicaSet", fmt.Sprintf("Scaled up replica set %v to %v", replicaSet["metadata"].(object)["name"], replicas), "Normal", ts)
+	return replicaSet
+}
+
+func (g *generator) podStatus(pod object, phase string, waitingReason string, restarts int) {
+	state := object{"running": object{}}
+	if waitingReason != "" {
+		state = object{"waiting": object{"reason": waitingReason}}
+	}
+	status := pod["status"].(object)
+	status["phase"] = phase
+	status["containerStatuses"] = []object{{
+		"name":         pod["spec"].(object)["containers"].([]object)[0]["name"],
+		"image":        pod["spec"].(object)["containers"].([]object)[0]["image"],
+		"ready":        waitingReason == "",
+		"restartCount": restarts,
+		"state":        state,
+	}}
+}
+
+// Creates a pod of the replica set and schedules it on a random node.  A pod that can not pull its image is left waiting
+func (g *generator) startPod(d *deployment, replicaSet object, ts time.Time, pullable bool) object {
+	template := replicaSet["spec"].(object)["template"].(object)
+	containerImage := template["spec"].(object)["containers"].([]object)[0]["image"].(string)
+	nodeIdx := g.rand.Intn(len(g.nodes))
+	g.podIps++
+	pod := object{
+		"kind":       kubeextractor.PodKind,
+		"apiVersion": "v1",
+		"metadata":   g.metadata(replicaSet["metadata"].(object)["name"].(string)+"-"+g.randomSuffix(5), d.namespace, ts, replicaSet),
+		"spec": object{
+			"nodeName":   nodeName(g.nodes[nodeIdx]),
+			"containers": []object{{"name": d.name, "image": containerImage}},
+		},
+		"status": object{"phase": "Pending"},
+	}
+	pod["metadata"].(object)["labels"] = object{"app": d.name}
+	g.emit(kubeextractor.PodKind, typed.KubeWatchResult_ADD, ts, pod)
+	g.event(d.namespace, pod, kubeextractor.PodKind, "Scheduled", fmt.Sprintf("Successfully assigned %v/%v to %v", d.namespace, pod["metadata"].(object)["name"], nodeName(g.nodes[nodeIdx])), "Normal", ts)
+
+	status := pod["status"].(object)
+	status["hostIP"] = fmt.Sprintf("10.0.0.%v", nodeIdx+1)
+	status["podIP"] = fmt.Sprintf("10.%v.%v.%v", nodeIdx+1, g.podIps/250, g.podIps%250+1)
+	if !pullable {
+		g.podStatus(pod, "Pending", "ErrImagePull", 0)
+		g.emit(kubeextractor.PodKind, typed.KubeWatchResult_UPDATE, ts.Add(3*time.Second), pod)
+		g.event(d.namespace, pod, kubeextractor.PodKind, "Failed", fmt.Sprintf("Failed to pull image %q: manifest unknown", containerImage), "Warning", ts.Add(3*time.Second))
+		return pod
+	}
+	g.event(d.namespace, pod, kubeextractor.PodKind, "Pulled", fmt.Sprintf("Container image %q already present on machine", containerImage), "Normal", ts.Add(2*time.Second))
+	g.event(d.namespace, pod, kubeextractor.PodKind, "Created", "Created container "+d.name, "Normal", ts.Add(2*time.Second))
+	g.event(d.namespace, pod, kubeextractor.PodKind, "Started", "Started container "+d.name, "Normal", ts.Add(3*time.Second))
+	g.podStatus(pod, "Running", "", 0)
+	g.emit(kubeextractor.PodKind, typed.KubeWatchResult_UPDATE, ts.Add(5*time.Second), pod)
+	return pod
+}
+
+func (g *generator) deletePod(d *deployment, pod object, ts time.Time) {
+	g.event(d.namespace, pod, kubeextractor.PodKind, "Killing", "Stopping container "+d.name, "Normal", ts)
+	g.emit(kubeextractor.PodKind, typed.KubeWatchResult_DELETE, ts.Add(30*time.Second), pod)
+}
+
+func (g *generator) updateEndpoints(d *deployment, ts time.Time, notReady map[string]bool) {
+	addresses := []object{}
+	notReadyAddresses := []object{}
+	for _, pod := range d.pods {
+		metadata := pod["metadata"].(object)
+		address := object{
+			"ip":        pod["status"].(object)["podIP"],
+			"nodeName":  pod["spec"].(object)["nodeName"],
+			"targetRef": object{"kind": kubeextractor.PodKind, "namespace": d.namespace, "name": metadata["name"], "uid": metadata["uid"]},
+		}
+		if notReady[metadata["name"].(string)] {
+			notReadyAddresses = append(notReadyAddresses, address)
+		} else {
+			addresses = append(addresses, address)
+		}
+	}
+	subset := object{"addresses": addresses, "ports": []object{{"port": 8080, "protocol": "TCP"}}}
+	if len(notReadyAddresses) > 0 {
+		subset["notReadyAddresses"] = notReadyAddresses
+	}
+	watchType := typed.KubeWatchResult_UPDATE
+	if _, ok := d.endpoints["subsets"]; !ok {
+		watchType = typed.KubeWatchResult_ADD
+	}
+	d.endpoints["subsets"] = []object{subset}
+	g.emit(kubeextractor.EndpointsKind, watchType, ts, d.endpoints)
+}
+
+/*
+Rolls the deployment to a new image one pod at a time, like a RollingUpdate with maxSurge 1 and maxUnavailable 0.
+When the new image can not be pulled the first new pod never gets ready, and the rollout is undone 15 minutes later
+*/
+func (g *generator) rollout(d *deployment, ts time.Time, broken bool) {
+	d.revision++
+	containerImage := image(d.name, d.revision)
+	if broken {
+		containerImage = fmt.Sprintf("registry.example.com/demo/%v:1.%v.0-rc", d.name, d.revision)
+	}
+	g.setDeploymentRevision(d, containerImage)
+	g.emit("Deployment", typed.KubeWatchResult_UPDATE, ts, d.object)
+	oldReplicaSet := d.replicaSet
+	newReplicaSet := g.addReplicaSet(d, ts)
+
+	if broken {
+		pod := g.startPod(d, newReplicaSet, ts.Add(time.Second), false)
+		g.setDeploymentStatus(d, replicas+1, 1, replicas, ts.Add(2*time.Second))
+		for backOff := 10 * time.Second; backOff < 15*time.Minute; backOff *= 2 {
+			g.podStatus(pod, "Pending", "ImagePullBackOff", 0)
+			g.emit(kubeextractor.PodKind, typed.KubeWatchResult_UPDATE, ts.Add(backOff), pod)
+			g.event(d.namespace, pod, kubeextractor.PodKind, "BackOff", fmt.Sprintf("Back-off pulling image %q", containerImage), "Normal", ts.Add(backOff))
+		}
+		undo := ts.Add(15 * time.Minute)
+		d.revision++
+		g.setDeploymentRevision(d, image(d.name, d.revision-2))
+		g.emit("Deployment", typed.KubeWatchResult_UPDATE, undo, d.object)
+		g.event(d.namespace, d.object, "Deployment", "DeploymentRollback", "Rolled back deployment "+d.name+" to revision "+fmt.Sprint(d.revision-2), "Normal", undo)
+		g.deletePod(d, pod, undo)
+		newReplicaSet["spec"].(object)["replicas"] = 0
+		g.emit("ReplicaSet", typed.KubeWatchResult_UPDATE, undo.Add(time.Second), newReplicaSet)
+		// The undo reuses the old replica set, which takes the new revision
+		oldReplicaSet["metadata"].(object)["annotations"] = object{
+			"deployment.kubernetes.io/revision":         fmt.Sprint(d.revision),
+			"deployment.kubernetes.io/revision-history": fmt.Sprint(d.revision - 2),
+		}
+		g.emit("ReplicaSet", typed.KubeWatchResult_UPDATE, undo.Add(time.Second), oldReplicaSet)
+		g.setDeploymentStatus(d, replicas, replicas, replicas, undo.Add(31*time.Second))
+		return
+	}
+
+	d.replicaSet = newReplicaSet
+	oldPods := d.pods
+	for idx, oldPod := range oldPods {
+		step := ts.Add(time.Duration(idx+1) * time.Minute)
+		d.pods = append(d.pods, g.startPod(d, newReplicaSet, step, true))
+		g.updateEndpoints(d, step.Add(10*time.Second), nil)
+		g.setDeploymentStatus(d, replicas+1, idx+1, replicas+1, step.Add(10*time.Second))
+		g.deletePod(d, oldPod, step.Add(20*time.Second))
+		d.pods = d.pods[1:]
+		g.updateEndpoints(d, step.Add(25*time.Second), nil)
+		g.setDeploymentStatus(d, replicas, idx+1, replicas, step.Add(50*time.Second))
+	}
+	oldReplicaSet["spec"].(object)["replicas"] = 0
+	g.emit("ReplicaSet", typed.KubeWatchResult_UPDATE, ts.Add(time.Duration(len(oldPods)+1)*time.Minute), oldReplicaSet)
+}
+
+// The first pod of the deployment keeps crashing from from until to, with the back off of the kubelet in between
+func (g *generator) crashLoop(d *deployment, from time.Time, to time.Time) {
+	pod := d.pods[0]
+	name := pod["metadata"].(object)["name"].(string)
+	restarts := 0
+	backOff := 10 * time.Second
+	for ts := from; ts.Before(to); ts = ts.Add(backOff) {
+		restarts++
+		g.podStatus(pod, "Running", "CrashLoopBackOff", restarts)
+		g.emit(kubeextractor.PodKind, typed.KubeWatchResult_UPDATE, ts, pod)
+		if restarts == 1 {
+			g.updateEndpoints(d, ts.Add(time.Second), map[string]bool{name: true})
+		}
+		g.event(d.namespace, pod, kubeextractor.PodKind, "BackOff", "Back-off restarting failed container", "Warning", ts)
+		if backOff < maxCrashBackOff {
+			backOff *= 2
+		}
+	}
+	g.podStatus(pod, "Running", "", restarts)
+	g.emit(kubeextractor.PodKind, typed.KubeWatchResult_UPDATE, to, pod)
+	g.updateEndpoints(d, to.Add(time.Second), nil)
+}
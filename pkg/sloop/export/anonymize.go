@@ -0,0 +1,171 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package export
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+)
+
+// Hashed names look like anon-0123456789abcdef, which is still a valid name for every kind
+const anonymizedPrefix = "anon-"
+
+/*
+Rewrites watch results so history can be shared outside the company without leaking internal naming.  Namespace and
+resource names are replaced by a salted hash, so the same name always maps to the same hash and relationships between
+resources survive, and annotations are removed.  These are rewritten:
+
+  - metadata name, namespace and generateName, and the names of ownerReferences
+  - spec.nodeName
+  - involvedObject name and namespace and source host of events
+  - those names in event messages, and the nodes, owners and generated pod names that common messages name, like the
+    node of "Successfully assigned ns/pod to node" or the pod of "Created pod: web-7d9f8-x2x4k"
+
+Everything else, like labels, images and the rest of the spec and status, is left as is
+*/
+type Anonymizer struct {
+	salt []byte
+}
+
+func NewAnonymizer(salt string) (*Anonymizer, error) {
+	if salt == "" {
+		return nil, errors.New("anonymization needs a salt")
+	}
+	return &Anonymizer{salt: []byte(salt)}, nil
+}
+
+// Empty names stay empty, so cluster scoped resources still have no namespace
+func (a *Anonymizer) HashName(name string) string {
+	if name == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, a.salt)
+	mac.Write([]byte(name))
+	return anonymizedPrefix + hex.EncodeToString(mac.Sum(nil))[:16]
+}
+
+func (a *Anonymizer) AnonymizeKey(key typed.WatchTableKey) typed.WatchTableKey {
+	key.Namespace = a.HashName(key.Namespace)
+	key.Name = a.HashName(key.Name)
+	return key
+}
+
+// Returns a copy of the watch result with the payload anonymized
+func (a *Anonymizer) AnonymizeWatchResult(result *typed.KubeWatchResult) (*typed.KubeWatchResult, error) {
+	payload, err := a.AnonymizePayload(result.Kind, result.Payload)
+	if err != nil {
+		return nil, err
+	}
+	ret := *result
+	ret.Payload = payload
+	return &ret, nil
+}
+
+func (a *Anonymizer) AnonymizePayload(kind string, payload string) (string, error) {
+	resource := map[string]interface{}{}
+	err := json.Unmarshal([]byte(payload), &resource)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to parse payload")
+	}
+
+	if metadata, ok := resource["metadata"].(map[string]interface{}); ok {
+		a.hashField(metadata, "name")
+		a.hashField(metadata, "namespace")
+		a.hashField(metadata, "generateName")
+		delete(metadata, "annotations")
+		if owners, ok := metadata["ownerReferences"].([]interface{}); ok {
+			for _, owner := range owners {
+				if ownerMap, ok := owner.(map[string]interface{}); ok {
+					a.hashField(ownerMap, "name")
+				}
+			}
+		}
+	}
+	if spec, ok := resource["spec"].(map[string]interface{}); ok {
+		a.hashField(spec, "nodeName")
+	}
+	if kind == kubeextractor.EventKind {
+		// Messages are free text, so replace the names we know of and the ones common messages are known to have
+		message, _ := resource["message"].(string)
+		names := namesInEventMessage(message)
+		if involved, ok := resource["involvedObject"].(map[string]interface{}); ok {
+			names = append(names, stringFields(involved, "name", "namespace")...)
+			a.hashField(involved, "name")
+			a.hashField(involved, "namespace")
+		}
+		if source, ok := resource["source"].(map[string]interface{}); ok {
+			names = append(names, stringFields(source, "host")...)
+			a.hashField(source, "host")
+		}
+		if message != "" {
+			resource["message"] = a.newNameReplacer(names).Replace(message)
+		}
+	}
+
+	out, err := json.Marshal(resource)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal payload")
+	}
+	return string(out), nil
+}
+
+func (a *Anonymizer) hashField(obj map[string]interface{}, field string) {
+	if value, ok := obj[field].(string); ok {
+		obj[field] = a.HashName(value)
+	}
+}
+
+func (a *Anonymizer) newNameReplacer(names []string) *strings.Replacer {
+	// The replacer takes the first match, so a namespace that is a prefix of the name must not win
+	sort.Slice(names, func(i, j int) bool { return len(names[i]) > len(names[j]) })
+	pairs := []string{}
+	for _, name := range names {
+		pairs = append(pairs, name, a.HashName(name))
+	}
+	return strings.NewReplacer(pairs...)
+}
+
+func stringFields(obj map[string]interface{}, fields ...string) []string {
+	values := []string{}
+	for _, field := range fields {
+		if value, ok := obj[field].(string); ok && value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// Messages of the scheduler and the workload controllers that name a node, an owner or a pod made from a
+// generateName, which are hashed in the payloads of those resources but are not fields of the event
+var eventMessageNamePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`^Successfully assigned (\S+)/(\S+) to (\S+)$`),
+	regexp.MustCompile(`^(?:Created|Deleted) pod: (\S+)$`),
+	regexp.MustCompile(`^Scaled (?:up|down) replica set (\S+) (?:from \d+ )?to \d+$`),
+	regexp.MustCompile(`^(?:Created|Saw completed|Deleted) job:? ([^\s,]+)`),
+	regexp.MustCompile(`^Preempted by (\S+)/(\S+) on node (\S+)$`),
+}
+
+func namesInEventMessage(message string) []string {
+	names := []string{}
+	for _, pattern := range eventMessageNamePatterns {
+		if match := pattern.FindStringSubmatch(message); match != nil {
+			names = append(names, match[1:]...)
+		}
+	}
+	return names
+}
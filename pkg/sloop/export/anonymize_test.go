@@ -0,0 +1,176 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+const somePodPayload = `{"kind":"Pod","metadata":{"name":"billing-api-1","namespace":"billing","uid":"uid-1","creationTimestamp":"2019-03-04T03:00:00Z","annotations":{"team":"payments"},"labels":{"app":"api"},"ownerReferences":[{"kind":"ReplicaSet","name":"billing-api"}]},"spec":{"nodeName":"node-7"}}`
+
+func Test_Anonymizer_HashNameIsConsistentAndSalted(t *testing.T) {
+	_, err := NewAnonymizer("")
+	assert.NotNil(t, err)
+
+	a, err := NewAnonymizer("salt1")
+	assert.Nil(t, err)
+	b, err := NewAnonymizer("salt2")
+	assert.Nil(t, err)
+	assert.Equal(t, a.HashName("billing"), a.HashName("billing"))
+	assert.NotEqual(t, a.HashName("billing"), a.HashName("payroll"))
+	assert.NotEqual(t, a.HashName("billing"), b.HashName("billing"))
+	assert.True(t, strings.HasPrefix(a.HashName("billing"), anonymizedPrefix))
+	assert.Equal(t, "", a.HashName(""))
+}
+
+func Test_Anonymizer_AnonymizePayload(t *testing.T) {
+	a, _ := NewAnonymizer("salt1")
+	out, err := a.AnonymizePayload("Pod", somePodPayload)
+	assert.Nil(t, err)
+	assert.NotContains(t, out, "billing")
+	assert.NotContains(t, out, "payments")
+	assert.NotContains(t, out, "node-7")
+
+	resource := map[string]interface{}{}
+	assert.Nil(t, json.Unmarshal([]byte(out), &resource))
+	metadata := resource["metadata"].(map[string]interface{})
+	assert.Equal(t, a.HashName("billing-api-1"), metadata["name"])
+	assert.Equal(t, a.HashName("billing"), metadata["namespace"])
+	assert.Equal(t, "uid-1", metadata["uid"])
+	assert.Nil(t, metadata["annotations"])
+	assert.Equal(t, map[string]interface{}{"app": "api"}, metadata["labels"])
+	owner := metadata["ownerReferences"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, a.HashName("billing-api"), owner["name"])
+
+	_, err = a.AnonymizePayload("Pod", "not json")
+	assert.NotNil(t, err)
+}
+
+func Test_Anonymizer_AnonymizeEventMessage(t *testing.T) {
+	a, _ := NewAnonymizer("salt1")
+	payload := `{"metadata":{"name":"billing-api-1.abc","namespace":"billing"},"involvedObject":{"kind":"Pod","name":"billing-api-1","namespace":"billing"},"message":"Successfully assigned billing/billing-api-1 to node-7"}`
+	out, err := a.AnonymizePayload("Event", payload)
+	assert.Nil(t, err)
+	resource := map[string]interface{}{}
+	assert.Nil(t, json.Unmarshal([]byte(out), &resource))
+	assert.Equal(t, "Successfully assigned "+a.HashName("billing")+"/"+a.HashName("billing-api-1")+" to "+a.HashName("node-7"), resource["message"])
+	involved := resource["involvedObject"].(map[string]interface{})
+	assert.Equal(t, a.HashName("billing-api-1"), involved["name"])
+}
+
+func Test_Anonymizer_AnonymizeSchedulerEvent(t *testing.T) {
+	a, _ := NewAnonymizer("salt1")
+	payload := `{"metadata":{"name":"pod.abc","namespace":"ns"},"involvedObject":{"kind":"Pod","name":"pod","namespace":"ns"},"source":{"component":"default-scheduler","host":"node-x"},"message":"Successfully assigned ns/pod to node-x","reason":"Scheduled"}`
+	out, err := a.AnonymizePayload("Event", payload)
+	assert.Nil(t, err)
+	assert.NotContains(t, out, "node-x")
+	resource := map[string]interface{}{}
+	assert.Nil(t, json.Unmarshal([]byte(out), &resource))
+	assert.Equal(t, "Successfully assigned "+a.HashName("ns")+"/"+a.HashName("pod")+" to "+a.HashName("node-x"), resource["message"])
+	assert.Equal(t, a.HashName("node-x"), resource["source"].(map[string]interface{})["host"])
+
+	// The node hashes the same as the nodeName of the pod
+	podOut, err := a.AnonymizePayload("Pod", `{"metadata":{"name":"pod","namespace":"ns"},"spec":{"nodeName":"node-x"}}`)
+	assert.Nil(t, err)
+	assert.Contains(t, podOut, a.HashName("node-x"))
+}
+
+func Test_Anonymizer_AnonymizeControllerEvents(t *testing.T) {
+	a, _ := NewAnonymizer("salt1")
+	for _, test := range []struct {
+		involvedKind string
+		involvedName string
+		message      string
+		expected     string
+	}{
+		{"ReplicaSet", "web-7d9f8", "Created pod: web-7d9f8-x2x4k", "Created pod: " + a.HashName("web-7d9f8-x2x4k")},
+		{"Deployment", "web", "Scaled up replica set web-7d9f8 to 3", "Scaled up replica set " + a.HashName("web-7d9f8") + " to 3"},
+		{"Deployment", "web", "Scaled down replica set web-6c5d4 from 2 to 1", "Scaled down replica set " + a.HashName("web-6c5d4") + " from 2 to 1"},
+		{"CronJob", "backup", "Saw completed job: backup-2789, status: Complete", "Saw completed job: " + a.HashName("backup-2789") + ", status: Complete"},
+		{"Pod", "batch-1", "Preempted by ns/critical-1 on node node-3", "Preempted by " + a.HashName("ns") + "/" + a.HashName("critical-1") + " on node " + a.HashName("node-3")},
+	} {
+		payload := fmt.Sprintf(`{"metadata":{"name":"e","namespace":"ns"},"involvedObject":{"kind":%q,"name":%q,"namespace":"ns"},"message":%q}`, test.involvedKind, test.involvedName, test.message)
+		out, err := a.AnonymizePayload("Event", payload)
+		assert.Nil(t, err)
+		resource := map[string]interface{}{}
+		assert.Nil(t, json.Unmarshal([]byte(out), &resource))
+		assert.Equal(t, test.expected, resource["message"], test.message)
+	}
+}
+
+func Test_Export_Anonymized(t *testing.T) {
+	tables := helper_getTables(t)
+	a, _ := NewAnonymizer("salt1")
+	exporter := NewExporter(tables, &badgerwrap.MockFactory{}, "")
+	out := &bytes.Buffer{}
+	count, err := exporter.Export(out, Request{Kinds: []string{"Deployment"}, Namespace: "ns1", StartTime: someTs, EndTime: someTs.Add(time.Hour), Anonymizer: a})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, count)
+	record := Record{}
+	assert.Nil(t, json.Unmarshal(out.Bytes(), &record))
+	key := &typed.WatchTableKey{}
+	assert.Nil(t, key.Parse(record.Key))
+	assert.Equal(t, a.HashName("ns1"), key.Namespace)
+	assert.Equal(t, a.HashName("deploy1"), key.Name)
+}
+
+// Keeps the store opened for the backup around so the test can look into it
+type captureFactory struct {
+	badgerwrap.MockFactory
+	db badgerwrap.DB
+}
+
+func (f *captureFactory) Open(opt badger.Options) (badgerwrap.DB, error) {
+	db, err := f.MockFactory.Open(opt)
+	f.db = db
+	return db, err
+}
+
+func Test_AnonymizedBackup_ProcessesAnonymizedResults(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	ts, _ := ptypes.TimestampProto(someTs)
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		key := typed.NewWatchTableKey(untyped.GetPartitionId(someTs), "Pod", "billing", "billing-api-1", someTs).String()
+		return tables.WatchTable().Set(txn, key, &typed.KubeWatchResult{Kind: "Pod", WatchType: typed.KubeWatchResult_ADD, Timestamp: ts, Payload: somePodPayload})
+	})
+	assert.Nil(t, err)
+
+	a, _ := NewAnonymizer("salt1")
+	factory := &captureFactory{}
+	count, err := NewExporter(tables, factory, "").AnonymizedBackup(&bytes.Buffer{}, a, 24*time.Hour)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, count)
+
+	backupTables := typed.NewTableList(factory.db)
+	err = factory.db.View(func(txn badgerwrap.Txn) error {
+		summaries, _, err := backupTables.ResourceSummaryTable().RangeRead(txn, nil, nil, nil, someTs, someTs)
+		assert.Nil(t, err)
+		assert.Len(t, summaries, 1)
+		for key := range summaries {
+			assert.Equal(t, a.HashName("billing"), key.Namespace)
+			assert.Equal(t, a.HashName("billing-api-1"), key.Name)
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+}
@@ -0,0 +1,112 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package export
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+
+	"github.com/salesforce/sloop/pkg/sloop/processing"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+/*
+Writes a backup that can be restored with -restore-database-file, but with every name hashed and annotations removed.
+The derived tables hold names in their keys and values, so rather than rewriting each of them the anonymized watch
+results are processed again into a temporary store, and that store is backed up.  Returns how many watch results
+went into the backup
+*/
+func (e *Exporter) AnonymizedBackup(w io.Writer, anonymizer *Anonymizer, maxLookback time.Duration) (int, error) {
+	dir, err := ioutil.TempDir(e.spillDir, "sloop-backup-")
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to create backup directory")
+	}
+	defer func() {
+		removeErr := os.RemoveAll(dir)
+		if removeErr != nil {
+			glog.Errorf("Failed to remove backup directory %v: %v", dir, removeErr)
+		}
+	}()
+	db, err := e.spillFactory.Open(badger.DefaultOptions(dir).WithLogger(nil))
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to open backup store in %v", dir)
+	}
+	defer db.Close()
+
+	watchChan := make(chan typed.KubeWatchResult, 1000)
+	processor := processing.NewProcessing(watchChan, typed.NewTableList(db), true, maxLookback, 0, nil, 0, nil)
+	processor.Start()
+	count, err := e.sendAnonymized(watchChan, anonymizer)
+	close(watchChan)
+	processor.Wait()
+	if err != nil {
+		return count, err
+	}
+
+	_, err = db.Backup(w, 0)
+	if err != nil {
+		return count, errors.Wrap(err, "failed to write backup")
+	}
+	return count, nil
+}
+
+// Sends every stored watch result anonymized, one partition at a time
+func (e *Exporter) sendAnonymized(watchChan chan typed.KubeWatchResult, anonymizer *Anonymizer) (int, error) {
+	var partitions []string
+	err := e.tables.Db().View(func(txn badgerwrap.Txn) error {
+		var err error
+		partitions, err = e.tables.WatchTable().GetUniquePartitionList(txn)
+		return err
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to list partitions")
+	}
+
+	count := 0
+	for _, partition := range partitions {
+		var results []*typed.KubeWatchResult
+		err = e.tables.Db().View(func(txn badgerwrap.Txn) error {
+			prefix := []byte("/" + (&typed.WatchTableKey{}).TableName() + "/" + partition + "/")
+			itr := txn.NewIterator(badger.IteratorOptions{Prefix: prefix})
+			defer itr.Close()
+			for itr.Seek(prefix); itr.ValidForPrefix(prefix); itr.Next() {
+				key := string(itr.Item().Key())
+				result, err := e.tables.WatchTable().Get(txn, key)
+				if err != nil {
+					return err
+				}
+				anonymized, err := anonymizer.AnonymizeWatchResult(result)
+				if err != nil {
+					return errors.Wrapf(err, "failed to anonymize %v", key)
+				}
+				results = append(results, anonymized)
+			}
+			return nil
+		})
+		if err != nil {
+			return count, errors.Wrapf(err, "failed to back up partition %v", partition)
+		}
+		// Keys are sorted by resource, processing expects the order the results were observed in
+		sort.SliceStable(results, func(i, j int) bool {
+			return results[i].Timestamp.AsTime().Before(results[j].Timestamp.AsTime())
+		})
+		for _, result := range results {
+			watchChan <- *result
+			count++
+		}
+	}
+	return count, nil
+}
@@ -0,0 +1,159 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package export
+
+import (
+	"io/ioutil"
+	"os"
+	"sort"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+// Spilled records are written in transactions of this size to stay well below the Badger transaction limit
+const spillBatchSize = 1000
+
+var metricExportSpilledCount = promauto.NewCounter(prometheus.CounterOpts{Name: "sloop_export_spilled_count"})
+
+// Holds the records of one partition until they can be written in sort key order
+type recordBuffer interface {
+	add(sortKey string, line []byte) error
+	// Calls fn for every record in sort key order
+	drain(fn func(line []byte) error) error
+	close() error
+}
+
+type bufferedRecord struct {
+	sortKey string
+	line    []byte
+}
+
+type memoryBuffer struct {
+	records []bufferedRecord
+}
+
+func (b *memoryBuffer) add(sortKey string, line []byte) error {
+	b.records = append(b.records, bufferedRecord{sortKey: sortKey, line: line})
+	return nil
+}
+
+func (b *memoryBuffer) drain(fn func(line []byte) error) error {
+	sort.Slice(b.records, func(i, j int) bool { return b.records[i].sortKey < b.records[j].sortKey })
+	for _, record := range b.records {
+		err := fn(record.line)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *memoryBuffer) close() error {
+	b.records = nil
+	return nil
+}
+
+// Badger keeps keys sorted, so draining is just iterating the store.  The store is only opened on the first add
+type spillBuffer struct {
+	factory badgerwrap.Factory
+	baseDir string
+	dir     string
+	db      badgerwrap.DB
+	pending []bufferedRecord
+}
+
+func newSpillBuffer(factory badgerwrap.Factory, baseDir string) *spillBuffer {
+	return &spillBuffer{factory: factory, baseDir: baseDir}
+}
+
+func (b *spillBuffer) add(sortKey string, line []byte) error {
+	b.pending = append(b.pending, bufferedRecord{sortKey: sortKey, line: line})
+	if len(b.pending) >= spillBatchSize {
+		return b.flush()
+	}
+	return nil
+}
+
+func (b *spillBuffer) flush() error {
+	if len(b.pending) == 0 {
+		return nil
+	}
+	if b.db == nil {
+		dir, err := ioutil.TempDir(b.baseDir, "sloop-export-")
+		if err != nil {
+			return errors.Wrap(err, "failed to create spill directory")
+		}
+		b.dir = dir
+		b.db, err = b.factory.Open(badger.DefaultOptions(dir).WithLogger(nil))
+		if err != nil {
+			return errors.Wrapf(err, "failed to open spill store in %v", dir)
+		}
+	}
+	err := b.db.Update(func(txn badgerwrap.Txn) error {
+		for _, record := range b.pending {
+			err := txn.Set([]byte(record.sortKey), record.line)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to write to spill store")
+	}
+	metricExportSpilledCount.Add(float64(len(b.pending)))
+	b.pending = nil
+	return nil
+}
+
+func (b *spillBuffer) drain(fn func(line []byte) error) error {
+	err := b.flush()
+	if err != nil {
+		return err
+	}
+	if b.db == nil {
+		return nil
+	}
+	return b.db.View(func(txn badgerwrap.Txn) error {
+		itr := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer itr.Close()
+		for itr.Rewind(); itr.Valid(); itr.Next() {
+			line, err := itr.Item().ValueCopy([]byte{})
+			if err != nil {
+				return err
+			}
+			err = fn(line)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (b *spillBuffer) close() error {
+	b.pending = nil
+	if b.db == nil {
+		return nil
+	}
+	err := b.db.Close()
+	b.db = nil
+	if b.dir != "" {
+		removeErr := os.RemoveAll(b.dir)
+		if removeErr != nil {
+			glog.Errorf("Failed to remove spill directory %v: %v", b.dir, removeErr)
+		}
+	}
+	return err
+}
@@ -0,0 +1,176 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+/*
+Streams stored watch results as json lines in the order they were observed.  Keys are sorted by kind, namespace and
+name within a partition, so each partition is buffered and sorted by timestamp before it is written.  By default the
+buffer is in memory.  With Spill set it is a temporary Badger store on disk instead, which is slower but lets exports
+of partitions that do not fit in memory complete.
+*/
+
+type Request struct {
+	// Empty exports all kinds
+	Kinds     []string
+	Namespace string
+	StartTime time.Time
+	EndTime   time.Time
+	Spill     bool
+	// Nil exports names and annotations as they are
+	Anonymizer *Anonymizer
+}
+
+// One line of the export
+type Record struct {
+	Timestamp time.Time       `json:"timestamp"`
+	Key       string          `json:"key"`
+	Kind      string          `json:"kind"`
+	WatchType string          `json:"watchType"`
+	Payload   json.RawMessage `json:"payload"`
+}
+
+type Exporter struct {
+	tables       typed.Tables
+	spillFactory badgerwrap.Factory
+	spillDir     string
+}
+
+// Spilled buffers are opened with spillFactory in a new directory under spillDir.  Empty spillDir uses the system
+// temp dir
+func NewExporter(tables typed.Tables, spillFactory badgerwrap.Factory, spillDir string) *Exporter {
+	return &Exporter{tables: tables, spillFactory: spillFactory, spillDir: spillDir}
+}
+
+// Writes every matching watch result to w and returns how many were written
+func (e *Exporter) Export(w io.Writer, req Request) (int, error) {
+	var partitions []string
+	err := e.tables.Db().View(func(txn badgerwrap.Txn) error {
+		var err error
+		partitions, err = e.tables.WatchTable().GetPartitionsFromTimeRange(txn, req.StartTime, req.EndTime)
+		return err
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to list partitions")
+	}
+
+	written := 0
+	for _, partition := range partitions {
+		count, err := e.exportPartition(w, req, partition)
+		written += count
+		if err != nil {
+			return written, errors.Wrapf(err, "failed to export partition %v", partition)
+		}
+	}
+	return written, nil
+}
+
+func (e *Exporter) exportPartition(w io.Writer, req Request, partition string) (int, error) {
+	var buffer recordBuffer
+	if req.Spill {
+		buffer = newSpillBuffer(e.spillFactory, e.spillDir)
+	} else {
+		buffer = &memoryBuffer{}
+	}
+	defer buffer.close()
+
+	err := e.tables.Db().View(func(txn badgerwrap.Txn) error {
+		for _, prefix := range keyPrefixes(req, partition) {
+			err := e.bufferPrefix(txn, req, prefix, buffer)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	written := 0
+	err = buffer.drain(func(line []byte) error {
+		_, err := w.Write(line)
+		if err != nil {
+			return err
+		}
+		written++
+		return nil
+	})
+	return written, err
+}
+
+func keyPrefixes(req Request, partition string) []string {
+	if len(req.Kinds) == 0 {
+		return []string{"/" + (&typed.WatchTableKey{}).TableName() + "/" + partition + "/"}
+	}
+	prefixes := []string{}
+	for _, kind := range req.Kinds {
+		prefixes = append(prefixes, typed.NewWatchTableKey(partition, kind, req.Namespace, "", time.Time{}).String())
+	}
+	return prefixes
+}
+
+func (e *Exporter) bufferPrefix(txn badgerwrap.Txn, req Request, prefix string, buffer recordBuffer) error {
+	itr := txn.NewIterator(badger.IteratorOptions{Prefix: []byte(prefix)})
+	defer itr.Close()
+	for itr.Seek([]byte(prefix)); itr.ValidForPrefix([]byte(prefix)); itr.Next() {
+		key := &typed.WatchTableKey{}
+		err := key.Parse(string(itr.Item().Key()))
+		if err != nil {
+			return err
+		}
+		if req.Namespace != "" && key.Namespace != req.Namespace {
+			continue
+		}
+		if key.Timestamp.Before(req.StartTime) || key.Timestamp.After(req.EndTime) {
+			continue
+		}
+
+		result, err := e.tables.WatchTable().Get(txn, key.String())
+		if err != nil {
+			return err
+		}
+		ts, err := ptypes.Timestamp(result.Timestamp)
+		if err != nil {
+			ts = key.Timestamp
+		}
+		recordKey := key.String()
+		if req.Anonymizer != nil {
+			result, err = req.Anonymizer.AnonymizeWatchResult(result)
+			if err != nil {
+				return errors.Wrapf(err, "failed to anonymize %v", key.String())
+			}
+			anonymizedKey := req.Anonymizer.AnonymizeKey(*key)
+			recordKey = anonymizedKey.String()
+		}
+		line, err := json.Marshal(Record{Timestamp: ts, Key: recordKey, Kind: result.Kind, WatchType: result.WatchType.String(), Payload: json.RawMessage(result.Payload)})
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal %v", key.String())
+		}
+		// Zero padded so the sort keys of a partition sort by time
+		sortKey := fmt.Sprintf("%020d%v", key.Timestamp.UnixNano(), key.String())
+		err = buffer.add(sortKey, append(line, '\n'))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+var someTs = time.Date(2019, 3, 4, 3, 4, 0, 0, time.UTC)
+
+func helper_addWatchResult(t *testing.T, tables typed.Tables, kind string, namespace string, name string, ts time.Time) {
+	pts, _ := ptypes.TimestampProto(ts)
+	val := &typed.KubeWatchResult{Kind: kind, WatchType: typed.KubeWatchResult_UPDATE, Timestamp: pts, Payload: `{"name":"` + name + `"}`}
+	key := typed.NewWatchTableKey(untyped.GetPartitionId(ts), kind, namespace, name, ts).String()
+	err := tables.Db().Update(func(txn badgerwrap.Txn) error {
+		return tables.WatchTable().Set(txn, key, val)
+	})
+	assert.Nil(t, err)
+}
+
+func helper_getTables(t *testing.T) typed.Tables {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	helper_addWatchResult(t, tables, "Pod", "ns1", "pod2", someTs.Add(2*time.Minute))
+	helper_addWatchResult(t, tables, "Pod", "ns1", "pod1", someTs.Add(3*time.Minute))
+	helper_addWatchResult(t, tables, "Pod", "ns2", "pod3", someTs.Add(time.Minute))
+	helper_addWatchResult(t, tables, "Deployment", "ns1", "deploy1", someTs.Add(4*time.Minute))
+	helper_addWatchResult(t, tables, "Pod", "ns1", "pod1", someTs.Add(time.Hour))
+	return tables
+}
+
+func helper_names(t *testing.T, out string) []string {
+	names := []string{}
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		record := Record{}
+		assert.Nil(t, json.Unmarshal([]byte(line), &record))
+		payload := map[string]string{}
+		assert.Nil(t, json.Unmarshal(record.Payload, &payload))
+		names = append(names, payload["name"])
+	}
+	return names
+}
+
+func Test_Export_OrderedByTime(t *testing.T) {
+	for _, spill := range []bool{false, true} {
+		tables := helper_getTables(t)
+		exporter := NewExporter(tables, &badgerwrap.MockFactory{}, "")
+		out := &bytes.Buffer{}
+		count, err := exporter.Export(out, Request{StartTime: someTs, EndTime: someTs.Add(2 * time.Hour), Spill: spill})
+		assert.Nil(t, err)
+		assert.Equal(t, 5, count)
+		assert.Equal(t, []string{"pod3", "pod2", "pod1", "deploy1", "pod1"}, helper_names(t, out.String()))
+	}
+}
+
+func Test_Export_KindNamespaceAndTimeFilter(t *testing.T) {
+	tables := helper_getTables(t)
+	exporter := NewExporter(tables, &badgerwrap.MockFactory{}, "")
+	out := &bytes.Buffer{}
+	count, err := exporter.Export(out, Request{Kinds: []string{"Pod"}, Namespace: "ns1", StartTime: someTs, EndTime: someTs.Add(30 * time.Minute), Spill: true})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, []string{"pod2", "pod1"}, helper_names(t, out.String()))
+}
+
+func Test_spillBuffer_ManyBatches(t *testing.T) {
+	buffer := newSpillBuffer(&badgerwrap.MockFactory{}, "")
+	for i := 2*spillBatchSize + 10; i > 0; i-- {
+		key := fmt.Sprintf("%06d", i)
+		assert.Nil(t, buffer.add(key, []byte(key)))
+	}
+	drained := []string{}
+	err := buffer.drain(func(line []byte) error {
+		drained = append(drained, string(line))
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Len(t, drained, 2*spillBatchSize+10)
+	assert.True(t, sort.StringsAreSorted(drained))
+	assert.Nil(t, buffer.close())
+}
@@ -0,0 +1,407 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+/*
+Package filterexpr evaluates a small subset of CEL against decoded json, such as
+object.kind == 'Event' && object.reason == 'Pulled'.
+
+Supported are string, number, bool, null and list literals, field selection (a.b), indexing (a['b'], a[0]), the
+operators ! - * / % + == != < <= > >= in && ||, the functions has(a.b) and size(a), and the methods startsWith,
+endsWith, contains, matches and size.  Numbers are all float64 like in encoding/json, and + also joins strings.
+Selecting a field that is not there is an error, so optional fields are checked with has() first
+*/
+package filterexpr
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+type Program struct {
+	source string
+	root   node
+}
+
+func Compile(source string) (*Program, error) {
+	tokens, err := tokenize(source)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, fmt.Errorf("unexpected %q at %v", t.text, t.pos)
+	}
+	return &Program{source: source, root: root}, nil
+}
+
+func (p *Program) String() string {
+	return p.source
+}
+
+// Evaluates the expression with vars as the top level identifiers
+func (p *Program) Eval(vars map[string]interface{}) (interface{}, error) {
+	return p.root.eval(vars)
+}
+
+// Evaluates an expression that should give a bool
+func (p *Program) Match(vars map[string]interface{}) (bool, error) {
+	value, err := p.Eval(vars)
+	if err != nil {
+		return false, err
+	}
+	match, ok := value.(bool)
+	if !ok {
+		return false, fmt.Errorf("expression gave %T, not bool", value)
+	}
+	return match, nil
+}
+
+type node interface {
+	eval(vars map[string]interface{}) (interface{}, error)
+}
+
+type literalNode struct {
+	value interface{}
+}
+
+func (n *literalNode) eval(vars map[string]interface{}) (interface{}, error) {
+	return n.value, nil
+}
+
+type listNode struct {
+	items []node
+}
+
+func (n *listNode) eval(vars map[string]interface{}) (interface{}, error) {
+	list := []interface{}{}
+	for _, item := range n.items {
+		value, err := item.eval(vars)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, value)
+	}
+	return list, nil
+}
+
+type identNode struct {
+	name string
+}
+
+func (n *identNode) eval(vars map[string]interface{}) (interface{}, error) {
+	value, ok := vars[n.name]
+	if !ok {
+		return nil, fmt.Errorf("undeclared reference to %v", n.name)
+	}
+	return value, nil
+}
+
+type selectNode struct {
+	operand node
+	field   string
+}
+
+func (n *selectNode) eval(vars map[string]interface{}) (interface{}, error) {
+	operand, err := n.operand.eval(vars)
+	if err != nil {
+		return nil, err
+	}
+	fields, ok := operand.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("can not select %v from %T", n.field, operand)
+	}
+	value, ok := fields[n.field]
+	if !ok {
+		return nil, fmt.Errorf("no such key: %v", n.field)
+	}
+	return value, nil
+}
+
+type indexNode struct {
+	operand node
+	index   node
+}
+
+func (n *indexNode) eval(vars map[string]interface{}) (interface{}, error) {
+	operand, err := n.operand.eval(vars)
+	if err != nil {
+		return nil, err
+	}
+	index, err := n.index.eval(vars)
+	if err != nil {
+		return nil, err
+	}
+	switch typedOperand := operand.(type) {
+	case map[string]interface{}:
+		key, ok := index.(string)
+		if !ok {
+			return nil, fmt.Errorf("map index must be a string, not %T", index)
+		}
+		value, ok := typedOperand[key]
+		if !ok {
+			return nil, fmt.Errorf("no such key: %v", key)
+		}
+		return value, nil
+	case []interface{}:
+		position, ok := index.(float64)
+		if !ok || position != float64(int(position)) {
+			return nil, fmt.Errorf("list index must be an integer, not %v", index)
+		}
+		if position < 0 || int(position) >= len(typedOperand) {
+			return nil, fmt.Errorf("index %v out of range", position)
+		}
+		return typedOperand[int(position)], nil
+	}
+	return nil, fmt.Errorf("can not index %T", operand)
+}
+
+type hasNode struct {
+	selection *selectNode
+}
+
+func (n *hasNode) eval(vars map[string]interface{}) (interface{}, error) {
+	operand, err := n.selection.operand.eval(vars)
+	if err != nil {
+		return nil, err
+	}
+	fields, ok := operand.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("can not check %v on %T", n.selection.field, operand)
+	}
+	_, found := fields[n.selection.field]
+	return found, nil
+}
+
+type sizeNode struct {
+	operand node
+}
+
+func (n *sizeNode) eval(vars map[string]interface{}) (interface{}, error) {
+	operand, err := n.operand.eval(vars)
+	if err != nil {
+		return nil, err
+	}
+	switch typedOperand := operand.(type) {
+	case string:
+		return float64(len([]rune(typedOperand))), nil
+	case []interface{}:
+		return float64(len(typedOperand)), nil
+	case map[string]interface{}:
+		return float64(len(typedOperand)), nil
+	}
+	return nil, fmt.Errorf("no size for %T", operand)
+}
+
+type stringMethodNode struct {
+	method  string
+	operand node
+	arg     node
+}
+
+func (n *stringMethodNode) eval(vars map[string]interface{}) (interface{}, error) {
+	operand, arg, err := evalStrings(vars, n.operand, n.arg)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %v", n.method, err)
+	}
+	switch n.method {
+	case "startsWith":
+		return strings.HasPrefix(operand, arg), nil
+	case "endsWith":
+		return strings.HasSuffix(operand, arg), nil
+	default:
+		return strings.Contains(operand, arg), nil
+	}
+}
+
+type matchesNode struct {
+	operand  node
+	pattern  node
+	compiled *regexp.Regexp
+}
+
+func (n *matchesNode) eval(vars map[string]interface{}) (interface{}, error) {
+	operand, pattern, err := evalStrings(vars, n.operand, n.pattern)
+	if err != nil {
+		return nil, fmt.Errorf("matches: %v", err)
+	}
+	compiled := n.compiled
+	if compiled == nil {
+		compiled, err = regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %v", pattern, err)
+		}
+	}
+	return compiled.MatchString(operand), nil
+}
+
+func evalStrings(vars map[string]interface{}, left node, right node) (string, string, error) {
+	leftValue, err := left.eval(vars)
+	if err != nil {
+		return "", "", err
+	}
+	rightValue, err := right.eval(vars)
+	if err != nil {
+		return "", "", err
+	}
+	leftString, ok := leftValue.(string)
+	if !ok {
+		return "", "", fmt.Errorf("expected a string, got %T", leftValue)
+	}
+	rightString, ok := rightValue.(string)
+	if !ok {
+		return "", "", fmt.Errorf("expected a string, got %T", rightValue)
+	}
+	return leftString, rightString, nil
+}
+
+type unaryNode struct {
+	operator string
+	operand  node
+}
+
+func (n *unaryNode) eval(vars map[string]interface{}) (interface{}, error) {
+	operand, err := n.operand.eval(vars)
+	if err != nil {
+		return nil, err
+	}
+	if n.operator == "!" {
+		value, ok := operand.(bool)
+		if !ok {
+			return nil, fmt.Errorf("! needs a bool, got %T", operand)
+		}
+		return !value, nil
+	}
+	value, ok := operand.(float64)
+	if !ok {
+		return nil, fmt.Errorf("- needs a number, got %T", operand)
+	}
+	return -value, nil
+}
+
+// Short circuits left to right, so a failing right side does not matter when the left decides
+type logicalNode struct {
+	or    bool
+	left  node
+	right node
+}
+
+func (n *logicalNode) eval(vars map[string]interface{}) (interface{}, error) {
+	for _, side := range []node{n.left, n.right} {
+		value, err := side.eval(vars)
+		if err != nil {
+			return nil, err
+		}
+		truth, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("&& and || need bools, got %T", value)
+		}
+		if truth == n.or {
+			return truth, nil
+		}
+	}
+	return !n.or, nil
+}
+
+type binaryNode struct {
+	operator string
+	left     node
+	right    node
+}
+
+func (n *binaryNode) eval(vars map[string]interface{}) (interface{}, error) {
+	left, err := n.left.eval(vars)
+	if err != nil {
+		return nil, err
+	}
+	right, err := n.right.eval(vars)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.operator {
+	case "==":
+		return reflect.DeepEqual(left, right), nil
+	case "!=":
+		return !reflect.DeepEqual(left, right), nil
+	case "in":
+		switch container := right.(type) {
+		case []interface{}:
+			for _, item := range container {
+				if reflect.DeepEqual(left, item) {
+					return true, nil
+				}
+			}
+			return false, nil
+		case map[string]interface{}:
+			key, ok := left.(string)
+			if !ok {
+				return false, nil
+			}
+			_, found := container[key]
+			return found, nil
+		}
+		return nil, fmt.Errorf("in needs a list or map, got %T", right)
+	}
+
+	if leftString, ok := left.(string); ok {
+		rightString, ok := right.(string)
+		if !ok {
+			return nil, fmt.Errorf("can not apply %v to string and %T", n.operator, right)
+		}
+		switch n.operator {
+		case "+":
+			return leftString + rightString, nil
+		case "<":
+			return leftString < rightString, nil
+		case "<=":
+			return leftString <= rightString, nil
+		case ">":
+			return leftString > rightString, nil
+		case ">=":
+			return leftString >= rightString, nil
+		}
+		return nil, fmt.Errorf("can not apply %v to strings", n.operator)
+	}
+
+	leftNumber, leftOk := left.(float64)
+	rightNumber, rightOk := right.(float64)
+	if !leftOk || !rightOk {
+		return nil, fmt.Errorf("can not apply %v to %T and %T", n.operator, left, right)
+	}
+	switch n.operator {
+	case "+":
+		return leftNumber + rightNumber, nil
+	case "-":
+		return leftNumber - rightNumber, nil
+	case "*":
+		return leftNumber * rightNumber, nil
+	case "/":
+		if rightNumber == 0 {
+			return nil, fmt.Errorf("division by zero")
+		}
+		return leftNumber / rightNumber, nil
+	case "%":
+		if int64(rightNumber) == 0 {
+			return nil, fmt.Errorf("modulus by zero")
+		}
+		return float64(int64(leftNumber) % int64(rightNumber)), nil
+	case "<":
+		return leftNumber < rightNumber, nil
+	case "<=":
+		return leftNumber <= rightNumber, nil
+	case ">":
+		return leftNumber > rightNumber, nil
+	default:
+		return leftNumber >= rightNumber, nil
+	}
+}
@@ -0,0 +1,83 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package filterexpr
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const someEvent = `{"kind":"Event","reason":"Pulled","count":3,"metadata":{"name":"e1","namespace":"kube-system","labels":{"app":"dns"}},"involvedObject":{"kind":"Pod","name":"coredns-abc"},"tags":["a","b"]}`
+
+func helper_vars(t *testing.T) map[string]interface{} {
+	object := map[string]interface{}{}
+	assert.Nil(t, json.Unmarshal([]byte(someEvent), &object))
+	return map[string]interface{}{"object": object, "watchType": "UPDATE"}
+}
+
+func Test_Match(t *testing.T) {
+	for expression, expected := range map[string]bool{
+		`object.kind == 'Event' && object.reason == 'Pulled'`:                     true,
+		`object.kind == "Event" && object.reason == "Failed"`:                     false,
+		`object.reason in ['Pulled', 'Pulling']`:                                  true,
+		`object.metadata.namespace.startsWith('kube-')`:                           true,
+		`object.involvedObject.name.matches('^coredns-[a-z]+$')`:                  true,
+		`object['metadata']['labels']['app'] == 'dns'`:                            true,
+		`has(object.metadata.labels) && !has(object.metadata.annotations)`:        true,
+		`'app' in object.metadata.labels`:                                         true,
+		`object.count >= 2 && object.count * 2 == 6 && object.count % 2 == 1`:     true,
+		`size(object.tags) == 2 && object.tags[1] == 'b'`:                         true,
+		`object.metadata.name.size() == 2 && object.metadata.name + '!' == 'e1!'`: true,
+		`watchType == 'DELETE' || (object.count > 5 || object.kind != 'Event')`:   false,
+		`-object.count < 0`: true,
+		// Short circuit past the missing field
+		`object.kind == 'Pod' && object.spec.nodeName == 'n1'`: false,
+	} {
+		program, err := Compile(expression)
+		assert.Nil(t, err, expression)
+		match, err := program.Match(helper_vars(t))
+		assert.Nil(t, err, expression)
+		assert.Equal(t, expected, match, expression)
+	}
+}
+
+func Test_Match_Errors(t *testing.T) {
+	for _, expression := range []string{
+		`object.spec.nodeName == 'n1'`,
+		`object.count`,
+		`object.tags[5] == 'a'`,
+		`unknown == 1`,
+		`object.count + 'a' == 1`,
+		`object.count / 0 == 1`,
+	} {
+		program, err := Compile(expression)
+		assert.Nil(t, err, expression)
+		_, err = program.Match(helper_vars(t))
+		assert.NotNil(t, err, expression)
+	}
+}
+
+func Test_Compile_Errors(t *testing.T) {
+	for _, expression := range []string{
+		``,
+		`object.kind ==`,
+		`object.kind == 'Event`,
+		`(object.kind == 'Event'`,
+		`object.kind == 'Event' 'Pod'`,
+		`object.name.matches('[')`,
+		`has(object)`,
+		`object.kind.lower()`,
+		`lower(object.kind)`,
+		`object.kind # 1`,
+	} {
+		_, err := Compile(expression)
+		assert.NotNil(t, err, expression)
+	}
+}
@@ -0,0 +1,403 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package filterexpr
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenNumber
+	tokenOperator
+)
+
+type token struct {
+	kind  tokenKind
+	text  string
+	value interface{}
+	pos   int
+}
+
+// Longest first, so == is not read as =
+var operators = []string{"&&", "||", "==", "!=", "<=", ">=", "<", ">", "!", "+", "-", "*", "/", "%", ".", ",", "(", ")", "[", "]"}
+
+func tokenize(src string) ([]token, error) {
+	tokens := []token{}
+	pos := 0
+	for pos < len(src) {
+		c := rune(src[pos])
+		switch {
+		case unicode.IsSpace(c):
+			pos++
+		case c == '\'' || c == '"':
+			value, end, err := readString(src, pos)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokenString, text: src[pos:end], value: value, pos: pos})
+			pos = end
+		case unicode.IsDigit(c):
+			end := pos
+			for end < len(src) && (unicode.IsDigit(rune(src[end])) || src[end] == '.') {
+				end++
+			}
+			value, err := strconv.ParseFloat(src[pos:end], 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number %q at %v", src[pos:end], pos)
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: src[pos:end], value: value, pos: pos})
+			pos = end
+		case c == '_' || unicode.IsLetter(c):
+			end := pos
+			for end < len(src) && (src[end] == '_' || unicode.IsLetter(rune(src[end])) || unicode.IsDigit(rune(src[end]))) {
+				end++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: src[pos:end], pos: pos})
+			pos = end
+		default:
+			found := false
+			for _, operator := range operators {
+				if strings.HasPrefix(src[pos:], operator) {
+					tokens = append(tokens, token{kind: tokenOperator, text: operator, pos: pos})
+					pos += len(operator)
+					found = true
+					break
+				}
+			}
+			if !found {
+				return nil, fmt.Errorf("unexpected %q at %v", c, pos)
+			}
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: pos}), nil
+}
+
+// Reads a quoted string starting at pos, returning its value and the position after the closing quote
+func readString(src string, pos int) (string, int, error) {
+	quote := src[pos]
+	value := strings.Builder{}
+	for end := pos + 1; end < len(src); end++ {
+		switch src[end] {
+		case quote:
+			return value.String(), end + 1, nil
+		case '\\':
+			end++
+			if end == len(src) {
+				break
+			}
+			switch src[end] {
+			case 'n':
+				value.WriteByte('\n')
+			case 't':
+				value.WriteByte('\t')
+			default:
+				value.WriteByte(src[end])
+			}
+		default:
+			value.WriteByte(src[end])
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated string at %v", pos)
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) isOperator(operators ...string) bool {
+	t := p.peek()
+	if t.kind != tokenOperator && !(t.kind == tokenIdent && t.text == "in") {
+		return false
+	}
+	for _, operator := range operators {
+		if t.text == operator {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) expect(operator string) error {
+	t := p.next()
+	if t.kind != tokenOperator || t.text != operator {
+		return fmt.Errorf("expected %q at %v, got %q", operator, t.pos, t.text)
+	}
+	return nil
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOperator("||") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalNode{or: true, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseRelation()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOperator("&&") {
+		p.next()
+		right, err := p.parseRelation()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseRelation() (node, error) {
+	left, err := p.parseBinary(p.parseMultiply, "+", "-")
+	if err != nil {
+		return nil, err
+	}
+	if p.isOperator("==", "!=", "<", "<=", ">", ">=", "in") {
+		operator := p.next().text
+		right, err := p.parseBinary(p.parseMultiply, "+", "-")
+		if err != nil {
+			return nil, err
+		}
+		return &binaryNode{operator: operator, left: left, right: right}, nil
+	}
+	return left, nil
+}
+
+func (p *parser) parseMultiply() (node, error) {
+	return p.parseBinary(p.parseUnary, "*", "/", "%")
+}
+
+func (p *parser) parseBinary(operand func() (node, error), operators ...string) (node, error) {
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOperator(operators...) {
+		operator := p.next().text
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{operator: operator, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.isOperator("!", "-") {
+		operator := p.next().text
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryNode{operator: operator, operand: operand}, nil
+	}
+	return p.parsePostfix()
+}
+
+func (p *parser) parsePostfix() (node, error) {
+	operand, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch {
+		case p.isOperator("."):
+			p.next()
+			field := p.next()
+			if field.kind != tokenIdent {
+				return nil, fmt.Errorf("expected a field name at %v", field.pos)
+			}
+			if p.isOperator("(") {
+				args, err := p.parseArgs()
+				if err != nil {
+					return nil, err
+				}
+				operand, err = newMethodNode(field.text, operand, args)
+				if err != nil {
+					return nil, err
+				}
+				continue
+			}
+			operand = &selectNode{operand: operand, field: field.text}
+		case p.isOperator("["):
+			p.next()
+			index, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			err = p.expect("]")
+			if err != nil {
+				return nil, err
+			}
+			operand = &indexNode{operand: operand, index: index}
+		default:
+			return operand, nil
+		}
+	}
+}
+
+func (p *parser) parseArgs() ([]node, error) {
+	err := p.expect("(")
+	if err != nil {
+		return nil, err
+	}
+	args := []node{}
+	for !p.isOperator(")") {
+		if len(args) > 0 {
+			err = p.expect(",")
+			if err != nil {
+				return nil, err
+			}
+		}
+		arg, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	p.next()
+	return args, nil
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.next()
+	switch {
+	case t.kind == tokenString || t.kind == tokenNumber:
+		return &literalNode{value: t.value}, nil
+	case t.kind == tokenIdent:
+		switch t.text {
+		case "true":
+			return &literalNode{value: true}, nil
+		case "false":
+			return &literalNode{value: false}, nil
+		case "null":
+			return &literalNode{value: nil}, nil
+		}
+		if p.isOperator("(") {
+			args, err := p.parseArgs()
+			if err != nil {
+				return nil, err
+			}
+			return newFunctionNode(t.text, args)
+		}
+		return &identNode{name: t.text}, nil
+	case t.kind == tokenOperator && t.text == "(":
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return inner, p.expect(")")
+	case t.kind == tokenOperator && t.text == "[":
+		items := []node{}
+		for !p.isOperator("]") {
+			if len(items) > 0 {
+				err := p.expect(",")
+				if err != nil {
+					return nil, err
+				}
+			}
+			item, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		p.next()
+		return &listNode{items: items}, nil
+	case t.kind == tokenEOF:
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+	return nil, fmt.Errorf("unexpected %q at %v", t.text, t.pos)
+}
+
+func newFunctionNode(name string, args []node) (node, error) {
+	switch name {
+	case "has":
+		if len(args) != 1 {
+			return nil, fmt.Errorf("has takes one field selection")
+		}
+		selection, ok := args[0].(*selectNode)
+		if !ok {
+			return nil, fmt.Errorf("has takes a field selection like has(object.metadata.labels)")
+		}
+		return &hasNode{selection: selection}, nil
+	case "size":
+		if len(args) != 1 {
+			return nil, fmt.Errorf("size takes one argument")
+		}
+		return &sizeNode{operand: args[0]}, nil
+	}
+	return nil, fmt.Errorf("unknown function %v", name)
+}
+
+func newMethodNode(name string, operand node, args []node) (node, error) {
+	switch name {
+	case "startsWith", "endsWith", "contains":
+		if len(args) != 1 {
+			return nil, fmt.Errorf("%v takes one argument", name)
+		}
+		return &stringMethodNode{method: name, operand: operand, arg: args[0]}, nil
+	case "matches":
+		if len(args) != 1 {
+			return nil, fmt.Errorf("matches takes one argument")
+		}
+		method := &matchesNode{operand: operand, pattern: args[0]}
+		// Literal patterns are compiled once, and checked when the expression is
+		if literal, ok := args[0].(*literalNode); ok {
+			pattern, ok := literal.value.(string)
+			if !ok {
+				return nil, fmt.Errorf("matches takes a string")
+			}
+			compiled, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pattern %q: %v", pattern, err)
+			}
+			method.compiled = compiled
+		}
+		return method, nil
+	case "size":
+		if len(args) != 0 {
+			return nil, fmt.Errorf("size takes no arguments")
+		}
+		return &sizeNode{operand: operand}, nil
+	}
+	return nil, fmt.Errorf("unknown method %v", name)
+}
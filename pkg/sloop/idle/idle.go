@@ -0,0 +1,115 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package idle
+
+import (
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	metricIdle          = promauto.NewGauge(prometheus.GaugeOpts{Name: "sloop_idle"})
+	metricIdleWakeCount = promauto.NewCounter(prometheus.CounterOpts{Name: "sloop_idle_wake_count"})
+)
+
+// Background work that slows down while nobody queries sloop.  The factor is 1 when sloop is not idle
+type Throttled interface {
+	SetIdleSlowdown(factor int)
+}
+
+/*
+Puts sloop in a low power mode after a period without requests, for instances that are only looked at now and then.
+While idle every Throttled gets the slowdown factor, which the resync throttle uses to keep fewer resyncs and the
+store manager to run value log GC less often.  The next request sets them back to 1 right away
+*/
+type Monitor struct {
+	after       time.Duration
+	slowdown    int
+	targets     []Throttled
+	lock        *sync.Mutex
+	lastRequest time.Time
+	idle        bool
+	done        chan bool
+	wg          *sync.WaitGroup
+}
+
+func NewMonitor(after time.Duration, slowdown int, now time.Time, targets ...Throttled) *Monitor {
+	return &Monitor{after: after, slowdown: slowdown, targets: targets, lock: &sync.Mutex{}, lastRequest: now, done: make(chan bool), wg: &sync.WaitGroup{}}
+}
+
+// Checks for idleness a few times per period, so sloop goes idle at most a tenth of it late
+func (m *Monitor) Start() {
+	interval := m.after / 10
+	if interval < time.Second {
+		interval = time.Second
+	}
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		for {
+			select {
+			case <-m.done:
+				return
+			case <-time.After(interval):
+			}
+			m.Check(time.Now())
+		}
+	}()
+}
+
+func (m *Monitor) Stop() {
+	close(m.done)
+	m.wg.Wait()
+}
+
+// Records a request at now, and wakes sloop up when it was idle
+func (m *Monitor) Touch(now time.Time) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if now.After(m.lastRequest) {
+		m.lastRequest = now
+	}
+	if !m.idle {
+		return
+	}
+	m.idle = false
+	metricIdle.Set(0)
+	metricIdleWakeCount.Inc()
+	glog.Infof("Leaving idle mode")
+	m.setSlowdown(1)
+}
+
+// Goes idle when there was no request for the idle period before now.  Returns whether sloop is idle
+func (m *Monitor) Check(now time.Time) bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.idle || now.Sub(m.lastRequest) < m.after {
+		return m.idle
+	}
+	m.idle = true
+	metricIdle.Set(1)
+	glog.Infof("No requests since %v, entering idle mode with %vx less background work", m.lastRequest, m.slowdown)
+	m.setSlowdown(m.slowdown)
+	return true
+}
+
+func (m *Monitor) IsIdle() bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.idle
+}
+
+func (m *Monitor) setSlowdown(factor int) {
+	for _, target := range m.targets {
+		target.SetIdleSlowdown(factor)
+	}
+}
@@ -0,0 +1,46 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package idle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeThrottled struct {
+	factors []int
+}
+
+func (f *fakeThrottled) SetIdleSlowdown(factor int) {
+	f.factors = append(f.factors, factor)
+}
+
+func Test_Monitor(t *testing.T) {
+	start := time.Date(2021, 3, 4, 5, 0, 0, 0, time.UTC)
+	throttled := &fakeThrottled{}
+	monitor := NewMonitor(time.Hour, 4, start, throttled)
+
+	assert.False(t, monitor.Check(start.Add(59*time.Minute)))
+	monitor.Touch(start.Add(30 * time.Minute))
+	assert.False(t, monitor.Check(start.Add(80*time.Minute)))
+	assert.True(t, monitor.Check(start.Add(90*time.Minute)))
+	assert.True(t, monitor.IsIdle())
+	// Staying idle does not tell the throttled parts again
+	assert.True(t, monitor.Check(start.Add(2*time.Hour)))
+	assert.Equal(t, []int{4}, throttled.factors)
+
+	monitor.Touch(start.Add(3 * time.Hour))
+	assert.False(t, monitor.IsIdle())
+	assert.Equal(t, []int{4, 1}, throttled.factors)
+	assert.False(t, monitor.Check(start.Add(3*time.Hour+time.Minute)))
+	// A request that is not new does not move the idle period back
+	monitor.Touch(start)
+	assert.True(t, monitor.Check(start.Add(4*time.Hour)))
+}
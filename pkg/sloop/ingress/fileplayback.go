@@ -8,13 +8,82 @@
 package ingress
 
 import (
+	"github.com/dgraph-io/badger/v2"
 	"github.com/ghodss/yaml"
 	"github.com/golang/glog"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
 	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
 	"io/ioutil"
+	"sort"
+	"time"
 )
 
 func PlayFile(outChan chan typed.KubeWatchResult, filename string) error {
+	playbackFile, err := loadPlaybackFile(filename)
+	if err != nil {
+		return err
+	}
+
+	for _, watchRecord := range playbackFile.Data {
+		outChan <- watchRecord
+	}
+	glog.Infof("Done writing kubeWatch events to channel")
+	return nil
+}
+
+// Plays a file into a store that already has data.  Records keep their original timestamps, so they land in the
+// partitions they were recorded in.  They are sent oldest first, and records that are already in the watch table are
+// skipped so the same file can be merged more than once
+func MergePlayFile(outChan chan typed.KubeWatchResult, filename string, tables typed.Tables) error {
+	playbackFile, err := loadPlaybackFile(filename)
+	if err != nil {
+		return err
+	}
+
+	records := playbackFile.Data
+	sort.SliceStable(records, func(i, j int) bool {
+		// Records without a valid timestamp sort first
+		iTime, _ := ptypes.Timestamp(records[i].Timestamp)
+		jTime, _ := ptypes.Timestamp(records[j].Timestamp)
+		return iTime.Before(jTime)
+	})
+
+	skipped := 0
+	for _, watchRecord := range records {
+		exists, err := isInWatchTable(tables, &watchRecord)
+		if err != nil {
+			return err
+		}
+		if exists {
+			skipped++
+			continue
+		}
+		outChan <- watchRecord
+	}
+	glog.Infof("Done merging kubeWatch events to channel, skipped %v already in the store", skipped)
+	return nil
+}
+
+// Time of the newest watch result in the file, zero when none has a valid timestamp
+func PlaybackFileEnd(filename string) (time.Time, error) {
+	playbackFile, err := loadPlaybackFile(filename)
+	if err != nil {
+		return time.Time{}, err
+	}
+	end := time.Time{}
+	for _, watchRecord := range playbackFile.Data {
+		timestamp, err := ptypes.Timestamp(watchRecord.Timestamp)
+		if err == nil && timestamp.After(end) {
+			end = timestamp
+		}
+	}
+	return end, nil
+}
+
+func loadPlaybackFile(filename string) (*KubePlaybackFile, error) {
 	b, err := ioutil.ReadFile(filename)
 	if err != nil {
 		panic(err)
@@ -22,14 +91,36 @@ func PlayFile(outChan chan typed.KubeWatchResult, filename string) error {
 	var playbackFile KubePlaybackFile
 	err = yaml.Unmarshal(b, &playbackFile)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	glog.Infof("Loaded %v resources from file source %v", len(playbackFile.Data), filename)
+	return &playbackFile, nil
+}
 
-	for _, watchRecord := range playbackFile.Data {
-		outChan <- watchRecord
+func isInWatchTable(tables typed.Tables, watchRecord *typed.KubeWatchResult) (bool, error) {
+	metadata, err := kubeextractor.ExtractMetadata(watchRecord.Payload)
+	if err != nil {
+		// Processing quarantines these
+		return false, nil
 	}
-	glog.Infof("Done writing kubeWatch events to channel")
-	return nil
+	timestamp, err := ptypes.Timestamp(watchRecord.Timestamp)
+	if err != nil {
+		return false, nil
+	}
+	namespace := kubeextractor.NormalizeNamespace(watchRecord.Kind, metadata.Namespace)
+	key := typed.NewWatchTableKey(untyped.GetPartitionId(timestamp), watchRecord.Kind, namespace, metadata.Name, timestamp)
+
+	exists := false
+	err = tables.Db().View(func(txn badgerwrap.Txn) error {
+		_, err := tables.WatchTable().Get(txn, key.String())
+		if err == badger.ErrKeyNotFound {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		exists = true
+		return nil
+	})
+	return exists, err
 }
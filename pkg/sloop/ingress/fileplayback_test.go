@@ -0,0 +1,78 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package ingress
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/ghodss/yaml"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+var somePlaybackTime = time.Date(2019, 3, 4, 3, 4, 5, 6, time.UTC)
+
+func helper_playbackRecord(t *testing.T, name string, offset time.Duration) typed.KubeWatchResult {
+	ts, err := ptypes.TimestampProto(somePlaybackTime.Add(offset))
+	assert.Nil(t, err)
+	return typed.KubeWatchResult{Kind: "Pod", WatchType: typed.KubeWatchResult_UPDATE, Timestamp: ts, Payload: `{"metadata":{"name":"` + name + `","namespace":"ns"}}`}
+}
+
+func Test_MergePlayFile_SkipsStoredRecordsAndSortsByTime(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+
+	stored := helper_playbackRecord(t, "a", 0)
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		key := typed.NewWatchTableKey(untyped.GetPartitionId(somePlaybackTime), "Pod", "ns", "a", somePlaybackTime)
+		return tables.WatchTable().Set(txn, key.String(), &stored)
+	})
+	assert.Nil(t, err)
+
+	later := helper_playbackRecord(t, "b", 2*time.Hour)
+	earlier := helper_playbackRecord(t, "c", -2*time.Hour)
+	bytes, err := yaml.Marshal(KubePlaybackFile{Data: []typed.KubeWatchResult{later, stored, earlier}})
+	assert.Nil(t, err)
+	dir, err := ioutil.TempDir("", "playback")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "playback.yaml")
+	assert.Nil(t, ioutil.WriteFile(filename, bytes, 0644))
+
+	outChan := make(chan typed.KubeWatchResult, 5)
+	err = MergePlayFile(outChan, filename, tables)
+	assert.Nil(t, err)
+	assert.Equal(t, earlier.Payload, (<-outChan).Payload)
+	assert.Equal(t, later.Payload, (<-outChan).Payload)
+	verifyChannelEmpty(t, outChan)
+}
+
+func Test_PlaybackFileEnd(t *testing.T) {
+	records := []typed.KubeWatchResult{helper_playbackRecord(t, "a", time.Hour), helper_playbackRecord(t, "b", 3*time.Hour), helper_playbackRecord(t, "c", 0)}
+	bytes, err := yaml.Marshal(KubePlaybackFile{Data: records})
+	assert.Nil(t, err)
+	dir, err := ioutil.TempDir("", "playback")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "playback.yaml")
+	assert.Nil(t, ioutil.WriteFile(filename, bytes, 0644))
+
+	end, err := PlaybackFileEnd(filename)
+	assert.Nil(t, err)
+	assert.True(t, somePlaybackTime.Add(3*time.Hour).Equal(end))
+}
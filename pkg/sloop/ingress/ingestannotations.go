@@ -0,0 +1,111 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package ingress
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+)
+
+var (
+	metricIngestAnnotationCount        = promauto.NewCounterVec(prometheus.CounterOpts{Name: "sloop_ingest_annotation_count"}, []string{"type", "kind"})
+	metricIngestAnnotationDroppedCount = promauto.NewCounter(prometheus.CounterOpts{Name: "sloop_ingest_annotation_dropped_count"})
+
+	// Reflectors report list and watch failures as "<name>: Failed to list *v1.Pod: <error>".  Informers of CRDs
+	// list *unstructured.Unstructured, which does not tell the kind
+	reflectorErrorRegex = regexp.MustCompile(`Failed to (?:list|watch) \*?[\w-]+\.(\w+): (.*)$`)
+
+	annotationHookOnce sync.Once
+	annotationChanLock sync.Mutex
+	annotationChan     chan *typed.IngestAnnotation
+)
+
+/*
+client-go 0.17 has no per informer watch error handler, reflectors hand their errors to the process wide
+utilruntime.ErrorHandlers.  The hook is added once and sends to the channel of the running watcher, if any
+*/
+func setIngestAnnotationChan(outChan chan *typed.IngestAnnotation) {
+	annotationHookOnce.Do(func() {
+		utilruntime.ErrorHandlers = append(utilruntime.ErrorHandlers, recordReflectorError)
+	})
+	annotationChanLock.Lock()
+	defer annotationChanLock.Unlock()
+	annotationChan = outChan
+}
+
+func recordReflectorError(err error) {
+	annotation := reflectorErrorToAnnotation(err)
+	if annotation != nil {
+		sendIngestAnnotation(annotation)
+	}
+}
+
+// Returns nil for errors that do not come from a reflector, since those do not leave gaps in the timeline
+func reflectorErrorToAnnotation(err error) *typed.IngestAnnotation {
+	if err == nil {
+		return nil
+	}
+	match := reflectorErrorRegex.FindStringSubmatch(err.Error())
+	if match == nil {
+		return nil
+	}
+	kind := match[1]
+	if kind == "Unstructured" {
+		kind = ""
+	}
+	annotationType := typed.IngestAnnotationWatchError
+	// The error was flattened into a string by the reflector, so errors.IsTooManyRequests can not be used
+	lowerMessage := strings.ToLower(match[2])
+	if strings.Contains(lowerMessage, "too many requests") || strings.Contains(lowerMessage, "429") {
+		annotationType = typed.IngestAnnotationThrottled
+	}
+	return &typed.IngestAnnotation{Type: annotationType, Kind: kind, Message: match[2], Count: 1}
+}
+
+// A delete the informer found by listing again, because the watch missed it
+func relistAnnotation(kind string, deleted cache.DeletedFinalStateUnknown) *typed.IngestAnnotation {
+	namespace, name, err := cache.SplitMetaNamespaceKey(deleted.Key)
+	if err != nil {
+		name = deleted.Key
+	}
+	return &typed.IngestAnnotation{
+		Type:      typed.IngestAnnotationRelist,
+		Kind:      kind,
+		Namespace: namespace,
+		Message:   fmt.Sprintf("relist found %v was deleted without a watch event", name),
+		Count:     1,
+	}
+}
+
+// Never blocks, the error handlers run on the goroutines of client-go
+func sendIngestAnnotation(annotation *typed.IngestAnnotation) {
+	now := common.TimestampNow()
+	annotation.FirstSeen = now
+	annotation.LastSeen = now
+
+	annotationChanLock.Lock()
+	defer annotationChanLock.Unlock()
+	if annotationChan == nil {
+		return
+	}
+	select {
+	case annotationChan <- annotation:
+		metricIngestAnnotationCount.WithLabelValues(annotation.Type, annotation.Kind).Inc()
+	default:
+		metricIngestAnnotationDroppedCount.Inc()
+	}
+}
@@ -0,0 +1,57 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package ingress
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/stretchr/testify/assert"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+)
+
+func Test_reflectorErrorToAnnotation(t *testing.T) {
+	annotation := reflectorErrorToAnnotation(fmt.Errorf("k8s.io/client-go/informers/factory.go:135: Failed to list *v1.Pod: Get https://api/pods: dial tcp: connection refused"))
+	assert.Equal(t, typed.IngestAnnotationWatchError, annotation.Type)
+	assert.Equal(t, "Pod", annotation.Kind)
+	assert.Equal(t, "Get https://api/pods: dial tcp: connection refused", annotation.Message)
+
+	annotation = reflectorErrorToAnnotation(fmt.Errorf("factory.go:135: Failed to watch *v1beta1.EndpointSlice: the server has received too many requests and has asked us to try again later"))
+	assert.Equal(t, typed.IngestAnnotationThrottled, annotation.Type)
+	assert.Equal(t, "EndpointSlice", annotation.Kind)
+
+	annotation = reflectorErrorToAnnotation(fmt.Errorf("informer.go:1: Failed to list *unstructured.Unstructured: Too many requests"))
+	assert.Equal(t, typed.IngestAnnotationThrottled, annotation.Type)
+	assert.Equal(t, "", annotation.Kind)
+
+	assert.Nil(t, reflectorErrorToAnnotation(fmt.Errorf("some unrelated error")))
+	assert.Nil(t, reflectorErrorToAnnotation(nil))
+}
+
+func Test_IngestAnnotations_SentThroughErrorHandlers(t *testing.T) {
+	annotationChan := make(chan *typed.IngestAnnotation, 1)
+	setIngestAnnotationChan(annotationChan)
+	defer setIngestAnnotationChan(nil)
+
+	utilruntime.HandleError(fmt.Errorf("factory.go:135: Failed to watch *v1.Node: unexpected EOF"))
+	annotation := <-annotationChan
+	assert.Equal(t, "Node", annotation.Kind)
+	assert.Equal(t, int64(1), annotation.Count)
+	assert.NotNil(t, annotation.FirstSeen)
+
+	// A full channel drops the annotation rather than blocking client-go
+	sendIngestAnnotation(relistAnnotation("Pod", cache.DeletedFinalStateUnknown{Key: "ns1/pod1"}))
+	sendIngestAnnotation(relistAnnotation("Pod", cache.DeletedFinalStateUnknown{Key: "ns1/pod2"}))
+	annotation = <-annotationChan
+	assert.Equal(t, typed.IngestAnnotationRelist, annotation.Type)
+	assert.Equal(t, "ns1", annotation.Namespace)
+	assert.Contains(t, annotation.Message, "pod1")
+	assert.Len(t, annotationChan, 0)
+}
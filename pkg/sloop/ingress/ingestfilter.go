@@ -0,0 +1,125 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package ingress
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/golang/glog"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/salesforce/sloop/pkg/sloop/filterexpr"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+)
+
+const (
+	IngestFilterDrop   = "drop"
+	IngestFilterSample = "sample"
+)
+
+var (
+	metricIngressFilteredCount    = promauto.NewCounterVec(prometheus.CounterOpts{Name: "sloop_ingress_filtered_count"}, []string{"filter"})
+	metricIngressFilterErrorCount = promauto.NewCounterVec(prometheus.CounterOpts{Name: "sloop_ingress_filter_error_count"}, []string{"filter"})
+)
+
+// A rule for dropping noisy watch results before they are stored.  Expression is a filterexpr expression, a subset of
+// CEL, over object (the payload, with its kind always set) and watchType (ADD, UPDATE or DELETE), like
+// object.kind == 'Event' && object.reason == 'Pulled'
+type IngestFilter struct {
+	Name       string `json:"name"`
+	Expression string `json:"expression"`
+	// drop or sample
+	Action string `json:"action"`
+	// With sample, one in this many matching watch results is kept
+	KeepOneIn int `json:"keepOneIn"`
+}
+
+// Checked in order, the first rule matching a watch result decides what happens to it
+type IngestFilters []IngestFilter
+
+func (f IngestFilters) Validate() error {
+	_, err := f.Compile()
+	return err
+}
+
+func (f IngestFilters) Compile() (*IngestFilterSet, error) {
+	set := &IngestFilterSet{}
+	names := map[string]bool{}
+	for idx, filter := range f {
+		if filter.Name == "" {
+			return nil, fmt.Errorf("ingest filter %v has no name", idx)
+		}
+		if names[filter.Name] {
+			return nil, fmt.Errorf("ingest filter %q is defined twice", filter.Name)
+		}
+		names[filter.Name] = true
+		switch filter.Action {
+		case IngestFilterDrop:
+		case IngestFilterSample:
+			if filter.KeepOneIn < 2 {
+				return nil, fmt.Errorf("ingest filter %q needs keepOneIn of at least 2 to sample", filter.Name)
+			}
+		default:
+			return nil, fmt.Errorf("ingest filter %q has action %q, should be %v or %v", filter.Name, filter.Action, IngestFilterDrop, IngestFilterSample)
+		}
+		program, err := filterexpr.Compile(filter.Expression)
+		if err != nil {
+			return nil, fmt.Errorf("ingest filter %q has an invalid expression: %v", filter.Name, err)
+		}
+		set.filters = append(set.filters, &compiledIngestFilter{IngestFilter: filter, program: program})
+	}
+	return set, nil
+}
+
+type compiledIngestFilter struct {
+	IngestFilter
+	program *filterexpr.Program
+	matches uint64
+}
+
+type IngestFilterSet struct {
+	filters []*compiledIngestFilter
+}
+
+// Returns the name of the filter dropping the watch result, or "" to keep it.  Deletes are always kept so dropped
+// resources do not look like they live forever, and an expression that fails to evaluate matches nothing
+func (s *IngestFilterSet) drop(kind string, watchType typed.KubeWatchResult_WatchType, payload string) string {
+	if s == nil || len(s.filters) == 0 || watchType == typed.KubeWatchResult_DELETE {
+		return ""
+	}
+	object := map[string]interface{}{}
+	err := json.Unmarshal([]byte(payload), &object)
+	if err != nil {
+		return ""
+	}
+	// Typed informers leave the kind out of their objects
+	if objectKind, _ := object["kind"].(string); objectKind == "" {
+		object["kind"] = kind
+	}
+	vars := map[string]interface{}{"object": object, "watchType": watchType.String()}
+
+	for _, filter := range s.filters {
+		match, err := filter.program.Match(vars)
+		if err != nil {
+			glog.V(2).Infof("Ingest filter %q failed on %v: %v", filter.Name, kind, err)
+			metricIngressFilterErrorCount.WithLabelValues(filter.Name).Inc()
+			continue
+		}
+		if !match {
+			continue
+		}
+		if filter.Action == IngestFilterSample && atomic.AddUint64(&filter.matches, 1)%uint64(filter.KeepOneIn) == 1 {
+			return ""
+		}
+		metricIngressFilteredCount.WithLabelValues(filter.Name).Inc()
+		return filter.Name
+	}
+	return ""
+}
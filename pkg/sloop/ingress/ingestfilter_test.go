@@ -0,0 +1,71 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package ingress
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/stretchr/testify/assert"
+)
+
+const somePulledEvent = `{"metadata":{"name":"e1","namespace":"n"},"reason":"Pulled"}`
+
+func Test_IngestFilters_Compile(t *testing.T) {
+	_, err := IngestFilters{{Name: "pulled", Expression: "object.reason == 'Pulled'", Action: IngestFilterDrop}}.Compile()
+	assert.Nil(t, err)
+
+	for _, filters := range []IngestFilters{
+		{{Expression: "true", Action: IngestFilterDrop}},
+		{{Name: "a", Expression: "true", Action: IngestFilterDrop}, {Name: "a", Expression: "true", Action: IngestFilterDrop}},
+		{{Name: "a", Expression: "true", Action: "keep"}},
+		{{Name: "a", Expression: "true", Action: IngestFilterSample, KeepOneIn: 1}},
+		{{Name: "a", Expression: "object.reason ==", Action: IngestFilterDrop}},
+	} {
+		assert.NotNil(t, filters.Validate(), filters)
+	}
+}
+
+func Test_IngestFilterSet_Drop(t *testing.T) {
+	set, err := IngestFilters{
+		{Name: "bad", Expression: "object.spec.nodeName == 'n1'", Action: IngestFilterDrop},
+		{Name: "pulled", Expression: "object.kind == 'Event' && object.reason == 'Pulled'", Action: IngestFilterDrop},
+		{Name: "pods", Expression: "object.kind == 'Pod' && watchType == 'UPDATE'", Action: IngestFilterSample, KeepOneIn: 3},
+	}.Compile()
+	assert.Nil(t, err)
+
+	assert.Equal(t, "pulled", set.drop("Event", typed.KubeWatchResult_UPDATE, somePulledEvent))
+	assert.Equal(t, "", set.drop("Event", typed.KubeWatchResult_DELETE, somePulledEvent))
+	assert.Equal(t, "", set.drop("Event", typed.KubeWatchResult_UPDATE, `{"reason":"Failed"}`))
+
+	kept := 0
+	for idx := 0; idx < 6; idx++ {
+		if set.drop("Pod", typed.KubeWatchResult_UPDATE, `{"metadata":{"name":"p1"}}`) == "" {
+			kept++
+		}
+	}
+	assert.Equal(t, 2, kept)
+	assert.Equal(t, "", set.drop("Pod", typed.KubeWatchResult_ADD, `{"metadata":{"name":"p1"}}`))
+
+	var noFilters *IngestFilterSet
+	assert.Equal(t, "", noFilters.drop("Event", typed.KubeWatchResult_UPDATE, somePulledEvent))
+}
+
+func Test_processUpdate_IngestFilters(t *testing.T) {
+	outChan := make(chan typed.KubeWatchResult, 5)
+	set, err := IngestFilters{{Name: "n", Expression: "object.Namespace == 'n'", Action: IngestFilterDrop}}.Compile()
+	assert.Nil(t, err)
+	kw := &kubeWatcherImpl{protection: &sync.Mutex{}, outchan: outChan, ingestFilters: set}
+
+	kw.processUpdate("k", dummyData{Namespace: "n"}, &typed.KubeWatchResult{Kind: "k"})
+	verifyChannelEmpty(t, outChan)
+	kw.processUpdate("k", dummyData{Namespace: "m"}, &typed.KubeWatchResult{Kind: "k"})
+	result := <-outChan
+	assert.Equal(t, "k", result.Kind)
+}
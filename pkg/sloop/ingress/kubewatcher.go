@@ -16,15 +16,18 @@ import (
 	"time"
 
 	"github.com/golang/glog"
-	"github.com/golang/protobuf/ptypes"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
 	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	"k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/dynamic/dynamicinformer"
 	"k8s.io/client-go/informers"
@@ -66,8 +69,18 @@ type kubeWatcherImpl struct {
 	stopped        bool
 	refreshCrd     *time.Ticker
 	currentContext string
+	// Optional.  When set, only kinds it accepts are watched (used in shard mode)
+	kindFilter func(string) bool
+	// Optional.  Drops or samples watch results before they are sent to outchan
+	ingestFilters *IngestFilterSet
+	// Optional.  Drops resyncs of kinds with a longer resync interval than the informers
+	resyncThrottle *ResyncThrottle
 }
 
+// APIServices are not CRDs, but like them are only served through the dynamic client.  They are watched along with
+// CRDs so the availability of aggregated APIs is recorded
+var apiServiceResource = crdGroupVersionResourceKind{group: "apiregistration.k8s.io", version: "v1", resource: "apiservices", kind: kubeextractor.ApiServiceKind}
+
 var (
 	newCrdClient = func(kubeCfg *rest.Config) (clientset.Interface, error) { return clientset.NewForConfig(kubeCfg) }
 
@@ -78,12 +91,14 @@ var (
 )
 
 // Todo: Add additional parameters for filtering
-func NewKubeWatcherSource(kubeClient kubernetes.Interface, outChan chan typed.KubeWatchResult, resync time.Duration, includeCrds bool, crdRefreshInterval time.Duration, masterURL string, kubeContext string) (KubeWatcher, error) {
-	kw := &kubeWatcherImpl{resync: resync, protection: &sync.Mutex{}}
+func NewKubeWatcherSource(kubeClient kubernetes.Interface, outChan chan typed.KubeWatchResult, resync time.Duration, includeCrds bool, crdRefreshInterval time.Duration, masterURL string, kubeContext string, kindFilter func(string) bool, annotationChan chan *typed.IngestAnnotation, ingestFilters *IngestFilterSet, resyncThrottle *ResyncThrottle) (KubeWatcher, error) {
+	kw := &kubeWatcherImpl{resync: resync, protection: &sync.Mutex{}, kindFilter: kindFilter, ingestFilters: ingestFilters, resyncThrottle: resyncThrottle}
 	kw.stopChan = make(chan struct{})
 	kw.crdInformers = make(map[crdGroupVersionResourceKind]*crdInformerInfo)
 	kw.outchan = outChan
+	setIngestAnnotationChan(annotationChan)
 
+	registerClusterScopedKinds(kubeClient.Discovery())
 	kw.startWellKnownInformers(kubeClient)
 	if includeCrds {
 		err := kw.startCustomInformers(masterURL, kubeContext)
@@ -101,27 +116,64 @@ func NewKubeWatcherSource(kubeClient kubernetes.Interface, outChan chan typed.Ku
 func (i *kubeWatcherImpl) startWellKnownInformers(kubeclient kubernetes.Interface) {
 	i.informerFactory = informers.NewSharedInformerFactory(kubeclient, i.resync)
 
-	i.informerFactory.Apps().V1().DaemonSets().Informer().AddEventHandler(i.getEventHandlerForResource("DaemonSet"))
-	i.informerFactory.Apps().V1().Deployments().Informer().AddEventHandler(i.getEventHandlerForResource("Deployment"))
-	i.informerFactory.Apps().V1().ReplicaSets().Informer().AddEventHandler(i.getEventHandlerForResource("ReplicaSet"))
-	i.informerFactory.Apps().V1().StatefulSets().Informer().AddEventHandler(i.getEventHandlerForResource("StatefulSet"))
-	i.informerFactory.Core().V1().ConfigMaps().Informer().AddEventHandler(i.getEventHandlerForResource("ConfigMap"))
-	i.informerFactory.Core().V1().Endpoints().Informer().AddEventHandler(i.getEventHandlerForResource("Endpoint"))
-	i.informerFactory.Core().V1().Events().Informer().AddEventHandler(i.getEventHandlerForResource("Event"))
-	i.informerFactory.Autoscaling().V1().HorizontalPodAutoscalers().Informer().AddEventHandler(i.getEventHandlerForResource("HorizontalPodAutoscaler"))
-	i.informerFactory.Batch().V1().Jobs().Informer().AddEventHandler(i.getEventHandlerForResource("Job"))
-	i.informerFactory.Core().V1().Namespaces().Informer().AddEventHandler(i.getEventHandlerForResource("Namespace"))
-	i.informerFactory.Core().V1().Nodes().Informer().AddEventHandler(i.getEventHandlerForResource("Node"))
-	i.informerFactory.Core().V1().PersistentVolumeClaims().Informer().AddEventHandler(i.getEventHandlerForResource("PersistentVolumeClaim"))
-	i.informerFactory.Core().V1().PersistentVolumes().Informer().AddEventHandler(i.getEventHandlerForResource("PersistentVolume"))
-	i.informerFactory.Core().V1().Pods().Informer().AddEventHandler(i.getEventHandlerForResource("Pod"))
-	i.informerFactory.Policy().V1beta1().PodDisruptionBudgets().Informer().AddEventHandler(i.getEventHandlerForResource("PodDisruptionBudget"))
-	i.informerFactory.Core().V1().Services().Informer().AddEventHandler(i.getEventHandlerForResource("Service"))
-	i.informerFactory.Core().V1().ReplicationControllers().Informer().AddEventHandler(i.getEventHandlerForResource("ReplicationController"))
-	i.informerFactory.Storage().V1().StorageClasses().Informer().AddEventHandler(i.getEventHandlerForResource("StorageClass"))
+	// Calling Informer() registers it with the factory, so only do that for kinds we want
+	wellKnownInformers := []struct {
+		kind       string
+		apiVersion string
+		informer   func() cache.SharedIndexInformer
+	}{
+		{"DaemonSet", "apps/v1", i.informerFactory.Apps().V1().DaemonSets().Informer},
+		{"Deployment", "apps/v1", i.informerFactory.Apps().V1().Deployments().Informer},
+		{"ReplicaSet", "apps/v1", i.informerFactory.Apps().V1().ReplicaSets().Informer},
+		{"StatefulSet", "apps/v1", i.informerFactory.Apps().V1().StatefulSets().Informer},
+		{"ConfigMap", "v1", i.informerFactory.Core().V1().ConfigMaps().Informer},
+		{"Endpoint", "v1", i.informerFactory.Core().V1().Endpoints().Informer},
+		{"EndpointSlice", "discovery.k8s.io/v1beta1", i.informerFactory.Discovery().V1beta1().EndpointSlices().Informer},
+		{"Event", "v1", i.informerFactory.Core().V1().Events().Informer},
+		{"HorizontalPodAutoscaler", "autoscaling/v1", i.informerFactory.Autoscaling().V1().HorizontalPodAutoscalers().Informer},
+		{"Job", "batch/v1", i.informerFactory.Batch().V1().Jobs().Informer},
+		{"Namespace", "v1", i.informerFactory.Core().V1().Namespaces().Informer},
+		{"Node", "v1", i.informerFactory.Core().V1().Nodes().Informer},
+		{"PersistentVolumeClaim", "v1", i.informerFactory.Core().V1().PersistentVolumeClaims().Informer},
+		{"PersistentVolume", "v1", i.informerFactory.Core().V1().PersistentVolumes().Informer},
+		{"Pod", "v1", i.informerFactory.Core().V1().Pods().Informer},
+		{"PodDisruptionBudget", "policy/v1beta1", i.informerFactory.Policy().V1beta1().PodDisruptionBudgets().Informer},
+		{"Service", "v1", i.informerFactory.Core().V1().Services().Informer},
+		{"ReplicationController", "v1", i.informerFactory.Core().V1().ReplicationControllers().Informer},
+		{"ResourceQuota", "v1", i.informerFactory.Core().V1().ResourceQuotas().Informer},
+		{"LimitRange", "v1", i.informerFactory.Core().V1().LimitRanges().Informer},
+		{"StorageClass", "storage.k8s.io/v1", i.informerFactory.Storage().V1().StorageClasses().Informer},
+	}
+	for _, wellKnown := range wellKnownInformers {
+		if !i.watchesKind(wellKnown.kind) {
+			glog.V(2).Infof("Skipping informer for kind %s which is not owned by this shard", wellKnown.kind)
+			continue
+		}
+		wellKnown.informer().AddEventHandler(i.getEventHandlerForResource(wellKnown.kind, wellKnown.apiVersion))
+	}
 	i.informerFactory.Start(i.stopChan)
 }
 
+// Looks up which kinds served by the api server are not namespaced, aggregated APIs included.  This is best effort,
+// the kinds that could be listed are still registered when some API groups are unavailable
+func registerClusterScopedKinds(discoveryClient discovery.DiscoveryInterface) {
+	resourceLists, err := discoveryClient.ServerPreferredResources()
+	if err != nil {
+		glog.Warningf("Failed to discover all api resources, cluster scoped kinds may be incomplete: %v", err)
+	}
+	for _, resourceList := range resourceLists {
+		for _, resource := range resourceList.APIResources {
+			if !resource.Namespaced {
+				kubeextractor.RegisterClusterScopedKind(resource.Kind)
+			}
+		}
+	}
+}
+
+func (i *kubeWatcherImpl) watchesKind(kind string) bool {
+	return i.kindFilter == nil || i.kindFilter(kind)
+}
+
 func (i *kubeWatcherImpl) startCustomInformers(masterURL string, kubeContext string) error {
 
 	clientCfg := getConfig(masterURL, kubeContext)
@@ -140,6 +192,7 @@ func (i *kubeWatcherImpl) startCustomInformers(masterURL string, kubeContext str
 	}
 
 	glog.Infof("Found %d CRD definitions", len(crdList))
+	crdList = append(crdList, apiServiceResource)
 	dynamicClient, err := dynamic.NewForConfig(kubeCfg)
 	if err != nil {
 		return errors.Wrap(err, "failed to instantiate client for custom informers")
@@ -147,6 +200,9 @@ func (i *kubeWatcherImpl) startCustomInformers(masterURL string, kubeContext str
 	existing := i.pullCrdInformers()
 	factory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicClient, i.resync, "", nil)
 	for _, crd := range crdList {
+		if !i.watchesKind(crd.kind) {
+			continue
+		}
 		i.existingOrStartNewCrdInformer(crd, existing, factory)
 	}
 
@@ -190,7 +246,7 @@ func (i *kubeWatcherImpl) startNewCrdInformer(crdInformer *crdInformerInfo, fact
 	gvr := schema.GroupVersionResource{Group: crdInformer.crd.group, Version: crdInformer.crd.version, Resource: crdInformer.crd.resource}
 	kind := crdInformer.crd.kind
 	informer := factory.ForResource(gvr)
-	informer.Informer().AddEventHandler(i.getEventHandlerForResource(kind))
+	informer.Informer().AddEventHandler(i.getEventHandlerForResource(kind, gvr.GroupVersion().String()))
 
 	go func() {
 		glog.V(2).Infof("Starting CRD informer for: %s (%v)", kind, gvr)
@@ -226,6 +282,9 @@ func getCrdList(crdClient clientset.Interface) ([]crdGroupVersionResourceKind, e
 			glog.V(2).Infof("CRD: group: %s, version: %s, kind: %s, plural:%s, singular:%s, short names:%v", crd.Spec.Group, version.Name, crd.Spec.Names.Kind, crd.Spec.Names.Plural, crd.Spec.Names.Singular, crd.Spec.Names.ShortNames)
 			resources = append(resources, gvrk)
 		}
+		if crd.Spec.Scope == apiextensionsv1.ClusterScoped {
+			kubeextractor.RegisterClusterScopedKind(crd.Spec.Names.Kind)
+		}
 	}
 	return resources, nil
 }
@@ -244,59 +303,92 @@ func getCrdListV1beta1(crdClient clientset.Interface) ([]crdGroupVersionResource
 			glog.V(2).Infof("CRD: group: %s, version: %s, kind: %s, plural:%s, singular:%s, short names:%v", crd.Spec.Group, version.Name, crd.Spec.Names.Kind, crd.Spec.Names.Plural, crd.Spec.Names.Singular, crd.Spec.Names.ShortNames)
 			resources = append(resources, gvrk)
 		}
+		if crd.Spec.Scope == apiextensionsv1beta1.ClusterScoped {
+			kubeextractor.RegisterClusterScopedKind(crd.Spec.Names.Kind)
+		}
 	}
 	return resources, nil
 }
 
-func (i *kubeWatcherImpl) getEventHandlerForResource(resourceKind string) cache.ResourceEventHandler {
+// apiVersion is the group and version the informer watches, typed objects have no apiVersion in their payload
+func (i *kubeWatcherImpl) getEventHandlerForResource(resourceKind string, apiVersion string) cache.ResourceEventHandler {
 	return cache.ResourceEventHandlerFuncs{
-		AddFunc:    i.reportAdd(resourceKind),
-		DeleteFunc: i.reportDelete(resourceKind),
-		UpdateFunc: i.reportUpdate(resourceKind),
+		AddFunc:    i.reportAdd(resourceKind, apiVersion),
+		DeleteFunc: i.reportDelete(resourceKind, apiVersion),
+		UpdateFunc: i.reportUpdate(resourceKind, apiVersion),
 	}
 }
 
-func (i *kubeWatcherImpl) reportAdd(kind string) func(interface{}) {
+func (i *kubeWatcherImpl) reportAdd(kind string, apiVersion string) func(interface{}) {
 	return func(obj interface{}) {
 		watchResultShell := &typed.KubeWatchResult{
-			Timestamp: ptypes.TimestampNow(),
-			Kind:      kind,
-			WatchType: typed.KubeWatchResult_ADD,
-			Payload:   "",
+			Timestamp:  common.TimestampNow(),
+			Kind:       kind,
+			WatchType:  typed.KubeWatchResult_ADD,
+			Payload:    "",
+			ApiVersion: apiVersion,
 		}
 		i.processUpdate(kind, obj, watchResultShell)
 	}
 }
 
-func (i *kubeWatcherImpl) reportDelete(kind string) func(interface{}) {
+func (i *kubeWatcherImpl) reportDelete(kind string, apiVersion string) func(interface{}) {
 	return func(obj interface{}) {
+		// The payload is the final state either way, but when the watch missed the delete it is only as new as the
+		// informer cache
+		finalState := typed.FinalStateWatch
 		delObj, ok := obj.(cache.DeletedFinalStateUnknown)
 		if ok {
 			obj = delObj.Obj
+			finalState = typed.FinalStateCache
+			sendIngestAnnotation(relistAnnotation(kind, delObj))
 		}
 
 		watchResultShell := &typed.KubeWatchResult{
-			Timestamp: ptypes.TimestampNow(),
-			Kind:      kind,
-			WatchType: typed.KubeWatchResult_DELETE,
-			Payload:   "",
+			Timestamp:  common.TimestampNow(),
+			Kind:       kind,
+			WatchType:  typed.KubeWatchResult_DELETE,
+			Payload:    "",
+			FinalState: finalState,
+			ApiVersion: apiVersion,
 		}
 		i.processUpdate(kind, obj, watchResultShell)
 	}
 }
 
-func (i *kubeWatcherImpl) reportUpdate(kind string) func(interface{}, interface{}) {
-	return func(_ interface{}, newObj interface{}) {
+func (i *kubeWatcherImpl) reportUpdate(kind string, apiVersion string) func(interface{}, interface{}) {
+	return func(oldObj interface{}, newObj interface{}) {
+		if !i.resyncThrottle.keepUpdate(kind, oldObj, newObj, common.Now()) {
+			return
+		}
 		watchResultShell := &typed.KubeWatchResult{
-			Timestamp: ptypes.TimestampNow(),
-			Kind:      kind,
-			WatchType: typed.KubeWatchResult_UPDATE,
-			Payload:   "",
+			Timestamp:  common.TimestampNow(),
+			Kind:       kind,
+			WatchType:  typed.KubeWatchResult_UPDATE,
+			Payload:    "",
+			ApiVersion: apiVersion,
 		}
+		watchResultShell.OldPayload = i.getOldPayload(kind, oldObj, newObj)
 		i.processUpdate(kind, newObj, watchResultShell)
 	}
 }
 
+// The informer's copy of the resource before an update, or "" when it is the same version as the update, as it is
+// for resyncs.  Ingest drops it again when that version was stored
+func (i *kubeWatcherImpl) getOldPayload(kind string, oldObj interface{}, newObj interface{}) string {
+	oldMeta, oldErr := meta.Accessor(oldObj)
+	newMeta, newErr := meta.Accessor(newObj)
+	if oldErr == nil && newErr == nil && newMeta.GetResourceVersion() != "" && newMeta.GetResourceVersion() == oldMeta.GetResourceVersion() {
+		return ""
+	}
+	oldJson, err := i.getResourceAsJsonString(kind, oldObj)
+	if err != nil {
+		glog.V(2).Infof("Not keeping old object of %v update: %v", kind, err)
+		return ""
+	}
+	return oldJson
+}
+
 func (i *kubeWatcherImpl) processUpdate(kind string, obj interface{}, watchResult *typed.KubeWatchResult) {
 	resourceJson, err := i.getResourceAsJsonString(kind, obj)
 	if err != nil {
@@ -314,6 +406,10 @@ func (i *kubeWatcherImpl) processUpdate(kind string, obj interface{}, watchResul
 	metricIngressKubewatchbytes.WithLabelValues(kind, watchResult.WatchType.String(), kubeMetadata.Namespace).Add(float64(len(resourceJson)))
 
 	glog.V(common.GlogVerbose).Infof("Informer update (%s) - Name: %s, Namespace: %s, ResourceVersion: %s", watchResult.WatchType, kubeMetadata.Name, kubeMetadata.Namespace, kubeMetadata.ResourceVersion)
+	if filterName := i.ingestFilters.drop(kind, watchResult.WatchType, resourceJson); filterName != "" {
+		glog.V(common.GlogVerbose).Infof("Ingest filter %q dropped %v %v/%v", filterName, kind, kubeMetadata.Namespace, kubeMetadata.Name)
+		return
+	}
 	watchResult.Payload = resourceJson
 	i.writeToOutChan(watchResult)
 }
@@ -365,4 +461,5 @@ func (i *kubeWatcherImpl) Stop() {
 
 	close(i.stopChan)
 	stopUnwantedCrdInformers(i.pullCrdInformers())
+	setIngestAnnotationChan(nil)
 }
@@ -15,6 +15,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang/protobuf/ptypes"
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
 	"github.com/salesforce/sloop/pkg/sloop/store/typed"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
@@ -23,6 +26,7 @@ import (
 	clientsetFake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	discoveryFake "k8s.io/client-go/discovery/fake"
 	"k8s.io/client-go/dynamic/dynamicinformer"
 	dynamicFake "k8s.io/client-go/dynamic/fake"
 	kubernetesFake "k8s.io/client-go/kubernetes/fake"
@@ -63,6 +67,25 @@ func newTestCrdClient(reaction func(_ k8sTesting.Action) (bool, runtime.Object,
 	}
 }
 
+type fakeDiscovery struct {
+	discoveryFake.FakeDiscovery
+	resources []*metav1.APIResourceList
+}
+
+func (d *fakeDiscovery) ServerPreferredResources() ([]*metav1.APIResourceList, error) {
+	return d.resources, fmt.Errorf("unable to retrieve the complete list of server APIs")
+}
+
+func Test_registerClusterScopedKinds(t *testing.T) {
+	d := &fakeDiscovery{resources: []*metav1.APIResourceList{{
+		GroupVersion: "metrics.k8s.io/v1beta1",
+		APIResources: []metav1.APIResource{{Kind: "NodeMetrics", Namespaced: false}, {Kind: "PodMetrics", Namespaced: true}},
+	}}}
+	registerClusterScopedKinds(d)
+	assert.True(t, kubeextractor.IsClustersScopedResource("NodeMetrics"))
+	assert.False(t, kubeextractor.IsClustersScopedResource("PodMetrics"))
+}
+
 // This test (test-harness) exercises the kubewatcher from the client perspective
 // - start a kubewatcher
 // - force a k8s event in the system
@@ -77,7 +100,7 @@ func Test_bigPicture(t *testing.T) {
 	includeCrds := true
 	masterURL := "url"
 	kubeContext := "" // empty string makes things work
-	kw, err := NewKubeWatcherSource(kubeClient, outChan, resync, includeCrds, time.Duration(10*time.Second), masterURL, kubeContext)
+	kw, err := NewKubeWatcherSource(kubeClient, outChan, resync, includeCrds, time.Duration(10*time.Second), masterURL, kubeContext, nil, nil, nil, nil)
 	assert.NoError(t, err)
 
 	// create service and await corresponding event
@@ -107,10 +130,24 @@ func Test_getCrdList(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func Test_getCrdList_RegistersClusterScopedKinds(t *testing.T) {
+	reaction := func(_ k8sTesting.Action) (bool, runtime.Object, error) {
+		versions := []apiextensionsv1.CustomResourceDefinitionVersion{{Name: "v1"}}
+		name := apiextensionsv1.CustomResourceDefinitionNames{Plural: "clusterthings", Kind: "ClusterThing"}
+		spec := apiextensionsv1.CustomResourceDefinitionSpec{Group: "g", Versions: versions, Names: name, Scope: apiextensionsv1.ClusterScoped}
+		list := apiextensionsv1.CustomResourceDefinitionList{Items: []apiextensionsv1.CustomResourceDefinition{{Spec: spec}}}
+		return true, &list, nil
+	}
+	crdClient, _ := newTestCrdClient(reaction)(&rest.Config{})
+	_, err := getCrdList(crdClient)
+	assert.NoError(t, err)
+	assert.True(t, kubeextractor.IsClustersScopedResource("ClusterThing"))
+}
+
 func Test_getEventHandlerForResource(t *testing.T) {
 	kw := &kubeWatcherImpl{protection: &sync.Mutex{}}
 
-	handler, ok := kw.getEventHandlerForResource("k").(cache.ResourceEventHandlerFuncs)
+	handler, ok := kw.getEventHandlerForResource("k", "v1").(cache.ResourceEventHandlerFuncs)
 	assert.True(t, ok)
 	assert.NotNil(t, handler)
 	assert.NotNil(t, handler.AddFunc)
@@ -123,7 +160,7 @@ func Test_reportAdd(t *testing.T) {
 	kw := &kubeWatcherImpl{protection: &sync.Mutex{}, outchan: outChan}
 
 	kind := "a"
-	report := kw.reportAdd(kind)
+	report := kw.reportAdd(kind, "v1")
 	assert.NotNil(t, report)
 	obj := dummyData{Namespace: "n"}
 	bytes, err := json.Marshal(obj)
@@ -135,16 +172,30 @@ func Test_reportAdd(t *testing.T) {
 	assert.Equal(t, kind, result.Kind)
 	assert.Equal(t, typed.KubeWatchResult_ADD, result.WatchType)
 	assert.Equal(t, string(bytes), result.Payload)
+	assert.Equal(t, "v1", result.ApiVersion)
 
 	verifyChannelEmpty(t, outChan)
 }
 
+func Test_reportAdd_TimestampFromClock(t *testing.T) {
+	defer common.SetClock(common.SetClock(common.NewFrozenClock(somePlaybackTime)))
+	outChan := make(chan typed.KubeWatchResult, 5)
+	kw := &kubeWatcherImpl{protection: &sync.Mutex{}, outchan: outChan}
+
+	kw.reportAdd("a", "v1")(dummyData{Namespace: "n"})
+
+	result := <-outChan
+	ts, err := ptypes.Timestamp(result.Timestamp)
+	assert.Nil(t, err)
+	assert.Equal(t, somePlaybackTime, ts)
+}
+
 func Test_reportDelete(t *testing.T) {
 	outChan := make(chan typed.KubeWatchResult, 5)
 	kw := &kubeWatcherImpl{protection: &sync.Mutex{}, outchan: outChan}
 
 	kind := "d"
-	report := kw.reportDelete(kind)
+	report := kw.reportDelete(kind, "v1")
 	assert.NotNil(t, report)
 	obj := dummyData{Namespace: "n"}
 	bytes, err := json.Marshal(obj)
@@ -156,6 +207,7 @@ func Test_reportDelete(t *testing.T) {
 	assert.Equal(t, kind, result.Kind)
 	assert.Equal(t, typed.KubeWatchResult_DELETE, result.WatchType)
 	assert.Equal(t, string(bytes), result.Payload)
+	assert.Equal(t, typed.FinalStateWatch, result.FinalState)
 
 	deleteObj := cache.DeletedFinalStateUnknown{
 		Key: "object-key",
@@ -164,6 +216,7 @@ func Test_reportDelete(t *testing.T) {
 	report(deleteObj)
 	result = <-outChan
 	assert.Equal(t, string(bytes), result.Payload)
+	assert.Equal(t, typed.FinalStateCache, result.FinalState)
 
 	verifyChannelEmpty(t, outChan)
 }
@@ -173,12 +226,14 @@ func Test_reportUpdate(t *testing.T) {
 	kw := &kubeWatcherImpl{protection: &sync.Mutex{}, outchan: outChan}
 
 	kind := "d"
-	report := kw.reportUpdate(kind)
+	report := kw.reportUpdate(kind, "v1")
 	assert.NotNil(t, report)
 	prev := dummyData{Namespace: "p"}
 	new := dummyData{Namespace: "n"}
 	bytes, err := json.Marshal(new)
 	assert.Nil(t, err)
+	prevBytes, err := json.Marshal(prev)
+	assert.Nil(t, err)
 
 	report(prev, new)
 
@@ -186,6 +241,13 @@ func Test_reportUpdate(t *testing.T) {
 	assert.Equal(t, kind, result.Kind)
 	assert.Equal(t, typed.KubeWatchResult_UPDATE, result.WatchType)
 	assert.Equal(t, string(bytes), result.Payload)
+	assert.Equal(t, string(prevBytes), result.OldPayload)
+
+	// A resync of the same version has no old object worth keeping
+	resynced := &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: "a", ResourceVersion: "5"}}
+	report(resynced, resynced.DeepCopy())
+	result = <-outChan
+	assert.Equal(t, "", result.OldPayload)
 
 	verifyChannelEmpty(t, outChan)
 }
@@ -248,3 +310,12 @@ func Test_existingOrStartNewCrdInformer(t *testing.T) {
 		time.Sleep(time.Millisecond)
 	}
 }
+
+func Test_watchesKind(t *testing.T) {
+	kw := &kubeWatcherImpl{protection: &sync.Mutex{}}
+	assert.True(t, kw.watchesKind("Pod"))
+
+	kw.kindFilter = func(kind string) bool { return kind == "Node" }
+	assert.True(t, kw.watchesKind("Node"))
+	assert.False(t, kw.watchesKind("Pod"))
+}
@@ -0,0 +1,193 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package ingress
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+const (
+	ProbeConfigMapName = "sloop-probe"
+	probeDataKey       = "probe"
+	probePollInterval  = time.Second
+
+	probeOpCreate = "create"
+	probeOpUpdate = "update"
+	probeOpDelete = "delete"
+)
+
+var (
+	metricProbeFreshness    = promauto.NewGaugeVec(prometheus.GaugeOpts{Name: "sloop_probe_freshness_sec"}, []string{"op"})
+	metricProbeSlaMet       = promauto.NewGaugeVec(prometheus.GaugeOpts{Name: "sloop_probe_sla_met"}, []string{"op"})
+	metricProbeFailureCount = promauto.NewCounterVec(prometheus.CounterOpts{Name: "sloop_probe_failure_count"}, []string{"op"})
+)
+
+/*
+Checks the whole watch, store and query path end to end.  Every interval it creates, updates and deletes a tiny
+ConfigMap in its namespace and waits for each change to show up in the current state table that GetCurrentState
+reads.  How long that took is on sloop_probe_freshness_sec, and sloop_probe_sla_met is 0 when it took longer
+than the sla or never showed up.  The namespace and ConfigMaps must be watched, so ingest filters and shards have to
+keep them
+*/
+type Prober struct {
+	kubeClient kubernetes.Interface
+	tables     typed.Tables
+	namespace  string
+	interval   time.Duration
+	sla        time.Duration
+	done       chan bool
+	wg         *sync.WaitGroup
+}
+
+func NewProber(kubeClient kubernetes.Interface, tables typed.Tables, namespace string, interval time.Duration, sla time.Duration) *Prober {
+	return &Prober{kubeClient: kubeClient, tables: tables, namespace: namespace, interval: interval, sla: sla, done: make(chan bool), wg: &sync.WaitGroup{}}
+}
+
+func (p *Prober) Start() {
+	glog.Infof("Probing namespace %q every %v with an sla of %v", p.namespace, p.interval, p.sla)
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		for {
+			select {
+			case <-p.done:
+				return
+			case <-time.After(p.interval):
+			}
+			p.Probe()
+		}
+	}()
+}
+
+func (p *Prober) Stop() {
+	close(p.done)
+	p.wg.Wait()
+}
+
+// Runs one create, update and delete.  A leftover ConfigMap from a probe that was cut short is deleted first
+func (p *Prober) Probe() {
+	configMaps := p.kubeClient.CoreV1().ConfigMaps(p.namespace)
+	err := configMaps.Delete(ProbeConfigMapName, &metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		p.fail(probeOpDelete, errors.Wrap(err, "failed to delete leftover probe"))
+		return
+	}
+
+	marker := fmt.Sprint(time.Now().UnixNano())
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: ProbeConfigMapName, Labels: map[string]string{"app": "sloop-probe"}},
+		Data:       map[string]string{probeDataKey: marker},
+	}
+	written := time.Now()
+	configMap, err = configMaps.Create(configMap)
+	if err != nil {
+		p.fail(probeOpCreate, errors.Wrap(err, "failed to create probe"))
+		return
+	}
+	if !p.check(probeOpCreate, written, marker) {
+		return
+	}
+
+	marker = fmt.Sprint(time.Now().UnixNano())
+	configMap.Data = map[string]string{probeDataKey: marker}
+	written = time.Now()
+	_, err = configMaps.Update(configMap)
+	if err != nil {
+		p.fail(probeOpUpdate, errors.Wrap(err, "failed to update probe"))
+		return
+	}
+	if !p.check(probeOpUpdate, written, marker) {
+		return
+	}
+
+	written = time.Now()
+	err = configMaps.Delete(ProbeConfigMapName, &metav1.DeleteOptions{})
+	if err != nil {
+		p.fail(probeOpDelete, errors.Wrap(err, "failed to delete probe"))
+		return
+	}
+	p.check(probeOpDelete, written, "")
+}
+
+// Waits for the stored probe to have the marker, or to be gone when it is empty, and records how long it took.  It
+// keeps waiting past the sla so a slow path still gets its freshness, up to the probe interval
+func (p *Prober) check(op string, written time.Time, marker string) bool {
+	deadline := written.Add(p.interval)
+	if p.sla > p.interval {
+		deadline = written.Add(p.sla)
+	}
+	for {
+		seen, err := p.stored(marker)
+		if err != nil {
+			p.fail(op, err)
+			return false
+		}
+		if seen {
+			freshness := time.Since(written)
+			metricProbeFreshness.WithLabelValues(op).Set(freshness.Seconds())
+			if freshness > p.sla {
+				metricProbeSlaMet.WithLabelValues(op).Set(0)
+				glog.Warningf("Probe %v took %v to show up in the store, more than the sla of %v", op, freshness, p.sla)
+			} else {
+				metricProbeSlaMet.WithLabelValues(op).Set(1)
+			}
+			return true
+		}
+		if time.Now().After(deadline) {
+			p.fail(op, fmt.Errorf("probe %v did not show up in the store after %v", op, time.Since(written)))
+			return false
+		}
+		select {
+		case <-p.done:
+			return false
+		case <-time.After(probePollInterval):
+		}
+	}
+}
+
+func (p *Prober) stored(marker string) (bool, error) {
+	var state *typed.CurrentState
+	err := p.tables.Db().View(func(txn badgerwrap.Txn) error {
+		var err2 error
+		_, state, err2 = p.tables.CurrentStateTable().Find(txn, "ConfigMap", p.namespace, ProbeConfigMapName)
+		return err2
+	})
+	if err != nil {
+		return false, errors.Wrap(err, "failed to read probe from the store")
+	}
+	if marker == "" || state == nil {
+		return marker == "" && state == nil, nil
+	}
+	stored := corev1.ConfigMap{}
+	err = json.Unmarshal([]byte(state.Payload), &stored)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to parse stored probe")
+	}
+	return stored.Data[probeDataKey] == marker, nil
+}
+
+func (p *Prober) fail(op string, err error) {
+	glog.Errorf("Probe %v failed: %v", op, err)
+	metricProbeFailureCount.WithLabelValues(op).Inc()
+	metricProbeSlaMet.WithLabelValues(op).Set(0)
+}
@@ -0,0 +1,88 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package ingress
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubernetesFake "k8s.io/client-go/kubernetes/fake"
+	k8sTesting "k8s.io/client-go/testing"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+// Stands in for the watch, storing every write to the probe as its current state right away
+func helper_probeStoreReactor(t *testing.T, tables typed.Tables) k8sTesting.ReactionFunc {
+	return func(action k8sTesting.Action) (bool, runtime.Object, error) {
+		return false, nil, tables.Db().Update(func(txn badgerwrap.Txn) error {
+			key := typed.NewCurrentStateKey(untyped.GetPartitionId(time.Now()), "ConfigMap", "probe-ns", ProbeConfigMapName, "uid")
+			if action.GetVerb() == "delete" {
+				return txn.Delete([]byte(key.String()))
+			}
+			configMap := action.(k8sTesting.CreateAction).GetObject().(*corev1.ConfigMap)
+			payload, err := json.Marshal(configMap)
+			assert.Nil(t, err)
+			return tables.CurrentStateTable().Set(txn, key.String(), &typed.CurrentState{Timestamp: ptypes.TimestampNow(), Payload: string(payload)})
+		})
+	}
+}
+
+func helper_probeTables(t *testing.T) typed.Tables {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	return typed.NewTableList(db)
+}
+
+func Test_Prober_Probe(t *testing.T) {
+	tables := helper_probeTables(t)
+	kubeClient := kubernetesFake.NewSimpleClientset()
+	for _, verb := range []string{"create", "update", "delete"} {
+		kubeClient.PrependReactor(verb, "configmaps", helper_probeStoreReactor(t, tables))
+	}
+	failuresBefore := testutil.ToFloat64(metricProbeFailureCount.WithLabelValues(probeOpUpdate))
+
+	prober := NewProber(kubeClient, tables, "probe-ns", time.Minute, 10*time.Second)
+	prober.Probe()
+
+	for _, op := range []string{probeOpCreate, probeOpUpdate, probeOpDelete} {
+		assert.Equal(t, float64(1), testutil.ToFloat64(metricProbeSlaMet.WithLabelValues(op)), op)
+		assert.True(t, testutil.ToFloat64(metricProbeFreshness.WithLabelValues(op)) < 10, op)
+	}
+	assert.Equal(t, failuresBefore, testutil.ToFloat64(metricProbeFailureCount.WithLabelValues(probeOpUpdate)))
+	_, err := kubeClient.CoreV1().ConfigMaps("probe-ns").Get(ProbeConfigMapName, metav1.GetOptions{})
+	assert.NotNil(t, err)
+}
+
+func Test_Prober_NotStored(t *testing.T) {
+	tables := helper_probeTables(t)
+	kubeClient := kubernetesFake.NewSimpleClientset()
+	failuresBefore := testutil.ToFloat64(metricProbeFailureCount.WithLabelValues(probeOpCreate))
+
+	// Nothing watches the namespace, so the create never shows up
+	prober := NewProber(kubeClient, tables, "probe-ns", time.Millisecond, time.Millisecond)
+	prober.Probe()
+
+	assert.Equal(t, failuresBefore+1, testutil.ToFloat64(metricProbeFailureCount.WithLabelValues(probeOpCreate)))
+	assert.Equal(t, float64(0), testutil.ToFloat64(metricProbeSlaMet.WithLabelValues(probeOpCreate)))
+
+	// The leftover is deleted by the next probe
+	prober.Probe()
+	assert.Equal(t, failuresBefore+2, testutil.ToFloat64(metricProbeFailureCount.WithLabelValues(probeOpCreate)))
+}
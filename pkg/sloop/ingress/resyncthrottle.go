@@ -0,0 +1,98 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package ingress
+
+import (
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"k8s.io/apimachinery/pkg/api/meta"
+)
+
+var metricIngressResyncDroppedCount = promauto.NewCounterVec(prometheus.CounterOpts{Name: "sloop_ingress_resync_dropped_count"}, []string{"kind"})
+
+/*
+Stretches the resync interval of some kinds past the one of the informers, which is the same for every kind and can
+not change while they run.  Resyncs are the updates where the resourceVersion did not change.  With an interval of n
+times the informer resync, each object keeps one resync round in n, picked by its uid so the resyncs of a kind stay
+spread over the rounds.  Intervals can change at any time, and all of them are stretched while sloop is idle
+*/
+type ResyncThrottle struct {
+	base      time.Duration
+	lock      *sync.RWMutex
+	intervals map[string]time.Duration
+	// Set by idle.Monitor, 1 or less when not idle
+	idleSlowdown int
+}
+
+// base is the resync interval of the informers
+func NewResyncThrottle(base time.Duration) *ResyncThrottle {
+	return &ResyncThrottle{base: base, lock: &sync.RWMutex{}, intervals: map[string]time.Duration{}, idleSlowdown: 1}
+}
+
+// Intervals up to the informer resync keep every resync
+func (t *ResyncThrottle) SetResyncInterval(kind string, interval time.Duration) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.intervals[kind] = interval
+}
+
+// Multiplies the resync interval of every kind by factor
+func (t *ResyncThrottle) SetIdleSlowdown(factor int) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.idleSlowdown = factor
+}
+
+// The effective resync interval of a kind, which is never shorter than the informer resync
+func (t *ResyncThrottle) ResyncInterval(kind string) time.Duration {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	interval := t.intervals[kind]
+	if interval < t.base {
+		interval = t.base
+	}
+	if t.idleSlowdown > 1 {
+		interval *= time.Duration(t.idleSlowdown)
+	}
+	return interval
+}
+
+// Returns false for a resync of oldObj to newObj that is not due at now
+func (t *ResyncThrottle) keepUpdate(kind string, oldObj interface{}, newObj interface{}, now time.Time) bool {
+	if t == nil || t.base <= 0 {
+		return true
+	}
+	oldMeta, err := meta.Accessor(oldObj)
+	if err != nil {
+		return true
+	}
+	newMeta, err := meta.Accessor(newObj)
+	if err != nil || newMeta.GetResourceVersion() == "" || newMeta.GetResourceVersion() != oldMeta.GetResourceVersion() {
+		return true
+	}
+	if t.keep(kind, string(newMeta.GetUID()), now) {
+		return true
+	}
+	metricIngressResyncDroppedCount.WithLabelValues(kind).Inc()
+	return false
+}
+
+func (t *ResyncThrottle) keep(kind string, uid string, now time.Time) bool {
+	rounds := int64(t.ResyncInterval(kind) / t.base)
+	if rounds <= 1 {
+		return true
+	}
+	hash := fnv.New64a()
+	hash.Write([]byte(uid))
+	round := now.UnixNano() / int64(t.base)
+	return (int64(hash.Sum64()%uint64(rounds))+round)%rounds == 0
+}
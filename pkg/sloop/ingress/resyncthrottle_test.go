@@ -0,0 +1,72 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package ingress
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func Test_ResyncThrottle_keep(t *testing.T) {
+	throttle := NewResyncThrottle(time.Minute)
+	throttle.SetResyncInterval("Pod", 4*time.Minute)
+	assert.Equal(t, 4*time.Minute, throttle.ResyncInterval("Pod"))
+	assert.Equal(t, time.Minute, throttle.ResyncInterval("Node"))
+
+	// Each object keeps one round in four
+	start := time.Unix(0, 0)
+	for i := 0; i < 10; i++ {
+		uid := fmt.Sprintf("uid%v", i)
+		kept := 0
+		for round := 0; round < 8; round++ {
+			if throttle.keep("Pod", uid, start.Add(time.Duration(round)*time.Minute)) {
+				kept++
+			}
+		}
+		assert.Equal(t, 2, kept)
+		assert.True(t, throttle.keep("Node", uid, start))
+	}
+}
+
+func Test_ResyncThrottle_keepUpdate(t *testing.T) {
+	throttle := NewResyncThrottle(time.Minute)
+	throttle.SetResyncInterval("Pod", time.Hour)
+	pod := func(resourceVersion string) *v1.Pod {
+		return &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "p", UID: types.UID("uid"), ResourceVersion: resourceVersion}}
+	}
+
+	kept := 0
+	for round := 0; round < 60; round++ {
+		if throttle.keepUpdate("Pod", pod("1"), pod("1"), time.Unix(int64(round)*60, 0)) {
+			kept++
+		}
+	}
+	assert.Equal(t, 1, kept)
+	assert.True(t, throttle.keepUpdate("Pod", pod("1"), pod("2"), time.Unix(30, 0)))
+
+	var nilThrottle *ResyncThrottle
+	assert.True(t, nilThrottle.keepUpdate("Pod", pod("1"), pod("1"), time.Unix(30, 0)))
+}
+
+func Test_ResyncThrottle_SetIdleSlowdown(t *testing.T) {
+	throttle := NewResyncThrottle(time.Minute)
+	throttle.SetResyncInterval("Pod", 4*time.Minute)
+	throttle.SetIdleSlowdown(3)
+	assert.Equal(t, 12*time.Minute, throttle.ResyncInterval("Pod"))
+	assert.Equal(t, 3*time.Minute, throttle.ResyncInterval("Node"))
+
+	throttle.SetIdleSlowdown(1)
+	assert.Equal(t, 4*time.Minute, throttle.ResyncInterval("Pod"))
+	assert.Equal(t, time.Minute, throttle.ResyncInterval("Node"))
+}
@@ -0,0 +1,83 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package kubeextractor
+
+import (
+	"encoding/json"
+)
+
+const ApiServiceAvailableCondition = "Available"
+
+type ApiService struct {
+	Group   string
+	Version string
+	// Namespace/name of the service behind an aggregated API, empty for APIs the api server serves itself
+	Service string
+	// Nil when the aggregator did not report it yet
+	Available *NodeCondition
+}
+
+// The api version of the resources the APIService serves, like metrics.k8s.io/v1beta1
+func (a ApiService) GroupVersion() string {
+	if a.Group == "" {
+		return a.Version
+	}
+	return a.Group + "/" + a.Version
+}
+
+// Extracts the served group version, the backing service and the Available condition from an APIService payload
+func ExtractApiService(payload string) (ApiService, error) {
+	resource := struct {
+		Spec struct {
+			Group   string `json:"group"`
+			Version string `json:"version"`
+			Service *struct {
+				Namespace string `json:"namespace"`
+				Name      string `json:"name"`
+			} `json:"service"`
+		} `json:"spec"`
+		Status struct {
+			Conditions []NodeCondition `json:"conditions"`
+		} `json:"status"`
+	}{}
+	err := json.Unmarshal([]byte(payload), &resource)
+	if err != nil {
+		return ApiService{}, err
+	}
+	apiService := ApiService{Group: resource.Spec.Group, Version: resource.Spec.Version}
+	if resource.Spec.Service != nil {
+		apiService.Service = resource.Spec.Service.Namespace + "/" + resource.Spec.Service.Name
+	}
+	for idx, condition := range resource.Status.Conditions {
+		if condition.Type == ApiServiceAvailableCondition {
+			apiService.Available = &resource.Status.Conditions[idx]
+		}
+	}
+	return apiService, nil
+}
+
+// Extracts the api version of the object an event is about, from involvedObject or from regarding for events of
+// the events.k8s.io API
+func ExtractEventObjectApiVersion(payload string) (string, error) {
+	resource := struct {
+		InvolvedObject struct {
+			ApiVersion string `json:"apiVersion"`
+		} `json:"involvedObject"`
+		Regarding struct {
+			ApiVersion string `json:"apiVersion"`
+		} `json:"regarding"`
+	}{}
+	err := json.Unmarshal([]byte(payload), &resource)
+	if err != nil {
+		return "", err
+	}
+	if resource.InvolvedObject.ApiVersion != "" {
+		return resource.InvolvedObject.ApiVersion, nil
+	}
+	return resource.Regarding.ApiVersion, nil
+}
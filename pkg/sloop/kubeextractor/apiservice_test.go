@@ -0,0 +1,40 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package kubeextractor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ExtractApiService(t *testing.T) {
+	apiService, err := ExtractApiService(`{"spec":{"group":"metrics.k8s.io","version":"v1beta1","service":{"namespace":"kube-system","name":"metrics-server"}},
+"status":{"conditions":[{"type":"Available","status":"False","reason":"FailedDiscoveryCheck","message":"failing or missing response"}]}}`)
+	assert.Nil(t, err)
+	assert.Equal(t, "metrics.k8s.io/v1beta1", apiService.GroupVersion())
+	assert.Equal(t, "kube-system/metrics-server", apiService.Service)
+	assert.Equal(t, "False", apiService.Available.Status)
+	assert.Equal(t, "FailedDiscoveryCheck", apiService.Available.Reason)
+
+	// Served by the api server itself, before the aggregator reported on it
+	apiService, err = ExtractApiService(`{"spec":{"version":"v1"}}`)
+	assert.Nil(t, err)
+	assert.Equal(t, "v1", apiService.GroupVersion())
+	assert.Equal(t, "", apiService.Service)
+	assert.Nil(t, apiService.Available)
+}
+
+func Test_ExtractEventObjectApiVersion(t *testing.T) {
+	apiVersion, err := ExtractEventObjectApiVersion(`{"involvedObject":{"kind":"HorizontalPodAutoscaler","apiVersion":"autoscaling/v2beta2"}}`)
+	assert.Nil(t, err)
+	assert.Equal(t, "autoscaling/v2beta2", apiVersion)
+	apiVersion, err = ExtractEventObjectApiVersion(`{"regarding":{"kind":"PodMetrics","apiVersion":"metrics.k8s.io/v1beta1"}}`)
+	assert.Nil(t, err)
+	assert.Equal(t, "metrics.k8s.io/v1beta1", apiVersion)
+}
@@ -0,0 +1,114 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package kubeextractor
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// These change on every update and say nothing about what changed
+var ignoredChangedPaths = map[string]bool{
+	"metadata.resourceVersion": true,
+	"metadata.managedFields":   true,
+	"metadata.generation":      true,
+}
+
+var plainJsonKey = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Returns the JSON paths that differ between two payloads, like spec.replicas or
+// spec.template.spec.containers[0].image, sorted.  Objects are compared key by key and arrays of the same length
+// element by element, anything else that differs is reported at its own path.
+func ComputeChangedPaths(prevPayload string, newPayload string) ([]string, error) {
+	paths := []string{}
+	err := walkChangedPaths(prevPayload, newPayload, func(path string, prev interface{}, cur interface{}) {
+		paths = append(paths, path)
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// Like ComputeChangedPaths, but returns the value of each changed path after the change as JSON, which is null when
+// it was removed
+func ComputeChangedPathValues(prevPayload string, newPayload string) (map[string]string, error) {
+	values := map[string]string{}
+	err := walkChangedPaths(prevPayload, newPayload, func(path string, prev interface{}, cur interface{}) {
+		bytes, _ := json.Marshal(cur)
+		values[path] = string(bytes)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+func walkChangedPaths(prevPayload string, newPayload string, changed func(path string, prev interface{}, cur interface{})) error {
+	var prev, cur interface{}
+	err := json.Unmarshal([]byte(prevPayload), &prev)
+	if err != nil {
+		return errors.Wrap(err, "Could not parse previous payload")
+	}
+	err = json.Unmarshal([]byte(newPayload), &cur)
+	if err != nil {
+		return errors.Wrap(err, "Could not parse new payload")
+	}
+	collectChangedPaths("", prev, cur, changed)
+	return nil
+}
+
+func collectChangedPaths(path string, prev interface{}, cur interface{}, changed func(path string, prev interface{}, cur interface{})) {
+	if ignoredChangedPaths[path] {
+		return
+	}
+	prevMap, prevIsMap := prev.(map[string]interface{})
+	curMap, curIsMap := cur.(map[string]interface{})
+	if prevIsMap && curIsMap {
+		keys := map[string]bool{}
+		for key := range prevMap {
+			keys[key] = true
+		}
+		for key := range curMap {
+			keys[key] = true
+		}
+		for key := range keys {
+			collectChangedPaths(joinJsonPath(path, key), prevMap[key], curMap[key], changed)
+		}
+		return
+	}
+
+	prevList, prevIsList := prev.([]interface{})
+	curList, curIsList := cur.([]interface{})
+	if prevIsList && curIsList && len(prevList) == len(curList) {
+		for idx := range prevList {
+			collectChangedPaths(fmt.Sprintf("%v[%v]", path, idx), prevList[idx], curList[idx], changed)
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(prev, cur) {
+		changed(path, prev, cur)
+	}
+}
+
+func joinJsonPath(path string, key string) string {
+	if !plainJsonKey.MatchString(key) {
+		return fmt.Sprintf("%v[%q]", path, key)
+	}
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
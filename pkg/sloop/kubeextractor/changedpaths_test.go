@@ -0,0 +1,51 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package kubeextractor
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_ComputeChangedPaths_OutputCorrect(t *testing.T) {
+	prev := `{"metadata":{"name":"d","resourceVersion":"1","labels":{"app.kubernetes.io/name":"a"}},
+"spec":{"replicas":1,"template":{"spec":{"containers":[{"name":"app","image":"app:v1"}]}}}}`
+	cur := `{"metadata":{"name":"d","resourceVersion":"2","labels":{"app.kubernetes.io/name":"b"}},
+"spec":{"replicas":3,"paused":true,"template":{"spec":{"containers":[{"name":"app","image":"app:v2"}]}}}}`
+	paths, err := ComputeChangedPaths(prev, cur)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		`metadata.labels["app.kubernetes.io/name"]`,
+		"spec.paused",
+		"spec.replicas",
+		"spec.template.spec.containers[0].image",
+	}, paths)
+}
+
+func Test_ComputeChangedPaths_ListLengthChange(t *testing.T) {
+	paths, err := ComputeChangedPaths(`{"spec":{"ports":[1]}}`, `{"spec":{"ports":[1,2]}}`)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"spec.ports"}, paths)
+}
+
+func Test_ComputeChangedPaths_NoChange(t *testing.T) {
+	paths, err := ComputeChangedPaths(`{"metadata":{"resourceVersion":"1"}}`, `{"metadata":{"resourceVersion":"2"}}`)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{}, paths)
+}
+
+func Test_ComputeChangedPaths_InvalidPayload_ReturnsError(t *testing.T) {
+	_, err := ComputeChangedPaths(`{}`, `{"spec":`)
+	assert.NotNil(t, err)
+}
+
+func Test_ComputeChangedPathValues(t *testing.T) {
+	values, err := ComputeChangedPathValues(`{"spec":{"replicas":1,"paused":true,"ports":[1]}}`, `{"spec":{"replicas":3,"ports":[1,2]}}`)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"spec.replicas": "3", "spec.paused": "null", "spec.ports": "[1,2]"}, values)
+}
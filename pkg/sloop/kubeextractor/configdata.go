@@ -0,0 +1,32 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package kubeextractor
+
+import (
+	"encoding/json"
+)
+
+// Returns the data and binaryData of a ConfigMap or Secret payload in a stable form, so two versions compare equal
+// when only their metadata changed
+func ExtractConfigData(payload string) (string, error) {
+	resource := struct {
+		Data       map[string]string `json:"data"`
+		BinaryData map[string]string `json:"binaryData"`
+		StringData map[string]string `json:"stringData"`
+	}{}
+	err := json.Unmarshal([]byte(payload), &resource)
+	if err != nil {
+		return "", err
+	}
+	// Maps are marshalled with sorted keys
+	data, err := json.Marshal(resource)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
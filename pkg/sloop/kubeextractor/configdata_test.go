@@ -0,0 +1,29 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package kubeextractor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ExtractConfigData_IgnoresMetadata(t *testing.T) {
+	a, err := ExtractConfigData(`{"metadata": {"resourceVersion": "1"}, "data": {"b": "2", "a": "1"}}`)
+	assert.Nil(t, err)
+	b, err := ExtractConfigData(`{"metadata": {"resourceVersion": "2", "labels": {"x": "y"}}, "data": {"a": "1", "b": "2"}}`)
+	assert.Nil(t, err)
+	assert.Equal(t, a, b)
+
+	c, err := ExtractConfigData(`{"data": {"a": "1", "b": "3"}}`)
+	assert.Nil(t, err)
+	assert.NotEqual(t, a, c)
+
+	_, err = ExtractConfigData(`{"data":`)
+	assert.NotNil(t, err)
+}
@@ -65,6 +65,47 @@ func ExtractInvolvedObject(payload string) (KubeInvolvedObject, error) {
 	return resource.InvolvedObject, nil
 }
 
+/*
+Extracts the object an Event is about.  Events of the events.k8s.io API name it in regarding instead of
+involvedObject.  When the payload names neither, the name comes from the event name, which starts with the name of
+the object, and the namespace is that of the event.  Returns an empty object for other kinds
+*/
+func ExtractEventInvolvedObject(kind string, metadata KubeMetadata, payload string) (KubeInvolvedObject, error) {
+	if kind != EventKind {
+		return KubeInvolvedObject{}, nil
+	}
+	resource := struct {
+		InvolvedObject KubeInvolvedObject
+		Regarding      KubeInvolvedObject
+	}{}
+	err := json.Unmarshal([]byte(payload), &resource)
+	if err != nil {
+		return KubeInvolvedObject{}, err
+	}
+	involvedObject := resource.InvolvedObject
+	if involvedObject.Kind == "" && involvedObject.Name == "" {
+		involvedObject = resource.Regarding
+	}
+	if involvedObject.Name == "" {
+		involvedObject.Name, _ = GetInvolvedObjectNameFromEventName(metadata.Name)
+	}
+	if involvedObject.Namespace == "" && !IsClustersScopedResource(involvedObject.Kind) {
+		involvedObject.Namespace = metadata.Namespace
+	}
+	return involvedObject, nil
+}
+
+func ExtractEventMessage(payload string) (string, error) {
+	event := struct {
+		Message string `json:"message"`
+	}{}
+	err := json.Unmarshal([]byte(payload), &event)
+	if err != nil {
+		return "", err
+	}
+	return event.Message, nil
+}
+
 type EventInfo struct {
 	Reason         string    `json:"reason"`
 	Type           string    `json:"type"`
@@ -124,10 +165,3 @@ func GetInvolvedObjectNameFromEventName(eventName string) (string, error) {
 	}
 	return eventName[0:dotIdx], nil
 }
-
-func IsClustersScopedResource(selectedKind string) bool {
-	if selectedKind == NodeKind || selectedKind == NamespaceKind {
-		return true
-	}
-	return false
-}
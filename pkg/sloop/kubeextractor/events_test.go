@@ -102,3 +102,23 @@ func Test_IsClustersScopedResource_False(t *testing.T) {
 	res := IsClustersScopedResource(selectedKind)
 	assert.False(t, res)
 }
+
+func Test_ExtractEventInvolvedObject(t *testing.T) {
+	metadata := KubeMetadata{Name: "name1.15c37e2c4b7ff38e", Namespace: "namespace1"}
+	result, err := ExtractEventInvolvedObject(EventKind, metadata, `{"involvedObject":{"kind":"Pod","namespace":"namespace1","name":"name1","uid":"uid1"}}`)
+	assert.Nil(t, err)
+	assert.Equal(t, KubeInvolvedObject{Kind: "Pod", Name: "name1", Namespace: "namespace1", Uid: "uid1"}, result)
+
+	// events.k8s.io events
+	result, err = ExtractEventInvolvedObject(EventKind, metadata, `{"regarding":{"kind":"Pod","namespace":"namespace1","name":"name1","uid":"uid1"}}`)
+	assert.Nil(t, err)
+	assert.Equal(t, KubeInvolvedObject{Kind: "Pod", Name: "name1", Namespace: "namespace1", Uid: "uid1"}, result)
+
+	result, err = ExtractEventInvolvedObject(EventKind, metadata, `{"involvedObject":{"kind":"Pod"}}`)
+	assert.Nil(t, err)
+	assert.Equal(t, KubeInvolvedObject{Kind: "Pod", Name: "name1", Namespace: "namespace1"}, result)
+
+	result, err = ExtractEventInvolvedObject(PodKind, metadata, `{"involvedObject":{"kind":"Pod"}}`)
+	assert.Nil(t, err)
+	assert.Equal(t, KubeInvolvedObject{}, result)
+}
@@ -0,0 +1,67 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package kubeextractor
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Characters of the random suffixes the API server and controllers add to generated names
+const generatedNameAlphabet = "bcdfghjklmnpqrstvwxz2456789"
+
+type messagePattern struct {
+	re          *regexp.Regexp
+	replacement string
+}
+
+// Applied in order, so the more specific patterns come before the ones for plain numbers
+var eventMessagePatterns = []messagePattern{
+	{regexp.MustCompile(`\b[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}\b`), "<uuid>"},
+	{regexp.MustCompile(`sha256:[0-9a-f]{64}`), "<digest>"},
+	{regexp.MustCompile(`\b\d{1,3}(\.\d{1,3}){3}(:\d+)?\b`), "<ip>"},
+	{regexp.MustCompile(`\b([0-9a-fA-F]{1,4}:){3,7}[0-9a-fA-F]{1,4}\b`), "<ip>"},
+	// Pods of ReplicaSets (name-hash-suffix), of other controllers (name-suffix) and ReplicaSets (name-hash)
+	{regexp.MustCompile(`\b([a-z0-9][-a-z0-9]*?[a-z0-9])-(?:[` + generatedNameAlphabet + `]{6,10}-[` + generatedNameAlphabet + `]{5}|[` + generatedNameAlphabet + `]{5,10})([^-a-z0-9]|$)`), "$1-<hash>$2"},
+	{regexp.MustCompile(`\b[0-9a-f]{12,}\b`), "<hex>"},
+	{regexp.MustCompile(`\b\d+(\.\d+)?(ns|us|µs|ms|s|m|h)(\d+(\.\d+)?(ns|us|µs|ms|s|m|h))*\b`), "<duration>"},
+	{regexp.MustCompile(`\b\d+(\.\d+)?([KMGTPE]i|[kMGTPE])?\b`), "<n>"},
+}
+
+/*
+Collapses the variable parts of an event message into placeholders, so the events of all pods of a workload, or of
+all retries, share one message template.  For example
+
+	Back-off pulling image "web@sha256:..." for pod web-7d9f8c6b5-x2k4q on 10.0.3.4
+	Back-off pulling image "web@<digest>" for pod web-<hash> on <ip>
+
+Words made only of the characters of generated names can be taken for a generated suffix, which just puts a few more
+messages into the same template
+*/
+func NormalizeEventMessage(message string) string {
+	normalized := strings.TrimSpace(message)
+	for _, pattern := range eventMessagePatterns {
+		normalized = pattern.re.ReplaceAllString(normalized, pattern.replacement)
+	}
+	return normalized
+}
+
+// Makes reasons written as words, like "back-off" or "failed mount", look like the usual CamelCase ones
+func NormalizeEventReason(reason string) string {
+	words := strings.FieldsFunc(reason, func(r rune) bool {
+		return unicode.IsSpace(r) || r == '-' || r == '_'
+	})
+	normalized := ""
+	for _, word := range words {
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		normalized += string(runes)
+	}
+	return normalized
+}
@@ -0,0 +1,43 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package kubeextractor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NormalizeEventMessage(t *testing.T) {
+	for message, expected := range map[string]string{
+		"Back-off restarting failed container app in pod web-7d9f8c6b5-x2k4q_payments(0f1c2d3e-1111-2222-3333-444455556666)":         "Back-off restarting failed container app in pod web-<hash>_payments(<uuid>)",
+		`Successfully pulled image "web@sha256:` + "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef" + `" in 1.52s`: `Successfully pulled image "web@<digest>" in <duration>`,
+		"Readiness probe failed: Get http://10.2.3.4:8080/healthz: dial tcp 10.2.3.4:8080: connect: connection refused":              "Readiness probe failed: Get http://<ip>/healthz: dial tcp <ip>: connect: connection refused",
+		"Created pod: fluentd-x2k4q":                                              "Created pod: fluentd-<hash>",
+		"Scaled up replica set web-7d9f8c6b5 to 3":                                "Scaled up replica set web-<hash> to <n>",
+		"Container image already present on machine":                              "Container image already present on machine",
+		"Memory cgroup out of memory: killed 24117 (app)":                         "Memory cgroup out of memory: killed <n> (app)",
+		"0/12 nodes are available: 3 Insufficient memory":                         "<n>/<n> nodes are available: <n> Insufficient memory",
+		"Killing container with id docker://6a8c1f2e9b3d4f5a: need to kill pod":   "Killing container with id docker://<hex>: need to kill pod",
+		"Requested 512Mi, limit 2Gi, took 2m30s on fe80:0:0:0:202:b3ff:fe1e:8329": "Requested <n>, limit <n>, took <duration> on <ip>",
+		"Started container kube-proxy":                                            "Started container kube-proxy",
+	} {
+		assert.Equal(t, expected, NormalizeEventMessage(message), message)
+	}
+	// The same problem on two pods of a workload gives one template
+	assert.Equal(t, NormalizeEventMessage("Liveness probe failed for pod api-5c6b7d8f9-x2k4q after 3 tries"),
+		NormalizeEventMessage("Liveness probe failed for pod api-5c6b7d8f9-zq8rt after 5 tries"))
+}
+
+func Test_NormalizeEventReason(t *testing.T) {
+	assert.Equal(t, "BackOff", NormalizeEventReason("BackOff"))
+	assert.Equal(t, "BackOff", NormalizeEventReason("back-off"))
+	assert.Equal(t, "FailedMount", NormalizeEventReason(" failed mount "))
+	assert.Equal(t, "NodeNotReady", NormalizeEventReason("node_not_ready"))
+	assert.Equal(t, "", NormalizeEventReason(""))
+}
@@ -0,0 +1,50 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package kubeextractor
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Returns when the change in the payload happened according to the cluster: the eventTime or lastTimestamp of an
+// Event, or the latest managedFields time of any other resource.  ok is false when the payload has neither
+func ExtractEventTime(kind string, payload string) (time.Time, bool) {
+	resource := struct {
+		EventTime     string `json:"eventTime"`
+		LastTimestamp string `json:"lastTimestamp"`
+		Metadata      struct {
+			ManagedFields []struct {
+				Time string `json:"time"`
+			} `json:"managedFields"`
+		} `json:"metadata"`
+	}{}
+	err := json.Unmarshal([]byte(payload), &resource)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	if kind == EventKind {
+		for _, value := range []string{resource.EventTime, resource.LastTimestamp} {
+			ts, err := time.Parse(time.RFC3339Nano, value)
+			if err == nil {
+				return ts, true
+			}
+		}
+		return time.Time{}, false
+	}
+
+	var latest time.Time
+	for _, field := range resource.Metadata.ManagedFields {
+		ts, err := time.Parse(time.RFC3339Nano, field.Time)
+		if err == nil && ts.After(latest) {
+			latest = ts
+		}
+	}
+	return latest, !latest.IsZero()
+}
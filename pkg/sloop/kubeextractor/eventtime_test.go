@@ -0,0 +1,40 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package kubeextractor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ExtractEventTime_Event(t *testing.T) {
+	ts, ok := ExtractEventTime(EventKind, `{"eventTime":null,"lastTimestamp":"2019-03-04T03:04:05Z"}`)
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2019, 3, 4, 3, 4, 5, 0, time.UTC), ts)
+
+	ts, ok = ExtractEventTime(EventKind, `{"eventTime":"2019-03-04T03:04:05.123456Z","lastTimestamp":"2019-03-04T03:00:00Z"}`)
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2019, 3, 4, 3, 4, 5, 123456000, time.UTC), ts)
+
+	_, ok = ExtractEventTime(EventKind, `{"lastTimestamp":null}`)
+	assert.False(t, ok)
+}
+
+func Test_ExtractEventTime_ManagedFields(t *testing.T) {
+	payload := `{"metadata":{"managedFields":[{"manager":"kubelet","time":"2019-03-04T03:04:05Z"},{"manager":"kube-scheduler","time":"2019-03-04T03:10:00Z"},{"manager":"x"}]}}`
+	ts, ok := ExtractEventTime("Pod", payload)
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2019, 3, 4, 3, 10, 0, 0, time.UTC), ts)
+
+	_, ok = ExtractEventTime("Pod", `{"metadata":{"name":"somepod"}}`)
+	assert.False(t, ok)
+	_, ok = ExtractEventTime("Pod", `not json`)
+	assert.False(t, ok)
+}
@@ -0,0 +1,69 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package kubeextractor
+
+import (
+	"sync"
+)
+
+// Kubernetes creates events about cluster scoped resources in this namespace
+const ClusterScopedEventNamespace = "default"
+
+// Kinds that have no namespace.  The built in ones are listed here, CRDs and kinds served by aggregated APIs are added
+// when they are discovered at runtime
+var (
+	clusterScopedKindsLock = &sync.RWMutex{}
+	clusterScopedKinds     = map[string]bool{
+		NodeKind:                         true,
+		NamespaceKind:                    true,
+		"PersistentVolume":               true,
+		"StorageClass":                   true,
+		"ClusterRole":                    true,
+		"ClusterRoleBinding":             true,
+		"CustomResourceDefinition":       true,
+		"APIService":                     true,
+		"PriorityClass":                  true,
+		"RuntimeClass":                   true,
+		"IngressClass":                   true,
+		"CSIDriver":                      true,
+		"CSINode":                        true,
+		"VolumeAttachment":               true,
+		"CertificateSigningRequest":      true,
+		"PodSecurityPolicy":              true,
+		"MutatingWebhookConfiguration":   true,
+		"ValidatingWebhookConfiguration": true,
+	}
+)
+
+func RegisterClusterScopedKind(kind string) {
+	clusterScopedKindsLock.Lock()
+	defer clusterScopedKindsLock.Unlock()
+	clusterScopedKinds[kind] = true
+}
+
+func IsClustersScopedResource(selectedKind string) bool {
+	clusterScopedKindsLock.RLock()
+	defer clusterScopedKindsLock.RUnlock()
+	return clusterScopedKinds[selectedKind]
+}
+
+// Returns the namespace a resource is stored under, which is always empty for cluster scoped kinds
+func NormalizeNamespace(kind string, namespace string) string {
+	if IsClustersScopedResource(kind) {
+		return ""
+	}
+	return namespace
+}
+
+// Returns the namespace of the events about a resource
+func GetEventNamespace(kind string, namespace string) string {
+	if IsClustersScopedResource(kind) {
+		return ClusterScopedEventNamespace
+	}
+	return namespace
+}
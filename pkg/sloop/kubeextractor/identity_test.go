@@ -0,0 +1,29 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package kubeextractor
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_RegisterClusterScopedKind(t *testing.T) {
+	assert.False(t, IsClustersScopedResource("SomeClusterThing"))
+	RegisterClusterScopedKind("SomeClusterThing")
+	assert.True(t, IsClustersScopedResource("SomeClusterThing"))
+}
+
+func Test_NormalizeNamespace(t *testing.T) {
+	assert.Equal(t, "", NormalizeNamespace("PersistentVolume", "someNamespace"))
+	assert.Equal(t, "someNamespace", NormalizeNamespace(PodKind, "someNamespace"))
+}
+
+func Test_GetEventNamespace(t *testing.T) {
+	assert.Equal(t, ClusterScopedEventNamespace, GetEventNamespace(NodeKind, ""))
+	assert.Equal(t, "someNamespace", GetEventNamespace(PodKind, "someNamespace"))
+}
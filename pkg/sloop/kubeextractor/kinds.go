@@ -12,4 +12,22 @@ const (
 	NamespaceKind = "Namespace"
 	PodKind       = "Pod"
 	EventKind     = "Event"
+	// Sloop watches core/v1 Endpoints as this kind
+	EndpointsKind             = "Endpoint"
+	EndpointSliceKind         = "EndpointSlice"
+	PersistentVolumeClaimKind = "PersistentVolumeClaim"
+	PersistentVolumeKind      = "PersistentVolume"
+	ResourceQuotaKind         = "ResourceQuota"
+	LimitRangeKind            = "LimitRange"
+	SecretKind                = "Secret"
+	ConfigMapKind             = "ConfigMap"
+	ServiceAccountKind        = "ServiceAccount"
+	ServiceKind               = "Service"
+	DeploymentKind            = "Deployment"
+	ReplicaSetKind            = "ReplicaSet"
+	StatefulSetKind           = "StatefulSet"
+	DaemonSetKind             = "DaemonSet"
+	JobKind                   = "Job"
+	CronJobKind               = "CronJob"
+	ApiServiceKind            = "APIService"
 )
@@ -18,6 +18,8 @@ type KubeMetadata struct {
 	SelfLink          string
 	ResourceVersion   string
 	CreationTimestamp string
+	// Set once deletion was requested, while finalizers or a foreground deletion of the dependents hold it back
+	DeletionTimestamp string
 	OwnerReferences   []KubeMetadataOwnerReference
 }
 
@@ -45,3 +47,17 @@ func ExtractMetadata(payload string) (KubeMetadata, error) {
 	}
 	return resource.Metadata, nil
 }
+
+// Extracts status.phase, which is empty for kinds that do not have one
+func ExtractStatusPhase(payload string) (string, error) {
+	resource := struct {
+		Status struct {
+			Phase string `json:"phase"`
+		} `json:"status"`
+	}{}
+	err := json.Unmarshal([]byte(payload), &resource)
+	if err != nil {
+		return "", err
+	}
+	return resource.Status.Phase, nil
+}
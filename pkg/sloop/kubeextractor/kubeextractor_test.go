@@ -79,3 +79,13 @@ func Test_ExtractMetadata_PayloadHasAdditionalFields_OutputCorrect(t *testing.T)
 	assert.Nil(t, err)
 	assert.Equal(t, expectedResult, result)
 }
+
+func Test_ExtractStatusPhase(t *testing.T) {
+	phase, err := ExtractStatusPhase(`{"metadata":{"name":"name1"},"status":{"phase":"Running"}}`)
+	assert.Nil(t, err)
+	assert.Equal(t, "Running", phase)
+
+	phase, err = ExtractStatusPhase(`{"metadata":{"name":"name1"}}`)
+	assert.Nil(t, err)
+	assert.Equal(t, "", phase)
+}
@@ -0,0 +1,173 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package kubeextractor
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"sort"
+)
+
+// Kinds that ExtractNetworkReferences understands
+var NetworkReferenceKinds = []string{PodKind, ServiceKind, EndpointsKind, EndpointSliceKind, NodeKind}
+
+// IP addresses and node names a resource refers to, each mapped to the fields they were found in like status.podIP
+type NetworkReferences struct {
+	Ips   map[string][]string
+	Nodes map[string][]string
+}
+
+func (r NetworkReferences) addIp(ip string, field string) {
+	if ip == "" || ip == "None" {
+		return
+	}
+	r.Ips[NormalizeIp(ip)] = append(r.Ips[NormalizeIp(ip)], field)
+}
+
+func (r NetworkReferences) addNode(node string, field string) {
+	if node != "" {
+		r.Nodes[node] = append(r.Nodes[node], field)
+	}
+}
+
+// Returns the canonical form of an IP address so IPv6 addresses written differently compare equal.  Strings that are
+// not IP addresses are returned as they are
+func NormalizeIp(ip string) string {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return ip
+	}
+	return parsed.String()
+}
+
+// Returns the IP addresses and node names in a Pod, Service, Endpoints, EndpointSlice or Node payload.  Node
+// addresses which are hostnames are listed with the IPs, since that is where people will look for them
+func ExtractNetworkReferences(kind string, payload string) (NetworkReferences, error) {
+	refs := NetworkReferences{Ips: map[string][]string{}, Nodes: map[string][]string{}}
+	var err error
+	switch kind {
+	case PodKind:
+		err = extractPodNetworkReferences(payload, refs)
+	case ServiceKind:
+		err = extractServiceNetworkReferences(payload, refs)
+	case EndpointsKind, EndpointSliceKind:
+		var backends []ServiceBackend
+		_, backends, err = ExtractServiceBackends(kind, payload)
+		field := "subsets.addresses"
+		if kind == EndpointSliceKind {
+			field = "endpoints.addresses"
+		}
+		for _, backend := range backends {
+			refs.addIp(backend.Ip, field)
+			refs.addNode(backend.NodeName, "nodeName")
+		}
+	case NodeKind:
+		resource := struct {
+			Metadata struct {
+				Name string `json:"name"`
+			} `json:"metadata"`
+			Status struct {
+				Addresses []struct {
+					Type    string `json:"type"`
+					Address string `json:"address"`
+				} `json:"addresses"`
+			} `json:"status"`
+		}{}
+		err = json.Unmarshal([]byte(payload), &resource)
+		refs.addNode(resource.Metadata.Name, "metadata.name")
+		for _, address := range resource.Status.Addresses {
+			refs.addIp(address.Address, "status.addresses:"+address.Type)
+		}
+	default:
+		return refs, fmt.Errorf("kind %v does not have network references", kind)
+	}
+	if err != nil {
+		return refs, err
+	}
+
+	for ip := range refs.Ips {
+		refs.Ips[ip] = sortedUnique(refs.Ips[ip])
+	}
+	for node := range refs.Nodes {
+		refs.Nodes[node] = sortedUnique(refs.Nodes[node])
+	}
+	return refs, nil
+}
+
+func extractPodNetworkReferences(payload string, refs NetworkReferences) error {
+	resource := struct {
+		Spec struct {
+			NodeName string `json:"nodeName"`
+		} `json:"spec"`
+		Status struct {
+			HostIP string `json:"hostIP"`
+			PodIP  string `json:"podIP"`
+			PodIPs []struct {
+				Ip string `json:"ip"`
+			} `json:"podIPs"`
+		} `json:"status"`
+	}{}
+	err := json.Unmarshal([]byte(payload), &resource)
+	if err != nil {
+		return err
+	}
+	refs.addNode(resource.Spec.NodeName, "spec.nodeName")
+	refs.addIp(resource.Status.HostIP, "status.hostIP")
+	refs.addIp(resource.Status.PodIP, "status.podIP")
+	for _, podIp := range resource.Status.PodIPs {
+		refs.addIp(podIp.Ip, "status.podIPs")
+	}
+	return nil
+}
+
+func extractServiceNetworkReferences(payload string, refs NetworkReferences) error {
+	resource := struct {
+		Spec struct {
+			ClusterIP      string   `json:"clusterIP"`
+			ClusterIPs     []string `json:"clusterIPs"`
+			ExternalIPs    []string `json:"externalIPs"`
+			LoadBalancerIP string   `json:"loadBalancerIP"`
+		} `json:"spec"`
+		Status struct {
+			LoadBalancer struct {
+				Ingress []struct {
+					Ip string `json:"ip"`
+				} `json:"ingress"`
+			} `json:"loadBalancer"`
+		} `json:"status"`
+	}{}
+	err := json.Unmarshal([]byte(payload), &resource)
+	if err != nil {
+		return err
+	}
+	// Headless services have a clusterIP of None, which addIp skips
+	refs.addIp(resource.Spec.ClusterIP, "spec.clusterIP")
+	for _, ip := range resource.Spec.ClusterIPs {
+		refs.addIp(ip, "spec.clusterIPs")
+	}
+	for _, ip := range resource.Spec.ExternalIPs {
+		refs.addIp(ip, "spec.externalIPs")
+	}
+	refs.addIp(resource.Spec.LoadBalancerIP, "spec.loadBalancerIP")
+	for _, ingress := range resource.Status.LoadBalancer.Ingress {
+		refs.addIp(ingress.Ip, "status.loadBalancer.ingress")
+	}
+	return nil
+}
+
+func sortedUnique(values []string) []string {
+	sort.Strings(values)
+	unique := []string{}
+	for idx, value := range values {
+		if idx == 0 || values[idx-1] != value {
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
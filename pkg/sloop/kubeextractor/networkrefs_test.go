@@ -0,0 +1,64 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package kubeextractor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ExtractNetworkReferences_Pod(t *testing.T) {
+	refs, err := ExtractNetworkReferences(PodKind, `{"spec": {"nodeName": "node1"}, "status": {"hostIP": "10.0.0.1", "podIP": "10.1.0.5", "podIPs": [{"ip": "10.1.0.5"}, {"ip": "fd00:0:0::5"}]}}`)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string][]string{
+		"10.0.0.1": {"status.hostIP"},
+		"10.1.0.5": {"status.podIP", "status.podIPs"},
+		"fd00::5":  {"status.podIPs"},
+	}, refs.Ips)
+	assert.Equal(t, map[string][]string{"node1": {"spec.nodeName"}}, refs.Nodes)
+}
+
+func Test_ExtractNetworkReferences_Service(t *testing.T) {
+	refs, err := ExtractNetworkReferences(ServiceKind, `{"spec": {"clusterIP": "10.96.0.10", "clusterIPs": ["10.96.0.10"], "externalIPs": ["1.2.3.4"]}, "status": {"loadBalancer": {"ingress": [{"ip": "5.6.7.8"}, {"hostname": "lb.example.com"}]}}}`)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string][]string{
+		"10.96.0.10": {"spec.clusterIP", "spec.clusterIPs"},
+		"1.2.3.4":    {"spec.externalIPs"},
+		"5.6.7.8":    {"status.loadBalancer.ingress"},
+	}, refs.Ips)
+	assert.Empty(t, refs.Nodes)
+
+	refs, err = ExtractNetworkReferences(ServiceKind, `{"spec": {"clusterIP": "None"}}`)
+	assert.Nil(t, err)
+	assert.Empty(t, refs.Ips)
+}
+
+func Test_ExtractNetworkReferences_Endpoints(t *testing.T) {
+	refs, err := ExtractNetworkReferences(EndpointsKind, `{"subsets": [{"addresses": [{"ip": "10.1.0.5", "nodeName": "node1"}], "notReadyAddresses": [{"ip": "10.1.0.6", "nodeName": "node2"}]}]}`)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string][]string{"10.1.0.5": {"subsets.addresses"}, "10.1.0.6": {"subsets.addresses"}}, refs.Ips)
+	assert.Equal(t, map[string][]string{"node1": {"nodeName"}, "node2": {"nodeName"}}, refs.Nodes)
+
+	refs, err = ExtractNetworkReferences(EndpointSliceKind, `{"endpoints": [{"addresses": ["10.1.0.5"], "topology": {"kubernetes.io/hostname": "node1"}}]}`)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string][]string{"10.1.0.5": {"endpoints.addresses"}}, refs.Ips)
+	assert.Equal(t, map[string][]string{"node1": {"nodeName"}}, refs.Nodes)
+}
+
+func Test_ExtractNetworkReferences_Node(t *testing.T) {
+	refs, err := ExtractNetworkReferences(NodeKind, `{"metadata": {"name": "node1"}, "status": {"addresses": [{"type": "InternalIP", "address": "10.0.0.1"}, {"type": "Hostname", "address": "node1.internal"}]}}`)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string][]string{"10.0.0.1": {"status.addresses:InternalIP"}, "node1.internal": {"status.addresses:Hostname"}}, refs.Ips)
+	assert.Equal(t, map[string][]string{"node1": {"metadata.name"}}, refs.Nodes)
+}
+
+func Test_ExtractNetworkReferences_BadKind(t *testing.T) {
+	_, err := ExtractNetworkReferences("Deployment", `{}`)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,43 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package kubeextractor
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type NodeCondition struct {
+	Type               string    `json:"type"`
+	Status             string    `json:"status"`
+	Reason             string    `json:"reason"`
+	Message            string    `json:"message"`
+	LastTransitionTime time.Time `json:"lastTransitionTime"`
+}
+
+// Extracts status.conditions from a node payload, in the order the kubelet reported them
+func ExtractNodeConditions(payload string) ([]NodeCondition, error) {
+	resource := struct {
+		Status struct {
+			Conditions []NodeCondition `json:"conditions"`
+		} `json:"status"`
+	}{}
+	err := json.Unmarshal([]byte(payload), &resource)
+	if err != nil {
+		return nil, err
+	}
+	return resource.Status.Conditions, nil
+}
+
+// Ready is healthy when True, every other built in condition is healthy when False
+func IsNodeConditionHealthy(conditionType string, status string) bool {
+	if conditionType == "Ready" {
+		return status == "True"
+	}
+	return status == "False"
+}
@@ -0,0 +1,38 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package kubeextractor
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_ExtractNodeConditions_OutputCorrect(t *testing.T) {
+	payload := `{"metadata":{"name":"node1"},"status":{"conditions":[
+{"type":"MemoryPressure","status":"False","reason":"KubeletHasSufficientMemory","lastHeartbeatTime":"2019-07-19T19:31:41Z","lastTransitionTime":"2019-07-10T01:09:46Z"},
+{"type":"Ready","status":"True","reason":"KubeletReady","message":"kubelet is posting ready status","lastTransitionTime":"2019-07-10T01:10:06Z"}]}}`
+	result, err := ExtractNodeConditions(payload)
+	assert.Nil(t, err)
+	assert.Equal(t, []NodeCondition{
+		{Type: "MemoryPressure", Status: "False", Reason: "KubeletHasSufficientMemory", LastTransitionTime: time.Date(2019, 7, 10, 1, 9, 46, 0, time.UTC)},
+		{Type: "Ready", Status: "True", Reason: "KubeletReady", Message: "kubelet is posting ready status", LastTransitionTime: time.Date(2019, 7, 10, 1, 10, 6, 0, time.UTC)},
+	}, result)
+}
+
+func Test_ExtractNodeConditions_InvalidPayload_ReturnsError(t *testing.T) {
+	_, err := ExtractNodeConditions(`{"status":{"conditions":[}`)
+	assert.NotNil(t, err)
+}
+
+func Test_IsNodeConditionHealthy(t *testing.T) {
+	assert.True(t, IsNodeConditionHealthy("Ready", "True"))
+	assert.False(t, IsNodeConditionHealthy("Ready", "Unknown"))
+	assert.True(t, IsNodeConditionHealthy("DiskPressure", "False"))
+	assert.False(t, IsNodeConditionHealthy("DiskPressure", "True"))
+}
@@ -0,0 +1,81 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package kubeextractor
+
+import (
+	"encoding/json"
+)
+
+// Well known labels of each node pool attribute, the first one a node has wins.  The beta labels are from clusters
+// older than 1.17
+var (
+	nodeZoneLabels         = []string{"topology.kubernetes.io/zone", "failure-domain.beta.kubernetes.io/zone"}
+	nodeRegionLabels       = []string{"topology.kubernetes.io/region", "failure-domain.beta.kubernetes.io/region"}
+	nodeInstanceTypeLabels = []string{"node.kubernetes.io/instance-type", "beta.kubernetes.io/instance-type"}
+	nodeArchLabels         = []string{"kubernetes.io/arch", "beta.kubernetes.io/arch"}
+	nodeOsLabels           = []string{"kubernetes.io/os", "beta.kubernetes.io/os"}
+	// GKE, EKS, AKS and karpenter
+	nodePoolLabels = []string{"cloud.google.com/gke-nodepool", "eks.amazonaws.com/nodegroup", "kubernetes.azure.com/agentpool", "agentpool", "karpenter.sh/provisioner-name"}
+)
+
+// Fields are empty when the node has none of the labels
+type NodePool struct {
+	Zone         string
+	Region       string
+	InstanceType string
+	// Like amd64 or arm64, from status.nodeInfo when the label is missing
+	Arch string
+	Os   string
+	// Name of the node pool or group of a managed cluster
+	Pool string
+}
+
+// Extracts the zone, region, instance type, architecture, os and node pool from the labels of a node payload
+func ExtractNodePool(payload string) (NodePool, error) {
+	resource := struct {
+		Metadata struct {
+			Labels map[string]string `json:"labels"`
+		} `json:"metadata"`
+		Status struct {
+			NodeInfo struct {
+				Architecture    string `json:"architecture"`
+				OperatingSystem string `json:"operatingSystem"`
+			} `json:"nodeInfo"`
+		} `json:"status"`
+	}{}
+	err := json.Unmarshal([]byte(payload), &resource)
+	if err != nil {
+		return NodePool{}, err
+	}
+
+	labels := resource.Metadata.Labels
+	pool := NodePool{
+		Zone:         firstLabel(labels, nodeZoneLabels),
+		Region:       firstLabel(labels, nodeRegionLabels),
+		InstanceType: firstLabel(labels, nodeInstanceTypeLabels),
+		Arch:         firstLabel(labels, nodeArchLabels),
+		Os:           firstLabel(labels, nodeOsLabels),
+		Pool:         firstLabel(labels, nodePoolLabels),
+	}
+	if pool.Arch == "" {
+		pool.Arch = resource.Status.NodeInfo.Architecture
+	}
+	if pool.Os == "" {
+		pool.Os = resource.Status.NodeInfo.OperatingSystem
+	}
+	return pool, nil
+}
+
+func firstLabel(labels map[string]string, names []string) string {
+	for _, name := range names {
+		if value, ok := labels[name]; ok && value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,30 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package kubeextractor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ExtractNodePool(t *testing.T) {
+	pool, err := ExtractNodePool(`{"metadata":{"name":"node-1","labels":{"topology.kubernetes.io/zone":"us-east-1a","topology.kubernetes.io/region":"us-east-1",
+"node.kubernetes.io/instance-type":"m6g.large","kubernetes.io/arch":"arm64","kubernetes.io/os":"linux","eks.amazonaws.com/nodegroup":"graviton"}}}`)
+	assert.Nil(t, err)
+	assert.Equal(t, NodePool{Zone: "us-east-1a", Region: "us-east-1", InstanceType: "m6g.large", Arch: "arm64", Os: "linux", Pool: "graviton"}, pool)
+
+	// Beta labels of older clusters, and the architecture of the node info without labels
+	pool, err = ExtractNodePool(`{"metadata":{"name":"node-2","labels":{"failure-domain.beta.kubernetes.io/zone":"b","beta.kubernetes.io/instance-type":"n1-standard-4"}},
+"status":{"nodeInfo":{"architecture":"amd64","operatingSystem":"linux"}}}`)
+	assert.Nil(t, err)
+	assert.Equal(t, NodePool{Zone: "b", InstanceType: "n1-standard-4", Arch: "amd64", Os: "linux"}, pool)
+
+	_, err = ExtractNodePool(`{`)
+	assert.NotNil(t, err)
+}
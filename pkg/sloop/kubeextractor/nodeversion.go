@@ -0,0 +1,46 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package kubeextractor
+
+import (
+	"encoding/json"
+)
+
+// Labels kubeadm and most managed clusters put on the nodes that run the control plane
+var controlPlaneNodeLabels = []string{"node-role.kubernetes.io/control-plane", "node-role.kubernetes.io/master"}
+
+type NodeVersion struct {
+	KubeletVersion string
+	// The node runs the control plane, so a new version of it is an upgrade of the control plane
+	ControlPlane bool
+}
+
+// Extracts status.nodeInfo.kubeletVersion and the control plane role labels from a node payload
+func ExtractNodeVersion(payload string) (NodeVersion, error) {
+	resource := struct {
+		Metadata struct {
+			Labels map[string]string `json:"labels"`
+		} `json:"metadata"`
+		Status struct {
+			NodeInfo struct {
+				KubeletVersion string `json:"kubeletVersion"`
+			} `json:"nodeInfo"`
+		} `json:"status"`
+	}{}
+	err := json.Unmarshal([]byte(payload), &resource)
+	if err != nil {
+		return NodeVersion{}, err
+	}
+	version := NodeVersion{KubeletVersion: resource.Status.NodeInfo.KubeletVersion}
+	for _, label := range controlPlaneNodeLabels {
+		if _, ok := resource.Metadata.Labels[label]; ok {
+			version.ControlPlane = true
+		}
+	}
+	return version, nil
+}
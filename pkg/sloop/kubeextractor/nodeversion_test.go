@@ -0,0 +1,27 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package kubeextractor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ExtractNodeVersion(t *testing.T) {
+	version, err := ExtractNodeVersion(`{"metadata":{"name":"cp-1","labels":{"node-role.kubernetes.io/control-plane":""}},"status":{"nodeInfo":{"kubeletVersion":"v1.21.1"}}}`)
+	assert.Nil(t, err)
+	assert.Equal(t, NodeVersion{KubeletVersion: "v1.21.1", ControlPlane: true}, version)
+
+	version, err = ExtractNodeVersion(`{"metadata":{"name":"node-1","labels":{"kubernetes.io/os":"linux"}},"status":{"nodeInfo":{"kubeletVersion":"v1.20.4"}}}`)
+	assert.Nil(t, err)
+	assert.Equal(t, NodeVersion{KubeletVersion: "v1.20.4"}, version)
+
+	_, err = ExtractNodeVersion(`{`)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,206 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package kubeextractor
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// Pods without a service account run as this one
+const DefaultServiceAccount = "default"
+
+// Credentials and config a pod spec refers to
+type PodSpecReferences struct {
+	ServiceAccount string
+	// Secret name to where it is used, like volume:certs, env:app/DB_PASSWORD, envFrom:app or imagePullSecrets
+	Secrets map[string][]string
+	// ConfigMap name to where it is used, like volume:config, env:app/LOG_LEVEL or envFrom:app
+	ConfigMaps map[string][]string
+	// PersistentVolumeClaim name to the volumes that mount it, like volume:data
+	PersistentVolumeClaims map[string][]string
+}
+
+type objectRef struct {
+	Name string `json:"name"`
+}
+
+type podSpecContainer struct {
+	Name string `json:"name"`
+	Env  []struct {
+		Name      string `json:"name"`
+		ValueFrom *struct {
+			SecretKeyRef    *objectRef `json:"secretKeyRef"`
+			ConfigMapKeyRef *objectRef `json:"configMapKeyRef"`
+		} `json:"valueFrom"`
+	} `json:"env"`
+	EnvFrom []struct {
+		SecretRef    *objectRef `json:"secretRef"`
+		ConfigMapRef *objectRef `json:"configMapRef"`
+	} `json:"envFrom"`
+}
+
+type podSpec struct {
+	ServiceAccountName string `json:"serviceAccountName"`
+	// Deprecated alias of serviceAccountName, still set by older clients
+	ServiceAccount   string `json:"serviceAccount"`
+	ImagePullSecrets []struct {
+		Name string `json:"name"`
+	} `json:"imagePullSecrets"`
+	Volumes []struct {
+		Name   string `json:"name"`
+		Secret *struct {
+			SecretName string `json:"secretName"`
+		} `json:"secret"`
+		ConfigMap             *objectRef `json:"configMap"`
+		PersistentVolumeClaim *struct {
+			ClaimName string `json:"claimName"`
+		} `json:"persistentVolumeClaim"`
+		Projected *struct {
+			Sources []struct {
+				Secret    *objectRef `json:"secret"`
+				ConfigMap *objectRef `json:"configMap"`
+			} `json:"sources"`
+		} `json:"projected"`
+	} `json:"volumes"`
+	InitContainers []podSpecContainer `json:"initContainers"`
+	Containers     []podSpecContainer `json:"containers"`
+}
+
+type podTemplate struct {
+	Spec podSpec `json:"spec"`
+}
+
+// Returns the service account, secrets and config maps used by the pod spec of a Pod, or of the pod template of a workload like a
+// Deployment, Job or CronJob
+func ExtractPodSpecReferences(kind string, payload string) (PodSpecReferences, error) {
+	spec, err := extractPodSpec(kind, payload)
+	if err != nil {
+		return PodSpecReferences{}, err
+	}
+
+	refs := PodSpecReferences{ServiceAccount: spec.ServiceAccountName, Secrets: map[string][]string{}, ConfigMaps: map[string][]string{}, PersistentVolumeClaims: map[string][]string{}}
+	if refs.ServiceAccount == "" {
+		refs.ServiceAccount = spec.ServiceAccount
+	}
+	if refs.ServiceAccount == "" {
+		refs.ServiceAccount = DefaultServiceAccount
+	}
+	addUsage := func(secret string, usage string) {
+		if secret != "" {
+			refs.Secrets[secret] = append(refs.Secrets[secret], usage)
+		}
+	}
+	addConfigMapUsage := func(configMap *objectRef, usage string) {
+		if configMap != nil && configMap.Name != "" {
+			refs.ConfigMaps[configMap.Name] = append(refs.ConfigMaps[configMap.Name], usage)
+		}
+	}
+	for _, pullSecret := range spec.ImagePullSecrets {
+		addUsage(pullSecret.Name, "imagePullSecrets")
+	}
+	for _, volume := range spec.Volumes {
+		if volume.Secret != nil {
+			addUsage(volume.Secret.SecretName, "volume:"+volume.Name)
+		}
+		addConfigMapUsage(volume.ConfigMap, "volume:"+volume.Name)
+		if volume.PersistentVolumeClaim != nil && volume.PersistentVolumeClaim.ClaimName != "" {
+			claim := volume.PersistentVolumeClaim.ClaimName
+			refs.PersistentVolumeClaims[claim] = append(refs.PersistentVolumeClaims[claim], "volume:"+volume.Name)
+		}
+		if volume.Projected != nil {
+			for _, source := range volume.Projected.Sources {
+				if source.Secret != nil {
+					addUsage(source.Secret.Name, "volume:"+volume.Name)
+				}
+				addConfigMapUsage(source.ConfigMap, "volume:"+volume.Name)
+			}
+		}
+	}
+	for _, container := range append(spec.InitContainers, spec.Containers...) {
+		for _, env := range container.Env {
+			if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil {
+				addUsage(env.ValueFrom.SecretKeyRef.Name, fmt.Sprintf("env:%v/%v", container.Name, env.Name))
+			}
+			if env.ValueFrom != nil {
+				addConfigMapUsage(env.ValueFrom.ConfigMapKeyRef, fmt.Sprintf("env:%v/%v", container.Name, env.Name))
+			}
+		}
+		for _, envFrom := range container.EnvFrom {
+			if envFrom.SecretRef != nil {
+				addUsage(envFrom.SecretRef.Name, "envFrom:"+container.Name)
+			}
+			addConfigMapUsage(envFrom.ConfigMapRef, "envFrom:"+container.Name)
+		}
+	}
+	for secret := range refs.Secrets {
+		sort.Strings(refs.Secrets[secret])
+	}
+	for configMap := range refs.ConfigMaps {
+		sort.Strings(refs.ConfigMaps[configMap])
+	}
+	return refs, nil
+}
+
+func extractPodSpec(kind string, payload string) (podSpec, error) {
+	switch kind {
+	case PodKind:
+		resource := struct {
+			Spec podSpec `json:"spec"`
+		}{}
+		err := json.Unmarshal([]byte(payload), &resource)
+		return resource.Spec, err
+	case "CronJob":
+		resource := struct {
+			Spec struct {
+				JobTemplate struct {
+					Spec struct {
+						Template podTemplate `json:"template"`
+					} `json:"spec"`
+				} `json:"jobTemplate"`
+			} `json:"spec"`
+		}{}
+		err := json.Unmarshal([]byte(payload), &resource)
+		return resource.Spec.JobTemplate.Spec.Template.Spec, err
+	default:
+		resource := struct {
+			Spec struct {
+				Template podTemplate `json:"template"`
+			} `json:"spec"`
+		}{}
+		err := json.Unmarshal([]byte(payload), &resource)
+		return resource.Spec.Template.Spec, err
+	}
+}
+
+// Returns the pod template of a workload as stored, so versions can be compared to tell when its pods were replaced.
+// Empty for a Pod
+func ExtractPodTemplate(kind string, payload string) (string, error) {
+	if kind == PodKind {
+		return "", nil
+	}
+	resource := struct {
+		Spec struct {
+			Template    json.RawMessage `json:"template"`
+			JobTemplate struct {
+				Spec struct {
+					Template json.RawMessage `json:"template"`
+				} `json:"spec"`
+			} `json:"jobTemplate"`
+		} `json:"spec"`
+	}{}
+	err := json.Unmarshal([]byte(payload), &resource)
+	if err != nil {
+		return "", err
+	}
+	if kind == "CronJob" {
+		return string(resource.Spec.JobTemplate.Spec.Template), nil
+	}
+	return string(resource.Spec.Template), nil
+}
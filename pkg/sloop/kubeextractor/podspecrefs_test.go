@@ -0,0 +1,78 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package kubeextractor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const somePodSpec = `{
+  "serviceAccountName": "builder",
+  "imagePullSecrets": [{"name": "registry"}],
+  "volumes": [
+    {"name": "certs", "secret": {"secretName": "tls"}},
+    {"name": "data", "persistentVolumeClaim": {"claimName": "data-0"}},
+    {"name": "bundle", "projected": {"sources": [{"secret": {"name": "tls"}}, {"configMap": {"name": "ca"}}]}}
+  ],
+  "initContainers": [{"name": "init", "envFrom": [{"secretRef": {"name": "db"}}]}],
+  "containers": [{"name": "app", "env": [{"name": "PLAIN", "value": "x"}, {"name": "DB_PASSWORD", "valueFrom": {"secretKeyRef": {"name": "db", "key": "password"}}},
+    {"name": "LOG_LEVEL", "valueFrom": {"configMapKeyRef": {"name": "settings", "key": "level"}}}], "envFrom": [{"configMapRef": {"name": "settings"}}]}]
+}`
+
+func Test_ExtractPodSpecReferences_Pod(t *testing.T) {
+	refs, err := ExtractPodSpecReferences(PodKind, `{"spec": `+somePodSpec+`}`)
+	assert.Nil(t, err)
+	assert.Equal(t, "builder", refs.ServiceAccount)
+	assert.Equal(t, map[string][]string{
+		"registry": {"imagePullSecrets"},
+		"tls":      {"volume:bundle", "volume:certs"},
+		"db":       {"env:app/DB_PASSWORD", "envFrom:init"},
+	}, refs.Secrets)
+	assert.Equal(t, map[string][]string{
+		"ca":       {"volume:bundle"},
+		"settings": {"env:app/LOG_LEVEL", "envFrom:app"},
+	}, refs.ConfigMaps)
+	assert.Equal(t, map[string][]string{"data-0": {"volume:data"}}, refs.PersistentVolumeClaims)
+}
+
+func Test_ExtractPodSpecReferences_Templates(t *testing.T) {
+	refs, err := ExtractPodSpecReferences("Deployment", `{"spec": {"template": {"spec": `+somePodSpec+`}}}`)
+	assert.Nil(t, err)
+	assert.Equal(t, "builder", refs.ServiceAccount)
+	assert.Len(t, refs.Secrets, 3)
+
+	refs, err = ExtractPodSpecReferences("CronJob", `{"spec": {"jobTemplate": {"spec": {"template": {"spec": `+somePodSpec+`}}}}}`)
+	assert.Nil(t, err)
+	assert.Len(t, refs.Secrets, 3)
+
+	refs, err = ExtractPodSpecReferences("Job", `{"spec": {"template": {"spec": {"serviceAccount": "legacy"}}}}`)
+	assert.Nil(t, err)
+	assert.Equal(t, "legacy", refs.ServiceAccount)
+
+	refs, err = ExtractPodSpecReferences(PodKind, `{"spec": {}}`)
+	assert.Nil(t, err)
+	assert.Equal(t, DefaultServiceAccount, refs.ServiceAccount)
+	assert.Len(t, refs.Secrets, 0)
+	assert.Len(t, refs.ConfigMaps, 0)
+}
+
+func Test_ExtractPodTemplate(t *testing.T) {
+	template, err := ExtractPodTemplate("Deployment", `{"spec": {"replicas": 2, "template": {"spec": {"containers": []}}}}`)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"spec": {"containers": []}}`, template)
+
+	template, err = ExtractPodTemplate("CronJob", `{"spec": {"jobTemplate": {"spec": {"template": {"metadata": {}}}}}}`)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"metadata": {}}`, template)
+
+	template, err = ExtractPodTemplate(PodKind, `{"spec": {}}`)
+	assert.Nil(t, err)
+	assert.Equal(t, "", template)
+}
@@ -0,0 +1,128 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package kubeextractor
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// States of ephemeral containers, like the ones kubectl debug adds to a pod
+const (
+	EphemeralContainerAdded      = "Added"
+	EphemeralContainerWaiting    = "Waiting"
+	EphemeralContainerRunning    = "Running"
+	EphemeralContainerTerminated = "Terminated"
+)
+
+type PodStatus struct {
+	Phase string
+	// Container name to waiting reason (like CrashLoopBackOff) for init, regular and ephemeral containers that are
+	// waiting
+	WaitingReasons map[string]string
+	// Ephemeral container name to state, nil when the pod has none.  Added until the kubelet reports a status for it
+	EphemeralContainers map[string]string
+	// From spec.nodeName, empty until the pod is scheduled
+	NodeName string
+}
+
+type containerStatus struct {
+	Name  string `json:"name"`
+	State struct {
+		Waiting *struct {
+			Reason string `json:"reason"`
+		} `json:"waiting"`
+		Running *struct {
+			StartedAt string `json:"startedAt"`
+		} `json:"running"`
+		Terminated *struct {
+			Reason string `json:"reason"`
+		} `json:"terminated"`
+	} `json:"state"`
+}
+
+func (c containerStatus) ephemeralState() string {
+	switch {
+	case c.State.Running != nil:
+		return EphemeralContainerRunning
+	case c.State.Terminated != nil:
+		return EphemeralContainerTerminated
+	case c.State.Waiting != nil:
+		return EphemeralContainerWaiting
+	}
+	return EphemeralContainerAdded
+}
+
+// Extracts the phase, container waiting reasons, ephemeral container states and node from a pod payload
+func ExtractPodStatus(payload string) (PodStatus, error) {
+	resource := struct {
+		Spec struct {
+			NodeName            string `json:"nodeName"`
+			EphemeralContainers []struct {
+				Name string `json:"name"`
+			} `json:"ephemeralContainers"`
+		} `json:"spec"`
+		Status struct {
+			Phase                      string            `json:"phase"`
+			InitContainerStatuses      []containerStatus `json:"initContainerStatuses"`
+			ContainerStatuses          []containerStatus `json:"containerStatuses"`
+			EphemeralContainerStatuses []containerStatus `json:"ephemeralContainerStatuses"`
+		} `json:"status"`
+	}{}
+	err := json.Unmarshal([]byte(payload), &resource)
+	if err != nil {
+		return PodStatus{}, err
+	}
+
+	status := PodStatus{Phase: resource.Status.Phase, WaitingReasons: map[string]string{}, NodeName: resource.Spec.NodeName}
+	for _, containers := range [][]containerStatus{resource.Status.InitContainerStatuses, resource.Status.ContainerStatuses, resource.Status.EphemeralContainerStatuses} {
+		for _, container := range containers {
+			if container.State.Waiting != nil {
+				status.WaitingReasons[container.Name] = container.State.Waiting.Reason
+			}
+		}
+	}
+	if len(resource.Spec.EphemeralContainers) > 0 || len(resource.Status.EphemeralContainerStatuses) > 0 {
+		status.EphemeralContainers = map[string]string{}
+	}
+	for _, container := range resource.Spec.EphemeralContainers {
+		status.EphemeralContainers[container.Name] = EphemeralContainerAdded
+	}
+	for _, container := range resource.Status.EphemeralContainerStatuses {
+		status.EphemeralContainers[container.Name] = container.ephemeralState()
+	}
+	return status, nil
+}
+
+// Returns when the running init and regular containers of a pod payload last started, which moves on every restart
+func ExtractContainerStartTimes(payload string) ([]time.Time, error) {
+	resource := struct {
+		Status struct {
+			InitContainerStatuses []containerStatus `json:"initContainerStatuses"`
+			ContainerStatuses     []containerStatus `json:"containerStatuses"`
+		} `json:"status"`
+	}{}
+	err := json.Unmarshal([]byte(payload), &resource)
+	if err != nil {
+		return nil, err
+	}
+
+	starts := []time.Time{}
+	for _, containers := range [][]containerStatus{resource.Status.InitContainerStatuses, resource.Status.ContainerStatuses} {
+		for _, container := range containers {
+			if container.State.Running == nil {
+				continue
+			}
+			startedAt, err := time.Parse(time.RFC3339, container.State.Running.StartedAt)
+			if err == nil {
+				starts = append(starts, startedAt)
+			}
+		}
+	}
+	return starts, nil
+}
@@ -0,0 +1,63 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package kubeextractor
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_ExtractPodStatus_OutputCorrect(t *testing.T) {
+	payload := `{"metadata":{"name":"name1"},"spec":{"nodeName":"node1"},"status":{"phase":"Pending",
+"initContainerStatuses":[{"name":"init","state":{"terminated":{"reason":"Completed"}}}],
+"containerStatuses":[{"name":"app","state":{"waiting":{"reason":"ContainerCreating"}}},{"name":"sidecar","state":{"running":{}}}]}}`
+	result, err := ExtractPodStatus(payload)
+	assert.Nil(t, err)
+	assert.Equal(t, PodStatus{Phase: "Pending", WaitingReasons: map[string]string{"app": "ContainerCreating"}, NodeName: "node1"}, result)
+}
+
+func Test_ExtractPodStatus_NoStatus(t *testing.T) {
+	result, err := ExtractPodStatus(`{"metadata":{"name":"name1"}}`)
+	assert.Nil(t, err)
+	assert.Equal(t, PodStatus{WaitingReasons: map[string]string{}}, result)
+}
+
+func Test_ExtractPodStatus_EphemeralContainers(t *testing.T) {
+	payload := `{"spec":{"ephemeralContainers":[{"name":"debugger-a"},{"name":"debugger-b"},{"name":"debugger-c"},{"name":"debugger-d"}]},
+"status":{"phase":"Running","ephemeralContainerStatuses":[{"name":"debugger-b","state":{"waiting":{"reason":"ErrImagePull"}}},
+{"name":"debugger-c","state":{"running":{"startedAt":"2019-03-04T03:04:00Z"}}},{"name":"debugger-d","state":{"terminated":{"reason":"Completed"}}}]}}`
+	result, err := ExtractPodStatus(payload)
+	assert.Nil(t, err)
+	assert.Equal(t, PodStatus{
+		Phase:          "Running",
+		WaitingReasons: map[string]string{"debugger-b": "ErrImagePull"},
+		EphemeralContainers: map[string]string{
+			"debugger-a": EphemeralContainerAdded,
+			"debugger-b": EphemeralContainerWaiting,
+			"debugger-c": EphemeralContainerRunning,
+			"debugger-d": EphemeralContainerTerminated,
+		},
+	}, result)
+}
+
+func Test_ExtractPodStatus_InvalidPayload_ReturnsError(t *testing.T) {
+	_, err := ExtractPodStatus(`{"status":{"phase":"Running"}`)
+	assert.NotNil(t, err)
+}
+
+func Test_ExtractContainerStartTimes(t *testing.T) {
+	payload := `{"status":{"initContainerStatuses":[{"name":"init","state":{"terminated":{"reason":"Completed"}}}],
+"containerStatuses":[{"name":"app","state":{"running":{"startedAt":"2019-03-04T03:04:00Z"}}},{"name":"sidecar","state":{"waiting":{"reason":"CrashLoopBackOff"}}}]}}`
+	starts, err := ExtractContainerStartTimes(payload)
+	assert.Nil(t, err)
+	assert.Equal(t, []time.Time{time.Date(2019, 3, 4, 3, 4, 0, 0, time.UTC)}, starts)
+
+	_, err = ExtractContainerStartTimes(`{"status":`)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,65 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package kubeextractor
+
+import (
+	"encoding/json"
+)
+
+// Limits and usage of a ResourceQuota, keyed by resource name like requests.cpu or pods
+type QuotaUsage struct {
+	Hard map[string]string
+	Used map[string]string
+}
+
+// One entry of spec.limits of a LimitRange
+type LimitRangeItem struct {
+	Type                 string            `json:"type"`
+	Max                  map[string]string `json:"max,omitempty"`
+	Min                  map[string]string `json:"min,omitempty"`
+	Default              map[string]string `json:"default,omitempty"`
+	DefaultRequest       map[string]string `json:"defaultRequest,omitempty"`
+	MaxLimitRequestRatio map[string]string `json:"maxLimitRequestRatio,omitempty"`
+}
+
+// Extracts status.hard and status.used of a ResourceQuota payload.  The status only has the limits the quota
+// controller has seen, so spec.hard is used until it catches up
+func ExtractQuotaUsage(payload string) (QuotaUsage, error) {
+	resource := struct {
+		Spec struct {
+			Hard map[string]string `json:"hard"`
+		} `json:"spec"`
+		Status struct {
+			Hard map[string]string `json:"hard"`
+			Used map[string]string `json:"used"`
+		} `json:"status"`
+	}{}
+	err := json.Unmarshal([]byte(payload), &resource)
+	if err != nil {
+		return QuotaUsage{}, err
+	}
+	hard := resource.Status.Hard
+	if len(hard) == 0 {
+		hard = resource.Spec.Hard
+	}
+	return QuotaUsage{Hard: hard, Used: resource.Status.Used}, nil
+}
+
+// Extracts spec.limits of a LimitRange payload
+func ExtractLimitRangeItems(payload string) ([]LimitRangeItem, error) {
+	resource := struct {
+		Spec struct {
+			Limits []LimitRangeItem `json:"limits"`
+		} `json:"spec"`
+	}{}
+	err := json.Unmarshal([]byte(payload), &resource)
+	if err != nil {
+		return nil, err
+	}
+	return resource.Spec.Limits, nil
+}
@@ -0,0 +1,36 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package kubeextractor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ExtractQuotaUsage(t *testing.T) {
+	usage, err := ExtractQuotaUsage(`{"spec": {"hard": {"pods": "10"}}, "status": {"hard": {"pods": "5"}, "used": {"pods": "3"}}}`)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"pods": "5"}, usage.Hard)
+	assert.Equal(t, map[string]string{"pods": "3"}, usage.Used)
+
+	// The quota controller has not filled in the status yet
+	usage, err = ExtractQuotaUsage(`{"spec": {"hard": {"pods": "10"}}}`)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"pods": "10"}, usage.Hard)
+	assert.Len(t, usage.Used, 0)
+
+	_, err = ExtractQuotaUsage("{")
+	assert.NotNil(t, err)
+}
+
+func Test_ExtractLimitRangeItems(t *testing.T) {
+	items, err := ExtractLimitRangeItems(`{"spec": {"limits": [{"type": "Container", "max": {"memory": "1Gi"}, "defaultRequest": {"cpu": "100m"}}]}}`)
+	assert.Nil(t, err)
+	assert.Equal(t, []LimitRangeItem{{Type: "Container", Max: map[string]string{"memory": "1Gi"}, DefaultRequest: map[string]string{"cpu": "100m"}}}, items)
+}
@@ -0,0 +1,151 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package kubeextractor
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+const (
+	// Set by the deployment controller on a Deployment and on each of its ReplicaSets
+	RevisionAnnotation = "deployment.kubernetes.io/revision"
+	// Earlier revisions of a ReplicaSet that was reused by a rollback
+	RevisionHistoryAnnotation = "deployment.kubernetes.io/revision-history"
+	// Reason of the Progressing condition once progressDeadlineSeconds passed without progress
+	ProgressDeadlineExceededReason = "ProgressDeadlineExceeded"
+)
+
+// The rollout state of a Deployment
+type DeploymentRollout struct {
+	Revision           string
+	Generation         int64
+	ObservedGeneration int64
+	// spec.replicas, which defaults to 1
+	Replicas int32
+	// All pods, old and new
+	StatusReplicas    int32
+	UpdatedReplicas   int32
+	ReadyReplicas     int32
+	AvailableReplicas int32
+	Paused            bool
+	ProgressingReason string
+	// Sorted images of the pod template, which tell rollouts apart for people
+	Images []string
+}
+
+// Same check as kubectl rollout status: the spec was seen by the controller, every replica runs the new template
+// and no old pod is left
+func (d DeploymentRollout) Complete() bool {
+	return d.ObservedGeneration >= d.Generation &&
+		d.UpdatedReplicas >= d.Replicas &&
+		d.StatusReplicas <= d.UpdatedReplicas &&
+		d.AvailableReplicas >= d.UpdatedReplicas
+}
+
+func (d DeploymentRollout) Failed() bool {
+	return d.ProgressingReason == ProgressDeadlineExceededReason
+}
+
+type templateContainer struct {
+	Image string `json:"image"`
+}
+
+func ExtractDeploymentRollout(payload string) (DeploymentRollout, error) {
+	resource := struct {
+		Metadata struct {
+			Generation  int64             `json:"generation"`
+			Annotations map[string]string `json:"annotations"`
+		} `json:"metadata"`
+		Spec struct {
+			Replicas *int32 `json:"replicas"`
+			Paused   bool   `json:"paused"`
+			Template struct {
+				Spec struct {
+					InitContainers []templateContainer `json:"initContainers"`
+					Containers     []templateContainer `json:"containers"`
+				} `json:"spec"`
+			} `json:"template"`
+		} `json:"spec"`
+		Status struct {
+			ObservedGeneration int64 `json:"observedGeneration"`
+			Replicas           int32 `json:"replicas"`
+			UpdatedReplicas    int32 `json:"updatedReplicas"`
+			ReadyReplicas      int32 `json:"readyReplicas"`
+			AvailableReplicas  int32 `json:"availableReplicas"`
+			Conditions         []struct {
+				Type   string `json:"type"`
+				Reason string `json:"reason"`
+			} `json:"conditions"`
+		} `json:"status"`
+	}{}
+	err := json.Unmarshal([]byte(payload), &resource)
+	if err != nil {
+		return DeploymentRollout{}, err
+	}
+
+	rollout := DeploymentRollout{
+		Revision:           resource.Metadata.Annotations[RevisionAnnotation],
+		Generation:         resource.Metadata.Generation,
+		ObservedGeneration: resource.Status.ObservedGeneration,
+		Replicas:           1,
+		StatusReplicas:     resource.Status.Replicas,
+		UpdatedReplicas:    resource.Status.UpdatedReplicas,
+		ReadyReplicas:      resource.Status.ReadyReplicas,
+		AvailableReplicas:  resource.Status.AvailableReplicas,
+		Paused:             resource.Spec.Paused,
+		Images:             []string{},
+	}
+	if resource.Spec.Replicas != nil {
+		rollout.Replicas = *resource.Spec.Replicas
+	}
+	for _, condition := range resource.Status.Conditions {
+		if condition.Type == "Progressing" {
+			rollout.ProgressingReason = condition.Reason
+		}
+	}
+	templateSpec := resource.Spec.Template.Spec
+	for _, container := range append(templateSpec.InitContainers, templateSpec.Containers...) {
+		rollout.Images = append(rollout.Images, container.Image)
+	}
+	sort.Strings(rollout.Images)
+	return rollout, nil
+}
+
+type ReplicaSetRevision struct {
+	// Name of the Deployment owning the ReplicaSet, empty for one created on its own
+	Deployment      string
+	Revision        string
+	RevisionHistory string
+}
+
+func ExtractReplicaSetRevision(payload string) (ReplicaSetRevision, error) {
+	resource := struct {
+		Metadata struct {
+			Annotations     map[string]string `json:"annotations"`
+			OwnerReferences []struct {
+				Kind string `json:"kind"`
+				Name string `json:"name"`
+			} `json:"ownerReferences"`
+		} `json:"metadata"`
+	}{}
+	err := json.Unmarshal([]byte(payload), &resource)
+	if err != nil {
+		return ReplicaSetRevision{}, err
+	}
+	revision := ReplicaSetRevision{
+		Revision:        resource.Metadata.Annotations[RevisionAnnotation],
+		RevisionHistory: resource.Metadata.Annotations[RevisionHistoryAnnotation],
+	}
+	for _, owner := range resource.Metadata.OwnerReferences {
+		if owner.Kind == DeploymentKind {
+			revision.Deployment = owner.Name
+		}
+	}
+	return revision, nil
+}
@@ -0,0 +1,64 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package kubeextractor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ExtractDeploymentRollout(t *testing.T) {
+	payload := `{"metadata":{"generation":3,"annotations":{"deployment.kubernetes.io/revision":"2"}},
+"spec":{"replicas":3,"template":{"spec":{"containers":[{"name":"web","image":"web:2"},{"name":"proxy","image":"envoy:1"}]}}},
+"status":{"observedGeneration":3,"replicas":4,"updatedReplicas":2,"readyReplicas":3,"availableReplicas":3,
+"conditions":[{"type":"Available","reason":"MinimumReplicasAvailable"},{"type":"Progressing","reason":"ReplicaSetUpdated"}]}}`
+	rollout, err := ExtractDeploymentRollout(payload)
+	assert.Nil(t, err)
+	assert.Equal(t, DeploymentRollout{
+		Revision:           "2",
+		Generation:         3,
+		ObservedGeneration: 3,
+		Replicas:           3,
+		StatusReplicas:     4,
+		UpdatedReplicas:    2,
+		ReadyReplicas:      3,
+		AvailableReplicas:  3,
+		ProgressingReason:  "ReplicaSetUpdated",
+		Images:             []string{"envoy:1", "web:2"},
+	}, rollout)
+	assert.False(t, rollout.Complete())
+	assert.False(t, rollout.Failed())
+}
+
+func Test_DeploymentRollout_Complete(t *testing.T) {
+	rollout, err := ExtractDeploymentRollout(`{"status":{"replicas":1,"updatedReplicas":1,"availableReplicas":1}}`)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1), rollout.Replicas)
+	assert.True(t, rollout.Complete())
+
+	// Not seen by the controller yet
+	rollout.Generation = 2
+	rollout.ObservedGeneration = 1
+	assert.False(t, rollout.Complete())
+
+	rollout, err = ExtractDeploymentRollout(`{"status":{"conditions":[{"type":"Progressing","reason":"ProgressDeadlineExceeded"}]}}`)
+	assert.Nil(t, err)
+	assert.True(t, rollout.Failed())
+}
+
+func Test_ExtractReplicaSetRevision(t *testing.T) {
+	payload := `{"metadata":{"annotations":{"deployment.kubernetes.io/revision":"4","deployment.kubernetes.io/revision-history":"1,2"},
+"ownerReferences":[{"kind":"Deployment","name":"web"}]}}`
+	revision, err := ExtractReplicaSetRevision(payload)
+	assert.Nil(t, err)
+	assert.Equal(t, ReplicaSetRevision{Deployment: "web", Revision: "4", RevisionHistory: "1,2"}, revision)
+
+	_, err = ExtractReplicaSetRevision(`{"metadata":`)
+	assert.NotNil(t, err)
+}
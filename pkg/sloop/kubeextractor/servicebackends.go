@@ -0,0 +1,131 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package kubeextractor
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// EndpointSlices name the service they belong to with this label
+const endpointSliceServiceNameLabel = "kubernetes.io/service-name"
+
+type ServiceBackend struct {
+	Ip         string
+	Ready      bool
+	TargetKind string
+	TargetName string
+	NodeName   string
+}
+
+type objectReference struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+// Extracts the service name and backend addresses from an EndpointSlice or Endpoints payload, sorted by ip.  Service
+// is empty for EndpointSlices that do not belong to a service
+func ExtractServiceBackends(kind string, payload string) (string, []ServiceBackend, error) {
+	switch kind {
+	case EndpointSliceKind:
+		return extractEndpointSliceBackends(payload)
+	case EndpointsKind:
+		return extractEndpointsBackends(payload)
+	}
+	return "", nil, fmt.Errorf("kind %v does not have service backends", kind)
+}
+
+func extractEndpointSliceBackends(payload string) (string, []ServiceBackend, error) {
+	resource := struct {
+		Metadata struct {
+			Labels map[string]string `json:"labels"`
+		} `json:"metadata"`
+		Endpoints []struct {
+			Addresses  []string `json:"addresses"`
+			Conditions struct {
+				Ready *bool `json:"ready"`
+			} `json:"conditions"`
+			TargetRef *objectReference  `json:"targetRef"`
+			NodeName  string            `json:"nodeName"`
+			Topology  map[string]string `json:"topology"`
+		} `json:"endpoints"`
+	}{}
+	err := json.Unmarshal([]byte(payload), &resource)
+	if err != nil {
+		return "", nil, err
+	}
+
+	backends := []ServiceBackend{}
+	for _, endpoint := range resource.Endpoints {
+		// A missing ready condition means ready
+		ready := endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready
+		nodeName := endpoint.NodeName
+		if nodeName == "" {
+			nodeName = endpoint.Topology["kubernetes.io/hostname"]
+		}
+		for _, ip := range endpoint.Addresses {
+			backends = append(backends, newServiceBackend(ip, ready, endpoint.TargetRef, nodeName))
+		}
+	}
+	sortServiceBackends(backends)
+	return resource.Metadata.Labels[endpointSliceServiceNameLabel], backends, nil
+}
+
+type endpointAddress struct {
+	Ip        string           `json:"ip"`
+	NodeName  string           `json:"nodeName"`
+	TargetRef *objectReference `json:"targetRef"`
+}
+
+// Endpoints objects have the same name as their service
+func extractEndpointsBackends(payload string) (string, []ServiceBackend, error) {
+	resource := struct {
+		Metadata struct {
+			Name string `json:"name"`
+		} `json:"metadata"`
+		Subsets []struct {
+			Addresses         []endpointAddress `json:"addresses"`
+			NotReadyAddresses []endpointAddress `json:"notReadyAddresses"`
+		} `json:"subsets"`
+	}{}
+	err := json.Unmarshal([]byte(payload), &resource)
+	if err != nil {
+		return "", nil, err
+	}
+
+	backends := []ServiceBackend{}
+	for _, subset := range resource.Subsets {
+		for _, address := range subset.Addresses {
+			backends = append(backends, newServiceBackend(address.Ip, true, address.TargetRef, address.NodeName))
+		}
+		for _, address := range subset.NotReadyAddresses {
+			backends = append(backends, newServiceBackend(address.Ip, false, address.TargetRef, address.NodeName))
+		}
+	}
+	sortServiceBackends(backends)
+	return resource.Metadata.Name, backends, nil
+}
+
+func newServiceBackend(ip string, ready bool, targetRef *objectReference, nodeName string) ServiceBackend {
+	backend := ServiceBackend{Ip: ip, Ready: ready, NodeName: nodeName}
+	if targetRef != nil {
+		backend.TargetKind = targetRef.Kind
+		backend.TargetName = targetRef.Name
+	}
+	return backend
+}
+
+func sortServiceBackends(backends []ServiceBackend) {
+	sort.Slice(backends, func(i, j int) bool {
+		if backends[i].Ip != backends[j].Ip {
+			return backends[i].Ip < backends[j].Ip
+		}
+		return backends[i].TargetName < backends[j].TargetName
+	})
+}
@@ -0,0 +1,57 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package kubeextractor
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+const someEndpointSlicePayload = `{
+  "metadata": {"name": "someservice-abcde", "namespace": "somens", "labels": {"kubernetes.io/service-name": "someservice"}},
+  "addressType": "IPv4",
+  "endpoints": [
+    {"addresses": ["10.0.0.2"], "conditions": {"ready": false}, "targetRef": {"kind": "Pod", "name": "pod-b"}, "topology": {"kubernetes.io/hostname": "node-b"}},
+    {"addresses": ["10.0.0.1"], "targetRef": {"kind": "Pod", "name": "pod-a"}, "nodeName": "node-a"}
+  ]
+}`
+
+const someEndpointsPayload = `{
+  "metadata": {"name": "someservice", "namespace": "somens"},
+  "subsets": [
+    {
+      "addresses": [{"ip": "10.0.0.1", "nodeName": "node-a", "targetRef": {"kind": "Pod", "name": "pod-a"}}],
+      "notReadyAddresses": [{"ip": "10.0.0.2", "nodeName": "node-b", "targetRef": {"kind": "Pod", "name": "pod-b"}}]
+    }
+  ]
+}`
+
+func Test_ExtractServiceBackends_EndpointSlice(t *testing.T) {
+	service, backends, err := ExtractServiceBackends(EndpointSliceKind, someEndpointSlicePayload)
+	assert.Nil(t, err)
+	assert.Equal(t, "someservice", service)
+	assert.Equal(t, []ServiceBackend{
+		{Ip: "10.0.0.1", Ready: true, TargetKind: "Pod", TargetName: "pod-a", NodeName: "node-a"},
+		{Ip: "10.0.0.2", Ready: false, TargetKind: "Pod", TargetName: "pod-b", NodeName: "node-b"},
+	}, backends)
+}
+
+func Test_ExtractServiceBackends_Endpoints(t *testing.T) {
+	service, backends, err := ExtractServiceBackends(EndpointsKind, someEndpointsPayload)
+	assert.Nil(t, err)
+	assert.Equal(t, "someservice", service)
+	assert.Equal(t, []ServiceBackend{
+		{Ip: "10.0.0.1", Ready: true, TargetKind: "Pod", TargetName: "pod-a", NodeName: "node-a"},
+		{Ip: "10.0.0.2", Ready: false, TargetKind: "Pod", TargetName: "pod-b", NodeName: "node-b"},
+	}, backends)
+}
+
+func Test_ExtractServiceBackends_OtherKind_ReturnsError(t *testing.T) {
+	_, _, err := ExtractServiceBackends(PodKind, `{}`)
+	assert.NotNil(t, err)
+}
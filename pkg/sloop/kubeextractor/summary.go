@@ -0,0 +1,459 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package kubeextractor
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/salesforce/sloop/pkg/sloop/common"
+)
+
+const maxSummaryMessage = 120
+
+var summarizers = map[string]func(payload string) (string, error){
+	PodKind:                   summarizePod,
+	DeploymentKind:            summarizeWorkload,
+	ReplicaSetKind:            summarizeWorkload,
+	StatefulSetKind:           summarizeWorkload,
+	DaemonSetKind:             summarizeDaemonSet,
+	JobKind:                   summarizeJob,
+	CronJobKind:               summarizeCronJob,
+	NodeKind:                  summarizeNode,
+	ServiceKind:               summarizeService,
+	PersistentVolumeClaimKind: summarizeVolume,
+	PersistentVolumeKind:      summarizeVolume,
+	NamespaceKind:             summarizeNamespace,
+	EventKind:                 summarizeEvent,
+	ConfigMapKind:             summarizeData,
+	SecretKind:                summarizeData,
+}
+
+/*
+Returns a one line summary of a payload for list views, like "Running 2/2 on node-x, image v1.2.3" for a pod.  Kinds
+without a summarizer get an empty summary
+*/
+func SummarizePayload(kind string, payload string) (string, error) {
+	summarizer, ok := summarizers[kind]
+	if !ok {
+		return "", nil
+	}
+	return summarizer(payload)
+}
+
+type summaryContainer struct {
+	Image string `json:"image"`
+}
+
+type summaryPodTemplate struct {
+	Spec struct {
+		Containers []summaryContainer `json:"containers"`
+	} `json:"spec"`
+}
+
+// Returns "image <tag>" for the distinct image tags of the containers, or "images <tag>, <tag>"
+func summarizeImages(containers []summaryContainer) string {
+	tags := []string{}
+	for _, container := range containers {
+		tag := imageTag(container.Image)
+		if !common.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	switch len(tags) {
+	case 0:
+		return ""
+	case 1:
+		return "image " + tags[0]
+	default:
+		return "images " + strings.Join(tags, ", ")
+	}
+}
+
+// The tag of an image, a shortened digest for images pulled by digest, or latest for images without either
+func imageTag(image string) string {
+	if at := strings.Index(image, "@"); at >= 0 {
+		digest := image[at+1:]
+		if len(digest) > len("sha256:")+12 {
+			digest = digest[:len("sha256:")+12]
+		}
+		return digest
+	}
+	// A colon before the last slash is a registry port
+	name := image[strings.LastIndex(image, "/")+1:]
+	if colon := strings.LastIndex(name, ":"); colon >= 0 {
+		return name[colon+1:]
+	}
+	return "latest"
+}
+
+func joinSummary(parts ...string) string {
+	nonEmpty := []string{}
+	for _, part := range parts {
+		if part != "" {
+			nonEmpty = append(nonEmpty, part)
+		}
+	}
+	return strings.Join(nonEmpty, ", ")
+}
+
+func summarizePod(payload string) (string, error) {
+	resource := struct {
+		Metadata struct {
+			DeletionTimestamp string `json:"deletionTimestamp"`
+		} `json:"metadata"`
+		Spec struct {
+			NodeName   string             `json:"nodeName"`
+			Containers []summaryContainer `json:"containers"`
+		} `json:"spec"`
+		Status struct {
+			Phase             string `json:"phase"`
+			ContainerStatuses []struct {
+				Ready        bool `json:"ready"`
+				RestartCount int  `json:"restartCount"`
+				State        struct {
+					Waiting *struct {
+						Reason string `json:"reason"`
+					} `json:"waiting"`
+				} `json:"state"`
+			} `json:"containerStatuses"`
+		} `json:"status"`
+	}{}
+	err := json.Unmarshal([]byte(payload), &resource)
+	if err != nil {
+		return "", err
+	}
+
+	// A waiting reason like CrashLoopBackOff says more than the phase
+	state := resource.Status.Phase
+	ready := 0
+	restarts := 0
+	for _, container := range resource.Status.ContainerStatuses {
+		if container.Ready {
+			ready++
+		}
+		restarts += container.RestartCount
+		if container.State.Waiting != nil && container.State.Waiting.Reason != "" && state == resource.Status.Phase {
+			state = container.State.Waiting.Reason
+		}
+	}
+	if resource.Metadata.DeletionTimestamp != "" {
+		state = "Terminating"
+	}
+	head := fmt.Sprintf("%v %v/%v", state, ready, len(resource.Spec.Containers))
+	if resource.Spec.NodeName != "" {
+		head += " on " + resource.Spec.NodeName
+	}
+	restartText := ""
+	if restarts > 0 {
+		restartText = fmt.Sprintf("%v restarts", restarts)
+	}
+	return joinSummary(strings.TrimSpace(head), restartText, summarizeImages(resource.Spec.Containers)), nil
+}
+
+// Deployments, replica sets and stateful sets
+func summarizeWorkload(payload string) (string, error) {
+	resource := struct {
+		Spec struct {
+			Replicas *int               `json:"replicas"`
+			Template summaryPodTemplate `json:"template"`
+		} `json:"spec"`
+		Status struct {
+			ReadyReplicas   int `json:"readyReplicas"`
+			UpdatedReplicas int `json:"updatedReplicas"`
+		} `json:"status"`
+	}{}
+	err := json.Unmarshal([]byte(payload), &resource)
+	if err != nil {
+		return "", err
+	}
+	replicas := 1
+	if resource.Spec.Replicas != nil {
+		replicas = *resource.Spec.Replicas
+	}
+	updated := ""
+	if resource.Status.UpdatedReplicas != replicas {
+		updated = fmt.Sprintf("%v up to date", resource.Status.UpdatedReplicas)
+	}
+	return joinSummary(fmt.Sprintf("%v/%v ready", resource.Status.ReadyReplicas, replicas), updated, summarizeImages(resource.Spec.Template.Spec.Containers)), nil
+}
+
+func summarizeDaemonSet(payload string) (string, error) {
+	resource := struct {
+		Spec struct {
+			Template summaryPodTemplate `json:"template"`
+		} `json:"spec"`
+		Status struct {
+			DesiredNumberScheduled int `json:"desiredNumberScheduled"`
+			NumberReady            int `json:"numberReady"`
+			UpdatedNumberScheduled int `json:"updatedNumberScheduled"`
+		} `json:"status"`
+	}{}
+	err := json.Unmarshal([]byte(payload), &resource)
+	if err != nil {
+		return "", err
+	}
+	updated := ""
+	if resource.Status.UpdatedNumberScheduled != resource.Status.DesiredNumberScheduled {
+		updated = fmt.Sprintf("%v up to date", resource.Status.UpdatedNumberScheduled)
+	}
+	return joinSummary(fmt.Sprintf("%v/%v ready", resource.Status.NumberReady, resource.Status.DesiredNumberScheduled), updated, summarizeImages(resource.Spec.Template.Spec.Containers)), nil
+}
+
+func summarizeJob(payload string) (string, error) {
+	resource := struct {
+		Spec struct {
+			Completions *int               `json:"completions"`
+			Template    summaryPodTemplate `json:"template"`
+		} `json:"spec"`
+		Status struct {
+			Active     int `json:"active"`
+			Succeeded  int `json:"succeeded"`
+			Failed     int `json:"failed"`
+			Conditions []struct {
+				Type   string `json:"type"`
+				Status string `json:"status"`
+			} `json:"conditions"`
+		} `json:"status"`
+	}{}
+	err := json.Unmarshal([]byte(payload), &resource)
+	if err != nil {
+		return "", err
+	}
+	state := "Running"
+	for _, condition := range resource.Status.Conditions {
+		if condition.Status == "True" && (condition.Type == "Complete" || condition.Type == "Failed") {
+			state = condition.Type
+		}
+	}
+	completions := 1
+	if resource.Spec.Completions != nil {
+		completions = *resource.Spec.Completions
+	}
+	active := ""
+	if resource.Status.Active > 0 {
+		active = fmt.Sprintf("%v active", resource.Status.Active)
+	}
+	failed := ""
+	if resource.Status.Failed > 0 {
+		failed = fmt.Sprintf("%v failed", resource.Status.Failed)
+	}
+	return joinSummary(fmt.Sprintf("%v %v/%v succeeded", state, resource.Status.Succeeded, completions), active, failed, summarizeImages(resource.Spec.Template.Spec.Containers)), nil
+}
+
+func summarizeCronJob(payload string) (string, error) {
+	resource := struct {
+		Spec struct {
+			Schedule string `json:"schedule"`
+			Suspend  bool   `json:"suspend"`
+		} `json:"spec"`
+		Status struct {
+			Active []interface{} `json:"active"`
+		} `json:"status"`
+	}{}
+	err := json.Unmarshal([]byte(payload), &resource)
+	if err != nil {
+		return "", err
+	}
+	active := ""
+	if len(resource.Status.Active) > 0 {
+		active = fmt.Sprintf("%v active", len(resource.Status.Active))
+	}
+	suspended := ""
+	if resource.Spec.Suspend {
+		suspended = "suspended"
+	}
+	return joinSummary("schedule "+resource.Spec.Schedule, active, suspended), nil
+}
+
+func summarizeNode(payload string) (string, error) {
+	resource := struct {
+		Spec struct {
+			Unschedulable bool `json:"unschedulable"`
+		} `json:"spec"`
+		Status struct {
+			Conditions []struct {
+				Type   string `json:"type"`
+				Status string `json:"status"`
+			} `json:"conditions"`
+			NodeInfo struct {
+				KubeletVersion string `json:"kubeletVersion"`
+			} `json:"nodeInfo"`
+		} `json:"status"`
+	}{}
+	err := json.Unmarshal([]byte(payload), &resource)
+	if err != nil {
+		return "", err
+	}
+	state := "NotReady"
+	// Conditions other than Ready are problems when true, like MemoryPressure
+	problems := []string{}
+	for _, condition := range resource.Status.Conditions {
+		if condition.Type == "Ready" {
+			if condition.Status == "True" {
+				state = "Ready"
+			}
+		} else if condition.Status == "True" {
+			problems = append(problems, condition.Type)
+		}
+	}
+	sort.Strings(problems)
+	unschedulable := ""
+	if resource.Spec.Unschedulable {
+		unschedulable = "unschedulable"
+	}
+	return joinSummary(state, unschedulable, strings.Join(problems, ", "), resource.Status.NodeInfo.KubeletVersion), nil
+}
+
+func summarizeService(payload string) (string, error) {
+	resource := struct {
+		Spec struct {
+			Type      string `json:"type"`
+			ClusterIP string `json:"clusterIP"`
+			Ports     []struct {
+				Port     int    `json:"port"`
+				Protocol string `json:"protocol"`
+			} `json:"ports"`
+		} `json:"spec"`
+		Status struct {
+			LoadBalancer struct {
+				Ingress []struct {
+					Ip       string `json:"ip"`
+					Hostname string `json:"hostname"`
+				} `json:"ingress"`
+			} `json:"loadBalancer"`
+		} `json:"status"`
+	}{}
+	err := json.Unmarshal([]byte(payload), &resource)
+	if err != nil {
+		return "", err
+	}
+	serviceType := resource.Spec.Type
+	if serviceType == "" {
+		serviceType = "ClusterIP"
+	}
+	head := strings.TrimSpace(serviceType + " " + resource.Spec.ClusterIP)
+	external := []string{}
+	for _, ingress := range resource.Status.LoadBalancer.Ingress {
+		if ingress.Ip != "" {
+			external = append(external, ingress.Ip)
+		} else if ingress.Hostname != "" {
+			external = append(external, ingress.Hostname)
+		}
+	}
+	if len(external) > 0 {
+		head += " (" + strings.Join(external, ", ") + ")"
+	}
+	ports := []string{}
+	for _, port := range resource.Spec.Ports {
+		protocol := port.Protocol
+		if protocol == "" {
+			protocol = "TCP"
+		}
+		ports = append(ports, fmt.Sprintf("%v/%v", port.Port, protocol))
+	}
+	portText := ""
+	if len(ports) > 0 {
+		portText = "ports " + strings.Join(ports, " ")
+	}
+	return joinSummary(head, portText), nil
+}
+
+// Persistent volumes and persistent volume claims
+func summarizeVolume(payload string) (string, error) {
+	resource := struct {
+		Spec struct {
+			VolumeName string `json:"volumeName"`
+			ClaimRef   *struct {
+				Namespace string `json:"namespace"`
+				Name      string `json:"name"`
+			} `json:"claimRef"`
+			Capacity  map[string]string `json:"capacity"`
+			Resources struct {
+				Requests map[string]string `json:"requests"`
+			} `json:"resources"`
+		} `json:"spec"`
+		Status struct {
+			Phase    string            `json:"phase"`
+			Capacity map[string]string `json:"capacity"`
+		} `json:"status"`
+	}{}
+	err := json.Unmarshal([]byte(payload), &resource)
+	if err != nil {
+		return "", err
+	}
+	head := resource.Status.Phase
+	if resource.Spec.VolumeName != "" {
+		head += " to " + resource.Spec.VolumeName
+	} else if resource.Spec.ClaimRef != nil {
+		head += " to " + resource.Spec.ClaimRef.Namespace + "/" + resource.Spec.ClaimRef.Name
+	}
+	// Claims have the size they got in their status and the one they asked for in their spec, volumes only in their spec
+	size := resource.Status.Capacity["storage"]
+	if size == "" {
+		size = resource.Spec.Capacity["storage"]
+	}
+	if size == "" {
+		size = resource.Spec.Resources.Requests["storage"]
+	}
+	return joinSummary(strings.TrimSpace(head), size), nil
+}
+
+func summarizeNamespace(payload string) (string, error) {
+	resource := struct {
+		Status struct {
+			Phase string `json:"phase"`
+		} `json:"status"`
+	}{}
+	err := json.Unmarshal([]byte(payload), &resource)
+	if err != nil {
+		return "", err
+	}
+	return resource.Status.Phase, nil
+}
+
+func summarizeEvent(payload string) (string, error) {
+	info, err := ExtractEventInfo(payload)
+	if err != nil {
+		return "", err
+	}
+	message, err := ExtractEventMessage(payload)
+	if err != nil {
+		return "", err
+	}
+	if runes := []rune(message); len(runes) > maxSummaryMessage {
+		message = string(runes[:maxSummaryMessage]) + "..."
+	}
+	head := strings.TrimSpace(info.Type + " " + info.Reason)
+	if info.Count > 1 {
+		head += fmt.Sprintf(" x%v", info.Count)
+	}
+	if message == "" {
+		return head, nil
+	}
+	return head + ": " + message, nil
+}
+
+// Config maps and secrets, which only get their number of keys so no values end up in list views
+func summarizeData(payload string) (string, error) {
+	resource := struct {
+		Data       map[string]interface{} `json:"data"`
+		BinaryData map[string]interface{} `json:"binaryData"`
+	}{}
+	err := json.Unmarshal([]byte(payload), &resource)
+	if err != nil {
+		return "", err
+	}
+	keys := len(resource.Data) + len(resource.BinaryData)
+	if keys == 1 {
+		return "1 key", nil
+	}
+	return fmt.Sprintf("%v keys", keys), nil
+}
@@ -0,0 +1,48 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package kubeextractor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SummarizePayload(t *testing.T) {
+	cases := []struct {
+		kind     string
+		payload  string
+		expected string
+	}{
+		{PodKind, `{"spec":{"nodeName":"node-x","containers":[{"image":"app:v1.2.3"},{"image":"registry:5000/team/app:v1.2.3"}]},"status":{"phase":"Running","containerStatuses":[{"ready":true},{"ready":true}]}}`, "Running 2/2 on node-x, image v1.2.3"},
+		{PodKind, `{"spec":{"containers":[{"image":"app"},{"image":"sidecar@sha256:0123456789abcdef0123"}]},"status":{"phase":"Running","containerStatuses":[{"ready":true,"restartCount":4},{"state":{"waiting":{"reason":"CrashLoopBackOff"}}}]}}`, "CrashLoopBackOff 1/2, 4 restarts, images latest, sha256:0123456789ab"},
+		{PodKind, `{"metadata":{"deletionTimestamp":"2019-01-01T00:00:00Z"},"spec":{"containers":[{"image":"app:v1"}]},"status":{"phase":"Running"}}`, "Terminating 0/1, image v1"},
+		{DeploymentKind, `{"spec":{"replicas":3,"template":{"spec":{"containers":[{"image":"app:v2"}]}}},"status":{"readyReplicas":2,"updatedReplicas":1}}`, "2/3 ready, 1 up to date, image v2"},
+		{StatefulSetKind, `{"spec":{"template":{"spec":{"containers":[{"image":"db:13"}]}}},"status":{"readyReplicas":1,"updatedReplicas":1}}`, "1/1 ready, image 13"},
+		{DaemonSetKind, `{"status":{"desiredNumberScheduled":5,"numberReady":5,"updatedNumberScheduled":5}}`, "5/5 ready"},
+		{JobKind, `{"spec":{"completions":3},"status":{"succeeded":1,"failed":6,"conditions":[{"type":"Failed","status":"True"}]}}`, "Failed 1/3 succeeded, 6 failed"},
+		{CronJobKind, `{"spec":{"schedule":"*/5 * * * *","suspend":true}}`, "schedule */5 * * * *, suspended"},
+		{NodeKind, `{"spec":{"unschedulable":true},"status":{"conditions":[{"type":"MemoryPressure","status":"True"},{"type":"Ready","status":"True"}],"nodeInfo":{"kubeletVersion":"v1.20.1"}}}`, "Ready, unschedulable, MemoryPressure, v1.20.1"},
+		{ServiceKind, `{"spec":{"type":"LoadBalancer","clusterIP":"10.0.0.1","ports":[{"port":80},{"port":53,"protocol":"UDP"}]},"status":{"loadBalancer":{"ingress":[{"ip":"1.2.3.4"}]}}}`, "LoadBalancer 10.0.0.1 (1.2.3.4), ports 80/TCP 53/UDP"},
+		{PersistentVolumeClaimKind, `{"spec":{"volumeName":"pv-1","resources":{"requests":{"storage":"5Gi"}}},"status":{"phase":"Bound","capacity":{"storage":"10Gi"}}}`, "Bound to pv-1, 10Gi"},
+		{PersistentVolumeKind, `{"spec":{"claimRef":{"namespace":"ns","name":"data"},"capacity":{"storage":"10Gi"}},"status":{"phase":"Bound"}}`, "Bound to ns/data, 10Gi"},
+		{NamespaceKind, `{"status":{"phase":"Terminating"}}`, "Terminating"},
+		{EventKind, `{"type":"Warning","reason":"BackOff","count":5,"message":"Back-off restarting failed container"}`, "Warning BackOff x5: Back-off restarting failed container"},
+		{SecretKind, `{"data":{"password":"c2VjcmV0"}}`, "1 key"},
+		{ConfigMapKind, `{"data":{"a":"1","b":"2"},"binaryData":{"c":"Mw=="}}`, "3 keys"},
+		{"Widget", `{"spec":{}}`, ""},
+	}
+	for _, c := range cases {
+		summary, err := SummarizePayload(c.kind, c.payload)
+		assert.Nil(t, err, c.kind)
+		assert.Equal(t, c.expected, summary, c.kind)
+	}
+
+	_, err := SummarizePayload(PodKind, `{"spec":`)
+	assert.NotNil(t, err)
+}
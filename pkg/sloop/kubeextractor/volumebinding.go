@@ -0,0 +1,93 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package kubeextractor
+
+import (
+	"encoding/json"
+)
+
+// Binding and capacity of a PersistentVolumeClaim
+type ClaimBinding struct {
+	Phase        string
+	VolumeName   string
+	StorageClass string
+	Requested    string
+	Capacity     string
+}
+
+// Binding and capacity of a PersistentVolume
+type VolumeBinding struct {
+	Phase          string
+	ClaimNamespace string
+	ClaimName      string
+	StorageClass   string
+	Capacity       string
+	ReclaimPolicy  string
+}
+
+// Extracts the bound volume, phase and storage size of a PersistentVolumeClaim payload
+func ExtractClaimBinding(payload string) (ClaimBinding, error) {
+	resource := struct {
+		Spec struct {
+			VolumeName       string `json:"volumeName"`
+			StorageClassName string `json:"storageClassName"`
+			Resources        struct {
+				Requests map[string]string `json:"requests"`
+			} `json:"resources"`
+		} `json:"spec"`
+		Status struct {
+			Phase    string            `json:"phase"`
+			Capacity map[string]string `json:"capacity"`
+		} `json:"status"`
+	}{}
+	err := json.Unmarshal([]byte(payload), &resource)
+	if err != nil {
+		return ClaimBinding{}, err
+	}
+	return ClaimBinding{
+		Phase:        resource.Status.Phase,
+		VolumeName:   resource.Spec.VolumeName,
+		StorageClass: resource.Spec.StorageClassName,
+		Requested:    resource.Spec.Resources.Requests["storage"],
+		Capacity:     resource.Status.Capacity["storage"],
+	}, nil
+}
+
+// Extracts the claim reference, phase and storage size of a PersistentVolume payload.  The claim is empty while the
+// volume is not bound
+func ExtractVolumeBinding(payload string) (VolumeBinding, error) {
+	resource := struct {
+		Spec struct {
+			ClaimRef *struct {
+				Namespace string `json:"namespace"`
+				Name      string `json:"name"`
+			} `json:"claimRef"`
+			StorageClassName              string            `json:"storageClassName"`
+			Capacity                      map[string]string `json:"capacity"`
+			PersistentVolumeReclaimPolicy string            `json:"persistentVolumeReclaimPolicy"`
+		} `json:"spec"`
+		Status struct {
+			Phase string `json:"phase"`
+		} `json:"status"`
+	}{}
+	err := json.Unmarshal([]byte(payload), &resource)
+	if err != nil {
+		return VolumeBinding{}, err
+	}
+	binding := VolumeBinding{
+		Phase:         resource.Status.Phase,
+		StorageClass:  resource.Spec.StorageClassName,
+		Capacity:      resource.Spec.Capacity["storage"],
+		ReclaimPolicy: resource.Spec.PersistentVolumeReclaimPolicy,
+	}
+	if resource.Spec.ClaimRef != nil {
+		binding.ClaimNamespace = resource.Spec.ClaimRef.Namespace
+		binding.ClaimName = resource.Spec.ClaimRef.Name
+	}
+	return binding, nil
+}
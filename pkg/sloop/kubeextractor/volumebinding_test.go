@@ -0,0 +1,55 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package kubeextractor
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+const someClaimPayload = `{
+  "metadata": {"name": "data-db-0", "namespace": "somens"},
+  "spec": {"volumeName": "pv-1", "storageClassName": "fast", "resources": {"requests": {"storage": "10Gi"}}},
+  "status": {"phase": "Bound", "capacity": {"storage": "20Gi"}}
+}`
+
+const someVolumePayload = `{
+  "metadata": {"name": "pv-1"},
+  "spec": {"claimRef": {"kind": "PersistentVolumeClaim", "namespace": "somens", "name": "data-db-0"}, "storageClassName": "fast",
+    "capacity": {"storage": "20Gi"}, "persistentVolumeReclaimPolicy": "Delete"},
+  "status": {"phase": "Bound"}
+}`
+
+func Test_ExtractClaimBinding(t *testing.T) {
+	binding, err := ExtractClaimBinding(someClaimPayload)
+	assert.Nil(t, err)
+	assert.Equal(t, ClaimBinding{Phase: "Bound", VolumeName: "pv-1", StorageClass: "fast", Requested: "10Gi", Capacity: "20Gi"}, binding)
+}
+
+func Test_ExtractClaimBinding_Pending(t *testing.T) {
+	binding, err := ExtractClaimBinding(`{"spec": {"resources": {"requests": {"storage": "1Gi"}}}, "status": {"phase": "Pending"}}`)
+	assert.Nil(t, err)
+	assert.Equal(t, ClaimBinding{Phase: "Pending", Requested: "1Gi"}, binding)
+}
+
+func Test_ExtractVolumeBinding(t *testing.T) {
+	binding, err := ExtractVolumeBinding(someVolumePayload)
+	assert.Nil(t, err)
+	assert.Equal(t, VolumeBinding{Phase: "Bound", ClaimNamespace: "somens", ClaimName: "data-db-0", StorageClass: "fast", Capacity: "20Gi", ReclaimPolicy: "Delete"}, binding)
+}
+
+func Test_ExtractVolumeBinding_Available(t *testing.T) {
+	binding, err := ExtractVolumeBinding(`{"spec": {"capacity": {"storage": "5Gi"}}, "status": {"phase": "Available"}}`)
+	assert.Nil(t, err)
+	assert.Equal(t, VolumeBinding{Phase: "Available", Capacity: "5Gi"}, binding)
+}
+
+func Test_ExtractVolumeBinding_BadPayload(t *testing.T) {
+	_, err := ExtractVolumeBinding("{")
+	assert.NotNil(t, err)
+}
@@ -10,7 +10,9 @@ package main
 import (
 	"flag"
 	"github.com/golang/glog"
+	"github.com/salesforce/sloop/pkg/sloop/demodata"
 	"github.com/salesforce/sloop/pkg/sloop/server"
+	"github.com/salesforce/sloop/pkg/sloop/storesync"
 	"os"
 	"runtime/pprof"
 )
@@ -26,7 +28,14 @@ func main() {
 		pprof.StartCPUProfile(f)
 		defer pprof.StopCPUProfile()
 	}
-	err := server.RealMain()
+	var err error
+	if len(os.Args) > 1 && os.Args[1] == "sync" {
+		err = storesync.RealMain(os.Args[2:])
+	} else if len(os.Args) > 1 && os.Args[1] == "demo-data" {
+		err = demodata.RealMain(os.Args[2:])
+	} else {
+		err = server.RealMain()
+	}
 	if err != nil {
 		glog.Errorf("Main exited with error: %v\n", err)
 		os.Exit(1)
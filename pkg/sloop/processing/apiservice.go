@@ -0,0 +1,71 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package processing
+
+import (
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+// Records a transition whenever the status or reason of the Available condition of an APIService changes, like
+// updateNodeConditionTable does for nodes
+func updateApiServiceAvailabilityTable(txn badgerwrap.Txn, watchRec *typed.KubeWatchResult, metadata *kubeextractor.KubeMetadata) error {
+	if watchRec.Kind != kubeextractor.ApiServiceKind || watchRec.WatchType == typed.KubeWatchResult_DELETE {
+		return nil
+	}
+
+	timestamp, err := ptypes.Timestamp(watchRec.Timestamp)
+	if err != nil {
+		return errors.Wrapf(err, "Could not convert timestamp %v", watchRec.Timestamp)
+	}
+
+	apiService, err := kubeextractor.ExtractApiService(watchRec.Payload)
+	if err != nil {
+		return errors.Wrap(err, "Could not extract api service")
+	}
+	if apiService.Available == nil {
+		return nil
+	}
+
+	table := typed.OpenApiServiceAvailabilityTable()
+	key := table.Key(untyped.GetPartitionId(timestamp), metadata.Name, metadata.Uid)
+	record, err := table.GetOrDefault(txn, key)
+	if err != nil {
+		return errors.Wrap(err, "Could not get api service availability record")
+	}
+	if len(record.Transitions) > 0 {
+		last := record.Transitions[len(record.Transitions)-1]
+		if last.Status == apiService.Available.Status && last.Reason == apiService.Available.Reason {
+			return nil
+		}
+	}
+
+	record.Group = apiService.Group
+	record.Version = apiService.Version
+	record.Service = apiService.Service
+	var lastTransitionTime int64
+	if !apiService.Available.LastTransitionTime.IsZero() {
+		lastTransitionTime = apiService.Available.LastTransitionTime.Unix()
+	}
+	record.Transitions = append(record.Transitions, &typed.ApiServiceTransition{
+		Timestamp:          timestamp.Unix(),
+		Status:             apiService.Available.Status,
+		Reason:             apiService.Available.Reason,
+		Message:            apiService.Available.Message,
+		LastTransitionTime: lastTransitionTime,
+	})
+	err = table.Set(txn, key, record)
+	if err != nil {
+		return errors.Wrap(err, "Failed to put api service availability record")
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package processing
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+func helper_apiServicePayload(resourceVersion int, status string, reason string) string {
+	return fmt.Sprintf(`{"metadata":{"name":"v1beta1.metrics.k8s.io","uid":"someApiServiceUid","resourceVersion":"%v"},
+"spec":{"group":"metrics.k8s.io","version":"v1beta1","service":{"namespace":"kube-system","name":"metrics-server"}},
+"status":{"conditions":[{"type":"Available","status":"%v","reason":"%v","lastTransitionTime":"2019-03-04T03:00:00Z"}]}}`,
+		resourceVersion, status, reason)
+}
+
+func Test_updateApiServiceAvailabilityTable_RecordsOnlyTransitions(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+
+	payloads := []string{
+		helper_apiServicePayload(1, "True", "Passed"),
+		// A resync without a change
+		helper_apiServicePayload(1, "True", "Passed"),
+		helper_apiServicePayload(2, "False", "FailedDiscoveryCheck"),
+	}
+	for idx, payload := range payloads {
+		ts, err := ptypes.TimestampProto(someWatchTime.Add(time.Duration(idx) * time.Minute))
+		assert.Nil(t, err)
+		watchRec := &typed.KubeWatchResult{Kind: kubeextractor.ApiServiceKind, WatchType: typed.KubeWatchResult_UPDATE, Timestamp: ts, Payload: payload}
+		metadata, err := kubeextractor.ExtractMetadata(watchRec.Payload)
+		assert.Nil(t, err)
+		err = db.Update(func(txn badgerwrap.Txn) error {
+			return updateApiServiceAvailabilityTable(txn, watchRec, &metadata)
+		})
+		assert.Nil(t, err)
+	}
+
+	err = db.View(func(txn badgerwrap.Txn) error {
+		table := typed.OpenApiServiceAvailabilityTable()
+		record, err := table.GetOrDefault(txn, table.Key(untyped.GetPartitionId(someWatchTime), "v1beta1.metrics.k8s.io", "someApiServiceUid"))
+		assert.Nil(t, err)
+		assert.Equal(t, "metrics.k8s.io", record.Group)
+		assert.Equal(t, "v1beta1", record.Version)
+		assert.Equal(t, "kube-system/metrics-server", record.Service)
+		assert.Len(t, record.Transitions, 2)
+		assert.Equal(t, "True", record.Transitions[0].Status)
+		assert.Equal(t, time.Date(2019, 3, 4, 3, 0, 0, 0, time.UTC).Unix(), record.Transitions[0].LastTransitionTime)
+		assert.Equal(t, "False", record.Transitions[1].Status)
+		assert.Equal(t, "FailedDiscoveryCheck", record.Transitions[1].Reason)
+		assert.Equal(t, someWatchTime.Add(2*time.Minute).Unix(), record.Transitions[1].Timestamp)
+		return nil
+	})
+	assert.Nil(t, err)
+}
@@ -0,0 +1,56 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package processing
+
+import (
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+var metricProcessingApiVersionMigrationCount = promauto.NewCounterVec(prometheus.CounterOpts{Name: "sloop_processing_apiversion_migration_count"}, []string{"kind", "from", "to"})
+
+// Takes the api version from the payload when the watcher did not set it, as for playback files and older results
+func setApiVersion(watchRec *typed.KubeWatchResult) {
+	watchRec.ApiVersion = typed.WatchResultApiVersion(watchRec)
+}
+
+/*
+Flags a watch result whose resource was stored with another api version before, which is what upgrading the cluster
+or sloop, or serving a CRD at a new version, looks like.  The migration is also stored as an ingest annotation for the
+kind, so the timeline shows when it happened, and repeats for other resources of the kind merge into it.  Nothing is
+flagged when either version is unknown or the name now belongs to a new resource
+*/
+func setApiVersionMigration(tables typed.Tables, txn badgerwrap.Txn, prevStored *typed.KubeWatchResult, watchRec *typed.KubeWatchResult, metadata *kubeextractor.KubeMetadata) error {
+	if prevStored == nil || watchRec.ApiVersion == "" {
+		return nil
+	}
+	prevApiVersion := typed.WatchResultApiVersion(prevStored)
+	if prevApiVersion == "" || prevApiVersion == watchRec.ApiVersion {
+		return nil
+	}
+	prevMetadata, err := kubeextractor.ExtractMetadata(prevStored.Payload)
+	if err != nil || prevMetadata.Uid != metadata.Uid {
+		return nil
+	}
+	watchRec.ApiVersionMigratedFrom = prevApiVersion
+	metricProcessingApiVersionMigrationCount.WithLabelValues(watchRec.Kind, prevApiVersion, watchRec.ApiVersion).Inc()
+	return updateIngestAnnotationTable(tables, txn, &typed.IngestAnnotation{
+		Type:      typed.IngestAnnotationApiVersionMigration,
+		Kind:      watchRec.Kind,
+		Message:   fmt.Sprintf("%v %v -> %v", watchRec.Kind, prevApiVersion, watchRec.ApiVersion),
+		Count:     1,
+		FirstSeen: watchRec.Timestamp,
+		LastSeen:  watchRec.Timestamp,
+	})
+}
@@ -0,0 +1,67 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package processing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+func Test_setApiVersion_FallsBackToThePayload(t *testing.T) {
+	watchRec := &typed.KubeWatchResult{Payload: `{"apiVersion":"example.com/v1","metadata":{"name":"a"}}`}
+	setApiVersion(watchRec)
+	assert.Equal(t, "example.com/v1", watchRec.ApiVersion)
+
+	watchRec = &typed.KubeWatchResult{ApiVersion: "apps/v1", Payload: `{"metadata":{"name":"a"}}`}
+	setApiVersion(watchRec)
+	assert.Equal(t, "apps/v1", watchRec.ApiVersion)
+}
+
+func Test_WatchTable_FlagsApiVersionMigrations(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+
+	var stored []*typed.KubeWatchResult
+	for idx, apiVersion := range []string{"example.com/v1beta1", "example.com/v1beta1", "example.com/v1", "example.com/v1"} {
+		ts, err := ptypes.TimestampProto(someWatchTime.Add(time.Duration(idx) * time.Minute))
+		assert.Nil(t, err)
+		watchRec := &typed.KubeWatchResult{Kind: "Widget", WatchType: typed.KubeWatchResult_UPDATE, Timestamp: ts, ApiVersion: apiVersion, Payload: somePodPayload}
+		err = tables.Db().Update(func(txn badgerwrap.Txn) error {
+			metadata, err := kubeextractor.ExtractMetadata(watchRec.Payload)
+			assert.Nil(t, err)
+			return updateKubeWatchTable(tables, txn, watchRec, &metadata, true, SamplingPolicy{})
+		})
+		assert.Nil(t, err)
+		stored = append(stored, watchRec)
+	}
+	assert.Equal(t, []string{"", "", "example.com/v1beta1", ""}, []string{stored[0].ApiVersionMigratedFrom, stored[1].ApiVersionMigratedFrom, stored[2].ApiVersionMigratedFrom, stored[3].ApiVersionMigratedFrom})
+
+	err = tables.Db().View(func(txn badgerwrap.Txn) error {
+		annotations, _, err := tables.IngestAnnotationTable().RangeRead(txn, nil, nil, nil, someWatchTime.Add(-time.Hour), someWatchTime.Add(time.Hour))
+		assert.Nil(t, err)
+		assert.Len(t, annotations, 1)
+		for key, annotation := range annotations {
+			assert.Equal(t, typed.IngestAnnotationApiVersionMigration, key.Type)
+			assert.Equal(t, "Widget", key.Kind)
+			assert.Equal(t, "Widget example.com/v1beta1 -> example.com/v1", annotation.Message)
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+}
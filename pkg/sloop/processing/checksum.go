@@ -0,0 +1,44 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package processing
+
+import (
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/ptypes"
+
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+// Late, synced and merged watch results are written to partitions that are already closed, whose checksums then no
+// longer match.  They are deleted so the store manager computes them again
+func invalidateClosedPartitionChecksums(txn badgerwrap.Txn, partition string) error {
+	if partition >= untyped.GetPartitionId(common.Now()) {
+		return nil
+	}
+	return typed.OpenChecksumTable().DeletePartition(txn, partition)
+}
+
+func (r *Runner) invalidateChecksums(watchRec *typed.KubeWatchResult) {
+	timestamp, err := ptypes.Timestamp(watchRec.Timestamp)
+	if err != nil {
+		return
+	}
+	partition := untyped.GetPartitionId(timestamp)
+	if partition >= untyped.GetPartitionId(common.Now()) {
+		return
+	}
+	err = r.tables.Db().Update(func(txn badgerwrap.Txn) error {
+		return invalidateClosedPartitionChecksums(txn, partition)
+	})
+	if err != nil {
+		glog.Errorf("Failed to invalidate checksums of partition %v: %v", partition, err)
+	}
+}
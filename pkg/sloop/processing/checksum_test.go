@@ -0,0 +1,45 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package processing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+func Test_invalidateClosedPartitionChecksums_LeavesTheOpenPartition(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	closed := untyped.GetPartitionId(someWatchTime)
+	open := untyped.GetPartitionId(time.Now())
+	checksumTable := typed.OpenChecksumTable()
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		assert.Nil(t, checksumTable.Set(txn, &typed.PartitionChecksum{Partition: closed, Table: "watch"}))
+		assert.Nil(t, checksumTable.Set(txn, &typed.PartitionChecksum{Partition: open, Table: "watch"}))
+		assert.Nil(t, invalidateClosedPartitionChecksums(txn, closed))
+		return invalidateClosedPartitionChecksums(txn, open)
+	})
+	assert.Nil(t, err)
+
+	err = db.View(func(txn badgerwrap.Txn) error {
+		checksums, err := checksumTable.ReadAll(txn)
+		assert.Nil(t, err)
+		assert.Len(t, checksums, 1)
+		assert.Equal(t, open, checksums[0].Partition)
+		return nil
+	})
+	assert.Nil(t, err)
+}
@@ -0,0 +1,91 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package processing
+
+import (
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+)
+
+var (
+	metricClockSkewSeconds      = promauto.NewGauge(prometheus.GaugeOpts{Name: "sloop_clock_skew_seconds"})
+	metricClockSkewFlaggedCount = promauto.NewCounterVec(prometheus.CounterOpts{Name: "sloop_clock_skew_flagged_count"}, []string{"kind"})
+)
+
+// How much ingest time has to pass before the skew estimate is updated
+const clockSkewWindow = 5 * time.Minute
+
+/*
+Estimates the skew between the clock of sloop and the clocks of the cluster from the delta between the ingest time
+of each watch result and the event time in its payload.  A single delta mixes skew with ingestion lag, and informer
+relists and resyncs replay old objects with very old event times, so the estimate is the smallest delta seen within
+each window: as long as at least one fresh change is seen in a window it has close to no lag.
+
+A result is flagged when its event time is more than the threshold after its ingest time, which can only be skew, or
+when the estimate itself is over the threshold and so is the delta of the result.  A threshold of 0 only updates the
+estimate.
+
+Not safe for concurrent use.  The Runner calls it from its single processing goroutine
+*/
+type clockSkewDetector struct {
+	threshold     time.Duration
+	windowStart   time.Time
+	windowMin     time.Duration
+	windowSamples int
+	estimate      time.Duration
+}
+
+func newClockSkewDetector(threshold time.Duration) *clockSkewDetector {
+	return &clockSkewDetector{threshold: threshold}
+}
+
+// Returns ingest time minus event time and whether the result looks affected by clock skew
+func (d *clockSkewDetector) observe(ingestTime time.Time, eventTime time.Time) (time.Duration, bool) {
+	delta := ingestTime.Sub(eventTime)
+	if d.windowSamples == 0 || delta < d.windowMin {
+		d.windowMin = delta
+	}
+	d.windowSamples++
+	if d.windowStart.IsZero() {
+		d.windowStart = ingestTime
+	} else if ingestTime.Sub(d.windowStart) >= clockSkewWindow {
+		d.estimate = d.windowMin
+		metricClockSkewSeconds.Set(d.estimate.Seconds())
+		d.windowStart = ingestTime
+		d.windowSamples = 0
+	}
+
+	if d.threshold <= 0 {
+		return delta, false
+	}
+	return delta, delta < -d.threshold || (d.estimate > d.threshold && delta > d.threshold)
+}
+
+// Sets ClockSkewMillis on the watch result when it looks affected by clock skew
+func (r *Runner) checkClockSkew(watchRec *typed.KubeWatchResult) {
+	eventTime, ok := kubeextractor.ExtractEventTime(watchRec.Kind, watchRec.Payload)
+	if !ok {
+		return
+	}
+	ingestTime, err := ptypes.Timestamp(watchRec.Timestamp)
+	if err != nil {
+		return
+	}
+	delta, skewed := r.clockSkew.observe(ingestTime, eventTime)
+	if skewed {
+		glog.V(2).Infof("Clock skew of %v on %v watch result ingested at %v", delta, watchRec.Kind, ingestTime)
+		watchRec.ClockSkewMillis = int64(delta / time.Millisecond)
+		metricClockSkewFlaggedCount.WithLabelValues(watchRec.Kind).Inc()
+	}
+}
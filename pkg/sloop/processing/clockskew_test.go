@@ -0,0 +1,81 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package processing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_clockSkewDetector_FutureEventTimeIsFlagged(t *testing.T) {
+	d := newClockSkewDetector(time.Minute)
+	delta, skewed := d.observe(someWatchTime, someWatchTime.Add(10*time.Minute))
+	assert.Equal(t, -10*time.Minute, delta)
+	assert.True(t, skewed)
+	_, skewed = d.observe(someWatchTime, someWatchTime.Add(30*time.Second))
+	assert.False(t, skewed)
+}
+
+func Test_clockSkewDetector_ReplaysAreNotFlagged(t *testing.T) {
+	d := newClockSkewDetector(time.Minute)
+	for i := 0; i < 3; i++ {
+		ingestTime := someWatchTime.Add(time.Duration(i) * clockSkewWindow)
+		// One fresh change per window and a resync of a resource that last changed a day ago
+		_, skewed := d.observe(ingestTime, ingestTime.Add(-time.Second))
+		assert.False(t, skewed)
+		_, skewed = d.observe(ingestTime, ingestTime.Add(-24*time.Hour))
+		assert.False(t, skewed)
+	}
+	assert.Equal(t, time.Second, d.estimate)
+}
+
+func Test_clockSkewDetector_LocalClockAheadIsFlagged(t *testing.T) {
+	d := newClockSkewDetector(time.Minute)
+	var skewed bool
+	for i := 0; i < 3; i++ {
+		ingestTime := someWatchTime.Add(time.Duration(i) * clockSkewWindow)
+		_, skewed = d.observe(ingestTime, ingestTime.Add(-10*time.Minute))
+	}
+	assert.Equal(t, 10*time.Minute, d.estimate)
+	assert.True(t, skewed)
+
+	d = newClockSkewDetector(0)
+	_, skewed = d.observe(someWatchTime, someWatchTime.Add(time.Hour))
+	assert.False(t, skewed)
+}
+
+func Test_processWatchResult_ClockSkewIsStored(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	r := NewProcessing(nil, tables, false, time.Hour, 0, nil, time.Minute, nil)
+
+	ts, err := ptypes.TimestampProto(someWatchTime)
+	assert.Nil(t, err)
+	payload := `{"kind":"Pod","metadata":{"name":"someName","namespace":"someNamespace","uid":"someUid","managedFields":[{"time":"` + someWatchTime.Add(time.Hour).Format(time.RFC3339) + `"}]}}`
+	r.processWatchResult(&typed.KubeWatchResult{Timestamp: ts, Kind: someKind, WatchType: typed.KubeWatchResult_UPDATE, Payload: payload})
+
+	err = db.View(func(txn badgerwrap.Txn) error {
+		watchRes, _, err := tables.WatchTable().RangeRead(txn, nil, nil, nil, someWatchTime, someWatchTime)
+		assert.Nil(t, err)
+		assert.Len(t, watchRes, 1)
+		for _, result := range watchRes {
+			assert.InDelta(t, -time.Hour/time.Millisecond, result.ClockSkewMillis, 1)
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+}
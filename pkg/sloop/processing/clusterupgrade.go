@@ -0,0 +1,56 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package processing
+
+import (
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+var metricProcessingNodeVersionChangeCount = promauto.NewCounterVec(prometheus.CounterOpts{Name: "sloop_processing_node_version_change_count"}, []string{"role"})
+
+/*
+Records a cluster upgrade ingest annotation when a node comes back with another kubelet version than it was stored
+with.  Nodes are upgraded one at a time, so the changes of one upgrade merge into one window.  The annotation has no
+kind, so the timeline of every kind shows it next to the churn the upgrade caused
+*/
+func setClusterUpgrade(tables typed.Tables, txn badgerwrap.Txn, prevStored *typed.KubeWatchResult, watchRec *typed.KubeWatchResult, metadata *kubeextractor.KubeMetadata) error {
+	if prevStored == nil || watchRec.Kind != kubeextractor.NodeKind {
+		return nil
+	}
+	prevMetadata, err := kubeextractor.ExtractMetadata(prevStored.Payload)
+	if err != nil || prevMetadata.Uid != metadata.Uid {
+		return nil
+	}
+	prevVersion, err := kubeextractor.ExtractNodeVersion(prevStored.Payload)
+	if err != nil {
+		return nil
+	}
+	version, err := kubeextractor.ExtractNodeVersion(watchRec.Payload)
+	if err != nil || prevVersion.KubeletVersion == "" || version.KubeletVersion == "" || prevVersion.KubeletVersion == version.KubeletVersion {
+		return nil
+	}
+	role := "node"
+	if version.ControlPlane {
+		role = "control plane node"
+	}
+	metricProcessingNodeVersionChangeCount.WithLabelValues(role).Inc()
+	return updateIngestAnnotationTable(tables, txn, &typed.IngestAnnotation{
+		Type:      typed.IngestAnnotationClusterUpgrade,
+		Message:   fmt.Sprintf("%v %v %v -> %v", role, metadata.Name, prevVersion.KubeletVersion, version.KubeletVersion),
+		Count:     1,
+		FirstSeen: watchRec.Timestamp,
+		LastSeen:  watchRec.Timestamp,
+	})
+}
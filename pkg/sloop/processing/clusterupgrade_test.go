@@ -0,0 +1,80 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package processing
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+func helper_nodeVersionPayload(name string, kubeletVersion string, controlPlane bool) string {
+	labels := `{}`
+	if controlPlane {
+		labels = `{"node-role.kubernetes.io/control-plane":""}`
+	}
+	return fmt.Sprintf(`{"metadata":{"name":"%v","uid":"uid-%v","labels":%v},"status":{"nodeInfo":{"kubeletVersion":"%v"}}}`, name, name, labels, kubeletVersion)
+}
+
+func Test_WatchTable_RecordsClusterUpgrades(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	start := time.Date(2021, 3, 4, 5, 0, 0, 0, time.UTC)
+
+	updates := []struct {
+		offset  time.Duration
+		payload string
+	}{
+		{0, helper_nodeVersionPayload("cp-1", "v1.20.4", true)},
+		{time.Minute, helper_nodeVersionPayload("node-1", "v1.20.4", false)},
+		{10 * time.Minute, helper_nodeVersionPayload("cp-1", "v1.21.1", true)},
+		{40 * time.Minute, helper_nodeVersionPayload("node-1", "v1.21.1", false)},
+		// An unrelated update of an upgraded node
+		{45 * time.Minute, helper_nodeVersionPayload("node-1", "v1.21.1", false)},
+	}
+	for _, update := range updates {
+		ts, err := ptypes.TimestampProto(start.Add(update.offset))
+		assert.Nil(t, err)
+		watchRec := &typed.KubeWatchResult{Kind: kubeextractor.NodeKind, WatchType: typed.KubeWatchResult_UPDATE, Timestamp: ts, Payload: update.payload}
+		err = tables.Db().Update(func(txn badgerwrap.Txn) error {
+			metadata, err := kubeextractor.ExtractMetadata(watchRec.Payload)
+			assert.Nil(t, err)
+			return updateKubeWatchTable(tables, txn, watchRec, &metadata, true, SamplingPolicy{})
+		})
+		assert.Nil(t, err)
+	}
+
+	err = tables.Db().View(func(txn badgerwrap.Txn) error {
+		annotations, _, err := tables.IngestAnnotationTable().RangeRead(txn, nil, nil, nil, start, start.Add(time.Hour))
+		assert.Nil(t, err)
+		assert.Len(t, annotations, 1)
+		for key, annotation := range annotations {
+			assert.Equal(t, typed.IngestAnnotationClusterUpgrade, key.Type)
+			assert.Equal(t, "", key.Kind)
+			assert.Equal(t, int64(2), annotation.Count)
+			assert.Equal(t, "node node-1 v1.20.4 -> v1.21.1", annotation.Message)
+			firstSeen, _ := ptypes.Timestamp(annotation.FirstSeen)
+			lastSeen, _ := ptypes.Timestamp(annotation.LastSeen)
+			assert.Equal(t, start.Add(10*time.Minute), firstSeen)
+			assert.Equal(t, start.Add(40*time.Minute), lastSeen)
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+}
@@ -0,0 +1,58 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package processing
+
+import (
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+// Keeps the latest payload of every live resource, moving it to the partition of the watch result.  Deletes clear
+// it, and an older watch result, such as one from a merged playback file, never replaces a newer payload
+func updateCurrentStateTable(tables typed.Tables, txn badgerwrap.Txn, watchRec *typed.KubeWatchResult, metadata *kubeextractor.KubeMetadata) error {
+	if watchRec.Kind == kubeextractor.EventKind {
+		return nil
+	}
+	if keySafe(metadata.Name) == "" || keySafe(metadata.Uid) != metadata.Uid {
+		return nil
+	}
+
+	timestamp, err := ptypes.Timestamp(watchRec.Timestamp)
+	if err != nil {
+		return errors.Wrapf(err, "Could not convert timestamp %v", watchRec.Timestamp)
+	}
+
+	existingKey, existing, err := tables.CurrentStateTable().Find(txn, watchRec.Kind, metadata.Namespace, metadata.Name)
+	if err != nil {
+		return errors.Wrap(err, "Could not find current state")
+	}
+	if existingKey != nil {
+		existingTimestamp, err := ptypes.Timestamp(existing.Timestamp)
+		if err == nil && existingTimestamp.After(timestamp) {
+			return nil
+		}
+		err = txn.Delete([]byte(existingKey.String()))
+		if err != nil {
+			return errors.Wrapf(err, "Failed to delete current state %v", existingKey.String())
+		}
+	}
+	if watchRec.WatchType == typed.KubeWatchResult_DELETE {
+		return nil
+	}
+
+	key := typed.NewCurrentStateKey(untyped.GetPartitionId(timestamp), watchRec.Kind, metadata.Namespace, metadata.Name, metadata.Uid)
+	err = tables.CurrentStateTable().Set(txn, key.String(), &typed.CurrentState{Timestamp: watchRec.Timestamp, Payload: watchRec.Payload})
+	if err != nil {
+		return errors.Wrap(err, "Failed to put current state")
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package processing
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+func helper_configMapPayload(uid string, version int) string {
+	return fmt.Sprintf(`{"metadata":{"name":"someconfig","namespace":"somens","uid":"%v","resourceVersion":"%v"}}`, uid, version)
+}
+
+func helper_updateCurrentState(t *testing.T, tables typed.Tables, payload string, watchType typed.KubeWatchResult_WatchType, ts time.Time) {
+	pts, err := ptypes.TimestampProto(ts)
+	assert.Nil(t, err)
+	watchRec := &typed.KubeWatchResult{Kind: "ConfigMap", WatchType: watchType, Timestamp: pts, Payload: payload}
+	metadata, err := kubeextractor.ExtractMetadata(payload)
+	assert.Nil(t, err)
+	err = tables.Db().Update(func(txn badgerwrap.Txn) error {
+		return updateCurrentStateTable(tables, txn, watchRec, &metadata)
+	})
+	assert.Nil(t, err)
+}
+
+func helper_listCurrentState(t *testing.T, tables typed.Tables) map[typed.CurrentStateKey]*typed.CurrentState {
+	var states map[typed.CurrentStateKey]*typed.CurrentState
+	err := tables.Db().View(func(txn badgerwrap.Txn) error {
+		var err error
+		states, err = tables.CurrentStateTable().List(txn, "ConfigMap", "")
+		return err
+	})
+	assert.Nil(t, err)
+	return states
+}
+
+func Test_updateCurrentStateTable_KeepsLatestAcrossPartitions(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+
+	helper_updateCurrentState(t, tables, helper_configMapPayload("uid1", 1), typed.KubeWatchResult_ADD, someWatchTime)
+	helper_updateCurrentState(t, tables, helper_configMapPayload("uid1", 2), typed.KubeWatchResult_UPDATE, someWatchTime.Add(2*time.Hour))
+	// Older than what is stored
+	helper_updateCurrentState(t, tables, helper_configMapPayload("uid1", 0), typed.KubeWatchResult_UPDATE, someWatchTime.Add(time.Hour))
+
+	states := helper_listCurrentState(t, tables)
+	assert.Len(t, states, 1)
+	for key, state := range states {
+		assert.Equal(t, untyped.GetPartitionId(someWatchTime.Add(2*time.Hour)), key.PartitionId)
+		assert.Equal(t, "uid1", key.Uid)
+		assert.Equal(t, helper_configMapPayload("uid1", 2), state.Payload)
+	}
+}
+
+func Test_updateCurrentStateTable_DeleteAndRecreate(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+
+	helper_updateCurrentState(t, tables, helper_configMapPayload("uid1", 1), typed.KubeWatchResult_ADD, someWatchTime)
+	helper_updateCurrentState(t, tables, helper_configMapPayload("uid1", 2), typed.KubeWatchResult_DELETE, someWatchTime.Add(time.Minute))
+	assert.Len(t, helper_listCurrentState(t, tables), 0)
+
+	helper_updateCurrentState(t, tables, helper_configMapPayload("uid2", 3), typed.KubeWatchResult_ADD, someWatchTime.Add(2*time.Minute))
+	states := helper_listCurrentState(t, tables)
+	assert.Len(t, states, 1)
+	for key := range states {
+		assert.Equal(t, "uid2", key.Uid)
+	}
+}
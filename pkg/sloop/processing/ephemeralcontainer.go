@@ -0,0 +1,83 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package processing
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+// Reasons of the events counted on the pod when one of its ephemeral containers changes state
+const (
+	EphemeralContainerAddedReason      = "EphemeralContainerAdded"
+	EphemeralContainerWaitingReason    = "EphemeralContainerWaiting"
+	EphemeralContainerStartedReason    = "EphemeralContainerStarted"
+	EphemeralContainerTerminatedReason = "EphemeralContainerTerminated"
+)
+
+type ephemeralContainerEvent struct {
+	reason   string
+	severity string
+}
+
+var ephemeralContainerEvents = map[string]ephemeralContainerEvent{
+	kubeextractor.EphemeralContainerAdded:      {reason: EphemeralContainerAddedReason, severity: "Normal"},
+	kubeextractor.EphemeralContainerWaiting:    {reason: EphemeralContainerWaitingReason, severity: "Warning"},
+	kubeextractor.EphemeralContainerRunning:    {reason: EphemeralContainerStartedReason, severity: "Normal"},
+	kubeextractor.EphemeralContainerTerminated: {reason: EphemeralContainerTerminatedReason, severity: "Normal"},
+}
+
+/*
+Counts an event on the pod for each ephemeral container that is new or in another state than in the previous stored
+version of the pod, so debugging sessions show on the pod timeline like the events of the cluster.  Runs with the
+event counts, before the watch table has the new version
+*/
+func updateEphemeralContainerEvents(tables typed.Tables, txn badgerwrap.Txn, watchRec *typed.KubeWatchResult, metadata *kubeextractor.KubeMetadata) error {
+	// A payload that can not be parsed fails the pod lifecycle stage instead
+	status, err := kubeextractor.ExtractPodStatus(watchRec.Payload)
+	if err != nil || len(status.EphemeralContainers) == 0 {
+		return nil
+	}
+
+	prevStates := map[string]string{}
+	prevWatch, err := getPreviousStoredWatchResult(tables, txn, nil, watchRec, metadata)
+	if err != nil {
+		return err
+	}
+	if prevWatch != nil {
+		prevStatus, err := kubeextractor.ExtractPodStatus(prevWatch.Payload)
+		if err == nil {
+			prevStates = prevStatus.EphemeralContainers
+		}
+	}
+
+	timestamp, err := ptypes.Timestamp(watchRec.Timestamp)
+	if err != nil {
+		return errors.Wrapf(err, "Could not convert timestamp %v", watchRec.Timestamp)
+	}
+	minute := map[int64]int{timestamp.Round(time.Minute).Unix(): 1}
+	for container, state := range status.EphemeralContainers {
+		if prevStates[container] == state {
+			continue
+		}
+		event, ok := ephemeralContainerEvents[state]
+		if !ok {
+			continue
+		}
+		err = storeMinutes(tables, txn, minute, kubeextractor.PodKind, metadata.Namespace, metadata.Name, metadata.Uid, event.reason, event.severity)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
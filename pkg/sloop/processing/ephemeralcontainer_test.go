@@ -0,0 +1,73 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package processing
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+const someDebugPodPayload = `{"metadata":{"name":"somePodName","namespace":"someNamespace","uid":"somePodUid","resourceVersion":"%v"},` +
+	`"spec":{"ephemeralContainers":%v},"status":{"phase":"Running","ephemeralContainerStatuses":%v}}`
+
+func Test_updateEventCountTable_EphemeralContainers(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+
+	debugger := `[{"name":"debugger"}]`
+	payloads := []string{
+		fmt.Sprintf(someDebugPodPayload, 1, `[]`, `[]`),
+		fmt.Sprintf(someDebugPodPayload, 2, debugger, `[]`),
+		fmt.Sprintf(someDebugPodPayload, 3, debugger, `[{"name":"debugger","state":{"running":{}}}]`),
+		fmt.Sprintf(someDebugPodPayload, 4, debugger, `[{"name":"debugger","state":{"running":{}}}]`),
+		fmt.Sprintf(someDebugPodPayload, 5, debugger, `[{"name":"debugger","state":{"terminated":{"reason":"Completed"}}}]`),
+	}
+	for idx, payload := range payloads {
+		ts := someWatchTime.Add(time.Duration(idx) * time.Minute)
+		protoTs, _ := ptypes.TimestampProto(ts)
+		watchRec := &typed.KubeWatchResult{Kind: kubeextractor.PodKind, WatchType: typed.KubeWatchResult_UPDATE, Timestamp: protoTs, Payload: payload}
+		metadata, err := kubeextractor.ExtractMetadata(payload)
+		assert.Nil(t, err)
+		err = db.Update(func(txn badgerwrap.Txn) error {
+			err := updateEventCountTable(tables, txn, watchRec, &metadata, &kubeextractor.KubeInvolvedObject{}, time.Hour)
+			if err != nil {
+				return err
+			}
+			return tables.WatchTable().Set(txn, typed.NewWatchTableKey(untyped.GetPartitionId(ts), kubeextractor.PodKind, "someNamespace", "somePodName", ts).String(), watchRec)
+		})
+		assert.Nil(t, err)
+	}
+
+	err = db.View(func(txn badgerwrap.Txn) error {
+		key := typed.NewEventCountKey(someWatchTime, kubeextractor.PodKind, "someNamespace", "somePodName", "somePodUid")
+		counts, err := tables.EventCountTable().Get(txn, key.String())
+		assert.Nil(t, err)
+		reasons := map[int64]map[string]int32{}
+		for minute, events := range counts.MapMinToEvents {
+			reasons[minute] = events.MapReasonToCount
+		}
+		assert.Equal(t, map[int64]map[string]int32{
+			someWatchTime.Add(time.Minute).Round(time.Minute).Unix():     {EphemeralContainerAddedReason + ":Normal": 1},
+			someWatchTime.Add(2 * time.Minute).Round(time.Minute).Unix(): {EphemeralContainerStartedReason + ":Normal": 1},
+			someWatchTime.Add(4 * time.Minute).Round(time.Minute).Unix(): {EphemeralContainerTerminatedReason + ":Normal": 1},
+		}, reasons)
+		return nil
+	})
+	assert.Nil(t, err)
+}
@@ -29,6 +29,9 @@ func updateEventCountTable(
 	metadata *kubeextractor.KubeMetadata,
 	involvedObject *kubeextractor.KubeInvolvedObject,
 	maxLookback time.Duration) error {
+	if watchRec.Kind == kubeextractor.PodKind {
+		return updateEphemeralContainerEvents(tables, txn, watchRec, metadata)
+	}
 	if watchRec.Kind != kubeextractor.EventKind {
 		glog.V(7).Infof("Skipping event processing for %v", watchRec.Kind)
 		return nil
@@ -39,6 +42,17 @@ func updateEventCountTable(
 		return errors.Wrap(err, "Could not get event info for previous event instance")
 	}
 
+	return updateEventCountTableWithPrevious(tables, txn, watchRec, involvedObject, prevEventInfo)
+}
+
+// Same as updateEventCountTable, but the previous copy of the event is passed in.  Folded events never reach the
+// watch table, so their previous copy comes from the event fold instead.
+func updateEventCountTableWithPrevious(
+	tables typed.Tables,
+	txn badgerwrap.Txn,
+	watchRec *typed.KubeWatchResult,
+	involvedObject *kubeextractor.KubeInvolvedObject,
+	prevEventInfo *kubeextractor.EventInfo) error {
 	newEventInfo, err := kubeextractor.ExtractEventInfo(watchRec.Payload)
 	if err != nil {
 		return errors.Wrap(err, "Could not extract reason")
@@ -70,6 +84,9 @@ func updateEventCountTable(
 
 	eventCountByMinute := spreadOutEvents(computedFirstTs, computedLastTs, computedCount)
 
+	if involvedObject.Uid == "" {
+		involvedObject.Uid = watchRec.InvolvedUid
+	}
 	if involvedObject.Uid == "" {
 		glog.V(common.GlogVerbose).Infof("Got empty Uid for name: %v, namespace: %v, kind: %v for event: %v ", involvedObject.Name, involvedObject.Namespace, involvedObject.Kind, newEventInfo.Reason)
 		returnedUid, err := GetUidForWatchEntry(tables, txn, involvedObject.Kind, involvedObject.Namespace, involvedObject.Name, time.Time{})
@@ -104,7 +121,11 @@ func storeMinutes(tables typed.Tables, txn badgerwrap.Txn, minToCount map[int64]
 		mapPartToTimeToCount[partitionId][unixTime] = count
 	}
 
-	for _, thisPartMap := range mapPartToTimeToCount {
+	for partitionId, thisPartMap := range mapPartToTimeToCount {
+		err := invalidateClosedPartitionChecksums(txn, partitionId)
+		if err != nil {
+			return err
+		}
 		for unixTime, count := range thisPartMap {
 
 			key := typed.NewEventCountKey(time.Unix(unixTime, 0).UTC(), kind, namespace, name, uid)
@@ -130,6 +151,10 @@ func storeMinutes(tables typed.Tables, txn badgerwrap.Txn, minToCount map[int64]
 				return errors.Wrap(err, "Failed to put")
 			}
 		}
+		err = updateEventRollups(tables, txn, thisPartMap, kind, namespace, name, uid, reason+":"+severity)
+		if err != nil {
+			return errors.Wrap(err, "Failed to update event rollups")
+		}
 	}
 	return nil
 }
@@ -0,0 +1,224 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package processing
+
+import (
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+var metricProcessingEventsFoldedCount = promauto.NewCounter(prometheus.CounterOpts{Name: "sloop_processing_events_folded_count"})
+
+// Kubernetes creates a new event object (with a new name) for the same problem whenever the old one has aged out of
+// its own dedupe cache, so a crash looping pod can produce hundreds of near identical events.  Events with the same
+// involved object, reason and message that start within the fold window of each other are folded into one record.
+// Only the first event of a fold is written to the watch table, the rest just update the fold.
+//
+// Returns true when the event was folded into a fold started by another event, along with the last copy of this
+// event seen by the fold so event counts can be computed without the watch table.
+func updateEventFoldTable(
+	tables typed.Tables,
+	txn badgerwrap.Txn,
+	watchRec *typed.KubeWatchResult,
+	metadata *kubeextractor.KubeMetadata,
+	involvedObject *kubeextractor.KubeInvolvedObject,
+	window time.Duration) (bool, *kubeextractor.EventInfo, error) {
+	ts, err := ptypes.Timestamp(watchRec.Timestamp)
+	if err != nil {
+		return false, nil, errors.Wrap(err, "Could not convert timestamp")
+	}
+	eventInfo, err := kubeextractor.ExtractEventInfo(watchRec.Payload)
+	if err != nil {
+		return false, nil, errors.Wrap(err, "Could not extract event info")
+	}
+	message, err := kubeextractor.ExtractEventMessage(watchRec.Payload)
+	if err != nil {
+		return false, nil, errors.Wrap(err, "Could not extract event message")
+	}
+	if eventInfo.FirstTimestamp.IsZero() {
+		eventInfo.FirstTimestamp = ts
+	}
+	if eventInfo.LastTimestamp.IsZero() {
+		eventInfo.LastTimestamp = eventInfo.FirstTimestamp
+	}
+
+	keyPrefix := typed.NewEventFoldKey(untyped.GetPartitionId(ts), keySafe(involvedObject.Kind), keySafe(involvedObject.Namespace),
+		keySafe(involvedObject.Name), keySafe(eventInfo.Reason), hashEventMessage(message), time.Time{})
+	key, fold, err := getLastEventFold(tables, txn, keyPrefix)
+	if err != nil {
+		return false, nil, err
+	}
+
+	if fold != nil {
+		_, isMember := fold.Events[metadata.Name]
+		lastTs, err := ptypes.Timestamp(fold.LastTimestamp)
+		if err != nil {
+			return false, nil, err
+		}
+		if !isMember && eventInfo.FirstTimestamp.Sub(lastTs) > window {
+			fold = nil
+		}
+	}
+
+	if fold == nil {
+		key = typed.NewEventFoldKey(keyPrefix.PartitionId, keyPrefix.Kind, keyPrefix.Namespace, keyPrefix.Name, keyPrefix.Reason, keyPrefix.MessageHash, eventInfo.FirstTimestamp.UTC()).String()
+		fold = &typed.EventFold{
+			Reason:          eventInfo.Reason,
+			Type:            eventInfo.Type,
+			Message:         message,
+			FirstEventName:  metadata.Name,
+			Events:          map[string]*typed.FoldedEvent{},
+			MessageTemplate: kubeextractor.NormalizeEventMessage(message),
+		}
+	}
+
+	var prevEventInfo *kubeextractor.EventInfo
+	if member, ok := fold.Events[metadata.Name]; ok {
+		prevEventInfo, err = foldedEventToEventInfo(member)
+		if err != nil {
+			return false, nil, err
+		}
+	}
+
+	member, err := eventInfoToFoldedEvent(eventInfo)
+	if err != nil {
+		return false, nil, err
+	}
+	fold.Events[metadata.Name] = member
+
+	err = recomputeEventFold(fold)
+	if err != nil {
+		return false, nil, err
+	}
+
+	err = tables.EventFoldTable().Set(txn, key, fold)
+	if err != nil {
+		return false, nil, errors.Wrap(err, "Failed to put")
+	}
+
+	folded := metadata.Name != fold.FirstEventName
+	if folded {
+		metricProcessingEventsFoldedCount.Inc()
+	}
+	return folded, prevEventInfo, nil
+}
+
+// Returns the newest fold with this prefix in the partition, or nil if there is none
+func getLastEventFold(tables typed.Tables, txn badgerwrap.Txn, keyPrefix *typed.EventFoldKey) (string, *typed.EventFold, error) {
+	keyPrefixBytes := []byte(keyPrefix.String())
+	iterOpt := badger.DefaultIteratorOptions
+	iterOpt.Prefix = keyPrefixBytes
+	iterOpt.Reverse = true
+	itr := txn.NewIterator(iterOpt)
+	defer itr.Close()
+	itr.Seek([]byte(keyPrefix.String() + string(rune(255))))
+	if !itr.ValidForPrefix(keyPrefixBytes) {
+		return "", nil, nil
+	}
+	key := string(itr.Item().Key())
+	fold, err := tables.EventFoldTable().Get(txn, key)
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "Failure getting event fold %v", key)
+	}
+	if fold.Events == nil {
+		fold.Events = map[string]*typed.FoldedEvent{}
+	}
+	return key, fold, nil
+}
+
+// The fold covers the union of the time ranges of its events, and the count is the sum of their latest counts
+func recomputeEventFold(fold *typed.EventFold) error {
+	var first, last time.Time
+	var count int32
+	for _, member := range fold.Events {
+		memberFirst, err := ptypes.Timestamp(member.FirstTimestamp)
+		if err != nil {
+			return err
+		}
+		memberLast, err := ptypes.Timestamp(member.LastTimestamp)
+		if err != nil {
+			return err
+		}
+		if first.IsZero() || memberFirst.Before(first) {
+			first = memberFirst
+		}
+		if memberLast.After(last) {
+			last = memberLast
+		}
+		count += member.Count
+	}
+	var err error
+	fold.FirstTimestamp, err = ptypes.TimestampProto(first)
+	if err != nil {
+		return err
+	}
+	fold.LastTimestamp, err = ptypes.TimestampProto(last)
+	if err != nil {
+		return err
+	}
+	fold.Count = count
+	return nil
+}
+
+func eventInfoToFoldedEvent(eventInfo *kubeextractor.EventInfo) (*typed.FoldedEvent, error) {
+	first, err := ptypes.TimestampProto(eventInfo.FirstTimestamp)
+	if err != nil {
+		return nil, err
+	}
+	last, err := ptypes.TimestampProto(eventInfo.LastTimestamp)
+	if err != nil {
+		return nil, err
+	}
+	return &typed.FoldedEvent{FirstTimestamp: first, LastTimestamp: last, Count: int32(eventInfo.Count)}, nil
+}
+
+func foldedEventToEventInfo(member *typed.FoldedEvent) (*kubeextractor.EventInfo, error) {
+	first, err := ptypes.Timestamp(member.FirstTimestamp)
+	if err != nil {
+		return nil, err
+	}
+	last, err := ptypes.Timestamp(member.LastTimestamp)
+	if err != nil {
+		return nil, err
+	}
+	return &kubeextractor.EventInfo{FirstTimestamp: first, LastTimestamp: last, Count: int(member.Count)}, nil
+}
+
+// Stores the normalized message and reason of events next to the payload, so event queries can group them
+func setEventTemplate(watchRec *typed.KubeWatchResult) {
+	if watchRec.Kind != kubeextractor.EventKind {
+		return
+	}
+	eventInfo, err := kubeextractor.ExtractEventInfo(watchRec.Payload)
+	if err != nil {
+		return
+	}
+	message, err := kubeextractor.ExtractEventMessage(watchRec.Payload)
+	if err != nil {
+		return
+	}
+	watchRec.MessageTemplate = kubeextractor.NormalizeEventMessage(message)
+	watchRec.NormalizedReason = kubeextractor.NormalizeEventReason(eventInfo.Reason)
+}
+
+func hashEventMessage(message string) string {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(message))
+	return fmt.Sprintf("%016x", h.Sum64())
+}
@@ -0,0 +1,159 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package processing
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+func helper_foldEventPayload(name string, message string, first string, last string, count int) string {
+	return fmt.Sprintf(`{"metadata":{"name":"%v","namespace":"someNamespace"},"involvedObject":{"kind":"Pod","namespace":"someNamespace","name":"somePodName","uid":"somePodUid"},"reason":"BackOff","message":"%v","firstTimestamp":"%v","lastTimestamp":"%v","count":%v,"type":"Warning"}`,
+		name, message, first, last, count)
+}
+
+func helper_processEvent(t *testing.T, r *Runner, payload string) {
+	ts, err := ptypes.TimestampProto(someWatchTime)
+	assert.Nil(t, err)
+	r.processWatchResult(&typed.KubeWatchResult{Timestamp: ts, Kind: kubeextractor.EventKind, WatchType: typed.KubeWatchResult_UPDATE, Payload: payload})
+}
+
+func Test_processWatchResult_FoldsRepeatedEvents(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	r := NewProcessing(nil, tables, false, time.Hour, 10*time.Minute, nil, 0, nil)
+
+	helper_processEvent(t, r, helper_foldEventPayload("somePodName.aa", "Back-off restarting", "2019-03-04T03:10:00Z", "2019-03-04T03:10:00Z", 1))
+	helper_processEvent(t, r, helper_foldEventPayload("somePodName.bb", "Back-off restarting", "2019-03-04T03:15:00Z", "2019-03-04T03:16:00Z", 2))
+	helper_processEvent(t, r, helper_foldEventPayload("somePodName.bb", "Back-off restarting", "2019-03-04T03:15:00Z", "2019-03-04T03:17:00Z", 3))
+	// Different message, so it starts its own fold
+	helper_processEvent(t, r, helper_foldEventPayload("somePodName.cc", "Liveness probe failed", "2019-03-04T03:18:00Z", "2019-03-04T03:19:00Z", 1))
+
+	err = db.View(func(txn badgerwrap.Txn) error {
+		watchRes, _, err := tables.WatchTable().RangeRead(txn, nil, nil, nil, someWatchTime, someWatchTime)
+		assert.Nil(t, err)
+		var names []string
+		for key := range watchRes {
+			names = append(names, key.Name)
+		}
+		assert.ElementsMatch(t, []string{"somePodName.aa", "somePodName.cc"}, names)
+
+		folds, _, err := tables.EventFoldTable().RangeRead(txn, nil, nil, nil, someWatchTime, someWatchTime)
+		assert.Nil(t, err)
+		assert.Len(t, folds, 2)
+		for key, fold := range folds {
+			if fold.FirstEventName != "somePodName.aa" {
+				continue
+			}
+			assert.Equal(t, "BackOff", key.Reason)
+			assert.Equal(t, int32(4), fold.Count)
+			assert.Len(t, fold.Events, 2)
+			lastTs, err := ptypes.Timestamp(fold.LastTimestamp)
+			assert.Nil(t, err)
+			assert.Equal(t, time.Date(2019, 3, 4, 3, 17, 0, 0, time.UTC), lastTs)
+		}
+
+		// The first event lands in an empty store so it has no partition to count into, the folded ones still count
+		eventCounts, _, err := tables.EventCountTable().RangeRead(txn, nil, nil, nil, someWatchTime, someWatchTime)
+		assert.Nil(t, err)
+		total := int32(0)
+		for _, eventCount := range eventCounts {
+			for _, counts := range eventCount.MapMinToEvents {
+				for _, count := range counts.MapReasonToCount {
+					total += count
+				}
+			}
+		}
+		assert.Equal(t, int32(4), total)
+		return nil
+	})
+	assert.Nil(t, err)
+}
+
+func Test_processWatchResult_EventOutsideWindowStartsNewFold(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	r := NewProcessing(nil, tables, false, time.Hour, 5*time.Minute, nil, 0, nil)
+
+	helper_processEvent(t, r, helper_foldEventPayload("somePodName.aa", "Back-off restarting", "2019-03-04T03:10:00Z", "2019-03-04T03:10:00Z", 1))
+	helper_processEvent(t, r, helper_foldEventPayload("somePodName.bb", "Back-off restarting", "2019-03-04T03:30:00Z", "2019-03-04T03:30:00Z", 1))
+
+	err = db.View(func(txn badgerwrap.Txn) error {
+		watchRes, _, err := tables.WatchTable().RangeRead(txn, nil, nil, nil, someWatchTime, someWatchTime)
+		assert.Nil(t, err)
+		assert.Len(t, watchRes, 2)
+		folds, _, err := tables.EventFoldTable().RangeRead(txn, nil, nil, nil, someWatchTime, someWatchTime)
+		assert.Nil(t, err)
+		assert.Len(t, folds, 2)
+		return nil
+	})
+	assert.Nil(t, err)
+}
+
+func Test_processWatchResult_NoFoldWhenDisabled(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	r := NewProcessing(nil, tables, false, time.Hour, 0, nil, 0, nil)
+
+	helper_processEvent(t, r, helper_foldEventPayload("somePodName.aa", "Back-off restarting", "2019-03-04T03:10:00Z", "2019-03-04T03:10:00Z", 1))
+
+	err = db.View(func(txn badgerwrap.Txn) error {
+		folds, _, err := tables.EventFoldTable().RangeRead(txn, nil, nil, nil, someWatchTime, someWatchTime)
+		assert.Nil(t, err)
+		assert.Len(t, folds, 0)
+		return nil
+	})
+	assert.Nil(t, err)
+}
+
+func Test_hashEventMessage(t *testing.T) {
+	assert.Equal(t, "cbf29ce484222325", hashEventMessage(""))
+	assert.NotEqual(t, hashEventMessage("a"), hashEventMessage("b"))
+}
+
+func Test_processWatchResult_StoresEventTemplate(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	r := NewProcessing(nil, tables, false, time.Hour, 10*time.Minute, nil, 0, nil)
+
+	helper_processEvent(t, r, helper_foldEventPayload("somePodName.aa", "Back-off pulling for pod web-7d9f8c6b5-x2k4q on 10.0.3.4", "2019-03-04T03:10:00Z", "2019-03-04T03:10:00Z", 1))
+
+	err = db.View(func(txn badgerwrap.Txn) error {
+		watchRes, _, err := tables.WatchTable().RangeRead(txn, nil, nil, nil, someWatchTime, someWatchTime)
+		assert.Nil(t, err)
+		assert.Len(t, watchRes, 1)
+		for _, watchRec := range watchRes {
+			assert.Equal(t, "Back-off pulling for pod web-<hash> on <ip>", watchRec.MessageTemplate)
+			assert.Equal(t, "BackOff", watchRec.NormalizedReason)
+		}
+		folds, _, err := tables.EventFoldTable().RangeRead(txn, nil, nil, nil, someWatchTime, someWatchTime)
+		assert.Nil(t, err)
+		for _, fold := range folds {
+			assert.Equal(t, "Back-off pulling for pod web-<hash> on <ip>", fold.MessageTemplate)
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+}
@@ -0,0 +1,70 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package processing
+
+import (
+	"strings"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/pkg/errors"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+/*
+Adds the counts of minToCount to the heat map buckets of the namespace they fall in, under the severity at the end of
+reasonKey.  Negative counts take them back out like for the rollups, and buckets that reach zero are dropped
+*/
+func updateEventHeatMap(txn badgerwrap.Txn, minToCount map[int64]int, namespace string, reasonKey string) error {
+	severity := reasonKey[strings.LastIndex(reasonKey, ":")+1:]
+	table := typed.OpenEventHeatMapTable()
+	keyToBuckets := map[string]map[int64]int{}
+	for unixTime, count := range minToCount {
+		minute := time.Unix(unixTime, 0).UTC()
+		key := table.Key(minute, namespace)
+		if _, ok := keyToBuckets[key]; !ok {
+			keyToBuckets[key] = map[int64]int{}
+		}
+		keyToBuckets[key][typed.EventHeatMapBucketStart(minute).Unix()] += count
+	}
+
+	for key, buckets := range keyToBuckets {
+		record, err := table.GetOrDefault(txn, key)
+		if err != nil {
+			return errors.Wrap(err, "could not get event heat map")
+		}
+		for bucket, count := range buckets {
+			counts, ok := record.MapMinToEvents[bucket]
+			if !ok || counts.MapReasonToCount == nil {
+				counts = &typed.EventCounts{MapReasonToCount: map[string]int32{}}
+				record.MapMinToEvents[bucket] = counts
+			}
+			counts.MapReasonToCount[severity] += int32(count)
+			if counts.MapReasonToCount[severity] <= 0 {
+				delete(counts.MapReasonToCount, severity)
+			}
+			if len(counts.MapReasonToCount) == 0 {
+				delete(record.MapMinToEvents, bucket)
+			}
+		}
+		if len(record.MapMinToEvents) == 0 {
+			err = txn.Delete([]byte(key))
+			if err != nil && err != badger.ErrKeyNotFound {
+				return errors.Wrapf(err, "delete failed for table %v", table.TableName())
+			}
+			continue
+		}
+		err = table.Set(txn, key, record)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
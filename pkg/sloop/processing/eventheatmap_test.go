@@ -0,0 +1,73 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package processing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+func helper_readEventHeatMap(t *testing.T, db badgerwrap.DB, ts time.Time, namespace string) *typed.ResourceEventCounts {
+	table := typed.OpenEventHeatMapTable()
+	var record *typed.ResourceEventCounts
+	err := db.View(func(txn badgerwrap.Txn) error {
+		var err2 error
+		record, err2 = table.GetOrDefault(txn, table.Key(ts, namespace))
+		return err2
+	})
+	assert.Nil(t, err)
+	return record
+}
+
+func Test_storeMinutes_UpdatesEventHeatMap(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	hour := time.Date(2021, 3, 4, 10, 0, 0, 0, time.UTC)
+	minutes := map[int64]int{}
+	for ts := hour; ts.Before(hour.Add(7 * time.Minute)); ts = ts.Add(time.Minute) {
+		minutes[ts.Unix()] = 1
+	}
+
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		err := tables.WatchTable().Set(txn, typed.NewWatchTableKey(untyped.GetPartitionId(hour), "Pod", "ns", "pod1", hour).String(), &typed.KubeWatchResult{})
+		if err != nil {
+			return err
+		}
+		err = storeMinutes(tables, txn, minutes, "Pod", "ns", "pod1", "uid1", "BackOff", "Warning")
+		if err != nil {
+			return err
+		}
+		err = storeMinutes(tables, txn, map[int64]int{hour.Unix(): 2}, "Pod", "ns", "pod2", "uid2", "Started", "Normal")
+		if err != nil {
+			return err
+		}
+		return storeMinutes(tables, txn, map[int64]int{hour.Unix(): 3}, "Node", "", "node1", "uid3", "Rebooted", "Warning")
+	})
+	assert.Nil(t, err)
+
+	record := helper_readEventHeatMap(t, db, hour, "ns")
+	assert.Len(t, record.MapMinToEvents, 2)
+	assert.Equal(t, map[string]int32{"Warning": 5, "Normal": 2}, record.MapMinToEvents[hour.Unix()].MapReasonToCount)
+	assert.Equal(t, map[string]int32{"Warning": 2}, record.MapMinToEvents[hour.Add(5*time.Minute).Unix()].MapReasonToCount)
+	cluster := helper_readEventHeatMap(t, db, hour, "")
+	assert.Equal(t, map[string]int32{"Warning": 3}, cluster.MapMinToEvents[hour.Unix()].MapReasonToCount)
+
+	// Reprocessing takes the minutes of the partition back out
+	err = subtractEventRollups(tables, untyped.GetPartitionId(hour))
+	assert.Nil(t, err)
+	assert.Len(t, helper_readEventHeatMap(t, db, hour, "ns").MapMinToEvents, 0)
+}
@@ -0,0 +1,57 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package processing
+
+import (
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+var metricEventLinkUnresolvedCount = promauto.NewCounter(prometheus.CounterOpts{Name: "sloop_event_link_unresolved_count"})
+
+/*
+Links an event to the incarnation of its involved object by setting the uid on both the involved object and the
+watch result, which keeps it in the watch table.  Most events carry the uid, for the rest it is the uid of the
+resource with that name at the time of the event, not the newest one, so events of a resource that was deleted are
+not put on the timeline of a new resource with the same name.  A result that was linked before, like one that is
+reprocessed, keeps its link
+*/
+func linkEventToInvolvedObject(tables typed.Tables, txn badgerwrap.Txn, watchRec *typed.KubeWatchResult, involvedObject *kubeextractor.KubeInvolvedObject) {
+	if watchRec.Kind != kubeextractor.EventKind {
+		return
+	}
+	if involvedObject.Uid == "" {
+		involvedObject.Uid = watchRec.InvolvedUid
+	}
+	if involvedObject.Uid == "" && involvedObject.Kind != "" && involvedObject.Name != "" {
+		eventTime, err := ptypes.Timestamp(watchRec.Timestamp)
+		if err != nil {
+			return
+		}
+		// The watch result can be well after the event when events are relisted
+		clusterTime, ok := kubeextractor.ExtractEventTime(watchRec.Kind, watchRec.Payload)
+		if ok && clusterTime.Before(eventTime) {
+			eventTime = clusterTime
+		}
+		uid, err := GetUidForWatchEntry(tables, txn, involvedObject.Kind, involvedObject.Namespace, involvedObject.Name, eventTime)
+		if err != nil || uid == "" {
+			glog.V(common.GlogVerbose).Infof("Could not link event to %v %v/%v: %v", involvedObject.Kind, involvedObject.Namespace, involvedObject.Name, err)
+			metricEventLinkUnresolvedCount.Inc()
+			return
+		}
+		involvedObject.Uid = uid
+	}
+	watchRec.InvolvedUid = involvedObject.Uid
+}
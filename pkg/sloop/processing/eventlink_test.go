@@ -0,0 +1,70 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package processing
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+func Test_linkEventToInvolvedObject(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+
+	// The pod is deleted and created again with the same name
+	for _, pod := range []struct {
+		uid    string
+		offset time.Duration
+	}{{"oldUid", 0}, {"newUid", 20 * time.Minute}} {
+		ts, _ := ptypes.TimestampProto(someWatchTime.Add(pod.offset))
+		watchRec := typed.KubeWatchResult{Kind: kubeextractor.PodKind, WatchType: typed.KubeWatchResult_ADD, Timestamp: ts,
+			Payload: fmt.Sprintf(`{"metadata":{"name":"somePod","namespace":"someNamespace","uid":"%v"}}`, pod.uid)}
+		metadata := &kubeextractor.KubeMetadata{Name: "somePod", Namespace: "someNamespace", Uid: pod.uid}
+		err = tables.Db().Update(func(txn badgerwrap.Txn) error {
+			return updateKubeWatchTable(tables, txn, &watchRec, metadata, true, SamplingPolicy{})
+		})
+		assert.Nil(t, err)
+	}
+
+	link := func(offset time.Duration, involvedObject kubeextractor.KubeInvolvedObject) (*typed.KubeWatchResult, kubeextractor.KubeInvolvedObject) {
+		ts, _ := ptypes.TimestampProto(someWatchTime.Add(offset))
+		eventRec := &typed.KubeWatchResult{Kind: kubeextractor.EventKind, Timestamp: ts, Payload: `{"reason":"Unhealthy"}`}
+		err := tables.Db().View(func(txn badgerwrap.Txn) error {
+			linkEventToInvolvedObject(tables, txn, eventRec, &involvedObject)
+			return nil
+		})
+		assert.Nil(t, err)
+		return eventRec, involvedObject
+	}
+
+	eventRec, involvedObject := link(10*time.Minute, kubeextractor.KubeInvolvedObject{Kind: kubeextractor.PodKind, Namespace: "someNamespace", Name: "somePod"})
+	assert.Equal(t, "oldUid", eventRec.InvolvedUid)
+	assert.Equal(t, "oldUid", involvedObject.Uid)
+
+	eventRec, _ = link(30*time.Minute, kubeextractor.KubeInvolvedObject{Kind: kubeextractor.PodKind, Namespace: "someNamespace", Name: "somePod"})
+	assert.Equal(t, "newUid", eventRec.InvolvedUid)
+
+	// The uid of the event wins
+	eventRec, _ = link(30*time.Minute, kubeextractor.KubeInvolvedObject{Kind: kubeextractor.PodKind, Namespace: "someNamespace", Name: "somePod", Uid: "eventUid"})
+	assert.Equal(t, "eventUid", eventRec.InvolvedUid)
+
+	eventRec, _ = link(30*time.Minute, kubeextractor.KubeInvolvedObject{Kind: kubeextractor.PodKind, Namespace: "someNamespace", Name: "otherPod"})
+	assert.Equal(t, "", eventRec.InvolvedUid)
+}
@@ -0,0 +1,157 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package processing
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/pkg/errors"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+/*
+Adds the counts of minToCount to the hour and day buckets they fall in, and to the namespace event heat map.  Negative
+counts take them back out, and buckets that reach zero are dropped.  A bucket that starts in a partition older than the
+store is skipped, since GC already took the rest of it and queries only read buckets that start inside the store
+*/
+func updateEventRollups(tables typed.Tables, txn badgerwrap.Txn, minToCount map[int64]int, kind string, namespace string, name string, uid string, reasonKey string) error {
+	minPartition := ""
+	for _, table := range typed.EventRollupTables() {
+		keyToBuckets := map[string]map[int64]int{}
+		for unixTime, count := range minToCount {
+			minute := time.Unix(unixTime, 0).UTC()
+			key := table.Key(minute, kind, namespace, name, uid)
+			if _, ok := keyToBuckets[key]; !ok {
+				keyToBuckets[key] = map[int64]int{}
+			}
+			keyToBuckets[key][typed.EventRollupBucketStart(table.Granularity(), minute).Unix()] += count
+		}
+
+		for key, buckets := range keyToBuckets {
+			parsed, err := table.ParseKey(key)
+			if err != nil {
+				return err
+			}
+			if parsed.PartitionId < untyped.GetPartitionId(minMinute(minToCount)) {
+				if minPartition == "" {
+					_, minPartition, _ = tables.GetMinAndMaxPartitionWithTxn(txn)
+				}
+				if parsed.PartitionId < minPartition {
+					continue
+				}
+			}
+			err = addToEventRollup(table, txn, key, buckets, reasonKey)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return updateEventHeatMap(txn, minToCount, namespace, reasonKey)
+}
+
+func addToEventRollup(table *typed.EventRollupTable, txn badgerwrap.Txn, key string, buckets map[int64]int, reasonKey string) error {
+	record, err := table.GetOrDefault(txn, key)
+	if err != nil {
+		return errors.Wrap(err, "could not get event rollup")
+	}
+	for bucket, count := range buckets {
+		counts, ok := record.MapMinToEvents[bucket]
+		if !ok {
+			counts = &typed.EventCounts{MapReasonToCount: map[string]int32{}}
+			record.MapMinToEvents[bucket] = counts
+		}
+		if counts.MapReasonToCount == nil {
+			counts.MapReasonToCount = map[string]int32{}
+		}
+		counts.MapReasonToCount[reasonKey] += int32(count)
+		if counts.MapReasonToCount[reasonKey] <= 0 {
+			delete(counts.MapReasonToCount, reasonKey)
+		}
+		if len(counts.MapReasonToCount) == 0 {
+			delete(record.MapMinToEvents, bucket)
+		}
+	}
+	if len(record.MapMinToEvents) == 0 {
+		err = txn.Delete([]byte(key))
+		if err != nil && err != badger.ErrKeyNotFound {
+			return errors.Wrapf(err, "delete failed for table %v", table.TableName())
+		}
+		return nil
+	}
+	return table.Set(txn, key, record)
+}
+
+// Takes the event counts of the partition back out of the rollups, before reprocessing rebuilds them.  Rollups of
+// other partitions can hold minutes of this one, so they can not just be cleared
+func subtractEventRollups(tables typed.Tables, partition string) error {
+	prefix := []byte(fmt.Sprintf("/%v/%v/", (&typed.EventCountKey{}).TableName(), partition))
+	keys := []string{}
+	err := tables.Db().View(func(txn badgerwrap.Txn) error {
+		iterOpt := badger.DefaultIteratorOptions
+		iterOpt.PrefetchValues = false
+		iterOpt.Prefix = prefix
+		itr := txn.NewIterator(iterOpt)
+		defer itr.Close()
+		for itr.Seek(prefix); itr.ValidForPrefix(prefix); itr.Next() {
+			keys = append(keys, string(itr.Item().KeyCopy(nil)))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		k := &typed.EventCountKey{}
+		err = k.Parse(key)
+		if err != nil {
+			return err
+		}
+		err = tables.Db().Update(func(txn badgerwrap.Txn) error {
+			record, err := tables.EventCountTable().Get(txn, key)
+			if err != nil {
+				return err
+			}
+			reasonToMinutes := map[string]map[int64]int{}
+			for minute, counts := range record.MapMinToEvents {
+				for reasonKey, count := range counts.MapReasonToCount {
+					if _, ok := reasonToMinutes[reasonKey]; !ok {
+						reasonToMinutes[reasonKey] = map[int64]int{}
+					}
+					reasonToMinutes[reasonKey][minute] = -int(count)
+				}
+			}
+			for reasonKey, minToCount := range reasonToMinutes {
+				err = updateEventRollups(tables, txn, minToCount, k.Kind, k.Namespace, k.Name, k.Uid, reasonKey)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func minMinute(minToCount map[int64]int) time.Time {
+	first := int64(0)
+	for unixTime := range minToCount {
+		if first == 0 || unixTime < first {
+			first = unixTime
+		}
+	}
+	return time.Unix(first, 0).UTC()
+}
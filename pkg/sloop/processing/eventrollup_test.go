@@ -0,0 +1,87 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package processing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+func helper_readEventRollup(t *testing.T, db badgerwrap.DB, granularity string, ts time.Time) *typed.ResourceEventCounts {
+	table := typed.OpenEventRollupTable(granularity)
+	var record *typed.ResourceEventCounts
+	err := db.View(func(txn badgerwrap.Txn) error {
+		var err error
+		record, err = table.GetOrDefault(txn, table.Key(ts, "Pod", "ns", "pod1", "uid1"))
+		return err
+	})
+	assert.Nil(t, err)
+	return record
+}
+
+func Test_storeMinutes_UpdatesEventRollups(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	dayStart := time.Date(2021, 3, 4, 0, 0, 0, 0, time.UTC)
+	minutes := map[int64]int{}
+	for ts := dayStart.Add(10*time.Hour + 58*time.Minute); ts.Before(dayStart.Add(11*time.Hour + 2*time.Minute)); ts = ts.Add(time.Minute) {
+		minutes[ts.Unix()] = 2
+	}
+
+	// The partition of the day start is in the store, so the day bucket is kept
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		err := tables.WatchTable().Set(txn, typed.NewWatchTableKey(untyped.GetPartitionId(dayStart), "Pod", "ns", "pod1", dayStart).String(), &typed.KubeWatchResult{})
+		if err != nil {
+			return err
+		}
+		return storeMinutes(tables, txn, minutes, "Pod", "ns", "pod1", "uid1", "BackOff", "Warning")
+	})
+	assert.Nil(t, err)
+
+	tenOClock := helper_readEventRollup(t, db, typed.EventRollupHour, dayStart.Add(10*time.Hour))
+	assert.Equal(t, int32(4), tenOClock.MapMinToEvents[dayStart.Add(10*time.Hour).Unix()].MapReasonToCount["BackOff:Warning"])
+	elevenOClock := helper_readEventRollup(t, db, typed.EventRollupHour, dayStart.Add(11*time.Hour))
+	assert.Equal(t, int32(4), elevenOClock.MapMinToEvents[dayStart.Add(11*time.Hour).Unix()].MapReasonToCount["BackOff:Warning"])
+	day := helper_readEventRollup(t, db, typed.EventRollupDay, dayStart)
+	assert.Equal(t, int32(8), day.MapMinToEvents[dayStart.Unix()].MapReasonToCount["BackOff:Warning"])
+
+	// Reprocessing takes the minutes of a partition back out
+	err = subtractEventRollups(tables, untyped.GetPartitionId(dayStart.Add(11*time.Hour)))
+	assert.Nil(t, err)
+	elevenOClock = helper_readEventRollup(t, db, typed.EventRollupHour, dayStart.Add(11*time.Hour))
+	assert.Len(t, elevenOClock.MapMinToEvents, 0)
+	day = helper_readEventRollup(t, db, typed.EventRollupDay, dayStart)
+	assert.Equal(t, int32(4), day.MapMinToEvents[dayStart.Unix()].MapReasonToCount["BackOff:Warning"])
+}
+
+func Test_storeMinutes_SkipsRollupsOlderThanTheStore(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	dayStart := time.Date(2021, 3, 4, 0, 0, 0, 0, time.UTC)
+	minute := dayStart.Add(10*time.Hour + 30*time.Minute)
+
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		return storeMinutes(tables, txn, map[int64]int{minute.Unix(): 3}, "Pod", "ns", "pod1", "uid1", "BackOff", "Warning")
+	})
+	assert.Nil(t, err)
+
+	hour := helper_readEventRollup(t, db, typed.EventRollupHour, minute)
+	assert.Equal(t, int32(3), hour.MapMinToEvents[dayStart.Add(10*time.Hour).Unix()].MapReasonToCount["BackOff:Warning"])
+	assert.Len(t, helper_readEventRollup(t, db, typed.EventRollupDay, minute).MapMinToEvents, 0)
+}
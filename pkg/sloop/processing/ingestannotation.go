@@ -0,0 +1,91 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package processing
+
+import (
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+// An api server that throttles or a watch that keeps failing produces an error every few seconds, so repeats this
+// close to the last one are merged into the same record
+const ingestAnnotationMergeWindow = time.Minute
+
+// Annotations that merge over a longer time
+var ingestAnnotationMergeWindows = map[string]time.Duration{typed.IngestAnnotationClusterUpgrade: typed.ClusterUpgradeMergeWindow}
+
+// Stores the annotations sent by the kube watcher until the channel is closed.  Wait also waits for these
+func (r *Runner) StartIngestAnnotations(annotationChan chan *typed.IngestAnnotation) {
+	r.inputWg.Add(1)
+	go func() {
+		defer r.inputWg.Done()
+		for annotation := range annotationChan {
+			err := r.tables.Db().Update(func(txn badgerwrap.Txn) error {
+				return updateIngestAnnotationTable(r.tables, txn, annotation)
+			})
+			if err != nil {
+				r.processingFailed("updateIngestAnnotationTable", err)
+			}
+		}
+	}()
+}
+
+func updateIngestAnnotationTable(tables typed.Tables, txn badgerwrap.Txn, annotation *typed.IngestAnnotation) error {
+	ts, err := ptypes.Timestamp(annotation.FirstSeen)
+	if err != nil {
+		return errors.Wrap(err, "Could not convert timestamp")
+	}
+	glog.V(2).Infof("Ingest annotation %v for kind %q: %v", annotation.Type, annotation.Kind, annotation.Message)
+
+	key := typed.NewIngestAnnotationKey(untyped.GetPartitionId(ts), keySafe(annotation.Kind), keySafe(annotation.Namespace), annotation.Type, time.Time{})
+	lastKey, last, err := getLastIngestAnnotation(tables, txn, key.String()+"/")
+	if err != nil {
+		return err
+	}
+	if last != nil {
+		lastSeen, err := ptypes.Timestamp(last.LastSeen)
+		mergeWindow := ingestAnnotationMergeWindow
+		if window, ok := ingestAnnotationMergeWindows[annotation.Type]; ok {
+			mergeWindow = window
+		}
+		if err == nil && ts.Sub(lastSeen) <= mergeWindow {
+			last.Count += annotation.Count
+			last.LastSeen = annotation.LastSeen
+			last.Message = annotation.Message
+			return tables.IngestAnnotationTable().Set(txn, lastKey, last)
+		}
+	}
+
+	key.Timestamp = ts
+	return tables.IngestAnnotationTable().Set(txn, key.String(), annotation)
+}
+
+// Keys end in the first timestamp, so the last key of the prefix is the newest record
+func getLastIngestAnnotation(tables typed.Tables, txn badgerwrap.Txn, prefix string) (string, *typed.IngestAnnotation, error) {
+	lastKey := ""
+	itr := txn.NewIterator(badger.IteratorOptions{Prefix: []byte(prefix)})
+	for itr.Seek([]byte(prefix)); itr.ValidForPrefix([]byte(prefix)); itr.Next() {
+		lastKey = string(itr.Item().Key())
+	}
+	itr.Close()
+	if lastKey == "" {
+		return "", nil, nil
+	}
+	last, err := tables.IngestAnnotationTable().Get(txn, lastKey)
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "failed to read ingest annotation %v", lastKey)
+	}
+	return lastKey, last, nil
+}
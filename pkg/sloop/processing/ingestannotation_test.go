@@ -0,0 +1,67 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package processing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+func helper_ingestAnnotation(t *testing.T, annotationType string, message string, ts time.Time) *typed.IngestAnnotation {
+	seen, err := ptypes.TimestampProto(ts)
+	assert.Nil(t, err)
+	return &typed.IngestAnnotation{Type: annotationType, Kind: "Pod", Message: message, Count: 1, FirstSeen: seen, LastSeen: seen}
+}
+
+func Test_updateIngestAnnotationTable_MergesRepeats(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	start := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	annotations := []*typed.IngestAnnotation{
+		helper_ingestAnnotation(t, typed.IngestAnnotationThrottled, "first", start),
+		helper_ingestAnnotation(t, typed.IngestAnnotationThrottled, "second", start.Add(30*time.Second)),
+		helper_ingestAnnotation(t, typed.IngestAnnotationWatchError, "other type", start.Add(40*time.Second)),
+		// Too long after the last repeat, so it starts a new record
+		helper_ingestAnnotation(t, typed.IngestAnnotationThrottled, "third", start.Add(5*time.Minute)),
+	}
+	for _, annotation := range annotations {
+		err = db.Update(func(txn badgerwrap.Txn) error {
+			return updateIngestAnnotationTable(tables, txn, annotation)
+		})
+		assert.Nil(t, err)
+	}
+
+	partitionId := untyped.GetPartitionId(start)
+	keys := common.GetKeysForPrefix(db, "")
+	assert.ElementsMatch(t, []string{
+		typed.NewIngestAnnotationKey(partitionId, "Pod", "", typed.IngestAnnotationThrottled, start).String(),
+		typed.NewIngestAnnotationKey(partitionId, "Pod", "", typed.IngestAnnotationWatchError, start.Add(40*time.Second)).String(),
+		typed.NewIngestAnnotationKey(partitionId, "Pod", "", typed.IngestAnnotationThrottled, start.Add(5*time.Minute)).String(),
+	}, keys)
+
+	err = db.View(func(txn badgerwrap.Txn) error {
+		merged, err := tables.IngestAnnotationTable().Get(txn, typed.NewIngestAnnotationKey(partitionId, "Pod", "", typed.IngestAnnotationThrottled, start).String())
+		assert.Nil(t, err)
+		assert.Equal(t, int64(2), merged.Count)
+		assert.Equal(t, "second", merged.Message)
+		assert.Equal(t, start.Add(30*time.Second).Unix(), merged.LastSeen.Seconds)
+		return nil
+	})
+	assert.Nil(t, err)
+}
@@ -0,0 +1,95 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package processing
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+// Records a transition whenever the status or reason of a node condition changes.  Heartbeats and messages are
+// ignored because they change on nearly every update.  The record also keeps the node pool attributes from the labels
+// of the node, so pod history can be grouped by zone or machine type.
+func updateNodeConditionTable(tables typed.Tables, txn badgerwrap.Txn, watchRec *typed.KubeWatchResult, metadata *kubeextractor.KubeMetadata) error {
+	if watchRec.Kind != kubeextractor.NodeKind {
+		return nil
+	}
+
+	timestamp, err := ptypes.Timestamp(watchRec.Timestamp)
+	if err != nil {
+		return errors.Wrapf(err, "Could not convert timestamp %v", watchRec.Timestamp)
+	}
+
+	conditions, err := kubeextractor.ExtractNodeConditions(watchRec.Payload)
+	if err != nil {
+		return errors.Wrap(err, "Could not extract node conditions")
+	}
+
+	key := typed.NewNodeConditionKey(untyped.GetPartitionId(timestamp), metadata.Name, metadata.Uid)
+	record, err := tables.NodeConditionTable().GetOrDefault(txn, key.String())
+	if err != nil {
+		return errors.Wrap(err, "Could not get node condition record")
+	}
+
+	lastByType := map[string]*typed.NodeConditionTransition{}
+	for _, transition := range record.Transitions {
+		lastByType[transition.Type] = transition
+	}
+
+	changed := false
+	for _, condition := range conditions {
+		last, ok := lastByType[condition.Type]
+		if ok && last.Status == condition.Status && last.Reason == condition.Reason {
+			continue
+		}
+		var lastTransitionTime int64
+		if !condition.LastTransitionTime.IsZero() {
+			lastTransitionTime = condition.LastTransitionTime.Unix()
+		}
+		record.Transitions = append(record.Transitions, &typed.NodeConditionTransition{
+			Timestamp:          timestamp.Unix(),
+			Type:               condition.Type,
+			Status:             condition.Status,
+			Reason:             condition.Reason,
+			Message:            condition.Message,
+			LastTransitionTime: lastTransitionTime,
+		})
+		changed = true
+	}
+
+	pool, err := nodePoolOfPayload(watchRec.Payload)
+	if err != nil {
+		return errors.Wrap(err, "Could not extract node pool")
+	}
+	if !proto.Equal(pool, record.Pool) {
+		record.Pool = pool
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+
+	err = tables.NodeConditionTable().Set(txn, key.String(), record)
+	if err != nil {
+		return errors.Wrap(err, "Failed to put node condition record")
+	}
+	return nil
+}
+
+func nodePoolOfPayload(payload string) (*typed.NodePool, error) {
+	pool, err := kubeextractor.ExtractNodePool(payload)
+	if err != nil {
+		return nil, err
+	}
+	return &typed.NodePool{Zone: pool.Zone, Region: pool.Region, InstanceType: pool.InstanceType, Arch: pool.Arch, Os: pool.Os, Pool: pool.Pool}, nil
+}
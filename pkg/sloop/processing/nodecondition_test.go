@@ -0,0 +1,103 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package processing
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+func helper_conditionNodePayload(heartbeat string, readyStatus string, readyReason string) string {
+	return fmt.Sprintf(`{"metadata":{"name":"someNode","uid":"someNodeUid"},"status":{"conditions":[
+{"type":"MemoryPressure","status":"False","reason":"KubeletHasSufficientMemory","lastHeartbeatTime":"%v"},
+{"type":"Ready","status":"%v","reason":"%v","lastHeartbeatTime":"%v","lastTransitionTime":"2019-03-04T03:00:00Z"}]}}`,
+		heartbeat, readyStatus, readyReason, heartbeat)
+}
+
+func Test_updateNodeConditionTable_RecordsOnlyTransitions(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+
+	payloads := []string{
+		helper_conditionNodePayload("2019-03-04T03:04:05Z", "True", "KubeletReady"),
+		// Only the heartbeat changed
+		helper_conditionNodePayload("2019-03-04T03:05:05Z", "True", "KubeletReady"),
+		helper_conditionNodePayload("2019-03-04T03:06:05Z", "Unknown", "NodeStatusUnknown"),
+	}
+	for idx, payload := range payloads {
+		ts, err := ptypes.TimestampProto(someWatchTime.Add(time.Duration(idx) * time.Minute))
+		assert.Nil(t, err)
+		watchRec := &typed.KubeWatchResult{Kind: kubeextractor.NodeKind, WatchType: typed.KubeWatchResult_UPDATE, Timestamp: ts, Payload: payload}
+		metadata, err := kubeextractor.ExtractMetadata(watchRec.Payload)
+		assert.Nil(t, err)
+		err = db.Update(func(txn badgerwrap.Txn) error {
+			return updateNodeConditionTable(tables, txn, watchRec, &metadata)
+		})
+		assert.Nil(t, err)
+	}
+
+	err = db.View(func(txn badgerwrap.Txn) error {
+		key := typed.NewNodeConditionKey(untyped.GetPartitionId(someWatchTime), "someNode", "someNodeUid")
+		record, err := tables.NodeConditionTable().Get(txn, key.String())
+		assert.Nil(t, err)
+		assert.Len(t, record.Transitions, 3)
+		assert.Equal(t, "MemoryPressure", record.Transitions[0].Type)
+		assert.Equal(t, "Ready", record.Transitions[1].Type)
+		assert.Equal(t, "True", record.Transitions[1].Status)
+		assert.Equal(t, time.Date(2019, 3, 4, 3, 0, 0, 0, time.UTC).Unix(), record.Transitions[1].LastTransitionTime)
+		assert.Equal(t, "Unknown", record.Transitions[2].Status)
+		assert.Equal(t, someWatchTime.Add(2*time.Minute).Unix(), record.Transitions[2].Timestamp)
+		return nil
+	})
+	assert.Nil(t, err)
+}
+
+func Test_updateNodeConditionTable_RecordsNodePool(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+
+	payloads := []string{
+		`{"metadata":{"name":"someNode","uid":"someNodeUid","labels":{"topology.kubernetes.io/zone":"us-east-1a"}},"status":{"nodeInfo":{"architecture":"arm64"}}}`,
+		// Relabeled without a condition change
+		`{"metadata":{"name":"someNode","uid":"someNodeUid","labels":{"topology.kubernetes.io/zone":"us-east-1b"}},"status":{"nodeInfo":{"architecture":"arm64"}}}`,
+	}
+	for idx, payload := range payloads {
+		ts, err := ptypes.TimestampProto(someWatchTime.Add(time.Duration(idx) * time.Minute))
+		assert.Nil(t, err)
+		watchRec := &typed.KubeWatchResult{Kind: kubeextractor.NodeKind, WatchType: typed.KubeWatchResult_UPDATE, Timestamp: ts, Payload: payload}
+		metadata, err := kubeextractor.ExtractMetadata(watchRec.Payload)
+		assert.Nil(t, err)
+		err = db.Update(func(txn badgerwrap.Txn) error {
+			return updateNodeConditionTable(tables, txn, watchRec, &metadata)
+		})
+		assert.Nil(t, err)
+	}
+
+	err = db.View(func(txn badgerwrap.Txn) error {
+		key := typed.NewNodeConditionKey(untyped.GetPartitionId(someWatchTime), "someNode", "someNodeUid")
+		record, err := tables.NodeConditionTable().Get(txn, key.String())
+		assert.Nil(t, err)
+		assert.Equal(t, "us-east-1b", record.Pool.Zone)
+		assert.Equal(t, "arm64", record.Pool.Arch)
+		return nil
+	})
+	assert.Nil(t, err)
+}
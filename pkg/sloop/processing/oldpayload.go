@@ -0,0 +1,88 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package processing
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+)
+
+// Versions of resources that reached the watch table only as the old object of an update or as the final state of a
+// delete, because the watch result that had them on its own was never seen
+var metricProcessingUnobservedVersionCount = promauto.NewCounterVec(prometheus.CounterOpts{Name: "sloop_processing_unobserved_version_count"}, []string{"kind", "source"})
+
+/*
+Keeps the old object of an update only when it is a version of the resource that was not stored, which happens when
+a watch result was missed or lost before it reached the store.  Otherwise it is the same as the previous stored
+payload and is dropped to save space.  keepGaps is false when the gap is on purpose.  Deletes have no old object, their
+payload is already the final state.  This only counts the deletes the watch missed whose cached final state no stored
+update had
+*/
+func setOldPayload(prevStored *typed.KubeWatchResult, watchRec *typed.KubeWatchResult, metadata *kubeextractor.KubeMetadata, keepGaps bool) {
+	if watchRec.WatchType == typed.KubeWatchResult_DELETE {
+		watchRec.OldPayload = ""
+		if watchRec.FinalState == typed.FinalStateCache && prevStored != nil && !sameStoredVersion(prevStored.Payload, watchRec.Payload, metadata) {
+			metricProcessingUnobservedVersionCount.WithLabelValues(watchRec.Kind, "delete_final_state").Inc()
+		}
+		return
+	}
+	if watchRec.OldPayload == "" {
+		return
+	}
+	if watchRec.WatchType != typed.KubeWatchResult_UPDATE || !keepGaps || (prevStored != nil && sameStoredVersion(prevStored.Payload, watchRec.OldPayload, nil)) {
+		watchRec.OldPayload = ""
+		return
+	}
+	oldMetadata, err := kubeextractor.ExtractMetadata(watchRec.OldPayload)
+	if err != nil || oldMetadata.Uid != metadata.Uid {
+		watchRec.OldPayload = ""
+		return
+	}
+	metricProcessingUnobservedVersionCount.WithLabelValues(watchRec.Kind, "update_old_object").Inc()
+}
+
+// True when the old object of an update is another version of the resource than the previous stored one, so the
+// watch result that had it on its own was missed.  Nothing counts as missed before the first stored version
+func missedOldObject(prevStored *typed.KubeWatchResult, watchRec *typed.KubeWatchResult, metadata *kubeextractor.KubeMetadata) bool {
+	if watchRec.WatchType != typed.KubeWatchResult_UPDATE || watchRec.OldPayload == "" || prevStored == nil {
+		return false
+	}
+	if sameStoredVersion(prevStored.Payload, watchRec.OldPayload, nil) {
+		return false
+	}
+	oldMetadata, err := kubeextractor.ExtractMetadata(watchRec.OldPayload)
+	return err == nil && oldMetadata.Uid == metadata.Uid && oldMetadata.ResourceVersion != ""
+}
+
+// Sampling and dropping minor node updates leave gaps on purpose
+func keepsVersionGaps(kind string, keepMinorNodeUpdates bool, sampling SamplingPolicy) bool {
+	return sampling.MinInterval <= 0 && (kind != kubeextractor.NodeKind || keepMinorNodeUpdates)
+}
+
+// True when both payloads are the same version of the same resource.  Metadata of payload can be passed when it is
+// already extracted
+func sameStoredVersion(storedPayload string, payload string, metadata *kubeextractor.KubeMetadata) bool {
+	if storedPayload == payload {
+		return true
+	}
+	storedMetadata, err := kubeextractor.ExtractMetadata(storedPayload)
+	if err != nil {
+		return false
+	}
+	if metadata == nil {
+		extracted, err := kubeextractor.ExtractMetadata(payload)
+		if err != nil {
+			return false
+		}
+		metadata = &extracted
+	}
+	return metadata.ResourceVersion != "" && storedMetadata.Uid == metadata.Uid && storedMetadata.ResourceVersion == metadata.ResourceVersion
+}
@@ -0,0 +1,110 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package processing
+
+import (
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+// Keeps one edge per (owner, child) in the owner graph table.  This has to run before the watch table is updated,
+// because owners that were removed are found by comparing against the previous copy of the resource.
+func updateOwnerGraphTable(tables typed.Tables, txn badgerwrap.Txn, watchRec *typed.KubeWatchResult, metadata *kubeextractor.KubeMetadata) error {
+	if watchRec.Kind == kubeextractor.EventKind || metadata.Uid == "" {
+		return nil
+	}
+
+	timestamp, err := ptypes.Timestamp(watchRec.Timestamp)
+	if err != nil {
+		return errors.Wrapf(err, "Could not convert timestamp %v", watchRec.Timestamp)
+	}
+	partitionId := untyped.GetPartitionId(timestamp)
+	deleted := watchRec.WatchType == typed.KubeWatchResult_DELETE
+
+	currentOwners := map[string]bool{}
+	for _, owner := range metadata.OwnerReferences {
+		if owner.Uid == "" {
+			continue
+		}
+		currentOwners[owner.Uid] = true
+		key := typed.NewOwnerEdgeKey(partitionId, owner.Uid, watchRec.Kind, metadata.Namespace, metadata.Uid)
+		edge, err := tables.OwnerGraphTable().GetOrDefault(txn, key.String())
+		if err != nil {
+			return errors.Wrap(err, "Could not get owner edge")
+		}
+		edge.OwnerKind = owner.Kind
+		edge.OwnerName = owner.Name
+		edge.ChildName = metadata.Name
+
+		last := lastOwnerEdgeInterval(edge)
+		if last == nil || last.End != 0 {
+			if deleted && last != nil {
+				continue
+			}
+			last = &typed.OwnerEdgeInterval{Start: timestamp.Unix()}
+			edge.Intervals = append(edge.Intervals, last)
+		}
+		last.LastSeen = timestamp.Unix()
+		if deleted {
+			last.End = timestamp.Unix()
+		}
+
+		err = tables.OwnerGraphTable().Set(txn, key.String(), edge)
+		if err != nil {
+			return errors.Wrap(err, "Failed to put owner edge")
+		}
+	}
+
+	prevWatch, err := getLastKubeWatchResult(tables, txn, watchRec.Timestamp, watchRec.Kind, metadata.Namespace, metadata.Name)
+	if err != nil {
+		return errors.Wrap(err, "Could not get previous watch result")
+	}
+	if prevWatch == nil {
+		return nil
+	}
+	prevMetadata, err := kubeextractor.ExtractMetadata(prevWatch.Payload)
+	if err != nil {
+		return errors.Wrap(err, "Cannot extract resource metadata")
+	}
+	// A resource that was deleted and created again with the same name is a different child
+	if prevMetadata.Uid != metadata.Uid {
+		return nil
+	}
+
+	for _, owner := range prevMetadata.OwnerReferences {
+		if owner.Uid == "" || currentOwners[owner.Uid] {
+			continue
+		}
+		key := typed.NewOwnerEdgeKey(partitionId, owner.Uid, watchRec.Kind, metadata.Namespace, metadata.Uid)
+		edge, err := tables.OwnerGraphTable().GetOrDefault(txn, key.String())
+		if err != nil {
+			return errors.Wrap(err, "Could not get owner edge")
+		}
+		last := lastOwnerEdgeInterval(edge)
+		if last == nil || last.End != 0 {
+			continue
+		}
+		last.End = timestamp.Unix()
+		err = tables.OwnerGraphTable().Set(txn, key.String(), edge)
+		if err != nil {
+			return errors.Wrap(err, "Failed to put owner edge")
+		}
+	}
+	return nil
+}
+
+func lastOwnerEdgeInterval(edge *typed.OwnerEdge) *typed.OwnerEdgeInterval {
+	if len(edge.Intervals) == 0 {
+		return nil
+	}
+	return edge.Intervals[len(edge.Intervals)-1]
+}
@@ -0,0 +1,71 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package processing
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+func helper_ownedPodPayload(resourceVersion int, ownerUid string) string {
+	return fmt.Sprintf(`{"metadata":{"name":"somePodName","namespace":"someNamespace","uid":"somePodUid","resourceVersion":"%v","ownerReferences":[{"kind":"ReplicaSet","name":"someRs","uid":"%v"}]}}`,
+		resourceVersion, ownerUid)
+}
+
+func Test_updateOwnerGraphTable_TracksIntervals(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	r := NewProcessing(nil, tables, false, time.Hour, 0, nil, 0, nil)
+
+	updates := []struct {
+		payload   string
+		watchType typed.KubeWatchResult_WatchType
+	}{
+		{helper_ownedPodPayload(1, "rsUid1"), typed.KubeWatchResult_ADD},
+		{helper_ownedPodPayload(2, "rsUid1"), typed.KubeWatchResult_UPDATE},
+		// Adopted by another owner
+		{helper_ownedPodPayload(3, "rsUid2"), typed.KubeWatchResult_UPDATE},
+		{helper_ownedPodPayload(4, "rsUid2"), typed.KubeWatchResult_DELETE},
+	}
+	for idx, update := range updates {
+		ts, err := ptypes.TimestampProto(someWatchTime.Add(time.Duration(idx) * time.Minute))
+		assert.Nil(t, err)
+		r.processWatchResult(&typed.KubeWatchResult{Kind: kubeextractor.PodKind, WatchType: update.watchType, Timestamp: ts, Payload: update.payload})
+	}
+
+	partitionId := untyped.GetPartitionId(someWatchTime)
+	err = db.View(func(txn badgerwrap.Txn) error {
+		edge, err := tables.OwnerGraphTable().Get(txn, typed.NewOwnerEdgeKey(partitionId, "rsUid1", kubeextractor.PodKind, "someNamespace", "somePodUid").String())
+		assert.Nil(t, err)
+		assert.Equal(t, "ReplicaSet", edge.OwnerKind)
+		assert.Equal(t, "someRs", edge.OwnerName)
+		assert.Equal(t, "somePodName", edge.ChildName)
+		assert.Equal(t, []*typed.OwnerEdgeInterval{{Start: someWatchTime.Unix(), End: someWatchTime.Add(2 * time.Minute).Unix(), LastSeen: someWatchTime.Add(time.Minute).Unix()}}, edge.Intervals)
+
+		edge, err = tables.OwnerGraphTable().Get(txn, typed.NewOwnerEdgeKey(partitionId, "rsUid2", kubeextractor.PodKind, "someNamespace", "somePodUid").String())
+		assert.Nil(t, err)
+		assert.Equal(t, []*typed.OwnerEdgeInterval{{Start: someWatchTime.Add(2 * time.Minute).Unix(), End: someWatchTime.Add(3 * time.Minute).Unix(), LastSeen: someWatchTime.Add(3 * time.Minute).Unix()}}, edge.Intervals)
+
+		children, _, err := tables.OwnerGraphTable().RangeRead(txn, typed.NewOwnerEdgeKeyComparator("rsUid2"), nil, nil, someWatchTime, someWatchTime)
+		assert.Nil(t, err)
+		assert.Len(t, children, 1)
+		return nil
+	})
+	assert.Nil(t, err)
+}
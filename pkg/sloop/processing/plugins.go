@@ -0,0 +1,73 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package processing
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+// A Processor is called for every watch result after the core tables have been updated for it.  It can write to its
+// own tables through the transaction, or send the result somewhere else.  Keys in those tables should follow the
+// usual /<table name>/<partition id>/... layout so partition GC ages them out with everything else.  A table created
+// with typed.NewProtoTable and registered with typed.RegisterTable does this for any proto value, and also shows up in
+// the debug pages.
+//
+// Processors are registered once at startup, typically from an init() in a binary that wraps server.RealMain.
+type Processor interface {
+	Name() string
+	// Tables this processor writes to, can be empty
+	TableNames() []string
+	// Each call gets its own transaction, so returning an error only discards the writes of this processor
+	Process(tables typed.Tables, txn badgerwrap.Txn, watchRec *typed.KubeWatchResult, metadata *kubeextractor.KubeMetadata) error
+}
+
+var (
+	processorsLock       = &sync.Mutex{}
+	registeredProcessors []Processor
+)
+
+func RegisterProcessor(processor Processor) error {
+	processorsLock.Lock()
+	defer processorsLock.Unlock()
+	for _, existing := range registeredProcessors {
+		if existing.Name() == processor.Name() {
+			return fmt.Errorf("processor %q is already registered", processor.Name())
+		}
+	}
+	registeredProcessors = append(registeredProcessors, processor)
+	for _, tableName := range processor.TableNames() {
+		typed.RegisterTableName(tableName)
+	}
+	return nil
+}
+
+func getRegisteredProcessors() []Processor {
+	processorsLock.Lock()
+	defer processorsLock.Unlock()
+	return append([]Processor{}, registeredProcessors...)
+}
+
+func (r *Runner) runProcessors(watchRec *typed.KubeWatchResult, metadata *kubeextractor.KubeMetadata, stageErrors map[string]string) {
+	for _, processor := range r.processors {
+		processor := processor
+		r.runStage("processor "+processor.Name(), watchRec, stageErrors, func(txn badgerwrap.Txn) (err error) {
+			// A broken plugin should not stop ingestion
+			defer func() {
+				if p := recover(); p != nil {
+					err = fmt.Errorf("processor panicked: %v", p)
+				}
+			}()
+			return processor.Process(r.tables, txn, watchRec, metadata)
+		})
+	}
+}
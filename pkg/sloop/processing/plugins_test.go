@@ -0,0 +1,83 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package processing
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeProcessor struct {
+	name    string
+	err     error
+	doPanic bool
+}
+
+func (p *fakeProcessor) Name() string {
+	return p.name
+}
+
+func (p *fakeProcessor) TableNames() []string {
+	return []string{p.name}
+}
+
+func (p *fakeProcessor) Process(tables typed.Tables, txn badgerwrap.Txn, watchRec *typed.KubeWatchResult, metadata *kubeextractor.KubeMetadata) error {
+	if p.doPanic {
+		panic("boom")
+	}
+	err := txn.Set([]byte(fmt.Sprintf("/%v/%v/%v", p.name, watchRec.Kind, metadata.Name)), []byte{})
+	if err != nil {
+		return err
+	}
+	return p.err
+}
+
+func Test_RegisterProcessor_RejectsDuplicateAndRegistersTable(t *testing.T) {
+	assert.Nil(t, RegisterProcessor(&fakeProcessor{name: "registertest"}))
+	assert.NotNil(t, RegisterProcessor(&fakeProcessor{name: "registertest"}))
+
+	tables := typed.NewTableList(nil)
+	assert.Contains(t, tables.GetTableNames(), "registertest")
+}
+
+func Test_processWatchResult_RunsProcessors(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	r := NewProcessing(nil, tables, false, time.Hour, 0, nil, 0, nil)
+	r.processors = []Processor{
+		&fakeProcessor{name: "panics", doPanic: true},
+		&fakeProcessor{name: "fails", err: fmt.Errorf("failed")},
+		&fakeProcessor{name: "works"},
+	}
+
+	ts, err := ptypes.TimestampProto(someWatchTime)
+	assert.Nil(t, err)
+	r.processWatchResult(&typed.KubeWatchResult{Timestamp: ts, Kind: someKind, Payload: somePodPayload})
+
+	err = db.View(func(txn badgerwrap.Txn) error {
+		_, err := txn.Get([]byte("/works/Pod/someName"))
+		assert.Nil(t, err)
+
+		watchRes, _, err := tables.WatchTable().RangeRead(txn, nil, nil, nil, someWatchTime, someWatchTime)
+		assert.Nil(t, err)
+		assert.Len(t, watchRes, 1)
+		return nil
+	})
+	assert.Nil(t, err)
+}
@@ -0,0 +1,61 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package processing
+
+import (
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+// Records a transition whenever the phase, container waiting reasons, ephemeral container states or node of a pod change.  Each partition starts
+// with the state at its first watch result, so a lifecycle query never has to look outside its time range.
+func updatePodLifecycleTable(tables typed.Tables, txn badgerwrap.Txn, watchRec *typed.KubeWatchResult, metadata *kubeextractor.KubeMetadata) error {
+	if watchRec.Kind != kubeextractor.PodKind {
+		return nil
+	}
+
+	timestamp, err := ptypes.Timestamp(watchRec.Timestamp)
+	if err != nil {
+		return errors.Wrapf(err, "Could not convert timestamp %v", watchRec.Timestamp)
+	}
+
+	status, err := kubeextractor.ExtractPodStatus(watchRec.Payload)
+	if err != nil {
+		return errors.Wrap(err, "Could not extract pod status")
+	}
+
+	key := typed.NewPodLifecycleKey(untyped.GetPartitionId(timestamp), metadata.Namespace, metadata.Name, metadata.Uid)
+	lifecycle, err := tables.PodLifecycleTable().GetOrDefault(txn, key.String())
+	if err != nil {
+		return errors.Wrap(err, "Could not get pod lifecycle record")
+	}
+
+	transition := &typed.PodPhaseTransition{
+		Timestamp:      timestamp.Unix(),
+		Phase:          status.Phase,
+		WaitingReasons: status.WaitingReasons,
+		Deleted:        watchRec.WatchType == typed.KubeWatchResult_DELETE,
+		// Ephemeral containers are added to a running pod, like by kubectl debug
+		EphemeralContainers: status.EphemeralContainers,
+		NodeName:            status.NodeName,
+	}
+	if len(lifecycle.Transitions) > 0 && typed.SamePodState(lifecycle.Transitions[len(lifecycle.Transitions)-1], transition) {
+		return nil
+	}
+	lifecycle.Transitions = append(lifecycle.Transitions, transition)
+
+	err = tables.PodLifecycleTable().Set(txn, key.String(), lifecycle)
+	if err != nil {
+		return errors.Wrap(err, "Failed to put pod lifecycle record")
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package processing
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+func helper_lifecyclePodPayload(resourceVersion int, phase string, containerState string) string {
+	return fmt.Sprintf(`{"metadata":{"name":"somePodName","namespace":"someNamespace","uid":"somePodUid","resourceVersion":"%v"},"status":{"phase":"%v","containerStatuses":[{"name":"app","state":%v}]}}`,
+		resourceVersion, phase, containerState)
+}
+
+func Test_updatePodLifecycleTable_RecordsOnlyTransitions(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+
+	waiting := `{"waiting":{"reason":"ContainerCreating"}}`
+	running := `{"running":{}}`
+	updates := []struct {
+		payload   string
+		watchType typed.KubeWatchResult_WatchType
+	}{
+		{helper_lifecyclePodPayload(1, "Pending", waiting), typed.KubeWatchResult_ADD},
+		{helper_lifecyclePodPayload(2, "Pending", waiting), typed.KubeWatchResult_UPDATE},
+		{helper_lifecyclePodPayload(3, "Running", running), typed.KubeWatchResult_UPDATE},
+		{helper_lifecyclePodPayload(4, "Running", running), typed.KubeWatchResult_UPDATE},
+		{helper_lifecyclePodPayload(5, "Running", running), typed.KubeWatchResult_DELETE},
+	}
+	for idx, update := range updates {
+		ts, err := ptypes.TimestampProto(someWatchTime.Add(time.Duration(idx) * time.Minute))
+		assert.Nil(t, err)
+		watchRec := &typed.KubeWatchResult{Kind: kubeextractor.PodKind, WatchType: update.watchType, Timestamp: ts, Payload: update.payload}
+		metadata, err := kubeextractor.ExtractMetadata(watchRec.Payload)
+		assert.Nil(t, err)
+		err = db.Update(func(txn badgerwrap.Txn) error {
+			return updatePodLifecycleTable(tables, txn, watchRec, &metadata)
+		})
+		assert.Nil(t, err)
+	}
+
+	err = db.View(func(txn badgerwrap.Txn) error {
+		key := typed.NewPodLifecycleKey(untyped.GetPartitionId(someWatchTime), "someNamespace", "somePodName", "somePodUid")
+		lifecycle, err := tables.PodLifecycleTable().Get(txn, key.String())
+		assert.Nil(t, err)
+		assert.Len(t, lifecycle.Transitions, 3)
+		assert.Equal(t, "Pending", lifecycle.Transitions[0].Phase)
+		assert.Equal(t, map[string]string{"app": "ContainerCreating"}, lifecycle.Transitions[0].WaitingReasons)
+		assert.Equal(t, someWatchTime.Unix(), lifecycle.Transitions[0].Timestamp)
+		assert.Equal(t, "Running", lifecycle.Transitions[1].Phase)
+		assert.Equal(t, someWatchTime.Add(2*time.Minute).Unix(), lifecycle.Transitions[1].Timestamp)
+		assert.True(t, lifecycle.Transitions[2].Deleted)
+		return nil
+	})
+	assert.Nil(t, err)
+}
+
+func Test_updatePodLifecycleTable_RecordsEphemeralContainers(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+
+	debugger := `[{"name":"debugger"}]`
+	payloads := []string{
+		fmt.Sprintf(someDebugPodPayload, 1, `[]`, `[]`),
+		fmt.Sprintf(someDebugPodPayload, 2, debugger, `[]`),
+		fmt.Sprintf(someDebugPodPayload, 3, debugger, `[{"name":"debugger","state":{"running":{}}}]`),
+	}
+	for idx, payload := range payloads {
+		ts, _ := ptypes.TimestampProto(someWatchTime.Add(time.Duration(idx) * time.Minute))
+		watchRec := &typed.KubeWatchResult{Kind: kubeextractor.PodKind, WatchType: typed.KubeWatchResult_UPDATE, Timestamp: ts, Payload: payload}
+		metadata, err := kubeextractor.ExtractMetadata(payload)
+		assert.Nil(t, err)
+		err = db.Update(func(txn badgerwrap.Txn) error {
+			return updatePodLifecycleTable(tables, txn, watchRec, &metadata)
+		})
+		assert.Nil(t, err)
+	}
+
+	err = db.View(func(txn badgerwrap.Txn) error {
+		key := typed.NewPodLifecycleKey(untyped.GetPartitionId(someWatchTime), "someNamespace", "somePodName", "somePodUid")
+		lifecycle, err := tables.PodLifecycleTable().Get(txn, key.String())
+		assert.Nil(t, err)
+		assert.Len(t, lifecycle.Transitions, 3)
+		assert.Nil(t, lifecycle.Transitions[0].EphemeralContainers)
+		assert.Equal(t, map[string]string{"debugger": kubeextractor.EphemeralContainerAdded}, lifecycle.Transitions[1].EphemeralContainers)
+		assert.Equal(t, map[string]string{"debugger": kubeextractor.EphemeralContainerRunning}, lifecycle.Transitions[2].EphemeralContainers)
+		return nil
+	})
+	assert.Nil(t, err)
+}
+
+func Test_updatePodLifecycleTable_SkipsOtherKinds(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+
+	ts, err := ptypes.TimestampProto(someWatchTime)
+	assert.Nil(t, err)
+	watchRec := &typed.KubeWatchResult{Kind: kubeextractor.NodeKind, Timestamp: ts, Payload: `{"metadata":{"name":"someNode"}}`}
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		return updatePodLifecycleTable(tables, txn, watchRec, &kubeextractor.KubeMetadata{Name: "someNode"})
+	})
+	assert.Nil(t, err)
+
+	err = db.View(func(txn badgerwrap.Txn) error {
+		lifecycles, _, err := tables.PodLifecycleTable().RangeRead(txn, nil, nil, nil, someWatchTime, someWatchTime)
+		assert.Nil(t, err)
+		assert.Len(t, lifecycles, 0)
+		return nil
+	})
+	assert.Nil(t, err)
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
 	"github.com/salesforce/sloop/pkg/sloop/store/typed"
 	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/salesforce/sloop/pkg/sloop/tenant"
 	"sync"
 	"time"
 )
@@ -24,6 +25,15 @@ type Runner struct {
 	inputWg              *sync.WaitGroup
 	keepMinorNodeUpdates bool
 	maxLookback          time.Duration
+	eventFoldWindow      time.Duration
+	sampling             SamplingConfig
+	samplingLock         *sync.RWMutex
+	processors           []Processor
+	clockSkew            *clockSkewDetector
+	tenantQuota          *tenantQuota
+	// Stages to run again after a transient error, see runStage
+	retryChan      chan *stageRetry
+	pendingRetries int
 }
 
 var (
@@ -32,8 +42,8 @@ var (
 	metricIngestionSuccessCount           = promauto.NewCounter(prometheus.CounterOpts{Name: "sloop_ingestion_success_count"})
 )
 
-func NewProcessing(kubeWatchChan chan typed.KubeWatchResult, tables typed.Tables, keepMinorNodeUpdates bool, maxLookback time.Duration) *Runner {
-	return &Runner{kubeWatchChan: kubeWatchChan, tables: tables, inputWg: &sync.WaitGroup{}, keepMinorNodeUpdates: keepMinorNodeUpdates, maxLookback: maxLookback}
+func NewProcessing(kubeWatchChan chan typed.KubeWatchResult, tables typed.Tables, keepMinorNodeUpdates bool, maxLookback time.Duration, eventFoldWindow time.Duration, sampling SamplingConfig, clockSkewThreshold time.Duration, tenants tenant.Map) *Runner {
+	return &Runner{kubeWatchChan: kubeWatchChan, tables: tables, inputWg: &sync.WaitGroup{}, keepMinorNodeUpdates: keepMinorNodeUpdates, maxLookback: maxLookback, eventFoldWindow: eventFoldWindow, sampling: sampling, samplingLock: &sync.RWMutex{}, processors: getRegisteredProcessors(), clockSkew: newClockSkewDetector(clockSkewThreshold), tenantQuota: newTenantQuota(tenants), retryChan: make(chan *stageRetry, maxPendingRetries)}
 }
 
 func (r *Runner) processingFailed(name string, err error) {
@@ -45,53 +55,129 @@ func (r *Runner) Start() {
 	r.inputWg.Add(1)
 	go func() {
 		for {
-			watchRec, more := <-r.kubeWatchChan
-			if !more {
-				r.inputWg.Done()
-				return
+			select {
+			case watchRec, more := <-r.kubeWatchChan:
+				if !more {
+					r.runPendingRetries()
+					r.inputWg.Done()
+					return
+				}
+				r.processWatchResult(&watchRec)
+			case retry := <-r.retryChan:
+				r.retryStage(retry)
 			}
+		}
+	}()
+}
 
-			resourceMetadata, err := kubeextractor.ExtractMetadata(watchRec.Payload)
-			if err != nil {
-				r.processingFailed("cannot extract resource metadata", err)
-			}
-			glog.V(99).Infof("watchRec metadata: %v", resourceMetadata)
-			involvedObject, err := kubeextractor.ExtractInvolvedObject(watchRec.Payload)
-			if err != nil {
-				r.processingFailed("cannot extract involved object", err)
-			}
+func (r *Runner) processWatchResult(watchRec *typed.KubeWatchResult) {
+	err := validatePayload(watchRec)
+	if err != nil {
+		r.quarantine(watchRec, err)
+		return
+	}
+	r.checkClockSkew(watchRec)
+	setEventTemplate(watchRec)
+	setApiVersion(watchRec)
 
-			// Processing event count first so it can easily find the previous copy of the event
-			// If we update watchTable first then this will see the new event and think it is a dupe
-			err = r.tables.Db().Update(func(txn badgerwrap.Txn) error {
-				return updateEventCountTable(r.tables, txn, &watchRec, &resourceMetadata, &involvedObject, r.maxLookback)
-			})
-			if err != nil {
-				r.processingFailed("updateEventCountTable", err)
-			}
+	resourceMetadata, err := kubeextractor.ExtractMetadata(watchRec.Payload)
+	if err != nil {
+		r.processingFailed("cannot extract resource metadata", err)
+	}
+	glog.V(99).Infof("watchRec metadata: %v", resourceMetadata)
+	involvedObject, err := kubeextractor.ExtractEventInvolvedObject(watchRec.Kind, resourceMetadata, watchRec.Payload)
+	if err != nil {
+		r.processingFailed("cannot extract involved object", err)
+	}
+	// Every table keys cluster scoped resources without a namespace
+	resourceMetadata.Namespace = kubeextractor.NormalizeNamespace(watchRec.Kind, resourceMetadata.Namespace)
+	involvedObject.Namespace = kubeextractor.NormalizeNamespace(involvedObject.Kind, involvedObject.Namespace)
+	if !r.tenantQuota.allow(watchRec, &resourceMetadata) {
+		return
+	}
+	r.invalidateChecksums(watchRec)
+	if watchRec.Kind == kubeextractor.EventKind {
+		_ = r.tables.Db().View(func(txn badgerwrap.Txn) error {
+			linkEventToInvolvedObject(r.tables, txn, watchRec, &involvedObject)
+			return nil
+		})
+	}
 
-			err = r.tables.Db().Update(func(txn badgerwrap.Txn) error {
-				return updateWatchActivityTable(r.tables, txn, &watchRec, &resourceMetadata)
+	stageErrors := map[string]string{}
+	if r.eventFoldWindow > 0 && watchRec.Kind == kubeextractor.EventKind {
+		var folded bool
+		var prevEventInfo *kubeextractor.EventInfo
+		// Not dead lettered on failure, the event is stored as is instead
+		err = r.runStage("updateEventFoldTable", watchRec, nil, func(txn badgerwrap.Txn) error {
+			var err error
+			folded, prevEventInfo, err = updateEventFoldTable(r.tables, txn, watchRec, &resourceMetadata, &involvedObject, r.eventFoldWindow)
+			return err
+		})
+		if err != nil {
+			folded = false
+		}
+		if folded {
+			r.runStageWithBackoff("updateEventCountTable", watchRec, stageErrors, func(txn badgerwrap.Txn) error {
+				return updateEventCountTableWithPrevious(r.tables, txn, watchRec, &involvedObject, prevEventInfo)
 			})
-			if err != nil {
-				r.processingFailed("updateWatchActivityTable", err)
-			}
+			r.runProcessors(watchRec, &resourceMetadata, stageErrors)
+			r.finishWatchResult(watchRec, &resourceMetadata, stageErrors)
+			return
+		}
+	}
 
-			err = r.tables.Db().Update(func(txn badgerwrap.Txn) error {
-				return updateKubeWatchTable(r.tables, txn, &watchRec, &resourceMetadata, r.keepMinorNodeUpdates)
-			})
-			if err != nil {
-				r.processingFailed("updateKubeWatchTable", err)
-			}
+	// Processing event count first so it can easily find the previous copy of the event
+	// If we update watchTable first then this will see the new event and think it is a dupe
+	// Stages that read the previous copy from the watch table, or that the next watch result of the resource builds
+	// on, retry in place so they never run after a later stage.  The rest can be retried from the retry queue
+	r.runStageWithBackoff("updateEventCountTable", watchRec, stageErrors, func(txn badgerwrap.Txn) error {
+		return updateEventCountTable(r.tables, txn, watchRec, &resourceMetadata, &involvedObject, r.maxLookback)
+	})
 
-			err = r.tables.Db().Update(func(txn badgerwrap.Txn) error {
-				return updateResourceSummaryTable(r.tables, txn, &watchRec, &resourceMetadata)
-			})
-			if err != nil {
-				r.processingFailed("updateResourceSummaryTable", err)
-			}
-		}
-	}()
+	r.runStageWithBackoff("updateWatchActivityTable", watchRec, stageErrors, func(txn badgerwrap.Txn) error {
+		return updateWatchActivityTable(r.tables, txn, watchRec, &resourceMetadata, keepsVersionGaps(watchRec.Kind, r.keepMinorNodeUpdates, r.samplingPolicy(watchRec.Kind)))
+	})
+
+	r.runStage("updatePodLifecycleTable", watchRec, stageErrors, func(txn badgerwrap.Txn) error {
+		return updatePodLifecycleTable(r.tables, txn, watchRec, &resourceMetadata)
+	})
+
+	r.runStage("updateNodeConditionTable", watchRec, stageErrors, func(txn badgerwrap.Txn) error {
+		return updateNodeConditionTable(r.tables, txn, watchRec, &resourceMetadata)
+	})
+
+	r.runStage("updateApiServiceAvailabilityTable", watchRec, stageErrors, func(txn badgerwrap.Txn) error {
+		return updateApiServiceAvailabilityTable(txn, watchRec, &resourceMetadata)
+	})
+
+	r.runStageWithBackoff("updateOwnerGraphTable", watchRec, stageErrors, func(txn badgerwrap.Txn) error {
+		return updateOwnerGraphTable(r.tables, txn, watchRec, &resourceMetadata)
+	})
+
+	r.runStage("updateServiceBackendsTable", watchRec, stageErrors, func(txn badgerwrap.Txn) error {
+		return updateServiceBackendsTable(r.tables, txn, watchRec, &resourceMetadata)
+	})
+
+	r.runStageWithBackoff("updateKubeWatchTable", watchRec, stageErrors, func(txn badgerwrap.Txn) error {
+		return updateKubeWatchTable(r.tables, txn, watchRec, &resourceMetadata, r.keepMinorNodeUpdates, r.samplingPolicy(watchRec.Kind))
+	})
+
+	r.runStageWithBackoff("updateResourceSummaryTable", watchRec, stageErrors, func(txn badgerwrap.Txn) error {
+		return updateResourceSummaryTable(r.tables, txn, watchRec, &resourceMetadata)
+	})
+
+	r.runStage("updateCurrentStateTable", watchRec, stageErrors, func(txn badgerwrap.Txn) error {
+		return updateCurrentStateTable(r.tables, txn, watchRec, &resourceMetadata)
+	})
+
+	r.runProcessors(watchRec, &resourceMetadata, stageErrors)
+	r.finishWatchResult(watchRec, &resourceMetadata, stageErrors)
+}
+
+func (r *Runner) finishWatchResult(watchRec *typed.KubeWatchResult, metadata *kubeextractor.KubeMetadata, stageErrors map[string]string) {
+	if len(stageErrors) > 0 {
+		r.deadLetter(watchRec, metadata, stageErrors)
+	}
 }
 
 func (r *Runner) Wait() {
@@ -0,0 +1,578 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package processing
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/glog"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"golang.org/x/time/rate"
+)
+
+var (
+	metricReprocessWatchResultCount = promauto.NewCounter(prometheus.CounterOpts{Name: "sloop_reprocess_watch_result_count"})
+	metricReprocessPartitionCount   = promauto.NewCounter(prometheus.CounterOpts{Name: "sloop_reprocess_partition_count"})
+)
+
+const reprocessDeleteBatchSize = 1000
+
+// A table built from the watch table by one processing stage
+type derivedTable struct {
+	name  string
+	stage string
+	// Rows stay in the partition of the watch result that wrote them, so the partition is cleared and rebuilt.  The
+	// other tables are updated in place, and must not go backwards when given an older watch result
+	partitionLocal bool
+	// Built from every watch result at ingestion, including the ones the watch table did not store, so a rebuild from
+	// the watch table would lose history
+	needsUnstoredVersions bool
+	update                func(r *Runner, txn badgerwrap.Txn, watchRec *typed.KubeWatchResult, metadata *kubeextractor.KubeMetadata, involvedObject *kubeextractor.KubeInvolvedObject) error
+}
+
+// In the order processWatchResult runs them
+var derivedTables = []derivedTable{
+	{name: (&typed.EventCountKey{}).TableName(), stage: "updateEventCountTable", partitionLocal: true,
+		update: func(r *Runner, txn badgerwrap.Txn, watchRec *typed.KubeWatchResult, metadata *kubeextractor.KubeMetadata, involvedObject *kubeextractor.KubeInvolvedObject) error {
+			return updateEventCountTable(r.tables, txn, watchRec, metadata, involvedObject, r.maxLookback)
+		}},
+	{name: (&typed.WatchActivityKey{}).TableName(), stage: "updateWatchActivityTable", partitionLocal: true, needsUnstoredVersions: true,
+		update: func(r *Runner, txn badgerwrap.Txn, watchRec *typed.KubeWatchResult, metadata *kubeextractor.KubeMetadata, involvedObject *kubeextractor.KubeInvolvedObject) error {
+			return updateWatchActivityTable(r.tables, txn, watchRec, metadata, keepsVersionGaps(watchRec.Kind, r.keepMinorNodeUpdates, r.samplingPolicy(watchRec.Kind)))
+		}},
+	{name: (&typed.PodLifecycleKey{}).TableName(), stage: "updatePodLifecycleTable", partitionLocal: true,
+		update: func(r *Runner, txn badgerwrap.Txn, watchRec *typed.KubeWatchResult, metadata *kubeextractor.KubeMetadata, involvedObject *kubeextractor.KubeInvolvedObject) error {
+			return updatePodLifecycleTable(r.tables, txn, watchRec, metadata)
+		}},
+	{name: (&typed.NodeConditionKey{}).TableName(), stage: "updateNodeConditionTable", partitionLocal: true,
+		update: func(r *Runner, txn badgerwrap.Txn, watchRec *typed.KubeWatchResult, metadata *kubeextractor.KubeMetadata, involvedObject *kubeextractor.KubeInvolvedObject) error {
+			return updateNodeConditionTable(r.tables, txn, watchRec, metadata)
+		}},
+	{name: typed.OpenApiServiceAvailabilityTable().TableName(), stage: "updateApiServiceAvailabilityTable", partitionLocal: true,
+		update: func(r *Runner, txn badgerwrap.Txn, watchRec *typed.KubeWatchResult, metadata *kubeextractor.KubeMetadata, involvedObject *kubeextractor.KubeInvolvedObject) error {
+			return updateApiServiceAvailabilityTable(txn, watchRec, metadata)
+		}},
+	{name: (&typed.OwnerEdgeKey{}).TableName(), stage: "updateOwnerGraphTable", partitionLocal: true,
+		update: func(r *Runner, txn badgerwrap.Txn, watchRec *typed.KubeWatchResult, metadata *kubeextractor.KubeMetadata, involvedObject *kubeextractor.KubeInvolvedObject) error {
+			return updateOwnerGraphTable(r.tables, txn, watchRec, metadata)
+		}},
+	{name: (&typed.ServiceBackendsKey{}).TableName(), stage: "updateServiceBackendsTable", partitionLocal: true,
+		update: func(r *Runner, txn badgerwrap.Txn, watchRec *typed.KubeWatchResult, metadata *kubeextractor.KubeMetadata, involvedObject *kubeextractor.KubeInvolvedObject) error {
+			return updateServiceBackendsTable(r.tables, txn, watchRec, metadata)
+		}},
+	{name: (&typed.ResourceSummaryKey{}).TableName(), stage: "updateResourceSummaryTable", partitionLocal: true, needsUnstoredVersions: true,
+		update: func(r *Runner, txn badgerwrap.Txn, watchRec *typed.KubeWatchResult, metadata *kubeextractor.KubeMetadata, involvedObject *kubeextractor.KubeInvolvedObject) error {
+			return updateResourceSummaryTable(r.tables, txn, watchRec, metadata)
+		}},
+	{name: (&typed.CurrentStateKey{}).TableName(), stage: "updateCurrentStateTable", partitionLocal: false,
+		update: func(r *Runner, txn badgerwrap.Txn, watchRec *typed.KubeWatchResult, metadata *kubeextractor.KubeMetadata, involvedObject *kubeextractor.KubeInvolvedObject) error {
+			return updateCurrentStateTable(r.tables, txn, watchRec, metadata)
+		}},
+}
+
+type ReprocessReport struct {
+	Partitions []string `json:"partitions"`
+	Tables     []string `json:"tables"`
+	// Tables that were asked for but left alone, with the reason
+	Skipped      map[string]string `json:"skipped,omitempty"`
+	WatchResults int               `json:"watchResults"`
+	DeletedKeys  int               `json:"deletedKeys"`
+	// Stage name to the number of watch results it failed for.  These are not dead lettered, the watch results
+	// are already stored
+	FailedStages map[string]int `json:"failedStages,omitempty"`
+	Duration     string         `json:"duration"`
+}
+
+// Where the running reprocess is, or how the last one ended
+type ReprocessProgress struct {
+	Running    bool     `json:"running"`
+	Started    string   `json:"started,omitempty"`
+	Partitions []string `json:"partitions"`
+	Tables     []string `json:"tables"`
+	// Partitions rebuilt so far, and the one being rebuilt with its watch results done and in total
+	PartitionsDone          int    `json:"partitionsDone"`
+	CurrentPartition        string `json:"currentPartition,omitempty"`
+	PartitionWatchResults   int    `json:"partitionWatchResults"`
+	PartitionWatchResultsOf int    `json:"partitionWatchResultsOf"`
+	WatchResults            int    `json:"watchResults"`
+	// Tables that were asked for but are left alone, with the reason
+	Skipped map[string]string `json:"skipped,omitempty"`
+	// Watch results per second it is kept to, 0 for unlimited
+	MaxRate int `json:"maxRate,omitempty"`
+	// Set once it finished
+	Report *ReprocessReport `json:"report,omitempty"`
+	Error  string           `json:"error,omitempty"`
+}
+
+// A reprocess that passed its checks and holds the lock
+type reprocessJob struct {
+	start      time.Time
+	tables     []derivedTable
+	processors []Processor
+	limiter    *rate.Limiter
+	report     ReprocessReport
+}
+
+var reprocessLock = &sync.Mutex{}
+var reprocessProgress = ReprocessProgress{}
+
+/*
+Rebuilds the derived tables of the partitions fromPartition to toPartition (both inclusive, empty for the first and
+last available) from the watch results already stored in them, so a new processor table or a fixed processing stage
+can be backfilled.  tableNames limits it to some of the core tables and the tables of registered processors, all of
+them when empty.  The watch table itself is never written, and each watch result is processed as it was at ingestion
+time, seeing only the watch results stored before it.
+
+The partition ingestion is writing to can not be reprocessed.  Event counts are skipped when event folding is on,
+since repeats of folded events were only ever counted and never stored.  For the same reason watch activity and
+resource summaries are skipped when minor node updates are dropped or a kind is sampled.  maxRate above 0 keeps it to that many watch
+results per second, so a large backfill leaves the disk to ingestion and queries.  ReprocessProgress shows how far it is
+*/
+func (r *Runner) Reprocess(fromPartition string, toPartition string, tableNames []string, maxRate int) (ReprocessReport, error) {
+	job, err := r.startReprocess(fromPartition, toPartition, tableNames, maxRate)
+	if err != nil {
+		return job.report, err
+	}
+	return r.runReprocess(job)
+}
+
+// Same as Reprocess, but only checks the request and returns while the partitions are rebuilt in the background
+func (r *Runner) StartReprocess(fromPartition string, toPartition string, tableNames []string, maxRate int) (ReprocessProgress, error) {
+	job, err := r.startReprocess(fromPartition, toPartition, tableNames, maxRate)
+	if err != nil {
+		return ReprocessProgress{}, err
+	}
+	go func() {
+		_, err := r.runReprocess(job)
+		if err != nil {
+			glog.Errorf("Reprocessing failed: %v", err)
+		}
+	}()
+	return r.ReprocessProgress(), nil
+}
+
+// Returns the progress of the running reprocess, or of the last one when none is running
+func (r *Runner) ReprocessProgress() ReprocessProgress {
+	reprocessLock.Lock()
+	defer reprocessLock.Unlock()
+	progress := reprocessProgress
+	progress.Partitions = append([]string{}, progress.Partitions...)
+	progress.Tables = append([]string{}, progress.Tables...)
+	skipped := map[string]string{}
+	for tableName, reason := range progress.Skipped {
+		skipped[tableName] = reason
+	}
+	progress.Skipped = skipped
+	return progress
+}
+
+func (r *Runner) startReprocess(fromPartition string, toPartition string, tableNames []string, maxRate int) (*reprocessJob, error) {
+	job := &reprocessJob{start: time.Now(), report: ReprocessReport{Skipped: map[string]string{}, FailedStages: map[string]int{}}}
+	if maxRate < 0 {
+		return job, fmt.Errorf("the maximum rate can not be negative, got %v", maxRate)
+	}
+	if maxRate > 0 {
+		job.limiter = rate.NewLimiter(rate.Limit(maxRate), maxRate)
+	}
+	reprocessLock.Lock()
+	if reprocessProgress.Running {
+		reprocessLock.Unlock()
+		return job, fmt.Errorf("a reprocess is already running")
+	}
+	reprocessProgress.Running = true
+	reprocessLock.Unlock()
+
+	var err error
+	job.tables, job.processors, err = r.selectReprocessTables(tableNames, job.report.Skipped)
+	if err == nil {
+		job.report.Partitions, err = r.getReprocessPartitions(fromPartition, toPartition)
+	}
+	if err != nil {
+		// The last progress stays as it was, this one never started
+		reprocessLock.Lock()
+		reprocessProgress.Running = false
+		reprocessLock.Unlock()
+		return job, err
+	}
+	for _, table := range job.tables {
+		job.report.Tables = append(job.report.Tables, table.name)
+	}
+	for _, processor := range job.processors {
+		job.report.Tables = append(job.report.Tables, processor.TableNames()...)
+	}
+	for tableName, reason := range job.report.Skipped {
+		glog.Warningf("Not reprocessing table %v: %v", tableName, reason)
+	}
+
+	reprocessLock.Lock()
+	reprocessProgress = ReprocessProgress{
+		Running:    true,
+		Started:    job.start.UTC().Format(time.RFC3339),
+		Partitions: job.report.Partitions,
+		Tables:     job.report.Tables,
+		Skipped:    job.report.Skipped,
+		MaxRate:    maxRate,
+	}
+	reprocessLock.Unlock()
+	return job, nil
+}
+
+func (r *Runner) runReprocess(job *reprocessJob) (ReprocessReport, error) {
+	report := &job.report
+	var err error
+	for _, partition := range report.Partitions {
+		glog.Infof("Reprocessing partition %v for tables %v", partition, report.Tables)
+		err = r.reprocessPartition(partition, job, report)
+		if err != nil {
+			break
+		}
+		metricReprocessPartitionCount.Inc()
+		reprocessLock.Lock()
+		reprocessProgress.PartitionsDone++
+		reprocessLock.Unlock()
+	}
+	report.Duration = time.Since(job.start).String()
+
+	reprocessLock.Lock()
+	defer reprocessLock.Unlock()
+	reprocessProgress.Running = false
+	reprocessProgress.CurrentPartition = ""
+	finished := *report
+	reprocessProgress.Report = &finished
+	if err != nil {
+		reprocessProgress.Error = err.Error()
+	}
+	return *report, err
+}
+
+func (r *Runner) selectReprocessTables(tableNames []string, skipped map[string]string) ([]derivedTable, []Processor, error) {
+	selected := map[string]bool{}
+	for _, tableName := range tableNames {
+		selected[tableName] = true
+	}
+
+	tables := []derivedTable{}
+	for _, table := range derivedTables {
+		if len(tableNames) > 0 && !selected[table.name] {
+			continue
+		}
+		delete(selected, table.name)
+		if table.name == (&typed.EventCountKey{}).TableName() && r.eventFoldWindow > 0 {
+			skipped[table.name] = "event folding is on"
+			continue
+		}
+		if reason := r.unstoredVersionsReason(); table.needsUnstoredVersions && reason != "" {
+			skipped[table.name] = reason
+			continue
+		}
+		tables = append(tables, table)
+	}
+	// Processors without tables send watch results elsewhere, and should not get old ones again
+	processors := []Processor{}
+	for _, processor := range r.processors {
+		keep := false
+		for _, tableName := range processor.TableNames() {
+			if len(tableNames) == 0 || selected[tableName] {
+				keep = true
+			}
+			delete(selected, tableName)
+		}
+		if keep {
+			processors = append(processors, processor)
+		}
+	}
+	if len(selected) > 0 {
+		unknown := []string{}
+		for tableName := range selected {
+			unknown = append(unknown, tableName)
+		}
+		sort.Strings(unknown)
+		return nil, nil, fmt.Errorf("tables %v are not derived from the watch table", unknown)
+	}
+	return tables, processors, nil
+}
+
+// Why the watch table is missing versions processing saw, empty when it stores all of them
+func (r *Runner) unstoredVersionsReason() string {
+	if !r.keepMinorNodeUpdates {
+		return "minor node updates are not stored"
+	}
+	r.samplingLock.RLock()
+	defer r.samplingLock.RUnlock()
+	sampled := []string{}
+	for kind, policy := range r.sampling {
+		if !keepsVersionGaps(kind, true, policy) {
+			sampled = append(sampled, kind)
+		}
+	}
+	if len(sampled) > 0 {
+		sort.Strings(sampled)
+		return fmt.Sprintf("sampled versions of %v are not stored", strings.Join(sampled, ", "))
+	}
+	return ""
+}
+
+func (r *Runner) getReprocessPartitions(fromPartition string, toPartition string) ([]string, error) {
+	var all []string
+	err := r.tables.Db().View(func(txn badgerwrap.Txn) error {
+		var err error
+		all, err = r.tables.WatchTable().GetUniquePartitionList(txn)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	currentPartition := untyped.GetPartitionId(common.Now())
+	if toPartition >= currentPartition {
+		return nil, fmt.Errorf("partition %v is still being written by ingestion", toPartition)
+	}
+	partitions := []string{}
+	for _, partition := range all {
+		if partition >= currentPartition {
+			break
+		}
+		if (fromPartition == "" || partition >= fromPartition) && (toPartition == "" || partition <= toPartition) {
+			partitions = append(partitions, partition)
+		}
+	}
+	if len(partitions) == 0 {
+		return nil, fmt.Errorf("no closed partitions with watch results between %q and %q", fromPartition, toPartition)
+	}
+	return partitions, nil
+}
+
+func (r *Runner) reprocessPartition(partition string, job *reprocessJob, report *ReprocessReport) error {
+	prefixes := []string{}
+	for _, table := range job.tables {
+		if table.name == (&typed.EventCountKey{}).TableName() {
+			err := subtractEventRollups(r.tables, partition)
+			if err != nil {
+				return err
+			}
+		}
+		if table.partitionLocal {
+			prefixes = append(prefixes, fmt.Sprintf("/%v/%v/", table.name, partition))
+		}
+	}
+	for _, processor := range job.processors {
+		for _, tableName := range processor.TableNames() {
+			prefixes = append(prefixes, fmt.Sprintf("/%v/%v/", tableName, partition))
+		}
+	}
+	for _, prefix := range prefixes {
+		deleted, err := deleteKeysWithPrefix(r.tables.Db(), prefix)
+		report.DeletedKeys += deleted
+		if err != nil {
+			return err
+		}
+	}
+
+	watchKeys, err := getSortedWatchKeys(r.tables.Db(), partition)
+	if err != nil {
+		return err
+	}
+	reprocessLock.Lock()
+	reprocessProgress.CurrentPartition = partition
+	reprocessProgress.PartitionWatchResults = 0
+	reprocessProgress.PartitionWatchResultsOf = len(watchKeys)
+	reprocessLock.Unlock()
+	for _, watchKey := range watchKeys {
+		if job.limiter != nil {
+			_ = job.limiter.Wait(context.Background())
+		}
+		var watchRec *typed.KubeWatchResult
+		err = r.tables.Db().View(func(txn badgerwrap.Txn) error {
+			var err error
+			watchRec, err = r.tables.WatchTable().Get(txn, watchKey)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		stageErrors := map[string]string{}
+		r.reprocessWatchResult(watchKey, watchRec, job.tables, job.processors, stageErrors)
+		for stage := range stageErrors {
+			report.FailedStages[stage]++
+		}
+		report.WatchResults++
+		metricReprocessWatchResultCount.Inc()
+		reprocessLock.Lock()
+		reprocessProgress.PartitionWatchResults++
+		reprocessProgress.WatchResults = report.WatchResults
+		reprocessLock.Unlock()
+	}
+	// Done last, so the store manager does not checksum a partition that is still being rebuilt
+	return r.tables.Db().Update(func(txn badgerwrap.Txn) error {
+		return invalidateClosedPartitionChecksums(txn, partition)
+	})
+}
+
+// Same as processWatchResult without the watch table, and with the stored copy of the watch result hidden from the
+// stages so they see the store as it was when it came in
+func (r *Runner) reprocessWatchResult(watchKey string, watchRec *typed.KubeWatchResult, tables []derivedTable, processors []Processor, stageErrors map[string]string) {
+	resourceMetadata, err := kubeextractor.ExtractMetadata(watchRec.Payload)
+	if err != nil {
+		r.processingFailed("cannot extract resource metadata", err)
+	}
+	involvedObject, err := kubeextractor.ExtractEventInvolvedObject(watchRec.Kind, resourceMetadata, watchRec.Payload)
+	if err != nil {
+		r.processingFailed("cannot extract involved object", err)
+	}
+	resourceMetadata.Namespace = kubeextractor.NormalizeNamespace(watchRec.Kind, resourceMetadata.Namespace)
+	involvedObject.Namespace = kubeextractor.NormalizeNamespace(involvedObject.Kind, involvedObject.Namespace)
+
+	hidden := []byte(watchKey)
+	if watchRec.Kind == kubeextractor.EventKind {
+		_ = r.tables.Db().View(func(txn badgerwrap.Txn) error {
+			linkEventToInvolvedObject(r.tables, &hidingTxn{Txn: txn, hidden: hidden}, watchRec, &involvedObject)
+			return nil
+		})
+	}
+	for _, table := range tables {
+		table := table
+		r.runStageWithBackoff(table.stage, watchRec, stageErrors, func(txn badgerwrap.Txn) error {
+			return table.update(r, &hidingTxn{Txn: txn, hidden: hidden}, watchRec, &resourceMetadata, &involvedObject)
+		})
+	}
+	for _, processor := range processors {
+		processor := processor
+		r.runStageWithBackoff("processor "+processor.Name(), watchRec, stageErrors, func(txn badgerwrap.Txn) (err error) {
+			defer func() {
+				if p := recover(); p != nil {
+					err = fmt.Errorf("processor panicked: %v", p)
+				}
+			}()
+			return processor.Process(r.tables, &hidingTxn{Txn: txn, hidden: hidden}, watchRec, &resourceMetadata)
+		})
+	}
+}
+
+// Watch table keys of the partition, oldest first
+func getSortedWatchKeys(db badgerwrap.DB, partition string) ([]string, error) {
+	prefix := []byte(fmt.Sprintf("/%v/%v/", (&typed.WatchTableKey{}).TableName(), partition))
+	keys := []*typed.WatchTableKey{}
+	err := db.View(func(txn badgerwrap.Txn) error {
+		iterOpt := badger.DefaultIteratorOptions
+		iterOpt.PrefetchValues = false
+		iterOpt.Prefix = prefix
+		itr := txn.NewIterator(iterOpt)
+		defer itr.Close()
+		for itr.Seek(prefix); itr.ValidForPrefix(prefix); itr.Next() {
+			key := &typed.WatchTableKey{}
+			err := key.Parse(string(itr.Item().Key()))
+			if err != nil {
+				return err
+			}
+			keys = append(keys, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		return keys[i].Timestamp.Before(keys[j].Timestamp)
+	})
+	sorted := []string{}
+	for _, key := range keys {
+		sorted = append(sorted, key.String())
+	}
+	return sorted, nil
+}
+
+func deleteKeysWithPrefix(db badgerwrap.DB, prefix string) (int, error) {
+	deleted := 0
+	for {
+		keys := [][]byte{}
+		err := db.View(func(txn badgerwrap.Txn) error {
+			iterOpt := badger.DefaultIteratorOptions
+			iterOpt.PrefetchValues = false
+			iterOpt.Prefix = []byte(prefix)
+			itr := txn.NewIterator(iterOpt)
+			defer itr.Close()
+			for itr.Seek([]byte(prefix)); itr.ValidForPrefix([]byte(prefix)) && len(keys) < reprocessDeleteBatchSize; itr.Next() {
+				keys = append(keys, itr.Item().KeyCopy(nil))
+			}
+			return nil
+		})
+		if err != nil || len(keys) == 0 {
+			return deleted, err
+		}
+		err = db.Update(func(txn badgerwrap.Txn) error {
+			for _, key := range keys {
+				err := txn.Delete(key)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return deleted, err
+		}
+		deleted += len(keys)
+	}
+}
+
+// Hides one key from reads, writes go through
+type hidingTxn struct {
+	badgerwrap.Txn
+	hidden []byte
+}
+
+func (t *hidingTxn) Get(key []byte) (badgerwrap.Item, error) {
+	if bytes.Equal(key, t.hidden) {
+		return nil, badger.ErrKeyNotFound
+	}
+	return t.Txn.Get(key)
+}
+
+func (t *hidingTxn) NewIterator(opt badger.IteratorOptions) badgerwrap.Iterator {
+	return &hidingIterator{Iterator: t.Txn.NewIterator(opt), hidden: t.hidden}
+}
+
+type hidingIterator struct {
+	badgerwrap.Iterator
+	hidden []byte
+}
+
+func (i *hidingIterator) skipHidden() {
+	for i.Iterator.Valid() && bytes.Equal(i.Iterator.Item().Key(), i.hidden) {
+		i.Iterator.Next()
+	}
+}
+
+func (i *hidingIterator) Seek(key []byte) {
+	i.Iterator.Seek(key)
+	i.skipHidden()
+}
+
+func (i *hidingIterator) Rewind() {
+	i.Iterator.Rewind()
+	i.skipHidden()
+}
+
+func (i *hidingIterator) Next() {
+	i.Iterator.Next()
+	i.skipHidden()
+}
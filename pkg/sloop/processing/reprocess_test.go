@@ -0,0 +1,285 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package processing
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+// Counts the watch results of every resource in its own table
+type countingProcessor struct{}
+
+func (p *countingProcessor) Name() string {
+	return "counting"
+}
+
+func (p *countingProcessor) TableNames() []string {
+	return []string{"reprocesstest"}
+}
+
+func (p *countingProcessor) Process(tables typed.Tables, txn badgerwrap.Txn, watchRec *typed.KubeWatchResult, metadata *kubeextractor.KubeMetadata) error {
+	ts, err := ptypes.Timestamp(watchRec.Timestamp)
+	if err != nil {
+		return err
+	}
+	key := []byte(fmt.Sprintf("/reprocesstest/%v/%v", untyped.GetPartitionId(ts), metadata.Name))
+	count := 0
+	item, err := txn.Get(key)
+	if err == nil {
+		value, _ := item.ValueCopy(nil)
+		count, _ = strconv.Atoi(string(value))
+	}
+	return txn.Set(key, []byte(strconv.Itoa(count+1)))
+}
+
+func helper_reprocessPodPayload(resourceVersion int, ownerUid string) string {
+	return fmt.Sprintf(`{"metadata":{"name":"somePodName","namespace":"someNamespace","uid":"somePodUid","creationTimestamp":"2019-03-04T03:00:00Z","resourceVersion":"%v","ownerReferences":[{"kind":"ReplicaSet","name":"someRs","uid":"%v"}]}}`,
+		resourceVersion, ownerUid)
+}
+
+func helper_reprocessRunner(t *testing.T) (*Runner, badgerwrap.DB) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	r := NewProcessing(nil, tables, true, time.Hour, 0, nil, 0, nil)
+	r.processors = []Processor{&countingProcessor{}}
+
+	for idx, payload := range []string{
+		helper_reprocessPodPayload(1, "rsUid1"),
+		helper_reprocessPodPayload(2, "rsUid1"),
+		helper_reprocessPodPayload(3, "rsUid2"),
+		helper_foldEventPayload("someEvent", "Back-off", "2019-03-04T03:04:00Z", "2019-03-04T03:06:00Z", 3),
+	} {
+		kind := kubeextractor.PodKind
+		if idx == 3 {
+			kind = kubeextractor.EventKind
+		}
+		ts, err := ptypes.TimestampProto(someWatchTime.Add(time.Duration(idx) * time.Minute))
+		assert.Nil(t, err)
+		r.processWatchResult(&typed.KubeWatchResult{Kind: kind, WatchType: typed.KubeWatchResult_UPDATE, Timestamp: ts, Payload: payload})
+	}
+	return r, db
+}
+
+func helper_dumpDerivedRows(t *testing.T, db badgerwrap.DB) map[string]string {
+	rows := map[string]string{}
+	err := db.View(func(txn badgerwrap.Txn) error {
+		itr := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer itr.Close()
+		for itr.Rewind(); itr.Valid(); itr.Next() {
+			key := string(itr.Item().Key())
+			value, err := itr.Item().ValueCopy(nil)
+			if err != nil {
+				return err
+			}
+			// Maps are not marshaled in a stable order
+			if strings.HasPrefix(key, "/eventcount/") || strings.HasPrefix(key, "/eventheatmap/") {
+				counts := &typed.ResourceEventCounts{}
+				err = proto.Unmarshal(value, counts)
+				if err != nil {
+					return err
+				}
+				value = []byte(proto.MarshalTextString(counts))
+			}
+			rows[key] = string(value)
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+	return rows
+}
+
+func Test_Reprocess_RebuildsTheSameRows(t *testing.T) {
+	r, db := helper_reprocessRunner(t)
+	before := helper_dumpDerivedRows(t, db)
+	assert.Contains(t, before, "/ressum/"+untyped.GetPartitionId(someWatchTime)+"/Pod/someNamespace/somePodName/somePodUid")
+
+	report, err := r.Reprocess("", "", nil, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{untyped.GetPartitionId(someWatchTime)}, report.Partitions)
+	assert.Equal(t, 4, report.WatchResults)
+	assert.Contains(t, report.Tables, "ressum")
+	assert.Contains(t, report.Tables, "reprocesstest")
+	assert.Empty(t, report.FailedStages)
+	assert.Equal(t, before, helper_dumpDerivedRows(t, db))
+}
+
+func Test_Reprocess_BackfillsOneTable(t *testing.T) {
+	r, db := helper_reprocessRunner(t)
+	before := helper_dumpDerivedRows(t, db)
+	prefix := "/reprocesstest/" + untyped.GetPartitionId(someWatchTime) + "/"
+	deleted, err := deleteKeysWithPrefix(db, prefix)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, deleted)
+
+	report, err := r.Reprocess(untyped.GetPartitionId(someWatchTime), untyped.GetPartitionId(someWatchTime), []string{"reprocesstest"}, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"reprocesstest"}, report.Tables)
+	assert.Equal(t, before, helper_dumpDerivedRows(t, db))
+	assert.Equal(t, "3", helper_dumpDerivedRows(t, db)[prefix+"somePodName"])
+}
+
+func Test_Reprocess_SkipsEventCountsWhenFolding(t *testing.T) {
+	r, _ := helper_reprocessRunner(t)
+	r.eventFoldWindow = time.Minute
+	report, err := r.Reprocess("", "", []string{"eventcount", "ressum"}, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"ressum"}, report.Tables)
+	assert.Equal(t, map[string]string{"eventcount": "event folding is on"}, report.Skipped)
+}
+
+func Test_Reprocess_SkipsTablesBuiltFromUnstoredVersions(t *testing.T) {
+	r, _ := helper_reprocessRunner(t)
+	r.keepMinorNodeUpdates = false
+	report, err := r.Reprocess("", "", []string{"watchactivity", "ressum", "podlifecycle"}, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"podlifecycle"}, report.Tables)
+	assert.Equal(t, map[string]string{"watchactivity": "minor node updates are not stored", "ressum": "minor node updates are not stored"}, report.Skipped)
+
+	r.keepMinorNodeUpdates = true
+	r.SetSamplingInterval("Pod", time.Minute)
+	report, err = r.Reprocess("", "", []string{"ressum"}, 0)
+	assert.Nil(t, err)
+	assert.Empty(t, report.Tables)
+	assert.Equal(t, map[string]string{"ressum": "sampled versions of Pod are not stored"}, report.Skipped)
+}
+
+// The minor update of the node only reached the watch activity, so rebuilding it from the watch table would lose it
+func Test_StartReprocess_KeepsWatchActivityOfUnstoredNodeUpdates(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	r := NewProcessing(nil, tables, false, time.Hour, 0, nil, 0, nil)
+	for idx, payload := range []string{someNode, someNodeDiffTsAndRV} {
+		ts, err := ptypes.TimestampProto(someWatchTime.Add(time.Duration(idx) * time.Minute))
+		assert.Nil(t, err)
+		r.processWatchResult(&typed.KubeWatchResult{Kind: kubeextractor.NodeKind, WatchType: typed.KubeWatchResult_UPDATE, Timestamp: ts, Payload: payload})
+	}
+	activityBefore := map[string]string{}
+	for key, value := range helper_dumpDerivedRows(t, db) {
+		if strings.HasPrefix(key, "/watchactivity/") {
+			activityBefore[key] = value
+		}
+	}
+	assert.Len(t, activityBefore, 1)
+	watchKeys, err := getSortedWatchKeys(db, untyped.GetPartitionId(someWatchTime))
+	assert.Nil(t, err)
+	assert.Len(t, watchKeys, 1)
+
+	progress, err := r.StartReprocess("", "", []string{"watchactivity"}, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"watchactivity": "minor node updates are not stored"}, progress.Skipped)
+	for r.ReprocessProgress().Running {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, 0, r.ReprocessProgress().Report.DeletedKeys)
+	for key, value := range activityBefore {
+		assert.Equal(t, value, helper_dumpDerivedRows(t, db)[key], key)
+	}
+}
+
+func Test_Reprocess_BadRequests(t *testing.T) {
+	r, _ := helper_reprocessRunner(t)
+	_, err := r.Reprocess("", "", []string{"watch"}, 0)
+	assert.NotNil(t, err)
+	_, err = r.Reprocess("", untyped.GetPartitionId(time.Now()), nil, 0)
+	assert.NotNil(t, err)
+	_, err = r.Reprocess(untyped.GetPartitionId(someWatchTime.Add(time.Hour)), "", nil, 0)
+	assert.NotNil(t, err)
+}
+
+func Test_Reprocess_ProgressAndMaxRate(t *testing.T) {
+	r, _ := helper_reprocessRunner(t)
+	_, err := r.Reprocess("", "", []string{"ressum"}, -1)
+	assert.NotNil(t, err)
+
+	// A burst of 3, so the 4th watch result waits a third of a second
+	before := time.Now()
+	report, err := r.Reprocess("", "", []string{"ressum"}, 3)
+	assert.Nil(t, err)
+	assert.True(t, time.Since(before) >= 300*time.Millisecond)
+
+	progress := r.ReprocessProgress()
+	assert.False(t, progress.Running)
+	assert.Equal(t, []string{"ressum"}, progress.Tables)
+	assert.Equal(t, 1, progress.PartitionsDone)
+	assert.Equal(t, 4, progress.PartitionWatchResults)
+	assert.Equal(t, 4, progress.PartitionWatchResultsOf)
+	assert.Equal(t, 3, progress.MaxRate)
+	assert.Equal(t, &report, progress.Report)
+	assert.Empty(t, progress.Error)
+
+	// A request that fails its checks leaves the last progress
+	_, err = r.Reprocess("", "", []string{"watch"}, 0)
+	assert.NotNil(t, err)
+	assert.Equal(t, progress, r.ReprocessProgress())
+}
+
+func Test_StartReprocess(t *testing.T) {
+	r, db := helper_reprocessRunner(t)
+	before := helper_dumpDerivedRows(t, db)
+
+	progress, err := r.StartReprocess("", "", nil, 1)
+	assert.Nil(t, err)
+	assert.True(t, progress.Running)
+	_, err = r.StartReprocess("", "", nil, 0)
+	assert.NotNil(t, err)
+
+	for r.ReprocessProgress().Running {
+		time.Sleep(10 * time.Millisecond)
+	}
+	progress = r.ReprocessProgress()
+	assert.Empty(t, progress.Error)
+	assert.Equal(t, 4, progress.Report.WatchResults)
+	assert.Equal(t, before, helper_dumpDerivedRows(t, db))
+}
+
+func Test_hidingTxn(t *testing.T) {
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		for _, key := range []string{"/a/1", "/a/2", "/a/3"} {
+			assert.Nil(t, txn.Set([]byte(key), []byte(key)))
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+
+	err = db.View(func(txn badgerwrap.Txn) error {
+		hiding := &hidingTxn{Txn: txn, hidden: []byte("/a/2")}
+		_, err := hiding.Get([]byte("/a/2"))
+		assert.Equal(t, badger.ErrKeyNotFound, err)
+		_, err = hiding.Get([]byte("/a/1"))
+		assert.Nil(t, err)
+
+		keys := []string{}
+		itr := hiding.NewIterator(badger.DefaultIteratorOptions)
+		defer itr.Close()
+		for itr.Seek([]byte("/a/2")); itr.ValidForPrefix([]byte("/a/")); itr.Next() {
+			keys = append(keys, string(itr.Item().Key()))
+		}
+		assert.Equal(t, []string{"/a/3"}, keys)
+		return nil
+	})
+	assert.Nil(t, err)
+}
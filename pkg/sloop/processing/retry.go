@@ -0,0 +1,182 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package processing
+
+import (
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+var (
+	metricProcessingRetryCount      = promauto.NewCounterVec(prometheus.CounterOpts{Name: "sloop_processing_retry_count"}, []string{"stage"})
+	metricProcessingDeadLetterCount = promauto.NewCounterVec(prometheus.CounterOpts{Name: "sloop_processing_deadletter_count"}, []string{"kind"})
+)
+
+const (
+	maxStageAttempts = 5
+	// Stages waiting for another attempt, past this a failed stage is dead lettered right away so a store that
+	// keeps failing does not pile up watch results in memory
+	maxPendingRetries = 1000
+)
+
+// Doubled after every failed attempt.  A variable so tests do not have to wait
+var initialRetryBackoff = 50 * time.Millisecond
+
+// Errors where the same update can succeed if it is tried again.  Conflicts happen when the webserver or the store
+// manager touch the same keys, and writes are blocked while partition GC drops a prefix
+func isTransientError(err error) bool {
+	cause := errors.Cause(err)
+	return cause == badger.ErrConflict || cause == badger.ErrRetry || cause == badger.ErrBlockedWrites
+}
+
+// A stage that failed with a transient error, waiting for its backoff to pass before it is run again
+type stageRetry struct {
+	stage    string
+	watchRec *typed.KubeWatchResult
+	update   func(txn badgerwrap.Txn) error
+	attempt  int
+	backoff  time.Duration
+	started  time.Time
+}
+
+/*
+Runs one processing stage of a new watch result in its own transaction.  A transient error does not hold up the
+processing goroutine, the stage is queued to run again once its backoff has passed, while the following stages and
+watch results go on.  So only stages that do not depend on the order they run in, like ones keyed by the watch
+timestamp, may use it, the others use runStageWithBackoff.  When the stage still fails after maxStageAttempts the
+watch result ends up in the dead letter table.  Other errors are added to stageErrors.  Callers that need the stage
+to be done before going on, like updateEventFoldTable, pass nil stageErrors and get a single attempt.  Every run is
+recorded in the stage status, retries included in its latency
+*/
+func (r *Runner) runStage(stage string, watchRec *typed.KubeWatchResult, stageErrors map[string]string, update func(txn badgerwrap.Txn) error) error {
+	start := time.Now()
+	err := r.tables.Db().Update(update)
+	if err != nil && isTransientError(err) && stageErrors != nil && r.pendingRetries < maxPendingRetries {
+		r.scheduleRetry(&stageRetry{stage: stage, watchRec: watchRec, update: update, attempt: 1, backoff: initialRetryBackoff, started: start}, err)
+		return err
+	}
+	r.finishStage(stage, watchRec, start, err)
+	if err != nil && stageErrors != nil {
+		stageErrors[stage] = err.Error()
+	}
+	return err
+}
+
+// Same as runStage, but waits out the backoff between attempts.  For stages that must be done before the later stages
+// of the watch result run, and for callers outside the processing goroutine, like reprocessing, which need to know
+// what failed before going on
+func (r *Runner) runStageWithBackoff(stage string, watchRec *typed.KubeWatchResult, stageErrors map[string]string, update func(txn badgerwrap.Txn) error) error {
+	start := time.Now()
+	backoff := initialRetryBackoff
+	var err error
+	for attempt := 1; attempt <= maxStageAttempts; attempt++ {
+		err = r.tables.Db().Update(update)
+		if err == nil || !isTransientError(err) {
+			break
+		}
+		if attempt < maxStageAttempts {
+			glog.V(2).Infof("Retrying %v after transient error: %v", stage, err)
+			metricProcessingRetryCount.WithLabelValues(stage).Inc()
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+	r.finishStage(stage, watchRec, start, err)
+	if err != nil && stageErrors != nil {
+		stageErrors[stage] = err.Error()
+	}
+	return err
+}
+
+func (r *Runner) finishStage(stage string, watchRec *typed.KubeWatchResult, start time.Time, err error) {
+	watchTime, _ := ptypes.Timestamp(watchRec.Timestamp)
+	stageStats.record(stage, watchTime, time.Since(start), err)
+	if err != nil {
+		r.processingFailed(stage, err)
+	}
+}
+
+// Only called from the processing goroutine, which owns pendingRetries
+func (r *Runner) scheduleRetry(retry *stageRetry, err error) {
+	glog.V(2).Infof("Retrying %v in %v after transient error: %v", retry.stage, retry.backoff, err)
+	metricProcessingRetryCount.WithLabelValues(retry.stage).Inc()
+	r.pendingRetries++
+	time.AfterFunc(retry.backoff, func() {
+		r.retryChan <- retry
+	})
+}
+
+func (r *Runner) retryStage(retry *stageRetry) {
+	r.pendingRetries--
+	retry.attempt++
+	err := r.tables.Db().Update(retry.update)
+	if err != nil && isTransientError(err) && retry.attempt < maxStageAttempts {
+		retry.backoff *= 2
+		r.scheduleRetry(retry, err)
+		return
+	}
+	r.finishStage(retry.stage, retry.watchRec, retry.started, err)
+	if err != nil {
+		metadata, _ := kubeextractor.ExtractMetadata(retry.watchRec.Payload)
+		metadata.Namespace = kubeextractor.NormalizeNamespace(retry.watchRec.Kind, metadata.Namespace)
+		r.deadLetter(retry.watchRec, &metadata, map[string]string{retry.stage: err.Error()})
+	}
+}
+
+// Runs the queued retries until there are none left, for shutdown and tests
+func (r *Runner) runPendingRetries() {
+	for r.pendingRetries > 0 {
+		r.retryStage(<-r.retryChan)
+	}
+}
+
+// Stores the watch result along with the stages that failed for it, so it can be inspected and replayed later
+func (r *Runner) deadLetter(watchRec *typed.KubeWatchResult, metadata *kubeextractor.KubeMetadata, stageErrors map[string]string) {
+	glog.Warningf("Writing %v watch result to the dead letter table, failed stages: %v", watchRec.Kind, stageErrors)
+	metricProcessingDeadLetterCount.WithLabelValues(watchRec.Kind).Inc()
+	err := r.tables.Db().Update(func(txn badgerwrap.Txn) error {
+		return updateDeadLetterTable(r.tables, txn, watchRec, metadata, stageErrors)
+	})
+	if err != nil {
+		r.processingFailed("updateDeadLetterTable", err)
+	}
+}
+
+func updateDeadLetterTable(tables typed.Tables, txn badgerwrap.Txn, watchRec *typed.KubeWatchResult, metadata *kubeextractor.KubeMetadata, stageErrors map[string]string) error {
+	ts, err := ptypes.Timestamp(watchRec.Timestamp)
+	if err != nil {
+		return err
+	}
+
+	key := typed.NewDeadLetterKey(untyped.GetPartitionId(ts), keySafe(watchRec.Kind), keySafe(metadata.Namespace), keySafe(metadata.Name), ts)
+	value := &typed.DeadLetter{WatchResult: watchRec, StageErrors: map[string]string{}}
+	// A stage that gave up after its retries comes after the other stages of the same watch result were dead lettered
+	existing, err := tables.DeadLetterTable().Get(txn, key.String())
+	if err != nil && err != badger.ErrKeyNotFound {
+		return err
+	}
+	if existing != nil {
+		for stage, stageErr := range existing.StageErrors {
+			value.StageErrors[stage] = stageErr
+		}
+	}
+	for stage, stageErr := range stageErrors {
+		value.StageErrors[stage] = stageErr
+	}
+	return tables.DeadLetterTable().Set(txn, key.String(), value)
+}
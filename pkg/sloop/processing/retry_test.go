@@ -0,0 +1,184 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package processing
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+// Fails with a conflict the first few times it is called
+type conflictingProcessor struct {
+	failures int
+	calls    int
+}
+
+func (p *conflictingProcessor) Name() string {
+	return "conflicting"
+}
+
+func (p *conflictingProcessor) TableNames() []string {
+	return []string{}
+}
+
+func (p *conflictingProcessor) Process(tables typed.Tables, txn badgerwrap.Txn, watchRec *typed.KubeWatchResult, metadata *kubeextractor.KubeMetadata) error {
+	p.calls++
+	if p.calls <= p.failures {
+		return badger.ErrConflict
+	}
+	return nil
+}
+
+// Fails the first writes of keys with the prefix with a conflict
+type conflictingDb struct {
+	badgerwrap.DB
+	prefix   string
+	failures int
+}
+
+func (db *conflictingDb) Update(fn func(txn badgerwrap.Txn) error) error {
+	return db.DB.Update(func(txn badgerwrap.Txn) error {
+		return fn(&conflictingTxn{Txn: txn, db: db})
+	})
+}
+
+type conflictingTxn struct {
+	badgerwrap.Txn
+	db *conflictingDb
+}
+
+func (txn *conflictingTxn) Set(key, val []byte) error {
+	if txn.db.failures > 0 && strings.HasPrefix(string(key), txn.db.prefix) {
+		txn.db.failures--
+		return badger.ErrConflict
+	}
+	return txn.Txn.Set(key, val)
+}
+
+func helper_processWithProcessors(t *testing.T, processors ...Processor) typed.Tables {
+	initialRetryBackoff = time.Millisecond
+	r := helper_newRetryRunner(t, processors...)
+	r.runPendingRetries()
+	return r.tables
+}
+
+// Processes one watch result without running the retries it queued
+func helper_newRetryRunner(t *testing.T, processors ...Processor) *Runner {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	r := NewProcessing(nil, tables, false, time.Hour, 0, nil, 0, nil)
+	r.processors = processors
+
+	ts, err := ptypes.TimestampProto(someWatchTime)
+	assert.Nil(t, err)
+	r.processWatchResult(&typed.KubeWatchResult{Timestamp: ts, Kind: someKind, Payload: somePodPayload})
+	return r
+}
+
+func helper_getDeadLetters(t *testing.T, tables typed.Tables) map[typed.DeadLetterKey]*typed.DeadLetter {
+	var deadLetters map[typed.DeadLetterKey]*typed.DeadLetter
+	err := tables.Db().View(func(txn badgerwrap.Txn) error {
+		var err error
+		deadLetters, _, err = tables.DeadLetterTable().RangeRead(txn, nil, nil, nil, someWatchTime, someWatchTime)
+		return err
+	})
+	assert.Nil(t, err)
+	return deadLetters
+}
+
+func Test_isTransientError(t *testing.T) {
+	assert.True(t, isTransientError(badger.ErrConflict))
+	assert.True(t, isTransientError(errors.Wrap(badger.ErrBlockedWrites, "Put failed")))
+	assert.False(t, isTransientError(badger.ErrTxnTooBig))
+	assert.False(t, isTransientError(fmt.Errorf("failed")))
+}
+
+func Test_processWatchResult_TransientErrorIsRetried(t *testing.T) {
+	processor := &conflictingProcessor{failures: 2}
+	tables := helper_processWithProcessors(t, processor)
+
+	assert.Equal(t, 3, processor.calls)
+	assert.Len(t, helper_getDeadLetters(t, tables), 0)
+}
+
+func Test_processWatchResult_RepeatedFailureIsDeadLettered(t *testing.T) {
+	processor := &conflictingProcessor{failures: maxStageAttempts}
+	tables := helper_processWithProcessors(t, processor, &fakeProcessor{name: "fails", err: fmt.Errorf("failed")})
+
+	assert.Equal(t, maxStageAttempts, processor.calls)
+	deadLetters := helper_getDeadLetters(t, tables)
+	assert.Len(t, deadLetters, 1)
+	for key, deadLetter := range deadLetters {
+		assert.Equal(t, "someName", key.Name)
+		assert.Equal(t, somePodPayload, deadLetter.WatchResult.Payload)
+		assert.Equal(t, map[string]string{
+			"processor conflicting": badger.ErrConflict.Error(),
+			"processor fails":       "failed",
+		}, deadLetter.StageErrors)
+	}
+}
+
+func Test_processWatchResult_RetryDoesNotBlock(t *testing.T) {
+	initialRetryBackoff = time.Hour
+	defer func() { initialRetryBackoff = time.Millisecond }()
+	processor := &conflictingProcessor{failures: 1}
+	r := helper_newRetryRunner(t, processor)
+
+	assert.Equal(t, 1, processor.calls)
+	assert.Equal(t, 1, r.pendingRetries)
+	assert.Len(t, helper_getDeadLetters(t, r.tables), 0)
+}
+
+func Test_retryStage_RunsQueuedStage(t *testing.T) {
+	processor := &conflictingProcessor{failures: 1}
+	initialRetryBackoff = time.Millisecond
+	r := helper_newRetryRunner(t, processor)
+	assert.Equal(t, 1, r.pendingRetries)
+
+	r.retryStage(<-r.retryChan)
+	assert.Equal(t, 2, processor.calls)
+	assert.Equal(t, 0, r.pendingRetries)
+	assert.Len(t, helper_getDeadLetters(t, r.tables), 0)
+}
+
+func Test_processWatchResult_EventCountIsRetriedBeforeWatchTable(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour * 24)
+	initialRetryBackoff = time.Millisecond
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(&conflictingDb{DB: db, prefix: "/eventcount/", failures: 1})
+	// Event counts are only kept within the partitions of the watch table
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		key := typed.NewWatchTableKey(untyped.GetPartitionId(someEventWatchTs), "Pod", "someNamespace", "somePodName", someEventWatchTs.Add(-time.Minute))
+		return tables.WatchTable().Set(txn, key.String(), &typed.KubeWatchResult{Kind: "Pod", Payload: someNamePodPayload})
+	})
+	assert.Nil(t, err)
+	r := NewProcessing(nil, tables, false, someMaxLookback, 0, nil, 0, nil)
+	r.processors = nil
+
+	r.processWatchResult(&typed.KubeWatchResult{Kind: kubeextractor.EventKind, Timestamp: someEventWatchPTime, Payload: get_event_pay_load(firstTimeStamp, lastTimeStamp, "somePodUid")})
+	r.runPendingRetries()
+
+	// A retry after the watch table stage would see the event itself as its previous copy and count nothing
+	counts, err := getEventKey(db, tables.EventCountTable(), expectedEventKey)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(4), counts.MapMinToEvents[expectedEventMinKey].MapReasonToCount[expectedEventReason])
+}
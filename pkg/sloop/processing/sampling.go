@@ -0,0 +1,110 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package processing
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+)
+
+var metricProcessingSampledCount = promauto.NewCounterVec(prometheus.CounterOpts{Name: "sloop_processing_sampled_count"}, []string{"kind"})
+
+// Limits how many payload versions of each resource of a kind are written to the watch table.  Deletes, status phase
+// and api version changes and the first payload of each partition are always written.  All other tables still see every watch result
+type SamplingPolicy struct {
+	// Keep at most one payload version per resource within this interval.  0 keeps all of them
+	MinInterval time.Duration `json:"minInterval"`
+}
+
+// Kind -> sampling policy.  Kinds without a policy are not sampled
+type SamplingConfig map[string]SamplingPolicy
+
+func (c SamplingConfig) Validate() error {
+	for kind, policy := range c {
+		if policy.MinInterval < 0 {
+			return fmt.Errorf("sampling minInterval for kind %v can not be < 0", kind)
+		}
+	}
+	return nil
+}
+
+// Returns true when the payload should not be written because the previous version in the watch table is newer
+// than the sampling interval of the kind.  The dropped payload is not kept anywhere, so a resource that stops
+// changing right after a dropped update shows the older version until its next write
+func shouldSampleOut(policy SamplingPolicy, prevValue *typed.KubeWatchResult, watchRec *typed.KubeWatchResult, metadata *kubeextractor.KubeMetadata) (bool, error) {
+	if policy.MinInterval <= 0 || prevValue == nil || watchRec.WatchType == typed.KubeWatchResult_DELETE {
+		return false, nil
+	}
+
+	prevTime, err := ptypes.Timestamp(prevValue.Timestamp)
+	if err != nil {
+		return false, errors.Wrapf(err, "Could not convert timestamp %v", prevValue.Timestamp)
+	}
+	newTime, err := ptypes.Timestamp(watchRec.Timestamp)
+	if err != nil {
+		return false, errors.Wrapf(err, "Could not convert timestamp %v", watchRec.Timestamp)
+	}
+	if newTime.Sub(prevTime) >= policy.MinInterval || typed.WatchResultApiVersion(prevValue) != typed.WatchResultApiVersion(watchRec) {
+		return false, nil
+	}
+
+	// A new resource with a reused name is a key transition too
+	prevMetadata, err := kubeextractor.ExtractMetadata(prevValue.Payload)
+	if err != nil || prevMetadata.Uid != metadata.Uid {
+		return false, nil
+	}
+	prevPhase, err := kubeextractor.ExtractStatusPhase(prevValue.Payload)
+	if err != nil {
+		return false, nil
+	}
+	newPhase, err := kubeextractor.ExtractStatusPhase(watchRec.Payload)
+	if err != nil {
+		return false, errors.Wrap(err, "Could not extract status phase")
+	}
+	if prevPhase != newPhase {
+		return false, nil
+	}
+	// So the version a debugging session started or ended with is kept, and compared to for its events
+	if watchRec.Kind == kubeextractor.PodKind {
+		prevStatus, prevErr := kubeextractor.ExtractPodStatus(prevValue.Payload)
+		newStatus, newErr := kubeextractor.ExtractPodStatus(watchRec.Payload)
+		if prevErr == nil && newErr == nil && !reflect.DeepEqual(prevStatus.EphemeralContainers, newStatus.EphemeralContainers) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (r *Runner) samplingPolicy(kind string) SamplingPolicy {
+	r.samplingLock.RLock()
+	defer r.samplingLock.RUnlock()
+	return r.sampling[kind]
+}
+
+// Changes the sampling interval of a kind while processing runs.  0 stops sampling it
+func (r *Runner) SetSamplingInterval(kind string, interval time.Duration) {
+	r.samplingLock.Lock()
+	defer r.samplingLock.Unlock()
+	// Copied so the config the runner was made with is not changed
+	sampling := SamplingConfig{}
+	for k, v := range r.sampling {
+		sampling[k] = v
+	}
+	policy := sampling[kind]
+	policy.MinInterval = interval
+	sampling[kind] = policy
+	r.sampling = sampling
+}
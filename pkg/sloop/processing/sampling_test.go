@@ -0,0 +1,137 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package processing
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+func helper_sampledWatchRec(t *testing.T, offset time.Duration, watchType typed.KubeWatchResult_WatchType, uid string, phase string) *typed.KubeWatchResult {
+	ts, err := ptypes.TimestampProto(someWatchTime.Add(offset))
+	assert.Nil(t, err)
+	payload := fmt.Sprintf(`{"metadata":{"name":"somename","namespace":"somens","uid":"%v","resourceVersion":"%v"},"status":{"phase":"%v"}}`, uid, offset.Nanoseconds(), phase)
+	return &typed.KubeWatchResult{Kind: kubeextractor.PodKind, WatchType: watchType, Timestamp: ts, Payload: payload}
+}
+
+func Test_shouldSampleOut(t *testing.T) {
+	policy := SamplingPolicy{MinInterval: time.Minute}
+	prev := helper_sampledWatchRec(t, 0, typed.KubeWatchResult_UPDATE, "uid1", "Running")
+	metadata := &kubeextractor.KubeMetadata{Uid: "uid1"}
+
+	sampled, err := shouldSampleOut(policy, prev, helper_sampledWatchRec(t, 10*time.Second, typed.KubeWatchResult_UPDATE, "uid1", "Running"), metadata)
+	assert.Nil(t, err)
+	assert.True(t, sampled)
+
+	// Always kept
+	for _, watchRec := range []*typed.KubeWatchResult{
+		helper_sampledWatchRec(t, time.Minute, typed.KubeWatchResult_UPDATE, "uid1", "Running"),
+		helper_sampledWatchRec(t, 10*time.Second, typed.KubeWatchResult_DELETE, "uid1", "Running"),
+		helper_sampledWatchRec(t, 10*time.Second, typed.KubeWatchResult_UPDATE, "uid1", "Failed"),
+	} {
+		sampled, err = shouldSampleOut(policy, prev, watchRec, metadata)
+		assert.Nil(t, err)
+		assert.False(t, sampled)
+	}
+
+	sampled, err = shouldSampleOut(policy, prev, helper_sampledWatchRec(t, 10*time.Second, typed.KubeWatchResult_ADD, "uid2", "Running"), &kubeextractor.KubeMetadata{Uid: "uid2"})
+	assert.Nil(t, err)
+	assert.False(t, sampled)
+
+	sampled, err = shouldSampleOut(policy, nil, prev, metadata)
+	assert.Nil(t, err)
+	assert.False(t, sampled)
+
+	sampled, err = shouldSampleOut(SamplingPolicy{}, prev, helper_sampledWatchRec(t, time.Second, typed.KubeWatchResult_UPDATE, "uid1", "Running"), metadata)
+	assert.Nil(t, err)
+	assert.False(t, sampled)
+}
+
+func Test_shouldSampleOut_KeepsEphemeralContainerChanges(t *testing.T) {
+	policy := SamplingPolicy{MinInterval: time.Minute}
+	metadata := &kubeextractor.KubeMetadata{Uid: "somePodUid"}
+	protoTs := func(offset time.Duration) *timestamp.Timestamp {
+		ts, _ := ptypes.TimestampProto(someWatchTime.Add(offset))
+		return ts
+	}
+	debugger := `[{"name":"debugger"}]`
+	prev := &typed.KubeWatchResult{Kind: kubeextractor.PodKind, Timestamp: protoTs(0), Payload: fmt.Sprintf(someDebugPodPayload, 1, debugger, `[]`)}
+
+	started := &typed.KubeWatchResult{Kind: kubeextractor.PodKind, Timestamp: protoTs(time.Second), Payload: fmt.Sprintf(someDebugPodPayload, 2, debugger, `[{"name":"debugger","state":{"running":{}}}]`)}
+	sampled, err := shouldSampleOut(policy, prev, started, metadata)
+	assert.Nil(t, err)
+	assert.False(t, sampled)
+
+	same := &typed.KubeWatchResult{Kind: kubeextractor.PodKind, Timestamp: protoTs(time.Second), Payload: fmt.Sprintf(someDebugPodPayload, 2, debugger, `[]`)}
+	sampled, err = shouldSampleOut(policy, prev, same, metadata)
+	assert.Nil(t, err)
+	assert.True(t, sampled)
+}
+
+func Test_updateKubeWatchTable_Sampling(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+
+	inRecs := []*typed.KubeWatchResult{
+		helper_sampledWatchRec(t, 0, typed.KubeWatchResult_ADD, "uid1", "Pending"),
+		helper_sampledWatchRec(t, 10*time.Second, typed.KubeWatchResult_UPDATE, "uid1", "Pending"),
+		helper_sampledWatchRec(t, 20*time.Second, typed.KubeWatchResult_UPDATE, "uid1", "Running"),
+		helper_sampledWatchRec(t, 30*time.Second, typed.KubeWatchResult_UPDATE, "uid1", "Running"),
+		helper_sampledWatchRec(t, 90*time.Second, typed.KubeWatchResult_UPDATE, "uid1", "Running"),
+		helper_sampledWatchRec(t, 95*time.Second, typed.KubeWatchResult_DELETE, "uid1", "Running"),
+	}
+	for _, watchRec := range inRecs {
+		err = tables.Db().Update(func(txn badgerwrap.Txn) error {
+			metadata, err := kubeextractor.ExtractMetadata(watchRec.Payload)
+			assert.Nil(t, err)
+			return updateKubeWatchTable(tables, txn, watchRec, &metadata, false, SamplingPolicy{MinInterval: time.Minute})
+		})
+		assert.Nil(t, err)
+	}
+
+	var stored []*typed.KubeWatchResult
+	err = tables.Db().View(func(txn badgerwrap.Txn) error {
+		results, _, err := tables.WatchTable().RangeRead(txn, nil, nil, nil, someWatchTime.Add(-time.Hour), someWatchTime.Add(time.Hour))
+		for _, result := range results {
+			stored = append(stored, result)
+		}
+		return err
+	})
+	assert.Nil(t, err)
+	// The add, the phase change, the update after the interval and the delete
+	assert.Len(t, stored, 4)
+}
+
+func Test_SamplingConfig_Validate(t *testing.T) {
+	assert.Nil(t, SamplingConfig{"Pod": {MinInterval: time.Minute}}.Validate())
+	assert.NotNil(t, SamplingConfig{"Pod": {MinInterval: -time.Minute}}.Validate())
+}
+
+func Test_SetSamplingInterval(t *testing.T) {
+	config := SamplingConfig{"Pod": {MinInterval: time.Minute}}
+	runner := NewProcessing(nil, nil, false, time.Hour, 0, config, 0, nil)
+
+	runner.SetSamplingInterval("Node", time.Hour)
+	runner.SetSamplingInterval("Pod", 0)
+	assert.Equal(t, SamplingPolicy{MinInterval: time.Hour}, runner.samplingPolicy("Node"))
+	assert.Equal(t, SamplingPolicy{}, runner.samplingPolicy("Pod"))
+	// The config that was passed in is not changed
+	assert.Equal(t, time.Minute, config["Pod"].MinInterval)
+}
@@ -0,0 +1,71 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package processing
+
+import (
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+// Records a snapshot of the backends of a service whenever one of its EndpointSlice or Endpoints objects changes
+// them.  Like the pod lifecycle each partition starts with the backends at its first watch result.
+func updateServiceBackendsTable(tables typed.Tables, txn badgerwrap.Txn, watchRec *typed.KubeWatchResult, metadata *kubeextractor.KubeMetadata) error {
+	if watchRec.Kind != kubeextractor.EndpointSliceKind && watchRec.Kind != kubeextractor.EndpointsKind {
+		return nil
+	}
+
+	timestamp, err := ptypes.Timestamp(watchRec.Timestamp)
+	if err != nil {
+		return errors.Wrapf(err, "Could not convert timestamp %v", watchRec.Timestamp)
+	}
+
+	service, backends, err := kubeextractor.ExtractServiceBackends(watchRec.Kind, watchRec.Payload)
+	if err != nil {
+		return errors.Wrap(err, "Could not extract service backends")
+	}
+	service = keySafe(service)
+	if service == "" {
+		return nil
+	}
+
+	key := typed.NewServiceBackendsKey(untyped.GetPartitionId(timestamp), metadata.Namespace, service, watchRec.Kind, metadata.Name)
+	record, err := tables.ServiceBackendsTable().GetOrDefault(txn, key.String())
+	if err != nil {
+		return errors.Wrap(err, "Could not get service backends record")
+	}
+
+	snapshot := &typed.ServiceBackendSnapshot{
+		Timestamp: timestamp.Unix(),
+		Deleted:   watchRec.WatchType == typed.KubeWatchResult_DELETE,
+	}
+	if !snapshot.Deleted {
+		for _, backend := range backends {
+			snapshot.Backends = append(snapshot.Backends, &typed.ServiceBackend{
+				Ip:         backend.Ip,
+				Ready:      backend.Ready,
+				TargetKind: backend.TargetKind,
+				TargetName: backend.TargetName,
+				NodeName:   backend.NodeName,
+			})
+		}
+	}
+	if len(record.Snapshots) > 0 && typed.SameServiceBackends(record.Snapshots[len(record.Snapshots)-1], snapshot) {
+		return nil
+	}
+	record.Snapshots = append(record.Snapshots, snapshot)
+
+	err = tables.ServiceBackendsTable().Set(txn, key.String(), record)
+	if err != nil {
+		return errors.Wrap(err, "Failed to put service backends record")
+	}
+	return nil
+}
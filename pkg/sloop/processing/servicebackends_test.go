@@ -0,0 +1,102 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package processing
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+func helper_endpointSlicePayload(resourceVersion int, ips ...string) string {
+	endpoints := ""
+	for idx, ip := range ips {
+		if idx > 0 {
+			endpoints += ","
+		}
+		endpoints += fmt.Sprintf(`{"addresses":["%v"],"targetRef":{"kind":"Pod","name":"pod-%v"}}`, ip, idx)
+	}
+	return fmt.Sprintf(`{"metadata":{"name":"someservice-abcde","namespace":"someNamespace","resourceVersion":"%v","labels":{"kubernetes.io/service-name":"someservice"}},"endpoints":[%v]}`,
+		resourceVersion, endpoints)
+}
+
+func Test_updateServiceBackendsTable_RecordsOnlyChanges(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+
+	updates := []struct {
+		payload   string
+		watchType typed.KubeWatchResult_WatchType
+	}{
+		{helper_endpointSlicePayload(1, "10.0.0.1"), typed.KubeWatchResult_ADD},
+		{helper_endpointSlicePayload(2, "10.0.0.1"), typed.KubeWatchResult_UPDATE},
+		{helper_endpointSlicePayload(3, "10.0.0.1", "10.0.0.2"), typed.KubeWatchResult_UPDATE},
+		{helper_endpointSlicePayload(4, "10.0.0.1", "10.0.0.2"), typed.KubeWatchResult_DELETE},
+	}
+	for idx, update := range updates {
+		ts, err := ptypes.TimestampProto(someWatchTime.Add(time.Duration(idx) * time.Minute))
+		assert.Nil(t, err)
+		watchRec := &typed.KubeWatchResult{Kind: kubeextractor.EndpointSliceKind, WatchType: update.watchType, Timestamp: ts, Payload: update.payload}
+		metadata, err := kubeextractor.ExtractMetadata(watchRec.Payload)
+		assert.Nil(t, err)
+		err = db.Update(func(txn badgerwrap.Txn) error {
+			return updateServiceBackendsTable(tables, txn, watchRec, &metadata)
+		})
+		assert.Nil(t, err)
+	}
+
+	err = db.View(func(txn badgerwrap.Txn) error {
+		key := typed.NewServiceBackendsKey(untyped.GetPartitionId(someWatchTime), "someNamespace", "someservice", kubeextractor.EndpointSliceKind, "someservice-abcde")
+		record, err := tables.ServiceBackendsTable().Get(txn, key.String())
+		assert.Nil(t, err)
+		assert.Len(t, record.Snapshots, 3)
+		assert.Len(t, record.Snapshots[0].Backends, 1)
+		assert.Len(t, record.Snapshots[1].Backends, 2)
+		assert.Equal(t, "pod-1", record.Snapshots[1].Backends[1].TargetName)
+		assert.True(t, record.Snapshots[2].Deleted)
+		assert.Len(t, record.Snapshots[2].Backends, 0)
+		return nil
+	})
+	assert.Nil(t, err)
+}
+
+func Test_updateServiceBackendsTable_IgnoresSliceWithoutService(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+
+	ts, err := ptypes.TimestampProto(someWatchTime)
+	assert.Nil(t, err)
+	payload := `{"metadata":{"name":"custom-slice","namespace":"someNamespace"},"endpoints":[{"addresses":["10.0.0.1"]}]}`
+	watchRec := &typed.KubeWatchResult{Kind: kubeextractor.EndpointSliceKind, WatchType: typed.KubeWatchResult_ADD, Timestamp: ts, Payload: payload}
+	metadata, err := kubeextractor.ExtractMetadata(watchRec.Payload)
+	assert.Nil(t, err)
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		return updateServiceBackendsTable(tables, txn, watchRec, &metadata)
+	})
+	assert.Nil(t, err)
+
+	err = db.View(func(txn badgerwrap.Txn) error {
+		records, _, err := tables.ServiceBackendsTable().RangeRead(txn, nil, nil, nil, someWatchTime, someWatchTime)
+		assert.Nil(t, err)
+		assert.Len(t, records, 0)
+		return nil
+	})
+	assert.Nil(t, err)
+}
@@ -0,0 +1,140 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package processing
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	metricProcessingStageProcessedCount = promauto.NewCounterVec(prometheus.CounterOpts{Name: "sloop_processing_stage_processed_count"}, []string{"stage"})
+	metricProcessingStageErrorCount     = promauto.NewCounterVec(prometheus.CounterOpts{Name: "sloop_processing_stage_error_count"}, []string{"stage"})
+	metricProcessingStageLatency        = promauto.NewGaugeVec(prometheus.GaugeOpts{Name: "sloop_processing_stage_latency_sec"}, []string{"stage"})
+	metricProcessingStageLag            = promauto.NewGaugeVec(prometheus.GaugeOpts{Name: "sloop_processing_stage_lag_sec"}, []string{"stage"})
+)
+
+const (
+	// Error budget of each stage, as a fraction of its most recent results
+	stageErrorBudget     = 0.01
+	stageErrorWindowSize = 1000
+)
+
+// Status of one processing stage since startup.  Lag is how far the watch results this stage last stored are behind
+// the newest watch result seen by any stage, so a stage that keeps failing falls behind while the others keep up.
+type StageStatus struct {
+	Stage           string    `json:"stage"`
+	Processed       int64     `json:"processed"`
+	Errors          int64     `json:"errors"`
+	RecentErrorRate float64   `json:"recentErrorRate"`
+	OverErrorBudget bool      `json:"overErrorBudget"`
+	LastLatencySec  float64   `json:"lastLatencySec"`
+	LastSuccess     time.Time `json:"lastSuccess"`
+	LastWatchTime   time.Time `json:"lastWatchTime"` // Timestamp of the last watch result stored by this stage
+	LagSec          float64   `json:"lagSec"`        // 0 until the stage has stored something
+	LastError       string    `json:"lastError,omitempty"`
+	LastErrorTime   time.Time `json:"lastErrorTime"`
+}
+
+type stageState struct {
+	status StageStatus
+	// Ring buffer of whether each of the most recent results failed
+	recentFailures   []bool
+	recentNextIdx    int
+	recentErrorCount int
+}
+
+type stageTracker struct {
+	lock            *sync.Mutex
+	stages          map[string]*stageState
+	newestWatchTime time.Time
+}
+
+var stageStats = &stageTracker{lock: &sync.Mutex{}, stages: map[string]*stageState{}}
+
+func (st *stageTracker) record(stage string, watchTime time.Time, latency time.Duration, err error) {
+	metricProcessingStageProcessedCount.WithLabelValues(stage).Inc()
+	metricProcessingStageLatency.WithLabelValues(stage).Set(latency.Seconds())
+	if err != nil {
+		metricProcessingStageErrorCount.WithLabelValues(stage).Inc()
+	}
+
+	st.lock.Lock()
+	defer st.lock.Unlock()
+	state, ok := st.stages[stage]
+	if !ok {
+		state = &stageState{status: StageStatus{Stage: stage}}
+		st.stages[stage] = state
+	}
+	status := &state.status
+	if watchTime.After(st.newestWatchTime) {
+		st.newestWatchTime = watchTime
+	}
+
+	status.Processed++
+	status.LastLatencySec = latency.Seconds()
+	if err != nil {
+		status.Errors++
+		status.LastError = err.Error()
+		status.LastErrorTime = time.Now()
+	} else {
+		status.LastSuccess = time.Now()
+		if watchTime.After(status.LastWatchTime) {
+			status.LastWatchTime = watchTime
+		}
+	}
+	state.addRecent(err != nil)
+	if !status.LastWatchTime.IsZero() {
+		metricProcessingStageLag.WithLabelValues(stage).Set(st.newestWatchTime.Sub(status.LastWatchTime).Seconds())
+	}
+}
+
+func (s *stageState) addRecent(failed bool) {
+	if len(s.recentFailures) < stageErrorWindowSize {
+		s.recentFailures = append(s.recentFailures, failed)
+	} else {
+		if s.recentFailures[s.recentNextIdx] {
+			s.recentErrorCount--
+		}
+		s.recentFailures[s.recentNextIdx] = failed
+		s.recentNextIdx = (s.recentNextIdx + 1) % stageErrorWindowSize
+	}
+	if failed {
+		s.recentErrorCount++
+	}
+}
+
+// Returns a copy of the status of every stage that has run, sorted by stage name
+func GetStageStatus() []StageStatus {
+	return stageStats.snapshot()
+}
+
+func (st *stageTracker) snapshot() []StageStatus {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+	ret := []StageStatus{}
+	for _, state := range st.stages {
+		status := state.status
+		if len(state.recentFailures) > 0 {
+			status.RecentErrorRate = float64(state.recentErrorCount) / float64(len(state.recentFailures))
+		}
+		status.OverErrorBudget = status.RecentErrorRate > stageErrorBudget
+		if !status.LastWatchTime.IsZero() {
+			status.LagSec = st.newestWatchTime.Sub(status.LastWatchTime).Seconds()
+		}
+		ret = append(ret, status)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Stage < ret[j].Stage
+	})
+	return ret
+}
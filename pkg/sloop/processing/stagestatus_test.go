@@ -0,0 +1,49 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package processing
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_stageTracker_TracksCountsAndLag(t *testing.T) {
+	st := &stageTracker{lock: &sync.Mutex{}, stages: map[string]*stageState{}}
+	st.record("good", someWatchTime, time.Millisecond, nil)
+	st.record("broken", someWatchTime, time.Millisecond, nil)
+	st.record("good", someWatchTime.Add(time.Minute), time.Millisecond, nil)
+	st.record("broken", someWatchTime.Add(time.Minute), time.Millisecond, fmt.Errorf("failed"))
+
+	status := st.snapshot()
+	assert.Len(t, status, 2)
+	assert.Equal(t, "broken", status[0].Stage)
+	assert.Equal(t, int64(2), status[0].Processed)
+	assert.Equal(t, int64(1), status[0].Errors)
+	assert.Equal(t, "failed", status[0].LastError)
+	assert.Equal(t, 60.0, status[0].LagSec)
+	assert.Equal(t, 0.5, status[0].RecentErrorRate)
+	assert.True(t, status[0].OverErrorBudget)
+
+	assert.Equal(t, "good", status[1].Stage)
+	assert.Equal(t, 0.0, status[1].LagSec)
+	assert.False(t, status[1].OverErrorBudget)
+}
+
+func Test_stageState_RecentErrorsOnlyCoverWindow(t *testing.T) {
+	state := &stageState{}
+	state.addRecent(true)
+	for i := 0; i < stageErrorWindowSize; i++ {
+		state.addRecent(false)
+	}
+	assert.Equal(t, 0, state.recentErrorCount)
+	assert.Len(t, state.recentFailures, stageErrorWindowSize)
+}
@@ -0,0 +1,69 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package processing
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/tenant"
+)
+
+var metricTenantQuotaDroppedCount = promauto.NewCounterVec(prometheus.CounterOpts{Name: "sloop_tenant_quota_dropped_count"}, []string{"tenant"})
+
+const tenantQuotaWindow = time.Hour
+
+/*
+Counts the watch results of each tenant per hour of watch time and drops them once the tenant is over its
+MaxWatchResultsPerHour, so one noisy team can not fill the store that everybody shares.  Deletes are always kept so
+resources do not stay alive forever.  Counts are kept in memory and start over on restart
+*/
+type tenantQuota struct {
+	tenants     tenant.Map
+	windowStart time.Time
+	counts      map[string]int
+}
+
+func newTenantQuota(tenants tenant.Map) *tenantQuota {
+	return &tenantQuota{tenants: tenants, counts: map[string]int{}}
+}
+
+func (q *tenantQuota) allow(watchRec *typed.KubeWatchResult, metadata *kubeextractor.KubeMetadata) bool {
+	if len(q.tenants) == 0 || watchRec.WatchType == typed.KubeWatchResult_DELETE {
+		return true
+	}
+	namespace := metadata.Namespace
+	if watchRec.Kind == kubeextractor.NamespaceKind {
+		namespace = metadata.Name
+	}
+	tenantName := q.tenants.TenantForNamespace(namespace)
+	limit := q.tenants[tenantName].MaxWatchResultsPerHour
+	if tenantName == "" || limit <= 0 {
+		return true
+	}
+
+	ts, err := ptypes.Timestamp(watchRec.Timestamp)
+	if err != nil {
+		return true
+	}
+	if window := ts.Truncate(tenantQuotaWindow); window.After(q.windowStart) {
+		q.windowStart = window
+		q.counts = map[string]int{}
+	}
+	if q.counts[tenantName] >= limit {
+		metricTenantQuotaDroppedCount.WithLabelValues(tenantName).Inc()
+		return false
+	}
+	q.counts[tenantName]++
+	return true
+}
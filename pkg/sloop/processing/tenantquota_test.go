@@ -0,0 +1,49 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package processing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/tenant"
+	"github.com/stretchr/testify/assert"
+)
+
+func helper_quotaWatchResult(t *testing.T, kind string, watchType typed.KubeWatchResult_WatchType, ts time.Time) *typed.KubeWatchResult {
+	pts, err := ptypes.TimestampProto(ts)
+	assert.Nil(t, err)
+	return &typed.KubeWatchResult{Kind: kind, WatchType: watchType, Timestamp: pts}
+}
+
+func Test_tenantQuota(t *testing.T) {
+	q := newTenantQuota(tenant.Map{
+		"limited":   {Namespaces: []string{"small"}, MaxWatchResultsPerHour: 2},
+		"unlimited": {Namespaces: []string{"big"}},
+	})
+	small := &kubeextractor.KubeMetadata{Namespace: "small", Name: "pod"}
+	big := &kubeextractor.KubeMetadata{Namespace: "big", Name: "pod"}
+	hour := someWatchTime.Truncate(time.Hour)
+
+	assert.True(t, q.allow(helper_quotaWatchResult(t, "Pod", typed.KubeWatchResult_UPDATE, hour), small))
+	assert.True(t, q.allow(helper_quotaWatchResult(t, "Pod", typed.KubeWatchResult_UPDATE, hour), small))
+	assert.False(t, q.allow(helper_quotaWatchResult(t, "Pod", typed.KubeWatchResult_UPDATE, hour), small))
+	// The namespace itself counts against its tenant too
+	assert.False(t, q.allow(helper_quotaWatchResult(t, kubeextractor.NamespaceKind, typed.KubeWatchResult_UPDATE, hour), &kubeextractor.KubeMetadata{Name: "small"}))
+	assert.True(t, q.allow(helper_quotaWatchResult(t, "Pod", typed.KubeWatchResult_DELETE, hour), small))
+	for i := 0; i < 5; i++ {
+		assert.True(t, q.allow(helper_quotaWatchResult(t, "Pod", typed.KubeWatchResult_UPDATE, hour), big))
+	}
+	assert.True(t, q.allow(helper_quotaWatchResult(t, "Node", typed.KubeWatchResult_UPDATE, hour), &kubeextractor.KubeMetadata{Name: "node"}))
+
+	// The next hour starts over
+	assert.True(t, q.allow(helper_quotaWatchResult(t, "Pod", typed.KubeWatchResult_UPDATE, hour.Add(time.Hour)), small))
+}
@@ -0,0 +1,97 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package processing
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+var metricProcessingQuarantineCount = promauto.NewCounterVec(prometheus.CounterOpts{Name: "sloop_processing_quarantine_count"}, []string{"kind"})
+
+// Checks that the payload is a json object of the kind it was watched as.  Objects from informers usually have
+// no type meta, so kind is only compared when the payload has one.
+func validatePayload(watchRec *typed.KubeWatchResult) error {
+	if !json.Valid([]byte(watchRec.Payload)) {
+		return fmt.Errorf("payload is not valid json")
+	}
+	resource := struct {
+		Kind     string
+		Metadata struct {
+			Name string
+		}
+	}{}
+	err := json.Unmarshal([]byte(watchRec.Payload), &resource)
+	if err != nil {
+		return fmt.Errorf("payload is not a kubernetes object: %v", err)
+	}
+	if resource.Kind != "" && resource.Kind != watchRec.Kind {
+		return fmt.Errorf("payload kind %q does not match watched kind %q", resource.Kind, watchRec.Kind)
+	}
+	if resource.Metadata.Name == "" {
+		return fmt.Errorf("payload has no metadata.name")
+	}
+	return nil
+}
+
+// Stores the watch result in the quarantine table instead of processing it
+func (r *Runner) quarantine(watchRec *typed.KubeWatchResult, validationErr error) {
+	glog.Warningf("Quarantining %v payload: %v", watchRec.Kind, validationErr)
+	metricProcessingQuarantineCount.WithLabelValues(watchRec.Kind).Inc()
+	err := r.tables.Db().Update(func(txn badgerwrap.Txn) error {
+		return updateQuarantineTable(r.tables, txn, watchRec, validationErr)
+	})
+	if err != nil {
+		r.processingFailed("updateQuarantineTable", err)
+	}
+}
+
+func updateQuarantineTable(tables typed.Tables, txn badgerwrap.Txn, watchRec *typed.KubeWatchResult, validationErr error) error {
+	ts, err := ptypes.Timestamp(watchRec.Timestamp)
+	if err != nil {
+		return err
+	}
+
+	// Best effort, a payload that failed validation may not have usable metadata
+	metadata := struct {
+		Metadata struct {
+			Name      string
+			Namespace string
+		}
+	}{}
+	_ = json.Unmarshal([]byte(watchRec.Payload), &metadata)
+	namespace := keySafe(metadata.Metadata.Namespace)
+	name := keySafe(metadata.Metadata.Name)
+
+	key := typed.NewQuarantineKey(untyped.GetPartitionId(ts), keySafe(watchRec.Kind), namespace, name, ts)
+	value := &typed.QuarantinedPayload{
+		Timestamp: watchRec.Timestamp,
+		Kind:      watchRec.Kind,
+		WatchType: watchRec.WatchType,
+		Payload:   []byte(watchRec.Payload),
+		Error:     validationErr.Error(),
+	}
+	return tables.QuarantineTable().Set(txn, key.String(), value)
+}
+
+// Key parts are separated by '/' so anything containing one can not be used
+func keySafe(part string) string {
+	if strings.Contains(part, "/") {
+		return ""
+	}
+	return part
+}
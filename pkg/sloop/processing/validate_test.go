@@ -0,0 +1,58 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package processing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_validatePayload(t *testing.T) {
+	assert.Nil(t, validatePayload(&typed.KubeWatchResult{Kind: someKind, Payload: somePodPayload}))
+	assert.Nil(t, validatePayload(&typed.KubeWatchResult{Kind: someKind, Payload: `{"kind":"Pod","metadata":{"name":"a"}}`}))
+	assert.NotNil(t, validatePayload(&typed.KubeWatchResult{Kind: someKind, Payload: `{"metadata":{"name":"a"}`}))
+	assert.NotNil(t, validatePayload(&typed.KubeWatchResult{Kind: someKind, Payload: `["a"]`}))
+	assert.NotNil(t, validatePayload(&typed.KubeWatchResult{Kind: someKind, Payload: `{"kind":"Node","metadata":{"name":"a"}}`}))
+	assert.NotNil(t, validatePayload(&typed.KubeWatchResult{Kind: someKind, Payload: `{"metadata":{}}`}))
+}
+
+func Test_processWatchResult_MalformedPayloadIsQuarantined(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	r := NewProcessing(nil, tables, false, time.Hour, 0, nil, 0, nil)
+
+	ts, err := ptypes.TimestampProto(someWatchTime)
+	assert.Nil(t, err)
+	badPayload := `{"metadata":{"name":"someName","namespace":"someNamespace"},"spec":`
+	r.processWatchResult(&typed.KubeWatchResult{Timestamp: ts, Kind: someKind, WatchType: typed.KubeWatchResult_UPDATE, Payload: badPayload})
+
+	err = db.View(func(txn badgerwrap.Txn) error {
+		watchRes, _, err := tables.WatchTable().RangeRead(txn, nil, nil, nil, someWatchTime, someWatchTime)
+		assert.Nil(t, err)
+		assert.Len(t, watchRes, 0)
+
+		// Metadata can not be read from broken json so only the kind is in the key
+		key := typed.NewQuarantineKey(untyped.GetPartitionId(someWatchTime), someKind, "", "", someWatchTime)
+		quarantined, err := tables.QuarantineTable().Get(txn, key.String())
+		assert.Nil(t, err)
+		assert.Equal(t, badPayload, string(quarantined.Payload))
+		assert.Equal(t, typed.KubeWatchResult_UPDATE, quarantined.WatchType)
+		assert.Contains(t, quarantined.Error, "not valid json")
+		return nil
+	})
+	assert.Nil(t, err)
+}
@@ -22,13 +22,19 @@ import (
 	"time"
 )
 
+// Returns the last watch result of the resource in the partition of ts that is not newer than ts.  Live watch
+// results arrive in order so this is the last one stored, but merged playback files can fill in older gaps
 func getLastKubeWatchResult(tables typed.Tables, txn badgerwrap.Txn, ts *timestamp.Timestamp, kind string, namespace string, name string) (*typed.KubeWatchResult, error) {
 	keyPrefixWithoutTs, err := toWatchTableKeyPrefix(ts, kind, namespace, name)
 	if err != nil {
 		return nil, err
 	}
+	key, err := toWatchTableKey(ts, kind, namespace, name)
+	if err != nil {
+		return nil, err
+	}
 
-	prevFound, prevKey, err := getLastWatchKey(txn, keyPrefixWithoutTs)
+	prevFound, prevKey, err := getLastWatchKey(txn, keyPrefixWithoutTs, key)
 	if err != nil {
 		return nil, errors.Wrapf(err, "Failure getting previous watch result for %v", keyPrefixWithoutTs.String())
 	}
@@ -43,21 +49,19 @@ func getLastKubeWatchResult(tables typed.Tables, txn badgerwrap.Txn, ts *timesta
 	return prevWatch, nil
 }
 
-// TODO: This code was labeled 'Previous' but really only returns 'Last', it may be helpful to actually have a 'Previous' implementation
 // TODO: Move this to code-gen per table
-func getLastWatchKey(txn badgerwrap.Txn, keyPrefix *typed.WatchTableKey) (bool, string, error) {
+func getLastWatchKey(txn badgerwrap.Txn, keyPrefix *typed.WatchTableKey, maxKey *typed.WatchTableKey) (bool, string, error) {
 	// Retrieve the previous copy of this node and see if differences are important
-	// Badger reverse seek is pretty goofy.  We need a prefix with 255 at the end for the seek, but not for prefix
-	keyPrefixStr := keyPrefix.String()
-	keyPrefixEndBytes := []byte(keyPrefixStr + string(rune(255)))
+	// Badger reverse seek is pretty goofy.  We need a key with 255 at the end for the seek, but not for prefix
 	keyPrefixBytes := []byte(keyPrefix.String())
+	maxKeyEndBytes := []byte(maxKey.String() + string(rune(255)))
 
 	iterOpt := badger.DefaultIteratorOptions
 	iterOpt.Prefix = []byte(keyPrefixBytes)
 	iterOpt.Reverse = true
 	itr := txn.NewIterator(iterOpt)
 	defer itr.Close()
-	itr.Seek([]byte(keyPrefixEndBytes))
+	itr.Seek(maxKeyEndBytes)
 	if itr.ValidForPrefix(keyPrefixBytes) {
 		item := itr.Item()
 		return true, string(item.Key()), nil
@@ -65,11 +69,10 @@ func getLastWatchKey(txn badgerwrap.Txn, keyPrefix *typed.WatchTableKey) (bool,
 	return false, "", nil
 }
 
-func doesNodeHaveMajorUpdates(tables typed.Tables, txn badgerwrap.Txn, watchRec *typed.KubeWatchResult, metadata *kubeextractor.KubeMetadata) (bool, error) {
-	prevValue, err := getLastKubeWatchResult(tables, txn, watchRec.Timestamp, watchRec.Kind, metadata.Namespace, metadata.Name)
-	if err != nil {
-		return false, err
-	}
+// Only this many changed paths are stored with a watch result, ChangedPathCount has the total
+const maxChangedPaths = 20
+
+func doesNodeHaveMajorUpdates(prevValue *typed.KubeWatchResult, watchRec *typed.KubeWatchResult, metadata *kubeextractor.KubeMetadata) (bool, error) {
 	if prevValue == nil {
 		return true, nil
 	}
@@ -82,7 +85,7 @@ func doesNodeHaveMajorUpdates(tables typed.Tables, txn badgerwrap.Txn, watchRec
 	return diff, nil
 }
 
-func updateKubeWatchTable(tables typed.Tables, txn badgerwrap.Txn, watchRec *typed.KubeWatchResult, metadata *kubeextractor.KubeMetadata, keepMinorNodeUpdates bool) error {
+func updateKubeWatchTable(tables typed.Tables, txn badgerwrap.Txn, watchRec *typed.KubeWatchResult, metadata *kubeextractor.KubeMetadata, keepMinorNodeUpdates bool, sampling SamplingPolicy) error {
 	metricProcessingWatchtableUpdatecount.Inc()
 
 	key, err := toWatchTableKey(watchRec.Timestamp, watchRec.Kind, metadata.Namespace, metadata.Name)
@@ -90,8 +93,13 @@ func updateKubeWatchTable(tables typed.Tables, txn badgerwrap.Txn, watchRec *typ
 		return err
 	}
 
+	prevValue, err := getLastKubeWatchResult(tables, txn, watchRec.Timestamp, watchRec.Kind, metadata.Namespace, metadata.Name)
+	if err != nil {
+		return err
+	}
+
 	if watchRec.Kind == kubeextractor.NodeKind && !keepMinorNodeUpdates {
-		hasUpdates, err := doesNodeHaveMajorUpdates(tables, txn, watchRec, metadata)
+		hasUpdates, err := doesNodeHaveMajorUpdates(prevValue, watchRec, metadata)
 		if err != nil {
 			return err
 		}
@@ -101,6 +109,37 @@ func updateKubeWatchTable(tables typed.Tables, txn badgerwrap.Txn, watchRec *typ
 		}
 	}
 
+	sampledOut, err := shouldSampleOut(sampling, prevValue, watchRec, metadata)
+	if err != nil {
+		return err
+	}
+	if sampledOut {
+		glog.V(2).Infof("Not inserting %v because the previous version is within the sampling interval", key.String())
+		metricProcessingSampledCount.WithLabelValues(watchRec.Kind).Inc()
+		return nil
+	}
+
+	err = setChangedPaths(prevValue, watchRec, metadata)
+	if err != nil {
+		return err
+	}
+	prevStored, err := getPreviousStoredWatchResult(tables, txn, prevValue, watchRec, metadata)
+	if err != nil {
+		return err
+	}
+	setVersionType(prevStored, watchRec, metadata)
+	// Sampling and dropping minor node updates leave gaps on purpose, so the old objects that would fill them are not kept
+	setOldPayload(prevStored, watchRec, metadata, keepsVersionGaps(watchRec.Kind, keepMinorNodeUpdates, sampling))
+	err = setApiVersionMigration(tables, txn, prevStored, watchRec, metadata)
+	if err != nil {
+		return err
+	}
+	err = setClusterUpgrade(tables, txn, prevStored, watchRec, metadata)
+	if err != nil {
+		return err
+	}
+
+	typed.SignWatchResult(key.String(), watchRec)
 	err = tables.WatchTable().Set(txn, key.String(), watchRec)
 	if err != nil {
 		return errors.Wrap(err, "Put failed")
@@ -110,6 +149,71 @@ func updateKubeWatchTable(tables typed.Tables, txn badgerwrap.Txn, watchRec *typ
 	return nil
 }
 
+// Stores the paths that changed since the last watch result of the same resource in this partition.  Nothing is
+// stored for the first watch result of a partition, or when the name was reused by a new resource
+func setChangedPaths(prevValue *typed.KubeWatchResult, watchRec *typed.KubeWatchResult, metadata *kubeextractor.KubeMetadata) error {
+	if prevValue == nil {
+		return nil
+	}
+	prevMetadata, err := kubeextractor.ExtractMetadata(prevValue.Payload)
+	if err != nil || prevMetadata.Uid != metadata.Uid {
+		return nil
+	}
+	paths, err := kubeextractor.ComputeChangedPaths(prevValue.Payload, watchRec.Payload)
+	if err != nil {
+		return errors.Wrap(err, "Could not compute changed paths")
+	}
+	watchRec.ChangedPathCount = int32(len(paths))
+	if len(paths) > maxChangedPaths {
+		paths = paths[:maxChangedPaths]
+	}
+	watchRec.ChangedPaths = paths
+	return nil
+}
+
+// Returns prevValue, or when it is nil because this is the first watch result of the resource in its partition, the
+// previous stored version from earlier partitions.  Nil when there is none
+func getPreviousStoredWatchResult(tables typed.Tables, txn badgerwrap.Txn, prevValue *typed.KubeWatchResult, watchRec *typed.KubeWatchResult, metadata *kubeextractor.KubeMetadata) (*typed.KubeWatchResult, error) {
+	if prevValue != nil {
+		return prevValue, nil
+	}
+	timestamp, err := ptypes.Timestamp(watchRec.Timestamp)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Could not convert timestamp %v", watchRec.Timestamp.String())
+	}
+	keyComparator := typed.NewWatchTableKeyComparator(watchRec.Kind, metadata.Namespace, metadata.Name, time.Time{})
+	prevKey, err := tables.WatchTable().GetPreviousKey(txn, typed.NewWatchTableKey(untyped.GetPartitionId(timestamp), watchRec.Kind, metadata.Namespace, metadata.Name, timestamp), keyComparator)
+	if err != nil {
+		return nil, nil
+	}
+	prevValue, err = tables.WatchTable().Get(txn, prevKey.String())
+	if err != nil && err != badger.ErrKeyNotFound {
+		return nil, err
+	}
+	return prevValue, nil
+}
+
+/*
+Classifies the watch result against the previous stored version of the resource.  A payload that is the same, or has
+the same resource version, is what a relist or resync sends for a resource that did not change.  Deletes are always a
+change
+*/
+func setVersionType(prevValue *typed.KubeWatchResult, watchRec *typed.KubeWatchResult, metadata *kubeextractor.KubeMetadata) {
+	if prevValue == nil {
+		watchRec.VersionType = typed.VersionTypeFirst
+		return
+	}
+	prevMetadata, err := kubeextractor.ExtractMetadata(prevValue.Payload)
+	switch {
+	case err != nil || prevMetadata.Uid != metadata.Uid:
+		watchRec.VersionType = typed.VersionTypeFirst
+	case watchRec.WatchType != typed.KubeWatchResult_DELETE && (prevValue.Payload == watchRec.Payload || (metadata.ResourceVersion != "" && prevMetadata.ResourceVersion == metadata.ResourceVersion)):
+		watchRec.VersionType = typed.VersionTypeResync
+	default:
+		watchRec.VersionType = typed.VersionTypeChanged
+	}
+}
+
 func toWatchTableKey(ts *timestamp.Timestamp, kind string, namespace string, name string) (*typed.WatchTableKey, error) {
 	timestamp, err := ptypes.Timestamp(ts)
 	if err != nil {
@@ -128,9 +232,14 @@ func toWatchTableKeyPrefix(ts *timestamp.Timestamp, kind string, namespace strin
 	return typed.NewWatchTableKey(untyped.GetPartitionId(timestamp), kind, namespace, name, time.Time{}), nil
 }
 
+// Returns the uid of the last watch result of the resource at or before timestamp, or the newest one for a zero timestamp
 func GetUidForWatchEntry(tables typed.Tables, txn badgerwrap.Txn, kind string, namespace string, name string, timestamp time.Time) (string, error) {
-	watchKeyComparator := typed.NewWatchTableKeyComparator(kind, namespace, name, timestamp)
-	seekKey := queries.GetSeekKey(watchKeyComparator, time.Now())
+	watchKeyComparator := typed.NewWatchTableKeyComparator(kind, namespace, name, time.Time{})
+	seekTime := timestamp
+	if seekTime.IsZero() {
+		seekTime = common.Now()
+	}
+	seekKey := queries.GetSeekKey(watchKeyComparator, seekTime)
 	previousKey, getPreviousErr := tables.WatchTable().GetPreviousKey(txn, seekKey, watchKeyComparator)
 	if getPreviousErr == nil {
 		var getErr error
@@ -101,7 +101,7 @@ func helper_runWatchTableProcessingOnInputs(t *testing.T, inRecs []*typed.KubeWa
 			kubeMetadata, err := kubeextractor.ExtractMetadata(watchRec.Payload)
 			assert.Nil(t, err)
 
-			return updateKubeWatchTable(tables, txn, watchRec, &kubeMetadata, keepMinorNodeUpdates)
+			return updateKubeWatchTable(tables, txn, watchRec, &kubeMetadata, keepMinorNodeUpdates, SamplingPolicy{})
 		})
 		assert.Nil(t, err)
 	}
@@ -203,6 +203,79 @@ func Test_WatchTable_KeepMinorNodeUpdates_AddNodeTwiceOnlyDiffIsTS_TwoOutputRow(
 	assert.Equal(t, 2, len(results))
 }
 
+func Test_WatchTable_StoresChangedPathsFromPreviousPayload(t *testing.T) {
+	ts1, err := ptypes.TimestampProto(someWatchTime)
+	assert.Nil(t, err)
+	ts2, err := ptypes.TimestampProto(someWatchTime.Add(time.Second))
+	assert.Nil(t, err)
+	changedPayload := `{"metadata":{"name":"someName","namespace":"someNamespace","uid":"6c2a9795-a282-11e9-ba2f-14187761de09","creationTimestamp":"2019-07-09T19:47:45Z"},"spec":{"nodeName":"somehostname"}}`
+	watchRec1 := &typed.KubeWatchResult{Kind: someKind, WatchType: typed.KubeWatchResult_ADD, Timestamp: ts1, Payload: somePodPayload}
+	watchRec2 := &typed.KubeWatchResult{Kind: someKind, WatchType: typed.KubeWatchResult_UPDATE, Timestamp: ts2, Payload: changedPayload}
+
+	results := helper_runWatchTableProcessingOnInputs(t, []*typed.KubeWatchResult{watchRec1, watchRec2}, false)
+
+	assert.Equal(t, 2, len(results))
+	assert.Nil(t, results[0].Value.ChangedPaths)
+	assert.Equal(t, []string{"spec"}, results[1].Value.ChangedPaths)
+	assert.Equal(t, int32(1), results[1].Value.ChangedPathCount)
+}
+
+func Test_WatchTable_StoresVersionTypes(t *testing.T) {
+	changedPayload := `{"metadata":{"name":"someName","namespace":"someNamespace","uid":"6c2a9795-a282-11e9-ba2f-14187761de09","creationTimestamp":"2019-07-09T19:47:45Z"},"spec":{"nodeName":"somehostname"}}`
+	recreatedPayload := `{"metadata":{"name":"someName","namespace":"someNamespace","uid":"7d3b0806-a282-11e9-ba2f-14187761de09","creationTimestamp":"2019-07-09T20:47:45Z"}}`
+	var inRecs []*typed.KubeWatchResult
+	for idx, payload := range []string{somePodPayload, somePodPayload, changedPayload, recreatedPayload} {
+		// The last one is in the next partition, so its previous version is found in the one before
+		ts, err := ptypes.TimestampProto(someWatchTime.Add(time.Duration(idx) * 20 * time.Minute))
+		assert.Nil(t, err)
+		inRecs = append(inRecs, &typed.KubeWatchResult{Kind: someKind, WatchType: typed.KubeWatchResult_UPDATE, Timestamp: ts, Payload: payload})
+	}
+	ts, err := ptypes.TimestampProto(someWatchTime.Add(90 * time.Minute))
+	assert.Nil(t, err)
+	inRecs = append(inRecs, &typed.KubeWatchResult{Kind: someKind, WatchType: typed.KubeWatchResult_DELETE, Timestamp: ts, Payload: recreatedPayload})
+
+	results := helper_runWatchTableProcessingOnInputs(t, inRecs, false)
+
+	versionTypes := []string{}
+	for _, result := range results {
+		versionTypes = append(versionTypes, result.Value.VersionType)
+	}
+	assert.Equal(t, []string{typed.VersionTypeFirst, typed.VersionTypeResync, typed.VersionTypeChanged, typed.VersionTypeFirst, typed.VersionTypeChanged}, versionTypes)
+}
+
+func Test_WatchTable_KeepsOldPayloadsOnlyForVersionsNotStored(t *testing.T) {
+	missedPayload := `{"metadata":{"name":"someName","namespace":"someNamespace","uid":"6c2a9795-a282-11e9-ba2f-14187761de09","resourceVersion":"2"},"spec":{"nodeName":"missed"}}`
+	newPayload := `{"metadata":{"name":"someName","namespace":"someNamespace","uid":"6c2a9795-a282-11e9-ba2f-14187761de09","resourceVersion":"3"},"spec":{"nodeName":"new"}}`
+	storedPayload := `{"metadata":{"name":"someName","namespace":"someNamespace","uid":"6c2a9795-a282-11e9-ba2f-14187761de09","resourceVersion":"1"}}`
+	var inRecs []*typed.KubeWatchResult
+	for idx, rec := range []typed.KubeWatchResult{
+		{WatchType: typed.KubeWatchResult_ADD, Payload: storedPayload},
+		// The update to version 2 was never seen
+		{WatchType: typed.KubeWatchResult_UPDATE, Payload: newPayload, OldPayload: missedPayload},
+		{WatchType: typed.KubeWatchResult_DELETE, Payload: newPayload, OldPayload: missedPayload, FinalState: typed.FinalStateCache},
+	} {
+		ts, err := ptypes.TimestampProto(someWatchTime.Add(time.Duration(idx) * time.Minute))
+		assert.Nil(t, err)
+		rec := rec
+		rec.Kind = someKind
+		rec.Timestamp = ts
+		inRecs = append(inRecs, &rec)
+	}
+	results := helper_runWatchTableProcessingOnInputs(t, inRecs, false)
+	assert.Equal(t, 3, len(results))
+	assert.Equal(t, missedPayload, results[1].Value.OldPayload)
+	assert.Equal(t, "", results[2].Value.OldPayload)
+	assert.Equal(t, typed.FinalStateCache, results[2].Value.FinalState)
+
+	// The old object is the stored version, or a sampled kind left the gap on purpose
+	stored := &typed.KubeWatchResult{Kind: someKind, WatchType: typed.KubeWatchResult_UPDATE, Payload: newPayload, OldPayload: storedPayload}
+	setOldPayload(&typed.KubeWatchResult{Payload: storedPayload}, stored, &kubeextractor.KubeMetadata{Uid: "6c2a9795-a282-11e9-ba2f-14187761de09", ResourceVersion: "3"}, true)
+	assert.Equal(t, "", stored.OldPayload)
+	sampled := &typed.KubeWatchResult{Kind: someKind, WatchType: typed.KubeWatchResult_UPDATE, Payload: newPayload, OldPayload: missedPayload}
+	setOldPayload(&typed.KubeWatchResult{Payload: storedPayload}, sampled, &kubeextractor.KubeMetadata{Uid: "6c2a9795-a282-11e9-ba2f-14187761de09", ResourceVersion: "3"}, false)
+	assert.Equal(t, "", sampled.OldPayload)
+}
+
 func Test_getLastKubeWatchResult(t *testing.T) {
 	untyped.TestHookSetPartitionDuration(time.Hour)
 	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
@@ -215,7 +288,7 @@ func Test_getLastKubeWatchResult(t *testing.T) {
 	watchRec := typed.KubeWatchResult{Kind: kubeextractor.NodeKind, WatchType: typed.KubeWatchResult_UPDATE, Timestamp: ts, Payload: somePodPayload}
 	metadata := &kubeextractor.KubeMetadata{Name: "someName", Namespace: "someNamespace"}
 	err = tables.Db().Update(func(txn badgerwrap.Txn) error {
-		return updateKubeWatchTable(tables, txn, &watchRec, metadata, true)
+		return updateKubeWatchTable(tables, txn, &watchRec, metadata, true, SamplingPolicy{})
 	})
 	assert.Nil(t, err)
 
@@ -233,6 +306,35 @@ func Test_getLastKubeWatchResult(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func Test_getLastKubeWatchResult_IgnoresNewerResults(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+
+	metadata := &kubeextractor.KubeMetadata{Name: "someName", Namespace: "someNamespace"}
+	for _, offset := range []time.Duration{0, 2 * time.Minute} {
+		ts, err := ptypes.TimestampProto(someWatchTime.Add(offset))
+		assert.Nil(t, err)
+		watchRec := typed.KubeWatchResult{Kind: kubeextractor.PodKind, WatchType: typed.KubeWatchResult_UPDATE, Timestamp: ts, Payload: somePodPayload}
+		err = tables.Db().Update(func(txn badgerwrap.Txn) error {
+			return updateKubeWatchTable(tables, txn, &watchRec, metadata, true, SamplingPolicy{})
+		})
+		assert.Nil(t, err)
+	}
+
+	// A result merged in between the two only sees the older one
+	ts, err := ptypes.TimestampProto(someWatchTime.Add(time.Minute))
+	assert.Nil(t, err)
+	err = tables.Db().View(func(txn badgerwrap.Txn) error {
+		prevWatch, err := getLastKubeWatchResult(tables, txn, ts, kubeextractor.PodKind, metadata.Namespace, metadata.Name)
+		assert.Nil(t, err)
+		assert.Equal(t, someWatchTime.Unix(), prevWatch.Timestamp.Seconds)
+		return nil
+	})
+	assert.Nil(t, err)
+}
+
 func Test_GetUidForWatchEntry(t *testing.T) {
 	untyped.TestHookSetPartitionDuration(time.Hour)
 	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
@@ -245,7 +347,7 @@ func Test_GetUidForWatchEntry(t *testing.T) {
 	watchRec := typed.KubeWatchResult{Kind: kubeextractor.PodKind, WatchType: typed.KubeWatchResult_UPDATE, Timestamp: ts, Payload: somePodPayload}
 	metadata := &kubeextractor.KubeMetadata{Name: "someName", Namespace: "someNamespace"}
 	err = tables.Db().Update(func(txn badgerwrap.Txn) error {
-		return updateKubeWatchTable(tables, txn, &watchRec, metadata, true)
+		return updateKubeWatchTable(tables, txn, &watchRec, metadata, true, SamplingPolicy{})
 	})
 	assert.Nil(t, err)
 
@@ -17,13 +17,18 @@ import (
 	"time"
 )
 
-func updateWatchActivityTable(tables typed.Tables, txn badgerwrap.Txn, watchRec *typed.KubeWatchResult, metadata *kubeextractor.KubeMetadata) error {
+// keepGaps is false when gaps between stored versions are on purpose, see setOldPayload
+func updateWatchActivityTable(tables typed.Tables, txn badgerwrap.Txn, watchRec *typed.KubeWatchResult, metadata *kubeextractor.KubeMetadata, keepGaps bool) error {
 
 	if watchRec.Kind == kubeextractor.EventKind {
 		return nil
 	}
 
-	resourceChanged, err := didKubeWatchResultChange(tables, txn, watchRec, metadata)
+	prevWatch, err := getLastKubeWatchResult(tables, txn, watchRec.Timestamp, watchRec.Kind, metadata.Namespace, metadata.Name)
+	if err != nil {
+		return errors.Wrap(err, "Could not get event info for previous event instance")
+	}
+	resourceChanged, err := didKubeWatchResultChange(prevWatch, metadata)
 	if err != nil {
 		return err
 	}
@@ -43,18 +48,16 @@ func updateWatchActivityTable(tables typed.Tables, txn badgerwrap.Txn, watchRec
 	} else {
 		activityRecord.NoChangeAt = append(activityRecord.NoChangeAt, timestamp.Unix())
 	}
+	if keepGaps && missedOldObject(prevWatch, watchRec, metadata) {
+		activityRecord.MissedAt = append(activityRecord.MissedAt, timestamp.Unix())
+	}
 
 	metricIngestionSuccessCount.Inc()
 	return putWatchActivity(tables, txn, activityRecord, key)
 }
 
-func didKubeWatchResultChange(tables typed.Tables, txn badgerwrap.Txn, watchRec *typed.KubeWatchResult, metadata *kubeextractor.KubeMetadata) (bool, error) {
+func didKubeWatchResultChange(prevWatch *typed.KubeWatchResult, metadata *kubeextractor.KubeMetadata) (bool, error) {
 	resourceChanged := false
-	prevWatch, err := getLastKubeWatchResult(tables, txn, watchRec.Timestamp, watchRec.Kind, metadata.Namespace, metadata.Name)
-	if err != nil {
-		return false, errors.Wrap(err, "Could not get event info for previous event instance")
-	}
-
 	if prevWatch != nil {
 		prevMetadata, err := kubeextractor.ExtractMetadata(prevWatch.Payload)
 		if err != nil {
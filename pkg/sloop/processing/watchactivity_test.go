@@ -72,7 +72,7 @@ func Test_updateWatchActivityTable(t *testing.T) {
 
 	// add a WatchActivity (no matching KubeWatchResult) => no change
 	err = tables.Db().Update(func(txn badgerwrap.Txn) error {
-		err = updateWatchActivityTable(tables, txn, watchRec, &metadata)
+		err = updateWatchActivityTable(tables, txn, watchRec, &metadata, true)
 		assert.Nil(t, err)
 
 		activityRecord, _, err := getWatchActivity(tables, txn, someWatchTime, watchRec, &metadata)
@@ -88,7 +88,7 @@ func Test_updateWatchActivityTable(t *testing.T) {
 
 	// add a KubeWatchResult
 	err = tables.Db().Update(func(txn badgerwrap.Txn) error {
-		return updateKubeWatchTable(tables, txn, watchRec, &metadata, true)
+		return updateKubeWatchTable(tables, txn, watchRec, &metadata, true, SamplingPolicy{})
 	})
 	assert.Nil(t, err)
 
@@ -98,7 +98,7 @@ func Test_updateWatchActivityTable(t *testing.T) {
 	assert.Nil(t, err)
 	watchRec.Timestamp = ts2
 	err = tables.Db().Update(func(txn badgerwrap.Txn) error {
-		err = updateWatchActivityTable(tables, txn, watchRec, &metadata)
+		err = updateWatchActivityTable(tables, txn, watchRec, &metadata, true)
 		assert.Nil(t, err)
 
 		activityRecord, _, err := getWatchActivity(tables, txn, timestamp2, watchRec, &metadata)
@@ -117,7 +117,7 @@ func Test_updateWatchActivityTable(t *testing.T) {
 	metadata, err = kubeextractor.ExtractMetadata(watchRec.Payload)
 	assert.Nil(t, err)
 	err = tables.Db().Update(func(txn badgerwrap.Txn) error {
-		err = updateWatchActivityTable(tables, txn, watchRec, &metadata)
+		err = updateWatchActivityTable(tables, txn, watchRec, &metadata, true)
 		assert.Nil(t, err)
 
 		activityRecord, _, err := getWatchActivity(tables, txn, timestamp2, watchRec, &metadata)
@@ -131,3 +131,44 @@ func Test_updateWatchActivityTable(t *testing.T) {
 	})
 	assert.Nil(t, err)
 }
+
+func Test_updateWatchActivityTable_MissedVersions(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	podPayload := func(resourceVersion string) string {
+		return `{"metadata":{"name":"p","namespace":"ns","uid":"uid1","resourceVersion":"` + resourceVersion + `"}}`
+	}
+	store := func(offset time.Duration, payload string, oldPayload string, keepGaps bool) *typed.WatchActivity {
+		ts, err := ptypes.TimestampProto(someWatchTime.Add(offset))
+		assert.Nil(t, err)
+		watchRec := &typed.KubeWatchResult{Kind: kubeextractor.PodKind, WatchType: typed.KubeWatchResult_UPDATE, Timestamp: ts, Payload: payload, OldPayload: oldPayload}
+		metadata, err := kubeextractor.ExtractMetadata(payload)
+		assert.Nil(t, err)
+		var activity *typed.WatchActivity
+		err = tables.Db().Update(func(txn badgerwrap.Txn) error {
+			err := updateWatchActivityTable(tables, txn, watchRec, &metadata, keepGaps)
+			if err != nil {
+				return err
+			}
+			err = updateKubeWatchTable(tables, txn, watchRec, &metadata, true, SamplingPolicy{})
+			if err != nil {
+				return err
+			}
+			activity, _, err = getWatchActivity(tables, txn, someWatchTime, watchRec, &metadata)
+			return err
+		})
+		assert.Nil(t, err)
+		return activity
+	}
+
+	// Nothing is missed before the first stored version
+	assert.Len(t, store(0, podPayload("1"), podPayload("0"), true).MissedAt, 0)
+	assert.Len(t, store(time.Second, podPayload("2"), podPayload("1"), true).MissedAt, 0)
+	// Version 3 was never stored
+	activity := store(2*time.Second, podPayload("4"), podPayload("3"), true)
+	assert.Equal(t, []int64{someWatchTime.Add(2 * time.Second).Unix()}, activity.MissedAt)
+	// Gaps on purpose are not missed versions
+	assert.Len(t, store(3*time.Second, podPayload("6"), podPayload("5"), false).MissedAt, 1)
+}
@@ -0,0 +1,98 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	badger "github.com/dgraph-io/badger/v2"
+)
+
+// Machine readable reason of a failed request, in the code of the ErrorEnvelope and in ErrorCodeHeader
+type ErrorCode string
+
+const (
+	ErrorCodeBadParams        ErrorCode = "bad_params"
+	ErrorCodeRangeTooLarge    ErrorCode = "range_too_large"
+	ErrorCodeStoreUnavailable ErrorCode = "store_unavailable"
+	ErrorCodeUnauthorized     ErrorCode = "unauthorized"
+	ErrorCodeForbidden        ErrorCode = "forbidden"
+	ErrorCodeNotFound         ErrorCode = "not_found"
+	ErrorCodeMethodNotAllowed ErrorCode = "method_not_allowed"
+	// Every query slot stayed busy for too long, worth another try later
+	ErrorCodeOverloaded ErrorCode = "overloaded"
+	// Only in ErrorCodeHeader, a truncated response still has the first max bytes of its body
+	ErrorCodeTruncated ErrorCode = "truncated"
+	// Only in ErrorCodeHeader, some shards failed and the response has the results of the others
+	ErrorCodePartial  ErrorCode = "partial"
+	ErrorCodeInternal ErrorCode = "internal"
+)
+
+const ErrorCodeHeader = "X-Sloop-Error-Code"
+
+var errorCodeStatuses = map[ErrorCode]int{
+	ErrorCodeBadParams:        http.StatusBadRequest,
+	ErrorCodeRangeTooLarge:    http.StatusBadRequest,
+	ErrorCodeStoreUnavailable: http.StatusServiceUnavailable,
+	ErrorCodeUnauthorized:     http.StatusUnauthorized,
+	ErrorCodeForbidden:        http.StatusForbidden,
+	ErrorCodeNotFound:         http.StatusNotFound,
+	ErrorCodeMethodNotAllowed: http.StatusMethodNotAllowed,
+	ErrorCodeOverloaded:       http.StatusServiceUnavailable,
+	ErrorCodeTruncated:        http.StatusOK,
+	ErrorCodePartial:          http.StatusOK,
+	ErrorCodeInternal:         http.StatusInternalServerError,
+}
+
+// The HTTP status an error with the code is returned with
+func (c ErrorCode) Status() int {
+	if status, ok := errorCodeStatuses[c]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// The body of every failed query endpoint
+type ErrorEnvelope struct {
+	Error ErrorBody `json:"error"`
+}
+
+type ErrorBody struct {
+	Code      ErrorCode `json:"code"`
+	Message   string    `json:"message"`
+	RequestId string    `json:"requestId,omitempty"`
+}
+
+// An error with the code it is returned to callers with.  Errors without one are internal
+type ApiError struct {
+	Code    ErrorCode
+	Message string
+}
+
+func (e *ApiError) Error() string {
+	return e.Message
+}
+
+func NewApiError(code ErrorCode, format string, args ...interface{}) error {
+	return &ApiError{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// Finds the code of an ApiError wrapped anywhere in err.  Writes blocked by a closing store are
+// ErrorCodeStoreUnavailable, other errors ErrorCodeInternal
+func ErrorCodeOf(err error) ErrorCode {
+	var apiErr *ApiError
+	if errors.As(err, &apiErr) {
+		return apiErr.Code
+	}
+	if errors.Is(err, badger.ErrBlockedWrites) {
+		return ErrorCodeStoreUnavailable
+	}
+	return ErrorCodeInternal
+}
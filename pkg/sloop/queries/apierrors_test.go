@@ -0,0 +1,50 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+func Test_ErrorCodeOf(t *testing.T) {
+	assert.Equal(t, ErrorCodeBadParams, ErrorCodeOf(NewApiError(ErrorCodeBadParams, "bad")))
+	assert.Equal(t, ErrorCodeRangeTooLarge, ErrorCodeOf(errors.Wrap(NewApiError(ErrorCodeRangeTooLarge, "long"), "context")))
+	assert.Equal(t, ErrorCodeStoreUnavailable, ErrorCodeOf(errors.Wrap(badger.ErrBlockedWrites, "context")))
+	assert.Equal(t, ErrorCodeInternal, ErrorCodeOf(fmt.Errorf("boom")))
+}
+
+func Test_ErrorCode_Status(t *testing.T) {
+	assert.Equal(t, http.StatusBadRequest, ErrorCodeRangeTooLarge.Status())
+	assert.Equal(t, http.StatusServiceUnavailable, ErrorCodeStoreUnavailable.Status())
+	assert.Equal(t, http.StatusForbidden, ErrorCodeForbidden.Status())
+	assert.Equal(t, http.StatusInternalServerError, ErrorCode("unknown").Status())
+}
+
+func Test_RunQuery_ErrorCodes(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+
+	_, err = RunQuery("NoSuchQuery", url.Values{}, tables, time.Hour, "")
+	assert.Equal(t, ErrorCodeBadParams, ErrorCodeOf(err))
+	_, err = RunQuery("EventHeatMap", url.Values{LookbackParam: []string{"abc"}}, tables, time.Hour, "")
+	assert.Equal(t, ErrorCodeBadParams, ErrorCodeOf(err))
+}
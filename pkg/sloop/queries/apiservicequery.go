@@ -0,0 +1,269 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+// Warning events kept for each outage, the rest are only counted
+const maxApiServiceOutageFailures = 50
+
+type ApiServiceAvailabilityOutput struct {
+	Name    string `json:"name"`
+	Uid     string `json:"uid"`
+	Group   string `json:"group"`
+	Version string `json:"version"`
+	// Namespace/name of the service behind the aggregated API, empty for APIs the api server serves itself
+	Service     string                       `json:"service,omitempty"`
+	Transitions []ApiServiceTransitionOutput `json:"transitions"`
+	Outages     []ApiServiceOutage           `json:"outages"`
+}
+
+type ApiServiceTransitionOutput struct {
+	Timestamp int64  `json:"timestamp"`
+	Status    string `json:"status"`
+	Reason    string `json:"reason,omitempty"`
+	Message   string `json:"message,omitempty"`
+}
+
+// A time range the APIService was not Available.  End is 0 when it was still unavailable at the end of the time range
+type ApiServiceOutage struct {
+	Start   int64  `json:"start"`
+	End     int64  `json:"end"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+	// Warning events seen during the outage about resources of the group version, or naming the group
+	Failures     []ApiServiceFailure `json:"failures"`
+	FailureCount int                 `json:"failureCount"`
+}
+
+type ApiServiceFailure struct {
+	Timestamp int64  `json:"timestamp"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	Reason    string `json:"reason"`
+	Message   string `json:"message"`
+}
+
+type apiServiceId struct {
+	name string
+	uid  string
+}
+
+/*
+Returns the availability history of the APIServices matching name, with the time ranges each was not Available.
+When an aggregated API is down, clients of its group fail, like HPAs reading metrics.k8s.io or namespaces that
+can not be deleted.  Warning events seen during each outage, about resources of the served group version or with a
+message naming the group, are listed with it
+*/
+func GetApiServiceAvailability(params url.Values, t typed.Tables, startTime time.Time, endTime time.Time, requestId string) ([]byte, error) {
+	selectedName := params.Get(NameParam)
+	table := typed.OpenApiServiceAvailabilityTable()
+
+	output := []ApiServiceAvailabilityOutput{}
+	err := t.Db().View(func(txn badgerwrap.Txn) error {
+		records, err := table.RangeRead(txn, startTime, endTime)
+		if err != nil {
+			return err
+		}
+
+		byId := map[apiServiceId]*ApiServiceAvailabilityOutput{}
+		transitionsById := map[apiServiceId][]*typed.ApiServiceTransition{}
+		for key, record := range records {
+			_, name, uid, err := table.ParseKey(key)
+			if err != nil {
+				return err
+			}
+			if selectedName != "" && name != selectedName {
+				continue
+			}
+			id := apiServiceId{name: name, uid: uid}
+			if _, ok := byId[id]; !ok {
+				byId[id] = &ApiServiceAvailabilityOutput{Name: name, Uid: uid, Group: record.Group, Version: record.Version, Service: record.Service}
+			}
+			transitionsById[id] = append(transitionsById[id], record.Transitions...)
+		}
+
+		hasOutages := false
+		for id, apiService := range byId {
+			apiService.Transitions, apiService.Outages = apiServiceAvailabilityInTimeRange(transitionsById[id], startTime, endTime)
+			if len(apiService.Transitions) == 0 {
+				continue
+			}
+			hasOutages = hasOutages || len(apiService.Outages) > 0
+			output = append(output, *apiService)
+		}
+		if !hasOutages {
+			return nil
+		}
+
+		events, err := readApiServiceWarningEvents(txn, t, startTime, endTime, requestId)
+		if err != nil {
+			return err
+		}
+		for idx := range output {
+			for outageIdx := range output[idx].Outages {
+				addApiServiceOutageFailures(&output[idx].Outages[outageIdx], output[idx].Group, output[idx].Version, events, endTime)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return []byte{}, err
+	}
+	sort.Slice(output, func(i, j int) bool {
+		if output[i].Name != output[j].Name {
+			return output[i].Name < output[j].Name
+		}
+		return output[i].Uid < output[j].Uid
+	})
+
+	bytes, err := json.MarshalIndent(output, "", " ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal json %v", err)
+	}
+	return bytes, nil
+}
+
+// Keeps the changes in the time range, starting with the status at the start time, and the outages between them
+func apiServiceAvailabilityInTimeRange(transitions []*typed.ApiServiceTransition, startTime time.Time, endTime time.Time) ([]ApiServiceTransitionOutput, []ApiServiceOutage) {
+	sort.SliceStable(transitions, func(i, j int) bool { return transitions[i].Timestamp < transitions[j].Timestamp })
+
+	outputs := []ApiServiceTransitionOutput{}
+	outages := []ApiServiceOutage{}
+	var last *typed.ApiServiceTransition
+	add := func(transition *typed.ApiServiceTransition) {
+		// Partitions repeat the status they start with, which is not a transition
+		if last != nil && last.Status == transition.Status && last.Reason == transition.Reason {
+			return
+		}
+		wasAvailable := last == nil || last.Status == "True"
+		last = transition
+		outputs = append(outputs, ApiServiceTransitionOutput{Timestamp: transition.Timestamp, Status: transition.Status, Reason: transition.Reason, Message: transition.Message})
+		if transition.Status == "True" {
+			if !wasAvailable {
+				outages[len(outages)-1].End = transition.Timestamp
+			}
+		} else if wasAvailable {
+			outages = append(outages, ApiServiceOutage{Start: transition.Timestamp, Reason: transition.Reason, Message: transition.Message, Failures: []ApiServiceFailure{}})
+		}
+	}
+
+	var before *typed.ApiServiceTransition
+	for _, transition := range transitions {
+		if transition.Timestamp < startTime.Unix() {
+			before = transition
+			continue
+		}
+		if transition.Timestamp > endTime.Unix() {
+			break
+		}
+		if before != nil {
+			add(before)
+			before = nil
+		}
+		add(transition)
+	}
+	if before != nil {
+		add(before)
+	}
+	return outputs, outages
+}
+
+type apiServiceWarningEvent struct {
+	timestamp      int64
+	involvedObject kubeextractor.KubeInvolvedObject
+	apiVersion     string
+	reason         string
+	message        string
+}
+
+// The last update in the time range of each Warning event
+func readApiServiceWarningEvents(txn badgerwrap.Txn, t typed.Tables, startTime time.Time, endTime time.Time, requestId string) ([]apiServiceWarningEvent, error) {
+	keyPredicate := func(key string) bool {
+		k := &typed.WatchTableKey{}
+		err := k.Parse(key)
+		return err == nil && k.Kind == kubeextractor.EventKind
+	}
+	records, stats, err := t.WatchTable().RangeRead(txn, nil, keyPredicate, nil, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+	stats.Log(requestId)
+
+	events := []apiServiceWarningEvent{}
+	for _, eventRecords := range groupWatchRecords(records) {
+		var last *watchRecord
+		for idx, record := range eventRecords {
+			if record.timestamp < startTime.Unix() || record.timestamp > endTime.Unix() || record.result.WatchType == typed.KubeWatchResult_DELETE {
+				continue
+			}
+			if last == nil || record.timestamp > last.timestamp {
+				last = &eventRecords[idx]
+			}
+		}
+		if last == nil {
+			continue
+		}
+		eventInfo, err := kubeextractor.ExtractEventInfo(last.result.Payload)
+		if err != nil || eventInfo.Type != "Warning" {
+			continue
+		}
+		involvedObject, err := kubeextractor.ExtractInvolvedObject(last.result.Payload)
+		if err != nil {
+			glog.Errorf("Failed to extract involved object: %v", err)
+			continue
+		}
+		apiVersion, _ := kubeextractor.ExtractEventObjectApiVersion(last.result.Payload)
+		message, _ := kubeextractor.ExtractEventMessage(last.result.Payload)
+		events = append(events, apiServiceWarningEvent{timestamp: last.timestamp, involvedObject: involvedObject, apiVersion: apiVersion, reason: eventInfo.Reason, message: message})
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].timestamp < events[j].timestamp })
+	return events, nil
+}
+
+func addApiServiceOutageFailures(outage *ApiServiceOutage, group string, version string, events []apiServiceWarningEvent, endTime time.Time) {
+	outageEnd := endTime.Unix()
+	if outage.End != 0 {
+		outageEnd = outage.End
+	}
+	groupVersion := kubeextractor.ApiService{Group: group, Version: version}.GroupVersion()
+	for _, event := range events {
+		if event.timestamp < outage.Start || event.timestamp > outageEnd {
+			continue
+		}
+		// The core group has no name to look for in messages
+		if event.apiVersion != groupVersion && (group == "" || !strings.Contains(event.message, group)) {
+			continue
+		}
+		outage.FailureCount++
+		if len(outage.Failures) < maxApiServiceOutageFailures {
+			outage.Failures = append(outage.Failures, ApiServiceFailure{
+				Timestamp: event.timestamp,
+				Kind:      event.involvedObject.Kind,
+				Namespace: event.involvedObject.Namespace,
+				Name:      event.involvedObject.Name,
+				Reason:    event.reason,
+				Message:   event.message,
+			})
+		}
+	}
+}
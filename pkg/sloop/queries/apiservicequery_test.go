@@ -0,0 +1,101 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+func helper_apiServiceEvent(objectKind string, apiVersion string, eventType string, message string) string {
+	return fmt.Sprintf(`{"metadata":{"name":"web.1","namespace":"ns"},"involvedObject":{"kind":"%v","namespace":"ns","name":"web","apiVersion":"%v"},
+"reason":"FailedGetResourceMetric","message":"%v","type":"%v","count":1}`, objectKind, apiVersion, message, eventType)
+}
+
+func Test_GetApiServiceAvailability(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	table := typed.OpenApiServiceAvailabilityTable()
+	ts := time.Date(2019, 3, 4, 3, 10, 0, 0, time.UTC)
+	next := ts.Add(time.Hour)
+
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		metrics := &typed.ApiServiceAvailability{Group: "metrics.k8s.io", Version: "v1beta1", Service: "kube-system/metrics-server", Transitions: []*typed.ApiServiceTransition{
+			{Timestamp: ts.Unix(), Status: "True", Reason: "Passed"},
+			{Timestamp: ts.Add(10 * time.Minute).Unix(), Status: "False", Reason: "FailedDiscoveryCheck", Message: "no response"},
+		}}
+		assert.Nil(t, table.Set(txn, table.Key(untyped.GetPartitionId(ts), "v1beta1.metrics.k8s.io", "uid1"), metrics))
+		// The next partition starts with the status it had, then recovers
+		metricsNext := &typed.ApiServiceAvailability{Group: "metrics.k8s.io", Version: "v1beta1", Service: "kube-system/metrics-server", Transitions: []*typed.ApiServiceTransition{
+			{Timestamp: next.Unix(), Status: "False", Reason: "FailedDiscoveryCheck"},
+			{Timestamp: next.Add(5 * time.Minute).Unix(), Status: "True", Reason: "Passed"},
+		}}
+		assert.Nil(t, table.Set(txn, table.Key(untyped.GetPartitionId(next), "v1beta1.metrics.k8s.io", "uid1"), metricsNext))
+		apps := &typed.ApiServiceAvailability{Group: "apps", Version: "v1", Transitions: []*typed.ApiServiceTransition{{Timestamp: ts.Unix(), Status: "True", Reason: "Local"}}}
+		assert.Nil(t, table.Set(txn, table.Key(untyped.GetPartitionId(ts), "v1.apps", "uid2"), apps))
+
+		events := []struct {
+			name    string
+			at      time.Time
+			payload string
+		}{
+			{"hpa-during", ts.Add(20 * time.Minute), helper_apiServiceEvent("HorizontalPodAutoscaler", "autoscaling/v2beta2", "Warning", "unable to get metrics for resource cpu (get pods.metrics.k8s.io)")},
+			{"unrelated", ts.Add(20 * time.Minute), helper_apiServiceEvent("Pod", "v1", "Warning", "Back-off restarting failed container")},
+			{"normal", ts.Add(25 * time.Minute), helper_apiServiceEvent("HorizontalPodAutoscaler", "autoscaling/v2beta2", "Normal", "metrics.k8s.io is fine")},
+			{"hpa-after", next.Add(10 * time.Minute), helper_apiServiceEvent("HorizontalPodAutoscaler", "autoscaling/v2beta2", "Warning", "unable to get metrics (get pods.metrics.k8s.io)")},
+		}
+		for _, event := range events {
+			key := typed.NewWatchTableKey(untyped.GetPartitionId(event.at), "Event", "ns", event.name, event.at)
+			assert.Nil(t, tables.WatchTable().Set(txn, key.String(), &typed.KubeWatchResult{Kind: "Event", WatchType: typed.KubeWatchResult_ADD, Payload: event.payload}))
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+
+	data, err := GetApiServiceAvailability(url.Values{}, tables, ts.Add(-time.Minute), next.Add(30*time.Minute), "")
+	assert.Nil(t, err)
+	output := []ApiServiceAvailabilityOutput{}
+	assert.Nil(t, json.Unmarshal(data, &output))
+	assert.Len(t, output, 2)
+	assert.Equal(t, "v1.apps", output[0].Name)
+	assert.Len(t, output[0].Outages, 0)
+
+	metrics := output[1]
+	assert.Equal(t, "v1beta1.metrics.k8s.io", metrics.Name)
+	assert.Equal(t, "kube-system/metrics-server", metrics.Service)
+	assert.Len(t, metrics.Transitions, 3)
+	assert.Len(t, metrics.Outages, 1)
+	outage := metrics.Outages[0]
+	assert.Equal(t, ts.Add(10*time.Minute).Unix(), outage.Start)
+	assert.Equal(t, next.Add(5*time.Minute).Unix(), outage.End)
+	assert.Equal(t, "FailedDiscoveryCheck", outage.Reason)
+	assert.Equal(t, 1, outage.FailureCount)
+	assert.Equal(t, "HorizontalPodAutoscaler", outage.Failures[0].Kind)
+	assert.Equal(t, "web", outage.Failures[0].Name)
+
+	// Still down at the end of the time range, and the status before the start counts
+	data, err = GetApiServiceAvailability(url.Values{NameParam: []string{"v1beta1.metrics.k8s.io"}}, tables, ts.Add(15*time.Minute), ts.Add(30*time.Minute), "")
+	assert.Nil(t, err)
+	output = []ApiServiceAvailabilityOutput{}
+	assert.Nil(t, json.Unmarshal(data, &output))
+	assert.Len(t, output, 1)
+	assert.Equal(t, []ApiServiceOutage{{Start: ts.Add(10 * time.Minute).Unix(), Reason: "FailedDiscoveryCheck", Message: "no response", FailureCount: 1, Failures: output[0].Outages[0].Failures}}, output[0].Outages)
+	assert.Equal(t, int64(0), output[0].Outages[0].End)
+}
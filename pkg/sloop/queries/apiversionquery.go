@@ -0,0 +1,157 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+type ApiVersionsOutput struct {
+	// Sorted by kind, and the api versions of a kind by when they were first seen
+	Kinds []KindApiVersions `json:"kinds"`
+	// Sorted by time
+	Migrations []ApiVersionMigration `json:"migrations"`
+}
+
+type KindApiVersions struct {
+	Kind        string           `json:"kind"`
+	ApiVersions []ApiVersionSpan `json:"apiVersions"`
+}
+
+// Unix seconds of the first and last watch result in the time range that had the api version
+type ApiVersionSpan struct {
+	ApiVersion string `json:"apiVersion"`
+	FirstSeen  int64  `json:"firstSeen"`
+	LastSeen   int64  `json:"lastSeen"`
+	Results    int    `json:"results"`
+}
+
+// A resource that was stored with another api version than before
+type ApiVersionMigration struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	Uid       string `json:"uid,omitempty"`
+	From      string `json:"from"`
+	To        string `json:"to"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+/*
+Lists the api versions each kind was stored with during the time range, and the resources that changed api version,
+to check what an upgrade window migrated.  Takes the usual kind, namespace, namematch and name params.  Migrations are
+flagged at ingest, so results stored before that was added only show up in the api versions, and typed kinds only
+have one from the version the watcher set.  Events are left out unless kind is Event
+*/
+func GetApiVersionMigrations(params url.Values, t typed.Tables, startTime time.Time, endTime time.Time, requestId string) ([]byte, error) {
+	selectedKind := defaultParam(params.Get(KindParam), AllKinds)
+	selectedNamespace := defaultParam(params.Get(NamespaceParam), AllNamespaces)
+	selectedNameMatch := params.Get(NameMatchParam)
+	selectedName := params.Get(NameParam)
+
+	output := ApiVersionsOutput{Kinds: []KindApiVersions{}, Migrations: []ApiVersionMigration{}}
+	spans := map[string]map[string]*ApiVersionSpan{}
+	err := t.Db().View(func(txn badgerwrap.Txn) error {
+		keyPredicate := func(key string) bool {
+			k := &typed.WatchTableKey{}
+			err := k.Parse(key)
+			if err != nil {
+				return false
+			}
+			if selectedKind == AllKinds && k.Kind == kubeextractor.EventKind {
+				return false
+			}
+			return keepRowHelper(k.Name, k.Kind, k.Namespace, selectedKind, selectedNamespace, selectedNameMatch, selectedName, "", "")
+		}
+		inTimeRange := isResPayloadInTimeRange(startTime, endTime)
+		// Spans are counted here so only the migrations are kept in memory
+		valPredicate := func(result *typed.KubeWatchResult) bool {
+			if !inTimeRange(result) {
+				return false
+			}
+			apiVersion := typed.WatchResultApiVersion(result)
+			if apiVersion != "" {
+				ts, _ := ptypes.Timestamp(result.Timestamp)
+				if spans[result.Kind] == nil {
+					spans[result.Kind] = map[string]*ApiVersionSpan{}
+				}
+				span := spans[result.Kind][apiVersion]
+				if span == nil {
+					span = &ApiVersionSpan{ApiVersion: apiVersion, FirstSeen: ts.Unix(), LastSeen: ts.Unix()}
+					spans[result.Kind][apiVersion] = span
+				}
+				if ts.Unix() < span.FirstSeen {
+					span.FirstSeen = ts.Unix()
+				}
+				if ts.Unix() > span.LastSeen {
+					span.LastSeen = ts.Unix()
+				}
+				span.Results++
+			}
+			return result.ApiVersionMigratedFrom != ""
+		}
+		records, stats, err := t.WatchTable().RangeRead(txn, getSnapshotDiffKeyPrefix(selectedKind, selectedNamespace), keyPredicate, valPredicate, startTime, endTime)
+		if err != nil {
+			return err
+		}
+		stats.Log(requestId)
+		for key, result := range records {
+			metadata, _ := kubeextractor.ExtractMetadata(result.Payload)
+			output.Migrations = append(output.Migrations, ApiVersionMigration{
+				Kind:      key.Kind,
+				Namespace: key.Namespace,
+				Name:      key.Name,
+				Uid:       metadata.Uid,
+				From:      result.ApiVersionMigratedFrom,
+				To:        result.ApiVersion,
+				Timestamp: key.Timestamp.Unix(),
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return []byte{}, err
+	}
+
+	for kind, kindSpans := range spans {
+		versions := KindApiVersions{Kind: kind, ApiVersions: []ApiVersionSpan{}}
+		for _, span := range kindSpans {
+			versions.ApiVersions = append(versions.ApiVersions, *span)
+		}
+		sort.Slice(versions.ApiVersions, func(i, j int) bool {
+			a, b := versions.ApiVersions[i], versions.ApiVersions[j]
+			if a.FirstSeen != b.FirstSeen {
+				return a.FirstSeen < b.FirstSeen
+			}
+			return a.ApiVersion < b.ApiVersion
+		})
+		output.Kinds = append(output.Kinds, versions)
+	}
+	sort.Slice(output.Kinds, func(i, j int) bool { return output.Kinds[i].Kind < output.Kinds[j].Kind })
+	sort.Slice(output.Migrations, func(i, j int) bool {
+		a, b := output.Migrations[i], output.Migrations[j]
+		if a.Timestamp != b.Timestamp {
+			return a.Timestamp < b.Timestamp
+		}
+		return a.Kind+"/"+a.Namespace+"/"+a.Name < b.Kind+"/"+b.Namespace+"/"+b.Name
+	})
+	bytes, err := json.MarshalIndent(output, "", " ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal json %v", err)
+	}
+	return bytes, nil
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+func Test_GetApiVersionMigrations(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+
+	results := []struct {
+		kind         string
+		name         string
+		offset       time.Duration
+		apiVersion   string
+		migratedFrom string
+		payload      string
+	}{
+		{"Widget", "a", 10 * time.Minute, "", "", `{"apiVersion":"example.com/v1beta1","metadata":{"name":"a","uid":"u1"}}`},
+		{"Widget", "a", 20 * time.Minute, "example.com/v1", "example.com/v1beta1", `{"apiVersion":"example.com/v1","metadata":{"name":"a","uid":"u1"}}`},
+		{"Widget", "b", 30 * time.Minute, "example.com/v1", "", `{"apiVersion":"example.com/v1","metadata":{"name":"b","uid":"u2"}}`},
+		{"Pod", "p", 15 * time.Minute, "v1", "", `{"metadata":{"name":"p","uid":"u3"}}`},
+		// Before the start
+		{"Widget", "c", -2 * time.Hour, "example.com/v1alpha1", "", `{"metadata":{"name":"c","uid":"u4"}}`},
+	}
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		for _, result := range results {
+			keyTs := someTs.Add(result.offset)
+			ts, _ := ptypes.TimestampProto(keyTs)
+			key := typed.NewWatchTableKey(untyped.GetPartitionId(keyTs), result.kind, "ns", result.name, keyTs)
+			err := tables.WatchTable().Set(txn, key.String(), &typed.KubeWatchResult{Kind: result.kind, Timestamp: ts, Payload: result.payload, ApiVersion: result.apiVersion, ApiVersionMigratedFrom: result.migratedFrom})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+
+	data, err := GetApiVersionMigrations(url.Values{}, tables, someTs, someTs.Add(time.Hour), someRequestId)
+	assert.Nil(t, err)
+	output := ApiVersionsOutput{}
+	assert.Nil(t, json.Unmarshal(data, &output))
+	assert.Equal(t, []KindApiVersions{
+		{Kind: "Pod", ApiVersions: []ApiVersionSpan{{ApiVersion: "v1", FirstSeen: someTs.Add(15 * time.Minute).Unix(), LastSeen: someTs.Add(15 * time.Minute).Unix(), Results: 1}}},
+		{Kind: "Widget", ApiVersions: []ApiVersionSpan{
+			{ApiVersion: "example.com/v1beta1", FirstSeen: someTs.Add(10 * time.Minute).Unix(), LastSeen: someTs.Add(10 * time.Minute).Unix(), Results: 1},
+			{ApiVersion: "example.com/v1", FirstSeen: someTs.Add(20 * time.Minute).Unix(), LastSeen: someTs.Add(30 * time.Minute).Unix(), Results: 2},
+		}},
+	}, output.Kinds)
+	assert.Equal(t, []ApiVersionMigration{{Kind: "Widget", Namespace: "ns", Name: "a", Uid: "u1", From: "example.com/v1beta1", To: "example.com/v1", Timestamp: someTs.Add(20 * time.Minute).Unix()}}, output.Migrations)
+
+	data, err = GetApiVersionMigrations(url.Values{KindParam: []string{"Pod"}}, tables, someTs, someTs.Add(time.Hour), someRequestId)
+	assert.Nil(t, err)
+	assert.Nil(t, json.Unmarshal(data, &output))
+	assert.Len(t, output.Kinds, 1)
+	assert.Empty(t, output.Migrations)
+}
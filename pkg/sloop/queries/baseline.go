@@ -0,0 +1,281 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+var baselineNameRegex = regexp.MustCompile(`^[A-Za-z0-9._-]{1,128}$`)
+
+// Fields that tell the rows of resources apart, in the order they are compared
+var baselineIdentityFields = []string{"kind", "namespace", "name", "uid"}
+
+type BaselineSummary struct {
+	Name string `json:"name"`
+	// Unix seconds
+	Created int64           `json:"created"`
+	Query   StructuredQuery `json:"query"`
+	Source  string          `json:"source"`
+	Total   int64           `json:"total"`
+}
+
+// A row that is in both results with other values
+type BaselineChange struct {
+	// The fields the rows were matched on
+	Key      map[string]interface{} `json:"key"`
+	Fields   []string               `json:"fields"`
+	Baseline map[string]interface{} `json:"baseline"`
+	Current  map[string]interface{} `json:"current"`
+}
+
+type BaselineDiff struct {
+	Name string `json:"name"`
+	// Unix seconds of the baseline and of the result it was compared to
+	Created  int64 `json:"created"`
+	Compared int64 `json:"compared"`
+	// Set when anything was added, removed or changed
+	Drift     bool                     `json:"drift"`
+	Added     []map[string]interface{} `json:"added"`
+	Removed   []map[string]interface{} `json:"removed"`
+	Changed   []BaselineChange         `json:"changed"`
+	Unchanged int                      `json:"unchanged"`
+}
+
+func ValidateBaselineName(name string) error {
+	if !baselineNameRegex.MatchString(name) {
+		return NewApiError(ErrorCodeBadParams, "invalid baseline name %q, use up to 128 letters, digits, '.', '_' and '-'", name)
+	}
+	return nil
+}
+
+// Runs the query and stores its result as the baseline with the name, replacing one that is already there
+func SaveBaseline(tables typed.Tables, name string, query StructuredQuery, maxLookBack time.Duration, now time.Time, requestId string) (*BaselineSummary, error) {
+	err := ValidateBaselineName(name)
+	if err != nil {
+		return nil, err
+	}
+	if query.Limit > 0 {
+		return nil, NewApiError(ErrorCodeBadParams, "baselines can not have a limit, every row is compared")
+	}
+	output, err := runBaselineQuery(query, tables, maxLookBack, requestId)
+	if err != nil {
+		return nil, err
+	}
+	queryBytes, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+	rowBytes, err := json.Marshal(output.Rows)
+	if err != nil {
+		return nil, err
+	}
+	created, _ := ptypes.TimestampProto(now)
+	baseline := &typed.QueryBaseline{Name: name, Created: created, Query: string(queryBytes), Source: output.Source, Rows: string(rowBytes), Total: int64(output.Total)}
+	err = tables.Db().Update(func(txn badgerwrap.Txn) error {
+		return typed.OpenBaselineTable().Set(txn, baseline)
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to store baseline %v", name)
+	}
+	return baselineSummary(baseline)
+}
+
+// Every baseline, ordered by name
+func ListBaselines(tables typed.Tables) ([]BaselineSummary, error) {
+	var baselines []*typed.QueryBaseline
+	err := tables.Db().View(func(txn badgerwrap.Txn) error {
+		var err error
+		baselines, err = typed.OpenBaselineTable().ReadAll(txn)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	summaries := []BaselineSummary{}
+	for _, baseline := range baselines {
+		summary, err := baselineSummary(baseline)
+		if err != nil {
+			return nil, err
+		}
+		summaries = append(summaries, *summary)
+	}
+	return summaries, nil
+}
+
+func DeleteBaseline(tables typed.Tables, name string) error {
+	return tables.Db().Update(func(txn badgerwrap.Txn) error {
+		return typed.OpenBaselineTable().Delete(txn, name)
+	})
+}
+
+/*
+Runs the query of the baseline again and compares the result to it.  Rows of resources are matched on the kind,
+namespace, name and uid fields the query selects, and groups on their group fields, so a matched row with other
+values is a change.  Rows without any of those fields are matched as a whole.  A lookback is relative to now, so a
+baseline of a history query compares the same window back from each run.  Returns nil when there is no baseline
+with the name
+*/
+func DiffBaseline(tables typed.Tables, name string, maxLookBack time.Duration, now time.Time, requestId string) (*BaselineDiff, error) {
+	var baseline *typed.QueryBaseline
+	err := tables.Db().View(func(txn badgerwrap.Txn) error {
+		var err error
+		baseline, err = typed.OpenBaselineTable().Get(txn, name)
+		return err
+	})
+	if err != nil || baseline == nil {
+		return nil, err
+	}
+	summary, err := baselineSummary(baseline)
+	if err != nil {
+		return nil, err
+	}
+	expected := []map[string]interface{}{}
+	err = json.Unmarshal([]byte(baseline.Rows), &expected)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the rows of baseline %v", name)
+	}
+	output, err := runBaselineQuery(summary.Query, tables, maxLookBack, requestId)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := diffBaselineRows(expected, output.Rows, summary.Query.baselineIdentity())
+	diff.Name = name
+	diff.Created = summary.Created
+	diff.Compared = now.Unix()
+	return diff, nil
+}
+
+func diffBaselineRows(expected []map[string]interface{}, actual []map[string]interface{}, identity []string) *BaselineDiff {
+	diff := &BaselineDiff{Added: []map[string]interface{}{}, Removed: []map[string]interface{}{}, Changed: []BaselineChange{}}
+	rowKey := func(row map[string]interface{}) (string, map[string]interface{}) {
+		key := row
+		if len(identity) > 0 {
+			key = map[string]interface{}{}
+			for _, field := range identity {
+				key[field] = row[field]
+			}
+		}
+		bytes, _ := json.Marshal(key)
+		return string(bytes), key
+	}
+	// Several rows can have the same key when rows are matched as a whole, so these are lists
+	expectedByKey := map[string][]map[string]interface{}{}
+	for _, row := range expected {
+		key, _ := rowKey(row)
+		expectedByKey[key] = append(expectedByKey[key], row)
+	}
+	for _, row := range actual {
+		key, keyFields := rowKey(row)
+		candidates := expectedByKey[key]
+		if len(candidates) == 0 {
+			diff.Added = append(diff.Added, row)
+			continue
+		}
+		baselineRow := candidates[0]
+		expectedByKey[key] = candidates[1:]
+		fields := changedBaselineFields(baselineRow, row)
+		if len(fields) == 0 {
+			diff.Unchanged++
+			continue
+		}
+		diff.Changed = append(diff.Changed, BaselineChange{Key: keyFields, Fields: fields, Baseline: baselineRow, Current: row})
+	}
+	for _, row := range expected {
+		key, _ := rowKey(row)
+		if len(expectedByKey[key]) > 0 {
+			diff.Removed = append(diff.Removed, expectedByKey[key][0])
+			expectedByKey[key] = expectedByKey[key][1:]
+		}
+	}
+	diff.Drift = len(diff.Added) > 0 || len(diff.Removed) > 0 || len(diff.Changed) > 0
+	return diff
+}
+
+// Sorted
+func changedBaselineFields(expected map[string]interface{}, actual map[string]interface{}) []string {
+	fields := []string{}
+	for field, value := range actual {
+		if !sameJson(expected[field], value) {
+			fields = append(fields, field)
+		}
+	}
+	for field := range expected {
+		if _, ok := actual[field]; !ok {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+func sameJson(a interface{}, b interface{}) bool {
+	aBytes, _ := json.Marshal(a)
+	bBytes, _ := json.Marshal(b)
+	return string(aBytes) == string(bBytes)
+}
+
+// Fields rows are matched on, nil to match them as a whole
+func (q StructuredQuery) baselineIdentity() []string {
+	if len(q.GroupBy) > 0 {
+		return q.GroupBy
+	}
+	selected := q.Select
+	if len(selected) == 0 {
+		selected = []string{"kind", "namespace", "name"}
+	}
+	identity := []string{}
+	for _, field := range baselineIdentityFields {
+		for _, selectedField := range selected {
+			if field == selectedField {
+				identity = append(identity, field)
+			}
+		}
+	}
+	if len(identity) == 0 {
+		return nil
+	}
+	return identity
+}
+
+// Rows are read back from json so they compare the same way as the stored ones
+func runBaselineQuery(query StructuredQuery, tables typed.Tables, maxLookBack time.Duration, requestId string) (*StructuredQueryOutput, error) {
+	data, err := RunStructuredQuery(query, tables, maxLookBack, requestId)
+	if err != nil {
+		return nil, err
+	}
+	output := &StructuredQueryOutput{}
+	err = json.Unmarshal(data, output)
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+func baselineSummary(baseline *typed.QueryBaseline) (*BaselineSummary, error) {
+	summary := &BaselineSummary{Name: baseline.Name, Source: baseline.Source, Total: baseline.Total}
+	err := json.Unmarshal([]byte(baseline.Query), &summary.Query)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the query of baseline %v", baseline.Name)
+	}
+	created, err := ptypes.Timestamp(baseline.Created)
+	if err == nil {
+		summary.Created = created.Unix()
+	}
+	return summary, nil
+}
@@ -0,0 +1,99 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+func Test_DiffBaseline_ReportsAddedRemovedAndChangedResources(t *testing.T) {
+	tables := helper_getStructuredTables(t)
+	query, err := ParseStructuredQuery(`kind=Pod namespace=ns | select namespace,name,status.phase`)
+	assert.Nil(t, err)
+	summary, err := SaveBaseline(tables, "ns-pods", query, 24*time.Hour, someTs, someRequestId)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), summary.Total)
+	assert.Equal(t, someTs.Unix(), summary.Created)
+
+	diff, err := DiffBaseline(tables, "ns-pods", 24*time.Hour, someTs, someRequestId)
+	assert.Nil(t, err)
+	assert.False(t, diff.Drift)
+	assert.Equal(t, 3, diff.Unchanged)
+
+	partition := untyped.GetPartitionId(someTs)
+	ts, _ := ptypes.TimestampProto(someTs.Add(30 * time.Minute))
+	err = tables.Db().Update(func(txn badgerwrap.Txn) error {
+		err := txn.Delete([]byte(typed.NewCurrentStateKey(partition, "Pod", "ns", "web-2", "uid-web-2").String()))
+		if err != nil {
+			return err
+		}
+		err = tables.CurrentStateTable().Set(txn, typed.NewCurrentStateKey(partition, "Pod", "ns", "db-1", "uid-db-1").String(), &typed.CurrentState{Timestamp: ts, Payload: helper_structuredPodPayload("db-1", "db", "Running")})
+		if err != nil {
+			return err
+		}
+		return tables.CurrentStateTable().Set(txn, typed.NewCurrentStateKey(partition, "Pod", "ns", "web-4", "uid-web-4").String(), &typed.CurrentState{Timestamp: ts, Payload: helper_structuredPodPayload("web-4", "web", "Running")})
+	})
+	assert.Nil(t, err)
+
+	diff, err = DiffBaseline(tables, "ns-pods", 24*time.Hour, someTs, someRequestId)
+	assert.Nil(t, err)
+	assert.True(t, diff.Drift)
+	assert.Equal(t, 1, diff.Unchanged)
+	assert.Len(t, diff.Added, 1)
+	assert.Equal(t, "web-4", diff.Added[0]["name"])
+	assert.Len(t, diff.Removed, 1)
+	assert.Equal(t, "web-2", diff.Removed[0]["name"])
+	assert.Len(t, diff.Changed, 1)
+	assert.Equal(t, map[string]interface{}{"namespace": "ns", "name": "db-1"}, diff.Changed[0].Key)
+	assert.Equal(t, []string{"status.phase"}, diff.Changed[0].Fields)
+	assert.Equal(t, "Failed", diff.Changed[0].Baseline["status.phase"])
+	assert.Equal(t, "Running", diff.Changed[0].Current["status.phase"])
+}
+
+func Test_Baselines_ListAndDelete(t *testing.T) {
+	tables := helper_getStructuredTables(t)
+	query, err := ParseStructuredQuery(`kind=Pod | count by namespace`)
+	assert.Nil(t, err)
+	_, err = SaveBaseline(tables, "by-namespace", query, 24*time.Hour, someTs, someRequestId)
+	assert.Nil(t, err)
+	_, err = SaveBaseline(tables, "bad/name", query, 24*time.Hour, someTs, someRequestId)
+	assert.NotNil(t, err)
+	query.Limit = 1
+	_, err = SaveBaseline(tables, "limited", query, 24*time.Hour, someTs, someRequestId)
+	assert.NotNil(t, err)
+
+	baselines, err := ListBaselines(tables)
+	assert.Nil(t, err)
+	assert.Len(t, baselines, 1)
+	assert.Equal(t, "by-namespace", baselines[0].Name)
+	assert.Equal(t, []string{"namespace"}, baselines[0].Query.GroupBy)
+
+	assert.Nil(t, DeleteBaseline(tables, "by-namespace"))
+	diff, err := DiffBaseline(tables, "by-namespace", 24*time.Hour, someTs, someRequestId)
+	assert.Nil(t, err)
+	assert.Nil(t, diff)
+}
+
+func Test_diffBaselineRows_MatchesWholeRowsWithoutIdentityFields(t *testing.T) {
+	expected := []map[string]interface{}{{"status.phase": "Running"}, {"status.phase": "Running"}, {"status.phase": "Failed"}}
+	actual := []map[string]interface{}{{"status.phase": "Running"}, {"status.phase": "Pending"}}
+	diff := diffBaselineRows(expected, actual, StructuredQuery{Select: []string{"status.phase"}}.baselineIdentity())
+	assert.True(t, diff.Drift)
+	assert.Equal(t, 1, diff.Unchanged)
+	assert.Equal(t, []map[string]interface{}{{"status.phase": "Pending"}}, diff.Added)
+	assert.Equal(t, []map[string]interface{}{{"status.phase": "Running"}, {"status.phase": "Failed"}}, diff.Removed)
+	assert.Empty(t, diff.Changed)
+}
@@ -0,0 +1,113 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"math"
+	"sort"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+)
+
+// A single relist or watch error is seen at one instant, but the updates it lost happened around it
+const minIngestGapSeconds = 60
+
+// Ingest annotations that mean updates may have been lost.  Api version migrations and cluster upgrades do not
+var completenessAnnotationTypes = map[string]bool{
+	typed.IngestAnnotationWatchError: true,
+	typed.IngestAnnotationThrottled:  true,
+	typed.IngestAnnotationRelist:     true,
+}
+
+/*
+How much of a row's history sloop has.  Score is from 0 to 1, the share of the row's time without ingest problems
+times the share of its changes whose previous version was stored.  A row with a score of 1 may still have missed
+updates nobody noticed, but a low one should not be trusted for what happened in between
+*/
+type HistoryCompleteness struct {
+	Score float64 `json:"score"`
+	// Updates that came in without the version before them being stored, from the informer's old object
+	MissedUpdates int `json:"missedUpdates,omitempty"`
+	// Seconds of the row covered by watch errors, throttling or relists of its kind and namespace
+	IngestGapSeconds int64 `json:"ingestGapSeconds,omitempty"`
+	Relists          int   `json:"relists,omitempty"`
+}
+
+func computeCompleteness(row *TimelineRow, annotations []IngestAnnotationOutput) *HistoryCompleteness {
+	completeness := &HistoryCompleteness{MissedUpdates: len(row.MissedAt)}
+
+	type span struct{ start, end int64 }
+	spans := []span{}
+	for _, annotation := range annotations {
+		if !completenessAnnotationTypes[annotation.Type] {
+			continue
+		}
+		if (annotation.Kind != "" && annotation.Kind != row.Kind) || (annotation.Namespace != "" && annotation.Namespace != row.Namespace) {
+			continue
+		}
+		end := annotation.End
+		if end-annotation.Start < minIngestGapSeconds {
+			end = annotation.Start + minIngestGapSeconds
+		}
+		if end < row.StartDate || annotation.Start > row.EndDate {
+			continue
+		}
+		if annotation.Type == typed.IngestAnnotationRelist {
+			completeness.Relists += int(annotation.Count)
+		}
+		spans = append(spans, span{start: maxInt64(annotation.Start, row.StartDate), end: minInt64(end, row.EndDate)})
+	}
+
+	// Overlapping annotations, like a watch error and the relist after it, count once
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start < spans[j].start })
+	covered := int64(-1)
+	for _, s := range spans {
+		if s.start < covered {
+			s.start = covered
+		}
+		if s.end > s.start {
+			completeness.IngestGapSeconds += s.end - s.start
+			covered = s.end
+		}
+	}
+
+	duration := row.EndDate - row.StartDate
+	if duration < minIngestGapSeconds {
+		duration = minIngestGapSeconds
+	}
+	timeShare := 1 - float64(completeness.IngestGapSeconds)/float64(duration)
+	if timeShare < 0 {
+		timeShare = 0
+	}
+	versionShare := 1.0
+	if changed := len(row.ChangedAt); changed+completeness.MissedUpdates > 0 {
+		versionShare = float64(changed) / float64(changed+completeness.MissedUpdates)
+	}
+	completeness.Score = math.Round(timeShare*versionShare*100) / 100
+	return completeness
+}
+
+func setRowsCompleteness(rows []TimelineRow, annotations []IngestAnnotationOutput) {
+	for idx := range rows {
+		rows[idx].Completeness = computeCompleteness(&rows[idx], annotations)
+	}
+}
+
+func minInt64(a int64, b int64) int64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt64(a int64, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,51 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+)
+
+func Test_computeCompleteness_Complete(t *testing.T) {
+	row := &TimelineRow{Kind: "Pod", Namespace: "ns", StartDate: 1000, EndDate: 2000, ChangedAt: []int64{1100, 1200}}
+	completeness := computeCompleteness(row, []IngestAnnotationOutput{
+		// Other kind, other namespace, outside of the row and not an ingest problem
+		{Type: typed.IngestAnnotationRelist, Kind: "Node", Start: 1500, End: 1500, Count: 1},
+		{Type: typed.IngestAnnotationWatchError, Namespace: "other", Start: 1500, End: 1600},
+		{Type: typed.IngestAnnotationThrottled, Start: 3000, End: 3100},
+		{Type: typed.IngestAnnotationClusterUpgrade, Start: 1000, End: 2000},
+	})
+	assert.Equal(t, &HistoryCompleteness{Score: 1}, completeness)
+}
+
+func Test_computeCompleteness_Gaps(t *testing.T) {
+	row := &TimelineRow{Kind: "Pod", Namespace: "ns", StartDate: 1000, EndDate: 2000, ChangedAt: []int64{1100, 1200, 1300, 1400}, MissedAt: []int64{1300}}
+	completeness := computeCompleteness(row, []IngestAnnotationOutput{
+		// A watch error of every kind and the relist after it overlap, and count once
+		{Type: typed.IngestAnnotationWatchError, Start: 1200, End: 1300},
+		{Type: typed.IngestAnnotationRelist, Kind: "Pod", Namespace: "ns", Start: 1290, End: 1290, Count: 2},
+		// Starts before the row
+		{Type: typed.IngestAnnotationThrottled, Kind: "Pod", Start: 900, End: 1050},
+	})
+	assert.Equal(t, 1, completeness.MissedUpdates)
+	assert.Equal(t, int64(150+50), completeness.IngestGapSeconds)
+	assert.Equal(t, 2, completeness.Relists)
+	// 0.8 of the time, 4 of at least 5 versions
+	assert.Equal(t, 0.64, completeness.Score)
+}
+
+func Test_computeCompleteness_ShortRow(t *testing.T) {
+	row := &TimelineRow{Kind: "Pod", Namespace: "ns", StartDate: 1000, EndDate: 1010}
+	completeness := computeCompleteness(row, []IngestAnnotationOutput{{Type: typed.IngestAnnotationRelist, Start: 1005, End: 1005, Count: 1}})
+	assert.Equal(t, int64(5), completeness.IngestGapSeconds)
+	assert.Equal(t, 0.92, completeness.Score)
+}
@@ -0,0 +1,287 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+// Like credentialUsageKinds without ReplicaSets.  A Deployment replaces its pods through a new ReplicaSet, so the old
+// ReplicaSet would never look restarted
+var configImpactKinds = []string{"Pod", "Deployment", "StatefulSet", "DaemonSet", "Job", "ReplicationController"}
+
+type ConfigImpactOutput struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// When the data of the ConfigMap or Secret changed within the time range, oldest first
+	Changes   []int64                 `json:"changes"`
+	Workloads []ConfigReferenceOutput `json:"workloads"`
+}
+
+type ConfigReferenceOutput struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Where the config was used, like volume:config or env:app/LOG_LEVEL
+	Usages []string `json:"usages"`
+	// When the workload started and stopped referring to the config within the time range
+	From    int64 `json:"from"`
+	To      int64 `json:"to"`
+	Current bool  `json:"current"`
+	// One for each change made while the workload referred to the config
+	Pickups []ConfigChangePickup `json:"pickups"`
+}
+
+type ConfigChangePickup struct {
+	ChangeTime int64 `json:"changeTime"`
+	// The pods of a workload were replaced, or the containers of a pod restarted or the pod was deleted, after the
+	// change.  Zero RestartTime when they were not
+	Restarted   bool  `json:"restarted"`
+	RestartTime int64 `json:"restartTime,omitempty"`
+	// Used in env or envFrom, which are only read when a container starts.  The kubelet updates mounted volumes in
+	// place, apart from subPath mounts which are not told apart here
+	NeedsRestart bool `json:"needsRestart"`
+	PickedUp     bool `json:"pickedUp"`
+}
+
+/*
+Answers "did everyone pick up the new config" for a ConfigMap or Secret (kind) of a namespace.  Changes are the stored
+versions whose data changed, and sloop does not watch Secrets, so their changes are given as change_time params (unix
+seconds, repeated for several).  Each workload that referred to the config when it changed is listed with whether its
+pods were replaced or restarted afterwards.  Pods are listed next to the workloads that own them, since a pod keeps the
+old environment until it restarts
+*/
+func GetConfigImpact(params url.Values, t typed.Tables, startTime time.Time, endTime time.Time, requestId string) ([]byte, error) {
+	selectedKind := params.Get(KindParam)
+	selectedNamespace := params.Get(NamespaceParam)
+	selectedName := params.Get(NameParam)
+	if selectedKind != kubeextractor.ConfigMapKind && selectedKind != kubeextractor.SecretKind {
+		return nil, NewApiError(ErrorCodeBadParams, "%v should be %v or %v", KindParam, kubeextractor.ConfigMapKind, kubeextractor.SecretKind)
+	}
+	if selectedNamespace == "" || selectedNamespace == AllNamespaces || selectedName == "" {
+		return nil, NewApiError(ErrorCodeBadParams, "%v and %v are required", NamespaceParam, NameParam)
+	}
+	changes := []int64{}
+	for _, value := range params[ChangeTimeParam] {
+		changeTime, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, NewApiError(ErrorCodeBadParams, "%v %q should be unix seconds", ChangeTimeParam, value)
+		}
+		changes = append(changes, changeTime)
+	}
+
+	output := ConfigImpactOutput{Kind: selectedKind, Namespace: selectedNamespace, Name: selectedName, Workloads: []ConfigReferenceOutput{}}
+	err := t.Db().View(func(txn badgerwrap.Txn) error {
+		configs, err := readNamespacedWatchRecords(txn, t, selectedKind, selectedNamespace, startTime, endTime, requestId)
+		if err != nil {
+			return err
+		}
+		changes = append(changes, configDataChanges(configs[watchResourceId{namespace: selectedNamespace, name: selectedName}], startTime, endTime)...)
+		output.Changes = sortedUniqueTimes(changes)
+
+		for _, kind := range configImpactKinds {
+			workloads, err := readNamespacedWatchRecords(txn, t, kind, selectedNamespace, startTime, endTime, requestId)
+			if err != nil {
+				return err
+			}
+			for id, records := range workloads {
+				reference, ok := configReference(kind, records, selectedKind, selectedName, output.Changes, startTime, endTime)
+				if !ok {
+					continue
+				}
+				reference.Kind = kind
+				reference.Namespace = id.namespace
+				reference.Name = id.name
+				output.Workloads = append(output.Workloads, reference)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return []byte{}, err
+	}
+
+	sort.Slice(output.Workloads, func(i, j int) bool {
+		if output.Workloads[i].Kind != output.Workloads[j].Kind {
+			return output.Workloads[i].Kind < output.Workloads[j].Kind
+		}
+		return output.Workloads[i].Name < output.Workloads[j].Name
+	})
+	bytes, err := json.MarshalIndent(output, "", " ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal json %v", err)
+	}
+	return bytes, nil
+}
+
+// Versions that only changed metadata, like labels or the resourceVersion, are not changes
+func configDataChanges(records []watchRecord, startTime time.Time, endTime time.Time) []int64 {
+	changes := []int64{}
+	previous := ""
+	for idx, record := range records {
+		if record.timestamp > endTime.Unix() {
+			break
+		}
+		if record.result.WatchType == typed.KubeWatchResult_DELETE {
+			continue
+		}
+		data, err := kubeextractor.ExtractConfigData(record.result.Payload)
+		if err != nil {
+			glog.Errorf("Failed to extract config data: %v", err)
+			continue
+		}
+		if idx > 0 && data != previous && record.timestamp >= startTime.Unix() {
+			changes = append(changes, record.timestamp)
+		}
+		previous = data
+	}
+	return changes
+}
+
+func sortedUniqueTimes(times []int64) []int64 {
+	sort.Slice(times, func(i, j int) bool { return times[i] < times[j] })
+	unique := []int64{}
+	for idx, value := range times {
+		if idx == 0 || times[idx-1] != value {
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
+
+type configWorkloadVersion struct {
+	timestamp int64
+	deleted   bool
+	refers    bool
+	template  string
+	starts    []time.Time
+}
+
+// Walks the versions of one workload like credentialUsage, then checks each change against the version current at
+// the time of the change and the versions after it
+func configReference(kind string, records []watchRecord, configKind string, configName string, changes []int64, startTime time.Time, endTime time.Time) (ConfigReferenceOutput, bool) {
+	reference := ConfigReferenceOutput{Usages: []string{}, Pickups: []ConfigChangePickup{}}
+	found := false
+	usages := map[string]bool{}
+	versions := []configWorkloadVersion{}
+	for _, record := range records {
+		if record.timestamp > endTime.Unix() {
+			break
+		}
+		refs, err := kubeextractor.ExtractPodSpecReferences(kind, record.result.Payload)
+		if err != nil {
+			glog.Errorf("Failed to extract pod spec references: %v", err)
+			continue
+		}
+		configUsages, refers := refs.ConfigMaps[configName]
+		if configKind == kubeextractor.SecretKind {
+			configUsages, refers = refs.Secrets[configName]
+		}
+		for _, configUsage := range configUsages {
+			usages[configUsage] = true
+		}
+		version := configWorkloadVersion{timestamp: record.timestamp, deleted: record.result.WatchType == typed.KubeWatchResult_DELETE, refers: refers}
+		if kind == kubeextractor.PodKind {
+			version.starts, _ = kubeextractor.ExtractContainerStartTimes(record.result.Payload)
+		} else {
+			version.template, _ = kubeextractor.ExtractPodTemplate(kind, record.result.Payload)
+		}
+		versions = append(versions, version)
+
+		timestamp := record.timestamp
+		if timestamp < startTime.Unix() {
+			timestamp = startTime.Unix()
+		}
+		switch {
+		case refers && !version.deleted && !reference.Current:
+			if !found {
+				reference.From = timestamp
+			}
+			found = true
+			reference.Current = true
+		case reference.Current && (!refers || version.deleted):
+			reference.To = timestamp
+			reference.Current = false
+		}
+	}
+	if !found {
+		return reference, false
+	}
+	if reference.Current {
+		reference.To = endTime.Unix()
+	}
+	for configUsage := range usages {
+		reference.Usages = append(reference.Usages, configUsage)
+	}
+	sort.Strings(reference.Usages)
+
+	needsRestart := false
+	for _, configUsage := range reference.Usages {
+		if strings.HasPrefix(configUsage, "env:") || strings.HasPrefix(configUsage, "envFrom:") {
+			needsRestart = true
+		}
+	}
+	for _, change := range changes {
+		pickup, ok := configChangePickup(kind, versions, change)
+		if !ok {
+			continue
+		}
+		pickup.NeedsRestart = needsRestart
+		pickup.PickedUp = pickup.Restarted || !needsRestart
+		reference.Pickups = append(reference.Pickups, pickup)
+	}
+	return reference, true
+}
+
+func configChangePickup(kind string, versions []configWorkloadVersion, change int64) (ConfigChangePickup, bool) {
+	pickup := ConfigChangePickup{ChangeTime: change}
+	current := -1
+	for idx, version := range versions {
+		if version.timestamp > change {
+			break
+		}
+		current = idx
+	}
+	if current < 0 || !versions[current].refers || versions[current].deleted {
+		return pickup, false
+	}
+
+	for _, version := range versions[current+1:] {
+		restartTime := int64(0)
+		switch {
+		case version.deleted && kind == kubeextractor.PodKind:
+			restartTime = version.timestamp
+		case kind == kubeextractor.PodKind:
+			for _, start := range version.starts {
+				if start.Unix() > change && (restartTime == 0 || start.Unix() < restartTime) {
+					restartTime = start.Unix()
+				}
+			}
+		case version.template != versions[current].template:
+			restartTime = version.timestamp
+		}
+		if restartTime != 0 {
+			pickup.Restarted = true
+			pickup.RestartTime = restartTime
+			break
+		}
+	}
+	return pickup, true
+}
@@ -0,0 +1,145 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+func helper_configPodPayload(volume string, envFrom string, startedAt time.Time) string {
+	return fmt.Sprintf(`{"spec": {"volumes": [{"name": "config", "configMap": {"name": "%v"}}], "containers": [{"name": "app", "envFrom": [{"configMapRef": {"name": "%v"}}, {"secretRef": {"name": "db"}}]}]},
+"status": {"containerStatuses": [{"name": "app", "state": {"running": {"startedAt": "%v"}}}]}}`, volume, envFrom, startedAt.Format(time.RFC3339))
+}
+
+func helper_configDeploymentPayload(volume string, envFrom string, restartedAt string) string {
+	return fmt.Sprintf(`{"spec": {"template": {"metadata": {"annotations": {"kubectl.kubernetes.io/restartedAt": "%v"}},
+"spec": {"volumes": [{"name": "config", "configMap": {"name": "%v"}}], "containers": [{"name": "app", "envFrom": [{"configMapRef": {"name": "%v"}}]}]}}}}`, restartedAt, volume, envFrom)
+}
+
+func helper_getConfigImpactTables(t *testing.T) typed.Tables {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	partitionId := untyped.GetPartitionId(someTs)
+	helper_setWatchResults(t, tables, map[*typed.WatchTableKey]string{
+		typed.NewWatchTableKey(partitionId, "ConfigMap", "ns", "settings", someTs.Add(-time.Minute)): `{"metadata": {"resourceVersion": "1"}, "data": {"level": "info"}}`,
+		// Only a label changed
+		typed.NewWatchTableKey(partitionId, "ConfigMap", "ns", "settings", someTs.Add(5*time.Minute)):  `{"metadata": {"resourceVersion": "2", "labels": {"a": "b"}}, "data": {"level": "info"}}`,
+		typed.NewWatchTableKey(partitionId, "ConfigMap", "ns", "settings", someTs.Add(10*time.Minute)): `{"metadata": {"resourceVersion": "3", "labels": {"a": "b"}}, "data": {"level": "debug"}}`,
+		typed.NewWatchTableKey(partitionId, "ConfigMap", "ns", "other", someTs.Add(20*time.Minute)):    `{"data": {"level": "warn"}}`,
+
+		// Restarted with kubectl rollout restart after the change
+		typed.NewWatchTableKey(partitionId, "Deployment", "ns", "web", someTs.Add(-time.Minute)):    helper_configDeploymentPayload("none", "settings", ""),
+		typed.NewWatchTableKey(partitionId, "Deployment", "ns", "web", someTs.Add(15*time.Minute)):  helper_configDeploymentPayload("none", "settings", "2019-03-04T03:19:00Z"),
+		typed.NewWatchTableKey(partitionId, "Deployment", "ns", "static", someTs.Add(-time.Minute)): helper_configDeploymentPayload("settings", "none", ""),
+		// Kept running with the old environment
+		typed.NewWatchTableKey(partitionId, "Pod", "ns", "web-1", someTs.Add(-time.Minute)):    helper_configPodPayload("none", "settings", someTs.Add(-time.Hour)),
+		typed.NewWatchTableKey(partitionId, "Pod", "ns", "web-1", someTs.Add(12*time.Minute)):  helper_configPodPayload("none", "settings", someTs.Add(-time.Hour)),
+		typed.NewWatchTableKey(partitionId, "Pod", "ns", "web-2", someTs.Add(-time.Minute)):    helper_configPodPayload("none", "settings", someTs.Add(-time.Hour)),
+		typed.NewWatchTableKey(partitionId, "Pod", "ns", "web-2", someTs.Add(13*time.Minute)):  helper_configPodPayload("none", "settings", someTs.Add(13*time.Minute)),
+		typed.NewWatchTableKey(partitionId, "Pod", "ns", "late", someTs.Add(11*time.Minute)):   helper_configPodPayload("none", "settings", someTs.Add(11*time.Minute)),
+		typed.NewWatchTableKey(partitionId, "Pod", "ns", "unrelated", someTs.Add(time.Minute)): helper_configPodPayload("none", "none", someTs),
+	})
+
+	deletedTs := someTs.Add(20 * time.Minute)
+	pts, _ := ptypes.TimestampProto(deletedTs)
+	err = tables.Db().Update(func(txn badgerwrap.Txn) error {
+		key := typed.NewWatchTableKey(partitionId, "Pod", "ns", "web-1", deletedTs).String()
+		return tables.WatchTable().Set(txn, key, &typed.KubeWatchResult{Kind: "Pod", WatchType: typed.KubeWatchResult_DELETE, Timestamp: pts, Payload: helper_configPodPayload("none", "settings", someTs.Add(-time.Hour))})
+	})
+	assert.Nil(t, err)
+	return tables
+}
+
+func Test_GetConfigImpact_ConfigMap(t *testing.T) {
+	tables := helper_getConfigImpactTables(t)
+	values := helper_get_params()
+	values[NamespaceParam] = []string{"ns"}
+	values[KindParam] = []string{"ConfigMap"}
+	values[NameParam] = []string{"settings"}
+	data, err := GetConfigImpact(values, tables, someTs, someTs.Add(30*time.Minute), someRequestId)
+	assert.Nil(t, err)
+	output := ConfigImpactOutput{}
+	assert.Nil(t, json.Unmarshal(data, &output))
+
+	change := someTs.Add(10 * time.Minute).Unix()
+	assert.Equal(t, []int64{change}, output.Changes)
+	pickups := map[string]ConfigChangePickup{}
+	names := []string{}
+	for _, workload := range output.Workloads {
+		names = append(names, workload.Kind+"/"+workload.Name)
+		if len(workload.Pickups) > 0 {
+			pickups[workload.Name] = workload.Pickups[0]
+		}
+	}
+	assert.Equal(t, []string{"Deployment/static", "Deployment/web", "Pod/late", "Pod/web-1", "Pod/web-2"}, names)
+	assert.Equal(t, ConfigChangePickup{ChangeTime: change, PickedUp: true}, pickups["static"])
+	assert.Equal(t, ConfigChangePickup{ChangeTime: change, Restarted: true, RestartTime: someTs.Add(15 * time.Minute).Unix(), NeedsRestart: true, PickedUp: true}, pickups["web"])
+	assert.Equal(t, ConfigChangePickup{ChangeTime: change, Restarted: true, RestartTime: someTs.Add(20 * time.Minute).Unix(), NeedsRestart: true, PickedUp: true}, pickups["web-1"])
+	assert.Equal(t, ConfigChangePickup{ChangeTime: change, Restarted: true, RestartTime: someTs.Add(13 * time.Minute).Unix(), NeedsRestart: true, PickedUp: true}, pickups["web-2"])
+	// Started after the change
+	_, ok := pickups["late"]
+	assert.False(t, ok)
+	assert.Equal(t, []string{"envFrom:app"}, output.Workloads[3].Usages)
+	assert.Equal(t, someTs.Add(20*time.Minute).Unix(), output.Workloads[3].To)
+
+	// Before web-1 was deleted it had not restarted
+	data, err = GetConfigImpact(values, tables, someTs, someTs.Add(19*time.Minute), someRequestId)
+	assert.Nil(t, err)
+	output = ConfigImpactOutput{}
+	assert.Nil(t, json.Unmarshal(data, &output))
+	assert.Equal(t, "web-1", output.Workloads[3].Name)
+	assert.Equal(t, []ConfigChangePickup{{ChangeTime: change, NeedsRestart: true}}, output.Workloads[3].Pickups)
+}
+
+func Test_GetConfigImpact_SecretChangeTime(t *testing.T) {
+	tables := helper_getConfigImpactTables(t)
+	values := helper_get_params()
+	values[NamespaceParam] = []string{"ns"}
+	values[KindParam] = []string{"Secret"}
+	values[NameParam] = []string{"db"}
+	values[ChangeTimeParam] = []string{fmt.Sprint(someTs.Add(12 * time.Minute).Unix())}
+	data, err := GetConfigImpact(values, tables, someTs, someTs.Add(30*time.Minute), someRequestId)
+	assert.Nil(t, err)
+	output := ConfigImpactOutput{}
+	assert.Nil(t, json.Unmarshal(data, &output))
+	assert.Equal(t, []int64{someTs.Add(12 * time.Minute).Unix()}, output.Changes)
+	names := []string{}
+	for _, workload := range output.Workloads {
+		names = append(names, workload.Name)
+	}
+	assert.Equal(t, []string{"late", "unrelated", "web-1", "web-2"}, names)
+	// late started before the change and was not restarted, web-2 restarted right after it
+	assert.Equal(t, []ConfigChangePickup{{ChangeTime: someTs.Add(12 * time.Minute).Unix(), NeedsRestart: true}}, output.Workloads[0].Pickups)
+	assert.Equal(t, someTs.Add(13*time.Minute).Unix(), output.Workloads[3].Pickups[0].RestartTime)
+}
+
+func Test_GetConfigImpact_BadParams(t *testing.T) {
+	tables := helper_getConfigImpactTables(t)
+	values := helper_get_params()
+	values[KindParam] = []string{"Pod"}
+	values[NameParam] = []string{"settings"}
+	_, err := GetConfigImpact(values, tables, someTs, someTs.Add(time.Hour), someRequestId)
+	assert.NotNil(t, err)
+
+	values[KindParam] = []string{"ConfigMap"}
+	values[ChangeTimeParam] = []string{"yesterday"}
+	_, err = GetConfigImpact(values, tables, someTs, someTs.Add(time.Hour), someRequestId)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,145 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+// Kinds with a pod spec or pod template that sloop watches
+var credentialUsageKinds = []string{"Pod", "Deployment", "StatefulSet", "DaemonSet", "ReplicaSet", "Job", "ReplicationController"}
+
+type CredentialUsageOutput struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Where the credential was used, like volume:certs or env:app/DB_PASSWORD.  Empty for service accounts
+	Usages []string `json:"usages,omitempty"`
+	// When the workload started and stopped referring to the credential within the time range
+	From int64 `json:"from"`
+	To   int64 `json:"to"`
+	// Still referred to at the end of the time range
+	Current bool `json:"current"`
+}
+
+/*
+Returns the workloads that referred to a Secret or ServiceAccount (kind) of a namespace over the time range, to see
+what a credential rotation touches.  Secrets are found in volumes, env, envFrom and imagePullSecrets, service accounts
+in serviceAccountName, and pods without one use the default service account.  Pods are listed next to the workloads
+that own them, since a pod keeps the old credential until it is replaced
+*/
+func GetCredentialUsage(params url.Values, t typed.Tables, startTime time.Time, endTime time.Time, requestId string) ([]byte, error) {
+	selectedKind := params.Get(KindParam)
+	selectedNamespace := params.Get(NamespaceParam)
+	selectedName := params.Get(NameParam)
+	if selectedKind != kubeextractor.SecretKind && selectedKind != kubeextractor.ServiceAccountKind {
+		return nil, NewApiError(ErrorCodeBadParams, "%v should be %v or %v", KindParam, kubeextractor.SecretKind, kubeextractor.ServiceAccountKind)
+	}
+	if selectedNamespace == "" || selectedNamespace == AllNamespaces || selectedName == "" {
+		return nil, NewApiError(ErrorCodeBadParams, "%v and %v are required", NamespaceParam, NameParam)
+	}
+
+	output := []CredentialUsageOutput{}
+	err := t.Db().View(func(txn badgerwrap.Txn) error {
+		for _, kind := range credentialUsageKinds {
+			workloads, err := readNamespacedWatchRecords(txn, t, kind, selectedNamespace, startTime, endTime, requestId)
+			if err != nil {
+				return err
+			}
+			for id, records := range workloads {
+				usage, ok := credentialUsage(kind, records, selectedKind, selectedName, startTime, endTime)
+				if !ok {
+					continue
+				}
+				usage.Kind = kind
+				usage.Namespace = id.namespace
+				usage.Name = id.name
+				output = append(output, usage)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return []byte{}, err
+	}
+
+	sort.Slice(output, func(i, j int) bool {
+		if output[i].Kind != output[j].Kind {
+			return output[i].Kind < output[j].Kind
+		}
+		return output[i].Name < output[j].Name
+	})
+	bytes, err := json.MarshalIndent(output, "", " ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal json %v", err)
+	}
+	return bytes, nil
+}
+
+// Walks the versions of one workload, which are sorted by time and can start with the last one before the time range
+func credentialUsage(kind string, records []watchRecord, credentialKind string, credentialName string, startTime time.Time, endTime time.Time) (CredentialUsageOutput, bool) {
+	usage := CredentialUsageOutput{}
+	found := false
+	usages := map[string]bool{}
+	for _, record := range records {
+		if record.timestamp > endTime.Unix() {
+			break
+		}
+		refs, err := kubeextractor.ExtractPodSpecReferences(kind, record.result.Payload)
+		if err != nil {
+			glog.Errorf("Failed to extract pod spec references: %v", err)
+			continue
+		}
+		refers := false
+		if credentialKind == kubeextractor.ServiceAccountKind {
+			refers = refs.ServiceAccount == credentialName
+		} else if secretUsages, ok := refs.Secrets[credentialName]; ok {
+			refers = true
+			for _, secretUsage := range secretUsages {
+				usages[secretUsage] = true
+			}
+		}
+		deleted := record.result.WatchType == typed.KubeWatchResult_DELETE
+		timestamp := record.timestamp
+		if timestamp < startTime.Unix() {
+			timestamp = startTime.Unix()
+		}
+
+		switch {
+		case refers && !deleted && !usage.Current:
+			if !found {
+				usage.From = timestamp
+			}
+			found = true
+			usage.Current = true
+		case usage.Current && (!refers || deleted):
+			usage.To = timestamp
+			usage.Current = false
+		}
+	}
+	if !found {
+		return usage, false
+	}
+	if usage.Current {
+		usage.To = endTime.Unix()
+	}
+	for secretUsage := range usages {
+		usage.Usages = append(usage.Usages, secretUsage)
+	}
+	sort.Strings(usage.Usages)
+	return usage, true
+}
@@ -0,0 +1,82 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+func helper_deploymentWithSecret(serviceAccount string, secret string) string {
+	return fmt.Sprintf(`{"spec": {"template": {"spec": {"serviceAccountName": "%v", "volumes": [{"name": "creds", "secret": {"secretName": "%v"}}]}}}}`, serviceAccount, secret)
+}
+
+func helper_getCredentialUsageTables(t *testing.T) typed.Tables {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	partitionId := untyped.GetPartitionId(someTs)
+	helper_setWatchResults(t, tables, map[*typed.WatchTableKey]string{
+		// Used the old secret from before the time range until it was rotated
+		typed.NewWatchTableKey(partitionId, "Deployment", "ns", "web", someTs.Add(-time.Minute)):     helper_deploymentWithSecret("web", "creds-v1"),
+		typed.NewWatchTableKey(partitionId, "Deployment", "ns", "web", someTs.Add(10*time.Minute)):   helper_deploymentWithSecret("web", "creds-v2"),
+		typed.NewWatchTableKey(partitionId, "Deployment", "ns", "api", someTs.Add(5*time.Minute)):    helper_deploymentWithSecret("", "creds-v1"),
+		typed.NewWatchTableKey(partitionId, "Pod", "ns", "web-1", someTs.Add(2*time.Minute)):         `{"spec": {"serviceAccountName": "web", "imagePullSecrets": [{"name": "creds-v1"}]}}`,
+		typed.NewWatchTableKey(partitionId, "Deployment", "other", "web", someTs.Add(5*time.Minute)): helper_deploymentWithSecret("web", "creds-v1"),
+	})
+	return tables
+}
+
+func Test_GetCredentialUsage_Secret(t *testing.T) {
+	tables := helper_getCredentialUsageTables(t)
+	values := helper_get_params()
+	values[KindParam] = []string{kubeextractor.SecretKind}
+	values[NamespaceParam] = []string{"ns"}
+	values[NameParam] = []string{"creds-v1"}
+	data, err := GetCredentialUsage(values, tables, someTs, someTs.Add(time.Hour), someRequestId)
+	assert.Nil(t, err)
+	output := []CredentialUsageOutput{}
+	assert.Nil(t, json.Unmarshal(data, &output))
+	assert.Equal(t, []CredentialUsageOutput{
+		{Kind: "Deployment", Namespace: "ns", Name: "api", Usages: []string{"volume:creds"}, From: someTs.Add(5 * time.Minute).Unix(), To: someTs.Add(time.Hour).Unix(), Current: true},
+		{Kind: "Deployment", Namespace: "ns", Name: "web", Usages: []string{"volume:creds"}, From: someTs.Unix(), To: someTs.Add(10 * time.Minute).Unix()},
+		{Kind: "Pod", Namespace: "ns", Name: "web-1", Usages: []string{"imagePullSecrets"}, From: someTs.Add(2 * time.Minute).Unix(), To: someTs.Add(time.Hour).Unix(), Current: true},
+	}, output)
+}
+
+func Test_GetCredentialUsage_ServiceAccount(t *testing.T) {
+	tables := helper_getCredentialUsageTables(t)
+	values := helper_get_params()
+	values[KindParam] = []string{kubeextractor.ServiceAccountKind}
+	values[NamespaceParam] = []string{"ns"}
+	values[NameParam] = []string{kubeextractor.DefaultServiceAccount}
+	data, err := GetCredentialUsage(values, tables, someTs, someTs.Add(time.Hour), someRequestId)
+	assert.Nil(t, err)
+	output := []CredentialUsageOutput{}
+	assert.Nil(t, json.Unmarshal(data, &output))
+	assert.Len(t, output, 1)
+	assert.Equal(t, "api", output[0].Name)
+
+	values[NamespaceParam] = []string{AllNamespaces}
+	_, err = GetCredentialUsage(values, tables, someTs, someTs.Add(time.Hour), someRequestId)
+	assert.NotNil(t, err)
+	values[KindParam] = []string{"ConfigMap"}
+	values[NamespaceParam] = []string{"ns"}
+	_, err = GetCredentialUsage(values, tables, someTs, someTs.Add(time.Hour), someRequestId)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,113 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+type CurrentStateOutput struct {
+	Kind        string          `json:"kind"`
+	Namespace   string          `json:"namespace"`
+	Name        string          `json:"name"`
+	Uid         string          `json:"uid"`
+	LastUpdated int64           `json:"lastUpdated"`
+	Payload     json.RawMessage `json:"payload,omitempty"`
+	Summary     string          `json:"summary,omitempty"`
+}
+
+// Returns the latest payload of every live resource matching kind, namespace, namematch and name.  The time range
+// is ignored, this is always the state as of the newest watch result of each resource
+func GetCurrentState(params url.Values, t typed.Tables, startTime time.Time, endTime time.Time, requestId string) ([]byte, error) {
+	selectedKind := params.Get(KindParam)
+	selectedNamespace := params.Get(NamespaceParam)
+	selectedNameMatch := params.Get(NameMatchParam)
+	selectedName := params.Get(NameParam)
+
+	var states map[typed.CurrentStateKey]*typed.CurrentState
+	err := t.Db().View(func(txn badgerwrap.Txn) error {
+		if selectedKind != AllKinds && selectedName != "" && (selectedNamespace != AllNamespaces || kubeextractor.IsClustersScopedResource(selectedKind)) {
+			// A single resource is a point read
+			states = map[typed.CurrentStateKey]*typed.CurrentState{}
+			key, state, err := t.CurrentStateTable().Find(txn, selectedKind, getCurrentStateNamespace(selectedKind, selectedNamespace), selectedName)
+			if key != nil {
+				states[*key] = state
+			}
+			return err
+		}
+		kind, namespace := getCurrentStatePrefix(selectedKind, selectedNamespace)
+		var err error
+		states, err = t.CurrentStateTable().List(txn, kind, namespace)
+		return err
+	})
+	if err != nil {
+		return []byte{}, err
+	}
+
+	summaryOnly := isSummaryOnly(params)
+	output := []CurrentStateOutput{}
+	for key, state := range states {
+		if !keepRowHelper(key.Name, key.Kind, key.Namespace, selectedKind, selectedNamespace, selectedNameMatch, selectedName, "", "") {
+			continue
+		}
+		row := CurrentStateOutput{
+			Kind:        key.Kind,
+			Namespace:   key.Namespace,
+			Name:        key.Name,
+			Uid:         key.Uid,
+			LastUpdated: state.GetTimestamp().GetSeconds(),
+			Summary:     summarizePayload(key.Kind, state.Payload),
+		}
+		if !summaryOnly {
+			row.Payload = json.RawMessage(state.Payload)
+		}
+		output = append(output, row)
+	}
+	sort.Slice(output, func(i, j int) bool {
+		if output[i].Kind != output[j].Kind {
+			return output[i].Kind < output[j].Kind
+		}
+		if output[i].Namespace != output[j].Namespace {
+			return output[i].Namespace < output[j].Namespace
+		}
+		return output[i].Name < output[j].Name
+	})
+
+	bytes, err := json.MarshalIndent(output, "", " ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal json %v", err)
+	}
+	return bytes, nil
+}
+
+// Cluster scoped resources are stored without a namespace
+func getCurrentStateNamespace(kind string, namespace string) string {
+	if kubeextractor.IsClustersScopedResource(kind) {
+		return ""
+	}
+	return namespace
+}
+
+// Kind and namespace to list, empty when all of them are listed and filtered by keepRowHelper
+func getCurrentStatePrefix(selectedKind string, selectedNamespace string) (string, string) {
+	if selectedKind == AllKinds {
+		return "", ""
+	}
+	if selectedNamespace == AllNamespaces {
+		return selectedKind, ""
+	}
+	return selectedKind, getCurrentStateNamespace(selectedKind, selectedNamespace)
+}
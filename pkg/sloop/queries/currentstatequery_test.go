@@ -0,0 +1,103 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+func helper_getCurrentStateTables(t *testing.T) typed.Tables {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	ts, _ := ptypes.TimestampProto(someTs)
+	older := untyped.GetPartitionId(someTs.Add(-3 * time.Hour))
+	newer := untyped.GetPartitionId(someTs)
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		for _, key := range []*typed.CurrentStateKey{
+			typed.NewCurrentStateKey(older, "Pod", "some-namespace", "pod-a", "uid-a"),
+			typed.NewCurrentStateKey(newer, "Pod", "some-namespace", "pod-b", "uid-b"),
+			typed.NewCurrentStateKey(newer, "Pod", "other", "pod-c", "uid-c"),
+			typed.NewCurrentStateKey(newer, "Node", "", "node-a", "uid-d"),
+		} {
+			err := tables.CurrentStateTable().Set(txn, key.String(), &typed.CurrentState{Timestamp: ts, Payload: `{"name":"` + key.Name + `"}`})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+	return tables
+}
+
+func helper_runCurrentState(t *testing.T, tables typed.Tables, kind string, namespace string, name string) []CurrentStateOutput {
+	values := helper_get_params()
+	values[KindParam] = []string{kind}
+	values[NamespaceParam] = []string{namespace}
+	if name != "" {
+		values[NameParam] = []string{name}
+	}
+	data, err := GetCurrentState(values, tables, someTs, someTs, someRequestId)
+	assert.Nil(t, err)
+	output := []CurrentStateOutput{}
+	assert.Nil(t, json.Unmarshal(data, &output))
+	return output
+}
+
+func Test_GetCurrentState_ListsAcrossPartitions(t *testing.T) {
+	tables := helper_getCurrentStateTables(t)
+
+	output := helper_runCurrentState(t, tables, "Pod", "some-namespace", "")
+	assert.Len(t, output, 2)
+	assert.Equal(t, "pod-a", output[0].Name)
+	assert.Equal(t, "uid-a", output[0].Uid)
+	assert.Equal(t, someTs.Unix(), output[0].LastUpdated)
+	assert.JSONEq(t, `{"name":"pod-a"}`, string(output[0].Payload))
+	assert.Equal(t, "pod-b", output[1].Name)
+
+	assert.Len(t, helper_runCurrentState(t, tables, AllKinds, AllNamespaces, ""), 4)
+	// Cluster scoped kinds are not filtered by namespace
+	assert.Len(t, helper_runCurrentState(t, tables, "Node", "some-namespace", ""), 1)
+}
+
+func Test_GetCurrentState_SingleResource(t *testing.T) {
+	tables := helper_getCurrentStateTables(t)
+
+	output := helper_runCurrentState(t, tables, "Pod", "other", "pod-c")
+	assert.Len(t, output, 1)
+	assert.Equal(t, "pod-c", output[0].Name)
+
+	assert.Len(t, helper_runCurrentState(t, tables, "Pod", "other", "pod-a"), 0)
+	assert.Len(t, helper_runCurrentState(t, tables, "Node", AllNamespaces, "node-a"), 1)
+}
+
+func Test_GetCurrentState_SummaryOnly(t *testing.T) {
+	tables := helper_getCurrentStateTables(t)
+	values := helper_get_params()
+	values[KindParam] = []string{"Node"}
+	values[SummaryOnlyParam] = []string{"true"}
+	data, err := GetCurrentState(values, tables, someTs, someTs, someRequestId)
+	assert.Nil(t, err)
+	output := []CurrentStateOutput{}
+	assert.Nil(t, json.Unmarshal(data, &output))
+	assert.Len(t, output, 1)
+	assert.Nil(t, output[0].Payload)
+	// The node payload has no conditions
+	assert.Equal(t, "NotReady", output[0].Summary)
+}
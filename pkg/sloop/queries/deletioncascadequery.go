@@ -0,0 +1,216 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+const (
+	// Deleted while it was still owned
+	CascadeStatusDeleted = "deleted"
+	// The owner reference was removed and the resource was not deleted, as with orphan propagation
+	CascadeStatusOrphaned = "orphaned"
+	// Still owned and not deleted by the end of the time range, garbage collection did not get to it
+	CascadeStatusRemaining = "remaining"
+)
+
+type DeletionCascadeOutput struct {
+	DeletionCascadeNode
+	DeletedCount   int `json:"deletedCount"`
+	OrphanedCount  int `json:"orphanedCount"`
+	RemainingCount int `json:"remainingCount"`
+	// Seconds from the deletion of the parent, or the request of it when that was seen first, to the last dependent
+	// that was deleted
+	CascadeSeconds int64 `json:"cascadeSeconds"`
+}
+
+type DeletionCascadeNode struct {
+	Uid       string `json:"uid"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	Status    string `json:"status,omitempty"`
+	// Unix seconds of the first version before the delete with metadata.deletionTimestamp, 0 when none was stored,
+	// which is usual for a background deletion without finalizers
+	DeletionRequested int64 `json:"deletionRequested,omitempty"`
+	// Unix seconds of the delete watch result, 0 when it was not deleted in the time range
+	Deleted int64 `json:"deleted,omitempty"`
+	// Seconds from the deletion of the owner, negative when it went first as in a foreground deletion
+	AfterOwner int64                  `json:"afterOwner,omitempty"`
+	Children   []*DeletionCascadeNode `json:"children"`
+}
+
+// The time its dependents are collected from.  A foreground deletion deletes them before the resource itself
+func (n *DeletionCascadeNode) cascadeStart() int64 {
+	if n.DeletionRequested != 0 {
+		return n.DeletionRequested
+	}
+	return n.Deleted
+}
+
+/*
+Returns the dependents that were deleted along with a resource, to check that garbage collection behaved.  Params
+kind, namespace and name select the parent, and its last deletion in the time range is used unless uuid picks one.
+Its children are the resources whose owner reference to it was in place when its deletion started, read from the
+owner graph table, and each one's deletion comes from its watch results.  Deleted children are walked the same way
+*/
+func GetDeletionCascade(params url.Values, t typed.Tables, startTime time.Time, endTime time.Time, requestId string) ([]byte, error) {
+	selectedKind := params.Get(KindParam)
+	selectedName := params.Get(NameParam)
+	if selectedKind == "" || selectedName == "" {
+		return []byte{}, NewApiError(ErrorCodeBadParams, "GetDeletionCascade requires %v and %v", KindParam, NameParam)
+	}
+	selectedNamespace := kubeextractor.NormalizeNamespace(selectedKind, params.Get(NamespaceParam))
+
+	output := &DeletionCascadeOutput{}
+	err := t.Db().View(func(txn badgerwrap.Txn) error {
+		root := &DeletionCascadeNode{Uid: params.Get(UuidParam), Kind: selectedKind, Namespace: selectedNamespace, Name: selectedName, Children: []*DeletionCascadeNode{}}
+		err := readResourceDeletion(txn, t, root, startTime, endTime, requestId)
+		if err != nil {
+			return err
+		}
+		if root.Deleted == 0 {
+			return NewApiError(ErrorCodeNotFound, "%v %v was not deleted in the time range", selectedKind, selectedName)
+		}
+		output.DeletionCascadeNode = *root
+
+		visited := map[string]bool{root.Uid: true}
+		level := []*DeletionCascadeNode{&output.DeletionCascadeNode}
+		for depth := 0; depth < maxOwnerTreeDepth && len(level) > 0; depth++ {
+			var nextLevel []*DeletionCascadeNode
+			for _, owner := range level {
+				children, err := getCascadeChildren(txn, t, owner, startTime, endTime, requestId)
+				if err != nil {
+					return err
+				}
+				for _, child := range children {
+					if visited[child.Uid] {
+						continue
+					}
+					visited[child.Uid] = true
+					owner.Children = append(owner.Children, child)
+					switch child.Status {
+					case CascadeStatusDeleted:
+						output.DeletedCount++
+						if child.Deleted-root.cascadeStart() > output.CascadeSeconds {
+							output.CascadeSeconds = child.Deleted - root.cascadeStart()
+						}
+						nextLevel = append(nextLevel, child)
+					case CascadeStatusOrphaned:
+						output.OrphanedCount++
+					default:
+						output.RemainingCount++
+					}
+				}
+			}
+			level = nextLevel
+		}
+		return nil
+	})
+	if err != nil {
+		return []byte{}, err
+	}
+
+	bytes, err := json.MarshalIndent(output, "", " ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal json %v", err)
+	}
+	return bytes, nil
+}
+
+// Returns the children whose edge to the owner was in place when its deletion started, with their deletions
+func getCascadeChildren(txn badgerwrap.Txn, t typed.Tables, owner *DeletionCascadeNode, startTime time.Time, endTime time.Time, requestId string) ([]*DeletionCascadeNode, error) {
+	owned, err := getOwnedChildren(txn, t, &OwnerTreeOutput{Uid: owner.Uid, Kind: owner.Kind}, startTime, endTime, requestId)
+	if err != nil {
+		return nil, err
+	}
+
+	start := owner.cascadeStart()
+	children := []*DeletionCascadeNode{}
+	for _, child := range owned {
+		var atStart *typed.OwnerEdgeInterval
+		for _, interval := range child.Intervals {
+			if interval.Start <= start && (interval.End == 0 || interval.End >= start) {
+				atStart = interval
+			}
+		}
+		if atStart == nil {
+			continue
+		}
+		node := &DeletionCascadeNode{Uid: child.Uid, Kind: child.Kind, Namespace: child.Namespace, Name: child.Name, Children: []*DeletionCascadeNode{}}
+		err = readResourceDeletion(txn, t, node, startTime, endTime, requestId)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case node.Deleted != 0 && (atStart.End == 0 || atStart.End >= node.Deleted):
+			node.Status = CascadeStatusDeleted
+			node.AfterOwner = node.Deleted - owner.Deleted
+		case atStart.End != 0:
+			node.Status = CascadeStatusOrphaned
+		default:
+			node.Status = CascadeStatusRemaining
+		}
+		children = append(children, node)
+	}
+	return children, nil
+}
+
+// Fills in when deletion of the resource was requested and when it was deleted.  Without a uid the last resource
+// with the name that was deleted in the time range is used, and its uid is filled in too
+func readResourceDeletion(txn badgerwrap.Txn, t typed.Tables, node *DeletionCascadeNode, startTime time.Time, endTime time.Time, requestId string) error {
+	keyComparator := typed.NewWatchTableKeyComparator(node.Kind, kubeextractor.NormalizeNamespace(node.Kind, node.Namespace), node.Name, time.Time{})
+	records, stats, err := t.WatchTable().RangeRead(txn, keyComparator, nil, nil, startTime, endTime)
+	if err != nil {
+		return err
+	}
+	stats.Log(requestId)
+
+	keys := []typed.WatchTableKey{}
+	for key := range records {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Timestamp.Before(keys[j].Timestamp) })
+	metadata := make([]kubeextractor.KubeMetadata, len(keys))
+	for idx, key := range keys {
+		metadata[idx], err = kubeextractor.ExtractMetadata(records[key].Payload)
+		if err != nil {
+			return err
+		}
+	}
+	if node.Uid == "" {
+		for idx := len(keys) - 1; idx >= 0; idx-- {
+			if records[keys[idx]].WatchType == typed.KubeWatchResult_DELETE {
+				node.Uid = metadata[idx].Uid
+				break
+			}
+		}
+	}
+
+	for idx, key := range keys {
+		if node.Uid == "" || metadata[idx].Uid != node.Uid {
+			continue
+		}
+		if node.DeletionRequested == 0 && metadata[idx].DeletionTimestamp != "" && records[key].WatchType != typed.KubeWatchResult_DELETE {
+			node.DeletionRequested = key.Timestamp.Unix()
+		}
+		if records[key].WatchType == typed.KubeWatchResult_DELETE {
+			node.Deleted = key.Timestamp.Unix()
+		}
+	}
+	return nil
+}
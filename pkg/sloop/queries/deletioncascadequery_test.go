@@ -0,0 +1,137 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+func helper_cascadePayload(name string, uid string, deleting bool) string {
+	deletionTimestamp := ""
+	if deleting {
+		deletionTimestamp = `,"deletionTimestamp":"2019-01-02T03:04:05Z"`
+	}
+	return fmt.Sprintf(`{"metadata":{"name":"%v","namespace":"ns","uid":"%v"%v}}`, name, uid, deletionTimestamp)
+}
+
+func Test_GetDeletionCascade(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	partitionId := untyped.GetPartitionId(someTs)
+	at := func(seconds int) time.Time { return someTs.Add(time.Duration(seconds) * time.Second) }
+	interval := func(start int, end int) []*typed.OwnerEdgeInterval {
+		return []*typed.OwnerEdgeInterval{{Start: at(start).Unix(), End: at(end).Unix(), LastSeen: at(end).Unix()}}
+	}
+
+	type watchResult struct {
+		kind     string
+		name     string
+		uid      string
+		seconds  int
+		deleting bool
+		delete   bool
+	}
+	results := []watchResult{
+		// A foreground deletion of the deployment, its replica set goes first
+		{kind: "Deployment", name: "app", uid: "deployUid", seconds: -60},
+		{kind: "Deployment", name: "app", uid: "deployUid", seconds: 0, deleting: true},
+		{kind: "Deployment", name: "app", uid: "deployUid", seconds: 20, deleting: true, delete: true},
+		{kind: "ReplicaSet", name: "app-1", uid: "rsUid", seconds: 2, deleting: true},
+		{kind: "ReplicaSet", name: "app-1", uid: "rsUid", seconds: 15, deleting: true, delete: true},
+		{kind: "Pod", name: "app-1-a", uid: "podUid1", seconds: 10, deleting: true, delete: true},
+		{kind: "Pod", name: "app-1-b", uid: "podUid2", seconds: -60},
+		{kind: "Pod", name: "app-1-c", uid: "podUid3", seconds: -60},
+		// Deleted by a rollout long before
+		{kind: "Pod", name: "app-1-d", uid: "podUid4", seconds: -50, delete: true},
+	}
+	edges := map[string]*typed.OwnerEdge{
+		typed.NewOwnerEdgeKey(partitionId, "deployUid", "ReplicaSet", "ns", "rsUid").String(): {OwnerKind: "Deployment", OwnerName: "app", ChildName: "app-1", Intervals: interval(-100, 15)},
+		typed.NewOwnerEdgeKey(partitionId, "rsUid", "Pod", "ns", "podUid1").String():          {OwnerKind: "ReplicaSet", OwnerName: "app-1", ChildName: "app-1-a", Intervals: interval(-100, 10)},
+		// Reference removed without deleting the pod
+		typed.NewOwnerEdgeKey(partitionId, "rsUid", "Pod", "ns", "podUid2").String(): {OwnerKind: "ReplicaSet", OwnerName: "app-1", ChildName: "app-1-b", Intervals: interval(-100, 5)},
+		typed.NewOwnerEdgeKey(partitionId, "rsUid", "Pod", "ns", "podUid3").String(): {OwnerKind: "ReplicaSet", OwnerName: "app-1", ChildName: "app-1-c",
+			Intervals: []*typed.OwnerEdgeInterval{{Start: at(-100).Unix(), LastSeen: at(-60).Unix()}}},
+		typed.NewOwnerEdgeKey(partitionId, "rsUid", "Pod", "ns", "podUid4").String(): {OwnerKind: "ReplicaSet", OwnerName: "app-1", ChildName: "app-1-d", Intervals: interval(-100, -50)},
+	}
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		for _, result := range results {
+			watchType := typed.KubeWatchResult_UPDATE
+			if result.delete {
+				watchType = typed.KubeWatchResult_DELETE
+			}
+			key := typed.NewWatchTableKey(partitionId, result.kind, "ns", result.name, at(result.seconds))
+			err := tables.WatchTable().Set(txn, key.String(), &typed.KubeWatchResult{Kind: result.kind, WatchType: watchType, Payload: helper_cascadePayload(result.name, result.uid, result.deleting)})
+			if err != nil {
+				return err
+			}
+		}
+		for key, edge := range edges {
+			err := tables.OwnerGraphTable().Set(txn, key, edge)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+
+	values := helper_get_params()
+	values[KindParam] = []string{"Deployment"}
+	values[NamespaceParam] = []string{"ns"}
+	values[NameParam] = []string{"app"}
+	data, err := GetDeletionCascade(values, tables, at(-120), at(120), someRequestId)
+	assert.Nil(t, err)
+	output := DeletionCascadeOutput{}
+	assert.Nil(t, json.Unmarshal(data, &output))
+	assert.Equal(t, "deployUid", output.Uid)
+	assert.Equal(t, at(0).Unix(), output.DeletionRequested)
+	assert.Equal(t, at(20).Unix(), output.Deleted)
+	assert.Equal(t, 2, output.DeletedCount)
+	assert.Equal(t, 1, output.OrphanedCount)
+	assert.Equal(t, 1, output.RemainingCount)
+	assert.Equal(t, int64(15), output.CascadeSeconds)
+
+	assert.Len(t, output.Children, 1)
+	replicaSet := output.Children[0]
+	assert.Equal(t, CascadeStatusDeleted, replicaSet.Status)
+	assert.Equal(t, int64(-5), replicaSet.AfterOwner)
+	assert.Len(t, replicaSet.Children, 3)
+	assert.Equal(t, "app-1-a", replicaSet.Children[0].Name)
+	assert.Equal(t, CascadeStatusDeleted, replicaSet.Children[0].Status)
+	assert.Equal(t, int64(-5), replicaSet.Children[0].AfterOwner)
+	assert.Equal(t, CascadeStatusOrphaned, replicaSet.Children[1].Status)
+	assert.Equal(t, CascadeStatusRemaining, replicaSet.Children[2].Status)
+}
+
+func Test_GetDeletionCascade_NotDeleted(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+
+	values := helper_get_params()
+	values[KindParam] = []string{"Deployment"}
+	values[NameParam] = []string{"app"}
+	_, err = GetDeletionCascade(values, tables, someTs, someTs.Add(time.Minute), someRequestId)
+	assert.NotNil(t, err)
+
+	_, err = GetDeletionCascade(helper_get_params(), tables, someTs, someTs.Add(time.Minute), someRequestId)
+	assert.NotNil(t, err)
+}
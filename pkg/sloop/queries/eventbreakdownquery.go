@@ -0,0 +1,269 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+type EventBreakdownOutput struct {
+	Reason          string `json:"reason"`
+	Type            string `json:"type"`
+	MessageTemplate string `json:"messageTemplate"`
+	// Sum of the counts of the events
+	Count            int      `json:"count"`
+	Events           int      `json:"events"`
+	DistinctMessages int      `json:"distinctMessages"`
+	SampleMessage    string   `json:"sampleMessage"`
+	InvolvedKinds    []string `json:"involvedKinds"`
+	FirstTimestamp   int64    `json:"firstTimestamp"`
+	LastTimestamp    int64    `json:"lastTimestamp"`
+}
+
+type eventBreakdownKey struct {
+	reason          string
+	eventType       string
+	messageTemplate string
+}
+
+type eventBreakdownGroup struct {
+	output   EventBreakdownOutput
+	messages map[string]bool
+	kinds    map[string]bool
+}
+
+/*
+Groups the events of the time range by normalized reason, type and message template, so thousands of messages that
+only differ in pod names or IPs show up as a single row.  Events folded at ingest are counted from the event fold
+table.  Events stored before templates were added get theirs computed here.  Optionally limited to events of an
+involved object kind, largest count first
+*/
+func GetEventBreakdown(params url.Values, t typed.Tables, startTime time.Time, endTime time.Time, requestId string) ([]byte, error) {
+	selectedNamespace := defaultParam(params.Get(NamespaceParam), AllNamespaces)
+	selectedKind := defaultParam(params.Get(KindParam), AllKinds)
+
+	groups := map[eventBreakdownKey]*eventBreakdownGroup{}
+	add := func(reason string, eventType string, message string, template string, kind string, count int, first time.Time, last time.Time) {
+		if template == "" {
+			template = kubeextractor.NormalizeEventMessage(message)
+		}
+		key := eventBreakdownKey{reason: kubeextractor.NormalizeEventReason(reason), eventType: eventType, messageTemplate: template}
+		group, ok := groups[key]
+		if !ok {
+			group = &eventBreakdownGroup{
+				output:   EventBreakdownOutput{Reason: key.reason, Type: eventType, MessageTemplate: template, SampleMessage: message, FirstTimestamp: first.Unix(), LastTimestamp: last.Unix()},
+				messages: map[string]bool{},
+				kinds:    map[string]bool{},
+			}
+			groups[key] = group
+		}
+		if count < 1 {
+			count = 1
+		}
+		group.output.Count += count
+		group.output.Events++
+		group.messages[message] = true
+		group.kinds[kind] = true
+		if first.Unix() < group.output.FirstTimestamp {
+			group.output.FirstTimestamp = first.Unix()
+		}
+		if last.Unix() > group.output.LastTimestamp {
+			group.output.LastTimestamp = last.Unix()
+		}
+	}
+
+	err := t.Db().View(func(txn badgerwrap.Txn) error {
+		keyPredicate := func(key string) bool {
+			k := &typed.WatchTableKey{}
+			err := k.Parse(key)
+			if err != nil {
+				return false
+			}
+			return keepRowHelper(k.Name, k.Kind, k.Namespace, kubeextractor.EventKind, selectedNamespace, "", "", "", "")
+		}
+		records, stats, err := t.WatchTable().RangeRead(txn, getNamespacedKindKeyPrefix(kubeextractor.EventKind, selectedNamespace), keyPredicate, nil, startTime, endTime)
+		if err != nil {
+			return err
+		}
+		stats.Log(requestId)
+
+		// An event is updated each time it repeats, the last update in the time range has its total count
+		for _, eventRecords := range groupWatchRecords(records) {
+			var last *typed.KubeWatchResult
+			lastTs := int64(0)
+			for _, record := range eventRecords {
+				if record.timestamp < startTime.Unix() || record.timestamp > endTime.Unix() || record.result.WatchType == typed.KubeWatchResult_DELETE {
+					continue
+				}
+				if last == nil || record.timestamp > lastTs {
+					last = record.result
+					lastTs = record.timestamp
+				}
+			}
+			if last == nil {
+				continue
+			}
+			involvedObject, err := kubeextractor.ExtractInvolvedObject(last.Payload)
+			if err != nil {
+				glog.Errorf("Failed to extract involved object: %v", err)
+				continue
+			}
+			if selectedKind != AllKinds && involvedObject.Kind != selectedKind {
+				continue
+			}
+			eventInfo, err := kubeextractor.ExtractEventInfo(last.Payload)
+			if err != nil {
+				glog.Errorf("Failed to extract event info: %v", err)
+				continue
+			}
+			message, err := kubeextractor.ExtractEventMessage(last.Payload)
+			if err != nil {
+				glog.Errorf("Failed to extract event message: %v", err)
+				continue
+			}
+			first, lastSeen := eventInfo.FirstTimestamp, eventInfo.LastTimestamp
+			if first.IsZero() {
+				first = time.Unix(lastTs, 0)
+			}
+			if lastSeen.IsZero() {
+				lastSeen = time.Unix(lastTs, 0)
+			}
+			add(eventInfo.Reason, eventInfo.Type, message, last.MessageTemplate, involvedObject.Kind, eventInfo.Count, first, lastSeen)
+		}
+
+		foldPredicate := func(key string) bool {
+			k := &typed.EventFoldKey{}
+			err := k.Parse(key)
+			if err != nil {
+				return false
+			}
+			return (selectedNamespace == AllNamespaces || k.Namespace == selectedNamespace) && (selectedKind == AllKinds || k.Kind == selectedKind)
+		}
+		folds, stats, err := t.EventFoldTable().RangeRead(txn, nil, foldPredicate, nil, startTime, endTime)
+		if err != nil {
+			return err
+		}
+		stats.Log(requestId)
+
+		// The first event of a fold is in the watch table, the others only here
+		for key, fold := range folds {
+			for name, member := range fold.Events {
+				if name == fold.FirstEventName {
+					continue
+				}
+				first, err := ptypes.Timestamp(member.FirstTimestamp)
+				if err != nil {
+					continue
+				}
+				last, err := ptypes.Timestamp(member.LastTimestamp)
+				if err != nil {
+					continue
+				}
+				if last.Before(startTime) || first.After(endTime) {
+					continue
+				}
+				add(fold.Reason, fold.Type, fold.Message, fold.MessageTemplate, key.Kind, int(member.Count), first, last)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return []byte{}, err
+	}
+
+	output := []EventBreakdownOutput{}
+	for _, group := range groups {
+		group.output.DistinctMessages = len(group.messages)
+		group.output.InvolvedKinds = []string{}
+		for kind := range group.kinds {
+			group.output.InvolvedKinds = append(group.output.InvolvedKinds, kind)
+		}
+		sort.Strings(group.output.InvolvedKinds)
+		output = append(output, group.output)
+	}
+	sort.Slice(output, func(i, j int) bool {
+		if output[i].Count != output[j].Count {
+			return output[i].Count > output[j].Count
+		}
+		if output[i].Reason != output[j].Reason {
+			return output[i].Reason < output[j].Reason
+		}
+		return output[i].MessageTemplate < output[j].MessageTemplate
+	})
+	bytes, err := json.MarshalIndent(output, "", " ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal json %v", err)
+	}
+	return bytes, nil
+}
+
+// Groups of several shards with the same reason, type and template add up.  Distinct messages of each shard can be
+// the same ones, so the largest of them is kept
+func mergeEventBreakdowns(params url.Values, results [][]byte, shardErrors []ShardErrorOutput) ([]byte, error) {
+	groups := map[eventBreakdownKey]*EventBreakdownOutput{}
+	output := []*EventBreakdownOutput{}
+	for idx, result := range results {
+		var rows []EventBreakdownOutput
+		err := json.Unmarshal(result, &rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal event breakdown from shard result %v: %v", idx, err)
+		}
+		for _, row := range rows {
+			row := row
+			key := eventBreakdownKey{reason: row.Reason, eventType: row.Type, messageTemplate: row.MessageTemplate}
+			group, ok := groups[key]
+			if !ok {
+				groups[key] = &row
+				output = append(output, &row)
+				continue
+			}
+			group.Count += row.Count
+			group.Events += row.Events
+			if row.DistinctMessages > group.DistinctMessages {
+				group.DistinctMessages = row.DistinctMessages
+			}
+			if row.FirstTimestamp < group.FirstTimestamp {
+				group.FirstTimestamp = row.FirstTimestamp
+			}
+			if row.LastTimestamp > group.LastTimestamp {
+				group.LastTimestamp = row.LastTimestamp
+			}
+			for _, kind := range row.InvolvedKinds {
+				if !common.Contains(group.InvolvedKinds, kind) {
+					group.InvolvedKinds = append(group.InvolvedKinds, kind)
+				}
+			}
+			sort.Strings(group.InvolvedKinds)
+		}
+	}
+	sort.Slice(output, func(i, j int) bool {
+		if output[i].Count != output[j].Count {
+			return output[i].Count > output[j].Count
+		}
+		if output[i].Reason != output[j].Reason {
+			return output[i].Reason < output[j].Reason
+		}
+		return output[i].MessageTemplate < output[j].MessageTemplate
+	})
+	bytes, err := json.MarshalIndent(output, "", " ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal json %v", err)
+	}
+	return bytes, nil
+}
@@ -0,0 +1,84 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+func helper_breakdownEventPayload(kind string, name string, reason string, message string, count int) string {
+	return fmt.Sprintf(`{"involvedObject":{"kind":"%v","name":"%v"},"reason":"%v","message":"%v","firstTimestamp":"2019-03-04T03:10:00Z","lastTimestamp":"2019-03-04T03:12:00Z","count":%v,"type":"Warning"}`,
+		kind, name, reason, message, count)
+}
+
+func Test_GetEventBreakdown(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	partitionId := untyped.GetPartitionId(someTs)
+	helper_setWatchResults(t, tables, map[*typed.WatchTableKey]string{
+		typed.NewWatchTableKey(partitionId, "Event", "some-namespace", "web-7d9f8c6b5-x2k4q.1", someTs.Add(time.Minute)): helper_breakdownEventPayload("Pod", "web-7d9f8c6b5-x2k4q", "BackOff", "Back-off restarting failed container in pod web-7d9f8c6b5-x2k4q", 1),
+		// A later update of the same event has its total count
+		typed.NewWatchTableKey(partitionId, "Event", "some-namespace", "web-7d9f8c6b5-x2k4q.1", someTs.Add(2*time.Minute)): helper_breakdownEventPayload("Pod", "web-7d9f8c6b5-x2k4q", "BackOff", "Back-off restarting failed container in pod web-7d9f8c6b5-x2k4q", 4),
+		typed.NewWatchTableKey(partitionId, "Event", "some-namespace", "web-7d9f8c6b5-zq8rt.1", someTs.Add(3*time.Minute)): helper_breakdownEventPayload("Pod", "web-7d9f8c6b5-zq8rt", "back-off", "Back-off restarting failed container in pod web-7d9f8c6b5-zq8rt", 2),
+		typed.NewWatchTableKey(partitionId, "Event", "some-namespace", "web.1", someTs.Add(4*time.Minute)):                 helper_breakdownEventPayload("Deployment", "web", "ScalingReplicaSet", "Scaled up replica set web-7d9f8c6b5 to 3", 1),
+		typed.NewWatchTableKey(partitionId, "Event", "other-namespace", "db.1", someTs.Add(4*time.Minute)):                 helper_breakdownEventPayload("Pod", "db", "BackOff", "Back-off restarting failed container in pod db", 1),
+	})
+
+	first, _ := ptypes.TimestampProto(someTs.Add(5 * time.Minute))
+	last, _ := ptypes.TimestampProto(someTs.Add(6 * time.Minute))
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		key := typed.NewEventFoldKey(partitionId, "Pod", "some-namespace", "web-7d9f8c6b5-bxzkq", "BackOff", "somehash", someTs.Add(5*time.Minute))
+		return tables.EventFoldTable().Set(txn, key.String(), &typed.EventFold{
+			Reason:          "BackOff",
+			Type:            "Warning",
+			Message:         "Back-off restarting failed container in pod web-7d9f8c6b5-bxzkq",
+			MessageTemplate: "Back-off restarting failed container in pod web-<hash>",
+			FirstEventName:  "web-7d9f8c6b5-bxzkq.1",
+			Events: map[string]*typed.FoldedEvent{
+				"web-7d9f8c6b5-bxzkq.1": {FirstTimestamp: first, LastTimestamp: last, Count: 1},
+				"web-7d9f8c6b5-bxzkq.2": {FirstTimestamp: first, LastTimestamp: last, Count: 3},
+			},
+		})
+	})
+	assert.Nil(t, err)
+
+	data, err := GetEventBreakdown(helper_get_params(), tables, someTs, someTs.Add(time.Hour), someRequestId)
+	assert.Nil(t, err)
+	output := []EventBreakdownOutput{}
+	assert.Nil(t, json.Unmarshal(data, &output))
+	assert.Len(t, output, 2)
+
+	backOff := output[0]
+	assert.Equal(t, "BackOff", backOff.Reason)
+	assert.Equal(t, "Back-off restarting failed container in pod web-<hash>", backOff.MessageTemplate)
+	assert.Equal(t, 9, backOff.Count)
+	assert.Equal(t, 3, backOff.Events)
+	assert.Equal(t, 3, backOff.DistinctMessages)
+	assert.Equal(t, []string{"Pod"}, backOff.InvolvedKinds)
+	assert.Equal(t, "Scaled up replica set web-<hash> to <n>", output[1].MessageTemplate)
+
+	values := helper_get_params()
+	values[KindParam] = []string{"Deployment"}
+	data, err = GetEventBreakdown(values, tables, someTs, someTs.Add(time.Hour), someRequestId)
+	assert.Nil(t, err)
+	assert.Nil(t, json.Unmarshal(data, &output))
+	assert.Len(t, output, 1)
+	assert.Equal(t, "ScalingReplicaSet", output[0].Reason)
+}
@@ -10,6 +10,7 @@ package queries
 import (
 	"encoding/json"
 	"fmt"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
 	"github.com/salesforce/sloop/pkg/sloop/store/typed"
 	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
@@ -30,33 +31,19 @@ type EventOutput struct {
 	WatchType      typed.KubeWatchResult_WatchType `json:"watchType,omitempty"`
 	Payload        string                          `json:"payload,omitempty"`
 	EventKey       string                          `json:"eventKey"`
+	// Number of event objects folded into this one at ingest, including itself, see EventFold
+	FoldedEvents int `json:"foldedEvents,omitempty"`
 }
 
+// Only the first event of a fold is in the watch table, so its last copy gets the count and first and last timestamps
+// of the whole fold, the same as if kubernetes had kept updating one event
 func GetEventData(params url.Values, t typed.Tables, startTime time.Time, endTime time.Time, requestId string) ([]byte, error) {
 	var watchEvents map[typed.WatchTableKey]*typed.KubeWatchResult
+	var folds map[typed.EventFoldKey]*typed.EventFold
 	err := t.Db().View(func(txn badgerwrap.Txn) error {
 		var err2 error
 		var stats typed.RangeReadStats
-		selectedNamespace := params.Get(NamespaceParam)
-		selectedName := params.Get(NameParam)
-		selectedKind := params.Get(KindParam)
-
-		// Events are stored with metadata name which are like InvolvedObjectName.XXXX
-		// To ensure we only get events for this resource. Add a '.' delimiter in the end.
-		selectedName = selectedName + "."
-
-		if kubeextractor.IsClustersScopedResource(selectedKind) {
-			selectedNamespace = DefaultNamespace
-		}
-
-		key := &typed.WatchTableKey{
-			// partition id will be rest, it is ok to leave it as empty string
-			PartitionId: "",
-			Kind:        kubeextractor.EventKind,
-			Namespace:   selectedNamespace,
-			Name:        selectedName,
-			Timestamp:   time.Time{},
-		}
+		key := getEventKeyPrefix(params)
 
 		// pass a few valPredFn filters: payload in time range and payload kind matched
 		valPredFn := typed.KubeWatchResult_ValPredicateFns(isEventValInTimeRange(startTime, endTime), matchEventInvolvedObject(params))
@@ -65,11 +52,28 @@ func GetEventData(params url.Values, t typed.Tables, startTime time.Time, endTim
 			return err2
 		}
 		stats.Log(requestId)
+
+		folds, stats, err2 = t.EventFoldTable().RangeRead(txn, nil, eventFoldPredicate(params), nil, startTime, endTime)
+		if err2 != nil {
+			return err2
+		}
+		stats.Log(requestId)
 		return nil
 	})
 	if err != nil {
 		return []byte{}, err
 	}
+	foldsByFirstEvent := map[string]*typed.EventFold{}
+	for _, fold := range folds {
+		foldsByFirstEvent[fold.FirstEventName] = fold
+	}
+	lastCopies := map[string]time.Time{}
+	for key := range watchEvents {
+		if _, ok := foldsByFirstEvent[key.Name]; ok && key.Timestamp.After(lastCopies[key.Name]) {
+			lastCopies[key.Name] = key.Timestamp
+		}
+	}
+
 	var res EventsData
 	eventsList := []EventOutput{}
 	for key, val := range watchEvents {
@@ -83,6 +87,13 @@ func GetEventData(params url.Values, t typed.Tables, startTime time.Time, endTim
 			Payload:        val.Payload,
 			EventKey:       key.String(),
 		}
+		if fold, ok := foldsByFirstEvent[key.Name]; ok && key.Timestamp.Equal(lastCopies[key.Name]) {
+			output.Payload, err = foldEventPayload(val.Payload, fold)
+			if err != nil {
+				return nil, err
+			}
+			output.FoldedEvents = len(fold.Events)
+		}
 		eventsList = append(eventsList, output)
 	}
 
@@ -96,3 +107,63 @@ func GetEventData(params url.Values, t typed.Tables, startTime time.Time, endTim
 	}
 	return bytes, nil
 }
+
+// Folds are keyed by the involved object, like the params of GetEventData
+func eventFoldPredicate(params url.Values) func(string) bool {
+	selectedKind := params.Get(KindParam)
+	selectedNamespace := kubeextractor.NormalizeNamespace(selectedKind, params.Get(NamespaceParam))
+	selectedName := params.Get(NameParam)
+	return func(key string) bool {
+		k := &typed.EventFoldKey{}
+		err := k.Parse(key)
+		if err != nil {
+			return false
+		}
+		return k.Kind == selectedKind && k.Namespace == selectedNamespace && k.Name == selectedName
+	}
+}
+
+func foldEventPayload(payload string, fold *typed.EventFold) (string, error) {
+	first, err := ptypes.Timestamp(fold.FirstTimestamp)
+	if err != nil {
+		return "", err
+	}
+	last, err := ptypes.Timestamp(fold.LastTimestamp)
+	if err != nil {
+		return "", err
+	}
+	event := map[string]interface{}{}
+	err = json.Unmarshal([]byte(payload), &event)
+	if err != nil {
+		return "", fmt.Errorf("failed to unmarshal event payload: %v", err)
+	}
+	event["count"] = fold.Count
+	event["firstTimestamp"] = first.UTC().Format(time.RFC3339)
+	event["lastTimestamp"] = last.UTC().Format(time.RFC3339)
+	folded, err := json.Marshal(event)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal event payload: %v", err)
+	}
+	return string(folded), nil
+}
+
+func getEventKeyPrefix(params url.Values) *typed.WatchTableKey {
+	selectedNamespace := params.Get(NamespaceParam)
+	selectedName := params.Get(NameParam)
+	selectedKind := params.Get(KindParam)
+
+	// Events are stored with metadata name which are like InvolvedObjectName.XXXX
+	// To ensure we only get events for this resource. Add a '.' delimiter in the end.
+	selectedName = selectedName + "."
+
+	selectedNamespace = kubeextractor.GetEventNamespace(selectedKind, selectedNamespace)
+
+	return &typed.WatchTableKey{
+		// partition id will be rest, it is ok to leave it as empty string
+		PartitionId: "",
+		Kind:        kubeextractor.EventKind,
+		Namespace:   selectedNamespace,
+		Name:        selectedName,
+		Timestamp:   time.Time{},
+	}
+}
@@ -8,7 +8,10 @@
 package queries
 
 import (
+	"encoding/json"
+	"fmt"
 	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/salesforce/sloop/pkg/sloop/store/typed"
 	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
 	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
@@ -241,3 +244,89 @@ func Test_GetEventData_True_HasSamePrefix(t *testing.T) {
 ]`
 	assertex.JsonEqual(t, expectedRes, string(res))
 }
+
+func Test_GetEventData_Uuid(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	partitionId := untyped.GetPartitionId(someTs)
+	values := helper_get_params()
+	values[KindParam] = []string{"Pod"}
+	values[NamespaceParam] = []string{"someNamespace"}
+	values[NameParam] = []string{"someName"}
+	values[UuidParam] = []string{"newUid"}
+
+	payload := `{"involvedObject":{"kind":"Pod","namespace":"someNamespace","name":"%v","uid":"%v"},"firstTimestamp":"2019-01-02T03:00:00Z","lastTimestamp":"2019-01-02T03:10:00Z"}`
+	events := map[string]*typed.KubeWatchResult{
+		"someName.old":      {Kind: "Event", Payload: fmt.Sprintf(payload, "someName", "oldUid")},
+		"someName.new":      {Kind: "Event", Payload: fmt.Sprintf(payload, "someName", "newUid")},
+		"someName.linked":   {Kind: "Event", Payload: fmt.Sprintf(payload, "someName", ""), InvolvedUid: "newUid"},
+		"someName.other":    {Kind: "Event", Payload: fmt.Sprintf(payload, "someName", ""), InvolvedUid: "oldUid"},
+		"someName.x.dotted": {Kind: "Event", Payload: fmt.Sprintf(payload, "someName.x", "newUid")},
+	}
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		for name, result := range events {
+			err := tables.WatchTable().Set(txn, typed.NewWatchTableKey(partitionId, "Event", "someNamespace", name, someTs).String(), result)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+
+	res, err := GetEventData(values, tables, someTs.Add(-1*time.Hour), someTs.Add(time.Hour), someRequestId)
+	assert.Nil(t, err)
+	output := []EventOutput{}
+	assert.Nil(t, json.Unmarshal(res, &output))
+	names := []string{}
+	for _, event := range output {
+		names = append(names, event.Name)
+	}
+	assert.ElementsMatch(t, []string{"someName.new", "someName.linked"}, names)
+}
+
+func Test_GetEventData_MergesEventFold(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	partitionId := untyped.GetPartitionId(someTs)
+	values := helper_get_params()
+	values[KindParam] = []string{"Pod"}
+	values[NamespaceParam] = []string{"someNamespace"}
+	values[NameParam] = []string{"someName"}
+	payload := `{"involvedObject":{"kind":"Pod","namespace":"someNamespace","name":"someName"},"reason":"BackOff",` +
+		`"firstTimestamp":"2019-01-02T03:00:00Z","lastTimestamp":"2019-01-02T03:01:00Z","count":2}`
+	firstCopy := typed.NewWatchTableKey(partitionId, "Event", "someNamespace", "someName.aa", someTs.Add(-time.Minute)).String()
+	lastCopy := typed.NewWatchTableKey(partitionId, "Event", "someNamespace", "someName.aa", someTs).String()
+	tables := helper_get_k8Watchtable([]string{firstCopy, lastCopy}, t, payload)
+
+	foldStart := time.Date(2019, 1, 2, 3, 0, 0, 0, time.UTC)
+	foldFirst, _ := ptypes.TimestampProto(foldStart)
+	foldLast, _ := ptypes.TimestampProto(time.Date(2019, 1, 2, 3, 30, 0, 0, time.UTC))
+	fold := &typed.EventFold{Reason: "BackOff", FirstTimestamp: foldFirst, LastTimestamp: foldLast, Count: 12, FirstEventName: "someName.aa",
+		Events: map[string]*typed.FoldedEvent{"someName.aa": {Count: 2}, "someName.bb": {Count: 10}}}
+	foldKey := typed.NewEventFoldKey(partitionId, "Pod", "someNamespace", "someName", "BackOff", "abc", foldStart).String()
+	err := tables.Db().Update(func(txn badgerwrap.Txn) error {
+		return tables.EventFoldTable().Set(txn, foldKey, fold)
+	})
+	assert.Nil(t, err)
+
+	res, err := GetEventData(values, tables, someTs.Add(-time.Hour), someTs.Add(time.Hour), someRequestId)
+	assert.Nil(t, err)
+	var output []EventOutput
+	assert.Nil(t, json.Unmarshal(res, &output))
+	assert.Len(t, output, 2)
+	for _, event := range output {
+		eventPayload := map[string]interface{}{}
+		assert.Nil(t, json.Unmarshal([]byte(event.Payload), &eventPayload))
+		if event.WatchTimestamp.Equal(someTs) {
+			assert.Equal(t, 2, event.FoldedEvents)
+			assert.Equal(t, float64(12), eventPayload["count"])
+			assert.Equal(t, "2019-01-02T03:00:00Z", eventPayload["firstTimestamp"])
+			assert.Equal(t, "2019-01-02T03:30:00Z", eventPayload["lastTimestamp"])
+		} else {
+			assert.Equal(t, 0, event.FoldedEvents)
+			assert.Equal(t, float64(2), eventPayload["count"])
+		}
+	}
+}
@@ -0,0 +1,226 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+const (
+	// Without a granularity param the finest one with at most this many buckets is used
+	maxAutoTrendBuckets = 100
+	maxTrendBuckets     = 10080
+)
+
+type EventTrendOutput struct {
+	Granularity string             `json:"granularity"`
+	Buckets     []EventTrendBucket `json:"buckets"`
+	Total       int                `json:"total"`
+	// Keys read from the event count table and each rollup table, by granularity
+	KeysRead map[string]int `json:"keysRead"`
+}
+
+type EventTrendBucket struct {
+	Start int64 `json:"start"`
+	End   int64 `json:"end"`
+	Count int   `json:"count"`
+	// Keyed by reason:type, like the event count table
+	Reasons map[string]int `json:"reasons"`
+}
+
+/*
+Counts the events about the resources matching namespace, kind and namematch in buckets of a minute, an hour or a
+day.  Whole days of the time range are read from the day rollups and whole hours from the hour rollups, only the
+ragged ends are read at a finer granularity, so a week reads a few keys per resource instead of every minute.  The
+time range starts no earlier than the oldest partition, since rollups of older buckets are gone with it
+*/
+func GetEventTrend(params url.Values, t typed.Tables, startTime time.Time, endTime time.Time, requestId string) ([]byte, error) {
+	selectedNamespace := defaultParam(params.Get(NamespaceParam), AllNamespaces)
+	selectedKind := defaultParam(params.Get(KindParam), AllKinds)
+	selectedNameMatch := params.Get(NameMatchParam)
+	keep := func(k *typed.EventCountKey, err error) bool {
+		if err != nil {
+			return false
+		}
+		return keepRowHelper(k.Name, k.Kind, k.Namespace, selectedKind, selectedNamespace, selectedNameMatch, "", "", "")
+	}
+	eventCountPredicate := func(key string) bool {
+		k := &typed.EventCountKey{}
+		return keep(k, k.Parse(key))
+	}
+
+	startTime, endTime = startTime.UTC(), endTime.UTC()
+	ok, minPartition, _, err := t.GetMinAndMaxPartition()
+	if err != nil {
+		return []byte{}, err
+	}
+	if ok {
+		oldest, err := untyped.GetTimeForPartition(minPartition)
+		if err == nil && startTime.Before(oldest) {
+			startTime = oldest.UTC()
+		}
+	}
+
+	granularity, err := eventTrendGranularity(params.Get(GranularityParam), startTime, endTime)
+	if err != nil {
+		return []byte{}, err
+	}
+	bucket := typed.EventRollupBucket(granularity)
+	output := EventTrendOutput{Granularity: granularity, Buckets: []EventTrendBucket{}, KeysRead: map[string]int{}}
+	bucketIndex := map[int64]int{}
+	for ts := typed.EventRollupBucketStart(granularity, startTime); ts.Before(endTime); ts = ts.Add(bucket) {
+		bucketIndex[ts.Unix()] = len(output.Buckets)
+		output.Buckets = append(output.Buckets, EventTrendBucket{Start: ts.Unix(), End: ts.Add(bucket).Unix(), Reasons: map[string]int{}})
+	}
+	add := func(records map[typed.EventCountKey]*typed.ResourceEventCounts, from time.Time, to time.Time) {
+		for _, record := range records {
+			for unixTime, counts := range record.MapMinToEvents {
+				if unixTime < from.Unix() || unixTime >= to.Unix() {
+					continue
+				}
+				idx, ok := bucketIndex[typed.EventRollupBucketStart(granularity, time.Unix(unixTime, 0)).Unix()]
+				if !ok {
+					continue
+				}
+				for reasonKey, count := range counts.MapReasonToCount {
+					output.Buckets[idx].Count += int(count)
+					output.Buckets[idx].Reasons[reasonKey] += int(count)
+					output.Total += int(count)
+				}
+			}
+		}
+	}
+
+	err = t.Db().View(func(txn badgerwrap.Txn) error {
+		// Reads [from, to) from the rollups of the granularity at level, and the parts that are not whole buckets
+		// of it from the finer ones
+		var collect func(level int, from time.Time, to time.Time) error
+		collect = func(level int, from time.Time, to time.Time) error {
+			if !from.Before(to) {
+				return nil
+			}
+			levelGranularity := typed.EventRollupGranularities[level]
+			table := typed.OpenEventRollupTable(levelGranularity)
+			if table == nil {
+				records, stats, err := t.EventCountTable().RangeRead(txn, nil, eventCountPredicate, nil, from, to.Add(-time.Nanosecond))
+				if err != nil {
+					return err
+				}
+				stats.Log(requestId)
+				output.KeysRead[levelGranularity] += len(records)
+				add(records, from, to)
+				return nil
+			}
+
+			first := typed.EventRollupBucketStart(levelGranularity, from)
+			if first.Before(from) {
+				first = first.Add(typed.EventRollupBucket(levelGranularity))
+			}
+			last := typed.EventRollupBucketStart(levelGranularity, to)
+			if !first.Before(last) {
+				return collect(level-1, from, to)
+			}
+			err := collect(level-1, from, first)
+			if err != nil {
+				return err
+			}
+			rollupPredicate := func(key string) bool {
+				return keep(table.ParseKey(key))
+			}
+			records, stats, err := table.RangeRead(txn, rollupPredicate, first, last.Add(-time.Nanosecond))
+			if err != nil {
+				return err
+			}
+			stats.Log(requestId)
+			output.KeysRead[levelGranularity] += len(records)
+			add(records, first, last)
+			return collect(level-1, last, to)
+		}
+		for level, levelGranularity := range typed.EventRollupGranularities {
+			if levelGranularity == granularity {
+				return collect(level, startTime, endTime)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return []byte{}, err
+	}
+
+	bytes, err := json.MarshalIndent(output, "", " ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal json %v", err)
+	}
+	return bytes, nil
+}
+
+// The granularity of the param, or when empty the finest one with at most maxAutoTrendBuckets buckets
+func eventTrendGranularity(param string, startTime time.Time, endTime time.Time) (string, error) {
+	if param != "" {
+		bucket := typed.EventRollupBucket(param)
+		if bucket == 0 {
+			return "", NewApiError(ErrorCodeBadParams, "invalid %v %q, must be one of %v", GranularityParam, param, typed.EventRollupGranularities)
+		}
+		if endTime.Sub(startTime)/bucket > maxTrendBuckets {
+			return "", NewApiError(ErrorCodeRangeTooLarge, "more than %v buckets of a %v, use a coarser %v", maxTrendBuckets, param, GranularityParam)
+		}
+		return param, nil
+	}
+	for _, granularity := range typed.EventRollupGranularities {
+		if endTime.Sub(startTime)/typed.EventRollupBucket(granularity) <= maxAutoTrendBuckets {
+			return granularity, nil
+		}
+	}
+	return typed.EventRollupGranularities[len(typed.EventRollupGranularities)-1], nil
+}
+
+// Every shard has the same buckets for the same params, so their counts add up
+func mergeEventTrends(params url.Values, results [][]byte, shardErrors []ShardErrorOutput) ([]byte, error) {
+	var merged EventTrendOutput
+	bucketIndex := map[int64]int{}
+	for idx, result := range results {
+		var trend EventTrendOutput
+		err := json.Unmarshal(result, &trend)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal event trend from shard result %v: %v", idx, err)
+		}
+		if idx == 0 {
+			merged = EventTrendOutput{Granularity: trend.Granularity, Buckets: []EventTrendBucket{}, KeysRead: map[string]int{}}
+		}
+		for _, bucket := range trend.Buckets {
+			existing, ok := bucketIndex[bucket.Start]
+			if !ok {
+				bucketIndex[bucket.Start] = len(merged.Buckets)
+				merged.Buckets = append(merged.Buckets, EventTrendBucket{Start: bucket.Start, End: bucket.End, Reasons: map[string]int{}})
+				existing = len(merged.Buckets) - 1
+			}
+			merged.Buckets[existing].Count += bucket.Count
+			for reasonKey, count := range bucket.Reasons {
+				merged.Buckets[existing].Reasons[reasonKey] += count
+			}
+		}
+		merged.Total += trend.Total
+		for granularity, keys := range trend.KeysRead {
+			merged.KeysRead[granularity] += keys
+		}
+	}
+	sort.Slice(merged.Buckets, func(i, j int) bool { return merged.Buckets[i].Start < merged.Buckets[j].Start })
+	bytes, err := json.MarshalIndent(merged, "", " ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal json %v", err)
+	}
+	return bytes, nil
+}
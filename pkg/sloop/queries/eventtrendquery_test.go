@@ -0,0 +1,127 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+// Adds the count to the minute in the event count table and to its hour and day rollups, like processing does
+func helper_addEventTrendMinute(t *testing.T, tables typed.Tables, txn badgerwrap.Txn, minute time.Time, count int32) {
+	add := func(record *typed.ResourceEventCounts, bucket int64) {
+		if _, ok := record.MapMinToEvents[bucket]; !ok {
+			record.MapMinToEvents[bucket] = &typed.EventCounts{MapReasonToCount: map[string]int32{}}
+		}
+		record.MapMinToEvents[bucket].MapReasonToCount["BackOff:Warning"] += count
+	}
+	key := typed.NewEventCountKey(minute, "Pod", "ns", "pod1", "uid1").String()
+	record, err := tables.EventCountTable().GetOrDefault(txn, key)
+	assert.Nil(t, err)
+	add(record, minute.Unix())
+	assert.Nil(t, tables.EventCountTable().Set(txn, key, record))
+	for _, table := range typed.EventRollupTables() {
+		key := table.Key(minute, "Pod", "ns", "pod1", "uid1")
+		record, err := table.GetOrDefault(txn, key)
+		assert.Nil(t, err)
+		add(record, typed.EventRollupBucketStart(table.Granularity(), minute).Unix())
+		assert.Nil(t, table.Set(txn, key, record))
+	}
+}
+
+func helper_getEventTrend(t *testing.T, tables typed.Tables, granularity string, start time.Time, end time.Time) EventTrendOutput {
+	params := url.Values{}
+	if granularity != "" {
+		params.Set(GranularityParam, granularity)
+	}
+	res, err := GetEventTrend(params, tables, start, end, someRequestId)
+	assert.Nil(t, err)
+	output := EventTrendOutput{}
+	assert.Nil(t, json.Unmarshal(res, &output))
+	return output
+}
+
+func Test_GetEventTrend(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	dayStart := time.Date(2021, 3, 4, 0, 0, 0, 0, time.UTC)
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		for minute := dayStart; minute.Before(dayStart.Add(72 * time.Hour)); minute = minute.Add(10 * time.Minute) {
+			helper_addEventTrendMinute(t, tables, txn, minute, 1)
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+
+	// Whole days come from the day rollups, the rest of the first day from the hour and minute counts
+	start := dayStart.Add(6*time.Hour + 5*time.Minute)
+	end := dayStart.Add(72 * time.Hour)
+	days := helper_getEventTrend(t, tables, typed.EventRollupDay, start, end)
+	assert.Equal(t, typed.EventRollupDay, days.Granularity)
+	assert.Len(t, days.Buckets, 3)
+	assert.Equal(t, 107, days.Buckets[0].Count)
+	assert.Equal(t, 144, days.Buckets[1].Count)
+	assert.Equal(t, 144, days.Buckets[2].Count)
+	assert.Equal(t, 144, days.Buckets[2].Reasons["BackOff:Warning"])
+	assert.Equal(t, 395, days.Total)
+	assert.Equal(t, map[string]int{typed.EventRollupMinute: 1, typed.EventRollupHour: 17, typed.EventRollupDay: 2}, days.KeysRead)
+
+	minutes := helper_getEventTrend(t, tables, typed.EventRollupMinute, start, end)
+	assert.Equal(t, days.Total, minutes.Total)
+	assert.Equal(t, 66*60-5, len(minutes.Buckets))
+
+	// 66 hours are few enough buckets
+	auto := helper_getEventTrend(t, tables, "", start, end)
+	assert.Equal(t, typed.EventRollupHour, auto.Granularity)
+	assert.Equal(t, days.Total, auto.Total)
+	assert.Equal(t, 5, auto.Buckets[0].Count)
+	assert.Equal(t, 6, auto.Buckets[1].Count)
+}
+
+func Test_GetEventTrend_StartsAtTheOldestPartition(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	oldest := time.Date(2021, 3, 4, 10, 0, 0, 0, time.UTC)
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		helper_addEventTrendMinute(t, tables, txn, oldest.Add(30*time.Minute), 3)
+		return nil
+	})
+	assert.Nil(t, err)
+
+	output := helper_getEventTrend(t, tables, typed.EventRollupDay, oldest.Add(-48*time.Hour), oldest.Add(time.Hour))
+	assert.Len(t, output.Buckets, 1)
+	assert.Equal(t, oldest.Truncate(24*time.Hour).Unix(), output.Buckets[0].Start)
+	assert.Equal(t, 3, output.Total)
+	assert.Equal(t, 0, output.KeysRead[typed.EventRollupDay])
+}
+
+func Test_GetEventTrend_BadGranularity(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	now := time.Date(2021, 3, 4, 10, 0, 0, 0, time.UTC)
+
+	_, err = GetEventTrend(url.Values{GranularityParam: []string{"week"}}, tables, now.Add(-time.Hour), now, someRequestId)
+	assert.Equal(t, ErrorCodeBadParams, ErrorCodeOf(err))
+	_, err = GetEventTrend(url.Values{GranularityParam: []string{typed.EventRollupMinute}}, tables, now.Add(-30*24*time.Hour), now, someRequestId)
+	assert.Equal(t, ErrorCodeRangeTooLarge, ErrorCodeOf(err))
+}
@@ -0,0 +1,595 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+// Explain describes how a query would read the store without running it.  Row estimates come from the key counts of
+// the LSM tables whose key range overlaps each scanned prefix, so they are an upper bound and do not include writes
+// that are still in the memtable.
+
+type ExplainScan struct {
+	Table                string   `json:"table"`
+	Partitions           []string `json:"partitions"`
+	KeyRanges            []string `json:"keyRanges"`
+	KeyPredicate         string   `json:"keyPredicate,omitempty"`
+	ValuePredicate       string   `json:"valuePredicate,omitempty"`
+	EstimatedRowsVisited uint64   `json:"estimatedRowsVisited"`
+}
+
+type ExplainOutput struct {
+	Query                string        `json:"query"`
+	StartTime            time.Time     `json:"startTime"`
+	EndTime              time.Time     `json:"endTime"`
+	Scans                []ExplainScan `json:"scans"`
+	EstimatedRowsVisited uint64        `json:"estimatedRowsVisited"`
+	Notes                []string      `json:"notes,omitempty"`
+}
+
+type scanPlan struct {
+	table string
+	// Returns the seek prefix for a partition.  Nil means the whole partition is scanned
+	keyPrefix      func(partitionId string) string
+	keyPredicate   string
+	valuePredicate string
+}
+
+type queryPlanner = func(params url.Values, startTime time.Time, endTime time.Time) ([]scanPlan, []string)
+
+// Keep this in sync with the RangeRead calls made by each query in funcMap
+var explainMap = map[string]queryPlanner{
+	"EventHeatMap":              explainEventHeatMap,
+	"GetEventData":              explainGetEventData,
+	"GetResPayload":             explainGetResPayload,
+	"Namespaces":                explainNamespaces,
+	"Kinds":                     explainKinds,
+	"Queries":                   explainQueries,
+	"GetResSummaryData":         explainGetResSummaryData,
+	"GetPodLifecycle":           explainGetPodLifecycle,
+	"GetNodeHealth":             explainGetNodeHealth,
+	"GetOwnerTree":              explainGetOwnerTree,
+	"GetServiceBackends":        explainGetServiceBackends,
+	"GetVolumeBinding":          explainGetVolumeBinding,
+	"GetCurrentState":           explainGetCurrentState,
+	"GetNamespaceEpochs":        explainGetNamespaceEpochs,
+	"GetIngestAnnotations":      explainGetIngestAnnotations,
+	"GetFlappingResources":      explainGetFlappingResources,
+	"GetQuotaUtilization":       explainGetQuotaUtilization,
+	"GetCredentialUsage":        explainGetCredentialUsage,
+	"GetNetworkReferences":      explainGetNetworkReferences,
+	"GetDeploymentRollouts":     explainGetDeploymentRollouts,
+	"GetConfigImpact":           explainGetConfigImpact,
+	"GetEventBreakdown":         explainGetEventBreakdown,
+	"GetSnapshotDiff":           explainGetSnapshotDiff,
+	"GetApiVersionMigrations":   explainGetApiVersionMigrations,
+	"GetWriterConflicts":        explainGetWriterConflicts,
+	"GetNamespaceComparison":    explainGetNamespaceComparison,
+	"GetSelfFootprint":          explainGetSelfFootprint,
+	"GetApiServiceAvailability": explainGetApiServiceAvailability,
+	"GetEventTrend":             explainGetEventTrend,
+	"GetDeletionCascade":        explainGetDeletionCascade,
+	"GetNodePoolPods":           explainGetNodePoolPods,
+	"GetResourceCounts":         explainGetResourceCounts,
+}
+
+func IsExplain(params url.Values) bool {
+	return params.Get(ExplainParam) == "true"
+}
+
+func explainQuery(queryName string, params url.Values, tables typed.Tables, startTime time.Time, endTime time.Time) ([]byte, error) {
+	planner, ok := explainMap[queryName]
+	if !ok {
+		return []byte{}, NewApiError(ErrorCodeBadParams, "Explain not supported for query: %v", queryName)
+	}
+	plans, notes := planner(params, startTime, endTime)
+
+	output := ExplainOutput{Query: queryName, StartTime: startTime, EndTime: endTime, Scans: []ExplainScan{}, Notes: notes}
+	lsmTables := tables.Db().Tables(true)
+	err := tables.Db().View(func(txn badgerwrap.Txn) error {
+		// All tables share the same partitioning scheme, so this is the partition list every RangeRead will use
+		partitions, err := tables.WatchTable().GetPartitionsFromTimeRange(txn, startTime, endTime)
+		if err != nil {
+			return err
+		}
+		for _, plan := range plans {
+			scan := ExplainScan{
+				Table:          plan.table,
+				Partitions:     partitions,
+				KeyRanges:      []string{},
+				KeyPredicate:   plan.keyPredicate,
+				ValuePredicate: plan.valuePredicate,
+			}
+			for _, partitionId := range partitions {
+				seekStr := "/" + plan.table + "/" + partitionId + "/"
+				if plan.keyPrefix != nil {
+					seekStr = plan.keyPrefix(partitionId)
+				}
+				scan.KeyRanges = append(scan.KeyRanges, seekStr)
+				scan.EstimatedRowsVisited += estimateRowsForPrefix(lsmTables, []byte(seekStr))
+			}
+			output.EstimatedRowsVisited += scan.EstimatedRowsVisited
+			output.Scans = append(output.Scans, scan)
+		}
+		return nil
+	})
+	if err != nil {
+		return []byte{}, err
+	}
+
+	bytes, err := json.MarshalIndent(output, "", " ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal json %v", err)
+	}
+	return bytes, nil
+}
+
+func estimateRowsForPrefix(lsmTables []badger.TableInfo, prefix []byte) uint64 {
+	var rows uint64
+	for _, info := range lsmTables {
+		if lsmTableOverlapsPrefix(info, prefix) {
+			rows += info.KeyCount
+		}
+	}
+	return rows
+}
+
+// LSM table bounds include the badger version suffix, which does not change how they compare against a prefix.
+// Tables without bounds are assumed to overlap.
+func lsmTableOverlapsPrefix(info badger.TableInfo, prefix []byte) bool {
+	if len(info.Left) == 0 && len(info.Right) == 0 {
+		return true
+	}
+	if bytes.Compare(info.Right, prefix) < 0 {
+		return false
+	}
+	if bytes.Compare(info.Left, prefix) > 0 && !bytes.HasPrefix(info.Left, prefix) {
+		return false
+	}
+	return true
+}
+
+// Describes the filters applied by keepRowHelper for the params that are set
+func describeKeyFilter(params url.Values, names ...string) string {
+	var filters []string
+	for _, name := range names {
+		value := params.Get(name)
+		if value == "" || value == AllKinds {
+			continue
+		}
+		filters = append(filters, name+"="+value)
+	}
+	return strings.Join(filters, " ")
+}
+
+func describeTimeRange(field string, startTime time.Time, endTime time.Time) string {
+	return fmt.Sprintf("%v in [%v, %v]", field, startTime.Format(time.RFC3339), endTime.Format(time.RFC3339))
+}
+
+func explainEventHeatMap(params url.Values, startTime time.Time, endTime time.Time) ([]scanPlan, []string) {
+	notes := []string{"rows from the scans are joined in memory after the read"}
+	if params.Get(NamespaceEpochParam) == CurrentNamespaceEpoch {
+		notes = append(notes, "resources created before the newest incarnation of their namespace are dropped after the read")
+	}
+	return []scanPlan{
+		{table: (&typed.EventCountKey{}).TableName(), keyPredicate: describeKeyFilter(params, KindParam, NamespaceParam, NameMatchParam)},
+		{table: (&typed.ResourceSummaryKey{}).TableName(), keyPredicate: describeKeyFilter(params, KindParam, NamespaceParam, NameMatchParam, NameParam, UuidParam)},
+		{table: (&typed.WatchActivityKey{}).TableName(), keyPredicate: describeKeyFilter(params, KindParam, NamespaceParam, NameMatchParam, NameParam, UuidParam)},
+		explainNamespaceEpochScan(),
+		explainIngestAnnotationScan(),
+	}, notes
+}
+
+func explainGetEventData(params url.Values, startTime time.Time, endTime time.Time) ([]scanPlan, []string) {
+	key := getEventKeyPrefix(params)
+	return []scanPlan{{
+		table: key.TableName(),
+		keyPrefix: func(partitionId string) string {
+			key.SetPartitionId(partitionId)
+			return key.String()
+		},
+		valuePredicate: describeTimeRange("event [firstTimestamp, lastTimestamp]", startTime, endTime) + " and involvedObject " + describeKeyFilter(params, KindParam, NameParam, UuidParam),
+	}, {table: (&typed.EventFoldKey{}).TableName(), keyPredicate: "involved " + describeKeyFilter(params, KindParam, NamespaceParam, NameParam)}}, nil
+}
+
+func explainGetResPayload(params url.Values, startTime time.Time, endTime time.Time) ([]scanPlan, []string) {
+	key := getKeyComparator(params)
+	notes := []string{fmt.Sprintf("one reverse seek from %v to find the last payload before the start time", GetSeekKey(key, startTime).String())}
+	if params.Get(VersionTypeParam) != "" {
+		notes = append(notes, fmt.Sprintf("keeps version types %v, and payloads stored before version types were computed", params.Get(VersionTypeParam)))
+	}
+	if params.Get(LatestParam) != "" {
+		notes = append(notes, fmt.Sprintf("partitions are read newest first and the scan stops after %v payloads, the seek is skipped when it finds them", params.Get(LatestParam)))
+	}
+	return []scanPlan{{
+		table: key.TableName(),
+		keyPrefix: func(partitionId string) string {
+			key.SetPartitionId(partitionId)
+			return key.String()
+		},
+		valuePredicate: describeTimeRange("watch timestamp", startTime, endTime),
+	}}, notes
+}
+
+func explainNamespaces(params url.Values, startTime time.Time, endTime time.Time) ([]scanPlan, []string) {
+	return []scanPlan{{table: (&typed.ResourceSummaryKey{}).TableName(), keyPredicate: "kind=Namespace"}}, nil
+}
+
+func explainKinds(params url.Values, startTime time.Time, endTime time.Time) ([]scanPlan, []string) {
+	return []scanPlan{{table: (&typed.ResourceSummaryKey{}).TableName(), keyPredicate: "first key seen for each kind"}}, nil
+}
+
+func explainQueries(params url.Values, startTime time.Time, endTime time.Time) ([]scanPlan, []string) {
+	return nil, []string{"does not read the store"}
+}
+
+func explainGetResSummaryData(params url.Values, startTime time.Time, endTime time.Time) ([]scanPlan, []string) {
+	return []scanPlan{{
+		table:          (&typed.ResourceSummaryKey{}).TableName(),
+		keyPredicate:   describeKeyFilter(params, KindParam, NamespaceParam, NameMatchParam, NameParam, UuidParam),
+		valuePredicate: describeTimeRange("[firstSeen, lastSeen]", startTime, endTime),
+	}}, []string{"only the first matching row is returned"}
+}
+
+func explainGetPodLifecycle(params url.Values, startTime time.Time, endTime time.Time) ([]scanPlan, []string) {
+	return []scanPlan{{
+		table:        (&typed.PodLifecycleKey{}).TableName(),
+		keyPredicate: describeKeyFilter(params, NamespaceParam, NameMatchParam, NameParam, UuidParam),
+	}}, []string{"records of the same pod from different partitions are merged in memory"}
+}
+
+func explainGetNodeHealth(params url.Values, startTime time.Time, endTime time.Time) ([]scanPlan, []string) {
+	return []scanPlan{{
+		table:          (&typed.NodeConditionKey{}).TableName(),
+		keyPredicate:   describeKeyFilter(params, NameMatchParam, NameParam, UuidParam),
+		valuePredicate: describeKeyFilter(params, ConditionParam),
+	}}, []string{"records of the same node from different partitions are merged in memory"}
+}
+
+func explainGetOwnerTree(params url.Values, startTime time.Time, endTime time.Time) ([]scanPlan, []string) {
+	key := typed.NewOwnerEdgeKeyComparator(params.Get(UuidParam))
+	return []scanPlan{{
+		table: key.TableName(),
+		keyPrefix: func(partitionId string) string {
+			key.SetPartitionId(partitionId)
+			return key.String()
+		},
+		valuePredicate: describeTimeRange("edge [start, end]", startTime, endTime),
+	}}, []string{fmt.Sprintf("the scan is repeated for every child found, up to %v levels deep", maxOwnerTreeDepth)}
+}
+
+func explainGetServiceBackends(params url.Values, startTime time.Time, endTime time.Time) ([]scanPlan, []string) {
+	key := typed.NewServiceBackendsKey("", params.Get(NamespaceParam), params.Get(NameParam), "", "")
+	return []scanPlan{{
+		table: key.TableName(),
+		keyPrefix: func(partitionId string) string {
+			key.SetPartitionId(partitionId)
+			return key.String()
+		},
+	}}, []string{"snapshots of all EndpointSlices (or the Endpoints) of the service are merged in memory"}
+}
+
+func explainGetVolumeBinding(params url.Values, startTime time.Time, endTime time.Time) ([]scanPlan, []string) {
+	claimPlan := scanPlan{
+		table:        (&typed.WatchTableKey{}).TableName(),
+		keyPredicate: "kind=" + kubeextractor.PersistentVolumeClaimKind + " " + describeKeyFilter(params, NamespaceParam, NameMatchParam, NameParam),
+	}
+	if claimKey := getClaimKeyPrefix(params); claimKey != nil {
+		claimPlan.keyPrefix = func(partitionId string) string {
+			claimKey.SetPartitionId(partitionId)
+			return claimKey.String()
+		}
+	}
+	volumeKey := typed.NewWatchTableKeyComparator(kubeextractor.PersistentVolumeKind, "", "", time.Time{})
+	volumePlan := scanPlan{
+		table: volumeKey.TableName(),
+		keyPrefix: func(partitionId string) string {
+			volumeKey.SetPartitionId(partitionId)
+			return volumeKey.String()
+		},
+	}
+	return []scanPlan{claimPlan, volumePlan}, []string{"one reverse seek per claim and volume found to get its state before the start time"}
+}
+
+func explainGetCurrentState(params url.Values, startTime time.Time, endTime time.Time) ([]scanPlan, []string) {
+	kind, namespace := getCurrentStatePrefix(params.Get(KindParam), params.Get(NamespaceParam))
+	plan := scanPlan{
+		table:        (&typed.CurrentStateKey{}).TableName(),
+		keyPredicate: describeKeyFilter(params, KindParam, NamespaceParam, NameMatchParam, NameParam),
+	}
+	if kind != "" {
+		plan.keyPrefix = func(partitionId string) string {
+			if namespace == "" {
+				return "/" + plan.table + "/" + partitionId + "/" + kind + "/"
+			}
+			return typed.NewCurrentStateKey(partitionId, kind, namespace, "", "").String()
+		}
+	}
+	return []scanPlan{plan}, []string{"every partition of the current state table is read whatever the time range, each resource is only in the partition of its latest watch result"}
+}
+
+func explainNamespaceEpochScan() scanPlan {
+	key := typed.NewResourceSummaryKeyComparator(kubeextractor.NamespaceKind, "", "", "")
+	return scanPlan{
+		table: key.TableName(),
+		keyPrefix: func(partitionId string) string {
+			key.SetPartitionId(partitionId)
+			return key.String()
+		},
+	}
+}
+
+func explainGetNamespaceEpochs(params url.Values, startTime time.Time, endTime time.Time) ([]scanPlan, []string) {
+	return []scanPlan{explainNamespaceEpochScan()}, []string{"epochs are grouped by namespace uid and filtered by namespace in memory"}
+}
+
+func explainIngestAnnotationScan() scanPlan {
+	return scanPlan{
+		table:          (&typed.IngestAnnotationKey{}).TableName(),
+		valuePredicate: "[firstSeen, lastSeen] overlaps the window, kind and namespace match or are unknown",
+	}
+}
+
+func explainGetIngestAnnotations(params url.Values, startTime time.Time, endTime time.Time) ([]scanPlan, []string) {
+	return []scanPlan{explainIngestAnnotationScan()}, nil
+}
+
+func explainGetFlappingResources(params url.Values, startTime time.Time, endTime time.Time) ([]scanPlan, []string) {
+	plan := scanPlan{
+		table:          (&typed.WatchTableKey{}).TableName(),
+		keyPredicate:   describeKeyFilter(params, KindParam, NamespaceParam, NameMatchParam, NameParam),
+		valuePredicate: describeTimeRange("watch timestamp", startTime, endTime),
+	}
+	if key := getFlappingKeyPrefix(params); key != nil {
+		plan.keyPrefix = func(partitionId string) string {
+			key.SetPartitionId(partitionId)
+			return key.String()
+		}
+	}
+	notes := []string{"payloads of each resource are compared in memory after the read, Event rows are skipped"}
+	return []scanPlan{plan}, notes
+}
+
+// Reads the same keys as GetFlappingResources
+func explainGetWriterConflicts(params url.Values, startTime time.Time, endTime time.Time) ([]scanPlan, []string) {
+	plans, _ := explainGetFlappingResources(params, startTime, endTime)
+	notes := []string{"fields of each resource and their writers from managedFields are compared in memory after the read, Event rows are skipped"}
+	return plans, notes
+}
+
+func explainGetQuotaUtilization(params url.Values, startTime time.Time, endTime time.Time) ([]scanPlan, []string) {
+	selectedNamespace := defaultParam(params.Get(NamespaceParam), AllNamespaces)
+	plans := []scanPlan{}
+	for _, kind := range []string{kubeextractor.ResourceQuotaKind, kubeextractor.LimitRangeKind} {
+		plan := scanPlan{
+			table:        (&typed.WatchTableKey{}).TableName(),
+			keyPredicate: "kind=" + kind + " " + describeKeyFilter(params, NamespaceParam),
+		}
+		if key := getNamespacedKindKeyPrefix(kind, selectedNamespace); key != nil {
+			plan.keyPrefix = func(partitionId string) string {
+				key.SetPartitionId(partitionId)
+				return key.String()
+			}
+		}
+		plans = append(plans, plan)
+	}
+	for _, kind := range quotaWorkloadKinds {
+		plan := scanPlan{
+			table:        (&typed.ResourceSummaryKey{}).TableName(),
+			keyPredicate: "kind=" + kind + " " + describeKeyFilter(params, NamespaceParam),
+		}
+		if selectedNamespace != AllNamespaces {
+			key := typed.NewResourceSummaryKeyComparator(kind, selectedNamespace, "", "")
+			plan.keyPrefix = func(partitionId string) string {
+				key.SetPartitionId(partitionId)
+				return key.String()
+			}
+		}
+		plans = append(plans, plan)
+	}
+	return plans, []string{"one reverse seek per quota and limit range found to get its state before the start time", "workloads are counted per quota sample in memory"}
+}
+
+func explainGetCredentialUsage(params url.Values, startTime time.Time, endTime time.Time) ([]scanPlan, []string) {
+	plans := []scanPlan{}
+	for _, kind := range credentialUsageKinds {
+		key := typed.NewWatchTableKeyComparator(kind, params.Get(NamespaceParam), "", time.Time{})
+		plans = append(plans, scanPlan{
+			table: key.TableName(),
+			keyPrefix: func(partitionId string) string {
+				key.SetPartitionId(partitionId)
+				return key.String()
+			},
+		})
+	}
+	return plans, []string{"one reverse seek per workload found to get its pod spec before the start time", "pod specs are searched for the " + params.Get(KindParam) + " " + params.Get(NameParam) + " in memory"}
+}
+
+func explainGetNetworkReferences(params url.Values, startTime time.Time, endTime time.Time) ([]scanPlan, []string) {
+	selectedNamespace := defaultParam(params.Get(NamespaceParam), AllNamespaces)
+	plans := []scanPlan{}
+	for _, kind := range kubeextractor.NetworkReferenceKinds {
+		if kind == kubeextractor.NodeKind && selectedNamespace != AllNamespaces {
+			continue
+		}
+		plan := scanPlan{table: typed.NewWatchTableKeyComparator(kind, "", "", time.Time{}).TableName()}
+		if selectedNamespace != AllNamespaces || kind == kubeextractor.NodeKind {
+			namespace := selectedNamespace
+			if kind == kubeextractor.NodeKind {
+				namespace = ""
+			}
+			key := typed.NewWatchTableKeyComparator(kind, namespace, "", time.Time{})
+			plan.keyPrefix = func(partitionId string) string {
+				key.SetPartitionId(partitionId)
+				return key.String()
+			}
+		}
+		plans = append(plans, plan)
+	}
+	return plans, []string{"one reverse seek per resource found to get its state before the start time", "payloads are searched for the ip or node in memory, there is no ip index"}
+}
+
+func explainGetDeploymentRollouts(params url.Values, startTime time.Time, endTime time.Time) ([]scanPlan, []string) {
+	selectedNamespace := defaultParam(params.Get(NamespaceParam), AllNamespaces)
+	plans := []scanPlan{}
+	for _, kind := range []string{kubeextractor.DeploymentKind, kubeextractor.ReplicaSetKind, kubeextractor.PodKind} {
+		plan := scanPlan{table: typed.NewWatchTableKeyComparator(kind, "", "", time.Time{}).TableName()}
+		if selectedNamespace != AllNamespaces {
+			key := typed.NewWatchTableKeyComparator(kind, selectedNamespace, "", time.Time{})
+			plan.keyPrefix = func(partitionId string) string {
+				key.SetPartitionId(partitionId)
+				return key.String()
+			}
+		}
+		plans = append(plans, plan)
+	}
+	return plans, []string{"replica sets and pods are only read when a rollout started in the time range", "one reverse seek per deployment and replica set found to get its state before the start time"}
+}
+
+func explainGetConfigImpact(params url.Values, startTime time.Time, endTime time.Time) ([]scanPlan, []string) {
+	plans := []scanPlan{}
+	for _, kind := range append([]string{params.Get(KindParam)}, configImpactKinds...) {
+		key := typed.NewWatchTableKeyComparator(kind, params.Get(NamespaceParam), "", time.Time{})
+		plans = append(plans, scanPlan{
+			table: key.TableName(),
+			keyPrefix: func(partitionId string) string {
+				key.SetPartitionId(partitionId)
+				return key.String()
+			},
+		})
+	}
+	return plans, []string{"one reverse seek per config and workload found to get its state before the start time", "pod specs are searched for the " + params.Get(KindParam) + " " + params.Get(NameParam) + " in memory"}
+}
+
+func explainGetEventBreakdown(params url.Values, startTime time.Time, endTime time.Time) ([]scanPlan, []string) {
+	plan := scanPlan{table: typed.NewWatchTableKeyComparator(kubeextractor.EventKind, "", "", time.Time{}).TableName()}
+	selectedNamespace := defaultParam(params.Get(NamespaceParam), AllNamespaces)
+	if selectedNamespace != AllNamespaces {
+		key := typed.NewWatchTableKeyComparator(kubeextractor.EventKind, selectedNamespace, "", time.Time{})
+		plan.keyPrefix = func(partitionId string) string {
+			key.SetPartitionId(partitionId)
+			return key.String()
+		}
+	}
+	return []scanPlan{plan, {table: (&typed.EventFoldKey{}).TableName(), keyPredicate: "namespace and involved kind"}},
+		[]string{"events stored before message templates were added are normalized in memory"}
+}
+
+func explainGetSnapshotDiff(params url.Values, startTime time.Time, endTime time.Time) ([]scanPlan, []string) {
+	plan := scanPlan{
+		table:          (&typed.WatchTableKey{}).TableName(),
+		keyPredicate:   describeKeyFilter(params, KindParam, NamespaceParam, NameMatchParam, NameParam),
+		valuePredicate: describeTimeRange("timestamp", startTime, endTime),
+	}
+	if key := getSnapshotDiffKeyPrefix(defaultParam(params.Get(KindParam), AllKinds), defaultParam(params.Get(NamespaceParam), AllNamespaces)); key != nil {
+		plan.keyPrefix = func(partitionId string) string {
+			key.SetPartitionId(partitionId)
+			return key.String()
+		}
+	}
+	return []scanPlan{plan}, []string{"one reverse seek per resource found to get its state before the start time", "events are left out unless kind is Event"}
+}
+
+func explainGetNamespaceComparison(params url.Values, startTime time.Time, endTime time.Time) ([]scanPlan, []string) {
+	plans := []scanPlan{}
+	selectedKind := defaultParam(params.Get(KindParam), AllKinds)
+	for _, name := range []string{NamespaceParam, OtherNamespaceParam} {
+		plan := scanPlan{
+			table:        (&typed.WatchTableKey{}).TableName(),
+			keyPredicate: describeKeyFilter(url.Values{NamespaceParam: {params.Get(name)}, KindParam: {selectedKind}, NameMatchParam: {params.Get(NameMatchParam)}}, KindParam, NamespaceParam, NameMatchParam),
+		}
+		if key := getSnapshotDiffKeyPrefix(selectedKind, defaultParam(params.Get(name), AllNamespaces)); key != nil {
+			plan.keyPrefix = func(partitionId string) string {
+				key.SetPartitionId(partitionId)
+				return key.String()
+			}
+		}
+		plans = append(plans, plan)
+	}
+	return plans, []string{"one reverse seek per resource found to get its state before the start time", "resources are matched and their payloads diffed in memory"}
+}
+
+func explainGetSelfFootprint(params url.Values, startTime time.Time, endTime time.Time) ([]scanPlan, []string) {
+	return []scanPlan{{table: typed.OpenSelfFootprintTable().TableName(), keyPredicate: describeTimeRange("timestamp", startTime, endTime)}},
+		[]string{"one seek to the start time, keys are read in time order until the end time"}
+}
+
+func explainGetApiServiceAvailability(params url.Values, startTime time.Time, endTime time.Time) ([]scanPlan, []string) {
+	return []scanPlan{
+		{table: typed.OpenApiServiceAvailabilityTable().TableName(), keyPredicate: describeKeyFilter(params, NameParam)},
+		{table: (&typed.WatchTableKey{}).TableName(), keyPredicate: "kind Event", valuePredicate: "type Warning"},
+	}, []string{"records of the same api service from different partitions are merged in memory",
+		"events are only read when an api service had an outage"}
+}
+
+func explainGetEventTrend(params url.Values, startTime time.Time, endTime time.Time) ([]scanPlan, []string) {
+	plans := []scanPlan{{table: (&typed.EventCountKey{}).TableName(), keyPredicate: describeKeyFilter(params, KindParam, NamespaceParam, NameMatchParam)}}
+	for _, table := range typed.EventRollupTables() {
+		plans = append(plans, scanPlan{table: table.TableName(), keyPredicate: describeKeyFilter(params, KindParam, NamespaceParam, NameMatchParam)})
+	}
+	return plans, []string{"whole days and hours of the time range are read from the rollups, only the ends from the event count table",
+		"partitions are listed for the whole time range, each scan only reads the part of it its granularity covers"}
+}
+
+func explainGetDeletionCascade(params url.Values, startTime time.Time, endTime time.Time) ([]scanPlan, []string) {
+	kind := params.Get(KindParam)
+	watchKey := typed.NewWatchTableKeyComparator(kind, kubeextractor.NormalizeNamespace(kind, params.Get(NamespaceParam)), params.Get(NameParam), time.Time{})
+	ownerPlan := scanPlan{table: (&typed.OwnerEdgeKey{}).TableName(), valuePredicate: "edge in place when the deletion of the owner started"}
+	// Without uuid the owner uid is only known from the watch results
+	if uid := params.Get(UuidParam); uid != "" {
+		ownerKey := typed.NewOwnerEdgeKeyComparator(uid)
+		ownerPlan.keyPrefix = func(partitionId string) string {
+			ownerKey.SetPartitionId(partitionId)
+			return ownerKey.String()
+		}
+	} else {
+		ownerPlan.keyPredicate = "owner uid of the deleted parent"
+	}
+	return []scanPlan{{
+		table: watchKey.TableName(),
+		keyPrefix: func(partitionId string) string {
+			watchKey.SetPartitionId(partitionId)
+			return watchKey.String()
+		},
+	}, ownerPlan}, []string{fmt.Sprintf("the owner graph scan is repeated for every deleted dependent, up to %v levels deep", maxOwnerTreeDepth),
+		"the watch results of every dependent are read to find its deletion"}
+}
+
+func explainGetApiVersionMigrations(params url.Values, startTime time.Time, endTime time.Time) ([]scanPlan, []string) {
+	plans, _ := explainGetSnapshotDiff(params, startTime, endTime)
+	return plans, []string{"api versions are counted for every result in the time range, only migrations are kept", "events are left out unless kind is Event"}
+}
+
+func explainGetNodePoolPods(params url.Values, startTime time.Time, endTime time.Time) ([]scanPlan, []string) {
+	return []scanPlan{{
+		table:        (&typed.PodLifecycleKey{}).TableName(),
+		keyPredicate: describeKeyFilter(params, NamespaceParam, NameMatchParam, NameParam, UuidParam),
+	}, {
+		table: (&typed.NodeConditionKey{}).TableName(),
+	}}, []string{"records of the same pod from different partitions are merged in memory",
+		"each node's pool attributes come from its record of the latest partition"}
+}
+
+func explainGetResourceCounts(params url.Values, startTime time.Time, endTime time.Time) ([]scanPlan, []string) {
+	return []scanPlan{{
+		table:          (&typed.ResourceSummaryKey{}).TableName(),
+		keyPredicate:   describeKeyFilter(params, KindParam, NamespaceParam, NameMatchParam, NameParam, UuidParam),
+		valuePredicate: "not deleted at the end of the partition",
+	}}, []string{"summaries are counted by kind and partition in memory"}
+}
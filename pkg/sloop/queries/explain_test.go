@@ -0,0 +1,95 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_explainMap_CoversAllQueries(t *testing.T) {
+	for name := range funcMap {
+		_, ok := explainMap[name]
+		assert.True(t, ok, "query %v has no explain planner", name)
+	}
+}
+
+func Test_RunQuery_ExplainHeatMap(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	helper_AddResSum(t, tables)
+	helper_AddEventSum(t, tables)
+
+	params := helper_UrlValues()
+	params[KindParam] = []string{kindPod}
+	params[StartTimeParam] = []string{fmt.Sprintf("%v", someHeatMapQueryStart.Unix())}
+	params[EndTimeParam] = []string{fmt.Sprintf("%v", someHeatMapQueryEnd.Add(-time.Second).Unix())}
+	params[ExplainParam] = []string{"true"}
+
+	data, err := RunQuery("EventHeatMap", params, tables, time.Hour, "someRequestId")
+	assert.Nil(t, err)
+
+	output := ExplainOutput{}
+	assert.Nil(t, json.Unmarshal(data, &output))
+	assert.Equal(t, "EventHeatMap", output.Query)
+	assert.Len(t, output.Scans, 5)
+	partitionId := untyped.GetPartitionId(someHeatMapQueryStart)
+	assert.Equal(t, []string{partitionId}, output.Scans[0].Partitions)
+	assert.Equal(t, []string{"/eventcount/" + partitionId + "/"}, output.Scans[0].KeyRanges)
+	assert.Equal(t, "kind=Pod", output.Scans[0].KeyPredicate)
+	// The mock reports a single LSM table holding every key
+	assert.Equal(t, uint64(2), output.Scans[0].EstimatedRowsVisited)
+	assert.Equal(t, []string{"/ressum/" + partitionId + "/Namespace//"}, output.Scans[3].KeyRanges)
+	assert.Equal(t, "/ingestannotation/"+partitionId+"/", output.Scans[4].KeyRanges[0])
+	assert.Equal(t, uint64(10), output.EstimatedRowsVisited)
+}
+
+func Test_RunQuery_ExplainResPayloadUsesKeyPrefix(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+
+	params := helper_UrlValues()
+	params[KindParam] = []string{kindPod}
+	params[NamespaceParam] = []string{someNamespace}
+	params[NameParam] = []string{someName}
+	params[StartTimeParam] = []string{fmt.Sprintf("%v", someHeatMapQueryStart.Unix())}
+	params[EndTimeParam] = []string{fmt.Sprintf("%v", someHeatMapQueryStart.Add(90*time.Minute).Unix())}
+	params[ExplainParam] = []string{"true"}
+
+	data, err := RunQuery("GetResPayload", params, tables, 2*time.Hour, "someRequestId")
+	assert.Nil(t, err)
+
+	output := ExplainOutput{}
+	assert.Nil(t, json.Unmarshal(data, &output))
+	assert.Len(t, output.Scans, 1)
+	assert.Len(t, output.Scans[0].KeyRanges, 2)
+	assert.Equal(t, "/watch/"+untyped.GetPartitionId(someHeatMapQueryStart)+"/Pod/somens/somename/", output.Scans[0].KeyRanges[0])
+	assert.Len(t, output.Notes, 1)
+}
+
+func Test_lsmTableOverlapsPrefix(t *testing.T) {
+	table := badger.TableInfo{Left: []byte("/watch/001/Pod/a"), Right: []byte("/watch/001/Pod/m")}
+	assert.True(t, lsmTableOverlapsPrefix(table, []byte("/watch/001/")))
+	assert.True(t, lsmTableOverlapsPrefix(table, []byte("/watch/001/Pod/c")))
+	assert.False(t, lsmTableOverlapsPrefix(table, []byte("/watch/001/Pod/z")))
+	assert.False(t, lsmTableOverlapsPrefix(table, []byte("/watch/002/")))
+	assert.False(t, lsmTableOverlapsPrefix(table, []byte("/ressum/001/")))
+	assert.True(t, lsmTableOverlapsPrefix(badger.TableInfo{}, []byte("/watch/001/")))
+}
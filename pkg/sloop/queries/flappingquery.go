@@ -0,0 +1,315 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+const (
+	SensitivityLow    = "low"
+	SensitivityMedium = "medium"
+	SensitivityHigh   = "high"
+)
+
+// A resource is flapping when it went back to a state it had before at least minReturns times, and changed state at
+// least minChangesPerHour times an hour over the window
+type flappingThresholds struct {
+	minReturns        int
+	minChangesPerHour float64
+}
+
+var flappingSensitivities = map[string]flappingThresholds{
+	SensitivityLow:    {minReturns: 6, minChangesPerHour: 6},
+	SensitivityMedium: {minReturns: 4, minChangesPerHour: 3},
+	SensitivityHigh:   {minReturns: 2, minChangesPerHour: 1},
+}
+
+// These change on updates that do not change what the resource is doing, so they are not part of its state
+var flappingVolatileFields = map[string]bool{
+	"resourceVersion":    true,
+	"managedFields":      true,
+	"generation":         true,
+	"observedGeneration": true,
+	"lastUpdateTime":     true,
+	"lastTransitionTime": true,
+	"lastHeartbeatTime":  true,
+	"lastProbeTime":      true,
+	"renewTime":          true,
+}
+
+// Only the first states of a resource get their own letter in the pattern
+const flappingPatternStates = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// Longer patterns keep the most recent states
+const maxFlappingPatternLength = 64
+
+const maxFlappingPaths = 5
+
+type FlappingResourceOutput struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Number of times the state changed within the window
+	Changes int `json:"changes"`
+	// Number of changes back to a state seen before, A to B and back to A is one
+	Returns        int     `json:"returns"`
+	DistinctStates int     `json:"distinctStates"`
+	ChangesPerHour float64 `json:"changesPerHour"`
+	// Returns / Changes.  Close to 1 for a resource going back and forth between two states
+	Alternation float64 `json:"alternation"`
+	// One letter per state in the order they were seen, like ABABAB.  States past Z show as *
+	Pattern string `json:"pattern"`
+	// Paths that changed most often, like spec.replicas
+	FlappingPaths []string `json:"flappingPaths,omitempty"`
+	FirstChange   int64    `json:"firstChange"`
+	LastChange    int64    `json:"lastChange"`
+}
+
+type flappingVersion struct {
+	timestamp time.Time
+	state     string
+}
+
+/*
+Finds resources going back and forth between states within the window, like a Deployment that is scaled up and down by
+two controllers or Endpoints whose backends keep coming and going.  The state of a version is its payload without
+metadata other than labels and annotations, and without fields that change on every update like resourceVersion and
+condition timestamps.  Sensitivity (low, medium or high, medium by default) sets how many returns to an earlier state
+and how many changes an hour it takes to report a resource.  Sorted by returns, most first
+*/
+func GetFlappingResources(params url.Values, t typed.Tables, startTime time.Time, endTime time.Time, requestId string) ([]byte, error) {
+	thresholds, err := getFlappingThresholds(params)
+	if err != nil {
+		return nil, err
+	}
+
+	var watchRes map[typed.WatchTableKey]*typed.KubeWatchResult
+	err = t.Db().View(func(txn badgerwrap.Txn) error {
+		var stats typed.RangeReadStats
+		var err error
+		valPredFn := typed.KubeWatchResult_ValPredicateFns(isResPayloadInTimeRange(startTime, endTime))
+		watchRes, stats, err = t.WatchTable().RangeRead(txn, getFlappingKeyPrefix(params), paramFilterFlappingFn(params), valPredFn, startTime, endTime)
+		stats.Log(requestId)
+		return err
+	})
+	if err != nil {
+		return []byte{}, err
+	}
+
+	versions := map[typed.WatchTableKey][]flappingVersion{}
+	for key, result := range watchRes {
+		state, err := flappingState(result.Payload)
+		if err != nil {
+			continue
+		}
+		resource := typed.WatchTableKey{Kind: key.Kind, Namespace: key.Namespace, Name: key.Name}
+		versions[resource] = append(versions[resource], flappingVersion{timestamp: key.Timestamp, state: state})
+	}
+
+	hours := endTime.Sub(startTime).Hours()
+	if hours < 1.0/60 {
+		hours = 1.0 / 60
+	}
+	output := []FlappingResourceOutput{}
+	for resource, resourceVersions := range versions {
+		row := analyzeFlapping(resourceVersions, hours)
+		if row.Returns < thresholds.minReturns || row.ChangesPerHour < thresholds.minChangesPerHour {
+			continue
+		}
+		row.Kind = resource.Kind
+		row.Namespace = resource.Namespace
+		row.Name = resource.Name
+		output = append(output, row)
+	}
+	sort.Slice(output, func(i, j int) bool {
+		if output[i].Returns != output[j].Returns {
+			return output[i].Returns > output[j].Returns
+		}
+		if output[i].Kind != output[j].Kind {
+			return output[i].Kind < output[j].Kind
+		}
+		if output[i].Namespace != output[j].Namespace {
+			return output[i].Namespace < output[j].Namespace
+		}
+		return output[i].Name < output[j].Name
+	})
+
+	bytes, err := json.MarshalIndent(output, "", " ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal json %v", err)
+	}
+	return bytes, nil
+}
+
+func getFlappingThresholds(params url.Values) (flappingThresholds, error) {
+	sensitivity := params.Get(SensitivityParam)
+	if sensitivity == "" {
+		sensitivity = SensitivityMedium
+	}
+	thresholds, ok := flappingSensitivities[sensitivity]
+	if !ok {
+		return flappingThresholds{}, NewApiError(ErrorCodeBadParams, "invalid %v %q, should be %v, %v or %v", SensitivityParam, sensitivity, SensitivityLow, SensitivityMedium, SensitivityHigh)
+	}
+	return thresholds, nil
+}
+
+// Keys only make a good prefix when both the kind and the namespace are known.  Otherwise every key of the window is
+// read and filtered by paramFilterFlappingFn
+func getFlappingKeyPrefix(params url.Values) *typed.WatchTableKey {
+	selectedKind := params.Get(KindParam)
+	selectedNamespace := params.Get(NamespaceParam)
+	if selectedKind == "" || selectedKind == AllKinds || kubeextractor.IsClustersScopedResource(selectedKind) {
+		return nil
+	}
+	if selectedNamespace == "" || selectedNamespace == AllNamespaces {
+		return nil
+	}
+	return typed.NewWatchTableKeyComparator(selectedKind, selectedNamespace, "", time.Time{})
+}
+
+// Events are new objects rather than new states of one, so they never flap
+func paramFilterFlappingFn(params url.Values) func(string) bool {
+	selectedKind := defaultParam(params.Get(KindParam), AllKinds)
+	selectedNamespace := defaultParam(params.Get(NamespaceParam), AllNamespaces)
+	selectedNameSubstring := params.Get(NameMatchParam)
+	selectedNameExactMatch := params.Get(NameParam)
+	return func(key string) bool {
+		k := &typed.WatchTableKey{}
+		err := k.Parse(key)
+		if err != nil || k.Kind == kubeextractor.EventKind {
+			return false
+		}
+		return keepRowHelper(k.Name, k.Kind, k.Namespace, selectedKind, selectedNamespace, selectedNameSubstring, selectedNameExactMatch, "", "")
+	}
+}
+
+func defaultParam(value string, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
+
+// Returns the payload as JSON, without the fields that change on updates that do not change the state
+func flappingState(payload string) (string, error) {
+	resource := map[string]interface{}{}
+	err := json.Unmarshal([]byte(payload), &resource)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to parse payload")
+	}
+	if metadata, ok := resource["metadata"].(map[string]interface{}); ok {
+		resource["metadata"] = map[string]interface{}{"labels": metadata["labels"], "annotations": metadata["annotations"]}
+	}
+	removeFlappingVolatileFields(resource)
+	// Keys of maps are sorted, so the same state always has the same JSON
+	state, err := json.Marshal(resource)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal payload")
+	}
+	return string(state), nil
+}
+
+func removeFlappingVolatileFields(value interface{}) {
+	switch typedValue := value.(type) {
+	case map[string]interface{}:
+		for key, child := range typedValue {
+			if flappingVolatileFields[key] {
+				delete(typedValue, key)
+				continue
+			}
+			removeFlappingVolatileFields(child)
+		}
+	case []interface{}:
+		for _, child := range typedValue {
+			removeFlappingVolatileFields(child)
+		}
+	}
+}
+
+func analyzeFlapping(versions []flappingVersion, hours float64) FlappingResourceOutput {
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].timestamp.Before(versions[j].timestamp)
+	})
+
+	row := FlappingResourceOutput{}
+	stateLetters := map[string]byte{}
+	pattern := []byte{}
+	pathCounts := map[string]int{}
+	previous := ""
+	for idx, version := range versions {
+		if idx > 0 && version.state == previous {
+			continue
+		}
+		letter, seen := stateLetters[version.state]
+		if !seen {
+			letter = '*'
+			if len(stateLetters) < len(flappingPatternStates) {
+				letter = flappingPatternStates[len(stateLetters)]
+			}
+			stateLetters[version.state] = letter
+		}
+		pattern = append(pattern, letter)
+
+		if idx > 0 {
+			row.Changes++
+			if seen {
+				row.Returns++
+			}
+			if row.FirstChange == 0 {
+				row.FirstChange = version.timestamp.Unix()
+			}
+			row.LastChange = version.timestamp.Unix()
+			paths, err := kubeextractor.ComputeChangedPaths(previous, version.state)
+			if err == nil {
+				for _, path := range paths {
+					pathCounts[path]++
+				}
+			}
+		}
+		previous = version.state
+	}
+
+	row.DistinctStates = len(stateLetters)
+	row.ChangesPerHour = float64(row.Changes) / hours
+	if row.Changes > 0 {
+		row.Alternation = float64(row.Returns) / float64(row.Changes)
+	}
+	if len(pattern) > maxFlappingPatternLength {
+		pattern = pattern[len(pattern)-maxFlappingPatternLength:]
+	}
+	row.Pattern = string(pattern)
+	row.FlappingPaths = topFlappingPaths(pathCounts)
+	return row
+}
+
+func topFlappingPaths(pathCounts map[string]int) []string {
+	paths := []string{}
+	for path := range pathCounts {
+		paths = append(paths, path)
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		if pathCounts[paths[i]] != pathCounts[paths[j]] {
+			return pathCounts[paths[i]] > pathCounts[paths[j]]
+		}
+		return paths[i] < paths[j]
+	})
+	if len(paths) > maxFlappingPaths {
+		paths = paths[:maxFlappingPaths]
+	}
+	return paths
+}
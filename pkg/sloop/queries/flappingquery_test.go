@@ -0,0 +1,111 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+const someFlappingDeployment = `{"metadata":{"name":"%v","namespace":"some-namespace","resourceVersion":"%v"},"spec":{"replicas":%v},"status":{"conditions":[{"type":"Available","lastUpdateTime":"%v"}]}}`
+
+// Every 5 minutes for an hour: flapper goes between 2 and 5 replicas, grower only scales up and churner only gets a
+// new resourceVersion and condition timestamp
+func helper_getFlappingTables(t *testing.T) typed.Tables {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		for idx := 0; idx < 12; idx++ {
+			ts := someTs.Add(time.Duration(idx) * 5 * time.Minute)
+			protoTs, _ := ptypes.TimestampProto(ts)
+			replicas := map[string]int{"flapper": 2 + 3*(idx%2), "grower": idx + 1, "churner": 3}
+			for name, count := range replicas {
+				payload := fmt.Sprintf(someFlappingDeployment, name, idx, count, ts.Format(time.RFC3339))
+				key := typed.NewWatchTableKey(untyped.GetPartitionId(ts), "Deployment", "some-namespace", name, ts)
+				err := tables.WatchTable().Set(txn, key.String(), &typed.KubeWatchResult{Timestamp: protoTs, Kind: "Deployment", Payload: payload})
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+	return tables
+}
+
+func Test_GetFlappingResources(t *testing.T) {
+	tables := helper_getFlappingTables(t)
+	values := helper_get_params()
+	values[KindParam] = []string{"Deployment"}
+	data, err := GetFlappingResources(values, tables, someTs, someTs.Add(time.Hour), someRequestId)
+	assert.Nil(t, err)
+	output := []FlappingResourceOutput{}
+	assert.Nil(t, json.Unmarshal(data, &output))
+	assert.Len(t, output, 1)
+	assert.Equal(t, "flapper", output[0].Name)
+	assert.Equal(t, 11, output[0].Changes)
+	assert.Equal(t, 10, output[0].Returns)
+	assert.Equal(t, 2, output[0].DistinctStates)
+	assert.Equal(t, "ABABABABABAB", output[0].Pattern)
+	assert.Equal(t, []string{"spec.replicas"}, output[0].FlappingPaths)
+	assert.Equal(t, someTs.Add(5*time.Minute).Unix(), output[0].FirstChange)
+	assert.InDelta(t, 11.0, output[0].ChangesPerHour, 0.001)
+}
+
+func Test_GetFlappingResources_Sensitivity(t *testing.T) {
+	tables := helper_getFlappingTables(t)
+	values := helper_get_params()
+	values[KindParam] = []string{AllKinds}
+
+	// Only a quarter of the window, so the flapper changes twice and goes back once
+	values[SensitivityParam] = []string{SensitivityHigh}
+	data, err := GetFlappingResources(values, tables, someTs, someTs.Add(10*time.Minute), someRequestId)
+	assert.Nil(t, err)
+	output := []FlappingResourceOutput{}
+	assert.Nil(t, json.Unmarshal(data, &output))
+	assert.Len(t, output, 0)
+
+	data, err = GetFlappingResources(values, tables, someTs, someTs.Add(15*time.Minute), someRequestId)
+	assert.Nil(t, err)
+	assert.Nil(t, json.Unmarshal(data, &output))
+	assert.Len(t, output, 1)
+
+	values[SensitivityParam] = []string{SensitivityLow}
+	data, err = GetFlappingResources(values, tables, someTs, someTs.Add(15*time.Minute), someRequestId)
+	assert.Nil(t, err)
+	output = []FlappingResourceOutput{}
+	assert.Nil(t, json.Unmarshal(data, &output))
+	assert.Len(t, output, 0)
+
+	values[SensitivityParam] = []string{"extreme"}
+	_, err = GetFlappingResources(values, tables, someTs, someTs.Add(time.Hour), someRequestId)
+	assert.NotNil(t, err)
+}
+
+func Test_flappingState_IgnoresVolatileFields(t *testing.T) {
+	first, err := flappingState(fmt.Sprintf(someFlappingDeployment, "a", 1, 3, "2021-01-01T00:00:00Z"))
+	assert.Nil(t, err)
+	second, err := flappingState(fmt.Sprintf(someFlappingDeployment, "a", 2, 3, "2021-01-01T00:05:00Z"))
+	assert.Nil(t, err)
+	assert.Equal(t, first, second)
+
+	_, err = flappingState("not json")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,126 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+// A stretch of time where ingestion had problems, so the timeline may be missing updates.  Start and End are unix
+// seconds of the first and last repeat
+type IngestAnnotationOutput struct {
+	Type      string `json:"type"`
+	Kind      string `json:"kind,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Message   string `json:"message"`
+	Count     int64  `json:"count"`
+	Start     int64  `json:"start"`
+	End       int64  `json:"end"`
+}
+
+/*
+Returns the annotations within the window that can affect the selected kind and namespace.  Annotations that do not
+know their kind or namespace are always kept, since a failing informer of an unknown kind or a watch of every namespace
+can be behind missing updates of any row.  Sorted by start time
+*/
+func getIngestAnnotations(params url.Values, t typed.Tables, txn badgerwrap.Txn, startTime time.Time, endTime time.Time) ([]IngestAnnotationOutput, error) {
+	selectedKind := params.Get(KindParam)
+	selectedNamespace := params.Get(NamespaceParam)
+	annotations, _, err := t.IngestAnnotationTable().RangeRead(txn, nil, nil, nil, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+
+	output := []IngestAnnotationOutput{}
+	for key, annotation := range annotations {
+		if selectedKind != "" && selectedKind != AllKinds && key.Kind != "" && key.Kind != selectedKind {
+			continue
+		}
+		if selectedNamespace != "" && selectedNamespace != AllNamespaces && key.Namespace != "" && key.Namespace != selectedNamespace {
+			continue
+		}
+		firstSeen, err := ptypes.Timestamp(annotation.FirstSeen)
+		if err != nil {
+			return nil, err
+		}
+		lastSeen, err := ptypes.Timestamp(annotation.LastSeen)
+		if err != nil {
+			return nil, err
+		}
+		if lastSeen.Before(startTime) || firstSeen.After(endTime) {
+			continue
+		}
+		output = append(output, IngestAnnotationOutput{
+			Type:      key.Type,
+			Kind:      key.Kind,
+			Namespace: key.Namespace,
+			Message:   annotation.Message,
+			Count:     annotation.Count,
+			Start:     firstSeen.Unix(),
+			End:       lastSeen.Unix(),
+		})
+	}
+	sort.Slice(output, func(i, j int) bool {
+		if output[i].Start != output[j].Start {
+			return output[i].Start < output[j].Start
+		}
+		if output[i].Type != output[j].Type {
+			return output[i].Type < output[j].Type
+		}
+		return output[i].Kind < output[j].Kind
+	})
+	return mergeClusterUpgrades(output), nil
+}
+
+// An upgrade that crosses a partition is stored as one annotation per partition, these are joined back into one
+// window.  Annotations are sorted by start time
+func mergeClusterUpgrades(annotations []IngestAnnotationOutput) []IngestAnnotationOutput {
+	merged := []IngestAnnotationOutput{}
+	lastUpgrade := -1
+	for _, annotation := range annotations {
+		if annotation.Type == typed.IngestAnnotationClusterUpgrade {
+			if lastUpgrade >= 0 && annotation.Start-merged[lastUpgrade].End <= int64(typed.ClusterUpgradeMergeWindow.Seconds()) {
+				if annotation.End > merged[lastUpgrade].End {
+					merged[lastUpgrade].End = annotation.End
+					merged[lastUpgrade].Message = annotation.Message
+				}
+				merged[lastUpgrade].Count += annotation.Count
+				continue
+			}
+			lastUpgrade = len(merged)
+		}
+		merged = append(merged, annotation)
+	}
+	return merged
+}
+
+func GetIngestAnnotations(params url.Values, t typed.Tables, startTime time.Time, endTime time.Time, requestId string) ([]byte, error) {
+	var output []IngestAnnotationOutput
+	err := t.Db().View(func(txn badgerwrap.Txn) error {
+		var err error
+		output, err = getIngestAnnotations(params, t, txn, startTime, endTime)
+		return err
+	})
+	if err != nil {
+		return []byte{}, err
+	}
+
+	bytes, err := json.MarshalIndent(output, "", " ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal json %v", err)
+	}
+	return bytes, nil
+}
@@ -0,0 +1,110 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+func helper_getIngestAnnotationTables(t *testing.T) typed.Tables {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	partitionId := untyped.GetPartitionId(someTs)
+	rows := []struct {
+		kind           string
+		namespace      string
+		annotationType string
+		first          time.Time
+		last           time.Time
+	}{
+		{"Pod", "", typed.IngestAnnotationThrottled, someTs.Add(5 * time.Minute), someTs.Add(7 * time.Minute)},
+		{"", "", typed.IngestAnnotationWatchError, someTs.Add(10 * time.Minute), someTs.Add(10 * time.Minute)},
+		{"Pod", "other", typed.IngestAnnotationRelist, someTs.Add(15 * time.Minute), someTs.Add(15 * time.Minute)},
+		{"Node", "", typed.IngestAnnotationWatchError, someTs.Add(20 * time.Minute), someTs.Add(20 * time.Minute)},
+		// Ended before the window
+		{"Pod", "", typed.IngestAnnotationWatchError, someTs.Add(-time.Minute), someTs.Add(-time.Second)},
+	}
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		for _, row := range rows {
+			first, _ := ptypes.TimestampProto(row.first)
+			last, _ := ptypes.TimestampProto(row.last)
+			key := typed.NewIngestAnnotationKey(partitionId, row.kind, row.namespace, row.annotationType, row.first)
+			value := &typed.IngestAnnotation{Type: row.annotationType, Kind: row.kind, Namespace: row.namespace, Message: "some message", Count: 1, FirstSeen: first, LastSeen: last}
+			err := tables.IngestAnnotationTable().Set(txn, key.String(), value)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+	return tables
+}
+
+func Test_GetIngestAnnotations(t *testing.T) {
+	tables := helper_getIngestAnnotationTables(t)
+	values := helper_get_params()
+	values[KindParam] = []string{"Pod"}
+	data, err := GetIngestAnnotations(values, tables, someTs, someTs.Add(time.Hour), someRequestId)
+	assert.Nil(t, err)
+	output := []IngestAnnotationOutput{}
+	assert.Nil(t, json.Unmarshal(data, &output))
+	assert.Equal(t, []IngestAnnotationOutput{
+		{Type: typed.IngestAnnotationThrottled, Kind: "Pod", Message: "some message", Count: 1, Start: someTs.Add(5 * time.Minute).Unix(), End: someTs.Add(7 * time.Minute).Unix()},
+		{Type: typed.IngestAnnotationWatchError, Message: "some message", Count: 1, Start: someTs.Add(10 * time.Minute).Unix(), End: someTs.Add(10 * time.Minute).Unix()},
+	}, output)
+
+	values[KindParam] = []string{AllKinds}
+	values[NamespaceParam] = []string{AllNamespaces}
+	data, err = GetIngestAnnotations(values, tables, someTs, someTs.Add(time.Hour), someRequestId)
+	assert.Nil(t, err)
+	output = []IngestAnnotationOutput{}
+	assert.Nil(t, json.Unmarshal(data, &output))
+	assert.Len(t, output, 4)
+}
+
+func Test_EventHeatMap3Query_IncludesIngestAnnotations(t *testing.T) {
+	tables := helper_getIngestAnnotationTables(t)
+	values := helper_get_params()
+	values[KindParam] = []string{"Node"}
+	data, err := EventHeatMap3Query(values, tables, someTs, someTs.Add(time.Hour), someRequestId)
+	assert.Nil(t, err)
+	output := TimelineRoot{}
+	assert.Nil(t, json.Unmarshal(data, &output))
+	assert.Len(t, output.IngestAnnotations, 2)
+	assert.Equal(t, "", output.IngestAnnotations[0].Kind)
+	assert.Equal(t, "Node", output.IngestAnnotations[1].Kind)
+}
+
+func Test_mergeClusterUpgrades(t *testing.T) {
+	start := someTs.Unix()
+	annotations := []IngestAnnotationOutput{
+		{Type: typed.IngestAnnotationClusterUpgrade, Message: "node a v1 -> v2", Count: 2, Start: start, End: start + 600},
+		{Type: typed.IngestAnnotationWatchError, Message: "watch failed", Count: 1, Start: start + 900, End: start + 900},
+		// The same upgrade carried on in the next partition
+		{Type: typed.IngestAnnotationClusterUpgrade, Message: "node b v1 -> v2", Count: 1, Start: start + 3600, End: start + 3700},
+		// Too long after to be the same upgrade
+		{Type: typed.IngestAnnotationClusterUpgrade, Message: "node c v2 -> v3", Count: 1, Start: start + 4*3600, End: start + 4*3600},
+	}
+	assert.Equal(t, []IngestAnnotationOutput{
+		{Type: typed.IngestAnnotationClusterUpgrade, Message: "node b v1 -> v2", Count: 3, Start: start, End: start + 3700},
+		{Type: typed.IngestAnnotationWatchError, Message: "watch failed", Count: 1, Start: start + 900, End: start + 900},
+		{Type: typed.IngestAnnotationClusterUpgrade, Message: "node c v2 -> v3", Count: 1, Start: start + 4*3600, End: start + 4*3600},
+	}, mergeClusterUpgrades(annotations))
+}
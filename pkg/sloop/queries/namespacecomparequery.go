@@ -0,0 +1,294 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+// Annotations that are set per namespace by the tools that apply a resource, so they always differ
+var namespaceComparisonIgnoredAnnotations = map[string]bool{
+	"kubectl.kubernetes.io/last-applied-configuration": true,
+	"deployment.kubernetes.io/revision":                true,
+}
+
+type NamespaceComparisonOutput struct {
+	Namespace      string `json:"namespace"`
+	OtherNamespace string `json:"otherNamespace"`
+	// Unix seconds the resources are compared at, the end of the time range
+	At int64 `json:"at"`
+	// Label key resources were matched by, empty when they were matched by name
+	MatchLabel string `json:"matchLabel,omitempty"`
+	// Matched resources with the same spec
+	Identical            int                           `json:"identical"`
+	Different            []NamespaceComparisonPair     `json:"different"`
+	OnlyInNamespace      []NamespaceComparisonResource `json:"onlyInNamespace"`
+	OnlyInOtherNamespace []NamespaceComparisonResource `json:"onlyInOtherNamespace"`
+}
+
+type NamespaceComparisonPair struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	OtherName string `json:"otherName"`
+	// Value of the match label of both, when resources were matched by it
+	LabelValue string `json:"labelValue,omitempty"`
+	// Paths that differ, with the value of each in both namespaces as JSON.  Only the first few are kept,
+	// changedPathCount has the total
+	Differences      []NamespaceComparisonField `json:"differences"`
+	ChangedPathCount int                        `json:"changedPathCount"`
+}
+
+type NamespaceComparisonField struct {
+	Path       string `json:"path"`
+	Value      string `json:"value"`
+	OtherValue string `json:"otherValue"`
+}
+
+type NamespaceComparisonResource struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+	// Value of the match label, when resources were matched by it
+	LabelValue string `json:"labelValue,omitempty"`
+}
+
+type namespaceComparisonState struct {
+	kind       string
+	name       string
+	labelValue string
+	// Payload without status and the metadata that differs between namespaces, like uid and namespace
+	state string
+}
+
+/*
+Compares the live resources of namespace and other_namespace at the end of the time range, like staging and prod, to
+find drift between them.  Resources of the same kind match by name, or by the value of the label match_label when it
+is set, and the ones with the same value are paired in the order of their names.  Only spec, labels and annotations
+are compared, so differences of status, uid or resourceVersion do not count.  Like GetSnapshotDiff only resources
+with watch results in the time range are seen, so the time range should be longer than the resync interval to see
+every resource.  kind and namematch narrow the resources down, events are always left out
+*/
+func GetNamespaceComparison(params url.Values, t typed.Tables, startTime time.Time, endTime time.Time, requestId string) ([]byte, error) {
+	selectedNamespace := params.Get(NamespaceParam)
+	otherNamespace := params.Get(OtherNamespaceParam)
+	selectedKind := defaultParam(params.Get(KindParam), AllKinds)
+	selectedNameMatch := params.Get(NameMatchParam)
+	matchLabel := params.Get(MatchLabelParam)
+	if selectedNamespace == "" || selectedNamespace == AllNamespaces || otherNamespace == "" || otherNamespace == AllNamespaces {
+		return nil, NewApiError(ErrorCodeBadParams, "%v and %v are both required", NamespaceParam, OtherNamespaceParam)
+	}
+	if selectedNamespace == otherNamespace {
+		return nil, NewApiError(ErrorCodeBadParams, "%v and %v must differ", NamespaceParam, OtherNamespaceParam)
+	}
+	if selectedKind != AllKinds && kubeextractor.IsClustersScopedResource(selectedKind) {
+		return nil, NewApiError(ErrorCodeBadParams, "%v is cluster scoped and not in a namespace", selectedKind)
+	}
+
+	var states, otherStates []namespaceComparisonState
+	err := t.Db().View(func(txn badgerwrap.Txn) error {
+		var err error
+		states, err = readNamespaceComparisonStates(txn, t, selectedKind, selectedNamespace, selectedNameMatch, matchLabel, startTime, endTime, requestId)
+		if err != nil {
+			return err
+		}
+		otherStates, err = readNamespaceComparisonStates(txn, t, selectedKind, otherNamespace, selectedNameMatch, matchLabel, startTime, endTime, requestId)
+		return err
+	})
+	if err != nil {
+		return []byte{}, err
+	}
+
+	output := compareNamespaceStates(states, otherStates, matchLabel != "")
+	output.Namespace = selectedNamespace
+	output.OtherNamespace = otherNamespace
+	output.At = endTime.Unix()
+	output.MatchLabel = matchLabel
+	bytes, err := json.MarshalIndent(output, "", " ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal json %v", err)
+	}
+	return bytes, nil
+}
+
+// The state of each resource of the namespace that was live at the end of the time range.  With a match label,
+// resources without it are left out
+func readNamespaceComparisonStates(txn badgerwrap.Txn, t typed.Tables, selectedKind string, namespace string, selectedNameMatch string, matchLabel string, startTime time.Time, endTime time.Time, requestId string) ([]namespaceComparisonState, error) {
+	keyPredicate := func(key string) bool {
+		k := &typed.WatchTableKey{}
+		err := k.Parse(key)
+		if err != nil {
+			return false
+		}
+		if selectedKind == AllKinds && k.Kind == kubeextractor.EventKind {
+			return false
+		}
+		return keepRowHelper(k.Name, k.Kind, k.Namespace, selectedKind, namespace, selectedNameMatch, "", "", "")
+	}
+	records, stats, err := t.WatchTable().RangeRead(txn, getSnapshotDiffKeyPrefix(selectedKind, namespace), keyPredicate, nil, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+	stats.Log(requestId)
+
+	// addWatchRecordsBefore works on the resources of one kind
+	byKind := map[string]map[typed.WatchTableKey]*typed.KubeWatchResult{}
+	for key, result := range records {
+		if byKind[key.Kind] == nil {
+			byKind[key.Kind] = map[typed.WatchTableKey]*typed.KubeWatchResult{}
+		}
+		byKind[key.Kind][key] = result
+	}
+	states := []namespaceComparisonState{}
+	for kind, kindRecords := range byKind {
+		grouped := groupWatchRecords(kindRecords)
+		err = addWatchRecordsBefore(txn, t, kind, grouped, startTime)
+		if err != nil {
+			return nil, err
+		}
+		for id, resourceRecords := range grouped {
+			last := resourceRecords[len(resourceRecords)-1].result
+			if last.WatchType == typed.KubeWatchResult_DELETE {
+				continue
+			}
+			state, labels, err := namespaceComparisonPayload(last.Payload)
+			if err != nil {
+				glog.Errorf("Failed to read %v %v/%v: %v", kind, id.namespace, id.name, err)
+				continue
+			}
+			labelValue := ""
+			if matchLabel != "" {
+				value, ok := labels[matchLabel]
+				if !ok {
+					continue
+				}
+				labelValue = value
+			}
+			states = append(states, namespaceComparisonState{kind: kind, name: id.name, labelValue: labelValue, state: state})
+		}
+	}
+	sort.Slice(states, func(i, j int) bool {
+		if states[i].kind != states[j].kind {
+			return states[i].kind < states[j].kind
+		}
+		return states[i].name < states[j].name
+	})
+	return states, nil
+}
+
+// Returns the payload as JSON without status and with only the labels and annotations of its metadata, along with
+// the labels
+func namespaceComparisonPayload(payload string) (string, map[string]string, error) {
+	resource := map[string]interface{}{}
+	err := json.Unmarshal([]byte(payload), &resource)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "failed to parse payload")
+	}
+	labels := map[string]string{}
+	if metadata, ok := resource["metadata"].(map[string]interface{}); ok {
+		if labelValues, ok := metadata["labels"].(map[string]interface{}); ok {
+			for key, value := range labelValues {
+				labels[key] = fmt.Sprint(value)
+			}
+		}
+		annotations, _ := metadata["annotations"].(map[string]interface{})
+		for key := range namespaceComparisonIgnoredAnnotations {
+			delete(annotations, key)
+		}
+		resource["metadata"] = map[string]interface{}{"labels": metadata["labels"], "annotations": annotations}
+	}
+	delete(resource, "status")
+	// Keys of maps are sorted, so the same state always has the same JSON
+	state, err := json.Marshal(resource)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "failed to marshal payload")
+	}
+	return string(state), labels, nil
+}
+
+// Both are sorted by kind and name
+func compareNamespaceStates(states []namespaceComparisonState, otherStates []namespaceComparisonState, byLabel bool) NamespaceComparisonOutput {
+	output := NamespaceComparisonOutput{
+		Different:            []NamespaceComparisonPair{},
+		OnlyInNamespace:      []NamespaceComparisonResource{},
+		OnlyInOtherNamespace: []NamespaceComparisonResource{},
+	}
+	matchKey := func(state namespaceComparisonState) string {
+		if byLabel {
+			return state.kind + "/" + state.labelValue
+		}
+		return state.kind + "/" + state.name
+	}
+	others := map[string][]namespaceComparisonState{}
+	for _, other := range otherStates {
+		key := matchKey(other)
+		others[key] = append(others[key], other)
+	}
+	for _, state := range states {
+		key := matchKey(state)
+		if len(others[key]) == 0 {
+			output.OnlyInNamespace = append(output.OnlyInNamespace, NamespaceComparisonResource{Kind: state.kind, Name: state.name, LabelValue: state.labelValue})
+			continue
+		}
+		other := others[key][0]
+		others[key] = others[key][1:]
+		pair, err := diffNamespaceStates(state, other)
+		if err != nil {
+			glog.Errorf("Failed to compare %v %v with %v: %v", state.kind, state.name, other.name, err)
+			continue
+		}
+		if pair.ChangedPathCount == 0 {
+			output.Identical++
+			continue
+		}
+		output.Different = append(output.Different, pair)
+	}
+	for _, other := range otherStates {
+		key := matchKey(other)
+		for _, left := range others[key] {
+			if left.name == other.name {
+				output.OnlyInOtherNamespace = append(output.OnlyInOtherNamespace, NamespaceComparisonResource{Kind: other.kind, Name: other.name, LabelValue: other.labelValue})
+			}
+		}
+	}
+	return output
+}
+
+func diffNamespaceStates(state namespaceComparisonState, other namespaceComparisonState) (NamespaceComparisonPair, error) {
+	pair := NamespaceComparisonPair{Kind: state.kind, Name: state.name, OtherName: other.name, LabelValue: state.labelValue, Differences: []NamespaceComparisonField{}}
+	// The value of a path in one namespace is its value after changing from the other
+	otherValues, err := kubeextractor.ComputeChangedPathValues(state.state, other.state)
+	if err != nil {
+		return pair, err
+	}
+	values, err := kubeextractor.ComputeChangedPathValues(other.state, state.state)
+	if err != nil {
+		return pair, err
+	}
+	paths := []string{}
+	for path := range otherValues {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	pair.ChangedPathCount = len(paths)
+	if len(paths) > maxSnapshotDiffPaths {
+		paths = paths[:maxSnapshotDiffPaths]
+	}
+	for _, path := range paths {
+		pair.Differences = append(pair.Differences, NamespaceComparisonField{Path: path, Value: values[path], OtherValue: otherValues[path]})
+	}
+	return pair, nil
+}
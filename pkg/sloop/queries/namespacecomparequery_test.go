@@ -0,0 +1,133 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+func helper_namespaceComparisonPayload(namespace string, name string, app string, image string) string {
+	return fmt.Sprintf(`{"metadata":{"name":"%v","namespace":"%v","uid":"%v-%v","labels":{"app":"%v"},"annotations":{"kubectl.kubernetes.io/last-applied-configuration":"%v"}},"spec":{"image":"%v"},"status":{"ready":"%v"}}`,
+		name, namespace, namespace, name, app, namespace, image, namespace)
+}
+
+func helper_namespaceComparisonTables(t *testing.T) typed.Tables {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+
+	results := []struct {
+		kind      string
+		namespace string
+		name      string
+		offset    time.Duration
+		watchType typed.KubeWatchResult_WatchType
+		payload   string
+	}{
+		// Same spec, only status, uid and the last applied configuration differ
+		{"Deployment", "staging", "web", 10 * time.Minute, typed.KubeWatchResult_UPDATE, helper_namespaceComparisonPayload("staging", "web", "web", "web:2")},
+		{"Deployment", "prod", "web", -30 * time.Minute, typed.KubeWatchResult_UPDATE, helper_namespaceComparisonPayload("prod", "web", "web", "web:1")},
+		{"Deployment", "prod", "web", 10 * time.Minute, typed.KubeWatchResult_UPDATE, helper_namespaceComparisonPayload("prod", "web", "web", "web:2")},
+		// Different images, with different names
+		{"Deployment", "staging", "api-staging", 10 * time.Minute, typed.KubeWatchResult_UPDATE, helper_namespaceComparisonPayload("staging", "api-staging", "api", "api:3")},
+		{"Deployment", "prod", "api-prod", 10 * time.Minute, typed.KubeWatchResult_UPDATE, helper_namespaceComparisonPayload("prod", "api-prod", "api", "api:2")},
+		// Only in staging, and deleted from prod before the end
+		{"Deployment", "staging", "canary", 10 * time.Minute, typed.KubeWatchResult_UPDATE, helper_namespaceComparisonPayload("staging", "canary", "canary", "canary:1")},
+		{"Deployment", "prod", "canary", -30 * time.Minute, typed.KubeWatchResult_UPDATE, helper_namespaceComparisonPayload("prod", "canary", "canary", "canary:1")},
+		{"Deployment", "prod", "canary", 20 * time.Minute, typed.KubeWatchResult_DELETE, helper_namespaceComparisonPayload("prod", "canary", "canary", "canary:1")},
+		// Events and other namespaces are left out
+		{"Event", "prod", "e1", 10 * time.Minute, typed.KubeWatchResult_ADD, `{"reason":"Scheduled"}`},
+		{"Deployment", "dev", "web", 10 * time.Minute, typed.KubeWatchResult_UPDATE, helper_namespaceComparisonPayload("dev", "web", "web", "web:9")},
+	}
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		for _, result := range results {
+			keyTs := someTs.Add(result.offset)
+			key := typed.NewWatchTableKey(untyped.GetPartitionId(keyTs), result.kind, result.namespace, result.name, keyTs)
+			ts, _ := ptypes.TimestampProto(keyTs)
+			err := tables.WatchTable().Set(txn, key.String(), &typed.KubeWatchResult{Kind: result.kind, Timestamp: ts, WatchType: result.watchType, Payload: result.payload})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+	return tables
+}
+
+func Test_GetNamespaceComparison_ByName(t *testing.T) {
+	tables := helper_namespaceComparisonTables(t)
+	values := helper_get_params()
+	values[NamespaceParam] = []string{"staging"}
+	values[OtherNamespaceParam] = []string{"prod"}
+	data, err := GetNamespaceComparison(values, tables, someTs, someTs.Add(time.Hour), someRequestId)
+	assert.Nil(t, err)
+	output := NamespaceComparisonOutput{}
+	assert.Nil(t, json.Unmarshal(data, &output))
+
+	assert.Equal(t, "staging", output.Namespace)
+	assert.Equal(t, "prod", output.OtherNamespace)
+	assert.Equal(t, someTs.Add(time.Hour).Unix(), output.At)
+	assert.Equal(t, 1, output.Identical)
+	assert.Len(t, output.Different, 0)
+	assert.Equal(t, []NamespaceComparisonResource{{Kind: "Deployment", Name: "api-staging"}, {Kind: "Deployment", Name: "canary"}}, output.OnlyInNamespace)
+	assert.Equal(t, []NamespaceComparisonResource{{Kind: "Deployment", Name: "api-prod"}}, output.OnlyInOtherNamespace)
+}
+
+func Test_GetNamespaceComparison_ByLabel(t *testing.T) {
+	tables := helper_namespaceComparisonTables(t)
+	values := helper_get_params()
+	values[NamespaceParam] = []string{"staging"}
+	values[OtherNamespaceParam] = []string{"prod"}
+	values[MatchLabelParam] = []string{"app"}
+	data, err := GetNamespaceComparison(values, tables, someTs, someTs.Add(time.Hour), someRequestId)
+	assert.Nil(t, err)
+	output := NamespaceComparisonOutput{}
+	assert.Nil(t, json.Unmarshal(data, &output))
+
+	assert.Equal(t, "app", output.MatchLabel)
+	assert.Equal(t, 1, output.Identical)
+	assert.Len(t, output.Different, 1)
+	different := output.Different[0]
+	assert.Equal(t, "api-staging", different.Name)
+	assert.Equal(t, "api-prod", different.OtherName)
+	assert.Equal(t, "api", different.LabelValue)
+	assert.Equal(t, 1, different.ChangedPathCount)
+	assert.Equal(t, []NamespaceComparisonField{{Path: "spec.image", Value: `"api:3"`, OtherValue: `"api:2"`}}, different.Differences)
+	assert.Equal(t, []NamespaceComparisonResource{{Kind: "Deployment", Name: "canary", LabelValue: "canary"}}, output.OnlyInNamespace)
+	assert.Len(t, output.OnlyInOtherNamespace, 0)
+}
+
+func Test_GetNamespaceComparison_Params(t *testing.T) {
+	tables := helper_namespaceComparisonTables(t)
+	values := helper_get_params()
+	values[NamespaceParam] = []string{"staging"}
+	_, err := GetNamespaceComparison(values, tables, someTs, someTs.Add(time.Hour), someRequestId)
+	assert.NotNil(t, err)
+
+	values[OtherNamespaceParam] = []string{"staging"}
+	_, err = GetNamespaceComparison(values, tables, someTs, someTs.Add(time.Hour), someRequestId)
+	assert.NotNil(t, err)
+
+	values[OtherNamespaceParam] = []string{"prod"}
+	values[KindParam] = []string{"Node"}
+	_, err = GetNamespaceComparison(values, tables, someTs, someTs.Add(time.Hour), someRequestId)
+	assert.NotNil(t, err)
+}
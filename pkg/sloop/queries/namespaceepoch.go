@@ -0,0 +1,200 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+// Value of NamespaceEpochParam that only keeps resources of the newest epoch of each namespace
+const CurrentNamespaceEpoch = "current"
+
+// An epoch is one incarnation of a namespace.  When a namespace is deleted and created again with the same name it
+// gets a new uid, but the keys of the resources in it only have the name.  We tell them apart by create time: a
+// resource belongs to the newest epoch of its namespace that started before it was created
+type namespaceEpoch struct {
+	uid   string
+	start time.Time
+	// Zero until we see the namespace deleted
+	end time.Time
+}
+
+type NamespaceEpochOutput struct {
+	Namespace string `json:"namespace"`
+	Uid       string `json:"uid"`
+	Start     int64  `json:"start"`
+	End       int64  `json:"end,omitempty"`
+	Current   bool   `json:"current"`
+}
+
+// Reads the Namespace rows of the resource summary table and returns the epochs of each namespace name sorted by
+// start time
+func getNamespaceEpochs(t typed.Tables, txn badgerwrap.Txn, startTime time.Time, endTime time.Time) (map[string][]namespaceEpoch, error) {
+	prefix := typed.NewResourceSummaryKeyComparator(kubeextractor.NamespaceKind, "", "", "")
+	summaries, _, err := t.ResourceSummaryTable().RangeRead(txn, prefix, nil, nil, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+
+	byUid := map[string]map[string]*namespaceEpoch{}
+	for key, summary := range summaries {
+		if key.Kind != kubeextractor.NamespaceKind {
+			continue
+		}
+		start, err := ptypes.Timestamp(summary.CreateTime)
+		if err != nil || start.Unix() <= 0 {
+			start, err = ptypes.Timestamp(summary.FirstSeen)
+			if err != nil {
+				return nil, err
+			}
+		}
+		var end time.Time
+		if summary.DeletedAtEnd {
+			end, err = ptypes.Timestamp(summary.LastSeen)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if byUid[key.Name] == nil {
+			byUid[key.Name] = map[string]*namespaceEpoch{}
+		}
+		// The same uid shows up once per partition
+		epoch, ok := byUid[key.Name][key.Uid]
+		if !ok {
+			byUid[key.Name][key.Uid] = &namespaceEpoch{uid: key.Uid, start: start, end: end}
+			continue
+		}
+		if start.Before(epoch.start) {
+			epoch.start = start
+		}
+		if end.After(epoch.end) {
+			epoch.end = end
+		}
+	}
+
+	ret := map[string][]namespaceEpoch{}
+	for name, epochs := range byUid {
+		for _, epoch := range epochs {
+			ret[name] = append(ret[name], *epoch)
+		}
+		sort.Slice(ret[name], func(i, j int) bool { return ret[name][i].start.Before(ret[name][j].start) })
+	}
+	return ret, nil
+}
+
+// Returns the uid of the epoch a resource created at createTime belongs to, or "" when it is older than all of them
+func namespaceEpochUidAt(epochs []namespaceEpoch, createTime time.Time) string {
+	uid := ""
+	for _, epoch := range epochs {
+		if epoch.start.After(createTime) {
+			break
+		}
+		uid = epoch.uid
+	}
+	return uid
+}
+
+// Returns the namespace uid of a resource summary row, or "" when it is not known
+func namespaceUidForResource(key typed.ResourceSummaryKey, summary *typed.ResourceSummary, epochs map[string][]namespaceEpoch) string {
+	if key.Kind == kubeextractor.NamespaceKind {
+		return key.Uid
+	}
+	nsEpochs := epochs[key.Namespace]
+	if len(nsEpochs) == 0 {
+		return ""
+	}
+	createTime, err := ptypes.Timestamp(summary.CreateTime)
+	if err != nil || createTime.Unix() <= 0 {
+		createTime, err = ptypes.Timestamp(summary.FirstSeen)
+		if err != nil {
+			return ""
+		}
+	}
+	uid := namespaceEpochUidAt(nsEpochs, createTime)
+	if uid == "" && len(nsEpochs) == 1 {
+		// Created before the only epoch we know of started, most likely the namespace has been around longer
+		// than our data and there is nothing to disambiguate
+		return nsEpochs[0].uid
+	}
+	return uid
+}
+
+// Removes resources that do not belong to the newest epoch of their namespace.  Resources in namespaces we have no
+// Namespace rows for are kept
+func filterToCurrentNamespaceEpoch(summaries map[typed.ResourceSummaryKey]*typed.ResourceSummary, epochs map[string][]namespaceEpoch) {
+	for key, summary := range summaries {
+		var nsEpochs []namespaceEpoch
+		if key.Kind == kubeextractor.NamespaceKind {
+			nsEpochs = epochs[key.Name]
+		} else {
+			nsEpochs = epochs[key.Namespace]
+		}
+		if len(nsEpochs) == 0 {
+			continue
+		}
+		if namespaceUidForResource(key, summary, epochs) != nsEpochs[len(nsEpochs)-1].uid {
+			delete(summaries, key)
+		}
+	}
+}
+
+// Lists the epochs of each namespace in the time range.  The newest epoch of a namespace is flagged as current
+func GetNamespaceEpochs(params url.Values, t typed.Tables, startTime time.Time, endTime time.Time, requestId string) ([]byte, error) {
+	selectedNamespace := params.Get(NamespaceParam)
+
+	var epochs map[string][]namespaceEpoch
+	err := t.Db().View(func(txn badgerwrap.Txn) error {
+		var err error
+		epochs, err = getNamespaceEpochs(t, txn, startTime, endTime)
+		return err
+	})
+	if err != nil {
+		return []byte{}, err
+	}
+
+	output := []NamespaceEpochOutput{}
+	for name, nsEpochs := range epochs {
+		if selectedNamespace != "" && selectedNamespace != AllNamespaces && selectedNamespace != name {
+			continue
+		}
+		for idx, epoch := range nsEpochs {
+			row := NamespaceEpochOutput{
+				Namespace: name,
+				Uid:       epoch.uid,
+				Start:     epoch.start.Unix(),
+				Current:   idx == len(nsEpochs)-1,
+			}
+			if !epoch.end.IsZero() {
+				row.End = epoch.end.Unix()
+			}
+			output = append(output, row)
+		}
+	}
+	sort.Slice(output, func(i, j int) bool {
+		if output[i].Namespace != output[j].Namespace {
+			return output[i].Namespace < output[j].Namespace
+		}
+		return output[i].Start < output[j].Start
+	})
+
+	bytes, err := json.MarshalIndent(output, "", " ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal json %v", err)
+	}
+	return bytes, nil
+}
@@ -0,0 +1,141 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+func helper_resSum(t *testing.T, createTime time.Time, lastSeen time.Time, deleted bool) *typed.ResourceSummary {
+	create, err := ptypes.TimestampProto(createTime)
+	assert.Nil(t, err)
+	last, err := ptypes.TimestampProto(lastSeen)
+	assert.Nil(t, err)
+	return &typed.ResourceSummary{FirstSeen: create, CreateTime: create, LastSeen: last, DeletedAtEnd: deleted}
+}
+
+// Namespace some-namespace is deleted and created again, and each epoch has a pod named pod-a
+func helper_getNamespaceEpochTables(t *testing.T) typed.Tables {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	recreated := someTs.Add(20 * time.Minute)
+	partitionId := untyped.GetPartitionId(someTs)
+	rows := map[*typed.ResourceSummaryKey]*typed.ResourceSummary{
+		typed.NewResourceSummaryKey(someTs, kubeextractor.NamespaceKind, "", "some-namespace", "ns-uid-1"): helper_resSum(t, someTs.Add(-time.Hour), someTs.Add(10*time.Minute), true),
+		typed.NewResourceSummaryKey(someTs, kubeextractor.NamespaceKind, "", "some-namespace", "ns-uid-2"): helper_resSum(t, recreated, recreated.Add(time.Minute), false),
+		typed.NewResourceSummaryKey(someTs, "Pod", "some-namespace", "pod-a", "pod-uid-1"):                 helper_resSum(t, someTs, someTs.Add(5*time.Minute), true),
+		typed.NewResourceSummaryKey(someTs, "Pod", "some-namespace", "pod-a", "pod-uid-2"):                 helper_resSum(t, recreated.Add(time.Minute), recreated.Add(2*time.Minute), false),
+		typed.NewResourceSummaryKey(someTs, "Pod", "other", "pod-b", "pod-uid-3"):                          helper_resSum(t, someTs, someTs.Add(5*time.Minute), false),
+	}
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		for key, value := range rows {
+			key.PartitionId = partitionId
+			err := tables.ResourceSummaryTable().Set(txn, key.String(), value)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+	return tables
+}
+
+func Test_getNamespaceEpochs(t *testing.T) {
+	tables := helper_getNamespaceEpochTables(t)
+	err := tables.Db().View(func(txn badgerwrap.Txn) error {
+		epochs, err := getNamespaceEpochs(tables, txn, someTs, someTs.Add(time.Hour))
+		assert.Nil(t, err)
+		assert.Len(t, epochs, 1)
+		assert.Len(t, epochs["some-namespace"], 2)
+		assert.Equal(t, "ns-uid-1", epochs["some-namespace"][0].uid)
+		assert.Equal(t, someTs.Add(10*time.Minute).Unix(), epochs["some-namespace"][0].end.Unix())
+		assert.Equal(t, "ns-uid-2", epochs["some-namespace"][1].uid)
+		assert.True(t, epochs["some-namespace"][1].end.IsZero())
+
+		assert.Equal(t, "", namespaceEpochUidAt(epochs["some-namespace"], someTs.Add(-2*time.Hour)))
+		assert.Equal(t, "ns-uid-1", namespaceEpochUidAt(epochs["some-namespace"], someTs))
+		assert.Equal(t, "ns-uid-2", namespaceEpochUidAt(epochs["some-namespace"], someTs.Add(30*time.Minute)))
+		return nil
+	})
+	assert.Nil(t, err)
+}
+
+func Test_filterToCurrentNamespaceEpoch(t *testing.T) {
+	tables := helper_getNamespaceEpochTables(t)
+	err := tables.Db().View(func(txn badgerwrap.Txn) error {
+		epochs, err := getNamespaceEpochs(tables, txn, someTs, someTs.Add(time.Hour))
+		assert.Nil(t, err)
+		summaries, _, err := tables.ResourceSummaryTable().RangeRead(txn, nil, nil, nil, someTs, someTs.Add(time.Hour))
+		assert.Nil(t, err)
+		assert.Len(t, summaries, 5)
+
+		filterToCurrentNamespaceEpoch(summaries, epochs)
+		uids := []string{}
+		for key := range summaries {
+			uids = append(uids, key.Uid)
+		}
+		assert.ElementsMatch(t, []string{"ns-uid-2", "pod-uid-2", "pod-uid-3"}, uids)
+		return nil
+	})
+	assert.Nil(t, err)
+}
+
+func Test_GetNamespaceEpochs(t *testing.T) {
+	tables := helper_getNamespaceEpochTables(t)
+	values := helper_get_params()
+	data, err := GetNamespaceEpochs(values, tables, someTs, someTs.Add(time.Hour), someRequestId)
+	assert.Nil(t, err)
+	output := []NamespaceEpochOutput{}
+	assert.Nil(t, json.Unmarshal(data, &output))
+	assert.Equal(t, []NamespaceEpochOutput{
+		{Namespace: "some-namespace", Uid: "ns-uid-1", Start: someTs.Add(-time.Hour).Unix(), End: someTs.Add(10 * time.Minute).Unix()},
+		{Namespace: "some-namespace", Uid: "ns-uid-2", Start: someTs.Add(20 * time.Minute).Unix(), Current: true},
+	}, output)
+
+	values[NamespaceParam] = []string{"other"}
+	data, err = GetNamespaceEpochs(values, tables, someTs, someTs.Add(time.Hour), someRequestId)
+	assert.Nil(t, err)
+	assert.Equal(t, "[]", string(data))
+}
+
+func Test_EventHeatMap3Query_CurrentNamespaceEpoch(t *testing.T) {
+	tables := helper_getNamespaceEpochTables(t)
+	values := helper_get_params()
+	values[KindParam] = []string{"Pod"}
+	values[NamespaceEpochParam] = []string{CurrentNamespaceEpoch}
+	data, err := EventHeatMap3Query(values, tables, someTs, someTs.Add(time.Hour), someRequestId)
+	assert.Nil(t, err)
+	output := TimelineRoot{}
+	assert.Nil(t, json.Unmarshal(data, &output))
+	assert.Len(t, output.Rows, 1)
+	assert.Equal(t, "ns-uid-2", output.Rows[0].NamespaceUid)
+
+	delete(values, NamespaceEpochParam)
+	data, err = EventHeatMap3Query(values, tables, someTs, someTs.Add(time.Hour), someRequestId)
+	assert.Nil(t, err)
+	output = TimelineRoot{}
+	assert.Nil(t, json.Unmarshal(data, &output))
+	nsUids := []string{}
+	for _, row := range output.Rows {
+		nsUids = append(nsUids, row.NamespaceUid)
+	}
+	assert.ElementsMatch(t, []string{"ns-uid-1", "ns-uid-2"}, nsUids)
+}
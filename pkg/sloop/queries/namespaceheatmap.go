@@ -0,0 +1,129 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"sort"
+	"time"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+// The default bucket is the smallest multiple of typed.EventHeatMapBucket that fits the time range in this many columns
+const namespaceHeatMapMaxColumns = 120
+
+type NamespaceHeatMapOutput struct {
+	// Unix seconds, start and end rounded out to whole buckets
+	Start         int64                 `json:"start"`
+	End           int64                 `json:"end"`
+	BucketSeconds int64                 `json:"bucketSeconds"`
+	Namespaces    []NamespaceHeatMapRow `json:"namespaces"`
+}
+
+// Namespaces with the most events come first
+type NamespaceHeatMapRow struct {
+	Namespace string `json:"namespace"`
+	Total     int64  `json:"total"`
+	// Only the buckets with events, oldest first
+	Cells []NamespaceHeatMapCell `json:"cells"`
+}
+
+type NamespaceHeatMapCell struct {
+	// Unix seconds of the start of the bucket
+	Timestamp int64 `json:"timestamp"`
+	// By event type, like Normal or Warning
+	Counts map[string]int64 `json:"counts"`
+}
+
+// Returns bucket when it is set, otherwise the smallest multiple of typed.EventHeatMapBucket giving at most
+// namespaceHeatMapMaxColumns columns.  A bucket that is not a multiple of typed.EventHeatMapBucket is a bad param
+func NamespaceHeatMapBucket(startTime time.Time, endTime time.Time, bucket time.Duration) (time.Duration, error) {
+	if bucket == 0 {
+		multiple := (endTime.Sub(startTime)/typed.EventHeatMapBucket + namespaceHeatMapMaxColumns - 1) / namespaceHeatMapMaxColumns
+		if multiple < 1 {
+			multiple = 1
+		}
+		return multiple * typed.EventHeatMapBucket, nil
+	}
+	if bucket < 0 || bucket%typed.EventHeatMapBucket != 0 {
+		return 0, NewApiError(ErrorCodeBadParams, "%v must be a multiple of %v, got %v", BucketParam, typed.EventHeatMapBucket, bucket)
+	}
+	return bucket, nil
+}
+
+/*
+Returns the event counts of each namespace by time bucket and event type from the event heat map table, which
+processing keeps summed at ingestion.  This reads one key per namespace and partition, so unlike EventHeatMap it does
+not slow down with the number of involved objects.  An empty namespace returns all of them
+*/
+func GetNamespaceEventHeatMap(tables typed.Tables, startTime time.Time, endTime time.Time, bucket time.Duration, namespace string) (*NamespaceHeatMapOutput, error) {
+	bucket, err := NamespaceHeatMapBucket(startTime, endTime, bucket)
+	if err != nil {
+		return nil, err
+	}
+	firstBucket := startTime.UTC().Truncate(bucket)
+	lastBucket := endTime.UTC().Truncate(bucket)
+	output := &NamespaceHeatMapOutput{
+		Start:         firstBucket.Unix(),
+		End:           lastBucket.Add(bucket).Unix(),
+		BucketSeconds: int64(bucket / time.Second),
+		Namespaces:    []NamespaceHeatMapRow{},
+	}
+
+	var records map[typed.EventHeatMapKey]*typed.ResourceEventCounts
+	err = tables.Db().View(func(txn badgerwrap.Txn) error {
+		var err2 error
+		records, _, err2 = typed.OpenEventHeatMapTable().RangeRead(txn, firstBucket, lastBucket.Add(bucket-time.Nanosecond))
+		return err2
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rows := map[string]map[int64]map[string]int64{}
+	totals := map[string]int64{}
+	for key, record := range records {
+		if namespace != "" && key.Namespace != namespace {
+			continue
+		}
+		for unixTime, counts := range record.MapMinToEvents {
+			ts := time.Unix(unixTime, 0).UTC()
+			if ts.Before(firstBucket) || !ts.Before(lastBucket.Add(bucket)) {
+				continue
+			}
+			if _, ok := rows[key.Namespace]; !ok {
+				rows[key.Namespace] = map[int64]map[string]int64{}
+			}
+			column := ts.Truncate(bucket).Unix()
+			if _, ok := rows[key.Namespace][column]; !ok {
+				rows[key.Namespace][column] = map[string]int64{}
+			}
+			for severity, count := range counts.MapReasonToCount {
+				rows[key.Namespace][column][severity] += int64(count)
+				totals[key.Namespace] += int64(count)
+			}
+		}
+	}
+
+	for ns, columns := range rows {
+		row := NamespaceHeatMapRow{Namespace: ns, Total: totals[ns]}
+		for column, counts := range columns {
+			row.Cells = append(row.Cells, NamespaceHeatMapCell{Timestamp: column, Counts: counts})
+		}
+		sort.Slice(row.Cells, func(i, j int) bool { return row.Cells[i].Timestamp < row.Cells[j].Timestamp })
+		output.Namespaces = append(output.Namespaces, row)
+	}
+	sort.Slice(output.Namespaces, func(i, j int) bool {
+		if output.Namespaces[i].Total != output.Namespaces[j].Total {
+			return output.Namespaces[i].Total > output.Namespaces[j].Total
+		}
+		return output.Namespaces[i].Namespace < output.Namespaces[j].Namespace
+	})
+	return output, nil
+}
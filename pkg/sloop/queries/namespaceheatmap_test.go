@@ -0,0 +1,87 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+func helper_setEventHeatMap(t *testing.T, tables typed.Tables, namespace string, bucket time.Time, counts map[string]int32) {
+	table := typed.OpenEventHeatMapTable()
+	err := tables.Db().Update(func(txn badgerwrap.Txn) error {
+		key := table.Key(bucket, namespace)
+		record, err := table.GetOrDefault(txn, key)
+		if err != nil {
+			return err
+		}
+		record.MapMinToEvents[bucket.Unix()] = &typed.EventCounts{MapReasonToCount: counts}
+		return table.Set(txn, key, record)
+	})
+	assert.Nil(t, err)
+}
+
+func Test_GetNamespaceEventHeatMap(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	start := time.Date(2019, 3, 1, 3, 0, 0, 0, time.UTC)
+	helper_setEventHeatMap(t, tables, "a", start, map[string]int32{"Normal": 1})
+	helper_setEventHeatMap(t, tables, "a", start.Add(5*time.Minute), map[string]int32{"Normal": 2, "Warning": 3})
+	helper_setEventHeatMap(t, tables, "a", start.Add(70*time.Minute), map[string]int32{"Warning": 4})
+	helper_setEventHeatMap(t, tables, "b", start.Add(65*time.Minute), map[string]int32{"Warning": 20})
+	// Outside of the time range
+	helper_setEventHeatMap(t, tables, "b", start.Add(3*time.Hour), map[string]int32{"Warning": 100})
+
+	output, err := GetNamespaceEventHeatMap(tables, start.Add(time.Minute), start.Add(75*time.Minute), 10*time.Minute, "")
+	assert.Nil(t, err)
+	assert.Equal(t, start.Unix(), output.Start)
+	assert.Equal(t, start.Add(80*time.Minute).Unix(), output.End)
+	assert.Equal(t, int64(600), output.BucketSeconds)
+	assert.Equal(t, []NamespaceHeatMapRow{
+		{Namespace: "b", Total: 20, Cells: []NamespaceHeatMapCell{
+			{Timestamp: start.Add(60 * time.Minute).Unix(), Counts: map[string]int64{"Warning": 20}},
+		}},
+		{Namespace: "a", Total: 10, Cells: []NamespaceHeatMapCell{
+			{Timestamp: start.Unix(), Counts: map[string]int64{"Normal": 3, "Warning": 3}},
+			{Timestamp: start.Add(70 * time.Minute).Unix(), Counts: map[string]int64{"Warning": 4}},
+		}},
+	}, output.Namespaces)
+
+	output, err = GetNamespaceEventHeatMap(tables, start, start.Add(75*time.Minute), 0, "a")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(300), output.BucketSeconds)
+	assert.Len(t, output.Namespaces, 1)
+	assert.Len(t, output.Namespaces[0].Cells, 3)
+
+	output, err = GetNamespaceEventHeatMap(tables, start, start.Add(75*time.Minute), 0, "none")
+	assert.Nil(t, err)
+	assert.Len(t, output.Namespaces, 0)
+
+	_, err = GetNamespaceEventHeatMap(tables, start, start.Add(75*time.Minute), 7*time.Minute, "")
+	assert.NotNil(t, err)
+	assert.Equal(t, ErrorCodeBadParams, ErrorCodeOf(err))
+}
+
+func Test_NamespaceHeatMapBucket_Default(t *testing.T) {
+	start := time.Date(2019, 3, 1, 3, 0, 0, 0, time.UTC)
+	bucket, err := NamespaceHeatMapBucket(start, start.Add(10*time.Hour), 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 5*time.Minute, bucket)
+	bucket, err = NamespaceHeatMapBucket(start, start.Add(14*24*time.Hour), 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 170*time.Minute, bucket)
+}
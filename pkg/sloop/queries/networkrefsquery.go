@@ -0,0 +1,161 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+type NetworkReferenceOutput struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Where the ip or node was found, like status.podIP or spec.nodeName
+	Fields []string `json:"fields"`
+	// When the resource started and stopped referring to the ip or node within the time range
+	From int64 `json:"from"`
+	To   int64 `json:"to"`
+	// Still referred to at the end of the time range
+	Current bool `json:"current"`
+}
+
+/*
+Returns the pods, services, endpoints and nodes that referred to an ip address (ip) or node name (node) over the time
+range, for working out what was behind an address during a network incident.  There is no ip index, so the payloads
+of those kinds are searched.  Setting namespace limits the search to that namespace, which leaves out nodes like
+other cluster scoped resources
+*/
+func GetNetworkReferences(params url.Values, t typed.Tables, startTime time.Time, endTime time.Time, requestId string) ([]byte, error) {
+	selectedIp := params.Get(IpParam)
+	selectedNode := params.Get(NodeParam)
+	if (selectedIp == "") == (selectedNode == "") {
+		return nil, NewApiError(ErrorCodeBadParams, "one of %v or %v is required", IpParam, NodeParam)
+	}
+	if selectedIp != "" {
+		if net.ParseIP(selectedIp) == nil {
+			return nil, NewApiError(ErrorCodeBadParams, "%v %q is not an ip address", IpParam, selectedIp)
+		}
+		selectedIp = kubeextractor.NormalizeIp(selectedIp)
+	}
+	selectedNamespace := defaultParam(params.Get(NamespaceParam), AllNamespaces)
+
+	output := []NetworkReferenceOutput{}
+	err := t.Db().View(func(txn badgerwrap.Txn) error {
+		for _, kind := range kubeextractor.NetworkReferenceKinds {
+			namespace := selectedNamespace
+			if kind == kubeextractor.NodeKind {
+				if selectedNamespace != AllNamespaces {
+					continue
+				}
+				namespace = ""
+			}
+			resources, err := readNamespacedWatchRecords(txn, t, kind, namespace, startTime, endTime, requestId)
+			if err != nil {
+				return err
+			}
+			for id, records := range resources {
+				ref, ok := networkReference(kind, records, selectedIp, selectedNode, startTime, endTime)
+				if !ok {
+					continue
+				}
+				ref.Kind = kind
+				ref.Namespace = id.namespace
+				ref.Name = id.name
+				output = append(output, ref)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return []byte{}, err
+	}
+
+	sort.Slice(output, func(i, j int) bool {
+		if output[i].From != output[j].From {
+			return output[i].From < output[j].From
+		}
+		if output[i].Kind != output[j].Kind {
+			return output[i].Kind < output[j].Kind
+		}
+		if output[i].Namespace != output[j].Namespace {
+			return output[i].Namespace < output[j].Namespace
+		}
+		return output[i].Name < output[j].Name
+	})
+	bytes, err := json.MarshalIndent(output, "", " ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal json %v", err)
+	}
+	return bytes, nil
+}
+
+// Walks the versions of one resource like credentialUsage.  An ip that moves between resources, like a pod IP being
+// reused, shows up as one row per resource with the times each held it
+func networkReference(kind string, records []watchRecord, ip string, node string, startTime time.Time, endTime time.Time) (NetworkReferenceOutput, bool) {
+	ref := NetworkReferenceOutput{}
+	found := false
+	fields := map[string]bool{}
+	for _, record := range records {
+		if record.timestamp > endTime.Unix() {
+			break
+		}
+		refs, err := kubeextractor.ExtractNetworkReferences(kind, record.result.Payload)
+		if err != nil {
+			glog.Errorf("Failed to extract network references: %v", err)
+			continue
+		}
+		var matched []string
+		if ip != "" {
+			matched = refs.Ips[ip]
+		} else {
+			matched = refs.Nodes[node]
+		}
+		refers := len(matched) > 0
+		for _, field := range matched {
+			fields[field] = true
+		}
+		deleted := record.result.WatchType == typed.KubeWatchResult_DELETE
+		timestamp := record.timestamp
+		if timestamp < startTime.Unix() {
+			timestamp = startTime.Unix()
+		}
+
+		switch {
+		case refers && !deleted && !ref.Current:
+			if !found {
+				ref.From = timestamp
+			}
+			found = true
+			ref.Current = true
+		case ref.Current && (!refers || deleted):
+			ref.To = timestamp
+			ref.Current = false
+		}
+	}
+	if !found {
+		return ref, false
+	}
+	if ref.Current {
+		ref.To = endTime.Unix()
+	}
+	for field := range fields {
+		ref.Fields = append(ref.Fields, field)
+	}
+	sort.Strings(ref.Fields)
+	return ref, true
+}
@@ -0,0 +1,105 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+func helper_getNetworkReferencesTables(t *testing.T) typed.Tables {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	partitionId := untyped.GetPartitionId(someTs)
+	helper_setWatchResults(t, tables, map[*typed.WatchTableKey]string{
+		// Had the ip from before the time range until it was rescheduled, then the ip was reused by another pod
+		typed.NewWatchTableKey(partitionId, "Pod", "ns", "web-1", someTs.Add(-time.Minute)):       `{"spec": {"nodeName": "node1"}, "status": {"podIP": "10.1.0.5"}}`,
+		typed.NewWatchTableKey(partitionId, "Pod", "ns", "web-1", someTs.Add(10*time.Minute)):     `{"spec": {"nodeName": "node2"}, "status": {"podIP": "10.2.0.7"}}`,
+		typed.NewWatchTableKey(partitionId, "Pod", "other", "db-1", someTs.Add(20*time.Minute)):   `{"spec": {"nodeName": "node1"}, "status": {"podIP": "10.1.0.5"}}`,
+		typed.NewWatchTableKey(partitionId, "Endpoint", "ns", "web", someTs.Add(2*time.Minute)):   `{"subsets": [{"addresses": [{"ip": "10.1.0.5", "nodeName": "node1"}]}]}`,
+		typed.NewWatchTableKey(partitionId, "Service", "ns", "web", someTs.Add(2*time.Minute)):    `{"spec": {"clusterIP": "10.96.0.10"}}`,
+		typed.NewWatchTableKey(partitionId, "Node", "", "node1", someTs.Add(-time.Hour/2)):        `{"metadata": {"name": "node1"}, "status": {"addresses": [{"type": "InternalIP", "address": "10.0.0.1"}]}}`,
+		typed.NewWatchTableKey(partitionId, "Deployment", "ns", "web", someTs.Add(5*time.Minute)): `{"spec": {"nodeName": "node1"}}`,
+	})
+	return tables
+}
+
+func Test_GetNetworkReferences_Ip(t *testing.T) {
+	tables := helper_getNetworkReferencesTables(t)
+	values := helper_get_params()
+	values[NamespaceParam] = []string{AllNamespaces}
+	values[IpParam] = []string{"10.1.0.5"}
+	data, err := GetNetworkReferences(values, tables, someTs, someTs.Add(time.Hour), someRequestId)
+	assert.Nil(t, err)
+	output := []NetworkReferenceOutput{}
+	assert.Nil(t, json.Unmarshal(data, &output))
+	assert.Equal(t, []NetworkReferenceOutput{
+		{Kind: "Pod", Namespace: "ns", Name: "web-1", Fields: []string{"status.podIP"}, From: someTs.Unix(), To: someTs.Add(10 * time.Minute).Unix()},
+		{Kind: "Endpoint", Namespace: "ns", Name: "web", Fields: []string{"subsets.addresses"}, From: someTs.Add(2 * time.Minute).Unix(), To: someTs.Add(time.Hour).Unix(), Current: true},
+		{Kind: "Pod", Namespace: "other", Name: "db-1", Fields: []string{"status.podIP"}, From: someTs.Add(20 * time.Minute).Unix(), To: someTs.Add(time.Hour).Unix(), Current: true},
+	}, output)
+
+	values[NamespaceParam] = []string{"other"}
+	data, err = GetNetworkReferences(values, tables, someTs, someTs.Add(time.Hour), someRequestId)
+	assert.Nil(t, err)
+	output = []NetworkReferenceOutput{}
+	assert.Nil(t, json.Unmarshal(data, &output))
+	assert.Equal(t, 1, len(output))
+	assert.Equal(t, "db-1", output[0].Name)
+}
+
+func Test_GetNetworkReferences_Node(t *testing.T) {
+	tables := helper_getNetworkReferencesTables(t)
+	values := helper_get_params()
+	values[NamespaceParam] = []string{AllNamespaces}
+	values[NodeParam] = []string{"node1"}
+	data, err := GetNetworkReferences(values, tables, someTs, someTs.Add(time.Hour), someRequestId)
+	assert.Nil(t, err)
+	output := []NetworkReferenceOutput{}
+	assert.Nil(t, json.Unmarshal(data, &output))
+	names := []string{}
+	for _, ref := range output {
+		names = append(names, ref.Kind+"/"+ref.Name)
+	}
+	assert.Equal(t, []string{"Node/node1", "Pod/web-1", "Endpoint/web", "Pod/db-1"}, names)
+}
+
+func Test_GetNetworkReferences_BadParams(t *testing.T) {
+	tables := helper_getNetworkReferencesTables(t)
+	values := helper_get_params()
+	_, err := GetNetworkReferences(values, tables, someTs, someTs.Add(time.Hour), someRequestId)
+	assert.NotNil(t, err)
+	values[IpParam] = []string{"10.1.0.5"}
+	values[NodeParam] = []string{"node1"}
+	_, err = GetNetworkReferences(values, tables, someTs, someTs.Add(time.Hour), someRequestId)
+	assert.NotNil(t, err)
+	delete(values, NodeParam)
+	values[IpParam] = []string{"web-1"}
+	_, err = GetNetworkReferences(values, tables, someTs, someTs.Add(time.Hour), someRequestId)
+	assert.NotNil(t, err)
+}
+
+func Test_explainGetNetworkReferences(t *testing.T) {
+	plans, _ := explainGetNetworkReferences(map[string][]string{NamespaceParam: {"ns"}}, someTs, someTs.Add(time.Hour))
+	assert.Equal(t, 4, len(plans))
+	assert.Equal(t, "/watch/p1/Pod/ns/", plans[0].keyPrefix("p1"))
+
+	plans, _ = explainGetNetworkReferences(map[string][]string{}, someTs, someTs.Add(time.Hour))
+	assert.Equal(t, 5, len(plans))
+	assert.Nil(t, plans[0].keyPrefix)
+	assert.Equal(t, "/watch/p1/Node//", plans[4].keyPrefix("p1"))
+}
@@ -0,0 +1,157 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+type NodeConditionOutput struct {
+	Timestamp int64  `json:"timestamp"`
+	Type      string `json:"type"`
+	Status    string `json:"status"`
+	Reason    string `json:"reason,omitempty"`
+	Message   string `json:"message,omitempty"`
+	Healthy   bool   `json:"healthy"`
+}
+
+// A time range where a condition was unhealthy.  End is 0 when it was still unhealthy at the end of the time range
+type NodeUnhealthyInterval struct {
+	Type   string `json:"type"`
+	Start  int64  `json:"start"`
+	End    int64  `json:"end"`
+	Reason string `json:"reason,omitempty"`
+}
+
+type NodeHealthOutput struct {
+	Name        string                  `json:"name"`
+	Uid         string                  `json:"uid"`
+	Transitions []NodeConditionOutput   `json:"transitions"`
+	Unhealthy   []NodeUnhealthyInterval `json:"unhealthy"`
+}
+
+// Returns the condition timeline of the nodes matching name, namematch and uuid.  The condition param limits it to
+// one condition type.  Unhealthy intervals make it easy to check which nodes were unhealthy at the time of an alert.
+func GetNodeHealth(params url.Values, t typed.Tables, startTime time.Time, endTime time.Time, requestId string) ([]byte, error) {
+	var records map[typed.NodeConditionKey]*typed.NodeConditions
+	err := t.Db().View(func(txn badgerwrap.Txn) error {
+		var err2 error
+		var stats typed.RangeReadStats
+		records, stats, err2 = t.NodeConditionTable().RangeRead(txn, nil, paramFilterNodeConditionFn(params), nil, startTime, endTime)
+		if err2 != nil {
+			return err2
+		}
+		stats.Log(requestId)
+		return nil
+	})
+	if err != nil {
+		return []byte{}, err
+	}
+
+	selectedCondition := params.Get(ConditionParam)
+	byNode := map[typed.NodeConditionKey][]*typed.NodeConditionTransition{}
+	for key, val := range records {
+		nodeKey := key
+		nodeKey.PartitionId = ""
+		for _, transition := range val.Transitions {
+			if selectedCondition != "" && transition.Type != selectedCondition {
+				continue
+			}
+			byNode[nodeKey] = append(byNode[nodeKey], transition)
+		}
+	}
+
+	output := []NodeHealthOutput{}
+	for key, transitions := range byNode {
+		node := nodeHealthInTimeRange(transitions, startTime, endTime)
+		if len(node.Transitions) == 0 {
+			continue
+		}
+		node.Name = key.Name
+		node.Uid = key.Uid
+		output = append(output, node)
+	}
+	sort.Slice(output, func(i, j int) bool {
+		if output[i].Name != output[j].Name {
+			return output[i].Name < output[j].Name
+		}
+		return output[i].Uid < output[j].Uid
+	})
+
+	bytes, err := json.MarshalIndent(output, "", " ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal json %v", err)
+	}
+	return bytes, nil
+}
+
+// Keeps the status changes in the time range, starting with the status of each condition at the start time
+func nodeHealthInTimeRange(transitions []*typed.NodeConditionTransition, startTime time.Time, endTime time.Time) NodeHealthOutput {
+	sort.SliceStable(transitions, func(i, j int) bool { return transitions[i].Timestamp < transitions[j].Timestamp })
+
+	node := NodeHealthOutput{Transitions: []NodeConditionOutput{}, Unhealthy: []NodeUnhealthyInterval{}}
+	lastByType := map[string]*typed.NodeConditionTransition{}
+	openIntervals := map[string]int{}
+	add := func(transition *typed.NodeConditionTransition) {
+		last, ok := lastByType[transition.Type]
+		// Partitions repeat the status they start with, which is not a transition
+		if ok && last.Status == transition.Status && last.Reason == transition.Reason {
+			return
+		}
+		lastByType[transition.Type] = transition
+		healthy := kubeextractor.IsNodeConditionHealthy(transition.Type, transition.Status)
+		node.Transitions = append(node.Transitions, NodeConditionOutput{
+			Timestamp: transition.Timestamp,
+			Type:      transition.Type,
+			Status:    transition.Status,
+			Reason:    transition.Reason,
+			Message:   transition.Message,
+			Healthy:   healthy,
+		})
+		idx, open := openIntervals[transition.Type]
+		if healthy && open {
+			node.Unhealthy[idx].End = transition.Timestamp
+			delete(openIntervals, transition.Type)
+		} else if !healthy && !open {
+			openIntervals[transition.Type] = len(node.Unhealthy)
+			node.Unhealthy = append(node.Unhealthy, NodeUnhealthyInterval{Type: transition.Type, Start: transition.Timestamp, Reason: transition.Reason})
+		}
+	}
+
+	before := map[string]*typed.NodeConditionTransition{}
+	var beforeTypes []string
+	for _, transition := range transitions {
+		if transition.Timestamp < startTime.Unix() {
+			if _, ok := before[transition.Type]; !ok {
+				beforeTypes = append(beforeTypes, transition.Type)
+			}
+			before[transition.Type] = transition
+			continue
+		}
+		if transition.Timestamp > endTime.Unix() {
+			break
+		}
+		for _, conditionType := range beforeTypes {
+			add(before[conditionType])
+		}
+		beforeTypes = nil
+		add(transition)
+	}
+	for _, conditionType := range beforeTypes {
+		add(before[conditionType])
+	}
+	return node
+}
@@ -0,0 +1,88 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+func helper_get_nodeConditionTable(t *testing.T) typed.Tables {
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+
+	secondPartitionTs := someTs.Add(time.Hour)
+	records := map[string]*typed.NodeConditions{
+		typed.NewNodeConditionKey(untyped.GetPartitionId(someTs), "someNode", "someUid").String(): {Transitions: []*typed.NodeConditionTransition{
+			{Timestamp: someTs.Unix(), Type: "Ready", Status: "True", Reason: "KubeletReady"},
+			{Timestamp: someTs.Unix(), Type: "DiskPressure", Status: "False"},
+			{Timestamp: someTs.Add(10 * time.Minute).Unix(), Type: "Ready", Status: "Unknown", Reason: "NodeStatusUnknown"},
+		}},
+		// Each partition repeats the status it starts with
+		typed.NewNodeConditionKey(untyped.GetPartitionId(secondPartitionTs), "someNode", "someUid").String(): {Transitions: []*typed.NodeConditionTransition{
+			{Timestamp: secondPartitionTs.Unix(), Type: "Ready", Status: "Unknown", Reason: "NodeStatusUnknown"},
+			{Timestamp: secondPartitionTs.Unix(), Type: "DiskPressure", Status: "False"},
+			{Timestamp: secondPartitionTs.Add(time.Minute).Unix(), Type: "Ready", Status: "True", Reason: "KubeletReady"},
+		}},
+	}
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		for key, val := range records {
+			err := tables.NodeConditionTable().Set(txn, key, val)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+	return tables
+}
+
+func Test_GetNodeHealth_ComputesUnhealthyIntervals(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	tables := helper_get_nodeConditionTable(t)
+
+	data, err := GetNodeHealth(helper_get_params(), tables, someTs.Add(-time.Minute), someTs.Add(2*time.Hour), someRequestId)
+	assert.Nil(t, err)
+	var output []NodeHealthOutput
+	assert.Nil(t, json.Unmarshal(data, &output))
+	assert.Len(t, output, 1)
+	assert.Equal(t, "someNode", output[0].Name)
+	assert.Len(t, output[0].Transitions, 4)
+	assert.Equal(t, []NodeUnhealthyInterval{{
+		Type:   "Ready",
+		Start:  someTs.Add(10 * time.Minute).Unix(),
+		End:    someTs.Add(61 * time.Minute).Unix(),
+		Reason: "NodeStatusUnknown",
+	}}, output[0].Unhealthy)
+}
+
+func Test_GetNodeHealth_StartsWithStatusAtStartTime(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	tables := helper_get_nodeConditionTable(t)
+	values := helper_get_params()
+	values[ConditionParam] = []string{"Ready"}
+
+	data, err := GetNodeHealth(values, tables, someTs.Add(30*time.Minute), someTs.Add(70*time.Minute), someRequestId)
+	assert.Nil(t, err)
+	var output []NodeHealthOutput
+	assert.Nil(t, json.Unmarshal(data, &output))
+	assert.Len(t, output, 1)
+	assert.Len(t, output[0].Transitions, 2)
+	assert.Equal(t, "Unknown", output[0].Transitions[0].Status)
+	assert.False(t, output[0].Transitions[0].Healthy)
+	assert.True(t, output[0].Transitions[1].Healthy)
+}
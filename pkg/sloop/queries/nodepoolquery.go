@@ -0,0 +1,252 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+const defaultNodePoolGroupBy = "zone"
+
+// Node pool attributes pods can be grouped by, each read from the well known labels of their node
+var nodePoolAttributes = map[string]func(pool *typed.NodePool) string{
+	"zone":         (*typed.NodePool).GetZone,
+	"region":       (*typed.NodePool).GetRegion,
+	"instanceType": (*typed.NodePool).GetInstanceType,
+	"arch":         (*typed.NodePool).GetArch,
+	"os":           (*typed.NodePool).GetOs,
+	"pool":         (*typed.NodePool).GetPool,
+}
+
+type NodePoolOutput struct {
+	GroupBy []string               `json:"groupBy"`
+	Groups  []*NodePoolGroupOutput `json:"groups"`
+	// Pods that were never scheduled in the time range
+	UnscheduledPods int `json:"unscheduledPods"`
+	// Pods on nodes without a record in the time range, so their attributes are not known
+	UnknownNodePods int `json:"unknownNodePods"`
+}
+
+type NodePoolGroupOutput struct {
+	// group_by attribute to its value, empty when the nodes do not have its labels
+	Attributes map[string]string `json:"attributes"`
+	Nodes      []string          `json:"nodes"`
+	Pods       int               `json:"pods"`
+	// Pods by their phase at their last transition in the time range
+	Phases map[string]int `json:"phases"`
+	// Pods that had a container waiting for the reason, like CrashLoopBackOff
+	WaitingReasons map[string]int `json:"waitingReasons,omitempty"`
+	Deleted        int            `json:"deleted"`
+}
+
+/*
+Groups the pod history of the time range by the node pool attributes of the nodes the pods ran on, so capacity and
+incident analysis can be sliced by zone, instance type or architecture.  Param group_by picks zone (default), region,
+instanceType, arch, os or pool, comma separated, and namespace, name, namematch and uuid select the pods.  A node's
+attributes come from its node condition record of the latest partition in the time range, and a pod's node from the
+pod lifecycle table.  Groups are sorted by pods, most first
+*/
+func GetNodePoolPods(params url.Values, t typed.Tables, startTime time.Time, endTime time.Time, requestId string) ([]byte, error) {
+	groupBy, err := parseNodePoolGroupBy(params.Get(GroupByParam))
+	if err != nil {
+		return []byte{}, err
+	}
+
+	var lifecycles map[typed.PodLifecycleKey]*typed.PodLifecycle
+	var nodes map[typed.NodeConditionKey]*typed.NodeConditions
+	err = t.Db().View(func(txn badgerwrap.Txn) error {
+		var err2 error
+		var stats typed.RangeReadStats
+		lifecycles, stats, err2 = t.PodLifecycleTable().RangeRead(txn, nil, paramFilterPodLifecycleFn(params), nil, startTime, endTime)
+		if err2 != nil {
+			return err2
+		}
+		stats.Log(requestId)
+		nodes, stats, err2 = t.NodeConditionTable().RangeRead(txn, nil, nil, nil, startTime, endTime)
+		if err2 != nil {
+			return err2
+		}
+		stats.Log(requestId)
+		return nil
+	})
+	if err != nil {
+		return []byte{}, err
+	}
+
+	poolByNode := map[string]*typed.NodePool{}
+	partitionByNode := map[string]string{}
+	for key, record := range nodes {
+		if record.Pool == nil || partitionByNode[key.Name] > key.PartitionId {
+			continue
+		}
+		poolByNode[key.Name] = record.Pool
+		partitionByNode[key.Name] = key.PartitionId
+	}
+
+	// A pod has one record per partition, so merge them
+	byPod := map[typed.PodLifecycleKey][]*typed.PodPhaseTransition{}
+	for key, val := range lifecycles {
+		key.PartitionId = ""
+		byPod[key] = append(byPod[key], val.Transitions...)
+	}
+
+	output := &NodePoolOutput{GroupBy: groupBy, Groups: []*NodePoolGroupOutput{}}
+	groups := map[string]*NodePoolGroupOutput{}
+	groupNodes := map[string]map[string]bool{}
+	for _, transitions := range byPod {
+		transitions = transitionsInTimeRange(transitions, startTime, endTime)
+		if len(transitions) == 0 {
+			continue
+		}
+		last := transitions[len(transitions)-1]
+		nodeName := ""
+		for _, transition := range transitions {
+			if transition.NodeName != "" {
+				nodeName = transition.NodeName
+			}
+		}
+		if nodeName == "" {
+			output.UnscheduledPods++
+			continue
+		}
+		pool, ok := poolByNode[nodeName]
+		if !ok {
+			output.UnknownNodePods++
+			continue
+		}
+
+		attributes := map[string]string{}
+		values := []string{}
+		for _, attribute := range groupBy {
+			attributes[attribute] = nodePoolAttributes[attribute](pool)
+			values = append(values, attributes[attribute])
+		}
+		groupKey := strings.Join(values, "/")
+		group, ok := groups[groupKey]
+		if !ok {
+			group = &NodePoolGroupOutput{Attributes: attributes, Nodes: []string{}, Phases: map[string]int{}, WaitingReasons: map[string]int{}}
+			groups[groupKey] = group
+			groupNodes[groupKey] = map[string]bool{}
+			output.Groups = append(output.Groups, group)
+		}
+		if !groupNodes[groupKey][nodeName] {
+			groupNodes[groupKey][nodeName] = true
+			group.Nodes = append(group.Nodes, nodeName)
+		}
+		group.Pods++
+		group.Phases[last.Phase]++
+		if last.Deleted {
+			group.Deleted++
+		}
+		reasons := map[string]bool{}
+		for _, transition := range transitions {
+			for _, reason := range transition.WaitingReasons {
+				reasons[reason] = true
+			}
+		}
+		for reason := range reasons {
+			group.WaitingReasons[reason]++
+		}
+	}
+
+	for _, group := range output.Groups {
+		sort.Strings(group.Nodes)
+	}
+	sort.Slice(output.Groups, func(i, j int) bool {
+		a, b := output.Groups[i], output.Groups[j]
+		if a.Pods != b.Pods {
+			return a.Pods > b.Pods
+		}
+		return fmt.Sprint(a.Attributes) < fmt.Sprint(b.Attributes)
+	})
+
+	bytes, err := json.MarshalIndent(output, "", " ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal json %v", err)
+	}
+	return bytes, nil
+}
+
+func parseNodePoolGroupBy(param string) ([]string, error) {
+	if param == "" {
+		param = defaultNodePoolGroupBy
+	}
+	groupBy := []string{}
+	for _, attribute := range strings.Split(param, ",") {
+		attribute = strings.TrimSpace(attribute)
+		if _, ok := nodePoolAttributes[attribute]; !ok {
+			return nil, NewApiError(ErrorCodeBadParams, "invalid %v %q, must be zone, region, instanceType, arch, os or pool", GroupByParam, attribute)
+		}
+		groupBy = append(groupBy, attribute)
+	}
+	return groupBy, nil
+}
+
+// Groups of several shards with the same attributes add up, along with the pods that could not be grouped
+func mergeNodePools(params url.Values, results [][]byte, shardErrors []ShardErrorOutput) ([]byte, error) {
+	var merged NodePoolOutput
+	groups := map[string]*NodePoolGroupOutput{}
+	for idx, result := range results {
+		var pools NodePoolOutput
+		err := json.Unmarshal(result, &pools)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal node pools from shard result %v: %v", idx, err)
+		}
+		if idx == 0 {
+			merged = NodePoolOutput{GroupBy: pools.GroupBy, Groups: []*NodePoolGroupOutput{}}
+		}
+		merged.UnscheduledPods += pools.UnscheduledPods
+		merged.UnknownNodePods += pools.UnknownNodePods
+		for _, group := range pools.Groups {
+			groupKey := fmt.Sprint(group.Attributes)
+			existing, ok := groups[groupKey]
+			if !ok {
+				existing = &NodePoolGroupOutput{Attributes: group.Attributes, Nodes: []string{}, Phases: map[string]int{}, WaitingReasons: map[string]int{}}
+				groups[groupKey] = existing
+				merged.Groups = append(merged.Groups, existing)
+			}
+			for _, node := range group.Nodes {
+				if !common.Contains(existing.Nodes, node) {
+					existing.Nodes = append(existing.Nodes, node)
+				}
+			}
+			existing.Pods += group.Pods
+			existing.Deleted += group.Deleted
+			for phase, count := range group.Phases {
+				existing.Phases[phase] += count
+			}
+			for reason, count := range group.WaitingReasons {
+				existing.WaitingReasons[reason] += count
+			}
+		}
+	}
+	for _, group := range merged.Groups {
+		sort.Strings(group.Nodes)
+	}
+	sort.Slice(merged.Groups, func(i, j int) bool {
+		a, b := merged.Groups[i], merged.Groups[j]
+		if a.Pods != b.Pods {
+			return a.Pods > b.Pods
+		}
+		return fmt.Sprint(a.Attributes) < fmt.Sprint(b.Attributes)
+	})
+	bytes, err := json.MarshalIndent(merged, "", " ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal json %v", err)
+	}
+	return bytes, nil
+}
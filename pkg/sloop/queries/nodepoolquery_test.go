@@ -0,0 +1,111 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+func helper_get_nodePoolTables(t *testing.T) typed.Tables {
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+
+	partitionId := untyped.GetPartitionId(someTs)
+	nextPartitionId := untyped.GetPartitionId(someTs.Add(time.Hour))
+	nodes := map[string]*typed.NodeConditions{
+		typed.NewNodeConditionKey(partitionId, "node-a", "uidA").String(): {Pool: &typed.NodePool{Zone: "zone-1", Arch: "amd64"}},
+		// Relabeled in the next partition, the latest one wins
+		typed.NewNodeConditionKey(partitionId, "node-b", "uidB").String():     {Pool: &typed.NodePool{Zone: "zone-1", Arch: "arm64"}},
+		typed.NewNodeConditionKey(nextPartitionId, "node-b", "uidB").String(): {Pool: &typed.NodePool{Zone: "zone-2", Arch: "arm64"}},
+	}
+	pods := map[string]*typed.PodLifecycle{
+		typed.NewPodLifecycleKey(partitionId, "some-namespace", "pod-1", "uid1").String(): {Transitions: []*typed.PodPhaseTransition{
+			{Timestamp: someTs.Unix(), Phase: "Pending"},
+			{Timestamp: someTs.Add(time.Minute).Unix(), Phase: "Pending", NodeName: "node-a", WaitingReasons: map[string]string{"app": "ImagePullBackOff"}},
+			{Timestamp: someTs.Add(2 * time.Minute).Unix(), Phase: "Running", NodeName: "node-a"},
+		}},
+		typed.NewPodLifecycleKey(partitionId, "some-namespace", "pod-2", "uid2").String(): {Transitions: []*typed.PodPhaseTransition{
+			{Timestamp: someTs.Unix(), Phase: "Failed", NodeName: "node-b", Deleted: true},
+		}},
+		typed.NewPodLifecycleKey(partitionId, "some-namespace", "pod-3", "uid3").String(): {Transitions: []*typed.PodPhaseTransition{
+			{Timestamp: someTs.Unix(), Phase: "Running", NodeName: "node-b"},
+		}},
+		typed.NewPodLifecycleKey(partitionId, "some-namespace", "pod-4", "uid4").String(): {Transitions: []*typed.PodPhaseTransition{
+			{Timestamp: someTs.Unix(), Phase: "Pending"},
+		}},
+		typed.NewPodLifecycleKey(partitionId, "some-namespace", "pod-5", "uid5").String(): {Transitions: []*typed.PodPhaseTransition{
+			{Timestamp: someTs.Unix(), Phase: "Running", NodeName: "node-gone"},
+		}},
+	}
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		for key, val := range nodes {
+			err := tables.NodeConditionTable().Set(txn, key, val)
+			if err != nil {
+				return err
+			}
+		}
+		for key, val := range pods {
+			err := tables.PodLifecycleTable().Set(txn, key, val)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+	return tables
+}
+
+func Test_GetNodePoolPods(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	tables := helper_get_nodePoolTables(t)
+
+	data, err := GetNodePoolPods(helper_get_params(), tables, someTs, someTs.Add(2*time.Hour), someRequestId)
+	assert.Nil(t, err)
+	output := &NodePoolOutput{}
+	assert.Nil(t, json.Unmarshal(data, output))
+	assert.Equal(t, []string{"zone"}, output.GroupBy)
+	assert.Equal(t, 1, output.UnscheduledPods)
+	assert.Equal(t, 1, output.UnknownNodePods)
+	assert.Len(t, output.Groups, 2)
+	assert.Equal(t, map[string]string{"zone": "zone-2"}, output.Groups[0].Attributes)
+	assert.Equal(t, []string{"node-b"}, output.Groups[0].Nodes)
+	assert.Equal(t, 2, output.Groups[0].Pods)
+	assert.Equal(t, map[string]int{"Failed": 1, "Running": 1}, output.Groups[0].Phases)
+	assert.Equal(t, 1, output.Groups[0].Deleted)
+	assert.Equal(t, map[string]string{"zone": "zone-1"}, output.Groups[1].Attributes)
+	assert.Equal(t, map[string]int{"Running": 1}, output.Groups[1].Phases)
+	assert.Equal(t, map[string]int{"ImagePullBackOff": 1}, output.Groups[1].WaitingReasons)
+
+	values := helper_get_params()
+	values[GroupByParam] = []string{"arch,zone"}
+	data, err = GetNodePoolPods(values, tables, someTs, someTs.Add(2*time.Hour), someRequestId)
+	assert.Nil(t, err)
+	output = &NodePoolOutput{}
+	assert.Nil(t, json.Unmarshal(data, output))
+	assert.Equal(t, map[string]string{"arch": "arm64", "zone": "zone-2"}, output.Groups[0].Attributes)
+}
+
+func Test_GetNodePoolPods_InvalidGroupBy(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	tables := helper_get_nodePoolTables(t)
+	values := helper_get_params()
+	values[GroupByParam] = []string{"rack"}
+	_, err := GetNodePoolPods(values, tables, someTs, someTs.Add(time.Hour), someRequestId)
+	assert.NotNil(t, err)
+}
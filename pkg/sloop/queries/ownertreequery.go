@@ -0,0 +1,124 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+// Owner chains in kubernetes are short (Deployment -> ReplicaSet -> Pod), this only guards against cycles
+const maxOwnerTreeDepth = 10
+
+type OwnerTreeOutput struct {
+	Uid       string                     `json:"uid"`
+	Kind      string                     `json:"kind,omitempty"`
+	Namespace string                     `json:"namespace,omitempty"`
+	Name      string                     `json:"name,omitempty"`
+	Intervals []*typed.OwnerEdgeInterval `json:"intervals,omitempty"`
+	Children  []*OwnerTreeOutput         `json:"children"`
+}
+
+// Returns everything owned directly or indirectly by the resource with the given uuid during the time range.  Each
+// level is one prefix lookup per partition in the owner graph table.
+func GetOwnerTree(params url.Values, t typed.Tables, startTime time.Time, endTime time.Time, requestId string) ([]byte, error) {
+	rootUid := params.Get(UuidParam)
+	if rootUid == "" {
+		return []byte{}, NewApiError(ErrorCodeBadParams, "GetOwnerTree requires %v", UuidParam)
+	}
+
+	root := &OwnerTreeOutput{Uid: rootUid, Children: []*OwnerTreeOutput{}}
+	err := t.Db().View(func(txn badgerwrap.Txn) error {
+		visited := map[string]bool{rootUid: true}
+		level := []*OwnerTreeOutput{root}
+		for depth := 0; depth < maxOwnerTreeDepth && len(level) > 0; depth++ {
+			var nextLevel []*OwnerTreeOutput
+			for _, owner := range level {
+				children, err := getOwnedChildren(txn, t, owner, startTime, endTime, requestId)
+				if err != nil {
+					return err
+				}
+				for _, child := range children {
+					if visited[child.Uid] {
+						continue
+					}
+					visited[child.Uid] = true
+					owner.Children = append(owner.Children, child)
+					nextLevel = append(nextLevel, child)
+				}
+			}
+			level = nextLevel
+		}
+		return nil
+	})
+	if err != nil {
+		return []byte{}, err
+	}
+
+	bytes, err := json.MarshalIndent(root, "", " ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal json %v", err)
+	}
+	return bytes, nil
+}
+
+// Returns the children of owner whose edge was valid at some point in the time range, sorted by kind and name.  The
+// owner kind and name are filled in from the edges when they are not known yet.
+func getOwnedChildren(txn badgerwrap.Txn, t typed.Tables, owner *OwnerTreeOutput, startTime time.Time, endTime time.Time, requestId string) ([]*OwnerTreeOutput, error) {
+	edges, stats, err := t.OwnerGraphTable().RangeRead(txn, typed.NewOwnerEdgeKeyComparator(owner.Uid), nil, nil, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+	stats.Log(requestId)
+
+	byChild := map[typed.OwnerEdgeKey]*OwnerTreeOutput{}
+	for key, edge := range edges {
+		if owner.Kind == "" {
+			owner.Kind = edge.OwnerKind
+			owner.Name = edge.OwnerName
+			owner.Namespace = key.ChildNamespace
+		}
+		childKey := key
+		childKey.PartitionId = ""
+		child, ok := byChild[childKey]
+		if !ok {
+			child = &OwnerTreeOutput{Uid: key.ChildUid, Kind: key.ChildKind, Namespace: key.ChildNamespace, Name: edge.ChildName, Children: []*OwnerTreeOutput{}}
+			byChild[childKey] = child
+		}
+		for _, interval := range edge.Intervals {
+			if interval.Start <= endTime.Unix() && (interval.End == 0 || interval.End >= startTime.Unix()) {
+				child.Intervals = append(child.Intervals, interval)
+			}
+		}
+	}
+
+	var children []*OwnerTreeOutput
+	for _, child := range byChild {
+		if len(child.Intervals) == 0 {
+			continue
+		}
+		sort.Slice(child.Intervals, func(i, j int) bool { return child.Intervals[i].Start < child.Intervals[j].Start })
+		children = append(children, child)
+	}
+	sort.Slice(children, func(i, j int) bool {
+		if children[i].Kind != children[j].Kind {
+			return children[i].Kind < children[j].Kind
+		}
+		if children[i].Name != children[j].Name {
+			return children[i].Name < children[j].Name
+		}
+		return children[i].Uid < children[j].Uid
+	})
+	return children, nil
+}
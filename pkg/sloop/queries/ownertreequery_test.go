@@ -0,0 +1,67 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetOwnerTree_WalksAllLevels(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+
+	partitionId := untyped.GetPartitionId(someTs)
+	open := []*typed.OwnerEdgeInterval{{Start: someTs.Unix(), LastSeen: someTs.Unix()}}
+	edges := map[string]*typed.OwnerEdge{
+		typed.NewOwnerEdgeKey(partitionId, "deployUid", "ReplicaSet", "ns", "rsUid").String(): {OwnerKind: "Deployment", OwnerName: "app", ChildName: "app-1", Intervals: open},
+		typed.NewOwnerEdgeKey(partitionId, "rsUid", "Pod", "ns", "podUid1").String():          {OwnerKind: "ReplicaSet", OwnerName: "app-1", ChildName: "app-1-a", Intervals: open},
+		typed.NewOwnerEdgeKey(partitionId, "rsUid", "Pod", "ns", "podUid2").String():          {OwnerKind: "ReplicaSet", OwnerName: "app-1", ChildName: "app-1-b", Intervals: open},
+		// Ended before the query time range
+		typed.NewOwnerEdgeKey(partitionId, "rsUid", "Pod", "ns", "podUid3").String(): {OwnerKind: "ReplicaSet", OwnerName: "app-1", ChildName: "app-1-c",
+			Intervals: []*typed.OwnerEdgeInterval{{Start: someTs.Add(-10 * time.Minute).Unix(), End: someTs.Add(-5 * time.Minute).Unix()}}},
+	}
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		for key, val := range edges {
+			err := tables.OwnerGraphTable().Set(txn, key, val)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+
+	values := helper_get_params()
+	values[UuidParam] = []string{"deployUid"}
+	data, err := GetOwnerTree(values, tables, someTs, someTs.Add(time.Minute), someRequestId)
+	assert.Nil(t, err)
+	output := OwnerTreeOutput{}
+	assert.Nil(t, json.Unmarshal(data, &output))
+	assert.Equal(t, "Deployment", output.Kind)
+	assert.Equal(t, "app", output.Name)
+	assert.Len(t, output.Children, 1)
+	assert.Equal(t, "app-1", output.Children[0].Name)
+	assert.Len(t, output.Children[0].Children, 2)
+	assert.Equal(t, "app-1-a", output.Children[0].Children[0].Name)
+	assert.Equal(t, "app-1-b", output.Children[0].Children[1].Name)
+}
+
+func Test_GetOwnerTree_RequiresUuid(t *testing.T) {
+	_, err := GetOwnerTree(helper_get_params(), nil, someTs, someTs, someRequestId)
+	assert.NotNil(t, err)
+}
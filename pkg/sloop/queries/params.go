@@ -10,19 +10,50 @@ package queries
 // Parameters are shared between webserver and here
 // Keep this in sync with pkg/sloop/webserver/webfiles/filter.js
 const (
-	LookbackParam  = "lookback"
-	NamespaceParam = "namespace"
-	KindParam      = "kind"
-	NameParam      = "name"
-	NameMatchParam = "namematch" // substring match on name
-	UuidParam      = "uuid"
-	StartTimeParam = "start_time"
-	EndTimeParam   = "end_time"
-	ClickTimeParam = "click_time"
-	QueryParam     = "query"
-	SortParam      = "sort"
+	LookbackParam       = "lookback"
+	NamespaceParam      = "namespace"
+	KindParam           = "kind"
+	NameParam           = "name"
+	NameMatchParam      = "namematch" // substring match on name
+	UuidParam           = "uuid"
+	StartTimeParam      = "start_time"
+	EndTimeParam        = "end_time"
+	ClickTimeParam      = "click_time"
+	QueryParam          = "query"
+	SortParam           = "sort"
+	ExplainParam        = "explain"         // return the query plan instead of running it
+	ConditionParam      = "condition"       // node condition type, like Ready
+	NamespaceEpochParam = "namespace_epoch" // "current" keeps only the newest incarnation of each namespace
+	SensitivityParam    = "sensitivity"     // low, medium or high, for detectors
+	IpParam             = "ip"
+	NodeParam           = "node"
+	ChangeTimeParam     = "change_time"     // unix seconds, can be repeated
+	SummaryOnlyParam    = "summary_only"    // "true" leaves out the payloads and only returns their summary lines
+	VersionTypeParam    = "version_type"    // first, resync or changed, comma separated
+	OtherNamespaceParam = "other_namespace" // the namespace GetNamespaceComparison compares namespace with
+	MatchLabelParam     = "match_label"     // label key to match resources by instead of their name
+	GranularityParam    = "granularity"     // minute, hour or day, for GetEventTrend
+	GroupByParam        = "group_by"        // node pool attributes for GetNodePoolPods, comma separated
+	LimitParam          = "limit"           // object count GetResourceCounts forecasts against
+	PayloadFormatParam  = "payload_format"  // "patch" sends GetResPayload payloads after the first as JSON Patches
+	MergeShardsParam    = "merge_shards"    // "true" merges the same resource from several shards into one timeline row
+	BucketParam         = "bucket"          // duration of one column of the namespace event heat map
+	LatestParam         = "latest"          // only the newest n payloads of GetResPayload
 )
 
+const PayloadFormatPatch = "patch"
+
+// Set by the webserver on a response that was cut at the maximum response size of its endpoint.  The body is then
+// the first max bytes and not valid json
+const (
+	TruncatedHeader        = "X-Sloop-Truncated"
+	ResponseBytesHeader    = "X-Sloop-Response-Bytes"
+	MaxResponseBytesHeader = "X-Sloop-Max-Response-Bytes"
+)
+
+// Set by the query-only frontend of shards when some shards failed, to the json list of ShardErrorOutput
+const ShardErrorsHeader = "X-Sloop-Shard-Errors"
+
 const (
 	AllKinds         = "_all"
 	AllNamespaces    = "_all"
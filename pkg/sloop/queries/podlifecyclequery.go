@@ -0,0 +1,155 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+type PodLifecycleOutput struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Uid       string `json:"uid"`
+	// The first transition is the state of the pod at the start of the time range when it was already known
+	Transitions []*typed.PodPhaseTransition `json:"transitions"`
+	// Containers added to the running pod, like by kubectl debug, sorted by when they were first seen
+	EphemeralContainers []EphemeralContainerOutput `json:"ephemeralContainers,omitempty"`
+}
+
+type EphemeralContainerOutput struct {
+	Name string `json:"name"`
+	// Unix seconds of the first transition with the container, and of the first ones it was running and terminated
+	FirstSeen  int64 `json:"firstSeen"`
+	Started    int64 `json:"started,omitempty"`
+	Terminated int64 `json:"terminated,omitempty"`
+	// State at the last transition with the container, like Running
+	State string `json:"state"`
+}
+
+// Returns the phase transitions of the pods matching namespace, name, namematch and uuid, oldest first
+func GetPodLifecycle(params url.Values, t typed.Tables, startTime time.Time, endTime time.Time, requestId string) ([]byte, error) {
+	var lifecycles map[typed.PodLifecycleKey]*typed.PodLifecycle
+	err := t.Db().View(func(txn badgerwrap.Txn) error {
+		var err2 error
+		var stats typed.RangeReadStats
+		lifecycles, stats, err2 = t.PodLifecycleTable().RangeRead(txn, nil, paramFilterPodLifecycleFn(params), nil, startTime, endTime)
+		if err2 != nil {
+			return err2
+		}
+		stats.Log(requestId)
+		return nil
+	})
+	if err != nil {
+		return []byte{}, err
+	}
+
+	// A pod has one record per partition, so merge them
+	byPod := map[typed.PodLifecycleKey]*PodLifecycleOutput{}
+	for key, val := range lifecycles {
+		podKey := key
+		podKey.PartitionId = ""
+		pod, ok := byPod[podKey]
+		if !ok {
+			pod = &PodLifecycleOutput{Namespace: key.Namespace, Name: key.Name, Uid: key.Uid}
+			byPod[podKey] = pod
+		}
+		pod.Transitions = append(pod.Transitions, val.Transitions...)
+	}
+
+	output := []*PodLifecycleOutput{}
+	for _, pod := range byPod {
+		pod.Transitions = transitionsInTimeRange(pod.Transitions, startTime, endTime)
+		pod.EphemeralContainers = ephemeralContainersOfTransitions(pod.Transitions)
+		if len(pod.Transitions) > 0 {
+			output = append(output, pod)
+		}
+	}
+	sort.Slice(output, func(i, j int) bool {
+		if output[i].Namespace != output[j].Namespace {
+			return output[i].Namespace < output[j].Namespace
+		}
+		if output[i].Name != output[j].Name {
+			return output[i].Name < output[j].Name
+		}
+		return output[i].Uid < output[j].Uid
+	})
+
+	bytes, err := json.MarshalIndent(output, "", " ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal json %v", err)
+	}
+	return bytes, nil
+}
+
+// Keeps the transitions inside the time range plus the last one before it, which is the state at the start time
+func transitionsInTimeRange(transitions []*typed.PodPhaseTransition, startTime time.Time, endTime time.Time) []*typed.PodPhaseTransition {
+	sort.SliceStable(transitions, func(i, j int) bool { return transitions[i].Timestamp < transitions[j].Timestamp })
+	var ret []*typed.PodPhaseTransition
+	var before *typed.PodPhaseTransition
+	for _, transition := range transitions {
+		if transition.Timestamp < startTime.Unix() {
+			before = transition
+			continue
+		}
+		if transition.Timestamp > endTime.Unix() {
+			break
+		}
+		if len(ret) == 0 && before != nil {
+			ret = append(ret, before)
+		}
+		// Partitions repeat the state they start with, which is not a transition
+		if len(ret) > 0 && typed.SamePodState(ret[len(ret)-1], transition) {
+			continue
+		}
+		ret = append(ret, transition)
+	}
+	if len(ret) == 0 && before != nil {
+		ret = append(ret, before)
+	}
+	return ret
+}
+
+// Transitions are sorted oldest first
+func ephemeralContainersOfTransitions(transitions []*typed.PodPhaseTransition) []EphemeralContainerOutput {
+	byName := map[string]*EphemeralContainerOutput{}
+	for _, transition := range transitions {
+		for name, state := range transition.EphemeralContainers {
+			container, ok := byName[name]
+			if !ok {
+				container = &EphemeralContainerOutput{Name: name, FirstSeen: transition.Timestamp}
+				byName[name] = container
+			}
+			container.State = state
+			if state == kubeextractor.EphemeralContainerRunning && container.Started == 0 {
+				container.Started = transition.Timestamp
+			}
+			if state == kubeextractor.EphemeralContainerTerminated && container.Terminated == 0 {
+				container.Terminated = transition.Timestamp
+			}
+		}
+	}
+	output := []EphemeralContainerOutput{}
+	for _, container := range byName {
+		output = append(output, *container)
+	}
+	sort.Slice(output, func(i, j int) bool {
+		if output[i].FirstSeen != output[j].FirstSeen {
+			return output[i].FirstSeen < output[j].FirstSeen
+		}
+		return output[i].Name < output[j].Name
+	})
+	return output
+}
@@ -0,0 +1,101 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+func helper_get_podLifecycleTable(t *testing.T) typed.Tables {
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+
+	secondPartitionTs := someTs.Add(time.Hour)
+	records := map[string]*typed.PodLifecycle{
+		typed.NewPodLifecycleKey(untyped.GetPartitionId(someTs), "some-namespace", "somePod", "someUid").String(): {Transitions: []*typed.PodPhaseTransition{
+			{Timestamp: someTs.Unix(), Phase: "Pending", WaitingReasons: map[string]string{"app": "ContainerCreating"}},
+			{Timestamp: someTs.Add(time.Minute).Unix(), Phase: "Running"},
+		}},
+		// Each partition repeats the state it starts with
+		typed.NewPodLifecycleKey(untyped.GetPartitionId(secondPartitionTs), "some-namespace", "somePod", "someUid").String(): {Transitions: []*typed.PodPhaseTransition{
+			{Timestamp: secondPartitionTs.Unix(), Phase: "Running"},
+			{Timestamp: secondPartitionTs.Add(time.Minute).Unix(), Phase: "Failed"},
+		}},
+		typed.NewPodLifecycleKey(untyped.GetPartitionId(someTs), "other-namespace", "otherPod", "otherUid").String(): {Transitions: []*typed.PodPhaseTransition{
+			{Timestamp: someTs.Unix(), Phase: "Running"},
+		}},
+	}
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		for key, val := range records {
+			err := tables.PodLifecycleTable().Set(txn, key, val)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+	return tables
+}
+
+func Test_GetPodLifecycle_MergesPartitions(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	tables := helper_get_podLifecycleTable(t)
+	values := helper_get_params()
+
+	data, err := GetPodLifecycle(values, tables, someTs.Add(-time.Minute), someTs.Add(2*time.Hour), someRequestId)
+	assert.Nil(t, err)
+	var output []PodLifecycleOutput
+	assert.Nil(t, json.Unmarshal(data, &output))
+	assert.Len(t, output, 1)
+	assert.Equal(t, "somePod", output[0].Name)
+	var phases []string
+	for _, transition := range output[0].Transitions {
+		phases = append(phases, transition.Phase)
+	}
+	assert.Equal(t, []string{"Pending", "Running", "Failed"}, phases)
+}
+
+func Test_GetPodLifecycle_StartsWithStateAtStartTime(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	tables := helper_get_podLifecycleTable(t)
+	values := helper_get_params()
+
+	data, err := GetPodLifecycle(values, tables, someTs.Add(30*time.Minute), someTs.Add(2*time.Hour), someRequestId)
+	assert.Nil(t, err)
+	var output []PodLifecycleOutput
+	assert.Nil(t, json.Unmarshal(data, &output))
+	assert.Len(t, output, 1)
+	assert.Len(t, output[0].Transitions, 2)
+	assert.Equal(t, "Running", output[0].Transitions[0].Phase)
+	assert.Equal(t, someTs.Add(time.Minute).Unix(), output[0].Transitions[0].Timestamp)
+	assert.Equal(t, "Failed", output[0].Transitions[1].Phase)
+}
+
+func Test_ephemeralContainersOfTransitions(t *testing.T) {
+	transitions := []*typed.PodPhaseTransition{
+		{Timestamp: 100, Phase: "Running"},
+		{Timestamp: 200, Phase: "Running", EphemeralContainers: map[string]string{"debugger": "Added"}},
+		{Timestamp: 300, Phase: "Running", EphemeralContainers: map[string]string{"debugger": "Running", "other": "Waiting"}},
+		{Timestamp: 400, Phase: "Running", EphemeralContainers: map[string]string{"debugger": "Terminated", "other": "Waiting"}},
+	}
+	assert.Equal(t, []EphemeralContainerOutput{
+		{Name: "debugger", FirstSeen: 200, Started: 300, Terminated: 400, State: "Terminated"},
+		{Name: "other", FirstSeen: 300, State: "Waiting"},
+	}, ephemeralContainersOfTransitions(transitions))
+	assert.Equal(t, []EphemeralContainerOutput{}, ephemeralContainersOfTransitions(transitions[:1]))
+}
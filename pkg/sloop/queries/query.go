@@ -8,7 +8,6 @@
 package queries
 
 import (
-	"fmt"
 	"github.com/golang/glog"
 	"github.com/salesforce/sloop/pkg/sloop/store/typed"
 	"net/url"
@@ -19,13 +18,38 @@ import (
 type ganttJsonQuery = func(params url.Values, tables typed.Tables, startTime time.Time, endTime time.Time, requestId string) ([]byte, error)
 
 var funcMap = map[string]ganttJsonQuery{
-	"EventHeatMap":      EventHeatMap3Query,
-	"GetEventData":      GetEventData,
-	"GetResPayload":     GetResPayload,
-	"Namespaces":        NamespaceQuery,
-	"Kinds":             KindQuery,
-	"Queries":           QueryAvailableQueries,
-	"GetResSummaryData": GetResSummaryData,
+	"EventHeatMap":              EventHeatMap3Query,
+	"GetEventData":              GetEventData,
+	"GetResPayload":             GetResPayload,
+	"Namespaces":                NamespaceQuery,
+	"Kinds":                     KindQuery,
+	"Queries":                   QueryAvailableQueries,
+	"GetResSummaryData":         GetResSummaryData,
+	"GetPodLifecycle":           GetPodLifecycle,
+	"GetNodeHealth":             GetNodeHealth,
+	"GetOwnerTree":              GetOwnerTree,
+	"GetServiceBackends":        GetServiceBackends,
+	"GetVolumeBinding":          GetVolumeBinding,
+	"GetCurrentState":           GetCurrentState,
+	"GetNamespaceEpochs":        GetNamespaceEpochs,
+	"GetIngestAnnotations":      GetIngestAnnotations,
+	"GetFlappingResources":      GetFlappingResources,
+	"GetQuotaUtilization":       GetQuotaUtilization,
+	"GetCredentialUsage":        GetCredentialUsage,
+	"GetNetworkReferences":      GetNetworkReferences,
+	"GetDeploymentRollouts":     GetDeploymentRollouts,
+	"GetConfigImpact":           GetConfigImpact,
+	"GetEventBreakdown":         GetEventBreakdown,
+	"GetSnapshotDiff":           GetSnapshotDiff,
+	"GetApiVersionMigrations":   GetApiVersionMigrations,
+	"GetWriterConflicts":        GetWriterConflicts,
+	"GetNamespaceComparison":    GetNamespaceComparison,
+	"GetSelfFootprint":          GetSelfFootprint,
+	"GetApiServiceAvailability": GetApiServiceAvailability,
+	"GetEventTrend":             GetEventTrend,
+	"GetDeletionCascade":        GetDeletionCascade,
+	"GetNodePoolPods":           GetNodePoolPods,
+	"GetResourceCounts":         GetResourceCounts,
 }
 
 func Default() string {
@@ -36,6 +60,11 @@ func GetNamesOfQueries() []string {
 	return []string{"EventHeatMap"}
 }
 
+func IsQuery(queryName string) bool {
+	_, ok := funcMap[queryName]
+	return ok
+}
+
 func RunQuery(queryName string, params url.Values, tables typed.Tables, maxLookBack time.Duration, requestId string) ([]byte, error) {
 	startTime, endTime, err := computeTimeRange(params, tables, maxLookBack)
 	if err != nil {
@@ -45,7 +74,10 @@ func RunQuery(queryName string, params url.Values, tables typed.Tables, maxLookB
 
 	fn, ok := funcMap[queryName]
 	if !ok {
-		return []byte{}, fmt.Errorf("Query not found: " + queryName)
+		return []byte{}, NewApiError(ErrorCodeBadParams, "Query not found: %v", queryName)
+	}
+	if IsExplain(params) {
+		return explainQuery(queryName, params, tables, startTime, endTime)
 	}
 	ret, err := fn(params, tables, startTime, endTime, requestId)
 	if err != nil {
@@ -27,6 +27,9 @@ type rawData struct {
 	Events        map[typed.EventCountKey]*typed.ResourceEventCounts
 	Resources     map[typed.ResourceSummaryKey]*typed.ResourceSummary
 	WatchActivity map[typed.WatchActivityKey]*typed.WatchActivity
+	// Keyed by namespace name
+	NamespaceEpochs   map[string][]namespaceEpoch
+	IngestAnnotations []IngestAnnotationOutput
 }
 
 func EventHeatMap3Query(params url.Values, t typed.Tables, queryStartTime time.Time, queryEndTime time.Time, requestId string) ([]byte, error) {
@@ -48,6 +51,9 @@ func EventHeatMap3Query(params url.Values, t typed.Tables, queryStartTime time.T
 		return []byte{}, err
 	}
 	timeFilterWatchActivityMap(rawRows.WatchActivity, queryStartTime, queryEndTime)
+	if params.Get(NamespaceEpochParam) == CurrentNamespaceEpoch {
+		filterToCurrentNamespaceEpoch(rawRows.Resources, rawRows.NamespaceEpochs)
+	}
 
 	glog.Infof("reqId: %v EventHeatMap3Query after filter %v events, %v resources, and %v watch activity", requestId, len(rawRows.Events), len(rawRows.Resources), len(rawRows.WatchActivity))
 
@@ -62,6 +68,10 @@ func EventHeatMap3Query(params url.Values, t typed.Tables, queryStartTime time.T
 	if err != nil {
 		return []byte{}, err
 	}
+	for key, summary := range rawRows.Resources {
+		key.PartitionId = EmptyPartition
+		mapResSumKeyToD3Gantt[key].NamespaceUid = namespaceUidForResource(key, summary, rawRows.NamespaceEpochs)
+	}
 
 	// add the event counts in as overlay
 	mapResSumKeyToOverlay, err := eventCountsToOverlayMap(rawRows.Events)
@@ -87,13 +97,15 @@ func EventHeatMap3Query(params url.Values, t typed.Tables, queryStartTime time.T
 	// This moves the overlay start/end values so they are contained properly in the resource timeline
 	outputRows := convertHeatmapToSlice(mapResSumKeyToD3Gantt)
 	adjustOverlays(outputRows)
+	setRowsCompleteness(outputRows, rawRows.IngestAnnotations)
 
 	outputRowValidation(outputRows, requestId)
 
 	sortParam := params.Get(SortParam)
 	outputRoot := TimelineRoot{
-		Rows:    outputRows,
-		ViewOpt: ViewOptions{Sort: sortParam},
+		Rows:              outputRows,
+		ViewOpt:           ViewOptions{Sort: sortParam},
+		IngestAnnotations: rawRows.IngestAnnotations,
 	}
 
 	bytes, err := json.MarshalIndent(outputRoot, "", " ")
@@ -133,6 +145,16 @@ func getRawDataFromStore(params url.Values, t typed.Tables, startTime time.Time,
 		}
 		stats.Log(requestId)
 
+		ret.NamespaceEpochs, err2 = getNamespaceEpochs(t, txn, startTime, endTime)
+		if err2 != nil {
+			return err2
+		}
+
+		ret.IngestAnnotations, err2 = getIngestAnnotations(params, t, txn, startTime, endTime)
+		if err2 != nil {
+			return err2
+		}
+
 		return nil
 	})
 	if err != nil {
@@ -340,6 +362,7 @@ func watchActivityToMap(watchActivity map[typed.WatchActivityKey]*typed.WatchAct
 		}
 		combined.ChangedAt = append(combined.ChangedAt, value.ChangedAt...)
 		combined.NoChangeAt = append(combined.NoChangeAt, value.NoChangeAt...)
+		combined.MissedAt = append(combined.MissedAt, value.MissedAt...)
 
 		retMap[resSumRefKey] = combined
 	}
@@ -352,6 +375,7 @@ func mergeHeatmapWithWatchActivity(resKeyToD3Map map[typed.ResourceSummaryKey]*T
 		if activity, found := watchActivity[resKey]; found {
 			d3row.ChangedAt = activity.ChangedAt
 			d3row.NoChangeAt = activity.NoChangeAt
+			d3row.MissedAt = activity.MissedAt
 		} else {
 			glog.Errorf("DEBUG: no activity - %v", resKey)
 		}
@@ -133,7 +133,10 @@ func Test_EventHeatMap3_SimpleTestWithOneDeployment(t *testing.T) {
    "changedat": null,
    "nochangeat": null,
    "start_date": 1551398520,
-   "end_date": 1551402000
+   "end_date": 1551402000,
+   "completeness": {
+    "score": 1
+   }
   }
  ]
 }`
@@ -183,7 +186,10 @@ func Test_EventHeatMap3_OneDeploymentAnd3Events(t *testing.T) {
     1551398820
    ],
    "start_date": 1551398520,
-   "end_date": 1551402000
+   "end_date": 1551402000,
+   "completeness": {
+    "score": 1
+   }
   }
  ]
 }`
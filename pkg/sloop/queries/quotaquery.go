@@ -0,0 +1,346 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// Kinds counted next to each quota sample, so usage can be compared with what was running
+var quotaWorkloadKinds = []string{"Pod", "Deployment", "StatefulSet", "DaemonSet", "Job", kubeextractor.PersistentVolumeClaimKind, "Service"}
+
+type QuotaResourceUsage struct {
+	Resource string `json:"resource"`
+	Hard     string `json:"hard"`
+	Used     string `json:"used"`
+	// Used / Hard, 0 when the quota has no limit for it
+	Utilization float64 `json:"utilization"`
+}
+
+// Usage of a quota from the given time until the next sample
+type QuotaSample struct {
+	Timestamp int64                `json:"timestamp"`
+	Deleted   bool                 `json:"deleted,omitempty"`
+	Resources []QuotaResourceUsage `json:"resources"`
+	// Resources of each of quotaWorkloadKinds in the namespace at the time of the sample
+	Workloads map[string]int `json:"workloads"`
+}
+
+type QuotaHistory struct {
+	Name string `json:"name"`
+	// The first sample is the usage at the start of the time range when it was already known
+	Samples []QuotaSample `json:"samples"`
+}
+
+type LimitRangeChange struct {
+	Timestamp int64                          `json:"timestamp"`
+	Deleted   bool                           `json:"deleted,omitempty"`
+	Limits    []kubeextractor.LimitRangeItem `json:"limits"`
+}
+
+type LimitRangeHistory struct {
+	Name    string             `json:"name"`
+	Changes []LimitRangeChange `json:"changes"`
+}
+
+type QuotaUtilizationOutput struct {
+	Namespace   string              `json:"namespace"`
+	Quotas      []QuotaHistory      `json:"quotas"`
+	LimitRanges []LimitRangeHistory `json:"limitRanges"`
+}
+
+// When a workload existed, merged over the partitions of its uid
+type workloadLifetime struct {
+	kind      string
+	namespace string
+	start     time.Time
+	lastSeen  time.Time
+	deleted   bool
+}
+
+/*
+Returns the usage of every ResourceQuota and the limits of every LimitRange in the selected namespace (or all of them)
+over the time range, rebuilt from the watch table.  Each quota sample also counts the workloads that existed in the
+namespace at that time, taken from the resource summaries, so capacity reviews can see what the usage came from
+*/
+func GetQuotaUtilization(params url.Values, t typed.Tables, startTime time.Time, endTime time.Time, requestId string) ([]byte, error) {
+	selectedNamespace := defaultParam(params.Get(NamespaceParam), AllNamespaces)
+	byNamespace := map[string]*QuotaUtilizationOutput{}
+	getNamespace := func(namespace string) *QuotaUtilizationOutput {
+		if byNamespace[namespace] == nil {
+			byNamespace[namespace] = &QuotaUtilizationOutput{Namespace: namespace, Quotas: []QuotaHistory{}, LimitRanges: []LimitRangeHistory{}}
+		}
+		return byNamespace[namespace]
+	}
+
+	err := t.Db().View(func(txn badgerwrap.Txn) error {
+		quotas, err := readNamespacedWatchRecords(txn, t, kubeextractor.ResourceQuotaKind, selectedNamespace, startTime, endTime, requestId)
+		if err != nil {
+			return err
+		}
+		limitRanges, err := readNamespacedWatchRecords(txn, t, kubeextractor.LimitRangeKind, selectedNamespace, startTime, endTime, requestId)
+		if err != nil {
+			return err
+		}
+		workloads, err := getWorkloadLifetimes(txn, t, selectedNamespace, startTime, endTime, requestId)
+		if err != nil {
+			return err
+		}
+
+		for id, records := range quotas {
+			samples := quotaSamples(records, startTime, endTime)
+			if len(samples) == 0 {
+				continue
+			}
+			for idx := range samples {
+				samples[idx].Workloads = countWorkloadsAt(workloads, id.namespace, time.Unix(samples[idx].Timestamp, 0), startTime)
+			}
+			output := getNamespace(id.namespace)
+			output.Quotas = append(output.Quotas, QuotaHistory{Name: id.name, Samples: samples})
+		}
+		for id, records := range limitRanges {
+			changes := limitRangeChanges(records, startTime, endTime)
+			if len(changes) == 0 {
+				continue
+			}
+			output := getNamespace(id.namespace)
+			output.LimitRanges = append(output.LimitRanges, LimitRangeHistory{Name: id.name, Changes: changes})
+		}
+		return nil
+	})
+	if err != nil {
+		return []byte{}, err
+	}
+
+	output := []QuotaUtilizationOutput{}
+	for _, namespaceOutput := range byNamespace {
+		sort.Slice(namespaceOutput.Quotas, func(i, j int) bool { return namespaceOutput.Quotas[i].Name < namespaceOutput.Quotas[j].Name })
+		sort.Slice(namespaceOutput.LimitRanges, func(i, j int) bool { return namespaceOutput.LimitRanges[i].Name < namespaceOutput.LimitRanges[j].Name })
+		output = append(output, *namespaceOutput)
+	}
+	sort.Slice(output, func(i, j int) bool { return output[i].Namespace < output[j].Namespace })
+
+	bytes, err := json.MarshalIndent(output, "", " ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal json %v", err)
+	}
+	return bytes, nil
+}
+
+// Resources of one namespace are a single prefix, otherwise every key is checked with keepRowHelper
+func getNamespacedKindKeyPrefix(kind string, selectedNamespace string) *typed.WatchTableKey {
+	if selectedNamespace == AllNamespaces {
+		return nil
+	}
+	return typed.NewWatchTableKeyComparator(kind, selectedNamespace, "", time.Time{})
+}
+
+// Watch records of a namespaced kind in the time range, along with the last one before it of each resource
+func readNamespacedWatchRecords(txn badgerwrap.Txn, t typed.Tables, kind string, selectedNamespace string, startTime time.Time, endTime time.Time, requestId string) (map[watchResourceId][]watchRecord, error) {
+	keyPredicate := func(key string) bool {
+		k := &typed.WatchTableKey{}
+		err := k.Parse(key)
+		if err != nil {
+			return false
+		}
+		return keepRowHelper(k.Name, k.Kind, k.Namespace, kind, selectedNamespace, "", "", "", "")
+	}
+	records, stats, err := t.WatchTable().RangeRead(txn, getNamespacedKindKeyPrefix(kind, selectedNamespace), keyPredicate, nil, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+	stats.Log(requestId)
+
+	grouped := groupWatchRecords(records)
+	err = addWatchRecordsBefore(txn, t, kind, grouped, startTime)
+	if err != nil {
+		return nil, err
+	}
+	return grouped, nil
+}
+
+// Like claimBindingChanges the sample from before the start time is kept as the first one, and repeats of the same
+// usage are dropped
+func quotaSamples(records []watchRecord, startTime time.Time, endTime time.Time) []QuotaSample {
+	samples := []QuotaSample{}
+	var before *QuotaSample
+	for _, record := range records {
+		if record.timestamp > endTime.Unix() {
+			break
+		}
+		usage, err := kubeextractor.ExtractQuotaUsage(record.result.Payload)
+		if err != nil {
+			glog.Errorf("Failed to extract quota usage: %v", err)
+			continue
+		}
+		sample := QuotaSample{
+			Timestamp: record.timestamp,
+			Deleted:   record.result.WatchType == typed.KubeWatchResult_DELETE,
+			Resources: quotaResourceUsages(usage),
+		}
+		if sample.Timestamp < startTime.Unix() {
+			before = &sample
+			continue
+		}
+		if len(samples) == 0 && before != nil {
+			samples = append(samples, *before)
+		}
+		if len(samples) > 0 {
+			last := samples[len(samples)-1]
+			if last.Deleted == sample.Deleted && reflect.DeepEqual(last.Resources, sample.Resources) {
+				continue
+			}
+		}
+		samples = append(samples, sample)
+	}
+	if len(samples) == 0 && before != nil && !before.Deleted {
+		samples = append(samples, *before)
+	}
+	return samples
+}
+
+func quotaResourceUsages(usage kubeextractor.QuotaUsage) []QuotaResourceUsage {
+	ret := []QuotaResourceUsage{}
+	for name, hard := range usage.Hard {
+		used := usage.Used[name]
+		ret = append(ret, QuotaResourceUsage{Resource: name, Hard: hard, Used: used, Utilization: quotaUtilization(hard, used)})
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Resource < ret[j].Resource })
+	return ret
+}
+
+func quotaUtilization(hard string, used string) float64 {
+	hardQuantity, err := resource.ParseQuantity(hard)
+	if err != nil || hardQuantity.IsZero() {
+		return 0
+	}
+	usedQuantity, err := resource.ParseQuantity(used)
+	if err != nil {
+		return 0
+	}
+	return float64(usedQuantity.MilliValue()) / float64(hardQuantity.MilliValue())
+}
+
+func limitRangeChanges(records []watchRecord, startTime time.Time, endTime time.Time) []LimitRangeChange {
+	changes := []LimitRangeChange{}
+	var before *LimitRangeChange
+	for _, record := range records {
+		if record.timestamp > endTime.Unix() {
+			break
+		}
+		limits, err := kubeextractor.ExtractLimitRangeItems(record.result.Payload)
+		if err != nil {
+			glog.Errorf("Failed to extract limit range: %v", err)
+			continue
+		}
+		change := LimitRangeChange{Timestamp: record.timestamp, Deleted: record.result.WatchType == typed.KubeWatchResult_DELETE, Limits: limits}
+		if change.Timestamp < startTime.Unix() {
+			before = &change
+			continue
+		}
+		if len(changes) == 0 && before != nil {
+			changes = append(changes, *before)
+		}
+		if len(changes) > 0 {
+			last := changes[len(changes)-1]
+			if last.Deleted == change.Deleted && reflect.DeepEqual(last.Limits, change.Limits) {
+				continue
+			}
+		}
+		changes = append(changes, change)
+	}
+	if len(changes) == 0 && before != nil && !before.Deleted {
+		changes = append(changes, *before)
+	}
+	return changes
+}
+
+// Lifetimes of the workloads in the time range, keyed by uid
+func getWorkloadLifetimes(txn badgerwrap.Txn, t typed.Tables, selectedNamespace string, startTime time.Time, endTime time.Time, requestId string) (map[string]*workloadLifetime, error) {
+	lifetimes := map[string]*workloadLifetime{}
+	for _, kind := range quotaWorkloadKinds {
+		var prefix *typed.ResourceSummaryKey
+		if selectedNamespace != AllNamespaces {
+			prefix = typed.NewResourceSummaryKeyComparator(kind, selectedNamespace, "", "")
+		}
+		keyPredicate := func(key string) bool {
+			k := &typed.ResourceSummaryKey{}
+			err := k.Parse(key)
+			if err != nil {
+				return false
+			}
+			return keepRowHelper(k.Name, k.Kind, k.Namespace, kind, selectedNamespace, "", "", "", "")
+		}
+		summaries, stats, err := t.ResourceSummaryTable().RangeRead(txn, prefix, keyPredicate, nil, startTime, endTime)
+		if err != nil {
+			return nil, err
+		}
+		stats.Log(requestId)
+
+		for key, summary := range summaries {
+			start, err := ptypes.Timestamp(summary.CreateTime)
+			if err != nil || start.Unix() <= 0 {
+				start, err = ptypes.Timestamp(summary.FirstSeen)
+				if err != nil {
+					continue
+				}
+			}
+			lastSeen, err := ptypes.Timestamp(summary.LastSeen)
+			if err != nil {
+				continue
+			}
+			// The same uid shows up once per partition
+			lifetime, ok := lifetimes[key.Uid]
+			if !ok {
+				lifetimes[key.Uid] = &workloadLifetime{kind: key.Kind, namespace: key.Namespace, start: start, lastSeen: lastSeen, deleted: summary.DeletedAtEnd}
+				continue
+			}
+			if start.Before(lifetime.start) {
+				lifetime.start = start
+			}
+			if lastSeen.After(lifetime.lastSeen) {
+				lifetime.lastSeen = lastSeen
+			}
+			lifetime.deleted = lifetime.deleted || summary.DeletedAtEnd
+		}
+	}
+	return lifetimes, nil
+}
+
+// Summaries only cover the partitions of the time range, so samples from before it are counted at the start time
+func countWorkloadsAt(lifetimes map[string]*workloadLifetime, namespace string, ts time.Time, startTime time.Time) map[string]int {
+	if ts.Before(startTime) {
+		ts = startTime
+	}
+	counts := map[string]int{}
+	for _, kind := range quotaWorkloadKinds {
+		counts[kind] = 0
+	}
+	for _, lifetime := range lifetimes {
+		if lifetime.namespace != namespace || lifetime.start.After(ts) {
+			continue
+		}
+		if lifetime.deleted && lifetime.lastSeen.Before(ts) {
+			continue
+		}
+		counts[lifetime.kind]++
+	}
+	return counts
+}
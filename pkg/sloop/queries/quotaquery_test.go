@@ -0,0 +1,96 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+func helper_quotaPayload(usedCpu string, usedPods string) string {
+	return fmt.Sprintf(`{"metadata": {"name": "compute"}, "spec": {"hard": {"requests.cpu": "4", "pods": "10"}}, "status": {"hard": {"requests.cpu": "4", "pods": "10"}, "used": {"requests.cpu": "%v", "pods": "%v"}}}`, usedCpu, usedPods)
+}
+
+func Test_GetQuotaUtilization(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+
+	partitionId := untyped.GetPartitionId(someTs)
+	quotaKind := kubeextractor.ResourceQuotaKind
+	helper_setWatchResults(t, tables, map[*typed.WatchTableKey]string{
+		// Before the start time, kept as the first sample
+		typed.NewWatchTableKey(partitionId, quotaKind, "ns", "compute", someTs.Add(-time.Minute)): helper_quotaPayload("1", "1"),
+		// Same usage, dropped
+		typed.NewWatchTableKey(partitionId, quotaKind, "ns", "compute", someTs.Add(time.Minute)):    helper_quotaPayload("1", "1"),
+		typed.NewWatchTableKey(partitionId, quotaKind, "ns", "compute", someTs.Add(10*time.Minute)): helper_quotaPayload("3500m", "2"),
+		typed.NewWatchTableKey(partitionId, quotaKind, "other", "compute", someTs.Add(time.Minute)): helper_quotaPayload("1", "1"),
+		typed.NewWatchTableKey(partitionId, kubeextractor.LimitRangeKind, "ns", "defaults", someTs): `{"spec": {"limits": [{"type": "Container", "default": {"cpu": "500m"}}]}}`,
+	})
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		summaries := map[*typed.ResourceSummaryKey]*typed.ResourceSummary{
+			typed.NewResourceSummaryKey(someTs, "Pod", "ns", "pod-a", "uid-a"): helper_resSum(t, someTs.Add(-time.Hour), someTs.Add(time.Hour), false),
+			typed.NewResourceSummaryKey(someTs, "Pod", "ns", "pod-b", "uid-b"): helper_resSum(t, someTs.Add(5*time.Minute), someTs.Add(time.Hour), false),
+			// Gone before the second sample
+			typed.NewResourceSummaryKey(someTs, "Pod", "ns", "pod-c", "uid-c"):        helper_resSum(t, someTs.Add(-time.Hour), someTs.Add(2*time.Minute), true),
+			typed.NewResourceSummaryKey(someTs, "Deployment", "ns", "web", "uid-web"): helper_resSum(t, someTs.Add(-time.Hour), someTs.Add(time.Hour), false),
+		}
+		for key, value := range summaries {
+			key.PartitionId = partitionId
+			err := tables.ResourceSummaryTable().Set(txn, key.String(), value)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+
+	values := helper_get_params()
+	values[NamespaceParam] = []string{"ns"}
+	data, err := GetQuotaUtilization(values, tables, someTs, someTs.Add(time.Hour), someRequestId)
+	assert.Nil(t, err)
+	output := []QuotaUtilizationOutput{}
+	assert.Nil(t, json.Unmarshal(data, &output))
+	assert.Len(t, output, 1)
+	assert.Equal(t, "ns", output[0].Namespace)
+	assert.Len(t, output[0].Quotas, 1)
+
+	samples := output[0].Quotas[0].Samples
+	assert.Len(t, samples, 2)
+	assert.Equal(t, someTs.Add(-time.Minute).Unix(), samples[0].Timestamp)
+	assert.Equal(t, []QuotaResourceUsage{
+		{Resource: "pods", Hard: "10", Used: "2", Utilization: 0.2},
+		{Resource: "requests.cpu", Hard: "4", Used: "3500m", Utilization: 0.875},
+	}, samples[1].Resources)
+	assert.Equal(t, 2, samples[0].Workloads["Pod"])
+	assert.Equal(t, 2, samples[1].Workloads["Pod"])
+	assert.Equal(t, 1, samples[1].Workloads["Deployment"])
+
+	assert.Len(t, output[0].LimitRanges, 1)
+	assert.Equal(t, "defaults", output[0].LimitRanges[0].Name)
+	assert.Equal(t, "500m", output[0].LimitRanges[0].Changes[0].Limits[0].Default["cpu"])
+
+	values[NamespaceParam] = []string{AllNamespaces}
+	data, err = GetQuotaUtilization(values, tables, someTs, someTs.Add(time.Hour), someRequestId)
+	assert.Nil(t, err)
+	output = []QuotaUtilizationOutput{}
+	assert.Nil(t, json.Unmarshal(data, &output))
+	assert.Len(t, output, 2)
+	assert.Equal(t, "other", output[1].Namespace)
+}
@@ -73,26 +73,50 @@ func paramFilterWatchActivityFn(params url.Values) func(string) bool {
 	}
 }
 
-// TODO: Try and remove some of this special logic.  Maybe have a generic approach for resources that dont have namespaces
-func keepRowHelper(name string, kind string, namespace string, selectedKind string, selectedNamespace string, selectedNameMatchSubstring string, selectedNameExactMatch string, selectedUuid string, uuid string) bool {
-	// Edge cases:
-	// 1) Node does not have a namespace
-	// 2) Namespace does not have a namespace
+func paramFilterPodLifecycleFn(params url.Values) func(string) bool {
+	selectedNamespace := params.Get(NamespaceParam)
+	selectedNameSubstring := params.Get(NameMatchParam)
+	selectedNameExactMatch := params.Get(NameParam)
+	selectedUuid := params.Get(UuidParam)
+	return func(key string) bool {
+		k := &typed.PodLifecycleKey{}
+		err := k.Parse(key)
+		if err != nil {
+			return false
+		}
+		return keepRowHelper(k.Name, k.Kind, k.Namespace, kubeextractor.PodKind, selectedNamespace, selectedNameSubstring, selectedNameExactMatch, selectedUuid, k.Uid)
+	}
+}
 
+func paramFilterNodeConditionFn(params url.Values) func(string) bool {
+	selectedNameSubstring := params.Get(NameMatchParam)
+	selectedNameExactMatch := params.Get(NameParam)
+	selectedUuid := params.Get(UuidParam)
+	return func(key string) bool {
+		k := &typed.NodeConditionKey{}
+		err := k.Parse(key)
+		if err != nil {
+			return false
+		}
+		return keepRowHelper(k.Name, k.Kind, k.Namespace, kubeextractor.NodeKind, AllNamespaces, selectedNameSubstring, selectedNameExactMatch, selectedUuid, k.Uid)
+	}
+}
+
+func keepRowHelper(name string, kind string, namespace string, selectedKind string, selectedNamespace string, selectedNameMatchSubstring string, selectedNameExactMatch string, selectedUuid string, uuid string) bool {
 	if selectedKind != AllKinds {
 		if selectedKind != kind {
 			return false
 		}
 	} else {
-		// When showing all kinds and a namespace is set dont show nodes
-		if selectedNamespace != AllNamespaces && kind == kubeextractor.NodeKind {
+		// When showing all kinds and a namespace is set dont show cluster scoped resources, apart from the namespace
+		if selectedNamespace != AllNamespaces && kind != kubeextractor.NamespaceKind && kubeextractor.IsClustersScopedResource(kind) {
 			return false
 		}
 	}
 
-	// Nodes do not have a namespace.  If user set kind=Node then no need to filter on namespace
-	// which would just confuse the user when they dont see the nodes
-	if selectedNamespace != AllNamespaces && selectedKind != kubeextractor.NodeKind {
+	// Cluster scoped resources do not have a namespace.  If the user selected such a kind then no need to filter on
+	// namespace which would just confuse the user when they dont see anything
+	if selectedNamespace != AllNamespaces && (selectedKind == kubeextractor.NamespaceKind || !kubeextractor.IsClustersScopedResource(selectedKind)) {
 		if kind == kubeextractor.NamespaceKind {
 			// A namespace itself does not have a namespace, so instead match on name
 			if selectedNamespace != name {
@@ -159,16 +183,31 @@ func isEventValInTimeRange(startTime time.Time, endTime time.Time) func(*typed.K
 	}
 }
 
+// Events are linked to the uid of their involved object at ingest, so with uuid set the events of an earlier or later
+// resource with the same name are left out.  Events that could not be linked are kept
 func matchEventInvolvedObject(params url.Values) func(*typed.KubeWatchResult) bool {
 	selectedKind := params.Get(KindParam)
+	selectedName := params.Get(NameParam)
+	selectedUid := params.Get(UuidParam)
 	return func(retVal *typed.KubeWatchResult) bool {
-		involvedObj, err := kubeextractor.ExtractInvolvedObject(retVal.Payload)
+		involvedObj, err := kubeextractor.ExtractEventInvolvedObject(kubeextractor.EventKind, kubeextractor.KubeMetadata{}, retVal.Payload)
 		if err != nil {
 			return false
 		}
 		if involvedObj.Kind != selectedKind {
 			return false
 		}
+		// The key prefix also matches events of resources whose name starts with the selected name and a dot
+		if selectedName != "" && involvedObj.Name != "" && involvedObj.Name != selectedName {
+			return false
+		}
+		uid := retVal.InvolvedUid
+		if uid == "" {
+			uid = involvedObj.Uid
+		}
+		if selectedUid != "" && uid != "" && uid != selectedUid {
+			return false
+		}
 		return true
 	}
 }
@@ -72,6 +72,17 @@ func Test_isFiltered_NodeReturnedForAllKindsAllNamespace(t *testing.T) {
 	assert.True(t, flag)
 }
 
+func Test_isFiltered_ClusterScopedKindIgnoresNamespace(t *testing.T) {
+	values := helper_get_params()
+	values["kind"] = []string{"PersistentVolume"}
+	values["namespace"] = []string{"someNamespace"}
+	key := "/ressum/001567094400/PersistentVolume//somevolume/96b0e282-9744-11e8-9d31-1418775557c8"
+	assert.True(t, paramFilterResSumFn(values)(key))
+
+	values["kind"] = []string{AllKinds}
+	assert.False(t, paramFilterResSumFn(values)(key))
+}
+
 func Test_isFiltered_KindIsNamespaceMatchNameNotNamespace(t *testing.T) {
 	values := make(map[string][]string)
 	values[NamespaceParam] = []string{"some-namespace"}
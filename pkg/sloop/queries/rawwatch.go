@@ -0,0 +1,81 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+// One stored watch result as it is in the watch table, with what ingest recorded about where it came from
+type RawWatchResult struct {
+	Key string `json:"key"`
+	// Unix nanoseconds, which is the order of the results of a resource
+	Timestamp int64           `json:"timestamp"`
+	WatchType string          `json:"watchType"`
+	Payload   json.RawMessage `json:"payload"`
+	// first, resync or changed, empty for results stored before it was recorded
+	VersionType      string   `json:"versionType,omitempty"`
+	ChangedPaths     []string `json:"changedPaths,omitempty"`
+	ChangedPathCount int32    `json:"changedPathCount,omitempty"`
+	// Set when ingest signed the result
+	Signed          bool   `json:"signed,omitempty"`
+	ClockSkewMillis int64  `json:"clockSkewMillis,omitempty"`
+	InvolvedUid     string `json:"involvedUid,omitempty"`
+	// A version of an update's old object that was never stored on its own, and for deletes watch or cache
+	OldPayload json.RawMessage `json:"oldPayload,omitempty"`
+	FinalState string          `json:"finalState,omitempty"`
+	// Set when the previous stored version had another api version
+	ApiVersion             string `json:"apiVersion,omitempty"`
+	ApiVersionMigratedFrom string `json:"apiVersionMigratedFrom,omitempty"`
+}
+
+// Returns the stored watch results of one resource with timestamps after after and up to end, oldest first
+func GetRawWatchResults(t typed.Tables, kind string, namespace string, name string, after time.Time, end time.Time) ([]RawWatchResult, error) {
+	namespace = kubeextractor.NormalizeNamespace(kind, namespace)
+	results := []RawWatchResult{}
+	err := t.Db().View(func(txn badgerwrap.Txn) error {
+		keyPrefix := typed.NewWatchTableKeyComparator(kind, namespace, name, time.Time{})
+		stored, _, err := t.WatchTable().RangeRead(txn, keyPrefix, nil, nil, after, end)
+		if err != nil {
+			return err
+		}
+		for key, result := range stored {
+			if !key.Timestamp.After(after) || key.Timestamp.After(end) {
+				continue
+			}
+			raw := RawWatchResult{
+				Key:                    key.String(),
+				Timestamp:              key.Timestamp.UnixNano(),
+				WatchType:              result.WatchType.String(),
+				Payload:                json.RawMessage(result.Payload),
+				VersionType:            result.VersionType,
+				ChangedPaths:           result.ChangedPaths,
+				ChangedPathCount:       result.ChangedPathCount,
+				Signed:                 len(result.Signature) > 0,
+				ClockSkewMillis:        result.ClockSkewMillis,
+				InvolvedUid:            result.InvolvedUid,
+				FinalState:             result.FinalState,
+				ApiVersion:             result.ApiVersion,
+				ApiVersionMigratedFrom: result.ApiVersionMigratedFrom,
+			}
+			if result.OldPayload != "" {
+				raw.OldPayload = json.RawMessage(result.OldPayload)
+			}
+			results = append(results, raw)
+		}
+		return nil
+	})
+	sort.Slice(results, func(i, j int) bool { return results[i].Timestamp < results[j].Timestamp })
+	return results, err
+}
@@ -0,0 +1,65 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+type ResourceAtOutput struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	// Unix seconds of the watch result
+	Timestamp int64  `json:"timestamp"`
+	WatchType string `json:"watchType"`
+	// The resource was deleted by then, and the payload is its last version
+	Deleted bool            `json:"deleted,omitempty"`
+	Payload json.RawMessage `json:"payload"`
+	// Set when asked to resolve references, see ResolveReferences
+	References []ResourceReferenceOutput `json:"references,omitempty"`
+}
+
+// Returns the payload stored for the resource at or before ts, or nil when there is none.  This is one reverse seek
+// per partition from the one of ts back to the first one holding the resource, so it stays cheap for a single object
+func GetResourceAt(t typed.Tables, kind string, namespace string, name string, ts time.Time) (*ResourceAtOutput, error) {
+	namespace = kubeextractor.NormalizeNamespace(kind, namespace)
+	var output *ResourceAtOutput
+	err := t.Db().View(func(txn badgerwrap.Txn) error {
+		keyComparator := typed.NewWatchTableKeyComparator(kind, namespace, name, time.Time{})
+		// GetPreviousKey skips a key equal to the seek key, so seek just past ts to include a result at ts
+		seekTs := ts.Add(time.Nanosecond)
+		seekKey := typed.NewWatchTableKey(untyped.GetPartitionId(seekTs), kind, namespace, name, seekTs)
+		previousKey, err := t.WatchTable().GetPreviousKey(txn, seekKey, keyComparator)
+		if err != nil {
+			// Nothing stored at or before ts
+			return nil
+		}
+		result, err := t.WatchTable().Get(txn, previousKey.String())
+		if err != nil {
+			return err
+		}
+		output = &ResourceAtOutput{
+			Kind:      previousKey.Kind,
+			Namespace: previousKey.Namespace,
+			Name:      previousKey.Name,
+			Timestamp: previousKey.Timestamp.Unix(),
+			WatchType: result.WatchType.String(),
+			Deleted:   result.WatchType == typed.KubeWatchResult_DELETE,
+			Payload:   json.RawMessage(result.Payload),
+		}
+		return nil
+	})
+	return output, err
+}
@@ -0,0 +1,90 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+func helper_getResourceAtTables(t *testing.T) typed.Tables {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		for _, version := range []struct {
+			ts        time.Time
+			name      string
+			watchType typed.KubeWatchResult_WatchType
+			payload   string
+		}{
+			{someTs.Add(-3 * time.Hour), "pod-a", typed.KubeWatchResult_ADD, `{"v":1}`},
+			{someTs, "pod-a", typed.KubeWatchResult_UPDATE, `{"v":2}`},
+			{someTs.Add(time.Minute), "pod-a", typed.KubeWatchResult_DELETE, `{"v":3}`},
+			{someTs.Add(-time.Minute), "pod-ab", typed.KubeWatchResult_ADD, `{"v":4}`},
+		} {
+			key := typed.NewWatchTableKey(untyped.GetPartitionId(version.ts), "Pod", "some-namespace", version.name, version.ts)
+			err := tables.WatchTable().Set(txn, key.String(), &typed.KubeWatchResult{Kind: "Pod", WatchType: version.watchType, Payload: version.payload})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+	return tables
+}
+
+func Test_GetResourceAt_ExactTimestamp(t *testing.T) {
+	tables := helper_getResourceAtTables(t)
+	output, err := GetResourceAt(tables, "Pod", "some-namespace", "pod-a", someTs)
+	assert.Nil(t, err)
+	assert.NotNil(t, output)
+	assert.Equal(t, someTs.Unix(), output.Timestamp)
+	assert.Equal(t, "UPDATE", output.WatchType)
+	assert.False(t, output.Deleted)
+	assert.Equal(t, `{"v":2}`, string(output.Payload))
+}
+
+func Test_GetResourceAt_EarlierPartition(t *testing.T) {
+	tables := helper_getResourceAtTables(t)
+	output, err := GetResourceAt(tables, "Pod", "some-namespace", "pod-a", someTs.Add(-time.Hour))
+	assert.Nil(t, err)
+	assert.NotNil(t, output)
+	assert.Equal(t, `{"v":1}`, string(output.Payload))
+}
+
+func Test_GetResourceAt_Deleted(t *testing.T) {
+	tables := helper_getResourceAtTables(t)
+	output, err := GetResourceAt(tables, "Pod", "some-namespace", "pod-a", someTs.Add(time.Hour))
+	assert.Nil(t, err)
+	assert.NotNil(t, output)
+	assert.True(t, output.Deleted)
+	assert.Equal(t, `{"v":3}`, string(output.Payload))
+}
+
+func Test_GetResourceAt_BeforeFirstVersion(t *testing.T) {
+	tables := helper_getResourceAtTables(t)
+	output, err := GetResourceAt(tables, "Pod", "some-namespace", "pod-ab", someTs.Add(-2*time.Minute))
+	assert.Nil(t, err)
+	assert.Nil(t, output)
+}
+
+func Test_GetResourceAt_UnknownResource(t *testing.T) {
+	tables := helper_getResourceAtTables(t)
+	output, err := GetResourceAt(tables, "Pod", "other", "pod-a", someTs)
+	assert.Nil(t, err)
+	assert.Nil(t, output)
+}
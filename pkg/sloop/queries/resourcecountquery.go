@@ -0,0 +1,220 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+// Kind of the series with the counts of all kinds together
+const resourceCountTotalKind = "Total"
+
+type ResourceCountOutput struct {
+	// Sorted by kind, with the total first
+	Series []ResourceCountSeries `json:"series"`
+}
+
+type ResourceCountSeries struct {
+	Kind   string               `json:"kind"`
+	Points []ResourceCountPoint `json:"points"`
+	// Least squares fit of the points, in objects per day
+	GrowthPerDay float64 `json:"growthPerDay"`
+	// Unix seconds when the fit reaches the limit param, 0 when it is not growing towards it.  The time of the first
+	// point at or over the limit when it was already reached
+	LimitReachedAt int64 `json:"limitReachedAt,omitempty"`
+}
+
+type ResourceCountPoint struct {
+	// Unix seconds of the start of the partition
+	Timestamp int64 `json:"timestamp"`
+	Count     int   `json:"count"`
+}
+
+/*
+Counts the objects of each kind in every partition of the time range, from the resource summaries, to follow the
+growth of what is stored in etcd and forecast when a cluster limit is hit.  An object counts in a partition when it has
+a summary there and was not deleted by its end, so kinds watched with a resync interval longer than the partition
+duration, and the newest partition before its first resync, undercount.  Params: kind and namespace select the
+objects, and limit is the object count to forecast each series against
+*/
+func GetResourceCounts(params url.Values, t typed.Tables, startTime time.Time, endTime time.Time, requestId string) ([]byte, error) {
+	limit := 0
+	if value := params.Get(LimitParam); value != "" {
+		var err error
+		limit, err = strconv.Atoi(value)
+		if err != nil || limit <= 0 {
+			return []byte{}, NewApiError(ErrorCodeBadParams, "invalid %v %q, must be a positive number of objects", LimitParam, value)
+		}
+	}
+
+	var summaries map[typed.ResourceSummaryKey]*typed.ResourceSummary
+	err := t.Db().View(func(txn badgerwrap.Txn) error {
+		var err2 error
+		var stats typed.RangeReadStats
+		summaries, stats, err2 = t.ResourceSummaryTable().RangeRead(txn, nil, paramFilterResSumFn(params), nil, startTime, endTime)
+		if err2 != nil {
+			return err2
+		}
+		stats.Log(requestId)
+		return nil
+	})
+	if err != nil {
+		return []byte{}, err
+	}
+
+	// Kind to partition to count
+	counts := map[string]map[string]int{resourceCountTotalKind: {}}
+	for key, summary := range summaries {
+		if summary.DeletedAtEnd {
+			continue
+		}
+		if _, ok := counts[key.Kind]; !ok {
+			counts[key.Kind] = map[string]int{}
+		}
+		counts[key.Kind][key.PartitionId]++
+		counts[resourceCountTotalKind][key.PartitionId]++
+	}
+	partitionIds := []string{}
+	for partitionId := range counts[resourceCountTotalKind] {
+		partitionIds = append(partitionIds, partitionId)
+	}
+	sort.Strings(partitionIds)
+
+	output := ResourceCountOutput{Series: []ResourceCountSeries{}}
+	for kind, byPartition := range counts {
+		series := ResourceCountSeries{Kind: kind, Points: []ResourceCountPoint{}}
+		// Every series has a point for each partition, so kinds that went away drop to 0
+		for _, partitionId := range partitionIds {
+			partitionStart, err := untyped.GetTimeForPartition(partitionId)
+			if err != nil {
+				return []byte{}, err
+			}
+			series.Points = append(series.Points, ResourceCountPoint{Timestamp: partitionStart.Unix(), Count: byPartition[partitionId]})
+		}
+		growth, limitReachedAt := forecastResourceCount(series.Points, limit)
+		series.GrowthPerDay = math.Round(growth*100) / 100
+		series.LimitReachedAt = limitReachedAt
+		output.Series = append(output.Series, series)
+	}
+	sort.Slice(output.Series, func(i, j int) bool {
+		a, b := output.Series[i], output.Series[j]
+		if (a.Kind == resourceCountTotalKind) != (b.Kind == resourceCountTotalKind) {
+			return a.Kind == resourceCountTotalKind
+		}
+		return a.Kind < b.Kind
+	})
+
+	bytes, err := json.MarshalIndent(output, "", " ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal json %v", err)
+	}
+	return bytes, nil
+}
+
+// Returns the slope of the least squares fit of the points in objects per day, and when it reaches limit
+func forecastResourceCount(points []ResourceCountPoint, limit int) (float64, int64) {
+	slope := fitResourceCountSlope(points)
+	if limit <= 0 || len(points) == 0 {
+		return slope, 0
+	}
+	for _, point := range points {
+		if point.Count >= limit {
+			return slope, point.Timestamp
+		}
+	}
+	if slope <= 0 {
+		return slope, 0
+	}
+	last := points[len(points)-1]
+	days := float64(limit-last.Count) / slope
+	return slope, last.Timestamp + int64(days*24*60*60)
+}
+
+func fitResourceCountSlope(points []ResourceCountPoint) float64 {
+	if len(points) < 2 {
+		return 0
+	}
+	n := float64(len(points))
+	var sumX, sumY, sumXY, sumXX float64
+	for _, point := range points {
+		// Days since the first point, to keep the sums small
+		x := float64(point.Timestamp-points[0].Timestamp) / (24 * 60 * 60)
+		y := float64(point.Count)
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return 0
+	}
+	return (n*sumXY - sumX*sumY) / denominator
+}
+
+// Series of several shards with the same kind, always the total, add up by partition and get forecast again
+func mergeResourceCounts(params url.Values, results [][]byte, shardErrors []ShardErrorOutput) ([]byte, error) {
+	limit, _ := strconv.Atoi(params.Get(LimitParam))
+	// Kind to timestamp to count
+	counts := map[string]map[int64]int{}
+	for idx, result := range results {
+		var shardCounts ResourceCountOutput
+		err := json.Unmarshal(result, &shardCounts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal resource counts from shard result %v: %v", idx, err)
+		}
+		for _, series := range shardCounts.Series {
+			if _, ok := counts[series.Kind]; !ok {
+				counts[series.Kind] = map[int64]int{}
+			}
+			for _, point := range series.Points {
+				counts[series.Kind][point.Timestamp] += point.Count
+			}
+		}
+	}
+	timestamps := []int64{}
+	for timestamp := range counts[resourceCountTotalKind] {
+		timestamps = append(timestamps, timestamp)
+	}
+	sort.Slice(timestamps, func(i, j int) bool { return timestamps[i] < timestamps[j] })
+
+	output := ResourceCountOutput{Series: []ResourceCountSeries{}}
+	for kind, byTimestamp := range counts {
+		series := ResourceCountSeries{Kind: kind, Points: []ResourceCountPoint{}}
+		for _, timestamp := range timestamps {
+			series.Points = append(series.Points, ResourceCountPoint{Timestamp: timestamp, Count: byTimestamp[timestamp]})
+		}
+		growth, limitReachedAt := forecastResourceCount(series.Points, limit)
+		series.GrowthPerDay = math.Round(growth*100) / 100
+		series.LimitReachedAt = limitReachedAt
+		output.Series = append(output.Series, series)
+	}
+	sort.Slice(output.Series, func(i, j int) bool {
+		a, b := output.Series[i], output.Series[j]
+		if (a.Kind == resourceCountTotalKind) != (b.Kind == resourceCountTotalKind) {
+			return a.Kind == resourceCountTotalKind
+		}
+		return a.Kind < b.Kind
+	})
+
+	bytes, err := json.MarshalIndent(output, "", " ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal json %v", err)
+	}
+	return bytes, nil
+}
@@ -0,0 +1,107 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+func helper_get_resourceCountTables(t *testing.T) typed.Tables {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		for hour := 0; hour < 3; hour++ {
+			ts := someTs.Add(time.Duration(hour) * time.Hour)
+			// One more pod every hour
+			for pod := 0; pod <= hour; pod++ {
+				key := typed.NewResourceSummaryKey(ts, "Pod", "some-namespace", fmt.Sprintf("pod-%v", pod), fmt.Sprintf("uid-%v", pod))
+				err := tables.ResourceSummaryTable().Set(txn, key.String(), &typed.ResourceSummary{})
+				if err != nil {
+					return err
+				}
+			}
+			// Deleted in the last hour
+			key := typed.NewResourceSummaryKey(ts, "ConfigMap", "some-namespace", "config", "uid-config")
+			err := tables.ResourceSummaryTable().Set(txn, key.String(), &typed.ResourceSummary{DeletedAtEnd: hour == 2})
+			if err != nil {
+				return err
+			}
+		}
+		key := typed.NewResourceSummaryKey(someTs, "Pod", "other-namespace", "pod-x", "uid-x")
+		return tables.ResourceSummaryTable().Set(txn, key.String(), &typed.ResourceSummary{})
+	})
+	assert.Nil(t, err)
+	return tables
+}
+
+func Test_GetResourceCounts(t *testing.T) {
+	tables := helper_get_resourceCountTables(t)
+	values := helper_get_params()
+	values[LimitParam] = []string{"6"}
+	data, err := GetResourceCounts(values, tables, someTs, someTs.Add(2*time.Hour), someRequestId)
+	assert.Nil(t, err)
+	output := &ResourceCountOutput{}
+	assert.Nil(t, json.Unmarshal(data, output))
+	assert.Len(t, output.Series, 3)
+
+	start, err := untyped.GetTimeForPartition(untyped.GetPartitionId(someTs))
+	assert.Nil(t, err)
+	counts := func(series ResourceCountSeries) []int {
+		result := []int{}
+		for idx, point := range series.Points {
+			assert.Equal(t, start.Add(time.Duration(idx)*time.Hour).Unix(), point.Timestamp)
+			result = append(result, point.Count)
+		}
+		return result
+	}
+	assert.Equal(t, "Total", output.Series[0].Kind)
+	assert.Equal(t, []int{2, 3, 3}, counts(output.Series[0]))
+	assert.Equal(t, "ConfigMap", output.Series[1].Kind)
+	assert.Equal(t, []int{1, 1, 0}, counts(output.Series[1]))
+	assert.Equal(t, int64(0), output.Series[1].LimitReachedAt)
+	assert.Equal(t, "Pod", output.Series[2].Kind)
+	assert.Equal(t, []int{1, 2, 3}, counts(output.Series[2]))
+	// One more pod every hour reaches 6 in three more hours
+	assert.Equal(t, float64(24), output.Series[2].GrowthPerDay)
+	assert.Equal(t, start.Add(5*time.Hour).Unix(), output.Series[2].LimitReachedAt)
+}
+
+func Test_GetResourceCounts_InvalidLimit(t *testing.T) {
+	tables := helper_get_resourceCountTables(t)
+	values := helper_get_params()
+	values[LimitParam] = []string{"-1"}
+	_, err := GetResourceCounts(values, tables, someTs, someTs.Add(time.Hour), someRequestId)
+	assert.NotNil(t, err)
+}
+
+func Test_forecastResourceCount(t *testing.T) {
+	points := []ResourceCountPoint{{Timestamp: someTs.Unix(), Count: 10}, {Timestamp: someTs.Unix() + 86400, Count: 8}}
+	growth, reachedAt := forecastResourceCount(points, 20)
+	assert.Equal(t, float64(-2), growth)
+	assert.Equal(t, int64(0), reachedAt)
+
+	// Already reached by the first point
+	_, reachedAt = forecastResourceCount(points, 9)
+	assert.Equal(t, someTs.Unix(), reachedAt)
+
+	growth, reachedAt = forecastResourceCount(points[:1], 0)
+	assert.Equal(t, float64(0), growth)
+	assert.Equal(t, int64(0), reachedAt)
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"sort"
+	"time"
+
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+)
+
+// Kinds with a pod spec or pod template whose references can be resolved
+var referencingKinds = map[string]bool{
+	kubeextractor.PodKind:         true,
+	kubeextractor.DeploymentKind:  true,
+	kubeextractor.ReplicaSetKind:  true,
+	kubeextractor.StatefulSetKind: true,
+	kubeextractor.DaemonSetKind:   true,
+	kubeextractor.JobKind:         true,
+	kubeextractor.CronJobKind:     true,
+	"ReplicationController":       true,
+}
+
+type ResourceReferenceOutput struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+	// Where the resource refers to it, like volume:config, env:app/LOG_LEVEL or serviceAccountName
+	Usages []string `json:"usages"`
+	// The referenced object as stored at or before the same time, nil when sloop has no version of it
+	Resource *ResourceAtOutput `json:"resource"`
+}
+
+/*
+Resolves the config maps, secrets, service account and persistent volume claims the pod spec of the resource refers to
+into their own stored versions at ts, so the full effective configuration of a workload at that time can be seen in
+one go.  References are sorted by kind and name.  Returns nil for kinds without a pod spec, and references to objects
+sloop has not stored are kept with a nil Resource so they show up as missing
+*/
+func ResolveReferences(t typed.Tables, resource *ResourceAtOutput, ts time.Time) ([]ResourceReferenceOutput, error) {
+	if !referencingKinds[resource.Kind] {
+		return nil, nil
+	}
+	refs, err := kubeextractor.ExtractPodSpecReferences(resource.Kind, string(resource.Payload))
+	if err != nil {
+		return nil, err
+	}
+
+	output := []ResourceReferenceOutput{}
+	add := func(kind string, byName map[string][]string) {
+		for name, usages := range byName {
+			output = append(output, ResourceReferenceOutput{Kind: kind, Name: name, Usages: usages})
+		}
+	}
+	add(kubeextractor.ConfigMapKind, refs.ConfigMaps)
+	add(kubeextractor.SecretKind, refs.Secrets)
+	add(kubeextractor.PersistentVolumeClaimKind, refs.PersistentVolumeClaims)
+	add(kubeextractor.ServiceAccountKind, map[string][]string{refs.ServiceAccount: {"serviceAccountName"}})
+	sort.Slice(output, func(i, j int) bool {
+		if output[i].Kind != output[j].Kind {
+			return output[i].Kind < output[j].Kind
+		}
+		return output[i].Name < output[j].Name
+	})
+
+	for idx := range output {
+		output[idx].Resource, err = GetResourceAt(t, output[idx].Kind, resource.Namespace, output[idx].Name, ts)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return output, nil
+}
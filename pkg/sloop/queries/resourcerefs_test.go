@@ -0,0 +1,79 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+const someReferencingDeployment = `{"spec": {"template": {"spec": {
+  "volumes": [{"name": "config", "configMap": {"name": "settings"}}, {"name": "data", "persistentVolumeClaim": {"claimName": "data"}}],
+  "containers": [{"name": "app", "env": [{"name": "DB_PASSWORD", "valueFrom": {"secretKeyRef": {"name": "db", "key": "password"}}}]}]
+}}}}`
+
+func Test_ResolveReferences(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		for _, version := range []struct {
+			ts      time.Time
+			kind    string
+			name    string
+			payload string
+		}{
+			{someTs.Add(-2 * time.Hour), "ConfigMap", "settings", `{"data":{"level":"info"}}`},
+			{someTs.Add(-time.Minute), "ConfigMap", "settings", `{"data":{"level":"debug"}}`},
+			// Changed after the time the references are resolved at
+			{someTs.Add(time.Minute), "ConfigMap", "settings", `{"data":{"level":"warn"}}`},
+			{someTs.Add(-time.Hour), "Secret", "db", `{"type":"Opaque"}`},
+			{someTs.Add(-time.Hour), "ServiceAccount", "default", `{}`},
+		} {
+			key := typed.NewWatchTableKey(untyped.GetPartitionId(version.ts), version.kind, "some-namespace", version.name, version.ts)
+			err := tables.WatchTable().Set(txn, key.String(), &typed.KubeWatchResult{Kind: version.kind, WatchType: typed.KubeWatchResult_UPDATE, Payload: version.payload})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+
+	resource := &ResourceAtOutput{Kind: "Deployment", Namespace: "some-namespace", Name: "app", Payload: json.RawMessage(someReferencingDeployment)}
+	references, err := ResolveReferences(tables, resource, someTs)
+	assert.Nil(t, err)
+	assert.Len(t, references, 4)
+
+	assert.Equal(t, "ConfigMap", references[0].Kind)
+	assert.Equal(t, []string{"volume:config"}, references[0].Usages)
+	assert.Equal(t, `{"data":{"level":"debug"}}`, string(references[0].Resource.Payload))
+	// No claim was stored
+	assert.Equal(t, "PersistentVolumeClaim", references[1].Kind)
+	assert.Equal(t, "data", references[1].Name)
+	assert.Nil(t, references[1].Resource)
+	assert.Equal(t, "Secret", references[2].Kind)
+	assert.Equal(t, []string{"env:app/DB_PASSWORD"}, references[2].Usages)
+	assert.NotNil(t, references[2].Resource)
+	assert.Equal(t, "ServiceAccount", references[3].Kind)
+	assert.Equal(t, "default", references[3].Name)
+	assert.NotNil(t, references[3].Resource)
+
+	references, err = ResolveReferences(tables, &ResourceAtOutput{Kind: "ConfigMap", Payload: json.RawMessage(`{}`)}, someTs)
+	assert.Nil(t, err)
+	assert.Nil(t, references)
+}
@@ -19,6 +19,8 @@ import (
 	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
 	"net/url"
 	"sort"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -30,12 +32,38 @@ type PayloadOuput struct {
 	PayloadKey  string `json:"payloadKey"`
 	PayLoadTime int64  `json:"payloadTime"`
 	Payload     string `json:"payload,omitempty"`
+	// One line like "Running 2/2 on node-x, image v1.2.3", empty for kinds without a summary
+	Summary string `json:"summary,omitempty"`
+	// Paths that changed since the previous payload, computed at ingest
+	ChangedPaths     []string `json:"changedPaths,omitempty"`
+	ChangedPathCount int32    `json:"changedPathCount,omitempty"`
+	// Only the first few changed paths are kept at ingest
+	ChangedPathsTruncated bool `json:"changedPathsTruncated,omitempty"`
+	// Size of the payload json, which is what fetching the payload returns
+	PayloadBytes int `json:"payloadBytes"`
+	// Payload codec of the stored watch result, like gzip or delta, and its size on disk including the fields above
+	StoredEncoding string `json:"storedEncoding,omitempty"`
+	StoredBytes    int    `json:"storedBytes,omitempty"`
+	// first, resync or changed, computed at ingest.  Empty for payloads stored before that
+	VersionType string `json:"versionType,omitempty"`
+	// With payload_format=patch, the RFC 6902 JSON Patch from the payload of the previous entry in the list to this
+	// one, which then has no Payload.  See ApplyPayloadPatches
+	Patch json.RawMessage `json:"patch,omitempty"`
 }
 
 func GetResPayload(params url.Values, t typed.Tables, startTime time.Time, endTime time.Time, requestId string) ([]byte, error) {
+	latest := 0
+	if value := params.Get(LatestParam); value != "" {
+		var err error
+		latest, err = strconv.Atoi(value)
+		if err != nil || latest <= 0 {
+			return []byte{}, NewApiError(ErrorCodeBadParams, "invalid %v %q, must be a positive number of payloads", LatestParam, value)
+		}
+	}
 
 	glog.V(common.GlogVerbose).Infof("GetResPayload: startTime: %v, endTime: %v", startTime.Unix(), endTime.Unix())
 	var watchRes map[typed.WatchTableKey]*typed.KubeWatchResult
+	storedInfo := map[typed.WatchTableKey]typed.StoredValueInfo{}
 	var previousKey *typed.WatchTableKey
 	var previousVal *typed.KubeWatchResult
 
@@ -47,36 +75,57 @@ func GetResPayload(params url.Values, t typed.Tables, startTime time.Time, endTi
 		valPredFn := typed.KubeWatchResult_ValPredicateFns(isResPayloadInTimeRange(startTime, endTime))
 
 		var rangeReadErr error
-		watchRes, _, rangeReadErr = t.WatchTable().RangeRead(txn, keyComparator, nil, valPredFn, startTime, endTime)
+		if latest > 0 {
+			// Reads back from the newest partition and stops after the newest payloads
+			var rows []typed.KubeWatchResultRow
+			rows, stats, rangeReadErr = t.WatchTable().RangeReadReverse(txn, keyComparator, nil, valPredFn, startTime, endTime, latest)
+			watchRes = map[typed.WatchTableKey]*typed.KubeWatchResult{}
+			for _, row := range rows {
+				watchRes[row.Key] = row.Value
+			}
+		} else {
+			watchRes, stats, rangeReadErr = t.WatchTable().RangeRead(txn, keyComparator, nil, valPredFn, startTime, endTime)
+		}
 		if rangeReadErr != nil {
 			glog.V(common.GlogVerbose).Infof("GetResPayload: range read error: %v", rangeReadErr)
 			return rangeReadErr
 		}
 		glog.V(common.GlogVerbose).Infof("GetResPayload: range read found: %v payload", len(watchRes))
 
-		// get the previous key for those who has same payload but just before startTime
-		var getPreviousErr error
-		seekKey := GetSeekKey(keyComparator, startTime)
-		glog.V(common.GlogVerbose).Infof("GetResPayload: seekKey: %v", seekKey.String())
-		previousKey, getPreviousErr = t.WatchTable().GetPreviousKey(txn, seekKey, keyComparator)
-
-		// when getPreviousErr is not nil, we will not return err since it is ok we did not find previous key from startTime,
-		// we can continue using the result from rangeRead to proceed the rest payload
-		if getPreviousErr == nil {
-			glog.V(common.GlogVerbose).Infof("GetResPayload: previousKey: %v", previousKey.String())
-			var getErr error
-			previousVal, getErr = t.WatchTable().Get(txn, previousKey.String())
-			if getErr == nil {
-				watchRes[*previousKey] = previousVal
-			} else {
-				glog.V(common.GlogVerbose).Infof("GetResPayload: getErr: %v", getErr)
-				// we need to return error when getErr is not nil and its error is not keyNotFound
-				if getErr != badger.ErrKeyNotFound {
-					return getErr
+		// get the previous key for those who has same payload but just before startTime.  With latest it is only
+		// needed when the time range has fewer payloads, otherwise it is older than all of them
+		if latest == 0 || len(watchRes) < latest {
+			var getPreviousErr error
+			seekKey := GetSeekKey(keyComparator, startTime)
+			glog.V(common.GlogVerbose).Infof("GetResPayload: seekKey: %v", seekKey.String())
+			previousKey, getPreviousErr = t.WatchTable().GetPreviousKey(txn, seekKey, keyComparator)
+
+			// when getPreviousErr is not nil, we will not return err since it is ok we did not find previous key from startTime,
+			// we can continue using the result from rangeRead to proceed the rest payload
+			if getPreviousErr == nil {
+				glog.V(common.GlogVerbose).Infof("GetResPayload: previousKey: %v", previousKey.String())
+				var getErr error
+				previousVal, getErr = t.WatchTable().Get(txn, previousKey.String())
+				if getErr == nil {
+					watchRes[*previousKey] = previousVal
+				} else {
+					glog.V(common.GlogVerbose).Infof("GetResPayload: getErr: %v", getErr)
+					// we need to return error when getErr is not nil and its error is not keyNotFound
+					if getErr != badger.ErrKeyNotFound {
+						return getErr
+					}
 				}
+			} else {
+				glog.V(common.GlogVerbose).Infof("GetResPayload: no previous key found. seekKey: %v, err: %v", seekKey.String(), getPreviousErr)
 			}
-		} else {
-			glog.V(common.GlogVerbose).Infof("GetResPayload: no previous key found. seekKey: %v, err: %v", seekKey.String(), getPreviousErr)
+		}
+
+		for key := range watchRes {
+			info, err := typed.GetStoredValueInfo(txn, key.String())
+			if err != nil {
+				return err
+			}
+			storedInfo[key] = info
 		}
 
 		stats.Log(requestId)
@@ -86,11 +135,19 @@ func GetResPayload(params url.Values, t typed.Tables, startTime time.Time, endTi
 		return []byte{}, err
 	}
 
-	payloadOutputList := getPayloadOutputList(watchRes)
+	payloadOutputList := getPayloadOutputList(watchRes, storedInfo)
 	glog.V(5).Infof("get the length of the resPayload is:%v", len(payloadOutputList))
 
 	// Sort by time and remove entries with no payload change
 	payloadOutputList = removeDupePayloads(payloadOutputList)
+	payloadOutputList = filterVersionTypes(payloadOutputList, params[VersionTypeParam])
+	if isSummaryOnly(params) {
+		for i := range payloadOutputList {
+			payloadOutputList[i].Payload = ""
+		}
+	} else if params.Get(PayloadFormatParam) == PayloadFormatPatch {
+		payloadOutputList = patchPayloads(payloadOutputList)
+	}
 
 	var res ResPayLoadData
 	res.PayloadList = payloadOutputList
@@ -110,26 +167,31 @@ func GetSeekKey(keyComparator *typed.WatchTableKey, startTime time.Time) *typed.
 	return seekKey
 }
 
-//todo: add unit tests
+// todo: add unit tests
 func getKeyComparator(params url.Values) *typed.WatchTableKey {
-	selectedNamespace := params.Get(NamespaceParam)
 	selectedName := params.Get(NameParam)
 	selectedKind := params.Get(KindParam)
-	if kubeextractor.IsClustersScopedResource(selectedKind) {
-		selectedNamespace = ""
-	}
+	selectedNamespace := kubeextractor.NormalizeNamespace(selectedKind, params.Get(NamespaceParam))
 	return typed.NewWatchTableKeyComparator(selectedKind, selectedNamespace, selectedName, time.Time{})
 }
 
-//todo: add unit tests
-func getPayloadOutputList(watchRes map[typed.WatchTableKey]*typed.KubeWatchResult) []PayloadOuput {
+// todo: add unit tests
+func getPayloadOutputList(watchRes map[typed.WatchTableKey]*typed.KubeWatchResult, storedInfo map[typed.WatchTableKey]typed.StoredValueInfo) []PayloadOuput {
 
 	payloadOutputList := []PayloadOuput{}
 	for key, val := range watchRes {
 		output := PayloadOuput{
-			PayLoadTime: key.Timestamp.UnixNano(),
-			Payload:     val.Payload,
-			PayloadKey:  key.String(),
+			PayLoadTime:           key.Timestamp.UnixNano(),
+			Payload:               val.Payload,
+			Summary:               summarizePayload(key.Kind, val.Payload),
+			PayloadKey:            key.String(),
+			ChangedPaths:          val.ChangedPaths,
+			ChangedPathCount:      val.ChangedPathCount,
+			ChangedPathsTruncated: int(val.ChangedPathCount) > len(val.ChangedPaths),
+			PayloadBytes:          len(val.Payload),
+			StoredEncoding:        storedInfo[key].Codec,
+			StoredBytes:           storedInfo[key].Bytes,
+			VersionType:           val.VersionType,
 		}
 		payloadOutputList = append(payloadOutputList, output)
 	}
@@ -137,6 +199,64 @@ func getPayloadOutputList(watchRes map[typed.WatchTableKey]*typed.KubeWatchResul
 	return payloadOutputList
 }
 
+// Sends the first payload in full and each later one as a patch from the one before it, which is much smaller for
+// resources with many versions.  A payload that can not be diffed is sent in full
+func patchPayloads(payloads []PayloadOuput) []PayloadOuput {
+	for idx := len(payloads) - 1; idx > 0; idx-- {
+		ops, err := common.CreateJsonPatch(payloads[idx-1].Payload, payloads[idx].Payload)
+		if err != nil {
+			glog.V(common.GlogVerbose).Infof("Failed to diff payload %v: %v", payloads[idx].PayloadKey, err)
+			continue
+		}
+		patch, err := json.Marshal(ops)
+		if err != nil {
+			continue
+		}
+		payloads[idx].Patch = patch
+		payloads[idx].Payload = ""
+	}
+	return payloads
+}
+
+// Rebuilds the payloads of a list returned with payload_format=patch.  They are equal as json to the stored ones, but
+// their keys may be ordered differently
+func ApplyPayloadPatches(payloads []PayloadOuput) error {
+	for idx := range payloads {
+		if payloads[idx].Patch == nil {
+			continue
+		}
+		if idx == 0 {
+			return fmt.Errorf("first payload %v is a patch", payloads[idx].PayloadKey)
+		}
+		ops := []common.JsonPatchOp{}
+		err := json.Unmarshal(payloads[idx].Patch, &ops)
+		if err != nil {
+			return fmt.Errorf("invalid patch of payload %v: %v", payloads[idx].PayloadKey, err)
+		}
+		payloads[idx].Payload, err = common.ApplyJsonPatch(payloads[idx-1].Payload, ops)
+		if err != nil {
+			return fmt.Errorf("failed to patch payload %v: %v", payloads[idx].PayloadKey, err)
+		}
+		payloads[idx].Patch = nil
+	}
+	return nil
+}
+
+func isSummaryOnly(params url.Values) bool {
+	return params.Get(SummaryOnlyParam) == "true"
+}
+
+// Summaries are a convenience, so a payload they can not parse only gets none
+func summarizePayload(kind string, payload string) string {
+	summary, err := kubeextractor.SummarizePayload(kind, payload)
+	if err != nil {
+		glog.V(common.GlogVerbose).Infof("Failed to summarize %v payload: %v", kind, err)
+	}
+	return summary
+}
+
+// Payloads classified at ingest are dropped by their version type without comparing them, apart from the first one,
+// which has the state at the start of the time range
 func removeDupePayloads(payloads []PayloadOuput) []PayloadOuput {
 	sort.Slice(payloads, func(i, j int) bool {
 		return payloads[i].PayLoadTime < payloads[j].PayLoadTime
@@ -145,8 +265,12 @@ func removeDupePayloads(payloads []PayloadOuput) []PayloadOuput {
 	ret := []PayloadOuput{}
 
 	lastPayload := ""
-	for _, val := range payloads {
-		if val.Payload != lastPayload {
+	for idx, val := range payloads {
+		isDupe := val.Payload == lastPayload
+		if val.VersionType != "" && idx > 0 {
+			isDupe = val.VersionType == typed.VersionTypeResync
+		}
+		if !isDupe {
 			glog.V(common.GlogVerbose).Infof("removeDupePayloads: found key: %v", len(val.PayloadKey))
 			ret = append(ret, val)
 		} else {
@@ -157,3 +281,26 @@ func removeDupePayloads(payloads []PayloadOuput) []PayloadOuput {
 
 	return ret
 }
+
+// Keeps the payloads with one of the version types, comma separated, along with those stored before version types
+// were computed.  Empty keeps all of them
+func filterVersionTypes(payloads []PayloadOuput, selected []string) []PayloadOuput {
+	keep := map[string]bool{}
+	for _, value := range selected {
+		for _, versionType := range strings.Split(value, ",") {
+			if versionType = strings.TrimSpace(versionType); versionType != "" {
+				keep[versionType] = true
+			}
+		}
+	}
+	if len(keep) == 0 {
+		return payloads
+	}
+	ret := []PayloadOuput{}
+	for _, val := range payloads {
+		if val.VersionType == "" || keep[val.VersionType] {
+			ret = append(ret, val)
+		}
+	}
+	return ret
+}
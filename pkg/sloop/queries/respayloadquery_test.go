@@ -8,7 +8,10 @@
 package queries
 
 import (
+	"encoding/json"
+	"fmt"
 	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/salesforce/sloop/pkg/sloop/store/typed"
 	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
@@ -112,6 +115,9 @@ func Test_GetResPayload_True_NoPreviousKeyFound(t *testing.T) {
  {
   "payloadTime": 1546398245000000006,
   "payload": "{\n  \"metadata\": {\n    \"name\": \"someName\",\n    \"namespace\": \"someNamespace\",\n    \"uid\": \"6c2a9795-a282-11e9-ba2f-14187761de09\",\n    \"creationTimestamp\": \"2019-07-09T19:47:45Z\"\n  }\n}",
+  "payloadBytes": 180,
+  "storedEncoding": "identity",
+  "storedBytes": 203,
   "payloadKey": "/watch/001546398000/someKind/someNamespace/someName/1546398245000000006"
  }
 ]`
@@ -145,12 +151,67 @@ func Test_GetResPayload_True_PreviousKeyFound(t *testing.T) {
  {
   "payloadKey": "/watch/001546394400/someKind/someNamespace/someName/1546398245000000006",
   "payloadTime": 1546398245000000006,
-  "payload": "{\n  \"metadata\": {\n    \"name\": \"someName\",\n    \"namespace\": \"someNamespace\",\n    \"uid\": \"6c2a9795-a282-11e9-ba2f-14187761de09\",\n    \"creationTimestamp\": \"2019-07-09T19:47:45Z\"\n  }\n}"
+  "payload": "{\n  \"metadata\": {\n    \"name\": \"someName\",\n    \"namespace\": \"someNamespace\",\n    \"uid\": \"6c2a9795-a282-11e9-ba2f-14187761de09\",\n    \"creationTimestamp\": \"2019-07-09T19:47:45Z\"\n  }\n}",
+  "payloadBytes": 180,
+  "storedEncoding": "identity",
+  "storedBytes": 203
  }
 ]`
 	assertex.JsonEqual(t, expectedRes, string(res))
 }
 
+func Test_GetResPayload_Latest(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	var keys []string
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		for i := 0; i < 4; i++ {
+			ts := someTs.Add(time.Duration(i) * time.Hour)
+			key := typed.NewWatchTableKey(untyped.GetPartitionId(ts), "someKind", "someNamespace", "someName", ts).String()
+			keys = append(keys, key)
+			pts, _ := ptypes.TimestampProto(ts)
+			val := &typed.KubeWatchResult{Kind: "someKind", Timestamp: pts, Payload: fmt.Sprintf(`{"version":%v}`, i)}
+			if err := tables.WatchTable().Set(txn, key, val); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+
+	values := helper_get_params()
+	values[KindParam] = []string{"someKind"}
+	values[NamespaceParam] = []string{"someNamespace"}
+	values[NameParam] = []string{"someName"}
+	values[LatestParam] = []string{"2"}
+	res, err := GetResPayload(values, tables, someTs.Add(time.Hour), someTs.Add(5*time.Hour), someRequestId)
+	assert.Nil(t, err)
+	var payloads []PayloadOuput
+	assert.Nil(t, json.Unmarshal(res, &payloads))
+	var payloadKeys []string
+	for _, payload := range payloads {
+		payloadKeys = append(payloadKeys, payload.PayloadKey)
+	}
+	assert.Equal(t, []string{keys[2], keys[3]}, payloadKeys)
+
+	// Fewer payloads in the time range than asked for still brings in the one before it
+	values[LatestParam] = []string{"5"}
+	res, err = GetResPayload(values, tables, someTs.Add(2*time.Hour), someTs.Add(5*time.Hour), someRequestId)
+	assert.Nil(t, err)
+	payloads = nil
+	assert.Nil(t, json.Unmarshal(res, &payloads))
+	assert.Len(t, payloads, 3)
+	assert.Equal(t, keys[1], payloads[0].PayloadKey)
+
+	values[LatestParam] = []string{"0"}
+	_, err = GetResPayload(values, tables, someTs, someTs.Add(5*time.Hour), someRequestId)
+	apiErr, ok := err.(*ApiError)
+	assert.True(t, ok)
+	assert.Equal(t, ErrorCodeBadParams, apiErr.Code)
+}
+
 var somePayloadTs = time.Date(2019, 3, 1, 3, 4, 0, 0, time.UTC)
 
 func Test_removeDupePayloads_emptyWorks(t *testing.T) {
@@ -183,6 +244,30 @@ func Test_removeDupePayloads_twoTheSame_returnsFirstOne(t *testing.T) {
 	assert.Equal(t, expected, ret)
 }
 
+func Test_removeDupePayloads_usesStoredVersionTypes(t *testing.T) {
+	input := []PayloadOuput{
+		{PayLoadTime: somePayloadTs.UnixNano(), Payload: "abc", VersionType: typed.VersionTypeResync},
+		{PayLoadTime: somePayloadTs.Add(time.Minute).UnixNano(), Payload: "abc", VersionType: typed.VersionTypeResync},
+		{PayLoadTime: somePayloadTs.Add(2 * time.Minute).UnixNano(), Payload: "abc", VersionType: typed.VersionTypeChanged},
+		{PayLoadTime: somePayloadTs.Add(3 * time.Minute).UnixNano(), Payload: "def"},
+	}
+	expected := []PayloadOuput{input[0], input[2], input[3]}
+	ret := removeDupePayloads(input)
+	assert.Equal(t, expected, ret)
+}
+
+func Test_filterVersionTypes(t *testing.T) {
+	input := []PayloadOuput{
+		{Payload: "a", VersionType: typed.VersionTypeFirst},
+		{Payload: "b", VersionType: typed.VersionTypeResync},
+		{Payload: "c", VersionType: typed.VersionTypeChanged},
+		{Payload: "d"},
+	}
+	assert.Equal(t, input, filterVersionTypes(input, nil))
+	assert.Equal(t, []PayloadOuput{input[0], input[2], input[3]}, filterVersionTypes(input, []string{"first, changed"}))
+	assert.Equal(t, []PayloadOuput{input[1], input[3]}, filterVersionTypes(input, []string{typed.VersionTypeResync}))
+}
+
 func Test_GetResPayload_True_HasSamePrefix(t *testing.T) {
 	untyped.TestHookSetPartitionDuration(time.Hour)
 	partitionId := untyped.GetPartitionId(someTs)
@@ -207,6 +292,9 @@ func Test_GetResPayload_True_HasSamePrefix(t *testing.T) {
  {
   "payloadTime": 1546398245000000006,
   "payload": "{\n  \"metadata\": {\n    \"name\": \"someName\",\n    \"namespace\": \"someNamespace\",\n    \"uid\": \"6c2a9795-a282-11e9-ba2f-14187761de09\",\n    \"creationTimestamp\": \"2019-07-09T19:47:45Z\"\n  }\n}",
+  "payloadBytes": 180,
+  "storedEncoding": "identity",
+  "storedBytes": 203,
   "payloadKey": "/watch/001546398000/someKind/someNamespace/someName-11/1546398245000000006"
  }
 ]`
@@ -235,6 +323,9 @@ func Test_GetResPayload_NoDataInTimeRangeStillFindsPayload(t *testing.T) {
  {
   "payloadTime": 1546398245000000006,
   "payload": "{\n  \"metadata\": {\n    \"name\": \"someName\",\n    \"namespace\": \"someNamespace\",\n    \"uid\": \"6c2a9795-a282-11e9-ba2f-14187761de09\",\n    \"creationTimestamp\": \"2019-07-09T19:47:45Z\"\n  }\n}",
+  "payloadBytes": 180,
+  "storedEncoding": "identity",
+  "storedBytes": 203,
   "payloadKey": "/watch/001546398000/someKind/someNamespace/someName/1546398245000000006"
  }
 ]`
@@ -261,3 +352,59 @@ func Test_getSeekKey(t *testing.T) {
 	assert.Equal(t, untyped.GetPartitionId(keyTime), keyComparator.PartitionId)
 	assert.Equal(t, keyTime, keyComparator.Timestamp)
 }
+
+func Test_getPayloadOutputList_SizesAndTruncation(t *testing.T) {
+	key := typed.NewWatchTableKey("001546398000", "Pod", "someNamespace", "someName", someTs)
+	watchRes := map[typed.WatchTableKey]*typed.KubeWatchResult{
+		*key: {Payload: somePodPayload, ChangedPaths: []string{"status.phase"}, ChangedPathCount: 3},
+	}
+	storedInfo := map[typed.WatchTableKey]typed.StoredValueInfo{*key: {Codec: typed.CodecGzip, Bytes: 120}}
+	output := getPayloadOutputList(watchRes, storedInfo)
+	assert.Equal(t, 1, len(output))
+	assert.True(t, output[0].ChangedPathsTruncated)
+	assert.Equal(t, len(somePodPayload), output[0].PayloadBytes)
+	assert.Equal(t, typed.CodecGzip, output[0].StoredEncoding)
+	assert.Equal(t, 120, output[0].StoredBytes)
+}
+
+func Test_getPayloadOutputList_Summary(t *testing.T) {
+	key := typed.NewWatchTableKey("001546398000", "Pod", "someNamespace", "someName", someTs)
+	otherKey := typed.NewWatchTableKey("001546398000", "someKind", "someNamespace", "someName", someTs)
+	watchRes := map[typed.WatchTableKey]*typed.KubeWatchResult{
+		*key:      {Payload: `{"spec":{"nodeName":"node-x","containers":[{"image":"app:v1.2.3"}]},"status":{"phase":"Running","containerStatuses":[{"ready":true}]}}`},
+		*otherKey: {Payload: `{}`},
+	}
+	output := getPayloadOutputList(watchRes, map[typed.WatchTableKey]typed.StoredValueInfo{})
+	assert.Len(t, output, 2)
+	for _, payload := range output {
+		if payload.PayloadKey == key.String() {
+			assert.Equal(t, "Running 1/1 on node-x, image v1.2.3", payload.Summary)
+		} else {
+			assert.Equal(t, "", payload.Summary)
+		}
+	}
+}
+
+func Test_patchPayloads_RoundTrips(t *testing.T) {
+	payloads := []PayloadOuput{
+		{PayloadKey: "a", Payload: `{"metadata":{"resourceVersion":"1"},"spec":{"replicas":1}}`},
+		{PayloadKey: "b", Payload: `{"metadata":{"resourceVersion":"2"},"spec":{"replicas":2}}`},
+		{PayloadKey: "c", Payload: `not json`},
+		{PayloadKey: "d", Payload: `{"metadata":{"resourceVersion":"3"},"spec":{"replicas":2}}`},
+	}
+	patched := patchPayloads(append([]PayloadOuput{}, payloads...))
+	assert.Equal(t, payloads[0].Payload, patched[0].Payload)
+	assert.Nil(t, patched[0].Patch)
+	assert.Equal(t, "", patched[1].Payload)
+	assert.JSONEq(t, `[{"op":"replace","path":"/metadata/resourceVersion","value":"2"},{"op":"replace","path":"/spec/replicas","value":2}]`, string(patched[1].Patch))
+	// Can not be diffed, so it and the one after it are sent in full
+	assert.Equal(t, payloads[2].Payload, patched[2].Payload)
+	assert.Equal(t, payloads[3].Payload, patched[3].Payload)
+
+	assert.Nil(t, ApplyPayloadPatches(patched))
+	for idx := range payloads {
+		assert.Equal(t, payloads[idx].Payload, patched[idx].Payload)
+	}
+
+	assert.NotNil(t, ApplyPayloadPatches([]PayloadOuput{{PayloadKey: "a", Patch: json.RawMessage(`[]`)}}))
+}
@@ -0,0 +1,64 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+)
+
+// Set by the webserver on a response of a query whose time range starts before the oldest partition in the store, or
+// was cut to the max look back.  The retained since header is the start of the data returned in unix seconds
+const (
+	RetentionWarningHeader = "X-Sloop-Retention-Warning"
+	RetainedSinceHeader    = "X-Sloop-Retained-Since"
+)
+
+const retentionTimeLayout = "2006-01-02T15:04Z"
+
+/*
+Returns the start of the oldest partition and, when startTime is before it, a warning that the data before it has
+been purged.  Without it an empty part of a long time range looks like a quiet period.  An empty store has no
+boundary and no warning
+*/
+func GetRetentionWarning(tables typed.Tables, startTime time.Time) (time.Time, string, error) {
+	ok, minPartition, _, err := tables.GetMinAndMaxPartition()
+	if err != nil || !ok {
+		return time.Time{}, "", err
+	}
+	retainedSince, _, err := untyped.GetTimeRangeForPartition(minPartition)
+	if err != nil {
+		return time.Time{}, "", err
+	}
+	retainedSince = retainedSince.UTC()
+	if !startTime.Before(retainedSince) {
+		return retainedSince, "", nil
+	}
+	return retainedSince, fmt.Sprintf("data before %v has been purged", retainedSince.Format(retentionTimeLayout)), nil
+}
+
+// Same as GetRetentionWarning, and also warns when the time range asked for in params was longer than maxLookBack and
+// now starts at startTime.  A long explicit range would otherwise lose its start without anyone noticing
+func GetTimeRangeWarning(tables typed.Tables, params url.Values, startTime time.Time, maxLookBack time.Duration) (time.Time, string, error) {
+	retainedSince, warning, err := GetRetentionWarning(tables, startTime)
+	if err != nil {
+		return time.Time{}, "", err
+	}
+	clampWarning := getTimeRangeClampWarning(params, startTime, maxLookBack)
+	if clampWarning == "" {
+		return retainedSince, warning, nil
+	}
+	if warning == "" {
+		return startTime.UTC(), clampWarning, nil
+	}
+	return retainedSince, clampWarning + ", and " + warning, nil
+}
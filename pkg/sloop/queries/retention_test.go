@@ -0,0 +1,89 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+func Test_GetRetentionWarning(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	ts := time.Date(2019, 3, 1, 3, 4, 0, 0, time.UTC)
+
+	// Nothing is stored yet, so nothing was purged either
+	_, warning, err := GetRetentionWarning(tables, ts.Add(-time.Hour))
+	assert.Nil(t, err)
+	assert.Equal(t, "", warning)
+
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		key := typed.NewWatchTableKey(untyped.GetPartitionId(ts), "Pod", "ns", "pod-a", ts)
+		return tables.WatchTable().Set(txn, key.String(), &typed.KubeWatchResult{Kind: "Pod", WatchType: typed.KubeWatchResult_ADD, Payload: `{}`})
+	})
+	assert.Nil(t, err)
+
+	retainedSince, warning, err := GetRetentionWarning(tables, ts.Add(-time.Hour))
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2019, 3, 1, 3, 0, 0, 0, time.UTC), retainedSince)
+	assert.Equal(t, "data before 2019-03-01T03:00Z has been purged", warning)
+
+	_, warning, err = GetRetentionWarning(tables, ts)
+	assert.Nil(t, err)
+	assert.Equal(t, "", warning)
+
+	query := StructuredQuery{Lookback: "2h", EndTime: ts.Add(time.Hour).Format("2006-01-02T15:04:05")}
+	data, err := RunStructuredQuery(query, tables, 24*time.Hour, "")
+	assert.Nil(t, err)
+	output := StructuredQueryOutput{}
+	assert.Nil(t, json.Unmarshal(data, &output))
+	assert.Equal(t, "data before 2019-03-01T03:00Z has been purged", output.RetentionWarning)
+}
+
+func Test_GetTimeRangeWarning(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	ts := time.Date(2019, 3, 1, 3, 4, 0, 0, time.UTC)
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		key := typed.NewWatchTableKey(untyped.GetPartitionId(ts), "Pod", "ns", "pod-a", ts)
+		return tables.WatchTable().Set(txn, key.String(), &typed.KubeWatchResult{Kind: "Pod", WatchType: typed.KubeWatchResult_ADD, Payload: `{}`})
+	})
+	assert.Nil(t, err)
+
+	params := url.Values{StartTimeParam: {fmt.Sprint(ts.Unix())}, EndTimeParam: {fmt.Sprint(ts.Add(time.Hour).Unix())}}
+	_, warning, err := GetTimeRangeWarning(tables, params, ts, 2*time.Hour)
+	assert.Nil(t, err)
+	assert.Equal(t, "", warning)
+
+	params = url.Values{StartTimeParam: {fmt.Sprint(ts.Unix())}, EndTimeParam: {fmt.Sprint(ts.Add(3 * time.Hour).Unix())}}
+	retainedSince, warning, err := GetTimeRangeWarning(tables, params, ts.Add(time.Hour), 2*time.Hour)
+	assert.Nil(t, err)
+	assert.Equal(t, "the time range was cut to the max look back of 2h0m0s, data before 2019-03-01T04:04Z is left out", warning)
+	assert.Equal(t, ts.Add(time.Hour), retainedSince)
+
+	// Cut and still older than what is retained
+	params = url.Values{LookbackParam: {"3h"}}
+	retainedSince, warning, err = GetTimeRangeWarning(tables, params, ts.Add(-time.Hour), 2*time.Hour)
+	assert.Nil(t, err)
+	assert.Equal(t, "the time range was cut to the max look back of 2h0m0s, data before 2019-03-01T02:04Z is left out, and data before 2019-03-01T03:00Z has been purged", warning)
+	assert.Equal(t, ts.Truncate(time.Hour), retainedSince)
+}
@@ -0,0 +1,368 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+const (
+	RolloutComplete   = "complete"
+	RolloutFailed     = "failed"
+	RolloutRolledBack = "rolledBack"
+	// Replaced by the next rollout before it finished
+	RolloutSuperseded = "superseded"
+	RolloutDeleted    = "deleted"
+	RolloutInProgress = "inProgress"
+)
+
+type RolloutProgressSample struct {
+	Timestamp int64 `json:"timestamp"`
+	// All pods of the deployment, old and new
+	Replicas  int32 `json:"replicas"`
+	Updated   int32 `json:"updated"`
+	Ready     int32 `json:"ready"`
+	Available int32 `json:"available"`
+}
+
+type DeploymentRolloutOutput struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Revision  string `json:"revision"`
+	// The ReplicaSet the rollout moved pods to, when it was seen
+	ReplicaSet string   `json:"replicaSet,omitempty"`
+	Images     []string `json:"images"`
+	// Replicas asked for when the rollout started
+	DesiredReplicas int32 `json:"desiredReplicas"`
+	StartTime       int64 `json:"startTime"`
+	// Zero while the rollout is in progress
+	EndTime int64 `json:"endTime,omitempty"`
+	// Up to the end of the time range while in progress
+	DurationSeconds int64  `json:"durationSeconds"`
+	Outcome         string `json:"outcome"`
+	// Went back to a pod template the deployment had before
+	Rollback    bool                    `json:"rollback"`
+	PodsCreated int                     `json:"podsCreated"`
+	PodsDeleted int                     `json:"podsDeleted"`
+	Progress    []RolloutProgressSample `json:"progress"`
+}
+
+/*
+Rebuilds the rollouts of Deployments matching namespace (all by default) and name from their stored history.  A rollout
+starts when the revision of the deployment changes, or when it is created, and ends when it is complete by the same
+check as kubectl rollout status, when its progress deadline is exceeded or when the next rollout replaces it.  Rollouts
+that started before the time range are left out.  ReplicaSets are read to find the one each rollout moved to and
+whether it was reused by a rollback, and pods to count the turnover.  Sorted by start time
+*/
+func GetDeploymentRollouts(params url.Values, t typed.Tables, startTime time.Time, endTime time.Time, requestId string) ([]byte, error) {
+	selectedNamespace := defaultParam(params.Get(NamespaceParam), AllNamespaces)
+	selectedName := params.Get(NameParam)
+
+	output := []DeploymentRolloutOutput{}
+	err := t.Db().View(func(txn badgerwrap.Txn) error {
+		deployments, err := readNamespacedWatchRecords(txn, t, kubeextractor.DeploymentKind, selectedNamespace, startTime, endTime, requestId)
+		if err != nil {
+			return err
+		}
+		rollouts := map[watchResourceId][]*DeploymentRolloutOutput{}
+		for id, records := range deployments {
+			if selectedName != "" && id.name != selectedName {
+				continue
+			}
+			if found := deploymentRollouts(records, startTime, endTime); len(found) > 0 {
+				rollouts[id] = found
+			}
+		}
+		if len(rollouts) == 0 {
+			return nil
+		}
+
+		replicaSets, err := readNamespacedWatchRecords(txn, t, kubeextractor.ReplicaSetKind, selectedNamespace, startTime, endTime, requestId)
+		if err != nil {
+			return err
+		}
+		pods, err := readRolloutPods(txn, t, selectedNamespace, startTime, endTime, requestId)
+		if err != nil {
+			return err
+		}
+		byDeployment := replicaSetsByDeployment(replicaSets)
+		for id, deploymentRollouts := range rollouts {
+			for _, rollout := range deploymentRollouts {
+				rollout.Namespace = id.namespace
+				rollout.Name = id.name
+				addRolloutReplicaSet(rollout, byDeployment[id], endTime)
+				addRolloutPodTurnover(rollout, id, byDeployment[id], pods, endTime)
+			}
+			markRolledBack(deploymentRollouts)
+			for _, rollout := range deploymentRollouts {
+				output = append(output, *rollout)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return []byte{}, err
+	}
+
+	sort.Slice(output, func(i, j int) bool {
+		if output[i].StartTime != output[j].StartTime {
+			return output[i].StartTime < output[j].StartTime
+		}
+		if output[i].Namespace != output[j].Namespace {
+			return output[i].Namespace < output[j].Namespace
+		}
+		return output[i].Name < output[j].Name
+	})
+	bytes, err := json.MarshalIndent(output, "", " ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal json %v", err)
+	}
+	return bytes, nil
+}
+
+// Walks the versions of one deployment, oldest first.  The version from before the start time only sets the revision
+// and pod template the time range starts with
+func deploymentRollouts(records []watchRecord, startTime time.Time, endTime time.Time) []*DeploymentRolloutOutput {
+	rollouts := []*DeploymentRolloutOutput{}
+	var current *DeploymentRolloutOutput
+	revision := ""
+	images := ""
+	seenImages := map[string]bool{}
+	for idx, record := range records {
+		if record.timestamp > endTime.Unix() {
+			break
+		}
+		state, err := kubeextractor.ExtractDeploymentRollout(record.result.Payload)
+		if err != nil {
+			glog.Errorf("Failed to extract deployment rollout: %v", err)
+			continue
+		}
+		deleted := record.result.WatchType == typed.KubeWatchResult_DELETE
+
+		switch {
+		case deleted:
+		case idx > 0 && state.Revision == revision:
+		case current != nil && current.Revision == "":
+			// Created before the controller set the first revision
+			current.Revision = state.Revision
+		default:
+			if current != nil && current.Outcome == RolloutInProgress {
+				current.Outcome = RolloutSuperseded
+				current.EndTime = record.timestamp
+			}
+			current = nil
+			if images != "" {
+				seenImages[images] = true
+			}
+			newImages := strings.Join(state.Images, ",")
+			if record.timestamp >= startTime.Unix() {
+				current = &DeploymentRolloutOutput{
+					Revision:        state.Revision,
+					Images:          state.Images,
+					DesiredReplicas: state.Replicas,
+					StartTime:       record.timestamp,
+					Outcome:         RolloutInProgress,
+					Rollback:        seenImages[newImages],
+					Progress:        []RolloutProgressSample{},
+				}
+				rollouts = append(rollouts, current)
+			}
+		}
+		revision = state.Revision
+		images = strings.Join(state.Images, ",")
+
+		if current == nil || current.Outcome != RolloutInProgress {
+			continue
+		}
+		sample := RolloutProgressSample{
+			Timestamp: record.timestamp,
+			Replicas:  state.StatusReplicas,
+			Updated:   state.UpdatedReplicas,
+			Ready:     state.ReadyReplicas,
+			Available: state.AvailableReplicas,
+		}
+		if len(current.Progress) == 0 || !sameRolloutProgress(current.Progress[len(current.Progress)-1], sample) {
+			current.Progress = append(current.Progress, sample)
+		}
+		switch {
+		case deleted:
+			current.Outcome = RolloutDeleted
+		case state.Failed():
+			current.Outcome = RolloutFailed
+		case state.Complete():
+			current.Outcome = RolloutComplete
+		default:
+			continue
+		}
+		current.EndTime = record.timestamp
+	}
+
+	for _, rollout := range rollouts {
+		if rollout.EndTime != 0 {
+			rollout.DurationSeconds = rollout.EndTime - rollout.StartTime
+		} else {
+			rollout.DurationSeconds = endTime.Unix() - rollout.StartTime
+		}
+	}
+	return rollouts
+}
+
+func sameRolloutProgress(a RolloutProgressSample, b RolloutProgressSample) bool {
+	return a.Replicas == b.Replicas && a.Updated == b.Updated && a.Ready == b.Ready && a.Available == b.Available
+}
+
+// A rollout replaced by a rollback is reported as rolled back rather than superseded
+func markRolledBack(rollouts []*DeploymentRolloutOutput) {
+	for idx := 1; idx < len(rollouts); idx++ {
+		if rollouts[idx].Rollback && rollouts[idx-1].Outcome == RolloutSuperseded {
+			rollouts[idx-1].Outcome = RolloutRolledBack
+		}
+	}
+}
+
+type rolloutReplicaSet struct {
+	name    string
+	records []watchRecord
+}
+
+func replicaSetsByDeployment(replicaSets map[watchResourceId][]watchRecord) map[watchResourceId][]rolloutReplicaSet {
+	byDeployment := map[watchResourceId][]rolloutReplicaSet{}
+	for id, records := range replicaSets {
+		if len(records) == 0 {
+			continue
+		}
+		revision, err := kubeextractor.ExtractReplicaSetRevision(records[len(records)-1].result.Payload)
+		if err != nil || revision.Deployment == "" {
+			continue
+		}
+		deployment := watchResourceId{namespace: id.namespace, name: revision.Deployment}
+		byDeployment[deployment] = append(byDeployment[deployment], rolloutReplicaSet{name: id.name, records: records})
+	}
+	return byDeployment
+}
+
+// The ReplicaSet of a rollout is the one carrying its revision.  The deployment controller gives a reused ReplicaSet
+// the new revision and keeps the old ones in the revision history, which is how a rollback through kubectl rollout
+// undo shows up when the pod template alone does not tell
+func addRolloutReplicaSet(rollout *DeploymentRolloutOutput, replicaSets []rolloutReplicaSet, endTime time.Time) {
+	if rollout.Revision == "" {
+		return
+	}
+	until := endTime.Unix()
+	if rollout.EndTime != 0 {
+		until = rollout.EndTime
+	}
+	for _, replicaSet := range replicaSets {
+		matched := false
+		reused := false
+		for _, record := range replicaSet.records {
+			if record.timestamp > until {
+				break
+			}
+			revision, err := kubeextractor.ExtractReplicaSetRevision(record.result.Payload)
+			if err != nil {
+				continue
+			}
+			if revision.Revision == rollout.Revision {
+				matched = true
+				reused = reused || revision.RevisionHistory != ""
+			} else if revision.Revision != "" && record.timestamp < rollout.StartTime {
+				reused = true
+			}
+		}
+		if matched {
+			rollout.ReplicaSet = replicaSet.name
+			rollout.Rollback = rollout.Rollback || reused
+			return
+		}
+	}
+}
+
+type rolloutPod struct {
+	owner   string
+	created int64
+	deleted int64
+}
+
+// Pods are only needed for when they were created and deleted, so unlike readNamespacedWatchRecords there is no
+// seek for the version before the start time
+func readRolloutPods(txn badgerwrap.Txn, t typed.Tables, selectedNamespace string, startTime time.Time, endTime time.Time, requestId string) (map[watchResourceId]rolloutPod, error) {
+	keyPredicate := func(key string) bool {
+		k := &typed.WatchTableKey{}
+		err := k.Parse(key)
+		if err != nil {
+			return false
+		}
+		return keepRowHelper(k.Name, k.Kind, k.Namespace, kubeextractor.PodKind, selectedNamespace, "", "", "", "")
+	}
+	records, stats, err := t.WatchTable().RangeRead(txn, getNamespacedKindKeyPrefix(kubeextractor.PodKind, selectedNamespace), keyPredicate, nil, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+	stats.Log(requestId)
+
+	pods := map[watchResourceId]rolloutPod{}
+	for key, result := range records {
+		id := watchResourceId{namespace: key.Namespace, name: key.Name}
+		pod, ok := pods[id]
+		if !ok {
+			metadata, err := kubeextractor.ExtractMetadata(result.Payload)
+			if err != nil {
+				continue
+			}
+			for _, owner := range metadata.OwnerReferences {
+				if owner.Kind == kubeextractor.ReplicaSetKind {
+					pod.owner = owner.Name
+				}
+			}
+			if pod.owner == "" {
+				continue
+			}
+			if created, err := time.Parse(time.RFC3339, metadata.CreationTimestamp); err == nil {
+				pod.created = created.Unix()
+			}
+		}
+		if result.WatchType == typed.KubeWatchResult_DELETE {
+			pod.deleted = key.Timestamp.Unix()
+		}
+		pods[id] = pod
+	}
+	return pods, nil
+}
+
+func addRolloutPodTurnover(rollout *DeploymentRolloutOutput, deployment watchResourceId, replicaSets []rolloutReplicaSet, pods map[watchResourceId]rolloutPod, endTime time.Time) {
+	owners := map[string]bool{}
+	for _, replicaSet := range replicaSets {
+		owners[replicaSet.name] = true
+	}
+	until := endTime.Unix()
+	if rollout.EndTime != 0 {
+		until = rollout.EndTime
+	}
+	for id, pod := range pods {
+		if id.namespace != deployment.namespace || !owners[pod.owner] {
+			continue
+		}
+		if pod.created >= rollout.StartTime && pod.created <= until {
+			rollout.PodsCreated++
+		}
+		if pod.deleted != 0 && pod.deleted >= rollout.StartTime && pod.deleted <= until {
+			rollout.PodsDeleted++
+		}
+	}
+}
@@ -0,0 +1,146 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+func helper_deploymentPayload(revision int, image string, generation int, status string) string {
+	return fmt.Sprintf(`{"metadata": {"generation": %v, "annotations": {"deployment.kubernetes.io/revision": "%v"}},
+"spec": {"replicas": 2, "template": {"spec": {"containers": [{"image": "%v"}]}}}, "status": {%v}}`, generation, revision, image, status)
+}
+
+func helper_replicaSetPayload(deployment string, revision int, history string) string {
+	return fmt.Sprintf(`{"metadata": {"ownerReferences": [{"kind": "Deployment", "name": "%v"}],
+"annotations": {"deployment.kubernetes.io/revision": "%v", "deployment.kubernetes.io/revision-history": "%v"}}}`, deployment, revision, history)
+}
+
+func helper_getRolloutTables(t *testing.T) typed.Tables {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	partitionId := untyped.GetPartitionId(someTs)
+	complete := `"observedGeneration": %v, "replicas": 2, "updatedReplicas": 2, "readyReplicas": 2, "availableReplicas": 2`
+	helper_setWatchResults(t, tables, map[*typed.WatchTableKey]string{
+		typed.NewWatchTableKey(partitionId, "Deployment", "ns", "web", someTs.Add(-time.Minute)): helper_deploymentPayload(1, "web:1", 1, fmt.Sprintf(complete, 1)),
+		// Rolled to web:2 one pod at a time
+		typed.NewWatchTableKey(partitionId, "Deployment", "ns", "web", someTs.Add(time.Minute)):   helper_deploymentPayload(2, "web:2", 2, fmt.Sprintf(complete, 1)),
+		typed.NewWatchTableKey(partitionId, "Deployment", "ns", "web", someTs.Add(2*time.Minute)): helper_deploymentPayload(2, "web:2", 2, `"observedGeneration": 2, "replicas": 3, "updatedReplicas": 1, "readyReplicas": 2, "availableReplicas": 2`),
+		typed.NewWatchTableKey(partitionId, "Deployment", "ns", "web", someTs.Add(4*time.Minute)): helper_deploymentPayload(2, "web:2", 2, fmt.Sprintf(complete, 2)),
+		// A broken image, undone back to web:2
+		typed.NewWatchTableKey(partitionId, "Deployment", "ns", "web", someTs.Add(10*time.Minute)): helper_deploymentPayload(3, "web:3-rc", 3, `"observedGeneration": 3, "replicas": 3, "updatedReplicas": 1, "availableReplicas": 2`),
+		typed.NewWatchTableKey(partitionId, "Deployment", "ns", "web", someTs.Add(20*time.Minute)): helper_deploymentPayload(4, "web:2", 4, fmt.Sprintf(complete, 4)),
+		// Created without a revision, which the controller sets right after, then never got ready
+		typed.NewWatchTableKey(partitionId, "Deployment", "ns", "api", someTs.Add(5*time.Minute)):  `{"spec": {"template": {"spec": {"containers": [{"image": "api:1"}]}}}}`,
+		typed.NewWatchTableKey(partitionId, "Deployment", "ns", "api", someTs.Add(6*time.Minute)):  helper_deploymentPayload(1, "api:1", 1, `"observedGeneration": 1, "replicas": 2, "updatedReplicas": 2`),
+		typed.NewWatchTableKey(partitionId, "Deployment", "ns", "api", someTs.Add(16*time.Minute)): helper_deploymentPayload(1, "api:1", 1, `"observedGeneration": 1, "replicas": 2, "updatedReplicas": 2, "conditions": [{"type": "Progressing", "reason": "ProgressDeadlineExceeded"}]`),
+
+		typed.NewWatchTableKey(partitionId, "ReplicaSet", "ns", "web-aaa", someTs.Add(-time.Minute)):   helper_replicaSetPayload("web", 1, ""),
+		typed.NewWatchTableKey(partitionId, "ReplicaSet", "ns", "web-bbb", someTs.Add(time.Minute)):    helper_replicaSetPayload("web", 2, ""),
+		typed.NewWatchTableKey(partitionId, "ReplicaSet", "ns", "web-ccc", someTs.Add(10*time.Minute)): helper_replicaSetPayload("web", 3, ""),
+		typed.NewWatchTableKey(partitionId, "ReplicaSet", "ns", "web-bbb", someTs.Add(20*time.Minute)): helper_replicaSetPayload("web", 4, "2"),
+		typed.NewWatchTableKey(partitionId, "ReplicaSet", "ns", "other-a", someTs.Add(10*time.Minute)): helper_replicaSetPayload("other", 3, ""),
+		typed.NewWatchTableKey(partitionId, "Pod", "ns", "web-bbb-1", someTs.Add(time.Minute)):         fmt.Sprintf(`{"metadata": {"creationTimestamp": "%v", "ownerReferences": [{"kind": "ReplicaSet", "name": "web-bbb"}]}}`, someTs.Add(time.Minute).Format(time.RFC3339)),
+		typed.NewWatchTableKey(partitionId, "Pod", "ns", "other-a-1", someTs.Add(time.Minute)):         fmt.Sprintf(`{"metadata": {"creationTimestamp": "%v", "ownerReferences": [{"kind": "ReplicaSet", "name": "other-a"}]}}`, someTs.Add(time.Minute).Format(time.RFC3339)),
+		typed.NewWatchTableKey(partitionId, "Pod", "other-ns", "web-bbb-1", someTs.Add(2*time.Minute)): fmt.Sprintf(`{"metadata": {"creationTimestamp": "%v", "ownerReferences": [{"kind": "ReplicaSet", "name": "web-bbb"}]}}`, someTs.Add(time.Minute).Format(time.RFC3339)),
+	})
+
+	deletedTs := someTs.Add(3 * time.Minute)
+	pts, _ := ptypes.TimestampProto(deletedTs)
+	err = tables.Db().Update(func(txn badgerwrap.Txn) error {
+		payload := fmt.Sprintf(`{"metadata": {"creationTimestamp": "%v", "ownerReferences": [{"kind": "ReplicaSet", "name": "web-aaa"}]}}`, someTs.Add(-time.Hour).Format(time.RFC3339))
+		return tables.WatchTable().Set(txn, typed.NewWatchTableKey(partitionId, "Pod", "ns", "web-aaa-1", deletedTs).String(), &typed.KubeWatchResult{Kind: "Pod", WatchType: typed.KubeWatchResult_DELETE, Timestamp: pts, Payload: payload})
+	})
+	assert.Nil(t, err)
+	return tables
+}
+
+func Test_GetDeploymentRollouts(t *testing.T) {
+	tables := helper_getRolloutTables(t)
+	values := helper_get_params()
+	values[NamespaceParam] = []string{"ns"}
+	data, err := GetDeploymentRollouts(values, tables, someTs, someTs.Add(30*time.Minute), someRequestId)
+	assert.Nil(t, err)
+	output := []DeploymentRolloutOutput{}
+	assert.Nil(t, json.Unmarshal(data, &output))
+
+	assert.Equal(t, 4, len(output))
+	assert.Equal(t, DeploymentRolloutOutput{
+		Namespace:       "ns",
+		Name:            "web",
+		Revision:        "2",
+		ReplicaSet:      "web-bbb",
+		Images:          []string{"web:2"},
+		DesiredReplicas: 2,
+		StartTime:       someTs.Add(time.Minute).Unix(),
+		EndTime:         someTs.Add(4 * time.Minute).Unix(),
+		DurationSeconds: 180,
+		Outcome:         RolloutComplete,
+		PodsCreated:     1,
+		PodsDeleted:     1,
+		Progress: []RolloutProgressSample{
+			{Timestamp: someTs.Add(time.Minute).Unix(), Replicas: 2, Updated: 2, Ready: 2, Available: 2},
+			{Timestamp: someTs.Add(2 * time.Minute).Unix(), Replicas: 3, Updated: 1, Ready: 2, Available: 2},
+			{Timestamp: someTs.Add(4 * time.Minute).Unix(), Replicas: 2, Updated: 2, Ready: 2, Available: 2},
+		},
+	}, output[0])
+
+	assert.Equal(t, "api", output[1].Name)
+	assert.Equal(t, "1", output[1].Revision)
+	assert.Equal(t, RolloutFailed, output[1].Outcome)
+	assert.Equal(t, int64(660), output[1].DurationSeconds)
+
+	assert.Equal(t, "3", output[2].Revision)
+	assert.Equal(t, "web-ccc", output[2].ReplicaSet)
+	assert.Equal(t, RolloutRolledBack, output[2].Outcome)
+	assert.False(t, output[2].Rollback)
+
+	assert.Equal(t, "4", output[3].Revision)
+	assert.Equal(t, "web-bbb", output[3].ReplicaSet)
+	assert.True(t, output[3].Rollback)
+	assert.Equal(t, RolloutComplete, output[3].Outcome)
+}
+
+func Test_GetDeploymentRollouts_Name(t *testing.T) {
+	tables := helper_getRolloutTables(t)
+	values := helper_get_params()
+	values[NamespaceParam] = []string{AllNamespaces}
+	values[NameParam] = []string{"api"}
+	data, err := GetDeploymentRollouts(values, tables, someTs, someTs.Add(10*time.Minute), someRequestId)
+	assert.Nil(t, err)
+	output := []DeploymentRolloutOutput{}
+	assert.Nil(t, json.Unmarshal(data, &output))
+	assert.Equal(t, 1, len(output))
+	assert.Equal(t, RolloutInProgress, output[0].Outcome)
+	assert.Equal(t, int64(0), output[0].EndTime)
+	assert.Equal(t, int64(300), output[0].DurationSeconds)
+}
+
+func Test_DeploymentRollouts_RollbackByReusedReplicaSet(t *testing.T) {
+	// kubectl rollout undo to a template that was last seen before the time range
+	rollouts := []*DeploymentRolloutOutput{{Revision: "5", StartTime: someTs.Unix(), EndTime: someTs.Add(time.Minute).Unix()}}
+	replicaSets := []rolloutReplicaSet{{name: "web-old", records: []watchRecord{
+		{timestamp: someTs.Add(-time.Hour).Unix(), result: &typed.KubeWatchResult{Payload: helper_replicaSetPayload("web", 1, "")}},
+		{timestamp: someTs.Unix(), result: &typed.KubeWatchResult{Payload: `{"metadata": {"annotations": {"deployment.kubernetes.io/revision": "5"}}}`}},
+	}}}
+	addRolloutReplicaSet(rollouts[0], replicaSets, someTs.Add(time.Hour))
+	assert.Equal(t, "web-old", rollouts[0].ReplicaSet)
+	assert.True(t, rollouts[0].Rollback)
+}
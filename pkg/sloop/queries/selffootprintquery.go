@@ -0,0 +1,100 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+type SelfFootprintOutput struct {
+	Samples []SelfFootprintSample `json:"samples"`
+	// Highest value of each field over the samples, with the time of the highest rss
+	Peak SelfFootprintSample `json:"peak"`
+}
+
+type SelfFootprintSample struct {
+	// Unix seconds
+	Timestamp            int64 `json:"timestamp"`
+	RssBytes             int64 `json:"rssBytes"`
+	HeapBytes            int64 `json:"heapBytes"`
+	Goroutines           int64 `json:"goroutines"`
+	LsmBytes             int64 `json:"lsmBytes"`
+	VlogBytes            int64 `json:"vlogBytes"`
+	WatchQueueDepth      int64 `json:"watchQueueDepth"`
+	WatchQueueCapacity   int64 `json:"watchQueueCapacity"`
+	AnnotationQueueDepth int64 `json:"annotationQueueDepth"`
+}
+
+// Returns the samples of the resources sloop itself used in the time range, stored every self-footprint-interval
+func GetSelfFootprint(params url.Values, t typed.Tables, startTime time.Time, endTime time.Time, requestId string) ([]byte, error) {
+	var stored []*typed.SelfFootprint
+	err := t.Db().View(func(txn badgerwrap.Txn) error {
+		var err error
+		stored, err = typed.OpenSelfFootprintTable().RangeRead(txn, startTime, endTime)
+		return err
+	})
+	if err != nil {
+		return []byte{}, err
+	}
+
+	output := SelfFootprintOutput{Samples: []SelfFootprintSample{}}
+	for _, footprint := range stored {
+		sample := SelfFootprintSample{
+			RssBytes:             footprint.RssBytes,
+			HeapBytes:            footprint.HeapBytes,
+			Goroutines:           footprint.Goroutines,
+			LsmBytes:             footprint.LsmBytes,
+			VlogBytes:            footprint.VlogBytes,
+			WatchQueueDepth:      footprint.WatchQueueDepth,
+			WatchQueueCapacity:   footprint.WatchQueueCapacity,
+			AnnotationQueueDepth: footprint.AnnotationQueueDepth,
+		}
+		if timestamp, err := ptypes.Timestamp(footprint.Timestamp); err == nil {
+			sample.Timestamp = timestamp.Unix()
+		}
+		output.Samples = append(output.Samples, sample)
+		addSelfFootprintPeak(&output.Peak, sample)
+	}
+
+	bytes, err := json.MarshalIndent(output, "", " ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal json %v", err)
+	}
+	return bytes, nil
+}
+
+func addSelfFootprintPeak(peak *SelfFootprintSample, sample SelfFootprintSample) {
+	if sample.RssBytes > peak.RssBytes || peak.Timestamp == 0 {
+		peak.RssBytes = sample.RssBytes
+		peak.Timestamp = sample.Timestamp
+	}
+	for _, field := range []struct {
+		peak  *int64
+		value int64
+	}{
+		{&peak.HeapBytes, sample.HeapBytes},
+		{&peak.Goroutines, sample.Goroutines},
+		{&peak.LsmBytes, sample.LsmBytes},
+		{&peak.VlogBytes, sample.VlogBytes},
+		{&peak.WatchQueueDepth, sample.WatchQueueDepth},
+		{&peak.WatchQueueCapacity, sample.WatchQueueCapacity},
+		{&peak.AnnotationQueueDepth, sample.AnnotationQueueDepth},
+	} {
+		if field.value > *field.peak {
+			*field.peak = field.value
+		}
+	}
+}
@@ -0,0 +1,53 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+func Test_GetSelfFootprint(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	table := typed.OpenSelfFootprintTable()
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		for i, footprint := range []*typed.SelfFootprint{
+			{RssBytes: 100, Goroutines: 50, WatchQueueDepth: 900},
+			{RssBytes: 300, Goroutines: 40},
+			{RssBytes: 200, Goroutines: 60},
+			// After the end
+			{RssBytes: 1000},
+		} {
+			ts := someTs.Add(time.Duration(i) * 20 * time.Minute)
+			footprint.Timestamp, _ = ptypes.TimestampProto(ts)
+			assert.Nil(t, table.Set(txn, ts, footprint))
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+
+	data, err := GetSelfFootprint(helper_get_params(), tables, someTs, someTs.Add(time.Hour-time.Minute), someRequestId)
+	assert.Nil(t, err)
+	output := SelfFootprintOutput{}
+	assert.Nil(t, json.Unmarshal(data, &output))
+	assert.Len(t, output.Samples, 3)
+	assert.Equal(t, someTs.Unix(), output.Samples[0].Timestamp)
+	assert.Equal(t, SelfFootprintSample{Timestamp: someTs.Add(20 * time.Minute).Unix(), RssBytes: 300, Goroutines: 60, WatchQueueDepth: 900}, output.Peak)
+}
@@ -0,0 +1,162 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+// The backends of the service from the given time until the next change
+type ServiceBackendsChange struct {
+	Timestamp int64                   `json:"timestamp"`
+	Backends  []*typed.ServiceBackend `json:"backends"`
+}
+
+type ServiceBackendsOutput struct {
+	Namespace string `json:"namespace"`
+	Service   string `json:"service"`
+	// The first change is the backend set at the start of the time range when it was already known
+	Changes []ServiceBackendsChange `json:"changes"`
+}
+
+// Returns the backend set of the service given by namespace and name every time it changed, oldest first.  The
+// EndpointSlices of the service are used when there are any, otherwise its Endpoints object
+func GetServiceBackends(params url.Values, t typed.Tables, startTime time.Time, endTime time.Time, requestId string) ([]byte, error) {
+	namespace := params.Get(NamespaceParam)
+	service := params.Get(NameParam)
+	if namespace == "" || namespace == AllNamespaces || service == "" {
+		return []byte{}, NewApiError(ErrorCodeBadParams, "GetServiceBackends requires %v and %v", NamespaceParam, NameParam)
+	}
+
+	var records map[typed.ServiceBackendsKey]*typed.ServiceBackends
+	err := t.Db().View(func(txn badgerwrap.Txn) error {
+		var err2 error
+		var stats typed.RangeReadStats
+		keyComparator := typed.NewServiceBackendsKey("", namespace, service, "", "")
+		records, stats, err2 = t.ServiceBackendsTable().RangeRead(txn, keyComparator, nil, nil, startTime, endTime)
+		if err2 != nil {
+			return err2
+		}
+		stats.Log(requestId)
+		return nil
+	})
+	if err != nil {
+		return []byte{}, err
+	}
+
+	output := ServiceBackendsOutput{Namespace: namespace, Service: service, Changes: reconstructServiceBackends(records, startTime, endTime)}
+	bytes, err := json.MarshalIndent(output, "", " ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal json %v", err)
+	}
+	return bytes, nil
+}
+
+type sourceSnapshot struct {
+	source   typed.ServiceBackendsKey
+	snapshot *typed.ServiceBackendSnapshot
+}
+
+// Replays the snapshots of every source in time order and returns the merged backend set each time it changes
+func reconstructServiceBackends(records map[typed.ServiceBackendsKey]*typed.ServiceBackends, startTime time.Time, endTime time.Time) []ServiceBackendsChange {
+	hasSlices := false
+	for key := range records {
+		if key.SourceKind == kubeextractor.EndpointSliceKind {
+			hasSlices = true
+		}
+	}
+
+	var snapshots []sourceSnapshot
+	for key, record := range records {
+		if hasSlices && key.SourceKind != kubeextractor.EndpointSliceKind {
+			continue
+		}
+		source := key
+		source.PartitionId = ""
+		for _, snapshot := range record.Snapshots {
+			snapshots = append(snapshots, sourceSnapshot{source: source, snapshot: snapshot})
+		}
+	}
+	sort.SliceStable(snapshots, func(i, j int) bool { return snapshots[i].snapshot.Timestamp < snapshots[j].snapshot.Timestamp })
+
+	// Like transitionsInTimeRange the backends from before the start time are kept as the first change
+	current := map[typed.ServiceBackendsKey][]*typed.ServiceBackend{}
+	changes := []ServiceBackendsChange{}
+	var before *ServiceBackendsChange
+	for idx, s := range snapshots {
+		if s.snapshot.Timestamp > endTime.Unix() {
+			break
+		}
+		current[s.source] = s.snapshot.Backends
+		// Apply every snapshot with the same timestamp before looking at the result
+		if idx+1 < len(snapshots) && snapshots[idx+1].snapshot.Timestamp == s.snapshot.Timestamp {
+			continue
+		}
+		change := ServiceBackendsChange{Timestamp: s.snapshot.Timestamp, Backends: mergeServiceBackends(current)}
+		if change.Timestamp < startTime.Unix() {
+			before = &change
+			continue
+		}
+		if len(changes) == 0 && before != nil {
+			changes = append(changes, *before)
+		}
+		// Partitions repeat the backends they start with, which is not a change
+		if len(changes) > 0 && sameBackendList(changes[len(changes)-1].Backends, change.Backends) {
+			continue
+		}
+		changes = append(changes, change)
+	}
+	if len(changes) == 0 && before != nil {
+		changes = append(changes, *before)
+	}
+	return changes
+}
+
+// Union of the backends of all sources, one entry per ip and target
+func mergeServiceBackends(current map[typed.ServiceBackendsKey][]*typed.ServiceBackend) []*typed.ServiceBackend {
+	seen := map[string]bool{}
+	merged := []*typed.ServiceBackend{}
+	for _, backends := range current {
+		for _, backend := range backends {
+			id := backend.Ip + "/" + backend.TargetName
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			merged = append(merged, backend)
+		}
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		if merged[i].Ip != merged[j].Ip {
+			return merged[i].Ip < merged[j].Ip
+		}
+		return merged[i].TargetName < merged[j].TargetName
+	})
+	return merged
+}
+
+func sameBackendList(a []*typed.ServiceBackend, b []*typed.ServiceBackend) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for idx := range a {
+		if !proto.Equal(a[idx], b[idx]) {
+			return false
+		}
+	}
+	return true
+}
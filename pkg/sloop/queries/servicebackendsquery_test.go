@@ -0,0 +1,88 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+func helper_backends(ips ...string) []*typed.ServiceBackend {
+	var backends []*typed.ServiceBackend
+	for _, ip := range ips {
+		backends = append(backends, &typed.ServiceBackend{Ip: ip, Ready: true, TargetKind: "Pod"})
+	}
+	return backends
+}
+
+func Test_GetServiceBackends_MergesSlicesOverTime(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+
+	partitionId := untyped.GetPartitionId(someTs)
+	ts := someTs.Unix()
+	records := map[string]*typed.ServiceBackends{
+		typed.NewServiceBackendsKey(partitionId, "ns", "svc", kubeextractor.EndpointSliceKind, "svc-a").String(): {Snapshots: []*typed.ServiceBackendSnapshot{
+			{Timestamp: ts - 60, Backends: helper_backends("10.0.0.1")},
+			{Timestamp: ts + 60, Backends: helper_backends("10.0.0.1", "10.0.0.3")},
+		}},
+		typed.NewServiceBackendsKey(partitionId, "ns", "svc", kubeextractor.EndpointSliceKind, "svc-b").String(): {Snapshots: []*typed.ServiceBackendSnapshot{
+			{Timestamp: ts - 30, Backends: helper_backends("10.0.0.2")},
+			{Timestamp: ts + 120, Deleted: true},
+		}},
+		// Ignored because the service has EndpointSlices
+		typed.NewServiceBackendsKey(partitionId, "ns", "svc", kubeextractor.EndpointsKind, "svc").String(): {Snapshots: []*typed.ServiceBackendSnapshot{
+			{Timestamp: ts + 90, Backends: helper_backends("10.9.9.9")},
+		}},
+		typed.NewServiceBackendsKey(partitionId, "ns", "othersvc", kubeextractor.EndpointSliceKind, "othersvc-a").String(): {Snapshots: []*typed.ServiceBackendSnapshot{
+			{Timestamp: ts, Backends: helper_backends("10.1.0.1")},
+		}},
+	}
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		for key, val := range records {
+			err := tables.ServiceBackendsTable().Set(txn, key, val)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+
+	values := helper_get_params()
+	values[NamespaceParam] = []string{"ns"}
+	values[NameParam] = []string{"svc"}
+	data, err := GetServiceBackends(values, tables, someTs, someTs.Add(5*time.Minute), someRequestId)
+	assert.Nil(t, err)
+	output := ServiceBackendsOutput{}
+	assert.Nil(t, json.Unmarshal(data, &output))
+	assert.Equal(t, "svc", output.Service)
+	assert.Len(t, output.Changes, 3)
+	assert.Equal(t, ts-30, output.Changes[0].Timestamp)
+	assert.Equal(t, helper_backends("10.0.0.1", "10.0.0.2"), output.Changes[0].Backends)
+	assert.Equal(t, helper_backends("10.0.0.1", "10.0.0.2", "10.0.0.3"), output.Changes[1].Backends)
+	assert.Equal(t, ts+120, output.Changes[2].Timestamp)
+	assert.Equal(t, helper_backends("10.0.0.1", "10.0.0.3"), output.Changes[2].Backends)
+}
+
+func Test_GetServiceBackends_RequiresService(t *testing.T) {
+	values := helper_get_params()
+	values[NamespaceParam] = []string{"ns"}
+	_, err := GetServiceBackends(values, nil, someTs, someTs, someRequestId)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,475 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+)
+
+// These queries are about a single resource, so in shard mode they only need to go to a shard owning the kind
+var singleResourceQueries = map[string]bool{
+	"GetEventData":       true,
+	"GetResPayload":      true,
+	"GetResSummaryData":  true,
+	"GetDeletionCascade": true,
+}
+
+func IsSingleResourceQuery(queryName string) bool {
+	return singleResourceQueries[queryName]
+}
+
+// These queries are about the sloop that answers them, so in shard mode each shard's result is returned as is,
+// keyed by shard name
+var perShardQueries = map[string]bool{
+	"GetSelfFootprint": true,
+}
+
+func IsPerShardQuery(queryName string) bool {
+	return perShardQueries[queryName]
+}
+
+// A shard that failed while the others answered
+type ShardErrorOutput struct {
+	Shard   string    `json:"shard"`
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+}
+
+type shardMerger = func(params url.Values, results [][]byte, shardErrors []ShardErrorOutput) ([]byte, error)
+
+/*
+How the results of each query are merged.  Shards only have the kinds they own, so results of queries that join
+several kinds, like the workloads of GetConfigImpact or the pods of GetNodePoolPods, are only complete when those
+kinds are on the same shard.  Keep this in sync with funcMap, queries that are neither single resource nor per shard
+need an entry
+*/
+var shardMergers = map[string]shardMerger{
+	"EventHeatMap":              mergeTimelineRoots,
+	"Namespaces":                mergeStringLists,
+	"Kinds":                     mergeStringLists,
+	"Queries":                   firstShardResult,
+	"GetPodLifecycle":           mergeJsonArrays("namespace", "name", "uid"),
+	"GetNodeHealth":             mergeJsonArrays("name", "uid"),
+	"GetOwnerTree":              mergeJsonTrees,
+	"GetServiceBackends":        mergeJsonObjects(map[string][]string{"changes": {"timestamp"}}),
+	"GetVolumeBinding":          mergeJsonObjects(map[string][]string{"claims": {"namespace", "name"}, "volumes": {"name"}}),
+	"GetCurrentState":           mergeJsonArrays("kind", "namespace", "name"),
+	"GetNamespaceEpochs":        mergeJsonArrays("namespace", "start"),
+	"GetIngestAnnotations":      mergeJsonArrays("start", "type", "kind"),
+	"GetFlappingResources":      mergeJsonArrays("-returns", "kind", "namespace", "name"),
+	"GetQuotaUtilization":       mergeJsonArrays("namespace"),
+	"GetCredentialUsage":        mergeJsonArrays("kind", "name"),
+	"GetNetworkReferences":      mergeJsonArrays("from", "kind", "namespace", "name"),
+	"GetDeploymentRollouts":     mergeJsonArrays("startTime", "namespace", "name"),
+	"GetConfigImpact":           mergeJsonObjects(map[string][]string{"changes": {}, "workloads": {"kind", "name"}}),
+	"GetEventBreakdown":         mergeEventBreakdowns,
+	"GetSnapshotDiff":           mergeJsonObjects(map[string][]string{"added": snapshotDiffOrder, "removed": snapshotDiffOrder, "changed": snapshotDiffOrder, "transient": snapshotDiffOrder}, "unchanged"),
+	"GetApiVersionMigrations":   mergeJsonObjects(map[string][]string{"kinds": {"kind"}, "migrations": {"timestamp", "kind", "namespace", "name"}}),
+	"GetWriterConflicts":        mergeJsonArrays("-reverts", "kind", "namespace", "name"),
+	"GetNamespaceComparison":    mergeJsonObjects(map[string][]string{"different": {"kind", "name"}, "onlyInNamespace": {"kind", "name"}, "onlyInOtherNamespace": {"kind", "name"}}, "identical"),
+	"GetApiServiceAvailability": mergeJsonArrays("name", "uid"),
+	"GetEventTrend":             mergeEventTrends,
+	"GetNodePoolPods":           mergeNodePools,
+	"GetResourceCounts":         mergeResourceCounts,
+}
+
+var snapshotDiffOrder = []string{"kind", "namespace", "name"}
+
+// Takes the json output of the same query run against several shards and combines them into what a single
+// store holding all the data would have returned
+func MergeShardResults(queryName string, params url.Values, results [][]byte) ([]byte, error) {
+	return MergePartialShardResults(queryName, params, results, nil)
+}
+
+// Same as MergeShardResults for when some shards failed.  Outputs with room for it, like the timeline, list the
+// failed shards in the body, the rest only get them in ShardErrorsHeader
+func MergePartialShardResults(queryName string, params url.Values, results [][]byte, shardErrors []ShardErrorOutput) ([]byte, error) {
+	if len(results) == 0 {
+		return nil, fmt.Errorf("no shard results to merge for query %v", queryName)
+	}
+	if len(results) == 1 && len(shardErrors) == 0 {
+		return results[0], nil
+	}
+
+	merge, ok := shardMergers[queryName]
+	if !ok {
+		return nil, fmt.Errorf("query %v can not be merged across %v shards", queryName, len(results))
+	}
+	return merge(params, results, shardErrors)
+}
+
+func firstShardResult(params url.Values, results [][]byte, shardErrors []ShardErrorOutput) ([]byte, error) {
+	return results[0], nil
+}
+
+type timelineRowKey struct {
+	kind      string
+	namespace string
+	text      string
+	startDate int64
+}
+
+func mergeTimelineRoots(params url.Values, results [][]byte, shardErrors []ShardErrorOutput) ([]byte, error) {
+	merged := TimelineRoot{Rows: []TimelineRow{}, ShardErrors: shardErrors}
+	// Kinds that belong to more than one shard (usually Events) are returned by each of them
+	seen := map[timelineRowKey]bool{}
+	for idx, result := range results {
+		var root TimelineRoot
+		err := json.Unmarshal(result, &root)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal timeline from shard result %v: %v", idx, err)
+		}
+		if idx == 0 {
+			merged.ViewOpt = root.ViewOpt
+		}
+		for _, row := range root.Rows {
+			key := timelineRowKey{kind: row.Kind, namespace: row.Namespace, text: row.Text, startDate: row.StartDate}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			merged.Rows = append(merged.Rows, row)
+		}
+	}
+
+	bytes, err := json.MarshalIndent(merged, "", " ")
+	if err != nil {
+		return nil, fmt.Errorf("Failed to marshal json %v", err)
+	}
+	return bytes, nil
+}
+
+func mergeStringLists(params url.Values, results [][]byte, shardErrors []ShardErrorOutput) ([]byte, error) {
+	exists := map[string]bool{}
+	for idx, result := range results {
+		var list []string
+		err := json.Unmarshal(result, &list)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal list from shard result %v: %v", idx, err)
+		}
+		for _, item := range list {
+			exists[item] = true
+		}
+	}
+	merged := []string{}
+	for item := range exists {
+		merged = append(merged, item)
+	}
+	sort.Strings(merged)
+
+	bytes, err := json.MarshalIndent(merged, "", " ")
+	if err != nil {
+		return nil, fmt.Errorf("Failed to marshal json %v", err)
+	}
+	return bytes, nil
+}
+
+type timelineResourceKey struct {
+	kind      string
+	namespace string
+	name      string
+}
+
+/*
+Merges the timelines of shards that each watch a different cluster running the same workloads.  Rows with the same
+kind, namespace and name are the same resource whatever cluster they are in, so they become one row that spans all
+of them, with every change and event, and the row of each shard as a lane of it.  The completeness of the merged row
+is that of its least complete lane.  shardNames are the names of the shards the results came from, in the same order
+*/
+func MergeShardLanes(shardNames []string, results [][]byte, shardErrors []ShardErrorOutput) ([]byte, error) {
+	if len(shardNames) != len(results) {
+		return nil, fmt.Errorf("got %v shard results for %v shards", len(results), len(shardNames))
+	}
+	merged := TimelineRoot{Rows: []TimelineRow{}, ShardErrors: shardErrors}
+	rowIdx := map[timelineResourceKey]int{}
+	for idx, result := range results {
+		var root TimelineRoot
+		err := json.Unmarshal(result, &root)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal timeline from shard %v: %v", shardNames[idx], err)
+		}
+		if idx == 0 {
+			merged.ViewOpt = root.ViewOpt
+		}
+		for _, row := range root.Rows {
+			row.Shard = shardNames[idx]
+			key := timelineResourceKey{kind: row.Kind, namespace: row.Namespace, name: row.Text}
+			existing, ok := rowIdx[key]
+			if !ok {
+				rowIdx[key] = len(merged.Rows)
+				merged.Rows = append(merged.Rows, TimelineRow{Text: row.Text, Kind: row.Kind, Namespace: row.Namespace,
+					NamespaceUid: row.NamespaceUid, StartDate: row.StartDate, EndDate: row.EndDate, Lanes: []TimelineRow{}})
+				existing = len(merged.Rows) - 1
+			}
+			addTimelineLane(&merged.Rows[existing], row)
+		}
+	}
+	for idx := range merged.Rows {
+		row := &merged.Rows[idx]
+		row.Duration = row.EndDate - row.StartDate
+		sort.Slice(row.Overlays, func(i, j int) bool { return row.Overlays[i].StartDate < row.Overlays[j].StartDate })
+		row.ChangedAt = sortedUniqueTimes(row.ChangedAt)
+		row.NoChangeAt = sortedUniqueTimes(row.NoChangeAt)
+		row.MissedAt = sortedUniqueTimes(row.MissedAt)
+	}
+
+	bytes, err := json.MarshalIndent(merged, "", " ")
+	if err != nil {
+		return nil, fmt.Errorf("Failed to marshal json %v", err)
+	}
+	return bytes, nil
+}
+
+func addTimelineLane(row *TimelineRow, lane TimelineRow) {
+	if lane.StartDate < row.StartDate {
+		row.StartDate = lane.StartDate
+	}
+	if lane.EndDate > row.EndDate {
+		row.EndDate = lane.EndDate
+	}
+	row.Overlays = append(row.Overlays, lane.Overlays...)
+	row.ChangedAt = append(row.ChangedAt, lane.ChangedAt...)
+	row.NoChangeAt = append(row.NoChangeAt, lane.NoChangeAt...)
+	row.MissedAt = append(row.MissedAt, lane.MissedAt...)
+	if lane.Completeness != nil && (row.Completeness == nil || lane.Completeness.Score < row.Completeness.Score) {
+		row.Completeness = lane.Completeness
+	}
+	row.Lanes = append(row.Lanes, lane)
+}
+
+// An element of a json list with its fields decoded, to sort and dedupe it without knowing its type
+type shardListItem struct {
+	raw    json.RawMessage
+	fields map[string]interface{}
+	value  interface{}
+}
+
+func decodeShardList(raw json.RawMessage) ([]shardListItem, error) {
+	var list []json.RawMessage
+	err := json.Unmarshal(raw, &list)
+	if err != nil {
+		return nil, err
+	}
+	items := []shardListItem{}
+	for _, element := range list {
+		item := shardListItem{raw: element}
+		err = json.Unmarshal(element, &item.value)
+		if err != nil {
+			return nil, err
+		}
+		item.fields, _ = item.value.(map[string]interface{})
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+/*
+Combines the lists of each shard into one sorted by the fields, like the query sorts its own output.  A field
+starting with - sorts in descending order, and lists of plain values sort by the value when no field is given.
+Elements that are the same in several shards, usually about Events which are on more than one of them, are kept once
+*/
+func mergeShardLists(lists []json.RawMessage, sortFields []string) (json.RawMessage, error) {
+	merged := []shardListItem{}
+	seen := map[string]bool{}
+	for _, list := range lists {
+		if len(list) == 0 || string(list) == "null" {
+			continue
+		}
+		items, err := decodeShardList(list)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			// Marshalling the decoded value sorts the keys of objects, so the same element has the same text
+			canonical, err := json.Marshal(item.value)
+			if err != nil {
+				return nil, err
+			}
+			if seen[string(canonical)] {
+				continue
+			}
+			seen[string(canonical)] = true
+			merged = append(merged, item)
+		}
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		if len(sortFields) == 0 {
+			return compareJsonValues(merged[i].value, merged[j].value) < 0
+		}
+		for _, field := range sortFields {
+			descending := field[0] == '-'
+			if descending {
+				field = field[1:]
+			}
+			cmp := compareJsonValues(merged[i].fields[field], merged[j].fields[field])
+			if cmp == 0 {
+				continue
+			}
+			return (cmp < 0) != descending
+		}
+		return false
+	})
+	output := make([]json.RawMessage, 0, len(merged))
+	for _, item := range merged {
+		output = append(output, item.raw)
+	}
+	return json.Marshal(output)
+}
+
+func compareJsonValues(a interface{}, b interface{}) int {
+	aNumber, aOk := a.(float64)
+	bNumber, bOk := b.(float64)
+	if aOk && bOk {
+		switch {
+		case aNumber < bNumber:
+			return -1
+		case aNumber > bNumber:
+			return 1
+		}
+		return 0
+	}
+	aText, bText := fmt.Sprint(a), fmt.Sprint(b)
+	switch {
+	case aText < bText:
+		return -1
+	case aText > bText:
+		return 1
+	}
+	return 0
+}
+
+// For queries returning a list, see mergeShardLists
+func mergeJsonArrays(sortFields ...string) shardMerger {
+	return func(params url.Values, results [][]byte, shardErrors []ShardErrorOutput) ([]byte, error) {
+		lists := []json.RawMessage{}
+		for _, result := range results {
+			lists = append(lists, result)
+		}
+		merged, err := mergeShardLists(lists, sortFields)
+		if err != nil {
+			return nil, fmt.Errorf("failed to merge shard lists: %v", err)
+		}
+		return indentShardResult(merged)
+	}
+}
+
+/*
+For queries returning an object with lists.  The lists are merged with mergeShardLists using their sort fields, the
+numbers in sumFields are added up and every other field is taken from the first shard, since they echo the params
+*/
+func mergeJsonObjects(lists map[string][]string, sumFields ...string) shardMerger {
+	return func(params url.Values, results [][]byte, shardErrors []ShardErrorOutput) ([]byte, error) {
+		objects := []map[string]json.RawMessage{}
+		for idx, result := range results {
+			object := map[string]json.RawMessage{}
+			err := json.Unmarshal(result, &object)
+			if err != nil {
+				return nil, fmt.Errorf("failed to unmarshal object from shard result %v: %v", idx, err)
+			}
+			objects = append(objects, object)
+		}
+		merged := objects[0]
+		for field, sortFields := range lists {
+			fieldLists := []json.RawMessage{}
+			for _, object := range objects {
+				fieldLists = append(fieldLists, object[field])
+			}
+			list, err := mergeShardLists(fieldLists, sortFields)
+			if err != nil {
+				return nil, fmt.Errorf("failed to merge %v of shard results: %v", field, err)
+			}
+			merged[field] = list
+		}
+		for _, field := range sumFields {
+			var sum float64
+			for _, object := range objects {
+				var value float64
+				if len(object[field]) > 0 {
+					err := json.Unmarshal(object[field], &value)
+					if err != nil {
+						return nil, fmt.Errorf("failed to add up %v of shard results: %v", field, err)
+					}
+				}
+				sum += value
+			}
+			merged[field], _ = json.Marshal(sum)
+		}
+		bytes, err := json.Marshal(merged)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to marshal json %v", err)
+		}
+		return indentShardResult(bytes)
+	}
+}
+
+func indentShardResult(raw []byte) ([]byte, error) {
+	var value interface{}
+	err := json.Unmarshal(raw, &value)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(value, "", " ")
+}
+
+/*
+For trees of resources found by uid, where each shard has the children of the kinds it owns.  Nodes with the same uid
+are merged, fields one shard left empty come from another and the children of both are merged the same way
+*/
+func mergeJsonTrees(params url.Values, results [][]byte, shardErrors []ShardErrorOutput) ([]byte, error) {
+	var merged map[string]interface{}
+	for idx, result := range results {
+		tree := map[string]interface{}{}
+		err := json.Unmarshal(result, &tree)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal tree from shard result %v: %v", idx, err)
+		}
+		if merged == nil {
+			merged = tree
+			continue
+		}
+		mergeTreeNode(merged, tree)
+	}
+	return json.MarshalIndent(merged, "", " ")
+}
+
+func mergeTreeNode(node map[string]interface{}, other map[string]interface{}) {
+	for field, value := range other {
+		if field == "children" {
+			continue
+		}
+		if existing, ok := node[field]; !ok || existing == nil || existing == "" {
+			node[field] = value
+		}
+	}
+	children, _ := node["children"].([]interface{})
+	otherChildren, _ := other["children"].([]interface{})
+	for _, otherChild := range otherChildren {
+		otherNode, ok := otherChild.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		found := false
+		for _, child := range children {
+			childNode, ok := child.(map[string]interface{})
+			if ok && childNode["uid"] == otherNode["uid"] {
+				mergeTreeNode(childNode, otherNode)
+				found = true
+				break
+			}
+		}
+		if !found {
+			children = append(children, otherNode)
+		}
+	}
+	if children != nil {
+		node["children"] = children
+	}
+}
@@ -0,0 +1,207 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MergeShardResults_SingleResultPassesThrough(t *testing.T) {
+	merged, err := MergeShardResults("GetResPayload", nil, [][]byte{[]byte("[]")})
+	assert.Nil(t, err)
+	assert.Equal(t, "[]", string(merged))
+}
+
+func Test_MergeShardResults_SingleResourceQueryCanNotMerge(t *testing.T) {
+	_, err := MergeShardResults("GetResPayload", nil, [][]byte{[]byte("[]"), []byte("[]")})
+	assert.NotNil(t, err)
+}
+
+func Test_shardMergers_CoversAllQueries(t *testing.T) {
+	for name := range funcMap {
+		_, ok := shardMergers[name]
+		covered := ok || IsSingleResourceQuery(name) || IsPerShardQuery(name)
+		assert.True(t, covered, "query %v has no shard merger and is neither single resource nor per shard", name)
+		assert.False(t, ok && IsSingleResourceQuery(name), "query %v is single resource and has a shard merger", name)
+	}
+	for name := range shardMergers {
+		assert.True(t, IsQuery(name), "shard merger for unknown query %v", name)
+	}
+}
+
+func Test_MergeShardResults_ListsAreSortedAndDeduped(t *testing.T) {
+	merged, err := MergeShardResults("GetFlappingResources", nil, [][]byte{
+		[]byte(`[{"kind":"Pod","namespace":"ns1","name":"b","returns":2},{"kind":"Event","namespace":"ns1","name":"e","returns":1}]`),
+		[]byte(`[{"kind":"Event","namespace":"ns1","name":"e","returns":1},{"kind":"Deployment","namespace":"ns1","name":"a","returns":5}]`),
+	})
+	assert.Nil(t, err)
+	var rows []FlappingResourceOutput
+	assert.Nil(t, json.Unmarshal(merged, &rows))
+	assert.Len(t, rows, 3)
+	assert.Equal(t, "a", rows[0].Name)
+	assert.Equal(t, "b", rows[1].Name)
+	assert.Equal(t, "e", rows[2].Name)
+}
+
+func Test_MergeShardResults_ObjectListsAndSums(t *testing.T) {
+	merged, err := MergeShardResults("GetSnapshotDiff", nil, [][]byte{
+		[]byte(`{"from":100,"to":200,"added":[{"kind":"Pod","name":"p1","updates":1}],"removed":[],"changed":[],"transient":[],"unchanged":3}`),
+		[]byte(`{"from":100,"to":200,"added":[{"kind":"ConfigMap","name":"c1","updates":1}],"removed":null,"changed":[],"transient":[],"unchanged":4}`),
+	})
+	assert.Nil(t, err)
+	var diff SnapshotDiffOutput
+	assert.Nil(t, json.Unmarshal(merged, &diff))
+	assert.Equal(t, int64(100), diff.From)
+	assert.Equal(t, 7, diff.Unchanged)
+	assert.Len(t, diff.Added, 2)
+	assert.Equal(t, "ConfigMap", diff.Added[0].Kind)
+	assert.Equal(t, []SnapshotDiffResource{}, diff.Removed)
+}
+
+func Test_MergeShardResults_OwnerTreesByUid(t *testing.T) {
+	merged, err := MergeShardResults("GetOwnerTree", nil, [][]byte{
+		[]byte(`{"uid":"d1","kind":"Deployment","name":"web","children":[{"uid":"rs1","kind":"ReplicaSet","name":"web-1","children":[]}]}`),
+		[]byte(`{"uid":"d1","children":[{"uid":"rs1","children":[{"uid":"p1","kind":"Pod","name":"web-1-x","children":[]}]}]}`),
+	})
+	assert.Nil(t, err)
+	var tree OwnerTreeOutput
+	assert.Nil(t, json.Unmarshal(merged, &tree))
+	assert.Equal(t, "Deployment", tree.Kind)
+	assert.Len(t, tree.Children, 1)
+	assert.Equal(t, "web-1", tree.Children[0].Name)
+	assert.Len(t, tree.Children[0].Children, 1)
+	assert.Equal(t, "p1", tree.Children[0].Children[0].Uid)
+}
+
+func Test_MergeShardResults_EventTrendsAddUp(t *testing.T) {
+	merged, err := MergeShardResults("GetEventTrend", nil, [][]byte{
+		[]byte(`{"granularity":"hour","buckets":[{"start":0,"end":3600,"count":2,"reasons":{"BackOff:Warning":2}}],"total":2,"keysRead":{"hour":1}}`),
+		[]byte(`{"granularity":"hour","buckets":[{"start":0,"end":3600,"count":3,"reasons":{"BackOff:Warning":1,"Pulled:Normal":2}}],"total":3,"keysRead":{"hour":2}}`),
+	})
+	assert.Nil(t, err)
+	var trend EventTrendOutput
+	assert.Nil(t, json.Unmarshal(merged, &trend))
+	assert.Equal(t, 5, trend.Total)
+	assert.Len(t, trend.Buckets, 1)
+	assert.Equal(t, map[string]int{"BackOff:Warning": 3, "Pulled:Normal": 2}, trend.Buckets[0].Reasons)
+	assert.Equal(t, 3, trend.KeysRead["hour"])
+}
+
+func Test_MergeShardResults_ResourceCountTotalsAddUp(t *testing.T) {
+	merged, err := MergeShardResults("GetResourceCounts", url.Values{LimitParam: []string{"10"}}, [][]byte{
+		[]byte(`{"series":[{"kind":"Total","points":[{"timestamp":0,"count":2},{"timestamp":86400,"count":3}]},{"kind":"Pod","points":[{"timestamp":0,"count":2},{"timestamp":86400,"count":3}]}]}`),
+		[]byte(`{"series":[{"kind":"Total","points":[{"timestamp":0,"count":1},{"timestamp":86400,"count":2}]},{"kind":"Node","points":[{"timestamp":0,"count":1},{"timestamp":86400,"count":2}]}]}`),
+	})
+	assert.Nil(t, err)
+	var counts ResourceCountOutput
+	assert.Nil(t, json.Unmarshal(merged, &counts))
+	assert.Len(t, counts.Series, 3)
+	assert.Equal(t, resourceCountTotalKind, counts.Series[0].Kind)
+	assert.Equal(t, []ResourceCountPoint{{Timestamp: 0, Count: 3}, {Timestamp: 86400, Count: 5}}, counts.Series[0].Points)
+	assert.Equal(t, 2.0, counts.Series[0].GrowthPerDay)
+	assert.Equal(t, int64(86400+5*86400/2), counts.Series[0].LimitReachedAt)
+	assert.Equal(t, "Node", counts.Series[1].Kind)
+}
+
+func Test_MergeShardResults_KindsAreUnioned(t *testing.T) {
+	merged, err := MergeShardResults("Kinds", nil, [][]byte{
+		[]byte(`["_all","Pod"]`),
+		[]byte(`["_all","Deployment","Node"]`),
+	})
+	assert.Nil(t, err)
+	var kinds []string
+	assert.Nil(t, json.Unmarshal(merged, &kinds))
+	assert.Equal(t, []string{"Deployment", "Node", "Pod", "_all"}, kinds)
+}
+
+func Test_MergeShardResults_HeatMapRowsAreCombinedAndDeduped(t *testing.T) {
+	podShard := TimelineRoot{ViewOpt: ViewOptions{Sort: "name"}, Rows: []TimelineRow{
+		{Text: "pod1", Kind: "Pod", Namespace: "ns1", StartDate: 100},
+		{Text: "event1", Kind: "Event", Namespace: "ns1", StartDate: 100},
+	}}
+	nodeShard := TimelineRoot{Rows: []TimelineRow{
+		{Text: "node1", Kind: "Node", Namespace: "", StartDate: 100},
+		{Text: "event1", Kind: "Event", Namespace: "ns1", StartDate: 100},
+	}}
+	podBytes, _ := json.Marshal(podShard)
+	nodeBytes, _ := json.Marshal(nodeShard)
+
+	merged, err := MergeShardResults("EventHeatMap", nil, [][]byte{podBytes, nodeBytes})
+	assert.Nil(t, err)
+	var root TimelineRoot
+	assert.Nil(t, json.Unmarshal(merged, &root))
+	assert.Equal(t, "name", root.ViewOpt.Sort)
+	assert.Len(t, root.Rows, 3)
+	assert.Equal(t, "node1", root.Rows[2].Text)
+}
+
+func Test_MergePartialShardResults_HeatMapListsShardErrors(t *testing.T) {
+	podBytes, _ := json.Marshal(TimelineRoot{Rows: []TimelineRow{{Text: "pod1", Kind: "Pod", Namespace: "ns1", StartDate: 100}}})
+	shardErrors := []ShardErrorOutput{{Shard: "nodes", Code: ErrorCodeInternal, Message: "boom"}}
+
+	merged, err := MergePartialShardResults("EventHeatMap", nil, [][]byte{podBytes}, shardErrors)
+	assert.Nil(t, err)
+	var root TimelineRoot
+	assert.Nil(t, json.Unmarshal(merged, &root))
+	assert.Len(t, root.Rows, 1)
+	assert.Equal(t, shardErrors, root.ShardErrors)
+
+	// Lists have no room for them
+	merged, err = MergePartialShardResults("Kinds", nil, [][]byte{[]byte(`["Pod"]`)}, shardErrors)
+	assert.Nil(t, err)
+	var kinds []string
+	assert.Nil(t, json.Unmarshal(merged, &kinds))
+	assert.Equal(t, []string{"Pod"}, kinds)
+}
+
+func Test_MergeShardLanes(t *testing.T) {
+	east, _ := json.Marshal(TimelineRoot{ViewOpt: ViewOptions{Sort: "name"}, Rows: []TimelineRow{
+		{Text: "web", Kind: "Deployment", Namespace: "shop", StartDate: 100, EndDate: 200, ChangedAt: []int64{150}, NoChangeAt: []int64{}, Overlays: []Overlay{{Text: "b", StartDate: 160}},
+			Completeness: &HistoryCompleteness{Score: 1}},
+		{Text: "db", Kind: "StatefulSet", Namespace: "shop", StartDate: 100, EndDate: 200},
+	}})
+	west, _ := json.Marshal(TimelineRoot{Rows: []TimelineRow{
+		{Text: "web", Kind: "Deployment", Namespace: "shop", StartDate: 50, EndDate: 180, ChangedAt: []int64{150, 170}, NoChangeAt: []int64{60}, Overlays: []Overlay{{Text: "a", StartDate: 55}},
+			Completeness: &HistoryCompleteness{Score: 0.5, Relists: 1}},
+		{Text: "web", Kind: "Service", Namespace: "shop", StartDate: 50, EndDate: 180},
+	}})
+
+	merged, err := MergeShardLanes([]string{"east", "west"}, [][]byte{east, west}, nil)
+	assert.Nil(t, err)
+	var root TimelineRoot
+	assert.Nil(t, json.Unmarshal(merged, &root))
+	assert.Equal(t, "name", root.ViewOpt.Sort)
+	assert.Len(t, root.Rows, 3)
+
+	web := root.Rows[0]
+	assert.Equal(t, "Deployment", web.Kind)
+	assert.Equal(t, int64(50), web.StartDate)
+	assert.Equal(t, int64(200), web.EndDate)
+	assert.Equal(t, int64(150), web.Duration)
+	assert.Equal(t, []int64{150, 170}, web.ChangedAt)
+	assert.Equal(t, []int64{60}, web.NoChangeAt)
+	assert.Equal(t, []Overlay{{Text: "a", StartDate: 55}, {Text: "b", StartDate: 160}}, web.Overlays)
+	assert.Equal(t, 0.5, web.Completeness.Score)
+	assert.Len(t, web.Lanes, 2)
+	assert.Equal(t, "east", web.Lanes[0].Shard)
+	assert.Equal(t, int64(100), web.Lanes[0].StartDate)
+	assert.Equal(t, "west", web.Lanes[1].Shard)
+
+	// Only in one cluster, or only the same name
+	assert.Equal(t, "StatefulSet", root.Rows[1].Kind)
+	assert.Len(t, root.Rows[1].Lanes, 1)
+	assert.Equal(t, "Service", root.Rows[2].Kind)
+	assert.Len(t, root.Rows[2].Lanes, 1)
+
+	_, err = MergeShardLanes([]string{"east"}, [][]byte{east, west}, nil)
+	assert.NotNil(t, err)
+}
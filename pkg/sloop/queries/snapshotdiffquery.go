@@ -0,0 +1,239 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+const maxSnapshotDiffPaths = 20
+
+type SnapshotDiffOutput struct {
+	// Unix seconds of the two cluster states that are compared, the start and end of the time range
+	From      int64                  `json:"from"`
+	To        int64                  `json:"to"`
+	Added     []SnapshotDiffResource `json:"added"`
+	Removed   []SnapshotDiffResource `json:"removed"`
+	Changed   []SnapshotDiffResource `json:"changed"`
+	Transient []SnapshotDiffResource `json:"transient"`
+	// Resources seen in between, mostly by resyncs, that ended up as they started
+	Unchanged int `json:"unchanged"`
+}
+
+type SnapshotDiffResource struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	Uid       string `json:"uid,omitempty"`
+	// Summary lines of the payloads at the start and end, for kinds that have them
+	SummaryBefore string `json:"summaryBefore,omitempty"`
+	SummaryAfter  string `json:"summaryAfter,omitempty"`
+	// Paths that differ between the start and the end, without the fields that change on every status update.  Only
+	// the first few are kept, changedPathCount has the total
+	ChangedPaths     []string `json:"changedPaths,omitempty"`
+	ChangedPathCount int      `json:"changedPathCount,omitempty"`
+	// Deleted and created again with a new uid in between
+	Recreated bool `json:"recreated,omitempty"`
+	// Watch results in the time range
+	Updates int `json:"updates"`
+}
+
+type snapshotDiffId struct {
+	kind      string
+	namespace string
+	name      string
+}
+
+/*
+Compares the live resources matching kind, namespace, namematch and name at the start and end of the time range, to
+audit a whole maintenance window in one call.  Resources are added or removed when they only lived at one end,
+changed when their payload differs, and transient when they were created and deleted in between.  Only resources with
+watch results in the time range can differ, so those are read, along with the last result of each before the start
+time.  Events are left out unless kind is Event
+*/
+func GetSnapshotDiff(params url.Values, t typed.Tables, startTime time.Time, endTime time.Time, requestId string) ([]byte, error) {
+	selectedKind := defaultParam(params.Get(KindParam), AllKinds)
+	selectedNamespace := defaultParam(params.Get(NamespaceParam), AllNamespaces)
+	selectedNameMatch := params.Get(NameMatchParam)
+	selectedName := params.Get(NameParam)
+
+	output := SnapshotDiffOutput{
+		From:      startTime.Unix(),
+		To:        endTime.Unix(),
+		Added:     []SnapshotDiffResource{},
+		Removed:   []SnapshotDiffResource{},
+		Changed:   []SnapshotDiffResource{},
+		Transient: []SnapshotDiffResource{},
+	}
+	err := t.Db().View(func(txn badgerwrap.Txn) error {
+		keyPredicate := func(key string) bool {
+			k := &typed.WatchTableKey{}
+			err := k.Parse(key)
+			if err != nil {
+				return false
+			}
+			if selectedKind == AllKinds && k.Kind == kubeextractor.EventKind {
+				return false
+			}
+			return keepRowHelper(k.Name, k.Kind, k.Namespace, selectedKind, selectedNamespace, selectedNameMatch, selectedName, "", "")
+		}
+		valPredFn := typed.KubeWatchResult_ValPredicateFns(isResPayloadInTimeRange(startTime, endTime))
+		records, stats, err := t.WatchTable().RangeRead(txn, getSnapshotDiffKeyPrefix(selectedKind, selectedNamespace), keyPredicate, valPredFn, startTime, endTime)
+		if err != nil {
+			return err
+		}
+		stats.Log(requestId)
+
+		// addWatchRecordsBefore works on the resources of one kind
+		byKind := map[string]map[typed.WatchTableKey]*typed.KubeWatchResult{}
+		for key, result := range records {
+			if byKind[key.Kind] == nil {
+				byKind[key.Kind] = map[typed.WatchTableKey]*typed.KubeWatchResult{}
+			}
+			byKind[key.Kind][key] = result
+		}
+		for kind, kindRecords := range byKind {
+			grouped := groupWatchRecords(kindRecords)
+			inRange := map[watchResourceId]int{}
+			for id, resourceRecords := range grouped {
+				inRange[id] = len(resourceRecords)
+			}
+			err = addWatchRecordsBefore(txn, t, kind, grouped, startTime)
+			if err != nil {
+				return err
+			}
+			for id, resourceRecords := range grouped {
+				diffSnapshotResource(&output, snapshotDiffId{kind: kind, namespace: id.namespace, name: id.name}, resourceRecords, len(resourceRecords) > inRange[id])
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return []byte{}, err
+	}
+
+	for _, list := range [][]SnapshotDiffResource{output.Added, output.Removed, output.Changed, output.Transient} {
+		sortSnapshotDiffResources(list)
+	}
+	bytes, err := json.MarshalIndent(output, "", " ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal json %v", err)
+	}
+	return bytes, nil
+}
+
+// Only a single kind can be read with a key prefix, cluster scoped kinds have an empty namespace in their keys
+func getSnapshotDiffKeyPrefix(selectedKind string, selectedNamespace string) *typed.WatchTableKey {
+	if selectedKind == AllKinds {
+		return nil
+	}
+	if kubeextractor.IsClustersScopedResource(selectedKind) {
+		return typed.NewWatchTableKeyComparator(selectedKind, "", "", time.Time{})
+	}
+	return getNamespacedKindKeyPrefix(selectedKind, selectedNamespace)
+}
+
+// Records are sorted by time, and the first one is from before the start time when hasBefore is set
+func diffSnapshotResource(output *SnapshotDiffOutput, id snapshotDiffId, records []watchRecord, hasBefore bool) {
+	row := SnapshotDiffResource{Kind: id.kind, Namespace: id.namespace, Name: id.name, Updates: len(records)}
+	var before *typed.KubeWatchResult
+	if hasBefore {
+		before = records[0].result
+		row.Updates--
+	}
+	after := records[len(records)-1].result
+	liveBefore := before != nil && before.WatchType != typed.KubeWatchResult_DELETE
+	liveAfter := after.WatchType != typed.KubeWatchResult_DELETE
+
+	if liveBefore {
+		row.Uid = snapshotDiffUid(before.Payload)
+		row.SummaryBefore = summarizePayload(id.kind, before.Payload)
+	}
+	if liveAfter {
+		row.Uid = snapshotDiffUid(after.Payload)
+		row.SummaryAfter = summarizePayload(id.kind, after.Payload)
+	}
+
+	switch {
+	case !liveBefore && liveAfter:
+		output.Added = append(output.Added, row)
+	case liveBefore && !liveAfter:
+		output.Removed = append(output.Removed, row)
+	case !liveBefore && !liveAfter:
+		row.Uid = snapshotDiffUid(after.Payload)
+		for _, record := range records[len(records)-row.Updates:] {
+			if record.result.WatchType != typed.KubeWatchResult_DELETE {
+				output.Transient = append(output.Transient, row)
+				return
+			}
+		}
+		// Only a delete, so it was live at the start but its last result before that is too old to still be stored
+		if before == nil {
+			row.SummaryBefore = summarizePayload(id.kind, after.Payload)
+			output.Removed = append(output.Removed, row)
+		}
+	default:
+		row.Recreated = snapshotDiffUid(before.Payload) != row.Uid
+		paths, err := snapshotDiffPaths(before.Payload, after.Payload)
+		if err != nil {
+			glog.Errorf("Failed to diff %v %v/%v: %v", id.kind, id.namespace, id.name, err)
+		}
+		if len(paths) == 0 && !row.Recreated {
+			output.Unchanged++
+			return
+		}
+		row.ChangedPathCount = len(paths)
+		if len(paths) > maxSnapshotDiffPaths {
+			paths = paths[:maxSnapshotDiffPaths]
+		}
+		row.ChangedPaths = paths
+		output.Changed = append(output.Changed, row)
+	}
+}
+
+// Like the flapping detector, fields that change on every status update are not a change of state
+func snapshotDiffPaths(beforePayload string, afterPayload string) ([]string, error) {
+	beforeState, err := flappingState(beforePayload)
+	if err != nil {
+		return nil, err
+	}
+	afterState, err := flappingState(afterPayload)
+	if err != nil {
+		return nil, err
+	}
+	return kubeextractor.ComputeChangedPaths(beforeState, afterState)
+}
+
+func snapshotDiffUid(payload string) string {
+	metadata, err := kubeextractor.ExtractMetadata(payload)
+	if err != nil {
+		return ""
+	}
+	return metadata.Uid
+}
+
+func sortSnapshotDiffResources(list []SnapshotDiffResource) {
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Kind != list[j].Kind {
+			return list[i].Kind < list[j].Kind
+		}
+		if list[i].Namespace != list[j].Namespace {
+			return list[i].Namespace < list[j].Namespace
+		}
+		return list[i].Name < list[j].Name
+	})
+}
@@ -0,0 +1,101 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+func helper_snapshotDiffPayload(uid string, resourceVersion int, replicas int) string {
+	return fmt.Sprintf(`{"metadata":{"name":"x","uid":"%v","resourceVersion":"%v"},"spec":{"replicas":%v}}`, uid, resourceVersion, replicas)
+}
+
+func Test_GetSnapshotDiff(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+
+	results := []struct {
+		kind      string
+		namespace string
+		name      string
+		offset    time.Duration
+		watchType typed.KubeWatchResult_WatchType
+		payload   string
+	}{
+		{"Deployment", "ns", "web", -30 * time.Minute, typed.KubeWatchResult_ADD, helper_snapshotDiffPayload("u1", 1, 2)},
+		{"Deployment", "ns", "web", 10 * time.Minute, typed.KubeWatchResult_UPDATE, helper_snapshotDiffPayload("u1", 2, 3)},
+		{"Pod", "ns", "old", -30 * time.Minute, typed.KubeWatchResult_UPDATE, helper_snapshotDiffPayload("u2", 1, 0)},
+		{"Pod", "ns", "old", 10 * time.Minute, typed.KubeWatchResult_DELETE, helper_snapshotDiffPayload("u2", 2, 0)},
+		{"Pod", "ns", "new", 10 * time.Minute, typed.KubeWatchResult_ADD, helper_snapshotDiffPayload("u3", 1, 0)},
+		{"Pod", "ns", "temp", 10 * time.Minute, typed.KubeWatchResult_ADD, helper_snapshotDiffPayload("u4", 1, 0)},
+		{"Pod", "ns", "temp", 20 * time.Minute, typed.KubeWatchResult_DELETE, helper_snapshotDiffPayload("u4", 2, 0)},
+		// A resync only moves the resource version
+		{"Pod", "ns", "same", -30 * time.Minute, typed.KubeWatchResult_UPDATE, helper_snapshotDiffPayload("u5", 1, 0)},
+		{"Pod", "ns", "same", 10 * time.Minute, typed.KubeWatchResult_UPDATE, helper_snapshotDiffPayload("u5", 2, 0)},
+		{"Pod", "ns", "re", -30 * time.Minute, typed.KubeWatchResult_UPDATE, helper_snapshotDiffPayload("u6", 1, 0)},
+		{"Pod", "ns", "re", 10 * time.Minute, typed.KubeWatchResult_UPDATE, helper_snapshotDiffPayload("u7", 1, 0)},
+		// Left out, after the end, events and other namespaces
+		{"Pod", "ns", "later", 2 * time.Hour, typed.KubeWatchResult_ADD, helper_snapshotDiffPayload("u8", 1, 0)},
+		{"Event", "ns", "e1", 10 * time.Minute, typed.KubeWatchResult_ADD, `{"reason":"Scheduled"}`},
+		{"Pod", "other", "p", 10 * time.Minute, typed.KubeWatchResult_ADD, helper_snapshotDiffPayload("u9", 1, 0)},
+	}
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		for _, result := range results {
+			keyTs := someTs.Add(result.offset)
+			key := typed.NewWatchTableKey(untyped.GetPartitionId(keyTs), result.kind, result.namespace, result.name, keyTs)
+			ts, _ := ptypes.TimestampProto(keyTs)
+			err := tables.WatchTable().Set(txn, key.String(), &typed.KubeWatchResult{Kind: result.kind, Timestamp: ts, WatchType: result.watchType, Payload: result.payload})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+
+	values := helper_get_params()
+	values[NamespaceParam] = []string{"ns"}
+	data, err := GetSnapshotDiff(values, tables, someTs, someTs.Add(time.Hour), someRequestId)
+	assert.Nil(t, err)
+	output := SnapshotDiffOutput{}
+	assert.Nil(t, json.Unmarshal(data, &output))
+
+	assert.Equal(t, someTs.Unix(), output.From)
+	assert.Len(t, output.Added, 1)
+	assert.Equal(t, "new", output.Added[0].Name)
+	assert.Equal(t, "u3", output.Added[0].Uid)
+	assert.Len(t, output.Removed, 1)
+	assert.Equal(t, "old", output.Removed[0].Name)
+	assert.Len(t, output.Transient, 1)
+	assert.Equal(t, "temp", output.Transient[0].Name)
+	assert.Equal(t, 2, output.Transient[0].Updates)
+	assert.Equal(t, 1, output.Unchanged)
+
+	assert.Len(t, output.Changed, 2)
+	assert.Equal(t, "Deployment", output.Changed[0].Kind)
+	assert.Equal(t, []string{"spec.replicas"}, output.Changed[0].ChangedPaths)
+	assert.Equal(t, "0/2 ready, 0 up to date", output.Changed[0].SummaryBefore)
+	assert.Equal(t, "0/3 ready, 0 up to date", output.Changed[0].SummaryAfter)
+	assert.Equal(t, 1, output.Changed[0].Updates)
+	assert.Equal(t, "re", output.Changed[1].Name)
+	assert.True(t, output.Changed[1].Recreated)
+	assert.Equal(t, "u7", output.Changed[1].Uid)
+}
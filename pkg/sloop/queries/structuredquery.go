@@ -0,0 +1,398 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+const (
+	StructuredSourceCurrent = "current"
+	StructuredSourceHistory = "history"
+
+	// Count of the rows of a group when there is a groupBy
+	structuredCountField = "count"
+)
+
+/*
+A query over resources for callers that need more than the params of the other queries.  It can be sent as json or
+written in the query language, see ParseStructuredQuery.  Without a time range it reads the current state of every
+resource, with one the last version of each resource that has watch results in the time range
+*/
+type StructuredQuery struct {
+	// Empty matches all of them
+	Kinds      []string `json:"kinds,omitempty"`
+	Namespaces []string `json:"namespaces,omitempty"`
+	Name       string   `json:"name,omitempty"`
+	NameMatch  string   `json:"nameMatch,omitempty"`
+	// Kubernetes label selector, like app=web,tier in (a,b)
+	Labels string `json:"labels,omitempty"`
+	// Same as the lookback, start_time and end_time params of the other queries
+	Lookback  string `json:"lookback,omitempty"`
+	StartTime string `json:"startTime,omitempty"`
+	EndTime   string `json:"endTime,omitempty"`
+	// Resources whose last version in the time range is a delete are left out unless this is set
+	IncludeDeleted bool `json:"includeDeleted,omitempty"`
+	// Fields returned for each resource, like kind, name, summary or a payload path like status.phase.  Empty is
+	// kind, namespace, name and summary
+	Select []string `json:"select,omitempty"`
+	// Fields to count resources by, which makes every row a group with the fields and a count
+	GroupBy []string `json:"groupBy,omitempty"`
+	// Rows returned, 0 = all
+	Limit int `json:"limit,omitempty"`
+}
+
+type StructuredQueryOutput struct {
+	Source string `json:"source"`
+	// Unix seconds of the time range, for history
+	From int64                    `json:"from,omitempty"`
+	To   int64                    `json:"to,omitempty"`
+	Rows []map[string]interface{} `json:"rows"`
+	// Rows before the limit
+	Total int `json:"total"`
+	// Set when the time range starts before the oldest retained data or was cut to the max look back, see
+	// GetTimeRangeWarning
+	RetentionWarning string `json:"retentionWarning,omitempty"`
+}
+
+type structuredResource struct {
+	kind        string
+	namespace   string
+	name        string
+	uid         string
+	lastUpdated int64
+	deleted     bool
+	payload     string
+}
+
+func (q StructuredQuery) hasTimeRange() bool {
+	return q.Lookback != "" || q.StartTime != "" || q.EndTime != ""
+}
+
+func (q StructuredQuery) Validate() error {
+	_, err := labels.Parse(q.Labels)
+	if err != nil {
+		return errors.Wrap(err, "invalid label selector")
+	}
+	if q.Limit < 0 {
+		return NewApiError(ErrorCodeBadParams, "limit can not be < 0")
+	}
+	if len(q.GroupBy) > 0 && len(q.Select) > 0 {
+		return NewApiError(ErrorCodeBadParams, "select and groupBy can not be used together, the rows of groupBy are the groups")
+	}
+	for _, field := range append(append([]string{}, q.Select...), q.GroupBy...) {
+		if field == "" || strings.HasPrefix(field, ".") || strings.HasSuffix(field, ".") {
+			return NewApiError(ErrorCodeBadParams, "invalid field %q", field)
+		}
+	}
+	return nil
+}
+
+/*
+Runs a structured query with the same filters the other queries use, on the current state table without a time range
+and on the watch table with one.  Events are left out unless they are one of the kinds.  Rows are sorted by kind,
+namespace and name, or by count for groups
+*/
+func RunStructuredQuery(query StructuredQuery, tables typed.Tables, maxLookBack time.Duration, requestId string) ([]byte, error) {
+	err := query.Validate()
+	if err != nil {
+		return nil, err
+	}
+	selector, _ := labels.Parse(query.Labels)
+
+	output := StructuredQueryOutput{Source: StructuredSourceCurrent, Rows: []map[string]interface{}{}}
+	var resources []structuredResource
+	if query.hasTimeRange() {
+		params := url.Values{}
+		for key, value := range map[string]string{LookbackParam: query.Lookback, StartTimeParam: query.StartTime, EndTimeParam: query.EndTime} {
+			if value != "" {
+				params.Set(key, value)
+			}
+		}
+		startTime, endTime, err := computeTimeRange(params, tables, maxLookBack)
+		if err != nil {
+			return nil, err
+		}
+		output.Source = StructuredSourceHistory
+		output.From = startTime.Unix()
+		output.To = endTime.Unix()
+		_, output.RetentionWarning, err = GetTimeRangeWarning(tables, params, startTime, maxLookBack)
+		if err != nil {
+			return nil, err
+		}
+		resources, err = readStructuredHistory(query, tables, startTime, endTime, requestId)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		resources, err = readStructuredCurrent(query, tables)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	groups := map[string]map[string]interface{}{}
+	for _, resource := range resources {
+		if resource.deleted && !query.IncludeDeleted {
+			continue
+		}
+		object := map[string]interface{}{}
+		err := json.Unmarshal([]byte(resource.payload), &object)
+		if err != nil {
+			glog.Errorf("reqId: %v Failed to parse payload of %v %v/%v: %v", requestId, resource.kind, resource.namespace, resource.name, err)
+			continue
+		}
+		if !selector.Empty() && !selector.Matches(labels.Set(structuredLabels(object))) {
+			continue
+		}
+
+		if len(query.GroupBy) == 0 {
+			output.Rows = append(output.Rows, structuredRow(resource, object, query.Select))
+			continue
+		}
+		row := structuredRow(resource, object, query.GroupBy)
+		groupKey, _ := json.Marshal(row)
+		if group, ok := groups[string(groupKey)]; ok {
+			group[structuredCountField] = group[structuredCountField].(int) + 1
+		} else {
+			row[structuredCountField] = 1
+			groups[string(groupKey)] = row
+		}
+	}
+	for _, group := range groups {
+		output.Rows = append(output.Rows, group)
+	}
+	sortStructuredRows(output.Rows, len(query.GroupBy) > 0)
+
+	output.Total = len(output.Rows)
+	if query.Limit > 0 && len(output.Rows) > query.Limit {
+		output.Rows = output.Rows[:query.Limit]
+	}
+	bytes, err := json.MarshalIndent(output, "", " ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal json %v", err)
+	}
+	return bytes, nil
+}
+
+func (q StructuredQuery) keepResource(kind string, namespace string, name string) bool {
+	if len(q.Kinds) == 0 && kind == kubeextractor.EventKind {
+		return false
+	}
+	kinds := q.Kinds
+	if len(kinds) == 0 {
+		kinds = []string{AllKinds}
+	}
+	namespaces := q.Namespaces
+	if len(namespaces) == 0 {
+		namespaces = []string{AllNamespaces}
+	}
+	for _, selectedKind := range kinds {
+		for _, selectedNamespace := range namespaces {
+			if keepRowHelper(name, kind, namespace, selectedKind, selectedNamespace, q.NameMatch, q.Name, "", "") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// A key prefix can only be used for a single kind
+func (q StructuredQuery) singleKind() string {
+	if len(q.Kinds) == 1 {
+		return q.Kinds[0]
+	}
+	return AllKinds
+}
+
+func (q StructuredQuery) singleNamespace() string {
+	if len(q.Namespaces) == 1 {
+		return q.Namespaces[0]
+	}
+	return AllNamespaces
+}
+
+func readStructuredCurrent(query StructuredQuery, tables typed.Tables) ([]structuredResource, error) {
+	var states map[typed.CurrentStateKey]*typed.CurrentState
+	err := tables.Db().View(func(txn badgerwrap.Txn) error {
+		kind, namespace := getCurrentStatePrefix(query.singleKind(), query.singleNamespace())
+		var err error
+		states, err = tables.CurrentStateTable().List(txn, kind, namespace)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	resources := []structuredResource{}
+	for key, state := range states {
+		if !query.keepResource(key.Kind, key.Namespace, key.Name) {
+			continue
+		}
+		resources = append(resources, structuredResource{
+			kind:        key.Kind,
+			namespace:   key.Namespace,
+			name:        key.Name,
+			uid:         key.Uid,
+			lastUpdated: state.GetTimestamp().GetSeconds(),
+			payload:     state.Payload,
+		})
+	}
+	return resources, nil
+}
+
+func readStructuredHistory(query StructuredQuery, tables typed.Tables, startTime time.Time, endTime time.Time, requestId string) ([]structuredResource, error) {
+	latest := map[snapshotDiffId]typed.WatchTableKey{}
+	var records map[typed.WatchTableKey]*typed.KubeWatchResult
+	err := tables.Db().View(func(txn badgerwrap.Txn) error {
+		keyPredicate := func(key string) bool {
+			k := &typed.WatchTableKey{}
+			err := k.Parse(key)
+			if err != nil {
+				return false
+			}
+			return query.keepResource(k.Kind, k.Namespace, k.Name)
+		}
+		valPredFn := typed.KubeWatchResult_ValPredicateFns(isResPayloadInTimeRange(startTime, endTime))
+		var stats typed.RangeReadStats
+		var err error
+		records, stats, err = tables.WatchTable().RangeRead(txn, getSnapshotDiffKeyPrefix(query.singleKind(), query.singleNamespace()), keyPredicate, valPredFn, startTime, endTime)
+		stats.Log(requestId)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	for key := range records {
+		id := snapshotDiffId{kind: key.Kind, namespace: key.Namespace, name: key.Name}
+		if previous, ok := latest[id]; !ok || key.Timestamp.After(previous.Timestamp) {
+			latest[id] = key
+		}
+	}
+	resources := []structuredResource{}
+	for id, key := range latest {
+		result := records[key]
+		resources = append(resources, structuredResource{
+			kind:        id.kind,
+			namespace:   id.namespace,
+			name:        id.name,
+			uid:         snapshotDiffUid(result.Payload),
+			lastUpdated: key.Timestamp.Unix(),
+			deleted:     result.WatchType == typed.KubeWatchResult_DELETE,
+			payload:     result.Payload,
+		})
+	}
+	return resources, nil
+}
+
+func structuredLabels(object map[string]interface{}) map[string]string {
+	ret := map[string]string{}
+	value, _ := structuredPath(object, "metadata.labels")
+	if labelMap, ok := value.(map[string]interface{}); ok {
+		for key, value := range labelMap {
+			ret[key] = fmt.Sprint(value)
+		}
+	}
+	return ret
+}
+
+func structuredRow(resource structuredResource, object map[string]interface{}, fields []string) map[string]interface{} {
+	if len(fields) == 0 {
+		fields = []string{"kind", "namespace", "name", "summary"}
+	}
+	row := map[string]interface{}{}
+	for _, field := range fields {
+		switch field {
+		case "kind":
+			row[field] = resource.kind
+		case "namespace":
+			row[field] = resource.namespace
+		case "name":
+			row[field] = resource.name
+		case "uid":
+			row[field] = resource.uid
+		case "lastUpdated":
+			row[field] = resource.lastUpdated
+		case "deleted":
+			row[field] = resource.deleted
+		case "summary":
+			row[field] = summarizePayload(resource.kind, resource.payload)
+		default:
+			// Missing paths are null, so every row has the same fields
+			value, _ := structuredPath(object, field)
+			row[field] = value
+		}
+	}
+	return row
+}
+
+/*
+Walks a dotted path, like status.phase, through the payload.  Keys with dots, like the app.kubernetes.io/name label,
+are found by trying the longest key first: metadata.labels.app.kubernetes.io/name works.  Lists can be indexed with a
+number, like spec.containers.0.image
+*/
+func structuredPath(value interface{}, path string) (interface{}, bool) {
+	parts := strings.Split(path, ".")
+	for len(parts) > 0 {
+		found := false
+		switch typedValue := value.(type) {
+		case map[string]interface{}:
+			for idx := len(parts); idx > 0; idx-- {
+				if next, ok := typedValue[strings.Join(parts[:idx], ".")]; ok {
+					value = next
+					parts = parts[idx:]
+					found = true
+					break
+				}
+			}
+		case []interface{}:
+			var index int
+			_, err := fmt.Sscanf(parts[0], "%d", &index)
+			if err == nil && fmt.Sprint(index) == parts[0] && index >= 0 && index < len(typedValue) {
+				value = typedValue[index]
+				parts = parts[1:]
+				found = true
+			}
+		}
+		if !found {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+func sortStructuredRows(rows []map[string]interface{}, byCount bool) {
+	sortKey := func(row map[string]interface{}) string {
+		key, _ := json.Marshal(row)
+		return string(key)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if byCount && rows[i][structuredCountField] != rows[j][structuredCountField] {
+			return rows[i][structuredCountField].(int) > rows[j][structuredCountField].(int)
+		}
+		for _, field := range []string{"kind", "namespace", "name"} {
+			a, b := fmt.Sprint(rows[i][field]), fmt.Sprint(rows[j][field])
+			if a != b {
+				return a < b
+			}
+		}
+		return sortKey(rows[i]) < sortKey(rows[j])
+	})
+}
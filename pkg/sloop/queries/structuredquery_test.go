@@ -0,0 +1,168 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+func helper_structuredPodPayload(name string, app string, phase string) string {
+	return fmt.Sprintf(`{"metadata":{"name":"%v","uid":"uid-%v","labels":{"app.kubernetes.io/name":"%v"}},"spec":{"containers":[{"image":"%v:1"}]},"status":{"phase":"%v"}}`, name, name, app, app, phase)
+}
+
+func helper_getStructuredTables(t *testing.T) typed.Tables {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	partition := untyped.GetPartitionId(someTs)
+	resources := []struct {
+		kind      string
+		namespace string
+		name      string
+		offset    time.Duration
+		watchType typed.KubeWatchResult_WatchType
+		payload   string
+	}{
+		{"Pod", "ns", "web-1", 10 * time.Minute, typed.KubeWatchResult_ADD, helper_structuredPodPayload("web-1", "web", "Pending")},
+		{"Pod", "ns", "web-1", 20 * time.Minute, typed.KubeWatchResult_UPDATE, helper_structuredPodPayload("web-1", "web", "Running")},
+		{"Pod", "ns", "web-2", 10 * time.Minute, typed.KubeWatchResult_ADD, helper_structuredPodPayload("web-2", "web", "Running")},
+		{"Pod", "ns", "db-1", 10 * time.Minute, typed.KubeWatchResult_ADD, helper_structuredPodPayload("db-1", "db", "Failed")},
+		{"Pod", "other", "web-3", 10 * time.Minute, typed.KubeWatchResult_ADD, helper_structuredPodPayload("web-3", "web", "Running")},
+		{"Pod", "other", "gone", 10 * time.Minute, typed.KubeWatchResult_DELETE, helper_structuredPodPayload("gone", "web", "Running")},
+		{"Event", "ns", "e1", 10 * time.Minute, typed.KubeWatchResult_ADD, `{"metadata":{"name":"e1"},"reason":"Scheduled"}`},
+	}
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		for _, resource := range resources {
+			keyTs := someTs.Add(resource.offset)
+			ts, _ := ptypes.TimestampProto(keyTs)
+			key := typed.NewWatchTableKey(partition, resource.kind, resource.namespace, resource.name, keyTs)
+			err := tables.WatchTable().Set(txn, key.String(), &typed.KubeWatchResult{Kind: resource.kind, Timestamp: ts, WatchType: resource.watchType, Payload: resource.payload})
+			if err != nil {
+				return err
+			}
+			if resource.watchType == typed.KubeWatchResult_DELETE {
+				continue
+			}
+			stateKey := typed.NewCurrentStateKey(partition, resource.kind, resource.namespace, resource.name, "uid-"+resource.name)
+			err = tables.CurrentStateTable().Set(txn, stateKey.String(), &typed.CurrentState{Timestamp: ts, Payload: resource.payload})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+	return tables
+}
+
+func helper_runStructuredQuery(t *testing.T, tables typed.Tables, text string) StructuredQueryOutput {
+	query, err := ParseStructuredQuery(text)
+	assert.Nil(t, err)
+	data, err := RunStructuredQuery(query, tables, 24*time.Hour, someRequestId)
+	assert.Nil(t, err)
+	output := StructuredQueryOutput{}
+	assert.Nil(t, json.Unmarshal(data, &output))
+	return output
+}
+
+func Test_RunStructuredQuery_Current(t *testing.T) {
+	tables := helper_getStructuredTables(t)
+
+	output := helper_runStructuredQuery(t, tables, `kind=Pod labels="app.kubernetes.io/name=web" | select namespace,name,status.phase,spec.containers.0.image`)
+	assert.Equal(t, StructuredSourceCurrent, output.Source)
+	assert.Equal(t, []map[string]interface{}{
+		{"namespace": "ns", "name": "web-1", "status.phase": "Running", "spec.containers.0.image": "web:1"},
+		{"namespace": "ns", "name": "web-2", "status.phase": "Running", "spec.containers.0.image": "web:1"},
+		{"namespace": "other", "name": "web-3", "status.phase": "Running", "spec.containers.0.image": "web:1"},
+	}, output.Rows)
+	assert.Equal(t, 3, output.Total)
+
+	// Events are only there when asked for
+	output = helper_runStructuredQuery(t, tables, `namespace=ns | select kind,name,metadata.labels.app.kubernetes.io/name | limit 2`)
+	assert.Equal(t, []map[string]interface{}{
+		{"kind": "Pod", "name": "db-1", "metadata.labels.app.kubernetes.io/name": "db"},
+		{"kind": "Pod", "name": "web-1", "metadata.labels.app.kubernetes.io/name": "web"},
+	}, output.Rows)
+	assert.Equal(t, 3, output.Total)
+
+	output = helper_runStructuredQuery(t, tables, `kind=Pod | count by status.phase`)
+	assert.Equal(t, []map[string]interface{}{
+		{"status.phase": "Running", "count": float64(3)},
+		{"status.phase": "Failed", "count": float64(1)},
+	}, output.Rows)
+}
+
+func Test_RunStructuredQuery_History(t *testing.T) {
+	tables := helper_getStructuredTables(t)
+	timeRange := fmt.Sprintf("start_time=%v end_time=%v", someTs.Unix(), someTs.Add(15*time.Minute).Unix())
+
+	// The last version in the time range, before web-1 was running
+	output := helper_runStructuredQuery(t, tables, "kind=Pod namespace=ns namematch=web "+timeRange+" | select name,status.phase")
+	assert.Equal(t, StructuredSourceHistory, output.Source)
+	assert.Equal(t, someTs.Unix(), output.From)
+	assert.Equal(t, []map[string]interface{}{
+		{"name": "web-1", "status.phase": "Pending"},
+		{"name": "web-2", "status.phase": "Running"},
+	}, output.Rows)
+
+	output = helper_runStructuredQuery(t, tables, "kind=Pod namespace=other "+timeRange+" | select name,deleted")
+	assert.Equal(t, []map[string]interface{}{{"name": "web-3", "deleted": false}}, output.Rows)
+	output = helper_runStructuredQuery(t, tables, "kind=Pod namespace=other "+timeRange+" | select name,deleted | deleted")
+	assert.Equal(t, []map[string]interface{}{{"name": "gone", "deleted": true}, {"name": "web-3", "deleted": false}}, output.Rows)
+
+	output = helper_runStructuredQuery(t, tables, "kind=Pod,Event "+timeRange+" | count by kind,namespace")
+	assert.Equal(t, []map[string]interface{}{
+		{"kind": "Pod", "namespace": "ns", "count": float64(3)},
+		{"kind": "Event", "namespace": "ns", "count": float64(1)},
+		{"kind": "Pod", "namespace": "other", "count": float64(1)},
+	}, output.Rows)
+}
+
+func Test_ParseStructuredQuery(t *testing.T) {
+	query, err := ParseStructuredQuery(`kind=Pod,Deployment namespace=a labels="app in (x, y),tier!=db" lookback=1h | select name, status.phase | limit 10 | deleted`)
+	assert.Nil(t, err)
+	assert.Equal(t, StructuredQuery{
+		Kinds:          []string{"Pod", "Deployment"},
+		Namespaces:     []string{"a"},
+		Labels:         "app in (x, y),tier!=db",
+		Lookback:       "1h",
+		Select:         []string{"name", "status.phase"},
+		Limit:          10,
+		IncludeDeleted: true,
+	}, query)
+
+	query, err = ParseStructuredQuery(`name="a|b" | count by namespace`)
+	assert.Nil(t, err)
+	assert.Equal(t, StructuredQuery{Name: "a|b", GroupBy: []string{"namespace"}}, query)
+
+	for _, bad := range []string{
+		`kind`,
+		`color=red`,
+		`name="unterminated`,
+		`kind=Pod | sort name`,
+		`kind=Pod | limit many`,
+		`labels="app in"`,
+		`kind=Pod | select name | count by namespace`,
+		`kind=Pod |`,
+	} {
+		_, err = ParseStructuredQuery(bad)
+		assert.NotNil(t, err, bad)
+	}
+}
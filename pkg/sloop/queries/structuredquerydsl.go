@@ -0,0 +1,156 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"strconv"
+	"strings"
+)
+
+/*
+Parses the query language of structured queries, which is filters followed by stages separated by |
+
+	kind=Pod,Deployment namespace=web labels="app=web,tier in (a,b)" lookback=1h | select name,status.phase | limit 10
+	kind=Pod | count by namespace,status.phase
+
+Filters are kind, namespace, name, namematch, labels, lookback, start_time and end_time, with values that are quoted
+when they have spaces.  Stages are select, count by, limit and deleted, which keeps deleted resources
+*/
+func ParseStructuredQuery(text string) (StructuredQuery, error) {
+	query := StructuredQuery{}
+	stages, err := splitStructuredStages(text)
+	if err != nil {
+		return query, err
+	}
+	filters, err := parseStructuredFilters(stages[0])
+	if err != nil {
+		return query, err
+	}
+	for _, filter := range filters {
+		switch filter[0] {
+		case KindParam:
+			query.Kinds = splitStructuredList(filter[1])
+		case NamespaceParam:
+			query.Namespaces = splitStructuredList(filter[1])
+		case NameParam:
+			query.Name = filter[1]
+		case NameMatchParam:
+			query.NameMatch = filter[1]
+		case "labels":
+			query.Labels = filter[1]
+		case LookbackParam:
+			query.Lookback = filter[1]
+		case StartTimeParam:
+			query.StartTime = filter[1]
+		case EndTimeParam:
+			query.EndTime = filter[1]
+		default:
+			return query, NewApiError(ErrorCodeBadParams, "unknown filter %q", filter[0])
+		}
+	}
+
+	for _, stage := range stages[1:] {
+		words := strings.Fields(stage)
+		if len(words) == 0 {
+			return query, NewApiError(ErrorCodeBadParams, "empty stage")
+		}
+		switch {
+		case words[0] == "select" && len(words) > 1:
+			query.Select = splitStructuredList(strings.Join(words[1:], ""))
+		case words[0] == "count" && len(words) > 2 && words[1] == "by":
+			query.GroupBy = splitStructuredList(strings.Join(words[2:], ""))
+		case words[0] == "limit" && len(words) == 2:
+			query.Limit, err = strconv.Atoi(words[1])
+			if err != nil {
+				return query, NewApiError(ErrorCodeBadParams, "invalid limit %q", words[1])
+			}
+		case words[0] == "deleted" && len(words) == 1:
+			query.IncludeDeleted = true
+		default:
+			return query, NewApiError(ErrorCodeBadParams, "unknown stage %q, expected select, count by, limit or deleted", strings.TrimSpace(stage))
+		}
+	}
+	return query, query.Validate()
+}
+
+// Splits on the | that are not in quotes
+func splitStructuredStages(text string) ([]string, error) {
+	stages := []string{}
+	current := strings.Builder{}
+	quoted := false
+	escaped := false
+	for _, char := range text {
+		switch {
+		case escaped:
+			escaped = false
+		case quoted && char == '\\':
+			escaped = true
+		case char == '"':
+			quoted = !quoted
+		case char == '|' && !quoted:
+			stages = append(stages, current.String())
+			current.Reset()
+			continue
+		}
+		current.WriteRune(char)
+	}
+	if quoted {
+		return nil, NewApiError(ErrorCodeBadParams, "unterminated quote")
+	}
+	return append(stages, current.String()), nil
+}
+
+// Returns key, value pairs, with the quotes taken off values
+func parseStructuredFilters(text string) ([][2]string, error) {
+	filters := [][2]string{}
+	rest := strings.TrimSpace(text)
+	for rest != "" {
+		equals := strings.Index(rest, "=")
+		if equals <= 0 || strings.ContainsAny(rest[:equals], " \t\"") {
+			return nil, NewApiError(ErrorCodeBadParams, "expected key=value at %q", rest)
+		}
+		key := rest[:equals]
+		rest = rest[equals+1:]
+		var value string
+		if strings.HasPrefix(rest, "\"") {
+			end := 1
+			for end < len(rest) && rest[end] != '"' {
+				if rest[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			unquoted, err := strconv.Unquote(rest[:end+1])
+			if err != nil {
+				return nil, NewApiError(ErrorCodeBadParams, "invalid quoted value for %v", key)
+			}
+			value = unquoted
+			rest = rest[end+1:]
+		} else {
+			end := strings.IndexAny(rest, " \t")
+			if end < 0 {
+				end = len(rest)
+			}
+			value = rest[:end]
+			rest = rest[end:]
+		}
+		filters = append(filters, [2]string{key, value})
+		rest = strings.TrimSpace(rest)
+	}
+	return filters, nil
+}
+
+func splitStructuredList(value string) []string {
+	ret := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			ret = append(ret, item)
+		}
+	}
+	return ret
+}
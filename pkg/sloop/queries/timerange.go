@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"github.com/golang/glog"
 	"github.com/golang/protobuf/ptypes"
+	"github.com/salesforce/sloop/pkg/sloop/common"
 	"github.com/salesforce/sloop/pkg/sloop/store/typed"
 	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
 	"net/url"
@@ -24,14 +25,14 @@ const minLookback = 1 * time.Minute
 //
 // Using "lookback":
 //
-//   We first find the endTime.  If we are looking at historic data, we use the end of the last partitions.  If
-//   that is in the future, we use now().  We don't want to always use now() as that would prevent users from looking
-//   at old data as it would get clipped by maxLookBack
-//   StartTime is just endTime - lookback
+//	We first find the endTime.  If we are looking at historic data, we use the end of the last partitions.  If
+//	that is in the future, we use now().  We don't want to always use now() as that would prevent users from looking
+//	at old data as it would get clipped by maxLookBack
+//	StartTime is just endTime - lookback
 //
 // Using "start_time" and "end_time"
 //
-//   This is straight forward.  These are UTC Unix times
+//	This is straight forward.  These are UTC Unix times
 //
 // TODO: If wall clock is in the middle of the newest partition min-max time we can use it
 func computeTimeRange(params url.Values, tables typed.Tables, maxLookBack time.Duration) (time.Time, time.Time, error) {
@@ -39,6 +40,11 @@ func computeTimeRange(params url.Values, tables typed.Tables, maxLookBack time.D
 	return computeTimeRangeInternal(params, endOfTime, maxLookBack)
 }
 
+// Exported for endpoints outside of queries that accept the same time range parameters
+func ComputeTimeRange(params url.Values, tables typed.Tables, maxLookBack time.Duration) (time.Time, time.Time, error) {
+	return computeTimeRange(params, tables, maxLookBack)
+}
+
 func computeTimeRangeInternal(params url.Values, endOfTime time.Time, maxLookBack time.Duration) (time.Time, time.Time, error) {
 	lookBackVal := params.Get(LookbackParam)
 	startTimeVal := params.Get(StartTimeParam)
@@ -50,15 +56,15 @@ func computeTimeRangeInternal(params url.Values, endOfTime time.Time, maxLookBac
 
 	// Input validations
 	if startTimeVal == "" && endTimeVal == "" && lookBackVal == "" {
-		return time.Time{}, time.Time{}, fmt.Errorf("Time range must be set with either [%v] or both of [%v,%v] but all 3 were empty", LookbackParam, StartTimeParam, EndTimeParam)
+		return time.Time{}, time.Time{}, NewApiError(ErrorCodeBadParams, "Time range must be set with either [%v] or both of [%v,%v] but all 3 were empty", LookbackParam, StartTimeParam, EndTimeParam)
 	}
 	if lookBackVal != "" {
 		if startTimeVal != "" {
-			return time.Time{}, time.Time{}, fmt.Errorf("When [%v] is set, you can not set both of [%v,%v] or set only [%v].  Got (%v,%v,%v) respectively", LookbackParam, StartTimeParam, EndTimeParam, StartTimeParam, lookBackVal, startTimeVal, endTimeVal)
+			return time.Time{}, time.Time{}, NewApiError(ErrorCodeBadParams, "When [%v] is set, you can not set both of [%v,%v] or set only [%v].  Got (%v,%v,%v) respectively", LookbackParam, StartTimeParam, EndTimeParam, StartTimeParam, lookBackVal, startTimeVal, endTimeVal)
 		}
 	} else {
 		if (startTimeVal == "") || (endTimeVal == "") {
-			return time.Time{}, time.Time{}, fmt.Errorf("Either %v and %v both need to be set or neither set.  Got (%v,%v) respectively", StartTimeParam, EndTimeParam, startTimeVal, endTimeVal)
+			return time.Time{}, time.Time{}, NewApiError(ErrorCodeBadParams, "Either %v and %v both need to be set or neither set.  Got (%v,%v) respectively", StartTimeParam, EndTimeParam, startTimeVal, endTimeVal)
 		}
 	}
 
@@ -71,18 +77,18 @@ func computeTimeRangeInternal(params url.Values, endOfTime time.Time, maxLookBac
 			//startTimeVal == "" && endTimeVal != "" && lookBackVal != ""
 			computedEnd, err = parseTimestampString(endTimeVal)
 			if err != nil {
-				return time.Time{}, time.Time{}, err
+				return time.Time{}, time.Time{}, NewApiError(ErrorCodeBadParams, "invalid %v: %v", EndTimeParam, err)
 			}
 		}
 		lookbackRange, err := getDurationFromLookback(lookBackVal)
 		if err != nil {
-			return time.Time{}, time.Time{}, err
+			return time.Time{}, time.Time{}, NewApiError(ErrorCodeBadParams, "invalid %v: %v", LookbackParam, err)
 		}
 		computedStart = computedEnd.Add(-1 * lookbackRange)
 	} else {
 		computedStart, computedEnd, err = getTimeRangeFromStartEnd(startTimeVal, endTimeVal)
 		if err != nil {
-			return time.Time{}, time.Time{}, err
+			return time.Time{}, time.Time{}, NewApiError(ErrorCodeBadParams, "invalid %v or %v: %v", StartTimeParam, EndTimeParam, err)
 		}
 	}
 
@@ -105,11 +111,30 @@ func computeTimeRangeInternal(params url.Values, endOfTime time.Time, maxLookBac
 
 }
 
+// Returns a warning when computeTimeRangeInternal cut the time range of params to maxLookBack and it starts at
+// startTime instead, empty otherwise
+func getTimeRangeClampWarning(params url.Values, startTime time.Time, maxLookBack time.Duration) string {
+	var requested time.Duration
+	if lookBackVal := params.Get(LookbackParam); lookBackVal != "" {
+		requested, _ = getDurationFromLookback(lookBackVal)
+	} else {
+		start, end, err := getTimeRangeFromStartEnd(params.Get(StartTimeParam), params.Get(EndTimeParam))
+		if err != nil {
+			return ""
+		}
+		requested = end.Sub(start)
+	}
+	if requested <= maxLookBack {
+		return ""
+	}
+	return fmt.Sprintf("the time range was cut to the max look back of %v, data before %v is left out", maxLookBack, startTime.UTC().Format(retentionTimeLayout))
+}
+
 // This looks at our store, and if it has data finds the newest partition, then finds the end time of that
 // But if that is in the future we return now
 // This bit of logic is needed for queries with a lookback to determine a good end time
 func getEndOfTime(tables typed.Tables) time.Time {
-	now := time.Now()
+	now := common.Now()
 
 	ok, _, maxPartition, err := tables.GetMinAndMaxPartition()
 	if err != nil || !ok {
@@ -256,6 +281,7 @@ func timeFilterWatchActivityOccurrences(occurrences []int64, queryStartTime time
 func timeFilterWatchActivity(activity *typed.WatchActivity, queryStartTime time.Time, queryEndTime time.Time) *typed.WatchActivity {
 	activity.ChangedAt = timeFilterWatchActivityOccurrences(activity.ChangedAt, queryStartTime, queryEndTime)
 	activity.NoChangeAt = timeFilterWatchActivityOccurrences(activity.NoChangeAt, queryStartTime, queryEndTime)
+	activity.MissedAt = timeFilterWatchActivityOccurrences(activity.MissedAt, queryStartTime, queryEndTime)
 	return activity
 }
 
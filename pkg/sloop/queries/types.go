@@ -10,18 +10,31 @@ package queries
 type TimelineRoot struct {
 	ViewOpt ViewOptions   `json:"view_options"`
 	Rows    []TimelineRow `json:"rows"`
+	// Times where ingestion had problems, so rows may be missing updates
+	IngestAnnotations []IngestAnnotationOutput `json:"ingestAnnotations,omitempty"`
+	// Shards that failed, so their kinds are missing from the rows
+	ShardErrors []ShardErrorOutput `json:"shardErrors,omitempty"`
 }
 
 type TimelineRow struct {
-	Text       string    `json:"text"`
-	Duration   int64     `json:"duration"`
-	Kind       string    `json:"kind"`
-	Namespace  string    `json:"namespace"`
-	Overlays   []Overlay `json:"overlays"`
-	ChangedAt  []int64   `json:"changedat"`
-	NoChangeAt []int64   `json:"nochangeat"`
-	StartDate  int64     `json:"start_date"`
-	EndDate    int64     `json:"end_date"`
+	Text      string `json:"text"`
+	Duration  int64  `json:"duration"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	// Tells apart resources of a namespace that was deleted and created again with the same name
+	NamespaceUid string    `json:"namespaceUid,omitempty"`
+	Overlays     []Overlay `json:"overlays"`
+	ChangedAt    []int64   `json:"changedat"`
+	NoChangeAt   []int64   `json:"nochangeat"`
+	// Updates whose previous version was never stored
+	MissedAt     []int64              `json:"missedat,omitempty"`
+	StartDate    int64                `json:"start_date"`
+	EndDate      int64                `json:"end_date"`
+	Completeness *HistoryCompleteness `json:"completeness,omitempty"`
+	// Set with merge_shards, the row of each shard the merged row was made of
+	Lanes []TimelineRow `json:"lanes,omitempty"`
+	// Shard of a lane
+	Shard string `json:"shard,omitempty"`
 }
 
 type ViewOptions struct {
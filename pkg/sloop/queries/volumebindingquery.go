@@ -0,0 +1,322 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/glog"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+// The binding of a claim from the given time until the next change
+type ClaimBindingChange struct {
+	Timestamp    int64  `json:"timestamp"`
+	Phase        string `json:"phase"`
+	VolumeName   string `json:"volumeName,omitempty"`
+	StorageClass string `json:"storageClass,omitempty"`
+	Requested    string `json:"requested,omitempty"`
+	Capacity     string `json:"capacity,omitempty"`
+	Deleted      bool   `json:"deleted,omitempty"`
+}
+
+type ClaimBindingHistory struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// The first change is the binding at the start of the time range when it was already known
+	Changes []ClaimBindingChange `json:"changes"`
+}
+
+// The binding of a volume from the given time until the next change
+type VolumeBindingChange struct {
+	Timestamp      int64  `json:"timestamp"`
+	Phase          string `json:"phase"`
+	ClaimNamespace string `json:"claimNamespace,omitempty"`
+	ClaimName      string `json:"claimName,omitempty"`
+	StorageClass   string `json:"storageClass,omitempty"`
+	Capacity       string `json:"capacity,omitempty"`
+	ReclaimPolicy  string `json:"reclaimPolicy,omitempty"`
+	Deleted        bool   `json:"deleted,omitempty"`
+}
+
+type VolumeBindingHistory struct {
+	Name    string                `json:"name"`
+	Changes []VolumeBindingChange `json:"changes"`
+}
+
+type VolumeBindingOutput struct {
+	Claims  []ClaimBindingHistory  `json:"claims"`
+	Volumes []VolumeBindingHistory `json:"volumes"`
+}
+
+// Name of a resource in the watch table, without the partition and timestamp
+type watchResourceId struct {
+	namespace string
+	name      string
+}
+
+type watchRecord struct {
+	timestamp int64
+	result    *typed.KubeWatchResult
+}
+
+// Returns every change to the binding, phase and storage size of the PersistentVolumeClaims matching namespace,
+// name and namematch, and of the PersistentVolumes they were bound to.  When no claim filter is set all volumes are
+// returned.  Both are rebuilt from the watch table
+func GetVolumeBinding(params url.Values, t typed.Tables, startTime time.Time, endTime time.Time, requestId string) ([]byte, error) {
+	selectedNamespace := params.Get(NamespaceParam)
+	selectedNameMatch := params.Get(NameMatchParam)
+	selectedName := params.Get(NameParam)
+	filterClaims := selectedNamespace != AllNamespaces || selectedNameMatch != "" || selectedName != ""
+
+	output := VolumeBindingOutput{Claims: []ClaimBindingHistory{}, Volumes: []VolumeBindingHistory{}}
+	err := t.Db().View(func(txn badgerwrap.Txn) error {
+		keyPredicate := func(key string) bool {
+			k := &typed.WatchTableKey{}
+			err := k.Parse(key)
+			if err != nil {
+				return false
+			}
+			return keepRowHelper(k.Name, k.Kind, k.Namespace, kubeextractor.PersistentVolumeClaimKind, selectedNamespace, selectedNameMatch, selectedName, "", "")
+		}
+		claimRecords, stats, err := t.WatchTable().RangeRead(txn, getClaimKeyPrefix(params), keyPredicate, nil, startTime, endTime)
+		if err != nil {
+			return err
+		}
+		stats.Log(requestId)
+
+		claims := groupWatchRecords(claimRecords)
+		if selectedNamespace != AllNamespaces && selectedName != "" {
+			// The claim might not have changed within the time range
+			id := watchResourceId{namespace: selectedNamespace, name: selectedName}
+			if _, ok := claims[id]; !ok {
+				claims[id] = nil
+			}
+		}
+		err = addWatchRecordsBefore(txn, t, kubeextractor.PersistentVolumeClaimKind, claims, startTime)
+		if err != nil {
+			return err
+		}
+
+		// Volumes linked to the matched claims from either side
+		linkedVolumes := map[string]bool{}
+		linkedClaims := map[watchResourceId]bool{}
+		for id, records := range claims {
+			history := ClaimBindingHistory{Namespace: id.namespace, Name: id.name, Changes: claimBindingChanges(records, startTime, endTime)}
+			if len(history.Changes) == 0 {
+				continue
+			}
+			for _, change := range history.Changes {
+				if change.VolumeName != "" {
+					linkedVolumes[change.VolumeName] = true
+				}
+			}
+			linkedClaims[id] = true
+			output.Claims = append(output.Claims, history)
+		}
+
+		volumeKey := typed.NewWatchTableKeyComparator(kubeextractor.PersistentVolumeKind, "", "", time.Time{})
+		volumeRecords, stats, err := t.WatchTable().RangeRead(txn, volumeKey, nil, nil, startTime, endTime)
+		if err != nil {
+			return err
+		}
+		stats.Log(requestId)
+
+		volumes := groupWatchRecords(volumeRecords)
+		for name := range linkedVolumes {
+			id := watchResourceId{name: name}
+			if _, ok := volumes[id]; !ok {
+				volumes[id] = nil
+			}
+		}
+		err = addWatchRecordsBefore(txn, t, kubeextractor.PersistentVolumeKind, volumes, startTime)
+		if err != nil {
+			return err
+		}
+
+		for id, records := range volumes {
+			history := VolumeBindingHistory{Name: id.name, Changes: volumeBindingChanges(records, startTime, endTime)}
+			if len(history.Changes) == 0 {
+				continue
+			}
+			if filterClaims && !linkedVolumes[id.name] && !isVolumeBoundToAny(history.Changes, linkedClaims) {
+				continue
+			}
+			output.Volumes = append(output.Volumes, history)
+		}
+		return nil
+	})
+	if err != nil {
+		return []byte{}, err
+	}
+
+	sort.Slice(output.Claims, func(i, j int) bool {
+		if output.Claims[i].Namespace != output.Claims[j].Namespace {
+			return output.Claims[i].Namespace < output.Claims[j].Namespace
+		}
+		return output.Claims[i].Name < output.Claims[j].Name
+	})
+	sort.Slice(output.Volumes, func(i, j int) bool { return output.Volumes[i].Name < output.Volumes[j].Name })
+
+	bytes, err := json.MarshalIndent(output, "", " ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal json %v", err)
+	}
+	return bytes, nil
+}
+
+// Claims of one namespace are a single prefix, otherwise every key is checked with keepRowHelper
+func getClaimKeyPrefix(params url.Values) *typed.WatchTableKey {
+	selectedNamespace := params.Get(NamespaceParam)
+	if selectedNamespace == AllNamespaces {
+		return nil
+	}
+	return typed.NewWatchTableKeyComparator(kubeextractor.PersistentVolumeClaimKind, selectedNamespace, "", time.Time{})
+}
+
+func groupWatchRecords(results map[typed.WatchTableKey]*typed.KubeWatchResult) map[watchResourceId][]watchRecord {
+	grouped := map[watchResourceId][]watchRecord{}
+	for key, result := range results {
+		id := watchResourceId{namespace: key.Namespace, name: key.Name}
+		grouped[id] = append(grouped[id], watchRecord{timestamp: key.Timestamp.Unix(), result: result})
+	}
+	return grouped
+}
+
+// Adds the last watch result before the start time of each resource, so its state at the start time is known
+func addWatchRecordsBefore(txn badgerwrap.Txn, t typed.Tables, kind string, grouped map[watchResourceId][]watchRecord, startTime time.Time) error {
+	for id := range grouped {
+		keyComparator := typed.NewWatchTableKeyComparator(kind, id.namespace, id.name, time.Time{})
+		seekKey := typed.NewWatchTableKey(untyped.GetPartitionId(startTime), kind, id.namespace, id.name, startTime)
+		previousKey, err := t.WatchTable().GetPreviousKey(txn, seekKey, keyComparator)
+		if err != nil {
+			// Nothing was seen before the start time
+			continue
+		}
+		if !previousKey.Timestamp.Before(startTime) {
+			continue
+		}
+		previousVal, err := t.WatchTable().Get(txn, previousKey.String())
+		if err == badger.ErrKeyNotFound {
+			continue
+		} else if err != nil {
+			return err
+		}
+		grouped[id] = append(grouped[id], watchRecord{timestamp: previousKey.Timestamp.Unix(), result: previousVal})
+	}
+	for id := range grouped {
+		records := grouped[id]
+		sort.SliceStable(records, func(i, j int) bool { return records[i].timestamp < records[j].timestamp })
+	}
+	return nil
+}
+
+// Like transitionsInTimeRange the binding from before the start time is kept as the first change
+func claimBindingChanges(records []watchRecord, startTime time.Time, endTime time.Time) []ClaimBindingChange {
+	changes := []ClaimBindingChange{}
+	var before *ClaimBindingChange
+	for _, record := range records {
+		if record.timestamp > endTime.Unix() {
+			break
+		}
+		binding, err := kubeextractor.ExtractClaimBinding(record.result.Payload)
+		if err != nil {
+			glog.Errorf("Failed to extract claim binding: %v", err)
+			continue
+		}
+		change := ClaimBindingChange{
+			Timestamp:    record.timestamp,
+			Phase:        binding.Phase,
+			VolumeName:   binding.VolumeName,
+			StorageClass: binding.StorageClass,
+			Requested:    binding.Requested,
+			Capacity:     binding.Capacity,
+			Deleted:      record.result.WatchType == typed.KubeWatchResult_DELETE,
+		}
+		if change.Timestamp < startTime.Unix() {
+			before = &change
+			continue
+		}
+		if len(changes) == 0 && before != nil {
+			changes = append(changes, *before)
+		}
+		if len(changes) > 0 {
+			last := changes[len(changes)-1]
+			last.Timestamp = change.Timestamp
+			if last == change {
+				continue
+			}
+		}
+		changes = append(changes, change)
+	}
+	if len(changes) == 0 && before != nil {
+		changes = append(changes, *before)
+	}
+	return changes
+}
+
+func volumeBindingChanges(records []watchRecord, startTime time.Time, endTime time.Time) []VolumeBindingChange {
+	changes := []VolumeBindingChange{}
+	var before *VolumeBindingChange
+	for _, record := range records {
+		if record.timestamp > endTime.Unix() {
+			break
+		}
+		binding, err := kubeextractor.ExtractVolumeBinding(record.result.Payload)
+		if err != nil {
+			glog.Errorf("Failed to extract volume binding: %v", err)
+			continue
+		}
+		change := VolumeBindingChange{
+			Timestamp:      record.timestamp,
+			Phase:          binding.Phase,
+			ClaimNamespace: binding.ClaimNamespace,
+			ClaimName:      binding.ClaimName,
+			StorageClass:   binding.StorageClass,
+			Capacity:       binding.Capacity,
+			ReclaimPolicy:  binding.ReclaimPolicy,
+			Deleted:        record.result.WatchType == typed.KubeWatchResult_DELETE,
+		}
+		if change.Timestamp < startTime.Unix() {
+			before = &change
+			continue
+		}
+		if len(changes) == 0 && before != nil {
+			changes = append(changes, *before)
+		}
+		if len(changes) > 0 {
+			last := changes[len(changes)-1]
+			last.Timestamp = change.Timestamp
+			if last == change {
+				continue
+			}
+		}
+		changes = append(changes, change)
+	}
+	if len(changes) == 0 && before != nil {
+		changes = append(changes, *before)
+	}
+	return changes
+}
+
+func isVolumeBoundToAny(changes []VolumeBindingChange, claims map[watchResourceId]bool) bool {
+	for _, change := range changes {
+		if claims[watchResourceId{namespace: change.ClaimNamespace, name: change.ClaimName}] {
+			return true
+		}
+	}
+	return false
+}
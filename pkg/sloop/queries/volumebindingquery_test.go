@@ -0,0 +1,142 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+func helper_claimPayload(phase string, volume string, capacity string) string {
+	return fmt.Sprintf(`{"metadata": {"name": "data"}, "spec": {"volumeName": "%v", "resources": {"requests": {"storage": "1Gi"}}}, "status": {"phase": "%v", "capacity": {"storage": "%v"}}}`, volume, phase, capacity)
+}
+
+func helper_volumePayload(phase string, claimName string, capacity string) string {
+	return fmt.Sprintf(`{"metadata": {"name": "pv-1"}, "spec": {"claimRef": {"namespace": "ns", "name": "%v"}, "capacity": {"storage": "%v"}}, "status": {"phase": "%v"}}`, claimName, capacity, phase)
+}
+
+func helper_setWatchResults(t *testing.T, tables typed.Tables, results map[*typed.WatchTableKey]string) {
+	err := tables.Db().Update(func(txn badgerwrap.Txn) error {
+		for key, payload := range results {
+			ts, _ := ptypes.TimestampProto(key.Timestamp)
+			err := tables.WatchTable().Set(txn, key.String(), &typed.KubeWatchResult{Kind: key.Kind, Timestamp: ts, Payload: payload})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+}
+
+func Test_GetVolumeBinding_ClaimAndBoundVolume(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+
+	partitionId := untyped.GetPartitionId(someTs)
+	claimKind := kubeextractor.PersistentVolumeClaimKind
+	volumeKind := kubeextractor.PersistentVolumeKind
+	helper_setWatchResults(t, tables, map[*typed.WatchTableKey]string{
+		// Before the start time, kept as the first change
+		typed.NewWatchTableKey(partitionId, claimKind, "ns", "data", someTs.Add(-time.Minute)): helper_claimPayload("Pending", "", ""),
+		typed.NewWatchTableKey(partitionId, claimKind, "ns", "data", someTs.Add(time.Minute)):  helper_claimPayload("Bound", "pv-1", "1Gi"),
+		// No change
+		typed.NewWatchTableKey(partitionId, claimKind, "ns", "data", someTs.Add(2*time.Minute)): helper_claimPayload("Bound", "pv-1", "1Gi"),
+		typed.NewWatchTableKey(partitionId, claimKind, "ns", "data", someTs.Add(3*time.Minute)): helper_claimPayload("Bound", "pv-1", "2Gi"),
+		typed.NewWatchTableKey(partitionId, claimKind, "ns", "other", someTs.Add(time.Minute)):  helper_claimPayload("Bound", "pv-2", "1Gi"),
+		typed.NewWatchTableKey(partitionId, volumeKind, "", "pv-1", someTs.Add(time.Minute)):    helper_volumePayload("Bound", "data", "1Gi"),
+		typed.NewWatchTableKey(partitionId, volumeKind, "", "pv-1", someTs.Add(3*time.Minute)):  helper_volumePayload("Bound", "data", "2Gi"),
+		typed.NewWatchTableKey(partitionId, volumeKind, "", "pv-2", someTs.Add(time.Minute)):    helper_volumePayload("Bound", "other", "1Gi"),
+		typed.NewWatchTableKey(partitionId, volumeKind, "", "pv-3", someTs.Add(time.Minute)):    helper_volumePayload("Available", "", "5Gi"),
+	})
+
+	values := helper_get_params()
+	values[NamespaceParam] = []string{"ns"}
+	values[NameParam] = []string{"data"}
+	data, err := GetVolumeBinding(values, tables, someTs, someTs.Add(5*time.Minute), someRequestId)
+	assert.Nil(t, err)
+	output := VolumeBindingOutput{}
+	assert.Nil(t, json.Unmarshal(data, &output))
+
+	assert.Len(t, output.Claims, 1)
+	assert.Equal(t, "data", output.Claims[0].Name)
+	assert.Equal(t, []ClaimBindingChange{
+		{Timestamp: someTs.Add(-time.Minute).Unix(), Phase: "Pending", Requested: "1Gi"},
+		{Timestamp: someTs.Add(time.Minute).Unix(), Phase: "Bound", VolumeName: "pv-1", Requested: "1Gi", Capacity: "1Gi"},
+		{Timestamp: someTs.Add(3 * time.Minute).Unix(), Phase: "Bound", VolumeName: "pv-1", Requested: "1Gi", Capacity: "2Gi"},
+	}, output.Claims[0].Changes)
+
+	assert.Len(t, output.Volumes, 1)
+	assert.Equal(t, "pv-1", output.Volumes[0].Name)
+	assert.Len(t, output.Volumes[0].Changes, 2)
+	assert.Equal(t, "2Gi", output.Volumes[0].Changes[1].Capacity)
+}
+
+func Test_GetVolumeBinding_StateBeforeStartWithoutChanges(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+
+	// Both were last written in an earlier partition
+	earlier := someTs.Add(-2 * time.Hour)
+	partitionId := untyped.GetPartitionId(earlier)
+	helper_setWatchResults(t, tables, map[*typed.WatchTableKey]string{
+		typed.NewWatchTableKey(partitionId, kubeextractor.PersistentVolumeClaimKind, "ns", "data", earlier): helper_claimPayload("Bound", "pv-1", "1Gi"),
+		typed.NewWatchTableKey(partitionId, kubeextractor.PersistentVolumeKind, "", "pv-1", earlier):        helper_volumePayload("Bound", "data", "1Gi"),
+	})
+
+	values := helper_get_params()
+	values[NamespaceParam] = []string{"ns"}
+	values[NameParam] = []string{"data"}
+	data, err := GetVolumeBinding(values, tables, someTs, someTs.Add(5*time.Minute), someRequestId)
+	assert.Nil(t, err)
+	output := VolumeBindingOutput{}
+	assert.Nil(t, json.Unmarshal(data, &output))
+	assert.Len(t, output.Claims, 1)
+	assert.Equal(t, []ClaimBindingChange{{Timestamp: earlier.Unix(), Phase: "Bound", VolumeName: "pv-1", Requested: "1Gi", Capacity: "1Gi"}}, output.Claims[0].Changes)
+	assert.Len(t, output.Volumes, 1)
+	assert.Equal(t, "data", output.Volumes[0].Changes[0].ClaimName)
+}
+
+func Test_GetVolumeBinding_AllNamespacesReturnsAllVolumes(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+
+	partitionId := untyped.GetPartitionId(someTs)
+	helper_setWatchResults(t, tables, map[*typed.WatchTableKey]string{
+		typed.NewWatchTableKey(partitionId, kubeextractor.PersistentVolumeClaimKind, "ns", "data", someTs.Add(time.Minute)): helper_claimPayload("Bound", "pv-1", "1Gi"),
+		typed.NewWatchTableKey(partitionId, kubeextractor.PersistentVolumeKind, "", "pv-3", someTs.Add(time.Minute)):        helper_volumePayload("Available", "", "5Gi"),
+		typed.NewWatchTableKey(partitionId, kubeextractor.PodKind, "ns", "data", someTs.Add(time.Minute)):                   "{}",
+	})
+
+	values := helper_get_params()
+	values[NamespaceParam] = []string{AllNamespaces}
+	data, err := GetVolumeBinding(values, tables, someTs, someTs.Add(5*time.Minute), someRequestId)
+	assert.Nil(t, err)
+	output := VolumeBindingOutput{}
+	assert.Nil(t, json.Unmarshal(data, &output))
+	assert.Len(t, output.Claims, 1)
+	assert.Len(t, output.Volumes, 1)
+	assert.Equal(t, "pv-3", output.Volumes[0].Name)
+	assert.Equal(t, "Available", output.Volumes[0].Changes[0].Phase)
+}
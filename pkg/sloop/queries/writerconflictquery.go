@@ -0,0 +1,331 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+// Only the first values of a field are listed
+const maxWriterConflictValues = 5
+
+type WriterConflictOutput struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Managers from managedFields that wrote the conflicting fields, empty when the payloads have no managedFields
+	Writers []string              `json:"writers"`
+	Fields  []WriterConflictField `json:"fields"`
+	// Sum over the fields
+	Reverts     int   `json:"reverts"`
+	FirstChange int64 `json:"firstChange"`
+	LastChange  int64 `json:"lastChange"`
+}
+
+type WriterConflictField struct {
+	// Like spec.replicas
+	Path    string `json:"path"`
+	Changes int    `json:"changes"`
+	// Changes back to the value before the last change, 2 to 5 and back to 2 is one
+	Reverts int `json:"reverts"`
+	// JSON of the values in the order they were first seen, null when the field was removed
+	Values  []string               `json:"values"`
+	Writers []WriterConflictWriter `json:"writers"`
+	// Changes by another writer than the change before
+	WriterSwitches      int     `json:"writerSwitches"`
+	ChangesPerHour      float64 `json:"changesPerHour"`
+	MeanIntervalSeconds float64 `json:"meanIntervalSeconds"`
+	FirstChange         int64   `json:"firstChange"`
+	LastChange          int64   `json:"lastChange"`
+}
+
+type WriterConflictWriter struct {
+	Manager string `json:"manager"`
+	Changes int    `json:"changes"`
+}
+
+type writerConflictVersion struct {
+	timestamp     time.Time
+	state         string
+	managedFields []managedFieldsEntry
+}
+
+type managedFieldsEntry struct {
+	Manager  string                 `json:"manager"`
+	Time     string                 `json:"time"`
+	FieldsV1 map[string]interface{} `json:"fieldsV1"`
+}
+
+type writerConflictChange struct {
+	timestamp time.Time
+	value     string
+	writer    string
+}
+
+/*
+Finds resources where two writers keep overwriting the same fields, like two controllers that disagree on the replicas
+of a Deployment.  Each field that changed within the window is checked on its own, and is reported when its value went
+back to the value before the last change often enough.  The writer of a change is the manager in managedFields that
+owns the field, or the most recent manager when none owns it.  Fields with a single known writer flap rather than
+fight, so they are left to GetFlappingResources.  Sensitivity (low, medium or high, medium by default) sets how many
+reverts and how many changes an hour it takes to report a field, like GetFlappingResources.  Sorted by reverts, most
+first
+*/
+func GetWriterConflicts(params url.Values, t typed.Tables, startTime time.Time, endTime time.Time, requestId string) ([]byte, error) {
+	thresholds, err := getFlappingThresholds(params)
+	if err != nil {
+		return nil, err
+	}
+
+	var watchRes map[typed.WatchTableKey]*typed.KubeWatchResult
+	err = t.Db().View(func(txn badgerwrap.Txn) error {
+		var stats typed.RangeReadStats
+		var err error
+		valPredFn := typed.KubeWatchResult_ValPredicateFns(isResPayloadInTimeRange(startTime, endTime))
+		watchRes, stats, err = t.WatchTable().RangeRead(txn, getFlappingKeyPrefix(params), paramFilterFlappingFn(params), valPredFn, startTime, endTime)
+		stats.Log(requestId)
+		return err
+	})
+	if err != nil {
+		return []byte{}, err
+	}
+
+	versions := map[typed.WatchTableKey][]writerConflictVersion{}
+	for key, result := range watchRes {
+		state, err := flappingState(result.Payload)
+		if err != nil {
+			continue
+		}
+		resource := typed.WatchTableKey{Kind: key.Kind, Namespace: key.Namespace, Name: key.Name}
+		versions[resource] = append(versions[resource], writerConflictVersion{timestamp: key.Timestamp, state: state, managedFields: getManagedFields(result.Payload)})
+	}
+
+	hours := endTime.Sub(startTime).Hours()
+	if hours < 1.0/60 {
+		hours = 1.0 / 60
+	}
+	output := []WriterConflictOutput{}
+	for resource, resourceVersions := range versions {
+		row := analyzeWriterConflicts(resourceVersions, hours, thresholds)
+		if len(row.Fields) == 0 {
+			continue
+		}
+		row.Kind = resource.Kind
+		row.Namespace = resource.Namespace
+		row.Name = resource.Name
+		output = append(output, row)
+	}
+	sort.Slice(output, func(i, j int) bool {
+		if output[i].Reverts != output[j].Reverts {
+			return output[i].Reverts > output[j].Reverts
+		}
+		if output[i].Kind != output[j].Kind {
+			return output[i].Kind < output[j].Kind
+		}
+		if output[i].Namespace != output[j].Namespace {
+			return output[i].Namespace < output[j].Namespace
+		}
+		return output[i].Name < output[j].Name
+	})
+
+	bytes, err := json.MarshalIndent(output, "", " ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal json %v", err)
+	}
+	return bytes, nil
+}
+
+// Nil when the payload has no managedFields
+func getManagedFields(payload string) []managedFieldsEntry {
+	resource := struct {
+		Metadata struct {
+			ManagedFields []managedFieldsEntry `json:"managedFields"`
+		} `json:"metadata"`
+	}{}
+	err := json.Unmarshal([]byte(payload), &resource)
+	if err != nil {
+		return nil
+	}
+	return resource.Metadata.ManagedFields
+}
+
+func analyzeWriterConflicts(versions []writerConflictVersion, hours float64, thresholds flappingThresholds) WriterConflictOutput {
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].timestamp.Before(versions[j].timestamp)
+	})
+
+	// The value each field had before its first change in the window
+	initialValues := map[string]string{}
+	changesByPath := map[string][]writerConflictChange{}
+	for idx := 1; idx < len(versions); idx++ {
+		if versions[idx].state == versions[idx-1].state {
+			continue
+		}
+		before, err := kubeextractor.ComputeChangedPathValues(versions[idx].state, versions[idx-1].state)
+		if err != nil {
+			continue
+		}
+		after, err := kubeextractor.ComputeChangedPathValues(versions[idx-1].state, versions[idx].state)
+		if err != nil {
+			continue
+		}
+		for path, value := range after {
+			if _, ok := changesByPath[path]; !ok {
+				initialValues[path] = before[path]
+			}
+			writer := fieldWriter(versions[idx].managedFields, path)
+			changesByPath[path] = append(changesByPath[path], writerConflictChange{timestamp: versions[idx].timestamp, value: value, writer: writer})
+		}
+	}
+
+	row := WriterConflictOutput{Writers: []string{}, Fields: []WriterConflictField{}}
+	writers := map[string]bool{}
+	for path, changes := range changesByPath {
+		field := analyzeWriterConflictField(path, initialValues[path], changes, hours)
+		if field.Reverts < thresholds.minReturns || field.ChangesPerHour < thresholds.minChangesPerHour {
+			continue
+		}
+		if len(field.Writers) == 1 && field.Writers[0].Manager != "" {
+			continue
+		}
+		row.Fields = append(row.Fields, field)
+		row.Reverts += field.Reverts
+		for _, writer := range field.Writers {
+			if writer.Manager != "" {
+				writers[writer.Manager] = true
+			}
+		}
+		if row.FirstChange == 0 || field.FirstChange < row.FirstChange {
+			row.FirstChange = field.FirstChange
+		}
+		if field.LastChange > row.LastChange {
+			row.LastChange = field.LastChange
+		}
+	}
+	for writer := range writers {
+		row.Writers = append(row.Writers, writer)
+	}
+	sort.Strings(row.Writers)
+	sort.Slice(row.Fields, func(i, j int) bool {
+		if row.Fields[i].Reverts != row.Fields[j].Reverts {
+			return row.Fields[i].Reverts > row.Fields[j].Reverts
+		}
+		return row.Fields[i].Path < row.Fields[j].Path
+	})
+	return row
+}
+
+func analyzeWriterConflictField(path string, initialValue string, changes []writerConflictChange, hours float64) WriterConflictField {
+	field := WriterConflictField{Path: path, Changes: len(changes), Values: []string{initialValue}, Writers: []WriterConflictWriter{}}
+	seenValues := map[string]bool{initialValue: true}
+	writerChanges := map[string]int{}
+	previousValues := []string{initialValue}
+	for idx, change := range changes {
+		if len(previousValues) >= 2 && previousValues[len(previousValues)-2] == change.value {
+			field.Reverts++
+		}
+		previousValues = append(previousValues, change.value)
+		if !seenValues[change.value] {
+			seenValues[change.value] = true
+			if len(field.Values) < maxWriterConflictValues {
+				field.Values = append(field.Values, change.value)
+			}
+		}
+		writerChanges[change.writer]++
+		if idx > 0 && change.writer != changes[idx-1].writer {
+			field.WriterSwitches++
+		}
+	}
+	for manager, count := range writerChanges {
+		field.Writers = append(field.Writers, WriterConflictWriter{Manager: manager, Changes: count})
+	}
+	sort.Slice(field.Writers, func(i, j int) bool {
+		if field.Writers[i].Changes != field.Writers[j].Changes {
+			return field.Writers[i].Changes > field.Writers[j].Changes
+		}
+		return field.Writers[i].Manager < field.Writers[j].Manager
+	})
+
+	field.FirstChange = changes[0].timestamp.Unix()
+	field.LastChange = changes[len(changes)-1].timestamp.Unix()
+	field.ChangesPerHour = float64(len(changes)) / hours
+	if len(changes) > 1 {
+		field.MeanIntervalSeconds = changes[len(changes)-1].timestamp.Sub(changes[0].timestamp).Seconds() / float64(len(changes)-1)
+	}
+	return field
+}
+
+// The manager whose fieldsV1 goes deepest into the path, the most recent one on a tie or when none has any of it
+func fieldWriter(entries []managedFieldsEntry, path string) string {
+	segments := fieldsV1Segments(path)
+	writer := ""
+	writerDepth := -1
+	writerTime := ""
+	for _, entry := range entries {
+		depth := 0
+		node := entry.FieldsV1
+		for _, segment := range segments {
+			child, ok := node["f:"+segment].(map[string]interface{})
+			if !ok {
+				break
+			}
+			depth++
+			node = child
+		}
+		// RFC3339 times in UTC sort as strings
+		if depth > writerDepth || (depth == writerDepth && entry.Time > writerTime) {
+			writer = entry.Manager
+			writerDepth = depth
+			writerTime = entry.Time
+		}
+	}
+	return writer
+}
+
+// Keys of a path like spec.replicas or metadata.labels["app.kubernetes.io/name"], up to the first list index since
+// fieldsV1 keys items of lists by their contents
+func fieldsV1Segments(path string) []string {
+	segments := []string{}
+	for len(path) > 0 {
+		switch {
+		case path[0] == '.':
+			path = path[1:]
+		case strings.HasPrefix(path, `["`):
+			end := strings.Index(path, `"]`)
+			if end < 0 {
+				return segments
+			}
+			key, err := strconv.Unquote(path[1 : end+1])
+			if err != nil {
+				return segments
+			}
+			segments = append(segments, key)
+			path = path[end+2:]
+		case path[0] == '[':
+			return segments
+		default:
+			end := strings.IndexAny(path, ".[")
+			if end < 0 {
+				end = len(path)
+			}
+			segments = append(segments, path[:end])
+			path = path[end:]
+		}
+	}
+	return segments
+}
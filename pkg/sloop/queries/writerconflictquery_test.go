@@ -0,0 +1,113 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+const someConflictDeployment = `{"metadata":{"name":"%v","namespace":"some-namespace","resourceVersion":"%v","managedFields":[` +
+	`{"manager":"%v","operation":"Update","time":"%v","fieldsV1":{"f:spec":{"f:replicas":{}}}},` +
+	`{"manager":"%v","operation":"Update","time":"%v","fieldsV1":{"f:spec":{"f:replicas":{}}}}]},"spec":{"replicas":%v}}`
+
+// Every 5 minutes for an hour: fight is scaled between 2 and 5 by deployer and autoscaler, flapper between 2 and 5 by
+// deployer alone and grower only scaled up by both
+func helper_getWriterConflictTables(t *testing.T) typed.Tables {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		for idx := 0; idx < 12; idx++ {
+			ts := someTs.Add(time.Duration(idx) * 5 * time.Minute)
+			previous := ts.Add(-5 * time.Minute).UTC().Format(time.RFC3339)
+			current := ts.UTC().Format(time.RFC3339)
+			writer, other := "deployer", "autoscaler"
+			if idx%2 == 1 {
+				writer, other = other, writer
+			}
+			payloads := map[string]string{
+				"fight":   fmt.Sprintf(someConflictDeployment, "fight", idx, other, previous, writer, current, 2+3*(idx%2)),
+				"flapper": fmt.Sprintf(someConflictDeployment, "flapper", idx, "deployer", current, "deployer", current, 2+3*(idx%2)),
+				"grower":  fmt.Sprintf(someConflictDeployment, "grower", idx, other, previous, writer, current, idx+1),
+			}
+			for name, payload := range payloads {
+				key := typed.NewWatchTableKey(untyped.GetPartitionId(ts), "Deployment", "some-namespace", name, ts)
+				protoTs, _ := ptypes.TimestampProto(ts)
+				err := tables.WatchTable().Set(txn, key.String(), &typed.KubeWatchResult{Timestamp: protoTs, Kind: "Deployment", Payload: payload})
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+	return tables
+}
+
+func Test_GetWriterConflicts(t *testing.T) {
+	tables := helper_getWriterConflictTables(t)
+	values := helper_get_params()
+	values[KindParam] = []string{"Deployment"}
+	data, err := GetWriterConflicts(values, tables, someTs, someTs.Add(time.Hour), someRequestId)
+	assert.Nil(t, err)
+	output := []WriterConflictOutput{}
+	assert.Nil(t, json.Unmarshal(data, &output))
+	assert.Len(t, output, 1)
+	assert.Equal(t, "fight", output[0].Name)
+	assert.Equal(t, []string{"autoscaler", "deployer"}, output[0].Writers)
+	assert.Equal(t, 10, output[0].Reverts)
+	assert.Len(t, output[0].Fields, 1)
+
+	field := output[0].Fields[0]
+	assert.Equal(t, "spec.replicas", field.Path)
+	assert.Equal(t, 11, field.Changes)
+	assert.Equal(t, 10, field.Reverts)
+	assert.Equal(t, []string{"2", "5"}, field.Values)
+	assert.Equal(t, []WriterConflictWriter{{Manager: "autoscaler", Changes: 6}, {Manager: "deployer", Changes: 5}}, field.Writers)
+	assert.Equal(t, 10, field.WriterSwitches)
+	assert.InDelta(t, 300.0, field.MeanIntervalSeconds, 0.001)
+	assert.InDelta(t, 11.0, field.ChangesPerHour, 0.001)
+	assert.Equal(t, someTs.Add(5*time.Minute).Unix(), field.FirstChange)
+	assert.Equal(t, someTs.Add(55*time.Minute).Unix(), output[0].LastChange)
+}
+
+func Test_GetWriterConflicts_InvalidSensitivity(t *testing.T) {
+	tables := helper_getWriterConflictTables(t)
+	values := helper_get_params()
+	values[SensitivityParam] = []string{"extreme"}
+	_, err := GetWriterConflicts(values, tables, someTs, someTs.Add(time.Hour), someRequestId)
+	assert.NotNil(t, err)
+}
+
+func Test_fieldWriter(t *testing.T) {
+	entries := []managedFieldsEntry{
+		{Manager: "kubectl", Time: "2021-01-01T00:00:00Z", FieldsV1: map[string]interface{}{"f:metadata": map[string]interface{}{"f:labels": map[string]interface{}{"f:app.kubernetes.io/name": map[string]interface{}{}}}}},
+		{Manager: "controller", Time: "2021-01-02T00:00:00Z", FieldsV1: map[string]interface{}{"f:metadata": map[string]interface{}{}}},
+	}
+	assert.Equal(t, "kubectl", fieldWriter(entries, `metadata.labels["app.kubernetes.io/name"]`))
+	assert.Equal(t, "controller", fieldWriter(entries, "spec.replicas"))
+	assert.Equal(t, "", fieldWriter(nil, "spec.replicas"))
+}
+
+func Test_fieldsV1Segments(t *testing.T) {
+	assert.Equal(t, []string{"spec", "replicas"}, fieldsV1Segments("spec.replicas"))
+	assert.Equal(t, []string{"metadata", "labels", "app.kubernetes.io/name"}, fieldsV1Segments(`metadata.labels["app.kubernetes.io/name"]`))
+	assert.Equal(t, []string{"spec", "template", "spec", "containers"}, fieldsV1Segments("spec.template.spec.containers[0].image"))
+}
@@ -0,0 +1,379 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package replay
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+/*
+Replays stored watch results to a webhook so controllers and operators can be tested against real cluster history.
+Each watch result is POSTed as a single json Event.  Gaps between results are preserved, scaled by Speed.  Watch
+results are read one partition at a time, so a long replay only holds one partition of them in memory.
+*/
+
+const (
+	StateRunning   = "running"
+	StateFinished  = "finished"
+	StateFailed    = "failed"
+	StateCancelled = "cancelled"
+
+	webhookTimeout = 30 * time.Second
+	// Jobs that are no longer running are dropped from the list this long after they ended
+	finishedJobTtl = time.Hour
+)
+
+var (
+	metricReplayEventsSent   = promauto.NewCounter(prometheus.CounterOpts{Name: "sloop_replay_events_sent"})
+	metricReplayEventsFailed = promauto.NewCounter(prometheus.CounterOpts{Name: "sloop_replay_events_failed"})
+)
+
+type Request struct {
+	WebhookUrl string    `json:"webhookUrl"`
+	Kinds      []string  `json:"kinds"`
+	Namespace  string    `json:"namespace,omitempty"`
+	StartTime  time.Time `json:"startTime"`
+	EndTime    time.Time `json:"endTime"`
+	// Multiplier on the original pace.  2 replays twice as fast as it happened.  0 sends without any delay
+	Speed float64 `json:"speed"`
+}
+
+// The body sent to the webhook for each watch result
+type Event struct {
+	Timestamp time.Time       `json:"timestamp"`
+	Kind      string          `json:"kind"`
+	WatchType string          `json:"watchType"`
+	Payload   json.RawMessage `json:"payload"`
+}
+
+type Status struct {
+	Id         string  `json:"id"`
+	Request    Request `json:"request"`
+	State      string  `json:"state"`
+	Partitions int     `json:"partitions"`
+	// Partitions whose watch results were all sent
+	PartitionsDone int `json:"partitionsDone"`
+	// Watch results read so far, it grows as each partition is read
+	Total      int       `json:"total"`
+	Sent       int       `json:"sent"`
+	Error      string    `json:"error,omitempty"`
+	StartedAt  time.Time `json:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt,omitempty"`
+}
+
+type job struct {
+	status Status
+	cancel context.CancelFunc
+}
+
+type Manager struct {
+	tables typed.Tables
+	client *http.Client
+	lock   *sync.Mutex
+	jobs   map[string]*job
+	nextId int
+	// Replaced in unit tests so they dont have to wait
+	sleep func(ctx context.Context, d time.Duration) bool
+}
+
+func NewManager(tables typed.Tables) *Manager {
+	return &Manager{
+		tables: tables,
+		client: &http.Client{Timeout: webhookTimeout},
+		lock:   &sync.Mutex{},
+		jobs:   map[string]*job{},
+		sleep:  sleepWithContext,
+	}
+}
+
+// Returns false if the context was cancelled before the duration elapsed
+func sleepWithContext(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// Checks the request before it is started, Start returns the same error
+func (r *Request) Validate() error {
+	if r.WebhookUrl == "" {
+		return fmt.Errorf("webhook url is required")
+	}
+	if len(r.Kinds) == 0 {
+		return fmt.Errorf("at least one kind is required")
+	}
+	if r.Speed < 0 {
+		return fmt.Errorf("speed can not be negative: %v", r.Speed)
+	}
+	if !r.EndTime.After(r.StartTime) {
+		return fmt.Errorf("end time %v must be after start time %v", r.EndTime, r.StartTime)
+	}
+	return nil
+}
+
+// Kicks off a background job reading the watch results to replay and sending them.  Returns the job id.
+func (m *Manager) Start(req Request) (string, error) {
+	err := req.Validate()
+	if err != nil {
+		return "", err
+	}
+
+	var partitions []string
+	err = m.tables.Db().View(func(txn badgerwrap.Txn) error {
+		var err2 error
+		partitions, err2 = m.tables.WatchTable().GetPartitionsFromTimeRange(txn, req.StartTime, req.EndTime)
+		return err2
+	})
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.lock.Lock()
+	m.pruneFinished(time.Now().UTC())
+	m.nextId += 1
+	id := fmt.Sprintf("%d", m.nextId)
+	j := &job{
+		status: Status{Id: id, Request: req, State: StateRunning, Partitions: len(partitions), StartedAt: time.Now().UTC()},
+		cancel: cancel,
+	}
+	m.jobs[id] = j
+	m.lock.Unlock()
+
+	glog.Infof("Starting replay %v of %v partitions to %v", id, len(partitions), req.WebhookUrl)
+	go m.run(ctx, j, partitions)
+	return id, nil
+}
+
+// Needs the lock
+func (m *Manager) pruneFinished(now time.Time) {
+	for id, j := range m.jobs {
+		if j.status.State != StateRunning && now.Sub(j.status.FinishedAt) > finishedJobTtl {
+			delete(m.jobs, id)
+		}
+	}
+}
+
+func (m *Manager) Status(id string) (Status, bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.pruneFinished(time.Now().UTC())
+	j, ok := m.jobs[id]
+	if !ok {
+		return Status{}, false
+	}
+	return j.status, true
+}
+
+func (m *Manager) List() []Status {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.pruneFinished(time.Now().UTC())
+	ret := []Status{}
+	for _, j := range m.jobs {
+		ret = append(ret, j.status)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].StartedAt.Before(ret[j].StartedAt) })
+	return ret
+}
+
+func (m *Manager) Cancel(id string) bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	j, ok := m.jobs[id]
+	if !ok {
+		return false
+	}
+	j.cancel()
+	return true
+}
+
+func (m *Manager) finish(j *job, state string, err error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	j.status.State = state
+	j.status.FinishedAt = time.Now().UTC()
+	if err != nil {
+		j.status.Error = err.Error()
+	}
+	glog.Infof("Replay %v %v after sending %v of %v watch results", j.status.Id, state, j.status.Sent, j.status.Total)
+}
+
+func (m *Manager) run(ctx context.Context, j *job, partitions []string) {
+	var previous time.Time
+	for _, partitionId := range partitions {
+		results, err := loadWatchResults(m.tables, j.status.Request, partitionId)
+		if err != nil {
+			m.finish(j, StateFailed, err)
+			return
+		}
+		m.lock.Lock()
+		j.status.Total += len(results)
+		m.lock.Unlock()
+
+		for _, result := range results {
+			ts, err := ptypes.Timestamp(result.Timestamp)
+			if err != nil {
+				m.finish(j, StateFailed, errors.Wrap(err, "invalid timestamp in watch result"))
+				return
+			}
+			if !previous.IsZero() && j.status.Request.Speed > 0 {
+				delay := time.Duration(float64(ts.Sub(previous)) / j.status.Request.Speed)
+				if !m.sleep(ctx, delay) {
+					m.finish(j, StateCancelled, nil)
+					return
+				}
+			}
+			if ctx.Err() != nil {
+				m.finish(j, StateCancelled, nil)
+				return
+			}
+			previous = ts
+
+			err = m.post(ctx, j.status.Request.WebhookUrl, result, ts)
+			if err != nil {
+				metricReplayEventsFailed.Inc()
+				m.finish(j, StateFailed, err)
+				return
+			}
+			metricReplayEventsSent.Inc()
+
+			m.lock.Lock()
+			j.status.Sent += 1
+			m.lock.Unlock()
+		}
+
+		m.lock.Lock()
+		j.status.PartitionsDone += 1
+		m.lock.Unlock()
+	}
+	m.finish(j, StateFinished, nil)
+}
+
+func (m *Manager) post(ctx context.Context, url string, result *typed.KubeWatchResult, ts time.Time) error {
+	event := Event{Timestamp: ts, Kind: result.Kind, WatchType: result.WatchType.String(), Payload: json.RawMessage(result.Payload)}
+	body, err := json.Marshal(event)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal replay event")
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrapf(err, "failed to build request for %v", url)
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("content-type", "application/json")
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "failed to post to %v", url)
+	}
+	defer resp.Body.Close()
+	_, _ = ioutil.ReadAll(resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook %v returned status %v", url, resp.StatusCode)
+	}
+	return nil
+}
+
+// Returns the matching watch results of one partition in the order they were observed
+func loadWatchResults(tables typed.Tables, req Request, partitionId string) ([]*typed.KubeWatchResult, error) {
+	partitionStart, err := untyped.GetTimeForPartition(partitionId)
+	if err != nil {
+		return nil, err
+	}
+	partitionEnd := partitionStart.Add(untyped.GetPartitionDuration())
+	// Only this partition is read, the end is exclusive so a result on the boundary is sent once
+	startTime, endTime := req.StartTime, req.EndTime
+	if partitionStart.After(startTime) {
+		startTime = partitionStart
+	}
+	if partitionEnd.Add(-time.Nanosecond).Before(endTime) {
+		endTime = partitionEnd.Add(-time.Nanosecond)
+	}
+
+	var ret []*typed.KubeWatchResult
+	err = tables.Db().View(func(txn badgerwrap.Txn) error {
+		for _, kind := range req.Kinds {
+			keyPrefix := &typed.WatchTableKey{Kind: kind, Namespace: req.Namespace}
+			var keyPredicate func(string) bool
+			if req.Namespace == "" {
+				// The key prefix can only include namespace if kind is also set, so filter on kind here instead
+				keyPrefix = nil
+				keyPredicate = isKind(kind)
+			}
+			watchRes, stats, err := tables.WatchTable().RangeRead(txn, keyPrefix, keyPredicate, isInTimeRange(startTime, endTime), startTime, endTime)
+			if err != nil {
+				return errors.Wrapf(err, "failed to read watch results for kind %v", kind)
+			}
+			stats.Log("replay")
+			for _, v := range watchRes {
+				ret = append(ret, v)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(ret, func(i, j int) bool {
+		if ret[i].Timestamp.Seconds != ret[j].Timestamp.Seconds {
+			return ret[i].Timestamp.Seconds < ret[j].Timestamp.Seconds
+		}
+		return ret[i].Timestamp.Nanos < ret[j].Timestamp.Nanos
+	})
+	return ret, nil
+}
+
+func isKind(kind string) func(string) bool {
+	return func(key string) bool {
+		k := &typed.WatchTableKey{}
+		err := k.Parse(key)
+		if err != nil {
+			glog.Errorf("Failed to parse key %v: %v", key, err)
+			return false
+		}
+		return k.Kind == kind
+	}
+}
+
+func isInTimeRange(startTime time.Time, endTime time.Time) func(*typed.KubeWatchResult) bool {
+	return func(result *typed.KubeWatchResult) bool {
+		ts, err := ptypes.Timestamp(result.Timestamp)
+		if err != nil {
+			return false
+		}
+		return !ts.Before(startTime) && !ts.After(endTime)
+	}
+}
@@ -0,0 +1,188 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package replay
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+var someTs = time.Date(2019, 3, 4, 3, 4, 0, 0, time.UTC)
+
+func helper_addWatchResult(t *testing.T, tables typed.Tables, kind string, namespace string, name string, ts time.Time) {
+	pts, _ := ptypes.TimestampProto(ts)
+	val := &typed.KubeWatchResult{Kind: kind, WatchType: typed.KubeWatchResult_UPDATE, Timestamp: pts, Payload: `{"name":"` + name + `"}`}
+	key := typed.NewWatchTableKey(untyped.GetPartitionId(ts), kind, namespace, name, ts).String()
+	err := tables.Db().Update(func(txn badgerwrap.Txn) error {
+		return tables.WatchTable().Set(txn, key, val)
+	})
+	assert.Nil(t, err)
+}
+
+func helper_getTables(t *testing.T) typed.Tables {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	helper_addWatchResult(t, tables, "Pod", "ns1", "pod2", someTs.Add(2*time.Minute))
+	helper_addWatchResult(t, tables, "Pod", "ns1", "pod1", someTs.Add(time.Minute))
+	helper_addWatchResult(t, tables, "Pod", "ns2", "pod3", someTs.Add(3*time.Minute))
+	helper_addWatchResult(t, tables, "Deployment", "ns1", "deploy1", someTs.Add(time.Minute))
+	return tables
+}
+
+func helper_waitForState(t *testing.T, mgr *Manager, id string) Status {
+	for i := 0; i < 100; i++ {
+		status, ok := mgr.Status(id)
+		assert.True(t, ok)
+		if status.State != StateRunning {
+			return status
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("replay %v did not finish", id)
+	return Status{}
+}
+
+func Test_loadWatchResults_FiltersAndSorts(t *testing.T) {
+	tables := helper_getTables(t)
+	req := Request{Kinds: []string{"Pod"}, StartTime: someTs, EndTime: someTs.Add(time.Hour)}
+	results, err := loadWatchResults(tables, req, untyped.GetPartitionId(someTs))
+	assert.Nil(t, err)
+	assert.Len(t, results, 3)
+	assert.Equal(t, `{"name":"pod1"}`, results[0].Payload)
+	assert.Equal(t, `{"name":"pod3"}`, results[2].Payload)
+
+	req.Namespace = "ns2"
+	results, err = loadWatchResults(tables, req, untyped.GetPartitionId(someTs))
+	assert.Nil(t, err)
+	assert.Len(t, results, 1)
+
+	results, err = loadWatchResults(tables, req, untyped.GetPartitionId(someTs.Add(time.Hour)))
+	assert.Nil(t, err)
+	assert.Len(t, results, 0)
+}
+
+func Test_Manager_Start_SendsOnePartitionAtATime(t *testing.T) {
+	tables := helper_getTables(t)
+	// In the next partition, with a result right on its start that must only be sent once
+	nextPartition, _ := untyped.GetTimeForPartition(untyped.GetPartitionId(someTs.Add(time.Hour)))
+	helper_addWatchResult(t, tables, "Pod", "ns1", "pod4", nextPartition)
+	helper_addWatchResult(t, tables, "Pod", "ns1", "pod5", nextPartition.Add(time.Minute))
+	lock := &sync.Mutex{}
+	var received []string
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event Event
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.Nil(t, json.Unmarshal(body, &event))
+		lock.Lock()
+		received = append(received, string(event.Payload))
+		lock.Unlock()
+	}))
+	defer webhook.Close()
+
+	mgr := NewManager(tables)
+	id, err := mgr.Start(Request{WebhookUrl: webhook.URL, Kinds: []string{"Pod"}, StartTime: someTs, EndTime: nextPartition.Add(time.Hour)})
+	assert.Nil(t, err)
+
+	status := helper_waitForState(t, mgr, id)
+	assert.Equal(t, StateFinished, status.State)
+	assert.Equal(t, 3, status.Partitions)
+	assert.Equal(t, 3, status.PartitionsDone)
+	assert.Equal(t, 5, status.Total)
+	assert.Equal(t, []string{`{"name":"pod1"}`, `{"name":"pod2"}`, `{"name":"pod3"}`, `{"name":"pod4"}`, `{"name":"pod5"}`}, received)
+}
+
+func Test_Manager_PrunesFinishedJobs(t *testing.T) {
+	tables := helper_getTables(t)
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer webhook.Close()
+
+	mgr := NewManager(tables)
+	id, err := mgr.Start(Request{WebhookUrl: webhook.URL, Kinds: []string{"Deployment"}, StartTime: someTs, EndTime: someTs.Add(time.Hour)})
+	assert.Nil(t, err)
+	helper_waitForState(t, mgr, id)
+	assert.Len(t, mgr.List(), 1)
+
+	mgr.lock.Lock()
+	mgr.jobs[id].status.FinishedAt = time.Now().UTC().Add(-finishedJobTtl - time.Minute)
+	mgr.lock.Unlock()
+	assert.Len(t, mgr.List(), 0)
+	_, ok := mgr.Status(id)
+	assert.False(t, ok)
+}
+
+func Test_Manager_Start_PostsEventsInOrder(t *testing.T) {
+	tables := helper_getTables(t)
+	lock := &sync.Mutex{}
+	var received []Event
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		var event Event
+		assert.Nil(t, json.Unmarshal(body, &event))
+		lock.Lock()
+		received = append(received, event)
+		lock.Unlock()
+	}))
+	defer webhook.Close()
+
+	mgr := NewManager(tables)
+	var delays []time.Duration
+	mgr.sleep = func(ctx context.Context, d time.Duration) bool {
+		delays = append(delays, d)
+		return true
+	}
+	id, err := mgr.Start(Request{WebhookUrl: webhook.URL, Kinds: []string{"Pod", "Deployment"}, StartTime: someTs, EndTime: someTs.Add(time.Hour), Speed: 60})
+	assert.Nil(t, err)
+
+	status := helper_waitForState(t, mgr, id)
+	assert.Equal(t, StateFinished, status.State)
+	assert.Equal(t, 4, status.Sent)
+	assert.Len(t, received, 4)
+	assert.Equal(t, "Pod", received[3].Kind)
+	assert.Equal(t, "UPDATE", received[3].WatchType)
+	assert.Equal(t, time.Second, delays[len(delays)-1])
+}
+
+func Test_Manager_Start_WebhookErrorFailsJob(t *testing.T) {
+	tables := helper_getTables(t)
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer webhook.Close()
+
+	mgr := NewManager(tables)
+	id, err := mgr.Start(Request{WebhookUrl: webhook.URL, Kinds: []string{"Pod"}, StartTime: someTs, EndTime: someTs.Add(time.Hour)})
+	assert.Nil(t, err)
+
+	status := helper_waitForState(t, mgr, id)
+	assert.Equal(t, StateFailed, status.State)
+	assert.Equal(t, 0, status.Sent)
+	assert.NotEmpty(t, status.Error)
+}
+
+func Test_Manager_Start_InvalidRequest(t *testing.T) {
+	mgr := NewManager(helper_getTables(t))
+	_, err := mgr.Start(Request{Kinds: []string{"Pod"}, StartTime: someTs, EndTime: someTs.Add(time.Hour)})
+	assert.NotNil(t, err)
+	_, err = mgr.Start(Request{WebhookUrl: "http://localhost", StartTime: someTs, EndTime: someTs.Add(time.Hour)})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,167 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package report
+
+// Reports every sloop has, they can be replaced by configured reports of the same name
+func Builtin() []Definition {
+	return []Definition{
+		{
+			Name:     "weekly-changes",
+			Title:    "Changes by namespace",
+			Query:    "GetSnapshotDiff",
+			Params:   map[string]string{"lookback": "168h"},
+			Markdown: weeklyChangesMarkdown,
+			Html:     weeklyChangesHtml,
+		},
+		{
+			Name:     "top-warnings",
+			Title:    "Top warning events",
+			Query:    "GetEventBreakdown",
+			Params:   map[string]string{"lookback": "168h"},
+			Markdown: topWarningsMarkdown,
+			Html:     topWarningsHtml,
+		},
+		{
+			Name:     "rollouts",
+			Title:    "Deployment rollouts",
+			Query:    "GetDeploymentRollouts",
+			Params:   map[string]string{"lookback": "168h"},
+			Markdown: rolloutsMarkdown,
+			Html:     rolloutsHtml,
+		},
+	}
+}
+
+const weeklyChangesMarkdown = `# {{.Title}}
+
+{{time .From}} to {{time .To}}
+{{- $r := .Result}}
+{{range $ns := values "namespace" $r.added $r.removed $r.changed $r.transient}}
+## {{if $ns}}{{$ns}}{{else}}Cluster scoped{{end}}
+{{range where $r.added "namespace" $ns}}
+- Added {{.kind}} {{.name}}{{if .summaryAfter}}: {{md .summaryAfter}}{{end}}
+{{- end}}
+{{- range where $r.removed "namespace" $ns}}
+- Removed {{.kind}} {{.name}}{{if .summaryBefore}}: {{md .summaryBefore}}{{end}}
+{{- end}}
+{{- range where $r.changed "namespace" $ns}}
+- {{if .recreated}}Recreated{{else}}Changed{{end}} {{.kind}} {{.name}}{{if .changedPaths}}: {{join ", " .changedPaths}}{{end}}
+{{- end}}
+{{- range where $r.transient "namespace" $ns}}
+- Created and deleted {{.kind}} {{.name}}
+{{- end}}
+{{else}}
+No changes.
+{{end}}
+{{- if $r.unchanged}}
+{{$r.unchanged}} resources were updated but ended up unchanged.
+{{end -}}
+`
+
+const weeklyChangesHtml = `<html>
+<head><title>{{.Title}}</title></head>
+<body>
+<h1>{{.Title}}</h1>
+<p>{{time .From}} to {{time .To}}</p>
+{{- $r := .Result}}
+{{range $ns := values "namespace" $r.added $r.removed $r.changed $r.transient}}
+<h2>{{if $ns}}{{$ns}}{{else}}Cluster scoped{{end}}</h2>
+<ul>
+{{- range where $r.added "namespace" $ns}}
+<li>Added {{.kind}} {{.name}}{{if .summaryAfter}}: {{.summaryAfter}}{{end}}</li>
+{{- end}}
+{{- range where $r.removed "namespace" $ns}}
+<li>Removed {{.kind}} {{.name}}{{if .summaryBefore}}: {{.summaryBefore}}{{end}}</li>
+{{- end}}
+{{- range where $r.changed "namespace" $ns}}
+<li>{{if .recreated}}Recreated{{else}}Changed{{end}} {{.kind}} {{.name}}{{if .changedPaths}}: {{join ", " .changedPaths}}{{end}}</li>
+{{- end}}
+{{- range where $r.transient "namespace" $ns}}
+<li>Created and deleted {{.kind}} {{.name}}</li>
+{{- end}}
+</ul>
+{{else}}
+<p>No changes.</p>
+{{end}}
+{{- if $r.unchanged}}
+<p>{{$r.unchanged}} resources were updated but ended up unchanged.</p>
+{{end -}}
+</body>
+</html>
+`
+
+const topWarningsMarkdown = `# {{.Title}}
+
+{{time .From}} to {{time .To}}
+{{- $warnings := first 20 (where .Result "type" "Warning")}}
+{{if $warnings}}
+| Count | Reason | Kinds | Message | Last seen |
+|---|---|---|---|---|
+{{- range $warnings}}
+| {{.count}} | {{md .reason}} | {{join ", " .involvedKinds}} | {{md .sampleMessage}} | {{time .lastTimestamp}} |
+{{- end}}
+{{else}}
+No warning events.
+{{end -}}
+`
+
+const topWarningsHtml = `<html>
+<head><title>{{.Title}}</title></head>
+<body>
+<h1>{{.Title}}</h1>
+<p>{{time .From}} to {{time .To}}</p>
+{{- $warnings := first 20 (where .Result "type" "Warning")}}
+{{if $warnings}}
+<table>
+<tr><th>Count</th><th>Reason</th><th>Kinds</th><th>Message</th><th>Last seen</th></tr>
+{{- range $warnings}}
+<tr><td>{{.count}}</td><td>{{.reason}}</td><td>{{join ", " .involvedKinds}}</td><td>{{.sampleMessage}}</td><td>{{time .lastTimestamp}}</td></tr>
+{{- end}}
+</table>
+{{else}}
+<p>No warning events.</p>
+{{end -}}
+</body>
+</html>
+`
+
+const rolloutsMarkdown = `# {{.Title}}
+
+{{time .From}} to {{time .To}}
+{{if .Result}}
+{{len .Result}} rollouts: {{len (where .Result "outcome" "complete")}} complete, {{len (where .Result "outcome" "failed")}} failed, {{len (where .Result "outcome" "rolledBack")}} rolled back, {{len (where .Result "outcome" "inProgress")}} in progress.
+
+| Deployment | Revision | Started | Duration | Outcome | Images |
+|---|---|---|---|---|---|
+{{- range .Result}}
+| {{.namespace}}/{{.name}} | {{.revision}} | {{time .startTime}} | {{duration .durationSeconds}} | {{.outcome}}{{if .rollback}} (rollback){{end}} | {{md (join ", " .images)}} |
+{{- end}}
+{{else}}
+No rollouts.
+{{end -}}
+`
+
+const rolloutsHtml = `<html>
+<head><title>{{.Title}}</title></head>
+<body>
+<h1>{{.Title}}</h1>
+<p>{{time .From}} to {{time .To}}</p>
+{{if .Result}}
+<p>{{len .Result}} rollouts: {{len (where .Result "outcome" "complete")}} complete, {{len (where .Result "outcome" "failed")}} failed, {{len (where .Result "outcome" "rolledBack")}} rolled back, {{len (where .Result "outcome" "inProgress")}} in progress.</p>
+<table>
+<tr><th>Deployment</th><th>Revision</th><th>Started</th><th>Duration</th><th>Outcome</th><th>Images</th></tr>
+{{- range .Result}}
+<tr><td>{{.namespace}}/{{.name}}</td><td>{{.revision}}</td><td>{{time .startTime}}</td><td>{{duration .durationSeconds}}</td><td>{{.outcome}}{{if .rollback}} (rollback){{end}}</td><td>{{join ", " .images}}</td></tr>
+{{- end}}
+</table>
+{{else}}
+<p>No rollouts.</p>
+{{end -}}
+</body>
+</html>
+`
@@ -0,0 +1,324 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	htmltemplate "html/template"
+	"net/url"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/salesforce/sloop/pkg/sloop/queries"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+)
+
+const (
+	FormatMarkdown = "markdown"
+	FormatHtml     = "html"
+
+	defaultLookback = "168h"
+)
+
+// A report is a query and the templates its result is rendered with
+type Definition struct {
+	Name  string `json:"name"`
+	Title string `json:"title"`
+	// Query name, like GetSnapshotDiff, and its params.  The time range defaults to lookback=168h
+	Query  string            `json:"query"`
+	Params map[string]string `json:"params"`
+	// Go templates over the Data of a run, at least one of them is needed.  The html one is an html/template, so the
+	// values in it are escaped
+	Markdown string `json:"markdown"`
+	Html     string `json:"html"`
+	// How often the report is pushed to Webhook.  0 = only on demand
+	Interval time.Duration `json:"interval"`
+	Webhook  string        `json:"webhook"`
+	// Format pushed to Webhook.  Empty = markdown, or html for reports without a markdown template
+	WebhookFormat string `json:"webhookFormat"`
+}
+
+// What the templates of a report are run on
+type Data struct {
+	Name      string
+	Title     string
+	Generated time.Time
+	From      time.Time
+	To        time.Time
+	Params    url.Values
+	// The query result as decoded json, so fields have their json names, like .Result.added
+	Result interface{}
+}
+
+func (d Definition) Formats() []string {
+	formats := []string{}
+	if d.Markdown != "" {
+		formats = append(formats, FormatMarkdown)
+	}
+	if d.Html != "" {
+		formats = append(formats, FormatHtml)
+	}
+	return formats
+}
+
+func (d Definition) defaultFormat() string {
+	if d.WebhookFormat != "" {
+		return d.WebhookFormat
+	}
+	return d.Formats()[0]
+}
+
+func (d Definition) Validate() error {
+	if d.Name == "" {
+		return fmt.Errorf("report name can not be empty")
+	}
+	if !queries.IsQuery(d.Query) {
+		return fmt.Errorf("report %v has unknown query %q", d.Name, d.Query)
+	}
+	if len(d.Formats()) == 0 {
+		return fmt.Errorf("report %v needs a markdown or html template", d.Name)
+	}
+	if d.Markdown != "" {
+		_, err := texttemplate.New(d.Name).Funcs(textFuncs).Parse(d.Markdown)
+		if err != nil {
+			return errors.Wrapf(err, "report %v has an invalid markdown template", d.Name)
+		}
+	}
+	if d.Html != "" {
+		_, err := htmltemplate.New(d.Name).Funcs(htmlFuncs).Parse(d.Html)
+		if err != nil {
+			return errors.Wrapf(err, "report %v has an invalid html template", d.Name)
+		}
+	}
+	if d.Interval < 0 {
+		return fmt.Errorf("report %v interval can not be < 0", d.Name)
+	}
+	if d.Interval > 0 && d.Webhook == "" {
+		return fmt.Errorf("report %v has an interval but no webhook", d.Name)
+	}
+	if d.WebhookFormat != "" && !d.hasFormat(d.WebhookFormat) {
+		return fmt.Errorf("report %v has no %v template for its webhook", d.Name, d.WebhookFormat)
+	}
+	return nil
+}
+
+func (d Definition) hasFormat(format string) bool {
+	for _, f := range d.Formats() {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// Report name -> definition
+type Map map[string]Definition
+
+// The built in reports, with the configured ones added and replacing built in ones of the same name
+func NewMap(configured []Definition) (Map, error) {
+	reports := Map{}
+	for _, def := range append(Builtin(), configured...) {
+		err := def.Validate()
+		if err != nil {
+			return nil, err
+		}
+		reports[def.Name] = def
+	}
+	return reports, nil
+}
+
+func (m Map) Names() []string {
+	names := []string{}
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func ContentType(format string) string {
+	if format == FormatHtml {
+		return "text/html; charset=utf-8"
+	}
+	return "text/markdown; charset=utf-8"
+}
+
+/*
+Runs the query of a report and renders its result in format, markdown or html, or the default format of the report
+when empty.  Params override the params of the report, so the same report can be rendered for another namespace or
+time range
+*/
+func Render(def Definition, format string, params url.Values, tables typed.Tables, maxLookBack time.Duration, requestId string) ([]byte, error) {
+	if format == "" {
+		format = def.defaultFormat()
+	}
+	if !def.hasFormat(format) {
+		return nil, fmt.Errorf("report %v has no %v template", def.Name, format)
+	}
+
+	queryParams := url.Values{}
+	for key, value := range def.Params {
+		queryParams.Set(key, value)
+	}
+	// The time range params only make sense together, so any of them replaces all of those of the report
+	timeParams := []string{queries.LookbackParam, queries.StartTimeParam, queries.EndTimeParam}
+	for _, key := range timeParams {
+		if params.Get(key) != "" {
+			for _, key := range timeParams {
+				queryParams.Del(key)
+			}
+			break
+		}
+	}
+	for key, values := range params {
+		queryParams[key] = values
+	}
+	if queryParams.Get(queries.LookbackParam) == "" && queryParams.Get(queries.StartTimeParam) == "" && queryParams.Get(queries.EndTimeParam) == "" {
+		queryParams.Set(queries.LookbackParam, defaultLookback)
+	}
+	from, to, err := queries.ComputeTimeRange(queryParams, tables, maxLookBack)
+	if err != nil {
+		return nil, err
+	}
+	resultJson, err := queries.RunQuery(def.Query, queryParams, tables, maxLookBack, requestId)
+	if err != nil {
+		return nil, errors.Wrapf(err, "query %v of report %v failed", def.Query, def.Name)
+	}
+	data := Data{Name: def.Name, Title: def.Title, Generated: time.Now().UTC(), From: from.UTC(), To: to.UTC(), Params: queryParams}
+	err = json.Unmarshal(resultJson, &data.Result)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decode result of query %v", def.Query)
+	}
+
+	out := &bytes.Buffer{}
+	if format == FormatHtml {
+		tmpl, err := htmltemplate.New(def.Name).Funcs(htmlFuncs).Parse(def.Html)
+		if err != nil {
+			return nil, err
+		}
+		err = tmpl.Execute(out, data)
+	} else {
+		tmpl, err := texttemplate.New(def.Name).Funcs(textFuncs).Parse(def.Markdown)
+		if err != nil {
+			return nil, err
+		}
+		err = tmpl.Execute(out, data)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to render report %v", def.Name)
+	}
+	return out.Bytes(), nil
+}
+
+var funcs = map[string]interface{}{
+	"time":     formatTime,
+	"duration": formatDuration,
+	"values":   distinctValues,
+	"where":    where,
+	"first":    first,
+	"join":     join,
+	"md":       escapeMarkdown,
+}
+
+var textFuncs = texttemplate.FuncMap(funcs)
+var htmlFuncs = htmltemplate.FuncMap(funcs)
+
+// Unix seconds, as they are in query results, or a time.Time
+func formatTime(value interface{}) string {
+	switch v := value.(type) {
+	case time.Time:
+		return v.UTC().Format("2006-01-02 15:04 MST")
+	case float64:
+		return time.Unix(int64(v), 0).UTC().Format("2006-01-02 15:04 MST")
+	}
+	return fmt.Sprint(value)
+}
+
+func formatDuration(seconds interface{}) string {
+	if v, ok := seconds.(float64); ok {
+		return (time.Duration(v) * time.Second).String()
+	}
+	return fmt.Sprint(seconds)
+}
+
+func field(item interface{}, name string) interface{} {
+	if object, ok := item.(map[string]interface{}); ok {
+		return object[name]
+	}
+	return nil
+}
+
+func items(list interface{}) []interface{} {
+	if typed, ok := list.([]interface{}); ok {
+		return typed
+	}
+	return nil
+}
+
+// Sorted distinct values of a field over the items of one or more lists, like the namespaces of a snapshot diff
+func distinctValues(name string, lists ...interface{}) []string {
+	seen := map[string]bool{}
+	for _, list := range lists {
+		for _, item := range items(list) {
+			seen[fmt.Sprint(defaultString(field(item, name)))] = true
+		}
+	}
+	ret := []string{}
+	for value := range seen {
+		ret = append(ret, value)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// Items of list whose field is value, a missing field matches ""
+func where(list interface{}, name string, value string) []interface{} {
+	ret := []interface{}{}
+	for _, item := range items(list) {
+		if fmt.Sprint(defaultString(field(item, name))) == value {
+			ret = append(ret, item)
+		}
+	}
+	return ret
+}
+
+func first(count int, list interface{}) []interface{} {
+	all := items(list)
+	if len(all) > count {
+		return all[:count]
+	}
+	return all
+}
+
+func join(separator string, list interface{}) string {
+	parts := []string{}
+	for _, item := range items(list) {
+		parts = append(parts, fmt.Sprint(item))
+	}
+	return strings.Join(parts, separator)
+}
+
+func defaultString(value interface{}) interface{} {
+	if value == nil {
+		return ""
+	}
+	return value
+}
+
+// Keeps a value on one line of a markdown table
+func escapeMarkdown(value interface{}) string {
+	text := fmt.Sprint(defaultString(value))
+	text = strings.ReplaceAll(text, "|", "\\|")
+	return strings.Join(strings.Fields(text), " ")
+}
@@ -0,0 +1,189 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package report
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+var someTs = time.Date(2019, 3, 4, 5, 0, 0, 0, time.UTC)
+
+func helper_reportTables(t *testing.T) typed.Tables {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	results := []struct {
+		kind      string
+		namespace string
+		name      string
+		offset    time.Duration
+		watchType typed.KubeWatchResult_WatchType
+		payload   string
+	}{
+		{"ConfigMap", "alpha", "settings", 10 * time.Minute, typed.KubeWatchResult_ADD, `{"metadata":{"name":"settings","uid":"u1"},"data":{"a":"1"}}`},
+		{"ConfigMap", "beta", "flags", -10 * time.Minute, typed.KubeWatchResult_ADD, `{"metadata":{"name":"flags","uid":"u2"},"data":{"a":"1"}}`},
+		{"ConfigMap", "beta", "flags", 20 * time.Minute, typed.KubeWatchResult_UPDATE, `{"metadata":{"name":"flags","uid":"u2"},"data":{"a":"2"}}`},
+	}
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		for _, result := range results {
+			keyTs := someTs.Add(result.offset)
+			key := typed.NewWatchTableKey(untyped.GetPartitionId(keyTs), result.kind, result.namespace, result.name, keyTs)
+			ts, _ := ptypes.TimestampProto(keyTs)
+			err := tables.WatchTable().Set(txn, key.String(), &typed.KubeWatchResult{Kind: result.kind, Timestamp: ts, WatchType: result.watchType, Payload: result.payload})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+	return tables
+}
+
+func helper_reportParams() url.Values {
+	return url.Values{
+		"start_time": []string{fmt.Sprint(someTs.Unix())},
+		"end_time":   []string{fmt.Sprint(someTs.Add(time.Hour).Unix())},
+	}
+}
+
+func Test_Render_WeeklyChanges(t *testing.T) {
+	tables := helper_reportTables(t)
+	reports, err := NewMap(nil)
+	assert.Nil(t, err)
+
+	body, err := Render(reports["weekly-changes"], "", helper_reportParams(), tables, 24*time.Hour, "")
+	assert.Nil(t, err)
+	expected := `# Changes by namespace
+
+2019-03-04 05:00 UTC to 2019-03-04 06:00 UTC
+
+## alpha
+
+- Added ConfigMap settings: 1 key
+
+## beta
+
+- Changed ConfigMap flags: data.a
+`
+	assert.Equal(t, expected, string(body))
+
+	body, err = Render(reports["weekly-changes"], FormatHtml, helper_reportParams(), tables, 24*time.Hour, "")
+	assert.Nil(t, err)
+	assert.Contains(t, string(body), "<h2>beta</h2>")
+	assert.Contains(t, string(body), "<li>Changed ConfigMap flags: data.a</li>")
+}
+
+func Test_Render_BuiltinsOnEmptyStore(t *testing.T) {
+	tables := helper_reportTables(t)
+	reports, err := NewMap(nil)
+	assert.Nil(t, err)
+	params := helper_reportParams()
+	params.Set("namespace", "empty")
+
+	for _, name := range reports.Names() {
+		for _, format := range reports[name].Formats() {
+			body, err := Render(reports[name], format, params, tables, 24*time.Hour, "")
+			assert.Nil(t, err, name)
+			assert.Contains(t, string(body), "No ", name)
+		}
+	}
+}
+
+func Test_Render_ParamsOverrideDefinition(t *testing.T) {
+	tables := helper_reportTables(t)
+	def := Definition{
+		Name:     "namespace-changes",
+		Query:    "GetSnapshotDiff",
+		Params:   map[string]string{"namespace": "alpha"},
+		Markdown: `{{range .Result.added}}{{.namespace}}/{{.name}} {{end}}`,
+	}
+	assert.Nil(t, def.Validate())
+
+	body, err := Render(def, "", helper_reportParams(), tables, 24*time.Hour, "")
+	assert.Nil(t, err)
+	assert.Equal(t, "alpha/settings ", string(body))
+
+	params := helper_reportParams()
+	params.Set("namespace", "beta")
+	body, err = Render(def, "", params, tables, 24*time.Hour, "")
+	assert.Nil(t, err)
+	assert.Equal(t, "", string(body))
+
+	_, err = Render(def, FormatHtml, params, tables, 24*time.Hour, "")
+	assert.NotNil(t, err)
+}
+
+func Test_Definition_Validate(t *testing.T) {
+	good := Definition{Name: "r", Query: "GetSnapshotDiff", Markdown: "{{.Title}}"}
+	assert.Nil(t, good.Validate())
+
+	bad := []Definition{
+		{Query: "GetSnapshotDiff", Markdown: "x"},
+		{Name: "r", Query: "NoSuchQuery", Markdown: "x"},
+		{Name: "r", Query: "GetSnapshotDiff"},
+		{Name: "r", Query: "GetSnapshotDiff", Markdown: "{{.Title"},
+		{Name: "r", Query: "GetSnapshotDiff", Html: "{{nosuchfunc}}"},
+		{Name: "r", Query: "GetSnapshotDiff", Markdown: "x", Interval: time.Hour},
+		{Name: "r", Query: "GetSnapshotDiff", Markdown: "x", Interval: time.Hour, Webhook: "http://x", WebhookFormat: FormatHtml},
+	}
+	for _, def := range bad {
+		assert.NotNil(t, def.Validate(), "%+v", def)
+	}
+}
+
+func Test_NewMap_ConfiguredReplacesBuiltin(t *testing.T) {
+	reports, err := NewMap([]Definition{{Name: "rollouts", Query: "GetDeploymentRollouts", Markdown: "mine"}})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"rollouts", "top-warnings", "weekly-changes"}, reports.Names())
+	assert.Equal(t, "mine", reports["rollouts"].Markdown)
+}
+
+func Test_Scheduler_Push(t *testing.T) {
+	tables := helper_reportTables(t)
+	var contentType string
+	var received []byte
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		contentType = request.Header.Get("content-type")
+		received, _ = ioutil.ReadAll(request.Body)
+	}))
+	defer server.Close()
+
+	def := Definition{
+		Name:     "alpha",
+		Query:    "GetSnapshotDiff",
+		Params:   map[string]string{"namespace": "alpha", "start_time": fmt.Sprint(someTs.Unix()), "end_time": fmt.Sprint(someTs.Add(time.Hour).Unix())},
+		Markdown: `{{range .Result.added}}{{.name}}{{end}}`,
+		Html:     `<b>{{range .Result.added}}{{.name}}{{end}}</b>`,
+		Webhook:  server.URL,
+	}
+	scheduler := NewScheduler(Map{def.Name: def}, tables, 24*time.Hour)
+	assert.Nil(t, scheduler.Push(def))
+	assert.Equal(t, "settings", string(received))
+	assert.Equal(t, ContentType(FormatMarkdown), contentType)
+
+	def.WebhookFormat = FormatHtml
+	assert.Nil(t, scheduler.Push(def))
+	assert.Equal(t, "<b>settings</b>", string(received))
+	assert.Equal(t, ContentType(FormatHtml), contentType)
+}
@@ -0,0 +1,119 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+)
+
+const webhookTimeout = 30 * time.Second
+
+var (
+	metricReportPushedCount = promauto.NewCounterVec(prometheus.CounterOpts{Name: "sloop_report_pushed_count"}, []string{"report"})
+	metricReportFailedCount = promauto.NewCounterVec(prometheus.CounterOpts{Name: "sloop_report_failed_count"}, []string{"report"})
+)
+
+// Pushes the reports with an interval to their webhooks
+type Scheduler struct {
+	reports     Map
+	tables      typed.Tables
+	maxLookBack time.Duration
+	client      *http.Client
+	done        chan bool
+	wg          *sync.WaitGroup
+}
+
+func NewScheduler(reports Map, tables typed.Tables, maxLookBack time.Duration) *Scheduler {
+	return &Scheduler{
+		reports:     reports,
+		tables:      tables,
+		maxLookBack: maxLookBack,
+		client:      &http.Client{Timeout: webhookTimeout},
+		done:        make(chan bool),
+		wg:          &sync.WaitGroup{},
+	}
+}
+
+// The first push of each report is one interval after the start, so restarts do not send the same report again
+func (s *Scheduler) Start() {
+	for _, name := range s.reports.Names() {
+		def := s.reports[name]
+		if def.Interval <= 0 {
+			continue
+		}
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			for {
+				select {
+				case <-s.done:
+					return
+				case <-time.After(def.Interval):
+				}
+				err := s.Push(def)
+				if err != nil {
+					glog.Errorf("Failed to push report %v to %v: %v", def.Name, def.Webhook, err)
+				}
+			}
+		}()
+	}
+}
+
+func (s *Scheduler) Stop() {
+	close(s.done)
+	s.wg.Wait()
+}
+
+// Renders a report in its webhook format and posts it to its webhook
+func (s *Scheduler) Push(def Definition) error {
+	requestId := fmt.Sprintf("report-%v", def.Name)
+	body, err := Render(def, "", nil, s.tables, s.maxLookBack, requestId)
+	if err != nil {
+		metricReportFailedCount.WithLabelValues(def.Name).Inc()
+		return err
+	}
+	err = s.post(def.Webhook, ContentType(def.defaultFormat()), body)
+	if err != nil {
+		metricReportFailedCount.WithLabelValues(def.Name).Inc()
+		return err
+	}
+	metricReportPushedCount.WithLabelValues(def.Name).Inc()
+	glog.Infof("Pushed report %v of %v bytes to %v", def.Name, len(body), def.Webhook)
+	return nil
+}
+
+func (s *Scheduler) post(url string, contentType string, body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrapf(err, "failed to build request for %v", url)
+	}
+	req.Header.Set("content-type", contentType)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "failed to post to %v", url)
+	}
+	defer resp.Body.Close()
+	_, _ = ioutil.ReadAll(resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook %v returned status %v", url, resp.StatusCode)
+	}
+	return nil
+}
@@ -19,17 +19,51 @@ import (
 	"strings"
 	"time"
 
+	"github.com/salesforce/sloop/pkg/sloop/autotune"
+	"github.com/salesforce/sloop/pkg/sloop/ingress"
+	"github.com/salesforce/sloop/pkg/sloop/processing"
+	"github.com/salesforce/sloop/pkg/sloop/report"
+	"github.com/salesforce/sloop/pkg/sloop/shard"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/salesforce/sloop/pkg/sloop/tenant"
 	"github.com/salesforce/sloop/pkg/sloop/webserver"
 )
 
 const sloopConfigEnvVar = "SLOOP_CONFIG"
 
+// FrozenTime that stops the clock at the newest watch result of the playback file
+const FrozenTimePlayback = "playback"
+
 type SloopConfig struct {
 	// These fields can only come from command line
 	ConfigFile string
 	// These fields can only come from file because they use complex types
 	LeftBarLinks  []webserver.LinkTemplate         `json:"leftBarLinks"`
 	ResourceLinks []webserver.ResourceLinkTemplate `json:"resourceLinks"`
+	// Shard name -> list of kinds owned by that shard
+	ShardMap shard.Map `json:"shardMap"`
+	// Shard name -> base url of that shard (including cluster context).  Setting this makes this instance a query
+	// front end that fans out to the shards
+	ShardEndpoints map[string]string `json:"shardEndpoints"`
+	// Codec used to store payloads, with optional per-kind overrides.  The default can also be set on the cmd line
+	PayloadCodecs typed.CodecConfig `json:"payloadCodecs"`
+	// Kind -> sampling policy, for kinds that churn too much to keep every payload version
+	Sampling processing.SamplingConfig `json:"sampling"`
+	// Kind -> bounds of the resync and sampling intervals that are tuned to keep the store growing at the target
+	AutoTune autotune.Config `json:"autoTune"`
+	// Rules for dropping or sampling noisy watch results before they are stored
+	IngestFilters ingress.IngestFilters `json:"ingestFilters"`
+	// Tenant name -> namespaces, retention and quota of that tenant.  Setting this makes queries need a tenant
+	Tenants tenant.Map `json:"tenants"`
+	// Query name, like GetEventData, or endpoint path below the context, like /export -> maximum response bytes.
+	// These override maxQueryResponseBytes, and 0 is no limit
+	ResponseLimits map[string]int64 `json:"responseLimits"`
+	// Query name or endpoint path -> latency target and objective, like responseLimits.  querySloLatency sets the one
+	// of /data, which is the one of every query without its own
+	QuerySlos webserver.QuerySlos `json:"querySlos"`
+	// Reports served on /report, and pushed to a webhook when they have an interval, in addition to the built in ones
+	Reports []report.Definition `json:"reports"`
 	// Normal fields that can come from file or cmd line
 	DisableKubeWatcher       bool          `json:"disableKubeWatch"`
 	KubeWatchResyncInterval  time.Duration `json:"kubeWatchResyncInterval"`
@@ -38,14 +72,23 @@ type SloopConfig struct {
 	Port                     int           `json:"port"`
 	StoreRoot                string        `json:"storeRoot"`
 	MaxLookback              time.Duration `json:"maxLookBack"`
+	EventRetention           time.Duration `json:"eventRetention"`
 	MaxDiskMb                int           `json:"maxDiskMb"`
 	DebugPlaybackFile        string        `json:"debugPlaybackFile"`
+	PlaybackMerge            bool          `json:"playbackMerge"`
+	FrozenTime               string        `json:"frozenTime"`
+	WatchSigningKeyFile      string        `json:"watchSigningKeyFile"`
 	DebugRecordFile          string        `json:"debugRecordFile"`
 	DeletionBatchSize        int           `json:"deletionBatchSize"`
+	DeletionBatchSleep       time.Duration `json:"deletionBatchSleep"`
+	GCMaxConcurrentTables    int           `json:"gcMaxConcurrentTables"`
 	UseMockBadger            bool          `json:"mockBadger"`
 	DisableStoreManager      bool          `json:"disableStoreManager"`
 	CleanupFrequency         time.Duration `json:"cleanupFrequency" validate:"min=1h,max=120h"`
 	KeepMinorNodeUpdates     bool          `json:"keepMinorNodeUpdates"`
+	CompactWatchKeyNames     bool          `json:"compactWatchKeyNames"`
+	EventFoldWindow          time.Duration `json:"eventFoldWindow"`
+	ClockSkewThreshold       time.Duration `json:"clockSkewThreshold"`
 	DefaultNamespace         string        `json:"defaultNamespace"`
 	DefaultKind              string        `json:"defaultKind"`
 	DefaultLookback          string        `json:"defaultLookback"`
@@ -72,8 +115,48 @@ type SloopConfig struct {
 	BadgerSyncWrites         bool          `json:"badgerSyncWrites"`
 	BadgerVLogFileIOMapping  bool          `json:"badgerVLogFileIOMapping"`
 	BadgerVLogTruncate       bool          `json:"badgerVLogTruncate"`
+	RecoverStore             bool          `json:"recoverStore"`
 	EnableDeleteKeys         bool          `json:"enableDeleteKeys"`
 	BadgerDetailLogEnabled   bool          `json:"badgerDetailLogEnabled"`
+	ShardName                string        `json:"shardName"`
+	EnableReplay             bool          `json:"enableReplay"`
+	EnableSync               bool          `json:"enableSync"`
+	Standby                  bool          `json:"standby"`
+	StandbyUrl               string        `json:"standbyUrl"`
+	StandbyInterval          time.Duration `json:"standbyInterval"`
+	EnableCompactionApi      bool          `json:"enableCompactionApi"`
+	EnableReprocessApi       bool          `json:"enableReprocessApi"`
+	EnablePurgeApi           bool          `json:"enablePurgeApi"`
+	PurgeSoftDeleteTtl       time.Duration `json:"purgeSoftDeleteTtl"`
+	EnableFreezeApi          bool          `json:"enableFreezeApi"`
+	EnableRetentionApi       bool          `json:"enableRetentionApi"`
+	ExportSpillDir           string        `json:"exportSpillDir"`
+	AnonymizationSaltFile    string        `json:"anonymizationSaltFile"`
+	TenantHeader             string        `json:"tenantHeader"`
+	TenantAdmin              string        `json:"tenantAdmin"`
+	QueryMaxKeysPerSec       int           `json:"queryMaxKeysPerSec"`
+	StorageFaults            string        `json:"storageFaults"`
+	QueryMaxBytesPerSec      int           `json:"queryMaxBytesPerSec"`
+	BudgetReportFreq         time.Duration `json:"budgetReportFreq"`
+	AuthMode                 string        `json:"authMode"`
+	AuthUserHeader           string        `json:"authUserHeader"`
+	AuthGroupsHeader         string        `json:"authGroupsHeader"`
+	AuthAllowedGroups        string        `json:"authAllowedGroups"`
+	MaxConcurrentQueries     int           `json:"maxConcurrentQueries"`
+	QueryAuditSize           int           `json:"queryAuditSize"`
+	MaxQueryResponseBytes    int64         `json:"maxQueryResponseBytes"`
+	QuerySloLatency          time.Duration `json:"querySloLatency"`
+	QuerySloObjective        float64       `json:"querySloObjective"`
+	TraceExemplars           bool          `json:"traceExemplars"`
+	WarmUpPartitions         int           `json:"warmUpPartitions"`
+	WarmUpValueTables        string        `json:"warmUpValueTables"`
+	SelfFootprintInterval    time.Duration `json:"selfFootprintInterval"`
+	SelfTestSamples          int           `json:"selfTestSamples"`
+	IdleAfter                time.Duration `json:"idleAfter"`
+	IdleSlowdown             int           `json:"idleSlowdown"`
+	ProbeNamespace           string        `json:"probeNamespace"`
+	ProbeInterval            time.Duration `json:"probeInterval"`
+	ProbeSla                 time.Duration `json:"probeSla"`
 }
 
 func registerFlags(fs *flag.FlagSet, config *SloopConfig) {
@@ -86,17 +169,25 @@ func registerFlags(fs *flag.FlagSet, config *SloopConfig) {
 	fs.IntVar(&config.Port, "port", config.Port, "Web server port")
 	fs.StringVar(&config.StoreRoot, "store-root", config.StoreRoot, "Path to store history data")
 	fs.DurationVar(&config.MaxLookback, "max-look-back", config.MaxLookback, "Max history data to keep")
+	fs.DurationVar(&config.EventRetention, "event-retention", config.EventRetention, "Max history of event counts, their rollups and event folds to keep, which can be longer than max-look-back.  0 = same as max-look-back")
 	fs.IntVar(&config.MaxDiskMb, "max-disk-mb", config.MaxDiskMb, "Max disk storage in MB")
 	fs.StringVar(&config.DebugPlaybackFile, "playback-file", config.DebugPlaybackFile, "Read watch data from a playback file")
+	fs.StringVar(&config.FrozenTime, "frozen-time", config.FrozenTime, "Stop the clock sloop reads the current time from at this RFC3339 time, or at the newest watch result of playback-file with 'playback', so queries, partitions and GC see the same time on every run.  Empty = wall clock")
+	fs.BoolVar(&config.PlaybackMerge, "playback-merge", config.PlaybackMerge, "Merge the playback file into a store that already has data, skipping the watch results it already has")
+	fs.StringVar(&config.WatchSigningKeyFile, "watch-signing-key-file", config.WatchSigningKeyFile, "File with the HMAC key used to sign stored watch results, for example a secret mounted from a KMS.  Empty = no signing")
 	fs.StringVar(&config.DebugRecordFile, "record-file", config.DebugRecordFile, "Record watch data to a playback file")
 	fs.BoolVar(&config.UseMockBadger, "use-mock-badger", config.UseMockBadger, "Use a fake in-memory mock of badger")
 	fs.BoolVar(&config.DisableStoreManager, "disable-store-manager", config.DisableStoreManager, "Turn off store manager which is to clean up database")
 	fs.DurationVar(&config.CleanupFrequency, "cleanup-frequency", config.CleanupFrequency, "Frequency between subsequent runs for the database cleanup")
 	fs.BoolVar(&config.KeepMinorNodeUpdates, "keep-minor-node-updates", config.KeepMinorNodeUpdates, "Keep all node updates even if change is only condition timestamps")
+	fs.DurationVar(&config.EventFoldWindow, "event-fold-window", config.EventFoldWindow, "Fold repeated events with the same involved object, reason and message that start within this window into one record.  GetEventData returns the first event with the count and first and last timestamps of the whole fold.  0 = disabled")
+	fs.DurationVar(&config.ClockSkewThreshold, "clock-skew-threshold", config.ClockSkewThreshold, "Flag watch results whose event time looks off from the ingest time by more than this, which means the clocks of sloop and the cluster disagree.  0 = only export the sloop_clock_skew_seconds metric")
 	fs.StringVar(&config.DefaultLookback, "default-lookback", config.DefaultLookback, "Default UX filter lookback")
 	fs.StringVar(&config.DefaultKind, "default-kind", config.DefaultKind, "Default UX filter kind")
 	fs.StringVar(&config.DefaultNamespace, "default-namespace", config.DefaultNamespace, "Default UX filter namespace")
 	fs.IntVar(&config.DeletionBatchSize, "deletion-batch-size", config.DeletionBatchSize, "Size of batch for deletion")
+	fs.DurationVar(&config.DeletionBatchSleep, "deletion-batch-sleep", config.DeletionBatchSleep, "Pause after each batch of keys GC deletes when enable-delete-keys is set, so cleanup of huge partitions leaves room for queries and ingestion.  0 means no pause")
+	fs.IntVar(&config.GCMaxConcurrentTables, "gc-max-concurrent-tables", config.GCMaxConcurrentTables, "Number of tables of a partition GC deletes at the same time")
 	fs.StringVar(&config.UseKubeContext, "context", config.UseKubeContext, "Use a specific kubernetes context")
 	fs.StringVar(&config.DisplayContext, "display-context", config.DisplayContext, "Use this to override the display context.  When running in k8s the context is empty string.  This lets you override that (mainly useful if you are running many copies of sloop on different clusters) ")
 	fs.StringVar(&config.ApiServerHost, "apiserver-host", config.ApiServerHost, "Kubernetes API server endpoint")
@@ -121,7 +212,49 @@ func registerFlags(fs *flag.FlagSet, config *SloopConfig) {
 	fs.BoolVar(&config.EnableDeleteKeys, "enable-delete-keys", config.EnableDeleteKeys, "Use delete prefixes instead of dropPrefix for GC")
 	fs.BoolVar(&config.BadgerVLogFileIOMapping, "badger-vlog-fileIO-mapping", config.BadgerVLogFileIOMapping, "Indicates which file loading mode should be used for the value log data, in memory constrained environments the value is recommended to be true")
 	fs.BoolVar(&config.BadgerVLogTruncate, "badger-vlog-truncate", config.BadgerVLogTruncate, "Truncate value log if badger db offset is different from badger db size")
+	fs.BoolVar(&config.RecoverStore, "recover-store", config.RecoverStore, "When the store does not open, open it again with value log truncation, and after any truncation delete the keys whose values were lost and record them on /debug/recovery/, so sloop serves what is left instead of failing to start")
 	fs.BoolVar(&config.BadgerDetailLogEnabled, "badger-detail-log-enabled", config.BadgerDetailLogEnabled, "Turns on detailed logging of BadgerDB")
+	fs.StringVar(&config.PayloadCodecs.Default, "payload-codec", config.PayloadCodecs.Default, "Codec for storing payloads: identity, gzip, zstd or delta")
+	fs.BoolVar(&config.CompactWatchKeyNames, "compact-watch-key-names", config.CompactWatchKeyNames, "Store the names in watch keys as short ids from a dictionary, starting with the next partition.  Can not be turned off once a store has used it")
+	fs.BoolVar(&config.EnableReplay, "enable-replay", config.EnableReplay, "Enable the API for replaying stored watch results to a webhook")
+	fs.BoolVar(&config.EnableSync, "enable-sync", config.EnableSync, "Enable the API used by sloop sync to read watch results from this store and push missing ones into it")
+	fs.BoolVar(&config.Standby, "standby", config.Standby, "Run as a warm standby, which does not watch kubernetes and instead loads the backups a primary started with standby-url streams to it.  Restart it without this flag to take over from the primary")
+	fs.StringVar(&config.StandbyUrl, "standby-url", config.StandbyUrl, "Base url with the context of a sloop running with -standby, for example http://sloop-standby:8080/mycluster, to stream incremental backups of this store to.  Empty = no standby")
+	fs.DurationVar(&config.StandbyInterval, "standby-interval", config.StandbyInterval, "How often to stream what changed since the last backup to standby-url")
+	fs.BoolVar(&config.EnableCompactionApi, "enable-compaction-api", config.EnableCompactionApi, "Serve POST /admin/compact, which flattens the Badger LSM tree and runs value log GC to reclaim disk after large purges")
+	fs.BoolVar(&config.EnableReprocessApi, "enable-reprocess-api", config.EnableReprocessApi, "Serve /admin/reprocess, where a POST rebuilds the tables derived from stored watch results for a range of partitions, to backfill a new processor table or a processing fix")
+	fs.BoolVar(&config.EnablePurgeApi, "enable-purge-api", config.EnablePurgeApi, "Serve POST /admin/purge, which removes every key of a namespace from the store, and POST /admin/undelete, which restores a soft deleted one")
+	fs.BoolVar(&config.EnableFreezeApi, "enable-freeze-api", config.EnableFreezeApi, "Serve /admin/freeze, which keeps a range of partitions from GC until it is removed with POST /admin/unfreeze")
+	fs.BoolVar(&config.EnableRetentionApi, "enable-retention-api", config.EnableRetentionApi, "Serve /admin/retention/override, which keeps the data of a namespace past the retention limits until the override expires.  Needs auth-mode")
+	fs.DurationVar(&config.PurgeSoftDeleteTtl, "purge-soft-delete-ttl", config.PurgeSoftDeleteTtl, "How long purges keep the keys of a namespace in a quarantine they can be restored from before deleting them.  0 = purges delete right away")
+	fs.StringVar(&config.ExportSpillDir, "export-spill-dir", config.ExportSpillDir, "Directory for the temporary stores of exports run with spill=true.  Empty = the system temp dir")
+	fs.StringVar(&config.AnonymizationSaltFile, "anonymization-salt-file", config.AnonymizationSaltFile, "File with the salt used to hash names for exports and backups requested with anonymize=true.  The same salt always gives the same hashes.  Empty = anonymization is not available")
+	fs.StringVar(&config.TenantHeader, "tenant-header", config.TenantHeader, "Request header with the tenant of the caller when tenants are configured.  It must be set by an authenticating proxy in front of sloop, which also strips it from client requests")
+	fs.StringVar(&config.TenantAdmin, "tenant-admin", config.TenantAdmin, "Tenant header value that gets unrestricted access when tenants are configured.  Empty = nobody does")
+	fs.StringVar(&config.StorageFaults, "storage-faults", config.StorageFaults, "Testing only: fails a share of store operations and slows them down, like get=0.01,set=0.01,iterate=0.001,commit=0.05,latency=5ms,seed=1, to check that sloop handles storage errors.  Empty = off")
+	fs.IntVar(&config.QueryMaxKeysPerSec, "query-max-keys-per-sec", config.QueryMaxKeysPerSec, "Ceiling on the keys per second read by queries, shared by all of them, so heavy queries can not starve ingestion.  0 = unlimited")
+	fs.IntVar(&config.QueryMaxBytesPerSec, "query-max-bytes-per-sec", config.QueryMaxBytesPerSec, "Ceiling on the bytes per second read by queries, shared by all of them.  0 = unlimited")
+	fs.DurationVar(&config.BudgetReportFreq, "budget-report-freq", config.BudgetReportFreq, "How often to log the received and stored watch results and bytes of the last 24h by kind and namespace, which /debug/budget/ always serves.  0 = never")
+	fs.StringVar(&config.AuthMode, "auth-mode", config.AuthMode, "How callers are authenticated: proxy trusts the identity headers of an authenticating proxy in front of sloop, which must strip them from client requests.  Empty = no authentication")
+	fs.StringVar(&config.AuthUserHeader, "auth-user-header", config.AuthUserHeader, "Header with the user name for auth-mode=proxy.  Requests without it are rejected")
+	fs.StringVar(&config.AuthGroupsHeader, "auth-groups-header", config.AuthGroupsHeader, "Header with the comma separated groups of the user for auth-mode=proxy.  When tenants are set, a group named after a tenant or tenant-admin picks the tenant instead of tenant-header")
+	fs.StringVar(&config.AuthAllowedGroups, "auth-allowed-groups", config.AuthAllowedGroups, "Comma separated groups for auth-mode=proxy, one of which the user needs.  Empty = any user")
+	fs.IntVar(&config.MaxConcurrentQueries, "max-concurrent-queries", config.MaxConcurrentQueries, "Queries and exports that can run at once.  The others queue, and slots go to tenants by their queryWeight, or to users when there are no tenants, so one caller can not hold all of them.  0 = unlimited")
+	fs.IntVar(&config.WarmUpPartitions, "warmup-partitions", config.WarmUpPartitions, "Before serving, read the keys of the newest this many partitions so the first queries after a restart are not slower than later ones.  Ingestion starts right away.  0 = off")
+	fs.StringVar(&config.WarmUpValueTables, "warmup-value-tables", config.WarmUpValueTables, "Comma separated tables, like watch,ressum, whose values are also read by warmup-partitions")
+	fs.Int64Var(&config.MaxQueryResponseBytes, "max-query-response-bytes", config.MaxQueryResponseBytes, "Cut query responses at this many bytes and mark them truncated in the X-Sloop-Truncated header.  responseLimits in the config file sets limits by query or endpoint.  Response sizes are on the sloop_webserver_response_bytes metric.  0 = unlimited")
+	fs.DurationVar(&config.QuerySloLatency, "query-slo-latency", config.QuerySloLatency, "Latency target of every query without one in querySlos.  Compliance and burn rates over the last hour and day are on /slo/status and the sloop_query_slo_* metrics.  0 = off")
+	fs.Float64Var(&config.QuerySloObjective, "query-slo-objective", config.QuerySloObjective, "Fraction of queries that should be within query-slo-latency")
+	fs.BoolVar(&config.TraceExemplars, "trace-exemplars", config.TraceExemplars, "Attach the trace id from the traceparent header of requests in sampled OpenTelemetry traces as exemplars to sloop_query_latency_seconds")
+	fs.IntVar(&config.QueryAuditSize, "query-audit-size", config.QueryAuditSize, "Number of the latest queries and exports to keep in the store with the user, params, duration and result size, shown on /debug/queryaudit/.  0 = off")
+	fs.DurationVar(&config.SelfFootprintInterval, "self-footprint-interval", config.SelfFootprintInterval, "How often to store the memory, goroutines, store size and ingest queue depths of sloop itself, which GetSelfFootprint returns for a time range.  0 = off")
+	fs.IntVar(&config.SelfTestSamples, "self-test-samples", config.SelfTestSamples, "At startup, decode the values of the first this many keys of every table in every partition and check the partitions for gaps, with the results on /debug/selftest.  Runs in the background.  0 = off")
+	fs.DurationVar(&config.IdleAfter, "idle-after", config.IdleAfter, "After this long without requests, keep idle-slowdown times fewer resyncs and run value log GC that much less often, until the next request.  Health checks and metric scrapes do not count.  0 = never idle")
+	fs.IntVar(&config.IdleSlowdown, "idle-slowdown", config.IdleSlowdown, "How many times less background work sloop does while idle, see idle-after")
+	fs.StringVar(&config.ProbeNamespace, "probe-namespace", config.ProbeNamespace, "Every probe-interval, create, update and delete the sloop-probe ConfigMap in this namespace and check each change shows up in the store within probe-sla, with the time it took on sloop_probe_freshness_sec.  Needs rights to write ConfigMaps there.  Empty = off")
+	fs.DurationVar(&config.ProbeInterval, "probe-interval", config.ProbeInterval, "How often to probe, see probe-namespace")
+	fs.DurationVar(&config.ProbeSla, "probe-sla", config.ProbeSla, "How soon a probe change should show up in the store, see probe-namespace")
+	fs.StringVar(&config.ShardName, "shard-name", config.ShardName, "Run as this ingest shard and only watch the kinds assigned to it in shardMap")
 }
 
 func getDefaultConfig() *SloopConfig {
@@ -136,12 +269,17 @@ func getDefaultConfig() *SloopConfig {
 		MaxLookback:              time.Duration(14*24) * time.Hour,
 		MaxDiskMb:                32 * 1024,
 		DebugPlaybackFile:        "",
+		PlaybackMerge:            false,
+		WatchSigningKeyFile:      "",
 		DebugRecordFile:          "",
 		DeletionBatchSize:        1000,
+		DeletionBatchSleep:       0,
+		GCMaxConcurrentTables:    1,
 		UseMockBadger:            false,
 		DisableStoreManager:      false,
 		CleanupFrequency:         time.Minute * 30,
 		KeepMinorNodeUpdates:     false,
+		ClockSkewThreshold:       time.Minute * 5,
 		DefaultNamespace:         "default",
 		DefaultKind:              "_all",
 		DefaultLookback:          "1h",
@@ -170,6 +308,31 @@ func getDefaultConfig() *SloopConfig {
 		BadgerVLogTruncate:       true,
 		EnableDeleteKeys:         false,
 		BadgerDetailLogEnabled:   false,
+		ShardName:                "",
+		EnableReplay:             false,
+		EnableSync:               false,
+		StandbyInterval:          time.Minute,
+		EnableCompactionApi:      false,
+		EnableReprocessApi:       false,
+		EnablePurgeApi:           false,
+		PurgeSoftDeleteTtl:       72 * time.Hour,
+		EnableFreezeApi:          false,
+		EnableRetentionApi:       false,
+		ExportSpillDir:           "",
+		AnonymizationSaltFile:    "",
+		TenantHeader:             "X-Sloop-Tenant",
+		TenantAdmin:              "",
+		AuthUserHeader:           "X-Forwarded-User",
+		AuthGroupsHeader:         "X-Forwarded-Groups",
+		QueryMaxKeysPerSec:       0,
+		StorageFaults:            "",
+		QueryMaxBytesPerSec:      0,
+		QuerySloObjective:        0.99,
+		SelfFootprintInterval:    time.Minute,
+		SelfTestSamples:          10,
+		IdleSlowdown:             4,
+		ProbeInterval:            5 * time.Minute,
+		ProbeSla:                 time.Minute,
 	}
 	return &defaultConfig
 }
@@ -201,6 +364,18 @@ func (c *SloopConfig) ToYaml() string {
 	return string(b)
 }
 
+// QuerySlos with the one of querySloLatency for /data when it is not set there
+func (c *SloopConfig) AllQuerySlos() webserver.QuerySlos {
+	slos := webserver.QuerySlos{}
+	for name, slo := range c.QuerySlos {
+		slos[name] = slo
+	}
+	if _, ok := slos["/data"]; !ok && c.QuerySloLatency > 0 {
+		slos["/data"] = webserver.QuerySlo{Latency: c.QuerySloLatency, Objective: c.QuerySloObjective}
+	}
+	return slos
+}
+
 func (c *SloopConfig) Validate() error {
 	if c.MaxLookback <= 0 {
 		return fmt.Errorf("SloopConfig value MaxLookback can not be <= 0")
@@ -212,10 +387,151 @@ func (c *SloopConfig) Validate() error {
 	if err != nil {
 		return errors.Wrapf(err, "DefaultLookback is an invalid duration: %v", c.DefaultLookback)
 	}
+	if c.EventRetention < 0 {
+		return fmt.Errorf("SloopConfig value EventRetention can not be < 0")
+	}
+	if c.EventFoldWindow < 0 {
+		return fmt.Errorf("SloopConfig value EventFoldWindow can not be < 0")
+	}
+	if c.ClockSkewThreshold < 0 {
+		return fmt.Errorf("SloopConfig value ClockSkewThreshold can not be < 0")
+	}
+	if c.MaxConcurrentQueries < 0 {
+		return fmt.Errorf("SloopConfig value MaxConcurrentQueries can not be < 0")
+	}
+	if c.QueryAuditSize < 0 {
+		return fmt.Errorf("SloopConfig value QueryAuditSize can not be < 0")
+	}
+	if c.MaxQueryResponseBytes < 0 {
+		return fmt.Errorf("SloopConfig value MaxQueryResponseBytes can not be < 0")
+	}
+	for endpoint, limit := range c.ResponseLimits {
+		if limit < 0 {
+			return fmt.Errorf("SloopConfig responseLimits value for %v can not be < 0", endpoint)
+		}
+	}
+	if c.QuerySloLatency < 0 {
+		return fmt.Errorf("SloopConfig value QuerySloLatency can not be < 0")
+	}
+	err = c.AllQuerySlos().Validate()
+	if err != nil {
+		return errors.Wrap(err, "QuerySlos is invalid")
+	}
+	if c.FrozenTime == FrozenTimePlayback && c.DebugPlaybackFile == "" {
+		return fmt.Errorf("SloopConfig FrozenTime %v needs DebugPlaybackFile", FrozenTimePlayback)
+	}
+	if c.FrozenTime != "" && c.FrozenTime != FrozenTimePlayback {
+		_, err = time.Parse(time.RFC3339, c.FrozenTime)
+		if err != nil {
+			return errors.Wrap(err, "SloopConfig FrozenTime is not an RFC3339 time")
+		}
+	}
+	if c.Standby && c.StandbyUrl != "" {
+		return fmt.Errorf("SloopConfig can not set both Standby and StandbyUrl")
+	}
+	if c.PurgeSoftDeleteTtl < 0 {
+		return fmt.Errorf("SloopConfig value PurgeSoftDeleteTtl can not be < 0")
+	}
+	if c.StandbyUrl != "" && c.StandbyInterval <= 0 {
+		return fmt.Errorf("SloopConfig value StandbyInterval can not be <= 0")
+	}
+	if c.WarmUpPartitions < 0 {
+		return fmt.Errorf("SloopConfig value WarmUpPartitions can not be < 0")
+	}
+	if c.WarmUpValueTables != "" && c.WarmUpPartitions == 0 {
+		return fmt.Errorf("SloopConfig value WarmUpValueTables needs WarmUpPartitions")
+	}
+	if c.SelfFootprintInterval < 0 {
+		return fmt.Errorf("SloopConfig value SelfFootprintInterval can not be < 0")
+	}
+	if c.SelfTestSamples < 0 {
+		return fmt.Errorf("SloopConfig value SelfTestSamples can not be < 0")
+	}
+	if c.IdleAfter < 0 {
+		return fmt.Errorf("SloopConfig value IdleAfter can not be < 0")
+	}
+	if c.ProbeNamespace != "" && (c.ProbeInterval <= 0 || c.ProbeSla <= 0) {
+		return fmt.Errorf("SloopConfig values ProbeInterval and ProbeSla can not be <= 0")
+	}
+	if c.IdleAfter > 0 && c.IdleSlowdown < 1 {
+		return fmt.Errorf("SloopConfig value IdleSlowdown can not be < 1")
+	}
+	if c.BudgetReportFreq < 0 {
+		return fmt.Errorf("SloopConfig value BudgetReportFreq can not be < 0")
+	}
+	if c.QueryMaxKeysPerSec < 0 || c.QueryMaxBytesPerSec < 0 {
+		return fmt.Errorf("SloopConfig values QueryMaxKeysPerSec and QueryMaxBytesPerSec can not be < 0")
+	}
+	_, err = badgerwrap.ParseFaultConfig(c.StorageFaults)
+	if err != nil {
+		return errors.Wrap(err, "StorageFaults is invalid")
+	}
+	if c.DeletionBatchSleep < 0 {
+		return fmt.Errorf("SloopConfig value DeletionBatchSleep can not be < 0")
+	}
+	if c.GCMaxConcurrentTables < 0 {
+		return fmt.Errorf("SloopConfig value GCMaxConcurrentTables can not be < 0")
+	}
 	if c.CleanupFrequency < time.Minute*15 {
 		return fmt.Errorf("CleanupFrequency can not be less than 15 minutes.  Badger is lazy about freeing space " +
 			"on disk so we need to give it time to avoid over-correction")
 	}
+	err = c.PayloadCodecs.Validate()
+	if err != nil {
+		return errors.Wrap(err, "PayloadCodecs is invalid")
+	}
+	err = c.Sampling.Validate()
+	if err != nil {
+		return errors.Wrap(err, "Sampling is invalid")
+	}
+	err = c.AutoTune.Validate()
+	if err != nil {
+		return errors.Wrap(err, "AutoTune is invalid")
+	}
+	_, err = report.NewMap(c.Reports)
+	if err != nil {
+		return errors.Wrap(err, "Reports is invalid")
+	}
+	err = c.IngestFilters.Validate()
+	if err != nil {
+		return errors.Wrap(err, "IngestFilters is invalid")
+	}
+	err = c.ShardMap.Validate()
+	if err != nil {
+		return errors.Wrap(err, "ShardMap is invalid")
+	}
+	err = c.Tenants.Validate()
+	if err != nil {
+		return errors.Wrap(err, "Tenants is invalid")
+	}
+	if len(c.Tenants) > 0 {
+		if c.TenantHeader == "" && c.AuthMode == "" {
+			return fmt.Errorf("TenantHeader can not be empty when Tenants are set without AuthMode")
+		}
+		if _, ok := c.Tenants[c.TenantAdmin]; ok {
+			return fmt.Errorf("TenantAdmin %q can not also be a tenant", c.TenantAdmin)
+		}
+	}
+	if c.EnableRetentionApi && c.AuthMode == "" {
+		return fmt.Errorf("EnableRetentionApi needs an AuthMode, overrides are only made by authenticated callers")
+	}
+	if c.ShardName != "" {
+		if _, ok := c.ShardMap[c.ShardName]; !ok {
+			return fmt.Errorf("ShardName %q is not in ShardMap", c.ShardName)
+		}
+	}
+	for shardName := range c.ShardEndpoints {
+		if _, ok := c.ShardMap[shardName]; !ok {
+			return fmt.Errorf("ShardEndpoints has shard %q which is not in ShardMap", shardName)
+		}
+	}
+	if len(c.ShardEndpoints) > 0 {
+		for shardName := range c.ShardMap {
+			if c.ShardEndpoints[shardName] == "" {
+				return fmt.Errorf("ShardEndpoints is missing shard %q", shardName)
+			}
+		}
+	}
 	return nil
 }
 
@@ -3,10 +3,13 @@ package config
 import (
 	"encoding/json"
 	"github.com/ghodss/yaml"
+	"github.com/salesforce/sloop/pkg/sloop/shard"
+	"github.com/salesforce/sloop/pkg/sloop/webserver"
 	"github.com/stretchr/testify/assert"
 	"io/ioutil"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 func Test_loadFromJSONFile_Success(t *testing.T) {
@@ -44,3 +47,71 @@ func Test_loadFromNoFile_shouldPanic(t *testing.T) {
 	configfilename, _ := filepath.Abs("../testconfig.json")
 	assert.Panics(t, func() { loadFromFile(configfilename, config) }, "The code did not panic")
 }
+
+func Test_Validate_ShardNameMustBeInShardMap(t *testing.T) {
+	config := getDefaultConfig()
+	config.ShardMap = shard.Map{"pods": {"Pod"}}
+	config.ShardName = "nodes"
+	assert.NotNil(t, config.Validate())
+
+	config.ShardName = "pods"
+	assert.Nil(t, config.Validate())
+}
+
+func Test_Validate_ShardEndpointsMustCoverShardMap(t *testing.T) {
+	config := getDefaultConfig()
+	config.ShardMap = shard.Map{"pods": {"Pod"}, "nodes": {"Node"}}
+	config.ShardEndpoints = map[string]string{"pods": "http://pods:8080/ctx"}
+	assert.NotNil(t, config.Validate())
+
+	config.ShardEndpoints["nodes"] = "http://nodes:8080/ctx"
+	assert.Nil(t, config.Validate())
+}
+
+func Test_Validate_EventRetention(t *testing.T) {
+	config := getDefaultConfig()
+	config.EventRetention = -time.Hour
+	assert.NotNil(t, config.Validate())
+
+	config.EventRetention = 30 * 24 * time.Hour
+	assert.Nil(t, config.Validate())
+}
+
+func Test_Validate_RetentionApiNeedsAuth(t *testing.T) {
+	config := getDefaultConfig()
+	config.EnableRetentionApi = true
+	assert.NotNil(t, config.Validate())
+
+	config.AuthMode = "proxy"
+	assert.Nil(t, config.Validate())
+}
+
+func Test_AllQuerySlos_DefaultIsForData(t *testing.T) {
+	config := getDefaultConfig()
+	assert.Len(t, config.AllQuerySlos(), 0)
+
+	config.QuerySloLatency = 2 * time.Second
+	config.QuerySlos = webserver.QuerySlos{"GetEventData": {Latency: 10 * time.Second, Objective: 0.95}}
+	assert.Nil(t, config.Validate())
+	assert.Equal(t, webserver.QuerySlos{
+		"/data":        {Latency: 2 * time.Second, Objective: 0.99},
+		"GetEventData": {Latency: 10 * time.Second, Objective: 0.95},
+	}, config.AllQuerySlos())
+
+	config.QuerySloObjective = 1
+	assert.NotNil(t, config.Validate())
+}
+
+func Test_Validate_FrozenTime(t *testing.T) {
+	config := getDefaultConfig()
+	config.FrozenTime = "2019-01-02T03:04:05Z"
+	assert.Nil(t, config.Validate())
+
+	config.FrozenTime = "yesterday"
+	assert.NotNil(t, config.Validate())
+
+	config.FrozenTime = FrozenTimePlayback
+	assert.NotNil(t, config.Validate())
+	config.DebugPlaybackFile = "playback.yaml"
+	assert.Nil(t, config.Validate())
+}
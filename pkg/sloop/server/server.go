@@ -8,7 +8,9 @@
 package server
 
 import (
+	"bytes"
 	"flag"
+	"io/ioutil"
 	"os"
 	"path"
 	"strings"
@@ -16,6 +18,10 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/salesforce/sloop/pkg/sloop/autotune"
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/export"
+	"github.com/salesforce/sloop/pkg/sloop/idle"
 	"github.com/salesforce/sloop/pkg/sloop/ingress"
 	"github.com/salesforce/sloop/pkg/sloop/server/internal/config"
 	"github.com/salesforce/sloop/pkg/sloop/store/typed"
@@ -26,6 +32,8 @@ import (
 	"github.com/spf13/afero"
 
 	"github.com/salesforce/sloop/pkg/sloop/processing"
+	"github.com/salesforce/sloop/pkg/sloop/report"
+	"github.com/salesforce/sloop/pkg/sloop/standby"
 	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
 	"github.com/salesforce/sloop/pkg/sloop/storemanager"
 	"github.com/salesforce/sloop/pkg/sloop/webserver"
@@ -45,6 +53,15 @@ func RealMain() error {
 		return errors.Wrap(err, "config validation failed")
 	}
 
+	if conf.FrozenTime != "" {
+		frozenTime, err := getFrozenTime(conf)
+		if err != nil {
+			return err
+		}
+		common.SetClock(common.NewFrozenClock(frozenTime))
+		glog.Infof("Time is frozen at %v", frozenTime)
+	}
+
 	kubeContext, err := ingress.GetKubernetesContext(conf.ApiServerHost, conf.UseKubeContext)
 	if err != nil {
 		return errors.Wrap(err, "failed to get kubernetes context")
@@ -53,6 +70,8 @@ func RealMain() error {
 	// Channel used for updates from ingress to store
 	// The channel is owned by this function, and no external code should close this!
 	kubeWatchChan := make(chan typed.KubeWatchResult, 1000)
+	// Watch errors, throttling and relists, kept apart so they never hold up watch results
+	ingestAnnotationChan := make(chan *typed.IngestAnnotation, 100)
 
 	factory := &badgerwrap.BadgerFactory{}
 
@@ -76,11 +95,21 @@ func RealMain() error {
 		BadgerVLogTruncate:       conf.BadgerVLogTruncate,
 		BadgerDetailLogEnabled:   conf.BadgerDetailLogEnabled,
 	}
-	db, err := untyped.OpenStore(factory, storeConfig)
+	var db badgerwrap.DB
+	if conf.RecoverStore {
+		db, _, err = storemanager.OpenStoreWithRecovery(factory, storeConfig, &afero.Afero{Fs: afero.NewOsFs()})
+	} else {
+		db, err = untyped.OpenStore(factory, storeConfig)
+	}
 	if err != nil {
 		return errors.Wrap(err, "failed to init untyped store")
 	}
 	defer untyped.CloseStore(db)
+	if conf.StorageFaults != "" {
+		faults, _ := badgerwrap.ParseFaultConfig(conf.StorageFaults)
+		glog.Warningf("Injecting storage faults %+v, the store can not be trusted", faults)
+		db = badgerwrap.NewFaultyDB(db, faults)
+	}
 
 	if conf.RestoreDatabaseFile != "" {
 		glog.Infof("Restoring from backup file %q into context %q", conf.RestoreDatabaseFile, kubeContext)
@@ -91,27 +120,93 @@ func RealMain() error {
 		glog.Infof("Restored from backup file %q into context %q", conf.RestoreDatabaseFile, kubeContext)
 	}
 
+	err = typed.SetPayloadCodecs(conf.PayloadCodecs)
+	if err != nil {
+		return errors.Wrap(err, "failed to set payload codecs")
+	}
+
+	err = typed.StartWatchKeyNames(db, conf.CompactWatchKeyNames, common.Now())
+	if err != nil {
+		return err
+	}
+
+	var watchSigningKey []byte
+	if conf.WatchSigningKeyFile != "" {
+		watchSigningKey, err = readSecretFile(conf.WatchSigningKeyFile)
+		if err != nil {
+			return errors.Wrap(err, "failed to read watch signing key")
+		}
+		typed.SetWatchSigningKey(watchSigningKey)
+	}
+
+	var anonymizer *export.Anonymizer
+	if conf.AnonymizationSaltFile != "" {
+		salt, err := readSecretFile(conf.AnonymizationSaltFile)
+		if err != nil {
+			return errors.Wrap(err, "failed to read anonymization salt")
+		}
+		anonymizer, err = export.NewAnonymizer(string(salt))
+		if err != nil {
+			return err
+		}
+	}
+
+	var allowedGroups []string
+	if conf.AuthAllowedGroups != "" {
+		allowedGroups = strings.Split(conf.AuthAllowedGroups, ",")
+	}
+	authenticator, err := webserver.NewAuthenticator(conf.AuthMode, conf.AuthUserHeader, conf.AuthGroupsHeader, allowedGroups)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up authentication")
+	}
+
 	tables := typed.NewTableList(db)
-	processor := processing.NewProcessing(kubeWatchChan, tables, conf.KeepMinorNodeUpdates, conf.MaxLookback)
+	var selfTest *storemanager.SelfTest
+	if conf.SelfTestSamples > 0 {
+		selfTest = storemanager.NewSelfTest(tables, conf.SelfTestSamples)
+		selfTest.Start()
+	}
+	processor := processing.NewProcessing(kubeWatchChan, tables, conf.KeepMinorNodeUpdates, conf.MaxLookback, conf.EventFoldWindow, conf.Sampling, conf.ClockSkewThreshold, conf.Tenants)
 	processor.Start()
+	processor.StartIngestAnnotations(ingestAnnotationChan)
+
+	// Per-kind resync intervals past the informer resync are only needed when auto tuning or idle mode move them
+	var resyncThrottle *ingress.ResyncThrottle
+	if conf.AutoTune.Enabled() || conf.IdleAfter > 0 {
+		resyncThrottle = ingress.NewResyncThrottle(conf.KubeWatchResyncInterval)
+	}
 
 	// Real kubernetes watcher
 	var kubeWatcherSource ingress.KubeWatcher
-	if !conf.DisableKubeWatcher {
+	var prober *ingress.Prober
+	// A standby gets its data from the primary
+	if !conf.DisableKubeWatcher && !conf.Standby {
 		kubeClient, err := ingress.MakeKubernetesClient(conf.ApiServerHost, kubeContext)
 		if err != nil {
 			return errors.Wrap(err, "failed to create kubernetes client")
 		}
+		ingestFilters, err := conf.IngestFilters.Compile()
+		if err != nil {
+			return errors.Wrap(err, "failed to compile ingest filters")
+		}
 
-		kubeWatcherSource, err = ingress.NewKubeWatcherSource(kubeClient, kubeWatchChan, conf.KubeWatchResyncInterval, conf.WatchCrds, conf.CrdRefreshInterval, conf.ApiServerHost, kubeContext)
+		kubeWatcherSource, err = ingress.NewKubeWatcherSource(kubeClient, kubeWatchChan, conf.KubeWatchResyncInterval, conf.WatchCrds, conf.CrdRefreshInterval, conf.ApiServerHost, kubeContext, conf.ShardMap.KindFilter(conf.ShardName), ingestAnnotationChan, ingestFilters, resyncThrottle)
 		if err != nil {
 			return errors.Wrap(err, "failed to initialize kubeWatcher")
 		}
+		if conf.ProbeNamespace != "" {
+			prober = ingress.NewProber(kubeClient, tables, conf.ProbeNamespace, conf.ProbeInterval, conf.ProbeSla)
+			prober.Start()
+		}
 	}
 
 	// File playback
 	if conf.DebugPlaybackFile != "" {
-		err = ingress.PlayFile(kubeWatchChan, conf.DebugPlaybackFile)
+		if conf.PlaybackMerge {
+			err = ingress.MergePlayFile(kubeWatchChan, conf.DebugPlaybackFile, tables)
+		} else {
+			err = ingress.PlayFile(kubeWatchChan, conf.DebugPlaybackFile)
+		}
 		if err != nil {
 			return errors.Wrap(err, "failed to play back file")
 		}
@@ -127,39 +222,144 @@ func RealMain() error {
 	if !conf.DisableStoreManager {
 		fs := &afero.Afero{Fs: afero.NewOsFs()}
 		storeCfg := &storemanager.Config{
-			StoreRoot:          conf.StoreRoot,
-			Freq:               conf.CleanupFrequency,
-			TimeLimit:          conf.MaxLookback,
-			SizeLimitBytes:     conf.MaxDiskMb * 1024 * 1024,
-			BadgerDiscardRatio: conf.BadgerDiscardRatio,
-			BadgerVLogGCFreq:   conf.BadgerVLogGCFreq,
-			DeletionBatchSize:  conf.DeletionBatchSize,
-			GCThreshold:        conf.ThresholdForGC,
-			EnableDeleteKeys:   conf.EnableDeleteKeys,
+			StoreRoot:                 conf.StoreRoot,
+			Freq:                      conf.CleanupFrequency,
+			TimeLimit:                 conf.MaxLookback,
+			EventTimeLimit:            conf.EventRetention,
+			SizeLimitBytes:            conf.MaxDiskMb * 1024 * 1024,
+			BadgerDiscardRatio:        conf.BadgerDiscardRatio,
+			BadgerVLogGCFreq:          conf.BadgerVLogGCFreq,
+			DeletionBatchSize:         conf.DeletionBatchSize,
+			DeletionBatchSleep:        conf.DeletionBatchSleep,
+			MaxConcurrentTableDeletes: conf.GCMaxConcurrentTables,
+			GCThreshold:               conf.ThresholdForGC,
+			EnableDeleteKeys:          conf.EnableDeleteKeys,
+			Tenants:                   conf.Tenants,
+			BudgetReportFreq:          conf.BudgetReportFreq,
 		}
 		storemgr = storemanager.NewStoreManager(tables, storeCfg, fs)
 		storemgr.Start()
 	}
 
+	var idleMonitor *idle.Monitor
+	if conf.IdleAfter > 0 {
+		var throttled []idle.Throttled
+		if resyncThrottle != nil {
+			throttled = append(throttled, resyncThrottle)
+		}
+		if storemgr != nil {
+			throttled = append(throttled, storemgr)
+		}
+		idleMonitor = idle.NewMonitor(conf.IdleAfter, conf.IdleSlowdown, time.Now(), throttled...)
+		idleMonitor.Start()
+	}
+
+	var footprintRecorder *storemanager.FootprintRecorder
+	if conf.SelfFootprintInterval > 0 {
+		footprintRecorder = storemanager.NewFootprintRecorder(db, conf.SelfFootprintInterval, kubeWatchChan, ingestAnnotationChan)
+		footprintRecorder.Start()
+	}
+
+	var autoTuner *autotune.Controller
+	if conf.AutoTune.Enabled() && !conf.Standby {
+		targetMb := conf.AutoTune.TargetMb
+		if targetMb == 0 {
+			targetMb = conf.MaxDiskMb / 2
+		}
+		autoTuner = autotune.NewController(conf.AutoTune, tables, conf.MaxLookback, int64(targetMb)*1024*1024, resyncThrottle, processor)
+		autoTuner.Start()
+	}
+
 	displayContext := kubeContext
 	if conf.DisplayContext != "" {
 		displayContext = conf.DisplayContext
 	}
 
+	// Queries can look as far back as the longest retention, event queries still have data past max-look-back
+	queryLookback := conf.MaxLookback
+	if conf.EventRetention > queryLookback {
+		queryLookback = conf.EventRetention
+	}
+
 	webConfig := webserver.WebConfig{
-		BindAddress:      conf.BindAddress,
-		Port:             conf.Port,
-		WebFilesPath:     conf.WebFilesPath,
-		ConfigYaml:       conf.ToYaml(),
-		MaxLookback:      conf.MaxLookback,
-		DefaultNamespace: conf.DefaultNamespace,
-		DefaultLookback:  conf.DefaultLookback,
-		DefaultResources: conf.DefaultKind,
-		ResourceLinks:    conf.ResourceLinks,
-		LeftBarLinks:     conf.LeftBarLinks,
-		CurrentContext:   displayContext,
-	}
-	err = webserver.Run(webConfig, tables)
+		BindAddress:           conf.BindAddress,
+		Port:                  conf.Port,
+		WebFilesPath:          conf.WebFilesPath,
+		ConfigYaml:            conf.ToYaml(),
+		MaxLookback:           queryLookback,
+		DefaultNamespace:      conf.DefaultNamespace,
+		DefaultLookback:       conf.DefaultLookback,
+		DefaultResources:      conf.DefaultKind,
+		ResourceLinks:         conf.ResourceLinks,
+		LeftBarLinks:          conf.LeftBarLinks,
+		CurrentContext:        displayContext,
+		ShardMap:              conf.ShardMap,
+		ShardEndpoints:        conf.ShardEndpoints,
+		EnableReplay:          conf.EnableReplay,
+		WatchSigningKey:       watchSigningKey,
+		EnableSync:            conf.EnableSync,
+		SyncIngestChan:        kubeWatchChan,
+		ExportSpillDir:        conf.ExportSpillDir,
+		Anonymizer:            anonymizer,
+		Tenants:               conf.Tenants,
+		TenantHeader:          conf.TenantHeader,
+		TenantAdmin:           conf.TenantAdmin,
+		Authenticator:         authenticator,
+		MaxConcurrentQueries:  conf.MaxConcurrentQueries,
+		QueryAuditSize:        conf.QueryAuditSize,
+		MaxQueryResponseBytes: conf.MaxQueryResponseBytes,
+		ResponseLimits:        conf.ResponseLimits,
+		QuerySlos:             conf.AllQuerySlos(),
+		TraceExemplars:        conf.TraceExemplars,
+		EnableStandby:         conf.Standby,
+		IdleMonitor:           idleMonitor,
+	}
+	if conf.EnableCompactionApi {
+		webConfig.Compactor = storemanager.NewCompactor(db, conf.StoreRoot, &afero.Afero{Fs: afero.NewOsFs()}, conf.BadgerDiscardRatio)
+	}
+	if conf.EnableReprocessApi {
+		webConfig.Reprocessor = processor
+	}
+	if conf.EnablePurgeApi {
+		webConfig.Purger = storemanager.NewPurger(tables, conf.DeletionBatchSize, conf.PurgeSoftDeleteTtl)
+	}
+	webConfig.SelfTest = selfTest
+	if conf.EnableFreezeApi {
+		webConfig.Freezer = storemanager.NewFreezer(db)
+	}
+	if conf.EnableRetentionApi {
+		webConfig.RetentionOverrides = storemanager.NewRetentionOverrides(db)
+	}
+	// Queries read through their own Tables so they can be paced without slowing ingestion
+	queryTables := tables
+	if conf.QueryMaxKeysPerSec > 0 || conf.QueryMaxBytesPerSec > 0 {
+		queryTables = typed.NewTableList(badgerwrap.NewPacedDB(db, conf.QueryMaxKeysPerSec, conf.QueryMaxBytesPerSec))
+	}
+	if conf.WarmUpPartitions > 0 {
+		var valueTables []string
+		if conf.WarmUpValueTables != "" {
+			valueTables = strings.Split(conf.WarmUpValueTables, ",")
+		}
+		for _, tableName := range valueTables {
+			if !common.Contains(tables.GetTableNames(), tableName) {
+				return errors.Errorf("unknown table %q in warmup-value-tables", tableName)
+			}
+		}
+		storemanager.WarmUpAndLog(tables, conf.WarmUpPartitions, valueTables)
+	}
+	reports, err := report.NewMap(conf.Reports)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up reports")
+	}
+	webConfig.Reports = reports
+	reportScheduler := report.NewScheduler(reports, queryTables, queryLookback)
+	reportScheduler.Start()
+	var streamer *standby.Streamer
+	if conf.StandbyUrl != "" {
+		streamer = standby.NewStreamer(db, conf.StandbyUrl, conf.StandbyInterval)
+		streamer.Start()
+	}
+	err = webserver.Run(webConfig, queryTables)
 	if err != nil {
 		return errors.Wrap(err, "failed to run webserver")
 	}
@@ -168,10 +368,27 @@ func RealMain() error {
 	// 1. Shut down ingress so that it stops emitting events
 	// 2. Close the input channel which signals processing to finish work
 	// 3. Wait on processor to tell us all work is complete.  Store will not change after that
+	if prober != nil {
+		prober.Stop()
+	}
 	if kubeWatcherSource != nil {
 		kubeWatcherSource.Stop()
 	}
+	if streamer != nil {
+		streamer.Stop()
+	}
+	if autoTuner != nil {
+		autoTuner.Stop()
+	}
+	if idleMonitor != nil {
+		idleMonitor.Stop()
+	}
+	reportScheduler.Stop()
+	if footprintRecorder != nil {
+		footprintRecorder.Stop()
+	}
 	close(kubeWatchChan)
+	close(ingestAnnotationChan)
 	processor.Wait()
 
 	if recorder != nil {
@@ -199,3 +416,30 @@ func setupStdErrLogging() {
 		panic(err)
 	}
 }
+
+// Trailing whitespace is dropped so keys written with echo or mounted from a secret work as is
+func readSecretFile(filename string) ([]byte, error) {
+	key, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	key = bytes.TrimSpace(key)
+	if len(key) == 0 {
+		return nil, errors.Errorf("secret file %v is empty", filename)
+	}
+	return key, nil
+}
+
+func getFrozenTime(conf *config.SloopConfig) (time.Time, error) {
+	if conf.FrozenTime != config.FrozenTimePlayback {
+		return time.Parse(time.RFC3339, conf.FrozenTime)
+	}
+	end, err := ingress.PlaybackFileEnd(conf.DebugPlaybackFile)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "failed to read the end of the playback file")
+	}
+	if end.IsZero() {
+		return time.Time{}, errors.Errorf("playback file %v has no watch results to freeze the time at", conf.DebugPlaybackFile)
+	}
+	return end, nil
+}
@@ -0,0 +1,111 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package shard
+
+import (
+	"fmt"
+	"sort"
+)
+
+// WildcardKind can be listed under a single shard to give it ownership of every kind that is not explicitly
+// listed under any shard (this is how CRDs discovered at runtime end up somewhere)
+const WildcardKind = "*"
+
+// Map assigns resource kinds to named ingest shards.  Each shard runs as a separate sloop process with its
+// own store and only watches the kinds it owns.  A query process fans requests out to the shards and merges
+// the results.
+//
+// A kind may be listed under more than one shard.  This is mainly useful for Events, because event counts are
+// joined to resources within a single store, so each shard that wants heatmap overlays needs its own copy.
+type Map map[string][]string
+
+func (m Map) Validate() error {
+	wildcardOwner := ""
+	for shardName, kinds := range m {
+		if shardName == "" {
+			return fmt.Errorf("shard name can not be empty")
+		}
+		if len(kinds) == 0 {
+			return fmt.Errorf("shard %q does not own any kinds", shardName)
+		}
+		for _, kind := range kinds {
+			if kind == "" {
+				return fmt.Errorf("shard %q has an empty kind", shardName)
+			}
+			if kind == WildcardKind {
+				if wildcardOwner != "" {
+					return fmt.Errorf("both shard %q and %q own wildcard kind %q", wildcardOwner, shardName, WildcardKind)
+				}
+				wildcardOwner = shardName
+			}
+		}
+	}
+	return nil
+}
+
+// Returns true if this kind is listed under any shard (not counting the wildcard)
+func (m Map) isExplicitlyMapped(kind string) bool {
+	for _, kinds := range m {
+		for _, k := range kinds {
+			if k == kind {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (m Map) OwnsKind(shardName string, kind string) bool {
+	kinds, ok := m[shardName]
+	if !ok {
+		return false
+	}
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+	for _, k := range kinds {
+		if k == WildcardKind {
+			return !m.isExplicitlyMapped(kind)
+		}
+	}
+	return false
+}
+
+// Returns the sorted list of shards holding data for this kind
+func (m Map) ShardsForKind(kind string) []string {
+	var ret []string
+	for shardName := range m {
+		if m.OwnsKind(shardName, kind) {
+			ret = append(ret, shardName)
+		}
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+func (m Map) ShardNames() []string {
+	var ret []string
+	for shardName := range m {
+		ret = append(ret, shardName)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// Returns a filter for ingress that accepts only the kinds owned by shardName.  An empty shardName means
+// sharding is disabled and every kind is accepted.
+func (m Map) KindFilter(shardName string) func(string) bool {
+	if shardName == "" {
+		return func(string) bool { return true }
+	}
+	return func(kind string) bool {
+		return m.OwnsKind(shardName, kind)
+	}
+}
@@ -0,0 +1,54 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package shard
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var someShardMap = Map{
+	"pods":  {"Pod", "Event"},
+	"nodes": {"Node", "Event"},
+	"other": {WildcardKind},
+}
+
+func Test_ShardMap_Validate_Success(t *testing.T) {
+	assert.Nil(t, someShardMap.Validate())
+}
+
+func Test_ShardMap_Validate_TwoWildcardsFails(t *testing.T) {
+	m := Map{"a": {WildcardKind}, "b": {WildcardKind}}
+	assert.NotNil(t, m.Validate())
+}
+
+func Test_ShardMap_Validate_NoKindsFails(t *testing.T) {
+	m := Map{"a": {}}
+	assert.NotNil(t, m.Validate())
+}
+
+func Test_ShardMap_OwnsKind(t *testing.T) {
+	assert.True(t, someShardMap.OwnsKind("pods", "Pod"))
+	assert.False(t, someShardMap.OwnsKind("pods", "Node"))
+	assert.False(t, someShardMap.OwnsKind("other", "Pod"))
+	assert.True(t, someShardMap.OwnsKind("other", "Deployment"))
+	assert.False(t, someShardMap.OwnsKind("missing", "Pod"))
+}
+
+func Test_ShardMap_ShardsForKind(t *testing.T) {
+	assert.Equal(t, []string{"nodes", "pods"}, someShardMap.ShardsForKind("Event"))
+	assert.Equal(t, []string{"other"}, someShardMap.ShardsForKind("MyCrd"))
+	assert.Nil(t, Map{"pods": {"Pod"}}.ShardsForKind("Node"))
+}
+
+func Test_ShardMap_KindFilter(t *testing.T) {
+	assert.True(t, someShardMap.KindFilter("")("Anything"))
+	assert.True(t, someShardMap.KindFilter("nodes")("Node"))
+	assert.False(t, someShardMap.KindFilter("nodes")("Pod"))
+}
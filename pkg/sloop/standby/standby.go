@@ -0,0 +1,304 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package standby
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+/*
+Keeps a warm standby sloop close to a primary by streaming incremental Badger backups to it.  The primary sends
+everything written since the version the standby has, the standby loads it as is, so it has the same keys as the
+primary, derived tables included, and can take over with -standby turned off if the primary's disk dies.
+
+The standby does not watch kubernetes while it is one, and its own store manager cleans up old partitions, since the
+partitions the primary drops are just missing from the next backup instead of being deleted in it.
+*/
+
+const (
+	StatusPath = "/standby/status"
+	LoadPath   = "/standby/load"
+	SinceParam = "since"
+	// Sent after the backup in the body of a load, since it is only known once the backup is written
+	VersionTrailer = "X-Sloop-Backup-Version"
+
+	// Not partitioned, so it is never cleaned up
+	statusKey = "/standby/status"
+)
+
+var (
+	metricStandbyStreamedBytes = promauto.NewCounter(prometheus.CounterOpts{Name: "sloop_standby_streamed_bytes"})
+	metricStandbyStreamFailed  = promauto.NewCounter(prometheus.CounterOpts{Name: "sloop_standby_stream_failed_count"})
+	metricStandbyLoadedBytes   = promauto.NewCounter(prometheus.CounterOpts{Name: "sloop_standby_loaded_bytes"})
+	metricStandbyLastLoad      = promauto.NewGauge(prometheus.GaugeOpts{Name: "sloop_standby_last_load_timestamp_seconds"})
+)
+
+// Returned by a load with a since after the version the standby has, which would leave a gap
+var ErrGap = errors.New("since is after the version the standby has")
+
+type Status struct {
+	// Everything written on the primary before this version is loaded
+	Version  uint64    `json:"version"`
+	LastLoad time.Time `json:"lastLoad,omitempty"`
+	Loads    int64     `json:"loads"`
+	Bytes    int64     `json:"bytes"`
+}
+
+// The standby side, which loads the backups the primary streams to it
+type Receiver struct {
+	lock *sync.Mutex
+	db   badgerwrap.DB
+}
+
+func NewReceiver(db badgerwrap.DB) *Receiver {
+	return &Receiver{lock: &sync.Mutex{}, db: db}
+}
+
+func (r *Receiver) Status() (Status, error) {
+	status := Status{}
+	err := r.db.View(func(txn badgerwrap.Txn) error {
+		item, err := txn.Get([]byte(statusKey))
+		if err == badger.ErrKeyNotFound {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		value, err := item.ValueCopy(nil)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(value, &status)
+	})
+	if err != nil {
+		return status, errors.Wrap(err, "failed to read standby status")
+	}
+	return status, nil
+}
+
+/*
+Loads a backup of everything the primary wrote from version since on.  The version of the newest entry in it is read
+from trailer once body is read, so it has to be the trailer of the request body.  Backups overlapping what is already
+loaded are fine, entries keep their versions so loading one twice changes nothing
+*/
+func (r *Receiver) Load(since uint64, body io.Reader, trailer http.Header) (Status, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	status, err := r.Status()
+	if err != nil {
+		return status, err
+	}
+	if since > status.Version {
+		return status, ErrGap
+	}
+
+	counted := &countingReader{Reader: body}
+	err = r.db.Load(counted, runtime.NumCPU())
+	if err != nil {
+		return status, errors.Wrap(err, "failed to load backup")
+	}
+	// Trailers are only there once the body is read to the end
+	_, err = io.Copy(ioutil.Discard, counted)
+	if err != nil {
+		return status, errors.Wrap(err, "failed to read backup")
+	}
+	backupVersion, err := strconv.ParseUint(trailer.Get(VersionTrailer), 10, 64)
+	if err != nil {
+		return status, errors.Wrapf(err, "missing or invalid %v trailer", VersionTrailer)
+	}
+
+	// A backup without entries has version 0
+	if backupVersion+1 > status.Version {
+		status.Version = backupVersion + 1
+	}
+	if since > status.Version {
+		status.Version = since
+	}
+	status.LastLoad = time.Now()
+	status.Loads++
+	status.Bytes += counted.bytes
+	value, err := json.Marshal(status)
+	if err != nil {
+		return status, err
+	}
+	err = r.db.Update(func(txn badgerwrap.Txn) error {
+		return txn.Set([]byte(statusKey), value)
+	})
+	if err != nil {
+		return status, errors.Wrap(err, "failed to write standby status")
+	}
+	metricStandbyLoadedBytes.Add(float64(counted.bytes))
+	metricStandbyLastLoad.Set(float64(status.LastLoad.Unix()))
+	return status, nil
+}
+
+type countingReader struct {
+	io.Reader
+	bytes int64
+}
+
+func (r *countingReader) Read(data []byte) (int, error) {
+	n, err := r.Reader.Read(data)
+	r.bytes += int64(n)
+	return n, err
+}
+
+// The primary side, which streams a backup to the standby every interval
+type Streamer struct {
+	db         badgerwrap.DB
+	standbyUrl string
+	interval   time.Duration
+	client     *http.Client
+	// Version the next backup starts at, asked from the standby when it is not known
+	since      uint64
+	sinceKnown bool
+	done       chan bool
+	wg         *sync.WaitGroup
+}
+
+// standbyUrl is the base url of the standby including the cluster context, for example http://sloop-standby:8080/mycluster
+func NewStreamer(db badgerwrap.DB, standbyUrl string, interval time.Duration) *Streamer {
+	return &Streamer{
+		db:         db,
+		standbyUrl: strings.TrimSuffix(standbyUrl, "/"),
+		interval:   interval,
+		// Backups are streamed as they are read, a full one can take a while
+		client: &http.Client{},
+		done:   make(chan bool),
+		wg:     &sync.WaitGroup{},
+	}
+}
+
+func (s *Streamer) Start() {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		for {
+			status, err := s.StreamOnce()
+			if err != nil {
+				// Try again at the next interval, the standby might just be restarting
+				glog.Errorf("Streaming backup to standby %v failed: %v", s.standbyUrl, err)
+			} else {
+				glog.V(2).Infof("Standby %v is at version %v", s.standbyUrl, status.Version)
+			}
+			select {
+			case <-s.done:
+				return
+			case <-time.After(s.interval):
+			}
+		}
+	}()
+}
+
+func (s *Streamer) Stop() {
+	close(s.done)
+	s.wg.Wait()
+}
+
+// Streams one backup of everything since the last one to the standby
+func (s *Streamer) StreamOnce() (Status, error) {
+	status := Status{}
+	if !s.sinceKnown {
+		err := s.getStatus(&status)
+		if err != nil {
+			metricStandbyStreamFailed.Inc()
+			return status, err
+		}
+		s.since = status.Version
+		s.sinceKnown = true
+	}
+
+	// A full stream copies every closed partition to the standby, so it has to match its checksums first
+	if s.since == 0 {
+		report, err := typed.VerifyPartitionChecksums(s.db)
+		if err == nil {
+			err = report.Err()
+		}
+		if err != nil {
+			s.sinceKnown = false
+			metricStandbyStreamFailed.Inc()
+			return status, errors.Wrap(err, "not streaming a full backup")
+		}
+	}
+
+	target := fmt.Sprintf("%v%v?%v=%v", s.standbyUrl, LoadPath, SinceParam, s.since)
+	reader, writer := io.Pipe()
+	counted := &countingReader{Reader: reader}
+	req, err := http.NewRequest(http.MethodPost, target, counted)
+	if err != nil {
+		return status, errors.Wrapf(err, "failed to build request for %v", target)
+	}
+	req.Header.Set("content-type", "application/octet-stream")
+	req.ContentLength = -1
+	req.Trailer = http.Header{VersionTrailer: nil}
+	go func() {
+		version, err := s.db.Backup(writer, s.since)
+		// Written before the body ends, which is when the client sends the trailers
+		req.Trailer.Set(VersionTrailer, strconv.FormatUint(version, 10))
+		writer.CloseWithError(err)
+	}()
+
+	err = s.do(req, &status)
+	reader.Close()
+	metricStandbyStreamedBytes.Add(float64(counted.bytes))
+	if err != nil {
+		// The standby might have lost data or been replaced, so ask it where to start next time
+		s.sinceKnown = false
+		metricStandbyStreamFailed.Inc()
+		return status, err
+	}
+	s.since = status.Version
+	return status, nil
+}
+
+func (s *Streamer) getStatus(status *Status) error {
+	target := s.standbyUrl + StatusPath
+	req, err := http.NewRequest(http.MethodGet, target, nil)
+	if err != nil {
+		return errors.Wrapf(err, "failed to build request for %v", target)
+	}
+	return s.do(req, status)
+}
+
+func (s *Streamer) do(req *http.Request, out interface{}) error {
+	target := req.URL.String()
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "failed to call %v", target)
+	}
+	defer resp.Body.Close()
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read response from %v", target)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%v returned status %v: %v", target, resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+	err = json.Unmarshal(respBody, out)
+	if err != nil {
+		return errors.Wrapf(err, "failed to unmarshal response from %v", target)
+	}
+	return nil
+}
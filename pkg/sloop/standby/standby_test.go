@@ -0,0 +1,140 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package standby
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+// Backup and Load are not mocked, so these need real stores
+func openTestStore(t *testing.T, name string) (badgerwrap.DB, func()) {
+	dir, err := ioutil.TempDir("", name)
+	assert.Nil(t, err)
+	db, err := untyped.OpenStore(&badgerwrap.BadgerFactory{}, &untyped.Config{RootPath: dir, ConfigPartitionDuration: time.Hour})
+	assert.Nil(t, err)
+	return db, func() {
+		db.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+// The same api the webserver serves
+func newTestStandby(receiver *Receiver) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc(StatusPath, func(writer http.ResponseWriter, request *http.Request) {
+		status, _ := receiver.Status()
+		json.NewEncoder(writer).Encode(status)
+	})
+	mux.HandleFunc(LoadPath, func(writer http.ResponseWriter, request *http.Request) {
+		since, _ := strconv.ParseUint(request.URL.Query().Get(SinceParam), 10, 64)
+		status, err := receiver.Load(since, request.Body, request.Trailer)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusConflict)
+			return
+		}
+		json.NewEncoder(writer).Encode(status)
+	})
+	return httptest.NewServer(mux)
+}
+
+func setKey(t *testing.T, db badgerwrap.DB, key string, value string) {
+	err := db.Update(func(txn badgerwrap.Txn) error {
+		return txn.Set([]byte(key), []byte(value))
+	})
+	assert.Nil(t, err)
+}
+
+func getKey(t *testing.T, db badgerwrap.DB, key string) string {
+	value := ""
+	err := db.View(func(txn badgerwrap.Txn) error {
+		item, err := txn.Get([]byte(key))
+		if err != nil {
+			return err
+		}
+		bytes, err := item.ValueCopy(nil)
+		value = string(bytes)
+		return err
+	})
+	if err != nil {
+		return err.Error()
+	}
+	return value
+}
+
+func Test_Streamer_StreamsIncrementalBackups(t *testing.T) {
+	primary, closePrimary := openTestStore(t, "primary")
+	defer closePrimary()
+	standbyDb, closeStandby := openTestStore(t, "standby")
+	defer closeStandby()
+	receiver := NewReceiver(standbyDb)
+	server := newTestStandby(receiver)
+	defer server.Close()
+	streamer := NewStreamer(primary, server.URL+"/", time.Minute)
+
+	setKey(t, primary, "/watch/001546405200/a", "1")
+	setKey(t, primary, "/watch/001546405200/b", "1")
+	first, err := streamer.StreamOnce()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), first.Loads)
+	assert.True(t, first.Version > 0)
+	assert.Equal(t, "1", getKey(t, standbyDb, "/watch/001546405200/a"))
+	assert.Equal(t, "1", getKey(t, standbyDb, "/watch/001546405200/b"))
+
+	setKey(t, primary, "/watch/001546405200/a", "2")
+	err = primary.Update(func(txn badgerwrap.Txn) error {
+		return txn.Delete([]byte("/watch/001546405200/b"))
+	})
+	assert.Nil(t, err)
+	second, err := streamer.StreamOnce()
+	assert.Nil(t, err)
+	assert.True(t, second.Version > first.Version)
+	assert.True(t, second.Bytes > first.Bytes)
+	assert.Equal(t, "2", getKey(t, standbyDb, "/watch/001546405200/a"))
+	assert.Equal(t, "Key not found", getKey(t, standbyDb, "/watch/001546405200/b"))
+
+	// Nothing new keeps the version
+	third, err := streamer.StreamOnce()
+	assert.Nil(t, err)
+	assert.Equal(t, second.Version, third.Version)
+	assert.Equal(t, int64(3), third.Loads)
+
+	// A new streamer, like after a restart of the primary, starts where the standby is
+	status, err := receiver.Status()
+	assert.Nil(t, err)
+	assert.Equal(t, third, status)
+	restarted := NewStreamer(primary, server.URL, time.Minute)
+	setKey(t, primary, "/watch/001546405200/c", "1")
+	_, err = restarted.StreamOnce()
+	assert.Nil(t, err)
+	assert.Equal(t, "1", getKey(t, standbyDb, "/watch/001546405200/c"))
+}
+
+func Test_Receiver_GapIsRejected(t *testing.T) {
+	standbyDb, closeStandby := openTestStore(t, "standby")
+	defer closeStandby()
+	receiver := NewReceiver(standbyDb)
+
+	_, err := receiver.Load(10, strings.NewReader(""), http.Header{})
+	assert.Equal(t, ErrGap, err)
+	status, err := receiver.Status()
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0), status.Version)
+}
@@ -0,0 +1,125 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	badger "github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+const apiServiceAvailabilityTableName = "apiserviceavailability"
+
+// Registered so partition GC and reprocessing cover it like the core tables
+func init() {
+	RegisterTableName(apiServiceAvailabilityTableName)
+}
+
+/*
+Changes of the Available condition of each APIService, one record per APIService and partition:
+
+	/apiserviceavailability/<partition>/<apiservice name>/<uid>
+
+Values are not run through the payload codecs
+*/
+type ApiServiceAvailabilityTable struct {
+	tableName string
+}
+
+func OpenApiServiceAvailabilityTable() *ApiServiceAvailabilityTable {
+	return &ApiServiceAvailabilityTable{tableName: apiServiceAvailabilityTableName}
+}
+
+func (t *ApiServiceAvailabilityTable) TableName() string {
+	return t.tableName
+}
+
+func (t *ApiServiceAvailabilityTable) Key(partitionId string, name string, uid string) string {
+	return fmt.Sprintf("/%v/%v/%v/%v", t.tableName, partitionId, name, uid)
+}
+
+// Returns the partition, name and uid of a key
+func (t *ApiServiceAvailabilityTable) ParseKey(key string) (string, string, string, error) {
+	parts := strings.Split(key, "/")
+	if len(parts) != 5 || parts[0] != "" || parts[1] != t.tableName {
+		return "", "", "", fmt.Errorf("key %q is not in table %v", key, t.tableName)
+	}
+	return parts[2], parts[3], parts[4], nil
+}
+
+func (t *ApiServiceAvailabilityTable) Set(txn badgerwrap.Txn, key string, value *ApiServiceAvailability) error {
+	outb, err := proto.Marshal(value)
+	if err != nil {
+		return errors.Wrapf(err, "protobuf marshal for table %v failed", t.tableName)
+	}
+	err = txn.Set([]byte(key), outb)
+	if err != nil {
+		return errors.Wrapf(err, "set for table %v failed", t.tableName)
+	}
+	return nil
+}
+
+// Returns an empty record when the key is not in the table
+func (t *ApiServiceAvailabilityTable) GetOrDefault(txn badgerwrap.Txn, key string) (*ApiServiceAvailability, error) {
+	record := &ApiServiceAvailability{}
+	item, err := txn.Get([]byte(key))
+	if err == badger.ErrKeyNotFound {
+		return record, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "get failed for table %v", t.tableName)
+	}
+	valueBytes, err := item.ValueCopy([]byte{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "value copy failed for table %v", t.tableName)
+	}
+	err = proto.Unmarshal(valueBytes, record)
+	if err != nil {
+		return nil, errors.Wrapf(err, "protobuf unmarshal failed for table %v on value length %v", t.tableName, len(valueBytes))
+	}
+	return record, nil
+}
+
+// Returns the records of the partitions overlapping [startTime, endTime] by key
+func (t *ApiServiceAvailabilityTable) RangeRead(txn badgerwrap.Txn, startTime time.Time, endTime time.Time) (map[string]*ApiServiceAvailability, error) {
+	records := map[string]*ApiServiceAvailability{}
+	prefix := []byte("/" + t.tableName + "/")
+	endPartition := untyped.GetPartitionId(endTime)
+	iterOpt := badger.DefaultIteratorOptions
+	iterOpt.Prefix = prefix
+	itr := txn.NewIterator(iterOpt)
+	defer itr.Close()
+	for itr.Seek([]byte("/" + t.tableName + "/" + untyped.GetPartitionId(startTime) + "/")); itr.ValidForPrefix(prefix); itr.Next() {
+		key := string(itr.Item().Key())
+		partitionId, _, _, err := t.ParseKey(key)
+		if err != nil {
+			return nil, err
+		}
+		if partitionId > endPartition {
+			break
+		}
+		valueBytes, err := itr.Item().ValueCopy([]byte{})
+		if err != nil {
+			return nil, errors.Wrapf(err, "value copy failed for table %v", t.tableName)
+		}
+		record := &ApiServiceAvailability{}
+		err = proto.Unmarshal(valueBytes, record)
+		if err != nil {
+			return nil, errors.Wrapf(err, "protobuf unmarshal failed for table %v on value length %v", t.tableName, len(valueBytes))
+		}
+		records[key] = record
+	}
+	return records, nil
+}
@@ -0,0 +1,52 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+func Test_ApiServiceAvailabilityTable_RangeRead(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	table := OpenApiServiceAvailabilityTable()
+	start := time.Date(2021, 3, 4, 5, 50, 0, 0, time.UTC)
+
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		for i := 0; i < 3; i++ {
+			partitionId := untyped.GetPartitionId(start.Add(time.Duration(i) * time.Hour))
+			assert.Nil(t, table.Set(txn, table.Key(partitionId, "v1beta1.metrics.k8s.io", "uid1"), &ApiServiceAvailability{Version: "v1beta1", Group: "metrics.k8s.io"}))
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+
+	var records map[string]*ApiServiceAvailability
+	err = db.View(func(txn badgerwrap.Txn) error {
+		records, err = table.RangeRead(txn, start.Add(time.Hour), start.Add(2*time.Hour))
+		return err
+	})
+	assert.Nil(t, err)
+	assert.Len(t, records, 2)
+	for key := range records {
+		partitionId, name, uid, err := table.ParseKey(key)
+		assert.Nil(t, err)
+		assert.True(t, partitionId > untyped.GetPartitionId(start))
+		assert.Equal(t, "v1beta1.metrics.k8s.io", name)
+		assert.Equal(t, "uid1", uid)
+	}
+	assert.Contains(t, NewTableList(db).GetTableNames(), "apiserviceavailability")
+}
@@ -0,0 +1,106 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"fmt"
+
+	badger "github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+/*
+Named baselines of structured query results:
+
+	/baseline/<name>
+
+Like the query audit log it is not partitioned, so baselines are kept until they are deleted or replaced.  Values are
+not run through the payload codecs
+*/
+type BaselineTable struct {
+	tableName string
+}
+
+func OpenBaselineTable() *BaselineTable {
+	return &BaselineTable{tableName: "baseline"}
+}
+
+func (t *BaselineTable) TableName() string {
+	return t.tableName
+}
+
+func (t *BaselineTable) Key(name string) string {
+	return fmt.Sprintf("/%v/%v", t.tableName, name)
+}
+
+func (t *BaselineTable) Set(txn badgerwrap.Txn, value *QueryBaseline) error {
+	outb, err := proto.Marshal(value)
+	if err != nil {
+		return errors.Wrapf(err, "protobuf marshal for table %v failed", t.tableName)
+	}
+	err = txn.Set([]byte(t.Key(value.Name)), outb)
+	if err != nil {
+		return errors.Wrapf(err, "set for table %v failed", t.tableName)
+	}
+	return nil
+}
+
+// Returns nil when there is no baseline with the name
+func (t *BaselineTable) Get(txn badgerwrap.Txn, name string) (*QueryBaseline, error) {
+	item, err := txn.Get([]byte(t.Key(name)))
+	if err == badger.ErrKeyNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "get for table %v failed", t.tableName)
+	}
+	valueBytes, err := item.ValueCopy([]byte{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "value copy failed for table %v", t.tableName)
+	}
+	baseline := &QueryBaseline{}
+	err = proto.Unmarshal(valueBytes, baseline)
+	if err != nil {
+		return nil, errors.Wrapf(err, "protobuf unmarshal failed for table %v on value length %v", t.tableName, len(valueBytes))
+	}
+	return baseline, nil
+}
+
+func (t *BaselineTable) Delete(txn badgerwrap.Txn, name string) error {
+	err := txn.Delete([]byte(t.Key(name)))
+	if err != nil {
+		return errors.Wrapf(err, "delete for table %v failed", t.tableName)
+	}
+	return nil
+}
+
+// Returns every baseline, ordered by name
+func (t *BaselineTable) ReadAll(txn badgerwrap.Txn) ([]*QueryBaseline, error) {
+	baselines := []*QueryBaseline{}
+	prefix := []byte("/" + t.tableName + "/")
+	iterOpt := badger.DefaultIteratorOptions
+	iterOpt.Prefix = prefix
+	itr := txn.NewIterator(iterOpt)
+	defer itr.Close()
+	for itr.Seek(prefix); itr.ValidForPrefix(prefix); itr.Next() {
+		valueBytes, err := itr.Item().ValueCopy([]byte{})
+		if err != nil {
+			return nil, errors.Wrapf(err, "value copy failed for table %v", t.tableName)
+		}
+		baseline := &QueryBaseline{}
+		err = proto.Unmarshal(valueBytes, baseline)
+		if err != nil {
+			return nil, errors.Wrapf(err, "protobuf unmarshal failed for table %v on value length %v", t.tableName, len(valueBytes))
+		}
+		baselines = append(baselines, baseline)
+	}
+	return baselines, nil
+}
@@ -0,0 +1,52 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+func Test_BaselineTable_SetGetDeleteAndReadAll(t *testing.T) {
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	table := OpenBaselineTable()
+	assert.Equal(t, "/baseline/ns-pods", table.Key("ns-pods"))
+
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		assert.Nil(t, table.Set(txn, &QueryBaseline{Name: "b", Total: 2}))
+		assert.Nil(t, table.Set(txn, &QueryBaseline{Name: "a", Total: 1}))
+		return nil
+	})
+	assert.Nil(t, err)
+
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		baseline, err := table.Get(txn, "a")
+		assert.Nil(t, err)
+		assert.Equal(t, int64(1), baseline.Total)
+		missing, err := table.Get(txn, "missing")
+		assert.Nil(t, err)
+		assert.Nil(t, missing)
+
+		baselines, err := table.ReadAll(txn)
+		assert.Nil(t, err)
+		assert.Len(t, baselines, 2)
+		assert.Equal(t, "a", baselines[0].Name)
+
+		assert.Nil(t, table.Delete(txn, "a"))
+		baselines, err = table.ReadAll(txn)
+		assert.Nil(t, err)
+		assert.Len(t, baselines, 1)
+		return nil
+	})
+	assert.Nil(t, err)
+}
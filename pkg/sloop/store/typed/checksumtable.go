@@ -0,0 +1,231 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	badger "github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+var metricChecksumMismatches = promauto.NewGauge(prometheus.GaugeOpts{Name: "sloop_checksum_mismatches"})
+
+/*
+Checksums of the tables of closed partitions:
+
+	/checksum/<partition>/<table>
+
+A checksum covers the keys and stored values of one table in one partition, so a partition that changes after it
+was closed without being written to shows up in VerifyPartitionChecksums.  Writes that legitimately change a closed
+partition, like late watch results or GC, have to delete or update its checksums.  Values are not
+run through the payload codecs
+*/
+type ChecksumTable struct {
+	tableName string
+}
+
+func OpenChecksumTable() *ChecksumTable {
+	return &ChecksumTable{tableName: "checksum"}
+}
+
+func (t *ChecksumTable) TableName() string {
+	return t.tableName
+}
+
+func (t *ChecksumTable) Key(partition string, tableName string) string {
+	return fmt.Sprintf("/%v/%v/%v", t.tableName, partition, tableName)
+}
+
+func (t *ChecksumTable) Set(txn badgerwrap.Txn, value *PartitionChecksum) error {
+	outb, err := proto.Marshal(value)
+	if err != nil {
+		return errors.Wrapf(err, "protobuf marshal for table %v failed", t.tableName)
+	}
+	err = txn.Set([]byte(t.Key(value.Partition, value.Table)), outb)
+	if err != nil {
+		return errors.Wrapf(err, "set for table %v failed", t.tableName)
+	}
+	return nil
+}
+
+// Returns nil when the table has no checksum in the partition
+func (t *ChecksumTable) Get(txn badgerwrap.Txn, partition string, tableName string) (*PartitionChecksum, error) {
+	item, err := txn.Get([]byte(t.Key(partition, tableName)))
+	if err == badger.ErrKeyNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "get for table %v failed", t.tableName)
+	}
+	valueBytes, err := item.ValueCopy([]byte{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "value copy failed for table %v", t.tableName)
+	}
+	checksum := &PartitionChecksum{}
+	err = proto.Unmarshal(valueBytes, checksum)
+	if err != nil {
+		return nil, errors.Wrapf(err, "protobuf unmarshal failed for table %v on value length %v", t.tableName, len(valueBytes))
+	}
+	return checksum, nil
+}
+
+func (t *ChecksumTable) Delete(txn badgerwrap.Txn, partition string, tableName string) error {
+	err := txn.Delete([]byte(t.Key(partition, tableName)))
+	if err != nil {
+		return errors.Wrapf(err, "delete for table %v failed", t.tableName)
+	}
+	return nil
+}
+
+// Deletes the checksums of every table of the partition, for writes to a partition that is already closed
+func (t *ChecksumTable) DeletePartition(txn badgerwrap.Txn, partition string) error {
+	prefix := []byte(fmt.Sprintf("/%v/%v/", t.tableName, partition))
+	var keys [][]byte
+	iterOpt := badger.DefaultIteratorOptions
+	iterOpt.Prefix = prefix
+	iterOpt.PrefetchValues = false
+	itr := txn.NewIterator(iterOpt)
+	for itr.Seek(prefix); itr.ValidForPrefix(prefix); itr.Next() {
+		keys = append(keys, itr.Item().KeyCopy(nil))
+	}
+	itr.Close()
+	for _, key := range keys {
+		err := txn.Delete(key)
+		if err != nil {
+			return errors.Wrapf(err, "delete for table %v failed", t.tableName)
+		}
+	}
+	return nil
+}
+
+// Returns every checksum, ordered by partition and table
+func (t *ChecksumTable) ReadAll(txn badgerwrap.Txn) ([]*PartitionChecksum, error) {
+	checksums := []*PartitionChecksum{}
+	prefix := []byte("/" + t.tableName + "/")
+	iterOpt := badger.DefaultIteratorOptions
+	iterOpt.Prefix = prefix
+	itr := txn.NewIterator(iterOpt)
+	defer itr.Close()
+	for itr.Seek(prefix); itr.ValidForPrefix(prefix); itr.Next() {
+		valueBytes, err := itr.Item().ValueCopy([]byte{})
+		if err != nil {
+			return nil, errors.Wrapf(err, "value copy failed for table %v", t.tableName)
+		}
+		checksum := &PartitionChecksum{}
+		err = proto.Unmarshal(valueBytes, checksum)
+		if err != nil {
+			return nil, errors.Wrapf(err, "protobuf unmarshal failed for table %v on value length %v", t.tableName, len(valueBytes))
+		}
+		checksums = append(checksums, checksum)
+	}
+	return checksums, nil
+}
+
+// Computes the checksum of the keys of tableName in partition from what is stored now
+func ComputePartitionChecksum(txn badgerwrap.Txn, tableName string, partition string) (*PartitionChecksum, error) {
+	checksum := &PartitionChecksum{Partition: partition, Table: tableName}
+	checksum.Computed, _ = ptypes.TimestampProto(time.Now())
+	hash := sha256.New()
+	length := make([]byte, 8)
+	prefix := []byte(fmt.Sprintf("/%v/%v/", tableName, partition))
+	iterOpt := badger.DefaultIteratorOptions
+	iterOpt.Prefix = prefix
+	itr := txn.NewIterator(iterOpt)
+	defer itr.Close()
+	for itr.Seek(prefix); itr.ValidForPrefix(prefix); itr.Next() {
+		key := itr.Item().Key()
+		value, err := itr.Item().ValueCopy([]byte{})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read %v", string(key))
+		}
+		// Length prefixed so a byte can not move between a key and its value unnoticed
+		for _, field := range [][]byte{key, value} {
+			binary.BigEndian.PutUint64(length, uint64(len(field)))
+			hash.Write(length)
+			hash.Write(field)
+		}
+		checksum.Keys++
+		checksum.Bytes += int64(len(key) + len(value))
+	}
+	checksum.Sha256 = hex.EncodeToString(hash.Sum(nil))
+	return checksum, nil
+}
+
+type ChecksumMismatch struct {
+	Partition    string `json:"partition"`
+	Table        string `json:"table"`
+	ExpectedKeys int64  `json:"expectedKeys"`
+	ActualKeys   int64  `json:"actualKeys"`
+	Expected     string `json:"expected"`
+	Actual       string `json:"actual"`
+	// Set when the partition could not be read at all
+	Error string `json:"error,omitempty"`
+}
+
+type ChecksumReport struct {
+	Checked    int                `json:"checked"`
+	Mismatches []ChecksumMismatch `json:"mismatches"`
+}
+
+// Nil when every checksum matched
+func (r ChecksumReport) Err() error {
+	if len(r.Mismatches) == 0 {
+		return nil
+	}
+	first := r.Mismatches[0]
+	return fmt.Errorf("%v of %v partition checksums do not match, the first is table %v of partition %v", len(r.Mismatches), r.Checked, first.Table, first.Partition)
+}
+
+// Computes the checksum of every table that has one again and reports those that differ.  Each is checked in its own
+// transaction so a large store is not held in one
+func VerifyPartitionChecksums(db badgerwrap.DB) (ChecksumReport, error) {
+	report := ChecksumReport{Mismatches: []ChecksumMismatch{}}
+	var checksums []*PartitionChecksum
+	err := db.View(func(txn badgerwrap.Txn) error {
+		var err error
+		checksums, err = OpenChecksumTable().ReadAll(txn)
+		return err
+	})
+	if err != nil {
+		return report, err
+	}
+
+	for _, expected := range checksums {
+		var actual *PartitionChecksum
+		err := db.View(func(txn badgerwrap.Txn) error {
+			var err error
+			actual, err = ComputePartitionChecksum(txn, expected.Table, expected.Partition)
+			return err
+		})
+		report.Checked++
+		mismatch := ChecksumMismatch{Partition: expected.Partition, Table: expected.Table, ExpectedKeys: expected.Keys, Expected: expected.Sha256}
+		if err != nil {
+			mismatch.Error = err.Error()
+			report.Mismatches = append(report.Mismatches, mismatch)
+			continue
+		}
+		if actual.Sha256 != expected.Sha256 {
+			mismatch.ActualKeys = actual.Keys
+			mismatch.Actual = actual.Sha256
+			report.Mismatches = append(report.Mismatches, mismatch)
+		}
+	}
+	metricChecksumMismatches.Set(float64(len(report.Mismatches)))
+	return report, nil
+}
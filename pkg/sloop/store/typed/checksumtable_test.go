@@ -0,0 +1,114 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+const someChecksumPartition = "001546405200"
+
+func helper_checksumDb(t *testing.T) badgerwrap.DB {
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		assert.Nil(t, txn.Set([]byte("/watch/"+someChecksumPartition+"/Pod/ns/a/1"), []byte("one")))
+		assert.Nil(t, txn.Set([]byte("/watch/"+someChecksumPartition+"/Pod/ns/b/1"), []byte("two")))
+		assert.Nil(t, txn.Set([]byte("/watch/001546408800/Pod/ns/a/1"), []byte("other partition")))
+		return nil
+	})
+	assert.Nil(t, err)
+	return db
+}
+
+func Test_ComputePartitionChecksum_CoversKeysAndValuesOfThePartition(t *testing.T) {
+	db := helper_checksumDb(t)
+	var before, after *PartitionChecksum
+	err := db.Update(func(txn badgerwrap.Txn) error {
+		var err error
+		before, err = ComputePartitionChecksum(txn, "watch", someChecksumPartition)
+		assert.Nil(t, err)
+		assert.Nil(t, txn.Set([]byte("/watch/001546408800/Pod/ns/b/1"), []byte("not in the partition")))
+		same, err := ComputePartitionChecksum(txn, "watch", someChecksumPartition)
+		assert.Nil(t, err)
+		assert.Equal(t, before.Sha256, same.Sha256)
+		assert.Nil(t, txn.Set([]byte("/watch/"+someChecksumPartition+"/Pod/ns/b/1"), []byte("twO")))
+		after, err = ComputePartitionChecksum(txn, "watch", someChecksumPartition)
+		return err
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), before.Keys)
+	assert.Equal(t, int64(len("/watch/001546405200/Pod/ns/a/1")*2+6), before.Bytes)
+	assert.Len(t, before.Sha256, 64)
+	assert.NotEqual(t, before.Sha256, after.Sha256)
+}
+
+func Test_ChecksumTable_SetGetAndDeletePartition(t *testing.T) {
+	db := helper_checksumDb(t)
+	table := OpenChecksumTable()
+	assert.Equal(t, "/checksum/001546405200/watch", table.Key(someChecksumPartition, "watch"))
+
+	err := db.Update(func(txn badgerwrap.Txn) error {
+		missing, err := table.Get(txn, someChecksumPartition, "watch")
+		assert.Nil(t, err)
+		assert.Nil(t, missing)
+		assert.Nil(t, table.Set(txn, &PartitionChecksum{Partition: someChecksumPartition, Table: "watch", Keys: 2}))
+		assert.Nil(t, table.Set(txn, &PartitionChecksum{Partition: someChecksumPartition, Table: "ressum", Keys: 3}))
+		assert.Nil(t, table.Set(txn, &PartitionChecksum{Partition: "001546408800", Table: "watch", Keys: 1}))
+		found, err := table.Get(txn, someChecksumPartition, "watch")
+		assert.Nil(t, err)
+		assert.Equal(t, int64(2), found.Keys)
+		return table.DeletePartition(txn, someChecksumPartition)
+	})
+	assert.Nil(t, err)
+
+	err = db.View(func(txn badgerwrap.Txn) error {
+		checksums, err := table.ReadAll(txn)
+		assert.Nil(t, err)
+		assert.Len(t, checksums, 1)
+		assert.Equal(t, "001546408800", checksums[0].Partition)
+		return nil
+	})
+	assert.Nil(t, err)
+}
+
+func Test_VerifyPartitionChecksums_ReportsChangedPartitions(t *testing.T) {
+	db := helper_checksumDb(t)
+	err := db.Update(func(txn badgerwrap.Txn) error {
+		for _, partition := range []string{someChecksumPartition, "001546408800"} {
+			checksum, err := ComputePartitionChecksum(txn, "watch", partition)
+			assert.Nil(t, err)
+			assert.Nil(t, OpenChecksumTable().Set(txn, checksum))
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+
+	report, err := VerifyPartitionChecksums(db)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, report.Checked)
+	assert.Empty(t, report.Mismatches)
+	assert.Nil(t, report.Err())
+
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		return txn.Delete([]byte("/watch/" + someChecksumPartition + "/Pod/ns/b/1"))
+	})
+	assert.Nil(t, err)
+	report, err = VerifyPartitionChecksums(db)
+	assert.Nil(t, err)
+	assert.Len(t, report.Mismatches, 1)
+	assert.Equal(t, someChecksumPartition, report.Mismatches[0].Partition)
+	assert.Equal(t, int64(2), report.Mismatches[0].ExpectedKeys)
+	assert.Equal(t, int64(1), report.Mismatches[0].ActualKeys)
+	assert.EqualError(t, report.Err(), "1 of 2 partition checksums do not match, the first is table watch of partition 001546405200")
+}
@@ -0,0 +1,143 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"fmt"
+	"github.com/dgraph-io/badger/v2"
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+// Key is /<partition>/<kind>/<namespace>/<name>/<uid>
+//
+// Each live resource name has exactly one key, in the partition of its latest watch result.  The key moves to the
+// newer partition when the resource changes, so partition GC only removes resources that were not seen within the
+// retention
+
+type CurrentStateKey struct {
+	PartitionId string
+	Kind        string
+	Namespace   string
+	Name        string
+	Uid         string
+}
+
+func NewCurrentStateKey(partitionId string, kind string, namespace string, name string, uid string) *CurrentStateKey {
+	return &CurrentStateKey{PartitionId: partitionId, Kind: kind, Namespace: namespace, Name: name, Uid: uid}
+}
+
+func (*CurrentStateKey) TableName() string {
+	return "currentstate"
+}
+
+func (k *CurrentStateKey) Parse(key string) error {
+	err, parts := common.ParseKey(key)
+	if err != nil {
+		return err
+	}
+
+	if parts[1] != k.TableName() {
+		return fmt.Errorf("Second part of key (%v) should be %v", key, k.TableName())
+	}
+	k.PartitionId = parts[2]
+	k.Kind = parts[3]
+	k.Namespace = parts[4]
+	k.Name = parts[5]
+	k.Uid = parts[6]
+	return nil
+}
+
+// Returns the prefix of all resources of the kind and namespace when the name is empty
+func (k *CurrentStateKey) String() string {
+	if k.Name == "" {
+		return fmt.Sprintf("/%v/%v/%v/%v/", k.TableName(), k.PartitionId, k.Kind, k.Namespace)
+	}
+	return fmt.Sprintf("/%v/%v/%v/%v/%v/%v", k.TableName(), k.PartitionId, k.Kind, k.Namespace, k.Name, k.Uid)
+}
+
+// Prefix of the name whatever its uid
+func (k *CurrentStateKey) namePrefix() string {
+	return fmt.Sprintf("/%v/%v/%v/%v/%v/", k.TableName(), k.PartitionId, k.Kind, k.Namespace, k.Name)
+}
+
+func (*CurrentStateKey) ValidateKey(key string) error {
+	newKey := CurrentStateKey{}
+	return newKey.Parse(key)
+}
+
+func (k *CurrentStateKey) SetPartitionId(newPartitionId string) {
+	k.PartitionId = newPartitionId
+}
+
+// Returns the key and state of a resource, or a nil key when it is not live.  Partitions are checked newest first,
+// which is usually the first one for resources that change or are resynced
+func (t *CurrentStateTable) Find(txn badgerwrap.Txn, kind string, namespace string, name string) (*CurrentStateKey, *CurrentState, error) {
+	partitions, err := t.GetUniquePartitionList(txn)
+	if err != nil {
+		return nil, nil, err
+	}
+	for idx := len(partitions) - 1; idx >= 0; idx-- {
+		prefix := NewCurrentStateKey(partitions[idx], kind, namespace, name, "").namePrefix()
+		states, err := t.readPrefix(txn, prefix)
+		if err != nil {
+			return nil, nil, err
+		}
+		for key, state := range states {
+			return &key, state, nil
+		}
+	}
+	return nil, nil, nil
+}
+
+// Returns the state of every live resource of the kind.  An empty kind matches all kinds and an empty namespace
+// matches all namespaces
+func (t *CurrentStateTable) List(txn badgerwrap.Txn, kind string, namespace string) (map[CurrentStateKey]*CurrentState, error) {
+	states := map[CurrentStateKey]*CurrentState{}
+	partitions, err := t.GetUniquePartitionList(txn)
+	if err != nil {
+		return nil, err
+	}
+	for _, partition := range partitions {
+		var prefix string
+		if kind == "" {
+			prefix = fmt.Sprintf("/%v/%v/", t.tableName, partition)
+		} else if namespace == "" {
+			prefix = fmt.Sprintf("/%v/%v/%v/", t.tableName, partition, kind)
+		} else {
+			prefix = NewCurrentStateKey(partition, kind, namespace, "", "").String()
+		}
+		partitionStates, err := t.readPrefix(txn, prefix)
+		if err != nil {
+			return nil, err
+		}
+		for key, state := range partitionStates {
+			states[key] = state
+		}
+	}
+	return states, nil
+}
+
+func (t *CurrentStateTable) readPrefix(txn badgerwrap.Txn, prefix string) (map[CurrentStateKey]*CurrentState, error) {
+	states := map[CurrentStateKey]*CurrentState{}
+	itr := txn.NewIterator(badger.IteratorOptions{Prefix: []byte(prefix)})
+	defer itr.Close()
+	for itr.Seek([]byte(prefix)); itr.ValidForPrefix([]byte(prefix)); itr.Next() {
+		key := CurrentStateKey{}
+		err := key.Parse(string(itr.Item().Key()))
+		if err != nil {
+			return nil, err
+		}
+		state, err := t.Get(txn, key.String())
+		if err != nil {
+			return nil, err
+		}
+		states[key] = state
+	}
+	return states, nil
+}
@@ -0,0 +1,106 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"github.com/dgraph-io/badger/v2"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_CurrentStateKey_OutputCorrect(t *testing.T) {
+	k := NewCurrentStateKey("001562961600", someKind, someNamespace, someName, someUid)
+	assert.Equal(t, "/currentstate/001562961600/somekind/somenamespace/somename/68510937-4ffc-11e9-8e26-1418775557c8", k.String())
+}
+
+func Test_CurrentStateKey_PrefixOutputCorrect(t *testing.T) {
+	k := NewCurrentStateKey("001562961600", someKind, someNamespace, "", "")
+	assert.Equal(t, "/currentstate/001562961600/somekind/somenamespace/", k.String())
+	k = NewCurrentStateKey("001562961600", someKind, someNamespace, someName, "")
+	assert.Equal(t, "/currentstate/001562961600/somekind/somenamespace/somename/", k.namePrefix())
+}
+
+func Test_CurrentStateKey_ParseCorrect(t *testing.T) {
+	k := &CurrentStateKey{}
+	err := k.Parse("/currentstate/001562961600/somekind/somenamespace/somename/68510937-4ffc-11e9-8e26-1418775557c8")
+	assert.Nil(t, err)
+	assert.Equal(t, "001562961600", k.PartitionId)
+	assert.Equal(t, someKind, k.Kind)
+	assert.Equal(t, someNamespace, k.Namespace)
+	assert.Equal(t, someName, k.Name)
+	assert.Equal(t, someUid, k.Uid)
+}
+
+func Test_CurrentStateKey_ValidateWorks(t *testing.T) {
+	assert.Nil(t, (&CurrentStateKey{}).ValidateKey("/currentstate/001562961600/somekind/somenamespace/somename/someuid"))
+	assert.NotNil(t, (&CurrentStateKey{}).ValidateKey("/ressum/001562961600/somekind/somenamespace/somename/someuid"))
+}
+
+func Test_CurrentStateTable_FindAndList(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	table := OpenCurrentStateTable()
+	older := untyped.GetPartitionId(someTs)
+	newer := untyped.GetPartitionId(someTs.Add(3 * time.Hour))
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		assert.Nil(t, table.Set(txn, NewCurrentStateKey(older, someKind, someNamespace, "a", "uid-a").String(), &CurrentState{Payload: "a"}))
+		assert.Nil(t, table.Set(txn, NewCurrentStateKey(newer, someKind, someNamespace, "b", "uid-b").String(), &CurrentState{Payload: "b"}))
+		assert.Nil(t, table.Set(txn, NewCurrentStateKey(newer, someKind, "other", "c", "uid-c").String(), &CurrentState{Payload: "c"}))
+		return nil
+	})
+	assert.Nil(t, err)
+
+	err = db.View(func(txn badgerwrap.Txn) error {
+		key, state, err := table.Find(txn, someKind, someNamespace, "a")
+		assert.Nil(t, err)
+		assert.Equal(t, older, key.PartitionId)
+		assert.Equal(t, "uid-a", key.Uid)
+		assert.Equal(t, "a", state.Payload)
+
+		key, _, err = table.Find(txn, someKind, someNamespace, "missing")
+		assert.Nil(t, err)
+		assert.Nil(t, key)
+
+		states, err := table.List(txn, someKind, someNamespace)
+		assert.Nil(t, err)
+		assert.Len(t, states, 2)
+		states, err = table.List(txn, someKind, "")
+		assert.Nil(t, err)
+		assert.Len(t, states, 3)
+		return nil
+	})
+	assert.Nil(t, err)
+}
+
+func (*CurrentStateKey) GetTestKey() string {
+	k := NewCurrentStateKey(someMinPartition, someKind, someNamespace, someName, someUid)
+	return k.String()
+}
+
+func (*CurrentStateKey) GetTestValue() *CurrentState {
+	return &CurrentState{}
+}
+
+func (*CurrentStateKey) SetTestKeys() []string {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	var keys []string
+	for curTime := someTs; !curTime.After(someMaxTs); curTime = curTime.Add(untyped.GetPartitionDuration()) {
+		partitionId := untyped.GetPartitionId(curTime)
+		keys = append(keys, NewCurrentStateKey(partitionId, someKind, someNamespace, someName, someUid).String())
+		keys = append(keys, NewCurrentStateKey(partitionId, someKind, someNamespace, someName+"b", someUid).String())
+	}
+	return keys
+}
+
+func (*CurrentStateKey) SetTestValue() *CurrentState {
+	return &CurrentState{Payload: "{}"}
+}
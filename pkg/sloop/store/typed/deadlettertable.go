@@ -0,0 +1,74 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"strconv"
+	"time"
+)
+
+// Key is /<partition>/<kind>/<namespace>/<name>/<timestamp>
+//
+// Same layout as the watch table and the quarantine table.
+// Timestamp is UnixNano in UTC
+
+type DeadLetterKey struct {
+	PartitionId string
+	Kind        string
+	Namespace   string
+	Name        string
+	Timestamp   time.Time
+}
+
+func NewDeadLetterKey(partitionId string, kind string, namespace string, name string, timestamp time.Time) *DeadLetterKey {
+	return &DeadLetterKey{PartitionId: partitionId, Kind: kind, Namespace: namespace, Name: name, Timestamp: timestamp}
+}
+
+func (*DeadLetterKey) TableName() string {
+	return "deadletter"
+}
+
+func (k *DeadLetterKey) Parse(key string) error {
+	err, parts := common.ParseKey(key)
+	if err != nil {
+		return err
+	}
+
+	if parts[1] != k.TableName() {
+		return fmt.Errorf("Second part of key (%v) should be %v", key, k.TableName())
+	}
+	k.PartitionId = parts[2]
+	k.Kind = parts[3]
+	k.Namespace = parts[4]
+	k.Name = parts[5]
+	tsint, err := strconv.ParseInt(parts[6], 10, 64)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to parse timestamp from key: %v", key)
+	}
+	k.Timestamp = time.Unix(0, tsint).UTC()
+	return nil
+}
+
+func (k *DeadLetterKey) String() string {
+	if k.Timestamp.IsZero() {
+		return fmt.Sprintf("/%v/%v/%v/%v/%v", k.TableName(), k.PartitionId, k.Kind, k.Namespace, k.Name)
+	}
+	return fmt.Sprintf("/%v/%v/%v/%v/%v/%v", k.TableName(), k.PartitionId, k.Kind, k.Namespace, k.Name, k.Timestamp.UnixNano())
+}
+
+func (*DeadLetterKey) ValidateKey(key string) error {
+	newKey := DeadLetterKey{}
+	return newKey.Parse(key)
+}
+
+func (k *DeadLetterKey) SetPartitionId(newPartitionId string) {
+	k.PartitionId = newPartitionId
+}
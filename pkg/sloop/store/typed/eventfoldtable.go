@@ -0,0 +1,92 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Key is /<partition>/<kind>/<namespace>/<name>/<reason>.<message hash>.<first timestamp>
+//
+// Kind, namespace and name are of the involved object
+// Message hash is a hex string
+// First timestamp is UnixNano in UTC of the first event in the fold
+
+type EventFoldKey struct {
+	PartitionId    string
+	Kind           string
+	Namespace      string
+	Name           string
+	Reason         string
+	MessageHash    string
+	FirstTimestamp time.Time
+}
+
+func NewEventFoldKey(partitionId string, kind string, namespace string, name string, reason string, messageHash string, firstTimestamp time.Time) *EventFoldKey {
+	return &EventFoldKey{PartitionId: partitionId, Kind: kind, Namespace: namespace, Name: name, Reason: reason, MessageHash: messageHash, FirstTimestamp: firstTimestamp}
+}
+
+func (*EventFoldKey) TableName() string {
+	return "eventfold"
+}
+
+func (k *EventFoldKey) Parse(key string) error {
+	err, parts := common.ParseKey(key)
+	if err != nil {
+		return err
+	}
+
+	if parts[1] != k.TableName() {
+		return fmt.Errorf("Second part of key (%v) should be %v", key, k.TableName())
+	}
+	k.PartitionId = parts[2]
+	k.Kind = parts[3]
+	k.Namespace = parts[4]
+	k.Name = parts[5]
+
+	// Reason can contain a '.' so parse from the right
+	last := parts[6]
+	tsIdx := strings.LastIndex(last, ".")
+	if tsIdx < 0 {
+		return fmt.Errorf("Last part of key (%v) should be <reason>.<message hash>.<timestamp>", key)
+	}
+	hashIdx := strings.LastIndex(last[:tsIdx], ".")
+	if hashIdx < 0 {
+		return fmt.Errorf("Last part of key (%v) should be <reason>.<message hash>.<timestamp>", key)
+	}
+	k.Reason = last[:hashIdx]
+	k.MessageHash = last[hashIdx+1 : tsIdx]
+	tsint, err := strconv.ParseInt(last[tsIdx+1:], 10, 64)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to parse timestamp from key: %v", key)
+	}
+	k.FirstTimestamp = time.Unix(0, tsint).UTC()
+	return nil
+}
+
+// With a zero first timestamp this is a prefix for all folds of the same event
+func (k *EventFoldKey) String() string {
+	if k.FirstTimestamp.IsZero() {
+		return fmt.Sprintf("/%v/%v/%v/%v/%v/%v.%v.", k.TableName(), k.PartitionId, k.Kind, k.Namespace, k.Name, k.Reason, k.MessageHash)
+	}
+	return fmt.Sprintf("/%v/%v/%v/%v/%v/%v.%v.%v", k.TableName(), k.PartitionId, k.Kind, k.Namespace, k.Name, k.Reason, k.MessageHash, k.FirstTimestamp.UnixNano())
+}
+
+func (*EventFoldKey) ValidateKey(key string) error {
+	newKey := EventFoldKey{}
+	return newKey.Parse(key)
+}
+
+func (k *EventFoldKey) SetPartitionId(newPartitionId string) {
+	k.PartitionId = newPartitionId
+}
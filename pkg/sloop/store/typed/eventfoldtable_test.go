@@ -0,0 +1,71 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+const someFoldReason = "Back.Off"
+const someMessageHash = "cbf29ce484222325"
+
+func Test_EventFoldKey_OutputCorrect(t *testing.T) {
+	k := NewEventFoldKey("001562961600", someKind, someNamespace, someName, someFoldReason, someMessageHash, someTs)
+	assert.Equal(t, "/eventfold/001562961600/somekind/somenamespace/somename/Back.Off.cbf29ce484222325.1546398245000000006", k.String())
+}
+
+func Test_EventFoldKey_PrefixWithoutTimestamp(t *testing.T) {
+	k := NewEventFoldKey("001562961600", someKind, someNamespace, someName, someFoldReason, someMessageHash, time.Time{})
+	assert.Equal(t, "/eventfold/001562961600/somekind/somenamespace/somename/Back.Off.cbf29ce484222325.", k.String())
+}
+
+func Test_EventFoldKey_ParseCorrect(t *testing.T) {
+	k := &EventFoldKey{}
+	err := k.Parse("/eventfold/001562961600/somekind/somenamespace/somename/Back.Off.cbf29ce484222325.1546398245000000006")
+	assert.Nil(t, err)
+	assert.Equal(t, "001562961600", k.PartitionId)
+	assert.Equal(t, someKind, k.Kind)
+	assert.Equal(t, someNamespace, k.Namespace)
+	assert.Equal(t, someName, k.Name)
+	assert.Equal(t, someFoldReason, k.Reason)
+	assert.Equal(t, someMessageHash, k.MessageHash)
+	assert.Equal(t, someTs, k.FirstTimestamp)
+}
+
+func Test_EventFoldKey_ValidateWorks(t *testing.T) {
+	assert.Nil(t, (&EventFoldKey{}).ValidateKey("/eventfold/001562961600/somekind/somenamespace/somename/Back.Off.cbf29ce484222325.1546398245000000006"))
+	assert.NotNil(t, (&EventFoldKey{}).ValidateKey("/eventfold/001562961600/somekind/somenamespace/somename/1546398245000000006"))
+	assert.NotNil(t, (&EventFoldKey{}).ValidateKey("/watch/001562961600/somekind/somenamespace/somename/Back.Off.cbf29ce484222325.1546398245000000006"))
+}
+
+func (*EventFoldKey) GetTestKey() string {
+	k := NewEventFoldKey(someMinPartition, someKind, someNamespace, someName, someFoldReason, someMessageHash, someTs)
+	return k.String()
+}
+
+func (*EventFoldKey) GetTestValue() *EventFold {
+	return &EventFold{}
+}
+
+func (*EventFoldKey) SetTestKeys() []string {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	var keys []string
+	for curTime := someTs; !curTime.After(someMaxTs); curTime = curTime.Add(untyped.GetPartitionDuration()) {
+		partitionId := untyped.GetPartitionId(curTime)
+		keys = append(keys, NewEventFoldKey(partitionId, someKind, someNamespace, someName, someFoldReason, someMessageHash, someTs.Add(time.Hour*-5)).String())
+		keys = append(keys, NewEventFoldKey(partitionId, someKind, someNamespace, someName, someFoldReason, someMessageHash, someTs).String())
+	}
+	return keys
+}
+
+func (*EventFoldKey) SetTestValue() *EventFold {
+	return &EventFold{Reason: someFoldReason}
+}
@@ -0,0 +1,156 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	badger "github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+const eventHeatMapTableName = "eventheatmap"
+
+// The finest time bucket of the heat map.  Partition durations are a multiple of it, so a bucket never spans two
+const EventHeatMapBucket = 5 * time.Minute
+
+// Registered so partition GC and purges cover it like the core tables
+func init() {
+	RegisterTableName(eventHeatMapTableName)
+}
+
+type EventHeatMapKey struct {
+	PartitionId string
+	Namespace   string
+}
+
+/*
+The event counts of the event count table summed per namespace, time bucket and severity, kept up to date at write
+time so the landing page reads one key per namespace and partition instead of every involved object:
+
+	/eventheatmap/<partition>/<namespace>
+
+Values are ResourceEventCounts keyed by the unix seconds of the start of the EventHeatMapBucket, with the counts keyed
+by the event type, like Normal or Warning.  Events of cluster scoped objects have an empty namespace.  Values are not
+run through the payload codecs
+*/
+type EventHeatMapTable struct{}
+
+func OpenEventHeatMapTable() *EventHeatMapTable {
+	return &EventHeatMapTable{}
+}
+
+func (t *EventHeatMapTable) TableName() string {
+	return eventHeatMapTableName
+}
+
+// The key of the bucket the time is in
+func (t *EventHeatMapTable) Key(ts time.Time, namespace string) string {
+	return fmt.Sprintf("/%v/%v/%v", eventHeatMapTableName, untyped.GetPartitionId(EventHeatMapBucketStart(ts)), namespace)
+}
+
+// The start of the bucket the time is in
+func EventHeatMapBucketStart(ts time.Time) time.Time {
+	return ts.UTC().Truncate(EventHeatMapBucket)
+}
+
+func (t *EventHeatMapTable) ParseKey(key string) (*EventHeatMapKey, error) {
+	parts := strings.Split(key, "/")
+	if len(parts) != 4 || parts[0] != "" || parts[1] != eventHeatMapTableName {
+		return nil, fmt.Errorf("key %q is not in table %v", key, eventHeatMapTableName)
+	}
+	return &EventHeatMapKey{PartitionId: parts[2], Namespace: parts[3]}, nil
+}
+
+func (t *EventHeatMapTable) Set(txn badgerwrap.Txn, key string, value *ResourceEventCounts) error {
+	outb, err := proto.Marshal(value)
+	if err != nil {
+		return errors.Wrapf(err, "protobuf marshal for table %v failed", eventHeatMapTableName)
+	}
+	err = txn.Set([]byte(key), outb)
+	if err != nil {
+		return errors.Wrapf(err, "set for table %v failed", eventHeatMapTableName)
+	}
+	return nil
+}
+
+// Returns an empty record when the key is not in the table
+func (t *EventHeatMapTable) GetOrDefault(txn badgerwrap.Txn, key string) (*ResourceEventCounts, error) {
+	item, err := txn.Get([]byte(key))
+	if err == badger.ErrKeyNotFound {
+		return &ResourceEventCounts{MapMinToEvents: map[int64]*EventCounts{}}, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "get failed for table %v", eventHeatMapTableName)
+	}
+	valueBytes, err := item.ValueCopy([]byte{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "value copy failed for table %v", eventHeatMapTableName)
+	}
+	return t.unmarshal(valueBytes)
+}
+
+// Returns the records of the partitions overlapping [startTime, endTime] by key
+func (t *EventHeatMapTable) RangeRead(txn badgerwrap.Txn, startTime time.Time, endTime time.Time) (map[EventHeatMapKey]*ResourceEventCounts, RangeReadStats, error) {
+	records := map[EventHeatMapKey]*ResourceEventCounts{}
+	stats := RangeReadStats{TableName: eventHeatMapTableName}
+	before := time.Now()
+
+	prefix := []byte("/" + eventHeatMapTableName + "/")
+	lastPartition := ""
+	endPartition := untyped.GetPartitionId(endTime.UTC())
+	iterOpt := badger.DefaultIteratorOptions
+	iterOpt.Prefix = prefix
+	itr := txn.NewIterator(iterOpt)
+	defer itr.Close()
+	for itr.Seek([]byte("/" + eventHeatMapTableName + "/" + untyped.GetPartitionId(startTime.UTC()) + "/")); itr.ValidForPrefix(prefix); itr.Next() {
+		stats.RowsVisitedCount++
+		parsed, err := t.ParseKey(string(itr.Item().Key()))
+		if err != nil {
+			return nil, stats, err
+		}
+		if parsed.PartitionId > endPartition {
+			break
+		}
+		if parsed.PartitionId != lastPartition {
+			stats.PartitionCount++
+			lastPartition = parsed.PartitionId
+		}
+		stats.RowsPassedKeyPredicateCount++
+		valueBytes, err := itr.Item().ValueCopy([]byte{})
+		if err != nil {
+			return nil, stats, errors.Wrapf(err, "value copy failed for table %v", eventHeatMapTableName)
+		}
+		record, err := t.unmarshal(valueBytes)
+		if err != nil {
+			return nil, stats, err
+		}
+		stats.RowsPassedValuePredicateCount++
+		records[*parsed] = record
+	}
+	stats.Elapsed = time.Since(before)
+	return records, stats, nil
+}
+
+func (t *EventHeatMapTable) unmarshal(valueBytes []byte) (*ResourceEventCounts, error) {
+	record := &ResourceEventCounts{}
+	err := proto.Unmarshal(valueBytes, record)
+	if err != nil {
+		return nil, errors.Wrapf(err, "protobuf unmarshal failed for table %v on value length %v", eventHeatMapTableName, len(valueBytes))
+	}
+	if record.MapMinToEvents == nil {
+		record.MapMinToEvents = map[int64]*EventCounts{}
+	}
+	return record, nil
+}
@@ -0,0 +1,72 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+func Test_EventHeatMapTable_Key(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	ts := time.Date(2021, 3, 4, 5, 59, 0, 0, time.UTC)
+	table := OpenEventHeatMapTable()
+	key := table.Key(ts, "ns")
+	assert.Equal(t, "/eventheatmap/"+untyped.GetPartitionId(ts)+"/ns", key)
+	assert.Equal(t, time.Date(2021, 3, 4, 5, 55, 0, 0, time.UTC), EventHeatMapBucketStart(ts))
+
+	parsed, err := table.ParseKey(key)
+	assert.Nil(t, err)
+	assert.Equal(t, EventHeatMapKey{PartitionId: untyped.GetPartitionId(ts), Namespace: "ns"}, *parsed)
+	_, err = table.ParseKey("/eventcounthour/001/Pod/ns/pod1/uid1")
+	assert.NotNil(t, err)
+
+	ns, ok := KeyNamespace(key)
+	assert.True(t, ok)
+	assert.Equal(t, "ns", ns)
+	kind, ok := KeyKind(key)
+	assert.True(t, ok)
+	assert.Equal(t, "Event", kind)
+}
+
+func Test_EventHeatMapTable_RangeRead(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	table := OpenEventHeatMapTable()
+	start := time.Date(2021, 3, 4, 5, 0, 0, 0, time.UTC)
+
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		for i := 0; i < 3; i++ {
+			hour := start.Add(time.Duration(i) * time.Hour)
+			value := &ResourceEventCounts{MapMinToEvents: map[int64]*EventCounts{hour.Unix(): {MapReasonToCount: map[string]int32{"Warning": int32(i + 1)}}}}
+			assert.Nil(t, table.Set(txn, table.Key(hour, "ns"), value))
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+
+	var records map[EventHeatMapKey]*ResourceEventCounts
+	err = db.View(func(txn badgerwrap.Txn) error {
+		records, _, err = table.RangeRead(txn, start.Add(time.Hour), start.Add(2*time.Hour))
+		return err
+	})
+	assert.Nil(t, err)
+	assert.Len(t, records, 2)
+	for key, record := range records {
+		assert.True(t, key.PartitionId > untyped.GetPartitionId(start))
+		assert.Len(t, record.MapMinToEvents, 1)
+	}
+	assert.Contains(t, NewTableList(db).GetTableNames(), "eventheatmap")
+}
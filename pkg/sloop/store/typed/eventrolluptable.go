@@ -0,0 +1,205 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	badger "github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+const (
+	EventRollupMinute = "minute"
+	EventRollupHour   = "hour"
+	EventRollupDay    = "day"
+)
+
+// Finest first.  Minutes are the event count table itself
+var EventRollupGranularities = []string{EventRollupMinute, EventRollupHour, EventRollupDay}
+
+var eventRollupBuckets = map[string]time.Duration{
+	EventRollupMinute: time.Minute,
+	EventRollupHour:   time.Hour,
+	EventRollupDay:    24 * time.Hour,
+}
+
+var eventRollupTableNames = map[string]string{
+	EventRollupHour: "eventcounthour",
+	EventRollupDay:  "eventcountday",
+}
+
+// Registered so partition GC and purges cover them like the core tables
+func init() {
+	for _, granularity := range EventRollupGranularities {
+		if tableName, ok := eventRollupTableNames[granularity]; ok {
+			RegisterTableName(tableName)
+		}
+	}
+}
+
+// The length of a bucket of the granularity, 0 for an unknown one
+func EventRollupBucket(granularity string) time.Duration {
+	return eventRollupBuckets[granularity]
+}
+
+// The UTC start of the bucket of the granularity the time is in
+func EventRollupBucketStart(granularity string, ts time.Time) time.Time {
+	return ts.UTC().Truncate(eventRollupBuckets[granularity])
+}
+
+/*
+The event counts of the event count table summed per hour or per day, kept up to date at write time so long trend
+queries read a few keys per involved object instead of every minute:
+
+	/eventcounthour/<partition>/<kind>/<namespace>/<name>/<uid>
+	/eventcountday/<partition>/<kind>/<namespace>/<name>/<uid>
+
+Values are ResourceEventCounts keyed by the unix seconds of the UTC start of the bucket.  A bucket is kept in the
+partition its start is in, so a day bucket goes away with the first partition of the day.  Values are not run through
+the payload codecs
+*/
+type EventRollupTable struct {
+	tableName   string
+	granularity string
+}
+
+// Returns nil for a granularity without a rollup table, including minutes
+func OpenEventRollupTable(granularity string) *EventRollupTable {
+	tableName, ok := eventRollupTableNames[granularity]
+	if !ok {
+		return nil
+	}
+	return &EventRollupTable{tableName: tableName, granularity: granularity}
+}
+
+// Tables of every granularity above minutes, finest first
+func EventRollupTables() []*EventRollupTable {
+	tables := []*EventRollupTable{}
+	for _, granularity := range EventRollupGranularities {
+		if table := OpenEventRollupTable(granularity); table != nil {
+			tables = append(tables, table)
+		}
+	}
+	return tables
+}
+
+func (t *EventRollupTable) TableName() string {
+	return t.tableName
+}
+
+func (t *EventRollupTable) Granularity() string {
+	return t.granularity
+}
+
+// The key of the bucket the time is in
+func (t *EventRollupTable) Key(ts time.Time, kind string, namespace string, name string, uid string) string {
+	partitionId := untyped.GetPartitionId(EventRollupBucketStart(t.granularity, ts))
+	return fmt.Sprintf("/%v/%v/%v/%v/%v/%v", t.tableName, partitionId, kind, namespace, name, uid)
+}
+
+// Returns the parts of the key in an EventCountKey
+func (t *EventRollupTable) ParseKey(key string) (*EventCountKey, error) {
+	parts := strings.Split(key, "/")
+	if len(parts) != 7 || parts[0] != "" || parts[1] != t.tableName {
+		return nil, fmt.Errorf("key %q is not in table %v", key, t.tableName)
+	}
+	return &EventCountKey{PartitionId: parts[2], Kind: parts[3], Namespace: parts[4], Name: parts[5], Uid: parts[6]}, nil
+}
+
+func (t *EventRollupTable) Set(txn badgerwrap.Txn, key string, value *ResourceEventCounts) error {
+	outb, err := proto.Marshal(value)
+	if err != nil {
+		return errors.Wrapf(err, "protobuf marshal for table %v failed", t.tableName)
+	}
+	err = txn.Set([]byte(key), outb)
+	if err != nil {
+		return errors.Wrapf(err, "set for table %v failed", t.tableName)
+	}
+	return nil
+}
+
+// Returns an empty record when the key is not in the table
+func (t *EventRollupTable) GetOrDefault(txn badgerwrap.Txn, key string) (*ResourceEventCounts, error) {
+	item, err := txn.Get([]byte(key))
+	if err == badger.ErrKeyNotFound {
+		return &ResourceEventCounts{MapMinToEvents: map[int64]*EventCounts{}}, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "get failed for table %v", t.tableName)
+	}
+	valueBytes, err := item.ValueCopy([]byte{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "value copy failed for table %v", t.tableName)
+	}
+	return t.unmarshal(valueBytes)
+}
+
+// Returns the records of the partitions overlapping [startTime, endTime] by key.  keyPredicateFn can be nil
+func (t *EventRollupTable) RangeRead(txn badgerwrap.Txn, keyPredicateFn func(string) bool, startTime time.Time, endTime time.Time) (map[EventCountKey]*ResourceEventCounts, RangeReadStats, error) {
+	records := map[EventCountKey]*ResourceEventCounts{}
+	stats := RangeReadStats{TableName: t.tableName}
+	before := time.Now()
+
+	prefix := []byte("/" + t.tableName + "/")
+	lastPartition := ""
+	endPartition := untyped.GetPartitionId(endTime.UTC())
+	iterOpt := badger.DefaultIteratorOptions
+	iterOpt.Prefix = prefix
+	itr := txn.NewIterator(iterOpt)
+	defer itr.Close()
+	for itr.Seek([]byte("/" + t.tableName + "/" + untyped.GetPartitionId(startTime.UTC()) + "/")); itr.ValidForPrefix(prefix); itr.Next() {
+		stats.RowsVisitedCount++
+		key := string(itr.Item().Key())
+		parsed, err := t.ParseKey(key)
+		if err != nil {
+			return nil, stats, err
+		}
+		if parsed.PartitionId > endPartition {
+			break
+		}
+		if parsed.PartitionId != lastPartition {
+			stats.PartitionCount++
+			lastPartition = parsed.PartitionId
+		}
+		if keyPredicateFn != nil && !keyPredicateFn(key) {
+			continue
+		}
+		stats.RowsPassedKeyPredicateCount++
+		valueBytes, err := itr.Item().ValueCopy([]byte{})
+		if err != nil {
+			return nil, stats, errors.Wrapf(err, "value copy failed for table %v", t.tableName)
+		}
+		record, err := t.unmarshal(valueBytes)
+		if err != nil {
+			return nil, stats, err
+		}
+		stats.RowsPassedValuePredicateCount++
+		records[*parsed] = record
+	}
+	stats.Elapsed = time.Since(before)
+	return records, stats, nil
+}
+
+func (t *EventRollupTable) unmarshal(valueBytes []byte) (*ResourceEventCounts, error) {
+	record := &ResourceEventCounts{}
+	err := proto.Unmarshal(valueBytes, record)
+	if err != nil {
+		return nil, errors.Wrapf(err, "protobuf unmarshal failed for table %v on value length %v", t.tableName, len(valueBytes))
+	}
+	if record.MapMinToEvents == nil {
+		record.MapMinToEvents = map[int64]*EventCounts{}
+	}
+	return record, nil
+}
@@ -0,0 +1,69 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+func Test_EventRollupTable_KeyIsInThePartitionOfTheBucket(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	ts := time.Date(2021, 3, 4, 5, 50, 0, 0, time.UTC)
+	day := OpenEventRollupTable(EventRollupDay)
+	key := day.Key(ts, "Pod", "ns", "pod1", "uid1")
+	assert.Equal(t, "/eventcountday/"+untyped.GetPartitionId(time.Date(2021, 3, 4, 0, 0, 0, 0, time.UTC))+"/Pod/ns/pod1/uid1", key)
+
+	parsed, err := day.ParseKey(key)
+	assert.Nil(t, err)
+	assert.Equal(t, EventCountKey{PartitionId: untyped.GetPartitionId(ts.Truncate(24 * time.Hour)), Kind: "Pod", Namespace: "ns", Name: "pod1", Uid: "uid1"}, *parsed)
+	_, err = OpenEventRollupTable(EventRollupHour).ParseKey(key)
+	assert.NotNil(t, err)
+	assert.Nil(t, OpenEventRollupTable(EventRollupMinute))
+	ns, ok := KeyNamespace(key)
+	assert.True(t, ok)
+	assert.Equal(t, "ns", ns)
+}
+
+func Test_EventRollupTable_RangeRead(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	table := OpenEventRollupTable(EventRollupHour)
+	start := time.Date(2021, 3, 4, 5, 0, 0, 0, time.UTC)
+
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		for i := 0; i < 3; i++ {
+			hour := start.Add(time.Duration(i) * time.Hour)
+			value := &ResourceEventCounts{MapMinToEvents: map[int64]*EventCounts{hour.Unix(): {MapReasonToCount: map[string]int32{"BackOff:Warning": int32(i + 1)}}}}
+			assert.Nil(t, table.Set(txn, table.Key(hour, "Pod", "ns", "pod1", "uid1"), value))
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+
+	var records map[EventCountKey]*ResourceEventCounts
+	err = db.View(func(txn badgerwrap.Txn) error {
+		records, _, err = table.RangeRead(txn, nil, start.Add(time.Hour), start.Add(2*time.Hour))
+		return err
+	})
+	assert.Nil(t, err)
+	assert.Len(t, records, 2)
+	for key, record := range records {
+		assert.True(t, key.PartitionId > untyped.GetPartitionId(start))
+		assert.Len(t, record.MapMinToEvents, 1)
+	}
+	assert.Contains(t, NewTableList(db).GetTableNames(), "eventcounthour")
+	assert.Contains(t, NewTableList(db).GetTableNames(), "eventcountday")
+}
@@ -0,0 +1,106 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"fmt"
+
+	badger "github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+/*
+Named ranges of partitions that GC keeps, for incident data that has to survive until responders release it:
+
+	/freeze/<name>
+
+Like the baselines it is not partitioned, so freezes are kept until they are removed.  Values are not run through
+the payload codecs
+*/
+type FreezeTable struct {
+	tableName string
+}
+
+func OpenFreezeTable() *FreezeTable {
+	return &FreezeTable{tableName: "freeze"}
+}
+
+func (t *FreezeTable) TableName() string {
+	return t.tableName
+}
+
+func (t *FreezeTable) Key(name string) string {
+	return fmt.Sprintf("/%v/%v", t.tableName, name)
+}
+
+func (t *FreezeTable) Set(txn badgerwrap.Txn, value *PartitionFreeze) error {
+	outb, err := proto.Marshal(value)
+	if err != nil {
+		return errors.Wrapf(err, "protobuf marshal for table %v failed", t.tableName)
+	}
+	err = txn.Set([]byte(t.Key(value.Name)), outb)
+	if err != nil {
+		return errors.Wrapf(err, "set for table %v failed", t.tableName)
+	}
+	return nil
+}
+
+// Returns nil when there is no freeze with the name
+func (t *FreezeTable) Get(txn badgerwrap.Txn, name string) (*PartitionFreeze, error) {
+	item, err := txn.Get([]byte(t.Key(name)))
+	if err == badger.ErrKeyNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "get for table %v failed", t.tableName)
+	}
+	valueBytes, err := item.ValueCopy([]byte{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "value copy failed for table %v", t.tableName)
+	}
+	freeze := &PartitionFreeze{}
+	err = proto.Unmarshal(valueBytes, freeze)
+	if err != nil {
+		return nil, errors.Wrapf(err, "protobuf unmarshal failed for table %v on value length %v", t.tableName, len(valueBytes))
+	}
+	return freeze, nil
+}
+
+func (t *FreezeTable) Delete(txn badgerwrap.Txn, name string) error {
+	err := txn.Delete([]byte(t.Key(name)))
+	if err != nil {
+		return errors.Wrapf(err, "delete for table %v failed", t.tableName)
+	}
+	return nil
+}
+
+// Returns every freeze, ordered by name
+func (t *FreezeTable) ReadAll(txn badgerwrap.Txn) ([]*PartitionFreeze, error) {
+	freezes := []*PartitionFreeze{}
+	prefix := []byte("/" + t.tableName + "/")
+	iterOpt := badger.DefaultIteratorOptions
+	iterOpt.Prefix = prefix
+	itr := txn.NewIterator(iterOpt)
+	defer itr.Close()
+	for itr.Seek(prefix); itr.ValidForPrefix(prefix); itr.Next() {
+		valueBytes, err := itr.Item().ValueCopy([]byte{})
+		if err != nil {
+			return nil, errors.Wrapf(err, "value copy failed for table %v", t.tableName)
+		}
+		freeze := &PartitionFreeze{}
+		err = proto.Unmarshal(valueBytes, freeze)
+		if err != nil {
+			return nil, errors.Wrapf(err, "protobuf unmarshal failed for table %v on value length %v", t.tableName, len(valueBytes))
+		}
+		freezes = append(freezes, freeze)
+	}
+	return freezes, nil
+}
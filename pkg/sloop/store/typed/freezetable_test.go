@@ -0,0 +1,48 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+func Test_FreezeTable_SetGetDeleteAndReadAll(t *testing.T) {
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	table := OpenFreezeTable()
+	assert.Equal(t, "/freeze/inc-42", table.Key("inc-42"))
+
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		assert.Nil(t, table.Set(txn, &PartitionFreeze{Name: "b", FromPartition: "001546398000", ToPartition: "001546405200"}))
+		assert.Nil(t, table.Set(txn, &PartitionFreeze{Name: "a", FromPartition: "001546398000", ToPartition: "001546398000"}))
+		return nil
+	})
+	assert.Nil(t, err)
+
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		freeze, err := table.Get(txn, "b")
+		assert.Nil(t, err)
+		assert.Equal(t, "001546405200", freeze.ToPartition)
+		missing, err := table.Get(txn, "missing")
+		assert.Nil(t, err)
+		assert.Nil(t, missing)
+
+		assert.Nil(t, table.Delete(txn, "a"))
+		freezes, err := table.ReadAll(txn)
+		assert.Nil(t, err)
+		assert.Len(t, freezes, 1)
+		assert.Equal(t, "b", freezes[0].Name)
+		return nil
+	})
+	assert.Nil(t, err)
+}
@@ -0,0 +1,89 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"strconv"
+	"time"
+)
+
+// Key is /<partition>/<kind>/<namespace>/<type>/<timestamp>
+//
+// Timestamp is the first time the annotation was seen, UnixNano in UTC
+
+const (
+	// The informer failed to list or watch
+	IngestAnnotationWatchError = "WatchError"
+	// The api server answered with 429 Too Many Requests
+	IngestAnnotationThrottled = "Throttled"
+	// The informer listed again and found deletes it never got a watch event for
+	IngestAnnotationRelist = "Relist"
+	// Resources of the kind were stored with another api version than before, the message has both versions
+	IngestAnnotationApiVersionMigration = "ApiVersionMigration"
+	// Nodes came back with a new kubelet version, the message has the latest node and both versions
+	IngestAnnotationClusterUpgrade = "ClusterUpgrade"
+)
+
+// Nodes of a cluster are upgraded one after the other, version changes this close to the last one are the same upgrade
+const ClusterUpgradeMergeWindow = time.Hour
+
+type IngestAnnotationKey struct {
+	PartitionId string
+	Kind        string
+	Namespace   string
+	Type        string
+	Timestamp   time.Time
+}
+
+func NewIngestAnnotationKey(partitionId string, kind string, namespace string, annotationType string, timestamp time.Time) *IngestAnnotationKey {
+	return &IngestAnnotationKey{PartitionId: partitionId, Kind: kind, Namespace: namespace, Type: annotationType, Timestamp: timestamp}
+}
+
+func (*IngestAnnotationKey) TableName() string {
+	return "ingestannotation"
+}
+
+func (k *IngestAnnotationKey) Parse(key string) error {
+	err, parts := common.ParseKey(key)
+	if err != nil {
+		return err
+	}
+
+	if parts[1] != k.TableName() {
+		return fmt.Errorf("Second part of key (%v) should be %v", key, k.TableName())
+	}
+	k.PartitionId = parts[2]
+	k.Kind = parts[3]
+	k.Namespace = parts[4]
+	k.Type = parts[5]
+	tsint, err := strconv.ParseInt(parts[6], 10, 64)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to parse timestamp from key: %v", key)
+	}
+	k.Timestamp = time.Unix(0, tsint).UTC()
+	return nil
+}
+
+func (k *IngestAnnotationKey) String() string {
+	if k.Timestamp.IsZero() {
+		return fmt.Sprintf("/%v/%v/%v/%v/%v", k.TableName(), k.PartitionId, k.Kind, k.Namespace, k.Type)
+	}
+	return fmt.Sprintf("/%v/%v/%v/%v/%v/%v", k.TableName(), k.PartitionId, k.Kind, k.Namespace, k.Type, k.Timestamp.UnixNano())
+}
+
+func (*IngestAnnotationKey) ValidateKey(key string) error {
+	newKey := IngestAnnotationKey{}
+	return newKey.Parse(key)
+}
+
+func (k *IngestAnnotationKey) SetPartitionId(newPartitionId string) {
+	k.PartitionId = newPartitionId
+}
@@ -0,0 +1,60 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_IngestAnnotationKey_OutputCorrect(t *testing.T) {
+	k := NewIngestAnnotationKey("001562961600", someKind, someNamespace, IngestAnnotationThrottled, someTs)
+	assert.Equal(t, "/ingestannotation/001562961600/somekind/somenamespace/Throttled/1546398245000000006", k.String())
+}
+
+func Test_IngestAnnotationKey_ParseCorrect(t *testing.T) {
+	k := &IngestAnnotationKey{}
+	err := k.Parse("/ingestannotation/001562961600///WatchError/1546398245000000006")
+	assert.Nil(t, err)
+	assert.Equal(t, "001562961600", k.PartitionId)
+	assert.Equal(t, "", k.Kind)
+	assert.Equal(t, "", k.Namespace)
+	assert.Equal(t, IngestAnnotationWatchError, k.Type)
+	assert.Equal(t, someTs, k.Timestamp)
+}
+
+func Test_IngestAnnotationKey_ValidateWorks(t *testing.T) {
+	assert.Nil(t, (&IngestAnnotationKey{}).ValidateKey("/ingestannotation/001562961600/somekind/somenamespace/Relist/1546398245000000006"))
+	assert.NotNil(t, (&IngestAnnotationKey{}).ValidateKey("/deadletter/001562961600/somekind/somenamespace/Relist/1546398245000000006"))
+}
+
+func (*IngestAnnotationKey) GetTestKey() string {
+	k := NewIngestAnnotationKey(someMinPartition, someKind, someNamespace, IngestAnnotationWatchError, someTs)
+	return k.String()
+}
+
+func (*IngestAnnotationKey) GetTestValue() *IngestAnnotation {
+	return &IngestAnnotation{}
+}
+
+func (*IngestAnnotationKey) SetTestKeys() []string {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	var keys []string
+	for curTime := someTs; !curTime.After(someMaxTs); curTime = curTime.Add(untyped.GetPartitionDuration()) {
+		partitionId := untyped.GetPartitionId(curTime)
+		keys = append(keys, NewIngestAnnotationKey(partitionId, someKind, someNamespace, IngestAnnotationWatchError, someTs.Add(time.Hour*-5)).String())
+		keys = append(keys, NewIngestAnnotationKey(partitionId, someKind, someNamespace, IngestAnnotationWatchError, someTs).String())
+	}
+	return keys
+}
+
+func (*IngestAnnotationKey) SetTestValue() *IngestAnnotation {
+	return &IngestAnnotation{Type: IngestAnnotationWatchError, Message: "some error", Count: 1}
+}
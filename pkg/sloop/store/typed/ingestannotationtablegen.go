@@ -0,0 +1,464 @@
+// This file was automatically generated by genny.
+// Any changes will be lost if this file is regenerated.
+// see https://github.com/cheekybits/genny
+
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"fmt"
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"strconv"
+	"strings"
+	"time"
+
+	badger "github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+type IngestAnnotationTable struct {
+	tableName string
+}
+
+func OpenIngestAnnotationTable() *IngestAnnotationTable {
+	keyInst := &IngestAnnotationKey{}
+	return &IngestAnnotationTable{tableName: keyInst.TableName()}
+}
+
+func (t *IngestAnnotationTable) Set(txn badgerwrap.Txn, key string, value *IngestAnnotation) error {
+	err := (&IngestAnnotationKey{}).ValidateKey(key)
+	if err != nil {
+		return errors.Wrapf(err, "invalid key for table %v: %v", t.tableName, key)
+	}
+
+	outb, err := proto.Marshal(value)
+	if err != nil {
+		return errors.Wrapf(err, "protobuf marshal for table %v failed", t.tableName)
+	}
+
+	outb, err = encodeValue(txn, t.tableName, key, outb)
+	if err != nil {
+		return errors.Wrapf(err, "value encode for table %v failed", t.tableName)
+	}
+
+	err = txn.Set([]byte(key), outb)
+	if err != nil {
+		return errors.Wrapf(err, "set for table %v failed", t.tableName)
+	}
+	return nil
+}
+
+func (t *IngestAnnotationTable) Get(txn badgerwrap.Txn, key string) (*IngestAnnotation, error) {
+	err := (&IngestAnnotationKey{}).ValidateKey(key)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid key for table %v: %v", t.tableName, key)
+	}
+
+	item, err := txn.Get([]byte(key))
+	if err == badger.ErrKeyNotFound {
+		// Dont wrap. Need to preserve error type
+		return nil, err
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "get failed for table %v", t.tableName)
+	}
+
+	valueBytes, err := item.ValueCopy([]byte{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "value copy failed for table %v", t.tableName)
+	}
+
+	valueBytes, err = decodeValue(txn, key, valueBytes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "value decode failed for table %v", t.tableName)
+	}
+
+	retValue := &IngestAnnotation{}
+	err = proto.Unmarshal(valueBytes, retValue)
+	if err != nil {
+		return nil, errors.Wrapf(err, "protobuf unmarshal failed for table %v on value length %v", t.tableName, len(valueBytes))
+	}
+	return retValue, nil
+}
+
+func (t *IngestAnnotationTable) GetMinKey(txn badgerwrap.Txn) (bool, string) {
+	keyPrefix := "/" + t.tableName + "/"
+	iterOpt := badger.DefaultIteratorOptions
+	iterOpt.Prefix = []byte(keyPrefix)
+	iterator := txn.NewIterator(iterOpt)
+	defer iterator.Close()
+	iterator.Seek([]byte(keyPrefix))
+	if !iterator.ValidForPrefix([]byte(keyPrefix)) {
+		return false, ""
+	}
+	return true, string(iterator.Item().Key())
+}
+
+func (t *IngestAnnotationTable) GetMaxKey(txn badgerwrap.Txn) (bool, string) {
+	keyPrefix := "/" + t.tableName + "/"
+	iterOpt := badger.DefaultIteratorOptions
+	iterOpt.Prefix = []byte(keyPrefix)
+	iterOpt.Reverse = true
+	iterator := txn.NewIterator(iterOpt)
+	defer iterator.Close()
+	// We need to seek to the end of the range so we add a 255 character at the end
+	iterator.Seek([]byte(keyPrefix + string(rune(255))))
+	if !iterator.Valid() {
+		return false, ""
+	}
+	return true, string(iterator.Item().Key())
+}
+
+func (t *IngestAnnotationTable) GetMinMaxPartitions(txn badgerwrap.Txn) (bool, string, string) {
+	minPartitionOk, minPar := t.GetMinPartition(txn)
+
+	if !minPartitionOk {
+		return false, "", ""
+	}
+
+	maxPartitionOk, maxPar := t.GetMaxPartition(txn)
+	return maxPartitionOk, minPar, maxPar
+}
+
+func (t *IngestAnnotationTable) GetMaxPartition(txn badgerwrap.Txn) (bool, string) {
+	ok, maxKeyStr := t.GetMaxKey(txn)
+	if !ok {
+		return false, ""
+	}
+
+	maxKey := &IngestAnnotationKey{}
+
+	err := maxKey.Parse(maxKeyStr)
+	if err != nil {
+		panic(fmt.Sprintf("invalid key in table: %v key: %q error: %v", t.tableName, maxKeyStr, err))
+	}
+
+	return true, maxKey.PartitionId
+}
+
+func (t *IngestAnnotationTable) GetMinPartition(txn badgerwrap.Txn) (bool, string) {
+	ok, minKeyStr := t.GetMinKey(txn)
+	if !ok {
+		return false, ""
+	}
+
+	minKey := &IngestAnnotationKey{}
+
+	err := minKey.Parse(minKeyStr)
+	if err != nil {
+		panic(fmt.Sprintf("invalid key in table: %v key: %q error: %v", t.tableName, minKeyStr, err))
+	}
+
+	return true, minKey.PartitionId
+}
+
+func (t *IngestAnnotationTable) GetUniquePartitionList(txn badgerwrap.Txn) ([]string, error) {
+	resources := []string{}
+	ok, minPar, maxPar := t.GetMinMaxPartitions(txn)
+	if ok {
+		parDuration := untyped.GetPartitionDuration()
+		for curPar := minPar; curPar <= maxPar; {
+			resources = append(resources, curPar)
+			// update curPar
+			partInt, err := strconv.ParseInt(curPar, 10, 64)
+			if err != nil {
+				return resources, errors.Wrapf(err, "failed to get partition:%v", curPar)
+			}
+			parTime := time.Unix(partInt, 0).UTC().Add(parDuration)
+			curPar = untyped.GetPartitionId(parTime)
+		}
+	}
+	return resources, nil
+}
+
+func (t *IngestAnnotationTable) GetPreviousKey(txn badgerwrap.Txn, key *IngestAnnotationKey, keyComparator *IngestAnnotationKey) (*IngestAnnotationKey, error) {
+	partitionList, err := t.GetUniquePartitionList(txn)
+	if err != nil {
+		return &IngestAnnotationKey{}, errors.Wrapf(err, "failed to get partition list from table:%v", t.tableName)
+	}
+	currentPartition := key.PartitionId
+	for i := len(partitionList) - 1; i >= 0; i-- {
+		prePart := partitionList[i]
+		if prePart > currentPartition {
+			continue
+		} else {
+			prevFound, prevKey, err := t.getLastMatchingKeyInPartition(txn, prePart, key, keyComparator)
+			if err != nil {
+				return &IngestAnnotationKey{}, errors.Wrapf(err, "Failure getting previous key for %v, for partition id:%v", key.String(), prePart)
+			}
+			if prevFound && err == nil {
+				return prevKey, nil
+			}
+		}
+	}
+	return &IngestAnnotationKey{}, fmt.Errorf("failed to get any previous key in table:%v, for key:%v, keyComparator:%v", t.tableName, key.String(), keyComparator)
+}
+
+func (t *IngestAnnotationTable) getLastMatchingKeyInPartition(txn badgerwrap.Txn, curPartition string, curKey *IngestAnnotationKey, keyComparator *IngestAnnotationKey) (bool, *IngestAnnotationKey, error) {
+	iterOpt := badger.DefaultIteratorOptions
+	iterOpt.Reverse = true
+	itr := txn.NewIterator(iterOpt)
+	defer itr.Close()
+
+	oldKey := curKey.String()
+
+	// update partition with current value
+	curKey.SetPartitionId(curPartition)
+	keyComparator.SetPartitionId(curPartition)
+
+	keySeekStr := curKey.String() + string(rune(255))
+	itr.Seek([]byte(keySeekStr))
+
+	// if the result is same as key, we want to check its previous one
+	if itr.Valid() && oldKey == string(itr.Item().Key()) {
+		itr.Next()
+	}
+
+	if itr.ValidForPrefix([]byte(keyComparator.String())) {
+		key := &IngestAnnotationKey{}
+		err := key.Parse(string(itr.Item().Key()))
+		if err != nil {
+			return true, &IngestAnnotationKey{}, err
+		}
+		return true, key, nil
+	}
+	return false, &IngestAnnotationKey{}, nil
+}
+
+func (t *IngestAnnotationTable) RangeRead(txn badgerwrap.Txn, keyPrefix *IngestAnnotationKey,
+	keyPredicateFn func(string) bool, valPredicateFn func(*IngestAnnotation) bool, startTime time.Time, endTime time.Time) (map[IngestAnnotationKey]*IngestAnnotation, RangeReadStats, error) {
+	resources := map[IngestAnnotationKey]*IngestAnnotation{}
+
+	stats := RangeReadStats{}
+	before := time.Now()
+
+	partitionList, err := t.GetPartitionsFromTimeRange(txn, startTime, endTime)
+	stats.PartitionCount = len(partitionList)
+	if err != nil {
+		return resources, stats, errors.Wrapf(err, "failed to get partitions from table:%v, from startTime:%v, to endTime:%v", t.tableName, startTime, endTime)
+	}
+
+	for _, currentPartition := range partitionList {
+		var seekStr string
+
+		// when keyPrefix does not have such info as kind,namespace,and etc, we seek from /tableName/currentPartition/
+		if keyPrefix == nil {
+			seekStr = "/" + t.tableName + "/" + currentPartition + "/"
+		} else {
+			// update keyPrefix with current partition
+			keyPrefix.SetPartitionId(currentPartition)
+			seekStr = keyPrefix.String()
+		}
+
+		itr := txn.NewIterator(badger.IteratorOptions{Prefix: []byte(seekStr)})
+		defer itr.Close()
+
+		//in worst case, when seekStr = /table/partition, we need to iterate a key list and return all of them
+		//in most cases, we should only hit one result per partition
+		for itr.Seek([]byte(seekStr)); itr.ValidForPrefix([]byte(seekStr)); itr.Next() {
+			stats.RowsVisitedCount += 1
+			if keyPredicateFn != nil {
+				if !keyPredicateFn(string(itr.Item().Key())) {
+					continue
+				}
+			}
+			key := IngestAnnotationKey{}
+			err := key.Parse(string(itr.Item().Key()))
+			if err != nil {
+				return nil, stats, err
+			}
+
+			stats.RowsPassedKeyPredicateCount += 1
+
+			valueBytes, err := itr.Item().ValueCopy([]byte{})
+			if err != nil {
+				return nil, stats, err
+			}
+			valueBytes, err = decodeValue(txn, string(itr.Item().Key()), valueBytes)
+			if err != nil {
+				return nil, stats, err
+			}
+			retValue := &IngestAnnotation{}
+			err = proto.Unmarshal(valueBytes, retValue)
+			if err != nil {
+				return nil, stats, err
+			}
+			if valPredicateFn != nil && !valPredicateFn(retValue) {
+				continue
+			}
+			stats.RowsPassedValuePredicateCount += 1
+			resources[key] = retValue
+		}
+
+		//Close() is safe to call more than once, close at the end of each partition to avoid having old iterators open
+		itr.Close()
+	}
+
+	stats.Elapsed = time.Since(before)
+	stats.TableName = (&IngestAnnotationKey{}).TableName()
+	return resources, stats, nil
+}
+
+// One row of RangeReadReverse
+type IngestAnnotationRow struct {
+	Key   IngestAnnotationKey
+	Value *IngestAnnotation
+}
+
+// Same as RangeRead but walks partitions newest first and keys within each partition in descending order, and
+// returns the rows in that order.  Reading stops as soon as maxRows rows have passed both predicates, so asking for
+// the latest few versions of a resource does not scan the whole time range.  maxRows <= 0 means no limit.
+func (t *IngestAnnotationTable) RangeReadReverse(txn badgerwrap.Txn, keyPrefix *IngestAnnotationKey,
+	keyPredicateFn func(string) bool, valPredicateFn func(*IngestAnnotation) bool, startTime time.Time, endTime time.Time, maxRows int) ([]IngestAnnotationRow, RangeReadStats, error) {
+	var rows []IngestAnnotationRow
+
+	stats := RangeReadStats{}
+	before := time.Now()
+
+	partitionList, err := t.GetPartitionsFromTimeRange(txn, startTime, endTime)
+	stats.PartitionCount = len(partitionList)
+	if err != nil {
+		return rows, stats, errors.Wrapf(err, "failed to get partitions from table:%v, from startTime:%v, to endTime:%v", t.tableName, startTime, endTime)
+	}
+
+	for i := len(partitionList) - 1; i >= 0; i-- {
+		currentPartition := partitionList[i]
+		var seekStr string
+		if keyPrefix == nil {
+			seekStr = "/" + t.tableName + "/" + currentPartition + "/"
+		} else {
+			keyPrefix.SetPartitionId(currentPartition)
+			seekStr = keyPrefix.String()
+		}
+
+		itr := txn.NewIterator(badger.IteratorOptions{Prefix: []byte(seekStr), Reverse: true})
+		defer itr.Close()
+
+		// In reverse a seek lands on the largest key <= the seek key, so seek past the end of the prefix
+		for itr.Seek([]byte(seekStr + string(rune(255)))); itr.ValidForPrefix([]byte(seekStr)); itr.Next() {
+			stats.RowsVisitedCount += 1
+			if keyPredicateFn != nil {
+				if !keyPredicateFn(string(itr.Item().Key())) {
+					continue
+				}
+			}
+			key := IngestAnnotationKey{}
+			err := key.Parse(string(itr.Item().Key()))
+			if err != nil {
+				return nil, stats, err
+			}
+
+			stats.RowsPassedKeyPredicateCount += 1
+
+			valueBytes, err := itr.Item().ValueCopy([]byte{})
+			if err != nil {
+				return nil, stats, err
+			}
+			valueBytes, err = decodeValue(txn, string(itr.Item().Key()), valueBytes)
+			if err != nil {
+				return nil, stats, err
+			}
+			retValue := &IngestAnnotation{}
+			err = proto.Unmarshal(valueBytes, retValue)
+			if err != nil {
+				return nil, stats, err
+			}
+			if valPredicateFn != nil && !valPredicateFn(retValue) {
+				continue
+			}
+			stats.RowsPassedValuePredicateCount += 1
+			rows = append(rows, IngestAnnotationRow{Key: key, Value: retValue})
+			if maxRows > 0 && len(rows) >= maxRows {
+				itr.Close()
+				stats.Elapsed = time.Since(before)
+				stats.TableName = (&IngestAnnotationKey{}).TableName()
+				return rows, stats, nil
+			}
+		}
+
+		itr.Close()
+	}
+
+	stats.Elapsed = time.Since(before)
+	stats.TableName = (&IngestAnnotationKey{}).TableName()
+	return rows, stats, nil
+}
+
+//todo: need to add unit test
+func (t *IngestAnnotationTable) GetPartitionsFromTimeRange(txn badgerwrap.Txn, startTime time.Time, endTime time.Time) ([]string, error) {
+	resources := []string{}
+	startPartition := untyped.GetPartitionId(startTime)
+	endPartition := untyped.GetPartitionId(endTime)
+	parDuration := untyped.GetPartitionDuration()
+	for curPar := startPartition; curPar <= endPartition; {
+		resources = append(resources, curPar)
+		// update curPar
+		partInt, err := strconv.ParseInt(curPar, 10, 64)
+		if err != nil {
+			return resources, errors.Wrapf(err, "failed to get partition:%v", curPar)
+		}
+		parTime := time.Unix(partInt, 0).UTC().Add(parDuration)
+		curPar = untyped.GetPartitionId(parTime)
+	}
+	return resources, nil
+}
+
+func IngestAnnotation_ValPredicateFns(valFn ...func(*IngestAnnotation) bool) func(*IngestAnnotation) bool {
+	return func(result *IngestAnnotation) bool {
+		for _, thisFn := range valFn {
+			if !thisFn(result) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+func IngestAnnotation_KeyPredicateFns(keyFn ...func(string) bool) func(string) bool {
+	return func(result string) bool {
+		for _, thisFn := range keyFn {
+			if !thisFn(result) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Return all keys in all partitions in the given a lookback period
+func (t *IngestAnnotationTable) GetAllKeysForGivenPartitions(db badgerwrap.DB, key *IngestAnnotationKey, maxNumberOfKeys int, lookBack int, keyPrefix string) []string {
+	var keys []string
+	var partitionList []string
+	_ = db.View(func(txn badgerwrap.Txn) error {
+		partitionList, _ = t.GetUniquePartitionList(txn)
+		return nil
+	})
+
+	count := 0
+	lookBackVal := lookBack
+
+	if len(partitionList) < lookBack {
+		lookBackVal = len(partitionList)
+	}
+
+	for i := len(partitionList) - 1; i >= len(partitionList)-lookBackVal; i-- {
+		prePart := partitionList[i]
+		key.SetPartitionId(prePart)
+		keyValue := strings.TrimRight(key.String(), "/") + keyPrefix
+		keys = append(keys, common.GetKeysForPrefix(db, keyValue)...)
+		count += len(keys)
+		if count >= maxNumberOfKeys {
+			return keys
+		}
+	}
+
+	return keys
+}
@@ -0,0 +1,74 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"strings"
+
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+)
+
+/*
+Returns the namespace the row of a key is about.  For rows of a Namespace that is its name, so the row goes with
+the resources in it.  Empty for cluster scoped resources.  False when the table is not scoped to a namespace at all,
+the key is malformed or the name of a Namespace in a watch key is an id that is not in the dictionary
+*/
+func KeyNamespace(key string) (string, bool) {
+	parts := strings.Split(key, "/")
+	if len(parts) < 4 {
+		return "", false
+	}
+	switch parts[1] {
+	// Keys of these are /table/partition/kind/namespace/name/...
+	case (&WatchTableKey{}).TableName(), (&ResourceSummaryKey{}).TableName(), (&EventCountKey{}).TableName(),
+		(&EventFoldKey{}).TableName(), (&WatchActivityKey{}).TableName(), (&NodeConditionKey{}).TableName(),
+		(&PodLifecycleKey{}).TableName(), (&CurrentStateKey{}).TableName(), (&DeadLetterKey{}).TableName(),
+		(&QuarantineKey{}).TableName(), eventRollupTableNames[EventRollupHour], eventRollupTableNames[EventRollupDay]:
+		if len(parts) < 6 {
+			return "", false
+		}
+		if parts[3] != kubeextractor.NamespaceKind {
+			return parts[4], true
+		}
+		if parts[1] != (&WatchTableKey{}).TableName() {
+			return parts[5], true
+		}
+		// Names in watch keys can be dictionary ids
+		name, err := watchKeyNames.decode(parts[2], parts[5])
+		if err != nil {
+			return "", false
+		}
+		return name, true
+	case (&OwnerEdgeKey{}).TableName():
+		if len(parts) < 6 {
+			return "", false
+		}
+		return parts[5], true
+	case (&ServiceBackendsKey{}).TableName(), eventHeatMapTableName:
+		return parts[3], true
+	}
+	return "", false
+}
+
+// Returns the kind the row of a key is about, for the tables KeyNamespace knows.  For the owner graph that is the kind
+// of the child, for service backends it is Service and for the event heat map it is Event
+func KeyKind(key string) (string, bool) {
+	if _, ok := KeyNamespace(key); !ok {
+		return "", false
+	}
+	parts := strings.Split(key, "/")
+	switch parts[1] {
+	case (&OwnerEdgeKey{}).TableName():
+		return parts[4], true
+	case (&ServiceBackendsKey{}).TableName():
+		return kubeextractor.ServiceKind, true
+	case eventHeatMapTableName:
+		return kubeextractor.EventKind, true
+	}
+	return parts[3], true
+}
@@ -0,0 +1,52 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"testing"
+	"time"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_KeyNamespace(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	for key, expected := range map[string]string{
+		NewWatchTableKey("001546405200", "Pod", "some-ns", "some-name", someTs).String():            "some-ns",
+		NewResourceSummaryKey(someTs, "Namespace", "", "some-ns", "some-uid").String():              "some-ns",
+		NewResourceSummaryKey(someTs, "Node", "", "some-node", "some-uid").String():                 "",
+		NewOwnerEdgeKey("001546405200", "owner-uid", "Pod", "some-ns", "child-uid").String():        "some-ns",
+		NewServiceBackendsKey("001546405200", "some-ns", "some-svc", "EndpointSlice", "x").String(): "some-ns",
+	} {
+		namespace, ok := KeyNamespace(key)
+		assert.True(t, ok, key)
+		assert.Equal(t, expected, namespace, key)
+	}
+	_, ok := KeyNamespace("/someExtraTable/" + "001546405200" + "/a/b/c")
+	assert.False(t, ok)
+	_, ok = KeyNamespace("/watch")
+	assert.False(t, ok)
+}
+
+func Test_KeyKind(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	for key, expected := range map[string]string{
+		NewWatchTableKey("001546405200", "Pod", "some-ns", "some-name", someTs).String():            "Pod",
+		NewResourceSummaryKey(someTs, "Namespace", "", "some-ns", "some-uid").String():              "Namespace",
+		NewOwnerEdgeKey("001546405200", "owner-uid", "Pod", "some-ns", "child-uid").String():        "Pod",
+		NewServiceBackendsKey("001546405200", "some-ns", "some-svc", "EndpointSlice", "x").String(): "Service",
+	} {
+		kind, ok := KeyKind(key)
+		assert.True(t, ok, key)
+		assert.Equal(t, expected, kind, key)
+	}
+	_, ok := KeyKind("/someExtraTable/" + "001546405200" + "/a/b/c")
+	assert.False(t, ok)
+}
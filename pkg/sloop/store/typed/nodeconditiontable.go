@@ -0,0 +1,79 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"fmt"
+	"github.com/dgraph-io/badger/v2"
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+// Key is /<partition>/<kind>/<namespace>/<name>/<uid>
+//
+// Kind is always Node and namespace is always empty, they are kept so the key has the same layout as the watch
+// activity table
+
+type NodeConditionKey struct {
+	PartitionId string
+	Kind        string
+	Namespace   string
+	Name        string
+	Uid         string
+}
+
+func NewNodeConditionKey(partitionId string, name string, uid string) *NodeConditionKey {
+	return &NodeConditionKey{PartitionId: partitionId, Kind: "Node", Name: name, Uid: uid}
+}
+
+func (*NodeConditionKey) TableName() string {
+	return "nodecondition"
+}
+
+func (k *NodeConditionKey) Parse(key string) error {
+	err, parts := common.ParseKey(key)
+	if err != nil {
+		return err
+	}
+
+	if parts[1] != k.TableName() {
+		return fmt.Errorf("Second part of key (%v) should be %v", key, k.TableName())
+	}
+	k.PartitionId = parts[2]
+	k.Kind = parts[3]
+	k.Namespace = parts[4]
+	k.Name = parts[5]
+	k.Uid = parts[6]
+	return nil
+}
+
+func (k *NodeConditionKey) String() string {
+	return fmt.Sprintf("/%v/%v/%v/%v/%v/%v", k.TableName(), k.PartitionId, k.Kind, k.Namespace, k.Name, k.Uid)
+}
+
+func (*NodeConditionKey) ValidateKey(key string) error {
+	newKey := NodeConditionKey{}
+	return newKey.Parse(key)
+}
+
+func (k *NodeConditionKey) SetPartitionId(newPartitionId string) {
+	k.PartitionId = newPartitionId
+}
+
+func (t *NodeConditionsTable) GetOrDefault(txn badgerwrap.Txn, key string) (*NodeConditions, error) {
+	rec, err := t.Get(txn, key)
+	if err != nil {
+		if err != badger.ErrKeyNotFound {
+			return nil, err
+		} else {
+			return &NodeConditions{}, nil
+		}
+	}
+	return rec, nil
+}
+
@@ -0,0 +1,60 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_NodeConditionKey_OutputCorrect(t *testing.T) {
+	k := NewNodeConditionKey("001562961600", someName, someUid)
+	assert.Equal(t, "/nodecondition/001562961600/Node//somename/68510937-4ffc-11e9-8e26-1418775557c8", k.String())
+}
+
+func Test_NodeConditionKey_ParseCorrect(t *testing.T) {
+	k := &NodeConditionKey{}
+	err := k.Parse("/nodecondition/001562961600/Node//somename/68510937-4ffc-11e9-8e26-1418775557c8")
+	assert.Nil(t, err)
+	assert.Equal(t, "001562961600", k.PartitionId)
+	assert.Equal(t, "Node", k.Kind)
+	assert.Equal(t, "", k.Namespace)
+	assert.Equal(t, someName, k.Name)
+	assert.Equal(t, someUid, k.Uid)
+}
+
+func Test_NodeConditionKey_ValidateWorks(t *testing.T) {
+	assert.Nil(t, (&NodeConditionKey{}).ValidateKey("/nodecondition/001562961600/Node//somename/68510937-4ffc-11e9-8e26-1418775557c8"))
+	assert.NotNil(t, (&NodeConditionKey{}).ValidateKey("/watchactivity/001562961600/Node//somename/68510937-4ffc-11e9-8e26-1418775557c8"))
+}
+
+func (*NodeConditionKey) GetTestKey() string {
+	k := NewNodeConditionKey(someMinPartition, someName, someUid)
+	return k.String()
+}
+
+func (*NodeConditionKey) GetTestValue() *NodeConditions {
+	return &NodeConditions{}
+}
+
+func (*NodeConditionKey) SetTestKeys() []string {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	var keys []string
+	for curTime := someTs; !curTime.After(someMaxTs); curTime = curTime.Add(untyped.GetPartitionDuration()) {
+		partitionId := untyped.GetPartitionId(curTime)
+		keys = append(keys, NewNodeConditionKey(partitionId, someName, someUid).String())
+		keys = append(keys, NewNodeConditionKey(partitionId, someName+"b", someUid).String())
+	}
+	return keys
+}
+
+func (*NodeConditionKey) SetTestValue() *NodeConditions {
+	return &NodeConditions{Transitions: []*NodeConditionTransition{{Type: "Ready", Status: "True"}}}
+}
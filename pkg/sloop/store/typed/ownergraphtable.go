@@ -0,0 +1,86 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"fmt"
+	"github.com/dgraph-io/badger/v2"
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+// Key is /<partition>/<owner uid>/<child kind>/<child namespace>/<child uid>
+//
+// Owner uid comes first so all children of an owner are one prefix lookup
+
+type OwnerEdgeKey struct {
+	PartitionId    string
+	OwnerUid       string
+	ChildKind      string
+	ChildNamespace string
+	ChildUid       string
+}
+
+func NewOwnerEdgeKey(partitionId string, ownerUid string, childKind string, childNamespace string, childUid string) *OwnerEdgeKey {
+	return &OwnerEdgeKey{PartitionId: partitionId, OwnerUid: ownerUid, ChildKind: childKind, ChildNamespace: childNamespace, ChildUid: childUid}
+}
+
+// Prefix for all children of an owner
+func NewOwnerEdgeKeyComparator(ownerUid string) *OwnerEdgeKey {
+	return &OwnerEdgeKey{OwnerUid: ownerUid}
+}
+
+func (*OwnerEdgeKey) TableName() string {
+	return "ownergraph"
+}
+
+func (k *OwnerEdgeKey) Parse(key string) error {
+	err, parts := common.ParseKey(key)
+	if err != nil {
+		return err
+	}
+
+	if parts[1] != k.TableName() {
+		return fmt.Errorf("Second part of key (%v) should be %v", key, k.TableName())
+	}
+	k.PartitionId = parts[2]
+	k.OwnerUid = parts[3]
+	k.ChildKind = parts[4]
+	k.ChildNamespace = parts[5]
+	k.ChildUid = parts[6]
+	return nil
+}
+
+// With an empty child uid this is a prefix for all children of the owner
+func (k *OwnerEdgeKey) String() string {
+	if k.ChildUid == "" {
+		return fmt.Sprintf("/%v/%v/%v/", k.TableName(), k.PartitionId, k.OwnerUid)
+	}
+	return fmt.Sprintf("/%v/%v/%v/%v/%v/%v", k.TableName(), k.PartitionId, k.OwnerUid, k.ChildKind, k.ChildNamespace, k.ChildUid)
+}
+
+func (*OwnerEdgeKey) ValidateKey(key string) error {
+	newKey := OwnerEdgeKey{}
+	return newKey.Parse(key)
+}
+
+func (k *OwnerEdgeKey) SetPartitionId(newPartitionId string) {
+	k.PartitionId = newPartitionId
+}
+
+func (t *OwnerEdgeTable) GetOrDefault(txn badgerwrap.Txn, key string) (*OwnerEdge, error) {
+	rec, err := t.Get(txn, key)
+	if err != nil {
+		if err != badger.ErrKeyNotFound {
+			return nil, err
+		} else {
+			return &OwnerEdge{}, nil
+		}
+	}
+	return rec, nil
+}
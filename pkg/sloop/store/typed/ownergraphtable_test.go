@@ -0,0 +1,68 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+const someOwnerUid = "4d2ab0f6-4ffc-11e9-8e26-1418775557c8"
+
+func Test_OwnerEdgeKey_OutputCorrect(t *testing.T) {
+	k := NewOwnerEdgeKey("001562961600", someOwnerUid, someKind, someNamespace, someUid)
+	assert.Equal(t, "/ownergraph/001562961600/4d2ab0f6-4ffc-11e9-8e26-1418775557c8/somekind/somenamespace/68510937-4ffc-11e9-8e26-1418775557c8", k.String())
+}
+
+func Test_OwnerEdgeKey_ComparatorIsPrefix(t *testing.T) {
+	k := NewOwnerEdgeKeyComparator(someOwnerUid)
+	k.SetPartitionId("001562961600")
+	assert.Equal(t, "/ownergraph/001562961600/4d2ab0f6-4ffc-11e9-8e26-1418775557c8/", k.String())
+}
+
+func Test_OwnerEdgeKey_ParseCorrect(t *testing.T) {
+	k := &OwnerEdgeKey{}
+	err := k.Parse("/ownergraph/001562961600/4d2ab0f6-4ffc-11e9-8e26-1418775557c8/somekind/somenamespace/68510937-4ffc-11e9-8e26-1418775557c8")
+	assert.Nil(t, err)
+	assert.Equal(t, "001562961600", k.PartitionId)
+	assert.Equal(t, someOwnerUid, k.OwnerUid)
+	assert.Equal(t, someKind, k.ChildKind)
+	assert.Equal(t, someNamespace, k.ChildNamespace)
+	assert.Equal(t, someUid, k.ChildUid)
+}
+
+func Test_OwnerEdgeKey_ValidateWorks(t *testing.T) {
+	assert.Nil(t, (&OwnerEdgeKey{}).ValidateKey("/ownergraph/001562961600/4d2ab0f6-4ffc-11e9-8e26-1418775557c8/somekind/somenamespace/68510937-4ffc-11e9-8e26-1418775557c8"))
+	assert.NotNil(t, (&OwnerEdgeKey{}).ValidateKey("/ownergraph/001562961600/4d2ab0f6-4ffc-11e9-8e26-1418775557c8/"))
+}
+
+func (*OwnerEdgeKey) GetTestKey() string {
+	k := NewOwnerEdgeKey(someMinPartition, someOwnerUid, someKind, someNamespace, someUid)
+	return k.String()
+}
+
+func (*OwnerEdgeKey) GetTestValue() *OwnerEdge {
+	return &OwnerEdge{}
+}
+
+func (*OwnerEdgeKey) SetTestKeys() []string {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	var keys []string
+	for curTime := someTs; !curTime.After(someMaxTs); curTime = curTime.Add(untyped.GetPartitionDuration()) {
+		partitionId := untyped.GetPartitionId(curTime)
+		keys = append(keys, NewOwnerEdgeKey(partitionId, someOwnerUid, someKind, someNamespace, someUid).String())
+		keys = append(keys, NewOwnerEdgeKey(partitionId, someOwnerUid, someKind, someNamespace, someUid+"b").String())
+	}
+	return keys
+}
+
+func (*OwnerEdgeKey) SetTestValue() *OwnerEdge {
+	return &OwnerEdge{ChildName: someName}
+}
@@ -0,0 +1,97 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"fmt"
+	"github.com/dgraph-io/badger/v2"
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+// Key is /<partition>/<kind>/<namespace>/<name>/<uid>
+//
+// Kind is always Pod, it is kept so the key has the same layout as the watch activity table
+
+type PodLifecycleKey struct {
+	PartitionId string
+	Kind        string
+	Namespace   string
+	Name        string
+	Uid         string
+}
+
+func NewPodLifecycleKey(partitionId string, namespace string, name string, uid string) *PodLifecycleKey {
+	return &PodLifecycleKey{PartitionId: partitionId, Kind: "Pod", Namespace: namespace, Name: name, Uid: uid}
+}
+
+func (*PodLifecycleKey) TableName() string {
+	return "podlifecycle"
+}
+
+func (k *PodLifecycleKey) Parse(key string) error {
+	err, parts := common.ParseKey(key)
+	if err != nil {
+		return err
+	}
+
+	if parts[1] != k.TableName() {
+		return fmt.Errorf("Second part of key (%v) should be %v", key, k.TableName())
+	}
+	k.PartitionId = parts[2]
+	k.Kind = parts[3]
+	k.Namespace = parts[4]
+	k.Name = parts[5]
+	k.Uid = parts[6]
+	return nil
+}
+
+func (k *PodLifecycleKey) String() string {
+	return fmt.Sprintf("/%v/%v/%v/%v/%v/%v", k.TableName(), k.PartitionId, k.Kind, k.Namespace, k.Name, k.Uid)
+}
+
+func (*PodLifecycleKey) ValidateKey(key string) error {
+	newKey := PodLifecycleKey{}
+	return newKey.Parse(key)
+}
+
+func (k *PodLifecycleKey) SetPartitionId(newPartitionId string) {
+	k.PartitionId = newPartitionId
+}
+
+func (t *PodLifecycleTable) GetOrDefault(txn badgerwrap.Txn, key string) (*PodLifecycle, error) {
+	rec, err := t.Get(txn, key)
+	if err != nil {
+		if err != badger.ErrKeyNotFound {
+			return nil, err
+		} else {
+			return &PodLifecycle{}, nil
+		}
+	}
+	return rec, nil
+}
+
+// True when both transitions describe the same pod state, ignoring the timestamp
+func SamePodState(a *PodPhaseTransition, b *PodPhaseTransition) bool {
+	if a.Phase != b.Phase || a.Deleted != b.Deleted || a.NodeName != b.NodeName {
+		return false
+	}
+	return sameStringMap(a.WaitingReasons, b.WaitingReasons) && sameStringMap(a.EphemeralContainers, b.EphemeralContainers)
+}
+
+func sameStringMap(a map[string]string, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key, value := range a {
+		if other, ok := b[key]; !ok || other != value {
+			return false
+		}
+	}
+	return true
+}
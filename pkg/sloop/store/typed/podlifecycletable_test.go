@@ -0,0 +1,60 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_PodLifecycleKey_OutputCorrect(t *testing.T) {
+	k := NewPodLifecycleKey("001562961600", someNamespace, someName, someUid)
+	assert.Equal(t, "/podlifecycle/001562961600/Pod/somenamespace/somename/68510937-4ffc-11e9-8e26-1418775557c8", k.String())
+}
+
+func Test_PodLifecycleKey_ParseCorrect(t *testing.T) {
+	k := &PodLifecycleKey{}
+	err := k.Parse("/podlifecycle/001562961600/Pod/somenamespace/somename/68510937-4ffc-11e9-8e26-1418775557c8")
+	assert.Nil(t, err)
+	assert.Equal(t, "001562961600", k.PartitionId)
+	assert.Equal(t, "Pod", k.Kind)
+	assert.Equal(t, someNamespace, k.Namespace)
+	assert.Equal(t, someName, k.Name)
+	assert.Equal(t, someUid, k.Uid)
+}
+
+func Test_PodLifecycleKey_ValidateWorks(t *testing.T) {
+	assert.Nil(t, (&PodLifecycleKey{}).ValidateKey("/podlifecycle/001562961600/Pod/somenamespace/somename/68510937-4ffc-11e9-8e26-1418775557c8"))
+	assert.NotNil(t, (&PodLifecycleKey{}).ValidateKey("/watchactivity/001562961600/Pod/somenamespace/somename/68510937-4ffc-11e9-8e26-1418775557c8"))
+}
+
+func (*PodLifecycleKey) GetTestKey() string {
+	k := NewPodLifecycleKey(someMinPartition, someNamespace, someName, someUid)
+	return k.String()
+}
+
+func (*PodLifecycleKey) GetTestValue() *PodLifecycle {
+	return &PodLifecycle{}
+}
+
+func (*PodLifecycleKey) SetTestKeys() []string {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	var keys []string
+	for curTime := someTs; !curTime.After(someMaxTs); curTime = curTime.Add(untyped.GetPartitionDuration()) {
+		partitionId := untyped.GetPartitionId(curTime)
+		keys = append(keys, NewPodLifecycleKey(partitionId, someNamespace, someName, someUid).String())
+		keys = append(keys, NewPodLifecycleKey(partitionId, someNamespace, someName+"b", someUid).String())
+	}
+	return keys
+}
+
+func (*PodLifecycleKey) SetTestValue() *PodLifecycle {
+	return &PodLifecycle{Transitions: []*PodPhaseTransition{{Phase: "Running"}}}
+}
@@ -0,0 +1,306 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	badger "github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+/*
+A ProtoTable is a table for code outside this package, such as processing plugins, that want to store their own
+derived data without adding a generated table here.  Values can be any proto message and keys have the same layout
+as the core tables:
+
+	/<table name>/<partition id>/<part>/<part>/<part>/<part>
+
+Registered tables are returned by Tables.ExtraTable, included in GetTableNames and GetTables so partition GC and
+retention cover them, and can be browsed and viewed from the debug pages.  See ExampleRegisterTable.
+*/
+type ProtoTable struct {
+	tableName string
+	newValue  func() proto.Message
+}
+
+// newValue returns an empty message of the value type, which Get unmarshals into
+func NewProtoTable(tableName string, newValue func() proto.Message) *ProtoTable {
+	return &ProtoTable{tableName: tableName, newValue: newValue}
+}
+
+var (
+	registeredTablesLock = &sync.Mutex{}
+	registeredTables     = map[string]*ProtoTable{}
+)
+
+// Makes the table available from every Tables.  It should be called once at startup, before the store is opened
+func RegisterTable(table *ProtoTable) error {
+	if table.tableName == "" || strings.Contains(table.tableName, "/") {
+		return fmt.Errorf("invalid table name %q", table.tableName)
+	}
+	if common.Contains(NewTableList(nil).(*tablesImpl).coreTableNames(), table.tableName) {
+		return fmt.Errorf("table name %q is used by a core table", table.tableName)
+	}
+	registeredTablesLock.Lock()
+	defer registeredTablesLock.Unlock()
+	if _, ok := registeredTables[table.tableName]; ok {
+		return fmt.Errorf("table %q is already registered", table.tableName)
+	}
+	registeredTables[table.tableName] = table
+	RegisterTableName(table.tableName)
+	return nil
+}
+
+func getRegisteredTable(tableName string) *ProtoTable {
+	registeredTablesLock.Lock()
+	defer registeredTablesLock.Unlock()
+	return registeredTables[tableName]
+}
+
+// Tables registered with RegisterTable, sorted by table name
+func RegisteredTables() []*ProtoTable {
+	registeredTablesLock.Lock()
+	defer registeredTablesLock.Unlock()
+	tables := []*ProtoTable{}
+	for _, table := range registeredTables {
+		tables = append(tables, table)
+	}
+	sort.Slice(tables, func(i, j int) bool { return tables[i].tableName < tables[j].tableName })
+	return tables
+}
+
+func (t *ProtoTable) TableName() string {
+	return t.tableName
+}
+
+// Builds a key from exactly four parts.  Parts must not contain '/', but can be empty
+func (t *ProtoTable) Key(partitionId string, parts ...string) string {
+	return fmt.Sprintf("/%v/%v/%v", t.tableName, partitionId, strings.Join(parts, "/"))
+}
+
+// Returns the four parts after the partition id
+func (t *ProtoTable) ParseKey(key string) (string, []string, error) {
+	err, parts := common.ParseKey(key)
+	if err != nil {
+		return "", nil, err
+	}
+	if parts[1] != t.tableName {
+		return "", nil, fmt.Errorf("second part of key (%v) should be %v", key, t.tableName)
+	}
+	return parts[2], parts[3:], nil
+}
+
+func (t *ProtoTable) ValidateKey(key string) error {
+	_, _, err := t.ParseKey(key)
+	return err
+}
+
+func (t *ProtoTable) Set(txn badgerwrap.Txn, key string, value proto.Message) error {
+	err := t.ValidateKey(key)
+	if err != nil {
+		return errors.Wrapf(err, "invalid key for table %v: %v", t.tableName, key)
+	}
+
+	outb, err := proto.Marshal(value)
+	if err != nil {
+		return errors.Wrapf(err, "protobuf marshal for table %v failed", t.tableName)
+	}
+
+	outb, err = encodeValue(txn, t.tableName, key, outb)
+	if err != nil {
+		return errors.Wrapf(err, "value encode for table %v failed", t.tableName)
+	}
+
+	err = txn.Set([]byte(key), outb)
+	if err != nil {
+		return errors.Wrapf(err, "set for table %v failed", t.tableName)
+	}
+	return nil
+}
+
+// Returns badger.ErrKeyNotFound unwrapped when the key is not in the table
+func (t *ProtoTable) Get(txn badgerwrap.Txn, key string) (proto.Message, error) {
+	err := t.ValidateKey(key)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid key for table %v: %v", t.tableName, key)
+	}
+
+	item, err := txn.Get([]byte(key))
+	if err == badger.ErrKeyNotFound {
+		// Dont wrap. Need to preserve error type
+		return nil, err
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "get failed for table %v", t.tableName)
+	}
+
+	valueBytes, err := item.ValueCopy([]byte{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "value copy failed for table %v", t.tableName)
+	}
+
+	valueBytes, err = decodeValue(txn, key, valueBytes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "value decode failed for table %v", t.tableName)
+	}
+
+	retValue := t.newValue()
+	err = proto.Unmarshal(valueBytes, retValue)
+	if err != nil {
+		return nil, errors.Wrapf(err, "protobuf unmarshal failed for table %v on value length %v", t.tableName, len(valueBytes))
+	}
+	return retValue, nil
+}
+
+func (t *ProtoTable) GetMinMaxPartitions(txn badgerwrap.Txn) (bool, string, string) {
+	keyPrefix := "/" + t.tableName + "/"
+	iterOpt := badger.DefaultIteratorOptions
+	iterOpt.Prefix = []byte(keyPrefix)
+	iterator := txn.NewIterator(iterOpt)
+	iterator.Seek([]byte(keyPrefix))
+	if !iterator.ValidForPrefix([]byte(keyPrefix)) {
+		iterator.Close()
+		return false, "", ""
+	}
+	minKey := string(iterator.Item().Key())
+	iterator.Close()
+
+	iterOpt.Reverse = true
+	iterator = txn.NewIterator(iterOpt)
+	defer iterator.Close()
+	// We need to seek to the end of the range so we add a 255 character at the end
+	iterator.Seek([]byte(keyPrefix + string(rune(255))))
+	if !iterator.Valid() {
+		return false, "", ""
+	}
+	maxKey := string(iterator.Item().Key())
+
+	minPar, _, err := t.ParseKey(minKey)
+	if err != nil {
+		panic(fmt.Sprintf("invalid key in table: %v key: %q error: %v", t.tableName, minKey, err))
+	}
+	maxPar, _, err := t.ParseKey(maxKey)
+	if err != nil {
+		panic(fmt.Sprintf("invalid key in table: %v key: %q error: %v", t.tableName, maxKey, err))
+	}
+	return true, minPar, maxPar
+}
+
+func (t *ProtoTable) GetUniquePartitionList(txn badgerwrap.Txn) ([]string, error) {
+	resources := []string{}
+	ok, minPar, maxPar := t.GetMinMaxPartitions(txn)
+	if ok {
+		parDuration := untyped.GetPartitionDuration()
+		for curPar := minPar; curPar <= maxPar; {
+			resources = append(resources, curPar)
+			partInt, err := strconv.ParseInt(curPar, 10, 64)
+			if err != nil {
+				return resources, errors.Wrapf(err, "failed to get partition:%v", curPar)
+			}
+			parTime := time.Unix(partInt, 0).UTC().Add(parDuration)
+			curPar = untyped.GetPartitionId(parTime)
+		}
+	}
+	return resources, nil
+}
+
+func (t *ProtoTable) GetPartitionsFromTimeRange(txn badgerwrap.Txn, startTime time.Time, endTime time.Time) ([]string, error) {
+	resources := []string{}
+	startPartition := untyped.GetPartitionId(startTime)
+	endPartition := untyped.GetPartitionId(endTime)
+	parDuration := untyped.GetPartitionDuration()
+	for curPar := startPartition; curPar <= endPartition; {
+		resources = append(resources, curPar)
+		partInt, err := strconv.ParseInt(curPar, 10, 64)
+		if err != nil {
+			return resources, errors.Wrapf(err, "failed to get partition:%v", curPar)
+		}
+		parTime := time.Unix(partInt, 0).UTC().Add(parDuration)
+		curPar = untyped.GetPartitionId(parTime)
+	}
+	return resources, nil
+}
+
+// Reads every value in the partitions overlapping the time range whose key starts with <partition id>/<keyPrefix>.
+// An empty keyPrefix reads the whole partition.  keyPredicateFn can be nil
+func (t *ProtoTable) RangeRead(txn badgerwrap.Txn, keyPrefix string, keyPredicateFn func(string) bool, startTime time.Time, endTime time.Time) (map[string]proto.Message, RangeReadStats, error) {
+	resources := map[string]proto.Message{}
+	stats := RangeReadStats{TableName: t.tableName}
+	before := time.Now()
+
+	partitionList, err := t.GetPartitionsFromTimeRange(txn, startTime, endTime)
+	if err != nil {
+		return resources, stats, errors.Wrapf(err, "failed to get partitions for table %v", t.tableName)
+	}
+	stats.PartitionCount = len(partitionList)
+
+	for _, partitionId := range partitionList {
+		prefix := []byte("/" + t.tableName + "/" + partitionId + "/" + keyPrefix)
+		iterOpt := badger.DefaultIteratorOptions
+		iterOpt.Prefix = prefix
+		itr := txn.NewIterator(iterOpt)
+		for itr.Seek(prefix); itr.ValidForPrefix(prefix); itr.Next() {
+			stats.RowsVisitedCount++
+			key := string(itr.Item().Key())
+			if keyPredicateFn != nil && !keyPredicateFn(key) {
+				continue
+			}
+			stats.RowsPassedKeyPredicateCount++
+			value, err := t.Get(txn, key)
+			if err != nil {
+				itr.Close()
+				return resources, stats, err
+			}
+			stats.RowsPassedValuePredicateCount++
+			resources[key] = value
+		}
+		itr.Close()
+	}
+
+	stats.Elapsed = time.Since(before)
+	return resources, stats, nil
+}
+
+// Lists the keys of the last lookBack partitions that start with /<table name>/<partition id><keyPrefix>, for the debug
+// pages
+func (t *ProtoTable) GetAllKeysForGivenPartitions(db badgerwrap.DB, maxNumberOfKeys int, lookBack int, keyPrefix string) []string {
+	var keys []string
+	_ = db.View(func(txn badgerwrap.Txn) error {
+		partitionList, _ := t.GetUniquePartitionList(txn)
+		lookBackVal := lookBack
+		if len(partitionList) < lookBack {
+			lookBackVal = len(partitionList)
+		}
+		for i := len(partitionList) - 1; i >= len(partitionList)-lookBackVal; i-- {
+			prefix := []byte("/" + t.tableName + "/" + partitionList[i] + keyPrefix)
+			iterOpt := badger.DefaultIteratorOptions
+			iterOpt.Prefix = prefix
+			iterOpt.PrefetchValues = false
+			itr := txn.NewIterator(iterOpt)
+			for itr.Seek(prefix); itr.ValidForPrefix(prefix); itr.Next() {
+				keys = append(keys, string(itr.Item().Key()))
+			}
+			itr.Close()
+			if len(keys) >= maxNumberOfKeys {
+				return nil
+			}
+		}
+		return nil
+	})
+	return keys
+}
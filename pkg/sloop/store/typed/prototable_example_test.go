@@ -0,0 +1,104 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/wrappers"
+
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/processing"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+// Counts the watch results of each resource per partition.  Any proto message can be the value, including one
+// generated from your own .proto file
+type watchCountProcessor struct {
+	table *typed.ProtoTable
+}
+
+func (p *watchCountProcessor) Name() string {
+	return "watchcount"
+}
+
+func (p *watchCountProcessor) TableNames() []string {
+	return []string{p.table.TableName()}
+}
+
+func (p *watchCountProcessor) Process(tables typed.Tables, txn badgerwrap.Txn, watchRec *typed.KubeWatchResult, metadata *kubeextractor.KubeMetadata) error {
+	ts, err := ptypes.Timestamp(watchRec.Timestamp)
+	if err != nil {
+		return err
+	}
+	// Keys always have four parts after the partition id so partition GC and the debug pages can parse them
+	key := p.table.Key(untyped.GetPartitionId(ts), watchRec.Kind, metadata.Namespace, metadata.Name, metadata.Uid)
+
+	count := &wrappers.Int64Value{}
+	value, err := p.table.Get(txn, key)
+	if err == nil {
+		count = value.(*wrappers.Int64Value)
+	} else if err != badger.ErrKeyNotFound {
+		return err
+	}
+	count.Value++
+	return p.table.Set(txn, key, count)
+}
+
+func ExampleRegisterTable() {
+	// Register the table and the processor that writes it at startup, typically from an init() in a binary that wraps
+	// server.RealMain
+	table := typed.NewProtoTable("watchcount", func() proto.Message { return &wrappers.Int64Value{} })
+	err := typed.RegisterTable(table)
+	if err != nil {
+		panic(err)
+	}
+	err = processing.RegisterProcessor(&watchCountProcessor{table: table})
+	if err != nil {
+		panic(err)
+	}
+
+	db, _ := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	tables := typed.NewTableList(db)
+	fmt.Println(tables.ExtraTable("watchcount") == table)
+	fmt.Println(common.Contains(tables.GetTableNames(), "watchcount"))
+
+	// The processing runner calls Process for every watch result.  Here it is called directly
+	ts := time.Date(2019, 3, 4, 3, 4, 0, 0, time.UTC)
+	pts, _ := ptypes.TimestampProto(ts)
+	watchRec := &typed.KubeWatchResult{Kind: "Pod", Timestamp: pts}
+	metadata := &kubeextractor.KubeMetadata{Namespace: "default", Name: "web-1", Uid: "1234"}
+	processor := &watchCountProcessor{table: table}
+	for i := 0; i < 3; i++ {
+		_ = db.Update(func(txn badgerwrap.Txn) error {
+			return processor.Process(tables, txn, watchRec, metadata)
+		})
+	}
+
+	_ = db.View(func(txn badgerwrap.Txn) error {
+		values, _, err := table.RangeRead(txn, "Pod/default/", nil, ts, ts)
+		if err != nil {
+			return err
+		}
+		for key, value := range values {
+			fmt.Println(key, value.(*wrappers.Int64Value).Value)
+		}
+		return nil
+	})
+	// Output:
+	// true
+	// true
+	// /watchcount/001551668400/Pod/default/web-1/1234 3
+}
@@ -0,0 +1,95 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+func helper_newProtoTable(tableName string) *ProtoTable {
+	return NewProtoTable(tableName, func() proto.Message { return &wrappers.StringValue{} })
+}
+
+func Test_ProtoTable_KeyRoundTrip(t *testing.T) {
+	table := helper_newProtoTable("prototest")
+	key := table.Key("001562961600", "Pod", someNamespace, "", "abc")
+	assert.Equal(t, "/prototest/001562961600/Pod/somenamespace//abc", key)
+	partitionId, parts, err := table.ParseKey(key)
+	assert.Nil(t, err)
+	assert.Equal(t, "001562961600", partitionId)
+	assert.Equal(t, []string{"Pod", someNamespace, "", "abc"}, parts)
+
+	assert.NotNil(t, table.ValidateKey("/othertable/001562961600/Pod/somenamespace//abc"))
+	assert.NotNil(t, table.ValidateKey(table.Key("001562961600", "Pod")))
+}
+
+func Test_ProtoTable_SetGetAndPartitions(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	table := helper_newProtoTable("prototest")
+	ts := time.Date(2019, 3, 4, 3, 4, 0, 0, time.UTC)
+	part1 := untyped.GetPartitionId(ts)
+	part2 := untyped.GetPartitionId(ts.Add(2 * time.Hour))
+
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		assert.Nil(t, table.Set(txn, table.Key(part1, "a", "b", "c", "d"), &wrappers.StringValue{Value: "one"}))
+		assert.Nil(t, table.Set(txn, table.Key(part2, "a", "b", "c", "d"), &wrappers.StringValue{Value: "two"}))
+		return table.Set(txn, table.Key(part2, "x", "b", "c", "d"), &wrappers.StringValue{Value: "three"})
+	})
+	assert.Nil(t, err)
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		return table.Set(txn, "/prototest/bad", &wrappers.StringValue{})
+	})
+	assert.NotNil(t, err)
+
+	err = db.View(func(txn badgerwrap.Txn) error {
+		value, err := table.Get(txn, table.Key(part2, "a", "b", "c", "d"))
+		assert.Nil(t, err)
+		assert.Equal(t, "two", value.(*wrappers.StringValue).Value)
+		_, err = table.Get(txn, table.Key(part2, "z", "b", "c", "d"))
+		assert.Equal(t, badger.ErrKeyNotFound, err)
+
+		ok, minPar, maxPar := table.GetMinMaxPartitions(txn)
+		assert.True(t, ok)
+		assert.Equal(t, part1, minPar)
+		assert.Equal(t, part2, maxPar)
+
+		values, stats, err := table.RangeRead(txn, "a/", nil, ts, ts.Add(2*time.Hour))
+		assert.Nil(t, err)
+		assert.Len(t, values, 2)
+		assert.Equal(t, 3, stats.PartitionCount)
+		return nil
+	})
+	assert.Nil(t, err)
+
+	keys := table.GetAllKeysForGivenPartitions(db, 100, 1, "/x/")
+	assert.Equal(t, []string{table.Key(part2, "x", "b", "c", "d")}, keys)
+}
+
+func Test_RegisterTable_WiredIntoTables(t *testing.T) {
+	table := helper_newProtoTable("registertest")
+	assert.Nil(t, RegisterTable(table))
+	assert.NotNil(t, RegisterTable(helper_newProtoTable("registertest")))
+	assert.NotNil(t, RegisterTable(helper_newProtoTable("watch")))
+	assert.NotNil(t, RegisterTable(helper_newProtoTable("bad/name")))
+
+	tables := NewTableList(nil)
+	assert.Equal(t, table, tables.ExtraTable("registertest"))
+	assert.Nil(t, tables.ExtraTable("missing"))
+	assert.Contains(t, tables.GetTableNames(), "registertest")
+	assert.Contains(t, tables.GetTables(), table)
+}
@@ -0,0 +1,65 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const purgedTableName = "purged"
+
+// Registered so partition GC drops soft deleted keys along with the rest of their partition
+func init() {
+	RegisterTableName(purgedTableName)
+}
+
+/*
+Keys of a namespace that a purge soft deleted, with the stored value of the original key as is:
+
+	/purged/<partition>/<namespace>/<expires>/<original key>
+
+Expires is unix seconds.  Kept in the partition of the original key so they never outlive it
+*/
+type PurgedKey struct {
+	PartitionId string
+	Namespace   string
+	Expires     time.Time
+	// Starts with /
+	OriginalKey string
+}
+
+func NewPurgedKey(partitionId string, namespace string, expires time.Time, originalKey string) *PurgedKey {
+	return &PurgedKey{PartitionId: partitionId, Namespace: namespace, Expires: expires, OriginalKey: originalKey}
+}
+
+func (*PurgedKey) TableName() string {
+	return purgedTableName
+}
+
+func (k *PurgedKey) String() string {
+	return fmt.Sprintf("/%v/%v/%v/%v%v", k.TableName(), k.PartitionId, k.Namespace, k.Expires.Unix(), k.OriginalKey)
+}
+
+func (k *PurgedKey) Parse(key string) error {
+	parts := strings.SplitN(key, "/", 6)
+	if len(parts) != 6 || parts[0] != "" || parts[1] != k.TableName() {
+		return fmt.Errorf("key %v is not a %v key", key, k.TableName())
+	}
+	expires, err := strconv.ParseInt(parts[4], 10, 64)
+	if err != nil {
+		return fmt.Errorf("failed to parse expiry of key %v: %v", key, err)
+	}
+	k.PartitionId = parts[2]
+	k.Namespace = parts[3]
+	k.Expires = time.Unix(expires, 0).UTC()
+	k.OriginalKey = "/" + parts[5]
+	return nil
+}
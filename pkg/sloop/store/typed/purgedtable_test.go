@@ -0,0 +1,27 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PurgedKey_OutputCorrect(t *testing.T) {
+	expires := time.Unix(1554000000, 0).UTC()
+	key := NewPurgedKey("001546405200", "ns", expires, "/watch/001546405200/Pod/ns/p/1546405200000000000")
+	assert.Equal(t, "/purged/001546405200/ns/1554000000/watch/001546405200/Pod/ns/p/1546405200000000000", key.String())
+
+	parsed := &PurgedKey{}
+	assert.Nil(t, parsed.Parse(key.String()))
+	assert.Equal(t, key, parsed)
+	assert.NotNil(t, parsed.Parse("/watch/001546405200/Pod/ns/p/1546405200000000000"))
+	assert.NotNil(t, parsed.Parse("/purged/001546405200/ns/soon/watch/x"))
+}
@@ -0,0 +1,60 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_QuarantineKey_OutputCorrect(t *testing.T) {
+	k := NewQuarantineKey("001562961600", someKind, someNamespace, someName, someTs)
+	assert.Equal(t, "/quarantine/001562961600/somekind/somenamespace/somename/1546398245000000006", k.String())
+}
+
+func Test_QuarantineKey_ParseCorrect(t *testing.T) {
+	k := &QuarantineKey{}
+	err := k.Parse("/quarantine/001562961600/somekind///1546398245000000006")
+	assert.Nil(t, err)
+	assert.Equal(t, "001562961600", k.PartitionId)
+	assert.Equal(t, someKind, k.Kind)
+	assert.Equal(t, "", k.Namespace)
+	assert.Equal(t, "", k.Name)
+	assert.Equal(t, someTs, k.Timestamp)
+}
+
+func Test_QuarantineKey_ValidateWorks(t *testing.T) {
+	assert.Nil(t, (&QuarantineKey{}).ValidateKey("/quarantine/001562961600/somekind/somenamespace/somename/1546398245000000006"))
+	assert.NotNil(t, (&QuarantineKey{}).ValidateKey("/watch/001562961600/somekind/somenamespace/somename/1546398245000000006"))
+}
+
+func (*QuarantineKey) GetTestKey() string {
+	k := NewQuarantineKey(someMinPartition, someKind, someNamespace, someName, someTs)
+	return k.String()
+}
+
+func (*QuarantineKey) GetTestValue() *QuarantinedPayload {
+	return &QuarantinedPayload{}
+}
+
+func (*QuarantineKey) SetTestKeys() []string {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	var keys []string
+	for curTime := someTs; !curTime.After(someMaxTs); curTime = curTime.Add(untyped.GetPartitionDuration()) {
+		partitionId := untyped.GetPartitionId(curTime)
+		keys = append(keys, NewQuarantineKey(partitionId, someKind, someNamespace, someName, someTs.Add(time.Hour*-5)).String())
+		keys = append(keys, NewQuarantineKey(partitionId, someKind, someNamespace, someName, someTs).String())
+	}
+	return keys
+}
+
+func (*QuarantineKey) SetTestValue() *QuarantinedPayload {
+	return &QuarantinedPayload{Kind: someKind}
+}
@@ -0,0 +1,100 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"fmt"
+	"sort"
+
+	badger "github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+/*
+The query audit log is a ring with a fixed number of slots:
+
+	/queryaudit/<slot>
+
+Unlike the other tables it is not partitioned, so partition GC and the time range of queries do not see it, and it
+keeps its size by overwriting the oldest slot.  Values are not run through the payload codecs
+*/
+type QueryAuditTable struct {
+	tableName string
+}
+
+func OpenQueryAuditTable() *QueryAuditTable {
+	return &QueryAuditTable{tableName: "queryaudit"}
+}
+
+func (t *QueryAuditTable) TableName() string {
+	return t.tableName
+}
+
+func (t *QueryAuditTable) Key(slot uint64) string {
+	return fmt.Sprintf("/%v/%010d", t.tableName, slot)
+}
+
+func (t *QueryAuditTable) Set(txn badgerwrap.Txn, slot uint64, value *QueryAuditRecord) error {
+	outb, err := proto.Marshal(value)
+	if err != nil {
+		return errors.Wrapf(err, "protobuf marshal for table %v failed", t.tableName)
+	}
+	err = txn.Set([]byte(t.Key(slot)), outb)
+	if err != nil {
+		return errors.Wrapf(err, "set for table %v failed", t.tableName)
+	}
+	return nil
+}
+
+// Returns every record, newest first
+func (t *QueryAuditTable) ReadAll(txn badgerwrap.Txn) ([]*QueryAuditRecord, error) {
+	records := []*QueryAuditRecord{}
+	prefix := []byte("/" + t.tableName + "/")
+	iterOpt := badger.DefaultIteratorOptions
+	iterOpt.Prefix = prefix
+	itr := txn.NewIterator(iterOpt)
+	defer itr.Close()
+	for itr.Seek(prefix); itr.ValidForPrefix(prefix); itr.Next() {
+		valueBytes, err := itr.Item().ValueCopy([]byte{})
+		if err != nil {
+			return nil, errors.Wrapf(err, "value copy failed for table %v", t.tableName)
+		}
+		record := &QueryAuditRecord{}
+		err = proto.Unmarshal(valueBytes, record)
+		if err != nil {
+			return nil, errors.Wrapf(err, "protobuf unmarshal failed for table %v on value length %v", t.tableName, len(valueBytes))
+		}
+		records = append(records, record)
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].Seq > records[j].Seq })
+	return records, nil
+}
+
+// Removes the slots from size on, left behind when the log was made smaller
+func (t *QueryAuditTable) Trim(txn badgerwrap.Txn, size uint64) error {
+	prefix := []byte("/" + t.tableName + "/")
+	iterOpt := badger.DefaultIteratorOptions
+	iterOpt.Prefix = prefix
+	iterOpt.PrefetchValues = false
+	itr := txn.NewIterator(iterOpt)
+	var keys [][]byte
+	for itr.Seek([]byte(t.Key(size))); itr.ValidForPrefix(prefix); itr.Next() {
+		keys = append(keys, itr.Item().KeyCopy(nil))
+	}
+	itr.Close()
+	for _, key := range keys {
+		err := txn.Delete(key)
+		if err != nil {
+			return errors.Wrapf(err, "delete for table %v failed", t.tableName)
+		}
+	}
+	return nil
+}
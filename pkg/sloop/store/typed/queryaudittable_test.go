@@ -0,0 +1,54 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+func Test_QueryAuditTable_SetAndReadAll(t *testing.T) {
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	table := OpenQueryAuditTable()
+	assert.Equal(t, "/queryaudit/0000000002", table.Key(2))
+
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		assert.Nil(t, table.Set(txn, 0, &QueryAuditRecord{Seq: 2, User: "c"}))
+		assert.Nil(t, table.Set(txn, 1, &QueryAuditRecord{Seq: 1, User: "b"}))
+		return nil
+	})
+	assert.Nil(t, err)
+
+	err = db.View(func(txn badgerwrap.Txn) error {
+		records, err := table.ReadAll(txn)
+		assert.Nil(t, err)
+		assert.Len(t, records, 2)
+		assert.Equal(t, "c", records[0].User)
+		assert.Equal(t, "b", records[1].User)
+		return nil
+	})
+	assert.Nil(t, err)
+
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		return table.Trim(txn, 1)
+	})
+	assert.Nil(t, err)
+	err = db.View(func(txn badgerwrap.Txn) error {
+		records, err := table.ReadAll(txn)
+		assert.Nil(t, err)
+		assert.Len(t, records, 1)
+		assert.Equal(t, "c", records[0].User)
+		return nil
+	})
+	assert.Nil(t, err)
+}
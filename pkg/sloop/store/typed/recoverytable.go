@@ -0,0 +1,78 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"fmt"
+	"time"
+
+	badger "github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+/*
+Reports of the store recoveries done at startup:
+
+	/recovery/<timestamp>
+
+Like the query audit log it is not partitioned, so the reports are kept after the partitions they describe are gone.
+There is one per recovery, so it stays small.  Values are not run through the payload codecs
+*/
+type RecoveryTable struct {
+	tableName string
+}
+
+func OpenRecoveryTable() *RecoveryTable {
+	return &RecoveryTable{tableName: "recovery"}
+}
+
+func (t *RecoveryTable) TableName() string {
+	return t.tableName
+}
+
+func (t *RecoveryTable) Key(timestamp time.Time) string {
+	return fmt.Sprintf("/%v/%020d", t.tableName, timestamp.UnixNano())
+}
+
+func (t *RecoveryTable) Set(txn badgerwrap.Txn, timestamp time.Time, value *RecoveryReport) error {
+	outb, err := proto.Marshal(value)
+	if err != nil {
+		return errors.Wrapf(err, "protobuf marshal for table %v failed", t.tableName)
+	}
+	err = txn.Set([]byte(t.Key(timestamp)), outb)
+	if err != nil {
+		return errors.Wrapf(err, "set for table %v failed", t.tableName)
+	}
+	return nil
+}
+
+// Returns every report, newest first
+func (t *RecoveryTable) ReadAll(txn badgerwrap.Txn) ([]*RecoveryReport, error) {
+	reports := []*RecoveryReport{}
+	prefix := []byte("/" + t.tableName + "/")
+	iterOpt := badger.DefaultIteratorOptions
+	iterOpt.Prefix = prefix
+	itr := txn.NewIterator(iterOpt)
+	defer itr.Close()
+	for itr.Seek(prefix); itr.ValidForPrefix(prefix); itr.Next() {
+		valueBytes, err := itr.Item().ValueCopy([]byte{})
+		if err != nil {
+			return nil, errors.Wrapf(err, "value copy failed for table %v", t.tableName)
+		}
+		report := &RecoveryReport{}
+		err = proto.Unmarshal(valueBytes, report)
+		if err != nil {
+			return nil, errors.Wrapf(err, "protobuf unmarshal failed for table %v on value length %v", t.tableName, len(valueBytes))
+		}
+		reports = append([]*RecoveryReport{report}, reports...)
+	}
+	return reports, nil
+}
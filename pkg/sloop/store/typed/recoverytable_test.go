@@ -0,0 +1,42 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+func Test_RecoveryTable_SetAndReadAll(t *testing.T) {
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	table := OpenRecoveryTable()
+	assert.Equal(t, "/recovery/00000000001000000000", table.Key(time.Unix(1, 0)))
+
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		assert.Nil(t, table.Set(txn, time.Unix(100, 0), &RecoveryReport{KeysLost: 1}))
+		assert.Nil(t, table.Set(txn, time.Unix(200, 0), &RecoveryReport{KeysLost: 2}))
+		return nil
+	})
+	assert.Nil(t, err)
+
+	err = db.View(func(txn badgerwrap.Txn) error {
+		reports, err := table.ReadAll(txn)
+		assert.Nil(t, err)
+		assert.Len(t, reports, 2)
+		assert.Equal(t, int64(2), reports[0].KeysLost)
+		assert.Equal(t, int64(1), reports[1].KeysLost)
+		return nil
+	})
+	assert.Nil(t, err)
+}
@@ -45,6 +45,11 @@ func (t *ResourceSummaryTable) Set(txn badgerwrap.Txn, key string, value *Resour
 		return errors.Wrapf(err, "protobuf marshal for table %v failed", t.tableName)
 	}
 
+	outb, err = encodeValue(txn, t.tableName, key, outb)
+	if err != nil {
+		return errors.Wrapf(err, "value encode for table %v failed", t.tableName)
+	}
+
 	err = txn.Set([]byte(key), outb)
 	if err != nil {
 		return errors.Wrapf(err, "set for table %v failed", t.tableName)
@@ -71,6 +76,11 @@ func (t *ResourceSummaryTable) Get(txn badgerwrap.Txn, key string) (*ResourceSum
 		return nil, errors.Wrapf(err, "value copy failed for table %v", t.tableName)
 	}
 
+	valueBytes, err = decodeValue(txn, key, valueBytes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "value decode failed for table %v", t.tableName)
+	}
+
 	retValue := &ResourceSummary{}
 	err = proto.Unmarshal(valueBytes, retValue)
 	if err != nil {
@@ -272,6 +282,10 @@ func (t *ResourceSummaryTable) RangeRead(txn badgerwrap.Txn, keyPrefix *Resource
 			if err != nil {
 				return nil, stats, err
 			}
+			valueBytes, err = decodeValue(txn, string(itr.Item().Key()), valueBytes)
+			if err != nil {
+				return nil, stats, err
+			}
 			retValue := &ResourceSummary{}
 			err = proto.Unmarshal(valueBytes, retValue)
 			if err != nil {
@@ -293,6 +307,91 @@ func (t *ResourceSummaryTable) RangeRead(txn badgerwrap.Txn, keyPrefix *Resource
 	return resources, stats, nil
 }
 
+// One row of RangeReadReverse
+type ResourceSummaryRow struct {
+	Key   ResourceSummaryKey
+	Value *ResourceSummary
+}
+
+// Same as RangeRead but walks partitions newest first and keys within each partition in descending order, and
+// returns the rows in that order.  Reading stops as soon as maxRows rows have passed both predicates, so asking for
+// the latest few versions of a resource does not scan the whole time range.  maxRows <= 0 means no limit.
+func (t *ResourceSummaryTable) RangeReadReverse(txn badgerwrap.Txn, keyPrefix *ResourceSummaryKey,
+	keyPredicateFn func(string) bool, valPredicateFn func(*ResourceSummary) bool, startTime time.Time, endTime time.Time, maxRows int) ([]ResourceSummaryRow, RangeReadStats, error) {
+	var rows []ResourceSummaryRow
+
+	stats := RangeReadStats{}
+	before := time.Now()
+
+	partitionList, err := t.GetPartitionsFromTimeRange(txn, startTime, endTime)
+	stats.PartitionCount = len(partitionList)
+	if err != nil {
+		return rows, stats, errors.Wrapf(err, "failed to get partitions from table:%v, from startTime:%v, to endTime:%v", t.tableName, startTime, endTime)
+	}
+
+	for i := len(partitionList) - 1; i >= 0; i-- {
+		currentPartition := partitionList[i]
+		var seekStr string
+		if keyPrefix == nil {
+			seekStr = "/" + t.tableName + "/" + currentPartition + "/"
+		} else {
+			keyPrefix.SetPartitionId(currentPartition)
+			seekStr = keyPrefix.String()
+		}
+
+		itr := txn.NewIterator(badger.IteratorOptions{Prefix: []byte(seekStr), Reverse: true})
+		defer itr.Close()
+
+		// In reverse a seek lands on the largest key <= the seek key, so seek past the end of the prefix
+		for itr.Seek([]byte(seekStr + string(rune(255)))); itr.ValidForPrefix([]byte(seekStr)); itr.Next() {
+			stats.RowsVisitedCount += 1
+			if keyPredicateFn != nil {
+				if !keyPredicateFn(string(itr.Item().Key())) {
+					continue
+				}
+			}
+			key := ResourceSummaryKey{}
+			err := key.Parse(string(itr.Item().Key()))
+			if err != nil {
+				return nil, stats, err
+			}
+
+			stats.RowsPassedKeyPredicateCount += 1
+
+			valueBytes, err := itr.Item().ValueCopy([]byte{})
+			if err != nil {
+				return nil, stats, err
+			}
+			valueBytes, err = decodeValue(txn, string(itr.Item().Key()), valueBytes)
+			if err != nil {
+				return nil, stats, err
+			}
+			retValue := &ResourceSummary{}
+			err = proto.Unmarshal(valueBytes, retValue)
+			if err != nil {
+				return nil, stats, err
+			}
+			if valPredicateFn != nil && !valPredicateFn(retValue) {
+				continue
+			}
+			stats.RowsPassedValuePredicateCount += 1
+			rows = append(rows, ResourceSummaryRow{Key: key, Value: retValue})
+			if maxRows > 0 && len(rows) >= maxRows {
+				itr.Close()
+				stats.Elapsed = time.Since(before)
+				stats.TableName = (&ResourceSummaryKey{}).TableName()
+				return rows, stats, nil
+			}
+		}
+
+		itr.Close()
+	}
+
+	stats.Elapsed = time.Since(before)
+	stats.TableName = (&ResourceSummaryKey{}).TableName()
+	return rows, stats, nil
+}
+
 //todo: need to add unit test
 func (t *ResourceSummaryTable) GetPartitionsFromTimeRange(txn badgerwrap.Txn, startTime time.Time, endTime time.Time) ([]string, error) {
 	resources := []string{}
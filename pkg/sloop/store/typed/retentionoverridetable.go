@@ -0,0 +1,106 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"fmt"
+
+	badger "github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+/*
+Namespaces, or kinds of a namespace, whose data GC keeps past retention until the override expires:
+
+	/retentionoverride/<namespace>/<kind>
+
+The kind is empty for every kind of the namespace.  Like the freezes it is not partitioned, and values are not run
+through the payload codecs
+*/
+type RetentionOverrideTable struct {
+	tableName string
+}
+
+func OpenRetentionOverrideTable() *RetentionOverrideTable {
+	return &RetentionOverrideTable{tableName: "retentionoverride"}
+}
+
+func (t *RetentionOverrideTable) TableName() string {
+	return t.tableName
+}
+
+func (t *RetentionOverrideTable) Key(namespace string, kind string) string {
+	return fmt.Sprintf("/%v/%v/%v", t.tableName, namespace, kind)
+}
+
+func (t *RetentionOverrideTable) Set(txn badgerwrap.Txn, value *RetentionOverride) error {
+	outb, err := proto.Marshal(value)
+	if err != nil {
+		return errors.Wrapf(err, "protobuf marshal for table %v failed", t.tableName)
+	}
+	err = txn.Set([]byte(t.Key(value.Namespace, value.Kind)), outb)
+	if err != nil {
+		return errors.Wrapf(err, "set for table %v failed", t.tableName)
+	}
+	return nil
+}
+
+// Returns nil when there is no override of the namespace and kind
+func (t *RetentionOverrideTable) Get(txn badgerwrap.Txn, namespace string, kind string) (*RetentionOverride, error) {
+	item, err := txn.Get([]byte(t.Key(namespace, kind)))
+	if err == badger.ErrKeyNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "get for table %v failed", t.tableName)
+	}
+	valueBytes, err := item.ValueCopy([]byte{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "value copy failed for table %v", t.tableName)
+	}
+	override := &RetentionOverride{}
+	err = proto.Unmarshal(valueBytes, override)
+	if err != nil {
+		return nil, errors.Wrapf(err, "protobuf unmarshal failed for table %v on value length %v", t.tableName, len(valueBytes))
+	}
+	return override, nil
+}
+
+func (t *RetentionOverrideTable) Delete(txn badgerwrap.Txn, namespace string, kind string) error {
+	err := txn.Delete([]byte(t.Key(namespace, kind)))
+	if err != nil {
+		return errors.Wrapf(err, "delete for table %v failed", t.tableName)
+	}
+	return nil
+}
+
+// Returns every override, ordered by namespace and kind
+func (t *RetentionOverrideTable) ReadAll(txn badgerwrap.Txn) ([]*RetentionOverride, error) {
+	overrides := []*RetentionOverride{}
+	prefix := []byte("/" + t.tableName + "/")
+	iterOpt := badger.DefaultIteratorOptions
+	iterOpt.Prefix = prefix
+	itr := txn.NewIterator(iterOpt)
+	defer itr.Close()
+	for itr.Seek(prefix); itr.ValidForPrefix(prefix); itr.Next() {
+		valueBytes, err := itr.Item().ValueCopy([]byte{})
+		if err != nil {
+			return nil, errors.Wrapf(err, "value copy failed for table %v", t.tableName)
+		}
+		override := &RetentionOverride{}
+		err = proto.Unmarshal(valueBytes, override)
+		if err != nil {
+			return nil, errors.Wrapf(err, "protobuf unmarshal failed for table %v on value length %v", t.tableName, len(valueBytes))
+		}
+		overrides = append(overrides, override)
+	}
+	return overrides, nil
+}
@@ -0,0 +1,48 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+func Test_RetentionOverrideTable_SetGetDeleteAndReadAll(t *testing.T) {
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	table := OpenRetentionOverrideTable()
+	assert.Equal(t, "/retentionoverride/payments/", table.Key("payments", ""))
+
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		assert.Nil(t, table.Set(txn, &RetentionOverride{Namespace: "payments", Reason: "inc-42"}))
+		assert.Nil(t, table.Set(txn, &RetentionOverride{Namespace: "payments", Kind: "Pod"}))
+		return nil
+	})
+	assert.Nil(t, err)
+
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		override, err := table.Get(txn, "payments", "")
+		assert.Nil(t, err)
+		assert.Equal(t, "inc-42", override.Reason)
+		missing, err := table.Get(txn, "other", "")
+		assert.Nil(t, err)
+		assert.Nil(t, missing)
+
+		assert.Nil(t, table.Delete(txn, "payments", ""))
+		overrides, err := table.ReadAll(txn)
+		assert.Nil(t, err)
+		assert.Len(t, overrides, 1)
+		assert.Equal(t, "Pod", overrides[0].Kind)
+		return nil
+	})
+	assert.Nil(t, err)
+}
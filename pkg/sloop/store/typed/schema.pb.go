@@ -50,13 +50,46 @@ func (KubeWatchResult_WatchType) EnumDescriptor() ([]byte, []int) {
 }
 
 type KubeWatchResult struct {
-	Timestamp            *timestamp.Timestamp      `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	Kind                 string                    `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
-	WatchType            KubeWatchResult_WatchType `protobuf:"varint,3,opt,name=watchType,proto3,enum=typed.KubeWatchResult_WatchType" json:"watchType,omitempty"`
-	Payload              string                    `protobuf:"bytes,4,opt,name=payload,proto3" json:"payload,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
-	XXX_unrecognized     []byte                    `json:"-"`
-	XXX_sizecache        int32                     `json:"-"`
+	Timestamp *timestamp.Timestamp      `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Kind      string                    `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	WatchType KubeWatchResult_WatchType `protobuf:"varint,3,opt,name=watchType,proto3,enum=typed.KubeWatchResult_WatchType" json:"watchType,omitempty"`
+	Payload   string                    `protobuf:"bytes,4,opt,name=payload,proto3" json:"payload,omitempty"`
+	// JSON paths that differ from the previous payload of the same resource in this partition, set at ingest.  Only the
+	// first few are kept, changedPathCount has the total
+	ChangedPaths     []string `protobuf:"bytes,5,rep,name=changedPaths,proto3" json:"changedPaths,omitempty"`
+	ChangedPathCount int32    `protobuf:"varint,6,opt,name=changedPathCount,proto3" json:"changedPathCount,omitempty"`
+	// HMAC-SHA256 of the watch table key and the fields above apart from the changed paths, set at ingest when a
+	// signing key is configured
+	Signature []byte `protobuf:"bytes,7,opt,name=signature,proto3" json:"signature,omitempty"`
+	// Ingest time minus the event time of the payload in milliseconds, set at ingest only when the result looks affected
+	// by clock skew between sloop and the cluster.  Results with it set may be placed at the wrong time on the timeline
+	ClockSkewMillis int64 `protobuf:"varint,8,opt,name=clockSkewMillis,proto3" json:"clockSkewMillis,omitempty"`
+	// For events, the message with variable parts like pod hashes and IPs replaced by placeholders and the reason in
+	// CamelCase, set at ingest
+	MessageTemplate  string `protobuf:"bytes,9,opt,name=messageTemplate,proto3" json:"messageTemplate,omitempty"`
+	NormalizedReason string `protobuf:"bytes,10,opt,name=normalizedReason,proto3" json:"normalizedReason,omitempty"`
+	// For events, the uid of the incarnation of the involved object they are about.  From the event when it has one,
+	// otherwise the uid of the resource with that name when the event was seen, set at ingest
+	InvolvedUid string `protobuf:"bytes,11,opt,name=involvedUid,proto3" json:"involvedUid,omitempty"`
+	// How this version compares to the previous stored version of the same resource: "first" when there is none with
+	// the same uid, "resync" when only a relist or resync sent it again unchanged, "changed" otherwise.  Set at ingest,
+	// empty for results stored before it was added
+	VersionType string `protobuf:"bytes,12,opt,name=versionType,proto3" json:"versionType,omitempty"`
+	// For updates, the informer's copy of the resource before the update.  Kept at ingest only when that version was
+	// never stored, so the states sloop missed between two stored versions are not lost
+	OldPayload string `protobuf:"bytes,13,opt,name=oldPayload,proto3" json:"oldPayload,omitempty"`
+	// For deletes, where the payload, the final state of the resource before it was deleted, came from: "watch" when
+	// the delete event carried it, "cache" when the watch missed the delete and the informer only had its last cached
+	// copy.  Empty for other watch types and for results stored before it was added
+	FinalState string `protobuf:"bytes,14,opt,name=finalState,proto3" json:"finalState,omitempty"`
+	// Group and version the informer watched the resource with, like apps/v1.  From the payload when the watcher did
+	// not set it
+	ApiVersion string `protobuf:"bytes,15,opt,name=apiVersion,proto3" json:"apiVersion,omitempty"`
+	// The api version of the previous stored version of the same resource, set at ingest only when it was another one
+	ApiVersionMigratedFrom string   `protobuf:"bytes,16,opt,name=apiVersionMigratedFrom,proto3" json:"apiVersionMigratedFrom,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{} `json:"-"`
+	XXX_unrecognized       []byte   `json:"-"`
+	XXX_sizecache          int32    `json:"-"`
 }
 
 func (m *KubeWatchResult) Reset()         { *m = KubeWatchResult{} }
@@ -112,6 +145,90 @@ func (m *KubeWatchResult) GetPayload() string {
 	return ""
 }
 
+func (m *KubeWatchResult) GetChangedPaths() []string {
+	if m != nil {
+		return m.ChangedPaths
+	}
+	return nil
+}
+
+func (m *KubeWatchResult) GetChangedPathCount() int32 {
+	if m != nil {
+		return m.ChangedPathCount
+	}
+	return 0
+}
+
+func (m *KubeWatchResult) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func (m *KubeWatchResult) GetClockSkewMillis() int64 {
+	if m != nil {
+		return m.ClockSkewMillis
+	}
+	return 0
+}
+
+func (m *KubeWatchResult) GetMessageTemplate() string {
+	if m != nil {
+		return m.MessageTemplate
+	}
+	return ""
+}
+
+func (m *KubeWatchResult) GetNormalizedReason() string {
+	if m != nil {
+		return m.NormalizedReason
+	}
+	return ""
+}
+
+func (m *KubeWatchResult) GetInvolvedUid() string {
+	if m != nil {
+		return m.InvolvedUid
+	}
+	return ""
+}
+
+func (m *KubeWatchResult) GetVersionType() string {
+	if m != nil {
+		return m.VersionType
+	}
+	return ""
+}
+
+func (m *KubeWatchResult) GetOldPayload() string {
+	if m != nil {
+		return m.OldPayload
+	}
+	return ""
+}
+
+func (m *KubeWatchResult) GetFinalState() string {
+	if m != nil {
+		return m.FinalState
+	}
+	return ""
+}
+
+func (m *KubeWatchResult) GetApiVersion() string {
+	if m != nil {
+		return m.ApiVersion
+	}
+	return ""
+}
+
+func (m *KubeWatchResult) GetApiVersionMigratedFrom() string {
+	if m != nil {
+		return m.ApiVersionMigratedFrom
+	}
+	return ""
+}
+
 // Enough information to draw a timeline and hierarchy
 // Key: /<kind>/<namespace>/<name>/<uid>
 type ResourceSummary struct {
@@ -274,7 +391,9 @@ type WatchActivity struct {
 	// List of timestamps where `watch` event did not contain changes from previous event
 	NoChangeAt []int64 `protobuf:"varint,1,rep,packed,name=NoChangeAt,proto3" json:"NoChangeAt,omitempty"`
 	// List of timestamps where 'watch' event contained a change from previous event
-	ChangedAt            []int64  `protobuf:"varint,2,rep,packed,name=ChangedAt,proto3" json:"ChangedAt,omitempty"`
+	ChangedAt []int64 `protobuf:"varint,2,rep,packed,name=ChangedAt,proto3" json:"ChangedAt,omitempty"`
+	// List of timestamps of updates whose old object was never stored, so at least one version before them is missing
+	MissedAt             []int64  `protobuf:"varint,3,rep,packed,name=MissedAt,proto3" json:"MissedAt,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -319,6 +438,1852 @@ func (m *WatchActivity) GetChangedAt() []int64 {
 	return nil
 }
 
+func (m *WatchActivity) GetMissedAt() []int64 {
+	if m != nil {
+		return m.MissedAt
+	}
+	return nil
+}
+
+// A watch result whose payload failed validation at ingest.  The payload is kept as the original bytes
+// Key: /<partition>/<kind>/<namespace>/<name>/<timestamp>
+type QuarantinedPayload struct {
+	Timestamp            *timestamp.Timestamp      `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Kind                 string                    `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	WatchType            KubeWatchResult_WatchType `protobuf:"varint,3,opt,name=watchType,proto3,enum=typed.KubeWatchResult_WatchType" json:"watchType,omitempty"`
+	Payload              []byte                    `protobuf:"bytes,4,opt,name=payload,proto3" json:"payload,omitempty"`
+	Error                string                    `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *QuarantinedPayload) Reset()         { *m = QuarantinedPayload{} }
+func (m *QuarantinedPayload) String() string { return proto.CompactTextString(m) }
+func (*QuarantinedPayload) ProtoMessage()    {}
+func (*QuarantinedPayload) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{5}
+}
+
+func (m *QuarantinedPayload) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QuarantinedPayload.Unmarshal(m, b)
+}
+func (m *QuarantinedPayload) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QuarantinedPayload.Marshal(b, m, deterministic)
+}
+func (m *QuarantinedPayload) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QuarantinedPayload.Merge(m, src)
+}
+func (m *QuarantinedPayload) XXX_Size() int {
+	return xxx_messageInfo_QuarantinedPayload.Size(m)
+}
+func (m *QuarantinedPayload) XXX_DiscardUnknown() {
+	xxx_messageInfo_QuarantinedPayload.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QuarantinedPayload proto.InternalMessageInfo
+
+func (m *QuarantinedPayload) GetTimestamp() *timestamp.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
+func (m *QuarantinedPayload) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *QuarantinedPayload) GetWatchType() KubeWatchResult_WatchType {
+	if m != nil {
+		return m.WatchType
+	}
+	return KubeWatchResult_ADD
+}
+
+func (m *QuarantinedPayload) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *QuarantinedPayload) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+// Repeats of the same event (same involved object, reason and message) folded into one record
+// Key: /<partition>/<involved kind>/<involved namespace>/<involved name>/<reason>.<message hash>.<first timestamp>
+type EventFold struct {
+	Reason         string               `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+	Type           string               `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Message        string               `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	FirstTimestamp *timestamp.Timestamp `protobuf:"bytes,4,opt,name=firstTimestamp,proto3" json:"firstTimestamp,omitempty"`
+	LastTimestamp  *timestamp.Timestamp `protobuf:"bytes,5,opt,name=lastTimestamp,proto3" json:"lastTimestamp,omitempty"`
+	Count          int32                `protobuf:"varint,6,opt,name=count,proto3" json:"count,omitempty"`
+	// Only this event is written to the watch table, the others are only recorded here
+	FirstEventName string                  `protobuf:"bytes,7,opt,name=firstEventName,proto3" json:"firstEventName,omitempty"`
+	Events         map[string]*FoldedEvent `protobuf:"bytes,8,rep,name=events,proto3" json:"events,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The normalized message the folded events share
+	MessageTemplate      string   `protobuf:"bytes,9,opt,name=messageTemplate,proto3" json:"messageTemplate,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EventFold) Reset()         { *m = EventFold{} }
+func (m *EventFold) String() string { return proto.CompactTextString(m) }
+func (*EventFold) ProtoMessage()    {}
+func (*EventFold) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{6}
+}
+
+func (m *EventFold) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EventFold.Unmarshal(m, b)
+}
+func (m *EventFold) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EventFold.Marshal(b, m, deterministic)
+}
+func (m *EventFold) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EventFold.Merge(m, src)
+}
+func (m *EventFold) XXX_Size() int {
+	return xxx_messageInfo_EventFold.Size(m)
+}
+func (m *EventFold) XXX_DiscardUnknown() {
+	xxx_messageInfo_EventFold.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EventFold proto.InternalMessageInfo
+
+func (m *EventFold) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *EventFold) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *EventFold) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *EventFold) GetFirstTimestamp() *timestamp.Timestamp {
+	if m != nil {
+		return m.FirstTimestamp
+	}
+	return nil
+}
+
+func (m *EventFold) GetLastTimestamp() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastTimestamp
+	}
+	return nil
+}
+
+func (m *EventFold) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *EventFold) GetFirstEventName() string {
+	if m != nil {
+		return m.FirstEventName
+	}
+	return ""
+}
+
+func (m *EventFold) GetEvents() map[string]*FoldedEvent {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func (m *EventFold) GetMessageTemplate() string {
+	if m != nil {
+		return m.MessageTemplate
+	}
+	return ""
+}
+
+type FoldedEvent struct {
+	FirstTimestamp       *timestamp.Timestamp `protobuf:"bytes,1,opt,name=firstTimestamp,proto3" json:"firstTimestamp,omitempty"`
+	LastTimestamp        *timestamp.Timestamp `protobuf:"bytes,2,opt,name=lastTimestamp,proto3" json:"lastTimestamp,omitempty"`
+	Count                int32                `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *FoldedEvent) Reset()         { *m = FoldedEvent{} }
+func (m *FoldedEvent) String() string { return proto.CompactTextString(m) }
+func (*FoldedEvent) ProtoMessage()    {}
+func (*FoldedEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{7}
+}
+
+func (m *FoldedEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FoldedEvent.Unmarshal(m, b)
+}
+func (m *FoldedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FoldedEvent.Marshal(b, m, deterministic)
+}
+func (m *FoldedEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FoldedEvent.Merge(m, src)
+}
+func (m *FoldedEvent) XXX_Size() int {
+	return xxx_messageInfo_FoldedEvent.Size(m)
+}
+func (m *FoldedEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_FoldedEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FoldedEvent proto.InternalMessageInfo
+
+func (m *FoldedEvent) GetFirstTimestamp() *timestamp.Timestamp {
+	if m != nil {
+		return m.FirstTimestamp
+	}
+	return nil
+}
+
+func (m *FoldedEvent) GetLastTimestamp() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastTimestamp
+	}
+	return nil
+}
+
+func (m *FoldedEvent) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+// Phase transitions of one pod within a partition.  The first entry of each partition is the state at the first
+// watch result in that partition, so every partition can be read on its own
+// Key: /<partition>/Pod/<namespace>/<name>/<uid>
+type PodLifecycle struct {
+	Transitions          []*PodPhaseTransition `protobuf:"bytes,1,rep,name=transitions,proto3" json:"transitions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *PodLifecycle) Reset()         { *m = PodLifecycle{} }
+func (m *PodLifecycle) String() string { return proto.CompactTextString(m) }
+func (*PodLifecycle) ProtoMessage()    {}
+func (*PodLifecycle) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{8}
+}
+
+func (m *PodLifecycle) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PodLifecycle.Unmarshal(m, b)
+}
+func (m *PodLifecycle) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PodLifecycle.Marshal(b, m, deterministic)
+}
+func (m *PodLifecycle) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PodLifecycle.Merge(m, src)
+}
+func (m *PodLifecycle) XXX_Size() int {
+	return xxx_messageInfo_PodLifecycle.Size(m)
+}
+func (m *PodLifecycle) XXX_DiscardUnknown() {
+	xxx_messageInfo_PodLifecycle.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PodLifecycle proto.InternalMessageInfo
+
+func (m *PodLifecycle) GetTransitions() []*PodPhaseTransition {
+	if m != nil {
+		return m.Transitions
+	}
+	return nil
+}
+
+type PodPhaseTransition struct {
+	Timestamp            int64             `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Phase                string            `protobuf:"bytes,2,opt,name=phase,proto3" json:"phase,omitempty"`
+	WaitingReasons       map[string]string `protobuf:"bytes,3,rep,name=waitingReasons,proto3" json:"waitingReasons,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Deleted              bool              `protobuf:"varint,4,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	EphemeralContainers  map[string]string `protobuf:"bytes,5,rep,name=ephemeralContainers,proto3" json:"ephemeralContainers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	NodeName             string            `protobuf:"bytes,6,opt,name=nodeName,proto3" json:"nodeName,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *PodPhaseTransition) Reset()         { *m = PodPhaseTransition{} }
+func (m *PodPhaseTransition) String() string { return proto.CompactTextString(m) }
+func (*PodPhaseTransition) ProtoMessage()    {}
+func (*PodPhaseTransition) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{9}
+}
+
+func (m *PodPhaseTransition) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PodPhaseTransition.Unmarshal(m, b)
+}
+func (m *PodPhaseTransition) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PodPhaseTransition.Marshal(b, m, deterministic)
+}
+func (m *PodPhaseTransition) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PodPhaseTransition.Merge(m, src)
+}
+func (m *PodPhaseTransition) XXX_Size() int {
+	return xxx_messageInfo_PodPhaseTransition.Size(m)
+}
+func (m *PodPhaseTransition) XXX_DiscardUnknown() {
+	xxx_messageInfo_PodPhaseTransition.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PodPhaseTransition proto.InternalMessageInfo
+
+func (m *PodPhaseTransition) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *PodPhaseTransition) GetPhase() string {
+	if m != nil {
+		return m.Phase
+	}
+	return ""
+}
+
+func (m *PodPhaseTransition) GetWaitingReasons() map[string]string {
+	if m != nil {
+		return m.WaitingReasons
+	}
+	return nil
+}
+
+func (m *PodPhaseTransition) GetDeleted() bool {
+	if m != nil {
+		return m.Deleted
+	}
+	return false
+}
+
+func (m *PodPhaseTransition) GetEphemeralContainers() map[string]string {
+	if m != nil {
+		return m.EphemeralContainers
+	}
+	return nil
+}
+
+func (m *PodPhaseTransition) GetNodeName() string {
+	if m != nil {
+		return m.NodeName
+	}
+	return ""
+}
+
+// Status changes of the conditions of one node within a partition.  Each partition starts with the status of every
+// condition at the first watch result in that partition
+// Key: /<partition>/Node//<name>/<uid>
+type NodeConditions struct {
+	Transitions []*NodeConditionTransition `protobuf:"bytes,1,rep,name=transitions,proto3" json:"transitions,omitempty"`
+	// Node pool attributes from the labels of the last watch result in the partition
+	Pool                 *NodePool `protobuf:"bytes,2,opt,name=pool,proto3" json:"pool,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *NodeConditions) Reset()         { *m = NodeConditions{} }
+func (m *NodeConditions) String() string { return proto.CompactTextString(m) }
+func (*NodeConditions) ProtoMessage()    {}
+func (*NodeConditions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{10}
+}
+
+func (m *NodeConditions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeConditions.Unmarshal(m, b)
+}
+func (m *NodeConditions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeConditions.Marshal(b, m, deterministic)
+}
+func (m *NodeConditions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeConditions.Merge(m, src)
+}
+func (m *NodeConditions) XXX_Size() int {
+	return xxx_messageInfo_NodeConditions.Size(m)
+}
+func (m *NodeConditions) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeConditions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeConditions proto.InternalMessageInfo
+
+func (m *NodeConditions) GetTransitions() []*NodeConditionTransition {
+	if m != nil {
+		return m.Transitions
+	}
+	return nil
+}
+
+func (m *NodeConditions) GetPool() *NodePool {
+	if m != nil {
+		return m.Pool
+	}
+	return nil
+}
+
+type NodeConditionTransition struct {
+	Timestamp            int64    `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Type                 string   `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Status               string   `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Reason               string   `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	Message              string   `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	LastTransitionTime   int64    `protobuf:"varint,6,opt,name=lastTransitionTime,proto3" json:"lastTransitionTime,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NodeConditionTransition) Reset()         { *m = NodeConditionTransition{} }
+func (m *NodeConditionTransition) String() string { return proto.CompactTextString(m) }
+func (*NodeConditionTransition) ProtoMessage()    {}
+func (*NodeConditionTransition) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{11}
+}
+
+func (m *NodeConditionTransition) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeConditionTransition.Unmarshal(m, b)
+}
+func (m *NodeConditionTransition) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeConditionTransition.Marshal(b, m, deterministic)
+}
+func (m *NodeConditionTransition) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeConditionTransition.Merge(m, src)
+}
+func (m *NodeConditionTransition) XXX_Size() int {
+	return xxx_messageInfo_NodeConditionTransition.Size(m)
+}
+func (m *NodeConditionTransition) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeConditionTransition.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeConditionTransition proto.InternalMessageInfo
+
+func (m *NodeConditionTransition) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *NodeConditionTransition) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *NodeConditionTransition) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *NodeConditionTransition) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *NodeConditionTransition) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *NodeConditionTransition) GetLastTransitionTime() int64 {
+	if m != nil {
+		return m.LastTransitionTime
+	}
+	return 0
+}
+
+// One owner reference from a child to its owner, maintained from metadata.ownerReferences
+// Key: /<partition>/<owner uid>/<child kind>/<child namespace>/<child uid>
+type OwnerEdge struct {
+	OwnerKind            string               `protobuf:"bytes,1,opt,name=ownerKind,proto3" json:"ownerKind,omitempty"`
+	OwnerName            string               `protobuf:"bytes,2,opt,name=ownerName,proto3" json:"ownerName,omitempty"`
+	ChildName            string               `protobuf:"bytes,3,opt,name=childName,proto3" json:"childName,omitempty"`
+	Intervals            []*OwnerEdgeInterval `protobuf:"bytes,4,rep,name=intervals,proto3" json:"intervals,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *OwnerEdge) Reset()         { *m = OwnerEdge{} }
+func (m *OwnerEdge) String() string { return proto.CompactTextString(m) }
+func (*OwnerEdge) ProtoMessage()    {}
+func (*OwnerEdge) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{12}
+}
+
+func (m *OwnerEdge) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OwnerEdge.Unmarshal(m, b)
+}
+func (m *OwnerEdge) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OwnerEdge.Marshal(b, m, deterministic)
+}
+func (m *OwnerEdge) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OwnerEdge.Merge(m, src)
+}
+func (m *OwnerEdge) XXX_Size() int {
+	return xxx_messageInfo_OwnerEdge.Size(m)
+}
+func (m *OwnerEdge) XXX_DiscardUnknown() {
+	xxx_messageInfo_OwnerEdge.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OwnerEdge proto.InternalMessageInfo
+
+func (m *OwnerEdge) GetOwnerKind() string {
+	if m != nil {
+		return m.OwnerKind
+	}
+	return ""
+}
+
+func (m *OwnerEdge) GetOwnerName() string {
+	if m != nil {
+		return m.OwnerName
+	}
+	return ""
+}
+
+func (m *OwnerEdge) GetChildName() string {
+	if m != nil {
+		return m.ChildName
+	}
+	return ""
+}
+
+func (m *OwnerEdge) GetIntervals() []*OwnerEdgeInterval {
+	if m != nil {
+		return m.Intervals
+	}
+	return nil
+}
+
+// Unix seconds of the watch results where the reference was first seen and where it was removed or the child was
+// deleted.  End is 0 while the reference is still in place, and lastSeen is the latest watch result that had it
+type OwnerEdgeInterval struct {
+	Start                int64    `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
+	End                  int64    `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`
+	LastSeen             int64    `protobuf:"varint,3,opt,name=lastSeen,proto3" json:"lastSeen,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OwnerEdgeInterval) Reset()         { *m = OwnerEdgeInterval{} }
+func (m *OwnerEdgeInterval) String() string { return proto.CompactTextString(m) }
+func (*OwnerEdgeInterval) ProtoMessage()    {}
+func (*OwnerEdgeInterval) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{13}
+}
+
+func (m *OwnerEdgeInterval) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OwnerEdgeInterval.Unmarshal(m, b)
+}
+func (m *OwnerEdgeInterval) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OwnerEdgeInterval.Marshal(b, m, deterministic)
+}
+func (m *OwnerEdgeInterval) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OwnerEdgeInterval.Merge(m, src)
+}
+func (m *OwnerEdgeInterval) XXX_Size() int {
+	return xxx_messageInfo_OwnerEdgeInterval.Size(m)
+}
+func (m *OwnerEdgeInterval) XXX_DiscardUnknown() {
+	xxx_messageInfo_OwnerEdgeInterval.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OwnerEdgeInterval proto.InternalMessageInfo
+
+func (m *OwnerEdgeInterval) GetStart() int64 {
+	if m != nil {
+		return m.Start
+	}
+	return 0
+}
+
+func (m *OwnerEdgeInterval) GetEnd() int64 {
+	if m != nil {
+		return m.End
+	}
+	return 0
+}
+
+func (m *OwnerEdgeInterval) GetLastSeen() int64 {
+	if m != nil {
+		return m.LastSeen
+	}
+	return 0
+}
+
+// A watch result that one or more processing stages could not store, even after retrying transient errors.  Only the
+// failed stages need to be replayed
+// Key: /<partition>/<kind>/<namespace>/<name>/<timestamp>
+type DeadLetter struct {
+	WatchResult          *KubeWatchResult  `protobuf:"bytes,1,opt,name=watchResult,proto3" json:"watchResult,omitempty"`
+	StageErrors          map[string]string `protobuf:"bytes,2,rep,name=stageErrors,proto3" json:"stageErrors,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *DeadLetter) Reset()         { *m = DeadLetter{} }
+func (m *DeadLetter) String() string { return proto.CompactTextString(m) }
+func (*DeadLetter) ProtoMessage()    {}
+func (*DeadLetter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{14}
+}
+
+func (m *DeadLetter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeadLetter.Unmarshal(m, b)
+}
+func (m *DeadLetter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeadLetter.Marshal(b, m, deterministic)
+}
+func (m *DeadLetter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeadLetter.Merge(m, src)
+}
+func (m *DeadLetter) XXX_Size() int {
+	return xxx_messageInfo_DeadLetter.Size(m)
+}
+func (m *DeadLetter) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeadLetter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeadLetter proto.InternalMessageInfo
+
+func (m *DeadLetter) GetWatchResult() *KubeWatchResult {
+	if m != nil {
+		return m.WatchResult
+	}
+	return nil
+}
+
+func (m *DeadLetter) GetStageErrors() map[string]string {
+	if m != nil {
+		return m.StageErrors
+	}
+	return nil
+}
+
+// Backends of a service as seen in one EndpointSlice or Endpoints object within a partition.  A snapshot is only
+// added when the backends change, and each partition starts with the backends at its first watch result
+// Key: /<partition>/<namespace>/<service>/<source kind>/<source name>
+type ServiceBackends struct {
+	Snapshots            []*ServiceBackendSnapshot `protobuf:"bytes,1,rep,name=snapshots,proto3" json:"snapshots,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *ServiceBackends) Reset()         { *m = ServiceBackends{} }
+func (m *ServiceBackends) String() string { return proto.CompactTextString(m) }
+func (*ServiceBackends) ProtoMessage()    {}
+func (*ServiceBackends) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{15}
+}
+
+func (m *ServiceBackends) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ServiceBackends.Unmarshal(m, b)
+}
+func (m *ServiceBackends) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ServiceBackends.Marshal(b, m, deterministic)
+}
+func (m *ServiceBackends) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ServiceBackends.Merge(m, src)
+}
+func (m *ServiceBackends) XXX_Size() int {
+	return xxx_messageInfo_ServiceBackends.Size(m)
+}
+func (m *ServiceBackends) XXX_DiscardUnknown() {
+	xxx_messageInfo_ServiceBackends.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ServiceBackends proto.InternalMessageInfo
+
+func (m *ServiceBackends) GetSnapshots() []*ServiceBackendSnapshot {
+	if m != nil {
+		return m.Snapshots
+	}
+	return nil
+}
+
+type ServiceBackendSnapshot struct {
+	Timestamp            int64             `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Backends             []*ServiceBackend `protobuf:"bytes,2,rep,name=backends,proto3" json:"backends,omitempty"`
+	Deleted              bool              `protobuf:"varint,3,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ServiceBackendSnapshot) Reset()         { *m = ServiceBackendSnapshot{} }
+func (m *ServiceBackendSnapshot) String() string { return proto.CompactTextString(m) }
+func (*ServiceBackendSnapshot) ProtoMessage()    {}
+func (*ServiceBackendSnapshot) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{16}
+}
+
+func (m *ServiceBackendSnapshot) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ServiceBackendSnapshot.Unmarshal(m, b)
+}
+func (m *ServiceBackendSnapshot) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ServiceBackendSnapshot.Marshal(b, m, deterministic)
+}
+func (m *ServiceBackendSnapshot) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ServiceBackendSnapshot.Merge(m, src)
+}
+func (m *ServiceBackendSnapshot) XXX_Size() int {
+	return xxx_messageInfo_ServiceBackendSnapshot.Size(m)
+}
+func (m *ServiceBackendSnapshot) XXX_DiscardUnknown() {
+	xxx_messageInfo_ServiceBackendSnapshot.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ServiceBackendSnapshot proto.InternalMessageInfo
+
+func (m *ServiceBackendSnapshot) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *ServiceBackendSnapshot) GetBackends() []*ServiceBackend {
+	if m != nil {
+		return m.Backends
+	}
+	return nil
+}
+
+func (m *ServiceBackendSnapshot) GetDeleted() bool {
+	if m != nil {
+		return m.Deleted
+	}
+	return false
+}
+
+type ServiceBackend struct {
+	Ip                   string   `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
+	Ready                bool     `protobuf:"varint,2,opt,name=ready,proto3" json:"ready,omitempty"`
+	TargetKind           string   `protobuf:"bytes,3,opt,name=targetKind,proto3" json:"targetKind,omitempty"`
+	TargetName           string   `protobuf:"bytes,4,opt,name=targetName,proto3" json:"targetName,omitempty"`
+	NodeName             string   `protobuf:"bytes,5,opt,name=nodeName,proto3" json:"nodeName,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ServiceBackend) Reset()         { *m = ServiceBackend{} }
+func (m *ServiceBackend) String() string { return proto.CompactTextString(m) }
+func (*ServiceBackend) ProtoMessage()    {}
+func (*ServiceBackend) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{17}
+}
+
+func (m *ServiceBackend) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ServiceBackend.Unmarshal(m, b)
+}
+func (m *ServiceBackend) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ServiceBackend.Marshal(b, m, deterministic)
+}
+func (m *ServiceBackend) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ServiceBackend.Merge(m, src)
+}
+func (m *ServiceBackend) XXX_Size() int {
+	return xxx_messageInfo_ServiceBackend.Size(m)
+}
+func (m *ServiceBackend) XXX_DiscardUnknown() {
+	xxx_messageInfo_ServiceBackend.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ServiceBackend proto.InternalMessageInfo
+
+func (m *ServiceBackend) GetIp() string {
+	if m != nil {
+		return m.Ip
+	}
+	return ""
+}
+
+func (m *ServiceBackend) GetReady() bool {
+	if m != nil {
+		return m.Ready
+	}
+	return false
+}
+
+func (m *ServiceBackend) GetTargetKind() string {
+	if m != nil {
+		return m.TargetKind
+	}
+	return ""
+}
+
+func (m *ServiceBackend) GetTargetName() string {
+	if m != nil {
+		return m.TargetName
+	}
+	return ""
+}
+
+func (m *ServiceBackend) GetNodeName() string {
+	if m != nil {
+		return m.NodeName
+	}
+	return ""
+}
+
+type CurrentState struct {
+	Timestamp            *timestamp.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Payload              string               `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *CurrentState) Reset()         { *m = CurrentState{} }
+func (m *CurrentState) String() string { return proto.CompactTextString(m) }
+func (*CurrentState) ProtoMessage()    {}
+func (*CurrentState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{18}
+}
+
+func (m *CurrentState) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CurrentState.Unmarshal(m, b)
+}
+func (m *CurrentState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CurrentState.Marshal(b, m, deterministic)
+}
+func (m *CurrentState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CurrentState.Merge(m, src)
+}
+func (m *CurrentState) XXX_Size() int {
+	return xxx_messageInfo_CurrentState.Size(m)
+}
+func (m *CurrentState) XXX_DiscardUnknown() {
+	xxx_messageInfo_CurrentState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CurrentState proto.InternalMessageInfo
+
+func (m *CurrentState) GetTimestamp() *timestamp.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
+func (m *CurrentState) GetPayload() string {
+	if m != nil {
+		return m.Payload
+	}
+	return ""
+}
+
+// Something that went wrong while watching the api server, so the timeline may be missing updates around it.
+// Repeats of the same annotation close together are merged into one record
+// Key: /<partition>/<kind>/<namespace>/<type>/<first timestamp>
+type IngestAnnotation struct {
+	Type                 string               `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Kind                 string               `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Namespace            string               `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Message              string               `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	Count                int64                `protobuf:"varint,5,opt,name=count,proto3" json:"count,omitempty"`
+	FirstSeen            *timestamp.Timestamp `protobuf:"bytes,6,opt,name=firstSeen,proto3" json:"firstSeen,omitempty"`
+	LastSeen             *timestamp.Timestamp `protobuf:"bytes,7,opt,name=lastSeen,proto3" json:"lastSeen,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *IngestAnnotation) Reset()         { *m = IngestAnnotation{} }
+func (m *IngestAnnotation) String() string { return proto.CompactTextString(m) }
+func (*IngestAnnotation) ProtoMessage()    {}
+func (*IngestAnnotation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{19}
+}
+
+func (m *IngestAnnotation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IngestAnnotation.Unmarshal(m, b)
+}
+func (m *IngestAnnotation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IngestAnnotation.Marshal(b, m, deterministic)
+}
+func (m *IngestAnnotation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IngestAnnotation.Merge(m, src)
+}
+func (m *IngestAnnotation) XXX_Size() int {
+	return xxx_messageInfo_IngestAnnotation.Size(m)
+}
+func (m *IngestAnnotation) XXX_DiscardUnknown() {
+	xxx_messageInfo_IngestAnnotation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IngestAnnotation proto.InternalMessageInfo
+
+func (m *IngestAnnotation) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *IngestAnnotation) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *IngestAnnotation) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *IngestAnnotation) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *IngestAnnotation) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *IngestAnnotation) GetFirstSeen() *timestamp.Timestamp {
+	if m != nil {
+		return m.FirstSeen
+	}
+	return nil
+}
+
+func (m *IngestAnnotation) GetLastSeen() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastSeen
+	}
+	return nil
+}
+
+type QueryAuditRecord struct {
+	// Counts up across all records, the slot of a record is seq modulo the size of the log
+	Seq       uint64               `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	Timestamp *timestamp.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	User      string               `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
+	Tenant    string               `protobuf:"bytes,4,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	// Below the cluster context, like /data or /export
+	Path string `protobuf:"bytes,5,opt,name=path,proto3" json:"path,omitempty"`
+	// Query name for /data
+	Query string `protobuf:"bytes,6,opt,name=query,proto3" json:"query,omitempty"`
+	// URL encoded
+	Params         string `protobuf:"bytes,7,opt,name=params,proto3" json:"params,omitempty"`
+	DurationMillis int64  `protobuf:"varint,8,opt,name=durationMillis,proto3" json:"durationMillis,omitempty"`
+	// Response body size
+	ResultBytes int64 `protobuf:"varint,9,opt,name=resultBytes,proto3" json:"resultBytes,omitempty"`
+	// HTTP status
+	Status               int32    `protobuf:"varint,10,opt,name=status,proto3" json:"status,omitempty"`
+	RequestId            string   `protobuf:"bytes,11,opt,name=requestId,proto3" json:"requestId,omitempty"`
+	RemoteAddr           string   `protobuf:"bytes,12,opt,name=remoteAddr,proto3" json:"remoteAddr,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QueryAuditRecord) Reset()         { *m = QueryAuditRecord{} }
+func (m *QueryAuditRecord) String() string { return proto.CompactTextString(m) }
+func (*QueryAuditRecord) ProtoMessage()    {}
+func (*QueryAuditRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{20}
+}
+
+func (m *QueryAuditRecord) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryAuditRecord.Unmarshal(m, b)
+}
+func (m *QueryAuditRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryAuditRecord.Marshal(b, m, deterministic)
+}
+func (m *QueryAuditRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryAuditRecord.Merge(m, src)
+}
+func (m *QueryAuditRecord) XXX_Size() int {
+	return xxx_messageInfo_QueryAuditRecord.Size(m)
+}
+func (m *QueryAuditRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryAuditRecord.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryAuditRecord proto.InternalMessageInfo
+
+func (m *QueryAuditRecord) GetSeq() uint64 {
+	if m != nil {
+		return m.Seq
+	}
+	return 0
+}
+
+func (m *QueryAuditRecord) GetTimestamp() *timestamp.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
+func (m *QueryAuditRecord) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
+}
+
+func (m *QueryAuditRecord) GetTenant() string {
+	if m != nil {
+		return m.Tenant
+	}
+	return ""
+}
+
+func (m *QueryAuditRecord) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *QueryAuditRecord) GetQuery() string {
+	if m != nil {
+		return m.Query
+	}
+	return ""
+}
+
+func (m *QueryAuditRecord) GetParams() string {
+	if m != nil {
+		return m.Params
+	}
+	return ""
+}
+
+func (m *QueryAuditRecord) GetDurationMillis() int64 {
+	if m != nil {
+		return m.DurationMillis
+	}
+	return 0
+}
+
+func (m *QueryAuditRecord) GetResultBytes() int64 {
+	if m != nil {
+		return m.ResultBytes
+	}
+	return 0
+}
+
+func (m *QueryAuditRecord) GetStatus() int32 {
+	if m != nil {
+		return m.Status
+	}
+	return 0
+}
+
+func (m *QueryAuditRecord) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *QueryAuditRecord) GetRemoteAddr() string {
+	if m != nil {
+		return m.RemoteAddr
+	}
+	return ""
+}
+
+type RecoveryReport struct {
+	Timestamp *timestamp.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Why the store did not open as it was, empty when it opened and only had to be truncated
+	OpenError string `protobuf:"bytes,2,opt,name=openError,proto3" json:"openError,omitempty"`
+	// Removed from the value log files by the truncation
+	TruncatedBytes int64 `protobuf:"varint,3,opt,name=truncatedBytes,proto3" json:"truncatedBytes,omitempty"`
+	KeysChecked    int64 `protobuf:"varint,4,opt,name=keysChecked,proto3" json:"keysChecked,omitempty"`
+	// Keys whose values could no longer be read, these were deleted
+	KeysLost int64 `protobuf:"varint,5,opt,name=keysLost,proto3" json:"keysLost,omitempty"`
+	// Like watch/001546405200
+	LostPartitions []string `protobuf:"bytes,6,rep,name=lostPartitions,proto3" json:"lostPartitions,omitempty"`
+	// Up to a limit, keysLost has the full count
+	LostKeys             []string `protobuf:"bytes,7,rep,name=lostKeys,proto3" json:"lostKeys,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RecoveryReport) Reset()         { *m = RecoveryReport{} }
+func (m *RecoveryReport) String() string { return proto.CompactTextString(m) }
+func (*RecoveryReport) ProtoMessage()    {}
+func (*RecoveryReport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{21}
+}
+
+func (m *RecoveryReport) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RecoveryReport.Unmarshal(m, b)
+}
+func (m *RecoveryReport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RecoveryReport.Marshal(b, m, deterministic)
+}
+func (m *RecoveryReport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RecoveryReport.Merge(m, src)
+}
+func (m *RecoveryReport) XXX_Size() int {
+	return xxx_messageInfo_RecoveryReport.Size(m)
+}
+func (m *RecoveryReport) XXX_DiscardUnknown() {
+	xxx_messageInfo_RecoveryReport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RecoveryReport proto.InternalMessageInfo
+
+func (m *RecoveryReport) GetTimestamp() *timestamp.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
+func (m *RecoveryReport) GetOpenError() string {
+	if m != nil {
+		return m.OpenError
+	}
+	return ""
+}
+
+func (m *RecoveryReport) GetTruncatedBytes() int64 {
+	if m != nil {
+		return m.TruncatedBytes
+	}
+	return 0
+}
+
+func (m *RecoveryReport) GetKeysChecked() int64 {
+	if m != nil {
+		return m.KeysChecked
+	}
+	return 0
+}
+
+func (m *RecoveryReport) GetKeysLost() int64 {
+	if m != nil {
+		return m.KeysLost
+	}
+	return 0
+}
+
+func (m *RecoveryReport) GetLostPartitions() []string {
+	if m != nil {
+		return m.LostPartitions
+	}
+	return nil
+}
+
+func (m *RecoveryReport) GetLostKeys() []string {
+	if m != nil {
+		return m.LostKeys
+	}
+	return nil
+}
+
+type PartitionChecksum struct {
+	Partition string               `protobuf:"bytes,1,opt,name=partition,proto3" json:"partition,omitempty"`
+	Table     string               `protobuf:"bytes,2,opt,name=table,proto3" json:"table,omitempty"`
+	Computed  *timestamp.Timestamp `protobuf:"bytes,3,opt,name=computed,proto3" json:"computed,omitempty"`
+	Keys      int64                `protobuf:"varint,4,opt,name=keys,proto3" json:"keys,omitempty"`
+	// Of the keys and stored values
+	Bytes int64 `protobuf:"varint,5,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	// Hex, over every key and stored value in key order
+	Sha256               string   `protobuf:"bytes,6,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PartitionChecksum) Reset()         { *m = PartitionChecksum{} }
+func (m *PartitionChecksum) String() string { return proto.CompactTextString(m) }
+func (*PartitionChecksum) ProtoMessage()    {}
+func (*PartitionChecksum) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{22}
+}
+
+func (m *PartitionChecksum) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PartitionChecksum.Unmarshal(m, b)
+}
+func (m *PartitionChecksum) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PartitionChecksum.Marshal(b, m, deterministic)
+}
+func (m *PartitionChecksum) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PartitionChecksum.Merge(m, src)
+}
+func (m *PartitionChecksum) XXX_Size() int {
+	return xxx_messageInfo_PartitionChecksum.Size(m)
+}
+func (m *PartitionChecksum) XXX_DiscardUnknown() {
+	xxx_messageInfo_PartitionChecksum.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PartitionChecksum proto.InternalMessageInfo
+
+func (m *PartitionChecksum) GetPartition() string {
+	if m != nil {
+		return m.Partition
+	}
+	return ""
+}
+
+func (m *PartitionChecksum) GetTable() string {
+	if m != nil {
+		return m.Table
+	}
+	return ""
+}
+
+func (m *PartitionChecksum) GetComputed() *timestamp.Timestamp {
+	if m != nil {
+		return m.Computed
+	}
+	return nil
+}
+
+func (m *PartitionChecksum) GetKeys() int64 {
+	if m != nil {
+		return m.Keys
+	}
+	return 0
+}
+
+func (m *PartitionChecksum) GetBytes() int64 {
+	if m != nil {
+		return m.Bytes
+	}
+	return 0
+}
+
+func (m *PartitionChecksum) GetSha256() string {
+	if m != nil {
+		return m.Sha256
+	}
+	return ""
+}
+
+// Key: /baseline/<name>, a stored result of a structured query that later results are compared against
+type QueryBaseline struct {
+	Name    string               `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Created *timestamp.Timestamp `protobuf:"bytes,2,opt,name=created,proto3" json:"created,omitempty"`
+	// queries.StructuredQuery as json
+	Query string `protobuf:"bytes,3,opt,name=query,proto3" json:"query,omitempty"`
+	// current or history
+	Source string `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
+	// Json array of the rows of the result
+	Rows                 string   `protobuf:"bytes,5,opt,name=rows,proto3" json:"rows,omitempty"`
+	Total                int64    `protobuf:"varint,6,opt,name=total,proto3" json:"total,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QueryBaseline) Reset()         { *m = QueryBaseline{} }
+func (m *QueryBaseline) String() string { return proto.CompactTextString(m) }
+func (*QueryBaseline) ProtoMessage()    {}
+func (*QueryBaseline) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{23}
+}
+
+func (m *QueryBaseline) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryBaseline.Unmarshal(m, b)
+}
+func (m *QueryBaseline) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryBaseline.Marshal(b, m, deterministic)
+}
+func (m *QueryBaseline) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryBaseline.Merge(m, src)
+}
+func (m *QueryBaseline) XXX_Size() int {
+	return xxx_messageInfo_QueryBaseline.Size(m)
+}
+func (m *QueryBaseline) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryBaseline.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryBaseline proto.InternalMessageInfo
+
+func (m *QueryBaseline) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *QueryBaseline) GetCreated() *timestamp.Timestamp {
+	if m != nil {
+		return m.Created
+	}
+	return nil
+}
+
+func (m *QueryBaseline) GetQuery() string {
+	if m != nil {
+		return m.Query
+	}
+	return ""
+}
+
+func (m *QueryBaseline) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *QueryBaseline) GetRows() string {
+	if m != nil {
+		return m.Rows
+	}
+	return ""
+}
+
+func (m *QueryBaseline) GetTotal() int64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+// Key: /selffootprint/<partition>/<unix nanos>, a sample of the resources the sloop process itself uses
+type SelfFootprint struct {
+	Timestamp *timestamp.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Resident set size, 0 where /proc is not available
+	RssBytes int64 `protobuf:"varint,2,opt,name=rssBytes,proto3" json:"rssBytes,omitempty"`
+	// Go heap in use
+	HeapBytes  int64 `protobuf:"varint,3,opt,name=heapBytes,proto3" json:"heapBytes,omitempty"`
+	Goroutines int64 `protobuf:"varint,4,opt,name=goroutines,proto3" json:"goroutines,omitempty"`
+	// Badger LSM tree and value log on disk
+	LsmBytes  int64 `protobuf:"varint,5,opt,name=lsmBytes,proto3" json:"lsmBytes,omitempty"`
+	VlogBytes int64 `protobuf:"varint,6,opt,name=vlogBytes,proto3" json:"vlogBytes,omitempty"`
+	// Watch results waiting for processing
+	WatchQueueDepth    int64 `protobuf:"varint,7,opt,name=watchQueueDepth,proto3" json:"watchQueueDepth,omitempty"`
+	WatchQueueCapacity int64 `protobuf:"varint,8,opt,name=watchQueueCapacity,proto3" json:"watchQueueCapacity,omitempty"`
+	// Ingest annotations waiting for processing
+	AnnotationQueueDepth int64    `protobuf:"varint,9,opt,name=annotationQueueDepth,proto3" json:"annotationQueueDepth,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SelfFootprint) Reset()         { *m = SelfFootprint{} }
+func (m *SelfFootprint) String() string { return proto.CompactTextString(m) }
+func (*SelfFootprint) ProtoMessage()    {}
+func (*SelfFootprint) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{24}
+}
+
+func (m *SelfFootprint) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SelfFootprint.Unmarshal(m, b)
+}
+func (m *SelfFootprint) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SelfFootprint.Marshal(b, m, deterministic)
+}
+func (m *SelfFootprint) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SelfFootprint.Merge(m, src)
+}
+func (m *SelfFootprint) XXX_Size() int {
+	return xxx_messageInfo_SelfFootprint.Size(m)
+}
+func (m *SelfFootprint) XXX_DiscardUnknown() {
+	xxx_messageInfo_SelfFootprint.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SelfFootprint proto.InternalMessageInfo
+
+func (m *SelfFootprint) GetTimestamp() *timestamp.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
+func (m *SelfFootprint) GetRssBytes() int64 {
+	if m != nil {
+		return m.RssBytes
+	}
+	return 0
+}
+
+func (m *SelfFootprint) GetHeapBytes() int64 {
+	if m != nil {
+		return m.HeapBytes
+	}
+	return 0
+}
+
+func (m *SelfFootprint) GetGoroutines() int64 {
+	if m != nil {
+		return m.Goroutines
+	}
+	return 0
+}
+
+func (m *SelfFootprint) GetLsmBytes() int64 {
+	if m != nil {
+		return m.LsmBytes
+	}
+	return 0
+}
+
+func (m *SelfFootprint) GetVlogBytes() int64 {
+	if m != nil {
+		return m.VlogBytes
+	}
+	return 0
+}
+
+func (m *SelfFootprint) GetWatchQueueDepth() int64 {
+	if m != nil {
+		return m.WatchQueueDepth
+	}
+	return 0
+}
+
+func (m *SelfFootprint) GetWatchQueueCapacity() int64 {
+	if m != nil {
+		return m.WatchQueueCapacity
+	}
+	return 0
+}
+
+func (m *SelfFootprint) GetAnnotationQueueDepth() int64 {
+	if m != nil {
+		return m.AnnotationQueueDepth
+	}
+	return 0
+}
+
+// Key: /apiserviceavailability/<partition>/<apiservice name>/<uid>, changes of the Available condition of an APIService
+type ApiServiceAvailability struct {
+	Group   string `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	// Namespace/name of the service serving the aggregated API, empty for APIs served by the api server itself
+	Service string `protobuf:"bytes,3,opt,name=service,proto3" json:"service,omitempty"`
+	// Oldest first
+	Transitions          []*ApiServiceTransition `protobuf:"bytes,4,rep,name=transitions,proto3" json:"transitions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *ApiServiceAvailability) Reset()         { *m = ApiServiceAvailability{} }
+func (m *ApiServiceAvailability) String() string { return proto.CompactTextString(m) }
+func (*ApiServiceAvailability) ProtoMessage()    {}
+func (*ApiServiceAvailability) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{25}
+}
+
+func (m *ApiServiceAvailability) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ApiServiceAvailability.Unmarshal(m, b)
+}
+func (m *ApiServiceAvailability) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ApiServiceAvailability.Marshal(b, m, deterministic)
+}
+func (m *ApiServiceAvailability) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApiServiceAvailability.Merge(m, src)
+}
+func (m *ApiServiceAvailability) XXX_Size() int {
+	return xxx_messageInfo_ApiServiceAvailability.Size(m)
+}
+func (m *ApiServiceAvailability) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApiServiceAvailability.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApiServiceAvailability proto.InternalMessageInfo
+
+func (m *ApiServiceAvailability) GetGroup() string {
+	if m != nil {
+		return m.Group
+	}
+	return ""
+}
+
+func (m *ApiServiceAvailability) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *ApiServiceAvailability) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
+}
+
+func (m *ApiServiceAvailability) GetTransitions() []*ApiServiceTransition {
+	if m != nil {
+		return m.Transitions
+	}
+	return nil
+}
+
+// A change of the Available condition of an APIService
+type ApiServiceTransition struct {
+	// Unix seconds of the watch result
+	Timestamp int64 `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// True, False or Unknown
+	Status  string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Reason  string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	Message string `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	// Unix seconds reported by the aggregator, 0 when missing
+	LastTransitionTime   int64    `protobuf:"varint,5,opt,name=lastTransitionTime,proto3" json:"lastTransitionTime,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ApiServiceTransition) Reset()         { *m = ApiServiceTransition{} }
+func (m *ApiServiceTransition) String() string { return proto.CompactTextString(m) }
+func (*ApiServiceTransition) ProtoMessage()    {}
+func (*ApiServiceTransition) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{26}
+}
+
+func (m *ApiServiceTransition) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ApiServiceTransition.Unmarshal(m, b)
+}
+func (m *ApiServiceTransition) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ApiServiceTransition.Marshal(b, m, deterministic)
+}
+func (m *ApiServiceTransition) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApiServiceTransition.Merge(m, src)
+}
+func (m *ApiServiceTransition) XXX_Size() int {
+	return xxx_messageInfo_ApiServiceTransition.Size(m)
+}
+func (m *ApiServiceTransition) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApiServiceTransition.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApiServiceTransition proto.InternalMessageInfo
+
+func (m *ApiServiceTransition) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *ApiServiceTransition) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *ApiServiceTransition) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *ApiServiceTransition) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *ApiServiceTransition) GetLastTransitionTime() int64 {
+	if m != nil {
+		return m.LastTransitionTime
+	}
+	return 0
+}
+
+// Partitions GC keeps until the freeze is removed
+type PartitionFreeze struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// First and last partition ids of the range, both included
+	FromPartition        string               `protobuf:"bytes,2,opt,name=fromPartition,proto3" json:"fromPartition,omitempty"`
+	ToPartition          string               `protobuf:"bytes,3,opt,name=toPartition,proto3" json:"toPartition,omitempty"`
+	Reason               string               `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	Created              *timestamp.Timestamp `protobuf:"bytes,5,opt,name=created,proto3" json:"created,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *PartitionFreeze) Reset()         { *m = PartitionFreeze{} }
+func (m *PartitionFreeze) String() string { return proto.CompactTextString(m) }
+func (*PartitionFreeze) ProtoMessage()    {}
+func (*PartitionFreeze) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{27}
+}
+
+func (m *PartitionFreeze) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PartitionFreeze.Unmarshal(m, b)
+}
+func (m *PartitionFreeze) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PartitionFreeze.Marshal(b, m, deterministic)
+}
+func (m *PartitionFreeze) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PartitionFreeze.Merge(m, src)
+}
+func (m *PartitionFreeze) XXX_Size() int {
+	return xxx_messageInfo_PartitionFreeze.Size(m)
+}
+func (m *PartitionFreeze) XXX_DiscardUnknown() {
+	xxx_messageInfo_PartitionFreeze.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PartitionFreeze proto.InternalMessageInfo
+
+func (m *PartitionFreeze) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *PartitionFreeze) GetFromPartition() string {
+	if m != nil {
+		return m.FromPartition
+	}
+	return ""
+}
+
+func (m *PartitionFreeze) GetToPartition() string {
+	if m != nil {
+		return m.ToPartition
+	}
+	return ""
+}
+
+func (m *PartitionFreeze) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *PartitionFreeze) GetCreated() *timestamp.Timestamp {
+	if m != nil {
+		return m.Created
+	}
+	return nil
+}
+
+// Where a node runs and what it runs on, from its well known labels.  Fields are empty when the label is missing
+type NodePool struct {
+	Zone         string `protobuf:"bytes,1,opt,name=zone,proto3" json:"zone,omitempty"`
+	Region       string `protobuf:"bytes,2,opt,name=region,proto3" json:"region,omitempty"`
+	InstanceType string `protobuf:"bytes,3,opt,name=instanceType,proto3" json:"instanceType,omitempty"`
+	// Like amd64 or arm64, from status.nodeInfo when the label is missing
+	Arch string `protobuf:"bytes,4,opt,name=arch,proto3" json:"arch,omitempty"`
+	Os   string `protobuf:"bytes,5,opt,name=os,proto3" json:"os,omitempty"`
+	// Name of the node pool or group of a managed cluster
+	Pool                 string   `protobuf:"bytes,6,opt,name=pool,proto3" json:"pool,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NodePool) Reset()         { *m = NodePool{} }
+func (m *NodePool) String() string { return proto.CompactTextString(m) }
+func (*NodePool) ProtoMessage()    {}
+func (*NodePool) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{28}
+}
+
+func (m *NodePool) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodePool.Unmarshal(m, b)
+}
+func (m *NodePool) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodePool.Marshal(b, m, deterministic)
+}
+func (m *NodePool) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodePool.Merge(m, src)
+}
+func (m *NodePool) XXX_Size() int {
+	return xxx_messageInfo_NodePool.Size(m)
+}
+func (m *NodePool) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodePool.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodePool proto.InternalMessageInfo
+
+func (m *NodePool) GetZone() string {
+	if m != nil {
+		return m.Zone
+	}
+	return ""
+}
+
+func (m *NodePool) GetRegion() string {
+	if m != nil {
+		return m.Region
+	}
+	return ""
+}
+
+func (m *NodePool) GetInstanceType() string {
+	if m != nil {
+		return m.InstanceType
+	}
+	return ""
+}
+
+func (m *NodePool) GetArch() string {
+	if m != nil {
+		return m.Arch
+	}
+	return ""
+}
+
+func (m *NodePool) GetOs() string {
+	if m != nil {
+		return m.Os
+	}
+	return ""
+}
+
+func (m *NodePool) GetPool() string {
+	if m != nil {
+		return m.Pool
+	}
+	return ""
+}
+
+// Key: /retentionoverride/<namespace>/<kind>, keeps the data of a namespace past retention until it expires
+type RetentionOverride struct {
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Empty for every kind
+	Kind    string               `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Expires *timestamp.Timestamp `protobuf:"bytes,3,opt,name=expires,proto3" json:"expires,omitempty"`
+	Reason  string               `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	// User of the request that set it, empty without authentication
+	CreatedBy            string               `protobuf:"bytes,5,opt,name=createdBy,proto3" json:"createdBy,omitempty"`
+	Created              *timestamp.Timestamp `protobuf:"bytes,6,opt,name=created,proto3" json:"created,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *RetentionOverride) Reset()         { *m = RetentionOverride{} }
+func (m *RetentionOverride) String() string { return proto.CompactTextString(m) }
+func (*RetentionOverride) ProtoMessage()    {}
+func (*RetentionOverride) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{29}
+}
+
+func (m *RetentionOverride) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RetentionOverride.Unmarshal(m, b)
+}
+func (m *RetentionOverride) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RetentionOverride.Marshal(b, m, deterministic)
+}
+func (m *RetentionOverride) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RetentionOverride.Merge(m, src)
+}
+func (m *RetentionOverride) XXX_Size() int {
+	return xxx_messageInfo_RetentionOverride.Size(m)
+}
+func (m *RetentionOverride) XXX_DiscardUnknown() {
+	xxx_messageInfo_RetentionOverride.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RetentionOverride proto.InternalMessageInfo
+
+func (m *RetentionOverride) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *RetentionOverride) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *RetentionOverride) GetExpires() *timestamp.Timestamp {
+	if m != nil {
+		return m.Expires
+	}
+	return nil
+}
+
+func (m *RetentionOverride) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *RetentionOverride) GetCreatedBy() string {
+	if m != nil {
+		return m.CreatedBy
+	}
+	return ""
+}
+
+func (m *RetentionOverride) GetCreated() *timestamp.Timestamp {
+	if m != nil {
+		return m.Created
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("typed.KubeWatchResult_WatchType", KubeWatchResult_WatchType_name, KubeWatchResult_WatchType_value)
 	proto.RegisterType((*KubeWatchResult)(nil), "typed.KubeWatchResult")
@@ -328,42 +2293,178 @@ func init() {
 	proto.RegisterType((*ResourceEventCounts)(nil), "typed.ResourceEventCounts")
 	proto.RegisterMapType((map[int64]*EventCounts)(nil), "typed.ResourceEventCounts.MapMinToEventsEntry")
 	proto.RegisterType((*WatchActivity)(nil), "typed.WatchActivity")
+	proto.RegisterType((*QuarantinedPayload)(nil), "typed.QuarantinedPayload")
+	proto.RegisterType((*EventFold)(nil), "typed.EventFold")
+	proto.RegisterMapType((map[string]*FoldedEvent)(nil), "typed.EventFold.EventsEntry")
+	proto.RegisterType((*FoldedEvent)(nil), "typed.FoldedEvent")
+	proto.RegisterType((*PodLifecycle)(nil), "typed.PodLifecycle")
+	proto.RegisterType((*PodPhaseTransition)(nil), "typed.PodPhaseTransition")
+	proto.RegisterMapType((map[string]string)(nil), "typed.PodPhaseTransition.WaitingReasonsEntry")
+	proto.RegisterMapType((map[string]string)(nil), "typed.PodPhaseTransition.EphemeralContainersEntry")
+	proto.RegisterType((*NodeConditions)(nil), "typed.NodeConditions")
+	proto.RegisterType((*NodeConditionTransition)(nil), "typed.NodeConditionTransition")
+	proto.RegisterType((*OwnerEdge)(nil), "typed.OwnerEdge")
+	proto.RegisterType((*OwnerEdgeInterval)(nil), "typed.OwnerEdgeInterval")
+	proto.RegisterType((*DeadLetter)(nil), "typed.DeadLetter")
+	proto.RegisterMapType((map[string]string)(nil), "typed.DeadLetter.StageErrorsEntry")
+	proto.RegisterType((*ServiceBackends)(nil), "typed.ServiceBackends")
+	proto.RegisterType((*ServiceBackendSnapshot)(nil), "typed.ServiceBackendSnapshot")
+	proto.RegisterType((*ServiceBackend)(nil), "typed.ServiceBackend")
+	proto.RegisterType((*CurrentState)(nil), "typed.CurrentState")
+	proto.RegisterType((*IngestAnnotation)(nil), "typed.IngestAnnotation")
+	proto.RegisterType((*QueryAuditRecord)(nil), "typed.QueryAuditRecord")
+	proto.RegisterType((*RecoveryReport)(nil), "typed.RecoveryReport")
+	proto.RegisterType((*PartitionChecksum)(nil), "typed.PartitionChecksum")
+	proto.RegisterType((*QueryBaseline)(nil), "typed.QueryBaseline")
+	proto.RegisterType((*SelfFootprint)(nil), "typed.SelfFootprint")
+	proto.RegisterType((*ApiServiceAvailability)(nil), "typed.ApiServiceAvailability")
+	proto.RegisterType((*ApiServiceTransition)(nil), "typed.ApiServiceTransition")
+	proto.RegisterType((*PartitionFreeze)(nil), "typed.PartitionFreeze")
+	proto.RegisterType((*NodePool)(nil), "typed.NodePool")
+	proto.RegisterType((*RetentionOverride)(nil), "typed.RetentionOverride")
 }
 
 func init() { proto.RegisterFile("schema.proto", fileDescriptor_1c5fb4d8cc22d66a) }
 
 var fileDescriptor_1c5fb4d8cc22d66a = []byte{
-	// 505 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x92, 0xcf, 0x6e, 0x9b, 0x40,
-	0x10, 0xc6, 0x0b, 0xc4, 0x49, 0x18, 0xe7, 0x8f, 0xb5, 0x69, 0x25, 0x64, 0x55, 0x2d, 0x42, 0x3d,
-	0x70, 0xa8, 0x88, 0xe4, 0x4a, 0x55, 0x94, 0x43, 0x25, 0x64, 0x73, 0x6a, 0x5d, 0x55, 0x1b, 0xd2,
-	0x9c, 0xd7, 0x66, 0x62, 0xa3, 0x00, 0x8b, 0xd8, 0xc5, 0x15, 0x8f, 0xd0, 0x37, 0xe9, 0x83, 0xf4,
-	0x5d, 0xfa, 0x1a, 0x15, 0x8b, 0xb1, 0x89, 0x63, 0xb5, 0xb9, 0x0d, 0xdf, 0xfe, 0xbe, 0xe1, 0x9b,
-	0xd9, 0x85, 0x13, 0x31, 0x5f, 0x62, 0xca, 0xbc, 0xbc, 0xe0, 0x92, 0x93, 0x9e, 0xac, 0x72, 0x8c,
-	0x86, 0x6f, 0x17, 0x9c, 0x2f, 0x12, 0xbc, 0x54, 0xe2, 0xac, 0xbc, 0xbf, 0x94, 0x71, 0x8a, 0x42,
-	0xb2, 0x34, 0x6f, 0x38, 0xe7, 0x8f, 0x06, 0xe7, 0x9f, 0xcb, 0x19, 0xde, 0x31, 0x39, 0x5f, 0x52,
-	0x14, 0x65, 0x22, 0xc9, 0x15, 0x98, 0x1b, 0xcc, 0xd2, 0x6c, 0xcd, 0xed, 0x8f, 0x86, 0x5e, 0xd3,
-	0xc8, 0x6b, 0x1b, 0x79, 0x61, 0x4b, 0xd0, 0x2d, 0x4c, 0x08, 0x1c, 0x3c, 0xc4, 0x59, 0x64, 0xe9,
-	0xb6, 0xe6, 0x9a, 0x54, 0xd5, 0xe4, 0x13, 0x98, 0x3f, 0xea, 0xe6, 0x61, 0x95, 0xa3, 0x65, 0xd8,
-	0x9a, 0x7b, 0x36, 0xb2, 0x3d, 0x95, 0xce, 0xdb, 0xf9, 0xb1, 0x77, 0xd7, 0x72, 0x74, 0x6b, 0x21,
-	0x16, 0x1c, 0xe5, 0xac, 0x4a, 0x38, 0x8b, 0xac, 0x03, 0xd5, 0xb6, 0xfd, 0x74, 0xde, 0x83, 0xb9,
-	0x71, 0x90, 0x23, 0x30, 0xfc, 0xc9, 0x64, 0xf0, 0x82, 0x00, 0x1c, 0xde, 0x7e, 0x9b, 0xf8, 0x61,
-	0x30, 0xd0, 0xea, 0x7a, 0x12, 0x7c, 0x09, 0xc2, 0x60, 0xa0, 0x3b, 0x3f, 0x75, 0x38, 0xa7, 0x28,
-	0x78, 0x59, 0xcc, 0xf1, 0xa6, 0x4c, 0x53, 0x56, 0x54, 0xf5, 0xa4, 0xf7, 0x71, 0x21, 0xe4, 0x0d,
-	0x62, 0xf6, 0x9c, 0x49, 0x37, 0x30, 0xf9, 0x08, 0xc7, 0x09, 0x5b, 0x1b, 0xf5, 0xff, 0x1a, 0x37,
-	0x2c, 0xb9, 0x06, 0x98, 0x17, 0xc8, 0x24, 0xd6, 0x87, 0x6a, 0x1d, 0xff, 0x76, 0x76, 0x68, 0xe2,
-	0xc0, 0x49, 0x84, 0x09, 0x4a, 0x8c, 0x7c, 0x19, 0x64, 0xcd, 0x3a, 0x8e, 0xe9, 0x23, 0x8d, 0xbc,
-	0x83, 0xd3, 0x02, 0x13, 0x26, 0x63, 0x9e, 0x89, 0x65, 0x9c, 0x0b, 0xab, 0x67, 0x1b, 0xae, 0x49,
-	0x1f, 0x8b, 0xce, 0x2f, 0x0d, 0xfa, 0xc1, 0x0a, 0x33, 0x39, 0xe6, 0x65, 0x26, 0x05, 0x09, 0x61,
-	0x90, 0xb2, 0x9c, 0x22, 0x13, 0x3c, 0x0b, 0xb9, 0x12, 0x2d, 0xcd, 0x36, 0xdc, 0xfe, 0xc8, 0x5d,
-	0x5f, 0x55, 0x87, 0xf6, 0xa6, 0x3b, 0x68, 0x90, 0xc9, 0xa2, 0xa2, 0x4f, 0x3a, 0x0c, 0xc7, 0xf0,
-	0x6a, 0x2f, 0x4a, 0x06, 0x60, 0x3c, 0x60, 0xa5, 0x16, 0x6e, 0xd2, 0xba, 0x24, 0x2f, 0xa1, 0xb7,
-	0x62, 0x49, 0x89, 0x6a, 0x97, 0x3d, 0xda, 0x7c, 0x5c, 0xeb, 0x57, 0x9a, 0xf3, 0x5b, 0x83, 0x8b,
-	0xf6, 0xda, 0xba, 0x91, 0xbf, 0xc3, 0x59, 0xca, 0xf2, 0x69, 0x9c, 0x85, 0x5c, 0xc9, 0x62, 0x1d,
-	0xd8, 0x5b, 0x07, 0xde, 0xe3, 0xa9, 0x83, 0x77, 0x0c, 0x4d, 0xec, 0x9d, 0x2e, 0xc3, 0x5b, 0xb8,
-	0xd8, 0x83, 0x75, 0x23, 0x1b, 0x4d, 0x64, 0xb7, 0x1b, 0xb9, 0x3f, 0x22, 0x4f, 0x17, 0xd5, 0x1d,
-	0x63, 0x0a, 0xa7, 0xea, 0xad, 0xfa, 0x73, 0x19, 0xaf, 0x62, 0x59, 0x91, 0x37, 0x00, 0x5f, 0xf9,
-	0x78, 0xc9, 0xb2, 0x05, 0xfa, 0xcd, 0xb2, 0x0d, 0xda, 0x51, 0xc8, 0x6b, 0x30, 0x9b, 0x3a, 0xf2,
-	0xa5, 0xa5, 0xab, 0xe3, 0xad, 0x30, 0x3b, 0x54, 0x4f, 0xe5, 0xc3, 0xdf, 0x00, 0x00, 0x00, 0xff,
-	0xff, 0x7e, 0x49, 0x70, 0xa9, 0xf5, 0x03, 0x00, 0x00,
+	// 2212 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x58, 0x4b, 0x6f, 0xdc, 0xc8,
+	0x11, 0x0e, 0x87, 0x1a, 0x49, 0x53, 0xa3, 0x97, 0x69, 0xad, 0xc2, 0x28, 0xce, 0x46, 0x60, 0x16,
+	0xc1, 0x20, 0x48, 0x66, 0x11, 0x65, 0x63, 0x18, 0xbb, 0xc8, 0x62, 0x47, 0x0f, 0x03, 0x86, 0x2d,
+	0xaf, 0x4c, 0xc9, 0xeb, 0x73, 0x8b, 0x2c, 0xcd, 0x10, 0xe2, 0xb0, 0xe9, 0xee, 0xe6, 0x28, 0xe3,
+	0x6b, 0x4e, 0xb9, 0xee, 0x3d, 0x87, 0xdc, 0x92, 0x4b, 0x80, 0x3d, 0xe7, 0x14, 0x60, 0x93, 0x53,
+	0x4e, 0xf9, 0x13, 0x39, 0x24, 0xbf, 0x20, 0xa7, 0xa0, 0x1f, 0x24, 0x9b, 0xa3, 0x19, 0x48, 0xb6,
+	0x2f, 0x7b, 0x63, 0x7d, 0x5d, 0xdd, 0x5d, 0x5d, 0x5d, 0xf5, 0x55, 0x35, 0x61, 0x8d, 0x47, 0x23,
+	0x1c, 0x93, 0x7e, 0xce, 0xa8, 0xa0, 0x5e, 0x5b, 0x4c, 0x73, 0x8c, 0x77, 0x7f, 0x3c, 0xa4, 0x74,
+	0x98, 0xe2, 0xc7, 0x0a, 0xbc, 0x28, 0x2e, 0x3f, 0x16, 0xc9, 0x18, 0xb9, 0x20, 0xe3, 0x5c, 0xeb,
+	0x05, 0xdf, 0xb4, 0x61, 0xf3, 0x69, 0x71, 0x81, 0xaf, 0x88, 0x88, 0x46, 0x21, 0xf2, 0x22, 0x15,
+	0xde, 0x23, 0xe8, 0x54, 0x6a, 0xbe, 0xb3, 0xe7, 0xf4, 0xba, 0xfb, 0xbb, 0x7d, 0xbd, 0x50, 0xbf,
+	0x5c, 0xa8, 0x7f, 0x5e, 0x6a, 0x84, 0xb5, 0xb2, 0xe7, 0xc1, 0xd2, 0x55, 0x92, 0xc5, 0x7e, 0x6b,
+	0xcf, 0xe9, 0x75, 0x42, 0xf5, 0xed, 0x7d, 0x0e, 0x9d, 0x6b, 0xb9, 0xf8, 0xf9, 0x34, 0x47, 0xdf,
+	0xdd, 0x73, 0x7a, 0x1b, 0xfb, 0x7b, 0x7d, 0x65, 0x5d, 0x7f, 0x66, 0xe3, 0xfe, 0xab, 0x52, 0x2f,
+	0xac, 0xa7, 0x78, 0x3e, 0xac, 0xe4, 0x64, 0x9a, 0x52, 0x12, 0xfb, 0x4b, 0x6a, 0xd9, 0x52, 0xf4,
+	0x02, 0x58, 0x8b, 0x46, 0x24, 0x1b, 0x62, 0x7c, 0x4a, 0xc4, 0x88, 0xfb, 0xed, 0x3d, 0xb7, 0xd7,
+	0x09, 0x1b, 0x98, 0xf7, 0x33, 0xd8, 0xb2, 0xe4, 0x43, 0x5a, 0x64, 0xc2, 0x5f, 0xde, 0x73, 0x7a,
+	0xed, 0xf0, 0x06, 0xee, 0x3d, 0x80, 0x0e, 0x4f, 0x86, 0x19, 0x11, 0x05, 0x43, 0x7f, 0x65, 0xcf,
+	0xe9, 0xad, 0x85, 0x35, 0xe0, 0xf5, 0x60, 0x33, 0x4a, 0x69, 0x74, 0x75, 0x76, 0x85, 0xd7, 0x27,
+	0x49, 0x9a, 0x26, 0xdc, 0x5f, 0xdd, 0x73, 0x7a, 0x6e, 0x38, 0x0b, 0x4b, 0xcd, 0x31, 0x72, 0x4e,
+	0x86, 0x78, 0x8e, 0xe3, 0x3c, 0x25, 0x02, 0xfd, 0x8e, 0xb2, 0x7c, 0x16, 0x96, 0xd6, 0x65, 0x94,
+	0x8d, 0x49, 0x9a, 0xbc, 0xc1, 0x38, 0x44, 0xc2, 0x69, 0xe6, 0x83, 0x52, 0xbd, 0x81, 0x7b, 0x7b,
+	0xd0, 0x4d, 0xb2, 0x09, 0x4d, 0x27, 0x18, 0xbf, 0x4c, 0x62, 0xbf, 0xab, 0xd4, 0x6c, 0x48, 0x6a,
+	0x4c, 0x90, 0xf1, 0x84, 0x66, 0xca, 0xd7, 0x6b, 0x5a, 0xc3, 0x82, 0xbc, 0x0f, 0x01, 0x68, 0x1a,
+	0x9f, 0x1a, 0x77, 0xae, 0x2b, 0x05, 0x0b, 0x91, 0xe3, 0x97, 0x49, 0x46, 0xd2, 0x33, 0x21, 0x8d,
+	0xde, 0xd0, 0xe3, 0x35, 0x22, 0xc7, 0x49, 0x9e, 0x7c, 0xa5, 0x57, 0xf4, 0x37, 0xf5, 0x78, 0x8d,
+	0x78, 0x0f, 0x61, 0xa7, 0x96, 0x4e, 0x92, 0x21, 0x23, 0x02, 0xe3, 0xc7, 0x8c, 0x8e, 0xfd, 0x2d,
+	0xa5, 0xbb, 0x60, 0x34, 0xf8, 0x39, 0x74, 0xaa, 0xbb, 0xf7, 0x56, 0xc0, 0x1d, 0x1c, 0x1d, 0x6d,
+	0x7d, 0xcf, 0x03, 0x58, 0x7e, 0x79, 0x7a, 0x34, 0x38, 0x3f, 0xde, 0x72, 0xe4, 0xf7, 0xd1, 0xf1,
+	0xb3, 0xe3, 0xf3, 0xe3, 0xad, 0x56, 0xf0, 0xfb, 0x16, 0x6c, 0x86, 0xc8, 0x69, 0xc1, 0x22, 0x3c,
+	0x2b, 0xc6, 0x63, 0xc2, 0xa6, 0x32, 0x66, 0x2f, 0x13, 0xc6, 0xc5, 0x19, 0x62, 0x76, 0x97, 0x98,
+	0xad, 0x94, 0xbd, 0x87, 0xb0, 0x9a, 0x12, 0x33, 0xb1, 0x75, 0xeb, 0xc4, 0x4a, 0xd7, 0xfb, 0x14,
+	0x20, 0x62, 0x48, 0x04, 0xca, 0x41, 0xdf, 0xbd, 0x75, 0xa6, 0xa5, 0x2d, 0x23, 0x37, 0xc6, 0x14,
+	0x05, 0xc6, 0x03, 0x71, 0x9c, 0xe9, 0xc0, 0x5e, 0x0d, 0x1b, 0x98, 0xf7, 0x11, 0xac, 0x33, 0x4c,
+	0x89, 0x48, 0x68, 0xc6, 0x47, 0x49, 0x5e, 0x86, 0x77, 0x13, 0x0c, 0xfe, 0xe4, 0x40, 0xf7, 0x78,
+	0x82, 0x99, 0x50, 0x21, 0xcc, 0xbd, 0x73, 0xd8, 0x1a, 0x93, 0x5c, 0x87, 0xcc, 0x39, 0x55, 0xa0,
+	0xef, 0xec, 0xb9, 0xbd, 0xee, 0x7e, 0xcf, 0x24, 0x9d, 0xa5, 0xdd, 0x3f, 0x99, 0x51, 0x3d, 0xce,
+	0x04, 0x9b, 0x86, 0x37, 0x56, 0xd8, 0x3d, 0x84, 0x0f, 0xe6, 0xaa, 0x7a, 0x5b, 0xe0, 0x5e, 0xe1,
+	0x54, 0x39, 0xbc, 0x13, 0xca, 0x4f, 0x6f, 0x1b, 0xda, 0x13, 0x92, 0x16, 0xa8, 0x7c, 0xd9, 0x0e,
+	0xb5, 0xf0, 0x69, 0xeb, 0x91, 0x13, 0x7c, 0xeb, 0xc0, 0xfd, 0xf2, 0xda, 0x6c, 0x93, 0xbf, 0x82,
+	0x8d, 0x31, 0xc9, 0x4f, 0x92, 0xec, 0x9c, 0x2a, 0x98, 0x1b, 0x83, 0xfb, 0xc6, 0xe0, 0x39, 0x73,
+	0xfa, 0x27, 0x8d, 0x09, 0xda, 0xec, 0x99, 0x55, 0x76, 0x5f, 0xc2, 0xfd, 0x39, 0x6a, 0xb6, 0xc9,
+	0xae, 0x36, 0xb9, 0x67, 0x9b, 0xdc, 0xdd, 0xf7, 0x6e, 0x3a, 0xca, 0x3e, 0x46, 0x02, 0xeb, 0x2a,
+	0x56, 0x07, 0x91, 0x48, 0x26, 0x89, 0x98, 0xca, 0xa4, 0x78, 0x4e, 0x0f, 0x15, 0x99, 0x0c, 0xb4,
+	0xb3, 0xdd, 0xd0, 0x42, 0x24, 0xad, 0xe8, 0xef, 0x78, 0x20, 0xfc, 0x96, 0x1a, 0xae, 0x01, 0x6f,
+	0x17, 0x56, 0x4f, 0x12, 0xce, 0xd5, 0xa0, 0xab, 0x06, 0x2b, 0x39, 0xf8, 0x97, 0x03, 0xde, 0x8b,
+	0x82, 0x30, 0x92, 0x89, 0x24, 0xc3, 0x2a, 0x4b, 0xbf, 0xd3, 0xfc, 0xbc, 0x56, 0xf3, 0xf3, 0x36,
+	0xb4, 0x91, 0x31, 0xca, 0xfc, 0xb6, 0xda, 0x4e, 0x0b, 0xc1, 0xb7, 0x2e, 0x74, 0x94, 0x6b, 0x1f,
+	0xd3, 0x34, 0xf6, 0x76, 0x60, 0x99, 0x69, 0xde, 0xd3, 0x31, 0x64, 0x24, 0x69, 0xa9, 0xb4, 0xa1,
+	0xb4, 0x54, 0x98, 0x9d, 0x0c, 0x81, 0x2a, 0x3b, 0x3b, 0x61, 0x29, 0x7a, 0x07, 0xb0, 0xa1, 0x12,
+	0xba, 0x3a, 0xb4, 0xbf, 0x74, 0xab, 0x5b, 0x66, 0x66, 0x78, 0x5f, 0xc0, 0x7a, 0x4a, 0x2c, 0xc0,
+	0x6f, 0xdf, 0xba, 0x44, 0x73, 0x82, 0x3c, 0x6f, 0x64, 0x15, 0x18, 0x2d, 0x78, 0x3f, 0x35, 0xb6,
+	0xa9, 0x33, 0x3f, 0x27, 0x63, 0x5d, 0x5a, 0x3a, 0xe1, 0x0c, 0xea, 0x7d, 0x02, 0xcb, 0xa8, 0xc3,
+	0x7f, 0x55, 0x85, 0xff, 0x03, 0x3b, 0x0c, 0xa5, 0xaf, 0xfa, 0x76, 0xb0, 0x1b, 0xdd, 0xbb, 0xd7,
+	0x9a, 0xdd, 0x13, 0xe8, 0x5a, 0x0b, 0xcc, 0xc9, 0xdc, 0x05, 0x69, 0x20, 0xb7, 0xc6, 0x58, 0x4d,
+	0xb5, 0xd3, 0xe0, 0xcf, 0x0e, 0x74, 0xad, 0xa1, 0x39, 0x57, 0xe0, 0xbc, 0xff, 0x15, 0xb4, 0xde,
+	0xf9, 0x0a, 0x5c, 0xeb, 0x0a, 0x82, 0xa7, 0xb0, 0x76, 0x4a, 0xe3, 0x67, 0xc9, 0x25, 0x46, 0xd3,
+	0x28, 0x45, 0xef, 0x33, 0xe8, 0x0a, 0x46, 0x32, 0x9e, 0x28, 0x1e, 0x35, 0x74, 0xf3, 0x03, 0x73,
+	0xde, 0x53, 0x1a, 0x9f, 0x8e, 0x08, 0xc7, 0xf3, 0x4a, 0x23, 0xb4, 0xb5, 0x83, 0x7f, 0xb8, 0xe0,
+	0xdd, 0xd4, 0x91, 0x59, 0xde, 0x4c, 0x4a, 0xd7, 0x4e, 0xbc, 0x6d, 0x68, 0xe7, 0x72, 0x82, 0x89,
+	0x67, 0x2d, 0x78, 0x2f, 0x61, 0xe3, 0x9a, 0x24, 0x22, 0xc9, 0x86, 0x9a, 0x5a, 0xb9, 0x62, 0x80,
+	0xee, 0xfe, 0x2f, 0x16, 0x9a, 0xd2, 0x7f, 0xd5, 0xd0, 0x37, 0xc4, 0xd7, 0x5c, 0x44, 0xe6, 0x89,
+	0xa9, 0x24, 0xa6, 0xb0, 0x94, 0xa2, 0x17, 0xc3, 0x7d, 0xcc, 0x47, 0x38, 0x46, 0x46, 0xd2, 0x43,
+	0x9a, 0x09, 0x92, 0x64, 0xc8, 0x74, 0x65, 0xe9, 0xee, 0xef, 0x2f, 0xde, 0xf5, 0xf8, 0xe6, 0x24,
+	0xbd, 0xf5, 0xbc, 0xe5, 0x24, 0xa5, 0x65, 0x34, 0x46, 0x15, 0xeb, 0xcb, 0xea, 0xbc, 0x95, 0xbc,
+	0x3b, 0x80, 0xfb, 0x73, 0x8e, 0x70, 0x5b, 0x1d, 0xe9, 0x58, 0x91, 0xb7, 0xfb, 0x18, 0xfc, 0x45,
+	0xf6, 0xbc, 0xcd, 0x3a, 0xc1, 0x35, 0x6c, 0x3c, 0xa7, 0x31, 0x1e, 0xd2, 0x2c, 0xd6, 0x57, 0xeb,
+	0x7d, 0x31, 0x2f, 0x2e, 0x3e, 0x34, 0x6e, 0x69, 0xe8, 0x2e, 0x08, 0x0e, 0xef, 0x27, 0xb0, 0x94,
+	0x53, 0x9a, 0x9a, 0xc0, 0xdd, 0xb4, 0xa6, 0x9e, 0x52, 0x9a, 0x86, 0x6a, 0x30, 0xf8, 0xbb, 0x03,
+	0xdf, 0x5f, 0xb0, 0xda, 0x2d, 0x61, 0x34, 0x8f, 0x15, 0x77, 0x60, 0x99, 0x0b, 0x22, 0x0a, 0x6e,
+	0x48, 0xd1, 0x48, 0x16, 0xb3, 0x2e, 0x35, 0x98, 0xd5, 0x62, 0xd1, 0x76, 0x93, 0x45, 0xfb, 0xe0,
+	0xa9, 0x6c, 0xaa, 0xac, 0x39, 0x4f, 0xcc, 0x0d, 0xba, 0xe1, 0x9c, 0x91, 0xe0, 0x0f, 0x0e, 0x74,
+	0xbe, 0xbc, 0xce, 0x90, 0x1d, 0xc7, 0x43, 0x94, 0x96, 0x53, 0x29, 0x3c, 0x95, 0x05, 0x46, 0x5f,
+	0x40, 0x0d, 0x54, 0xa3, 0x2a, 0x28, 0x5a, 0xd6, 0xa8, 0x04, 0xe4, 0x68, 0x34, 0x4a, 0xd2, 0x58,
+	0x8d, 0xea, 0x63, 0xd4, 0x80, 0xf7, 0x10, 0x3a, 0x49, 0x26, 0x90, 0x4d, 0x48, 0xca, 0xfd, 0x25,
+	0x75, 0x29, 0xbe, 0xf1, 0x6c, 0xb5, 0xfd, 0x13, 0xa3, 0x10, 0xd6, 0xaa, 0xc1, 0x2b, 0xb8, 0x77,
+	0x63, 0x5c, 0xc6, 0x03, 0x17, 0x84, 0x09, 0xe3, 0x5c, 0x2d, 0xc8, 0xb8, 0x41, 0x53, 0x17, 0xdd,
+	0x50, 0x7e, 0xca, 0x20, 0xae, 0xda, 0x42, 0x57, 0xc1, 0x95, 0x1c, 0xfc, 0xd3, 0x01, 0x38, 0x42,
+	0x12, 0x3f, 0x43, 0x21, 0x90, 0x79, 0x8f, 0xa0, 0x7b, 0x5d, 0x57, 0x49, 0xc3, 0x7b, 0x3b, 0xf3,
+	0x6b, 0x68, 0x68, 0xab, 0x7a, 0x47, 0xd0, 0xe5, 0x82, 0x0c, 0xf1, 0x58, 0x56, 0x46, 0xae, 0x9a,
+	0x83, 0xee, 0x7e, 0x60, 0x66, 0xd6, 0x3b, 0xf4, 0xcf, 0x6a, 0x25, 0x9d, 0x77, 0xf6, 0xb4, 0xdd,
+	0xcf, 0x61, 0x6b, 0x56, 0xe1, 0xad, 0x12, 0xe1, 0x39, 0x6c, 0x9e, 0x21, 0x9b, 0x24, 0x11, 0x1e,
+	0x90, 0xe8, 0x0a, 0xb3, 0x98, 0x7b, 0x9f, 0x41, 0x87, 0x67, 0x24, 0xe7, 0x23, 0x5a, 0xb5, 0x63,
+	0x3f, 0x32, 0x66, 0x35, 0x55, 0xcf, 0x8c, 0x56, 0x58, 0xeb, 0x07, 0xbf, 0x73, 0x60, 0x67, 0xbe,
+	0xd6, 0x2d, 0xe1, 0xfd, 0x4b, 0x58, 0xbd, 0x30, 0x16, 0x18, 0x5f, 0x7c, 0x30, 0x77, 0xd3, 0xb0,
+	0x52, 0xb3, 0xb9, 0xce, 0x6d, 0x70, 0x5d, 0xf0, 0xb5, 0x03, 0x1b, 0xcd, 0x69, 0xde, 0x06, 0xb4,
+	0x92, 0xdc, 0xf8, 0xa4, 0x95, 0x28, 0x56, 0x66, 0x48, 0xe2, 0xa9, 0x72, 0xc9, 0x6a, 0xa8, 0x05,
+	0xd9, 0xcf, 0x09, 0xc2, 0x86, 0x28, 0x54, 0x24, 0xeb, 0x68, 0xb4, 0x90, 0x7a, 0x5c, 0x45, 0xeb,
+	0x92, 0x3d, 0x2e, 0x91, 0x06, 0xfd, 0xb5, 0x9b, 0xf4, 0x17, 0x5c, 0xc0, 0xda, 0x61, 0xc1, 0x18,
+	0x66, 0x42, 0x3f, 0xa8, 0xde, 0xbd, 0x95, 0xb3, 0xda, 0xae, 0x56, 0xe3, 0x59, 0x1c, 0xfc, 0xcf,
+	0x81, 0xad, 0x27, 0xd9, 0x10, 0xb9, 0x18, 0x64, 0x19, 0x15, 0xea, 0xb1, 0x50, 0x31, 0x87, 0x63,
+	0x31, 0xc7, 0xbc, 0x6e, 0xf0, 0x01, 0x74, 0x32, 0x32, 0x46, 0x9e, 0x93, 0xa8, 0xca, 0xc4, 0x0a,
+	0xb0, 0xb9, 0x63, 0xa9, 0xc9, 0x1d, 0x55, 0xe1, 0x6d, 0xeb, 0xb4, 0x52, 0x42, 0xf3, 0x55, 0xb6,
+	0xfc, 0xae, 0xaf, 0xb2, 0x95, 0xbb, 0xbf, 0xca, 0x82, 0xff, 0xb6, 0x60, 0xeb, 0x45, 0x81, 0x6c,
+	0x3a, 0x28, 0xe2, 0x44, 0x84, 0x18, 0x51, 0x16, 0xcb, 0x64, 0xe0, 0xf8, 0x5a, 0x9d, 0x7d, 0x29,
+	0x94, 0x9f, 0x4d, 0xbf, 0xb7, 0xde, 0xb2, 0x85, 0x2e, 0x38, 0x32, 0xe3, 0x1b, 0xf5, 0x2d, 0xa9,
+	0x56, 0x60, 0x46, 0x32, 0x51, 0x52, 0xad, 0x96, 0xa4, 0x6e, 0x4e, 0xc4, 0xc8, 0x44, 0x81, 0xfa,
+	0x96, 0x8e, 0x7a, 0x2d, 0xed, 0x33, 0x95, 0x51, 0x0b, 0x72, 0x85, 0x9c, 0x30, 0x32, 0xe6, 0xa6,
+	0x39, 0x34, 0x92, 0x6c, 0x1e, 0xe3, 0x82, 0xa9, 0x2b, 0x6c, 0xfc, 0x73, 0x98, 0x41, 0xe5, 0xd3,
+	0x9f, 0x29, 0x4a, 0x39, 0x98, 0x0a, 0xe4, 0xaa, 0x05, 0x74, 0x43, 0x1b, 0xb2, 0xca, 0x04, 0xa8,
+	0xd6, 0xc8, 0x48, 0xf2, 0xc2, 0x19, 0xbe, 0x2e, 0x90, 0x8b, 0x27, 0xe5, 0x4f, 0x85, 0x1a, 0x90,
+	0xb1, 0xce, 0x70, 0x4c, 0x05, 0x0e, 0xe2, 0x98, 0x99, 0x3f, 0x0a, 0x16, 0x12, 0x7c, 0xdd, 0x82,
+	0x0d, 0xe9, 0xe4, 0x09, 0xb2, 0x69, 0x88, 0x39, 0x65, 0xef, 0xf3, 0xf7, 0x48, 0xd6, 0x88, 0x1c,
+	0x33, 0x45, 0x63, 0x55, 0x8d, 0x28, 0x01, 0xe9, 0x0a, 0xc1, 0x8a, 0x2c, 0x22, 0x02, 0x63, 0x7d,
+	0x4a, 0x4d, 0xcb, 0x33, 0xa8, 0x74, 0xc5, 0x15, 0x4e, 0xf9, 0xe1, 0x08, 0xa3, 0x2b, 0xd3, 0x01,
+	0xb9, 0xa1, 0x0d, 0xc9, 0x04, 0x95, 0xe2, 0x33, 0xca, 0xcb, 0x70, 0xad, 0x64, 0xb9, 0x4b, 0x4a,
+	0xb9, 0x38, 0x25, 0x4c, 0x98, 0x2e, 0x60, 0x59, 0x3d, 0xbb, 0x67, 0x50, 0x55, 0x1e, 0x28, 0x17,
+	0x4f, 0x71, 0x2a, 0xaf, 0x4c, 0x6a, 0x54, 0x72, 0xf0, 0x37, 0x07, 0xee, 0x55, 0xaa, 0x6a, 0x53,
+	0x5e, 0x8c, 0xe5, 0xe9, 0xf2, 0x12, 0x2c, 0xeb, 0x63, 0x05, 0xc8, 0xb0, 0x10, 0xe4, 0x22, 0xad,
+	0xd8, 0x59, 0x09, 0x32, 0x0b, 0x22, 0x3a, 0xce, 0x8b, 0x92, 0xde, 0x6e, 0xc9, 0x82, 0x52, 0x57,
+	0x65, 0xb6, 0xb4, 0x4c, 0x1f, 0x5e, 0x7d, 0xcb, 0x1d, 0x2e, 0x94, 0xdb, 0x4c, 0x86, 0x5e, 0x54,
+	0x61, 0x31, 0x22, 0xfb, 0xbf, 0x7e, 0x68, 0xe2, 0xd1, 0x48, 0xc1, 0x37, 0x0e, 0xac, 0xab, 0x3c,
+	0x3a, 0x20, 0x1c, 0xd3, 0x24, 0x53, 0x6c, 0x21, 0x89, 0xa0, 0x64, 0x90, 0x4c, 0xbf, 0x59, 0x56,
+	0xf4, 0x5f, 0x8d, 0xf8, 0x0e, 0x49, 0x54, 0xaa, 0xd6, 0x29, 0xe0, 0xce, 0xa4, 0x80, 0x7e, 0xe7,
+	0x97, 0x49, 0xa4, 0x25, 0xb9, 0x2f, 0xa3, 0xd7, 0xbc, 0x4c, 0x22, 0xf9, 0xad, 0xbc, 0x45, 0x05,
+	0x49, 0x4d, 0x73, 0xa2, 0x85, 0xe0, 0x3f, 0x2d, 0x58, 0x3f, 0xc3, 0xf4, 0xf2, 0x31, 0xa5, 0x22,
+	0x67, 0x49, 0xf6, 0x3e, 0xb1, 0xb8, 0x0b, 0xab, 0x8c, 0x73, 0x1d, 0x67, 0xba, 0x2b, 0xa8, 0x64,
+	0x79, 0x93, 0x23, 0x24, 0xb9, 0x1d, 0x84, 0x35, 0x20, 0x53, 0x66, 0x48, 0x19, 0x2d, 0xe4, 0x93,
+	0xbd, 0xbc, 0x01, 0x0b, 0x51, 0x91, 0xc3, 0xc7, 0x07, 0xd6, 0x55, 0x54, 0xb2, 0x5c, 0x79, 0x92,
+	0xd2, 0xa1, 0x1e, 0xd4, 0x67, 0xab, 0x01, 0xf9, 0xd6, 0x53, 0xcd, 0xc3, 0x8b, 0x02, 0x0b, 0x3c,
+	0xc2, 0x5c, 0x8c, 0x14, 0x5b, 0xb8, 0xe1, 0x2c, 0x2c, 0x3b, 0xb9, 0x1a, 0x3a, 0x24, 0x39, 0x89,
+	0x12, 0x31, 0x35, 0xd4, 0x31, 0x67, 0xc4, 0xdb, 0x87, 0x6d, 0x52, 0xd5, 0x0a, 0x6b, 0x79, 0xcd,
+	0x23, 0x73, 0xc7, 0x82, 0x3f, 0x3a, 0xb0, 0x33, 0xc8, 0x13, 0x53, 0x62, 0x07, 0x13, 0x92, 0xa4,
+	0xe4, 0x22, 0x49, 0xe5, 0x72, 0xdb, 0xd0, 0x1e, 0x32, 0x5a, 0x94, 0xa5, 0x56, 0x0b, 0xb2, 0x78,
+	0x98, 0x7f, 0x91, 0x65, 0xc5, 0x32, 0xa2, 0x1c, 0xe1, 0x7a, 0x99, 0xf2, 0x61, 0x6f, 0x44, 0xef,
+	0x37, 0xcd, 0x8e, 0x5c, 0x37, 0x7f, 0x3f, 0x34, 0x4d, 0x41, 0xbd, 0xfb, 0xa2, 0xb7, 0xda, 0x5f,
+	0x1c, 0xd8, 0x9e, 0xa7, 0x75, 0x4b, 0x1f, 0x52, 0x73, 0x65, 0x6b, 0x41, 0x4b, 0xed, 0x2e, 0x6a,
+	0xa9, 0x97, 0xee, 0xd2, 0x52, 0xb7, 0x17, 0xb6, 0xd4, 0x7f, 0x75, 0x60, 0xb3, 0xa2, 0x8e, 0xc7,
+	0x0c, 0xf1, 0xcd, 0xfc, 0xc4, 0xfb, 0x08, 0xd6, 0x2f, 0x19, 0x1d, 0x57, 0xaa, 0xc6, 0xd0, 0x26,
+	0x28, 0xa9, 0x50, 0xd0, 0x5a, 0x47, 0x1b, 0x6d, 0x43, 0x0b, 0x1f, 0x09, 0x56, 0x62, 0xb7, 0xef,
+	0x9c, 0xd8, 0xb2, 0xe5, 0x5a, 0x2d, 0xdf, 0x3a, 0xd2, 0xec, 0x37, 0x34, 0xab, 0xcc, 0x96, 0xdf,
+	0x7a, 0xbb, 0x61, 0x6d, 0xaf, 0x91, 0xe4, 0xff, 0xd0, 0x24, 0xe3, 0x82, 0x64, 0x11, 0x56, 0xbf,
+	0xa1, 0x3a, 0x61, 0x03, 0x93, 0xeb, 0x11, 0x16, 0x8d, 0x8c, 0xa1, 0xea, 0x5b, 0x36, 0x74, 0xb4,
+	0x64, 0x86, 0x16, 0xe5, 0x52, 0x47, 0x3d, 0xbf, 0x34, 0x97, 0xa9, 0xef, 0xe0, 0xdf, 0x0e, 0xdc,
+	0x0b, 0x51, 0x60, 0x26, 0x0f, 0xfc, 0xe5, 0x04, 0x19, 0x4b, 0x62, 0x6c, 0xf6, 0x39, 0xce, 0x6c,
+	0x9f, 0x33, 0xaf, 0x33, 0xfa, 0x04, 0x56, 0xf0, 0xb7, 0x79, 0xc2, 0x4c, 0xce, 0xdf, 0xe2, 0x12,
+	0xa3, 0xba, 0xd0, 0xc1, 0xf2, 0xc5, 0xa3, 0xbd, 0x76, 0x30, 0x35, 0x07, 0xa8, 0x01, 0xdb, 0xfd,
+	0xcb, 0x77, 0x76, 0xff, 0xc5, 0xb2, 0x1a, 0xfc, 0xd5, 0xff, 0x07, 0x00, 0x55, 0x6f, 0x50, 0xca,
+	0x0a, 0x1a, 0x00, 0x00,
 }
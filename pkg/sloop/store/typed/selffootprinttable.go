@@ -0,0 +1,89 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"fmt"
+	"time"
+
+	badger "github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+const selfFootprintTableName = "selffootprint"
+
+// Registered so partition GC drops the samples along with the cluster history of the same time
+func init() {
+	RegisterTableName(selfFootprintTableName)
+}
+
+/*
+Samples of the resources the sloop process uses, taken every few minutes:
+
+	/selffootprint/<partition>/<unix nanos>
+
+Nanos are zero padded so keys sort by time.  Values are not run through the payload codecs
+*/
+type SelfFootprintTable struct {
+	tableName string
+}
+
+func OpenSelfFootprintTable() *SelfFootprintTable {
+	return &SelfFootprintTable{tableName: selfFootprintTableName}
+}
+
+func (t *SelfFootprintTable) TableName() string {
+	return t.tableName
+}
+
+func (t *SelfFootprintTable) Key(timestamp time.Time) string {
+	return fmt.Sprintf("/%v/%v/%019d", t.tableName, untyped.GetPartitionId(timestamp), timestamp.UnixNano())
+}
+
+func (t *SelfFootprintTable) Set(txn badgerwrap.Txn, timestamp time.Time, value *SelfFootprint) error {
+	outb, err := proto.Marshal(value)
+	if err != nil {
+		return errors.Wrapf(err, "protobuf marshal for table %v failed", t.tableName)
+	}
+	err = txn.Set([]byte(t.Key(timestamp)), outb)
+	if err != nil {
+		return errors.Wrapf(err, "set for table %v failed", t.tableName)
+	}
+	return nil
+}
+
+// Returns the samples taken in [startTime, endTime], oldest first
+func (t *SelfFootprintTable) RangeRead(txn badgerwrap.Txn, startTime time.Time, endTime time.Time) ([]*SelfFootprint, error) {
+	samples := []*SelfFootprint{}
+	prefix := []byte("/" + t.tableName + "/")
+	endKey := t.Key(endTime)
+	iterOpt := badger.DefaultIteratorOptions
+	iterOpt.Prefix = prefix
+	itr := txn.NewIterator(iterOpt)
+	defer itr.Close()
+	for itr.Seek([]byte(t.Key(startTime))); itr.ValidForPrefix(prefix); itr.Next() {
+		if string(itr.Item().Key()) > endKey {
+			break
+		}
+		valueBytes, err := itr.Item().ValueCopy([]byte{})
+		if err != nil {
+			return nil, errors.Wrapf(err, "value copy failed for table %v", t.tableName)
+		}
+		sample := &SelfFootprint{}
+		err = proto.Unmarshal(valueBytes, sample)
+		if err != nil {
+			return nil, errors.Wrapf(err, "protobuf unmarshal failed for table %v on value length %v", t.tableName, len(valueBytes))
+		}
+		samples = append(samples, sample)
+	}
+	return samples, nil
+}
@@ -0,0 +1,50 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+func Test_SelfFootprintTable_RangeRead(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	table := OpenSelfFootprintTable()
+	start := time.Date(2021, 3, 4, 5, 50, 0, 0, time.UTC)
+
+	// Across a partition boundary
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		for i := 0; i < 4; i++ {
+			assert.Nil(t, table.Set(txn, start.Add(time.Duration(i)*5*time.Minute), &SelfFootprint{Goroutines: int64(i)}))
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+
+	var samples []*SelfFootprint
+	err = db.View(func(txn badgerwrap.Txn) error {
+		samples, err = table.RangeRead(txn, start.Add(5*time.Minute), start.Add(10*time.Minute))
+		return err
+	})
+	assert.Nil(t, err)
+	assert.Len(t, samples, 2)
+	assert.Equal(t, int64(1), samples[0].Goroutines)
+	assert.Equal(t, int64(2), samples[1].Goroutines)
+
+	assert.True(t, strings.HasPrefix(table.Key(start), "/selffootprint/"+untyped.GetPartitionId(start)+"/"))
+	assert.Contains(t, NewTableList(db).GetTableNames(), "selffootprint")
+}
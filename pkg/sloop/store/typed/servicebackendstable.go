@@ -0,0 +1,95 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"fmt"
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/proto"
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+// Key is /<partition>/<namespace>/<service>/<source kind>/<source name>
+//
+// Source kind is EndpointSlice or Endpoint, and a service can have several EndpointSlices.  All the sources of a
+// service share a prefix, which String() returns when the source kind is empty
+
+type ServiceBackendsKey struct {
+	PartitionId string
+	Namespace   string
+	Service     string
+	SourceKind  string
+	SourceName  string
+}
+
+func NewServiceBackendsKey(partitionId string, namespace string, service string, sourceKind string, sourceName string) *ServiceBackendsKey {
+	return &ServiceBackendsKey{PartitionId: partitionId, Namespace: namespace, Service: service, SourceKind: sourceKind, SourceName: sourceName}
+}
+
+func (*ServiceBackendsKey) TableName() string {
+	return "servicebackends"
+}
+
+func (k *ServiceBackendsKey) Parse(key string) error {
+	err, parts := common.ParseKey(key)
+	if err != nil {
+		return err
+	}
+
+	if parts[1] != k.TableName() {
+		return fmt.Errorf("Second part of key (%v) should be %v", key, k.TableName())
+	}
+	k.PartitionId = parts[2]
+	k.Namespace = parts[3]
+	k.Service = parts[4]
+	k.SourceKind = parts[5]
+	k.SourceName = parts[6]
+	return nil
+}
+
+func (k *ServiceBackendsKey) String() string {
+	if k.SourceKind == "" {
+		return fmt.Sprintf("/%v/%v/%v/%v/", k.TableName(), k.PartitionId, k.Namespace, k.Service)
+	}
+	return fmt.Sprintf("/%v/%v/%v/%v/%v/%v", k.TableName(), k.PartitionId, k.Namespace, k.Service, k.SourceKind, k.SourceName)
+}
+
+func (*ServiceBackendsKey) ValidateKey(key string) error {
+	newKey := ServiceBackendsKey{}
+	return newKey.Parse(key)
+}
+
+func (k *ServiceBackendsKey) SetPartitionId(newPartitionId string) {
+	k.PartitionId = newPartitionId
+}
+
+func (t *ServiceBackendsTable) GetOrDefault(txn badgerwrap.Txn, key string) (*ServiceBackends, error) {
+	rec, err := t.Get(txn, key)
+	if err != nil {
+		if err != badger.ErrKeyNotFound {
+			return nil, err
+		} else {
+			return &ServiceBackends{}, nil
+		}
+	}
+	return rec, nil
+}
+
+// True when both snapshots have the same backends and deleted flag, ignoring the timestamp
+func SameServiceBackends(a *ServiceBackendSnapshot, b *ServiceBackendSnapshot) bool {
+	if a.Deleted != b.Deleted || len(a.Backends) != len(b.Backends) {
+		return false
+	}
+	for idx := range a.Backends {
+		if !proto.Equal(a.Backends[idx], b.Backends[idx]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,76 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+const someService = "someservice"
+
+func Test_ServiceBackendsKey_OutputCorrect(t *testing.T) {
+	k := NewServiceBackendsKey("001562961600", someNamespace, someService, "EndpointSlice", "someservice-abcde")
+	assert.Equal(t, "/servicebackends/001562961600/somenamespace/someservice/EndpointSlice/someservice-abcde", k.String())
+}
+
+func Test_ServiceBackendsKey_PrefixOutputCorrect(t *testing.T) {
+	k := NewServiceBackendsKey("001562961600", someNamespace, someService, "", "")
+	assert.Equal(t, "/servicebackends/001562961600/somenamespace/someservice/", k.String())
+}
+
+func Test_ServiceBackendsKey_ParseCorrect(t *testing.T) {
+	k := &ServiceBackendsKey{}
+	err := k.Parse("/servicebackends/001562961600/somenamespace/someservice/Endpoint/someservice")
+	assert.Nil(t, err)
+	assert.Equal(t, "001562961600", k.PartitionId)
+	assert.Equal(t, someNamespace, k.Namespace)
+	assert.Equal(t, someService, k.Service)
+	assert.Equal(t, "Endpoint", k.SourceKind)
+	assert.Equal(t, someService, k.SourceName)
+}
+
+func Test_ServiceBackendsKey_ValidateWorks(t *testing.T) {
+	assert.Nil(t, (&ServiceBackendsKey{}).ValidateKey("/servicebackends/001562961600/somenamespace/someservice/Endpoint/someservice"))
+	assert.NotNil(t, (&ServiceBackendsKey{}).ValidateKey("/podlifecycle/001562961600/somenamespace/someservice/Endpoint/someservice"))
+}
+
+func Test_SameServiceBackends(t *testing.T) {
+	a := &ServiceBackendSnapshot{Timestamp: 1, Backends: []*ServiceBackend{{Ip: "10.0.0.1", Ready: true}}}
+	b := &ServiceBackendSnapshot{Timestamp: 2, Backends: []*ServiceBackend{{Ip: "10.0.0.1", Ready: true}}}
+	assert.True(t, SameServiceBackends(a, b))
+	b.Backends[0].Ready = false
+	assert.False(t, SameServiceBackends(a, b))
+	assert.False(t, SameServiceBackends(a, &ServiceBackendSnapshot{Timestamp: 2}))
+}
+
+func (*ServiceBackendsKey) GetTestKey() string {
+	k := NewServiceBackendsKey(someMinPartition, someNamespace, someService, "EndpointSlice", someName)
+	return k.String()
+}
+
+func (*ServiceBackendsKey) GetTestValue() *ServiceBackends {
+	return &ServiceBackends{}
+}
+
+func (*ServiceBackendsKey) SetTestKeys() []string {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	var keys []string
+	for curTime := someTs; !curTime.After(someMaxTs); curTime = curTime.Add(untyped.GetPartitionDuration()) {
+		partitionId := untyped.GetPartitionId(curTime)
+		keys = append(keys, NewServiceBackendsKey(partitionId, someNamespace, someService, "EndpointSlice", someName).String())
+		keys = append(keys, NewServiceBackendsKey(partitionId, someNamespace, someService, "EndpointSlice", someName+"b").String())
+	}
+	return keys
+}
+
+func (*ServiceBackendsKey) SetTestValue() *ServiceBackends {
+	return &ServiceBackends{Snapshots: []*ServiceBackendSnapshot{{Backends: []*ServiceBackend{{Ip: "10.0.0.1"}}}}}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/golang/glog"
 	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
 	"sort"
+	"sync"
 )
 
 type Tables interface {
@@ -18,6 +19,17 @@ type Tables interface {
 	EventCountTable() *ResourceEventCountsTable
 	WatchTable() *KubeWatchResultTable
 	WatchActivityTable() *WatchActivityTable
+	QuarantineTable() *QuarantinedPayloadTable
+	EventFoldTable() *EventFoldTable
+	PodLifecycleTable() *PodLifecycleTable
+	NodeConditionTable() *NodeConditionsTable
+	OwnerGraphTable() *OwnerEdgeTable
+	DeadLetterTable() *DeadLetterTable
+	ServiceBackendsTable() *ServiceBackendsTable
+	CurrentStateTable() *CurrentStateTable
+	IngestAnnotationTable() *IngestAnnotationTable
+	// Returns nil if no table with this name was registered with RegisterTable
+	ExtraTable(tableName string) *ProtoTable
 	Db() badgerwrap.DB
 	GetMinAndMaxPartition() (bool, string, string, error)
 	GetTableNames() []string
@@ -25,16 +37,43 @@ type Tables interface {
 	GetMinAndMaxPartitionWithTxn(badgerwrap.Txn) (bool, string, string)
 }
 
+// Tables written by code outside this package, such as processing plugins.  They are included in GetTableNames so
+// partition GC removes their keys along with the core tables.
+var (
+	extraTableNamesLock = &sync.Mutex{}
+	extraTableNames     []string
+)
+
+func RegisterTableName(tableName string) {
+	extraTableNamesLock.Lock()
+	defer extraTableNamesLock.Unlock()
+	for _, existing := range extraTableNames {
+		if existing == tableName {
+			return
+		}
+	}
+	extraTableNames = append(extraTableNames, tableName)
+}
+
 type MinMaxPartitionsGetter interface {
 	GetMinMaxPartitions(badgerwrap.Txn) (bool, string, string)
 }
 
 type tablesImpl struct {
-	resourceSummaryTable *ResourceSummaryTable
-	eventCountTable      *ResourceEventCountsTable
-	watchTable           *KubeWatchResultTable
-	watchActivityTable   *WatchActivityTable
-	db                   badgerwrap.DB
+	resourceSummaryTable  *ResourceSummaryTable
+	eventCountTable       *ResourceEventCountsTable
+	watchTable            *KubeWatchResultTable
+	watchActivityTable    *WatchActivityTable
+	quarantineTable       *QuarantinedPayloadTable
+	eventFoldTable        *EventFoldTable
+	podLifecycleTable     *PodLifecycleTable
+	nodeConditionTable    *NodeConditionsTable
+	ownerGraphTable       *OwnerEdgeTable
+	deadLetterTable       *DeadLetterTable
+	serviceBackendsTable  *ServiceBackendsTable
+	currentStateTable     *CurrentStateTable
+	ingestAnnotationTable *IngestAnnotationTable
+	db                    badgerwrap.DB
 }
 
 func NewTableList(db badgerwrap.DB) Tables {
@@ -43,6 +82,15 @@ func NewTableList(db badgerwrap.DB) Tables {
 	t.eventCountTable = OpenResourceEventCountsTable()
 	t.watchTable = OpenKubeWatchResultTable()
 	t.watchActivityTable = OpenWatchActivityTable()
+	t.quarantineTable = OpenQuarantinedPayloadTable()
+	t.eventFoldTable = OpenEventFoldTable()
+	t.podLifecycleTable = OpenPodLifecycleTable()
+	t.nodeConditionTable = OpenNodeConditionsTable()
+	t.ownerGraphTable = OpenOwnerEdgeTable()
+	t.deadLetterTable = OpenDeadLetterTable()
+	t.serviceBackendsTable = OpenServiceBackendsTable()
+	t.currentStateTable = OpenCurrentStateTable()
+	t.ingestAnnotationTable = OpenIngestAnnotationTable()
 	t.db = db
 	return t
 }
@@ -63,6 +111,46 @@ func (t *tablesImpl) WatchActivityTable() *WatchActivityTable {
 	return t.watchActivityTable
 }
 
+func (t *tablesImpl) QuarantineTable() *QuarantinedPayloadTable {
+	return t.quarantineTable
+}
+
+func (t *tablesImpl) EventFoldTable() *EventFoldTable {
+	return t.eventFoldTable
+}
+
+func (t *tablesImpl) PodLifecycleTable() *PodLifecycleTable {
+	return t.podLifecycleTable
+}
+
+func (t *tablesImpl) NodeConditionTable() *NodeConditionsTable {
+	return t.nodeConditionTable
+}
+
+func (t *tablesImpl) OwnerGraphTable() *OwnerEdgeTable {
+	return t.ownerGraphTable
+}
+
+func (t *tablesImpl) DeadLetterTable() *DeadLetterTable {
+	return t.deadLetterTable
+}
+
+func (t *tablesImpl) ServiceBackendsTable() *ServiceBackendsTable {
+	return t.serviceBackendsTable
+}
+
+func (t *tablesImpl) CurrentStateTable() *CurrentStateTable {
+	return t.currentStateTable
+}
+
+func (t *tablesImpl) IngestAnnotationTable() *IngestAnnotationTable {
+	return t.ingestAnnotationTable
+}
+
+func (t *tablesImpl) ExtraTable(tableName string) *ProtoTable {
+	return getRegisteredTable(tableName)
+}
+
 func (t *tablesImpl) Db() badgerwrap.DB {
 	return t.db
 }
@@ -104,12 +192,22 @@ func (t *tablesImpl) GetMinAndMaxPartitionWithTxn(txn badgerwrap.Txn) (bool, str
 	return true, allPartitions[0], allPartitions[len(allPartitions)-1]
 }
 
+func (t *tablesImpl) coreTableNames() []string {
+	return []string{t.watchTable.tableName, t.resourceSummaryTable.tableName, t.eventCountTable.tableName, t.watchActivityTable.tableName, t.quarantineTable.tableName, t.eventFoldTable.tableName, t.podLifecycleTable.tableName, t.nodeConditionTable.tableName, t.ownerGraphTable.tableName, t.deadLetterTable.tableName, t.serviceBackendsTable.tableName, t.currentStateTable.tableName, t.ingestAnnotationTable.tableName}
+}
+
 func (t *tablesImpl) GetTableNames() []string {
-	return []string{t.watchTable.tableName, t.resourceSummaryTable.tableName, t.eventCountTable.tableName, t.watchActivityTable.tableName}
+	names := t.coreTableNames()
+	extraTableNamesLock.Lock()
+	defer extraTableNamesLock.Unlock()
+	return append(names, extraTableNames...)
 }
 
 func (t *tablesImpl) GetTables() []interface{} {
 	intfs := new([]interface{})
-	*intfs = append(*intfs, t.eventCountTable, t.resourceSummaryTable, t.watchTable, t.watchActivityTable)
+	*intfs = append(*intfs, t.eventCountTable, t.resourceSummaryTable, t.watchTable, t.watchActivityTable, t.quarantineTable, t.eventFoldTable, t.podLifecycleTable, t.nodeConditionTable, t.ownerGraphTable, t.deadLetterTable, t.serviceBackendsTable, t.currentStateTable, t.ingestAnnotationTable)
+	for _, table := range RegisteredTables() {
+		*intfs = append(*intfs, table)
+	}
 	return *intfs
 }
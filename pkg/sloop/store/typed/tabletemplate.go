@@ -22,6 +22,14 @@ import (
 //go:generate genny -in=$GOFILE -out=resourcesummarytablegen.go gen "ValueType=ResourceSummary KeyType=ResourceSummaryKey"
 //go:generate genny -in=$GOFILE -out=eventcounttablegen.go gen "ValueType=ResourceEventCounts KeyType=EventCountKey"
 //go:generate genny -in=$GOFILE -out=watchactivitytablegen.go gen "ValueType=WatchActivity KeyType=WatchActivityKey"
+//go:generate genny -in=$GOFILE -out=quarantinetablegen.go gen "ValueType=QuarantinedPayload KeyType=QuarantineKey"
+//go:generate genny -in=$GOFILE -out=eventfoldtablegen.go gen "ValueType=EventFold KeyType=EventFoldKey"
+//go:generate genny -in=$GOFILE -out=podlifecycletablegen.go gen "ValueType=PodLifecycle KeyType=PodLifecycleKey"
+//go:generate genny -in=$GOFILE -out=nodeconditiontablegen.go gen "ValueType=NodeConditions KeyType=NodeConditionKey"
+//go:generate genny -in=$GOFILE -out=ownergraphtablegen.go gen "ValueType=OwnerEdge KeyType=OwnerEdgeKey"
+//go:generate genny -in=$GOFILE -out=deadlettertablegen.go gen "ValueType=DeadLetter KeyType=DeadLetterKey"
+//go:generate genny -in=$GOFILE -out=servicebackendstablegen.go gen "ValueType=ServiceBackends KeyType=ServiceBackendsKey"
+//go:generate genny -in=$GOFILE -out=currentstatetablegen.go gen "ValueType=CurrentState KeyType=CurrentStateKey"
 
 type ValueTypeTable struct {
 	tableName string
@@ -43,6 +51,11 @@ func (t *ValueTypeTable) Set(txn badgerwrap.Txn, key string, value *ValueType) e
 		return errors.Wrapf(err, "protobuf marshal for table %v failed", t.tableName)
 	}
 
+	outb, err = encodeValue(txn, t.tableName, key, outb)
+	if err != nil {
+		return errors.Wrapf(err, "value encode for table %v failed", t.tableName)
+	}
+
 	err = txn.Set([]byte(key), outb)
 	if err != nil {
 		return errors.Wrapf(err, "set for table %v failed", t.tableName)
@@ -69,6 +82,11 @@ func (t *ValueTypeTable) Get(txn badgerwrap.Txn, key string) (*ValueType, error)
 		return nil, errors.Wrapf(err, "value copy failed for table %v", t.tableName)
 	}
 
+	valueBytes, err = decodeValue(txn, key, valueBytes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "value decode failed for table %v", t.tableName)
+	}
+
 	retValue := &ValueType{}
 	err = proto.Unmarshal(valueBytes, retValue)
 	if err != nil {
@@ -254,6 +272,10 @@ func (t *ValueTypeTable) RangeRead(txn badgerwrap.Txn, keyPrefix *KeyType,
 			if err != nil {
 				return nil, stats, err
 			}
+			valueBytes, err = decodeValue(txn, string(itr.Item().Key()), valueBytes)
+			if err != nil {
+				return nil, stats, err
+			}
 			retValue := &ValueType{}
 			err = proto.Unmarshal(valueBytes, retValue)
 			if err != nil {
@@ -275,6 +297,91 @@ func (t *ValueTypeTable) RangeRead(txn badgerwrap.Txn, keyPrefix *KeyType,
 	return resources, stats, nil
 }
 
+// One row of RangeReadReverse
+type ValueTypeRow struct {
+	Key   KeyType
+	Value *ValueType
+}
+
+// Same as RangeRead but walks partitions newest first and keys within each partition in descending order, and
+// returns the rows in that order.  Reading stops as soon as maxRows rows have passed both predicates, so asking for
+// the latest few versions of a resource does not scan the whole time range.  maxRows <= 0 means no limit.
+func (t *ValueTypeTable) RangeReadReverse(txn badgerwrap.Txn, keyPrefix *KeyType,
+	keyPredicateFn func(string) bool, valPredicateFn func(*ValueType) bool, startTime time.Time, endTime time.Time, maxRows int) ([]ValueTypeRow, RangeReadStats, error) {
+	var rows []ValueTypeRow
+
+	stats := RangeReadStats{}
+	before := time.Now()
+
+	partitionList, err := t.GetPartitionsFromTimeRange(txn, startTime, endTime)
+	stats.PartitionCount = len(partitionList)
+	if err != nil {
+		return rows, stats, errors.Wrapf(err, "failed to get partitions from table:%v, from startTime:%v, to endTime:%v", t.tableName, startTime, endTime)
+	}
+
+	for i := len(partitionList) - 1; i >= 0; i-- {
+		currentPartition := partitionList[i]
+		var seekStr string
+		if keyPrefix == nil {
+			seekStr = "/" + t.tableName + "/" + currentPartition + "/"
+		} else {
+			keyPrefix.SetPartitionId(currentPartition)
+			seekStr = keyPrefix.String()
+		}
+
+		itr := txn.NewIterator(badger.IteratorOptions{Prefix: []byte(seekStr), Reverse: true})
+		defer itr.Close()
+
+		// In reverse a seek lands on the largest key <= the seek key, so seek past the end of the prefix
+		for itr.Seek([]byte(seekStr + string(rune(255)))); itr.ValidForPrefix([]byte(seekStr)); itr.Next() {
+			stats.RowsVisitedCount += 1
+			if keyPredicateFn != nil {
+				if !keyPredicateFn(string(itr.Item().Key())) {
+					continue
+				}
+			}
+			key := KeyType{}
+			err := key.Parse(string(itr.Item().Key()))
+			if err != nil {
+				return nil, stats, err
+			}
+
+			stats.RowsPassedKeyPredicateCount += 1
+
+			valueBytes, err := itr.Item().ValueCopy([]byte{})
+			if err != nil {
+				return nil, stats, err
+			}
+			valueBytes, err = decodeValue(txn, string(itr.Item().Key()), valueBytes)
+			if err != nil {
+				return nil, stats, err
+			}
+			retValue := &ValueType{}
+			err = proto.Unmarshal(valueBytes, retValue)
+			if err != nil {
+				return nil, stats, err
+			}
+			if valPredicateFn != nil && !valPredicateFn(retValue) {
+				continue
+			}
+			stats.RowsPassedValuePredicateCount += 1
+			rows = append(rows, ValueTypeRow{Key: key, Value: retValue})
+			if maxRows > 0 && len(rows) >= maxRows {
+				itr.Close()
+				stats.Elapsed = time.Since(before)
+				stats.TableName = (&KeyType{}).TableName()
+				return rows, stats, nil
+			}
+		}
+
+		itr.Close()
+	}
+
+	stats.Elapsed = time.Since(before)
+	stats.TableName = (&KeyType{}).TableName()
+	return rows, stats, nil
+}
+
 //todo: need to add unit test
 func (t *ValueTypeTable) GetPartitionsFromTimeRange(txn badgerwrap.Txn, startTime time.Time, endTime time.Time) ([]string, error) {
 	resources := []string{}
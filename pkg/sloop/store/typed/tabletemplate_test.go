@@ -22,6 +22,14 @@ import (
 //go:generate genny -in=$GOFILE -out=resourcesummarytablegen_test.go gen "ValueType=ResourceSummary KeyType=ResourceSummaryKey"
 //go:generate genny -in=$GOFILE -out=eventcounttablegen_test.go gen "ValueType=ResourceEventCounts KeyType=EventCountKey"
 //go:generate genny -in=$GOFILE -out=watchactivitytablegen_test.go gen "ValueType=WatchActivity KeyType=WatchActivityKey"
+//go:generate genny -in=$GOFILE -out=quarantinetablegen_test.go gen "ValueType=QuarantinedPayload KeyType=QuarantineKey"
+//go:generate genny -in=$GOFILE -out=eventfoldtablegen_test.go gen "ValueType=EventFold KeyType=EventFoldKey"
+//go:generate genny -in=$GOFILE -out=podlifecycletablegen_test.go gen "ValueType=PodLifecycle KeyType=PodLifecycleKey"
+//go:generate genny -in=$GOFILE -out=nodeconditiontablegen_test.go gen "ValueType=NodeConditions KeyType=NodeConditionKey"
+//go:generate genny -in=$GOFILE -out=ownergraphtablegen_test.go gen "ValueType=OwnerEdge KeyType=OwnerEdgeKey"
+//go:generate genny -in=$GOFILE -out=deadlettertablegen_test.go gen "ValueType=DeadLetter KeyType=DeadLetterKey"
+//go:generate genny -in=$GOFILE -out=servicebackendstablegen_test.go gen "ValueType=ServiceBackends KeyType=ServiceBackendsKey"
+//go:generate genny -in=$GOFILE -out=currentstatetablegen_test.go gen "ValueType=CurrentState KeyType=CurrentStateKey"
 
 func helper_ValueType_ShouldSkip() bool {
 	// Tests will not work on the fake types in the template, but we want to run tests on real objects
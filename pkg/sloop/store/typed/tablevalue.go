@@ -0,0 +1,87 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+// Value types of the tables outside the core tables and ProtoTables whose values are plain proto messages
+var plainValueTypes = map[string]func() proto.Message{
+	selfFootprintTableName:                 func() proto.Message { return &SelfFootprint{} },
+	apiServiceAvailabilityTableName:        func() proto.Message { return &ApiServiceAvailability{} },
+	eventRollupTableNames[EventRollupHour]: func() proto.Message { return &ResourceEventCounts{} },
+	eventRollupTableNames[EventRollupDay]:  func() proto.Message { return &ResourceEventCounts{} },
+}
+
+/*
+Reads the value of key in the table the way the table itself does, so a value that is damaged or was written by an
+incompatible version fails here like it would in a query.  Values of tables whose type is not known here, like those
+registered with only RegisterTableName, are only read from the store and false is returned
+*/
+func DecodeTableValue(tables Tables, txn badgerwrap.Txn, tableName string, key string) (bool, error) {
+	var err error
+	switch tableName {
+	case tables.WatchTable().tableName:
+		_, err = tables.WatchTable().Get(txn, key)
+	case tables.ResourceSummaryTable().tableName:
+		_, err = tables.ResourceSummaryTable().Get(txn, key)
+	case tables.EventCountTable().tableName:
+		_, err = tables.EventCountTable().Get(txn, key)
+	case tables.WatchActivityTable().tableName:
+		_, err = tables.WatchActivityTable().Get(txn, key)
+	case tables.QuarantineTable().tableName:
+		_, err = tables.QuarantineTable().Get(txn, key)
+	case tables.EventFoldTable().tableName:
+		_, err = tables.EventFoldTable().Get(txn, key)
+	case tables.PodLifecycleTable().tableName:
+		_, err = tables.PodLifecycleTable().Get(txn, key)
+	case tables.NodeConditionTable().tableName:
+		_, err = tables.NodeConditionTable().Get(txn, key)
+	case tables.OwnerGraphTable().tableName:
+		_, err = tables.OwnerGraphTable().Get(txn, key)
+	case tables.DeadLetterTable().tableName:
+		_, err = tables.DeadLetterTable().Get(txn, key)
+	case tables.ServiceBackendsTable().tableName:
+		_, err = tables.ServiceBackendsTable().Get(txn, key)
+	case tables.CurrentStateTable().tableName:
+		_, err = tables.CurrentStateTable().Get(txn, key)
+	case tables.IngestAnnotationTable().tableName:
+		_, err = tables.IngestAnnotationTable().Get(txn, key)
+	default:
+		if table := tables.ExtraTable(tableName); table != nil {
+			_, err = table.Get(txn, key)
+			return true, err
+		}
+		return decodePlainValue(txn, tableName, key)
+	}
+	return true, err
+}
+
+func decodePlainValue(txn badgerwrap.Txn, tableName string, key string) (bool, error) {
+	item, err := txn.Get([]byte(key))
+	if err != nil {
+		return false, errors.Wrapf(err, "get failed for table %v", tableName)
+	}
+	valueBytes, err := item.ValueCopy([]byte{})
+	if err != nil {
+		return false, errors.Wrapf(err, "value copy failed for table %v", tableName)
+	}
+	newValue, ok := plainValueTypes[tableName]
+	if !ok {
+		return false, nil
+	}
+	err = proto.Unmarshal(valueBytes, newValue())
+	if err != nil {
+		return true, errors.Wrapf(err, "protobuf unmarshal failed for table %v on value length %v", tableName, len(valueBytes))
+	}
+	return true, nil
+}
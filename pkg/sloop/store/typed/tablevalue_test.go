@@ -0,0 +1,54 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+func Test_DecodeTableValue(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := NewTableList(db)
+	ts := time.Date(2021, 3, 4, 5, 6, 0, 0, time.UTC)
+	watchKey := NewWatchTableKey(untyped.GetPartitionId(ts), "Pod", "ns", "pod", ts).String()
+	footprintKey := OpenSelfFootprintTable().Key(ts)
+	unknownKey := "/othertable/" + untyped.GetPartitionId(ts) + "/a"
+
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		assert.Nil(t, tables.WatchTable().Set(txn, watchKey, &KubeWatchResult{Kind: "Pod", Payload: `{}`}))
+		assert.Nil(t, OpenSelfFootprintTable().Set(txn, ts, &SelfFootprint{Goroutines: 3}))
+		assert.Nil(t, txn.Set([]byte(unknownKey), []byte("anything")))
+		return nil
+	})
+	assert.Nil(t, err)
+
+	err = db.View(func(txn badgerwrap.Txn) error {
+		decoded, err := DecodeTableValue(tables, txn, "watch", watchKey)
+		assert.True(t, decoded)
+		assert.Nil(t, err)
+		decoded, err = DecodeTableValue(tables, txn, selfFootprintTableName, footprintKey)
+		assert.True(t, decoded)
+		assert.Nil(t, err)
+		decoded, err = DecodeTableValue(tables, txn, "othertable", unknownKey)
+		assert.False(t, decoded)
+		assert.Nil(t, err)
+		_, err = DecodeTableValue(tables, txn, "watch", watchKey+"0")
+		assert.NotNil(t, err)
+		return nil
+	})
+	assert.Nil(t, err)
+}
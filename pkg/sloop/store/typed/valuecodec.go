@@ -0,0 +1,251 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/DataDog/zstd"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+// Values in the watch table can be stored with a codec chosen per kind.  An encoded value is
+//
+//   <valueEnvelopeMarker><codec id><codec specific bytes>
+//
+// A protobuf message never starts with a zero byte (field number 0 is invalid), so values written without a codec
+// (including everything written before codecs existed) are still read as plain protobuf.  Only the watch table is
+// encoded because it holds the payloads, and it is the only append-only table which the delta codec relies on.
+
+const valueEnvelopeMarker byte = 0x00
+
+const (
+	CodecIdentity = "identity"
+	CodecGzip     = "gzip"
+	CodecZstd     = "zstd"
+	CodecDelta    = "delta"
+)
+
+type CodecContext struct {
+	Txn badgerwrap.Txn
+	Key string
+}
+
+type ValueCodec interface {
+	Id() byte
+	Name() string
+	Encode(ctx CodecContext, raw []byte) ([]byte, error)
+	Decode(ctx CodecContext, encoded []byte) ([]byte, error)
+}
+
+type CodecConfig struct {
+	// Codec for kinds without an override.  Empty means identity
+	Default string `json:"default"`
+	// Kind -> codec name
+	PerKind map[string]string `json:"perKind"`
+}
+
+var (
+	valueCodecs = map[byte]ValueCodec{}
+	// Keyed by name
+	valueCodecsByName = map[string]ValueCodec{}
+
+	payloadCodecConfig = CodecConfig{}
+
+	metricPayloadCodecRawBytes     = promauto.NewCounterVec(prometheus.CounterOpts{Name: "sloop_payload_codec_raw_bytes"}, []string{"codec"})
+	metricPayloadCodecEncodedBytes = promauto.NewCounterVec(prometheus.CounterOpts{Name: "sloop_payload_codec_encoded_bytes"}, []string{"codec"})
+)
+
+func init() {
+	registerValueCodec(identityCodec{})
+	registerValueCodec(gzipCodec{})
+	registerValueCodec(zstdCodec{})
+	registerValueCodec(deltaCodec{})
+}
+
+func registerValueCodec(codec ValueCodec) {
+	valueCodecs[codec.Id()] = codec
+	valueCodecsByName[codec.Name()] = codec
+}
+
+func (c CodecConfig) Validate() error {
+	if c.Default != "" {
+		if _, ok := valueCodecsByName[c.Default]; !ok {
+			return fmt.Errorf("unknown default payload codec %q", c.Default)
+		}
+	}
+	for kind, name := range c.PerKind {
+		if _, ok := valueCodecsByName[name]; !ok {
+			return fmt.Errorf("unknown payload codec %q for kind %v", name, kind)
+		}
+	}
+	return nil
+}
+
+func (c CodecConfig) codecForKind(kind string) string {
+	if name, ok := c.PerKind[kind]; ok {
+		return name
+	}
+	if c.Default != "" {
+		return c.Default
+	}
+	return CodecIdentity
+}
+
+// Sets the codecs used for new writes to the watch table.  Existing values are always readable regardless of
+// this setting because each value records its own codec.
+func SetPayloadCodecs(cfg CodecConfig) error {
+	err := cfg.Validate()
+	if err != nil {
+		return err
+	}
+	payloadCodecConfig = cfg
+	return nil
+}
+
+func encodeValue(txn badgerwrap.Txn, tableName string, key string, raw []byte) ([]byte, error) {
+	if tableName != (&WatchTableKey{}).TableName() {
+		return raw, nil
+	}
+	err := rememberWatchKeyName(txn, key)
+	if err != nil {
+		return nil, err
+	}
+	err, parts := common.ParseKey(key)
+	if err != nil {
+		return nil, err
+	}
+	name := payloadCodecConfig.codecForKind(parts[3])
+	if name == CodecIdentity {
+		// No envelope so values stay readable by older versions of sloop
+		return raw, nil
+	}
+	codec := valueCodecsByName[name]
+	encoded, err := codec.Encode(CodecContext{Txn: txn, Key: key}, raw)
+	if err != nil {
+		return nil, errors.Wrapf(err, "codec %v failed to encode value for key %v", name, key)
+	}
+	metricPayloadCodecRawBytes.WithLabelValues(name).Add(float64(len(raw)))
+	metricPayloadCodecEncodedBytes.WithLabelValues(name).Add(float64(len(encoded) + 2))
+	return append([]byte{valueEnvelopeMarker, codec.Id()}, encoded...), nil
+}
+
+func decodeValue(txn badgerwrap.Txn, key string, value []byte) ([]byte, error) {
+	codec, body, err := codecForValue(value)
+	if err != nil {
+		return nil, err
+	}
+	if codec == nil {
+		return value, nil
+	}
+	decoded, err := codec.Decode(CodecContext{Txn: txn, Key: key}, body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "codec %v failed to decode value for key %v", codec.Name(), key)
+	}
+	return decoded, nil
+}
+
+// Codec and size of a value as it is stored, before it is decoded
+type StoredValueInfo struct {
+	Codec string
+	Bytes int
+}
+
+// Returns how the value under key is stored.  Values without an envelope were stored as they are
+func GetStoredValueInfo(txn badgerwrap.Txn, key string) (StoredValueInfo, error) {
+	item, err := txn.Get([]byte(key))
+	if err != nil {
+		return StoredValueInfo{}, err
+	}
+	info := StoredValueInfo{Codec: CodecIdentity}
+	err = item.Value(func(value []byte) error {
+		info.Bytes = len(value)
+		codec, _, err := codecForValue(value)
+		if codec != nil {
+			info.Codec = codec.Name()
+		}
+		return err
+	})
+	return info, err
+}
+
+// Returns a nil codec for values that are not in an envelope
+func codecForValue(value []byte) (ValueCodec, []byte, error) {
+	if len(value) == 0 || value[0] != valueEnvelopeMarker {
+		return nil, value, nil
+	}
+	if len(value) < 2 {
+		return nil, nil, fmt.Errorf("value envelope is truncated")
+	}
+	codec, ok := valueCodecs[value[1]]
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown value codec id %v", value[1])
+	}
+	return codec, value[2:], nil
+}
+
+type identityCodec struct{}
+
+func (identityCodec) Id() byte     { return 0 }
+func (identityCodec) Name() string { return CodecIdentity }
+
+func (identityCodec) Encode(ctx CodecContext, raw []byte) ([]byte, error) {
+	return raw, nil
+}
+
+func (identityCodec) Decode(ctx CodecContext, encoded []byte) ([]byte, error) {
+	return encoded, nil
+}
+
+type gzipCodec struct{}
+
+func (gzipCodec) Id() byte     { return 1 }
+func (gzipCodec) Name() string { return CodecGzip }
+
+func (gzipCodec) Encode(ctx CodecContext, raw []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	_, err := writer.Write(raw)
+	if err != nil {
+		return nil, err
+	}
+	err = writer.Close()
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (gzipCodec) Decode(ctx CodecContext, encoded []byte) ([]byte, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(encoded))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return ioutil.ReadAll(reader)
+}
+
+type zstdCodec struct{}
+
+func (zstdCodec) Id() byte     { return 2 }
+func (zstdCodec) Name() string { return CodecZstd }
+
+func (zstdCodec) Encode(ctx CodecContext, raw []byte) ([]byte, error) {
+	return zstd.Compress(nil, raw)
+}
+
+func (zstdCodec) Decode(ctx CodecContext, encoded []byte) ([]byte, error) {
+	return zstd.Decompress(nil, encoded)
+}
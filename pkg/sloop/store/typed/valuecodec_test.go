@@ -0,0 +1,154 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+func helper_podPayload(version int) string {
+	return fmt.Sprintf(`{"metadata":{"name":"somename","namespace":"somenamespace","resourceVersion":"%v"},"spec":{"containers":[{"name":"app","image":"someimage:v1","args":[%v]}]},"status":{"phase":"Running"}}`,
+		version, strings.Repeat(`"--some-long-argument",`, 20)+`"--last"`)
+}
+
+// Writes a few versions of one pod and verifies they read back the same with the codec
+func helper_roundTripWithCodec(t *testing.T, cfg CodecConfig) badgerwrap.DB {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	assert.Nil(t, SetPayloadCodecs(cfg))
+	defer SetPayloadCodecs(CodecConfig{})
+
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	wt := OpenKubeWatchResultTable()
+
+	var keys []string
+	for i := 0; i < 5; i++ {
+		ts := someTs.Add(time.Duration(i) * time.Second)
+		pts, _ := ptypes.TimestampProto(ts)
+		key := NewWatchTableKey(untyped.GetPartitionId(ts), "Pod", someNamespace, someName, ts).String()
+		keys = append(keys, key)
+		err = db.Update(func(txn badgerwrap.Txn) error {
+			return wt.Set(txn, key, &KubeWatchResult{Kind: "Pod", Timestamp: pts, Payload: helper_podPayload(i)})
+		})
+		assert.Nil(t, err)
+	}
+
+	err = db.View(func(txn badgerwrap.Txn) error {
+		for i, key := range keys {
+			val, err := wt.Get(txn, key)
+			assert.Nil(t, err)
+			assert.Equal(t, helper_podPayload(i), val.Payload)
+		}
+		results, _, err := wt.RangeRead(txn, nil, nil, nil, someTs, someTs.Add(time.Minute))
+		assert.Nil(t, err)
+		assert.Len(t, results, 5)
+		return nil
+	})
+	assert.Nil(t, err)
+	return db
+}
+
+func helper_rawValueSizes(t *testing.T, db badgerwrap.DB) []int {
+	var sizes []int
+	err := db.View(func(txn badgerwrap.Txn) error {
+		itr := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer itr.Close()
+		for itr.Rewind(); itr.Valid(); itr.Next() {
+			v, err := itr.Item().ValueCopy(nil)
+			assert.Nil(t, err)
+			sizes = append(sizes, len(v))
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+	return sizes
+}
+
+func Test_ValueCodec_RoundTripAllCodecs(t *testing.T) {
+	for _, name := range []string{CodecIdentity, CodecGzip, CodecZstd, CodecDelta} {
+		helper_roundTripWithCodec(t, CodecConfig{Default: name})
+	}
+}
+
+func Test_ValueCodec_PerKindOverride(t *testing.T) {
+	cfg := CodecConfig{Default: CodecGzip, PerKind: map[string]string{"Pod": CodecIdentity}}
+	assert.Equal(t, CodecIdentity, cfg.codecForKind("Pod"))
+	assert.Equal(t, CodecGzip, cfg.codecForKind("Node"))
+	assert.Equal(t, CodecIdentity, CodecConfig{}.codecForKind("Node"))
+}
+
+func Test_ValueCodec_IdentityHasNoEnvelope(t *testing.T) {
+	db := helper_roundTripWithCodec(t, CodecConfig{})
+	err := db.View(func(txn badgerwrap.Txn) error {
+		itr := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer itr.Close()
+		for itr.Rewind(); itr.Valid(); itr.Next() {
+			v, _ := itr.Item().ValueCopy(nil)
+			assert.NotEqual(t, valueEnvelopeMarker, v[0])
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+}
+
+func Test_ValueCodec_DeltaIsSmallerAfterKeyframe(t *testing.T) {
+	sizes := helper_rawValueSizes(t, helper_roundTripWithCodec(t, CodecConfig{Default: CodecDelta}))
+	assert.Len(t, sizes, 5)
+	for _, size := range sizes[1:] {
+		assert.True(t, size < sizes[0]/4, "delta of %v bytes should be much smaller than keyframe of %v bytes", size, sizes[0])
+	}
+}
+
+func Test_ValueCodec_Validate(t *testing.T) {
+	assert.Nil(t, CodecConfig{Default: CodecZstd, PerKind: map[string]string{"Event": CodecGzip}}.Validate())
+	assert.NotNil(t, CodecConfig{Default: "lz4"}.Validate())
+	assert.NotNil(t, CodecConfig{PerKind: map[string]string{"Event": "lz4"}}.Validate())
+}
+
+func Test_computeDelta_ApplyRestoresTarget(t *testing.T) {
+	base := []byte(helper_podPayload(1))
+	for _, target := range [][]byte{[]byte(helper_podPayload(2)), []byte(""), []byte("short"), base} {
+		ops := computeDelta(base, target)
+		out, err := applyDelta(base, ops)
+		assert.Nil(t, err)
+		assert.Equal(t, string(target), string(out))
+	}
+}
+
+func Test_applyDelta_RejectsOutOfRangeCopy(t *testing.T) {
+	ops := appendCopyOp(nil, 10, 100)
+	_, err := applyDelta([]byte("short"), ops)
+	assert.NotNil(t, err)
+}
+
+func Test_GetStoredValueInfo(t *testing.T) {
+	for _, name := range []string{CodecIdentity, CodecGzip} {
+		db := helper_roundTripWithCodec(t, CodecConfig{Default: name})
+		sizes := helper_rawValueSizes(t, db)
+		err := db.View(func(txn badgerwrap.Txn) error {
+			key := NewWatchTableKey(untyped.GetPartitionId(someTs), "Pod", someNamespace, someName, someTs).String()
+			info, err := GetStoredValueInfo(txn, key)
+			assert.Nil(t, err)
+			assert.Equal(t, StoredValueInfo{Codec: name, Bytes: sizes[0]}, info)
+
+			_, err = GetStoredValueInfo(txn, key+"0")
+			assert.Equal(t, badger.ErrKeyNotFound, err)
+			return nil
+		})
+		assert.Nil(t, err)
+	}
+}
@@ -0,0 +1,223 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/pkg/errors"
+)
+
+// The delta codec stores a watch result as a binary delta against an earlier version of the same resource in the
+// same partition.  That earlier version (the keyframe) is never itself a delta, so decoding reads at most one extra
+// key.  Keyframes live in the same partition as their deltas, so GC drops them together.
+//
+// Frame layout after the envelope:
+//   keyframe: <deltaFrameKeyframe><raw bytes>
+//   delta:    <deltaFrameDiff><uvarint len(baseKey)><baseKey><ops>
+//
+// Ops are a sequence of uvarint headers (length<<1 | isCopy).  A copy is followed by a uvarint offset into the base,
+// an insert is followed by length literal bytes.
+
+const (
+	deltaFrameKeyframe byte = 0
+	deltaFrameDiff     byte = 1
+
+	deltaBlockSize = 16
+	// How many earlier versions we look through to find a keyframe
+	maxDeltaBaseScan = 64
+)
+
+type deltaCodec struct{}
+
+func (deltaCodec) Id() byte     { return 3 }
+func (deltaCodec) Name() string { return CodecDelta }
+
+func (d deltaCodec) Encode(ctx CodecContext, raw []byte) ([]byte, error) {
+	baseKey, baseRaw, found, err := findDeltaBase(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		ops := computeDelta(baseRaw, raw)
+		// When the resource has drifted too far from its keyframe start a new one
+		if len(ops) < len(raw)/2 {
+			frame := []byte{deltaFrameDiff}
+			frame = appendUvarint(frame, uint64(len(baseKey)))
+			frame = append(frame, baseKey...)
+			return append(frame, ops...), nil
+		}
+	}
+	return append([]byte{deltaFrameKeyframe}, raw...), nil
+}
+
+func (d deltaCodec) Decode(ctx CodecContext, encoded []byte) ([]byte, error) {
+	if len(encoded) == 0 {
+		return nil, fmt.Errorf("delta frame is empty")
+	}
+	if encoded[0] == deltaFrameKeyframe {
+		return encoded[1:], nil
+	}
+	if encoded[0] != deltaFrameDiff {
+		return nil, fmt.Errorf("unknown delta frame type %v", encoded[0])
+	}
+
+	keyLen, n := binary.Uvarint(encoded[1:])
+	if n <= 0 || uint64(len(encoded)-1-n) < keyLen {
+		return nil, fmt.Errorf("delta frame has invalid base key length")
+	}
+	baseKey := string(encoded[1+n : 1+n+int(keyLen)])
+	ops := encoded[1+n+int(keyLen):]
+
+	item, err := ctx.Txn.Get([]byte(baseKey))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read delta base %v", baseKey)
+	}
+	baseValue, err := item.ValueCopy([]byte{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to copy delta base %v", baseKey)
+	}
+	if isDeltaFrame(baseValue) {
+		return nil, fmt.Errorf("delta base %v is itself a delta", baseKey)
+	}
+	baseRaw, err := decodeValue(ctx.Txn, baseKey, baseValue)
+	if err != nil {
+		return nil, err
+	}
+	return applyDelta(baseRaw, ops)
+}
+
+func isDeltaFrame(value []byte) bool {
+	codec, body, err := codecForValue(value)
+	if err != nil || codec == nil {
+		return false
+	}
+	return codec.Id() == (deltaCodec{}).Id() && len(body) > 0 && body[0] == deltaFrameDiff
+}
+
+// Walks backwards from the key through earlier versions of the same resource in the same partition
+// and returns the newest one that is not a delta
+func findDeltaBase(ctx CodecContext) (string, []byte, bool, error) {
+	prefix := ctx.Key[:strings.LastIndex(ctx.Key, "/")+1]
+
+	iterOpt := badger.DefaultIteratorOptions
+	iterOpt.Prefix = []byte(prefix)
+	iterOpt.Reverse = true
+	itr := ctx.Txn.NewIterator(iterOpt)
+	defer itr.Close()
+
+	itr.Seek([]byte(ctx.Key))
+	if itr.ValidForPrefix([]byte(prefix)) && string(itr.Item().Key()) == ctx.Key {
+		itr.Next()
+	}
+	for scanned := 0; scanned < maxDeltaBaseScan && itr.ValidForPrefix([]byte(prefix)); scanned++ {
+		baseKey := string(itr.Item().KeyCopy(nil))
+		value, err := itr.Item().ValueCopy([]byte{})
+		if err != nil {
+			return "", nil, false, errors.Wrapf(err, "failed to read %v while looking for delta base", baseKey)
+		}
+		if !isDeltaFrame(value) {
+			raw, err := decodeValue(ctx.Txn, baseKey, value)
+			if err != nil {
+				return "", nil, false, err
+			}
+			return baseKey, raw, true, nil
+		}
+		itr.Next()
+	}
+	return "", nil, false, nil
+}
+
+func appendUvarint(buf []byte, v uint64) []byte {
+	tmp := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(tmp, v)
+	return append(buf, tmp[:n]...)
+}
+
+func appendInsertOp(ops []byte, literal []byte) []byte {
+	if len(literal) == 0 {
+		return ops
+	}
+	ops = appendUvarint(ops, uint64(len(literal))<<1)
+	return append(ops, literal...)
+}
+
+func appendCopyOp(ops []byte, offset int, length int) []byte {
+	ops = appendUvarint(ops, uint64(length)<<1|1)
+	return appendUvarint(ops, uint64(offset))
+}
+
+// Greedy block matching delta.  Base is indexed by aligned blocks, and each match is extended in both directions.
+func computeDelta(base []byte, target []byte) []byte {
+	index := map[string]int{}
+	for i := 0; i+deltaBlockSize <= len(base); i += deltaBlockSize {
+		block := string(base[i : i+deltaBlockSize])
+		if _, ok := index[block]; !ok {
+			index[block] = i
+		}
+	}
+
+	var ops []byte
+	insertStart := 0
+	i := 0
+	for i+deltaBlockSize <= len(target) {
+		offset, ok := index[string(target[i:i+deltaBlockSize])]
+		if !ok {
+			i++
+			continue
+		}
+		start, baseStart := i, offset
+		for start > insertStart && baseStart > 0 && target[start-1] == base[baseStart-1] {
+			start--
+			baseStart--
+		}
+		end, baseEnd := i+deltaBlockSize, offset+deltaBlockSize
+		for end < len(target) && baseEnd < len(base) && target[end] == base[baseEnd] {
+			end++
+			baseEnd++
+		}
+		ops = appendInsertOp(ops, target[insertStart:start])
+		ops = appendCopyOp(ops, baseStart, end-start)
+		i = end
+		insertStart = end
+	}
+	return appendInsertOp(ops, target[insertStart:])
+}
+
+func applyDelta(base []byte, ops []byte) ([]byte, error) {
+	var out []byte
+	for len(ops) > 0 {
+		header, n := binary.Uvarint(ops)
+		if n <= 0 {
+			return nil, fmt.Errorf("invalid delta op header")
+		}
+		ops = ops[n:]
+		length := int(header >> 1)
+		if header&1 == 1 {
+			offset, n := binary.Uvarint(ops)
+			if n <= 0 {
+				return nil, fmt.Errorf("invalid delta copy offset")
+			}
+			ops = ops[n:]
+			if int(offset)+length > len(base) {
+				return nil, fmt.Errorf("delta copy [%v:%v] is outside of base length %v", offset, int(offset)+length, len(base))
+			}
+			out = append(out, base[offset:int(offset)+length]...)
+		} else {
+			if length > len(ops) {
+				return nil, fmt.Errorf("delta insert of %v bytes is truncated", length)
+			}
+			out = append(out, ops[:length]...)
+			ops = ops[length:]
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,226 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	badger "github.com/dgraph-io/badger/v2"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+var (
+	metricWatchKeyNamesAdded      = promauto.NewCounter(prometheus.CounterOpts{Name: "sloop_watch_key_names_added"})
+	metricWatchKeyNamesSavedBytes = promauto.NewCounter(prometheus.CounterOpts{Name: "sloop_watch_key_names_saved_bytes"})
+)
+
+/*
+Names in watch table keys can be stored as a short id, so the many versions of a resource do not each repeat its
+name.  An id is a hash of the name, so a key can be built for a lookup without reading anything, and the dictionary
+holds the names of the ids used in each partition:
+
+	/watchkeyname/<partition>/<id>     the name
+	/watchkeyname/since                the first partition with ids
+
+The dictionary of a partition goes away with the partition.  A name in a key is an id when it starts with
+watchKeyNameMarker, which kubernetes does not allow in names, so keys written before ids were turned on, or in
+partitions before the since partition, are read as they are.  Event names are never replaced, since events are
+looked up by the prefix of their names and rarely have more than one version
+*/
+const (
+	watchKeyNameTable  = "watchkeyname"
+	watchKeyNameMarker = "%"
+)
+
+// Registered so partition GC drops the dictionary of a partition along with its keys
+func init() {
+	RegisterTableName(watchKeyNameTable)
+}
+
+// The cache of names by id is dropped when it gets this big, and filled again from the dictionary
+const maxCachedWatchKeyNames = 1000000
+
+var watchKeyNames = &watchKeyNameDictionary{names: map[string]string{}}
+
+type watchKeyNameDictionary struct {
+	lock sync.RWMutex
+	db   badgerwrap.DB
+	// Empty when ids are not used
+	since string
+	names map[string]string
+}
+
+/*
+Turns on ids for the names in the watch keys of the partitions after now when enable is set.  Once a store has ids it
+keeps using them even when enable is not set anymore, because the keys already written can only be found by their
+ids.  Needs to be called before the watch table is used
+*/
+func StartWatchKeyNames(db badgerwrap.DB, enable bool, now time.Time) error {
+	sinceKey := []byte("/" + watchKeyNameTable + "/since")
+	since := ""
+	err := db.Update(func(txn badgerwrap.Txn) error {
+		item, err := txn.Get(sinceKey)
+		if err == nil {
+			value, err := item.ValueCopy([]byte{})
+			if err != nil {
+				return err
+			}
+			since = string(value)
+			return nil
+		}
+		if err != badger.ErrKeyNotFound {
+			return err
+		}
+		if !enable {
+			return nil
+		}
+		// The current partition can already have keys with names
+		_, end, err := untyped.GetTimeRangeForPartition(untyped.GetPartitionId(now))
+		if err != nil {
+			return err
+		}
+		since = untyped.GetPartitionId(end)
+		return txn.Set(sinceKey, []byte(since))
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to read when watch key names started")
+	}
+
+	watchKeyNames.lock.Lock()
+	defer watchKeyNames.lock.Unlock()
+	watchKeyNames.db = db
+	watchKeyNames.since = since
+	watchKeyNames.names = map[string]string{}
+	return nil
+}
+
+// First partition with ids for names, empty when they are not used
+func WatchKeyNamesSince() string {
+	watchKeyNames.lock.RLock()
+	defer watchKeyNames.lock.RUnlock()
+	return watchKeyNames.since
+}
+
+func watchKeyNameDictionaryKey(partitionId string, id string) string {
+	return fmt.Sprintf("/%v/%v/%v", watchKeyNameTable, partitionId, id)
+}
+
+func watchKeyNameId(name string) string {
+	sum := sha256.Sum256([]byte(name))
+	return watchKeyNameMarker + strconv.FormatUint(binary.BigEndian.Uint64(sum[:8]), 36)
+}
+
+// The name to put in a key, which is an id when the partition uses them and the id is shorter
+func (d *watchKeyNameDictionary) encode(k *WatchTableKey) string {
+	if k.Name == "" || k.PartitionId == "" || k.Kind == kubeextractor.EventKind || k.IsNameAlreadyDelimited() {
+		return k.Name
+	}
+	d.lock.RLock()
+	since := d.since
+	d.lock.RUnlock()
+	if since == "" || k.PartitionId < since {
+		return k.Name
+	}
+	id := watchKeyNameId(k.Name)
+	if len(id) >= len(k.Name) {
+		return k.Name
+	}
+	d.cache(id, k.Name)
+	return id
+}
+
+func (d *watchKeyNameDictionary) cache(id string, name string) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if len(d.names) >= maxCachedWatchKeyNames {
+		d.names = map[string]string{}
+	}
+	d.names[id] = name
+}
+
+func (d *watchKeyNameDictionary) decode(partitionId string, name string) (string, error) {
+	if !strings.HasPrefix(name, watchKeyNameMarker) {
+		return name, nil
+	}
+	d.lock.RLock()
+	decoded, ok := d.names[name]
+	db := d.db
+	d.lock.RUnlock()
+	if ok {
+		return decoded, nil
+	}
+	if db == nil {
+		return "", fmt.Errorf("no dictionary to read watch key name %v from", name)
+	}
+	err := db.View(func(txn badgerwrap.Txn) error {
+		var err error
+		decoded, err = readWatchKeyName(txn, partitionId, name)
+		return err
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to read watch key name %v of partition %v", name, partitionId)
+	}
+	d.cache(name, decoded)
+	return decoded, nil
+}
+
+func readWatchKeyName(txn badgerwrap.Txn, partitionId string, id string) (string, error) {
+	item, err := txn.Get([]byte(watchKeyNameDictionaryKey(partitionId, id)))
+	if err != nil {
+		return "", err
+	}
+	value, err := item.ValueCopy([]byte{})
+	if err != nil {
+		return "", err
+	}
+	return string(value), nil
+}
+
+// Adds the name of a watch key with an id to the dictionary of its partition, in the transaction that writes the key
+func rememberWatchKeyName(txn badgerwrap.Txn, key string) error {
+	k := &WatchTableKey{}
+	err := k.Parse(key)
+	if err != nil {
+		return err
+	}
+	err, parts := common.ParseKey(key)
+	if err != nil || !strings.HasPrefix(parts[5], watchKeyNameMarker) {
+		return err
+	}
+	id := parts[5]
+	metricWatchKeyNamesSavedBytes.Add(float64(len(k.Name) - len(id)))
+	stored, err := readWatchKeyName(txn, k.PartitionId, id)
+	if err == nil {
+		if stored != k.Name {
+			return fmt.Errorf("watch key name id %v is taken by %v, can not store %v", id, stored, k.Name)
+		}
+		return nil
+	}
+	if err != badger.ErrKeyNotFound {
+		return err
+	}
+	err = txn.Set([]byte(watchKeyNameDictionaryKey(k.PartitionId, id)), []byte(k.Name))
+	if err != nil {
+		return err
+	}
+	metricWatchKeyNamesAdded.Inc()
+	return nil
+}
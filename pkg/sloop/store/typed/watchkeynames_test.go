@@ -0,0 +1,146 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	badger "github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+const someLongName = "istio-pilot-56f7d9848-2xk9w-with-a-long-suffix"
+
+func helper_startWatchKeyNames(t *testing.T, enable bool) badgerwrap.DB {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	assert.Nil(t, StartWatchKeyNames(db, enable, someTs))
+	return db
+}
+
+func helper_stopWatchKeyNames(t *testing.T) {
+	helper_startWatchKeyNames(t, false)
+}
+
+func Test_WatchKeyNames_StartsWithNextPartitionAndStaysOn(t *testing.T) {
+	defer helper_stopWatchKeyNames(t)
+	db := helper_startWatchKeyNames(t, true)
+	assert.Equal(t, someMiddlePartition, WatchKeyNamesSince())
+
+	assert.Nil(t, StartWatchKeyNames(db, false, someMaxTs))
+	assert.Equal(t, someMiddlePartition, WatchKeyNamesSince())
+}
+
+func Test_WatchKeyNames_DictionaryIsDroppedWithPartitions(t *testing.T) {
+	db := helper_startWatchKeyNames(t, false)
+	assert.Contains(t, NewTableList(db).GetTableNames(), watchKeyNameTable)
+}
+
+func Test_WatchKeyNames_KeyRoundTrips(t *testing.T) {
+	defer helper_stopWatchKeyNames(t)
+	helper_startWatchKeyNames(t, true)
+
+	k := NewWatchTableKey(someMiddlePartition, someKind, someNamespace, someLongName, someMiddleTs)
+	assert.NotContains(t, k.String(), someLongName)
+	assert.Contains(t, k.PlainString(), someLongName)
+	assert.True(t, len(k.String()) < len(k.PlainString()))
+
+	parsed := &WatchTableKey{}
+	assert.Nil(t, parsed.Parse(k.String()))
+	assert.Equal(t, *k, *parsed)
+}
+
+func Test_WatchKeyNames_PlainKeysStayPlain(t *testing.T) {
+	defer helper_stopWatchKeyNames(t)
+	helper_startWatchKeyNames(t, true)
+
+	before := NewWatchTableKey(someMinPartition, someKind, someNamespace, someLongName, someTs)
+	assert.Equal(t, before.PlainString(), before.String())
+	event := NewWatchTableKey(someMiddlePartition, "Event", someNamespace, someLongName+".16a8d2f0e3b4c5d6", someMiddleTs)
+	assert.Equal(t, event.PlainString(), event.String())
+	short := NewWatchTableKey(someMiddlePartition, someKind, someNamespace, someName, someMiddleTs)
+	assert.Equal(t, short.PlainString(), short.String())
+
+	// Keys written before ids were turned on are read as they are
+	parsed := &WatchTableKey{}
+	assert.Nil(t, parsed.Parse(NewWatchTableKey(someMiddlePartition, someKind, someNamespace, someLongName, someMiddleTs).PlainString()))
+	assert.Equal(t, someLongName, parsed.Name)
+}
+
+func Test_WatchKeyNames_SetStoresNameAndGetReadsIt(t *testing.T) {
+	defer helper_stopWatchKeyNames(t)
+	db := helper_startWatchKeyNames(t, true)
+	wt := OpenKubeWatchResultTable()
+	key := NewWatchTableKey(someMiddlePartition, someKind, someNamespace, someLongName, someMiddleTs)
+	err := db.Update(func(txn badgerwrap.Txn) error {
+		return wt.Set(txn, key.String(), &KubeWatchResult{Kind: someKind, Payload: "{}"})
+	})
+	assert.Nil(t, err)
+
+	err, parts := common.ParseKey(key.String())
+	assert.Nil(t, err)
+	var name string
+	err = db.View(func(txn badgerwrap.Txn) error {
+		var err error
+		name, err = readWatchKeyName(txn, someMiddlePartition, parts[5])
+		return err
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, someLongName, name)
+
+	// Without the cache the name comes from the dictionary
+	watchKeyNames.names = map[string]string{}
+	var keys []string
+	err = db.View(func(txn badgerwrap.Txn) error {
+		results, _, err := wt.RangeRead(txn, nil, nil, nil, someTs, someMaxTs)
+		for k := range results {
+			keys = append(keys, k.Name)
+		}
+		return err
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{someLongName}, keys)
+}
+
+func Test_WatchKeyNames_KeyNamespaceOfNamespace(t *testing.T) {
+	defer helper_stopWatchKeyNames(t)
+	db := helper_startWatchKeyNames(t, true)
+	key := NewWatchTableKey(someMiddlePartition, "Namespace", "", someLongName, someMiddleTs)
+	assert.NotContains(t, key.String(), someLongName)
+	err := db.Update(func(txn badgerwrap.Txn) error {
+		return OpenKubeWatchResultTable().Set(txn, key.String(), &KubeWatchResult{Kind: "Namespace", Payload: "{}"})
+	})
+	assert.Nil(t, err)
+
+	// From the dictionary, not the cache
+	watchKeyNames.names = map[string]string{}
+	namespace, ok := KeyNamespace(key.String())
+	assert.True(t, ok)
+	assert.Equal(t, someLongName, namespace)
+
+	notStored := NewWatchTableKey(someMiddlePartition, "Namespace", "", someLongName+"-not-stored", someMiddleTs).String()
+	watchKeyNames.names = map[string]string{}
+	_, ok = KeyNamespace(notStored)
+	assert.False(t, ok)
+}
+
+func Test_WatchKeyNames_UnknownIdFails(t *testing.T) {
+	defer helper_stopWatchKeyNames(t)
+	helper_startWatchKeyNames(t, true)
+	k := &WatchTableKey{}
+	err := k.Parse("/watch/" + someMiddlePartition + "/" + someKind + "/" + someNamespace + "/" + watchKeyNameId(someLongName) + "/1546401845000000006")
+	assert.NotNil(t, err)
+	assert.True(t, strings.Contains(err.Error(), "watch key name"))
+}
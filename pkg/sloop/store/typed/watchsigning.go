@@ -0,0 +1,145 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+	"strconv"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+// Watch results are only signed while a key is set
+var watchSigningKey []byte
+
+// Only this many keys of each kind of failure are listed in a verification report
+const maxReportedSignatureKeys = 100
+
+// Sets the HMAC key used to sign new writes to the watch table.  Nil turns signing off
+func SetWatchSigningKey(key []byte) {
+	watchSigningKey = key
+}
+
+func computeWatchSignature(signingKey []byte, key string, watchRec *KubeWatchResult) []byte {
+	mac := hmac.New(sha256.New, signingKey)
+	// Every field is length prefixed so values can not bleed into each other
+	for _, field := range []string{
+		key,
+		watchRec.Kind,
+		watchRec.WatchType.String(),
+		strconv.FormatInt(watchRec.GetTimestamp().GetSeconds(), 10),
+		strconv.FormatInt(int64(watchRec.GetTimestamp().GetNanos()), 10),
+		watchRec.Payload,
+	} {
+		mac.Write([]byte(strconv.Itoa(len(field)) + ":"))
+		mac.Write([]byte(field))
+	}
+	// Later fields are only covered when set, so results signed before they were added still verify
+	if watchRec.OldPayload != "" || watchRec.FinalState != "" {
+		for _, field := range []string{watchRec.OldPayload, watchRec.FinalState} {
+			mac.Write([]byte(strconv.Itoa(len(field)) + ":"))
+			mac.Write([]byte(field))
+		}
+	}
+	if watchRec.ApiVersion != "" || watchRec.ApiVersionMigratedFrom != "" {
+		for _, field := range []string{watchRec.ApiVersion, watchRec.ApiVersionMigratedFrom} {
+			mac.Write([]byte(strconv.Itoa(len(field)) + ":"))
+			mac.Write([]byte(field))
+		}
+	}
+	return mac.Sum(nil)
+}
+
+// Sets the signature of a watch result that is about to be stored under key.  Does nothing when no signing key is
+// set.  Changed paths are derived at ingest and are not covered
+func SignWatchResult(key string, watchRec *KubeWatchResult) {
+	if len(watchSigningKey) == 0 {
+		return
+	}
+	watchRec.Signature = computeWatchSignature(watchSigningKey, key, watchRec)
+}
+
+type SignatureReport struct {
+	Checked    int `json:"checked"`
+	Valid      int `json:"valid"`
+	Unsigned   int `json:"unsigned"`
+	Invalid    int `json:"invalid"`
+	Unreadable int `json:"unreadable"`
+	// First few keys of each kind of failure
+	InvalidKeys    []string `json:"invalidKeys,omitempty"`
+	UnsignedKeys   []string `json:"unsignedKeys,omitempty"`
+	UnreadableKeys []string `json:"unreadableKeys,omitempty"`
+}
+
+// Checks the signature of every watch result in the store against signingKey.  Results written before signing was
+// turned on are reported as unsigned rather than invalid
+func VerifyWatchSignatures(db badgerwrap.DB, signingKey []byte) (SignatureReport, error) {
+	report := SignatureReport{}
+	if len(signingKey) == 0 {
+		return report, fmt.Errorf("no signing key to verify with")
+	}
+
+	prefix := []byte("/" + (&WatchTableKey{}).TableName() + "/")
+	err := db.View(func(txn badgerwrap.Txn) error {
+		itr := txn.NewIterator(badger.IteratorOptions{Prefix: prefix})
+		defer itr.Close()
+		for itr.Seek(prefix); itr.ValidForPrefix(prefix); itr.Next() {
+			key := string(itr.Item().Key())
+			report.Checked++
+
+			watchRec, err := readWatchResult(txn, key, itr.Item())
+			if err != nil {
+				report.Unreadable++
+				report.UnreadableKeys = appendReportedKey(report.UnreadableKeys, key)
+				continue
+			}
+			if len(watchRec.Signature) == 0 {
+				report.Unsigned++
+				report.UnsignedKeys = appendReportedKey(report.UnsignedKeys, key)
+				continue
+			}
+			if !hmac.Equal(watchRec.Signature, computeWatchSignature(signingKey, key, watchRec)) {
+				report.Invalid++
+				report.InvalidKeys = appendReportedKey(report.InvalidKeys, key)
+				continue
+			}
+			report.Valid++
+		}
+		return nil
+	})
+	return report, err
+}
+
+func readWatchResult(txn badgerwrap.Txn, key string, item badgerwrap.Item) (*KubeWatchResult, error) {
+	valueBytes, err := item.ValueCopy([]byte{})
+	if err != nil {
+		return nil, err
+	}
+	valueBytes, err = decodeValue(txn, key, valueBytes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decode %v", key)
+	}
+	watchRec := &KubeWatchResult{}
+	err = proto.Unmarshal(valueBytes, watchRec)
+	if err != nil {
+		return nil, err
+	}
+	return watchRec, nil
+}
+
+func appendReportedKey(keys []string, key string) []string {
+	if len(keys) >= maxReportedSignatureKeys {
+		return keys
+	}
+	return append(keys, key)
+}
@@ -0,0 +1,82 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+var someSigningKey = []byte("somesigningkey")
+
+func helper_signedWatchResult(t *testing.T, name string) (string, *KubeWatchResult) {
+	ts, err := ptypes.TimestampProto(someTs)
+	assert.Nil(t, err)
+	key := NewWatchTableKey(untyped.GetPartitionId(someTs), "Pod", "somenamespace", name, someTs).String()
+	watchRec := &KubeWatchResult{Kind: "Pod", WatchType: KubeWatchResult_UPDATE, Timestamp: ts, Payload: helper_podPayload(1)}
+	SignWatchResult(key, watchRec)
+	return key, watchRec
+}
+
+func Test_SignWatchResult_OnlyWithKey(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	_, watchRec := helper_signedWatchResult(t, "somename")
+	assert.Nil(t, watchRec.Signature)
+
+	SetWatchSigningKey(someSigningKey)
+	defer SetWatchSigningKey(nil)
+	key, watchRec := helper_signedWatchResult(t, "somename")
+	assert.Len(t, watchRec.Signature, 32)
+	assert.Equal(t, watchRec.Signature, computeWatchSignature(someSigningKey, key, watchRec))
+
+	// The signature covers the key, so a result moved to another key does not verify
+	otherKey := NewWatchTableKey(untyped.GetPartitionId(someTs), "Pod", "somenamespace", "othername", someTs).String()
+	assert.NotEqual(t, watchRec.Signature, computeWatchSignature(someSigningKey, otherKey, watchRec))
+}
+
+func Test_VerifyWatchSignatures(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	wt := OpenKubeWatchResultTable()
+
+	unsignedKey, unsigned := helper_signedWatchResult(t, "unsigned")
+	SetWatchSigningKey(someSigningKey)
+	defer SetWatchSigningKey(nil)
+	validKey, valid := helper_signedWatchResult(t, "valid")
+	tamperedKey, tampered := helper_signedWatchResult(t, "tampered")
+	tampered.Payload = helper_podPayload(2)
+
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		for key, watchRec := range map[string]*KubeWatchResult{unsignedKey: unsigned, validKey: valid, tamperedKey: tampered} {
+			err := wt.Set(txn, key, watchRec)
+			if err != nil {
+				return err
+			}
+		}
+		return txn.Set([]byte("/ressum/001546398000/Pod/somenamespace/somename/someuid"), []byte("not a watch result"))
+	})
+	assert.Nil(t, err)
+
+	report, err := VerifyWatchSignatures(db, someSigningKey)
+	assert.Nil(t, err)
+	assert.Equal(t, SignatureReport{Checked: 3, Valid: 1, Unsigned: 1, Invalid: 1, InvalidKeys: []string{tamperedKey}, UnsignedKeys: []string{unsignedKey}}, report)
+
+	report, err = VerifyWatchSignatures(db, []byte("otherkey"))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, report.Invalid)
+
+	_, err = VerifyWatchSignatures(db, nil)
+	assert.NotNil(t, err)
+}
@@ -8,6 +8,7 @@
 package typed
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/pkg/errors"
 	"github.com/salesforce/sloop/pkg/sloop/common"
@@ -15,6 +16,32 @@ import (
 	"time"
 )
 
+// Values of KubeWatchResult.VersionType
+const (
+	VersionTypeFirst   = "first"
+	VersionTypeResync  = "resync"
+	VersionTypeChanged = "changed"
+)
+
+// Values of KubeWatchResult.FinalState
+const (
+	FinalStateWatch = "watch"
+	FinalStateCache = "cache"
+)
+
+// The api version of a watch result, from the payload for results stored before the watcher set it.  Empty when
+// neither has it, typed objects have no apiVersion in their payload
+func WatchResultApiVersion(watchRec *KubeWatchResult) string {
+	if watchRec.ApiVersion != "" {
+		return watchRec.ApiVersion
+	}
+	resource := struct {
+		ApiVersion string `json:"apiVersion"`
+	}{}
+	_ = json.Unmarshal([]byte(watchRec.Payload), &resource)
+	return resource.ApiVersion
+}
+
 // Key is /<partition>/<kind>/<namespace>/<name>/<timestamp>
 //
 // Partition is UnixSeconds rounded down to partition duration
@@ -55,7 +82,10 @@ func (k *WatchTableKey) Parse(key string) error {
 	k.PartitionId = parts[2]
 	k.Kind = parts[3]
 	k.Namespace = parts[4]
-	k.Name = parts[5]
+	k.Name, err = watchKeyNames.decode(parts[2], parts[5])
+	if err != nil {
+		return err
+	}
 	tsint, err := strconv.ParseInt(parts[6], 10, 64)
 	if err != nil {
 		return errors.Wrapf(err, "Failed to parse timestamp from key: %v", key)
@@ -80,16 +110,26 @@ func (k *WatchTableKey) IsNameAlreadyDelimited() bool {
 
 //todo: need to make sure it can work as keyPrefix when some fields are empty
 func (k *WatchTableKey) String() string {
+	return k.format(watchKeyNames.encode(k))
+}
+
+// The key with the name as it is, even in partitions that store an id for it.  The same for every store, so it is
+// what stores compare their keys with
+func (k *WatchTableKey) PlainString() string {
+	return k.format(k.Name)
+}
+
+func (k *WatchTableKey) format(name string) string {
 	if k.Name == "" && k.Timestamp.IsZero() {
 		return fmt.Sprintf("/%v/%v/%v/%v/", k.TableName(), k.PartitionId, k.Kind, k.Namespace)
 	} else if k.Timestamp.IsZero() {
 		if k.IsNameAlreadyDelimited() {
-			return fmt.Sprintf("/%v/%v/%v/%v/%v", k.TableName(), k.PartitionId, k.Kind, k.Namespace, k.Name)
+			return fmt.Sprintf("/%v/%v/%v/%v/%v", k.TableName(), k.PartitionId, k.Kind, k.Namespace, name)
 		} else {
-			return fmt.Sprintf("/%v/%v/%v/%v/%v/", k.TableName(), k.PartitionId, k.Kind, k.Namespace, k.Name)
+			return fmt.Sprintf("/%v/%v/%v/%v/%v/", k.TableName(), k.PartitionId, k.Kind, k.Namespace, name)
 		}
 	} else {
-		return fmt.Sprintf("/%v/%v/%v/%v/%v/%v", k.TableName(), k.PartitionId, k.Kind, k.Namespace, k.Name, k.Timestamp.UnixNano())
+		return fmt.Sprintf("/%v/%v/%v/%v/%v/%v", k.TableName(), k.PartitionId, k.Kind, k.Namespace, name, k.Timestamp.UnixNano())
 	}
 }
 
@@ -243,3 +243,37 @@ func (*WatchTableKey) SetTestKeys() []string {
 func (*WatchTableKey) SetTestValue() *KubeWatchResult {
 	return &KubeWatchResult{Kind: someKind}
 }
+
+func Test_WatchTable_RangeReadReverse_StopsAtMaxRows(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	var keys []string
+	for _, ts := range []time.Time{someTs, someMiddleTs, someMaxTs} {
+		keys = append(keys, NewWatchTableKey(untyped.GetPartitionId(ts), someKind, someNamespace, someName, ts).String())
+		keys = append(keys, NewWatchTableKey(untyped.GetPartitionId(ts), someKind, someNamespace, someName, ts.Add(time.Minute)).String())
+		keys = append(keys, NewWatchTableKey(untyped.GetPartitionId(ts), someKind, someNamespace, "othername", ts).String())
+	}
+	db, wt := helper_update_KubeWatchResultTable(t, keys, &KubeWatchResult{})
+
+	err := db.View(func(txn badgerwrap.Txn) error {
+		keyComparator := NewWatchTableKeyComparator(someKind, someNamespace, someName, time.Time{})
+		rows, stats, err := wt.RangeReadReverse(txn, keyComparator, nil, nil, someTs, someMaxTs.Add(time.Hour), 3)
+		assert.Nil(t, err)
+		var keys []WatchTableKey
+		for _, row := range rows {
+			keys = append(keys, row.Key)
+		}
+		assert.Equal(t, []WatchTableKey{
+			*NewWatchTableKey(someMaxPartition, someKind, someNamespace, someName, someMaxTs.Add(time.Minute)),
+			*NewWatchTableKey(someMaxPartition, someKind, someNamespace, someName, someMaxTs),
+			*NewWatchTableKey(someMiddlePartition, someKind, someNamespace, someName, someMiddleTs.Add(time.Minute)),
+		}, keys)
+		// The oldest partition is never read
+		assert.Equal(t, 3, stats.RowsVisitedCount)
+
+		rows, _, err = wt.RangeReadReverse(txn, nil, nil, nil, someTs, someMaxTs.Add(time.Hour), 0)
+		assert.Nil(t, err)
+		assert.Len(t, rows, 9)
+		return nil
+	})
+	assert.Nil(t, err)
+}
@@ -0,0 +1,226 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package badgerwrap
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// The error returned by the operations a FaultyDB fails
+var ErrInjectedFault = errors.New("injected storage fault")
+
+var metricInjectedFaults = promauto.NewCounterVec(prometheus.CounterOpts{Name: "sloop_injected_storage_faults"}, []string{"op"})
+
+const (
+	faultOpGet     = "get"
+	faultOpSet     = "set"
+	faultOpIterate = "iterate"
+	faultOpCommit  = "commit"
+)
+
+// Rates are the chance from 0 to 1 of each operation failing with ErrInjectedFault
+type FaultConfig struct {
+	GetErrorRate float64
+	// Set and Delete
+	SetErrorRate float64
+	// Reading the value of an item an iterator is on
+	IterateErrorRate float64
+	// Update transactions, which then fail after their function ran and write nothing on Badger.  The mock DB does
+	// not roll back, so its writes stay
+	CommitErrorRate float64
+	// Added to each Get, Set, Delete, new iterator and commit
+	Latency time.Duration
+	// 0 seeds from the time
+	Seed int64
+}
+
+/*
+Parses a comma separated list of get, set, iterate and commit error rates, latency and seed, like
+get=0.01,commit=0.05,latency=20ms.  Missing ones are 0
+*/
+func ParseFaultConfig(spec string) (FaultConfig, error) {
+	config := FaultConfig{}
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		pair := strings.SplitN(part, "=", 2)
+		if len(pair) != 2 {
+			return FaultConfig{}, fmt.Errorf("storage fault %q should be name=value", part)
+		}
+		var err error
+		switch pair[0] {
+		case faultOpGet:
+			config.GetErrorRate, err = parseFaultRate(pair[1])
+		case faultOpSet:
+			config.SetErrorRate, err = parseFaultRate(pair[1])
+		case faultOpIterate:
+			config.IterateErrorRate, err = parseFaultRate(pair[1])
+		case faultOpCommit:
+			config.CommitErrorRate, err = parseFaultRate(pair[1])
+		case "latency":
+			config.Latency, err = time.ParseDuration(pair[1])
+		case "seed":
+			config.Seed, err = strconv.ParseInt(pair[1], 10, 64)
+		default:
+			err = fmt.Errorf("unknown storage fault, should be get, set, iterate, commit, latency or seed")
+		}
+		if err != nil {
+			return FaultConfig{}, fmt.Errorf("invalid storage fault %q: %v", part, err)
+		}
+	}
+	return config, nil
+}
+
+func parseFaultRate(value string) (float64, error) {
+	rate, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, err
+	}
+	if rate < 0 || rate > 1 {
+		return 0, fmt.Errorf("rate should be from 0 to 1")
+	}
+	return rate, nil
+}
+
+/*
+Wraps a DB to fail a share of its operations and slow them down, so integration tests and soak environments can check
+that queries, processing and GC handle storage errors.  Never use it on a store whose data matters
+*/
+type FaultyDB struct {
+	DB
+	config FaultConfig
+	lock   *sync.Mutex
+	random *rand.Rand
+}
+
+func NewFaultyDB(db DB, config FaultConfig) *FaultyDB {
+	seed := config.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return &FaultyDB{DB: db, config: config, lock: &sync.Mutex{}, random: rand.New(rand.NewSource(seed))}
+}
+
+func (b *FaultyDB) delay() {
+	if b.config.Latency > 0 {
+		time.Sleep(b.config.Latency)
+	}
+}
+
+// Returns ErrInjectedFault at the rate
+func (b *FaultyDB) fail(op string, rate float64) error {
+	if rate <= 0 {
+		return nil
+	}
+	b.lock.Lock()
+	fail := b.random.Float64() < rate
+	b.lock.Unlock()
+	if !fail {
+		return nil
+	}
+	metricInjectedFaults.WithLabelValues(op).Inc()
+	return ErrInjectedFault
+}
+
+func (b *FaultyDB) Update(fn func(txn Txn) error) error {
+	return b.DB.Update(func(txn Txn) error {
+		err := fn(&faultyTxn{Txn: txn, db: b})
+		if err != nil {
+			return err
+		}
+		// Failing inside the function makes Badger discard the transaction, like a failed commit
+		b.delay()
+		return b.fail(faultOpCommit, b.config.CommitErrorRate)
+	})
+}
+
+func (b *FaultyDB) View(fn func(txn Txn) error) error {
+	return b.DB.View(func(txn Txn) error {
+		return fn(&faultyTxn{Txn: txn, db: b})
+	})
+}
+
+type faultyTxn struct {
+	Txn
+	db *FaultyDB
+}
+
+func (t *faultyTxn) Get(key []byte) (Item, error) {
+	t.db.delay()
+	err := t.db.fail(faultOpGet, t.db.config.GetErrorRate)
+	if err != nil {
+		return nil, err
+	}
+	return t.Txn.Get(key)
+}
+
+func (t *faultyTxn) Set(key, val []byte) error {
+	t.db.delay()
+	err := t.db.fail(faultOpSet, t.db.config.SetErrorRate)
+	if err != nil {
+		return err
+	}
+	return t.Txn.Set(key, val)
+}
+
+func (t *faultyTxn) Delete(key []byte) error {
+	t.db.delay()
+	err := t.db.fail(faultOpSet, t.db.config.SetErrorRate)
+	if err != nil {
+		return err
+	}
+	return t.Txn.Delete(key)
+}
+
+func (t *faultyTxn) NewIterator(opt badger.IteratorOptions) Iterator {
+	t.db.delay()
+	return &faultyIterator{Iterator: t.Txn.NewIterator(opt), db: t.db}
+}
+
+// Iterators can not fail, so the values of their items do
+type faultyIterator struct {
+	Iterator
+	db *FaultyDB
+}
+
+func (i *faultyIterator) Item() Item {
+	return &faultyItem{Item: i.Iterator.Item(), db: i.db}
+}
+
+type faultyItem struct {
+	Item
+	db *FaultyDB
+}
+
+func (i *faultyItem) Value(fn func(val []byte) error) error {
+	err := i.db.fail(faultOpIterate, i.db.config.IterateErrorRate)
+	if err != nil {
+		return err
+	}
+	return i.Item.Value(fn)
+}
+
+func (i *faultyItem) ValueCopy(dst []byte) ([]byte, error) {
+	err := i.db.fail(faultOpIterate, i.db.config.IterateErrorRate)
+	if err != nil {
+		return nil, err
+	}
+	return i.Item.ValueCopy(dst)
+}
@@ -0,0 +1,113 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package badgerwrap
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseFaultConfig(t *testing.T) {
+	config, err := ParseFaultConfig("get=0.1, commit=1,latency=5ms,seed=7")
+	assert.Nil(t, err)
+	assert.Equal(t, FaultConfig{GetErrorRate: 0.1, CommitErrorRate: 1, Latency: 5 * time.Millisecond, Seed: 7}, config)
+
+	config, err = ParseFaultConfig("")
+	assert.Nil(t, err)
+	assert.Equal(t, FaultConfig{}, config)
+
+	for _, spec := range []string{"get", "get=x", "set=1.5", "iterate=-0.1", "latency=5", "disk=0.1"} {
+		_, err = ParseFaultConfig(spec)
+		assert.NotNil(t, err, spec)
+	}
+}
+
+func Test_FaultyDB_NoFaults(t *testing.T) {
+	faulty := NewFaultyDB(helper_OpenDb(t), FaultConfig{})
+	err := faulty.Update(func(txn Txn) error {
+		return txn.Set([]byte("/key/a"), []byte("value"))
+	})
+	assert.Nil(t, err)
+	err = faulty.View(func(txn Txn) error {
+		item, err := txn.Get([]byte("/key/a"))
+		if err != nil {
+			return err
+		}
+		_, err = item.ValueCopy(nil)
+		return err
+	})
+	assert.Nil(t, err)
+}
+
+func Test_FaultyDB_AlwaysFails(t *testing.T) {
+	db := helper_OpenDb(t)
+	err := db.Update(func(txn Txn) error {
+		return txn.Set([]byte("/key/a"), []byte("value"))
+	})
+	assert.Nil(t, err)
+	faulty := NewFaultyDB(db, FaultConfig{GetErrorRate: 1, SetErrorRate: 1, IterateErrorRate: 1, CommitErrorRate: 1})
+
+	err = faulty.View(func(txn Txn) error {
+		_, err := txn.Get([]byte("/key/a"))
+		return err
+	})
+	assert.Equal(t, ErrInjectedFault, err)
+	err = faulty.Update(func(txn Txn) error {
+		return txn.Delete([]byte("/key/a"))
+	})
+	assert.Equal(t, ErrInjectedFault, err)
+	// Nothing failed in the function, so the commit does
+	err = faulty.Update(func(txn Txn) error { return nil })
+	assert.Equal(t, ErrInjectedFault, err)
+
+	err = faulty.View(func(txn Txn) error {
+		itr := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer itr.Close()
+		itr.Rewind()
+		assert.True(t, itr.Valid())
+		assert.Equal(t, "/key/a", string(itr.Item().Key()))
+		return itr.Item().Value(func(val []byte) error { return nil })
+	})
+	assert.Equal(t, ErrInjectedFault, err)
+}
+
+func Test_FaultyDB_Rate(t *testing.T) {
+	db := helper_OpenDb(t)
+	err := db.Update(func(txn Txn) error {
+		return txn.Set([]byte("/key/a"), []byte("value"))
+	})
+	assert.Nil(t, err)
+	faulty := NewFaultyDB(db, FaultConfig{GetErrorRate: 0.5, Seed: 1})
+
+	failed := 0
+	for i := 0; i < 1000; i++ {
+		err := faulty.View(func(txn Txn) error {
+			_, err := txn.Get([]byte("/key/a"))
+			return err
+		})
+		if err == ErrInjectedFault {
+			failed++
+		}
+	}
+	assert.InDelta(t, 500, failed, 100, fmt.Sprint(failed))
+}
+
+func Test_FaultyDB_Latency(t *testing.T) {
+	faulty := NewFaultyDB(helper_OpenDb(t), FaultConfig{Latency: 20 * time.Millisecond})
+	before := time.Now()
+	err := faulty.Update(func(txn Txn) error {
+		return txn.Set([]byte("/key/a"), []byte("value"))
+	})
+	assert.Nil(t, err)
+	// The set and the commit
+	assert.True(t, time.Since(before) >= 40*time.Millisecond)
+}
@@ -0,0 +1,117 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package badgerwrap
+
+import (
+	"context"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"golang.org/x/time/rate"
+)
+
+var metricPacedWaitSeconds = promauto.NewCounter(prometheus.CounterOpts{Name: "sloop_paced_read_wait_seconds"})
+
+/*
+Wraps a DB so reads done in View transactions are paced to a ceiling of keys per second and bytes per second, shared
+by all transactions of the DB.  Every key an iterator moves to and every Get count as one key and the estimated size
+of the item.  Update transactions and the other DB operations are not paced.
+
+This is meant for the query side, so heavy interactive reads can not hold Badger long enough to starve ingestion
+writes.  Ingestion should keep using the unwrapped DB
+*/
+type PacedDB struct {
+	DB
+	pacer *pacer
+}
+
+// A limit of 0 leaves that dimension unlimited
+func NewPacedDB(db DB, maxKeysPerSec int, maxBytesPerSec int) *PacedDB {
+	p := &pacer{}
+	if maxKeysPerSec > 0 {
+		p.keys = rate.NewLimiter(rate.Limit(maxKeysPerSec), maxKeysPerSec)
+	}
+	if maxBytesPerSec > 0 {
+		p.bytes = rate.NewLimiter(rate.Limit(maxBytesPerSec), maxBytesPerSec)
+	}
+	return &PacedDB{DB: db, pacer: p}
+}
+
+func (b *PacedDB) View(fn func(txn Txn) error) error {
+	return b.DB.View(func(txn Txn) error {
+		return fn(&pacedTxn{Txn: txn, pacer: b.pacer})
+	})
+}
+
+type pacer struct {
+	keys  *rate.Limiter
+	bytes *rate.Limiter
+}
+
+func (p *pacer) wait(size int64) {
+	if p.keys == nil && p.bytes == nil {
+		return
+	}
+	before := time.Now()
+	if p.keys != nil {
+		_ = p.keys.Wait(context.Background())
+	}
+	if p.bytes != nil && size > 0 {
+		// WaitN fails for more than the burst, and the burst is one second worth
+		if size > int64(p.bytes.Burst()) {
+			size = int64(p.bytes.Burst())
+		}
+		_ = p.bytes.WaitN(context.Background(), int(size))
+	}
+	metricPacedWaitSeconds.Add(time.Since(before).Seconds())
+}
+
+type pacedTxn struct {
+	Txn
+	pacer *pacer
+}
+
+func (t *pacedTxn) Get(key []byte) (Item, error) {
+	item, err := t.Txn.Get(key)
+	if err == nil {
+		t.pacer.wait(item.EstimatedSize())
+	}
+	return item, err
+}
+
+func (t *pacedTxn) NewIterator(opt badger.IteratorOptions) Iterator {
+	return &pacedIterator{Iterator: t.Txn.NewIterator(opt), pacer: t.pacer}
+}
+
+type pacedIterator struct {
+	Iterator
+	pacer *pacer
+}
+
+func (i *pacedIterator) pace() {
+	if i.Iterator.Valid() {
+		i.pacer.wait(i.Iterator.Item().EstimatedSize())
+	}
+}
+
+func (i *pacedIterator) Next() {
+	i.Iterator.Next()
+	i.pace()
+}
+
+func (i *pacedIterator) Seek(key []byte) {
+	i.Iterator.Seek(key)
+	i.pace()
+}
+
+func (i *pacedIterator) Rewind() {
+	i.Iterator.Rewind()
+	i.pace()
+}
@@ -0,0 +1,75 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package badgerwrap
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func helper_countKeys(t *testing.T, db DB) int {
+	count := 0
+	err := db.View(func(txn Txn) error {
+		itr := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer itr.Close()
+		for itr.Rewind(); itr.Valid(); itr.Next() {
+			count++
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+	return count
+}
+
+func Test_PacedDB_KeysPerSecond(t *testing.T) {
+	db := helper_OpenDb(t)
+	paced := NewPacedDB(db, 20, 0)
+	before := time.Now()
+	err := paced.Update(func(txn Txn) error {
+		for i := 0; i < 25; i++ {
+			err := txn.Set([]byte(fmt.Sprintf("/key/%02d", i)), []byte("value"))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.True(t, time.Since(before) < 100*time.Millisecond)
+
+	before = time.Now()
+	assert.Equal(t, 25, helper_countKeys(t, paced))
+	// The first 20 keys are the burst, the other 5 wait 50ms each
+	assert.True(t, time.Since(before) >= 200*time.Millisecond)
+
+	before = time.Now()
+	assert.Equal(t, 25, helper_countKeys(t, NewPacedDB(db, 0, 0)))
+	assert.True(t, time.Since(before) < 100*time.Millisecond)
+}
+
+func Test_PacedDB_BytesPerSecond(t *testing.T) {
+	db := helper_OpenDb(t)
+	// 16 bytes with the key
+	helper_Set(t, db, []byte("/key/1"), make([]byte, 10))
+	paced := NewPacedDB(db, 0, 64)
+
+	before := time.Now()
+	for i := 0; i < 6; i++ {
+		err := paced.View(func(txn Txn) error {
+			_, err := txn.Get([]byte("/key/1"))
+			return err
+		})
+		assert.Nil(t, err)
+	}
+	// The first 4 reads are the burst, the other 2 wait 250ms each
+	assert.True(t, time.Since(before) >= 450*time.Millisecond)
+}
@@ -11,6 +11,8 @@ import (
 	"fmt"
 	"strconv"
 	"time"
+
+	"github.com/salesforce/sloop/pkg/sloop/common"
 )
 
 // For now we want the ability to try different durations, and this can not change during runtime
@@ -67,7 +69,7 @@ func GetAgeOfPartitionInHours(partitionId string) (float64, error) {
 	}
 
 	nanosecondsInAnHour := time.Duration(60 * 60 * 1000000000)
-	return float64(time.Now().Sub(timeForPartition) / nanosecondsInAnHour), nil
+	return float64(common.Now().Sub(timeForPartition) / nanosecondsInAnHour), nil
 }
 
 func TestHookSetPartitionDuration(partDuration time.Duration) {
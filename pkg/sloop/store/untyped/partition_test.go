@@ -8,6 +8,7 @@
 package untyped
 
 import (
+	"github.com/salesforce/sloop/pkg/sloop/common"
 	"github.com/stretchr/testify/assert"
 	"testing"
 	"time"
@@ -34,3 +35,11 @@ func Test_PartitionsRoundTrip_Day(t *testing.T) {
 	assert.Equal(t, someTsRoundedDay, minTs)
 	assert.Equal(t, someTsRoundedDay.Add(24*time.Hour), maxTs)
 }
+
+func Test_GetAgeOfPartitionInHours_FrozenClock(t *testing.T) {
+	TestHookSetPartitionDuration(time.Hour)
+	defer common.SetClock(common.SetClock(common.NewFrozenClock(someTs.Add(5 * time.Hour))))
+	age, err := GetAgeOfPartitionInHours(GetPartitionId(someTs))
+	assert.Nil(t, err)
+	assert.Equal(t, float64(5), age)
+}
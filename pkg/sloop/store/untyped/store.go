@@ -35,6 +35,8 @@ type Config struct {
 	BadgerVLogFileIOMapping  bool
 	BadgerDetailLogEnabled   bool
 	BadgerVLogTruncate       bool
+	// Values are checked against their checksum on every read, a recovery uses this to find the damaged ones
+	BadgerVerifyValueChecksum bool
 }
 
 func OpenStore(factory badgerwrap.Factory, config *Config) (badgerwrap.DB, error) {
@@ -102,6 +104,10 @@ func OpenStore(factory badgerwrap.Factory, config *Config) (badgerwrap.DB, error
 
 	opts = opts.WithTruncate(config.BadgerVLogTruncate)
 
+	if config.BadgerVerifyValueChecksum {
+		opts = opts.WithVerifyValueChecksum(true)
+	}
+
 	opts = opts.WithSyncWrites(config.BadgerSyncWrites)
 
 	db, err := factory.Open(opts)
@@ -0,0 +1,96 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package storemanager
+
+import (
+	"expvar"
+	"strconv"
+
+	"github.com/dgraph-io/badger/v2/y"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+/*
+Exports the metrics Badger keeps in expvar, which only show up on /debug/vars otherwise, as sloop_badger_* at scrape
+time.  They are per process, so the ones that are not maps keyed by the store or level add up every store sloop has
+opened.  This version of Badger has no block cache and does not count compactions, see sloop_badger_max_table_id
+for the rate tables are written at
+*/
+type badgerCollector struct {
+	counters map[*prometheus.Desc]*expvar.Int
+	// Keyed by level
+	levelCounters map[*prometheus.Desc]*expvar.Map
+	// Keyed by store directory
+	dirGauges map[*prometheus.Desc]*expvar.Map
+}
+
+func init() {
+	prometheus.MustRegister(newBadgerCollector())
+}
+
+func newBadgerCollector() *badgerCollector {
+	counter := func(name string) *prometheus.Desc {
+		return prometheus.NewDesc(name, "", nil, nil)
+	}
+	return &badgerCollector{
+		counters: map[*prometheus.Desc]*expvar.Int{
+			counter("sloop_badger_disk_reads_total"):    y.NumReads,
+			counter("sloop_badger_disk_writes_total"):   y.NumWrites,
+			counter("sloop_badger_read_bytes_total"):    y.NumBytesRead,
+			counter("sloop_badger_written_bytes_total"): y.NumBytesWritten,
+			counter("sloop_badger_gets_total"):          y.NumGets,
+			counter("sloop_badger_puts_total"):          y.NumPuts,
+			counter("sloop_badger_blocked_puts_total"):  y.NumBlockedPuts,
+			counter("sloop_badger_memtable_gets_total"): y.NumMemtableGets,
+		},
+		levelCounters: map[*prometheus.Desc]*expvar.Map{
+			prometheus.NewDesc("sloop_badger_lsm_level_gets_total", "", []string{"level"}, nil): y.NumLSMGets,
+			prometheus.NewDesc("sloop_badger_lsm_bloom_hits_total", "", []string{"level"}, nil): y.NumLSMBloomHits,
+		},
+		dirGauges: map[*prometheus.Desc]*expvar.Map{
+			prometheus.NewDesc("sloop_badger_lsm_size_bytes", "", []string{"dir"}, nil):  y.LSMSize,
+			prometheus.NewDesc("sloop_badger_vlog_size_bytes", "", []string{"dir"}, nil): y.VlogSize,
+			prometheus.NewDesc("sloop_badger_pending_writes", "", []string{"dir"}, nil):  y.PendingWrites,
+		},
+	}
+}
+
+func (c *badgerCollector) Describe(ch chan<- *prometheus.Desc) {
+	for desc := range c.counters {
+		ch <- desc
+	}
+	for desc := range c.levelCounters {
+		ch <- desc
+	}
+	for desc := range c.dirGauges {
+		ch <- desc
+	}
+}
+
+func (c *badgerCollector) Collect(ch chan<- prometheus.Metric) {
+	for desc, value := range c.counters {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, float64(value.Value()))
+	}
+	for desc, values := range c.levelCounters {
+		collectExpvarMap(ch, desc, prometheus.CounterValue, values)
+	}
+	for desc, values := range c.dirGauges {
+		collectExpvarMap(ch, desc, prometheus.GaugeValue, values)
+	}
+}
+
+// Values that are not numbers are skipped
+func collectExpvarMap(ch chan<- prometheus.Metric, desc *prometheus.Desc, valueType prometheus.ValueType, values *expvar.Map) {
+	values.Do(func(kv expvar.KeyValue) {
+		value, err := strconv.ParseFloat(kv.Value.String(), 64)
+		if err != nil {
+			return
+		}
+		ch <- prometheus.MustNewConstMetric(desc, valueType, value, kv.Key)
+	})
+}
@@ -0,0 +1,34 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package storemanager
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2/y"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_badgerCollector(t *testing.T) {
+	y.NumLSMGets.Add("l1", 3)
+	y.LSMSize.Add("/data/store", 1024)
+
+	ch := make(chan prometheus.Metric, 100)
+	newBadgerCollector().Collect(ch)
+	close(ch)
+	names := map[string]bool{}
+	for metric := range ch {
+		desc := metric.Desc().String()
+		names[desc[strings.Index(desc, `"`)+1:strings.Index(desc, `",`)]] = true
+	}
+	assert.True(t, names["sloop_badger_gets_total"])
+	assert.True(t, names["sloop_badger_lsm_level_gets_total"])
+	assert.True(t, names["sloop_badger_lsm_size_bytes"])
+}
@@ -0,0 +1,232 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package storemanager
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+// The time range of the periodic budget log and the default of the endpoint
+const BudgetReportWindow = 24 * time.Hour
+
+// Kinds listed in the periodic budget log, the endpoint has all of them
+const budgetLogTopKinds = 10
+
+type BudgetUsage struct {
+	// Watch results that reached processing, before node dedupe, sampling and event folding
+	Received int64 `json:"received"`
+	// Received with the same resourceVersion as the version before, which is mostly informer resyncs
+	Unchanged int64 `json:"unchanged"`
+	// Watch results written to the watch table and the estimated size of their keys and values
+	Stored          int64   `json:"stored"`
+	StoredBytes     int64   `json:"storedBytes"`
+	ReceivedPerHour float64 `json:"receivedPerHour"`
+	// Share of the received watch results that were not stored
+	DedupRatio float64 `json:"dedupRatio"`
+}
+
+type NamespaceBudget struct {
+	Namespace string `json:"namespace"`
+	BudgetUsage
+}
+
+type KindBudget struct {
+	Kind string `json:"kind"`
+	BudgetUsage
+	// Largest stored bytes first
+	Namespaces []NamespaceBudget `json:"namespaces"`
+}
+
+type BudgetReport struct {
+	From  int64       `json:"from"`
+	To    int64       `json:"to"`
+	Total BudgetUsage `json:"total"`
+	// Largest stored bytes first
+	Kinds []KindBudget `json:"kinds"`
+}
+
+type budgetId struct {
+	kind      string
+	namespace string
+}
+
+/*
+Breaks down how much each kind and namespace sent and stored between now-window and now.  Received comes from the
+watch activity table, which has every watch result apart from events.  Events have no watch activity, so for them
+received is what was stored plus the events folded into an earlier one, counted in the namespace of the involved
+object.  Stored bytes read only the keys, so this is cheap enough to run every few hours on a large store
+*/
+func GenerateBudgetReport(tables typed.Tables, now time.Time, window time.Duration) (*BudgetReport, error) {
+	if window <= 0 {
+		return nil, fmt.Errorf("window must be positive: %v", window)
+	}
+	startTime := now.Add(-window)
+	usage := map[budgetId]*BudgetUsage{}
+	get := func(kind string, namespace string) *BudgetUsage {
+		id := budgetId{kind: kind, namespace: namespace}
+		if usage[id] == nil {
+			usage[id] = &BudgetUsage{}
+		}
+		return usage[id]
+	}
+	inWindow := func(ts int64) bool {
+		return ts >= startTime.Unix() && ts <= now.Unix()
+	}
+
+	err := tables.Db().View(func(txn badgerwrap.Txn) error {
+		activities, _, err := tables.WatchActivityTable().RangeRead(txn, nil, func(string) bool { return true }, func(*typed.WatchActivity) bool { return true }, startTime, now)
+		if err != nil {
+			return errors.Wrap(err, "failed to read watch activity")
+		}
+		for key, activity := range activities {
+			u := get(key.Kind, key.Namespace)
+			for _, ts := range activity.ChangedAt {
+				if inWindow(ts) {
+					u.Received++
+				}
+			}
+			for _, ts := range activity.NoChangeAt {
+				if inWindow(ts) {
+					u.Received++
+					u.Unchanged++
+				}
+			}
+		}
+
+		folds, _, err := tables.EventFoldTable().RangeRead(txn, nil, func(string) bool { return true }, func(*typed.EventFold) bool { return true }, startTime, now)
+		if err != nil {
+			return errors.Wrap(err, "failed to read event folds")
+		}
+		for key, fold := range folds {
+			for name, member := range fold.Events {
+				firstTs, err := ptypes.Timestamp(member.FirstTimestamp)
+				if name == fold.FirstEventName || err != nil || !inWindow(firstTs.Unix()) {
+					continue
+				}
+				get(kubeextractor.EventKind, key.Namespace).Received++
+			}
+		}
+
+		partitions, err := tables.WatchTable().GetPartitionsFromTimeRange(txn, startTime, now)
+		if err != nil {
+			return err
+		}
+		for _, partitionId := range partitions {
+			err = addStoredWatchResults(txn, partitionId, inWindow, get)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	report := &BudgetReport{From: startTime.Unix(), To: now.Unix(), Kinds: []KindBudget{}}
+	kinds := map[string]*KindBudget{}
+	for id, u := range usage {
+		if id.kind == kubeextractor.EventKind {
+			u.Received += u.Stored
+		}
+		u.finish(window)
+		if kinds[id.kind] == nil {
+			kinds[id.kind] = &KindBudget{Kind: id.kind}
+		}
+		kinds[id.kind].Namespaces = append(kinds[id.kind].Namespaces, NamespaceBudget{Namespace: id.namespace, BudgetUsage: *u})
+		kinds[id.kind].add(u)
+		report.Total.add(u)
+	}
+	for _, kind := range kinds {
+		kind.finish(window)
+		sort.Slice(kind.Namespaces, func(i, j int) bool {
+			if kind.Namespaces[i].StoredBytes != kind.Namespaces[j].StoredBytes {
+				return kind.Namespaces[i].StoredBytes > kind.Namespaces[j].StoredBytes
+			}
+			return kind.Namespaces[i].Namespace < kind.Namespaces[j].Namespace
+		})
+		report.Kinds = append(report.Kinds, *kind)
+	}
+	report.Total.finish(window)
+	sort.Slice(report.Kinds, func(i, j int) bool {
+		if report.Kinds[i].StoredBytes != report.Kinds[j].StoredBytes {
+			return report.Kinds[i].StoredBytes > report.Kinds[j].StoredBytes
+		}
+		return report.Kinds[i].Kind < report.Kinds[j].Kind
+	})
+	return report, nil
+}
+
+func addStoredWatchResults(txn badgerwrap.Txn, partitionId string, inWindow func(int64) bool, get func(string, string) *BudgetUsage) error {
+	prefix := []byte(fmt.Sprintf("/%v/%v/", (&typed.WatchTableKey{}).TableName(), partitionId))
+	iterOpt := badger.DefaultIteratorOptions
+	iterOpt.PrefetchValues = false
+	iterOpt.Prefix = prefix
+	itr := txn.NewIterator(iterOpt)
+	defer itr.Close()
+	for itr.Seek(prefix); itr.ValidForPrefix(prefix); itr.Next() {
+		key := &typed.WatchTableKey{}
+		err := key.Parse(string(itr.Item().Key()))
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse watch key %v", string(itr.Item().Key()))
+		}
+		if !inWindow(key.Timestamp.Unix()) {
+			continue
+		}
+		u := get(key.Kind, key.Namespace)
+		u.Stored++
+		u.StoredBytes += itr.Item().EstimatedSize()
+	}
+	return nil
+}
+
+func (u *BudgetUsage) add(other *BudgetUsage) {
+	u.Received += other.Received
+	u.Unchanged += other.Unchanged
+	u.Stored += other.Stored
+	u.StoredBytes += other.StoredBytes
+}
+
+func (u *BudgetUsage) finish(window time.Duration) {
+	u.ReceivedPerHour = float64(u.Received) / window.Hours()
+	if u.Received > 0 && u.Stored < u.Received {
+		u.DedupRatio = 1 - float64(u.Stored)/float64(u.Received)
+	}
+}
+
+// One line for the whole store and one per kind with the most stored bytes
+func logBudgetReport(report *BudgetReport) {
+	glog.Infof("Budget report for the last %v: received %v (%.1f/h), stored %v using %v bytes, dedup ratio %.2f",
+		time.Duration(report.To-report.From)*time.Second, report.Total.Received, report.Total.ReceivedPerHour, report.Total.Stored, report.Total.StoredBytes, report.Total.DedupRatio)
+	for idx, kind := range report.Kinds {
+		if idx >= budgetLogTopKinds {
+			break
+		}
+		namespaces := []string{}
+		for _, namespace := range kind.Namespaces {
+			if len(namespaces) >= 3 {
+				break
+			}
+			namespaces = append(namespaces, fmt.Sprintf("%q=%v", namespace.Namespace, namespace.StoredBytes))
+		}
+		glog.Infof("Budget report kind %v: received %v (%.1f/h), unchanged %v, stored %v using %v bytes, dedup ratio %.2f, top namespaces by bytes %v",
+			kind.Kind, kind.Received, kind.ReceivedPerHour, kind.Unchanged, kind.Stored, kind.StoredBytes, kind.DedupRatio, strings.Join(namespaces, " "))
+	}
+}
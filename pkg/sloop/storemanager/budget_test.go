@@ -0,0 +1,89 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package storemanager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+func Test_GenerateBudgetReport(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	now := someTs.Add(2 * time.Hour)
+	old := someTs.Add(-time.Hour)
+	partitionId := untyped.GetPartitionId(someTs)
+
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		// Three resyncs of a pod of which only the change was stored
+		podActivity := &typed.WatchActivity{ChangedAt: []int64{old.Unix(), someTs.Unix()}, NoChangeAt: []int64{someTs.Unix() + 1, someTs.Unix() + 2, someTs.Unix() + 3}}
+		err := tables.WatchActivityTable().Set(txn, typed.NewWatchActivityKey(partitionId, "Pod", "ns", "p", "uid").String(), podActivity)
+		if err != nil {
+			return err
+		}
+		for _, key := range []*typed.WatchTableKey{
+			typed.NewWatchTableKey(partitionId, "Pod", "ns", "p", someTs),
+			typed.NewWatchTableKey(untyped.GetPartitionId(old), "Pod", "ns", "p", old),
+			typed.NewWatchTableKey(partitionId, "Node", "", "n", someTs),
+			typed.NewWatchTableKey(partitionId, "Event", "ns", "e1", someTs),
+		} {
+			err = tables.WatchTable().Set(txn, key.String(), &typed.KubeWatchResult{Kind: key.Kind, Payload: `{"metadata": {"name": "x"}}`})
+			if err != nil {
+				return err
+			}
+		}
+		err = tables.WatchActivityTable().Set(txn, typed.NewWatchActivityKey(partitionId, "Node", "", "n", "uid").String(), &typed.WatchActivity{ChangedAt: []int64{someTs.Unix()}})
+		if err != nil {
+			return err
+		}
+		// e2 was folded into e1 and never stored
+		pts, _ := ptypes.TimestampProto(someTs)
+		fold := &typed.EventFold{FirstEventName: "e1", Events: map[string]*typed.FoldedEvent{"e1": {FirstTimestamp: pts}, "e2": {FirstTimestamp: pts}}}
+		return tables.EventFoldTable().Set(txn, typed.NewEventFoldKey(partitionId, "Pod", "ns", "p", "BackOff", "hash", someTs).String(), fold)
+	})
+	assert.Nil(t, err)
+
+	report, err := GenerateBudgetReport(tables, now, 2*time.Hour)
+	assert.Nil(t, err)
+	assert.Equal(t, someTs.Unix(), report.From)
+	assert.Equal(t, 3, len(report.Kinds))
+	assert.Equal(t, int64(7), report.Total.Received)
+	assert.Equal(t, int64(3), report.Total.Stored)
+
+	kinds := map[string]KindBudget{}
+	for _, kind := range report.Kinds {
+		kinds[kind.Kind] = kind
+	}
+	pod := kinds["Pod"]
+	assert.Equal(t, int64(4), pod.Received)
+	assert.Equal(t, int64(3), pod.Unchanged)
+	assert.Equal(t, int64(1), pod.Stored)
+	assert.True(t, pod.StoredBytes > 0)
+	assert.Equal(t, 2.0, pod.ReceivedPerHour)
+	assert.Equal(t, 0.75, pod.DedupRatio)
+	assert.Equal(t, []NamespaceBudget{{Namespace: "ns", BudgetUsage: pod.BudgetUsage}}, pod.Namespaces)
+
+	event := kinds["Event"]
+	assert.Equal(t, int64(2), event.Received)
+	assert.Equal(t, int64(1), event.Stored)
+	assert.Equal(t, 0.5, event.DedupRatio)
+	assert.Equal(t, 0.0, kinds["Node"].DedupRatio)
+
+	_, err = GenerateBudgetReport(tables, now, 0)
+	assert.NotNil(t, err)
+}
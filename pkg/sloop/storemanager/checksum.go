@@ -0,0 +1,113 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package storemanager
+
+import (
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+var metricChecksumComputedCount = promauto.NewCounter(prometheus.CounterOpts{Name: "sloop_checksum_computed_count"})
+
+// Tables that are still rewritten after their partition closes.  Current states move to the partition of the newest
+// watch result, ingest annotations merge into the last one of their kind, and soft deleted keys come and go with purges
+var checksumSkippedTables = map[string]bool{
+	(&typed.CurrentStateKey{}).TableName():     true,
+	(&typed.IngestAnnotationKey{}).TableName(): true,
+	(&typed.PurgedKey{}).TableName():           true,
+}
+
+/*
+Stores the checksums of the tables of every partition with watch results that is closed, which is every partition
+before the one of now, and does not have them yet.  A partition whose watch table has a checksum is done.  Each
+partition is checksummed in one update, so a write to it at the same time makes the update conflict and it is
+done again on the next run.  Returns the number of checksums stored
+*/
+func ChecksumClosedPartitions(tables typed.Tables, now time.Time) (int, error) {
+	var partitions []string
+	err := tables.Db().View(func(txn badgerwrap.Txn) error {
+		var err error
+		partitions, err = tables.WatchTable().GetUniquePartitionList(txn)
+		return err
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to list partitions")
+	}
+
+	checksumTable := typed.OpenChecksumTable()
+	watchTableName := (&typed.WatchTableKey{}).TableName()
+	currentPartition := untyped.GetPartitionId(now)
+	computed := 0
+	for _, partition := range partitions {
+		if partition >= currentPartition {
+			break
+		}
+		count := 0
+		err = tables.Db().Update(func(txn badgerwrap.Txn) error {
+			existing, err := checksumTable.Get(txn, partition, watchTableName)
+			if err != nil || existing != nil {
+				return err
+			}
+			for _, tableName := range tables.GetTableNames() {
+				if checksumSkippedTables[tableName] {
+					continue
+				}
+				checksum, err := typed.ComputePartitionChecksum(txn, tableName, partition)
+				if err != nil {
+					return err
+				}
+				if checksum.Keys == 0 {
+					continue
+				}
+				err = checksumTable.Set(txn, checksum)
+				if err != nil {
+					return err
+				}
+				count++
+			}
+			return nil
+		})
+		if err != nil {
+			return computed, errors.Wrapf(err, "failed to checksum partition %v", partition)
+		}
+		if count > 0 {
+			glog.Infof("Stored checksums of %v tables of closed partition %v", count, partition)
+		}
+		computed += count
+		metricChecksumComputedCount.Add(float64(count))
+	}
+	return computed, nil
+}
+
+// Computes the checksum of a table again after keys were deleted from its closed partition.  Tables without a
+// checksum are left for ChecksumClosedPartitions
+func updateChecksum(db badgerwrap.DB, partition string, tableName string) error {
+	checksumTable := typed.OpenChecksumTable()
+	return db.Update(func(txn badgerwrap.Txn) error {
+		existing, err := checksumTable.Get(txn, partition, tableName)
+		if err != nil || existing == nil {
+			return err
+		}
+		checksum, err := typed.ComputePartitionChecksum(txn, tableName, partition)
+		if err != nil {
+			return err
+		}
+		if checksum.Keys == 0 {
+			return checksumTable.Delete(txn, partition, tableName)
+		}
+		return checksumTable.Set(txn, checksum)
+	})
+}
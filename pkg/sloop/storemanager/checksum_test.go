@@ -0,0 +1,77 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package storemanager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+func Test_ChecksumClosedPartitions(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	closed := untyped.GetPartitionId(someTs)
+	open := untyped.GetPartitionId(someTs.Add(time.Hour))
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		for _, key := range []string{
+			typed.NewWatchTableKey(closed, "Pod", "ns", "pod", someTs).String(),
+			typed.NewResourceSummaryKey(someTs, "Pod", "ns", "pod", "uid").String(),
+			typed.NewCurrentStateKey(closed, "Pod", "ns", "pod", "uid").String(),
+			typed.NewWatchTableKey(open, "Pod", "ns", "pod", someTs.Add(time.Hour)).String(),
+		} {
+			err := txn.Set([]byte(key), []byte("value"))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+
+	computed, err := ChecksumClosedPartitions(tables, someTs.Add(time.Hour))
+	assert.Nil(t, err)
+	// Current states are rewritten after their partition closes, and the newest partition is still open
+	assert.Equal(t, 2, computed)
+	computed, err = ChecksumClosedPartitions(tables, someTs.Add(time.Hour))
+	assert.Nil(t, err)
+	assert.Equal(t, 0, computed)
+
+	var checksums []*typed.PartitionChecksum
+	err = db.View(func(txn badgerwrap.Txn) error {
+		checksums, err = typed.OpenChecksumTable().ReadAll(txn)
+		return err
+	})
+	assert.Nil(t, err)
+	assert.Len(t, checksums, 2)
+	assert.Equal(t, "ressum", checksums[0].Table)
+	assert.Equal(t, "watch", checksums[1].Table)
+	assert.Equal(t, closed, checksums[1].Partition)
+
+	// Deleting keys of a closed partition updates its checksum, so it still verifies
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		return txn.Delete([]byte(typed.NewResourceSummaryKey(someTs, "Pod", "ns", "pod", "uid").String()))
+	})
+	assert.Nil(t, err)
+	report, err := typed.VerifyPartitionChecksums(db)
+	assert.Nil(t, err)
+	assert.Len(t, report.Mismatches, 1)
+	assert.Nil(t, updateChecksum(db, closed, "ressum"))
+	report, err = typed.VerifyPartitionChecksums(db)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, report.Checked)
+	assert.Empty(t, report.Mismatches)
+}
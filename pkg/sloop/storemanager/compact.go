@@ -0,0 +1,117 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package storemanager
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/spf13/afero"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+var metricCompactionRunCount = promauto.NewCounter(prometheus.CounterOpts{Name: "sloop_compaction_run_count"})
+
+// Upper bound on value log GC runs after a flatten, each run rewrites at most one value log file
+const maxCompactionValueLogGCRuns = 100
+
+type LevelSize struct {
+	Level      int    `json:"level"`
+	TableCount int    `json:"tableCount"`
+	KeyCount   uint64 `json:"keyCount"`
+	SizeBytes  uint64 `json:"sizeBytes"`
+}
+
+type CompactionReport struct {
+	Elapsed         string      `json:"elapsed"`
+	ValueLogGCRuns  int         `json:"valueLogGCRuns"`
+	BeforeLevels    []LevelSize `json:"beforeLevels"`
+	AfterLevels     []LevelSize `json:"afterLevels"`
+	BeforeDiskBytes int64       `json:"beforeDiskBytes"`
+	AfterDiskBytes  int64       `json:"afterDiskBytes"`
+}
+
+/*
+Flattens the Badger LSM tree into one level and then runs value log GC until it has nothing left to rewrite.  Badger
+compacts in the background anyway, but after a large purge it can take a long time before the space of the removed
+keys is reclaimed on disk.  Only one compaction runs at a time
+*/
+type Compactor struct {
+	db                 badgerwrap.DB
+	storeRoot          string
+	fs                 *afero.Afero
+	badgerDiscardRatio float64
+	lock               *sync.Mutex
+}
+
+func NewCompactor(db badgerwrap.DB, storeRoot string, fs *afero.Afero, badgerDiscardRatio float64) *Compactor {
+	return &Compactor{db: db, storeRoot: storeRoot, fs: fs, badgerDiscardRatio: badgerDiscardRatio, lock: &sync.Mutex{}}
+}
+
+func (c *Compactor) Compact(workers int) (*CompactionReport, error) {
+	if workers < 1 {
+		return nil, fmt.Errorf("workers must be at least 1: %v", workers)
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	metricCompactionRunCount.Inc()
+	before := time.Now()
+	report := &CompactionReport{}
+	report.BeforeLevels, report.BeforeDiskBytes = c.levelSizes()
+
+	err := c.db.Flatten(workers)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to flatten store")
+	}
+	for report.ValueLogGCRuns < maxCompactionValueLogGCRuns {
+		// Returns an error once there is nothing left to rewrite
+		if c.db.RunValueLogGC(c.badgerDiscardRatio) != nil {
+			break
+		}
+		report.ValueLogGCRuns++
+	}
+
+	report.AfterLevels, report.AfterDiskBytes = c.levelSizes()
+	report.Elapsed = time.Since(before).String()
+	glog.Infof("Compaction with %v workers took %v.  Disk size went from %v to %v bytes", workers, report.Elapsed, report.BeforeDiskBytes, report.AfterDiskBytes)
+	return report, nil
+}
+
+func (c *Compactor) levelSizes() ([]LevelSize, int64) {
+	byLevel := map[int]*LevelSize{}
+	for _, table := range c.db.Tables(true) {
+		size, ok := byLevel[table.Level]
+		if !ok {
+			size = &LevelSize{Level: table.Level}
+			byLevel[table.Level] = size
+		}
+		size.TableCount++
+		size.KeyCount += table.KeyCount
+		size.SizeBytes += table.EstimatedSz
+	}
+	levels := []LevelSize{}
+	for _, size := range byLevel {
+		levels = append(levels, *size)
+	}
+	sort.Slice(levels, func(i, j int) bool { return levels[i].Level < levels[j].Level })
+
+	diskSize, _, _, err := getDirSizeRecursive(c.storeRoot, c.fs)
+	if err != nil {
+		// Swallowing on purpose as the level sizes are still useful
+		glog.Errorf("Failed to check storage size on disk: %v", err)
+	}
+	return levels, int64(diskSize)
+}
@@ -0,0 +1,44 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package storemanager
+
+import (
+	"path"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+func Test_Compactor_ReportsLevelsAndDiskSize(t *testing.T) {
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		return txn.Set(testKey, testValue1)
+	})
+	assert.Nil(t, err)
+	fs := &afero.Afero{Fs: afero.NewMemMapFs()}
+	fs.MkdirAll(someDir, 0700)
+	fs.WriteFile(path.Join(someDir, "000010.vlog"), []byte("aaaa"), 0700)
+
+	compactor := NewCompactor(db, someDir, fs, 0.5)
+	_, err = compactor.Compact(0)
+	assert.NotNil(t, err)
+
+	report, err := compactor.Compact(2)
+	assert.Nil(t, err)
+	assert.Equal(t, []LevelSize{{Level: 0, TableCount: 1, KeyCount: 1}}, report.BeforeLevels)
+	assert.Equal(t, report.BeforeLevels, report.AfterLevels)
+	assert.Equal(t, int64(4), report.BeforeDiskBytes)
+	assert.Equal(t, int64(4), report.AfterDiskBytes)
+	// The mock value log GC never runs out of work
+	assert.Equal(t, maxCompactionValueLogGCRuns, report.ValueLogGCRuns)
+}
@@ -0,0 +1,181 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package storemanager
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+var metricGcFrozenPartitions = promauto.NewGauge(prometheus.GaugeOpts{Name: "sloop_gc_frozen_partitions"})
+
+type FreezeReport struct {
+	Name          string `json:"name"`
+	FromPartition string `json:"fromPartition"`
+	ToPartition   string `json:"toPartition"`
+	// Unix seconds of the start of the first partition and the end of the last one
+	Start   int64  `json:"start"`
+	End     int64  `json:"end"`
+	Reason  string `json:"reason,omitempty"`
+	Created int64  `json:"created"`
+	// Partitions of the range and their keys that are in the store
+	Partitions int    `json:"partitions"`
+	Keys       uint64 `json:"keys"`
+}
+
+/*
+Freezes ranges of partitions for forensics.  GC and tenant retention skip the partitions of every freeze, even past
+the time and size limits, until the freeze is removed with Unfreeze.  Freezes are kept in the freeze table, so they
+outlive restarts.  Purges still remove the keys of a namespace from frozen partitions, since those are asked for
+*/
+type Freezer struct {
+	db    badgerwrap.DB
+	table *typed.FreezeTable
+}
+
+func NewFreezer(db badgerwrap.DB) *Freezer {
+	return &Freezer{db: db, table: typed.OpenFreezeTable()}
+}
+
+// Freezes the partitions from the partition id from to the one to, both included.  Freezing again with the same
+// name replaces the range of the freeze
+func (f *Freezer) Freeze(name string, from string, to string, reason string, now time.Time) (*FreezeReport, error) {
+	if name == "" || strings.Contains(name, "/") {
+		return nil, fmt.Errorf("invalid freeze name %q", name)
+	}
+	for _, partitionId := range []string{from, to} {
+		err := ValidatePartitionId(partitionId)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if from > to {
+		return nil, fmt.Errorf("partition %v is after partition %v", from, to)
+	}
+	freeze := &typed.PartitionFreeze{Name: name, FromPartition: from, ToPartition: to, Reason: reason}
+	freeze.Created, _ = ptypes.TimestampProto(now)
+	err := f.db.Update(func(txn badgerwrap.Txn) error {
+		return f.table.Set(txn, freeze)
+	})
+	if err != nil {
+		return nil, err
+	}
+	glog.Infof("Froze partitions %v to %v as %q: %v", from, to, name, reason)
+	partitionMap, _ := common.GetPartitionsInfo(f.db)
+	return newFreezeReport(freeze, partitionMap), nil
+}
+
+// Returns nil when there was no freeze with the name
+func (f *Freezer) Unfreeze(name string) (*FreezeReport, error) {
+	var freeze *typed.PartitionFreeze
+	err := f.db.Update(func(txn badgerwrap.Txn) error {
+		var err error
+		freeze, err = f.table.Get(txn, name)
+		if err != nil || freeze == nil {
+			return err
+		}
+		return f.table.Delete(txn, name)
+	})
+	if err != nil || freeze == nil {
+		return nil, err
+	}
+	glog.Infof("Unfroze partitions %v to %v of %q", freeze.FromPartition, freeze.ToPartition, name)
+	partitionMap, _ := common.GetPartitionsInfo(f.db)
+	return newFreezeReport(freeze, partitionMap), nil
+}
+
+// Ordered by name
+func (f *Freezer) List() ([]*FreezeReport, error) {
+	freezes, err := readFreezes(f.db)
+	if err != nil {
+		return nil, err
+	}
+	reports := []*FreezeReport{}
+	if len(freezes) == 0 {
+		return reports, nil
+	}
+	partitionMap, _ := common.GetPartitionsInfo(f.db)
+	for _, freeze := range freezes {
+		reports = append(reports, newFreezeReport(freeze, partitionMap))
+	}
+	return reports, nil
+}
+
+// Partition ids are the unix seconds of the start of the partition
+func ValidatePartitionId(partitionId string) error {
+	partitionTime, err := untyped.GetTimeForPartition(partitionId)
+	if err != nil || untyped.GetPartitionId(partitionTime) != partitionId {
+		return fmt.Errorf("invalid partition id %q", partitionId)
+	}
+	return nil
+}
+
+func newFreezeReport(freeze *typed.PartitionFreeze, partitionMap map[string]*common.PartitionInfo) *FreezeReport {
+	report := &FreezeReport{Name: freeze.Name, FromPartition: freeze.FromPartition, ToPartition: freeze.ToPartition, Reason: freeze.Reason}
+	if created, err := ptypes.Timestamp(freeze.Created); err == nil {
+		report.Created = created.Unix()
+	}
+	if start, _, err := untyped.GetTimeRangeForPartition(freeze.FromPartition); err == nil {
+		report.Start = start.Unix()
+	}
+	if _, end, err := untyped.GetTimeRangeForPartition(freeze.ToPartition); err == nil {
+		report.End = end.Unix()
+	}
+	for partitionId, info := range partitionMap {
+		if isFrozen([]*typed.PartitionFreeze{freeze}, partitionId) {
+			report.Partitions++
+			report.Keys += info.TotalKeyCount
+		}
+	}
+	return report
+}
+
+func readFreezes(db badgerwrap.DB) ([]*typed.PartitionFreeze, error) {
+	var freezes []*typed.PartitionFreeze
+	err := db.View(func(txn badgerwrap.Txn) error {
+		var err error
+		freezes, err = typed.OpenFreezeTable().ReadAll(txn)
+		return err
+	})
+	return freezes, err
+}
+
+// Partition ids have the same length, so they compare like their times
+func isFrozen(freezes []*typed.PartitionFreeze, partitionId string) bool {
+	for _, freeze := range freezes {
+		if partitionId >= freeze.FromPartition && partitionId <= freeze.ToPartition {
+			return true
+		}
+	}
+	return false
+}
+
+// The sorted partition ids without the frozen ones
+func withoutFrozenPartitions(sortedPartitionIds []string, freezes []*typed.PartitionFreeze) []string {
+	if len(freezes) == 0 {
+		return sortedPartitionIds
+	}
+	var unfrozen []string
+	for _, partitionId := range sortedPartitionIds {
+		if !isFrozen(freezes, partitionId) {
+			unfrozen = append(unfrozen, partitionId)
+		}
+	}
+	return unfrozen
+}
@@ -0,0 +1,85 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package storemanager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+)
+
+func Test_Freezer_FreezeListUnfreeze(t *testing.T) {
+	oldest := someTs.Add(-3 * time.Hour)
+	older := someTs.Add(-2 * time.Hour)
+	db := help_get_db_with_partitions(t, oldest, older, someTs)
+	freezer := NewFreezer(db)
+
+	_, err := freezer.Freeze("inc/42", untyped.GetPartitionId(oldest), untyped.GetPartitionId(older), "", someTs)
+	assert.NotNil(t, err)
+	_, err = freezer.Freeze("inc-42", untyped.GetPartitionId(older), untyped.GetPartitionId(oldest), "", someTs)
+	assert.NotNil(t, err)
+	_, err = freezer.Freeze("inc-42", "12", untyped.GetPartitionId(oldest), "", someTs)
+	assert.NotNil(t, err)
+
+	report, err := freezer.Freeze("inc-42", untyped.GetPartitionId(oldest), untyped.GetPartitionId(older), "outage of the web tier", someTs)
+	assert.Nil(t, err)
+	assert.Equal(t, &FreezeReport{
+		Name:          "inc-42",
+		FromPartition: untyped.GetPartitionId(oldest),
+		ToPartition:   untyped.GetPartitionId(older),
+		Start:         time.Date(2019, 1, 2, 0, 0, 0, 0, time.UTC).Unix(),
+		End:           time.Date(2019, 1, 2, 2, 0, 0, 0, time.UTC).Unix(),
+		Reason:        "outage of the web tier",
+		Created:       someTs.Unix(),
+		Partitions:    2,
+		Keys:          4,
+	}, report)
+
+	reports, err := freezer.List()
+	assert.Nil(t, err)
+	assert.Equal(t, []*FreezeReport{report}, reports)
+
+	report, err = freezer.Unfreeze("inc-42")
+	assert.Nil(t, err)
+	assert.Equal(t, "inc-42", report.Name)
+	report, err = freezer.Unfreeze("inc-42")
+	assert.Nil(t, err)
+	assert.Nil(t, report)
+	reports, err = freezer.List()
+	assert.Nil(t, err)
+	assert.Len(t, reports, 0)
+}
+
+func Test_getPartitionsToDelete_SkipsFrozenPartitions(t *testing.T) {
+	oldest := someTs.Add(-3 * time.Hour)
+	older := someTs.Add(-2 * time.Hour)
+	db := help_get_db_with_partitions(t, oldest, older, someTs)
+	tables := typed.NewTableList(db)
+	_, err := NewFreezer(db).Freeze("inc-42", untyped.GetPartitionId(oldest), untyped.GetPartitionId(oldest), "", someTs)
+	assert.Nil(t, err)
+
+	partitionsToDelete, _ := getPartitionsToDelete(tables, 90*time.Minute, 90*time.Minute, 1000, 10, 0.9)
+	assert.Equal(t, []string{untyped.GetPartitionId(older)}, sortedKeys(partitionsToDelete))
+
+	// Over the size limit the oldest partitions that are not frozen go first, and frozen ones are never collected
+	partitionsToDelete, _ = getPartitionsToDelete(tables, 24*time.Hour, 24*time.Hour, 1, 1000, 0.9)
+	assert.NotContains(t, partitionsToDelete, untyped.GetPartitionId(oldest))
+	assert.Contains(t, partitionsToDelete, untyped.GetPartitionId(older))
+}
+
+func sortedKeys(partitionsToDelete map[string][]string) []string {
+	var keys []string
+	for key := range partitionsToDelete {
+		keys = append(keys, key)
+	}
+	return sortedStrings(keys)
+}
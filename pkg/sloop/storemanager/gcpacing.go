@@ -0,0 +1,73 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package storemanager
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	metricGcPartitionKeysToDelete = promauto.NewGauge(prometheus.GaugeOpts{Name: "sloop_gc_partition_keys_to_delete"})
+	metricGcPartitionKeysDeleted  = promauto.NewGauge(prometheus.GaugeOpts{Name: "sloop_gc_partition_keys_deleted"})
+	metricGcTablesDeleting        = promauto.NewGauge(prometheus.GaugeOpts{Name: "sloop_gc_tables_deleting"})
+	metricGcDeleteBatchCount      = promauto.NewCounter(prometheus.CounterOpts{Name: "sloop_gc_delete_batch_count"})
+)
+
+// How GC deletes the keys of a partition, so that huge partitions do not starve queries and ingestion
+type gcPacing struct {
+	batchSize int
+	// Slept after each batch of deleted keys, 0 for no pause
+	batchSleep time.Duration
+	// Tables of a partition that are deleted at the same time, 1 or less deletes them one after the other
+	maxConcurrentTables int
+	// Sleeps of a StoreManager, which return early on shutdown. Nil uses time.Sleep
+	sleeper *SleepWithCancel
+	// Stops the deletes between batches when it returns true. The rest of the partition is left for the next run
+	stop func() bool
+}
+
+func (p gcPacing) concurrency() int {
+	if p.maxConcurrentTables < 1 {
+		return 1
+	}
+	return p.maxConcurrentTables
+}
+
+// Called after each batch of deleted keys, returns false to stop deleting
+func (p gcPacing) afterBatch(deletedInBatch uint64) bool {
+	metricGcDeleteBatchCount.Inc()
+	addPartitionKeysDeleted(deletedInBatch)
+	if p.stop != nil && p.stop() {
+		return false
+	}
+	if p.batchSleep > 0 {
+		if p.sleeper != nil {
+			p.sleeper.Sleep(p.batchSleep)
+		} else {
+			time.Sleep(p.batchSleep)
+		}
+	}
+	return p.stop == nil || !p.stop()
+}
+
+// Progress of the partition being deleted, shared by the tables deleted at the same time
+var partitionKeysDeleted uint64
+
+func startPartitionProgress(keysToDelete uint64) {
+	atomic.StoreUint64(&partitionKeysDeleted, 0)
+	metricGcPartitionKeysToDelete.Set(float64(keysToDelete))
+	metricGcPartitionKeysDeleted.Set(0)
+}
+
+func addPartitionKeysDeleted(keys uint64) {
+	metricGcPartitionKeysDeleted.Set(float64(atomic.AddUint64(&partitionKeysDeleted, keys)))
+}
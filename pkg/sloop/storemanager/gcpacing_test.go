@@ -0,0 +1,61 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package storemanager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+)
+
+func Test_gcPacing_concurrency(t *testing.T) {
+	assert.Equal(t, 1, gcPacing{}.concurrency())
+	assert.Equal(t, 1, gcPacing{maxConcurrentTables: -1}.concurrency())
+	assert.Equal(t, 4, gcPacing{maxConcurrentTables: 4}.concurrency())
+}
+
+func Test_gcPacing_afterBatch_Sleeps(t *testing.T) {
+	pacing := gcPacing{batchSleep: 10 * time.Millisecond}
+	start := time.Now()
+	assert.True(t, pacing.afterBatch(1))
+	assert.True(t, time.Since(start) >= 10*time.Millisecond)
+}
+
+func Test_gcPacing_afterBatch_CanceledSleep(t *testing.T) {
+	sleeper := NewSleepWithCancel()
+	sleeper.Cancel()
+	pacing := gcPacing{batchSleep: time.Hour, sleeper: sleeper, stop: func() bool { return false }}
+	assert.True(t, pacing.afterBatch(1))
+}
+
+func Test_gcPacing_afterBatch_Stop(t *testing.T) {
+	pacing := gcPacing{stop: func() bool { return true }}
+	assert.False(t, pacing.afterBatch(1))
+}
+
+func Test_deletePartition_ConcurrentTablesInBatches(t *testing.T) {
+	db := help_get_db_with_partitions(t, someTs)
+	tables := typed.NewTableList(db)
+	partitionId := untyped.GetPartitionId(someTs)
+	partitionMap, _ := common.GetPartitionsInfo(db)
+	tableNames := []string{(&typed.WatchTableKey{}).TableName(), (&typed.EventCountKey{}).TableName()}
+
+	pacing := gcPacing{batchSize: 1, batchSleep: time.Millisecond, maxConcurrentTables: 2}
+	deleted, toDelete, errMessages := deletePartition(partitionId, tableNames, tables, pacing, true, partitionMap[partitionId], nil)
+	assert.Nil(t, errMessages)
+	assert.Equal(t, uint64(2), deleted)
+	assert.Equal(t, uint64(2), toDelete)
+
+	partitionMap, _ = common.GetPartitionsInfo(db)
+	assert.Nil(t, partitionMap[partitionId])
+}
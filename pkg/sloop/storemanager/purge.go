@@ -0,0 +1,344 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package storemanager
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+var (
+	metricPurgeDeletedCount     = promauto.NewCounter(prometheus.CounterOpts{Name: "sloop_purge_deleted_count"})
+	metricPurgeSoftDeletedCount = promauto.NewCounter(prometheus.CounterOpts{Name: "sloop_purge_soft_deleted_count"})
+	metricPurgeRestoredCount    = promauto.NewCounter(prometheus.CounterOpts{Name: "sloop_purge_restored_count"})
+	metricPurgeExpiredCount     = promauto.NewCounter(prometheus.CounterOpts{Name: "sloop_purge_expired_count"})
+)
+
+var purgedTableName = (&typed.PurgedKey{}).TableName()
+
+type PurgeReport struct {
+	Namespace string `json:"namespace"`
+	// The keys were moved to the purged table instead of deleted, and can be restored until expires
+	Soft bool `json:"soft"`
+	// Unix seconds
+	Expires    int64  `json:"expires,omitempty"`
+	Keys       uint64 `json:"keys"`
+	Partitions int    `json:"partitions"`
+	Elapsed    string `json:"elapsed"`
+}
+
+// A namespace with soft deleted keys
+type PurgedNamespace struct {
+	Namespace string `json:"namespace"`
+	Keys      uint64 `json:"keys"`
+	// Unix seconds of the first soft deleted keys to expire, later purges of the namespace can last longer
+	Expires int64 `json:"expires"`
+}
+
+/*
+Removes every key of one namespace from the namespace scoped tables, for a namespace that should not have been stored
+or whose data has to go.  A soft purge moves the keys to the purged table instead, where they stay for the soft delete
+TTL so a purge of the wrong namespace can be undone with Undelete.  Store GC deletes them once they expire, or with
+their partition.  Only one purge or undelete runs at a time
+*/
+type Purger struct {
+	tables            typed.Tables
+	deletionBatchSize int
+	ttl               time.Duration
+	lock              *sync.Mutex
+}
+
+// ttl is how long soft deleted keys are kept
+func NewPurger(tables typed.Tables, deletionBatchSize int, ttl time.Duration) *Purger {
+	return &Purger{tables: tables, deletionBatchSize: deletionBatchSize, ttl: ttl, lock: &sync.Mutex{}}
+}
+
+// Soft purges need a TTL
+func (p *Purger) SoftDeleteEnabled() bool {
+	return p.ttl > 0
+}
+
+func (p *Purger) PurgeNamespace(namespace string, soft bool, now time.Time) (*PurgeReport, error) {
+	if namespace == "" || strings.Contains(namespace, "/") {
+		return nil, fmt.Errorf("invalid namespace %q", namespace)
+	}
+	if soft && !p.SoftDeleteEnabled() {
+		return nil, fmt.Errorf("soft deletes are disabled")
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	before := time.Now()
+	report := &PurgeReport{Namespace: namespace, Soft: soft}
+	expires := now.Add(p.ttl)
+	if soft {
+		report.Expires = expires.Unix()
+	}
+	partitionMap, _ := common.GetPartitionsInfo(p.tables.Db())
+	for _, partitionId := range common.GetSortedPartitionIDs(partitionMap) {
+		found := false
+		for _, tableName := range p.tables.GetTableNames() {
+			if tableName == purgedTableName {
+				continue
+			}
+			moved, err := p.purgeKeys(fmt.Sprintf("/%v/%v/", tableName, partitionId), namespace, soft, expires)
+			report.Keys += moved
+			if err != nil {
+				return report, errors.Wrapf(err, "failed to purge table %v of partition %v", tableName, partitionId)
+			}
+			if moved == 0 {
+				continue
+			}
+			found = true
+			err = updateChecksum(p.tables.Db(), partitionId, tableName)
+			if err != nil {
+				return report, errors.Wrapf(err, "failed to update checksum of table %v in partition %v", tableName, partitionId)
+			}
+		}
+		if found {
+			report.Partitions++
+		}
+	}
+	if soft {
+		metricPurgeSoftDeletedCount.Add(float64(report.Keys))
+	} else {
+		metricPurgeDeletedCount.Add(float64(report.Keys))
+	}
+	report.Elapsed = time.Since(before).String()
+	glog.Infof("Purged %v keys of namespace %v from %v partitions (soft delete %v) in %v", report.Keys, namespace, report.Partitions, soft, report.Elapsed)
+	return report, nil
+}
+
+func (p *Purger) purgeKeys(prefix string, namespace string, soft bool, expires time.Time) (uint64, error) {
+	var keys [][]byte
+	err := p.tables.Db().View(func(txn badgerwrap.Txn) error {
+		iterOpt := badger.DefaultIteratorOptions
+		iterOpt.Prefix = []byte(prefix)
+		iterOpt.PrefetchValues = false
+		itr := txn.NewIterator(iterOpt)
+		defer itr.Close()
+		for itr.Seek([]byte(prefix)); itr.ValidForPrefix([]byte(prefix)); itr.Next() {
+			keyNamespace, ok := typed.KeyNamespace(string(itr.Item().Key()))
+			if !ok {
+				// Not scoped to a namespace, so neither is the rest of the table
+				return nil
+			}
+			if keyNamespace == namespace {
+				keys = append(keys, itr.Item().KeyCopy(nil))
+			}
+		}
+		return nil
+	})
+	if err != nil || len(keys) == 0 {
+		return 0, err
+	}
+	if !soft {
+		err, deleted := common.DeleteKeysInBatches(p.tables.Db(), keys, p.deletionBatchSize)
+		return deleted, err
+	}
+	return p.moveKeys(keys, func(key string) (string, error) {
+		sloopKey := strings.SplitN(key, "/", 4)
+		return typed.NewPurgedKey(sloopKey[2], namespace, expires, key).String(), nil
+	})
+}
+
+// Moves the values of keys to the keys dest returns for them, in batches.  A dest that already exists is left as is
+// and the key is dropped, so restoring never overwrites what was stored since
+func (p *Purger) moveKeys(keys [][]byte, dest func(key string) (string, error)) (uint64, error) {
+	var moved uint64
+	batchSize := p.deletionBatchSize
+	if batchSize < 1 {
+		batchSize = 1
+	}
+	for start := 0; start < len(keys); start += batchSize {
+		end := start + batchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+		var batchMoved uint64
+		err := p.tables.Db().Update(func(txn badgerwrap.Txn) error {
+			batchMoved = 0
+			for _, key := range keys[start:end] {
+				to, err := dest(string(key))
+				if err != nil {
+					return err
+				}
+				item, err := txn.Get(key)
+				if err == badger.ErrKeyNotFound {
+					continue
+				}
+				if err != nil {
+					return err
+				}
+				value, err := item.ValueCopy([]byte{})
+				if err != nil {
+					return err
+				}
+				_, err = txn.Get([]byte(to))
+				if err == badger.ErrKeyNotFound {
+					err = txn.Set([]byte(to), value)
+				}
+				if err != nil {
+					return err
+				}
+				err = txn.Delete(key)
+				if err != nil {
+					return err
+				}
+				batchMoved++
+			}
+			return nil
+		})
+		if err != nil {
+			return moved, err
+		}
+		moved += batchMoved
+	}
+	return moved, nil
+}
+
+// Moves the soft deleted keys of the namespace back to where they were.  Does not need the TTL, so keys soft deleted
+// before soft deletes were turned off can still be restored until they expire
+func (p *Purger) Undelete(namespace string) (*PurgeReport, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	before := time.Now()
+	report := &PurgeReport{Namespace: namespace}
+	byPartition := map[string][][]byte{}
+	err := p.tables.Db().View(func(txn badgerwrap.Txn) error {
+		return forEachPurgedKey(txn, func(key *typed.PurgedKey, rawKey []byte) {
+			if key.Namespace == namespace {
+				byPartition[key.PartitionId] = append(byPartition[key.PartitionId], rawKey)
+			}
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	partitions := []string{}
+	for partitionId := range byPartition {
+		partitions = append(partitions, partitionId)
+	}
+	sort.Strings(partitions)
+	for _, partitionId := range partitions {
+		tableNames := map[string]bool{}
+		moved, err := p.moveKeys(byPartition[partitionId], func(key string) (string, error) {
+			purgedKey := &typed.PurgedKey{}
+			err := purgedKey.Parse(key)
+			if err != nil {
+				return "", err
+			}
+			tableNames[strings.SplitN(purgedKey.OriginalKey, "/", 3)[1]] = true
+			return purgedKey.OriginalKey, nil
+		})
+		report.Keys += moved
+		if err != nil {
+			return report, errors.Wrapf(err, "failed to restore partition %v", partitionId)
+		}
+		report.Partitions++
+		for _, tableName := range keysOf(tableNames) {
+			err = updateChecksum(p.tables.Db(), partitionId, tableName)
+			if err != nil {
+				return report, errors.Wrapf(err, "failed to update checksum of table %v in partition %v", tableName, partitionId)
+			}
+		}
+	}
+	metricPurgeRestoredCount.Add(float64(report.Keys))
+	report.Elapsed = time.Since(before).String()
+	glog.Infof("Restored %v soft deleted keys of namespace %v in %v partitions in %v", report.Keys, namespace, report.Partitions, report.Elapsed)
+	return report, nil
+}
+
+// Namespaces with soft deleted keys, ordered by name
+func (p *Purger) ListPurged() ([]PurgedNamespace, error) {
+	byNamespace := map[string]*PurgedNamespace{}
+	err := p.tables.Db().View(func(txn badgerwrap.Txn) error {
+		return forEachPurgedKey(txn, func(key *typed.PurgedKey, rawKey []byte) {
+			purged, ok := byNamespace[key.Namespace]
+			if !ok {
+				purged = &PurgedNamespace{Namespace: key.Namespace, Expires: key.Expires.Unix()}
+				byNamespace[key.Namespace] = purged
+			}
+			purged.Keys++
+			if key.Expires.Unix() < purged.Expires {
+				purged.Expires = key.Expires.Unix()
+			}
+		})
+	})
+	namespaces := []PurgedNamespace{}
+	for _, purged := range byNamespace {
+		namespaces = append(namespaces, *purged)
+	}
+	sort.Slice(namespaces, func(i, j int) bool { return namespaces[i].Namespace < namespaces[j].Namespace })
+	return namespaces, err
+}
+
+// Deletes the soft deleted keys that expired by now
+func expirePurgedKeys(tables typed.Tables, now time.Time, deletionBatchSize int) (uint64, error) {
+	var keys [][]byte
+	err := tables.Db().View(func(txn badgerwrap.Txn) error {
+		return forEachPurgedKey(txn, func(key *typed.PurgedKey, rawKey []byte) {
+			if !key.Expires.After(now) {
+				keys = append(keys, rawKey)
+			}
+		})
+	})
+	if err != nil || len(keys) == 0 {
+		return 0, err
+	}
+	err, deleted := common.DeleteKeysInBatches(tables.Db(), keys, deletionBatchSize)
+	metricPurgeExpiredCount.Add(float64(deleted))
+	if err != nil {
+		return deleted, err
+	}
+	glog.Infof("Deleted %v expired soft deleted keys", deleted)
+	return deleted, nil
+}
+
+// Keys that do not parse are skipped
+func forEachPurgedKey(txn badgerwrap.Txn, fn func(key *typed.PurgedKey, rawKey []byte)) error {
+	prefix := []byte("/" + purgedTableName + "/")
+	iterOpt := badger.DefaultIteratorOptions
+	iterOpt.Prefix = prefix
+	iterOpt.PrefetchValues = false
+	itr := txn.NewIterator(iterOpt)
+	defer itr.Close()
+	for itr.Seek(prefix); itr.ValidForPrefix(prefix); itr.Next() {
+		key := &typed.PurgedKey{}
+		if err := key.Parse(string(itr.Item().Key())); err != nil {
+			glog.Errorf("Skipping purged key: %v", err)
+			continue
+		}
+		fn(key, itr.Item().KeyCopy(nil))
+	}
+	return nil
+}
+
+func keysOf(set map[string]bool) []string {
+	keys := []string{}
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,118 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package storemanager
+
+import (
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+func helper_purgeTestStore(t *testing.T) (typed.Tables, []string) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	var keys []string
+	for hour := 0; hour < 3; hour++ {
+		ts := someTs.Add(time.Duration(hour) * time.Hour)
+		keys = append(keys,
+			typed.NewWatchTableKey(untyped.GetPartitionId(ts), "Pod", "wrong", "pod", ts).String(),
+			typed.NewWatchTableKey(untyped.GetPartitionId(ts), "Pod", "right", "pod", ts).String(),
+			typed.NewResourceSummaryKey(ts, "Namespace", "", "wrong", "uid").String(),
+		)
+	}
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		for _, key := range keys {
+			err := txn.Set([]byte(key), []byte(key))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+	sort.Strings(keys)
+	return tables, keys
+}
+
+func Test_Purger_SoftDeleteAndUndelete(t *testing.T) {
+	tables, keys := helper_purgeTestStore(t)
+	purger := NewPurger(tables, 2, time.Hour)
+
+	report, err := purger.PurgeNamespace("wrong", true, someTs)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(6), report.Keys)
+	assert.Equal(t, 3, report.Partitions)
+	assert.Equal(t, someTs.Add(time.Hour).Unix(), report.Expires)
+	remaining := 0
+	for _, key := range common.GetKeysForPrefix(tables.Db(), "") {
+		if strings.HasPrefix(key, "/watch/") {
+			remaining++
+		}
+	}
+	assert.Equal(t, 3, remaining)
+
+	purged, err := purger.ListPurged()
+	assert.Nil(t, err)
+	assert.Equal(t, []PurgedNamespace{{Namespace: "wrong", Keys: 6, Expires: someTs.Add(time.Hour).Unix()}}, purged)
+
+	report, err = purger.Undelete("wrong")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(6), report.Keys)
+	restored := common.GetKeysForPrefix(tables.Db(), "")
+	sort.Strings(restored)
+	assert.Equal(t, keys, restored)
+	// Values are restored as they were stored
+	err = tables.Db().View(func(txn badgerwrap.Txn) error {
+		item, err := txn.Get([]byte(keys[0]))
+		if err != nil {
+			return err
+		}
+		value, err := item.ValueCopy(nil)
+		assert.Equal(t, keys[0], string(value))
+		return err
+	})
+	assert.Nil(t, err)
+}
+
+func Test_Purger_HardDeleteAndExpiry(t *testing.T) {
+	tables, _ := helper_purgeTestStore(t)
+	purger := NewPurger(tables, 100, time.Hour)
+
+	report, err := purger.PurgeNamespace("right", false, someTs)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(3), report.Keys)
+	purged, err := purger.ListPurged()
+	assert.Nil(t, err)
+	assert.Len(t, purged, 0)
+
+	_, err = purger.PurgeNamespace("wrong", true, someTs)
+	assert.Nil(t, err)
+	deleted, err := expirePurgedKeys(tables, someTs.Add(time.Minute), 100)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0), deleted)
+	deleted, err = expirePurgedKeys(tables, someTs.Add(time.Hour), 100)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(6), deleted)
+	assert.Len(t, common.GetKeysForPrefix(tables.Db(), ""), 0)
+
+	_, err = NewPurger(tables, 100, 0).PurgeNamespace("wrong", true, someTs)
+	assert.NotNil(t, err)
+	_, err = purger.PurgeNamespace("", false, someTs)
+	assert.NotNil(t, err)
+}
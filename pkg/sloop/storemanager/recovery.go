@@ -0,0 +1,179 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package storemanager
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/spf13/afero"
+
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+var metricRecoveryLostKeys = promauto.NewGauge(prometheus.GaugeOpts{Name: "sloop_recovery_lost_keys"})
+
+const (
+	maxReportedLostKeys = 1000
+	recoveryDeleteBatch = 1000
+)
+
+/*
+Opens the store for -recover-store.  When it does not open as it is, it is opened again with value log truncation,
+which is what a crash in the middle of a write to the value log needs.  When that or the open itself truncated a value
+log, every key is read back with value checksums on, and the keys whose values are gone or damaged are deleted so
+queries do not fail on them.  Which keys and partitions those were goes into a report in the recovery table.
+
+Writes that were only in the truncated part of the value log and never made it to the LSM tree are lost without a
+trace in the store, truncatedBytes is all that is known of them.  Returns a nil report when nothing had to be
+recovered
+*/
+func OpenStoreWithRecovery(factory badgerwrap.Factory, config *untyped.Config, fs *afero.Afero) (badgerwrap.DB, *typed.RecoveryReport, error) {
+	before := time.Now()
+	sizesBefore, err := vlogSizes(config.RootPath, fs)
+	if err != nil {
+		return nil, nil, err
+	}
+	recoveryConfig := *config
+	recoveryConfig.BadgerVerifyValueChecksum = true
+	report := &typed.RecoveryReport{}
+	db, err := untyped.OpenStore(factory, &recoveryConfig)
+	if err != nil {
+		glog.Errorf("Store failed to open, trying again with value log truncation: %v", err)
+		report.OpenError = err.Error()
+		recoveryConfig.BadgerVLogTruncate = true
+		db, err = untyped.OpenStore(factory, &recoveryConfig)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "store failed to open with value log truncation after %v", report.OpenError)
+		}
+	}
+	sizesAfter, err := vlogSizes(config.RootPath, fs)
+	if err != nil {
+		untyped.CloseStore(db)
+		return nil, nil, err
+	}
+	for fileName, size := range sizesBefore {
+		if sizesAfter[fileName] < size {
+			report.TruncatedBytes += size - sizesAfter[fileName]
+		}
+	}
+	if report.OpenError == "" && report.TruncatedBytes == 0 {
+		return db, nil, nil
+	}
+
+	err = removeLostKeys(db, report)
+	if err != nil {
+		untyped.CloseStore(db)
+		return nil, nil, errors.Wrap(err, "failed to check the store for lost keys")
+	}
+	report.Timestamp, _ = ptypes.TimestampProto(before)
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		return typed.OpenRecoveryTable().Set(txn, before, report)
+	})
+	if err != nil {
+		untyped.CloseStore(db)
+		return nil, nil, errors.Wrap(err, "failed to write the recovery report")
+	}
+	metricRecoveryLostKeys.Set(float64(report.KeysLost))
+	glog.Warningf("Recovered the store in %v: truncated %v value log bytes, lost %v of %v keys in partitions %v", time.Since(before), report.TruncatedBytes, report.KeysLost, report.KeysChecked, report.LostPartitions)
+	return db, report, nil
+}
+
+// Value log file names to their size, empty for a store that does not exist yet
+func vlogSizes(storeRoot string, fs *afero.Afero) (map[string]int64, error) {
+	sizes := map[string]int64{}
+	files, err := fs.ReadDir(storeRoot)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return sizes, nil
+		}
+		return nil, errors.Wrapf(err, "failed to list %v", storeRoot)
+	}
+	for _, file := range files {
+		if filepath.Ext(file.Name()) == vlogExt {
+			sizes[file.Name()] = file.Size()
+		}
+	}
+	return sizes, nil
+}
+
+// Reads the value of every key and deletes the keys whose value can not be read
+func removeLostKeys(db badgerwrap.DB, report *typed.RecoveryReport) error {
+	var lostKeys [][]byte
+	lostPartitions := map[string]bool{}
+	err := db.View(func(txn badgerwrap.Txn) error {
+		iterOpt := badger.DefaultIteratorOptions
+		iterOpt.PrefetchValues = false
+		itr := txn.NewIterator(iterOpt)
+		defer itr.Close()
+		for itr.Rewind(); itr.Valid(); itr.Next() {
+			report.KeysChecked++
+			err := itr.Item().Value(func(value []byte) error { return nil })
+			if err == nil {
+				continue
+			}
+			key := itr.Item().KeyCopy(nil)
+			glog.V(common.GlogVerbose).Infof("Lost key %q: %v", string(key), err)
+			lostKeys = append(lostKeys, key)
+			lostPartitions[lostPartition(string(key))] = true
+			if len(report.LostKeys) < maxReportedLostKeys {
+				report.LostKeys = append(report.LostKeys, string(key))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for start := 0; start < len(lostKeys); start += recoveryDeleteBatch {
+		end := start + recoveryDeleteBatch
+		if end > len(lostKeys) {
+			end = len(lostKeys)
+		}
+		err = db.Update(func(txn badgerwrap.Txn) error {
+			for _, key := range lostKeys[start:end] {
+				err := txn.Delete(key)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	report.KeysLost = int64(len(lostKeys))
+	for partition := range lostPartitions {
+		report.LostPartitions = append(report.LostPartitions, partition)
+	}
+	sort.Strings(report.LostPartitions)
+	return nil
+}
+
+// Keys are /<table>/<partition>/..., this returns <table>/<partition>
+func lostPartition(key string) string {
+	parts := strings.SplitN(strings.TrimPrefix(key, "/"), "/", 3)
+	if len(parts) < 2 {
+		return parts[0]
+	}
+	return parts[0] + "/" + parts[1]
+}
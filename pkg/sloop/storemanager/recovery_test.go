@@ -0,0 +1,81 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package storemanager
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+// Needs real badger files to damage
+func Test_OpenStoreWithRecovery(t *testing.T) {
+	dir, err := ioutil.TempDir("", "recovery")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	fs := &afero.Afero{Fs: afero.NewOsFs()}
+	config := &untyped.Config{RootPath: dir, ConfigPartitionDuration: time.Hour}
+
+	db, report, err := OpenStoreWithRecovery(&badgerwrap.BadgerFactory{}, config, fs)
+	assert.Nil(t, err)
+	assert.Nil(t, report)
+	for i := 0; i < 20; i++ {
+		err = db.Update(func(txn badgerwrap.Txn) error {
+			return txn.Set([]byte(fmt.Sprintf("/watch/001546405200/key%02d", i)), make([]byte, 2000))
+		})
+		assert.Nil(t, err)
+	}
+	assert.Nil(t, db.Close())
+
+	// Cut off the values of the last two keys
+	vlog := filepath.Join(dir, "000000.vlog")
+	info, err := os.Stat(vlog)
+	assert.Nil(t, err)
+	assert.Nil(t, os.Truncate(vlog, info.Size()-3000))
+
+	config.BadgerVLogTruncate = false
+	_, err = untyped.OpenStore(&badgerwrap.BadgerFactory{}, config)
+	assert.NotNil(t, err)
+	db, report, err = OpenStoreWithRecovery(&badgerwrap.BadgerFactory{}, config, fs)
+	assert.Nil(t, err)
+	assert.Contains(t, report.OpenError, "truncate")
+	assert.Equal(t, int64(20), report.KeysChecked)
+	assert.Equal(t, int64(2), report.KeysLost)
+	assert.Equal(t, []string{"watch/001546405200"}, report.LostPartitions)
+	assert.Equal(t, []string{"/watch/001546405200/key18", "/watch/001546405200/key19"}, report.LostKeys)
+
+	err = db.View(func(txn badgerwrap.Txn) error {
+		_, err := txn.Get([]byte("/watch/001546405200/key19"))
+		assert.NotNil(t, err)
+		_, err = txn.Get([]byte("/watch/001546405200/key17"))
+		assert.Nil(t, err)
+		reports, err := typed.OpenRecoveryTable().ReadAll(txn)
+		assert.Nil(t, err)
+		assert.Len(t, reports, 1)
+		assert.Equal(t, int64(2), reports[0].KeysLost)
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, db.Close())
+}
+
+func Test_lostPartition(t *testing.T) {
+	assert.Equal(t, "watch/001546405200", lostPartition("/watch/001546405200/Pod/ns/name/uid"))
+	assert.Equal(t, "queryaudit/0000000002", lostPartition("/queryaudit/0000000002"))
+	assert.Equal(t, "other", lostPartition("other"))
+}
@@ -0,0 +1,213 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package storemanager
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+var (
+	metricRetentionOverrides        = promauto.NewGauge(prometheus.GaugeOpts{Name: "sloop_retention_overrides"})
+	metricRetentionOverrideKeptKeys = promauto.NewCounter(prometheus.CounterOpts{Name: "sloop_retention_override_kept_keys"})
+)
+
+type RetentionOverrideReport struct {
+	Namespace string `json:"namespace"`
+	Kind      string `json:"kind,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+	CreatedBy string `json:"createdBy,omitempty"`
+	// Unix seconds
+	Created int64 `json:"created"`
+	Expires int64 `json:"expires"`
+}
+
+/*
+Keeps the data of a namespace, or of one kind in it, past the time and size limits until the override expires, so
+an investigation can hold on to it without a config change and a restart.  GC and tenant retention then delete the
+partitions they collect key by key and leave the keys of the overrides, which is slower than dropping whole
+partitions, so overrides should be short.  Once an override expires GC removes it and the normal policy resumes
+*/
+type RetentionOverrides struct {
+	db    badgerwrap.DB
+	table *typed.RetentionOverrideTable
+}
+
+func NewRetentionOverrides(db badgerwrap.DB) *RetentionOverrides {
+	return &RetentionOverrides{db: db, table: typed.OpenRetentionOverrideTable()}
+}
+
+// Keeps the namespace, or only the kind in it when set, for duration from now.  Setting it again replaces the expiry
+func (r *RetentionOverrides) Extend(namespace string, kind string, duration time.Duration, reason string, user string, now time.Time) (*RetentionOverrideReport, error) {
+	if namespace == "" || strings.Contains(namespace, "/") || strings.Contains(kind, "/") {
+		return nil, fmt.Errorf("invalid namespace %q or kind %q", namespace, kind)
+	}
+	if duration <= 0 {
+		return nil, fmt.Errorf("retention override duration %v must be positive", duration)
+	}
+	override := &typed.RetentionOverride{Namespace: namespace, Kind: kind, Reason: reason, CreatedBy: user}
+	override.Created, _ = ptypes.TimestampProto(now)
+	override.Expires, _ = ptypes.TimestampProto(now.Add(duration))
+	err := r.db.Update(func(txn badgerwrap.Txn) error {
+		return r.table.Set(txn, override)
+	})
+	if err != nil {
+		return nil, err
+	}
+	glog.Infof("Retention of namespace %q kind %q extended until %v by %q: %v", namespace, kind, now.Add(duration), user, reason)
+	return newRetentionOverrideReport(override), nil
+}
+
+// Ends the override right away.  Returns nil when there was none for the namespace and kind
+func (r *RetentionOverrides) Remove(namespace string, kind string) (*RetentionOverrideReport, error) {
+	var override *typed.RetentionOverride
+	err := r.db.Update(func(txn badgerwrap.Txn) error {
+		var err error
+		override, err = r.table.Get(txn, namespace, kind)
+		if err != nil || override == nil {
+			return err
+		}
+		return r.table.Delete(txn, namespace, kind)
+	})
+	if err != nil || override == nil {
+		return nil, err
+	}
+	glog.Infof("Retention override of namespace %q kind %q removed", namespace, kind)
+	return newRetentionOverrideReport(override), nil
+}
+
+// The overrides that have not expired at now, ordered by namespace and kind
+func (r *RetentionOverrides) List(now time.Time) ([]*RetentionOverrideReport, error) {
+	overrides, err := readActiveRetentionOverrides(r.db, now)
+	if err != nil {
+		return nil, err
+	}
+	reports := []*RetentionOverrideReport{}
+	for _, override := range overrides {
+		reports = append(reports, newRetentionOverrideReport(override))
+	}
+	return reports, nil
+}
+
+func newRetentionOverrideReport(override *typed.RetentionOverride) *RetentionOverrideReport {
+	report := &RetentionOverrideReport{Namespace: override.Namespace, Kind: override.Kind, Reason: override.Reason, CreatedBy: override.CreatedBy}
+	if created, err := ptypes.Timestamp(override.Created); err == nil {
+		report.Created = created.Unix()
+	}
+	if expires, err := ptypes.Timestamp(override.Expires); err == nil {
+		report.Expires = expires.Unix()
+	}
+	return report
+}
+
+func isOverrideActive(override *typed.RetentionOverride, now time.Time) bool {
+	expires, err := ptypes.Timestamp(override.Expires)
+	return err == nil && expires.After(now)
+}
+
+func readActiveRetentionOverrides(db badgerwrap.DB, now time.Time) ([]*typed.RetentionOverride, error) {
+	var overrides []*typed.RetentionOverride
+	err := db.View(func(txn badgerwrap.Txn) error {
+		all, err := typed.OpenRetentionOverrideTable().ReadAll(txn)
+		for _, override := range all {
+			if isOverrideActive(override, now) {
+				overrides = append(overrides, override)
+			}
+		}
+		return err
+	})
+	return overrides, err
+}
+
+// Removes the overrides that expired before now, so their data goes with the next GC run
+func expireRetentionOverrides(db badgerwrap.DB, now time.Time) (int, error) {
+	table := typed.OpenRetentionOverrideTable()
+	expired := 0
+	active := 0
+	err := db.Update(func(txn badgerwrap.Txn) error {
+		overrides, err := table.ReadAll(txn)
+		if err != nil {
+			return err
+		}
+		for _, override := range overrides {
+			if isOverrideActive(override, now) {
+				active++
+				continue
+			}
+			err = table.Delete(txn, override.Namespace, override.Kind)
+			if err != nil {
+				return err
+			}
+			glog.Infof("Retention override of namespace %q kind %q expired", override.Namespace, override.Kind)
+			expired++
+		}
+		return nil
+	})
+	if err == nil {
+		metricRetentionOverrides.Set(float64(active))
+	}
+	return expired, err
+}
+
+// True when an override keeps the row of the key.  Tables that are not scoped to a namespace are never kept
+func isRetainedByOverride(overrides []*typed.RetentionOverride, key string) bool {
+	namespace, ok := typed.KeyNamespace(key)
+	if !ok {
+		return false
+	}
+	kind, _ := typed.KeyKind(key)
+	for _, override := range overrides {
+		if override.Namespace == namespace && (override.Kind == "" || override.Kind == kind) {
+			return true
+		}
+	}
+	return false
+}
+
+// Deletes the keys with the prefix that no override keeps.  Returns the number of deleted and kept keys
+func deleteKeysNotRetained(db badgerwrap.DB, prefix string, overrides []*typed.RetentionOverride, deletionBatchSize int) (uint64, uint64, error) {
+	var keys [][]byte
+	var kept uint64
+	err := db.View(func(txn badgerwrap.Txn) error {
+		iterOpt := badger.DefaultIteratorOptions
+		iterOpt.PrefetchValues = false
+		itr := txn.NewIterator(iterOpt)
+		defer itr.Close()
+		for itr.Seek([]byte(prefix)); itr.ValidForPrefix([]byte(prefix)); itr.Next() {
+			if isRetainedByOverride(overrides, string(itr.Item().Key())) {
+				kept++
+				continue
+			}
+			keys = append(keys, itr.Item().KeyCopy(nil))
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, kept, err
+	}
+	metricRetentionOverrideKeptKeys.Add(float64(kept))
+	if len(keys) == 0 {
+		return 0, kept, nil
+	}
+	err, deleted := common.DeleteKeysInBatches(db, keys, deletionBatchSize)
+	if kept > 0 {
+		glog.Infof("Kept %v keys with prefix %v for retention overrides", kept, prefix)
+	}
+	return deleted, kept, err
+}
@@ -0,0 +1,109 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package storemanager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+func Test_RetentionOverrides_ExtendListRemove(t *testing.T) {
+	db := help_get_db_with_partitions(t)
+	overrides := NewRetentionOverrides(db)
+
+	_, err := overrides.Extend("", "", time.Hour, "", "", someTs)
+	assert.NotNil(t, err)
+	_, err = overrides.Extend("a/b", "", time.Hour, "", "", someTs)
+	assert.NotNil(t, err)
+	_, err = overrides.Extend(someNamespace, "", 0, "", "", someTs)
+	assert.NotNil(t, err)
+
+	report, err := overrides.Extend(someNamespace, "", 14*24*time.Hour, "inc-42", "alice", someTs)
+	assert.Nil(t, err)
+	assert.Equal(t, &RetentionOverrideReport{Namespace: someNamespace, Reason: "inc-42", CreatedBy: "alice", Created: someTs.Unix(), Expires: someTs.Add(14 * 24 * time.Hour).Unix()}, report)
+	_, err = overrides.Extend("other", someKind, time.Hour, "", "", someTs)
+	assert.Nil(t, err)
+
+	list, err := overrides.List(someTs)
+	assert.Nil(t, err)
+	assert.Len(t, list, 2)
+	// The override of other has expired
+	list, err = overrides.List(someTs.Add(2 * time.Hour))
+	assert.Nil(t, err)
+	assert.Equal(t, []*RetentionOverrideReport{report}, list)
+
+	removed, err := overrides.Remove(someNamespace, "")
+	assert.Nil(t, err)
+	assert.Equal(t, report, removed)
+	removed, err = overrides.Remove(someNamespace, "")
+	assert.Nil(t, err)
+	assert.Nil(t, removed)
+}
+
+func Test_expireRetentionOverrides(t *testing.T) {
+	db := help_get_db_with_partitions(t)
+	overrides := NewRetentionOverrides(db)
+	_, err := overrides.Extend(someNamespace, "", time.Hour, "", "", someTs)
+	assert.Nil(t, err)
+	_, err = overrides.Extend("other", "", 3*time.Hour, "", "", someTs)
+	assert.Nil(t, err)
+
+	expired, err := expireRetentionOverrides(db, someTs.Add(2*time.Hour))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, expired)
+	list, err := overrides.List(someTs)
+	assert.Nil(t, err)
+	assert.Len(t, list, 1)
+	assert.Equal(t, "other", list[0].Namespace)
+}
+
+func Test_isRetainedByOverride(t *testing.T) {
+	partitionId := untyped.GetPartitionId(someTs)
+	key := typed.NewWatchTableKey(partitionId, someKind, someNamespace, someName, someTs).String()
+	otherKind := typed.NewWatchTableKey(partitionId, "Pod", someNamespace, someName, someTs).String()
+
+	assert.False(t, isRetainedByOverride(nil, key))
+	assert.True(t, isRetainedByOverride([]*typed.RetentionOverride{{Namespace: someNamespace}}, key))
+	assert.True(t, isRetainedByOverride([]*typed.RetentionOverride{{Namespace: someNamespace, Kind: someKind}}, key))
+	assert.False(t, isRetainedByOverride([]*typed.RetentionOverride{{Namespace: someNamespace, Kind: someKind}}, otherKind))
+	assert.False(t, isRetainedByOverride([]*typed.RetentionOverride{{Namespace: "other"}}, key))
+}
+
+func Test_doCleanup_KeepsOverriddenNamespace(t *testing.T) {
+	oldest := someTs.Add(-3 * time.Hour)
+	db := help_get_db_with_partitions(t, oldest, someTs)
+	tables := typed.NewTableList(db)
+	partitionId := untyped.GetPartitionId(oldest)
+	otherKey := typed.NewWatchTableKey(partitionId, someKind, "other", someName, oldest).String()
+	err := db.Update(func(txn badgerwrap.Txn) error {
+		return typed.OpenKubeWatchResultTable().Set(txn, otherKey, &typed.KubeWatchResult{Kind: someKind})
+	})
+	assert.Nil(t, err)
+	_, err = NewRetentionOverrides(db).Extend(someNamespace, "", time.Hour, "", "", common.Now())
+	assert.Nil(t, err)
+
+	flag, _, _, err := doCleanup(tables, 2*time.Hour, 2*time.Hour, 1000, &storeStats{DiskSizeBytes: 10}, gcPacing{batchSize: 10}, 1, false)
+	assert.True(t, flag)
+	assert.Nil(t, err)
+
+	err = db.View(func(txn badgerwrap.Txn) error {
+		_, err := txn.Get([]byte(otherKey))
+		assert.NotNil(t, err)
+		_, err = txn.Get([]byte(typed.NewWatchTableKey(partitionId, someKind, someNamespace, someName, oldest).String()))
+		assert.Nil(t, err)
+		return nil
+	})
+	assert.Nil(t, err)
+}
@@ -0,0 +1,110 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package storemanager
+
+import (
+	"io/ioutil"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/ptypes"
+
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+const procStatmFile = "/proc/self/statm"
+
+/*
+Stores a sample of the memory, goroutines, store size and ingest queue depths of sloop itself in the selffootprint
+table every interval, so a slowdown can be looked at with GetSelfFootprint next to the cluster history of the same
+time.  The samples live in the partitions of their time and are dropped with them by GC
+*/
+type FootprintRecorder struct {
+	db              badgerwrap.DB
+	table           *typed.SelfFootprintTable
+	interval        time.Duration
+	watchQueue      chan typed.KubeWatchResult
+	annotationQueue chan *typed.IngestAnnotation
+	done            chan bool
+	wg              *sync.WaitGroup
+}
+
+func NewFootprintRecorder(db badgerwrap.DB, interval time.Duration, watchQueue chan typed.KubeWatchResult, annotationQueue chan *typed.IngestAnnotation) *FootprintRecorder {
+	return &FootprintRecorder{db: db, table: typed.OpenSelfFootprintTable(), interval: interval, watchQueue: watchQueue, annotationQueue: annotationQueue, done: make(chan bool), wg: &sync.WaitGroup{}}
+}
+
+func (r *FootprintRecorder) Start() {
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		for {
+			select {
+			case <-r.done:
+				return
+			case <-time.After(r.interval):
+			}
+			err := r.Record(common.Now())
+			if err != nil {
+				glog.Errorf("Failed to record the footprint of sloop: %v", err)
+			}
+		}
+	}()
+}
+
+func (r *FootprintRecorder) Stop() {
+	close(r.done)
+	r.wg.Wait()
+}
+
+func (r *FootprintRecorder) Record(now time.Time) error {
+	sample := r.Sample()
+	sample.Timestamp, _ = ptypes.TimestampProto(now)
+	return r.db.Update(func(txn badgerwrap.Txn) error {
+		return r.table.Set(txn, now, sample)
+	})
+}
+
+func (r *FootprintRecorder) Sample() *typed.SelfFootprint {
+	memStats := runtime.MemStats{}
+	runtime.ReadMemStats(&memStats)
+	lsmBytes, vlogBytes := r.db.Size()
+	return &typed.SelfFootprint{
+		RssBytes:             residentBytes(),
+		HeapBytes:            int64(memStats.HeapInuse),
+		Goroutines:           int64(runtime.NumGoroutine()),
+		LsmBytes:             lsmBytes,
+		VlogBytes:            vlogBytes,
+		WatchQueueDepth:      int64(len(r.watchQueue)),
+		WatchQueueCapacity:   int64(cap(r.watchQueue)),
+		AnnotationQueueDepth: int64(len(r.annotationQueue)),
+	}
+}
+
+// The second field of statm is the resident set size in pages.  0 when it can not be read, like off Linux
+func residentBytes() int64 {
+	statm, err := ioutil.ReadFile(procStatmFile)
+	if err != nil {
+		return 0
+	}
+	fields := strings.Fields(string(statm))
+	if len(fields) < 2 {
+		return 0
+	}
+	pages, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return pages * int64(os.Getpagesize())
+}
@@ -0,0 +1,49 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package storemanager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+func Test_FootprintRecorder_Record(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	watchQueue := make(chan typed.KubeWatchResult, 10)
+	watchQueue <- typed.KubeWatchResult{}
+	watchQueue <- typed.KubeWatchResult{}
+	annotationQueue := make(chan *typed.IngestAnnotation, 5)
+	annotationQueue <- &typed.IngestAnnotation{}
+	recorder := NewFootprintRecorder(db, time.Minute, watchQueue, annotationQueue)
+
+	now := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+	assert.Nil(t, recorder.Record(now))
+
+	var samples []*typed.SelfFootprint
+	err = db.View(func(txn badgerwrap.Txn) error {
+		samples, err = typed.OpenSelfFootprintTable().RangeRead(txn, now, now)
+		return err
+	})
+	assert.Nil(t, err)
+	assert.Len(t, samples, 1)
+	assert.Equal(t, now.Unix(), samples[0].Timestamp.Seconds)
+	assert.Equal(t, int64(2), samples[0].WatchQueueDepth)
+	assert.Equal(t, int64(10), samples[0].WatchQueueCapacity)
+	assert.Equal(t, int64(1), samples[0].AnnotationQueueDepth)
+	assert.True(t, samples[0].HeapBytes > 0)
+	assert.True(t, samples[0].Goroutines > 0)
+}
@@ -0,0 +1,214 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package storemanager
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/glog"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+var (
+	metricSelfTestHealthy  = promauto.NewGauge(prometheus.GaugeOpts{Name: "sloop_selftest_healthy"})
+	metricSelfTestFailures = promauto.NewGauge(prometheus.GaugeOpts{Name: "sloop_selftest_failures"})
+)
+
+// Errors kept in a report, the rest are only counted
+const maxSelfTestErrors = 100
+
+type SelfTestReport struct {
+	Running bool `json:"running"`
+	// No value failed to decode and every partition id is valid and not in the future.  Gaps do not count, sloop
+	// can just have been down
+	Healthy bool `json:"healthy"`
+	// Unix seconds
+	Started    int64           `json:"started"`
+	Elapsed    string          `json:"elapsed,omitempty"`
+	Partitions int             `json:"partitions"`
+	Oldest     string          `json:"oldest,omitempty"`
+	Newest     string          `json:"newest,omitempty"`
+	Tables     []SelfTestTable `json:"tables"`
+	Gaps       []SelfTestGap   `json:"gaps"`
+	Errors     []SelfTestError `json:"errors"`
+	// Errors past maxSelfTestErrors
+	MoreErrors int `json:"moreErrors,omitempty"`
+}
+
+type SelfTestTable struct {
+	Name       string `json:"name"`
+	Partitions int    `json:"partitions"`
+	Keys       uint64 `json:"keys"`
+	// Values read from the sampled keys.  Decoded is less than Sampled for tables whose value type is unknown,
+	// those values were only read from the store
+	Sampled  int `json:"sampled"`
+	Decoded  int `json:"decoded"`
+	Failures int `json:"failures"`
+}
+
+// Partitions missing between two partitions of the store
+type SelfTestGap struct {
+	After   string `json:"after"`
+	Before  string `json:"before"`
+	Missing int    `json:"missing"`
+}
+
+type SelfTestError struct {
+	Table     string `json:"table,omitempty"`
+	Partition string `json:"partition,omitempty"`
+	Key       string `json:"key,omitempty"`
+	Error     string `json:"error"`
+}
+
+/*
+Checks at startup that a restored or upgraded store can be read: the values of the first samples keys of every table
+in every partition are decoded the way queries do, and the partition ids are checked to be valid, not in the future
+and without gaps.  It only reads, so it runs in the background while the store is ingesting and serving.  The report
+is on /debug/selftest
+*/
+type SelfTest struct {
+	tables  typed.Tables
+	samples int
+	lock    *sync.Mutex
+	report  *SelfTestReport
+}
+
+func NewSelfTest(tables typed.Tables, samples int) *SelfTest {
+	return &SelfTest{tables: tables, samples: samples, lock: &sync.Mutex{}}
+}
+
+func (s *SelfTest) Start() {
+	s.setReport(&SelfTestReport{Running: true, Started: common.Now().Unix()})
+	go func() {
+		report := RunSelfTest(s.tables, s.samples, common.Now())
+		s.setReport(report)
+		if report.Healthy {
+			glog.Infof("Store self-test passed for %v partitions in %v", report.Partitions, report.Elapsed)
+		} else {
+			glog.Errorf("Store self-test failed with %v errors, see /debug/selftest", len(report.Errors)+report.MoreErrors)
+		}
+	}()
+}
+
+// Nil before Start
+func (s *SelfTest) Report() *SelfTestReport {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.report
+}
+
+func (s *SelfTest) setReport(report *SelfTestReport) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.report = report
+}
+
+func RunSelfTest(tables typed.Tables, samples int, now time.Time) *SelfTestReport {
+	before := time.Now()
+	report := &SelfTestReport{Started: now.Unix(), Tables: []SelfTestTable{}, Gaps: []SelfTestGap{}, Errors: []SelfTestError{}}
+	partitionMap, _ := common.GetPartitionsInfo(tables.Db())
+	partitionIds := common.GetSortedPartitionIDs(partitionMap)
+	report.Partitions = len(partitionIds)
+	if len(partitionIds) > 0 {
+		report.Oldest = partitionIds[0]
+		report.Newest = partitionIds[len(partitionIds)-1]
+	}
+	checkPartitionContinuity(report, partitionIds, now)
+
+	for _, tableName := range tables.GetTableNames() {
+		tableReport := SelfTestTable{Name: tableName}
+		for _, partitionId := range partitionIds {
+			keys := partitionMap[partitionId].TableNameToKeyCountMap[tableName]
+			if keys == 0 {
+				continue
+			}
+			tableReport.Partitions++
+			tableReport.Keys += keys
+			err := tables.Db().View(func(txn badgerwrap.Txn) error {
+				sampleTableValues(report, &tableReport, tables, txn, partitionId, samples)
+				return nil
+			})
+			if err != nil {
+				addSelfTestError(report, SelfTestError{Table: tableName, Partition: partitionId, Error: err.Error()})
+			}
+		}
+		report.Tables = append(report.Tables, tableReport)
+	}
+
+	failures := len(report.Errors) + report.MoreErrors
+	report.Healthy = failures == 0
+	report.Elapsed = time.Since(before).String()
+	metricSelfTestHealthy.Set(common.BoolToFloat(report.Healthy))
+	metricSelfTestFailures.Set(float64(failures))
+	return report
+}
+
+func sampleTableValues(report *SelfTestReport, tableReport *SelfTestTable, tables typed.Tables, txn badgerwrap.Txn, partitionId string, samples int) {
+	prefix := []byte(fmt.Sprintf("/%v/%v/", tableReport.Name, partitionId))
+	iterOpt := badger.DefaultIteratorOptions
+	iterOpt.Prefix = prefix
+	iterOpt.PrefetchValues = false
+	itr := txn.NewIterator(iterOpt)
+	defer itr.Close()
+	var keys []string
+	for itr.Seek(prefix); itr.ValidForPrefix(prefix) && len(keys) < samples; itr.Next() {
+		keys = append(keys, string(itr.Item().KeyCopy(nil)))
+	}
+	for _, key := range keys {
+		tableReport.Sampled++
+		decoded, err := typed.DecodeTableValue(tables, txn, tableReport.Name, key)
+		if decoded {
+			tableReport.Decoded++
+		}
+		if err != nil {
+			tableReport.Failures++
+			addSelfTestError(report, SelfTestError{Table: tableReport.Name, Partition: partitionId, Key: key, Error: err.Error()})
+		}
+	}
+}
+
+// Partitions are sorted oldest first
+func checkPartitionContinuity(report *SelfTestReport, partitionIds []string, now time.Time) {
+	newestAllowed := untyped.GetPartitionId(now)
+	var previous time.Time
+	for _, partitionId := range partitionIds {
+		err := ValidatePartitionId(partitionId)
+		if err != nil {
+			addSelfTestError(report, SelfTestError{Partition: partitionId, Error: err.Error()})
+			continue
+		}
+		if partitionId > newestAllowed {
+			addSelfTestError(report, SelfTestError{Partition: partitionId, Error: "partition is in the future, the clock or a restored store is off"})
+		}
+		partitionTime, _ := untyped.GetTimeForPartition(partitionId)
+		if !previous.IsZero() {
+			missing := int(partitionTime.Sub(previous)/untyped.GetPartitionDuration()) - 1
+			if missing > 0 {
+				report.Gaps = append(report.Gaps, SelfTestGap{After: untyped.GetPartitionId(previous), Before: partitionId, Missing: missing})
+			}
+		}
+		previous = partitionTime
+	}
+}
+
+func addSelfTestError(report *SelfTestReport, selfTestError SelfTestError) {
+	if len(report.Errors) >= maxSelfTestErrors {
+		report.MoreErrors++
+		return
+	}
+	report.Errors = append(report.Errors, selfTestError)
+}
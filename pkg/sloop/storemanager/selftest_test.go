@@ -0,0 +1,55 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package storemanager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+func Test_RunSelfTest_Healthy(t *testing.T) {
+	older := someTs.Add(-3 * time.Hour)
+	db := help_get_db_with_partitions(t, older, someTs)
+	tables := typed.NewTableList(db)
+
+	report := RunSelfTest(tables, 10, someTs)
+	assert.True(t, report.Healthy)
+	assert.Equal(t, 2, report.Partitions)
+	assert.Equal(t, untyped.GetPartitionId(older), report.Oldest)
+	assert.Equal(t, []SelfTestGap{{After: untyped.GetPartitionId(older), Before: untyped.GetPartitionId(someTs), Missing: 2}}, report.Gaps)
+	assert.Len(t, report.Errors, 0)
+	for _, table := range report.Tables {
+		if table.Name == (&typed.WatchTableKey{}).TableName() {
+			assert.Equal(t, SelfTestTable{Name: table.Name, Partitions: 2, Keys: 2, Sampled: 2, Decoded: 2}, table)
+		}
+	}
+}
+
+func Test_RunSelfTest_DamagedValueAndFuturePartition(t *testing.T) {
+	db := help_get_db_with_partitions(t, someTs, someTs.Add(2*time.Hour))
+	tables := typed.NewTableList(db)
+	damagedKey := typed.NewWatchTableKey(untyped.GetPartitionId(someTs), someKind, someNamespace, "damaged", someTs).String()
+	err := db.Update(func(txn badgerwrap.Txn) error {
+		return txn.Set([]byte(damagedKey), []byte{0xff, 0xff, 0xff})
+	})
+	assert.Nil(t, err)
+
+	// Only the first key of each table and partition is sampled, and the damaged one sorts first
+	report := RunSelfTest(tables, 1, someTs)
+	assert.False(t, report.Healthy)
+	assert.Len(t, report.Errors, 2)
+	assert.Equal(t, untyped.GetPartitionId(someTs.Add(2*time.Hour)), report.Errors[0].Partition)
+	assert.Equal(t, damagedKey, report.Errors[1].Key)
+	assert.Equal(t, (&typed.WatchTableKey{}).TableName(), report.Errors[1].Table)
+}
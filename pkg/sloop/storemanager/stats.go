@@ -20,6 +20,8 @@ var (
 	metricStoreSizeOnDiskMb          = promauto.NewGauge(prometheus.GaugeOpts{Name: "sloop_store_sizeondiskmb"})
 	metricBadgerKeys                 = promauto.NewGaugeVec(prometheus.GaugeOpts{Name: "sloop_badger_keys"}, []string{"level"})
 	metricBadgerTables               = promauto.NewGaugeVec(prometheus.GaugeOpts{Name: "sloop_badger_tables"}, []string{"level"})
+	metricBadgerLevelSizeBytes       = promauto.NewGaugeVec(prometheus.GaugeOpts{Name: "sloop_badger_level_size_bytes"}, []string{"level"})
+	metricBadgerMaxTableId           = promauto.NewGauge(prometheus.GaugeOpts{Name: "sloop_badger_max_table_id"})
 	metricBadgerLsmFileCount         = promauto.NewGauge(prometheus.GaugeOpts{Name: "sloop_badger_lsmfilecount"})
 	metricBadgerLsmSizeMb            = promauto.NewGauge(prometheus.GaugeOpts{Name: "sloop_badger_lsmsizemb"})
 	metricBadgerVLogFileCount        = promauto.NewGauge(prometheus.GaugeOpts{Name: "sloop_badger_vlogfilecount"})
@@ -42,13 +44,17 @@ type storeStats struct {
 	DiskVlogFileCount int
 	LevelToKeyCount   map[int]uint64
 	LevelToTableCount map[int]int
-	TotalKeyCount     uint64
+	LevelToSizeBytes  map[int]uint64
+	// Badger numbers tables in the order it writes them, by memtable flushes and compactions
+	MaxTableId    uint64
+	TotalKeyCount uint64
 }
 
 func generateStats(storeRoot string, db badgerwrap.DB, fs *afero.Afero) *storeStats {
 	ret := &storeStats{}
 	ret.LevelToKeyCount = make(map[int]uint64)
 	ret.LevelToTableCount = make(map[int]int)
+	ret.LevelToSizeBytes = make(map[int]uint64)
 	ret.timestamp = time.Now()
 
 	totalSizeBytes, extFileCount, extByteCount, err := getDirSizeRecursive(storeRoot, fs)
@@ -67,6 +73,10 @@ func generateStats(storeRoot string, db badgerwrap.DB, fs *afero.Afero) *storeSt
 		glog.V(common.GlogVerbose).Infof("BadgerDB TABLE id=%v keycount=%v level=%v left=%q right=%q", table.ID, table.KeyCount, table.Level, string(table.Left), string(table.Right))
 		ret.LevelToTableCount[table.Level] += 1
 		ret.LevelToKeyCount[table.Level] += table.KeyCount
+		ret.LevelToSizeBytes[table.Level] += table.EstimatedSz
+		if table.ID > ret.MaxTableId {
+			ret.MaxTableId = table.ID
+		}
 	}
 
 	glog.V(common.GlogVerbose).Infof("Finished updating store stats: %+v", ret)
@@ -106,6 +116,12 @@ func emitMetrics(stats *storeStats) {
 	for k, v := range stats.LevelToTableCount {
 		metricBadgerTables.WithLabelValues(fmt.Sprintf("%v", k)).Set(float64(v))
 	}
+	for k, v := range stats.LevelToSizeBytes {
+		metricBadgerLevelSizeBytes.WithLabelValues(fmt.Sprintf("%v", k)).Set(float64(v))
+	}
+	if stats.MaxTableId > 0 {
+		metricBadgerMaxTableId.Set(float64(stats.MaxTableId))
+	}
 	metricBadgerLsmFileCount.Set(float64(stats.DiskLsmFileCount))
 	metricBadgerLsmSizeMb.Set(float64(stats.DiskLsmBytes / 1024 / 1024))
 	metricBadgerVLogFileCount.Set(float64(stats.DiskVlogFileCount))
@@ -15,9 +15,12 @@ import (
 	"github.com/salesforce/sloop/pkg/sloop/common"
 	"github.com/salesforce/sloop/pkg/sloop/store/typed"
 	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/tenant"
 	"github.com/spf13/afero"
 	"math"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -41,16 +44,34 @@ var (
 	metricTotalNumberOfKeys            = promauto.NewGauge(prometheus.GaugeOpts{Name: "sloop_total_number_of_keys"})
 )
 
+// Tables derived from events.  They are kept for EventTimeLimit, which can be longer than the TimeLimit of all other
+// tables since event messages stay useful long after the full payloads
+var eventTableNames = map[string]bool{
+	(&typed.EventCountKey{}).TableName():                          true,
+	(&typed.EventFoldKey{}).TableName():                           true,
+	typed.OpenEventRollupTable(typed.EventRollupHour).TableName(): true,
+	typed.OpenEventRollupTable(typed.EventRollupDay).TableName():  true,
+}
+
 type Config struct {
 	StoreRoot          string
 	Freq               time.Duration
 	TimeLimit          time.Duration
+	EventTimeLimit     time.Duration // 0 means the same as TimeLimit
 	SizeLimitBytes     int
 	BadgerDiscardRatio float64
 	BadgerVLogGCFreq   time.Duration
 	DeletionBatchSize  int
-	GCThreshold        float64
-	EnableDeleteKeys   bool
+	// Pause between batches of deleted keys, so GC of huge partitions leaves room for queries and ingestion
+	DeletionBatchSleep time.Duration
+	// Tables of a partition GC deletes at the same time, 1 or less deletes them one after the other
+	MaxConcurrentTableDeletes int
+	GCThreshold               float64
+	EnableDeleteKeys          bool
+	// Tenants with a retention shorter than TimeLimit lose their keys earlier
+	Tenants tenant.Map
+	// How often to log a BudgetReport of the last day, 0 means never
+	BudgetReportFreq time.Duration
 }
 
 type StoreManager struct {
@@ -63,6 +84,8 @@ type StoreManager struct {
 	donelock *sync.Mutex
 	config   *Config
 	stats    *storeStats
+	// Set by idle.Monitor, 1 or less when not idle
+	idleSlowdown int32
 }
 
 func NewStoreManager(tables typed.Tables, config *Config, fs *afero.Afero) *StoreManager {
@@ -77,6 +100,43 @@ func NewStoreManager(tables typed.Tables, config *Config, fs *afero.Afero) *Stor
 	}
 }
 
+func (c *Config) getEventTimeLimit() time.Duration {
+	if c.EventTimeLimit <= 0 {
+		return c.TimeLimit
+	}
+	return c.EventTimeLimit
+}
+
+func (sm *StoreManager) gcPacing() gcPacing {
+	return gcPacing{
+		batchSize:           sm.config.DeletionBatchSize,
+		batchSleep:          sm.config.DeletionBatchSleep,
+		maxConcurrentTables: sm.config.MaxConcurrentTableDeletes,
+		sleeper:             sm.sleeper,
+		stop:                sm.isDone,
+	}
+}
+
+// While idle, value log GC runs factor times less often and does one pass per run instead of rewriting value log
+// files until none is left to rewrite.  Partition GC keeps its pace so retention and size limits still hold
+func (sm *StoreManager) SetIdleSlowdown(factor int) {
+	atomic.StoreInt32(&sm.idleSlowdown, int32(factor))
+}
+
+func (sm *StoreManager) isIdle() bool {
+	return atomic.LoadInt32(&sm.idleSlowdown) > 1
+}
+
+// Sleeps freq, and up to the slowdown more times while idle.  Waking up ends it after the current freq
+func (sm *StoreManager) sleepIdle(freq time.Duration) {
+	for round := int32(0); round == 0 || round < atomic.LoadInt32(&sm.idleSlowdown); round++ {
+		if sm.isDone() {
+			return
+		}
+		sm.sleeper.Sleep(freq)
+	}
+}
+
 func (sm *StoreManager) isDone() bool {
 	sm.donelock.Lock()
 	defer sm.donelock.Unlock()
@@ -86,6 +146,9 @@ func (sm *StoreManager) isDone() bool {
 func (sm *StoreManager) Start() {
 	go sm.gcLoop()
 	go sm.vlogGcLoop()
+	if sm.config.BudgetReportFreq > 0 {
+		go sm.budgetReportLoop()
+	}
 }
 
 func (sm *StoreManager) gcLoop() {
@@ -103,7 +166,11 @@ func (sm *StoreManager) gcLoop() {
 		metricGcRunCount.Inc()
 		before := time.Now()
 		metricGcRunning.Set(1)
-		cleanUpPerformed, numOfDeletedKeys, numOfKeysToDelete, err := doCleanup(sm.tables, sm.config.TimeLimit, sm.config.SizeLimitBytes, sm.stats, sm.config.DeletionBatchSize, sm.config.GCThreshold, sm.config.EnableDeleteKeys)
+		_, overrideErr := expireRetentionOverrides(sm.tables.Db(), common.Now())
+		if overrideErr != nil {
+			glog.Errorf("Removing expired retention overrides failed: %v", overrideErr)
+		}
+		cleanUpPerformed, numOfDeletedKeys, numOfKeysToDelete, err := doCleanup(sm.tables, sm.config.TimeLimit, sm.config.getEventTimeLimit(), sm.config.SizeLimitBytes, sm.stats, sm.gcPacing(), sm.config.GCThreshold, sm.config.EnableDeleteKeys)
 		metricGcCleanUpPerformed.Set(common.BoolToFloat(cleanUpPerformed))
 		metricGcDeletedNumberOfKeys.Set(float64(numOfDeletedKeys))
 		metricGcNumberOfKeysToDelete.Set(float64(numOfKeysToDelete))
@@ -113,6 +180,20 @@ func (sm *StoreManager) gcLoop() {
 		} else {
 			metricGcFailedCount.Inc()
 		}
+		if len(sm.config.Tenants) > 0 {
+			_, tenantErr := cleanUpTenantRetention(sm.tables, sm.config.Tenants, sm.config.DeletionBatchSize)
+			if tenantErr != nil {
+				glog.Errorf("Tenant retention failed: %v", tenantErr)
+			}
+		}
+		_, purgeErr := expirePurgedKeys(sm.tables, common.Now(), sm.config.DeletionBatchSize)
+		if purgeErr != nil {
+			glog.Errorf("Deleting expired soft deleted keys failed: %v", purgeErr)
+		}
+		_, checksumErr := ChecksumClosedPartitions(sm.tables, common.Now())
+		if checksumErr != nil {
+			glog.Errorf("Partition checksums failed: %v", checksumErr)
+		}
 		metricGcLatency.Set(time.Since(before).Seconds())
 		glog.V(common.GlogVerbose).Infof("GC finished in %v with error '%v'.  Next run in %v", time.Since(before), err, sm.config.Freq)
 
@@ -150,8 +231,29 @@ func (sm *StoreManager) vlogGcLoop() {
 			var afterGCEnds = sm.refreshStats()
 			var deltaStats = getDeltaStats(beforeGCStats, afterGCEnds)
 			emitGCMetrics(deltaStats)
+			if sm.isIdle() {
+				break
+			}
 		}
-		sm.sleeper.Sleep(sm.config.BadgerVLogGCFreq)
+		sm.sleepIdle(sm.config.BadgerVLogGCFreq)
+	}
+}
+
+func (sm *StoreManager) budgetReportLoop() {
+	sm.wg.Add(1)
+	defer sm.wg.Done()
+	for {
+		sm.sleeper.Sleep(sm.config.BudgetReportFreq)
+		if sm.isDone() {
+			glog.Infof("Budget report loop exiting")
+			return
+		}
+		report, err := GenerateBudgetReport(sm.tables, common.Now(), BudgetReportWindow)
+		if err != nil {
+			glog.Errorf("Failed to generate budget report: %v", err)
+			continue
+		}
+		logBudgetReport(report)
 	}
 }
 
@@ -175,16 +277,28 @@ func (sm *StoreManager) refreshStats() *storeStats {
 	return sm.stats
 }
 
-func doCleanup(tables typed.Tables, timeLimit time.Duration, sizeLimitBytes int, stats *storeStats, deletionBatchSize int, gcThreshold float64, enableDeletePrefix bool) (bool, int64, int64, error) {
+func doCleanup(tables typed.Tables, timeLimit time.Duration, eventTimeLimit time.Duration, sizeLimitBytes int, stats *storeStats, pacing gcPacing, gcThreshold float64, enableDeletePrefix bool) (bool, int64, int64, error) {
 	anyCleanupPerformed := false
 	var totalNumOfDeletedKeys int64 = 0
 	var totalNumOfKeysToDelete int64 = 0
-	partitionsToDelete, partitionsInfoMap := getPartitionsToDelete(tables, timeLimit, sizeLimitBytes, stats.DiskSizeBytes, gcThreshold)
+	partitionsToDelete, partitionsInfoMap := getPartitionsToDelete(tables, timeLimit, eventTimeLimit, sizeLimitBytes, stats.DiskSizeBytes, gcThreshold)
+
+	// Without the overrides no key is safe to delete, so wait for the next run
+	overrides, err := readActiveRetentionOverrides(tables.Db(), common.Now())
+	if err != nil {
+		return false, 0, 0, fmt.Errorf("GC skipped, failed to read the retention overrides: %v", err)
+	}
+
+	sortedPartitionsToDelete := make([]string, 0, len(partitionsToDelete))
+	for partitionToDelete := range partitionsToDelete {
+		sortedPartitionsToDelete = append(sortedPartitionsToDelete, partitionToDelete)
+	}
+	sort.Strings(sortedPartitionsToDelete)
 
 	beforeGCTime := time.Now()
-	for _, partitionToDelete := range partitionsToDelete {
+	for _, partitionToDelete := range sortedPartitionsToDelete {
 		partitionInfo := partitionsInfoMap[partitionToDelete]
-		numOfDeletedKeysForPrefix, numOfKeysToDeleteForPrefix, errMessages := deletePartition(partitionToDelete, tables, deletionBatchSize, enableDeletePrefix, partitionInfo)
+		numOfDeletedKeysForPrefix, numOfKeysToDeleteForPrefix, errMessages := deletePartition(partitionToDelete, partitionsToDelete[partitionToDelete], tables, pacing, enableDeletePrefix, partitionInfo, overrides)
 		anyCleanupPerformed = true
 		if len(errMessages) != 0 {
 			var errMsg string
@@ -243,7 +357,8 @@ func getPartitionsToDeleteWhenSizeConditionHasBeenMet(sizeLimitBytes int, diskSi
 	garbageCollectionRatio := getGarbageCollectionRatio(float64(diskSizeBytes), sizeLimitBytes, gcThreshold)
 	numOfKeysToDeleteForFileSizeCondition := getNumberOfKeysToDelete(garbageCollectionRatio, totalKeysCount)
 	index := 0
-	for keysToBeCollected < numOfKeysToDeleteForFileSizeCondition {
+	// Frozen partitions are not in the list, so it can run out before enough keys are collected
+	for keysToBeCollected < numOfKeysToDeleteForFileSizeCondition && index < len(sortedPartitionsList) {
 		keysToBeCollected += partitionMap[sortedPartitionsList[index]].TotalKeyCount
 		partitionsToDelete = append(partitionsToDelete, sortedPartitionsList[index])
 		index++
@@ -252,7 +367,9 @@ func getPartitionsToDeleteWhenSizeConditionHasBeenMet(sizeLimitBytes int, diskSi
 	return partitionsToDelete
 }
 
-func getPartitionsToDelete(tables typed.Tables, timeLimit time.Duration, sizeLimitBytes int, diskSizeBytes int64, gcThreshold float64) ([]string, map[string]*common.PartitionInfo) {
+// Returns the tables to delete from each partition.  Partitions collected for the size limit are removed from every
+// table, otherwise the event tables and all other tables each follow their own time limit
+func getPartitionsToDelete(tables typed.Tables, timeLimit time.Duration, eventTimeLimit time.Duration, sizeLimitBytes int, diskSizeBytes int64, gcThreshold float64) (map[string][]string, map[string]*common.PartitionInfo) {
 
 	ok, minPartition, maxPartition := getMinAndMaxPartitionsAndSetMetrics(tables)
 	if !ok {
@@ -262,17 +379,28 @@ func getPartitionsToDelete(tables typed.Tables, timeLimit time.Duration, sizeLim
 	// check if size condition has been met
 	sizeConditionMet := hasFilesOnDiskExceededThreshold(diskSizeBytes, sizeLimitBytes, gcThreshold)
 
-	needCleanUp := sizeConditionMet || cleanUpTimeCondition(minPartition, maxPartition, timeLimit)
+	needCleanUp := sizeConditionMet || cleanUpTimeCondition(minPartition, maxPartition, timeLimit) || cleanUpTimeCondition(minPartition, maxPartition, eventTimeLimit)
 	if !needCleanUp {
 		return nil, nil
 	}
 
+	// Without the freezes no partition is safe to delete, so wait for the next run
+	freezes, err := readFreezes(tables.Db())
+	if err != nil {
+		glog.Errorf("GC skipped, failed to read the partition freezes: %v", err)
+		return nil, nil
+	}
+
 	partitionMap, totalKeysCount := common.GetPartitionsInfo(tables.Db())
-	var partitionsToDelete []string
-	sortedPartitionsList := common.GetSortedPartitionIDs(partitionMap)
+	partitionsToDelete := map[string][]string{}
+	allPartitions := common.GetSortedPartitionIDs(partitionMap)
+	sortedPartitionsList := withoutFrozenPartitions(allPartitions, freezes)
+	metricGcFrozenPartitions.Set(float64(len(allPartitions) - len(sortedPartitionsList)))
 
 	if sizeConditionMet {
-		partitionsToDelete = getPartitionsToDeleteWhenSizeConditionHasBeenMet(sizeLimitBytes, diskSizeBytes, gcThreshold, totalKeysCount, partitionMap, sortedPartitionsList)
+		for _, partitionId := range getPartitionsToDeleteWhenSizeConditionHasBeenMet(sizeLimitBytes, diskSizeBytes, gcThreshold, totalKeysCount, partitionMap, sortedPartitionsList) {
+			partitionsToDelete[partitionId] = tables.GetTableNames()
+		}
 	}
 
 	// if all the partitions have to be cleaned uo there is no need to further check for time condition
@@ -280,62 +408,149 @@ func getPartitionsToDelete(tables typed.Tables, timeLimit time.Duration, sizeLim
 		return partitionsToDelete, partitionMap
 	}
 
-	// Is clean up condition still not met for partitions collected for size
-	currentLastPartitionToDeleteIndex := len(partitionsToDelete)
-	for currentLastPartitionToDeleteIndex < len(sortedPartitionsList) && cleanUpTimeCondition(sortedPartitionsList[currentLastPartitionToDeleteIndex], maxPartition, timeLimit) {
-		partitionsToDelete = append(partitionsToDelete, sortedPartitionsList[currentLastPartitionToDeleteIndex])
-		currentLastPartitionToDeleteIndex++
+	// Is clean up condition still not met for partitions collected for size.  Partitions past the shorter time limit
+	// only lose some of their tables, and the oldest of those is the oldest partition left
+	minRemainingPartitionIndex := len(sortedPartitionsList)
+	for index := len(partitionsToDelete); index < len(sortedPartitionsList); index++ {
+		partitionId := sortedPartitionsList[index]
+		deletePayloads := cleanUpTimeCondition(partitionId, maxPartition, timeLimit)
+		deleteEvents := cleanUpTimeCondition(partitionId, maxPartition, eventTimeLimit)
+		if !(deletePayloads && deleteEvents) && index < minRemainingPartitionIndex {
+			minRemainingPartitionIndex = index
+		}
+		if !deletePayloads && !deleteEvents {
+			break
+		}
+		tableNames := getTablesToDelete(tables.GetTableNames(), partitionMap[partitionId], deletePayloads, deleteEvents)
+		if len(tableNames) > 0 {
+			partitionsToDelete[partitionId] = tableNames
+		}
 	}
 
-	minPartitionAge, err := untyped.GetAgeOfPartitionInHours(sortedPartitionsList[currentLastPartitionToDeleteIndex])
-	if err == nil {
-		metricAgeOfMinimumPartition.Set(minPartitionAge)
+	if minRemainingPartitionIndex < len(sortedPartitionsList) {
+		minPartitionAge, err := untyped.GetAgeOfPartitionInHours(sortedPartitionsList[minRemainingPartitionIndex])
+		if err == nil {
+			metricAgeOfMinimumPartition.Set(minPartitionAge)
+		}
 	}
 
 	return partitionsToDelete, partitionMap
 }
 
-func deletePartition(minPartition string, tables typed.Tables, deletionBatchSize int, enableDeleteKeys bool, partitionInfo *common.PartitionInfo) (uint64, uint64, []string) {
+// Tables of the partition whose time limit has passed.  Tables with no keys left in the partition are skipped, so
+// partitions kept only for their events do not drop the other tables again on every run
+func getTablesToDelete(tableNames []string, partitionInfo *common.PartitionInfo, deletePayloads bool, deleteEvents bool) []string {
+	var tablesToDelete []string
+	for _, tableName := range tableNames {
+		if partitionInfo != nil && partitionInfo.TableNameToKeyCountMap[tableName] == 0 {
+			continue
+		}
+		if eventTableNames[tableName] {
+			if deleteEvents {
+				tablesToDelete = append(tablesToDelete, tableName)
+			}
+		} else if deletePayloads {
+			tablesToDelete = append(tablesToDelete, tableName)
+		}
+	}
+	return tablesToDelete
+}
+
+func deletePartition(minPartition string, tableNames []string, tables typed.Tables, pacing gcPacing, enableDeleteKeys bool, partitionInfo *common.PartitionInfo, overrides []*typed.RetentionOverride) (uint64, uint64, []string) {
 	var totalNumOfDeletedKeysForPrefix uint64 = 0
 	var totalNumOfKeysToDeleteForPrefix uint64 = 0
-	var numOfDeletedKeysForPrefix uint64 = 0
-	var numOfKeysToDeleteForPrefix uint64 = 0
 
 	partStart, partEnd, err := untyped.GetTimeRangeForPartition(minPartition)
-	glog.Infof("GC removing partition %q with data from %v to %v (err %v)", minPartition, partStart, partEnd, err)
+	glog.Infof("GC removing partition %q of tables %v with data from %v to %v (err %v)", minPartition, tableNames, partStart, partEnd, err)
+
+	var keysToDelete uint64
+	for _, tableName := range tableNames {
+		keysToDelete += partitionInfo.TableNameToKeyCountMap[tableName]
+	}
+	startPartitionProgress(keysToDelete)
+
+	// Tables are deleted concurrently up to the pacing limit, results are kept by index so the errors stay in order
+	deletedKeys := make([]uint64, len(tableNames))
+	keysToDeleteByTable := make([]uint64, len(tableNames))
+	tableErrors := make([]string, len(tableNames))
+	semaphore := make(chan struct{}, pacing.concurrency())
+	wg := sync.WaitGroup{}
+	for idx, tableName := range tableNames {
+		semaphore <- struct{}{}
+		wg.Add(1)
+		go func(idx int, tableName string) {
+			defer func() {
+				metricGcTablesDeleting.Dec()
+				<-semaphore
+				wg.Done()
+			}()
+			metricGcTablesDeleting.Inc()
+			deletedKeys[idx], keysToDeleteByTable[idx], tableErrors[idx] = deletePartitionTable(minPartition, tableName, tables, pacing, enableDeleteKeys, partitionInfo, overrides)
+		}(idx, tableName)
+	}
+	wg.Wait()
+
 	var errMessages []string
-	for _, tableName := range tables.GetTableNames() {
-		prefix := fmt.Sprintf("/%s/%s", tableName, minPartition)
-		start := time.Now()
-		numberOfKeysToRemove := partitionInfo.TableNameToKeyCountMap[tableName]
-		if enableDeleteKeys {
-			err, numOfDeletedKeysForPrefix, numOfKeysToDeleteForPrefix = common.DeleteKeysWithPrefix(prefix, tables.Db(), deletionBatchSize, numberOfKeysToRemove)
-			metricGcDeletedNumberOfKeysByTable.WithLabelValues(fmt.Sprintf("%v", tableName)).Set(float64(numOfDeletedKeysForPrefix))
-		} else {
+	for idx := range tableNames {
+		if tableErrors[idx] != "" {
+			errMessages = append(errMessages, tableErrors[idx])
+		}
+		totalNumOfDeletedKeysForPrefix += deletedKeys[idx]
+		totalNumOfKeysToDeleteForPrefix += keysToDeleteByTable[idx]
+	}
 
-			beforeDropPrefix := time.Now()
-			err = tables.Db().DropPrefix([]byte(prefix))
+	return totalNumOfDeletedKeysForPrefix, totalNumOfKeysToDeleteForPrefix, errMessages
+}
 
-			// !badger!move keys for the given prefix should also be cleaned up. For details: https://github.com/dgraph-io/badger/issues/1288
-			err = tables.Db().DropPrefix([]byte("!badger!move" + prefix))
+// Returns the number of deleted keys and keys to delete of the table in the partition, and an error message if it failed
+// With retention overrides the keys are deleted one by one, leaving the ones the overrides keep
+func deletePartitionTable(minPartition string, tableName string, tables typed.Tables, pacing gcPacing, enableDeleteKeys bool, partitionInfo *common.PartitionInfo, overrides []*typed.RetentionOverride) (uint64, uint64, string) {
+	var err error
+	var numOfDeletedKeysForPrefix uint64 = 0
+	var numOfKeysToDeleteForPrefix uint64 = 0
 
-			metricDropPrefixLatency.Set(time.Since(beforeDropPrefix).Seconds())
-			// there will be same deletions for dropPrefix as the tables are locked when prefixes are dropped
-			numOfDeletedKeysForPrefix = numberOfKeysToRemove
-			numOfKeysToDeleteForPrefix = numberOfKeysToRemove
+	prefix := fmt.Sprintf("/%s/%s", tableName, minPartition)
+	start := time.Now()
+	numberOfKeysToRemove := partitionInfo.TableNameToKeyCountMap[tableName]
+	if len(overrides) > 0 {
+		numOfDeletedKeysForPrefix, _, err = deleteKeysNotRetained(tables.Db(), prefix+"/", overrides, pacing.batchSize)
+		numOfKeysToDeleteForPrefix = numOfDeletedKeysForPrefix
+		addPartitionKeysDeleted(numOfDeletedKeysForPrefix)
+	} else if enableDeleteKeys {
+		var reported uint64
+		afterBatch := func(deletedInBatch uint64) bool {
+			reported += deletedInBatch
+			return pacing.afterBatch(deletedInBatch)
 		}
+		err, numOfDeletedKeysForPrefix, numOfKeysToDeleteForPrefix = common.DeleteKeysWithPrefixPaced(prefix, tables.Db(), pacing.batchSize, numberOfKeysToRemove, afterBatch)
+		// The last batch is not followed by a pause
+		addPartitionKeysDeleted(numOfDeletedKeysForPrefix - reported)
+		metricGcDeletedNumberOfKeysByTable.WithLabelValues(fmt.Sprintf("%v", tableName)).Set(float64(numOfDeletedKeysForPrefix))
+	} else {
 
-		elapsed := time.Since(start)
-		glog.V(common.GlogVerbose).Infof("Call to DropPrefix(%v) took %v and removed %d keys with error: %v", prefix, elapsed, numOfDeletedKeysForPrefix, err)
-		if err != nil {
-			errMessages = append(errMessages, fmt.Sprintf("failed to cleanup with min key: %s, elapsed: %v,err: %v,", prefix, elapsed, err))
-		}
+		beforeDropPrefix := time.Now()
+		err = tables.Db().DropPrefix([]byte(prefix))
+
+		// !badger!move keys for the given prefix should also be cleaned up. For details: https://github.com/dgraph-io/badger/issues/1288
+		err = tables.Db().DropPrefix([]byte("!badger!move" + prefix))
 
-		totalNumOfDeletedKeysForPrefix += numOfDeletedKeysForPrefix
-		totalNumOfKeysToDeleteForPrefix += numOfKeysToDeleteForPrefix
+		metricDropPrefixLatency.Set(time.Since(beforeDropPrefix).Seconds())
+		// there will be same deletions for dropPrefix as the tables are locked when prefixes are dropped
+		numOfDeletedKeysForPrefix = numberOfKeysToRemove
+		numOfKeysToDeleteForPrefix = numberOfKeysToRemove
+		addPartitionKeysDeleted(numOfDeletedKeysForPrefix)
 	}
 
-	return totalNumOfDeletedKeysForPrefix, totalNumOfKeysToDeleteForPrefix, errMessages
+	elapsed := time.Since(start)
+	glog.V(common.GlogVerbose).Infof("Call to DropPrefix(%v) took %v and removed %d keys with error: %v", prefix, elapsed, numOfDeletedKeysForPrefix, err)
+	if err != nil {
+		return numOfDeletedKeysForPrefix, numOfKeysToDeleteForPrefix, fmt.Sprintf("failed to cleanup with min key: %s, elapsed: %v,err: %v,", prefix, elapsed, err)
+	}
+	err = updateChecksum(tables.Db(), minPartition, tableName)
+	if err != nil {
+		return numOfDeletedKeysForPrefix, numOfKeysToDeleteForPrefix, fmt.Sprintf("failed to update checksum of %s: %v", prefix, err)
+	}
+	return numOfDeletedKeysForPrefix, numOfKeysToDeleteForPrefix, ""
 }
 
 func cleanUpTimeCondition(minPartition string, maxPartition string, timeLimit time.Duration) bool {
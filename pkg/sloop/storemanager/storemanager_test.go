@@ -9,10 +9,12 @@ package storemanager
 
 import (
 	"github.com/stretchr/testify/assert"
+	"sort"
 	"testing"
 	"time"
 
 	"github.com/dgraph-io/badger/v2"
+	"github.com/salesforce/sloop/pkg/sloop/common"
 	"github.com/salesforce/sloop/pkg/sloop/store/typed"
 	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
 	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
@@ -129,7 +131,7 @@ func Test_doCleanup_true(t *testing.T) {
 		DiskSizeBytes: 10,
 	}
 
-	flag, _, _, err := doCleanup(tables, time.Hour, 2, stats, 10, 1, false)
+	flag, _, _, err := doCleanup(tables, time.Hour, time.Hour, 2, stats, gcPacing{batchSize: 10}, 1, false)
 	assert.True(t, flag)
 	assert.Nil(t, err)
 }
@@ -142,7 +144,7 @@ func Test_doCleanup_false(t *testing.T) {
 		DiskSizeBytes: 10,
 	}
 
-	flag, _, _, err := doCleanup(tables, time.Hour, 1000, stats, 10, 1, false)
+	flag, _, _, err := doCleanup(tables, time.Hour, time.Hour, 1000, stats, gcPacing{batchSize: 10}, 1, false)
 	assert.False(t, flag)
 	assert.Nil(t, err)
 }
@@ -151,13 +153,84 @@ func Test_getPartitionsToDelete(t *testing.T) {
 	db := help_get_db(t)
 	tables := typed.NewTableList(db)
 
-	partitionsToDelete, _ := getPartitionsToDelete(tables, time.Hour, 2, 10, 0.9)
+	partitionsToDelete, _ := getPartitionsToDelete(tables, time.Hour, time.Hour, 2, 10, 0.9)
 	assert.Equal(t, len(partitionsToDelete), 1)
 
-	partitionsToDelete, _ = getPartitionsToDelete(tables, time.Hour, 20, 10, 0.9)
+	partitionsToDelete, _ = getPartitionsToDelete(tables, time.Hour, time.Hour, 20, 10, 0.9)
 	assert.Equal(t, len(partitionsToDelete), 0)
 }
 
+func help_get_db_with_partitions(t *testing.T, timestamps ...time.Time) badgerwrap.DB {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+
+	wt := typed.OpenKubeWatchResultTable()
+	ec := typed.OpenResourceEventCountsTable()
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		for _, ts := range timestamps {
+			txerr := wt.Set(txn, typed.NewWatchTableKey(untyped.GetPartitionId(ts), someKind, someNamespace, someName, ts).String(), &typed.KubeWatchResult{Kind: someKind})
+			if txerr != nil {
+				return txerr
+			}
+			txerr = ec.Set(txn, typed.NewEventCountKey(ts, someKind, someNamespace, someName, someUid).String(), &typed.ResourceEventCounts{})
+			if txerr != nil {
+				return txerr
+			}
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+	return db
+}
+
+func Test_getPartitionsToDelete_LongerEventTimeLimit(t *testing.T) {
+	oldest := someTs.Add(-3 * time.Hour)
+	older := someTs.Add(-1 * time.Hour)
+	db := help_get_db_with_partitions(t, oldest, older, someTs)
+	tables := typed.NewTableList(db)
+	watchTable := (&typed.WatchTableKey{}).TableName()
+	eventCountTable := (&typed.EventCountKey{}).TableName()
+
+	// Payloads of both older partitions are past the limit, their events are not
+	partitionsToDelete, _ := getPartitionsToDelete(tables, 90*time.Minute, 5*time.Hour, 1000, 10, 0.9)
+	assert.Equal(t, map[string][]string{
+		untyped.GetPartitionId(oldest): {watchTable},
+		untyped.GetPartitionId(older):  {watchTable},
+	}, partitionsToDelete)
+
+	partitionsToDelete, _ = getPartitionsToDelete(tables, 90*time.Minute, 3*time.Hour, 1000, 10, 0.9)
+	assert.Equal(t, []string{eventCountTable, watchTable}, sortedStrings(partitionsToDelete[untyped.GetPartitionId(oldest)]))
+	assert.Equal(t, []string{watchTable}, partitionsToDelete[untyped.GetPartitionId(older)])
+	assert.Len(t, partitionsToDelete, 2)
+}
+
+func Test_doCleanup_KeepsEventsOfDeletedPayloads(t *testing.T) {
+	oldest := someTs.Add(-3 * time.Hour)
+	db := help_get_db_with_partitions(t, oldest, someTs)
+	tables := typed.NewTableList(db)
+	stats := &storeStats{DiskSizeBytes: 10}
+
+	flag, _, _, err := doCleanup(tables, 90*time.Minute, 5*time.Hour, 1000, stats, gcPacing{batchSize: 10}, 1, false)
+	assert.True(t, flag)
+	assert.Nil(t, err)
+
+	partitionMap, _ := common.GetPartitionsInfo(db)
+	oldestInfo := partitionMap[untyped.GetPartitionId(oldest)]
+	assert.Equal(t, uint64(0), oldestInfo.TableNameToKeyCountMap[(&typed.WatchTableKey{}).TableName()])
+	assert.Equal(t, uint64(1), oldestInfo.TableNameToKeyCountMap[(&typed.EventCountKey{}).TableName()])
+
+	// The payloads are already gone, so only the events are left to remove once their limit passes
+	partitionsToDelete, _ := getPartitionsToDelete(tables, 90*time.Minute, 3*time.Hour, 1000, 10, 0.9)
+	assert.Equal(t, map[string][]string{untyped.GetPartitionId(oldest): {(&typed.EventCountKey{}).TableName()}}, partitionsToDelete)
+}
+
+func sortedStrings(values []string) []string {
+	sorted := append([]string{}, values...)
+	sort.Strings(sorted)
+	return sorted
+}
+
 func Test_getGarbageCollectionRatio(t *testing.T) {
 	ratio := getGarbageCollectionRatio(1000, 900, 0.9)
 	assert.Equal(t, 0.19, ratio)
@@ -202,3 +275,17 @@ func Test_getNumberOfKeysToDelete_TestCeiling(t *testing.T) {
 	keysToDelete := getNumberOfKeysToDelete(0.33, 4)
 	assert.Equal(t, uint64(2), keysToDelete)
 }
+
+func Test_StoreManager_sleepIdle(t *testing.T) {
+	sm := NewStoreManager(nil, &Config{}, nil)
+	assert.False(t, sm.isIdle())
+
+	sm.SetIdleSlowdown(5)
+	assert.True(t, sm.isIdle())
+	before := time.Now()
+	sm.sleepIdle(10 * time.Millisecond)
+	assert.True(t, time.Since(before) >= 50*time.Millisecond)
+
+	sm.SetIdleSlowdown(1)
+	assert.False(t, sm.isIdle())
+}
@@ -0,0 +1,128 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package storemanager
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/salesforce/sloop/pkg/sloop/tenant"
+)
+
+var metricTenantRetentionDeletedCount = promauto.NewCounterVec(prometheus.CounterOpts{Name: "sloop_tenant_retention_deleted_count"}, []string{"tenant"})
+
+/*
+Deletes the keys of tenants with a retention shorter than the one of the store from partitions past that retention.
+Partitions are shared by all tenants, so unlike the regular GC this can not drop prefixes and has to look at every
+key of the partitions past the shortest tenant retention.  Keys of tables that are not scoped to a namespace are
+left for the regular GC, and so are the keys retention overrides keep.  Like the time limit of the store, ages are relative to the newest partition
+*/
+func cleanUpTenantRetention(tables typed.Tables, tenants tenant.Map, deletionBatchSize int) (uint64, error) {
+	ok, _, maxPartition, err := tables.GetMinAndMaxPartition()
+	if err != nil || !ok {
+		return 0, err
+	}
+	_, latestTime, err := untyped.GetTimeRangeForPartition(maxPartition)
+	if err != nil {
+		return 0, err
+	}
+
+	freezes, err := readFreezes(tables.Db())
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to read the partition freezes")
+	}
+	overrides, err := readActiveRetentionOverrides(tables.Db(), common.Now())
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to read the retention overrides")
+	}
+
+	partitionMap, _ := common.GetPartitionsInfo(tables.Db())
+	var totalDeleted uint64
+	for _, partitionId := range withoutFrozenPartitions(common.GetSortedPartitionIDs(partitionMap), freezes) {
+		oldestTime, _, err := untyped.GetTimeRangeForPartition(partitionId)
+		if err != nil {
+			return totalDeleted, err
+		}
+		expired := expiredTenants(tenants, latestTime.Sub(oldestTime))
+		if len(expired) == 0 {
+			// Partitions are sorted oldest first, so the rest are newer still
+			break
+		}
+		for _, tableName := range tables.GetTableNames() {
+			deleted, err := deleteTenantKeys(tables.Db(), fmt.Sprintf("/%v/%v/", tableName, partitionId), tenants, expired, overrides, deletionBatchSize)
+			totalDeleted += deleted
+			if err != nil {
+				return totalDeleted, errors.Wrapf(err, "failed to delete tenant keys of table %v in partition %v", tableName, partitionId)
+			}
+			if deleted > 0 {
+				err = updateChecksum(tables.Db(), partitionId, tableName)
+				if err != nil {
+					return totalDeleted, errors.Wrapf(err, "failed to update checksum of table %v in partition %v", tableName, partitionId)
+				}
+			}
+		}
+	}
+	return totalDeleted, nil
+}
+
+func expiredTenants(tenants tenant.Map, age time.Duration) map[string]bool {
+	expired := map[string]bool{}
+	for tenantName, t := range tenants {
+		if t.Retention > 0 && age > t.Retention {
+			expired[tenantName] = true
+		}
+	}
+	return expired
+}
+
+func deleteTenantKeys(db badgerwrap.DB, prefix string, tenants tenant.Map, expired map[string]bool, overrides []*typed.RetentionOverride, deletionBatchSize int) (uint64, error) {
+	var keys [][]byte
+	deletedByTenant := map[string]int{}
+	err := db.View(func(txn badgerwrap.Txn) error {
+		iterOpt := badger.DefaultIteratorOptions
+		iterOpt.PrefetchValues = false
+		itr := txn.NewIterator(iterOpt)
+		defer itr.Close()
+		for itr.Seek([]byte(prefix)); itr.ValidForPrefix([]byte(prefix)); itr.Next() {
+			namespace, ok := typed.KeyNamespace(string(itr.Item().Key()))
+			if !ok {
+				// Not scoped to a namespace, so the whole table can be skipped
+				return nil
+			}
+			tenantName := tenants.TenantForNamespace(namespace)
+			if expired[tenantName] && !isRetainedByOverride(overrides, string(itr.Item().Key())) {
+				keys = append(keys, itr.Item().KeyCopy(nil))
+				deletedByTenant[tenantName]++
+			}
+		}
+		return nil
+	})
+	if err != nil || len(keys) == 0 {
+		return 0, err
+	}
+
+	err, deleted := common.DeleteKeysInBatches(db, keys, deletionBatchSize)
+	if err != nil {
+		return deleted, err
+	}
+	for tenantName, count := range deletedByTenant {
+		metricTenantRetentionDeletedCount.WithLabelValues(tenantName).Add(float64(count))
+	}
+	glog.Infof("Tenant retention removed %v keys with prefix %v", deleted, prefix)
+	return deleted, nil
+}
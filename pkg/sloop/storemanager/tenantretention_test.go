@@ -0,0 +1,92 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package storemanager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/salesforce/sloop/pkg/sloop/tenant"
+)
+
+func Test_cleanUpTenantRetention(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	tenants := tenant.Map{
+		"short": {Namespaces: []string{"short-*"}, Retention: 2 * time.Hour},
+		"long":  {Namespaces: []string{"long"}},
+	}
+
+	var keys []string
+	for hour := 0; hour < 5; hour++ {
+		ts := someTs.Add(time.Duration(hour) * time.Hour)
+		keys = append(keys,
+			typed.NewWatchTableKey(untyped.GetPartitionId(ts), "Pod", "short-a", "pod", ts).String(),
+			typed.NewWatchTableKey(untyped.GetPartitionId(ts), "Pod", "long", "pod", ts).String(),
+			typed.NewResourceSummaryKey(ts, "Namespace", "", "short-a", "uid").String(),
+			typed.NewResourceSummaryKey(ts, "Node", "", "node", "uid").String(),
+		)
+	}
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		for _, key := range keys {
+			err := txn.Set([]byte(key), []byte{})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+
+	deleted, err := cleanUpTenantRetention(tables, tenants, 4)
+	assert.Nil(t, err)
+	// Measured from the end of the newest partition, the three oldest partitions are more than 2 hours old
+	assert.Equal(t, uint64(6), deleted)
+	remaining := map[string]int{}
+	for _, key := range common.GetKeysForPrefix(db, "") {
+		namespace, _ := typed.KeyNamespace(key)
+		remaining[namespace]++
+	}
+	assert.Equal(t, map[string]int{"short-a": 4, "long": 5, "": 5}, remaining)
+}
+
+func Test_cleanUpTenantRetention_SkipsFrozenPartitions(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	tenants := tenant.Map{"short": {Namespaces: []string{"short-*"}, Retention: time.Hour}}
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		for hour := 0; hour < 4; hour++ {
+			ts := someTs.Add(time.Duration(hour) * time.Hour)
+			err := txn.Set([]byte(typed.NewWatchTableKey(untyped.GetPartitionId(ts), "Pod", "short-a", "pod", ts).String()), []byte{})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+	_, err = NewFreezer(db).Freeze("inc-42", untyped.GetPartitionId(someTs), untyped.GetPartitionId(someTs), "", someTs)
+	assert.Nil(t, err)
+
+	// Of the three partitions past the retention the frozen one is kept
+	deleted, err := cleanUpTenantRetention(tables, tenants, 4)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(2), deleted)
+	assert.Contains(t, common.GetKeysForPrefix(db, ""), typed.NewWatchTableKey(untyped.GetPartitionId(someTs), "Pod", "short-a", "pod", someTs).String())
+}
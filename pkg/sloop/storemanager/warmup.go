@@ -0,0 +1,118 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package storemanager
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/glog"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+var (
+	metricWarmUpLatency = promauto.NewGauge(prometheus.GaugeOpts{Name: "sloop_warmup_latency_sec"})
+	metricWarmUpKeys    = promauto.NewGauge(prometheus.GaugeOpts{Name: "sloop_warmup_keys"})
+)
+
+type WarmUpStats struct {
+	Partitions []string
+	Keys       int64
+	// Of the values read for valueTables
+	ValueBytes int64
+	Elapsed    time.Duration
+}
+
+/*
+Reads the keys of every table in the newest partitions, and the values of valueTables, so Badger has loaded the
+table indexes and blocks the first queries after a restart would need and the OS has the files in its page cache.
+Queries mostly look at the last few hours, so a few partitions cover most of them.  It only reads, so it can run
+while the store is ingesting and serving
+*/
+func WarmUp(tables typed.Tables, partitions int, valueTables []string) (*WarmUpStats, error) {
+	before := time.Now()
+	stats := &WarmUpStats{}
+	ok, minPartition, maxPartition, err := tables.GetMinAndMaxPartition()
+	if err != nil || !ok {
+		return stats, err
+	}
+	partitionTime, err := untyped.GetTimeForPartition(maxPartition)
+	if err != nil {
+		return stats, err
+	}
+	for len(stats.Partitions) < partitions {
+		partitionId := untyped.GetPartitionId(partitionTime)
+		if partitionId < minPartition {
+			break
+		}
+		stats.Partitions = append(stats.Partitions, partitionId)
+		partitionTime = partitionTime.Add(-untyped.GetPartitionDuration())
+	}
+
+	err = tables.Db().View(func(txn badgerwrap.Txn) error {
+		for _, tableName := range tables.GetTableNames() {
+			readValues := common.Contains(valueTables, tableName)
+			for _, partitionId := range stats.Partitions {
+				keys, valueBytes, err := warmUpPrefix(txn, fmt.Sprintf("/%v/%v/", tableName, partitionId), readValues)
+				stats.Keys += keys
+				stats.ValueBytes += valueBytes
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	stats.Elapsed = time.Since(before)
+	metricWarmUpLatency.Set(stats.Elapsed.Seconds())
+	metricWarmUpKeys.Set(float64(stats.Keys))
+	return stats, err
+}
+
+func warmUpPrefix(txn badgerwrap.Txn, prefix string, readValues bool) (int64, int64, error) {
+	var keys int64
+	var valueBytes int64
+	iterOpt := badger.DefaultIteratorOptions
+	iterOpt.Prefix = []byte(prefix)
+	iterOpt.PrefetchValues = readValues
+	itr := txn.NewIterator(iterOpt)
+	defer itr.Close()
+	for itr.Seek([]byte(prefix)); itr.ValidForPrefix([]byte(prefix)); itr.Next() {
+		keys++
+		if !readValues {
+			continue
+		}
+		// Values in the value log are only read when asked for
+		err := itr.Item().Value(func(value []byte) error {
+			valueBytes += int64(len(value))
+			return nil
+		})
+		if err != nil {
+			return keys, valueBytes, err
+		}
+	}
+	return keys, valueBytes, nil
+}
+
+// Logs instead of failing, a cold store is only slower
+func WarmUpAndLog(tables typed.Tables, partitions int, valueTables []string) {
+	glog.Infof("Warming up the store with the newest %v partitions", partitions)
+	stats, err := WarmUp(tables, partitions, valueTables)
+	if err != nil {
+		glog.Errorf("Store warm up failed after %v keys: %v", stats.Keys, err)
+		return
+	}
+	glog.Infof("Warmed up the store with %v keys and %v value bytes of partitions %v in %v", stats.Keys, stats.ValueBytes, stats.Partitions, stats.Elapsed)
+}
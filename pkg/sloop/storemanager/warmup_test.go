@@ -0,0 +1,57 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package storemanager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+func Test_WarmUp(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+
+	stats, err := WarmUp(tables, 2, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), stats.Keys)
+
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		for _, ts := range []time.Time{someTs, someTs.Add(time.Hour), someTs.Add(2 * time.Hour), someTs.Add(2*time.Hour + time.Minute)} {
+			key := typed.NewWatchTableKey(untyped.GetPartitionId(ts), "Pod", "ns", "p", ts)
+			err := tables.WatchTable().Set(txn, key.String(), &typed.KubeWatchResult{Kind: "Pod", Payload: "{}"})
+			if err != nil {
+				return err
+			}
+		}
+		key := typed.NewResourceSummaryKey(someTs.Add(2*time.Hour), "Pod", "ns", "p", "uid")
+		return tables.ResourceSummaryTable().Set(txn, key.String(), &typed.ResourceSummary{})
+	})
+	assert.Nil(t, err)
+
+	// The oldest partition is left out
+	stats, err = WarmUp(tables, 2, []string{"watch"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{untyped.GetPartitionId(someTs.Add(2 * time.Hour)), untyped.GetPartitionId(someTs.Add(time.Hour))}, stats.Partitions)
+	assert.Equal(t, int64(4), stats.Keys)
+	assert.True(t, stats.ValueBytes > 0)
+
+	stats, err = WarmUp(tables, 10, nil)
+	assert.Nil(t, err)
+	assert.Len(t, stats.Partitions, 3)
+	assert.Equal(t, int64(5), stats.Keys)
+	assert.Equal(t, int64(0), stats.ValueBytes)
+}
@@ -0,0 +1,215 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package storesync
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/pkg/errors"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+const (
+	PartitionsPath = "/sync/partitions"
+	KeysPath       = "/sync/keys"
+	ResultsPath    = "/sync/results"
+	PushPath       = "/sync/push"
+	PartitionParam = "partition"
+
+	requestTimeout = 5 * time.Minute
+)
+
+// One side of a sync.  Both a local store and a remote sloop serving the sync API can be the source or destination
+type Endpoint interface {
+	// Partitions that have watch results, oldest first
+	Partitions() ([]string, error)
+	// Keys of all watch results in a partition
+	Keys(partition string) ([]string, error)
+	// Watch results stored under keys.  Keys that no longer exist are left out
+	Results(keys []string) ([]typed.KubeWatchResult, error)
+	// Sends watch results through processing as if they had just been watched
+	Push(results []typed.KubeWatchResult) error
+}
+
+type PartitionList struct {
+	Partitions []string `json:"partitions"`
+}
+
+type KeyList struct {
+	Keys []string `json:"keys"`
+}
+
+type PushResult struct {
+	Accepted int `json:"accepted"`
+}
+
+type localEndpoint struct {
+	tables     typed.Tables
+	ingestChan chan typed.KubeWatchResult
+}
+
+// Reads from tables and pushes into ingestChan.  Push fails when ingestChan is nil
+func NewLocalEndpoint(tables typed.Tables, ingestChan chan typed.KubeWatchResult) Endpoint {
+	return &localEndpoint{tables: tables, ingestChan: ingestChan}
+}
+
+func watchKeyPrefix() string {
+	return "/" + (&typed.WatchTableKey{}).TableName() + "/"
+}
+
+func (e *localEndpoint) Partitions() ([]string, error) {
+	partitions := []string{}
+	prefix := watchKeyPrefix()
+	err := e.tables.Db().View(func(txn badgerwrap.Txn) error {
+		itr := txn.NewIterator(badger.IteratorOptions{Prefix: []byte(prefix)})
+		defer itr.Close()
+		itr.Seek([]byte(prefix))
+		for itr.ValidForPrefix([]byte(prefix)) {
+			key := &typed.WatchTableKey{}
+			err := key.Parse(string(itr.Item().Key()))
+			if err != nil {
+				return err
+			}
+			partitions = append(partitions, key.PartitionId)
+			// Skip the rest of the partition
+			itr.Seek([]byte(prefix + key.PartitionId + "/" + string(rune(255))))
+		}
+		return nil
+	})
+	return partitions, err
+}
+
+func (e *localEndpoint) Keys(partition string) ([]string, error) {
+	keys := []string{}
+	prefix := []byte(watchKeyPrefix() + partition + "/")
+	err := e.tables.Db().View(func(txn badgerwrap.Txn) error {
+		itr := txn.NewIterator(badger.IteratorOptions{Prefix: prefix})
+		defer itr.Close()
+		for itr.Seek(prefix); itr.ValidForPrefix(prefix); itr.Next() {
+			// Stores can keep names in other forms, see typed.StartWatchKeyNames
+			key := &typed.WatchTableKey{}
+			err := key.Parse(string(itr.Item().Key()))
+			if err != nil {
+				return err
+			}
+			keys = append(keys, key.PlainString())
+		}
+		return nil
+	})
+	return keys, err
+}
+
+func (e *localEndpoint) Results(keys []string) ([]typed.KubeWatchResult, error) {
+	results := []typed.KubeWatchResult{}
+	err := e.tables.Db().View(func(txn badgerwrap.Txn) error {
+		for _, plainKey := range keys {
+			key := &typed.WatchTableKey{}
+			err := key.Parse(plainKey)
+			if err != nil {
+				return err
+			}
+			result, err := e.tables.WatchTable().Get(txn, key.String())
+			if err == badger.ErrKeyNotFound {
+				continue
+			} else if err != nil {
+				return err
+			}
+			results = append(results, *result)
+		}
+		return nil
+	})
+	return results, err
+}
+
+func (e *localEndpoint) Push(results []typed.KubeWatchResult) error {
+	if e.ingestChan == nil {
+		return fmt.Errorf("this store does not accept pushed watch results")
+	}
+	for _, result := range results {
+		e.ingestChan <- result
+	}
+	return nil
+}
+
+type remoteEndpoint struct {
+	baseUrl string
+	client  *http.Client
+}
+
+// Talks to the sync API of the sloop at baseUrl, for example http://sloop-archive:8080
+func NewRemoteEndpoint(baseUrl string) Endpoint {
+	return &remoteEndpoint{baseUrl: strings.TrimSuffix(baseUrl, "/"), client: &http.Client{Timeout: requestTimeout}}
+}
+
+func (e *remoteEndpoint) Partitions() ([]string, error) {
+	list := PartitionList{}
+	err := e.do(http.MethodGet, PartitionsPath, nil, &list)
+	return list.Partitions, err
+}
+
+func (e *remoteEndpoint) Keys(partition string) ([]string, error) {
+	list := KeyList{}
+	err := e.do(http.MethodGet, KeysPath+"?"+PartitionParam+"="+url.QueryEscape(partition), nil, &list)
+	return list.Keys, err
+}
+
+func (e *remoteEndpoint) Results(keys []string) ([]typed.KubeWatchResult, error) {
+	results := []typed.KubeWatchResult{}
+	err := e.do(http.MethodPost, ResultsPath, KeyList{Keys: keys}, &results)
+	return results, err
+}
+
+func (e *remoteEndpoint) Push(results []typed.KubeWatchResult) error {
+	return e.do(http.MethodPost, PushPath, results, &PushResult{})
+}
+
+func (e *remoteEndpoint) do(method string, path string, in interface{}, out interface{}) error {
+	var body []byte
+	if in != nil {
+		var err error
+		body, err = json.Marshal(in)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal sync request")
+		}
+	}
+
+	target := e.baseUrl + path
+	req, err := http.NewRequest(method, target, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrapf(err, "failed to build request for %v", target)
+	}
+	req.Header.Set("content-type", "application/json")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "failed to call %v", target)
+	}
+	defer resp.Body.Close()
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read response from %v", target)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%v returned status %v: %v", target, resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+	err = json.Unmarshal(respBody, out)
+	if err != nil {
+		return errors.Wrapf(err, "failed to unmarshal response from %v", target)
+	}
+	return nil
+}
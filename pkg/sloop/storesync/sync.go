@@ -0,0 +1,136 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package storesync
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+/*
+Copies the watch results that are in a source store but missing in a destination store, so an edge sloop can keep a
+central archive sloop up to date.  Only the watch table is copied.  The destination builds every other table from the
+pushed watch results, the same way it does for freshly watched ones.
+*/
+
+const defaultBatchSize = 500
+
+var metricSyncPushedCount = promauto.NewCounter(prometheus.CounterOpts{Name: "sloop_sync_pushed_count"})
+
+type Stats struct {
+	Partitions int `json:"partitions"`
+	Missing    int `json:"missing"`
+	Pushed     int `json:"pushed"`
+}
+
+// Pushes watch results of source that destination does not have, one partition at a time.  Results are pushed in
+// batches of batchSize, oldest first within each partition
+func Sync(source Endpoint, destination Endpoint, batchSize int) (Stats, error) {
+	stats := Stats{}
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+
+	partitions, err := source.Partitions()
+	if err != nil {
+		return stats, errors.Wrap(err, "failed to list source partitions")
+	}
+	for _, partition := range partitions {
+		stats.Partitions++
+		missing, err := missingKeys(source, destination, partition)
+		if err != nil {
+			return stats, err
+		}
+		stats.Missing += len(missing)
+
+		for start := 0; start < len(missing); start += batchSize {
+			end := start + batchSize
+			if end > len(missing) {
+				end = len(missing)
+			}
+			results, err := source.Results(missing[start:end])
+			if err != nil {
+				return stats, errors.Wrapf(err, "failed to read watch results of partition %v", partition)
+			}
+			err = destination.Push(results)
+			if err != nil {
+				return stats, errors.Wrapf(err, "failed to push watch results of partition %v", partition)
+			}
+			stats.Pushed += len(results)
+			metricSyncPushedCount.Add(float64(len(results)))
+		}
+	}
+	return stats, nil
+}
+
+func missingKeys(source Endpoint, destination Endpoint, partition string) ([]string, error) {
+	sourceKeys, err := source.Keys(partition)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list source keys of partition %v", partition)
+	}
+	destinationKeys, err := destination.Keys(partition)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list destination keys of partition %v", partition)
+	}
+
+	existing := map[string]bool{}
+	for _, key := range destinationKeys {
+		existing[key] = true
+	}
+	missing := []string{}
+	for _, key := range sourceKeys {
+		if !existing[key] {
+			missing = append(missing, key)
+		}
+	}
+	return missing, nil
+}
+
+// Entry point of `sloop sync`.  Both sides must be running sloop with enableSync set
+func RealMain(args []string) error {
+	fs := flag.NewFlagSet("sync", flag.ContinueOnError)
+	// Keep the glog flags working
+	flag.CommandLine.VisitAll(func(f *flag.Flag) { fs.Var(f.Value, f.Name, f.Usage) })
+	source := fs.String("source", "", "Base url of the sloop to copy watch results from, for example http://localhost:8080")
+	destination := fs.String("destination", "", "Base url of the sloop to copy missing watch results to")
+	batchSize := fs.Int("batch-size", defaultBatchSize, "Number of watch results read and pushed per request")
+	interval := fs.Duration("interval", 0, "Time between syncs.  0 = sync once and exit")
+	err := fs.Parse(args)
+	if err != nil {
+		return err
+	}
+	if *source == "" || *destination == "" {
+		return fmt.Errorf("both source and destination must be set")
+	}
+
+	sourceEndpoint := NewRemoteEndpoint(*source)
+	destinationEndpoint := NewRemoteEndpoint(*destination)
+	for {
+		stats, err := Sync(sourceEndpoint, destinationEndpoint, *batchSize)
+		if *interval == 0 {
+			if err != nil {
+				return err
+			}
+			glog.Infof("Sync from %v to %v done: %+v", *source, *destination, stats)
+			return nil
+		}
+		if err != nil {
+			// Try again at the next interval, the other side might just be restarting
+			glog.Errorf("Sync from %v to %v failed after %+v: %v", *source, *destination, stats, err)
+		} else {
+			glog.Infof("Sync from %v to %v done: %+v", *source, *destination, stats)
+		}
+		time.Sleep(*interval)
+	}
+}
@@ -0,0 +1,88 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package storesync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+var someTs = time.Date(2019, 3, 4, 3, 4, 0, 0, time.UTC)
+
+func helper_getTables(t *testing.T) typed.Tables {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	return typed.NewTableList(db)
+}
+
+func helper_addWatchResult(t *testing.T, tables typed.Tables, name string, ts time.Time) string {
+	pts, _ := ptypes.TimestampProto(ts)
+	val := &typed.KubeWatchResult{Kind: "Pod", WatchType: typed.KubeWatchResult_UPDATE, Timestamp: pts, Payload: `{"metadata":{"name":"` + name + `","namespace":"ns"}}`}
+	key := typed.NewWatchTableKey(untyped.GetPartitionId(ts), "Pod", "ns", name, ts).String()
+	err := tables.Db().Update(func(txn badgerwrap.Txn) error {
+		return tables.WatchTable().Set(txn, key, val)
+	})
+	assert.Nil(t, err)
+	return key
+}
+
+func Test_LocalEndpoint_PartitionsAndKeys(t *testing.T) {
+	tables := helper_getTables(t)
+	key1 := helper_addWatchResult(t, tables, "pod1", someTs)
+	key2 := helper_addWatchResult(t, tables, "pod2", someTs)
+	key3 := helper_addWatchResult(t, tables, "pod1", someTs.Add(2*time.Hour))
+
+	endpoint := NewLocalEndpoint(tables, nil)
+	partitions, err := endpoint.Partitions()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{untyped.GetPartitionId(someTs), untyped.GetPartitionId(someTs.Add(2 * time.Hour))}, partitions)
+
+	keys, err := endpoint.Keys(partitions[0])
+	assert.Nil(t, err)
+	assert.Equal(t, []string{key1, key2}, keys)
+	keys, err = endpoint.Keys(partitions[1])
+	assert.Nil(t, err)
+	assert.Equal(t, []string{key3}, keys)
+
+	results, err := endpoint.Results([]string{key3, typed.NewWatchTableKey(partitions[1], "Pod", "ns", "gone", someTs).String()})
+	assert.Nil(t, err)
+	assert.Len(t, results, 1)
+	assert.Contains(t, results[0].Payload, "pod1")
+
+	assert.NotNil(t, endpoint.Push(results))
+}
+
+func Test_Sync_PushesOnlyMissing(t *testing.T) {
+	source := helper_getTables(t)
+	helper_addWatchResult(t, source, "pod1", someTs)
+	helper_addWatchResult(t, source, "pod2", someTs.Add(time.Minute))
+	helper_addWatchResult(t, source, "pod1", someTs.Add(2*time.Hour))
+
+	destination := helper_getTables(t)
+	helper_addWatchResult(t, destination, "pod1", someTs)
+	ingestChan := make(chan typed.KubeWatchResult, 10)
+
+	stats, err := Sync(NewLocalEndpoint(source, nil), NewLocalEndpoint(destination, ingestChan), 1)
+	assert.Nil(t, err)
+	assert.Equal(t, Stats{Partitions: 2, Missing: 2, Pushed: 2}, stats)
+	close(ingestChan)
+	pushed := []string{}
+	for result := range ingestChan {
+		ts, _ := ptypes.Timestamp(result.Timestamp)
+		pushed = append(pushed, ts.String())
+	}
+	assert.Equal(t, []string{someTs.Add(time.Minute).String(), someTs.Add(2 * time.Hour).String()}, pushed)
+}
@@ -0,0 +1,121 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package tenant
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// A namespace pattern ending in this matches every namespace with the rest of the pattern as prefix
+const PrefixWildcard = "*"
+
+type Tenant struct {
+	// Namespace names, or prefixes like team-a-* for every namespace starting with team-a-
+	Namespaces []string `json:"namespaces"`
+	// How long data of the namespaces of this tenant is kept.  0 uses the retention of the store.  It can only be
+	// shorter than that, since whole partitions are dropped once they are older than the retention of the store
+	Retention time.Duration `json:"retention"`
+	// Ceiling on watch results stored per hour for the namespaces of this tenant.  0 = unlimited
+	MaxWatchResultsPerHour int `json:"maxWatchResultsPerHour"`
+	// Share of the query slots this tenant gets when queries have to queue, relative to the other tenants.  0 = 1
+	QueryWeight int `json:"queryWeight"`
+}
+
+/*
+Map assigns namespaces to tenants so one sloop can be shared by several teams.  The tenant of a key is the tenant of
+the namespace in it, so the keys on disk do not change when tenants are added or moved around.  A namespace matches
+the exact names of a tenant first and then its longest prefix.  Cluster scoped resources belong to no tenant, apart
+from Namespace objects which belong to the tenant of their name.
+*/
+type Map map[string]Tenant
+
+func (m Map) Validate() error {
+	exactOwner := map[string]string{}
+	prefixOwner := map[string]string{}
+	for tenantName, tenant := range m {
+		if tenantName == "" {
+			return fmt.Errorf("tenant name can not be empty")
+		}
+		if len(tenant.Namespaces) == 0 {
+			return fmt.Errorf("tenant %q does not own any namespaces", tenantName)
+		}
+		if tenant.Retention < 0 {
+			return fmt.Errorf("tenant %q retention can not be < 0", tenantName)
+		}
+		if tenant.MaxWatchResultsPerHour < 0 {
+			return fmt.Errorf("tenant %q maxWatchResultsPerHour can not be < 0", tenantName)
+		}
+		if tenant.QueryWeight < 0 {
+			return fmt.Errorf("tenant %q queryWeight can not be < 0", tenantName)
+		}
+		for _, pattern := range tenant.Namespaces {
+			owners := exactOwner
+			name := pattern
+			if strings.HasSuffix(pattern, PrefixWildcard) {
+				owners = prefixOwner
+				name = strings.TrimSuffix(pattern, PrefixWildcard)
+			}
+			if name == "" {
+				return fmt.Errorf("tenant %q has an empty namespace pattern", tenantName)
+			}
+			if other, ok := owners[name]; ok && other != tenantName {
+				return fmt.Errorf("both tenant %q and %q own namespace %q", other, tenantName, pattern)
+			}
+			owners[name] = tenantName
+		}
+	}
+	return nil
+}
+
+// Returns the tenant owning the namespace, or "" when none does
+func (m Map) TenantForNamespace(namespace string) string {
+	if namespace == "" {
+		return ""
+	}
+	owner := ""
+	longestPrefix := -1
+	for tenantName, tenant := range m {
+		for _, pattern := range tenant.Namespaces {
+			if pattern == namespace {
+				return tenantName
+			}
+			if strings.HasSuffix(pattern, PrefixWildcard) {
+				prefix := strings.TrimSuffix(pattern, PrefixWildcard)
+				if strings.HasPrefix(namespace, prefix) && len(prefix) > longestPrefix {
+					owner = tenantName
+					longestPrefix = len(prefix)
+				}
+			}
+		}
+	}
+	return owner
+}
+
+func (m Map) OwnsNamespace(tenantName string, namespace string) bool {
+	return tenantName != "" && m.TenantForNamespace(namespace) == tenantName
+}
+
+// Returns the query weight of the tenant, 1 for unknown tenants and tenants without one
+func (m Map) QueryWeight(tenantName string) int {
+	if weight := m[tenantName].QueryWeight; weight > 0 {
+		return weight
+	}
+	return 1
+}
+
+func (m Map) TenantNames() []string {
+	var ret []string
+	for tenantName := range m {
+		ret = append(ret, tenantName)
+	}
+	sort.Strings(ret)
+	return ret
+}
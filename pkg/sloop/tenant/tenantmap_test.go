@@ -0,0 +1,56 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package tenant
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var someTenantMap = Map{
+	"payments": {Namespaces: []string{"billing", "pay-*"}, Retention: time.Hour},
+	"platform": {Namespaces: []string{"kube-system", "pay-infra-*"}},
+}
+
+func Test_TenantMap_Validate_Success(t *testing.T) {
+	assert.Nil(t, someTenantMap.Validate())
+}
+
+func Test_TenantMap_Validate_Failures(t *testing.T) {
+	assert.NotNil(t, Map{"a": {}}.Validate())
+	assert.NotNil(t, Map{"": {Namespaces: []string{"a"}}}.Validate())
+	assert.NotNil(t, Map{"a": {Namespaces: []string{PrefixWildcard}}}.Validate())
+	assert.NotNil(t, Map{"a": {Namespaces: []string{"x"}}, "b": {Namespaces: []string{"x"}}}.Validate())
+	assert.NotNil(t, Map{"a": {Namespaces: []string{"x-*"}}, "b": {Namespaces: []string{"x-*"}}}.Validate())
+	assert.NotNil(t, Map{"a": {Namespaces: []string{"x"}, Retention: -time.Hour}}.Validate())
+	assert.NotNil(t, Map{"a": {Namespaces: []string{"x"}, MaxWatchResultsPerHour: -1}}.Validate())
+	assert.NotNil(t, Map{"a": {Namespaces: []string{"x"}, QueryWeight: -1}}.Validate())
+}
+
+func Test_TenantMap_QueryWeight(t *testing.T) {
+	tenants := Map{"a": {Namespaces: []string{"x"}, QueryWeight: 3}, "b": {Namespaces: []string{"y"}}}
+	assert.Equal(t, 3, tenants.QueryWeight("a"))
+	assert.Equal(t, 1, tenants.QueryWeight("b"))
+	assert.Equal(t, 1, tenants.QueryWeight("admins"))
+}
+
+func Test_TenantMap_TenantForNamespace(t *testing.T) {
+	assert.Equal(t, "payments", someTenantMap.TenantForNamespace("billing"))
+	assert.Equal(t, "payments", someTenantMap.TenantForNamespace("pay-api"))
+	// The longest prefix wins
+	assert.Equal(t, "platform", someTenantMap.TenantForNamespace("pay-infra-db"))
+	assert.Equal(t, "", someTenantMap.TenantForNamespace("default"))
+	assert.Equal(t, "", someTenantMap.TenantForNamespace(""))
+
+	assert.True(t, someTenantMap.OwnsNamespace("payments", "billing"))
+	assert.False(t, someTenantMap.OwnsNamespace("platform", "billing"))
+	assert.False(t, someTenantMap.OwnsNamespace("", "default"))
+	assert.Equal(t, []string{"payments", "platform"}, someTenantMap.TenantNames())
+}
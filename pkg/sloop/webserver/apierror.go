@@ -0,0 +1,60 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/golang/glog"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/queries"
+)
+
+var metricApiErrorCount = promauto.NewCounterVec(prometheus.CounterOpts{Name: "sloop_api_error_count"}, []string{"endpoint", "code"})
+
+/*
+Fails the request of a query endpoint with a queries.ErrorEnvelope, so callers can tell bad params from an
+unavailable store by the code instead of parsing the message.  The status comes from the code of err, see
+queries.ErrorCodeOf.  Internal errors are logged like logWebError with the note, the message of the others is
+already meant for the caller
+*/
+func writeApiError(writer http.ResponseWriter, request *http.Request, err error, note string) {
+	code := queries.ErrorCodeOf(err)
+	message := err.Error()
+	if code == queries.ErrorCodeInternal {
+		if note != "" {
+			message = fmt.Sprintf("%v: %v", note, err)
+		}
+		glog.ErrorDepth(1, fmt.Sprintf("Error rendering url: %q.  Note: %v. Error: %v", request.URL, note, err))
+	} else {
+		glog.V(common.GlogVerbose).Infof("Rejected %q with %v: %v", request.URL, code, err)
+	}
+	endpoint, _ := responseEndpoint(request)
+	metricApiErrorCount.WithLabelValues(endpoint, string(code)).Inc()
+
+	envelope := queries.ErrorEnvelope{Error: queries.ErrorBody{Code: code, Message: message, RequestId: getRequestId(request.Context())}}
+	header := writer.Header()
+	header.Del("Content-Length")
+	header.Set("content-type", "application/json")
+	header.Set(queries.ErrorCodeHeader, string(code))
+	writer.WriteHeader(code.Status())
+	body, _ := json.Marshal(envelope)
+	_, err = writer.Write(body)
+	if err != nil {
+		glog.Errorf("Failed to write error response: %v", err)
+	}
+}
+
+func writeApiErrorf(writer http.ResponseWriter, request *http.Request, code queries.ErrorCode, format string, args ...interface{}) {
+	writeApiError(writer, request, queries.NewApiError(code, format, args...), "")
+}
@@ -0,0 +1,49 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/pkg/errors"
+	"github.com/salesforce/sloop/pkg/sloop/queries"
+	"github.com/stretchr/testify/assert"
+)
+
+func helper_apiError(t *testing.T, err error) (*httptest.ResponseRecorder, queries.ErrorEnvelope) {
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/data?query=EventHeatMap", nil)
+	writeApiError(recorder, request, err, "Failed to run query")
+	envelope := queries.ErrorEnvelope{}
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &envelope))
+	return recorder, envelope
+}
+
+func Test_writeApiError_Codes(t *testing.T) {
+	recorder, envelope := helper_apiError(t, errors.Wrap(queries.NewApiError(queries.ErrorCodeBadParams, "invalid %v", "lookback"), "context"))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header().Get("content-type"))
+	assert.Equal(t, "bad_params", recorder.Header().Get(queries.ErrorCodeHeader))
+	assert.Equal(t, queries.ErrorCodeBadParams, envelope.Error.Code)
+	assert.Equal(t, "context: invalid lookback", envelope.Error.Message)
+
+	recorder, envelope = helper_apiError(t, errors.Wrap(badger.ErrBlockedWrites, "failed to write"))
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	assert.Equal(t, queries.ErrorCodeStoreUnavailable, envelope.Error.Code)
+
+	// Internal errors get the note, like logWebError
+	recorder, envelope = helper_apiError(t, fmt.Errorf("boom"))
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	assert.Equal(t, queries.ErrorCodeInternal, envelope.Error.Code)
+	assert.Equal(t, "Failed to run query: boom", envelope.Error.Message)
+}
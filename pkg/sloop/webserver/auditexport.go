@@ -0,0 +1,172 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+
+	"github.com/salesforce/sloop/pkg/sloop/queries"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+)
+
+const (
+	auditExportPath = "/admin/audit/export"
+	formatParam     = "format"
+	// Namespaces of a request that was not limited to some.  A tenant still only read its own namespaces
+	allAuditNamespaces = "*"
+)
+
+var auditExportColumns = []string{"time", "user", "tenant", "remote_addr", "request_id", "path", "query", "namespaces", "history_start", "history_end", "status", "result_bytes"}
+
+// One read of the query audit log, in the columns of the csv export
+type AuditExportRow struct {
+	Time       string `json:"time"`
+	User       string `json:"user"`
+	Tenant     string `json:"tenant"`
+	RemoteAddr string `json:"remoteAddr"`
+	RequestId  string `json:"requestId"`
+	Path       string `json:"path"`
+	Query      string `json:"query"`
+	// Sorted and separated by spaces, allAuditNamespaces when not limited to some
+	Namespaces string `json:"namespaces"`
+	// The time range of history the request asked for, empty when it did not name one.  A lookback ends at the
+	// time of the request
+	HistoryStart string `json:"historyStart"`
+	HistoryEnd   string `json:"historyEnd"`
+	Status       int32  `json:"status"`
+	ResultBytes  int64  `json:"resultBytes"`
+}
+
+func newAuditExportRow(record *typed.QueryAuditRecord) AuditExportRow {
+	row := AuditExportRow{User: record.User, Tenant: record.Tenant, RemoteAddr: record.RemoteAddr, RequestId: record.RequestId,
+		Path: record.Path, Query: record.Query, Status: record.Status, ResultBytes: record.ResultBytes}
+	ts, _ := ptypes.Timestamp(record.Timestamp)
+	row.Time = ts.UTC().Format(time.RFC3339)
+	params, _ := url.ParseQuery(record.Params)
+	row.Namespaces = auditNamespaces(params)
+	start, end := auditHistoryRange(params, ts)
+	if !start.IsZero() {
+		row.HistoryStart = start.UTC().Format(time.RFC3339)
+		row.HistoryEnd = end.UTC().Format(time.RFC3339)
+	}
+	return row
+}
+
+func auditNamespaces(params url.Values) string {
+	namespaces := []string{}
+	for _, param := range []string{queries.NamespaceParam, queries.OtherNamespaceParam} {
+		for _, namespace := range params[param] {
+			if namespace == queries.AllNamespaces {
+				return allAuditNamespaces
+			}
+			if namespace != "" {
+				namespaces = append(namespaces, namespace)
+			}
+		}
+	}
+	if len(namespaces) == 0 {
+		return allAuditNamespaces
+	}
+	sort.Strings(namespaces)
+	return strings.Join(namespaces, " ")
+}
+
+// From start_time and end_time, or lookback before end_time or the time of the request
+func auditHistoryRange(params url.Values, requestTime time.Time) (time.Time, time.Time) {
+	end := requestTime
+	if endTime, err := strconv.ParseInt(params.Get(queries.EndTimeParam), 10, 64); err == nil {
+		end = time.Unix(endTime, 0)
+	}
+	if startTime, err := strconv.ParseInt(params.Get(queries.StartTimeParam), 10, 64); err == nil {
+		return time.Unix(startTime, 0), end
+	}
+	if lookback, err := time.ParseDuration(params.Get(queries.LookbackParam)); err == nil {
+		return end.Add(-lookback), end
+	}
+	return time.Time{}, time.Time{}
+}
+
+/*
+Exports the query audit log for data access audits: who read the history of which namespaces, and when.  Params:
+start_time and end_time in unix seconds limit it to the requests made in that time range, user and tenant to one
+identity, and format is csv (default) or jsonl for one AuditExportRow per line.  Rows are oldest first.  Only the
+last -query-audit-size requests are in the log, so it should be exported more often than it fills up
+*/
+func auditExportHandler(auditLog *queryAuditLog, currentContext string) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		if auditLog == nil {
+			http.Error(writer, "the query audit log is off, see -query-audit-size", http.StatusNotFound)
+			return
+		}
+		from, err := timeFromUnixTimeParam(request, queries.StartTimeParam, time.Time{}, time.Second)
+		if err != nil {
+			http.Error(writer, fmt.Sprintf("invalid %v: %v", queries.StartTimeParam, err), http.StatusBadRequest)
+			return
+		}
+		to, err := timeFromUnixTimeParam(request, queries.EndTimeParam, time.Time{}, time.Second)
+		if err != nil {
+			http.Error(writer, fmt.Sprintf("invalid %v: %v", queries.EndTimeParam, err), http.StatusBadRequest)
+			return
+		}
+		format := request.URL.Query().Get(formatParam)
+		if format == "" {
+			format = "csv"
+		}
+		if format != "csv" && format != "jsonl" {
+			http.Error(writer, fmt.Sprintf("invalid %v %q, must be csv or jsonl", formatParam, format), http.StatusBadRequest)
+			return
+		}
+
+		records, err := auditLog.read(request.URL.Query().Get("user"), "", int(auditLog.size))
+		if err != nil {
+			logWebError(err, "failed to read the query audit log", request, writer)
+			return
+		}
+		selectedTenant := request.URL.Query().Get("tenant")
+		rows := []AuditExportRow{}
+		for idx := len(records) - 1; idx >= 0; idx-- {
+			record := records[idx]
+			ts, _ := ptypes.Timestamp(record.Timestamp)
+			if (!from.IsZero() && ts.Before(from)) || (!to.IsZero() && ts.After(to)) {
+				continue
+			}
+			if selectedTenant != "" && record.Tenant != selectedTenant {
+				continue
+			}
+			rows = append(rows, newAuditExportRow(record))
+		}
+
+		writer.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=sloop-%s-audit.%s", currentContext, format))
+		if format == "jsonl" {
+			writer.Header().Set("content-type", "application/x-ndjson")
+			encoder := json.NewEncoder(writer)
+			for _, row := range rows {
+				_ = encoder.Encode(row)
+			}
+			return
+		}
+		writer.Header().Set("content-type", "text/csv")
+		csvWriter := csv.NewWriter(writer)
+		_ = csvWriter.Write(auditExportColumns)
+		for _, row := range rows {
+			_ = csvWriter.Write([]string{row.Time, row.User, row.Tenant, row.RemoteAddr, row.RequestId, row.Path, row.Query, row.Namespaces,
+				row.HistoryStart, row.HistoryEnd, strconv.Itoa(int(row.Status)), strconv.FormatInt(row.ResultBytes, 10)})
+		}
+		csvWriter.Flush()
+	}
+}
@@ -0,0 +1,132 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/salesforce/sloop/pkg/sloop/queries"
+)
+
+var metricAuthDeniedCount = promauto.NewCounter(prometheus.CounterOpts{Name: "sloop_auth_denied_count"})
+
+const (
+	AuthModeNone  = ""
+	AuthModeProxy = "proxy"
+)
+
+// The caller of a request, as told by an Authenticator
+type Identity struct {
+	User   string
+	Groups []string
+}
+
+// Implemented for each way sloop can learn who the caller is.  An error rejects the request with
+// http.StatusUnauthorized
+type Authenticator interface {
+	Authenticate(r *http.Request) (*Identity, error)
+}
+
+/*
+Trusts the identity headers set by an authenticating proxy in front of sloop, like oauth2-proxy or an ingress with
+external auth.  The proxy must strip these headers from client requests and sloop must only be reachable through it.
+Requests without UserHeader are rejected.  GroupsHeader is a comma separated list, and when AllowedGroups is set the
+caller needs at least one of them
+*/
+type ProxyHeaderAuthenticator struct {
+	UserHeader    string
+	GroupsHeader  string
+	AllowedGroups []string
+}
+
+func NewAuthenticator(mode string, userHeader string, groupsHeader string, allowedGroups []string) (Authenticator, error) {
+	switch mode {
+	case AuthModeNone:
+		return nil, nil
+	case AuthModeProxy:
+		if userHeader == "" {
+			return nil, fmt.Errorf("%v auth needs a user header", AuthModeProxy)
+		}
+		return &ProxyHeaderAuthenticator{UserHeader: userHeader, GroupsHeader: groupsHeader, AllowedGroups: allowedGroups}, nil
+	default:
+		return nil, fmt.Errorf("unknown auth mode %q, should be %q or empty", mode, AuthModeProxy)
+	}
+}
+
+func (a *ProxyHeaderAuthenticator) Authenticate(r *http.Request) (*Identity, error) {
+	user := strings.TrimSpace(r.Header.Get(a.UserHeader))
+	if user == "" {
+		return nil, fmt.Errorf("missing header %v", a.UserHeader)
+	}
+	identity := &Identity{User: user, Groups: []string{}}
+	if a.GroupsHeader != "" {
+		for _, value := range r.Header.Values(a.GroupsHeader) {
+			for _, group := range strings.Split(value, ",") {
+				group = strings.TrimSpace(group)
+				if group != "" {
+					identity.Groups = append(identity.Groups, group)
+				}
+			}
+		}
+	}
+	if len(a.AllowedGroups) == 0 {
+		return identity, nil
+	}
+	for _, allowed := range a.AllowedGroups {
+		if identity.InGroup(allowed) {
+			return identity, nil
+		}
+	}
+	return nil, fmt.Errorf("user %q is not in any of the allowed groups", user)
+}
+
+func (i *Identity) InGroup(group string) bool {
+	for _, g := range i.Groups {
+		if g == group {
+			return true
+		}
+	}
+	return false
+}
+
+type identityContextKey struct{}
+
+// Returns the identity put on the request by authWrapper, or nil when there is no Authenticator
+func IdentityFromContext(ctx context.Context) *Identity {
+	identity, _ := ctx.Value(identityContextKey{}).(*Identity)
+	return identity
+}
+
+// Rejects the requests the Authenticator does not accept and puts the identity of the others on the request context.
+// Does nothing when there is no Authenticator
+func authWrapper(handler http.Handler, authenticator Authenticator) http.Handler {
+	if authenticator == nil {
+		return handler
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if openPaths[pathBelowContext(r.URL.Path)] {
+			handler.ServeHTTP(w, r)
+			return
+		}
+		identity, err := authenticator.Authenticate(r)
+		if err != nil {
+			metricAuthDeniedCount.Inc()
+			glog.Warningf("Denied %q: %v", r.URL, err)
+			writeApiErrorf(w, r, queries.ErrorCodeUnauthorized, "%v", err)
+			return
+		}
+		handler.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), identityContextKey{}, identity)))
+	})
+}
@@ -0,0 +1,92 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func helper_proxyRequest(handler http.Handler, url string, user string, groups ...string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(http.MethodGet, url, nil)
+	if user != "" {
+		request.Header.Set("X-Forwarded-User", user)
+	}
+	for _, group := range groups {
+		request.Header.Add("X-Forwarded-Groups", group)
+	}
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func Test_ProxyHeaderAuthenticator(t *testing.T) {
+	authenticator, err := NewAuthenticator(AuthModeProxy, "X-Forwarded-User", "X-Forwarded-Groups", []string{"sre", "dev"})
+	assert.Nil(t, err)
+
+	request := httptest.NewRequest(http.MethodGet, "/ctx/data", nil)
+	request.Header.Set("X-Forwarded-User", " alice ")
+	request.Header.Add("X-Forwarded-Groups", "payments, dev")
+	request.Header.Add("X-Forwarded-Groups", "oncall")
+	identity, err := authenticator.Authenticate(request)
+	assert.Nil(t, err)
+	assert.Equal(t, &Identity{User: "alice", Groups: []string{"payments", "dev", "oncall"}}, identity)
+
+	request.Header.Set("X-Forwarded-Groups", "payments")
+	_, err = authenticator.Authenticate(request)
+	assert.NotNil(t, err)
+
+	_, err = NewAuthenticator("ldap", "X-Forwarded-User", "", nil)
+	assert.NotNil(t, err)
+	_, err = NewAuthenticator(AuthModeProxy, "", "", nil)
+	assert.NotNil(t, err)
+	authenticator, err = NewAuthenticator(AuthModeNone, "", "", nil)
+	assert.Nil(t, err)
+	assert.Nil(t, authenticator)
+}
+
+func Test_authWrapper(t *testing.T) {
+	var seen *Identity
+	inner := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = IdentityFromContext(r.Context())
+	})
+	authenticator, _ := NewAuthenticator(AuthModeProxy, "X-Forwarded-User", "X-Forwarded-Groups", nil)
+	handler := authWrapper(inner, authenticator)
+
+	assert.Equal(t, http.StatusOK, helper_proxyRequest(handler, "/ctx/data", "bob").Code)
+	assert.Equal(t, "bob", seen.User)
+	assert.Equal(t, http.StatusUnauthorized, helper_proxyRequest(handler, "/ctx/data", "").Code)
+
+	seen = nil
+	assert.Equal(t, http.StatusOK, helper_proxyRequest(handler, "/ctx/healthz", "").Code)
+	assert.Nil(t, seen)
+	assert.Equal(t, http.StatusOK, helper_proxyRequest(authWrapper(inner, nil), "/ctx/data", "").Code)
+}
+
+func Test_authWrapper_TenantFromGroups(t *testing.T) {
+	inner := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	authenticator, _ := NewAuthenticator(AuthModeProxy, "X-Forwarded-User", "X-Forwarded-Groups", nil)
+	handler := authWrapper(tenantWrapper(inner, someTenants, "X-Sloop-Tenant", "admins"), authenticator)
+
+	assert.Equal(t, http.StatusOK, helper_proxyRequest(handler, "/ctx/data?query=EventHeatMap&namespace=billing", "alice", "dev,payments").Code)
+	assert.Equal(t, http.StatusForbidden, helper_proxyRequest(handler, "/ctx/data?query=EventHeatMap&namespace=kube-system", "alice", "payments").Code)
+	assert.Equal(t, http.StatusOK, helper_proxyRequest(handler, "/ctx/data/backup", "root", "admins").Code)
+	assert.Equal(t, http.StatusForbidden, helper_proxyRequest(handler, "/ctx/data?query=Kinds", "mallory", "dev").Code)
+	assert.Equal(t, http.StatusOK, helper_proxyRequest(handler, "/ctx/healthz", "").Code)
+
+	// The tenant header is not trusted once there is an authenticator
+	request := httptest.NewRequest(http.MethodGet, "/ctx/data?query=Kinds", nil)
+	request.Header.Set("X-Forwarded-User", "mallory")
+	request.Header.Set("X-Sloop-Tenant", "payments")
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusForbidden, recorder.Code)
+}
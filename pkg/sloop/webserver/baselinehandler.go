@@ -0,0 +1,116 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/queries"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+)
+
+const (
+	baselinePath             = "/api/v1/baseline"
+	baselineNameParam        = "name"
+	baselineFailOnDriftParam = "failOnDrift"
+)
+
+/*
+Named baselines of structured query results, for drift checks from CI or cron:
+
+	GET                   lists the baselines as queries.BaselineSummary
+	GET ?name=x           runs the query of baseline x again and returns a queries.BaselineDiff
+	POST ?name=x&q=...    stores the result of the query as baseline x, the query can also be POSTed as json
+	DELETE ?name=x        deletes baseline x
+
+With failOnDrift=true a diff with drift is returned with 409 Conflict, so curl --fail fails the job
+*/
+func baselineHandler(tables typed.Tables, maxLookBack time.Duration) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		name := request.URL.Query().Get(baselineNameParam)
+		if name == "" && request.Method != http.MethodGet {
+			writeApiErrorf(writer, request, queries.ErrorCodeBadParams, "missing %v parameter", baselineNameParam)
+			return
+		}
+		requestId := getRequestId(request.Context())
+		switch request.Method {
+		case http.MethodGet:
+			if name == "" {
+				baselines, err := queries.ListBaselines(tables)
+				if err != nil {
+					writeApiError(writer, request, err, "Failed to list baselines")
+					return
+				}
+				writeJson(writer, request, baselines)
+				return
+			}
+			failOnDrift := false
+			if failStr := request.URL.Query().Get(baselineFailOnDriftParam); failStr != "" {
+				var err error
+				failOnDrift, err = strconv.ParseBool(failStr)
+				if err != nil {
+					writeApiErrorf(writer, request, queries.ErrorCodeBadParams, "invalid %v: %v", baselineFailOnDriftParam, err)
+					return
+				}
+			}
+			diff, err := queries.DiffBaseline(tables, name, maxLookBack, common.Now(), requestId)
+			if err != nil {
+				writeApiError(writer, request, err, "Baseline diff failed")
+				return
+			}
+			if diff == nil {
+				writeApiErrorf(writer, request, queries.ErrorCodeNotFound, "no baseline named %v", name)
+				return
+			}
+			bytes, err := json.MarshalIndent(diff, "", " ")
+			if err != nil {
+				writeApiError(writer, request, err, "Failed to marshal json")
+				return
+			}
+			writer.Header().Set("content-type", "application/json")
+			if diff.Drift && failOnDrift {
+				writer.WriteHeader(http.StatusConflict)
+			}
+			writer.Write(bytes)
+		case http.MethodPost:
+			err := queries.ValidateBaselineName(name)
+			if err != nil {
+				writeApiErrorf(writer, request, queries.ErrorCodeBadParams, "%v", err)
+				return
+			}
+			query, err := readStructuredQuery(request)
+			if err != nil {
+				writeApiErrorf(writer, request, queries.ErrorCodeBadParams, "%v", err)
+				return
+			}
+			if query.Limit > 0 {
+				writeApiErrorf(writer, request, queries.ErrorCodeBadParams, "baselines can not have a limit, every row is compared")
+				return
+			}
+			summary, err := queries.SaveBaseline(tables, name, query, maxLookBack, common.Now(), requestId)
+			if err != nil {
+				writeApiError(writer, request, err, "Failed to save baseline")
+				return
+			}
+			writeJson(writer, request, summary)
+		case http.MethodDelete:
+			err := queries.DeleteBaseline(tables, name)
+			if err != nil {
+				writeApiError(writer, request, err, "Failed to delete baseline")
+				return
+			}
+			writer.WriteHeader(http.StatusNoContent)
+		default:
+			writeApiErrorf(writer, request, queries.ErrorCodeMethodNotAllowed, "baselines support GET, POST and DELETE")
+		}
+	}
+}
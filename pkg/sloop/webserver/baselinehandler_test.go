@@ -0,0 +1,79 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/queries"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+func Test_baselineHandler(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	now := time.Now()
+	setPod := func(name string) {
+		ts, _ := ptypes.TimestampProto(now)
+		key := typed.NewCurrentStateKey(untyped.GetPartitionId(now), "Pod", "ns", name, "uid-"+name)
+		err := db.Update(func(txn badgerwrap.Txn) error {
+			return tables.CurrentStateTable().Set(txn, key.String(), &typed.CurrentState{Timestamp: ts, Payload: `{"metadata":{"name":"` + name + `"}}`})
+		})
+		assert.Nil(t, err)
+	}
+	setPod("a")
+	handler := baselineHandler(tables, 24*time.Hour)
+	serve := func(method string, params string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		handler(recorder, httptest.NewRequest(method, baselinePath+"?"+params, nil))
+		return recorder
+	}
+
+	recorder := serve(http.MethodPost, "name=pods&q="+url.QueryEscape("kind=Pod namespace=ns | select namespace,name"))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	summary := queries.BaselineSummary{}
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &summary))
+	assert.Equal(t, int64(1), summary.Total)
+
+	recorder = serve(http.MethodGet, "name=pods&failOnDrift=true")
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	setPod("b")
+	recorder = serve(http.MethodGet, "name=pods")
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	diff := queries.BaselineDiff{}
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &diff))
+	assert.True(t, diff.Drift)
+	assert.Len(t, diff.Added, 1)
+	assert.Equal(t, http.StatusConflict, serve(http.MethodGet, "name=pods&failOnDrift=true").Code)
+
+	recorder = serve(http.MethodGet, "")
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	baselines := []queries.BaselineSummary{}
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &baselines))
+	assert.Len(t, baselines, 1)
+
+	assert.Equal(t, http.StatusBadRequest, serve(http.MethodPost, "q=kind%3DPod").Code)
+	assert.Equal(t, http.StatusBadRequest, serve(http.MethodPost, "name=a%2Fb&q=kind%3DPod").Code)
+	assert.Equal(t, http.StatusBadRequest, serve(http.MethodPost, "name=limited&q="+url.QueryEscape("kind=Pod | limit 1")).Code)
+	assert.Equal(t, http.StatusNoContent, serve(http.MethodDelete, "name=pods").Code)
+	assert.Equal(t, http.StatusNotFound, serve(http.MethodGet, "name=pods").Code)
+}
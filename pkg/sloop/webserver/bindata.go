@@ -1,19 +1,19 @@
 // Code generated by go-bindata. DO NOT EDIT.
 // sources:
-// webfiles/debug.html (1.255kB)
+// webfiles/debug.html (2.113kB)
 // webfiles/debug.js (463B)
 // webfiles/debugconfig.html (754B)
 // webfiles/debughistogram.html (2.468kB)
-// webfiles/debuglistkeys.html (3.34kB)
+// webfiles/debuglistkeys.html (3.961kB)
 // webfiles/debugtables.html (1.091kB)
 // webfiles/debugviewkey.html (946B)
 // webfiles/favicon.ico (15.406kB)
-// webfiles/filter.js (5.195kB)
-// webfiles/index.html (5.777kB)
+// webfiles/filter.js (5.62kB)
+// webfiles/index.html (6.191kB)
 // webfiles/resource.css (929B)
-// webfiles/resource.html (10.741kB)
-// webfiles/sloop.css (3.31kB)
-// webfiles/sloop_ui.js (21.722kB)
+// webfiles/resource.html (12.883kB)
+// webfiles/sloop.css (3.47kB)
+// webfiles/sloop_ui.js (26.438kB)
 
 package webserver
 
@@ -82,7 +82,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _webfilesDebugHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x84\x54\xcd\x6e\xe3\x36\x10\xbe\xeb\x29\xa6\xbc\xd8\xc6\x46\x62\x37\x3d\x75\x57\x16\xb0\x6b\xa7\xd8\xa0\x4e\xd1\xc6\x45\x51\x20\xc8\x81\xa6\x46\x16\x13\x8a\xa3\x90\x23\x3b\x7e\xfb\x82\xa2\xf3\xdb\xd6\xeb\x83\x45\x0d\xbf\xbf\x21\x07\x2a\x7f\xc8\xf3\x6c\x41\xfd\xc1\x9b\x6d\xcb\x30\xd5\x33\x38\xff\xf1\xe3\xcf\x67\x10\x94\xc5\xd0\x90\xd7\x58\x68\xea\xce\xc0\x38\x5d\x64\x5f\xac\x85\x11\x18\xc0\x63\x40\xbf\xc3\xba\xc8\xd6\xbf\x2f\xff\xce\x57\x46\xa3\x0b\x98\x5f\xd6\xe8\xd8\x34\x06\xfd\x27\xf8\xba\x5e\xe6\x3f\xe5\x0b\xab\x86\x80\xd9\x2f\xe4\xa1\x19\xac\x05\x9b\x90\xc0\xf8\xc8\x67\x10\x10\x61\x75\xb9\xb8\xf8\x6d\x7d\x51\xf0\x23\x43\x63\x2c\x82\x71\xc0\x2d\x82\xc7\x9e\xc0\x13\x31\x90\x87\x96\xb9\x0f\x9f\xa4\xa4\x1e\x5d\xa0\x21\xe6\x22\xbf\x95\x47\xb5\x20\xdf\x98\xe5\x79\x95\x95\x2d\x77\x36\x3e\x50\xd5\x55\x06\x00\x50\x06\xed\x4d\xcf\xc0\x87\x1e\xe7\x22\xfa\xcb\x3b\xb5\x53\xa9\x2a\x12\x26\xfe\x6a\xd2\x43\x87\x8e\x8b\xbd\x37\x8c\x53\x51\x6e\x54\x40\x68\x3d\x36\xf3\x89\x14\xf0\x01\xf6\xc6\xd5\xb4\x2f\x2c\x69\xc5\x86\x5c\xd1\x2b\x6e\x9d\xea\xb0\x08\xbd\x35\x3c\x9d\xc8\xc9\xec\xe6\xe3\x2d\x7c\x00\x21\x27\x20\x2b\x31\xfb\x9c\xfc\x65\xb2\x7a\x9b\x26\x78\x3d\x17\x7b\xdc\xc4\xce\x83\xac\x71\x33\x6c\x8b\xbb\x20\xaa\x77\x68\x36\x6c\xb1\x5a\x5b\xa2\x1e\x96\x11\x04\x57\xe8\x86\x52\xa6\x7a\xc2\x58\xe3\xee\xc1\xa3\x9d\x4f\x42\x4b\x9e\xf5\xc0\x60\x34\xb9\x49\xea\x78\x62\x3a\xb5\x45\xf9\x98\xa7\x5a\xea\xe7\xd9\xb8\x51\xbb\x58\x2f\x8c\xa6\x98\x39\x2b\x65\x3a\xb8\x72\x43\xf5\x01\xc8\x59\x52\xf5\x5c\xc4\xff\x6f\xd4\xe1\x35\x36\xd3\xd9\x67\x51\x41\x76\x03\xa5\x02\x53\xcf\x45\x4b\x1d\xae\x8c\xbb\x17\x55\x04\x94\x52\x55\x70\x9b\x65\x65\x7b\xfe\x1f\xa1\xdb\xf3\x2a\xcb\xca\xc1\x3e\xe7\xae\x4a\x95\x02\x89\xf1\x00\xa4\x35\x81\xef\xf1\x10\xa4\xa8\xfe\x18\xd0\x1f\x60\xa9\x58\xc1\x9a\xc9\x27\xe5\x1c\xbe\x58\x4b\xfb\x00\x07\x1a\x80\x09\x1e\x46\x50\x64\x80\x72\x35\xec\x94\x1d\x30\x40\xe3\xa9\x1b\x27\x69\xa3\xea\x2d\x7a\x08\x89\x6f\xcd\xff\xfa\xb6\x26\x30\x6d\xbd\xea\xa4\x38\xc6\xfe\x35\x6a\x7e\x7b\x2a\x1f\xcd\xff\x32\xb8\x1f\x85\x47\xc7\xf6\x65\xf7\x84\xb4\x26\xd7\x98\xad\x14\xd5\x62\x5c\xbc\x57\xd2\x83\xf7\xe8\x18\x94\x66\xb3\x43\x48\x68\x68\xc8\xc3\x98\xe3\xa4\x34\xab\x4d\xbc\x42\x51\xfd\x39\x2e\x5e\x4b\x7f\x4d\x9d\xaf\xd6\x57\x30\x6e\xc2\xa5\x6b\xe8\xa4\x98\xc7\x87\x01\x03\x07\x51\x1d\xb9\xd7\xc7\x42\x94\x3d\xc9\xc4\x1d\xba\x57\xbc\x8b\xf1\xf5\xbb\xac\x9d\xf2\x2f\x9c\x2b\x64\x6f\xf4\x29\x52\x97\x10\x4f\xd7\xf3\x2f\x42\x29\xe3\x58\x65\xa5\x8c\x73\x3b\x8e\x71\xfc\x0c\xfc\x13\x00\x00\xff\xff\x18\xd3\x24\xd6\xe7\x04\x00\x00")
+var _webfilesDebugHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\x03\x8d\x55\xdf\x73\xdb\x36\x0c\x7e\xf7\x5f\x81\xe9\xc5\xf6\x2d\x92\xd6\x6c\x2f\x6b\x65\xdd\x35\x4e\x76\xcd\x35\xd9\x75\x71\x6f\xdb\x5d\xaf\x0f\x34\x05\x59\x6c\x28\x51\xe3\x0f\x2b\xfa\xef\x07\x92\xb2\x93\xb4\x9d\x33\x3d\x48\x24\x08\xe0\x03\x3e\x02\x50\xf1\x43\x9a\xce\xd6\xaa\x1f\xb5\xd8\x35\x16\x16\x7c\x09\xe7\x3f\xbd\xfa\xf5\x0c\x0c\x93\x68\x6a\xa5\x39\x66\x5c\xb5\x67\x20\x3a\x9e\xcd\xde\x4a\x09\x41\xd1\x80\x46\x83\x7a\x8f\x55\x36\xdb\x7c\xb8\xfc\x3b\xbd\x11\x1c\x3b\x83\xe9\x75\x85\x9d\x15\xb5\x40\xfd\x1a\x2e\x36\x97\xe9\xcf\xe9\x5a\x32\x67\x70\xf6\x9b\xd2\x50\x3b\xb2\x97\x51\x13\x2c\x3e\x58\x82\x41\x84\x9b\xeb\xf5\xd5\xef\x9b\xab\xcc\x3e\x58\xa8\x85\x44\xc2\x02\xdb\x20\x41\xf4\x0a\xb4\x52\x16\xc8\xb6\xb1\xb6\x37\xaf\xf3\x5c\xf5\x64\xad\x9c\x8f\x4b\xe9\x5d\x3e\x79\x33\xf9\x33\xb0\x34\x2d\x67\x45\x63\x5b\xe9\x3f\xc8\xaa\x72\x06\xf4\x14\x86\x6b\xd1\x5b\xb0\x63\x8f\xab\xc4\xe3\xe7\x5f\xd8\x9e\x45\x69\x12\x75\xfc\x53\x29\xee\x5a\x4a\x23\x1b\xb4\xb0\xb8\x48\x8a\x2d\xa3\x78\x1b\x8d\xf5\x6a\x9e\x27\xf0\x23\x0c\xa2\xab\xd4\x90\x49\xc5\x99\x15\xaa\xcb\x7a\x66\x9b\x8e\xb5\x98\x99\x5e\x0a\xbb\x98\xe7\xf3\xe5\xa7\x57\x9f\x49\x31\xc9\xe7\x90\x97\xc9\xf2\x4d\xc4\xcf\x23\xd4\xf3\x68\x8c\xe6\xab\x64\xc0\xad\xcf\xdc\xe4\x15\x6e\xdd\x2e\xfb\x62\x92\xf2\x2b\x6d\x2b\xac\xc4\x72\x23\x95\xea\xe1\xd2\x2b\xc1\x2d\x76\xae\xc8\xa3\x3c\xea\x48\xd1\xdd\x13\x6b\x72\x35\x37\x8d\xd2\x96\x3b\x0b\x82\xab\x6e\x1e\x33\x9e\x8b\x96\xed\x30\x7f\x48\xa3\x2c\xe6\x73\x04\xae\xd9\xde\xcb\x33\x7a\xf9\x98\x67\x45\x1e\x89\x2b\xb6\xaa\x1a\x41\x75\x52\xb1\x6a\x95\xf8\xf7\x3b\xd5\xe2\x1d\xd6\x8b\xe5\x9b\xa4\x84\xd9\x27\x28\x18\x08\x3a\x6a\x48\x7c\x43\x01\x24\xa5\x57\x28\x72\x56\xc2\xe7\x19\xd1\x7f\xfe\x9d\xa0\x49\x48\x47\x4e\x1e\xe3\x2e\xc9\x49\x08\x28\x09\x04\xd0\xb5\x1a\x7b\x8f\xa3\xc9\x93\xf2\x0f\x87\x7a\x84\x4b\x66\x19\x6c\xac\xd2\xd1\x73\x0a\x54\x8a\x6a\x30\x30\x2a\x07\x56\xc1\x3f\x41\xc9\x5b\x00\xeb\x2a\xd8\x33\xe9\xd0\x40\xad\x55\x1b\x2a\x69\xcb\xaa\x1d\x6a\x30\xd1\x9e\xe0\xfe\x0b\xb7\x21\x5c\xb5\xd3\xac\x25\xe0\x18\xf6\x7b\xef\xf3\xdd\x41\x3c\x81\xff\x29\x70\x08\x8e\x03\x62\xf3\x78\x7a\xc2\x35\x91\x5b\x8b\x1d\xf9\x5d\x87\xc5\xd7\x9e\xb8\xd3\x9a\x6a\x0e\x18\xb7\x62\x4f\xdb\xa0\x04\xd4\x80\x10\xe2\x38\xe9\xba\xd7\x8a\xa3\x31\xa2\xf3\xee\x3f\x1c\x37\x13\xc4\x24\xc0\x8a\x9c\xba\x8e\x7a\x0e\xb5\x56\x3a\x12\x25\x59\xc4\x40\xc6\x1b\x78\x74\x43\x4c\x51\xa9\x9c\xc4\xdc\x3a\xa2\xd4\x12\xde\x45\x58\x4c\x58\x77\xc8\x91\xc2\xaf\x82\xf3\x40\x77\x05\x03\xb3\xe4\x9c\xe6\x85\x93\x36\xa2\x6e\x47\x4b\xb7\xb3\xa5\x0b\xa3\x46\x0a\x12\xdf\x3d\xa6\x67\x1c\x41\xed\xe9\xa2\x3c\x21\x92\x19\x0b\xe7\xbf\x34\x27\xa3\x08\xf7\xce\x5c\x25\xec\xb1\x52\xde\xfa\xdd\x14\xce\x5f\x0d\x0d\x10\xd6\x4d\xfe\x08\xd4\x86\x4a\x11\x18\xe3\xc0\x87\x9e\xda\xc4\x9c\x41\xa3\x06\x90\x8a\xf2\x26\xc5\x91\xea\x49\xdd\x87\x73\x2f\x6e\x1d\x05\x1f\xc4\x1a\xad\xd3\x1d\x56\x27\x03\x32\x28\x6b\x8f\x43\xe5\x43\xab\xf4\x23\x2d\x0f\x37\x1d\x6b\xd2\xfb\xed\x99\xa6\xb6\xa5\xc9\x61\x40\xd5\x21\xb8\x40\x15\xf0\x06\xf9\xbd\x27\xcf\xfa\x0b\xd0\xd6\x9d\xbe\x76\x8d\xdc\xb3\x35\x52\xea\x77\xd3\x72\xc2\x7a\x7f\x68\x84\x27\x48\x52\x51\xf6\x43\x83\xdd\x13\xc0\x81\xf9\x41\x1e\x4c\x9f\xc1\xd2\x84\xb3\x0d\xa4\xd3\x51\xfa\x72\xdf\x18\xb1\xeb\x18\xd1\x83\xbe\x63\x37\xc7\xcd\x21\x75\xa2\xbc\x1e\x23\xee\xf1\xcc\xa7\xfe\xdd\x0a\x59\x18\xea\xec\xe5\xe9\x5e\xf2\x44\x19\xd7\x7a\xb4\xf5\x61\xfd\x2d\xd8\x51\xcd\x63\x71\x22\x00\x9f\x31\xf2\x3f\x80\x2c\xdb\xca\x90\xd3\xc7\xb0\x78\xda\xb4\x17\x71\xa6\xdc\x6c\x6e\x21\x1c\xc2\x75\x57\xab\x17\xee\x8b\x8a\xcf\x58\x9a\xed\x93\xed\xdd\x24\xf0\x6e\x4f\x5a\xe2\x9e\x46\xc3\xa3\xdd\x55\xd8\xbe\x68\xb5\x67\xfa\xd1\xe6\x16\xad\x16\xfc\x94\x51\x1b\x35\x0e\x83\xef\x1b\x83\x22\xf7\x03\x9b\x3e\xfe\x8f\x10\x7e\x10\xfe\x07\xfb\x2f\xc1\x85\x8a\x05\x41\x08\x00\x00")
 
 func webfilesDebugHtmlBytes() ([]byte, error) {
 	return bindataRead(
@@ -97,8 +97,8 @@ func webfilesDebugHtml() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "webfiles/debug.html", size: 1255, mode: os.FileMode(0644), modTime: time.Unix(1633465593, 0)}
-	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x9e, 0xa8, 0x5a, 0x14, 0x50, 0x8, 0x24, 0x9d, 0xc3, 0x27, 0xbc, 0x50, 0xc0, 0x17, 0xf8, 0xb1, 0x42, 0x63, 0x3, 0x98, 0x3, 0x63, 0x6c, 0xc, 0xab, 0x8c, 0x1a, 0x90, 0xb0, 0xec, 0x3c, 0x7e}}
+	info := bindataFileInfo{name: "webfiles/debug.html", size: 2113, mode: os.FileMode(0644), modTime: time.Unix(1791969413, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x7b, 0x82, 0x26, 0x7d, 0xfe, 0xeb, 0x65, 0x7c, 0x7f, 0xfb, 0x62, 0xe9, 0xbf, 0xc4, 0xd2, 0x45, 0x18, 0x73, 0xfe, 0x8d, 0x27, 0xdf, 0x29, 0xd3, 0xab, 0x5b, 0xde, 0xa4, 0xc4, 0xdf, 0x9c, 0x90}}
 	return a, nil
 }
 
@@ -162,7 +162,7 @@ func webfilesDebughistogramHtml() (*asset, error) {
 	return a, nil
 }
 
-var _webfilesDebuglistkeysHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x57\x7f\x6f\xdb\x38\x12\xfd\xdf\x9f\x62\x8e\x28\x60\xfb\x6a\x9b\xb1\x73\x17\xdc\xb9\x34\x17\x9b\xa4\x45\x8b\xa6\xdd\xdd\x24\xc0\x2e\x50\x14\x0b\x5a\x1a\x5b\xac\x69\x51\x4b\x52\xfe\xb1\x41\xbe\xfb\x82\xa4\x2c\xcb\x89\x93\xb4\x01\x12\x51\xd4\x9b\x37\x6f\x86\x43\x0e\xc3\xfe\xd5\xef\xb7\x2e\x74\xb1\x35\x72\x9e\x39\xe8\x24\x5d\x18\x9d\x0c\xff\xdf\x03\x2b\x14\xda\x99\x36\x09\x0e\x12\xbd\xec\x81\xcc\x93\x41\xeb\x67\xa5\x20\x00\x2d\x18\xb4\x68\x56\x98\x0e\x5a\x37\xbf\x5e\xfe\xd1\xbf\x92\x09\xe6\x16\xfb\x1f\x52\xcc\x9d\x9c\x49\x34\x63\x38\xbf\xb9\xec\x9f\xf6\x2f\x94\x28\x2d\xb6\xde\x69\x03\xb3\x52\x29\x50\x11\x09\x0e\x37\xae\x07\x16\x11\xae\x3e\x5c\xbc\xfd\x7c\xf3\x76\xe0\x36\x0e\x66\x52\x21\xc8\x1c\x5c\x86\x60\xb0\xd0\x60\xb4\x76\xa0\x0d\x64\xce\x15\x76\x4c\xa9\x2e\x30\xb7\xba\xf4\xba\xb4\x99\xd3\x8a\xcd\xd2\x03\x67\xfd\x3e\x6f\xb1\xcc\x2d\x95\x7f\xa0\x48\x79\x0b\x00\x80\xd9\xc4\xc8\xc2\x81\xdb\x16\x38\x21\xde\x3f\xfd\x26\x56\x22\xce\x92\x88\xf1\x3f\xa9\x4e\xca\x25\xe6\x6e\xb0\x36\xd2\x61\x87\xb0\xa9\xb0\x08\x99\xc1\xd9\xa4\x4d\x09\xbc\x86\xb5\xcc\x53\xbd\x1e\x28\x9d\x08\x27\x75\x3e\x28\x84\xcb\x72\xb1\xc4\x81\x2d\x94\x74\x9d\x36\x6d\x77\xbf\x0c\xbf\xc2\x6b\x20\xb4\x0d\x94\x93\xee\x9b\xe8\x9f\x46\x57\x87\x6a\xac\x49\x26\x64\x8d\x53\x1f\xb9\xa5\x29\x4e\xcb\xf9\xe0\x9b\x25\xfc\x7b\xd0\x56\x69\x5d\xfc\x59\xca\x63\x06\x4e\x3a\x85\xfc\xc6\x23\xe0\xd2\xb3\xc2\x6f\x25\x9a\x2d\x9c\x8b\x74\x8e\x86\xd1\xf8\x3d\x62\x95\xcc\x17\x60\x50\x4d\xda\x36\xd3\xc6\x25\xa5\x03\x99\xe8\xbc\x1d\x53\xd5\x96\x4b\x31\x47\xba\xe9\xc7\xb9\x98\x88\x5a\xc3\x4c\xac\xfc\xfc\x40\x26\xda\x07\xdb\x62\x34\x66\x9c\x4d\x75\xba\x05\x9d\x2b\x2d\xd2\x09\xf1\x7f\xdf\xeb\x25\x5e\xe3\xac\xd3\x7d\x43\x38\xb4\xbe\x00\x13\x20\xd3\x09\xc9\xf4\x12\xaf\x64\xbe\x20\xdc\x03\x18\x15\x1c\xbe\x86\x8f\xc1\x11\x09\x19\xa1\x84\xc7\x18\x3e\x61\x5e\x46\x08\x9b\x1a\xca\x5b\x2d\x96\x8d\x78\x0c\x2c\x84\xda\xb6\x55\x80\x70\x79\x0e\x97\xd2\x60\xe2\xd4\x96\xd1\x6c\xe4\xa1\x4e\x4c\x15\xc2\x74\x9e\x68\xa5\xcd\x84\x58\xa9\x56\x68\x08\xac\x65\xea\xb2\x09\xf9\xef\xc9\x49\xb1\x21\x9c\x39\xc3\x99\x4b\xc1\xba\xad\xc2\x09\x29\x44\x9a\xca\x7c\x3e\x86\x51\xf8\xda\x62\x33\x6d\x96\x20\x12\xbf\xf0\x3b\x71\x4a\x5a\xb7\xc0\xad\xa5\x04\x96\xe8\x32\x9d\x4e\xc8\x1c\x77\x15\xc5\x94\x98\xa2\x82\x99\xf7\x18\x04\x10\x7e\x1b\x74\x7c\x16\x4b\x1c\x33\x1a\x3e\xf3\x18\x4d\x5c\x69\x54\x98\x38\xf0\x05\xb5\xb3\x08\x79\xaa\x8c\xeb\x32\x65\xba\xf0\x22\x60\x25\x54\x89\x13\xb2\x16\x2e\xc9\x08\x0f\x0f\x46\xe3\xb7\x27\xc1\x06\xad\x2d\x97\x84\xc7\xe7\x8b\x70\x5c\x61\xee\x12\x5d\xe6\x8e\xf0\xfd\xf8\x45\xb3\xa0\xc5\xa7\x6a\x25\xdd\xb6\x92\xb6\x7b\x7d\xd1\x58\xe6\x0e\x4d\x2e\x14\xe1\xbb\xd1\x8b\x26\x42\x29\xc2\x85\x7a\x00\x64\x34\x66\xd4\xe7\x38\xfc\xb6\xe2\xb4\xcc\x8b\x72\x77\x18\x18\x91\x4a\x1d\xd3\x6c\x70\x8e\x1b\x52\xa5\xdf\xa2\x30\x49\xf6\x4b\x60\x23\xfb\xe4\x05\x84\xce\x93\x4c\xe4\x73\x9c\x90\xbf\x7c\xfd\x5d\x84\x97\x8e\xcb\xa4\xed\x12\x48\x32\x4c\x16\x98\x3e\x2e\x81\x68\x5c\x95\xec\x74\x0b\xd7\xfe\x7d\x57\x05\xcf\x0a\x2b\x84\x71\x32\x0a\x79\x46\x5c\x03\xf5\x9c\xc0\xc7\xc2\xf6\x86\x7b\x71\xb7\xd2\x6f\xc8\xba\x42\x9b\xd9\x4b\xe5\x0a\x12\x25\xac\xad\x43\xda\xaf\x4a\x83\x75\x81\xdb\x65\x2c\xcc\x8f\x18\x82\x7d\xbb\x81\x77\x52\x39\x34\xcd\xd2\x6f\xd8\x36\x83\xf7\x47\xf4\x2e\xd8\x9a\x28\xe4\x62\x4f\x5b\xcb\x8a\x4b\x9d\xca\xd5\x11\x85\x8d\xa4\x54\xdb\x3a\x95\xb6\x50\x62\x3b\xce\x75\x8e\x4f\x48\x57\x5a\x2f\xa6\x22\x59\x10\x7e\xa5\xf5\x02\xce\x45\xb2\x80\x6b\x9f\xc2\x23\x9b\xf6\xf1\xc6\xad\xad\x83\xde\x3d\x57\x0d\x3f\x52\xbf\x43\xc2\x87\xf0\x5e\x97\xe6\x71\xa5\x1f\x41\x9f\x12\x7e\x1a\xd0\xf6\xbb\xe0\x67\x84\x9f\xfd\x00\x7c\x38\x22\x7c\x38\xfa\x01\x83\xd1\x7f\xbc\xfa\x4b\x71\x64\x67\x1f\xa3\x3f\xfb\x9f\x87\xff\x8e\xb8\xf8\xbe\x60\x4f\xcf\x08\x1f\x05\xfc\x11\x39\x4f\x6c\xf1\x87\x2b\x5a\x1a\xd5\x28\xc6\x9b\xb0\x7d\xc6\x77\x1f\x65\x9e\x52\x7f\x18\xdb\x42\x24\x18\x46\xf7\xf0\xc4\x12\x3f\x55\x9d\x35\x73\x58\xed\xbd\x9f\xa7\xab\xb3\x21\x6b\x29\x36\x46\xaf\x2d\xe1\x9f\xc4\x06\xae\xf5\xda\x3e\xde\x1a\x4f\x3a\xde\xd9\x06\xbf\x35\xd1\x33\x27\x9d\x2d\xa7\x4b\xe9\x7b\x13\xa3\xbe\x93\xf9\xa7\x4b\x39\xa3\xbe\xeb\xd1\xd0\x62\x7c\xeb\x36\x94\xef\xfa\x6b\xd5\x34\xb5\x49\xd1\x84\x12\xad\xae\x17\xa1\x4b\xf2\x5b\xed\x84\x82\x8f\xb8\xb5\xf0\xc9\x87\x8c\x69\xe4\x73\x29\xbf\xbb\x1b\xf8\xf9\x6a\xfa\xfe\x7e\xef\xe8\x08\xc3\x8d\xfc\x1b\x41\xcf\x76\x24\x81\xb1\x66\x62\x85\x41\x4f\x17\xa0\x1e\xe9\xc9\xfc\xdc\xb3\x94\x41\x54\x5c\xe4\x86\xaa\x03\x2e\x0f\x39\xc2\x75\x24\x11\x31\x1b\x6c\x1a\x2a\xe7\x4a\x5a\xc7\xe8\x94\x8f\xab\x59\x5d\x9d\xdc\x77\x77\xc6\x9f\x0f\xf0\x6a\x81\xdb\x1e\xbc\x0a\xa5\x0b\xe3\x09\x0c\xa2\x9f\x46\x4d\x4a\xbe\xbb\xdf\xb4\xe3\x15\x62\x25\x71\xfd\xd3\x62\x72\x77\x37\xb8\xbf\x6f\xf3\xf0\xf0\xd7\x9c\x8a\x16\xf3\xf4\xfe\xbe\xc5\xa8\x77\xc4\xa8\xbf\x58\xf9\x95\x39\x7a\x25\x9c\x85\xc3\xf5\xc1\x85\x90\x35\x6f\x86\x16\xdd\x2d\x6e\x5c\xa7\x2e\x97\x1e\x34\x87\xc3\x93\x93\x13\xd2\xdd\x21\x2f\x8d\x2e\x52\xbd\xce\x3b\xd5\xf5\xa3\x07\xfb\x41\xbc\x71\x74\x0f\x49\xeb\x93\xb9\x07\x07\xe3\xc1\xbf\x8f\x91\xd6\xe7\x62\x0f\x0e\xc6\xc3\x87\xb4\xf5\x96\xea\xc1\xc1\x98\xee\x81\xd7\xbe\x55\x76\x0e\xbb\x62\x0f\x1e\xbd\xc7\x6e\xd5\x6d\x35\xb2\x43\xe3\xbf\x0a\xff\x04\x00\x00\xff\xff\xb5\xd8\x81\x2e\x0c\x0d\x00\x00")
+var _webfilesDebuglistkeysHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\x03\x95\x57\x6d\x6f\xdb\x36\x10\xfe\xee\x5f\xc1\x11\x05\x6c\x6f\xb1\x15\x3b\x5b\xb1\xb9\xb2\x86\x35\x4e\xd1\xa2\x69\xb7\x25\x01\x36\xa0\x28\x06\x5a\x3a\xdb\xac\x69\x51\x23\x29\xbf\x2c\xc8\x7f\xdf\x91\x94\x64\x39\x91\xe3\xd6\x80\x2c\x8a\x7a\xee\x78\xef\x77\x0a\xbf\xeb\xf5\x5a\x97\x32\xdb\x29\x3e\x5f\x18\xd2\x89\xbb\x64\x78\x3e\xf8\xe5\x8c\x68\x26\x40\xcf\xa4\x8a\xa1\x1f\xcb\xd5\x19\xe1\x69\xdc\x6f\xfd\x26\x04\x71\x40\x4d\x14\x68\x50\x6b\x48\xfa\xad\xdb\x3f\x26\x7f\xf7\xae\x79\x0c\xa9\x86\xde\xbb\x04\x52\xc3\x67\x1c\xd4\x88\xbc\xbe\x9d\xf4\x2e\x7a\x97\x82\xe5\x1a\x5a\x6f\xa4\x22\xb3\x1c\xe9\x85\x47\x12\x03\x5b\x83\xc7\x00\x90\xeb\x77\x97\x57\x1f\x6f\xaf\xfa\x66\x6b\xc8\x8c\x0b\xc0\xb3\x88\x59\x00\x1e\x91\x49\xa2\xa4\x34\x04\x69\x17\xc6\x64\x7a\x14\x04\x32\x43\x6a\x99\x5b\xb9\xa4\x9a\x07\x05\x37\x1d\x1c\x1c\xd6\xeb\x45\xad\x70\x61\x56\xc2\xde\x80\x25\x51\x8b\xe0\x2f\xd4\xb1\xe2\x99\x21\x66\x97\xc1\x98\xda\xf3\x83\x2f\x6c\xcd\xfc\x2e\xf5\x18\xfb\x4b\x64\x9c\xaf\x50\x8d\xfe\x46\x71\x03\x1d\x1a\x4e\x19\xca\xbb\x50\x30\x1b\xb7\x03\x4a\x7e\x20\x1b\x9e\x26\x72\xd3\x17\x32\x66\x86\xcb\xb4\x9f\x31\xb3\x48\xd9\x0a\xfa\x3a\x13\xdc\x74\xda\x41\xbb\xfb\x69\xf0\x19\x81\x34\x68\x93\x20\xa2\xdd\x57\xfe\xfc\xc0\x1f\x75\x28\x8d\x56\xf1\x98\x6e\x60\x6a\x35\xd7\x41\x02\xd3\x7c\xde\xff\xa2\x69\xf4\x35\x68\x2d\xa4\xcc\xfe\xc9\x79\x13\x81\xe1\x46\x40\x74\x6b\x11\x64\x62\xb9\x92\x3f\x73\x50\x3b\xf2\x9a\x25\x73\x50\x61\xe0\xdf\x7b\xac\xe0\xe9\x12\xcd\x2d\xc6\x6d\xbd\x90\xca\xc4\xb9\x21\x3c\x96\x69\xdb\x9b\xaa\xcd\x57\x6c\x0e\xc1\xb6\xe7\xf7\xbc\x21\x2a\x19\x66\x6c\x6d\xf7\xfb\xf8\x67\x95\x6d\x85\x81\xb7\x78\x38\x95\xc9\x8e\xc8\x54\x48\x96\x8c\xa9\xfd\x7f\x2b\x57\x70\x03\xb3\x4e\xf7\x15\x8d\x48\xeb\x13\x09\x19\xe1\xf8\x6a\x81\xdb\xd7\x28\x00\x8d\x2c\x20\x0c\x58\x44\x3e\xbb\x97\xee\x20\xea\x2c\x12\xd0\xc8\xeb\xf0\x01\xd2\xdc\x43\xc2\xa9\xc2\xd3\xd0\xbf\xc3\xc8\x2b\xe6\x54\x6d\xeb\x42\x41\x32\x79\x4d\x26\x5c\x41\x6c\xc4\x0e\x45\x1a\x5a\xa8\x61\x53\x8c\xae\xe9\x3c\x96\x42\xaa\x31\xd5\x5c\xac\x41\x51\x74\x67\x62\x16\x63\xfa\xd3\xf9\x79\xb6\x45\x33\x1a\x85\x57\x42\xb4\xd9\x09\x0c\x93\x8c\x25\x09\x4f\xe7\x23\x4c\x0b\xfb\xb6\x15\x62\x4e\xac\x08\x8b\xad\xe3\x4b\xe1\x04\xd7\x66\x09\x3b\x8d\xc1\xb1\x02\xb3\x90\xa8\xd4\x1c\xca\x88\x0a\x05\x9b\x82\x20\x33\x7b\xa2\x13\x80\x46\x77\x4e\x8e\x8f\x18\x31\xa3\x30\x70\xaf\x23\xaf\x8d\xf7\x34\x08\x94\x9a\xd8\x80\x2a\x29\x9c\x9d\x0a\xe2\x2a\x4c\x43\x99\x59\x21\xc8\x9a\x89\x1c\x91\x1b\x66\xe2\x05\x8d\xdc\x2d\x0c\xfc\xbb\xa3\x60\xcc\x5e\x9d\xaf\x68\xe4\xef\x27\xe1\xb0\xc6\x74\x88\x65\x9e\xa2\x52\xfb\xf5\x49\x32\x27\x8b\x35\xd5\x9a\x9b\x5d\x21\x5a\xf9\x78\x92\xf8\xdf\x9c\x29\x86\xb5\x24\x45\x9d\xf7\xeb\xaf\x13\x75\x26\x45\x52\x48\x6a\x97\x27\x89\x32\x99\x08\x3e\x83\x78\x17\x5b\x0b\xd7\x9f\x4e\x92\xa6\x32\x01\x0c\xff\x84\xdb\x4d\x1a\x1d\x3c\x9e\x24\x96\x9b\x14\xd4\x5c\xb1\x0c\x1d\xb7\x5f\x9f\x24\x4b\x30\xc1\x04\x18\x83\xc1\x1b\xed\xd7\x27\xc9\x6c\xc1\xc6\x72\x39\x65\xf1\x12\xd2\x04\x2b\xc6\xa3\x8d\x93\x0c\xe2\x5c\x29\x34\xa9\x36\xcc\xa0\x99\xea\x4f\x4f\x49\xef\xef\xd1\x63\x73\x20\xfd\xab\xad\x51\xcc\x45\xbc\x7e\x78\x38\xc6\xf9\xfe\xbe\xff\xf0\x40\x23\x77\x6b\xe2\x85\xe2\x1d\x27\xc6\xf4\x04\x94\x22\x4d\xa5\x61\xde\x0b\x8f\x77\x4e\x6a\xc6\x53\x34\x60\xca\x84\x25\xf5\xab\x93\x24\x4c\x20\x1a\xff\x0e\x81\x58\x84\x5d\xfa\xda\x84\x76\x57\xcb\x6f\xf3\x34\xcb\xcb\xce\xa3\x58\xc2\xa5\xcf\x69\x05\x73\xd8\xd2\x22\xd7\x35\x30\x15\x2f\x7e\x77\xdc\xe8\x3e\x53\x1d\x42\xa6\x98\x3a\xa8\x95\xcd\x0b\x2c\x76\x97\xee\xa1\x63\x16\x5c\x77\x29\x89\x17\x80\x1e\x4c\x9e\xd6\x1b\x4f\x5c\xd4\xc7\xe9\x8e\xdc\xd8\xe7\xb2\xe4\x3c\x2b\x58\xc6\x94\xf1\x21\xfd\x9c\x70\x35\xd4\x73\x02\x3e\x15\x6c\x4f\xb8\x17\xee\x8e\xdb\xea\x5f\x95\xc3\xba\xf5\x12\xbe\x26\xb1\x60\x5a\x57\x2a\xed\xbd\x52\xe3\x8a\x35\x78\xe5\xab\xe0\x7b\x70\xca\x5e\x6d\xc9\x1b\x2e\xd0\xa1\xf5\x3a\x5b\xa3\xad\x2b\x6f\xe7\x81\x52\xd9\x8a\x91\xb3\xc5\x9e\x6d\x25\x96\x77\x35\x8a\xd5\x20\x61\xcd\x28\x45\x0f\x49\x38\x0e\x06\x6c\x37\x4a\x65\x0a\x47\x44\xc7\xde\xb5\xb4\x79\x48\xa3\x6b\x5c\x61\x0f\x8b\x97\xe4\xc6\x9a\xb0\xa1\x43\x38\xda\x83\x2e\x51\x51\x3b\x79\xf7\xbc\x2a\x78\x43\xfc\x0e\x68\x34\x20\x6f\x71\x92\x7a\x1a\xe9\x0d\xe8\x0b\x1a\x5d\x38\x74\x43\x95\x68\x80\xbf\xa4\xd1\xcb\x6f\x80\x0f\x86\x28\xcc\xf0\x1b\x08\x86\x3f\x5a\xe9\x27\xac\xa1\x8d\x34\xb1\x7f\xf9\xb3\x85\xff\x05\xb0\xfc\x3a\x65\x2f\x50\xfe\xa1\xc3\x37\x15\xc5\xe6\x14\x7f\xec\xd1\x5c\x89\x5a\x30\xde\xba\xf4\x19\xdd\xbf\xc7\xd1\x31\xb0\x9d\x5f\x67\x2c\x06\xb7\x7a\x20\x47\x5c\x7c\x2c\x3a\x2b\xce\xce\xdb\xfb\x73\x8e\x47\x67\x4d\xac\x15\xdb\x2a\xb9\xc1\xe2\xff\x81\x6d\xc9\x0d\xae\x9e\xa6\xc6\xd1\x83\x4b\x5a\x77\x6e\xc5\xe8\x99\x4a\xa7\xf3\xe9\x8a\xdb\x41\x28\x0c\xec\xd8\x64\xef\x26\xc1\x41\xd5\x8e\x58\x81\x9b\x67\xec\x9c\x88\x4a\x97\xc3\x5c\x31\xa1\x49\x95\x80\x72\x21\x5a\xcc\xb2\x6e\x24\x8b\xee\xb0\x9c\x0b\x82\xe6\xd4\xe4\x83\x55\x19\x12\xcf\x0f\x2f\xec\x1c\x76\xbf\xd8\xb6\x4d\xa4\x3c\xa8\x81\xc3\x2d\xff\x0f\x88\x9c\x95\x4c\x1c\xc7\x8a\x53\x98\x29\xb0\xec\x1c\xd4\x22\x2d\x33\xbb\xf7\x2c\x4b\x27\x94\x77\x72\x4d\xaa\x03\x5e\x16\xd2\xc0\xab\xc1\x10\xde\x1a\xe1\xd4\x45\xce\x35\x0e\x97\x61\x30\x8d\x46\xc5\xae\x2c\x2a\x77\xd9\x60\x5f\x60\x79\x3a\x23\x2f\x5c\xe8\x92\xd1\x98\xf4\xfd\x39\xb5\x98\xe4\x51\x39\x4c\xb7\xfd\xbc\xba\xe6\xb0\xf9\x75\x39\x76\xdd\xb6\x5d\x36\x5d\x56\xb2\xf5\xbd\x16\xe3\xde\x7e\x3d\x05\x76\x8a\xb7\x9e\x69\xfc\xfe\x98\xb9\xe2\xfa\xe8\xeb\x23\xac\x7f\x86\x68\x30\x77\x18\x41\x9d\x2a\x5c\xce\x48\x7d\x39\x38\x3f\x3f\xa7\xdd\x12\x39\x51\x32\xc3\x0f\xab\xb4\x53\xcc\xba\x08\xa8\x16\x7e\xbc\xed\x1e\x32\xad\x2a\x33\x02\xea\xeb\xfe\xf7\x4d\x4c\xab\xba\x88\x88\xfa\x7a\xf0\x98\x6d\x95\x52\xf8\xb2\xbe\x0e\xf6\xc0\x1b\xdb\x2a\x3b\x87\x5d\x11\x11\x8f\x9f\x7d\xb7\xea\xb6\x6a\xd6\x09\xfc\x77\xe9\xff\xb3\x74\x35\x45\x79\x0f\x00\x00")
 
 func webfilesDebuglistkeysHtmlBytes() ([]byte, error) {
 	return bindataRead(
@@ -177,8 +177,8 @@ func webfilesDebuglistkeysHtml() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "webfiles/debuglistkeys.html", size: 3340, mode: os.FileMode(0644), modTime: time.Unix(1633465593, 0)}
-	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x7d, 0xf3, 0xde, 0xd4, 0x42, 0xe, 0xc1, 0xfa, 0x6d, 0x8e, 0xf5, 0x23, 0x23, 0xd8, 0x9a, 0x71, 0x35, 0x2, 0xbc, 0x71, 0x91, 0xf6, 0x4c, 0x9e, 0x93, 0x2a, 0x85, 0xc6, 0xa2, 0x89, 0xb7, 0x53}}
+	info := bindataFileInfo{name: "webfiles/debuglistkeys.html", size: 3961, mode: os.FileMode(0644), modTime: time.Unix(1791961132, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x8d, 0xf9, 0x33, 0xe8, 0xc8, 0x8, 0x81, 0x1c, 0x28, 0xe9, 0xb, 0x1c, 0xe1, 0x96, 0x69, 0x19, 0x78, 0x6e, 0xc3, 0xd7, 0xc7, 0xd0, 0xd5, 0x3, 0x35, 0x80, 0x56, 0xfb, 0xe4, 0xe1, 0x79, 0x80}}
 	return a, nil
 }
 
@@ -242,7 +242,7 @@ func webfilesFaviconIco() (*asset, error) {
 	return a, nil
 }
 
-var _webfilesFilterJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbc\x58\x6d\x6f\xdc\xc6\x11\xfe\xae\x5f\x31\x61\x83\x33\xe9\x3b\x91\x4e\x83\x7e\xa8\x55\x36\x70\x24\xb9\x3d\x44\x89\x62\xcb\x36\x0a\x08\x4a\xb1\x26\x87\xc7\x8d\xf6\x76\x99\xdd\xa1\xce\x87\xc0\xff\xbd\x98\x5d\x92\x47\xde\x49\x8a\x5d\xa3\x3d\x40\xe2\x69\x39\xfb\xcc\xec\x33\xaf\xab\xec\xe9\x11\x3c\x85\x53\xd3\x6c\xad\x5c\xd5\x04\x71\x91\xc0\x9f\x9f\x7d\xf3\xd7\x05\x38\xa1\xd0\x55\xc6\x16\x98\x16\x66\xbd\x00\xa9\x8b\x94\x65\x5f\x28\x05\x5e\xd6\x81\x45\x87\xf6\x0e\x4b\xbf\x7e\xf5\xf3\xd9\xbf\x8e\x2f\x64\x81\xda\xe1\xf1\xb2\x44\x4d\xb2\x92\x68\x9f\xc3\xf7\x57\x67\xc7\xdf\x1e\x9f\x2a\xd1\x3a\x64\xc1\x97\xc6\x42\xd5\x2a\x05\x2a\x08\x03\xe1\x07\x5a\x80\x43\x84\x8b\xe5\xe9\xf9\x4f\x57\xe7\x29\x7d\x20\xa8\xa4\x42\x90\x1a\xa8\x46\xb0\xd8\x18\xb0\xc6\x10\x18\x0b\x35\x51\xe3\x9e\x67\x99\x69\x50\x3b\xd3\xb2\x81\xc6\xae\xb2\x0e\xcd\x65\x7b\xfa\xb2\xa3\xa3\xaa\xd5\x05\x49\xa3\x61\x85\xf4\xd6\xaa\x77\xc2\xba\x38\x81\xdf\x8f\x00\x00\xee\x84\xe5\x1f\x07\x39\xfc\xfe\xf1\x64\x58\x6a\x84\x25\x5e\xdb\x48\x5d\x9a\x4d\xaa\x4c\x21\x18\x21\xad\x2d\x56\xa9\xc5\x46\x89\x02\xe3\xec\xfa\xbb\xd9\xcd\x3c\xbe\xfe\x25\x9f\xdd\xcc\x93\x3c\xbe\xfe\x65\x76\xf3\x34\xc9\x56\x72\x01\xbd\xca\x78\xbd\xb8\xc5\xed\xe2\x4e\xa8\x16\x7b\x95\x9d\x0e\x77\x7d\x8b\xdb\x1b\xc8\xc1\xbf\x0c\xaa\x3f\x26\xe1\x69\x91\x5a\xab\xbd\xd4\xc9\xd1\xc7\x83\x13\xfc\x2c\xac\x58\xc7\x0d\xff\x46\x42\xbb\x80\x12\x2b\xd1\x2a\x0a\x6a\x76\x07\x6b\xad\x1a\x84\x20\x9f\x48\x05\x3d\xb2\x8a\xef\x3d\xa1\xd4\x25\x7e\xb8\xac\x76\x2a\x12\xf8\x3b\x1c\x7f\x03\xb3\x19\x94\x58\x98\x12\xdf\xbe\x5e\x9e\x9a\x75\x63\x34\x6a\x8a\xc7\xb4\x5e\x0f\x5b\x6e\x12\xf8\x2a\x87\x28\x82\x64\x77\xec\x03\x83\x3e\x19\xab\xe3\x67\xcc\xce\x18\xcc\xb3\x94\x65\x70\x61\xcc\x2d\xb4\x8d\x8f\x9a\xd6\x2a\xd8\x69\x8b\xfc\xd7\x08\x5a\x27\xf5\x0a\xa2\x8e\x8b\x77\xcc\x45\x04\xb2\x02\x6d\x08\x2a\xd3\xea\x92\x61\xa8\x46\x0d\x0e\xc9\xe3\x54\xc6\xae\xc1\x34\x9e\xff\x8d\xa4\x1a\x64\x09\x11\x2a\x5c\xa3\xa6\x65\x19\x01\x19\xa0\x5a\x50\xf0\xe3\xce\x55\x0e\xe9\xcc\x9a\xa6\x34\x1b\x1d\x78\x5c\xc0\xb0\x69\xf0\x98\xd7\xcf\xc9\xe5\xd0\x86\x3f\x96\xd5\x8f\xd2\xb1\x8d\xd3\x08\x55\x2d\x42\x7e\xe8\xfe\x29\x50\xb2\x0b\x60\x87\x0a\x0b\x62\x8e\x4d\xd1\xb2\xd2\x74\x85\x74\x1e\xf4\x7f\xbf\x5d\x96\xf1\x60\xcb\x68\x93\x3f\x3f\xe4\x50\x09\xc5\xb9\x03\xc0\x67\x8f\xf9\x8d\x84\x1c\x9e\x9d\x48\xf8\x5b\x07\x9c\x06\x3e\x5c\xaa\x50\xaf\xa8\x3e\x01\x39\x9f\x8f\xfc\x2c\xab\x78\x2a\x77\x2d\x6f\xd2\xee\x10\x5d\xc0\xc3\x38\x1d\xf8\x73\xb8\x21\xac\x20\x5b\x44\xb6\x0f\xd9\xfe\xd3\xdb\xca\x6f\x86\x17\x1f\x47\x51\x22\x2b\x88\xbf\x0a\x52\xb3\xd9\xa3\x0c\x8f\xb4\x8b\xa6\x41\x5d\xc6\xa0\x71\x03\x97\xde\x92\xf8\x2e\xb8\xa8\x7b\x78\x6a\x16\x5e\x6b\x92\x1c\xc6\x64\x88\x81\xff\x71\x2c\x72\xc1\x04\xa9\x9b\x96\x3e\x33\x1e\xdf\xe0\x07\xfa\x83\x58\xfc\xb2\xa8\x93\xba\xf9\x9c\x98\x63\xf1\x3e\x2c\x3a\x73\xff\x8f\x5c\x5a\x51\x4a\x03\x45\x8d\xc5\x2d\x96\xdd\x61\x99\x3b\xdb\x22\x08\x5d\x42\x21\x94\x0a\xa4\xf7\x14\x6e\x78\x7b\xb7\x61\x42\xec\x6b\x86\xfa\x44\x66\x15\xd2\x67\x32\xfb\x10\x9d\xa1\xdc\xa7\xfd\x09\xc6\x59\xf2\x5b\x8b\x76\x7b\x5a\x0b\xbd\xc2\xf8\xf1\xed\xfb\x1d\x67\xc7\xf9\x3f\x90\x40\x80\x92\x8e\xc0\x54\xe1\x8d\x83\xca\x9a\x75\x40\x7f\x6b\x15\xc4\x5d\x83\x0e\x25\xb2\x02\x01\xbf\x3a\xa3\x41\x58\x2b\xb6\x09\x63\x2c\x7d\xda\x01\xd5\xc6\x71\x37\x27\x03\xa5\x35\x0d\x70\x51\x1c\x62\x17\x7f\x6b\x85\x1a\x47\x30\x6f\x7c\xa1\xcb\x40\xf7\xd6\xb4\x50\x49\x5d\x82\xe8\x58\x5b\x0b\x2a\x6a\xf6\xf5\x10\x07\x43\x0c\xb0\x67\x25\xb1\x13\xfb\xd2\xb1\xf3\x52\x63\x9a\x56\x09\xc2\xbe\x26\xbf\xb4\x66\xfd\x8a\xcf\xf1\x87\xc5\xb9\x3f\xed\x97\xa5\x46\x07\xff\x19\xd9\x91\x65\x70\x45\xc2\x32\xfb\x55\x20\xeb\xd7\xd6\x11\x08\xdd\x37\xa3\xca\x58\xcf\x7e\x30\xc6\x7b\x86\xff\x7c\xfb\xfa\xc2\xef\xef\xf0\xfe\x9b\x9a\xa6\xc5\x1a\x5d\x23\x0a\xe4\x19\xa8\xfc\x36\x65\xaf\xc6\x03\x0f\x27\x7b\x32\x29\xa7\x55\x3c\x30\x1d\x5b\x74\xad\xa2\x71\x75\xed\x4d\xb1\xb8\x36\x77\x18\x3f\x4b\xc6\x83\xd0\x3d\x6d\x27\x44\x24\xa3\xa4\x95\xb1\xe7\xa2\xa8\xe3\x51\xf1\x1f\x86\x2b\x6b\x36\xfb\x5d\x04\x40\xba\xab\x5d\xdf\x88\x87\xa6\xc3\xb2\x7b\x92\x8f\x10\x64\xcd\x66\x01\xfe\x57\x47\xce\x0e\x35\x81\xe4\x64\x5f\x65\x05\xf1\x58\x60\xdf\xa4\x07\xfb\xd5\xb8\x6b\xc1\x68\xfe\x9b\xf6\xaf\x7d\xc0\x2f\x70\xec\xa8\x49\x26\xf7\xe6\xfd\xd7\x43\xb9\x48\x52\x8b\xa2\xdc\x0e\x7e\x8d\x87\x0a\xf6\x75\xfc\xe4\x4f\x7d\x82\x9d\xeb\xf2\x8d\x5c\xe3\x93\x24\x35\x3a\x7e\xf2\x5e\xb5\xf6\xc9\x68\xfa\xc5\x3b\xda\x9b\x7a\xa1\x14\x84\xbc\xe3\x5d\x97\x41\x0f\x65\xc3\x93\x43\x0d\xa3\xc1\x15\x26\x63\xce\x20\x34\x80\x8e\x95\xa4\x64\xae\xc8\x4a\xbd\x8a\x47\xec\x3a\x74\x4e\x1a\x7d\x45\xc6\x8a\x15\xa6\x0e\x69\x49\xb8\x3e\xd4\xba\xb8\x57\xc5\x27\x01\xd1\xd5\x01\x16\x7b\xea\x4c\x10\xc6\x49\x4a\x66\x79\x75\xd9\xdb\x95\xec\xc6\x7f\xfe\x99\xf4\x96\x97\x52\x11\x5a\xf7\x42\x97\xaf\xbd\xaf\x5e\x75\x69\x18\x77\x85\x86\x5b\xe3\x7b\x51\xdc\x0e\x95\xe7\x07\xa9\x77\x35\xec\xa7\x3e\x4b\x7b\x3f\x64\x19\xfc\x80\xc8\x9d\x54\x3a\xbe\x5f\xb9\xad\x2e\x42\x75\x69\x6e\x57\x99\x53\xc6\x34\x19\x67\xba\x44\x97\xf9\x8a\xe6\xd2\x95\x39\x1a\x0a\x92\x59\x23\x17\x7a\xaa\xd1\x21\x68\xc4\x92\xab\x6d\x2d\x43\x47\x65\x33\xd0\x17\x6e\x59\xd4\x40\xe2\x16\x9d\xef\x20\x44\x0a\x81\xe4\x1a\x7b\x98\x33\x13\xda\x86\xe0\xde\xa2\xb9\xad\x48\xeb\xa8\x7f\xfb\xe6\xf2\xec\xf2\x39\xf8\x73\x0e\xb0\xc7\x8c\x2b\xd8\xd8\xa9\xd4\x0b\xe5\xcc\x02\x36\xdc\x16\xb6\x50\x18\xed\x64\x89\x3c\x87\x48\x92\x42\xa9\x6d\x5f\xf6\xb9\x5f\x30\x14\x77\x9f\xe3\x5d\xf7\xd9\xab\x9e\x43\x47\x71\x20\xd8\x72\xbe\xe6\xd5\x46\x95\x68\x7b\xa5\xe1\xd3\x6a\x92\x8a\x95\xae\xfa\xb1\x2c\xdc\x67\x1d\x79\x5b\x03\x5b\x7b\x71\x03\xf9\x7e\xa8\xac\x1e\x8a\xb9\xa4\xd7\xb6\xac\xa0\x75\x68\xc1\xd5\x2d\x85\x9e\xc9\xca\x8c\x2a\xbb\x7b\xa9\x1f\x53\xf8\x1e\x0c\xc2\x87\x56\x58\x5d\x0c\x13\x4e\x17\x03\xd0\x4a\x28\xa5\x6b\x94\xd8\xb2\xbf\xd8\x18\x32\xa0\xcd\x66\x98\x93\x0f\x6c\xcd\x73\xd0\xad\x52\xfb\xc9\xab\xcd\x06\x72\x78\x30\x8c\xf7\xa6\xe9\xf1\xd9\xb5\xd9\xa4\xae\x7d\xef\x82\xe4\xb3\x85\x5f\x08\x97\x87\xe3\xbf\xf4\x83\x74\x98\x8f\xba\x78\x66\x45\x3d\xda\xee\x26\x15\xf5\xaf\xa3\x05\x44\x95\x4f\x8d\xd1\xca\x41\x4a\xf8\xd2\x17\xdc\x61\x2c\xed\x20\x0f\x60\xf9\x75\xb4\xf0\xeb\x1d\xec\x78\xc5\x71\x1f\xfe\x37\x33\x17\x75\x55\x35\x39\x1a\x9a\xa0\x1f\x48\xbc\x7b\xc3\x80\x1d\x0d\x8b\x3b\x1b\x27\x4b\x51\xb7\x39\xb8\xeb\xa2\xbb\x79\xdf\xf3\xdf\x86\x46\x50\xcd\x3b\x47\x15\x6c\x37\xdd\x4d\x0e\xf3\xf0\x74\x13\x79\xe1\xe9\xd1\x26\x4b\xe7\x77\xa8\xe9\x9f\x28\xe8\x47\xd1\xf0\xda\xd4\xaa\x79\x94\x95\x82\xc4\x77\x7e\x4b\xfe\x2a\x14\x86\x59\x4f\x79\x1e\xcd\xfb\xaf\xfd\x54\xe0\xa6\x24\x3f\x6a\xda\x30\x42\x4c\x89\xea\x97\xf6\x4b\xd8\xe2\x51\xdb\x06\xb1\xc7\xcc\xbb\x95\xbe\x09\x7f\x9a\x79\x2c\xdc\xb1\xd4\x9b\x37\x5e\x9a\x94\x5b\x38\x74\xe8\xd4\x3c\x16\x7b\xc8\xb2\x30\xe5\x0b\x12\xaf\x3a\xc7\x3e\x06\x14\xcd\xfd\x73\x1e\xcd\x06\xae\xf2\x68\xae\xdd\x3c\xba\x0f\x7d\x1e\xcd\xd8\xe6\x3c\x9a\xf3\x63\x1e\xcd\x38\xac\xf3\x68\xce\x8f\x0e\xc2\xc7\x25\x43\xf4\xdf\xe7\xd1\x0c\x75\xe9\xc3\x9d\x25\xa7\xb9\x3c\x9e\x1a\x06\x93\x79\x72\xf8\x4f\x00\x00\x00\xff\xff\x7e\xb0\xe9\x34\x4b\x14\x00\x00")
+var _webfilesFilterJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\x03\xbd\x58\x5b\x6f\xdb\x36\x14\x7e\xcf\xaf\x60\xb5\x22\x91\x6a\x5b\x4e\x57\xec\x61\xcd\xb2\xa2\x4d\xd2\xcd\x68\xda\x34\x71\x5b\x0c\x08\xb2\x82\x95\x28\x8b\x8d\x2c\xaa\x24\x65\xd7\x28\xf2\xdf\x77\x0e\x29\xea\xe6\xd8\x4d\x56\x6c\x02\x12\xd9\xe4\xb9\xf1\x3b\x57\x7a\xfc\x68\x87\x3c\x22\x47\xa2\x58\x49\x3e\x4b\x35\xf1\xa3\x80\xfc\xbc\xff\xf8\xd7\x21\x51\x34\x63\x2a\x11\x32\x62\x61\x24\xe6\x43\xc2\xf3\x28\x44\xda\xe7\x59\x46\x0c\xad\x22\x92\x29\x26\x17\x2c\x36\xeb\xd3\xb7\xc7\x7f\x8d\x4e\x79\xc4\x72\xc5\x46\x93\x98\xe5\x9a\x27\x9c\xc9\xa7\xe4\xc5\xf4\x78\xf4\x64\x74\x94\xd1\x52\x31\x24\x7c\x29\x24\x49\x4a\x90\x92\x59\x62\xa2\xd9\x57\x0d\xfa\x18\x23\xa7\x93\xa3\x93\x37\xd3\x93\x50\x7f\xd5\x24\xe1\x19\x03\xa5\x44\xa7\x0c\x14\x15\x82\x48\x21\x34\x01\xde\x54\xeb\x42\x3d\x1d\x8f\x45\x01\xdc\xa2\x44\x03\x85\x9c\x8d\x2b\x69\x6a\xdc\xd3\x37\xde\xd9\x49\xca\x3c\xd2\x5c\xe4\x64\xc6\xf4\x7b\x99\x7d\xa0\x52\xf9\x01\xf9\xb6\x43\xe0\x59\x50\x89\x7f\x8a\x1c\x92\x6f\x37\x07\xf5\x52\x41\xa5\xc6\xb5\x25\xcf\x63\xb1\x0c\x33\x11\x51\x94\x10\xa6\x92\x25\x21\x98\x93\xd1\x88\xf9\xe3\xcb\x67\xbb\x57\x03\xff\xf2\xef\x43\x78\x05\x87\xf0\x61\xf7\xea\x51\x30\x9e\xf1\x21\x71\x2a\xfd\xf9\xf0\x9a\xad\x86\x0b\x9a\x95\xcc\xa9\xac\x74\xa8\x4b\xd8\xb9\x02\x1d\x66\xd3\xaa\xbe\x09\xec\x5b\x32\x5d\xca\xdc\x50\x1d\xec\xdc\xac\x9d\xe0\x2d\x95\x74\xee\x17\xf8\x9f\x69\x26\x87\x24\x66\x09\x2d\x33\x6d\xd5\x34\x07\x2b\x65\x56\x13\x81\xa2\x36\x95\xd5\xc3\x13\xff\xd6\x13\xc2\x1a\xfb\x7a\x96\x34\x2a\x02\xf2\x3b\x19\x3d\x26\xbb\xbb\x20\x24\x12\x31\x7b\x7f\x31\x39\x12\xf3\x42\xe4\xe0\x67\xbf\x0d\xeb\x65\xcd\x72\x15\x90\x07\x87\xc4\xf3\x48\xd0\x1c\x7b\xcd\xa0\x3b\xcb\xaa\xf0\x69\xa3\xd3\x16\x66\x50\x1a\x8f\xc9\xa9\x10\xd7\xa4\x2c\x4c\xd4\xc0\x3e\x69\xb4\x79\xe6\xa3\x47\x4a\xc5\xf3\x19\xf1\x2a\x2c\x3e\x20\x16\x1e\xe0\x40\x72\x88\xae\x44\x94\x79\x8c\x62\x80\x3d\x87\x88\xd4\x46\x0e\x24\xc1\x9c\x88\xc2\xe0\xbf\xe4\x3a\x25\x3c\x26\x1e\xcb\xd8\x1c\xec\x9d\xc4\x1e\xd1\x02\xc8\xa8\xb6\x7e\x6c\x5c\x05\xec\xc7\x52\x14\x00\x6e\x6e\x71\x1c\x92\x9a\xa9\xf6\x98\xd1\x8f\xc9\x05\x99\x64\xbf\x4c\x92\xd7\x5c\xa1\x8d\xdd\x08\x85\x1d\x00\x6c\xcd\xfd\x5d\x41\x41\x13\xc0\x0a\x74\x45\x1a\x31\x16\x51\x89\x4a\x43\xe0\x3d\xb1\xfa\x5f\xac\x26\xb1\x5f\xdb\xd2\x62\x32\xe7\x07\x9e\x84\x66\x98\x3b\xf0\xc0\xd9\x7d\xdc\xe1\xb0\xba\x7f\xc0\xc9\x6f\x95\xe0\xd0\xe2\xa1\xc2\x8c\xe5\x33\x9d\x1e\x10\x3e\x18\xb4\xfc\x0c\x71\xd5\xa5\xbb\xe4\x57\x61\x75\x88\x2a\xe0\x49\x3b\x1d\xf0\x59\x67\xb0\x2b\x0c\x2d\xd2\xd2\x85\xac\x7b\x9c\xad\xb8\x53\x6f\xdc\xb4\xa2\x04\x7c\xea\x3f\xb0\x54\x10\xb6\xdb\x10\x6e\x69\xa7\x05\x54\x95\xd8\x27\x39\x5b\x92\x33\x63\x89\xbf\xb0\x2e\xaa\x5e\x06\x9a\xa1\xd1\x1a\x04\xeb\x31\x69\x63\xe0\x3f\x8e\x45\x2c\x98\x70\xa0\xa2\xd4\xf7\x8c\xc7\x77\xc0\xf8\x9d\x58\xfc\xb1\xa8\x03\xa3\xee\x13\x73\x48\xee\xc2\xa2\x32\xf7\x7f\xc4\x52\xd2\x98\x0b\x12\xa5\x2c\xba\x86\x18\xb3\x66\x20\x76\xe0\x5a\x42\x21\x6a\x22\x0a\x4d\xca\x80\xee\x20\x5c\x22\x7b\xc5\xd0\x01\xf6\x02\x45\xdd\x11\xd9\x8c\xe9\x7b\x22\xbb\x09\x4e\x5b\xee\x43\x77\x82\x76\x96\x7c\x29\x99\x5c\x1d\xa5\x34\x9f\x31\x7f\x3b\x7b\xbf\xe3\x34\x98\xff\x01\x86\x52\xe8\xd2\x0a\xda\x6e\x62\x77\x14\x49\xa4\x98\x5b\xe9\x60\x38\xf1\xab\x06\x6d\x4b\x64\x02\xe4\x9f\x15\x20\x42\xa5\xa4\xab\x00\x65\x4c\x4c\xda\x01\x8d\x50\xd8\xcd\x01\xde\x18\x6a\x22\xc1\xa2\x58\xc7\x2e\xfb\x52\xd2\xac\x1d\xc1\xc8\xf8\x1c\x1c\x60\xe0\x5e\x89\x12\x66\x01\xf8\x46\x2b\xd4\xe6\x54\x47\x29\xfa\xba\x8e\x83\x3a\x06\xd0\xb3\x5c\xa3\x13\x5d\xe9\x68\xbc\x54\x88\xa2\xcc\xa8\x66\xae\x26\xbf\x84\x83\x9c\xe3\x39\xbe\x5b\x9c\xdd\x69\x7f\x2c\x35\x2a\xf1\xf7\xc8\x0e\x40\x61\xaa\x61\x0e\x01\x60\x13\x0b\xd6\xe7\x12\x7c\x41\x73\xd7\x8c\x00\x75\x83\xbe\x35\xc6\x78\x06\xbf\xbe\xbf\x38\x35\xfc\x95\xbc\x7f\x53\xd3\x72\x48\x2e\x55\xc0\x8c\x83\x33\x50\xfc\x24\x44\xaf\xfa\x35\x0e\x07\x3d\x9a\x10\xd3\xca\xaf\x91\xf6\x61\x2e\x04\x00\xda\xd5\xd5\x99\x22\xd9\x5c\x2c\x98\xbf\x1f\xb4\x07\xa1\x5b\xda\x8e\x8d\x48\x94\x12\xc2\x21\x4f\x68\x94\xfa\xad\xe2\x5f\x0f\x57\x52\x2c\xfb\x5d\x04\x8a\x8a\x9a\x36\x7d\xc3\xaf\x9b\x0e\xd2\xf6\x28\xb7\x00\x04\xd4\x43\x62\xfe\x55\xe0\x34\x52\x03\x12\x1c\xf4\x55\x42\xab\x69\x13\xf4\x4d\xda\xd8\xaf\xec\x73\xd3\xf4\xaf\x96\xe8\xa6\x7f\xf5\x05\xfe\x80\x63\x1b\x75\x37\xc1\xad\x79\xff\xb0\x2e\x17\x01\x78\x8b\xc6\xab\xda\xaf\x7e\x5d\xc1\x1e\xfa\x7b\x3f\xb9\x04\x3b\xc9\xe3\x77\x7c\xce\xf6\x82\x10\x28\xf6\x3e\x65\xa5\xdc\x6b\x4d\xbf\x6c\xa1\x7b\x53\x2f\x89\x21\x07\x91\xe3\x43\x95\x41\x9b\xb2\x61\x6f\x5d\x43\x6b\x70\x75\xd2\x7a\x44\xb5\xd0\xb6\x92\x50\x8b\xa9\x96\x50\x31\xfc\x16\xba\x70\x53\x50\x60\xe1\x54\x0b\x49\x67\x0c\x46\x0d\x3d\xd1\x6c\xbe\xae\x75\x78\xab\x8a\x3b\x09\xd2\xd3\x35\x59\xe8\xa9\x63\xb0\xcd\x0f\xc0\xa8\xc9\xf4\xcc\xd9\xe5\xc6\x5b\x78\xe3\x5f\xa7\xb7\xbc\xe4\x19\xf4\x39\x05\x05\xf1\xc2\xf8\xea\xbc\x4a\x43\xbf\x2a\x34\xd8\x1a\x3f\xd1\xe8\xba\xae\x3c\xaf\xa0\x5a\xd6\x5f\xde\xb8\x2c\x75\x7e\x80\xaa\xf2\x8a\x31\xec\xa4\x5c\xe1\xfd\x4a\xad\xf2\xc8\x56\x97\xe2\x7a\x36\x56\x99\x10\xc5\x18\x33\x9d\xc3\x55\xca\x54\x34\x15\xce\xc4\x4e\x5d\x90\xc4\x9c\x61\xa1\x87\x8c\x87\x82\x9e\x33\x48\x32\xa8\xb6\x29\xb7\x1d\x15\xcd\x60\xa6\x70\xf3\x28\x25\x9a\x5e\x43\xfd\xc0\x0e\xa2\x35\xdc\xe5\x34\x40\xe0\xc4\x1c\x0b\xdb\x36\x28\xf6\x96\x1c\xdb\x0a\x97\x4a\xbb\xdd\x77\x67\xc7\x67\x4f\x89\x39\x67\x2d\x76\x84\x72\x29\x1a\xdb\xa5\x7a\x9e\x29\x31\x24\x4b\x6c\x0b\x2b\x12\xc1\xe0\xc8\x63\x86\x73\x08\xd7\x1c\xda\xf7\xca\x95\x7d\xec\x17\x28\x0a\xbb\xcf\xa8\xe9\x3e\xbd\xea\x59\x77\x14\x30\x1b\x2d\xc7\x6b\x5e\x2a\x32\x90\xe8\x94\xda\xa7\x84\xcb\x6d\x86\x4a\x67\x6e\x2c\xb3\xf7\x59\x38\x0d\xda\x6a\xd1\xea\xc5\x0d\x44\x65\x2f\x54\x66\x9b\x62\x2e\x70\xda\x26\x09\x8c\x35\x70\x1c\x95\xc2\xa8\x67\xac\x46\x65\x60\x50\x75\x2f\x35\x63\x0a\xde\x83\xc1\x56\x0c\x2d\xbb\x3a\xac\x27\x9c\x2a\x06\x48\xc9\x49\xcc\x15\x9c\x66\x85\xfe\x42\x63\xc0\x69\xb9\x58\xd6\x73\xf2\x9a\xad\x50\x30\x73\x38\x53\x3f\x79\x81\x07\xce\xb1\x31\x8c\x7b\xd3\x74\xfb\xec\xc0\x19\xaa\xf2\x93\xb2\x94\xfb\x43\xb3\x60\x2f\x0f\xa3\x5f\xdc\x20\x6d\xe7\xa3\x2a\x9e\x51\x91\x93\xd6\xdc\xa4\x3c\xb7\xed\x0d\x89\x97\x98\xd4\x68\xad\xac\xa5\x84\x29\x7d\xd6\x1d\x42\xea\x46\xe4\x9a\x58\xdc\x06\x01\xf8\x54\x62\xdb\x2b\x0a\xfb\xf0\x47\x44\xce\xab\xaa\x6a\xb0\x53\x37\x41\x33\x90\x18\xf7\xda\x01\xdb\xab\x17\x1b\x1b\x3b\x4b\x9e\xb5\x68\xce\xe4\x8c\x4d\x53\x2a\x63\xd5\x9b\x24\x3c\xb3\xf5\x51\x99\x3d\x23\x04\x55\x56\x6c\x38\x3f\xb6\x58\x8f\x70\xfa\xdb\x52\x47\x2b\x03\x0c\x47\x25\x30\xa8\xfd\xde\x97\xd3\xf6\x77\x7f\xaf\x35\x67\xfa\x1d\xd3\x21\x56\x3c\xc4\xd9\xeb\xb8\xd1\xc6\xe2\x69\xf5\xb3\xc2\x2d\x3f\xa5\x14\x54\xa7\x08\x4b\xab\x3c\x37\xa3\x6b\xc7\x53\x9b\x47\x37\xcf\x10\x77\xfd\xd6\x59\x3a\x59\x00\x0e\x7f\x32\xaa\x5f\xd3\x02\xd7\xba\x56\x0d\xbc\x31\x74\x0a\xfa\xcc\xb0\x1c\x9e\xdb\xaa\xb7\xeb\xe2\xe9\xd0\x1b\xb8\x8f\x6e\xe4\x51\xdd\x08\xda\x6a\x5a\x3d\x1f\x75\xa3\xc0\x2d\xf5\xeb\xf3\x70\xab\x6d\x35\xd9\x36\xf3\xae\xb9\x99\x30\xee\x66\x1e\x12\x57\x28\x39\xf3\xda\x4b\x9d\x5e\x42\xd6\x1d\xda\x35\x0f\xc9\x36\x59\x66\xaf\x30\x40\x7b\x5e\x39\x76\x9b\x20\x6f\x60\xde\x03\x6f\xb7\xc6\x0a\xd6\x72\x05\x0b\xb7\x48\x87\x55\xb4\x19\x56\xf0\x05\xdf\x30\x67\xe1\x1b\xbe\x2a\x11\x26\xe9\x50\x84\xfb\x0c\xeb\xd0\x4a\x4c\x2e\x23\x65\xb7\x50\xdd\x96\x18\xed\x00\x6f\xa5\x47\x73\xa2\x01\x6c\xef\xb6\x33\xf6\xd0\x10\xb7\x7e\x1c\x48\x6d\x00\x6e\x04\x80\x16\x7c\xbc\x78\x3c\x66\x18\xac\x48\x3b\xa7\xc5\xb3\x3b\x9e\xff\x3b\x67\xa9\xc6\xbb\xda\x58\x1c\xf1\xfe\x01\x60\xed\x1a\x87\xf4\x15\x00\x00")
 
 func webfilesFilterJsBytes() ([]byte, error) {
 	return bindataRead(
@@ -257,12 +257,12 @@ func webfilesFilterJs() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "webfiles/filter.js", size: 5195, mode: os.FileMode(0644), modTime: time.Unix(1633465593, 0)}
-	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x83, 0x16, 0x9e, 0xac, 0xe5, 0xe5, 0xa6, 0x46, 0xc6, 0xbf, 0x53, 0xec, 0x8f, 0xed, 0xdb, 0xda, 0xe3, 0x20, 0xc0, 0x56, 0x13, 0xeb, 0xa4, 0xac, 0x3d, 0x16, 0xdb, 0x77, 0xa7, 0x55, 0x76, 0xcc}}
+	info := bindataFileInfo{name: "webfiles/filter.js", size: 5620, mode: os.FileMode(0644), modTime: time.Unix(1791972136, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x7b, 0xc9, 0xfc, 0x4b, 0xb9, 0x3a, 0x50, 0x23, 0x52, 0x30, 0x70, 0x35, 0x2a, 0x8b, 0x59, 0x94, 0xd3, 0xef, 0x23, 0x37, 0x84, 0x98, 0x98, 0x48, 0x15, 0xc7, 0x9a, 0x36, 0x7b, 0x37, 0x37, 0xe2}}
 	return a, nil
 }
 
-var _webfilesIndexHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x58\xed\x52\x1b\xb9\xd2\xfe\xcf\x55\xf4\xaa\xea\x2d\xa0\x96\x99\xc1\x36\x61\xb3\xc4\x9e\xda\x60\x48\x60\x43\xf2\xb2\x31\x24\x24\xa7\x4e\xa5\xe4\x51\xdb\x23\x2c\x4b\x13\x49\x63\xe3\xb8\xb8\xf7\x53\x92\x6c\x3c\x36\xe6\x23\xb5\xf1\x1f\x4b\x1a\xf5\xd3\x9f\x6a\x75\xab\xf9\x5b\x14\x6d\xb4\x55\x31\xd1\xbc\x9f\x5b\xd8\xca\xb6\xa1\xbe\x5b\xfb\x73\x07\x0c\x15\x68\x7a\x4a\x67\x18\x67\x6a\xb8\x03\x5c\x66\xf1\xc6\x6b\x21\xc0\x6f\x34\xa0\xd1\xa0\x1e\x21\x8b\x37\x3a\xe7\x47\x57\xd1\x19\xcf\x50\x1a\x8c\x4e\x19\x4a\xcb\x7b\x1c\xf5\x01\x1c\x76\x8e\xa2\x46\xd4\x16\xb4\x34\xb8\xf1\x46\x69\xe8\x95\x42\x80\x08\x3b\xc1\xe2\x8d\xdd\x01\x83\x08\x67\xa7\xed\xe3\x0f\x9d\xe3\xd8\xde\x58\xe8\x71\x81\xc0\x25\xd8\x1c\x41\x63\xa1\x40\x2b\x65\x41\x69\xc8\xad\x2d\xcc\x41\x92\xa8\x02\xa5\x51\xa5\x93\x4b\xe9\x7e\x32\x43\x33\xc9\x12\xb3\x28\x4a\x37\x9a\xbf\x1d\xfd\x7f\xfb\xe2\xcb\xf9\x31\xe4\x76\x28\xdc\x3c\x8a\x4c\x59\x14\x1a\x8d\x81\x13\x3b\x14\x97\x72\x20\xd5\x58\x5e\x50\xdd\x47\xbb\x03\x7f\x77\x2e\xa5\x46\xa3\xc4\x08\xd9\x27\xaa\x39\xed\x0a\x04\x0f\x64\xec\x44\x20\xd8\x49\x81\x2d\xe2\xa4\x4e\x32\x63\x48\xba\xd1\x4c\xfc\x87\x74\xa3\x99\x23\x65\xe9\x06\x00\x40\xd3\x64\x9a\x17\xb6\xba\xf9\x9a\x8e\x68\x58\x25\x61\x8f\xfb\x31\x95\x95\x43\x94\x36\x1e\x6b\x6e\x71\x8b\x34\xbb\xd4\x20\xe4\x1a\x7b\xad\xcd\x84\xc0\xef\x30\xe6\x92\xa9\x71\x2c\x54\x46\x2d\x57\x32\x2e\xa8\xcd\x25\x1d\x62\x6c\x0a\xc1\xed\xd6\x66\xb2\xb9\xfd\x9f\xda\x7f\xe1\x77\x20\xc9\x26\x24\x29\xd9\x7e\x15\xf8\x27\x81\xd5\x4c\x9a\x21\x5a\xea\x2d\x17\xe1\xf7\x92\x8f\x5a\xa4\xad\xa4\x45\x69\x23\x27\x1f\x81\x2c\xcc\x66\x82\x3a\x33\xbd\x82\x2c\xa7\xda\xa0\x6d\x95\xb6\x17\xbd\x9c\x49\xdc\xb4\xdc\x0a\x4c\x3b\x42\xa9\x62\x3a\xe5\x3d\xd8\x92\x08\x71\xbb\xd4\x1a\xa5\xf5\x90\x37\x16\x08\xd9\xbe\xbd\x85\x08\xa6\xd3\x95\x2f\xb7\xb7\xd3\x29\x4a\x76\x7b\xdb\x4c\x02\x4e\xc0\x14\x5c\x0e\x40\xa3\x68\x11\x6f\x46\x93\x23\x5a\xb2\x6a\xe5\x60\x12\x32\xc6\xae\x0b\x0c\x93\x18\x27\x42\x1c\xec\xbf\x8c\xb2\x69\x72\xa5\x6d\x56\x5a\xe0\x99\x92\x9b\x01\x68\x93\x0f\x69\x1f\x93\x9b\x28\xac\x05\xfb\xde\x81\xf5\xe8\xc8\xad\xc7\x3c\x53\x9b\xc9\xa3\x52\x05\x29\xe6\x21\x98\x31\x19\x5f\x1b\x86\x82\x8f\x74\x2c\xd1\x26\xb2\x18\x26\x5d\xa5\xac\xb1\x9a\x16\x7f\xed\xc5\x2f\xe2\x46\xc2\xb8\xf1\x2a\x2c\x3e\xc4\x43\x2e\xbd\xe8\x77\x51\x00\xc0\xa5\xc5\xbe\xe6\x76\xd2\x22\x26\xa7\x8d\x97\x7b\xd1\xc5\xd5\x4b\x5b\xff\xe3\x38\xfb\x78\xdc\xc0\x84\xe7\x97\x7f\xfc\x18\xfe\x73\xf3\x49\x66\x47\xaf\x27\x2f\xca\xd3\x77\x3f\xf6\xf4\xf1\xa0\x7f\x7a\x85\xef\x91\xed\xbd\xdf\xbd\x16\xbd\xd3\xa3\xf3\x51\x7f\xbf\xfc\xfe\xee\xb4\x7e\x73\xa5\xeb\x55\xf4\x4c\x2b\x63\x94\xe6\x7d\x2e\x5b\x84\x4a\x25\x27\x43\x55\x86\xd0\x0d\x21\xbb\xd1\xec\x2a\x36\x49\x37\x9a\x8c\x8f\xc0\x2b\xdc\x22\x8c\x9b\x42\xd0\xc9\x01\xf4\x04\xde\xbc\x82\x31\x67\x36\x3f\xa8\xed\xee\xfe\xdf\x2b\xc8\xd1\x9d\x7d\x3f\x99\xdb\xdf\x11\x72\xd6\x22\xde\x31\x02\x7b\x56\xd2\x11\x81\x4c\x50\x63\x56\x16\x17\xc1\xdf\x34\x05\x95\x73\x76\x3d\x25\x6d\x64\xf8\x0f\x3c\xa8\xef\x16\x37\x24\x04\x19\x8c\x76\xe3\x7a\x33\x71\xfb\xd2\x66\x57\x3f\x49\x5a\xab\x3b\xd2\x77\x65\x17\xb5\x44\x8b\x06\x4e\xb8\xb1\x4a\x4f\xe0\x13\x37\x25\x15\xfc\x87\x3f\x44\x15\x40\x0f\xfa\x78\x28\x2f\x78\x0a\xda\x45\x01\x3d\xa5\x5b\x24\x5b\xda\xb8\xc4\x72\xb6\x76\xd0\x4c\xfc\x7e\xc7\x22\xa9\x08\xce\x65\x51\x56\xf3\x02\xf1\x66\x5b\xc1\x83\x11\x15\x25\xb6\xc8\x9a\x33\x44\x80\x71\xe3\x72\x12\x6b\x11\xab\x4b\x24\x55\x3d\xfc\xf1\x5a\xf0\xea\x29\x3d\x04\x9a\x39\x9d\x5b\x84\xc0\x10\x6d\xae\x58\x8b\xf4\xb1\x9a\x82\xfc\xce\xdf\xa2\x08\x3e\x28\x0b\x26\x57\x63\x2e\xfb\x3e\xed\x7e\x2f\x51\x4f\x80\x69\x55\x00\x53\x63\x09\xd4\xc0\x18\x41\x49\x31\x81\x9c\x8e\xdc\x68\xbe\x87\x5a\x4f\x30\x54\x2e\x95\xf9\x5c\x79\x0f\xbc\x6a\xbc\x1e\x17\x16\xb5\x27\x25\xe9\x3f\xee\xaf\x6a\x2c\x48\xd2\xfb\x10\x06\x05\x66\x16\x5c\xe6\x6b\x91\x40\xe9\xed\x56\x85\x82\x9c\x33\x86\x72\x6e\x16\x97\x03\x3d\xd5\x3a\x69\x66\x26\xf3\x8c\x96\x3f\x57\xe4\x0c\xe4\xc8\x8e\x25\xbb\xe0\x43\x24\xe9\xb1\x64\xe0\x46\x0f\xf8\x76\x7e\x10\x96\x57\xee\x79\x9d\x51\x8b\x96\x0f\x31\x72\x49\x5d\x10\x30\x16\x8b\x16\xa9\x05\x85\x56\x79\xce\x54\xbe\xb7\x9c\x3c\xc1\xa4\x5b\x5a\xab\xe4\x5d\x20\x7d\x50\xe3\x39\x94\x74\x43\xc7\xca\x0d\x56\x84\x4f\x9c\xf4\x3e\x96\x1e\xb6\x4a\x30\xb9\x50\x6a\xd0\xa5\xd9\x80\xa4\x67\x4a\x0d\xe0\x90\x66\x03\xf8\x48\x65\xff\x51\xdb\x2c\x79\xf1\x0e\xa1\xe2\xc8\x05\xea\x7d\xed\x54\xe1\xe2\x78\xae\x50\x2d\x27\x69\x0d\x4e\x54\xa9\x9b\x49\xf8\xf2\x24\x49\x23\x27\x69\xc3\x93\x98\x67\xd3\xec\xe7\x24\xdd\xff\x49\x9a\x5a\xdd\xc9\x56\xff\x49\xaa\xfa\x9e\xd7\xe8\x88\x4e\x9e\xcf\x68\xff\xa5\xa7\xf9\x8c\x38\x78\xbe\x15\x1a\x4e\xa7\xba\x27\x7a\x40\xba\xbb\x83\x73\x97\x59\x9e\x08\x06\xe7\x50\x53\xd0\x0c\x49\xfa\xc6\x2f\xc0\x87\xf9\xca\xb3\xc3\x61\x81\x51\x89\x87\x0a\xf0\x7a\x09\x97\x57\x9f\x29\xee\x80\x4b\x76\x27\xe9\x3b\x2e\xd9\x01\x3c\x2d\xe5\x42\x28\x4f\x3e\x93\x3a\x40\xfd\x3b\xeb\x19\xa5\x2d\x49\x3b\x4a\x2f\xdd\x19\x8f\xd9\xca\x53\x54\x24\x0a\x08\x4f\x79\xde\x58\xaa\xad\xf5\x89\xac\xe3\x86\x3e\x95\x3d\x3b\x6e\x86\xca\x58\x1c\xa1\xb4\x86\xa4\xef\x95\xb1\x70\xec\x27\xcf\xa6\x77\x92\x93\xd4\xc5\xc5\x2f\x0c\xba\x21\xb5\x59\x1e\x50\x21\xf8\xf3\x11\x13\xde\xbf\x79\x17\x91\x17\x80\x56\x22\x6f\x86\xfe\x80\x40\x55\x38\x53\x76\x87\xbc\xea\x82\x66\xe2\xee\xde\xca\xdc\xdd\x3a\x17\xaa\xdf\x17\x08\x66\xcc\x6d\x96\x83\x55\xfe\xb6\x85\x82\x4e\x84\xa2\xcc\x15\xdc\xb2\x8f\x66\xe9\xee\xf3\x65\xd5\xbc\x88\xf2\x64\x91\xab\xd5\x29\x97\xa8\x57\xc3\xae\xf0\xd2\xcf\xd0\x2e\x7c\x55\xd2\x71\xf8\xe7\x33\xfc\x76\xc0\x6f\x26\x45\xba\xce\xb2\x4b\x5c\x48\x0a\x8f\x5f\x2f\x59\x8e\xd9\xa0\xab\x6e\x48\x95\x69\xdb\x2d\x12\x50\x32\xa8\xb2\xbc\x8e\x7a\x6b\x9b\x80\xa7\x43\xb6\x26\x5a\x7c\x41\x77\x57\x2f\x72\x86\x1a\xb4\x2a\xdd\xe9\x9a\x95\x6b\x2b\xd1\x12\xbc\x5c\x31\xf8\xfc\xe2\x5d\x2c\xdd\xf3\x5b\x33\xaf\xa7\x67\x5c\xba\xbc\x97\xd7\x2b\xb4\x74\x56\xd8\x33\xec\x96\xfd\x64\x5e\x7b\x1e\xb9\x19\xbc\x47\x59\x36\x13\xba\x5a\xc6\xcd\x49\x82\x01\x18\xb5\xd4\xf5\x12\xae\x6b\x20\xe9\x11\xb5\xd4\x85\x23\x82\x6b\x76\x2f\x72\x6e\xc0\x57\x39\x8f\xc0\xcc\x5b\x8a\x3e\xb7\x79\xd9\x75\x9d\x76\xb2\x68\xbc\x43\xb7\x43\xc0\xfa\x0e\xb5\x45\xbe\x75\x05\x75\x7c\x3a\xbe\xfd\x85\xb6\x62\xae\x18\x83\xb7\xdc\x9e\x94\xdd\x05\x93\xe9\x54\x3b\x37\x40\x7c\x86\x3d\x7b\x48\xb5\xd7\xbc\x5a\x1e\xce\x99\x4f\xa7\xf1\xa5\x16\xae\xb4\x5c\xe5\x30\x9d\xc6\x17\xbe\xea\xac\xa2\x86\x22\x73\x63\xd9\xea\x8b\x0e\x80\x35\xbe\xe5\xa8\x71\x51\xfc\x8f\xfa\x95\xa0\x9d\xd5\xec\x9b\xa1\x9d\x80\x7b\xfd\xc4\xab\xcd\xf4\x3e\xf4\xac\x99\x36\x3a\x5b\x58\x8a\x35\xae\x8d\xef\xfc\x59\x23\x1e\xbd\x88\xaf\x8d\x0f\x94\x6a\xd3\x1b\x26\x4b\x61\xb3\x84\x90\x29\x86\xf1\xb5\xaf\x1d\xbd\xc1\xc3\x30\x6a\xc4\x7b\x71\xcd\xf7\x68\xd7\x4b\x2d\xda\x6a\x93\x56\x7f\xb1\x1f\xb5\x3b\x57\x4a\x5f\x8d\xbe\x66\x17\x03\xca\x6f\xf6\xbf\x8c\xd4\xfe\x49\x51\x64\x5f\xdf\xa2\xed\x7e\x79\xff\xf6\x73\xe7\x8d\x38\x1c\xbf\x3c\xe9\xb5\xff\x56\xad\x65\xac\x87\x5a\xb2\x7f\xa9\x43\xc9\x93\x5a\x5c\xab\xc7\xb5\xb9\x36\x25\x7f\xa6\x2a\x9f\xe8\x8f\xf3\x3f\xff\xf8\xda\x1e\x5b\x1c\xbc\x36\xa3\xfe\xf9\x61\xe7\x72\x7c\xfe\xe6\x1d\xd3\xe3\xa3\x46\x29\x2f\x7b\x9d\xb7\x9f\xbe\x68\x9a\x5f\x7e\xbf\xfc\x69\x55\x82\x2e\x3e\x05\xba\xc3\xc0\x8d\x6f\x17\x04\x37\x16\x54\x0f\xc2\x2e\x03\x12\x91\x21\x83\xee\x04\x34\xce\x5e\x76\x72\x3b\x14\x3b\xd0\xc5\x8c\x96\x06\x81\x4b\x89\xda\xbf\xe2\x40\x46\x25\x48\x65\x21\x2c\x67\xa2\x64\x95\xcc\xb9\x36\x5e\x4a\x59\x0c\xfa\xde\x46\xf4\x86\x2b\x13\xfa\x72\x3f\x9c\x1b\x08\xa8\x99\xc8\xcc\x1d\xe9\x07\x5e\x6d\xd6\xfa\x66\xc5\x1f\xeb\x9e\x04\x46\x25\x06\x76\xa3\x12\x7f\x15\xa3\x85\x3a\xb2\xd0\xaa\xaf\xd1\x98\xbf\x76\xe3\x7a\xbc\xbb\x98\xff\x32\x9d\x70\x88\x9a\x67\x83\x78\x96\x9c\xb8\x4a\xae\x0d\xe3\xbd\x9e\xe0\xdd\xc4\xfd\x8f\x38\x8e\x3d\xb3\xf5\x3c\xe0\x97\x30\x11\xbc\xfb\x4c\x1e\xf7\x99\x2c\x5e\x7a\xfc\xdd\xfe\x70\xb2\x58\x64\xe6\x24\x81\xcf\x39\x4a\xd7\xf1\x6a\xf4\xf7\xa7\x0b\xd9\x82\xf6\x11\xac\x8b\xe1\x31\x17\x02\x0c\x86\xc6\x37\x53\x5a\xbb\xea\x2c\x54\x30\x5c\x49\xe3\x0a\x15\xff\xc9\xb5\xcf\x91\x6b\x9f\x0d\x74\xa9\x41\xe6\x12\x75\x41\x8d\x1b\x71\x37\xd2\x74\x88\xae\x82\x5b\xbc\x08\x52\x4b\xfd\x6d\x71\xa9\x05\xb4\x1c\x8b\x50\xd8\x98\xd7\x92\x7d\x44\x5b\x6a\x39\xff\xba\xe5\x12\xf7\x11\xf6\x68\x29\xec\xd9\xac\x71\xba\xbd\x25\x3b\x50\x59\x77\xd5\xed\xea\xda\x5d\x6d\x7e\x7b\x3b\x7f\x2e\x84\xea\x53\x64\x1f\xed\xb1\x40\x37\x3c\x9c\x9c\xb2\xad\xe5\xcb\x6d\x3b\x76\x77\x06\xb4\x96\xe4\x5c\xfb\xe6\xb8\xd6\x01\xfe\x26\xfb\x56\xf2\x75\x2e\x08\x09\xbf\x99\x84\xa7\xa8\xff\x05\x00\x00\xff\xff\xef\xab\xd7\xea\x91\x16\x00\x00")
+var _webfilesIndexHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\x03\xad\x18\x6b\x53\xdb\xb8\xf6\x3b\xbf\x42\xeb\x99\x9d\xc0\xdc\xda\x26\x09\xa5\x2c\x4d\x32\x5b\x92\xb4\xb0\x40\x97\x6d\x80\xd2\xde\xb9\xc3\x28\xb6\x12\x8b\x38\x92\x2b\xc9\x79\xc0\xf0\xdf\xef\x91\x64\xc7\xce\x83\x47\x67\x9b\xc9\x24\x7a\x9d\xa7\x8e\xce\xab\xf1\x9b\xeb\x6e\xb5\x79\x32\x17\x74\x18\x29\xb4\x1d\xec\xa0\xda\x6e\xf5\x8f\x37\x48\xe2\x98\xc8\x01\x17\x01\xf1\x02\x3e\x7e\x83\x28\x0b\xbc\xad\x0f\x71\x8c\xcc\x41\x89\x04\x91\x44\x4c\x48\xe8\x6d\xf5\x2e\x3a\x37\xee\x19\x0d\x08\x93\xc4\x3d\x09\x09\x53\x74\x40\x89\x38\x44\x47\xbd\x8e\x5b\x77\xdb\x31\x4e\x25\xd9\xfa\xc8\x05\x1a\xa4\x00\x1f\xdb\x93\x48\x91\x99\x02\x32\x84\xa0\xb3\x93\x76\xf7\x73\xaf\xeb\xa9\x99\x42\x03\x1a\x13\xa0\x85\x54\x44\x80\x44\xc2\x91\xe0\x5c\x21\x80\x8d\x94\x4a\xe4\xa1\xef\xf3\x04\xa0\x79\xaa\xf9\xe2\x62\xe8\x67\xd8\xa4\xbf\x44\xcc\x75\x5b\x5b\x8d\xdf\x3a\x7f\xb7\x2f\xbf\x5d\x74\x01\x74\x1c\xeb\xb9\xeb\xca\x34\x49\x80\x71\x89\x8e\x61\xe9\x8a\x8d\x18\x9f\xb2\x4b\x2c\x86\x04\x38\xf9\xab\x77\xc5\x60\x8f\xc7\x20\xd4\x35\x16\x14\xf7\x81\x13\x83\x48\xaa\x39\x0c\xd5\x3c\x21\x4d\x47\x73\xed\x07\x52\x3a\xb0\xee\x9b\x0d\x18\x44\x04\x87\xad\x2d\x04\x9f\x86\x0c\x04\x4d\x54\xf9\xf0\x1d\x9e\x60\xbb\xea\xd8\x33\xfa\x13\xf2\x20\x1d\x83\xa6\xbc\xa9\xa0\x8a\x6c\x3b\x8d\x3e\x06\x95\x44\x82\x0c\x9a\x15\xdf\x41\xff\x41\x53\xca\x42\x3e\xf5\x62\x1e\x60\x45\x39\xf3\x12\xac\x22\x86\xc7\xc4\x93\x49\x4c\xd5\x76\xc5\xaf\xec\xfc\xb7\xfa\x3f\x38\xe8\xf8\x15\xe4\xb7\x9c\x9d\xf7\x96\xbe\x6f\x49\x65\xdc\x8c\x89\xc2\x46\x73\x2e\xf9\x91\xd2\x49\xd3\x69\x73\xa6\x80\xac\xab\xf9\x73\x50\x60\x67\x19\xa3\x5a\x4d\xef\x51\x10\x61\x21\x89\x6a\xa6\x6a\xe0\x1e\x64\x1c\x37\x14\x55\x20\x68\x2f\xe6\x3c\x79\x78\xa0\x03\xb4\xcd\x08\xf2\xda\xa9\x10\x00\x6d\x50\xc2\xcd\x39\xce\xce\xe3\x23\x72\xd1\xc3\xc3\xca\xce\xe3\xe3\xc3\x03\x61\xe1\xe3\x63\xc3\xb7\x78\x2c\xce\x98\xb2\x11\x5c\x71\xdc\x74\x8c\x1a\x65\x44\x88\x72\x56\xb5\x6c\x55\xe2\x4c\x49\x5f\x1b\x86\xf4\xa5\x66\xc1\xb3\xfa\x5f\xc6\x52\x91\x11\x17\x2a\x48\x15\xa2\x20\x56\xc5\x22\xaa\xd0\x31\x1e\x12\x7f\xe6\xda\x35\xab\xdf\x05\xb2\x01\x9e\xe8\x75\x0f\x7e\x2a\xfe\xb3\x5c\x59\x2e\x72\x13\x0c\x42\xe6\xdd\xc9\x90\xc4\x74\x22\x3c\x46\x94\xcf\x92\xb1\xdf\x07\x3b\x95\x4a\xe0\xe4\xcf\x3d\xef\xad\x57\xf7\x43\x2a\x8d\x08\xc5\x86\x37\xa6\xcc\xb0\xbe\xb0\x02\x04\x96\xae\xc8\x10\x4c\x60\x0e\xf4\x22\x5c\x3f\xd8\x73\x2f\x6f\x0e\x54\xed\x5d\x37\xf8\xd2\xad\x13\x9f\x46\x57\xef\xee\xc7\xff\xcc\xae\x59\xd0\xf9\x30\x7f\x9b\x9e\x9c\xde\xef\x89\xee\x68\x78\x72\x43\xce\x49\xb8\x77\xbe\x7b\x17\x0f\x4e\x3a\x17\x93\xe1\x7e\xfa\xe3\xf4\xa4\x36\xbb\x11\xb5\x32\xf6\x40\x70\x29\x39\x3c\x58\xca\x9a\x0e\x66\x9c\xcd\xc7\x3c\xb5\xa6\x6b\x4d\x76\xab\xd1\xe7\xe1\x1c\xe6\x21\x9d\x20\x23\x70\xd3\x01\xc6\x93\x18\xcf\x0f\xd1\x20\x26\xb3\xf7\x60\x88\xa1\x8a\x0e\xab\xbb\xbb\xbf\xbf\x47\x11\xd1\x6f\xdf\x4c\x72\xfd\x6b\x40\x1a\x02\xf7\xfa\x62\x62\x32\x50\x0c\x4f\xc0\xb0\x62\x2c\xe5\xca\x62\x61\xfc\x0d\x99\x60\x96\x93\x1b\x80\x91\xb8\x92\xde\x93\xc3\xda\x6e\x32\x73\xac\x91\xa1\xc9\xae\x57\x03\x5b\x86\x73\xad\x46\x5f\xbc\x08\x5a\xad\x69\xd0\xd3\xb4\x4f\x04\xdc\x07\x81\xf7\x0d\xda\xe7\x62\x8e\xae\xa9\x4c\x71\x4c\xef\xcd\x23\x2a\x21\x34\x48\x9f\x37\xe5\x82\x66\x8c\xfb\x24\x46\xe0\x0b\x9b\x4e\xb0\x74\x70\x89\x64\xb6\x76\xd8\xf0\xcd\x79\x4d\xc2\x2f\x31\x4e\x59\x92\x96\xfd\x82\x63\xd4\xb6\x82\x0f\x4d\x70\x9c\xc2\x81\x0d\x6f\xc8\x41\x70\x31\xda\x27\x01\x94\x12\x29\x71\xca\x72\x98\xe7\x55\xd0\x02\x56\xc7\x08\x07\x5a\xe6\xa6\xe3\x20\xf0\x02\x11\x07\x30\x70\x73\xa5\x5b\x30\x27\xc1\x27\xa2\xcf\xe0\x60\xe1\xf1\x80\xc7\x19\x1a\xb7\xfb\x23\x25\xa0\xb9\x50\xc0\x35\x80\x0b\x62\x08\x4b\x34\x25\x88\xb3\x78\x8e\x22\x3c\xd1\xa3\xfc\x0c\x56\x06\x60\xcc\xb5\x2b\x33\xbe\x72\x0d\x79\x59\x79\xf0\xea\x14\x11\x06\xd4\x69\xfd\xa3\xff\xca\xca\x02\x2f\xb6\x8e\x42\x92\x98\x04\x0a\x69\xcf\xd7\x74\x2c\xa4\xd1\x5b\x19\x15\x8a\x68\x08\x51\x27\x57\x8b\xf6\x81\x06\x6a\x13\x37\x99\xca\x0c\xa1\xe5\xed\x12\x9f\x16\x9c\x84\x5d\x16\x5e\xd2\x31\xa0\x84\x01\xd2\xa3\x27\xee\x36\x7f\x08\xcb\x2b\x6b\xb7\x1e\x62\x45\x14\x60\x71\xb5\x53\x8f\x1d\xb0\x62\x92\x34\x9d\xaa\x15\x68\x95\x66\x26\xf2\xda\xb2\xff\x02\x91\x7e\xaa\x14\x67\x0b\x43\xfa\xcc\xa7\x39\x2a\xa6\x87\x9a\x94\x1e\xac\x30\xef\x6b\xee\x8d\x2d\x3d\xad\x15\xab\x72\x78\x9d\xa3\x3e\x0e\x46\x4e\xeb\x0c\x46\xe8\x08\x86\xe8\x0b\x66\xc3\x67\x75\xb3\x74\x8b\x0b\x0c\xa5\x8b\x2c\xb0\xae\x4b\xc7\x13\x6d\xc7\xb9\x40\xd5\xc8\x69\x55\xd1\x31\x24\x00\x0d\xdf\xee\xbc\x08\x52\x07\x90\xba\x01\x91\xaf\x86\xd9\x07\x98\xfd\x9f\x84\xa9\xd6\x34\x6f\xb5\x9f\x84\xaa\xed\x19\x89\x3a\x78\xfe\x7a\x42\xfb\x07\x06\xe6\x2b\x21\xa3\xd7\x6b\xa1\xae\x65\xaa\x19\xa0\x27\xb8\x5b\x3c\x9c\x85\x67\x79\xc1\x18\xf4\x85\x82\x4b\x0d\xe0\x89\x7c\x34\x0b\xe8\x73\xbe\xf2\x6a\x73\x28\x70\x94\xec\xa1\x84\x78\x33\x87\xcb\xab\xaf\x64\x77\x04\x39\xd5\x82\xd3\x53\x98\x1c\xa2\x97\xb9\x2c\x98\x32\xe0\x19\xd7\x16\xd5\xbf\xd3\x1e\x44\x66\xf0\xc7\x3d\xf8\x2d\x2b\xeb\x39\x5d\x19\x88\x12\x47\x16\xc3\x4b\x37\x2f\x15\x16\x4a\x19\x47\xd6\xd3\x43\xe3\xca\x5e\x6d\x37\x63\x0e\x7e\x6a\x02\xfe\x1d\xf2\x86\x73\x18\xa3\xae\x99\xbc\x1a\x5e\x73\xee\xb4\xb4\x5d\xfc\x42\xa3\x1b\x63\x15\x44\x16\x2b\xb2\xf7\xf9\x8c\x0a\xd7\x23\x6f\x61\x79\x16\xd1\x8a\xe5\x65\xd8\x97\xf3\x04\xaf\x07\x89\x71\x48\xca\x71\x76\x0d\x7b\x10\x91\x60\xd4\xe7\xb3\x9c\xc2\x98\x40\x69\x71\x2b\x35\xa0\x5c\xb8\x64\x13\xa5\x4a\x14\xcd\xa1\xec\x4c\xeb\x05\xd9\x97\xce\x9e\xeb\x09\x32\x6c\xc9\xb2\xf4\x4b\x39\xc1\xd3\xcc\xca\xb4\x3f\xa6\x65\xf3\x69\xf8\x3a\x6f\x28\xcd\x75\xc4\xbc\xe4\xc3\x21\x14\x3f\x72\x4a\x41\x27\x48\x71\x93\x29\xa0\x04\xcf\x63\x8e\x43\x5d\x2c\x80\xeb\x97\x4b\x71\xdb\xa4\x84\x79\x02\x68\xc0\x5c\x5d\x67\x60\xca\x88\x58\x15\x30\x31\x7a\xc8\xb0\x5d\x9a\x8c\xaa\xa7\xf1\x5f\x64\xf8\xdb\x16\x7f\xc3\x4f\x36\x6a\x66\x89\x0a\x44\xfe\xe7\x43\x63\x71\x3b\x25\xa2\x6d\xbd\xe8\x40\x4e\x63\x45\x59\x5e\x27\x62\x7b\x07\x92\x59\x33\x0c\x37\x58\xba\x49\x46\x17\xb9\x2e\x0d\xc1\xad\x08\x9e\x6a\xcf\x90\xa5\x9a\x2b\x96\x6e\xef\xa8\xa4\xf0\x3c\x69\x28\x96\xd6\x1e\x41\x23\xaa\xb5\xce\xa0\x28\x01\x25\xc0\xa8\x58\xc6\x59\x51\x12\x92\x7e\x3a\xf4\xf3\xbc\xb9\xa3\x67\xe8\x9c\xb0\xb4\xe1\xe3\xd5\x14\x34\x07\xb1\x0a\x80\x74\x04\xeb\x3a\x48\x57\x3c\x4e\xab\x03\x33\xfd\x94\xe0\x3d\x41\xb1\x7d\x19\x51\x89\x4c\x86\xf6\x0c\x9a\xbc\x1c\x1a\x52\x15\xa5\x7d\xdd\x25\xf0\x8b\xa6\x81\xad\xd4\xa0\x9e\x33\xd5\x75\xd3\xb9\xed\xc7\x58\xd3\xe9\x99\xd2\x1d\x92\xe5\x50\x27\x92\xe8\x13\x55\xc7\x69\xbf\x20\xf2\xf0\x20\xf4\x35\x20\xef\x0c\xaa\x86\x23\x2c\x8c\xe4\xe5\xd4\x36\x27\x0e\x19\xf2\x95\x88\x75\x5a\xbc\x4a\x01\x76\x2e\x4d\xc6\x5c\xc6\x5a\x7a\x0c\x85\xd6\xd1\x33\x65\x8f\xfe\x75\x43\x2a\x88\xc9\xa2\x0f\xa1\x54\x8e\xd3\x31\xcb\xcb\x21\xb4\x54\x0f\xa1\x8d\x05\x91\x7e\x97\xb7\x44\x08\x2e\x64\x51\x10\xe9\xc5\x6e\xb6\x66\xd3\xd6\xd6\x2a\x3f\x26\x45\xcb\xe3\xdf\x2d\xd4\x69\x6a\x8c\x93\x05\x86\xc5\xce\x31\x6c\x9c\xeb\x8d\x67\xd0\x84\x75\x80\x17\xa4\x20\x3f\x19\x96\xde\x62\x26\x79\x65\x93\x48\x66\x52\x69\xad\x6b\xac\x34\xcc\x5a\x1d\x52\x04\x85\x2d\x84\xf5\x3b\x69\xfa\x32\x61\xdd\x9b\xbc\x85\x2a\xd9\x3c\x85\x72\x4b\xc2\x4e\x96\x1e\xc6\x12\x86\x00\x2c\xc3\xbb\x33\x99\xbd\x31\x29\x3b\x74\xeb\xde\x9e\x57\x35\x15\xf4\xdd\x52\x01\xbd\x5a\x42\xd7\xde\xee\xbb\xed\xde\x0d\x17\x37\x93\xef\xc1\xe5\x08\xd3\xd9\xfe\xb7\x09\xdf\x3f\x4e\x92\xe0\xfb\x27\xa2\xfa\xdf\xce\x3f\x7d\xed\x7d\x8c\x8f\xa6\x07\xc7\x83\xf6\x5f\xbc\xb9\x8c\xeb\xa9\x82\xf9\x5f\xca\x90\x52\xbf\xea\x55\x6b\x5e\x35\x97\x26\xa5\xaf\x14\xe5\x1a\xdf\x5f\xfc\xf1\xee\x7b\x7b\xaa\xc8\xe8\x03\x5c\xdf\xc5\x51\xef\x6a\x7a\xf1\xf1\x34\x14\xd3\x4e\x3d\x65\x57\x83\xde\xa7\xeb\x6f\x02\x47\x57\x3f\xae\x7e\x5a\x14\x2b\x8b\x71\xf2\xfa\xb9\xc3\x57\x17\x73\x31\x54\xce\x88\x0f\x90\x3d\x25\x11\x23\x04\xa2\x1e\xea\xcf\x75\xc7\xcf\xf6\xdd\x74\xa3\xe8\x0d\xea\x93\x40\xf7\xda\x80\x69\x30\x26\xd3\x63\x43\x01\xb8\x43\x06\xd5\xa4\x5d\x0e\xe2\x34\x2c\xc5\x86\x8d\xf6\x92\xb2\x64\x34\x34\x3a\xc2\x33\xca\xa5\xed\x9a\x98\x61\xae\x20\x28\x3f\xe7\x2c\xd0\x4e\xeb\x89\x9e\xda\xc6\xbb\x59\xb9\x8f\x4d\x0d\x9b\x49\x4a\x2c\x39\x18\xfc\x2a\x42\x85\x38\x2c\x11\x7c\xa8\x5b\x8d\x7f\xee\x7a\x35\x6f\xb7\x98\xff\x32\x99\x08\x24\x03\x34\x18\x79\x99\xfb\xa5\xdc\x07\x11\xe9\x60\x10\xd3\xbe\xaf\xff\x27\x94\x4c\x0d\xb1\xcd\x34\xd0\x2f\x21\x02\xff\xaf\xa4\xb1\x4e\xa4\xe8\xc3\x99\xdc\xe6\x69\x67\x51\xc4\x1e\xdf\x47\x5f\x23\xc2\x74\x3f\x42\x10\x93\x21\x68\x93\x4d\x30\x44\x0c\xa5\x6d\x78\x4a\xe3\x18\x49\x62\xdb\x12\x01\x17\xda\x7d\x23\x9b\x5f\x82\x17\x97\x3a\x95\x32\x5b\xba\xb9\xe1\xea\xe6\x86\x44\xba\xf1\x1a\xea\x50\x94\x80\x8b\x84\x11\xd5\x23\x01\x5e\x56\xe7\xd7\x45\xbf\x16\xc2\xa3\x89\x87\x10\x74\x50\x53\x93\xb0\x69\xa7\xfc\xc0\xc2\x2f\x44\xa5\x82\xe5\xbb\xdb\x3a\x34\x75\xc8\x00\xa7\xb1\x3a\xcb\xca\x5a\x08\x53\x6f\x50\x69\x5d\xd7\x1e\xab\x6b\x8b\xca\x09\x36\xb2\x66\xae\x21\x9c\x37\x8a\x21\xc6\x75\x63\xa2\x87\x47\xf3\x93\x70\x7b\x39\x7c\xef\x78\x3a\x2a\x02\x63\x65\x3e\x37\x76\x84\x37\x5e\x80\x89\xd5\xb7\xe0\x94\x36\x5c\x81\x75\xf8\x0d\xdf\x36\x0a\xff\x0f\x89\x52\x6b\xa5\x2f\x18\x00\x00")
 
 func webfilesIndexHtmlBytes() ([]byte, error) {
 	return bindataRead(
@@ -277,8 +277,8 @@ func webfilesIndexHtml() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "webfiles/index.html", size: 5777, mode: os.FileMode(0644), modTime: time.Unix(1633046391, 0)}
-	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xfe, 0x4a, 0x84, 0x35, 0xdf, 0x2c, 0xe8, 0x8a, 0xcc, 0xa1, 0x94, 0xb8, 0xa7, 0x5e, 0xfd, 0xfa, 0xc9, 0x54, 0x8, 0xa6, 0x9f, 0xaa, 0xa8, 0x40, 0xf9, 0x15, 0x63, 0x5a, 0x33, 0x12, 0xff, 0xc5}}
+	info := bindataFileInfo{name: "webfiles/index.html", size: 6191, mode: os.FileMode(0644), modTime: time.Unix(1791972136, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x65, 0x10, 0x64, 0xf9, 0x83, 0x7a, 0x8c, 0x26, 0x3, 0x9c, 0xcf, 0xb9, 0x28, 0x56, 0x41, 0x95, 0xed, 0x0, 0xa0, 0x7e, 0x2c, 0xf3, 0x2, 0x2e, 0x99, 0x2f, 0x70, 0x6c, 0x16, 0x68, 0xeb, 0xbf}}
 	return a, nil
 }
 
@@ -302,7 +302,7 @@ func webfilesResourceCss() (*asset, error) {
 	return a, nil
 }
 
-var _webfilesResourceHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x3a\x79\x6f\xdb\x46\xf6\xff\xfb\x53\xbc\xb0\x45\x49\xfd\x6a\x91\xb6\x8b\x1f\xb0\x4b\x8b\xea\x61\xa7\xd8\x34\x8e\x13\x54\x6e\xd0\x45\x51\x04\x23\xf2\x51\x9a\x98\x9a\xe1\xce\x0c\x15\x6b\x55\x7d\xf7\xc5\xcc\x50\xe2\xa5\xcb\x4e\x16\xc1\x02\xe1\x3f\x9e\xe3\x5d\xf3\xe6\xcd\xbb\xac\xc1\xb3\x7e\xff\xe4\x8a\xe7\x0b\x41\x27\x53\x05\x5e\xdc\x83\x8b\xb3\xf3\xbf\x9f\x82\x24\x19\xca\x94\x8b\x18\xfd\x98\xcf\x4e\x81\xb2\xd8\x3f\xf9\x31\xcb\xc0\x00\x4a\x10\x28\x51\xcc\x31\xf1\x4f\x46\x6f\xae\x7f\xef\xdf\xd0\x18\x99\xc4\xfe\x8b\x04\x99\xa2\x29\x45\x11\xc2\x4f\xa3\xeb\xfe\x77\xfd\xab\x8c\x14\x12\x4f\x7e\xe6\x02\xd2\x22\xcb\x20\xb3\x90\xa0\xf0\x41\x9d\x82\x44\x84\x9b\x17\x57\xcf\x6f\x47\xcf\x7d\xf5\xa0\x20\xa5\x19\x02\x65\xa0\xa6\x08\x02\x73\x0e\x82\x73\x05\x5c\xc0\x54\xa9\x5c\x86\x41\xc0\x73\x64\x92\x17\x5a\x2e\x2e\x26\x41\x49\x4d\x06\x0d\x66\xfd\xfe\xf0\x64\xf0\xec\xfa\xf5\xd5\xdd\x3f\xdf\x3c\x87\xa9\x9a\x65\xc3\x93\x81\xfe\x03\x19\x61\x93\xc8\x41\xe6\xe8\x05\x24\xc9\xf0\x04\x00\x60\x30\x43\x45\x20\x9e\x12\x21\x51\x45\xce\x6f\x77\x3f\xf7\xff\xe6\x94\x5b\x19\x65\xf7\x20\x30\x8b\x5c\x39\xe5\x42\xc5\x85\x02\x1a\x73\xe6\x82\x5a\xe4\x18\xb9\x74\x46\x26\x18\x3c\xf4\xed\xda\x54\x60\x1a\xb9\x1f\x70\xac\xcf\x21\x83\x94\xcc\xf5\xba\x4f\x63\xee\x06\x6d\x7a\x8e\x54\x8b\x0c\xe5\x14\x51\x39\x96\x98\xa3\x75\x12\xc4\x52\x3a\x96\x90\xb3\x21\x24\x33\xce\x73\x5f\xef\x7c\x0c\x15\x81\xa5\xe6\x0e\x12\xb2\x88\x6b\x9d\x17\x2c\xbf\x9f\x68\x33\x08\x58\x2e\xf8\x44\xa0\x94\x3f\x9c\xf9\x17\xfe\x59\x35\x37\x24\x01\x9e\x72\xc8\x35\x97\x18\x67\x28\x68\x7c\xef\x4f\xa8\x9a\x16\x63\x9f\xf2\xe0\xbd\x4c\x68\x9a\x66\x74\x1c\xe8\xbf\x73\x8a\x1f\x2a\x3e\x96\x91\xa2\x2a\xc3\xe1\xaf\xe5\xc1\x60\xb9\xf4\x5f\x52\x96\xac\x56\xc1\x72\xe9\xdf\x92\x19\xca\x9c\xc4\x58\x4d\x57\xab\x41\x60\x51\x4a\xfc\x67\xfd\x3e\xdc\x4d\xa9\x84\x8c\x4a\x05\x3c\x05\x19\x0b\x9a\x2b\x09\x0c\x31\x91\xa0\x38\x90\x4c\x72\xc8\xe8\xdc\x98\x25\x65\x09\x3e\xf8\xc6\x92\xb4\x8d\x19\x12\x16\x03\xa4\x88\xb7\x69\x8c\x3c\x50\x2e\x83\x84\x4a\x65\x87\xfe\x8c\x32\xff\xbd\x6c\x28\xe3\x3d\x99\x13\x4b\xc5\x19\x0e\x02\x3b\xda\x43\x3c\x4e\x34\x85\x04\x33\x3a\x17\x3e\x43\x15\xb0\x7c\x16\xcc\x0b\xb4\x5c\xe6\x05\x7e\x24\xfd\x63\xae\xfb\x23\x4f\x70\xd4\x55\xef\xe6\x01\x9f\x84\x49\x46\xc7\x47\xf2\x38\x19\x04\xd6\x59\x0c\xc6\x3c\x59\x0c\x4f\x06\x09\x9d\x03\x4d\x22\x67\xfd\xa2\xde\xe9\x75\x07\x8c\xb1\x47\x4e\x4e\x92\x84\xb2\x49\x78\x7e\x96\x3f\x38\xdb\xa0\x63\xce\x14\xa1\x0c\xc5\xfa\x15\x8e\x0b\xa5\x38\xd3\x40\x6e\x9c\x71\x89\x2e\x70\x16\x67\x34\xbe\x8f\x5c\x35\xa5\xd2\xcf\x89\x40\xa6\x6e\x79\x82\x3b\x86\x02\x67\x7c\x8e\x57\x53\x9a\x25\xde\x6e\x8c\xde\x25\x08\x54\x85\x60\x90\x92\x4c\xe2\xa5\x3b\xfc\x7d\x10\x58\xde\xa5\x20\xd3\x8b\xe1\x35\x2a\x42\x33\x39\x08\xa6\x17\x6b\xe9\x86\xfa\xed\x0c\x82\xf1\x30\x84\xea\x21\x8d\x45\x63\xdb\xbc\xb4\x06\x4c\xf9\xf6\xea\x80\xfa\x71\x6e\x60\xec\x4b\xd5\xdb\x06\xc4\xc2\x90\xd2\x2b\x2c\x97\xfe\x08\xb3\xf4\x37\x91\xad\x56\x0e\x28\x22\x26\xda\x33\xbf\x1b\x67\x84\xdd\x3b\xc3\xd7\x39\x32\x78\xc1\xe0\x16\x3f\xc0\x1d\x19\x0f\x02\x52\xa3\xb1\x5c\xd2\x14\x18\x82\x97\x21\x03\xff\x86\xb2\x7b\xd9\x83\x33\x58\xad\xcc\xee\xfa\x98\x66\xbd\x3a\xa4\x45\x14\x84\x4d\xb0\xc4\x59\xad\xea\xc2\xec\x10\x64\xb9\xf4\xef\xf0\x41\x69\xbf\x62\x45\x58\x2e\x51\x9f\xaa\x14\xc4\x8e\xed\xc9\x3a\x56\x90\x93\x45\xc6\x49\xe2\x0c\x1b\x72\xbd\xa5\xf8\x81\xb2\x09\x58\x51\x96\x4b\xff\x4a\xdb\xc1\x1d\xd5\x3a\x87\x6f\x83\xbe\x5e\x7a\x93\x15\xf2\x15\x65\x85\xb4\xcb\xcd\x53\x0c\x14\x19\xeb\x20\x5a\x67\x85\x73\x64\xea\x9d\xd9\xa8\xb1\xb3\xd0\x02\xe6\x7d\x9a\x46\x8e\xe4\x42\x61\xf2\x2b\xca\x37\x56\x2e\xe9\x67\xc8\x26\x6a\xda\x42\xb0\x48\x53\xf8\xc1\xda\xa7\x41\xf3\xdc\x0c\x53\x55\xe2\xb9\x3d\x67\x78\x83\xa9\x82\x72\x3e\x08\xd4\xf4\x18\x12\x26\xb1\xa8\xd1\xf8\xd5\x64\x24\x87\x88\x0c\x4b\x00\xd0\x77\x76\x2c\xab\x52\xf3\x5a\x7b\x9a\xd3\x9b\x6a\x0a\xdf\x7c\xf5\x70\x71\x7e\xf5\xff\x5d\x4a\x83\x40\x89\xd6\x4a\x5e\x6a\xee\xd9\x6e\xd5\x0d\xe8\xf0\x96\x43\xc9\x4f\x42\xca\x0b\x96\x40\xca\x05\xe8\x47\x0a\x39\x0a\xca\x93\x41\x40\x87\x83\x20\xdf\x76\x2f\x29\x17\xe6\x16\x75\xec\xe9\x30\xd9\x7a\x31\xc9\xf0\xeb\xa5\x40\x2d\xc0\xe6\x42\x56\x03\xca\xf2\x42\x95\x7e\x4e\x90\x84\x72\x07\xc2\x39\xc9\x0a\x34\xc4\xfd\xb5\x21\x42\x58\x1a\xcd\x7a\xe5\x25\x2e\x1c\x98\xf7\x67\x3c\xd1\xa1\xbc\x46\x52\xaf\x72\x16\xc6\x53\x6d\xa4\x91\x93\xf0\x6b\x9a\xa6\x5e\xef\xd2\x09\x86\x83\x40\x25\xfb\x04\xab\x5f\xf3\xa7\x92\xac\x4e\xf3\x49\xa2\x0d\x08\x84\xfa\xa9\x83\x65\xc2\x05\x9d\xbc\xd5\x52\xc0\x5f\x30\x41\xb5\x7e\xa9\xef\x0a\x91\x75\x5d\xc0\xc6\x5f\x92\xbd\x67\x87\x9a\xf4\xc6\xd2\x2c\xe9\x94\x8b\x19\x51\x0a\x93\x77\x4a\xbf\xe5\x2e\x85\xa6\xdd\x0d\x02\xf3\x88\x6b\x0b\x6b\xb7\xa2\x23\x1a\x2f\x54\x5e\x28\x67\x08\x83\x20\xa1\xf3\xd2\xeb\xd6\x86\xf5\x98\x99\x16\x2c\x56\x94\x33\xd0\x88\xbf\x49\xca\x26\xbf\x8c\x3c\x7d\xc5\x77\x26\x31\x37\x2a\xb5\x43\x1d\x8c\xef\x16\x39\xf6\x60\xb9\x61\xeb\x14\x12\x41\x2a\x41\x63\xe5\x5c\x6e\x56\xe7\x44\xc0\x78\xf1\x22\x81\xa8\x22\xef\xd1\xa4\x8e\xb8\xfe\xca\x38\x94\xf0\xb8\x98\x21\x53\xfe\x04\xd5\xf3\x0c\xf5\xf0\xa7\xc5\x8b\x44\x23\x5d\x36\x70\x56\xa7\x8d\xe9\x98\x48\x84\x08\xd6\x61\x5c\x4b\xc2\x26\x3f\xca\x1b\xca\x50\x6e\x4e\xd1\x6b\xe2\x30\xfc\xa0\x4b\x8c\x5d\x58\x9b\x13\xb7\xd0\xe4\x0c\x22\x8d\xbb\x41\x1b\xe1\xbf\x0a\x64\x31\xbe\x22\x2a\x9e\xa2\xf0\xb4\x2c\xa7\x25\xf5\x16\x2e\xcf\x63\x9e\xa0\x84\x08\xe4\x4c\x1f\xf1\x5d\xb9\xe0\xb5\xe0\xaa\xcb\xd3\xd7\x19\x19\x25\x7a\xf5\x2b\x6d\xc1\xeb\x04\x02\x1f\xd4\x88\xfe\x5b\x6b\xc1\xa9\x5d\x41\x83\x92\x4f\x19\x43\xf1\x8f\xbb\x57\x37\x2d\xa8\x26\x7e\x7d\xf6\xd7\x5f\xc0\x8a\x2c\xbb\x3c\xd9\x41\x91\xe4\x39\xb2\xc4\x26\x1a\x9b\x4c\x6d\x5c\xd0\x2c\xd1\x81\xcb\x5b\x76\x6e\x49\x6b\xd4\x28\x38\x34\xd3\xce\x95\xd4\xf6\xad\x0a\xb7\x6a\x30\x5c\x0f\xba\x66\xa0\x09\xe8\x74\x23\x04\xa7\x1e\x76\x9c\xad\x9c\x4a\xc0\x46\x6c\x71\x76\xea\x36\xac\x4f\x9a\x50\xeb\x47\x11\x6e\x46\x9b\xed\x55\xaf\xb4\xdd\x32\xf2\x6b\xdb\x79\x5b\x60\x4d\x37\x98\x85\xe0\x7e\xd5\x4e\x04\xdc\x8a\x83\x4e\xee\x67\x54\xa1\x90\x21\xfc\xe1\x7e\xbd\x74\x4f\xc1\x5d\xb9\x7f\xd6\x00\x88\x22\x61\xeb\x59\x89\x4d\x68\x08\xe1\x8f\x3f\x5b\x87\x2a\x84\x4e\x02\x47\x5c\xa8\x9f\x16\x21\x34\x22\xe0\x4e\xc8\x6b\x2a\xd0\x3c\xe2\x10\x5c\x22\xe3\x16\xa0\x54\x44\x28\x4d\x20\x04\xb7\xb5\x55\x8b\x14\x21\x14\x2c\xc1\x94\x32\x4c\x9a\x30\x75\x9f\xbd\x13\x28\xe6\x05\x53\x21\x9c\x55\xba\xad\xf6\x53\x9a\x59\x05\x35\xb5\xd0\x75\xaa\x5e\xc1\xe8\x03\x23\x8c\x9b\xd9\x1e\x67\xa4\x2f\xea\x9a\xa8\x16\x02\x04\x70\x7e\x66\xbe\x9e\xaf\xf8\x8b\xd1\xeb\x91\x71\x1b\x5e\xcf\x97\x79\x46\x95\xe7\xde\xb9\x3d\xff\x3d\xa7\xcc\x73\xc1\xdd\xef\xb5\x5a\xb1\xc4\x33\x91\x6e\x8f\x40\x4e\x82\xe3\x62\x12\x68\x0b\xfb\xfe\x3e\x72\xe0\x5b\x30\x18\xb5\x20\xd8\x62\xb7\x4d\x4f\x33\xad\x43\x4c\xbc\x36\x9f\xdb\x37\x9b\xda\x9d\xb3\x94\x4e\x0a\x81\x5e\x57\x12\x24\x3a\x34\x84\xe0\x22\x91\x6d\x53\x31\xd4\x29\xa3\xb3\x62\x16\xc2\x99\xff\x5d\x77\xd7\x96\x1e\x5b\x8d\xbd\x29\x78\xaf\xe6\x70\xf4\x67\x6b\x65\xca\x14\x8a\x18\x73\xc5\x85\xf4\x85\x76\xbb\x52\xf9\x85\x44\xcf\x4a\x0c\xd1\x70\x8b\xee\xaa\x73\x19\x0b\xf5\x5a\x57\x52\x53\xaf\xa5\x72\xf9\x04\x49\x64\xce\x99\x44\x23\xca\x7a\x72\x48\x98\x84\x33\xdc\x23\xcb\x9a\xcc\x31\xd2\x74\x68\xe8\xd0\xe2\xb9\xba\x30\xb0\xca\x35\xc5\x8a\xdb\xeb\xc2\xa9\x29\xb2\x03\x12\xeb\x8f\xa6\xb0\x81\xf2\xb5\xab\xd9\x66\xa3\xeb\xcf\x94\x9b\x95\xef\x81\x08\x1a\xa8\xfe\x8c\xe4\x5e\x95\x0d\xcc\x49\xb6\x8f\x58\x4d\x23\xfb\x81\xc0\x58\xd7\xc6\x89\x85\xfa\x65\xd4\xd3\xab\xae\x2d\xee\xc0\x7e\x89\x8b\x06\xf2\x4b\x5c\x1c\x8d\xdb\x40\x3c\x8c\x55\x77\x8b\x6d\x97\xb9\xed\x6b\xb8\xc8\x63\x10\x36\x79\xab\x11\x6c\x3f\xfc\xaa\x6b\x8a\x9b\xad\x2d\x66\x6a\xd6\x01\x33\x89\x7b\x2e\x26\xe6\x4c\xf2\x0c\xfd\x8c\x4f\x3c\x67\x7b\xdd\x63\x4b\x1e\xa7\x6b\x9b\xd0\xf0\x5f\x95\x28\x5b\x5d\x1a\xaa\x29\x4f\x3a\xae\x5f\x57\x48\x61\x2d\xf3\x94\x26\xda\x6d\x33\x37\x6d\xe0\x76\x17\xa2\x28\xb2\x26\xdc\x08\x91\xbb\x6c\xb4\x0d\xb9\x09\x91\x10\x81\xb7\x67\x33\x8a\x6c\x08\xed\xc1\xf7\xe0\x26\x28\x63\x17\xca\xa0\xda\x55\x75\x57\x0b\x5d\xf9\x74\x46\x69\x06\x7b\xe3\x4d\x2e\x50\xa9\x45\x5d\x25\x87\x22\xce\x2f\xa3\xd7\xb7\x65\x5e\x4c\xd3\x85\x67\xa6\x39\x11\x12\x4b\xcc\x53\x93\x1d\x9e\xc2\xc5\xfe\x40\x67\xab\xae\x3a\xe3\x5d\xb7\x60\x8e\x56\x7b\x18\xf0\x2c\xaa\x92\x01\xf8\xe6\x9b\xd2\xbd\xd4\x5e\x42\x03\x62\xd7\x3d\xd5\x8b\x1a\xdb\x0f\x33\xaa\xe8\xb0\xeb\x9d\x42\x67\xbb\xce\xac\x77\x0a\xe7\x5b\x5e\xc3\xea\x70\xd8\x8d\xf9\x2c\x2f\x14\x26\xdb\x8c\xb4\x51\xc6\x1f\xd2\x52\x79\x33\x2d\x37\xeb\x9b\x4e\x86\x47\x4e\x61\xdc\xdb\xed\xc8\x33\x54\x90\x3c\xc1\x44\xcf\x21\x84\xfe\xf9\x76\x2f\xa0\x6f\x8d\xfc\xd1\x35\xc9\x3f\x61\x00\xe3\x6d\xeb\xbd\xf5\x11\xfa\xe7\xf0\x7f\x95\x38\x8f\xa5\x3e\x3c\x40\xfd\x00\xe1\x12\xea\x6c\xcb\x65\xb6\x6d\xf9\xa4\x39\xd2\xfb\xf5\x5e\x70\xa7\x91\x67\xba\x6b\x9b\xff\xa8\x4c\x2f\x86\xcf\xcd\x42\xad\x83\x7a\x74\x4b\x6e\x4b\x3b\xce\x12\xdb\xde\x8c\x3b\xdc\x00\xeb\x36\xbf\x66\x28\x25\x99\x98\xc6\xd7\x2b\x3b\xdc\xd3\xf4\xea\x76\xe9\x90\x48\xce\x4c\x7f\xce\x8c\x1e\x83\x6b\x4f\xae\x71\x47\xf6\xdf\x35\x8f\xc0\x35\x95\x80\x46\xbd\xd2\x83\xc7\x60\xa6\x54\x48\x35\x42\x34\x42\xff\xac\x27\x20\x11\x1f\x25\x78\x46\x2a\x0a\x37\x64\x2f\x81\x56\xbb\xa6\xdb\x22\x6c\x5d\xa7\x6d\x10\x5a\x0b\x3a\xb2\x3d\xd8\x6c\x0d\x1a\x7a\x8d\xfe\xe0\xf3\xba\x39\x56\x48\x55\x97\xab\xc2\xfb\x84\x9d\xae\xaa\xcb\x65\x29\x97\x66\x06\xdb\x5a\x5a\x2d\x50\x6b\x53\xc7\x40\x96\xff\xe6\x3b\x02\xd2\xd8\xcb\x31\x80\x1b\xf3\xe8\x34\xe4\x12\xa2\x8e\x62\xb5\xb6\x8e\xa3\x08\x54\xf6\xb1\x69\xe5\x95\x1d\xba\x46\x77\xee\x60\xcf\xc0\x5a\xcc\x27\x68\x19\x58\x6b\x39\xdc\x31\xa8\x9e\xd1\xd1\xfd\x82\x27\x57\xed\x5a\x71\x07\x53\x15\x5b\x0a\x7f\xb6\x1a\xdc\x5c\xc0\xff\x64\x05\x5e\x9a\xce\x97\x02\xbc\x21\xcb\xa7\x29\xc0\xed\x6b\xfa\x2c\xf5\xb7\x65\xfd\xf1\xe5\xb7\xce\x19\x4d\xca\x9f\xbc\x25\x19\x44\xd0\xac\x01\xd6\xd5\xee\x8e\x12\x71\xfd\x1d\x5d\xc3\x97\x61\x22\xac\x58\xae\x23\xc7\xe1\x72\xd7\xda\x73\x1d\xb5\xfc\x7d\xcb\x94\x4b\x75\x44\x79\x6d\xc2\x4e\x1d\xdd\xae\x1c\xc6\x2c\xdb\x92\x15\xa2\x59\x38\x8c\xb7\xf1\xa1\x75\x5c\xb3\x78\x47\x67\x28\x15\x99\xe5\x47\x74\x11\x48\x97\x86\x5e\x7b\x04\x89\x66\x9f\xe0\xb3\xb7\x09\x3a\xd9\xcf\x97\x26\xc1\x7f\xb5\x49\xf0\xa4\x72\x75\x9d\x27\x3c\xaa\x58\x2d\x93\xdd\x2f\xb5\xea\xe7\xa9\x55\xd7\x99\x65\x50\xfe\x6c\x29\xb0\x3f\x85\xfc\x4f\x00\x00\x00\xff\xff\x38\xcb\xd6\x85\xf5\x29\x00\x00")
+var _webfilesResourceHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\x03\xed\x1b\x6b\x73\xdb\x36\xf2\xbb\x7f\x05\xc2\x76\x4a\xea\x2a\x91\xb6\x3b\x77\x73\x95\x25\xf5\x12\xdb\xb9\x4b\x9b\x38\x99\xda\xed\xf4\x26\x93\xc9\x40\x24\x24\x21\xa1\x08\x1e\x01\x2a\xf6\xb9\xea\x6f\xbf\x5d\x80\x14\x41\x52\x94\xe4\x24\x77\x9d\x9b\x29\x3f\xc4\x78\xec\x0b\x8b\xc5\xee\x62\xa1\x8c\x1e\x0d\x06\x47\xe7\x22\xbd\xcb\xf8\x7c\xa1\x88\x17\xf6\xc8\xe9\xf1\xc9\xb7\x7d\x22\x69\xcc\xe4\x4c\x64\x21\xf3\x43\xb1\xec\x13\x9e\x84\xfe\xd1\xe3\x38\x26\x1a\x50\x92\x8c\x49\x96\xad\x58\xe4\x1f\x5d\xbf\xba\xf8\x65\xf0\x9c\x87\x2c\x91\x6c\xf0\x2c\x62\x89\xe2\x33\xce\xb2\x21\x79\x72\x7d\x31\xf8\x66\x70\x1e\xd3\x5c\xb2\xa3\xa7\x22\x23\xb3\x1c\xf0\x63\x03\x49\x14\xbb\x55\xc0\x86\x31\xf2\xfc\xd9\xf9\xe5\xd5\xf5\xa5\xaf\x6e\x15\x99\xf1\x98\x01\x2f\xa2\x16\x0c\x58\xa4\x82\x64\x42\x28\x02\xb8\x0b\xa5\x52\x39\x0c\x02\x91\x02\xb6\xc8\x51\x2e\x91\xcd\x83\x82\x9a\x0c\x6a\xcc\x06\x83\xc9\xd1\xe8\xd1\xc5\xcb\xf3\x9b\x7f\xbe\xba\x04\xd4\x65\x0c\x7d\xfc\x43\x62\x9a\xcc\xc7\x0e\x4b\x1c\x1c\x60\x34\x9a\x1c\x11\xf8\x46\x4b\xa6\x28\x09\x17\x34\x93\x4c\x8d\x9d\x9f\x6e\x9e\x0e\xfe\xea\x14\x53\x31\x4f\xde\x83\x28\xf1\xd8\x95\x0b\x91\xa9\x30\x57\x84\x87\x22\x71\x89\xba\x4b\xd9\xd8\xe5\x4b\x3a\x67\xc1\xed\xc0\x8c\x2d\x32\x36\x1b\xbb\x1f\xd8\x14\xd7\x21\x83\x19\x5d\xe1\xb8\x0f\xff\xb8\x41\x93\x9e\x23\xd5\x1d\x00\x2d\x18\x53\x8e\x21\xe6\xa0\x4e\x82\x50\x4a\xc7\x10\x72\x36\x84\x64\x2c\x44\xea\xe3\xcc\xa7\x50\x81\x3d\x33\x9a\xdb\x4b\xc8\x20\x96\x3a\xcf\x93\xf4\xfd\x1c\xcd\x20\x48\xd2\x4c\xcc\x81\x8c\xfc\xdb\xb1\x7f\xea\x1f\x57\x7d\x4d\x92\x90\x8f\x59\x64\xc9\x25\x64\x4b\x96\xf1\xf0\xbd\x3f\xe7\x6a\x91\x4f\x7d\x2e\x82\x77\x32\xe2\xb3\x59\xcc\xa7\x01\xfe\x5d\x71\xf6\xa1\xe2\x63\x18\x29\xae\x62\x36\xf9\xb1\x58\x18\xb9\xbf\xf7\x7f\xe0\x49\xb4\x5e\x07\xd0\xba\xa2\x4b\x26\x53\x1a\xb2\xaa\xbb\x5e\x8f\x02\x83\x52\xe0\x83\xf9\x93\x9b\x05\x97\x60\x96\x12\xec\x6c\x46\x64\x98\xf1\x14\xec\x3b\x61\x2c\x92\x44\x09\x42\x63\x29\x60\x76\xa5\xcd\x12\x68\xb3\x5b\x5f\x5b\x12\xda\x98\x26\x61\x30\x88\xcc\xc2\x6d\x1a\xa3\xb7\x5c\x48\x10\x5f\x2a\xd3\xf4\x97\x3c\xf1\xdf\xc9\x9a\x32\xde\xd1\x15\x35\x54\x9c\xc9\x28\x30\xad\x1d\xc4\xc3\x08\x29\x44\x0c\x84\xca\xfc\x84\x29\xd8\x85\x65\xb0\xca\x99\xe1\x02\x8d\x4f\xa4\x7f\xc8\x76\x7f\xe2\x0a\x0e\xda\xea\x6e\x1e\xe4\xb3\x30\x81\xbf\x07\xf2\x38\x1a\x05\xc6\x59\x8c\xa6\x22\xba\x83\x3f\x11\x5f\x11\x1e\x8d\x9d\xf2\x44\xbd\xc5\x71\x87\x68\x63\x1f\x3b\x29\x8d\x22\x9e\xcc\x87\x27\xc7\xe9\xad\xb3\x0d\x1a\x7c\x82\xa2\x3c\x61\x59\x79\x0a\xa7\xb9\x52\x22\x41\x20\x37\x8c\x85\x64\x2e\x11\x49\x08\xbe\xed\xfd\xd8\x55\x60\x9d\x7e\x4a\x33\xf0\xab\x57\x22\x62\x1d\xcd\x8c\x2d\xc5\x8a\x9d\x2f\x78\x1c\x79\xdd\x18\xbd\x33\x38\x94\x2a\xcf\x12\x32\x03\xb3\x66\x67\xee\xe4\x97\x51\x60\x78\x17\x82\x2c\x4e\x27\x17\xe0\x0b\x79\x2c\x61\xc9\xa7\xa5\x74\x13\x3c\x3b\x00\x39\x19\x92\xea\x20\x4d\xb3\xda\xb4\x3e\x69\x35\x98\xe2\xec\xd9\x80\x78\x38\x37\x30\xe6\xa4\xe2\xb4\x06\x31\x30\xb4\xf0\x0a\x30\x7f\xcd\xe2\xd9\x4f\x59\xbc\x5e\xc3\x16\xd1\x6c\x8e\x9e\xf9\xed\x14\x1c\xf8\x7b\x67\xf2\x12\xa2\x00\x79\x96\x90\x2b\xf6\x81\xdc\xd0\xe9\x28\xa0\x16\x8d\xfb\x7b\x3e\x83\xf3\x4b\xbc\x18\x80\xfc\xe7\xe0\x8a\x64\x8f\x1c\x93\xf5\x5a\xcf\x96\xcb\xd4\xe3\xd5\x22\x0d\x62\x06\xe1\x81\x15\x38\x20\x9a\x25\x4c\x87\x20\x30\x73\x03\x86\x83\x7e\xc5\x88\x70\x7f\xcf\x70\x55\x85\x20\xa6\x6d\x56\xd6\xb2\x82\x94\xde\xc5\x82\x46\xce\xa4\x26\xd7\xcf\x60\xfa\x60\x3c\xc4\x88\x02\xf4\xcf\xd1\x0e\x6e\x38\xea\x9c\x7c\x1d\x0c\x70\xe8\x55\x9c\xcb\x17\x3c\xc9\xa5\x19\xae\xaf\x62\xa4\xe8\x14\x83\xa8\xcd\x8a\xad\xc0\x02\xde\xea\x09\x8b\x9d\x81\xce\xc8\x6a\xc0\x61\x91\x12\xa2\x1b\x8b\xc0\x95\xbe\x32\x72\x49\x1f\xf4\x37\x57\x8b\x06\x82\x41\x5a\x90\xbf\x19\xfb\xd4\x68\x9e\x1b\xb3\x99\x2a\xf0\xdc\x9e\x33\x79\x0e\x5d\x52\xf4\xc1\xe3\x2e\x0e\x21\xa1\x13\x0b\x8b\xc6\x8f\x3a\x23\xd9\x47\x64\x52\x00\x10\xdc\xb3\x43\x59\x15\x9a\x47\xed\x21\xa7\x57\x55\x97\x7c\xf5\xc5\xed\xe9\xc9\xf9\x9f\xbb\xf9\x5d\xe7\xcb\x25\xcd\xee\x1e\xc8\xea\xc9\x9d\x62\x12\x79\x5d\xf3\x7f\xb3\x36\x2e\x8c\x64\x8d\x91\xb4\xd8\x96\x47\xdd\xfb\x32\xe2\x93\x2b\x41\x0a\x0e\x92\xcc\x44\x9e\x44\xf0\x6f\x46\xd0\x03\x90\x14\xbc\x9f\x00\xc5\x71\x70\x64\xe9\xb6\x4d\x07\x48\x6d\x22\x18\xd8\x5a\x4c\xb6\xee\x7a\x34\xf9\xf2\x1e\x10\x7c\x6b\xb7\xd7\x23\x9e\xa4\x90\x13\x19\x27\x9a\xd1\x88\x0b\x87\x0c\x57\x34\xce\x99\x26\xee\x97\x56\x4e\x86\x85\x45\x96\x23\x3f\x30\xf0\x98\xab\xc1\x12\xfc\x12\xe4\x09\x16\x49\x1c\x15\xc9\x10\xd2\x31\x38\x01\x63\x27\x12\x17\xe0\xac\xbd\xde\x99\x13\xc0\x4a\x54\xb4\x4b\x30\xdb\x86\x3e\x97\x64\x36\xcd\x8f\x12\x0d\xdc\xc8\x10\xfd\x08\x31\x4c\x04\x10\xfc\x19\xa5\x20\xbf\x12\xf0\x25\xa5\x1b\x78\x9b\x67\x71\xdb\xbf\x6c\x9c\x31\xdd\xb9\x76\x62\x49\xaf\xcd\xd8\x90\x86\x1d\x5e\x52\x05\x1b\xfb\x56\xa1\xa3\xd8\x4b\x41\x1a\xdb\x26\xdd\x90\x64\xa8\xd3\xa7\xb1\xe3\x4a\x25\x32\x16\x11\x2a\x89\x4b\xbe\x36\xd8\x7a\xe4\x32\x09\x05\x46\x3f\x18\x74\xfb\x8d\x39\x7d\x0a\x70\x82\x4c\xf5\x79\x70\x1a\xa2\x9b\xf9\xb5\x99\x6d\xcb\x50\x3f\x25\xd0\x43\x7f\x66\x0d\x94\x1e\x16\x83\xbb\xc8\x15\x6c\xbe\x33\x01\x30\x18\x2e\x02\x90\xd5\xb4\xd3\x87\x59\x9e\x84\x8a\x43\xfc\x45\xc4\x9f\x24\x08\xff\xfd\xb5\x87\x06\x79\xa3\xef\x28\xda\x00\x4c\x13\xf3\x92\x1b\xb0\xa7\x1e\xb9\xdf\xb0\x75\xe0\xc6\x01\x91\x1f\x52\x0d\xe5\x9c\x6d\x46\x57\x34\x83\x65\x3c\x8b\xc8\xb8\x22\xef\xf1\xc8\x46\x2c\xbf\x22\x24\x47\x22\xcc\x97\xe0\xa6\x7d\xd8\xb9\xcb\x98\x61\xf3\x09\x10\x40\xa4\xb3\x1a\xce\xba\x5f\xeb\x4e\x29\xf0\x1f\x93\x32\xa3\x41\x49\x92\xf9\x63\x09\xfe\x90\xc9\xcd\x2a\x7a\x75\x9c\x84\x7d\xc0\xdb\x56\x17\xd6\x66\xc5\x0d\x34\xb9\x04\x14\xc0\xdd\xa0\x5d\xb3\x7f\xe5\x2c\x09\xd9\x0b\xaa\xc2\x05\xcb\x3c\x94\xa5\x5f\x50\x6f\xe0\x8a\x14\xec\x02\x76\x77\x0c\x54\x70\x89\x6f\x8b\x01\xaf\x01\x57\x6d\x1e\x6e\xe7\x58\x2b\xd1\xb3\xb7\xb4\x01\x8f\xb9\x14\x48\x8a\x7e\x15\xa0\x1d\x6b\x0b\x6a\x94\x7c\x9e\x40\xbe\xf5\x8f\x9b\x17\xcf\x1b\x50\x75\x7c\xbb\xf7\xeb\xaf\x24\x81\x1b\xeb\xd9\x51\x07\x45\x9a\x42\x16\x12\x99\x9c\x6b\x93\xb4\x4e\x73\xe8\x62\x0c\xf7\xee\x5b\xbb\x84\x1a\xd5\x0a\x1e\xea\x6e\x6b\x4b\xac\x79\xa3\xc2\xad\x1a\x1c\x96\x8d\xb6\x19\x20\x01\xcc\xbc\x86\xc4\xb1\x23\xb0\xb3\x95\x53\x01\x58\x0b\xb3\x4e\xa7\x6e\x87\x76\xa7\x0e\x55\x1e\x8a\xe1\xa6\xb5\x99\x5e\xf7\x0a\xdb\x2d\x92\xa0\x20\x20\x8f\xd3\x34\xe6\x60\x07\x78\xc3\x87\x3c\x19\x8e\x97\x4e\x5d\x09\x85\xd8\x05\x37\xfe\x18\x72\x46\x58\x20\xcb\x28\x1e\x19\x89\x57\x32\x9a\x90\x1f\x9f\x9e\x93\xbf\x7c\x7b\x7c\x4a\xbe\xbf\x7e\x79\x05\xc2\x82\xb5\xf5\xc9\x87\x05\x0f\x17\x04\x92\x5b\xb8\xa3\xc5\xe4\xef\x4c\x55\xe1\x8b\x48\xd8\x19\x59\x3f\xdc\xb0\x5d\xf1\xdd\xf7\x52\x24\x1a\xdd\x83\xe3\xd6\x87\xd8\x09\x4d\xfb\x4c\xea\x01\x1f\xfc\xe6\x25\x05\x98\xea\xe4\x8a\xb4\x79\x72\x63\x06\xa1\x45\xbc\x67\x09\x9a\x34\x5c\xce\x01\x73\xe1\x4b\x58\x1b\x04\xfd\xc0\xed\xf9\x12\x4b\x13\xde\x49\xcf\x5f\xd2\xd4\x22\xa4\x51\x76\x78\x01\x3d\xef\x17\x8a\xf0\x82\xdf\x4e\x82\x39\x78\x51\x24\x58\x8d\x1d\xeb\xb1\xdf\xdc\xa6\x5f\x68\xf4\x21\x0b\x36\xec\xca\x8c\x81\x8c\xc7\x63\x72\xbc\x8d\x39\x28\xc3\xac\x42\x87\xc7\xb3\x0e\xe1\x1a\xec\x5a\xea\x30\x17\x0d\xf4\x2a\x22\xac\xc3\x16\x62\x18\x9d\x1c\xf7\xc9\x00\xf4\xd2\x56\x72\xa7\x6e\x36\x84\x4d\xe3\xb5\x06\x7c\xb3\x7b\xf5\x28\x50\x4c\x25\x62\x19\xee\xaf\xeb\xba\x18\x90\x93\x37\x6d\x7d\x3d\xce\x32\x7a\xe7\x73\xa9\xff\x7a\x86\x5d\x6f\x9b\x48\x48\x9e\x47\xb7\x40\xdd\x33\x5c\x40\xb5\xee\xc0\xed\x91\xef\x0a\x21\x4b\x3e\x43\xec\x4b\xf6\x2c\x51\x1a\xb0\x4f\x4e\x8e\x7b\x6d\x05\x23\x6f\x50\xbf\x48\x0d\x21\x38\x18\xee\x36\xae\x95\x32\xb4\xa9\x81\x32\x41\x86\x3e\x01\x8d\x96\x7b\xb7\x85\xf6\x9a\x30\xb8\xec\x35\x59\x98\x63\xf7\x10\x2e\x27\xdd\xb4\x77\xd1\x78\x0d\xc8\x6f\x76\x5a\x57\xdd\x92\x1e\x2c\x2e\x64\x69\x4c\xb1\x92\x1b\x2a\xb9\x69\x1a\x5d\x42\xda\x28\x9d\x12\xae\x2d\x6f\x56\xcd\x54\x41\xbb\xe6\xe0\x30\x38\xfe\x9c\x33\xcb\xf9\xb3\x78\x48\xdc\x2f\x9a\x97\x3e\xb7\x72\xa1\x58\xc8\x59\x72\xc5\x32\xf0\xec\xaf\xdd\x2f\xef\x31\x6b\x5a\xbb\x6f\x2c\x00\xaa\xe8\xb0\x21\x7d\xb6\x71\x75\x80\xf4\xa6\xe1\xb5\xf3\x0c\x97\x75\x0d\x29\xfd\x93\x3b\x60\x6e\xdf\x76\x3a\x21\x2f\x78\xc6\xf4\x31\x04\x04\x2a\xc3\x06\xa0\x84\xbc\x54\x21\x01\x98\x6d\x4c\x59\x89\xfb\x90\xc0\x05\x84\xcd\x20\x82\x45\x75\x18\x3b\x85\xee\x04\x0a\xe1\xfa\xa2\x86\xe4\xb8\x52\x77\x35\x3f\xe3\xb1\x51\x50\x5d\x0b\xed\x1c\xd7\xcb\x13\x7e\x9b\xd0\x44\xe8\xde\x0e\x3f\x8b\x1b\x75\x41\x55\x03\x81\x04\x70\x3c\xf5\xd7\xf3\x95\x78\x76\xfd\xf2\x5a\xe7\x45\x5e\xaf\xf4\xec\x37\xe0\x88\xdf\x09\x9e\x78\x2e\x69\xb9\xdf\x7e\x4b\x36\x2b\xb5\xf7\xcc\xe9\xec\x16\xc8\x89\xd8\x34\x9f\x07\x18\x42\xbf\x83\xdb\x23\x64\xc9\x1a\xc3\xba\x93\x74\x9a\x65\xc5\x78\x89\x3a\x64\x91\xd7\xe4\x73\xf5\x6a\x53\xa7\x15\xc9\x8c\xcf\xf3\x8c\x79\x6d\x49\x18\xc5\xdc\x17\xb6\x18\x1a\x4d\x53\xd1\xd4\x79\xc2\x97\xf9\x12\xf6\xc8\xff\xa6\x3d\x6b\x0e\xd3\x56\x63\x6f\x39\xea\xda\x80\xa9\x8b\x72\x10\x1c\x70\x52\xb8\x28\xc0\x3d\x0e\xf3\x4a\xa9\x7c\x48\xad\x3d\x23\x31\x19\x4f\xb6\xe8\xae\x5a\x97\xb6\x50\x6f\x8b\x87\x2a\xd4\x6b\xa8\xb4\x43\xc6\x7e\x49\x64\x0a\x79\x08\xd3\xa2\x94\x9d\x7d\xc2\x44\x22\x61\x3b\x64\x29\xc9\x1c\x22\x4d\x8b\x06\xe6\xce\x9e\x8b\x45\x20\xa3\x5c\x5d\x98\x72\x7b\x6d\x38\x48\xb0\x92\x3d\x12\xe3\x87\x7e\x76\xb3\x48\x74\x35\x5d\x11\x01\x3f\x1d\xe5\x33\xb6\xe2\x22\xc7\xb4\xc7\x75\xdb\x6b\x2c\x3f\x5d\x85\xac\xdc\x14\x40\xd7\xb8\x34\xd2\x22\xb0\xf5\x5d\x7c\xf1\x83\xdc\xb1\x2c\x4e\x10\x3a\x83\x2d\xd2\x29\xe4\x8c\x67\x10\x7d\x43\x01\x67\x17\xae\xa2\x3a\x7d\x83\xe4\x72\x96\x89\xa5\x9e\x86\x8d\x20\x53\x06\x3e\x82\x61\x77\xb9\x93\x01\x6a\x02\xe4\xf0\x5b\x49\x61\xd7\x67\x80\xcb\xe5\x61\x6a\x5a\xdc\xa3\xf8\xec\xce\x6b\xa4\x9b\x7a\x56\x67\x02\x5e\xa9\xc0\x5e\x9f\x54\xec\xb6\x58\x8b\xfd\xad\x77\xce\x5a\x5b\x62\xc9\xb4\x9b\x62\x61\x8b\xfb\x57\x69\x85\x8f\xa1\x4d\x1e\x07\xda\x5e\xa0\x03\x1b\xdc\x57\x0d\x19\xfa\x07\xe3\xd6\x10\x0f\xc6\xd2\xb5\x84\x1a\xaa\x1e\xd9\x8f\x5f\x14\x41\x0c\x6a\xd1\x39\x00\xab\x56\xfc\x28\x90\x6b\x63\x87\xd2\xb0\x04\xb7\x06\xf6\x63\xdb\x81\xb8\x19\xa4\xb7\x7d\xb5\xa0\x7c\x08\xc2\xa6\x70\xa5\x85\xdb\x0d\xbf\xee\x36\xbe\x66\xa2\xbe\x19\xdf\x95\x4a\xe2\x07\x2e\x5c\x8a\x98\xf9\xb1\x98\x7b\xce\xf6\xc2\xa7\xa9\x79\x3a\x6d\x6f\xa8\x19\xb4\x13\xcf\x0a\xd0\x0e\xa2\x4c\x2d\x44\xd4\x4a\x36\xb0\x44\x3a\xb4\x8a\x39\x52\xe7\x57\xdb\xfc\x04\x3a\x12\x33\xab\x73\x57\xed\x09\x6b\x49\x59\x97\x73\x69\x42\x6e\x92\x32\xbc\x66\xec\x98\xd4\x57\x06\x48\xda\xf0\xf6\xe1\x46\x0c\x5a\xa4\x48\xe3\xf6\x25\xdb\xdb\xb8\xa2\xd8\xc4\xc8\xbf\x33\xc3\x01\xaf\xa3\xd4\x9d\xad\x92\x7d\x39\x4e\xc3\x45\x5a\x3e\xd1\x60\xf6\x75\xc1\xa5\x4f\x4e\x77\xa7\x56\xa6\xec\x6a\x33\xee\xda\x05\xbd\x34\xeb\x60\x90\x47\xe3\x2a\xfd\x24\x5f\x7d\x55\x44\x29\xeb\x24\xd4\x20\xba\xf6\xc9\xae\x13\x9a\xd7\x36\xad\x8a\x16\x3b\x58\x50\x6b\xda\x66\xd6\xeb\xb8\x54\xed\x4f\xf4\x20\xe0\xa5\x39\x64\x7a\xdb\x8c\xb4\x56\xc7\xdf\xa7\xa5\xb2\xec\x50\x8f\xd6\xbe\x7e\xbc\xf0\x68\x9f\x4c\x7b\xdd\xa9\x03\x66\x03\xd1\x47\x98\xe8\x09\x58\xe7\xe0\x64\xbb\x17\xc0\x5d\xa3\xaf\xdb\x26\xf9\x86\x8c\xc8\x74\xdb\x78\xaf\x5c\xc2\xe0\x84\xfc\xa9\x12\xe7\xa1\xd4\x27\x7b\xa8\xef\x21\x5c\x40\x1d\x6f\xd9\xcc\xa6\x2d\x1f\xd5\x5b\x38\x6f\xbf\x34\xb7\x9e\x09\xf5\xdb\xdd\xe6\xf7\x1a\x8b\xd3\xc9\xa5\x1e\xb0\xde\x67\x0f\x7e\xf0\xdb\xf2\xd8\x67\x88\x6d\x7f\xea\xdb\xff\xbc\xd6\x7e\xef\x5a\x42\xfe\x4b\xe7\xfa\x59\xed\x85\x69\x76\x3f\xa9\x6d\x79\x03\x84\x9b\x87\x48\xf4\xeb\x9f\x6e\x3d\x04\xd7\xac\x5c\xbf\xb1\x99\x1f\x83\x3c\x00\x57\xdf\x3d\x11\xf5\x1c\x1b\x0f\xc1\xd4\xf9\xe7\x35\x63\x5a\xe8\xa7\x3a\x19\x95\xd0\x7b\x08\x09\x2c\x3f\x94\x14\x9e\xd3\x9d\x04\x1a\x2f\x20\xed\x37\xc2\xc6\x76\x9a\x17\x42\x63\x41\x07\xbe\x0f\xd6\xdf\x06\x35\xbd\xda\x03\xe1\xa5\x6d\x8e\x15\x52\xf5\xcc\x55\xe1\x7d\xc6\xa7\xae\xea\x91\xca\x50\x2e\xcc\x6c\xdb\x4b\x55\x13\xd4\xd8\xd4\x21\x90\xc5\x8f\x88\x0e\x80\xd4\xf6\x72\x08\xe0\xc6\x3c\x5a\x2f\x72\x70\x09\x3a\x88\x55\x69\x1d\x07\x11\xa8\xec\x63\xf3\x3a\x56\x3c\x7a\xd5\x1e\xbc\xf6\x56\xa9\x8c\xc5\x7c\x86\x22\x95\xb1\x96\xfd\x35\xaa\xea\x18\x1d\x5c\xa1\xfa\xe8\x3a\x11\x2a\x6e\x6f\xaa\x62\x8a\x2f\xbf\x5b\xd5\x47\x6f\xc0\xff\x65\xcd\xa7\x30\x9d\x3f\x4a\x3e\x35\x59\x3e\x4f\xc9\xc7\x9c\xa6\xff\x69\xc5\xa7\x4c\x0c\x0d\xeb\x4f\xaf\xe2\x14\xef\x44\x92\x45\x10\x18\xca\xaa\xc9\xe6\x0e\x50\x5e\xd5\xf7\x54\x43\x0e\xae\x5d\x14\x61\x62\x58\xb1\x2c\x23\xc7\x01\xb7\x71\x6d\xcf\x36\x6a\xf1\xeb\xd9\x85\x90\xea\x80\xeb\xb5\x0e\x3b\x36\xba\x19\xd9\x8f\x59\x14\xc2\x2b\x44\x3d\xb0\x1f\x6f\xe3\x43\x6d\x5c\x3d\x88\xa5\x1a\x38\x2d\xcb\xf4\x80\x2a\x02\x6d\xd3\xc0\xb1\x07\x90\xa8\xd7\x09\x7e\xf7\x32\x41\x2b\xfb\xf9\xa3\x48\xf0\x5f\x2d\x12\x7c\xd4\x75\xb5\xcc\x13\x1e\x74\x59\x2d\x92\xdd\x3f\xee\xaa\xbf\xcf\x5d\xb5\xcc\x2c\x83\xe2\x47\xd1\x81\xf9\x8f\x16\xff\x01\xe8\x9f\xe8\x0a\x53\x32\x00\x00")
 
 func webfilesResourceHtmlBytes() ([]byte, error) {
 	return bindataRead(
@@ -317,12 +317,12 @@ func webfilesResourceHtml() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "webfiles/resource.html", size: 10741, mode: os.FileMode(0644), modTime: time.Unix(1634851404, 0)}
-	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xce, 0xaf, 0x66, 0xb4, 0x2b, 0xbd, 0x90, 0x8e, 0xee, 0x67, 0xd6, 0x6d, 0x3b, 0x85, 0x72, 0xf4, 0xbe, 0x8d, 0x82, 0x70, 0x3d, 0xb6, 0x23, 0x96, 0xd5, 0xae, 0xd7, 0xb, 0xd, 0x5a, 0xf9, 0x92}}
+	info := bindataFileInfo{name: "webfiles/resource.html", size: 12883, mode: os.FileMode(0644), modTime: time.Unix(1791971421, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x20, 0xca, 0x32, 0x4, 0xdc, 0xb7, 0xc4, 0x5a, 0x44, 0x5a, 0xe4, 0x3c, 0x8e, 0xc8, 0xe3, 0x25, 0x41, 0xb7, 0x7f, 0xbe, 0x28, 0x2c, 0xe6, 0x96, 0x1a, 0xa7, 0xfb, 0x9c, 0x8e, 0xe6, 0xf9, 0x48}}
 	return a, nil
 }
 
-var _webfilesSloopCss = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x56\x5b\x6f\xdb\x3a\x12\x7e\xb6\x7e\xc5\x20\xc1\x02\x69\x1a\xd9\xf2\x2d\x68\x64\xe4\xc1\x49\xdc\x6e\xda\x6e\xdb\xdc\x8a\xec\x16\x45\x41\x49\x63\x8a\x6b\x8a\xd4\x21\x29\x5b\x6e\x91\xff\x7e\x40\xc9\x52\x24\xc7\x69\x83\x03\xbf\xc8\xa3\x4f\x33\xdf\x7c\x73\x21\x7b\x87\x0e\x1c\xc2\xb9\x4c\xd7\x8a\xd1\xd8\xc0\x41\xf8\x0a\x06\x5e\xff\xe4\x08\x34\xe1\xa8\xe7\x52\x85\xd8\x0d\x65\x72\x04\x4c\x84\x5d\x8b\x9d\x72\x0e\x05\x56\x83\x42\x8d\x6a\x89\x51\x61\xbf\xf9\x72\x71\xef\x7e\x64\x21\x0a\x8d\xee\x65\x84\xc2\xb0\x39\x43\xe5\xc3\xd9\xcd\x85\x3b\x74\xcf\x39\xc9\x34\x5a\xe0\x5b\xa9\x60\x9e\x71\x0e\xbc\x04\x83\xc1\xdc\x1c\x81\x46\x84\x8f\x97\xe7\xb3\x4f\x37\xb3\xae\xc9\x0d\xcc\x19\x47\x60\x02\x4c\x8c\xa0\x30\x95\xa0\xa4\x34\x20\x15\xc4\xc6\xa4\xda\xef\xf5\x64\x8a\x42\xcb\xcc\x12\x94\x8a\xf6\x36\xde\x74\x6f\x2b\x5e\xcf\x71\x02\x19\xad\xe1\x97\xd3\x99\x4b\x61\xdc\x39\x49\x18\x5f\xfb\xa0\x89\xd0\xae\x46\xc5\xe6\x13\xe7\xc1\x71\xba\x7a\x49\xdd\x50\x0a\x43\x98\x40\x65\xd1\x31\xda\x2c\x7d\xe8\x7b\xde\xbf\x26\x4e\x67\xc5\x22\x13\xd7\xff\xe4\x12\xd5\x9c\xcb\x95\x0f\x3a\x54\x92\xf3\x89\xd3\x09\x48\xb8\xa0\x4a\x66\x22\x72\x43\xc9\xa5\xf2\x61\x15\x33\x83\x3a\x91\x0b\x2c\x42\xe8\x25\xb5\x7e\x7f\xba\x4c\x44\x98\xfb\xe0\xf6\xcb\xc8\x86\x85\x8b\x42\x84\x9a\xa3\x66\x3f\xd1\x87\xfe\x28\xcd\x0b\x44\x6c\x12\x7e\x04\x55\x16\x5b\xbc\x52\x12\x45\x4c\x50\x1f\xbc\x89\xd3\x49\x88\xa2\x4c\x94\xcf\x8f\x14\x63\x16\x45\x28\x26\x7f\x10\xa0\xa0\xf1\xcb\xe9\x6c\xc8\xef\xcf\xc6\xb3\x93\xb7\x5e\xf9\x8e\x51\x21\x15\xba\xa9\x64\xc2\xa0\x72\x71\x89\xc2\x68\x0b\x6e\x5b\x7c\x10\x52\x94\xc9\x76\x15\x96\xc5\x71\x03\xa2\x5c\x4e\x02\xe4\x16\x1f\xc9\x84\x09\x22\x8c\x1b\x10\x8d\x9c\x09\xf4\x21\x26\x82\x32\x41\x27\x0e\x00\xc0\xae\xf4\xbb\x31\x12\x93\x90\xb4\x20\x97\x29\x6d\xd9\x6d\xe2\xb6\x43\x3d\x0b\x30\x52\x72\xc3\xd2\x92\xb0\x66\x86\x49\xe1\xc3\x9c\xe5\x18\x59\x9d\x52\x12\x32\xb3\x2e\x45\x7b\x2c\x62\xbb\x7c\xb5\x2a\xc3\xb3\xd1\x60\x3c\xb0\x48\xa9\x22\x54\xae\x22\x11\xcb\xb4\x0f\x63\x4b\xb6\x13\xc8\xdc\xd5\x31\x89\xac\xea\x1e\x78\xd0\xf7\xd2\x1c\x14\x0d\xc8\x81\x77\x64\x7f\xdd\xf1\xab\x89\xd3\xb1\x79\xbb\x75\x19\x27\x8d\x96\xe8\x6f\x8a\xe4\x43\x7f\x90\xe6\xad\x12\x75\x12\x92\xd7\x1f\x8d\x8b\xd2\x5b\xcb\xa6\x2d\xc7\xad\xae\x74\xd7\x8f\x7d\xf9\xe0\x38\xfb\x86\x89\xb5\xdb\x14\xa1\xea\x19\xcb\xaf\x80\x44\x6c\xb9\x5f\xc9\xf8\xa3\x35\x06\xcd\xfe\xb2\xbe\x34\x97\x32\xe5\x38\x37\x82\x2c\x9f\x1f\x93\xe1\xa0\xf0\x5c\x7d\xed\xaa\x12\xb4\xb1\x36\x79\x92\xcc\xc8\xd2\x73\x98\x29\x85\xc2\x9c\x4b\x51\x0d\x43\xe9\xac\xf4\x6c\x07\x08\x39\x86\x8d\x17\x50\xbf\x79\x32\xbd\xb6\x97\xaa\xd8\x46\xa6\x55\xa6\x55\xd5\x2c\x7f\x1f\x0a\x89\x25\x67\x11\x04\x9c\x84\x8b\xc9\x93\x64\x5e\x34\xe5\xfb\x83\xd9\x70\x34\xf2\x1a\x3d\x72\xf1\xe6\x62\x36\x3b\xf9\xed\xcc\x6f\xab\xb8\xc3\x6d\xdd\x69\x55\x1e\x25\xe9\x7e\x5b\xd8\x66\x72\xcd\xe1\x19\x16\x96\x1d\xb3\xdc\x2e\x20\xf1\x39\x13\x0b\xf8\x05\x1b\x24\xb7\xf9\x53\x45\xd6\xf0\xf0\x04\xb9\x64\x9a\x19\x8c\x5e\x06\x8e\xad\x76\x8f\xd0\x62\x96\x76\xc0\x48\x68\xd8\x12\x77\xbb\x74\xba\x61\xa6\x8d\x4c\x7e\xa4\x4a\x52\x85\xba\x58\x38\x11\xd3\x29\x27\x6b\x1f\x98\x28\xc6\x28\xe0\xb2\xa8\xdc\x12\x95\x61\x21\xe1\x2e\xe1\x8c\x0a\x1f\x8c\x4c\x27\x4e\xc7\x36\x52\x65\x09\xb1\x5c\x08\x5b\x45\x7e\x70\x1c\xf2\xcd\x10\x45\xd1\x9c\xee\xfd\x08\x38\x11\x8b\xbd\xef\x3e\x99\x9b\xb2\xfd\x6d\x53\xa1\x1d\xc8\x4c\xf1\x83\x88\x18\xe2\xb3\x84\x50\xec\xa5\x82\x4e\xec\x0e\x3b\x1e\x1d\xb1\xaf\x67\x9f\xaf\x57\xde\x87\x77\x54\x4e\xa7\xd3\xe9\xa7\x9b\xbb\x78\x76\x47\xed\x63\xf1\xff\xc3\xf9\xf4\xbf\xd3\xe9\xf4\xfc\xd3\x7f\xf4\xeb\x13\x6b\xb8\x9a\xf1\xd9\xd5\xd7\xeb\xd1\xe0\xaf\xfb\x0f\xab\xab\xc5\xf4\x72\x9a\x5f\xdc\xdd\x45\xb9\xf9\x7c\xdc\xbb\x3e\xbb\x5a\x5c\xfd\x6f\x79\xc3\xde\x5c\xf6\xd2\x8f\xa3\x33\xf9\x6e\xd5\xbb\xff\xb2\x88\x47\xf7\x8c\x7e\x49\xf4\x1d\x8d\xbd\xe3\xc1\xf1\xf4\xff\xd7\x9a\xe6\xff\xbe\x5d\xdc\xdd\xc6\xfa\xdd\xe0\xb6\xa7\x2f\xf9\xcf\xe8\x56\xa7\xe3\xc1\xe2\xe6\xa6\xbf\xb2\x51\xce\xde\x5f\xdf\x8d\x67\x6a\xf1\x9e\x52\x7a\x7a\xfa\xaa\x79\x38\xc0\x30\xcd\xc1\x2b\x57\xd6\x83\xe3\x30\x91\x66\xe6\x9b\x59\xa7\x78\xba\x17\x11\x83\x86\x25\xe8\x72\x19\x12\xbe\xf7\xbd\x31\x6c\x03\xaf\xec\xb2\x5a\xbe\xee\x50\x61\xd2\x6e\x3b\xaf\xfb\x66\x6c\x6d\x5b\x5e\x83\xcc\x18\x29\x5a\xde\x86\xe3\x97\x38\xf3\x76\x38\xb3\x35\x6d\x13\x1b\xed\xf6\xf5\xe0\x38\xbd\x43\xb8\x95\x94\x72\x04\xbd\x62\x26\x8c\x41\x9b\x35\x67\x82\xda\x6b\x41\xb7\x34\xb5\x0f\xfd\xdd\xed\x05\x95\x78\xe5\xb8\x0d\xaa\xad\xf9\xd4\x45\xda\x74\xf2\x82\x16\x85\x86\x9b\xf6\xf1\xa4\x90\x13\x3b\x1b\x93\xe7\x7b\xbe\xde\xb5\xad\xf4\x37\x3b\x76\x43\xb8\x5c\x1a\xe5\x92\xad\x6c\x9b\x65\x5c\xec\x03\x80\x3a\x7c\x21\x72\x4b\x84\xea\x34\x2f\x40\x9c\x45\x9b\x13\xa1\xe6\x48\x02\x2d\x79\x66\x8a\xf3\x71\xfb\xdc\xed\x14\x52\xd9\xb5\x58\x52\xb0\x4f\x8f\x81\x3b\x81\x34\x46\x26\xdb\x87\x6e\xbd\xfc\xc2\x30\x9c\x38\x1d\x77\x85\xc1\x82\x19\xd7\x28\x22\xaa\x98\xdd\x91\xb6\xce\xb7\x2d\x4d\x92\x7e\x80\x73\xa9\xf0\x79\xae\xd5\x4c\xef\xed\x35\xdb\xa6\xb8\x6f\xd4\x47\x4b\xf9\xaf\x3e\x28\x1a\x94\xcb\xd5\xfa\xcc\x75\xef\x1f\xb0\x2e\x74\xf7\xc3\x18\xc3\x05\x46\xaf\x1b\x42\xef\xd0\xe5\x38\x20\xc1\x70\xdc\xfa\x70\x2e\xc3\x4c\xb7\x3e\xdb\xbe\x84\xa4\xf9\xce\x0f\xb7\x22\x36\x54\x6b\x65\x30\x97\x2a\xf1\xa1\x78\xe4\xc4\xe0\xfd\x81\x95\xc6\xee\x13\x37\xd1\x7f\x40\xfc\xf6\xed\x83\x9d\xce\x6b\x9b\x1d\x46\x50\x52\xd0\x70\xd8\x7b\x2c\x63\xb7\x48\xbd\xcc\xa8\x75\xd7\x1a\x6e\x6e\x86\xdb\xd0\x46\x06\xdb\xb7\xb3\x72\xd3\xff\x1d\x00\x00\xff\xff\x3a\xd5\xa1\xf2\xee\x0c\x00\x00")
+var _webfilesSloopCss = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\x03\x9d\x56\x6d\x6f\xdb\x38\x0c\xfe\xbc\xfc\x0a\xa1\xc5\x01\x5d\x57\x27\x8e\x13\x17\x6d\x82\x7e\xe8\x4b\xb6\x75\xef\x6d\x9a\xa1\x77\xc3\x30\xc8\xb6\x6c\xeb\x22\x5b\x3e\x49\x79\xeb\x61\xff\xfd\x28\xf9\xa5\xb6\x9b\x6c\xc5\x25\x40\xeb\xd0\x14\xf9\x90\x7c\x48\xaa\x77\xd8\x41\x87\xe8\x92\x67\x1b\x41\xa3\x58\xa1\x03\xff\x25\x72\xec\xfe\xe9\x11\x92\x98\x11\x19\x72\xe1\x93\xae\xcf\x93\x23\x44\x53\xbf\xab\x75\xcf\x19\x43\x46\x57\x22\x41\x24\x11\x4b\x12\x18\xf9\xf4\xcb\xd5\xbd\xf5\x81\xfa\x24\x95\xc4\xba\x0e\x48\xaa\x68\x48\x89\x18\xa1\x8b\xe9\x95\x35\xb0\x2e\x19\x5e\x48\xa2\x15\x5f\x73\x81\xc2\x05\x58\x61\xb9\x32\x52\x64\xad\xc0\x1f\x21\xe8\xc3\xf5\xe5\xe4\xd3\x74\xd2\x55\x6b\x85\x42\xca\x08\x38\x45\x2a\x26\xe0\x28\xe3\x48\x70\xae\x10\x9c\x8d\x95\xca\xe4\xa8\xd7\xe3\x19\x9c\xe6\x0b\x0d\x90\x8b\xa8\x57\x58\x93\xbd\x96\xbf\x5e\xa7\xe3\xf1\x60\x83\xfe\xed\xbc\x08\x79\xaa\xac\x10\x27\x94\x6d\x46\x10\x5f\x2a\x2d\xc0\x4f\xc3\x71\xe7\x67\xa7\xd3\x95\xcb\xc8\xf2\x41\x01\xd3\x94\x08\xad\x1d\x13\x1d\xe5\x08\xf5\x6d\xfb\x8f\x71\xe7\xc5\x8a\x06\x2a\xae\x7e\xf1\x25\x11\x21\xe3\x2b\xb0\xe3\x0b\xce\x18\x88\x3c\xec\xcf\x23\xc1\x17\x69\x00\x76\x18\x87\xc8\x57\x31\x55\x44\x26\x7c\x4e\x8c\x0b\xf0\xa0\xed\x3e\x58\x34\x0d\xc8\x7a\x84\xac\x7e\xee\x59\x51\x7f\x6e\x92\x50\x61\x94\xf4\x81\x80\xab\x61\xb6\x36\x1a\xb1\x4a\xd8\x11\x2a\xa3\x68\xe1\xca\x70\x10\xd0\x34\x1a\x21\x1b\x7e\x24\x58\x44\x34\xcd\x9f\x1f\x21\xc6\x34\x80\x7a\x8c\x7f\x93\x00\x03\x03\xec\x17\xe0\xf7\x27\xee\xe4\xf4\xb5\x9d\xbf\xa3\x51\xca\x05\xb1\x32\x4e\x53\x45\x84\x45\x96\x50\x5e\xa9\x95\x9b\x92\x11\x4a\x79\x9a\x07\xdb\x05\x72\x98\xe2\x58\x1e\x16\x16\xc3\x1e\x61\x5a\x3f\xe0\x09\x4d\x31\xa0\xf0\xb0\x24\x0c\x52\x0d\xe8\x70\x0a\x98\xa3\x71\x07\xc1\x67\x5b\xf8\xdd\x98\x60\x95\xe0\xcc\x80\x5b\x08\xa9\xd1\x15\x7e\x9b\xae\x76\x2a\x28\xce\x99\xa2\x59\x0e\x58\x52\x45\x39\xe4\x28\xa4\x6b\x12\xe8\x3c\x65\xd8\xa7\x6a\x93\x27\xed\xb1\x88\xcd\xf2\x55\x59\x19\x5c\x0c\x1d\xd7\xd1\x9a\x5c\x04\x10\xb8\xc0\x01\x5d\x40\xe0\xae\x06\x0b\xc2\xb5\x25\x63\x1c\xe8\xac\xdb\xf0\xed\xdb\xd9\x1a\x89\xc8\xc3\x07\xf6\x91\xfe\x76\xdd\x97\xa0\xa5\xe3\xb6\xaa\x32\x8e\x6b\x94\xe8\x17\x45\x82\x27\x07\x4e\xd6\x4b\x04\xb5\x5d\x57\x87\x5c\x53\x7a\x2d\x29\x68\xe9\x36\x58\x69\x6d\x1e\x79\x09\xf1\xef\x2b\x9a\x6e\xac\x7a\x12\x4a\xce\x68\x7c\x46\x25\xa0\xcb\xfd\x32\x8d\x3f\x1a\x6d\x50\xe7\x97\xb6\x25\x19\xe7\x19\x23\xa1\x4a\xf1\x72\x77\x9b\x0c\x1c\x63\xb9\x3c\x6d\x89\x5c\xa9\x90\xd6\x71\xe2\x85\xe2\xb9\x65\x28\x9d\x00\x16\x5d\x82\xf7\xa2\x19\x72\x63\xb9\x65\xdd\x40\x84\x11\xbf\xf6\x02\x55\x6f\x9e\x74\xaf\xe6\x52\xe9\x5b\xf1\xac\x8c\xb4\xac\x9a\xc6\x0f\x68\x74\x8a\x39\xa3\x01\xf2\x18\xd4\x7d\xfc\x24\x98\x67\x75\xf9\xbe\x33\x19\x0c\x87\x76\x8d\x23\x57\x27\x57\x93\xc9\xe9\x2f\x7b\xbe\x9d\xc5\x2d\x66\x2b\xa6\x95\x71\xe4\xa0\xfb\xcd\xc4\xd6\x83\xab\x37\xcf\xc0\x48\xb6\xf4\x72\xb3\x80\x78\x04\x64\x84\xb6\x47\x85\x26\xd3\xf1\x47\x02\x6f\xd0\xcf\x27\x9a\x4b\x0a\xad\x43\x82\xe7\x29\xc7\x3a\x77\x8f\xaa\xa6\x97\xb6\xa8\x61\x5f\xd1\x25\xd9\x6e\xb2\xd3\xf5\x17\x52\xf1\xe4\x47\x26\x78\x04\xe4\x34\x03\x27\xa0\x32\x63\x18\x78\x43\x53\xd3\x46\x1e\xe3\xa6\x72\xe0\x0d\x06\x18\x66\x16\x06\x13\xd0\xdd\x90\x17\x90\x6a\x22\x95\x12\x58\x0f\x66\x20\xb4\x8a\x0c\x7e\xf0\x37\x05\x73\x93\xa8\xb3\xbd\x1f\x40\x84\x74\xbe\xf7\x7d\x84\x43\x95\xd3\x5f\x93\x8a\xe8\x86\x5c\x08\x76\x10\x60\x85\x47\x34\xc1\x11\xe9\x65\x30\xb1\xf4\x0c\x3b\x1e\x1e\xd1\xaf\x17\x9f\x6f\x57\xf6\xfb\x37\x11\x3f\x87\xcf\xa7\xe9\x2c\x9e\xcc\x22\xfd\x68\x7e\xbf\xbf\x3c\xff\x13\xfe\x5d\x7e\xfa\x28\x5f\x9d\x6a\xc1\xcd\x84\x4d\x6e\xbe\xde\x0e\x9d\x7f\xee\xdf\xaf\x6e\xe6\xe7\xd7\xe7\xeb\xab\xd9\x2c\x58\xab\xcf\xc7\xbd\xdb\x8b\x9b\xf9\xcd\x5f\xcb\x29\x3d\xb9\xee\x65\x1f\x86\x17\xfc\xcd\xaa\x77\xff\x65\x1e\x0f\xef\x69\xf4\x25\x91\xb3\x28\xb6\x8f\x9d\xe3\xf3\xbf\x6f\x65\xb4\x7e\x7b\x37\x9f\xdd\xc5\xf2\x8d\x73\xd7\x93\xd7\xec\x21\xb8\x93\x99\xeb\xcc\xa7\xd3\xfe\x4a\x7b\xb9\x78\x77\x3b\x73\x27\x62\xfe\x2e\x8a\xa2\xb3\xb3\x97\xf5\xe5\x80\x80\x1c\xf0\xd7\x2d\x7a\x9f\xa6\xd9\x42\x7d\x53\x9b\x8c\x9c\xed\x41\x84\x44\xd1\x84\x58\x90\x56\xcc\xf6\xbe\xd7\x9a\xcd\xb1\x73\x96\x55\xe9\xeb\x0e\x04\x49\x9a\xb4\xb3\xbb\x27\xae\x96\xb5\xac\x7a\x0b\xa5\x78\xda\xb0\x36\x70\x9f\x63\xcc\xde\x62\x4c\xd7\xb4\x09\x6c\xb8\xdd\x16\x9c\xeb\x1d\xa2\x3b\x1e\x45\x70\x93\x90\x2b\xaa\xfc\x18\x49\xb5\x01\xda\x44\xfa\x5a\xd0\xcd\x45\xcd\xa5\xbf\x9d\x5e\xa8\x4c\x5e\xde\x6e\x4e\x39\x35\x9f\x9a\xc8\xea\x46\x9e\x41\x51\x54\x33\xd3\x5c\x4f\x82\x30\xac\x7b\x63\xbc\x9b\xf3\xd5\xac\x6d\x84\x5f\xcc\xd8\x02\x70\x3e\x34\xf2\x21\x5b\xca\x8a\x61\x6c\xe6\x01\x42\x95\x7b\x93\xe4\x46\x12\xca\x6d\x6e\x94\x60\x4e\x16\x1b\xa1\xc2\x88\x3d\x18\x9f\x0b\x65\xf6\x63\x7b\xef\xbe\x30\xa9\xd2\x63\x31\x87\xa0\x9f\x1e\x1d\xc3\x20\x06\x4a\x24\xed\xa5\x5b\x0d\x3f\xdf\xf7\xe1\x85\xb5\x22\xde\x9c\x2a\x4b\x09\x58\x84\x85\xcf\xee\x50\x6a\xe3\x6d\x49\x1d\xe4\xc8\x23\x70\x71\x25\xbb\xb1\x96\x3d\xbd\xb7\x57\xa7\x8d\xb9\x6f\x54\xab\x25\xff\x55\x2d\x8a\x1a\xe4\x7c\xb4\xee\xb8\xee\xfd\x0f\xd4\x26\xef\x23\x3f\x26\xfe\x9c\x04\xaf\x6a\x89\xde\x92\x97\x63\x0f\x7b\x03\xb7\x71\x30\xe4\x30\x26\x1b\xc7\xda\x97\x10\xe8\xf6\x6d\x07\x5b\x1e\x6b\x59\x6b\x44\x00\x42\x08\xda\x3c\x02\x23\xc9\xfd\x81\x4e\x8d\x9e\x27\x56\x22\x7f\xa3\xf1\xcb\xb7\x3f\x75\x77\xde\xea\xe8\x60\xa7\xe4\x10\x24\xf4\xe5\x63\x19\xbb\x26\xf4\x3c\xa2\xc6\x5d\x6b\x50\xdc\x0c\xdb\xaa\xb5\x08\xda\xb7\xb3\xea\x9e\x10\x63\x11\x4c\x84\xe0\x42\x36\x2e\x37\x60\x11\x9d\xec\xa8\xeb\x7e\x18\x86\x03\x3f\xa8\xad\xd3\x13\xf7\x78\x68\x0f\x5b\x1b\xd7\x29\x87\x42\x8a\x13\x22\xe1\x52\x49\xde\xc2\xbd\xf5\x23\xce\x76\x38\xaa\x9f\x2d\x06\xca\x7f\x87\xbe\x09\x81\x8e\x0d\x00\x00")
 
 func webfilesSloopCssBytes() ([]byte, error) {
 	return bindataRead(
@@ -337,12 +337,12 @@ func webfilesSloopCss() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "webfiles/sloop.css", size: 3310, mode: os.FileMode(0644), modTime: time.Unix(1633046391, 0)}
-	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xec, 0xcf, 0x62, 0x11, 0xed, 0x5f, 0xee, 0x2d, 0x20, 0x98, 0x33, 0xba, 0x7b, 0xbd, 0x9e, 0x96, 0x28, 0x97, 0x34, 0xff, 0x51, 0xd3, 0x95, 0x57, 0x5a, 0x33, 0xad, 0xfb, 0x41, 0xb2, 0xee, 0xe4}}
+	info := bindataFileInfo{name: "webfiles/sloop.css", size: 3470, mode: os.FileMode(0644), modTime: time.Unix(1791972136, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x72, 0x8c, 0xe8, 0xfe, 0x42, 0xb1, 0xec, 0x9c, 0x53, 0xd0, 0x4, 0x3f, 0x28, 0x7e, 0xd, 0xf2, 0xa6, 0xd7, 0x7f, 0x1f, 0xe, 0xe7, 0x10, 0xdc, 0x1f, 0x5, 0x66, 0x57, 0x59, 0x13, 0x8b, 0x2e}}
 	return a, nil
 }
 
-var _webfilesSloop_uiJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcc\x7c\x7d\x73\xdb\x36\xd2\xf8\xff\xfe\x14\x5b\x26\xf3\x98\x8a\x29\x4a\xb2\xe3\xd4\xb1\x2d\x3f\x63\xf9\xe5\x9a\xdf\x25\x69\x7f\x75\x7a\xd7\x8c\xc7\x53\x43\xe4\x4a\xc2\x99\x02\x74\x00\xf4\xd6\x9c\xbf\xfb\x33\x00\xf8\x02\x52\xa4\x5f\x72\x6d\xee\xdc\x99\x86\x02\x76\x17\xbb\x8b\xc5\x62\x77\x01\xb2\xf3\x6a\x0b\x5e\xc1\x19\x9f\xad\x05\x1d\x4f\x14\xf8\x51\x0b\x76\xbb\xbd\xb7\x01\x48\x92\xa0\x1c\x71\x11\x61\x18\xf1\x69\x00\x94\x45\xa1\x86\x3d\x4d\x12\x30\xb0\x12\x04\x4a\x14\x0b\x8c\x4d\xfb\xd5\x4f\xe7\xbf\xb6\xdf\xd3\x08\x99\xc4\xf6\xbb\x18\x99\xa2\x23\x8a\xe2\x10\x06\x57\xe7\xed\xbd\xf6\x59\x42\xe6\x12\x35\xe0\x25\x17\x30\x9a\x27\x09\x24\x16\x18\x14\xae\x54\x00\x12\x11\xde\xbf\x3b\xbb\xf8\x78\x75\x11\xaa\x95\x82\x11\x4d\x10\x28\x03\x35\x41\x10\x38\xe3\x20\x38\x57\xc0\x05\x4c\x94\x9a\xc9\xc3\x4e\x87\xcf\x90\x49\x3e\xd7\x0c\x72\x31\xee\xa4\xd4\x64\xa7\x32\x5e\x67\x6b\x2b\xe2\x4c\x2a\x98\x91\x04\x95\x42\xe8\xc3\x97\x2d\x00\x80\x21\x91\x78\x4e\xc4\xdd\x21\x5c\x7b\x2f\x76\x2f\xf6\x5e\xbf\xee\x7a\x01\x78\x2f\xf6\x06\xaf\x77\xf7\x77\xcd\xe3\xeb\xbd\xd7\x67\xfb\x17\xf6\xf1\x6c\xff\xcd\x9b\x53\xef\x26\xc8\x71\xdf\x6b\x25\x18\xe4\xf3\x83\xf3\x8b\x8b\xb7\x06\xec\x62\xff\xe2\xed\xa5\xa5\x73\x71\x76\x71\x79\xf9\xda\x3c\x5e\xee\x5d\xee\x5d\x5e\x64\xc8\x33\x41\xa7\x44\xac\x0d\xea\xc1\xe5\xe0\x6c\x30\x30\x40\x07\x07\x67\xdd\x73\x8b\x7a\xd0\x3b\xed\x9d\xf5\xcc\xe3\xfe\xc5\x41\xef\xf4\x2c\x43\x9d\xd0\xf1\x24\xc9\xc7\x1d\x5c\xbe\xe9\xbd\x39\x35\x60\xe7\xdd\x83\xef\xbf\x4f\xc7\x1d\x9c\x0d\x0e\x2c\xc9\xd3\xbd\xc1\xc5\xc1\x99\x67\x71\xf5\x9f\xf7\x62\xf0\xfa\xe0\xe2\xf4\xdc\x0b\xbc\x17\xa7\xe7\xa7\x07\x83\x37\xfa\x29\x26\xdf\xbf\xd9\xef\xea\xa7\xf3\xd7\x6f\xdf\x9c\x7e\x6f\x7a\x07\x67\xaf\x4f\x07\x25\xd4\xd3\xd7\xa7\x6f\xce\x77\x75\xe7\xdb\xde\xe0\xe2\xd2\x3e\x7d\x3f\xe8\x9d\x1a\x22\x07\xa7\x6f\x07\x6f\x0e\x32\x46\x25\x2e\x50\x50\xa5\x85\xdc\x7e\xf1\x7a\x70\x7e\xb0\xbf\xbf\x1d\xc0\xf6\x8b\x8b\xee\x45\xb7\xdb\x35\x8f\xe7\x07\xaf\x07\xaf\x07\xdb\x37\xc1\xd6\xfd\xd1\xd6\xd6\x56\xa7\x03\x7f\x49\xf8\x90\x24\x12\xde\xd3\x05\xc2\x0f\x28\x70\x2b\x41\x05\x8a\xcf\x4e\x57\x54\x06\x30\xe4\x4a\xf1\xa9\x7e\x3e\x32\xe0\x9f\x26\x28\x11\x46\x73\x16\x29\xca\x99\x84\x31\x2a\x88\x48\x92\x60\x0c\xcb\x09\x32\xcd\x81\xb1\x9e\x99\xe0\x33\x14\x8a\xa2\x04\x3e\x02\xa4\x6a\x82\x02\xc8\x8a\x4a\x20\x02\x21\x9a\x10\x36\xc6\xd8\x1d\xea\x5c\x90\xe5\xe5\x9c\x45\xee\x90\x59\x9b\x3b\xb4\x46\x8f\xf7\x40\x2a\x31\x8f\x94\x34\x14\x56\x1a\xf6\x2a\x22\x09\x06\xb0\xd6\xcf\x03\xc2\x62\x8b\x73\x2a\x04\x59\x43\xc4\x99\x22\x94\x51\x36\x86\x98\x28\x02\x02\x95\xa0\xb8\xc0\x18\x46\x82\x4f\x41\x26\x9c\xcf\xc0\x2c\x2b\x61\x08\x6a\xa0\x7c\x4c\x50\x74\x8a\x10\x53\x39\x4b\xc8\x1a\x63\xe0\x0c\x22\x14\x9a\x1e\x4c\xf9\x5c\x22\xd7\x22\x13\x16\xdb\x5f\x53\xbe\x40\xc0\x05\xb2\x94\x37\x35\xc1\x4f\x74\x8a\xae\x04\x31\x8e\x28\x43\xa3\xa5\x29\x59\xd1\xe9\x7c\x0a\xb1\x20\x4b\xcd\x9d\x9c\x91\x08\xf5\x08\xba\x73\x49\x59\xcc\x97\x21\xbc\x83\x98\xb3\x6d\x4d\x8a\xb2\x3b\x4d\x46\x4d\xa8\x04\x2a\x0d\x50\xc4\x85\xc0\x48\xc1\x92\xac\xb5\xa2\xe7\x52\x93\x51\x66\x9c\x05\x11\x12\xda\x40\x15\xc4\x1c\xa5\xa6\x20\x90\x24\xc9\x5a\xbb\x90\x99\xc1\x31\x03\xe8\x9f\xf4\x77\xca\xc6\x9a\xb4\x96\x63\x89\x54\xc4\x30\xa5\x4c\xb3\x26\x4d\x53\x2a\x3d\xc8\x88\x24\x7a\x80\x88\xcf\x93\x18\x66\x5c\x69\x8f\x63\x68\x46\x7a\xe5\xeb\x59\x1f\x26\x38\x95\xa1\x55\xa3\xc5\xfa\x40\x56\xbf\x06\xce\x8f\xcf\x56\x19\x7f\xd3\xe6\x11\x91\xc4\x08\xad\x89\x0e\x51\x2d\x11\x19\x0c\x89\x90\xa9\xfb\x10\x68\x9d\xcd\x80\x88\x0c\xfc\x2a\x85\xee\x43\x37\xdc\xb5\x94\xe4\x62\x0c\x02\x47\x28\x90\x45\x08\x23\x2e\x40\x20\x8b\x51\x18\x8d\x2e\xc6\xa9\x52\xe2\x3d\xc3\x94\x5c\x8c\x2d\xd6\x15\xd5\xd0\x4b\xdc\xd6\x06\x95\xea\x5f\x0f\xad\xd5\x6f\xfe\x5d\x52\xad\x71\xa3\x65\x49\xa6\x58\x98\xd6\x92\xc6\x6a\x02\x6d\x3b\xa3\x54\xc2\x94\x88\x31\x65\xe9\xbc\xda\x69\xb1\x13\x99\x49\x64\xdd\xa9\x15\x45\xd3\x86\xb6\xd1\x2a\x55\xdb\xd2\xb1\x4d\x4d\x6f\x68\x26\xc0\x0e\x9c\x8e\x9d\x0f\x6b\xd9\x9f\x92\x24\x19\x10\xf1\xc1\x8c\x79\xb4\x65\x1a\x53\x06\x32\x27\xab\xf8\xec\x10\x76\xbb\xd6\x29\x24\x38\x52\x87\xd0\xeb\x76\xcd\x8a\x4f\x6d\x8a\x33\x33\xe9\xda\x2f\x27\x9c\xc4\x57\x7f\xfb\xcb\xd1\xd6\x56\xb6\xa8\x81\x32\xaa\x67\x95\xfe\x8e\xe7\x74\x8a\x4c\xea\x85\xee\xb7\x52\xe2\xce\xa4\x42\x1f\x62\x1e\xcd\xa7\xc8\x54\x98\x3d\x5c\x24\x68\x7e\x47\x09\x45\xa6\xfe\xae\x35\x75\x54\xc1\xfb\xfc\x38\xde\x0f\xa8\xfd\xed\xd1\xd6\xfd\xd6\x56\x8c\x8a\xd0\x04\xe3\x4f\x9c\x27\x9f\xe8\xec\x9d\xfc\x1b\x95\x74\x98\x68\xd6\x47\x24\x91\x98\xaa\x80\xf1\x2b\x2e\xd4\xa5\x56\x42\x2e\x47\xce\xb3\x40\x35\x17\x0c\xac\x0a\xac\x65\x45\x7c\x3a\x23\x02\xaf\x14\xd9\xc0\x22\x01\x0c\x33\x4c\x3a\x02\x9f\x84\x77\x94\xc5\xf0\x5d\x1f\x86\xe6\x29\xeb\x73\x28\xa7\xd4\xfe\x4a\x59\x6c\xd1\x0d\xc0\xbd\x3b\x38\x09\xa5\x1e\x0b\xda\x30\xb4\x4f\x47\x9a\x9b\x12\x33\x1f\xb8\x54\x17\xc6\x75\x7c\x13\x8e\x86\xa1\x76\x5d\x09\x59\xcb\x30\x41\x36\xd6\x26\x0d\xa4\xda\xb6\xc9\xe5\x47\x32\xc5\x6f\xc2\x9f\xbf\xbd\x0d\x3b\x40\x42\x1d\xaa\xb4\xc2\x84\x6b\x07\x7f\x66\xd1\xfc\xa1\x6d\x35\xdc\xe9\xe9\x8f\xa6\x33\xc3\x53\x66\x06\xae\x39\xa7\x16\x9e\x5b\xc3\x8c\xac\x75\x93\xb6\xc2\xbd\xf0\x1f\x92\x33\x5f\xfb\xfb\xff\x3f\x47\xb1\xfe\x45\x24\xad\x23\x17\x28\x54\x13\x64\x7e\x21\xa9\x40\x39\x4f\x94\x2b\x4f\xfd\x62\x39\xca\xfb\xb5\x03\xea\xa7\x0e\x29\x43\x2f\x7a\x87\x94\xc5\x1f\xf4\xbe\x61\xe7\xdd\x97\x8b\xb1\xd3\x4b\x66\x33\x64\xf1\xe9\x0a\xab\x1d\x96\x9c\x5e\x12\x8a\xce\xb2\xd1\xee\xb5\x3a\xb6\x72\x69\xad\x97\xfb\x29\x15\x56\xf1\xf1\x38\x41\x90\x4b\xaa\xa2\x89\xd9\xc3\xcc\x16\x0c\x8a\xe7\xce\x3d\xd3\x4b\xd6\x43\xa3\x3b\x59\x68\x31\xed\x3d\x9b\x60\x74\x87\xc2\x2f\xa6\xdb\xcf\xd7\xf2\x18\xb3\x65\x3c\x58\xbf\x8b\x7d\xcf\x45\xf1\x5a\x61\x64\x50\x63\xe8\xf7\x41\x89\x39\xba\x4a\x34\xdb\x74\xa8\x37\xe3\x3a\x6a\x72\xb0\x3e\x4b\x88\x94\xda\xf2\x1c\xaa\x9a\x4b\xaf\xd5\xca\x89\xe8\xbf\x70\x4a\x66\x3e\x42\xff\x04\x30\x94\x6a\x9d\x60\x98\x49\xd7\x07\x6f\x98\x70\xcd\x48\xaa\x2d\xc0\x44\xe2\x9f\xc1\xc3\xc3\x4c\x30\xce\x30\xe7\x41\x3b\xb8\x4e\x07\xe0\x3c\xed\x8f\xe9\xc8\x6c\x63\x0a\xfe\xa9\xcd\x11\xa6\xa8\x26\x3c\x96\x26\xf4\x35\x91\x87\x20\x31\xe5\xb0\x20\xc9\x1c\x8b\xa9\x31\xb0\x96\x17\xdf\x00\xb8\x6b\xd1\x34\x84\x06\x03\xfa\xfd\x3e\x78\x02\xc7\xb8\xf2\xbe\x56\xfb\x29\xf6\xd7\x6a\xfd\xf9\x8a\x16\x8a\x6a\x21\x9f\x35\x64\x59\xc7\x8d\x9a\x70\x88\x7f\x33\x6d\xb8\xac\x7d\x1b\x65\x94\xad\x5e\x5b\x5c\x6e\x38\x65\xaf\x94\xea\x20\x0b\x80\xa1\xaf\xc3\xb9\x08\xa5\x3c\x65\xb1\xf6\xaa\x3f\xa7\x11\x8c\x2c\xbb\xb1\x0c\x7e\xb0\xd6\xce\x3c\x00\xed\xf0\x65\xa0\x53\x49\x85\x02\xe3\x73\x1b\x4b\xe7\xb3\xf0\x9d\x86\x75\xf5\x5d\x44\xef\xd6\x23\xeb\x18\x13\x7f\x51\x91\xdf\x0a\x85\x31\xe9\x6b\x1b\xde\x84\x3a\x92\x09\x4a\xf1\x47\x1b\x9c\xae\x1b\x47\xab\x79\xcc\xe4\x90\xd4\x3f\xfd\x56\x38\x23\x71\x4c\xd9\xd8\x6f\x0e\x2d\xb5\xe7\xcc\x08\x55\x92\x13\x4b\x4e\xa7\x31\x9f\xf8\xcc\x2f\x38\x77\x3d\xfa\x46\xf6\x52\x20\x0d\x4c\x5f\x3d\x9e\xab\x2f\xe8\xc3\xf5\x4d\xbd\x97\x2a\x34\x6d\xc9\x32\x94\xca\x6f\x85\x77\xb8\xf6\x63\x6d\x00\xb1\xdd\x70\x43\x64\x3a\xc5\x91\x66\x6b\x73\x46\x31\x93\xa3\x31\x73\x32\xc6\x76\x32\x54\x5c\xbb\xc2\x0f\x89\x38\xe3\x09\x17\x7f\x41\x56\xc8\x61\x74\xf9\xa3\x88\x29\x23\x89\xdf\x0a\x63\x3e\x25\x94\xf9\x86\x6e\x36\x61\x69\xd2\x1f\xe6\x89\xb3\xbb\x1d\xa6\x39\x6a\x03\xe1\xf7\x94\x21\x11\x05\xdd\xeb\x6e\x00\xbd\x00\x76\x6f\xaa\xb4\x33\x3a\x2e\xbf\xcd\x96\x54\x5e\x2d\x19\xed\x78\x2f\x9c\x52\xbb\xfb\x07\x90\xaa\xc0\x84\x66\xad\x40\xa3\x4f\xc9\xaa\xdc\x87\x2c\x6e\xdd\x54\x68\x3d\xdb\x44\x9f\x60\xa3\xb5\xdc\xc6\x7b\xe9\x58\x9a\xa5\x34\x38\xab\xba\x81\x32\x33\x8a\xcf\x02\x70\xc1\xe1\x15\xf8\x7b\xdd\x56\xab\x60\x4a\xf1\x59\x55\xa0\xe7\xad\x8f\x72\x3a\x62\x92\xb2\x1e\xbc\x2a\x64\x0b\x87\x59\xbe\x64\x02\x92\x66\x6b\x0f\x23\xce\x22\xa2\x42\x32\x9b\x25\x6b\xff\xfa\x26\x68\x30\x51\xe3\xbe\x65\xab\x61\xe1\x84\x23\x2e\x2e\x48\x34\xc9\xa0\x23\x6d\x65\x56\xbf\xe6\xd1\xaf\x98\xb4\x9f\x2e\x97\x56\xee\x1e\xb7\x9e\xbf\xea\x9f\xbb\xe2\x73\xaf\x29\x17\x63\x93\x20\x41\xdf\x31\xdd\x74\x12\x5b\xd7\xbd\x1b\xd8\x01\x7f\x17\x5e\xb9\x16\xe4\xf8\x5c\xb9\x18\xdb\x34\x09\xfa\x8e\xbe\x1b\xb1\x15\x9f\x65\x63\x77\x3a\x20\xd2\x42\xc5\x8a\x4a\x95\xa5\xc9\x79\x0a\x6d\x73\x7e\x81\x91\x40\xa2\x10\xa8\x0a\x21\x8b\xf5\x74\x18\xea\x78\x23\x1b\xdc\x6a\xeb\xc5\x04\x23\xe5\x7b\x2f\xe2\xbd\xdf\x26\x28\x4a\x5b\x9c\x5c\x8c\xd3\xfe\xd3\x24\xf1\xb7\x5f\x6d\xb7\x42\x3b\xbc\x5f\xda\xa2\x1f\xa0\x95\x93\x0a\x6d\x44\xec\x7b\x72\x31\x2e\x35\x2b\x25\x7c\x6f\x41\x71\x39\xe0\x2b\x2f\x80\xdb\x2e\x74\xe1\xe5\x97\x4c\xc1\xf7\xf6\xd9\xaa\xeb\xfe\xd6\x41\x8c\xf4\xee\x8a\x96\x60\x5b\xa7\xe2\xc8\x94\x17\xd8\xf8\x54\xdb\xab\x81\xd4\x7c\x69\x21\xb2\xc1\xc7\x99\x74\x9d\x0e\x9c\x59\x1d\xe9\x44\x7d\x2c\xc8\x6c\x62\x2a\x1a\x02\x67\x02\x25\x32\x45\xcc\x36\xcb\x47\x80\x24\x9a\xe4\x25\x00\x4b\x54\xf0\xf9\x4c\xbb\xe2\x71\xc1\x4d\xa1\x25\xaf\x24\x9e\x5e\x0a\xbe\x6b\xe7\x4e\x1f\x32\xa5\xc3\xf1\x4d\x15\xd5\x28\x48\x09\xc2\xe4\x88\x8b\xa9\xa7\x1d\x43\x00\xb4\xa5\x97\xc9\xad\x69\x4e\x88\x42\x5f\x2b\x2d\xb7\x25\x9f\xb6\x60\xa7\xb2\xc2\xef\x5b\xae\xf6\xb4\x54\xbe\xb5\x92\x9f\x0b\x77\x91\x99\x59\x1e\xcf\x98\xf8\xf4\xca\xc8\xc6\x85\xef\x0d\x79\xbc\xf6\x5a\x61\xa1\x00\xf3\x70\xe4\xa6\x7e\x72\x31\xd6\x81\x4a\xe6\xe4\x75\x62\x87\x4b\xf8\x40\x66\xfe\xf5\xb5\xf7\x91\x8b\x29\x49\xbc\xa0\x7b\x13\x5c\x7b\x7f\x27\x82\x51\x36\xf6\x82\x9e\xfe\x75\x21\x04\x17\x5e\xb0\x7b\x63\x3c\x6d\x91\xbb\x3c\x1c\xc7\x38\x81\x8f\x36\xa1\x1f\x67\xb6\xb4\xa9\xb3\x36\xdd\x1f\xea\xc6\xdf\xb8\x6d\x3d\x72\x22\x99\xb4\x5b\xf0\xa5\x6c\x55\xb6\x68\xe8\xc3\x97\xfb\xe6\x1d\xbc\xa0\xad\x91\x0b\xff\xf6\xa5\xe4\x8b\xb3\xa4\xb6\x5c\xab\x38\x2a\xc1\xa4\x09\x9d\xef\x30\x1e\x4a\x2e\x4a\x29\x6a\x4e\x8f\x48\x04\xcf\xec\x70\x8a\x4e\xd1\x3b\xdc\x80\x78\xea\xa8\xd9\xdf\x50\x20\xb9\xdb\xec\xb2\x03\x31\xf2\xd4\x31\x6c\x49\xe1\x2b\x86\x98\x72\xa9\x6c\xb1\xf5\x69\x03\xb9\x15\x96\x67\x0d\x17\xe3\x88\xcc\x13\xd5\x30\x08\x67\x92\x27\x18\x26\x7c\xec\x7b\xbf\xb0\x3b\xc6\x97\x0c\xf4\x24\x1c\x82\x07\x3b\xb0\x31\x35\x4f\x1e\xf9\x7e\xab\xf4\xd3\x9a\x4c\x5e\xe6\x73\xff\xc2\x30\x8c\x83\x8d\x56\x33\xd5\x87\x59\x54\xf3\x5b\xac\x3d\xd5\x2b\xe8\x75\xbb\xdd\x4d\x58\x64\xf1\x21\xf8\x35\xa0\xda\x09\xf8\x71\x18\xcf\x85\xf5\x66\x69\xeb\x26\x85\xac\x72\xa4\x07\xcc\xab\x48\x79\x66\xb2\xc9\x33\x58\x0f\x8a\x59\x29\xfb\x47\x8b\x93\x96\xf6\xd3\x72\x6a\x0c\x94\x35\x61\xce\xee\xc6\x1d\x53\xbb\xef\x68\x0f\x43\x51\x76\xd4\x7a\x86\x32\x1c\xf3\x5a\x0c\xb3\x69\xce\x12\xaa\x3e\xe1\x4a\x6b\x11\x4d\x0d\x29\x34\x4d\xbe\x07\x5e\xab\x11\x6b\xc9\x85\x54\x57\x85\x33\x4a\x83\xc3\x9c\x58\x60\x8e\xd3\x9a\xa5\x04\xc7\xb3\xa5\x54\x74\x92\xe7\xbb\xe3\x1f\x7a\x7a\xd3\xae\xe7\xe1\xde\x0d\xb9\xaa\xcc\xa5\xaa\xae\x35\x8b\xec\x2f\x0c\x43\xdc\x9c\xb0\xec\x2f\x35\x13\x1f\x6b\x26\xbf\x19\xcb\x1a\x4c\x1d\x8e\x36\x18\x7c\x82\xc1\xe4\xe3\xe7\xe7\x56\x25\x45\x37\x23\x08\x24\x92\xb3\xc3\x74\x06\x9b\xe1\x22\x3e\x67\xea\xb0\x98\xf4\xeb\xdd\x9b\x7a\xe0\xfb\xfa\x25\x99\xce\x59\xaa\xe1\x0d\x90\xfb\xf2\x6c\x55\x88\xa4\xc8\x76\xd1\x6e\x15\x38\xc6\x07\xf8\xc6\x31\x95\x2a\x7a\x06\x5a\x6f\x0e\x35\x89\xfa\x46\xb5\xb4\x5c\xd8\xce\x2a\xa5\x36\xf5\xab\x56\x4a\x4d\x6b\x89\x5c\xa5\xae\x98\xed\x7f\x94\x61\x25\xd2\xd1\x4d\x9b\x61\xc4\xba\xe7\x05\x35\x21\x67\xf7\x66\x13\x72\xb7\x16\xb2\xb7\x09\x29\x95\xe0\x77\xe8\x05\xe0\x89\xf1\x90\xf8\xdd\xc0\xfc\x17\xee\xb7\xdc\xe1\x4d\x6d\xc3\xf7\x66\x9c\xea\xa0\xa7\x9d\x7a\xfe\xa0\xa8\xaa\xb8\xd1\xbb\x15\xe5\xf9\x71\xd1\x83\x31\x91\x23\x6c\x39\x14\x8a\x48\x92\xf8\x95\xbc\xa1\x59\xc8\x2c\x8b\xcd\x8f\xb4\xcb\x2a\xc9\xa3\xd2\x94\xa0\x13\x91\x42\x29\xe1\xf8\x73\x65\xec\xd5\xc9\xb8\x99\xed\xfc\xfb\x62\x16\x34\x1d\x49\x37\x0b\x55\x79\xbd\x3b\x3f\xe7\x32\xbf\xcb\x59\x83\x8d\x2e\x37\x55\x12\xd3\x85\x57\xaf\x62\x43\x24\x1b\xb8\x34\x6c\x5d\x75\x3e\x1d\x5b\xaf\x12\xce\x7c\x2f\x3f\xf4\xf5\x82\x9a\x83\x27\x48\x7d\xf4\xf5\x2a\x80\xf5\x4d\xba\x73\x68\x0c\x5f\x4d\xa8\x74\xbd\xba\x0e\x28\x9d\x24\x90\xb2\x05\x0a\xe5\xaf\x5a\x70\xec\xe6\x86\x69\x2d\x40\x9b\x1f\xfc\xeb\x5f\x0d\x18\x27\xb5\x18\xbd\x9b\x56\x35\x26\x2c\xc5\x2d\xf9\x71\xec\x90\x08\xe0\x73\xa5\xb3\x96\x21\x9f\xb3\x58\xc2\xca\x51\x5c\x1a\xce\x6a\x76\xd7\x70\x5c\xeb\x05\x0c\x67\x6b\x38\xa9\x5f\xf8\x5f\xcb\x84\xe2\xb3\x4d\x36\xca\xa4\xb4\xb7\xaa\xb1\x76\xc7\xce\x5f\x7e\x59\xdd\x43\xb7\x75\x5b\x09\xc1\xd2\x43\xfa\x72\x1e\x9e\x2b\xb4\x0c\x6b\x6b\x98\x0d\x87\x92\x75\x51\xb7\xbd\xe3\x60\x8c\xec\x57\x6b\x01\xc6\x6f\x85\x33\x32\xc6\x5f\x37\xf7\x1d\x07\xfc\x73\x15\xfc\xf3\x26\xf8\x8c\x4b\x53\x12\xce\xd6\x46\x36\x52\x90\x13\x69\x55\x63\xca\xf2\xd3\x7d\x5e\x97\x71\xb3\x74\x2f\xcc\x92\x55\xaf\x55\xd8\xb9\xde\x08\x4b\x76\x5e\x3a\xd9\x7b\x96\x66\x8a\x15\x6b\x56\x42\x3a\x6d\x23\x9a\x24\x5e\x90\x15\x6e\xc2\x98\x08\x73\xd6\x54\x9d\x2e\x2b\x59\xb6\x1d\xf0\x19\x89\xa8\x5a\x7b\x01\xf4\x5a\x1b\xc2\x15\xcc\x27\x48\x2a\xab\xf4\xcf\xe2\xfe\x89\xc5\xa6\x47\xc5\xe9\x3e\x24\xce\x86\xcf\xf9\x7a\x69\x32\x06\x26\x6a\x9a\xf8\x63\x54\x4e\x2e\x7f\x66\x4b\x22\xfe\x86\xdd\xd5\xc7\x9a\x8a\xaa\x04\x75\xfc\xdf\x1c\x97\x69\x15\x1c\xa6\x65\xea\x7a\x08\x9d\x37\x9a\xfb\x13\x1a\x2c\xff\x51\x0f\xab\xf3\xd8\xc3\x6c\xfd\x6e\x06\x68\xa5\x96\x56\x83\x3a\xa3\x84\x46\x77\xcd\xaa\x94\x13\xbe\x3c\x77\x34\xa9\x57\x59\x1c\xe4\x0b\x33\x80\xd4\x95\x43\xba\x98\xc0\x26\x27\xef\x98\x9a\x53\x45\x17\x98\xac\x61\x3b\xde\xd6\x64\xe6\x49\x0c\x43\x5b\x2b\xda\x9e\x20\x51\x53\x32\xdb\x06\xb4\x27\x3d\xd0\x86\xe1\x5c\x99\xcb\x2b\xcb\x09\x51\xe6\x1e\x95\x0d\x73\x33\x82\x1a\xcd\x8c\x68\xb6\x25\x99\xdd\xfc\x49\xd6\x06\x51\x0f\x91\xe6\x51\xf9\x4d\x93\x94\x74\x08\x1f\xb9\x02\x39\x17\x08\xcb\xc9\x1a\xda\xf0\x2e\xbd\x4a\x94\x12\x8e\xf7\x52\x8a\x36\x9e\xd2\xf9\x97\x76\xd7\xc9\x1a\x12\x7a\xa7\xd9\x25\x2a\xac\x71\x10\xa9\x04\x7f\x92\x7f\xd0\x6e\x50\xc7\xaf\x4c\x9d\x65\x35\xdc\x8a\x53\x38\xda\x80\x4f\xe3\xf4\x77\x2c\xc6\x15\xf4\x35\xba\xc4\x77\xcc\xae\x51\x9d\x6f\x9d\x2a\x25\xe8\x70\xae\xd0\xf7\xa8\x86\xf1\xaa\x2b\xd1\x5e\xd8\xa2\xf2\xc7\x3c\xa5\x2a\x32\xd9\x6b\x97\xfa\x4d\x25\x1f\x2b\xfb\x83\xd0\x32\x9e\x9e\xe1\xb5\xf2\xd0\xc4\xf5\xa9\x25\x8f\xe1\x08\x5a\xe1\xe8\xeb\x1c\x8d\x23\x83\xbd\x27\xd1\x72\x5d\xe9\x86\xc8\x69\xdd\xb3\x36\x83\xd4\xe8\x87\x50\x25\xb8\xb9\x18\x1f\x5e\xd6\x4f\x5d\xd2\x8f\xf8\x8f\x34\x51\x75\xb9\x31\x4d\x0d\xd5\x0c\x17\x0e\xab\x6c\xdd\x57\x14\xf1\xd4\x79\x7f\xfe\xec\xd4\x1d\x79\x95\xa6\x28\x3f\xcb\x6a\x35\xed\x78\x4d\xec\x84\x46\x61\xd0\x87\x1a\x13\x37\x5d\x5e\xfd\x5e\xb3\x59\x3f\x7a\x68\x2f\xcd\x81\xb2\x0d\xe2\x07\xbb\xf4\xb3\xcd\x21\xb5\x1f\x97\xe9\x6f\xb9\xfd\x3e\x7b\xb9\xa5\x9e\xa4\x6e\x29\x7c\x53\x17\xf2\x64\x53\x7a\x86\x05\xfd\x9b\xa1\xc5\x1f\xbc\x17\xd6\x6c\x1b\x95\xab\x33\x7f\xda\xe6\xb1\xfa\x89\xeb\xf4\x78\xa7\x5e\xb1\xab\xea\xc2\x30\x65\xbe\xf4\x30\xae\x01\xc7\x74\xd7\xe1\x4d\xb2\x63\xb8\x06\x44\xdb\x5f\x87\xa9\xa1\xac\x26\x9e\x68\x6c\x69\x31\xad\x4c\x69\x41\x44\x7a\x69\x6b\xc0\x79\xb2\x99\xae\xd4\x73\x45\x63\xaf\x5a\x2b\xf2\x18\x8f\xd2\x79\xe9\xf7\xa1\x5b\x7b\x86\x90\x8f\x53\x5c\xc0\x74\xfb\x6b\x13\xb3\x0d\x44\x9d\x6a\x3f\x58\xea\x7e\x74\x5b\x7a\x78\xa3\xc8\xc2\xc2\x4c\xbb\x01\x34\xf0\x73\xe8\xf2\xf5\xe0\xfe\xd0\x64\x47\x81\xb5\xb4\x36\xec\x97\xed\x24\x48\xcd\x69\x07\x7a\xdd\x27\x6d\xea\xa9\x95\x04\x99\x39\xd5\x20\xfe\x31\xde\xdb\xaa\xe4\x3f\xe6\xbc\xff\x6b\x17\xe7\x73\xa6\x7b\xa7\x61\xba\xdb\xbd\x6e\xc3\x7c\xb6\x9b\x66\xf3\x49\xce\xb9\x52\x0f\xdb\xdc\x83\x63\xf7\x14\x93\x24\xc9\xcf\x26\x77\x30\x57\x7d\xaa\xc7\x1c\xa1\xc0\x78\x1e\xa1\xef\x8b\x00\x92\x00\x68\x00\xa4\x55\x3e\xbb\xa8\x9e\x94\x24\xce\x21\xc5\x51\x19\x2a\xdd\x78\x52\xc0\xa2\xd2\xde\xbb\xa9\x07\x3c\xe3\xb1\x29\x32\xbb\xc7\x20\x2e\x56\x03\xfd\x2c\x09\xa8\x5e\xff\xb9\x76\xe9\xde\x6c\x94\xd3\x6f\x8f\x95\x38\xd9\x4c\x1c\x8f\x55\x7c\x02\xc7\xc3\x13\x78\xf9\xa5\x18\xbb\x7b\x73\x0f\xc7\x9d\xe1\x09\x1c\x77\x54\xfc\x54\xa4\xdd\x12\xd2\xa6\x97\xc9\xb0\xc0\x4c\x72\xdf\x33\x81\xc7\xe1\xcb\x2f\x25\xb9\xee\xbd\x93\xa2\x45\x93\xbd\x7f\x90\x8f\x8e\x12\x27\xb7\xb0\x03\xc2\xda\x46\x00\x9e\xe7\xde\x2a\x51\xc4\xde\x8e\xbf\x3d\x36\x4f\x27\xa0\x75\x60\xf8\xb0\x36\x61\x39\xd5\xbf\x75\xce\x2c\xe1\x0a\xd1\x69\xcb\x0e\x5f\xd2\x16\x3d\x16\xbc\xfc\x52\x18\x94\x16\xd7\xd2\xbd\x2d\x9d\xd8\xdf\x7e\x24\xda\xe5\x1e\x0f\x4f\x5e\x7e\x89\x6d\x58\x6a\xa4\x38\x1e\x8a\x4e\x21\xc4\x5f\x4d\x96\x90\x02\xe9\x54\xa1\x06\xe6\x63\x91\x2b\xa4\x80\x79\xc2\x90\x41\x83\x03\xfe\xf2\x8b\x61\xe7\xde\x69\x18\x71\x31\x25\xea\x9c\x28\x53\x15\xc8\xce\x34\x5b\xf7\xd0\xae\xeb\x44\x16\xb7\xee\x6f\xab\xcb\xab\xa6\x06\x12\x57\x4e\x5d\x6e\x8f\x63\xba\x00\x1a\xf7\x3d\x45\xd9\xba\x9d\x15\x92\x4f\x1e\xd2\xc4\x2d\xec\xe4\x8c\xde\x3e\xa0\x8d\x12\xdc\x13\x34\x52\xc1\x30\x2d\x35\xb2\xea\x7d\xb1\x75\x7f\xdc\x89\xe9\x42\xcf\x5f\x45\xe6\xf2\xc6\x10\xbb\xb7\x88\xe3\xd0\xee\x93\x35\x37\xf9\xff\x6d\x35\xfc\x54\xbe\x77\x4e\x54\xdd\x2c\x59\xce\x21\x65\xdd\x5a\x7e\x35\xd4\xf8\xa3\x18\xfa\xc8\xab\x77\xe1\x9f\xce\x53\x49\xa7\x55\x8c\x8a\x01\x31\x5c\x82\xee\xf4\xe3\x56\xa8\xf8\x2f\x9f\xce\xae\x94\xa0\x6c\xec\x97\x0f\x21\x36\xee\xd7\x14\x74\xec\x7b\x19\x98\x94\x4e\x40\x9c\x10\xdc\xf6\xcb\x55\xa9\xb0\x9d\xaf\x07\xc7\x67\x2c\xa1\x0f\x1f\x88\x9a\x98\x63\xed\x12\xa8\x5e\x1d\xd0\xae\x43\x0f\x8a\xf8\xc4\x8e\x43\xe5\x7b\x32\xc4\xe4\xe7\x74\xbf\xf5\xe5\x0a\x4e\x4a\x77\x21\x3b\xb0\x0b\xff\xab\xd9\xd9\x81\x25\x1c\x97\xba\x0e\x75\x73\x1b\x96\x70\x02\xdd\x8c\x31\x4c\xf2\x29\xc9\x4f\x71\x04\x46\x6a\xf3\x6c\x4b\x6f\xcb\x72\xb5\xd1\x9c\xef\xc0\xb5\xd7\x11\xa1\x6d\xef\xc7\x95\x2f\x38\xb5\x36\xa8\xe4\xfb\xfb\x46\x4f\x9a\x9d\x35\x95\x79\x0b\xf0\xfc\xb0\x29\xcf\x48\xcb\x07\x7a\xe6\x05\x26\xe8\x43\xb7\xb8\xa0\xf7\x93\xa0\xac\x38\xdb\x4f\x6b\x6d\xa6\x3c\x68\xae\x92\xf1\xe1\x3f\x30\xb2\xa7\xca\xce\x65\x8b\xec\xf6\x63\x11\xa6\xa5\x5d\xee\xb2\xb5\x93\x95\x76\x5c\xfd\x5a\xb6\x0d\xee\x96\x55\x9c\xbd\xb8\x84\xf4\xf7\x7a\x9c\x4d\x53\xa9\x52\x2b\x05\x8c\x7e\xc1\xc2\xb1\x9e\x3d\x73\x6c\xe4\x34\xee\xe4\xc3\xb5\xe0\xc4\x58\xd3\x0e\x2c\x5b\x1b\xe7\x47\x6c\x67\xa7\x1c\x56\x95\x0e\x94\xb2\x34\xbc\x7c\x96\x64\x5f\x11\xcc\x8a\x03\x4e\xe0\x5b\x7b\xaa\xa4\xf7\x54\x1b\xde\x78\x5e\x4d\x72\x65\x2b\x6c\x0d\xa5\xb3\x3c\xfe\x7a\x28\xd9\x71\x0c\xfd\x31\x83\xaf\x58\xa0\x36\xfc\x5c\x63\x8d\x50\xeb\xc6\x25\xf0\x0a\xba\x61\x6f\xbf\x11\x51\x68\xfa\x6f\x9a\xbb\xd7\x0f\x76\x3f\xb2\xfc\xf4\xd8\xcd\xc8\xd9\xaa\xcb\x4d\x4e\x83\x7f\xdf\xcc\xea\x93\x8a\x6e\x7c\xa3\x5c\xd2\x48\xaf\xf6\x0c\xfb\x9c\x88\xbb\xeb\xbd\x9a\x4b\x3a\x25\xa4\x76\xc6\xbb\xd7\x9b\xad\x9a\xe7\xce\xd6\xe7\xec\xd5\xa1\x66\xa0\xfa\x73\xcc\x2e\xbc\xfc\xd2\x7e\xe0\x66\x66\x85\x8a\xad\x1e\x04\x7a\xad\xd4\xc0\xe4\xde\x29\xab\xe8\x67\x47\xe1\x19\xc4\x7d\xb9\x9e\x63\xad\x3e\x2b\x15\x10\x05\xdf\xf5\x81\xcd\x93\xa4\x74\x07\xd2\xe9\xaf\x71\x4b\x7a\xe7\x94\x8a\x4c\x67\xd5\x75\xd3\xe9\x00\x89\x63\x18\x26\x24\xba\x33\x6f\xa0\xc1\x94\x88\x3b\xbd\xff\xda\x3b\x02\x66\x11\x13\x16\x43\x1b\x7a\x9d\x5e\x37\xfb\xf9\x07\x2e\x27\xc7\x7d\xe5\x5c\xbe\x32\x17\x98\x1e\x5c\x5f\x6f\xe1\x15\xf8\xf5\x86\xde\xd1\x1b\xe5\xd7\xae\x92\x0e\x34\xdb\x7c\x66\x67\xbb\x8f\xad\x0a\x6f\x39\xa1\x0a\x9b\xe5\xce\xec\xa3\x98\x96\x07\xac\xa4\x5c\xc0\xab\xda\x4a\x95\x72\x6c\xaf\xe7\x64\x65\xa5\xc2\xa6\xca\x37\xee\xdd\x18\x33\x6e\x32\xa9\xbc\xfb\x2b\x2c\x4a\x60\x5c\xb6\x27\xc5\x67\x25\x63\xda\xff\xef\xb0\xa5\x6f\x62\x0e\x02\xe3\xff\x9c\x31\x64\xa6\x90\x75\x36\x99\x04\x26\xb9\xa6\xb5\x8b\x74\xa3\x3e\xfd\x3b\xdb\x78\xeb\x62\xc1\x52\x24\xfa\xbf\x36\xb4\xdc\xb7\x31\xa6\x0e\x3d\x77\x5c\x25\x56\x4f\xe2\xaa\x37\x96\xba\xb5\x37\x96\xb2\xe8\xa1\x3d\x24\xa2\x9d\xe8\xc1\x36\x84\xcf\xea\x3a\x9a\xcb\x36\x61\xd1\x84\x8b\x4d\xd6\x3c\x64\xb1\x07\x87\xe9\xad\x6c\xaf\x55\xce\xc9\x70\x41\x92\xff\x77\x75\x29\xf8\xf4\x07\x35\x4d\xfc\x89\x9a\x26\x6e\x99\x87\xe1\x32\x3d\xf4\x70\xdf\xa8\xb7\xf9\x42\xda\xe1\x6f\xc7\x74\xb1\x9d\x2a\xb6\x80\x0f\x29\x63\x28\x7e\xf8\xf4\xe1\x3d\xf4\x41\x93\x75\x5e\x2b\x89\x04\x9d\x29\x69\xaf\xdc\x67\xe0\xa5\x37\x12\x3f\x91\xb1\x7d\x1f\xd1\x82\x66\x01\x94\x0e\xaa\x7c\x4d\x81\x9a\x48\x16\xa8\x0e\xeb\x2c\xb1\xec\x5d\x72\xd8\xd9\xa1\xee\xfa\xd4\xf2\xf9\x29\xcc\x35\xbd\x29\xb8\xaa\x7d\x5b\xb1\x7a\xd5\x65\x15\xc0\xda\x55\x87\x73\xc9\x66\x75\x54\x6d\xfd\x0c\x7d\x58\x3b\x1b\x58\x4d\x6e\xe2\x72\x56\xa9\xcd\x89\x74\x51\xfa\xe5\x57\xbb\xb2\x11\x5b\xb0\x03\xde\xac\x54\xb6\xac\x10\x48\x70\xa4\xf1\x8d\x4f\xab\x4f\x5e\xeb\x11\x72\x99\x1e\x1b\x20\xe3\xd0\x19\xa1\x10\x76\x5d\x12\xf6\xf3\x23\xc2\xda\x9d\xb6\x2c\xed\xe7\x42\xda\xcf\x8f\x4b\xab\xf8\xec\x61\x61\x3b\x1d\x78\xa7\x20\xe1\xfc\x4e\x66\x1f\x25\x19\x73\x3e\x5a\x6b\x6e\xd7\x7c\x6e\x3f\x78\x12\xc2\x6e\x77\xb6\x02\x2a\x81\x0c\x75\xfc\x6e\xbe\xab\x41\x7f\x47\xed\xa8\xcd\xc7\x53\xf8\x5c\x9a\x8f\xa5\x10\xe8\x75\x0f\xba\xe6\xe3\x24\x98\x7f\xab\xe4\x61\xde\x72\xab\xd8\x81\xdd\xee\xa3\xf2\xe4\x1a\xa9\xd5\xee\x53\x0a\xdf\x19\xc1\xdc\x81\xd0\x31\xe3\x02\xdb\x1b\x57\x59\xcd\x91\xca\x23\x26\xf2\x28\x91\xc2\x11\x95\x57\x50\xc3\xf1\x5d\x7a\x76\x67\x8f\x51\x1b\x56\xd4\xc6\x9d\xb5\xca\xda\xda\xb8\xa4\xf6\x54\xcd\x68\x3a\xce\xbb\x86\xda\xd1\x41\xbf\xa1\x02\x57\xda\x58\x9e\x7d\x28\xf4\x47\x5d\x51\x68\xba\x71\x54\x94\x20\x6b\x2c\xc9\x1c\xbe\x54\xe4\x74\xc0\x9e\x75\x8d\xef\xb1\x4f\xa2\xd4\x5b\x4f\xfd\x3d\xd4\x74\xd2\x9d\xdc\xfc\xb1\x89\x7f\xca\xe4\x43\x5e\x32\xc8\x44\xfe\x19\xff\x39\x47\xa9\x7e\x22\xe6\x10\xa7\x28\x84\x14\xf0\x2f\x43\xf2\x0f\xb2\xf2\xcb\xd3\x3a\x17\xc9\x61\x1d\x8d\xf2\xbc\xc4\x44\x91\xc3\xba\x33\xc7\x84\x46\x77\xbf\xd9\x19\xab\xbb\xd9\xa9\xf7\x35\x53\x99\xab\x79\x39\x81\x99\x6a\x61\x93\x2d\x3d\xd5\x5a\x9a\x6d\xee\xbe\xfc\x53\xce\xa3\x08\xa5\x3c\x84\x87\xbe\x35\xf2\x04\x03\xd8\x3c\x59\x85\x1a\x2b\x2c\x7f\x84\x24\xfb\xab\x84\x1b\x8d\x70\x4f\x30\xd6\x86\x85\x71\xef\x6e\xec\x2f\xf3\x2f\x1e\xb4\x42\x81\x24\x5e\xe7\xe1\x7c\x7e\x7f\xba\xd3\xb9\x32\x9f\x37\x5a\x01\x49\x12\xbe\xc4\x18\x6c\x91\x53\xbb\x79\xa2\xb0\x63\x3e\xcd\xa5\x38\x30\xbe\x34\xf0\xd6\xe6\x18\x5f\xa6\x6f\x0c\x9a\xea\x6a\x3a\xe6\x82\x08\x98\xab\xe8\x63\xb9\x93\xf1\xa5\xb6\x82\x5f\x3e\x9d\x5d\xce\x93\xe4\xb3\x79\xf9\x3c\x80\xa2\xf5\x03\x67\x3a\xf0\x76\x9b\x2c\x51\xb7\xe5\x07\x3e\x17\xb2\x82\x47\xd9\x5c\x61\xa5\xf1\x0a\x23\xce\x62\x99\x5f\xef\xd1\x2c\x51\xc9\xa1\x9f\x32\x16\x2a\xfe\xee\xea\xc7\xac\x0a\x5c\xc0\x4c\xc9\x4a\x0f\x0a\x7d\x0d\x1d\xca\xf9\x50\x5a\x90\x6e\xa0\x7f\xdb\xe0\xaa\xbd\x9f\x22\x60\x82\x53\x37\x1e\xac\x7e\x95\x25\xd3\xe0\x05\x8b\xb5\xed\x7b\x0e\x5a\xf6\x71\x8c\x6c\x40\xa7\x47\xcf\x81\xd3\x9e\xce\x4e\xf6\xdd\x92\xb9\x44\x51\x4c\x0d\xb2\xd8\x7e\x34\x8d\x33\x98\x53\x20\x23\x85\xc2\xae\x45\x90\xf3\xe1\x94\x2a\x18\xce\x95\xe2\x0c\x4c\x19\x6e\x24\x50\x4e\xec\x37\xe3\xc8\x18\x9d\x69\xd7\x3f\xb3\x17\xef\xf4\x24\xff\xf2\xe9\x2c\x27\x3b\xa2\x42\x2a\xf3\x65\x1f\xca\xc6\xc5\xf4\x4a\x14\xa9\xa2\xac\x42\xf3\xdb\x98\xe6\xdb\x33\x7a\x4f\xda\x64\x72\x62\xb2\x0c\xcc\x3f\xbb\xb5\x07\xd2\x4e\x53\xbe\x8d\x49\x94\x92\x72\x76\xa5\xb8\x20\x63\xd4\x0a\x7d\xa7\x70\xea\x6f\x4b\x54\x57\x65\x65\x6e\xb7\xe0\xbb\xbe\x4d\x5d\x73\x9b\xff\x9f\xff\x81\xef\x8c\xad\x85\xe6\x82\xc7\xb3\xa8\xb5\xe0\xb8\x30\xd5\x4c\xba\xc2\x6b\x41\x1b\xf6\x4c\x36\x59\xb8\x08\x47\x05\x39\x62\xf3\x88\xd5\xe1\xdc\xf7\xb1\xf3\xe1\x24\xaa\xcc\x9a\x5d\x16\x72\x0b\x87\x36\x54\x59\xfb\x9d\x33\xfc\x71\x34\x92\xa8\x72\x5b\x2f\x93\x4b\x12\x9a\x6a\xd9\x37\x31\x55\xfa\x8d\xb0\x06\x9b\xdd\xe4\x34\xb7\xd4\x9c\x6c\x69\xe9\x84\x32\xa1\x11\xfa\xdd\x00\xda\xbd\x6c\x6f\x7b\xe9\x6f\xbf\x60\x7c\xb9\xdd\x0a\x8d\x29\x3a\xce\xa6\x5a\x1b\x17\x28\x51\x7d\xac\x75\x22\x60\x5f\xc3\x34\xdd\x0f\x49\xf2\x95\xd2\xe4\xa4\x1f\x92\x26\xdf\x32\xca\xd3\x6a\xdf\xa9\x6f\x98\xd9\x46\x2c\xd9\x64\x0c\xc1\x53\x98\x71\xaf\x7a\xe9\xff\xfd\x5f\x00\x00\x00\xff\xff\xa7\x0b\x0d\x86\xda\x54\x00\x00")
+var _webfilesSloop_uiJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\x03\xcd\x3c\x6b\x77\xdb\x38\xae\xdf\xf3\x2b\x38\x6a\xef\x46\x6e\x6c\xc5\x79\xb5\x69\xd2\x76\x6e\x9c\xa4\xd3\xee\xf6\x75\x9b\xce\xa3\x27\x27\xa7\x95\x25\xc6\xd6\x44\x96\xbc\x92\x9c\xc4\xdb\xf1\x7f\xbf\x00\xf8\x10\xa9\x87\x93\x74\xa6\x9d\xcd\xec\xd9\x5a\x12\x08\x82\x20\x00\x02\x20\xc8\xf5\x07\x2b\xec\x01\x3b\x4c\xa7\xf3\x2c\x1a\x8d\x0b\xe6\x06\x1d\xb6\xd9\xdf\x78\xdc\x65\xb9\x1f\xf3\xfc\x3c\xcd\x02\xee\x05\xe9\xa4\xcb\xa2\x24\xf0\x10\xf6\x20\x8e\x19\xc1\xe6\x2c\xe3\x39\xcf\x2e\x79\x48\xef\x4f\xde\x1d\xfd\xd6\x7b\x15\x05\x3c\xc9\x79\xef\x65\xc8\x93\x22\x3a\x8f\x78\xb6\xc7\x06\x27\x47\xbd\xad\xde\x61\xec\xcf\x72\x8e\x80\xcf\xd3\x8c\x9d\xcf\x00\x4b\x2c\x80\x59\xc1\xaf\x0b\xe8\x8f\x73\xf6\xea\xe5\xe1\xf1\x9b\x93\x63\xaf\xb8\x2e\xd8\x79\x14\x73\xe8\x94\x15\x63\x0e\x1d\x4d\x53\x96\xa5\x69\xc1\xa0\xed\xb8\x28\xa6\xf9\xde\xfa\x7a\x3a\x85\xd6\xe9\x0c\x09\x4c\xb3\xd1\xba\xc4\x96\xaf\x57\xfa\x5b\x5f\x59\x09\xd2\x24\x2f\xd8\x14\x06\x54\x14\x9c\x3d\x65\x5f\x56\x18\xfc\x0d\xfd\x9c\x1f\xf9\xd9\xc5\x1e\x3b\x75\xee\x6d\x1e\x6f\x6d\x6f\xf7\x9d\x2e\x73\xee\x6d\x0d\xb6\x37\x77\x36\xe9\xe7\xf6\xd6\xf6\xe1\xce\xb1\xf8\x79\xb8\xf3\xf0\xe1\x81\x73\xd6\xd5\x6d\x5f\x21\x13\xa8\xf1\xd1\xee\xd1\xf1\xf1\x63\x02\x3b\xde\x39\x7e\xfc\x5c\xe0\x39\x3e\x3c\x7e\xfe\x7c\x9b\x7e\x3e\xdf\x82\xff\x8e\x55\xe3\x69\x16\x4d\xfc\x6c\x4e\x4d\x77\x9f\x0f\x0e\x07\x03\x02\xda\xdd\x3d\xec\x1f\x89\xa6\xbb\x1b\x07\x1b\x87\x1b\xf4\x73\xe7\x18\x1e\x0e\x55\xd3\x31\xf4\x19\xeb\x7e\x07\xcf\x1f\x6e\x00\x4d\x08\x76\xd4\xdf\x7d\xf4\x48\xf6\x0b\x18\x77\x05\xca\x83\xad\xc1\xf1\xee\xa1\x23\xda\xe2\x1f\xb4\xd9\xde\x3d\x3e\x38\x72\xba\xf0\xf1\xe8\x60\x77\xf0\x10\x7f\x85\xfe\xa3\x87\x3b\x7d\xfc\x75\xb4\xfd\xf8\xe1\xc1\x23\xfa\x3a\x38\xdc\x3e\x18\x58\x4d\x0f\xb6\x0f\x1e\x1e\x6d\xe2\xc7\xc7\x1b\x83\xe3\xe7\xe2\xd7\xa3\xc1\xc6\x01\x21\xd9\x3d\x78\x3c\x78\xb8\xab\x08\xcd\xf9\x25\xcf\xa2\x02\x07\xb9\x7a\x6f\x7b\x70\xb4\xbb\xb3\xb3\xda\x65\xab\xf7\x8e\xfb\xc7\xfd\x7e\x9f\x7e\x1e\xed\x6e\x0f\xb6\x07\xab\xd0\x60\xb1\xbf\xb2\xb2\xb2\xbe\xce\x7e\x8a\xd3\xa1\x1f\xe7\xec\x55\x74\xc9\xd9\x0b\x9e\xf1\x15\x98\x31\x56\xa4\xd3\x83\xeb\x28\xef\xb2\x61\x5a\x14\xe9\x04\x7f\xef\x13\xf8\x87\x31\xc8\x1f\x88\x52\x12\x14\x11\xcc\x30\x1b\x01\x70\xe0\xc7\x31\x0f\xd9\xd5\x98\x27\x48\x01\x49\xcf\x34\x03\x51\xc9\x8a\x88\xe7\x2c\x3d\x67\x3c\x82\x77\x19\xf3\x01\x0d\xf3\x33\xce\x82\xb1\x9f\x8c\x78\x68\x76\x75\x94\xf9\x57\xcf\x01\xad\xd9\xa5\x7a\x67\x76\x8d\xcd\xc3\x2d\x96\x17\xd9\x2c\x28\x72\xc2\x70\x8d\xb0\x27\x40\x05\xef\xb2\x39\xfe\x1e\xf8\x49\x28\xda\x1c\x64\x99\x3f\x67\x20\x8b\x85\x1f\x25\x51\x32\x62\xa1\x5f\xf8\x20\xda\x45\x16\x01\xa9\x21\x3b\xcf\xd2\x09\xcb\xe3\x34\x9d\x32\x52\xab\x8c\x10\x22\x90\xee\x93\x15\xd1\x04\xba\x8c\xf2\x69\xec\xcf\xa1\x49\x9a\xb0\x00\x46\x06\xf8\xd8\x24\x05\x71\x4f\x71\xc8\xd0\xa1\x78\x9a\xc0\x23\x03\xd4\x89\xa4\x0d\xc6\xfd\x01\xda\x9b\x23\x08\xf9\x79\x94\x70\xe2\xd2\x04\x38\x32\x99\x4d\x58\x08\x03\x45\xea\xf2\xa9\x1f\x70\xec\x01\x3f\xc2\x9b\x30\xbd\xf2\xd8\x4b\x16\xa6\xc9\x2a\xa2\x8a\x92\x0b\x44\x03\x3f\x72\x06\xff\x43\xa0\x20\xcd\x32\x1e\x14\xec\x0a\x86\x09\x8c\x9e\xe5\x88\xa6\xa0\x7e\x2e\xfd\x2c\x67\x3d\x16\xc1\x78\x52\x9e\x23\x86\x8c\xc3\x4c\xcd\xd1\x84\x4c\xa9\x0d\x75\x80\x8f\xd1\x7f\xa0\x19\xa2\xc6\x71\x5c\xf1\x28\x83\xd1\x00\xbf\x80\xb4\x9c\x5e\xc9\xd1\xb3\x1c\x98\x8c\x1d\x04\xe9\x2c\x0e\xd9\x34\x2d\xd0\xe2\x10\xce\x00\x35\x1f\x67\x7d\x18\xf3\x49\xee\x09\x36\x8a\x56\xaf\xfd\xeb\xdf\xba\xc6\xc3\x47\xc1\x8c\x5f\x50\x3c\x00\x1f\x0d\x1a\x91\x0e\x79\x71\xc5\x79\x02\x7a\x9e\xe5\xd2\x7c\x00\x69\x64\x6c\x06\x7e\xa6\xc0\x4f\x24\xf4\x53\xd6\xf7\x36\x05\xa6\xfc\x72\x04\x90\xe7\x20\xbb\x09\x70\x0f\xcc\x27\x3c\x25\x21\xa8\x02\x72\x14\xbe\x09\xa6\x84\x5b\x44\x14\xbc\x10\xad\x4e\x22\x84\xbe\xe2\xab\x28\x50\x92\xff\xd8\x35\xb2\x9f\xfe\xbd\x8a\x90\xe3\xc4\xe5\xdc\x07\x11\xd0\xa2\x75\x15\x85\xc5\x98\xf5\xc4\x8c\xc2\x3c\x80\x61\x19\x01\xa0\x98\x57\x31\x2d\x62\x22\xd5\x88\x84\x39\x15\x43\x41\xdc\x30\x2b\xc8\xd5\xa8\x58\xcd\x0d\xd9\x44\x7c\x43\x9a\x00\xd1\xb1\xec\x5b\x77\x2b\xc8\x9f\x00\xbb\x81\x1d\xaf\xa9\x4f\x18\x09\xbe\x94\x04\x28\x23\x0b\x1a\xb5\x07\x0b\x8a\x30\x0a\x31\x3f\x07\xc3\xb5\xd1\xef\x93\xc6\x4b\x99\x4a\x13\x9a\x74\xb4\xcb\x71\xea\x87\x27\xbf\xfc\x04\xdf\x94\x52\x43\xc7\x11\xce\x2a\x7c\x3f\x02\xd1\x4d\x72\x54\x74\xb7\x23\x91\x1b\x93\x0a\xad\xc3\x34\x98\x01\x48\xe1\xa9\x1f\xc7\x30\xfd\xf8\x1c\xc4\x11\xfc\xf3\x2b\x72\x6a\xbf\xd2\xee\xe3\xcd\xed\x5e\x70\xb4\xb7\xfb\x2b\x8b\x95\x95\x90\x03\x7b\xc0\xbc\x7c\x48\xd3\xf8\x43\x34\x7d\x99\xff\x12\xe5\x11\x08\x19\x20\x39\x07\xbb\xc5\x25\x0b\x92\xf4\x24\xcd\x8a\xe7\xc8\x04\x3d\x0e\x4d\x33\xe8\xfb\x2c\x4b\x98\x60\x81\x90\x2c\x58\x5e\xa7\x60\x4a\x4e\x0a\xbf\xd6\xca\x07\x13\xa4\x5a\x46\xe7\xf0\xec\x5d\x00\xd7\xd8\x0f\x4f\xd9\x90\x7e\xa9\x6f\x06\x66\x89\xed\x5f\xf0\x55\x34\x27\x80\x85\xd9\xb9\xef\xe5\xd8\x17\x4c\xfd\x50\xfc\xda\x47\x6a\x2c\x62\x5e\xa7\x79\x71\x4c\xa6\xe3\xbb\x50\x34\xf4\xd0\x74\xc1\x9c\xe4\x5e\xcc\x93\x11\x8a\x34\x50\x59\x79\x57\xa7\xf2\x0d\xe8\xc2\x77\xa1\xcf\x5d\x5d\x65\x6b\x40\x11\xba\x2a\x1d\x2f\x4e\xd1\xc0\x1f\x8a\x66\xee\x50\xbc\x25\xea\x70\xfa\x83\xc9\x94\x68\x52\x62\x60\x8a\xb3\x94\x70\x2d\x0d\x53\x7f\x8e\xaf\x50\x0a\xb7\xbc\xdf\xf3\x34\x71\xd1\xde\xff\xdf\x8c\x67\xf3\x9f\xb3\xb8\xb3\x6f\x02\x79\xa0\x81\x89\x5b\x8e\x14\xd4\x66\x16\x17\xf6\x78\xd0\xd4\x9c\x8c\xfd\x2c\x3c\xce\xb2\x34\xcb\x25\x8c\x97\x97\xaf\x24\x4e\x62\x4f\xa3\x6e\x95\xdf\xd1\x5e\x3d\x95\x48\x55\x6f\xe5\xd7\x21\xb0\xeb\x35\x2e\x33\x42\x4c\x5c\x80\x36\xbe\xfa\x53\xf0\xce\xc2\x83\x6b\x5e\xfd\x20\xd0\xa1\x06\x15\xd1\x54\xf5\xb6\x40\xee\xad\x68\xe6\xec\xaf\x08\x28\x9c\x5e\xb2\x5e\x2f\xb8\x5f\xbc\xf6\x09\x9c\xcc\x25\x0e\x07\x8d\x9b\x0f\x6e\x22\x69\x24\x2d\xc5\x93\x28\x27\xeb\x4a\x0b\x69\x21\x57\x4b\x58\x23\x38\x3a\xb3\x73\xf0\x09\xa2\x60\x0c\x83\xce\x0b\x0e\x1c\x87\x05\x2a\x1f\xa7\x64\x69\x61\x55\xf2\x73\x92\x02\x98\x3e\x5e\x4e\x56\x9d\x9d\x26\x1f\x25\xdf\x71\xc6\xc1\x52\x26\xb0\xf0\x1a\xa6\x04\x5c\x11\x69\x45\x06\xf3\x97\xa1\xeb\x50\xc3\x4f\x9c\x5a\x3a\x72\xd0\x28\x9d\x3f\x18\x18\xd9\x1f\x7f\x30\xe3\x51\x29\xc2\xd3\xa7\xb0\xbc\x98\xb3\x2c\x7a\xf3\xc6\x51\x08\x4e\x36\x74\x0a\xde\x07\xdf\xaf\xc8\xf4\xbe\x21\xc2\x12\x1e\x85\xf4\x10\x0c\x3c\xd0\x04\x8d\x9c\x77\xa0\xf6\x30\xf9\x4c\xcc\x2b\x78\x57\x62\xa1\xce\x05\x6b\x05\x57\xf7\x98\xc3\xd6\x4a\x71\x30\x68\x9b\xc0\x64\x80\xd9\x7b\xc6\xb8\x90\x2d\x50\x0e\x87\xb9\x00\x0d\x2f\x60\xce\x72\x7f\xc4\xf1\x55\xc7\xe9\x78\xbf\xa7\x51\xe2\xa2\x37\x2a\x87\x5d\x25\x5f\x5a\xce\x05\xcd\xec\x00\xd6\x47\x0e\xfa\x9d\xa8\x99\x87\xa5\x6f\x9c\xe6\xca\x8d\xa1\x29\x63\xb3\xa9\x5a\x8f\xc6\x20\x17\xb0\xe2\x4c\xd1\x05\x8b\xa6\xd2\x36\x24\x15\xa9\x79\x9f\x5e\xe5\xd0\xcf\xce\x7e\xfb\x77\x61\xe3\x01\x68\xa3\x2f\x7d\x35\x81\x11\xa5\x04\xfb\x19\xd6\xc9\x1a\xce\x85\x33\x36\x9c\x05\x17\x20\x02\xfe\x10\x9d\x2d\x5b\xe8\xb4\x1c\x4e\xc1\x2d\x02\xe9\x9a\x15\x20\xa8\x34\x10\x4d\xb8\xc7\xd8\x21\x8f\x63\xf2\x5d\xb1\xdf\x10\xe2\x11\x90\x24\x5c\x75\xc1\x87\xcb\xf4\xb8\x71\x29\xce\x38\x70\xcc\xfc\x76\xe5\x67\xb8\x56\xe7\x80\xe4\x15\xac\xac\x4c\xb2\x14\x7d\x5f\xea\x16\xc4\x77\x0a\xcc\x2f\xd0\x3f\x4b\x20\x76\xf2\x2f\x61\x56\x7d\x58\xaf\xaa\x22\x5e\xd7\x33\x43\xb8\x05\x23\x96\xc8\xb6\x66\xca\x27\x1c\x15\x0c\xca\x14\xf0\x62\x3e\xe5\xc0\xc4\xb1\x40\x4c\x86\x8d\x24\xda\x99\x25\xc2\x47\x09\x9d\xba\x45\x36\xa5\x57\xd9\x45\x13\x43\xe7\x66\x53\x88\x94\x67\x62\xe2\xa5\x0d\x2c\xe7\xce\xcb\x31\x56\x74\xfb\xdd\x46\x51\x31\x0d\x24\x0c\x00\x91\xb4\xaa\x22\xa9\x05\xf2\xa7\x4d\x1b\xab\x63\x2a\xc7\xa5\x88\x04\xb7\xfb\x30\x9d\x91\x4e\xc2\x50\xe1\x89\x7a\xec\xb2\x0c\xb5\x4b\xbd\xf1\x02\x14\x92\x2e\x0b\xe4\xcb\x7c\x36\x71\xdf\x0e\x7f\x07\xc7\xd9\xbb\xf4\xe3\x19\x18\xd9\x00\x82\x74\xc0\x92\x77\xe0\x6f\xdf\xea\x40\x78\x88\xb7\x73\x8f\x60\xd5\x15\xfe\x9b\x87\xae\x1a\x3c\x6d\xf7\x6d\x64\xd7\x82\x4c\xf4\xbd\x21\xfe\x4d\xb8\x9f\xb9\x1d\x40\x38\x01\xaf\xd1\x3d\x55\x8b\x0d\x7a\x14\x5d\xc5\x76\x10\xb0\xb3\x8e\x97\x61\x8c\xe5\x9e\x02\xcb\x89\x9c\xb3\x0a\x8d\x38\xbc\x5f\x25\x9d\xaf\xfd\x62\x4c\xa3\xde\xe8\xb2\x6b\xd7\xc4\x09\x16\x45\x3e\x0a\xa5\x3b\x01\xad\x4a\x42\xb0\xc4\xbd\x0a\x60\x95\x05\x2a\x18\x5d\x46\x3c\x90\xb6\x51\x12\x2a\x73\x05\x9e\x6a\x79\xda\x3f\xeb\xb2\xda\xcb\xcd\x33\x1c\x88\xee\x0a\x71\xf3\x18\x26\xc5\x25\x99\xe8\xc8\xa7\x83\x38\x76\x9d\x07\x60\x09\x33\x8a\xc8\xdc\x2a\x71\x08\x7b\x42\x4b\x6d\x0d\x81\x58\x45\x61\xf9\xb8\x1c\x39\x1d\x4b\xa8\x3c\xbf\x28\x32\xd7\x21\x6e\x3a\x92\xab\xc0\x1f\x63\xf6\x1a\xe1\xc7\x64\xe8\xa0\x81\x29\xd6\x0f\xda\x4d\x62\x85\x56\x68\x05\x64\x2a\x8a\xcd\xf1\x01\x7d\x1e\xba\x2e\x24\xbd\x1d\x98\xf5\x82\x23\x77\xd5\x00\x5a\xc8\x2f\x80\xdd\x98\x63\x9a\x00\x45\x6e\xd6\x65\x51\x07\x05\x5c\xbc\x8e\xfd\x02\x75\x14\xd7\x94\x68\x19\x89\x72\x9d\x31\x56\x40\x08\x2a\x54\xbf\xb8\xe8\x35\x77\x7d\x0d\x5d\x56\x64\xbd\x11\x6e\xee\x34\x9a\x09\xd9\x77\x8f\x6d\x36\x0f\x0c\xfa\xed\xf9\x49\x30\x4e\x33\x5c\xfa\x80\x96\x2a\x15\x08\xe1\x92\x92\x67\xa5\x69\x12\xa6\xd1\x61\x3f\x32\xc7\x0d\xe2\x19\x78\x2a\x59\xc7\x61\x7b\x26\x48\x65\xa0\xc6\x14\x60\xec\xad\x67\x41\x20\x26\xb3\x51\x9f\x0d\x01\xd9\xc6\x15\x32\x32\x26\x6b\xd6\x40\xbd\x02\x0f\xd7\x35\xd0\xae\xc9\xb4\xb3\x54\x12\xb5\x2a\x2f\x97\xbf\x65\x2c\xdd\x68\x6c\x7a\x1e\xc5\xb1\xa2\x4e\x69\xa0\xab\xed\x9e\xf7\xab\x58\x0c\xd1\x89\x02\x13\xbd\x7e\xb3\x95\x6c\xeb\xa3\x97\x62\x54\x5f\xcc\x55\x5f\x10\xda\x03\x07\xfa\xde\x2e\x08\xe1\x0d\x48\xa1\x5b\x65\xce\xab\xe8\x95\x3c\x46\x45\xcc\x1b\x45\x81\xfa\x42\x55\xf0\x8b\x23\x90\x7c\x4c\xd4\xb8\x09\xbf\x62\xf8\x60\x72\x1f\xa8\x80\x00\xba\x0f\x9d\xa1\xcb\x85\xda\xf1\xcf\x93\xb7\x6f\x3c\xd4\xc9\x64\x14\x9d\xcf\x0d\x72\x0c\x4f\xde\x5e\xa3\xa4\xcb\x85\x5f\x16\x1d\x2f\xf0\x8b\x60\xec\xd6\x23\xd5\x86\x86\xe5\xe2\x26\x3c\x76\x74\x59\xde\xc9\x10\xa6\x48\x47\x23\x08\x85\x73\xf0\x4f\xc0\xcd\xc6\xcc\x14\x25\xd6\xe0\xbd\x4e\xd9\xa8\x68\x47\x7d\x89\x82\x8b\xbc\xf4\x45\xe4\xd7\xc3\x31\x07\xe3\x9e\xb9\x65\x10\xe7\xb6\xba\x1e\x66\x13\x10\xfd\x80\x9a\x42\x34\x25\x28\x35\x07\x42\xc9\x37\x0f\x3d\xb2\x26\x6c\xf9\x60\x7e\x18\xfb\x79\x8e\x8e\x90\x81\x15\xa9\x74\xaa\x92\x62\xba\xbe\xc5\x3c\xe6\x9e\x1a\x1d\x68\xee\x10\x22\xc3\x0b\x65\x8b\x16\x8c\x03\x9b\xbf\x05\x0d\xcb\x89\x48\xd2\x84\x6b\x1a\xe4\x24\xb1\x23\xf9\x3d\x8c\xce\x29\x39\x55\xb0\x7f\x93\x2f\x36\xe1\xc5\x38\x05\x87\x1f\x13\xda\x94\x4f\xcc\xfc\x30\x4a\x19\x89\x76\x39\x35\x04\x2b\x68\x71\x09\xc0\x8c\xb0\xe9\x85\x50\x06\x61\xbf\x32\x3e\xe2\xd7\xce\xd7\x72\x5f\xb6\xfe\x5a\xae\xdf\x9d\xd1\x10\x07\xe1\x20\xef\xd4\xa5\xcd\xe3\x56\x4e\x18\xc8\xbf\x1b\x37\x4c\xd2\xbe\x0f\x33\x6c\xa9\x47\x89\xab\xc4\x17\x15\xff\x5c\xa5\xb5\xa1\xed\x34\x4b\xc1\x21\xcf\x0f\x92\x10\x73\x25\xef\x65\x5e\x32\xb7\xb3\x0d\x0a\x7e\x30\xc7\x14\x4d\x97\x61\x1a\x07\x9c\x61\x30\xd6\xb0\xac\xf1\xf0\x48\x64\xc8\xcb\x98\x1a\x61\x4d\x7e\x97\x39\x79\xc3\x01\xfc\xb9\x08\x5c\xed\xee\x19\xab\x5d\xd7\xca\x2a\x5a\xfe\xb0\xe9\xb5\xea\x4c\xa8\x81\x12\x1f\x01\xe7\xd4\x0f\x43\xb0\xc5\x6e\x7b\xc2\xd8\x74\x1a\x2b\x5b\x0e\x02\x1d\x6e\x4e\x7c\x48\xa7\x6e\x49\xb9\x99\x78\xa9\xed\x49\x94\x8d\x06\xf4\xad\xb9\x9d\xc9\x2f\x68\x71\x7a\xd6\x6c\xa5\x4a\x4e\x0b\xb4\x09\xac\x3b\x30\xaa\x0b\x3e\x77\x43\x0a\x3f\x44\x1a\x0d\x9d\x8a\x0c\x82\x62\x4a\x58\x19\xbd\xd0\xe4\x60\x4b\x8d\x86\x64\x47\x35\xe5\x73\x73\xf0\x43\x3f\x3b\x4c\xe3\x34\xfb\x89\x27\xe5\x38\x88\x97\x6f\x33\xe0\xa1\x1f\x97\x0e\x3a\xe1\x55\x13\xa6\x3c\x71\xbd\x1d\x66\xae\x75\xd2\x37\x68\x41\xdc\xe8\xf8\x77\xd9\xe6\x59\x15\xb7\xc2\x63\xd2\xdb\x2e\x49\xb6\xb6\x28\xdc\x18\xbf\x45\x22\xa7\x07\x72\x25\x58\x20\x22\x94\xae\x8a\xed\xac\x6f\xa0\x2d\x9d\xb3\x0a\xae\x3b\x8b\xe8\x2d\x64\xb4\x91\x5a\x00\x11\x7d\x21\x49\x32\x36\xa8\x9a\x01\x9b\x18\x90\x5d\xf0\xdb\x0d\x70\x70\x4e\xdc\x2d\xf4\x4d\x34\x51\x00\x52\x1d\xd0\xdd\xf4\xc3\xde\x64\xa0\xad\x96\x0d\xe8\x46\x8f\xcd\x1b\xaa\x5d\x10\xca\x0e\xb6\x4b\x3b\xb8\x45\x09\xb8\x3a\xe8\x8a\xc5\x73\xf7\x14\xe2\xb9\x66\x11\x15\x5e\x5d\xa7\x45\x71\x3c\x70\xd1\x8e\x7d\xf0\x97\x24\x74\x80\x52\x26\xf8\x4b\x3f\xdd\x8a\x48\xbb\x52\x5d\x3a\xda\x3c\xaa\x6d\x91\x3b\x68\xfd\x5d\x35\xbe\xcc\xdd\x5c\x8e\x54\x58\x5d\x02\xc8\x49\xec\x9c\x6e\x9c\x81\xf7\xe8\x6e\x02\x37\xcd\xb0\x71\xdf\x6c\xad\x13\x63\x25\xbf\x5b\x5b\xc3\x98\x54\xdf\xe0\x71\x64\x72\xfb\x11\x9a\x15\x6a\xf3\x4b\x6f\x8c\xa9\x84\x56\x90\x81\xf7\xcf\x59\x54\x78\x4c\xf9\x7a\x98\x2d\x36\x1d\xd0\x4a\x60\xec\xdc\x0b\xb7\x3e\x8d\x01\x8b\xb9\xc4\xe5\x56\x2c\xba\xfa\x60\xb5\x1a\x6b\x8b\x25\x7a\x09\x2e\x8d\xaa\x25\xe4\x96\xf1\xc1\x65\xc4\xaf\x06\x29\x06\x49\x9f\xfb\xac\xcf\xee\x7f\x51\x0c\x5e\x88\xdf\x82\x5d\x8b\xcf\x46\xc3\x00\x57\x57\x2e\x10\xf6\x02\x91\x7f\x85\xf6\xe4\x9f\xa2\xbc\x12\x24\xd2\x85\x83\x30\xc2\xe5\x7d\xc5\xc8\x43\xc1\x23\x4c\xe8\x8d\x32\x7f\x3a\xa6\x7d\xca\x8c\x4f\xb1\xf8\x22\x29\x7c\x5a\x66\x71\x5b\x1b\x84\x52\x6f\xec\x09\xa4\x59\x3a\x9b\xa2\x29\x1e\x95\xd4\xd8\x11\x7b\xf9\x9e\x82\x46\x53\xce\x8d\x6f\x32\x7a\xac\xb3\xa8\x81\x41\x56\x40\x1f\xaa\x80\xfe\xb3\x11\xd0\x03\xa3\xb4\x2c\xb9\x11\x46\x31\xb6\x86\x2f\x3a\x26\xf7\x70\x54\xae\x90\x92\xf7\xa5\xb9\x50\x62\xa6\xfd\x19\xf2\x4f\x4f\x68\x6c\xa0\x82\xce\x30\x0d\xe7\x10\x0e\x94\x0c\xa0\x1f\xfb\xe6\x86\x0e\x70\x1b\x1d\x15\x23\x33\x84\xc1\x16\xe6\x3e\x4f\x4f\x9d\x37\x18\x86\x41\xb0\xd9\x3f\xeb\x9e\x3a\x32\xb0\x74\xba\x1b\xf8\x44\xf9\x6f\xa7\x2b\x33\x3f\x65\xec\xb2\xdc\x8f\x31\x1c\x1f\x14\xa1\xb7\x53\x51\xb0\xa0\x73\x93\xf8\xf2\x53\x2a\xde\xee\x1b\x9e\x8c\xfc\x4c\x39\x95\xca\x12\x8d\x3b\xac\x8b\xf6\x15\xbc\xc4\x4d\x99\x1e\x6d\xdf\xec\xdc\xa5\xda\xaa\xb2\x77\x20\xed\xf4\xa5\x0c\xe8\x5c\x83\x70\x2f\x87\x41\x56\xf3\xa0\x84\x0f\xa2\x08\xe6\xd0\x0a\x87\x01\xab\xb3\x57\x83\xb8\x6d\xaf\xea\x6f\x08\x73\x7f\x51\xff\x24\x3a\xc2\x24\xc2\xed\xfa\x10\x1b\x85\x5f\xd1\xc5\x24\xcd\x0b\x91\x83\xbf\x5d\x47\xe6\xbe\xe9\x9d\xba\x0b\xf9\xb9\x0f\xd3\xd5\xd2\x09\x30\x3d\x05\xcb\x1d\xa7\x23\xd7\xf9\x39\xb9\x48\xd2\x2b\x10\x61\x98\x04\xda\xa1\x61\xb5\xa9\xb9\x75\xcf\x8b\x15\xeb\x51\x2a\x47\x91\x7e\x90\x9b\x18\xa0\x6c\x6e\x58\xea\xe3\xa2\x43\xf8\x5d\x1a\xb4\xd1\x0b\x98\x29\xda\x65\x99\xf0\x6c\x84\x9b\x71\x41\x96\xe6\xb9\xda\x4b\x12\x1b\x16\xf8\xe5\x93\x7c\x83\x9b\x75\xe7\x69\x1c\xa7\x57\x00\x8c\x1b\x29\x60\xdb\x62\x3f\xe1\xda\x8e\x11\x5c\x65\x53\x80\xc4\xda\x3b\x8f\xc5\x0e\x05\x09\xf3\x69\xa8\x56\x75\x17\xd6\x59\xc4\x40\x9b\x68\xa7\xe0\xcb\xa1\xc0\x13\x4a\x00\xb3\x87\x63\x4b\xad\xe7\x51\xbb\xae\xf5\x12\x13\x32\x7b\xb0\xb8\xe3\xbf\x94\x66\xf9\x5f\x62\x33\x02\x8a\xed\xae\xae\xc1\x11\x63\x69\x37\x23\x9f\x2a\x0f\xed\xed\xff\x92\x08\x20\xc0\x40\x47\xaa\xb3\xa7\xbc\xc4\x4f\x21\x5a\x7e\x91\xf0\x29\x61\xc0\xf6\xee\x81\x71\xad\x83\xa0\x31\x85\xf7\xe1\x2c\x13\xab\x82\x7c\x5b\xb6\x54\xfb\xe9\xd4\x5c\x3d\x98\x1c\xe3\x75\x13\x01\x53\xcb\x55\x81\xcf\x5b\xd1\x44\x16\x3c\xc9\x22\x93\x90\x45\x49\xb5\xc5\xf4\x62\xb4\x4e\x95\x4c\xeb\x68\x99\x21\x4a\x58\xc7\x7d\xa0\xdc\x1b\xa5\x16\x24\x39\x19\xd3\x38\x2a\x3e\x20\xa3\x9f\x42\x6c\x89\x1c\xf7\xe8\x95\xeb\xb0\x4a\x9e\x8c\x36\x33\xd2\x2c\x2f\x4e\xac\x74\x3e\x3a\xd1\x1a\x49\x97\xe6\xae\x3e\x0a\x83\xf5\xca\xe4\x63\x10\xec\x9a\xfd\xed\x39\xe8\xd4\xd8\x7d\x2e\x4c\x57\x54\x11\x21\x39\xa7\x6b\x5c\xcc\x3f\x98\xce\x8a\x30\x19\xd3\xea\xf2\x86\x49\xab\x43\x8b\x09\x6e\x82\xc5\x09\xe6\x4b\x26\x58\xf7\xa7\xab\xef\x2c\x86\xd5\x01\xc1\x22\xe4\x69\xb2\x27\x39\x5f\xff\x4e\xa9\xc4\xbd\x72\x92\x4e\x37\xcf\x6c\xa0\x45\xd3\x5e\x97\xe2\x90\xa1\x25\x2b\x12\xd8\x54\x91\x5a\xdd\x85\xad\x23\xaa\xe6\x42\x84\x9b\xd5\x9a\x0b\x7a\x6b\xa1\xab\x94\x1c\xa8\x35\x17\xeb\xdb\x6c\xef\x0a\x5f\xd5\x5d\x97\x39\xd6\x5b\xd6\xdd\xdc\xfe\x59\x1d\x72\xb3\x11\x72\xa3\x0e\x09\x8a\x92\x5e\x70\xdc\x01\xc8\x46\x43\xdf\xed\x77\xe9\x3f\x6f\xa7\x63\x76\x4f\xf9\x14\xd7\xa1\xad\x5a\x9e\xf5\xe4\x6a\xd3\x2d\x33\x39\x66\xc4\x20\x86\x72\x77\x5f\x6c\xa9\x1f\x66\x0c\xd6\x76\xbf\xb0\x9a\xd2\xad\xc4\x2a\xed\x83\x54\x91\xb3\x2e\x8e\xb5\x59\xa2\x3d\x61\x89\xd0\xf0\x82\xf1\x7b\x19\xe4\x7c\xdb\x31\x6e\x34\x8d\xb1\x1e\x61\xfd\xf9\x61\x96\x38\x8d\x91\xd6\x93\x63\xba\x14\x46\x57\xcc\xd1\xb3\x1d\xa9\x08\x8f\xb6\xce\x92\x30\xba\x74\x9a\x59\x4c\x48\x54\xc7\x56\xb7\x4d\x85\x3b\xb2\x6f\xd4\x92\x34\x71\x1d\x5d\x3e\x0a\x08\x9a\x37\x06\xd0\x0e\x9e\x5e\x83\x1a\x9c\x49\x2b\x8c\x2d\x5c\xac\x06\x35\x2d\x26\x3a\xb1\x46\xe0\x19\x25\x60\x17\x0a\xf7\xba\xc3\x9e\x98\xf1\xa8\xcc\x3f\xa0\xf8\xe1\x62\xd4\xdc\xe2\x59\x63\x0b\xe0\x7c\xd5\x0f\xb5\x7c\x25\x5d\xd8\x89\x95\x8e\xe9\xac\x40\x0f\x63\x08\x36\x0d\x9c\x90\x6b\x83\x71\xd2\x85\x46\x72\xe7\x40\x5b\x93\x62\x10\x65\x73\x20\xa3\x51\xf1\xbf\x96\x08\x50\x85\x3a\x19\x36\x2a\xb4\x56\x0d\xd2\x6e\xc8\xf9\xfd\x2f\xd7\x0b\xd6\x07\xa1\xb6\x6d\xb1\x2c\xf7\xb5\x63\x7f\xcd\x50\x1b\x56\xe4\x4d\x5b\xca\x1b\x9b\x3c\x7d\x51\x2d\x4d\x42\xf6\x9b\x90\x00\xb2\x5b\xde\xd4\x1f\xf1\xdf\xea\xee\xa7\x01\xfe\xb1\x0a\xfe\xb1\x0e\x3e\x4d\x73\x4a\x43\x2b\xdd\x50\x3d\x75\x35\x92\x4e\xd5\x8f\xb5\x7f\x2d\x74\x2e\xc8\xda\xa5\xf6\x54\x80\x0c\xd1\xa1\x96\x73\x5c\xac\x2c\x39\xb7\x6a\x04\xef\xc4\x99\x52\x63\x49\x13\xec\x9d\x4b\x99\x2c\xf2\x44\x8d\x8f\xdb\xa9\x4e\x97\x18\x99\x5a\x0e\xca\x5d\x48\x63\x3b\x54\x0d\xae\x24\x3e\xe6\x7e\x45\x4b\xbf\x15\xf5\xb7\x4c\x70\xdd\x38\x9c\xfe\xb2\xe1\xd4\x6c\xce\xd7\x8f\x46\x11\x30\x2e\x26\xb1\x0b\xbe\x9e\x91\x3f\x90\x65\x70\x6e\x4d\xee\xea\x72\x4e\x98\x70\xd3\x56\xc5\x03\x75\x1f\x09\xff\x90\x05\x7b\x32\x35\xde\x0c\xa1\x37\xbc\x11\x4c\x3f\x34\xc3\xaa\x52\x44\x88\x67\xf2\x3d\x92\x9b\xf2\xb9\xb9\x05\x46\xdb\x7b\x4a\xe3\x6b\x10\x0b\xeb\x4d\xa7\x65\x02\x82\x38\x0a\x2e\xda\x99\x8f\xa5\x77\x47\x06\xef\x51\x2f\xc3\xae\x56\xe5\x2e\x93\xc6\x5f\x60\xd4\xe9\xab\x97\x49\x31\x03\x5d\xbe\xe4\xf1\x9c\xad\x86\xab\x88\x06\xeb\xf5\x87\x22\xa3\xb5\x2a\x4b\xc6\x56\xc1\xf2\xd1\x7e\x14\xd6\x24\x83\x85\xc4\xc2\xf9\xab\xb1\x5f\xd0\x19\x0e\xe1\xa4\x2a\x84\x54\xd9\x46\x45\x74\x43\xda\x6a\x90\xa7\x0e\x00\x3d\x36\xc4\x2e\x64\xb4\xa2\xab\xdc\x25\x6a\x8f\xbd\x49\x21\xde\x98\x61\xe5\xdc\x78\x0e\x1d\xbd\x94\xc7\x18\x24\xe2\x70\x4b\x62\x54\xb5\x86\x05\x19\x78\x40\x1c\x47\x17\x9c\xaa\x4d\xbd\x06\x93\xa2\x8b\xde\xbe\x89\x45\x41\xc3\x89\x1e\x6f\x52\x1c\xaa\x4c\x73\xc5\x8c\xec\xd7\xe0\xa5\xf7\xfd\x12\xbc\x0b\xac\xd7\x82\xe6\x39\x87\x69\x20\xad\xc6\xa8\xe7\x00\xf4\x3a\x02\x66\x81\x5a\x46\x08\xe3\x54\x75\x57\x1c\x16\x89\xf2\xb7\x3a\xd0\x29\xc3\xc5\x53\x13\xfb\x59\x25\x3a\xb2\x2d\x88\x27\x08\x97\x3b\x8d\x1d\xed\xcc\x98\x56\xd8\xb2\x31\xc6\x40\x2b\x14\x7d\x9d\x69\x32\xc6\x20\x6a\xb4\x3b\xa6\xf1\xad\x0d\x39\xd0\xd5\xb1\x75\x3b\x20\x52\x02\x55\x84\x75\x65\x5c\x6e\x08\x6e\x6b\x04\x6e\xb0\x38\x32\x9c\x34\xa9\x11\x45\x77\xcd\xa1\xa4\x09\xc7\xab\x64\x2d\x2a\x8c\xb8\xed\xbc\xdf\x7d\x76\x9a\x36\xe6\xac\x29\xd2\x3b\x6e\x9d\xb6\x35\xb2\x8d\x1c\x8f\x18\x86\x05\x2a\x75\x11\x97\x25\x37\x8d\xab\x53\x3d\x7c\x5f\xb6\xfa\x6a\x20\xb5\xa4\xbc\x10\xaa\xaf\x96\x13\x29\x3f\x26\xd1\xdf\x73\xc1\xbe\xb3\xba\x49\x4b\xb2\x24\xc5\xf1\x7d\x4c\xc8\xad\x45\xe9\x0e\x12\xf4\x27\x9d\x91\xbf\x78\x2d\x6c\x58\x36\x2a\x05\x3e\xdf\x6c\xf1\xb8\x7e\x97\x62\x40\xbd\xd6\xcc\xd8\xeb\xaa\x62\x98\x15\xc3\x2d\x6d\x44\xa5\x5f\x43\xbb\xb1\xda\x2c\x6c\x69\x28\x8b\xff\x5a\x24\x45\x70\xe2\x96\xc2\x26\x53\x5f\x36\xa6\x4b\x08\xaf\x44\x69\xd9\x00\x18\x53\x0f\x70\x9a\xa9\x8a\x42\xa7\x9a\x5d\x72\x92\x34\x90\xf3\xd2\x54\xf1\x8d\x7f\x65\x3f\x76\x3d\x9d\x96\xa6\xa6\x50\xae\xd6\xb0\x5e\x2c\xbe\xb8\xe3\xb2\xb4\x7c\xa1\x50\x6e\xa1\xe2\x6e\x97\xb5\xd0\xb3\x67\xd0\x55\x47\x83\x07\x6b\xf0\x34\x48\x3b\x03\x45\xb9\x93\x80\x73\x96\xae\x2f\x6d\x72\xd8\x15\x92\xda\x63\x3b\x9d\x4a\x45\xa9\xaa\x6d\xde\xe8\xdf\xca\x29\xd0\x25\xa6\x63\x55\x1e\x5c\x6b\xf8\xd7\x58\x7f\xc1\xd2\xbf\xcd\xf8\xff\xd7\x2a\xf7\x5d\xa6\x7b\xad\x65\xba\x7b\x30\x67\xcd\xf3\xd9\x6b\x9b\xcd\x5b\x19\xf7\x4a\x06\xae\xbe\x86\x87\xe6\x5e\xad\x1f\xc7\xef\x29\xf6\xa0\x82\xa6\xea\xe6\x04\xac\xab\xe1\x2c\xe0\x2e\x16\xae\xc7\x5d\x16\x75\x99\xdf\xb1\x77\x1e\xaa\xfb\x1b\xb1\xb1\xd5\xd0\x7c\x42\x41\x02\x96\xf9\xf6\x8d\xb3\x66\xc0\xc3\x34\xa4\xb4\xb6\xb9\x99\x61\xb6\x6a\xc1\xaf\x82\x88\xda\x51\x06\x13\xaf\xd1\xa5\x4c\xc5\x7f\x7e\x52\x64\xcf\xea\x81\xe7\x93\x22\x7c\xc6\x9e\x0c\x9f\x61\xf1\x83\xee\xbb\x7f\xb6\x60\x4f\xd6\xe1\xe5\x93\x75\xf8\x7c\xcb\x46\x9b\x56\xa3\xba\x95\x52\xad\x18\x4d\xf2\x53\x87\x1c\x97\x3d\xc0\x60\x8e\x6b\xe1\x3c\x2b\xdf\x20\xda\xc5\x52\x3a\xd6\x61\x4c\x9f\xf1\x4c\x89\x90\x8d\x2e\x73\xb4\xf4\xd2\x9a\xe4\x8b\x93\xbd\x30\x76\xfc\x05\x78\x00\x9e\xe8\x10\x32\x21\x28\xc5\x67\x8c\xb9\x73\x76\xc2\xb9\xf1\x4e\x6d\xb9\xc8\x37\xd8\x17\x0c\xb8\x14\x28\x1c\xae\xc0\xfb\xd9\xaa\x4b\xf8\x8c\x3b\xd5\x7b\xc8\x9f\xfb\x5f\x42\xe1\xd6\xd2\x28\x9e\x0c\xb3\xf5\x72\x10\xff\xa2\x28\x43\x02\x61\xa8\xd1\x00\xf3\xa6\x8c\x35\x24\xa0\x0e\x38\x14\x34\x33\xc0\xef\x7f\x21\x72\x16\xc6\x8b\x4a\x35\xba\x2a\x5d\x5b\x80\x91\x6e\xf8\x88\xb5\x6b\x8b\xcf\x55\xf5\x6a\xc8\xba\x54\xf7\x42\x3f\x3f\x09\xa3\x4b\x16\x85\x4f\xc1\x55\x4f\xe6\x3d\x95\xba\x7e\xb6\x8c\x13\x9f\x8d\x73\x89\x9f\x97\x70\xc3\x82\xbb\x05\x47\xec\x16\x68\xe4\x8d\xd4\x8b\x1e\x80\x95\x90\xe9\x98\x5d\x10\x8a\x06\xe6\xe0\x42\xdc\x81\x4e\x60\xa4\x38\xe1\xa2\x28\xfb\x45\x94\x17\x69\x36\x37\xbb\xc0\x83\x8d\xf5\xcd\x53\xb3\x3b\x6f\x94\x76\x59\x9a\xc4\x73\x72\x42\xe5\x69\xbf\x3c\x9d\x70\x36\x16\xe8\xd8\x04\xdc\xed\xa1\x3e\x0d\x6b\xcd\x46\xd3\x68\x02\xb3\x96\x3b\xc0\xda\xf9\x04\x2f\x67\xf9\xe3\x0f\x16\x78\x79\x80\xe7\x0c\x9f\x3d\x85\x75\xb0\x7e\x6c\xda\x71\xcc\xe2\x2a\x3a\x1e\xa4\x8d\xe5\xa9\xb4\x21\x84\xd3\x13\x7e\xc1\xcf\x53\xdc\xca\xcc\x6d\x4c\xd4\xc0\x9b\xce\xf2\xb1\xfb\xf9\xfe\x97\x0a\xe8\x42\x7a\x1e\x6c\x26\x9e\x55\x86\x7b\x61\xe0\x86\x21\xf2\xbc\xf8\xc9\x9f\xea\xd3\x60\xcb\xd0\x57\xa1\x17\x74\x25\x88\x78\xab\x6f\x8c\x68\xea\x26\xe3\x31\xb0\xf7\x06\xec\x12\x68\xc1\xe4\x0f\x1b\x91\x12\x77\x39\xed\x95\x2c\x1f\x49\x24\x9d\x7d\xcb\x70\x6b\xc0\x55\xcc\x27\x47\xb7\xb3\xf8\x1f\x32\x65\xee\xfd\x2f\xaa\xd7\xf2\x58\xed\xa2\x23\x04\x77\xbf\xaa\x7b\xb6\x83\x12\x9a\xf3\x1c\x4a\x46\x37\x4c\xeb\x9f\x56\xc7\x5f\x78\x46\x47\xc9\x41\x08\xcf\x71\x04\x98\x8b\x13\x13\xc8\xfc\xa2\xc9\x72\x08\xe5\x60\x57\x1c\x80\xf1\x9c\x2a\xb2\x87\x87\x52\x59\x04\x03\xcb\xfd\x19\x50\x3e\x1a\xd6\xb7\xa0\xfc\x9d\x7d\x3e\x65\x29\xb5\x0d\xe4\xfd\xf5\x04\xbd\x49\xab\x67\x66\x6e\x4f\x93\x25\x0d\xd5\x16\x15\x13\xac\x0f\x1a\x85\x1d\xaf\x48\x7f\xfe\x70\x78\x42\xc7\x89\x5c\x7b\xe3\xb0\x56\x87\x57\xe2\x11\x27\xab\x79\x6c\xed\x5a\x1a\x41\xb0\xf8\x9e\x5f\x5b\x9b\x51\x7a\x45\x31\x56\xdd\x2b\xf3\x04\xa8\x05\x8a\xeb\x0b\x9e\xf4\xac\x37\xef\x96\x1e\xbe\xe8\x27\xca\x5f\xf9\x43\x1e\xbf\x97\x1e\xab\x0b\xfd\x3e\xb3\x6a\xa6\xd7\xd9\x26\xfb\x11\xc9\x59\x83\x0e\x9f\x58\x9f\xf6\xf0\x75\x0f\x5e\x3f\x63\x7d\x45\x18\x8f\xf5\x94\xb4\x9d\xa1\x33\x1c\xdb\xfc\xba\xf6\x5a\xfb\xb0\x8d\x65\xcb\xd0\x1d\xd5\xd1\xda\x85\x90\x9d\x1a\x16\xed\x21\xd7\xbe\xc8\xfc\x48\xdb\xd6\x4c\x09\xae\x37\x88\x75\x4e\xc8\xde\x84\xa7\xeb\x4b\xb0\xc8\xba\x2c\xe4\x7d\x97\xe1\x11\x72\x55\xf3\x22\xb3\xdd\x94\xa0\xa7\x52\xad\x94\xce\xc2\x11\x70\x99\xe1\xd1\x55\xd2\x65\xa0\x23\x3f\x99\x6a\x2b\x26\x4b\x7e\x38\xf9\xcd\x96\x8d\xd4\x4c\x6c\x1a\xde\xac\xd5\xe8\xd7\xe6\x36\x75\x51\xa9\x62\xb3\x42\x2e\xb7\x24\xe1\x09\xce\x1e\x6d\xf5\x1a\x2f\xd7\x74\x77\xb8\x0d\xed\x0a\xb1\xe9\xd4\xf6\x7c\x93\xb5\x35\x3b\x30\xb1\x36\x81\x55\x22\xcc\xde\xff\x15\x17\x04\xa9\xf4\x9c\x11\x3a\x36\xee\x04\xa3\x57\x2a\x02\x04\xb5\xf6\x9a\xc3\x10\x01\x4a\x53\xb2\x42\x36\x12\x00\xcb\xd2\x0d\x86\xa0\x97\x12\xd6\x7e\x68\x54\x7c\xd7\x82\xaf\x39\xd6\x0a\x35\x6f\x55\x81\x07\x58\xd7\xbf\xd3\xda\x30\x43\xfc\x0f\xdb\x3f\xcf\x97\x7e\xbe\x41\xfd\xb0\xef\xf6\xc6\x4a\xeb\xb4\xc8\x21\xf8\xa3\x76\x52\x6f\x95\xf6\x4e\x6b\x09\xcb\x56\x7c\x8d\x75\x27\x78\x6f\xdd\xe9\xd6\xd9\x0d\x8d\x7a\x8a\x76\x67\x63\x7a\xdd\x3e\x77\x22\x43\x2e\x4a\xe7\xda\x81\x9a\x6b\x0f\xb0\xc6\xa6\xb7\xa4\x82\xbb\x82\x45\xe4\xef\xba\xa8\x2b\x0d\x30\xda\x3a\xa9\x3d\x35\x55\xbe\xa2\x20\x8c\x30\x1e\x7f\x08\xa9\x57\xc9\x3a\x58\x1a\x7f\x10\xce\xab\x55\x2b\x6d\x7c\x6f\x30\x4b\xe5\xa9\xe6\x7a\xdd\xa3\x1f\x86\x6c\x18\xfb\xc1\x05\x9d\x54\xc5\x63\x0e\x17\xb8\xfe\x8a\xba\x1e\x52\x62\x3c\xc5\xd0\x63\x1b\xeb\x1b\x7d\xf5\xf8\x17\xaa\x93\x61\xbe\x34\x95\x0f\xc4\xd9\xdf\x65\xfa\xf5\x18\x0f\xe1\x34\x0b\xfa\x3a\x2e\x94\x5f\xab\x25\xeb\xac\x5d\xe6\x95\x9c\x6d\xde\xa4\x15\xce\xd5\x38\x2a\xaa\x07\xa0\x0d\x30\x25\x1f\xe5\xb4\x2c\x91\x12\x3b\x85\x5e\x95\x95\x2a\xe6\x50\x94\xd4\xa9\xc4\x6e\x29\x53\xf6\xc9\x1c\xd3\xc7\x0c\xdb\x44\x4a\x7f\xfe\x0a\x89\x02\xd7\xd6\x96\xa7\x22\x9d\x5a\xc2\xb4\xf3\xdf\x21\x4b\xdf\x45\x1c\x32\x5e\xbd\x18\xc1\x9c\xb2\x6f\x2c\x0c\x4a\x14\xd4\xc7\xe5\x22\x21\x02\xa6\x36\x89\x50\x5f\xbf\x42\x20\xd2\x4c\x9f\x86\xd7\x32\x31\x89\xc2\x30\xe6\x5a\x2c\xae\xf0\x94\x12\xbb\xac\x05\x56\x51\xae\x23\xab\xf2\xfe\xab\xbf\xdf\x04\x6d\x2e\x33\x41\x3b\x7f\xb3\xc8\x09\x76\xff\x7d\x52\x27\x37\x49\x5a\x0d\x10\x8f\xdb\x6e\x2f\x11\x17\x45\x48\x37\xaf\x29\xf2\xb0\xe2\x9e\x1f\x45\x20\xb3\x23\x22\x1a\x0c\x74\xd6\x4c\xfe\x55\x2b\x2f\xaa\x35\xad\xfd\xc6\x9a\x56\xe5\xab\xf6\x20\xd4\xe8\xc5\xd8\x59\x6d\xd0\x2a\x0f\x6f\x5f\x81\x52\x21\x8d\x6e\x44\x01\xca\xc4\x59\x21\xa7\x63\xe7\x2e\xf8\xa5\x1f\xff\xf3\xe4\x79\x96\x4e\x5e\xe0\x5e\x0b\x6e\xb8\x98\x69\x79\x88\x56\xe5\x26\xb7\x79\xad\x91\x88\x4e\xe5\x07\x77\x15\x22\xe1\x55\xc9\xd8\x12\xde\x8b\xf0\xee\xb1\x17\x1f\x5e\xbf\x82\x96\x88\x76\x5f\x23\xcd\x83\x2c\x9a\x16\xb9\x38\x08\xa6\xc0\xad\x73\xf2\x1f\xfc\x91\x38\x25\x2f\x40\x95\xbb\x8e\x2e\xbc\x8b\x18\x22\x8a\x9b\xe0\x9f\x27\x0a\x99\xba\xb7\x90\xad\xad\x45\xa6\xf2\xe3\xf8\x5c\x09\x73\x1a\x9d\x95\x54\x35\x9e\x24\xa9\x16\x43\x62\xd5\xad\xc9\x0e\xa3\x0c\xf3\x7a\xbf\xfa\x16\xab\x2d\xe7\x86\xbb\xd4\x10\x09\x9b\x94\x55\xf6\x52\x32\xa9\x8f\xae\x7d\xe0\x58\xf5\x48\x77\x90\x4c\xad\x6d\xa6\x0a\x02\x3c\x51\x8a\xee\x1e\xda\xcb\xe6\x54\x49\x73\x03\x3d\xa6\x9b\x3a\x50\x14\x1a\x3d\x94\x83\x9d\x5b\x83\xfd\x78\xc3\x60\x85\x5f\x67\x8f\xf6\x63\x39\xda\x8f\x37\x8f\x16\xab\x79\x97\x0e\x16\xeb\xe1\x0a\x16\xa7\xe9\x45\xae\x2e\xc0\x1d\xa5\xe9\xf9\x1c\xa9\x9d\xa7\x33\x71\xb9\xae\xc7\x36\xfb\xd3\x6b\xac\x62\xf3\x87\x18\x2d\xd2\x1d\xae\x78\x41\xaa\xbc\x8c\x8e\x76\x12\xf1\xaa\x0e\x1f\xdc\xb9\xdd\x3e\x5d\x84\xcb\xf5\xbd\xb8\xcb\x69\xd3\x52\xb1\x06\x9d\xdc\x38\x1e\xcd\x91\x46\xee\xde\x66\xa3\x52\x21\xd4\x06\x24\x1a\x25\xb0\x74\xf5\x6a\x87\x1d\x68\x0b\xfd\x06\x11\xb9\x11\x49\x69\x88\x6c\x0d\x6a\x29\xd7\x90\xb5\x1a\xa2\x6c\xa6\x45\xa3\x6a\x55\xcd\x15\xdd\xaa\x95\x31\xdf\x96\x33\x22\x5f\xae\x53\x69\x68\xe8\xf0\xa4\xea\xcd\x75\xaa\x77\x2e\x02\xf8\xab\x4a\xd2\xda\x2a\x4c\xcb\x2d\xa3\x06\x49\xa2\xcd\xf2\xca\x38\x0d\xb0\x3b\x15\x7a\xdf\x74\xfd\x6e\xb3\xf4\x34\x9f\x54\x90\x93\x6e\x64\x82\x6e\x9a\xf8\xdb\x4c\x3e\xfe\xd9\xb7\x45\xbf\xe7\xff\x9e\x81\x1b\xf1\xce\xa7\x4d\xf7\x32\xed\x56\xc2\xdf\xf7\xfc\xdf\xfd\xeb\xca\xb1\xc4\x59\x16\xef\x35\xe1\xb0\xe7\x05\x4f\x44\xee\x35\xd5\x98\x60\xf5\xd2\x27\x31\x63\x4d\xb5\xff\xb8\xae\x51\x1e\xb8\xe1\xe8\x58\x42\xb9\xe9\x36\x59\xba\xad\xb4\xb4\xcb\xdc\xc2\x7e\xcc\x67\x41\x40\xfb\x1f\xcb\x2e\x73\xd4\xe3\x6d\x17\x80\x7a\x25\x0d\xfe\x55\xa5\xd0\xbe\xc1\x56\xfd\x55\xdc\x8d\x56\xb8\x5b\x08\x6b\x8b\x62\x2c\xcc\x85\xfd\xbe\xbe\x87\x07\x4b\x08\xfc\x70\xae\x63\x05\x7d\xc2\x66\x7d\xfd\x44\x5c\x0d\x89\x25\x08\x74\x4a\x56\xa4\xd4\xd1\xcc\x83\x93\xb3\x4e\x37\x8f\x16\x29\x4b\xd2\x2b\x82\x97\x17\x9b\xd2\xed\x7c\x3a\x97\x2f\xfb\xc4\xaa\xa8\x59\x11\xbc\xb1\x3f\x02\x2c\x4a\xc1\xcf\x1f\x0e\x9f\x83\x6d\xff\x48\x57\xa2\x74\x59\xf9\xf6\x35\x98\x9e\xb1\xfd\x4a\x20\x35\xdf\xbc\x00\xf1\xcc\x2b\xed\xa2\x64\x56\xf0\xca\x4b\xb9\xd9\xa6\xcb\x39\x91\xa4\x28\x4f\x81\x1e\x41\x98\x57\xa4\x2f\x4f\xde\xaa\x3d\x87\x12\x06\x18\x80\x9d\x02\x1c\x40\x7b\xf9\x6c\x28\x6e\x39\x73\xfb\x5d\x7c\x16\xce\x55\x6f\x47\x36\xc0\x2a\xef\xa5\x57\xf0\x4a\x0e\x1e\x27\x21\xca\xbe\x63\x34\x53\x57\x36\xa9\x0e\x8d\x2f\x38\x07\xc6\x7b\x39\x3b\xea\x36\x2d\xb0\x24\x59\x39\x35\xe0\xd3\x8a\x3b\x61\x41\x8a\x67\x11\xf3\xcf\x61\x71\x12\xba\x08\x62\x3e\x9c\x44\x05\x56\xa8\x17\x78\x5d\x03\x26\x7d\xcf\x41\xce\xc6\xe2\x7a\x58\x30\x20\xc6\xb4\xe3\xa3\x3a\x0e\x8e\x93\x0c\x0c\xd4\x68\xcf\xa3\x2c\x2f\xe8\x16\x69\x15\xe8\xd1\xf4\x02\x19\x92\x51\x82\xa1\xba\xfa\x9e\x6e\x44\xc3\x35\xa9\x4e\xe4\x98\xa2\x0c\xae\xaf\x78\xdf\x02\x18\x9a\x26\xbd\x8c\xe5\xa0\x98\x20\x95\x27\x05\x84\x4c\x23\x8e\x0c\x7d\x59\xf0\x89\xbb\x9a\xe3\xd5\x9b\x16\x33\x57\x3b\x10\x15\x8b\xb0\x58\xcb\xfc\x3f\xfe\xc1\x7e\x20\x59\xf3\xa8\xa0\xef\x4e\xd8\xf0\x80\x98\x16\x55\x35\xba\xd2\x6a\x81\x53\xb6\x45\x41\x68\x69\x22\x0c\x16\xe8\x86\xed\x3d\x56\xbb\x33\xb7\x69\x75\x77\x40\x98\x92\x66\x93\x04\x2d\xe1\x40\x45\x95\xb4\xff\xa4\x09\x7f\x7b\x7e\x0e\x2d\xb5\xac\xdb\xe8\xe2\x38\x92\x5c\x76\xc9\xa7\x12\x30\x6d\x32\x5b\xa7\x54\x4b\xaa\x46\x6b\xa9\x4e\x79\xb3\x6d\x6f\x43\xad\x6d\xf7\xdd\xd5\x7b\xa0\x8a\xd0\x96\x44\xd1\x30\x36\xd5\x9d\x18\xbc\x51\xa4\x78\xd3\x68\x44\xf0\x4f\x7d\x5e\x36\x92\xaf\x1c\x8d\x46\xbd\x6c\x34\x0a\x7f\x65\x5a\xc5\x4d\x2f\x2d\x33\xdb\xda\x2a\x6f\x13\x86\xee\x6d\x88\x31\x4b\x7b\xf1\xff\xfe\x1f\x5c\xbb\xa8\x50\x46\x67\x00\x00")
 
 func webfilesSloop_uiJsBytes() ([]byte, error) {
 	return bindataRead(
@@ -357,8 +357,8 @@ func webfilesSloop_uiJs() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "webfiles/sloop_ui.js", size: 21722, mode: os.FileMode(0644), modTime: time.Unix(1641415852, 0)}
-	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xa0, 0xa0, 0x30, 0x48, 0xa6, 0x8d, 0xf1, 0x3d, 0xa3, 0xfd, 0x29, 0x48, 0x3e, 0x5e, 0x23, 0x39, 0xd7, 0x73, 0x2c, 0x62, 0xde, 0x23, 0x8, 0x5a, 0x43, 0xa6, 0x70, 0xb6, 0x6a, 0xd9, 0x20, 0xf4}}
+	info := bindataFileInfo{name: "webfiles/sloop_ui.js", size: 26438, mode: os.FileMode(0644), modTime: time.Unix(1791972136, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x52, 0x61, 0x32, 0x87, 0xd6, 0x33, 0x69, 0xc6, 0x95, 0x5c, 0xaa, 0x89, 0x26, 0x73, 0x61, 0x1b, 0xed, 0xa0, 0x3d, 0x39, 0x57, 0x23, 0x10, 0xbb, 0x5d, 0x91, 0x1c, 0x65, 0xf3, 0x3a, 0xab, 0xf7}}
 	return a, nil
 }
 
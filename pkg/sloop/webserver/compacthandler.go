@@ -0,0 +1,33 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	"net/http"
+
+	"github.com/salesforce/sloop/pkg/sloop/storemanager"
+)
+
+const compactPath = "/admin/compact"
+
+// POST only, since it can keep Badger busy for a long time.  Params: workers (default 4).  Returns a
+// storemanager.CompactionReport with the level sizes before and after
+func compactHandler(compactor *storemanager.Compactor) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method != http.MethodPost {
+			http.Error(writer, "compaction must be started with POST", http.StatusMethodNotAllowed)
+			return
+		}
+		report, err := compactor.Compact(numberFromParam(request, "workers", 4))
+		if err != nil {
+			logWebError(err, "Compaction failed", request, writer)
+			return
+		}
+		writeJson(writer, request, report)
+	}
+}
@@ -0,0 +1,38 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/salesforce/sloop/pkg/sloop/storemanager"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_compactHandler(t *testing.T) {
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	handler := compactHandler(storemanager.NewCompactor(db, "/store", &afero.Afero{Fs: afero.NewMemMapFs()}, 0.5))
+
+	recorder := httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodGet, compactPath, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodPost, compactPath+"?workers=2", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	report := storemanager.CompactionReport{}
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &report))
+	assert.Len(t, report.BeforeLevels, 1)
+}
@@ -15,12 +15,15 @@ import (
 	"github.com/golang/glog"
 	"github.com/pkg/errors"
 	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/processing"
 	"github.com/salesforce/sloop/pkg/sloop/store/typed"
 	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/salesforce/sloop/pkg/sloop/storemanager"
 	"html/template"
 	"net/http"
 	"regexp"
 	"strings"
+	"time"
 )
 
 type keyView struct {
@@ -79,6 +82,68 @@ func viewKeyHandler(tables typed.Tables) http.HandlerFunc {
 					return err
 				}
 				valueFromTable = *wa
+			} else if (&typed.QuarantineKey{}).ValidateKey(key) == nil {
+				qp, err := tables.QuarantineTable().Get(txn, key)
+				if err != nil {
+					return err
+				}
+				valueFromTable = *qp
+				data.ExtraName = "$.Payload"
+				data.ExtraValue = template.HTML(template.HTMLEscapeString(string(qp.Payload)))
+			} else if (&typed.EventFoldKey{}).ValidateKey(key) == nil {
+				ef, err := tables.EventFoldTable().Get(txn, key)
+				if err != nil {
+					return err
+				}
+				valueFromTable = *ef
+			} else if (&typed.PodLifecycleKey{}).ValidateKey(key) == nil {
+				pl, err := tables.PodLifecycleTable().Get(txn, key)
+				if err != nil {
+					return err
+				}
+				valueFromTable = *pl
+			} else if (&typed.NodeConditionKey{}).ValidateKey(key) == nil {
+				nc, err := tables.NodeConditionTable().Get(txn, key)
+				if err != nil {
+					return err
+				}
+				valueFromTable = *nc
+			} else if (&typed.OwnerEdgeKey{}).ValidateKey(key) == nil {
+				oe, err := tables.OwnerGraphTable().Get(txn, key)
+				if err != nil {
+					return err
+				}
+				valueFromTable = *oe
+			} else if (&typed.DeadLetterKey{}).ValidateKey(key) == nil {
+				dl, err := tables.DeadLetterTable().Get(txn, key)
+				if err != nil {
+					return err
+				}
+				valueFromTable = *dl
+			} else if (&typed.ServiceBackendsKey{}).ValidateKey(key) == nil {
+				sb, err := tables.ServiceBackendsTable().Get(txn, key)
+				if err != nil {
+					return err
+				}
+				valueFromTable = *sb
+			} else if (&typed.CurrentStateKey{}).ValidateKey(key) == nil {
+				cs, err := tables.CurrentStateTable().Get(txn, key)
+				if err != nil {
+					return err
+				}
+				valueFromTable = *cs
+			} else if extraTable := extraTableForKey(tables, key); extraTable != nil {
+				value, err := extraTable.Get(txn, key)
+				if err != nil {
+					return err
+				}
+				valueFromTable = value
+			} else if (&typed.IngestAnnotationKey{}).ValidateKey(key) == nil {
+				ingestAnnotation, err := tables.IngestAnnotationTable().Get(txn, key)
+				if err != nil {
+					return err
+				}
+				valueFromTable = *ingestAnnotation
 			} else {
 				return fmt.Errorf("Invalid key: %v", key)
 			}
@@ -137,7 +202,8 @@ func listKeysHandler(tables typed.Tables) http.HandlerFunc {
 		var tablesToSearch []string
 
 		if table == "all" {
-			tablesToSearch = append(tablesToSearch, "watch", "eventcount", "ressum", "watchactivity")
+			tablesToSearch = append(tablesToSearch, "watch", "eventcount", "ressum", "watchactivity", "quarantine", "eventfold", "podlifecycle", "nodecondition", "ownergraph", "deadletter", "servicebackends", "currentstate", "ingestannotation")
+			tablesToSearch = append(tablesToSearch, extraTableNames()...)
 		} else {
 			tablesToSearch = append(tablesToSearch, table)
 		}
@@ -187,6 +253,37 @@ func listKeysHandler(tables typed.Tables) http.HandlerFunc {
 					case "watchactivity":
 						key := &typed.WatchActivityKey{}
 						keys = append(keys, tables.WatchActivityTable().GetAllKeysForGivenPartitions(tables.Db(), key, maxRows, lookBack, keySearch)...)
+					case "quarantine":
+						key := &typed.QuarantineKey{}
+						keys = append(keys, tables.QuarantineTable().GetAllKeysForGivenPartitions(tables.Db(), key, maxRows, lookBack, keySearch)...)
+					case "eventfold":
+						key := &typed.EventFoldKey{}
+						keys = append(keys, tables.EventFoldTable().GetAllKeysForGivenPartitions(tables.Db(), key, maxRows, lookBack, keySearch)...)
+					case "podlifecycle":
+						key := &typed.PodLifecycleKey{}
+						keys = append(keys, tables.PodLifecycleTable().GetAllKeysForGivenPartitions(tables.Db(), key, maxRows, lookBack, keySearch)...)
+					case "nodecondition":
+						key := &typed.NodeConditionKey{}
+						keys = append(keys, tables.NodeConditionTable().GetAllKeysForGivenPartitions(tables.Db(), key, maxRows, lookBack, keySearch)...)
+					case "ownergraph":
+						key := &typed.OwnerEdgeKey{}
+						keys = append(keys, tables.OwnerGraphTable().GetAllKeysForGivenPartitions(tables.Db(), key, maxRows, lookBack, keySearch)...)
+					case "deadletter":
+						key := &typed.DeadLetterKey{}
+						keys = append(keys, tables.DeadLetterTable().GetAllKeysForGivenPartitions(tables.Db(), key, maxRows, lookBack, keySearch)...)
+					case "servicebackends":
+						key := &typed.ServiceBackendsKey{}
+						keys = append(keys, tables.ServiceBackendsTable().GetAllKeysForGivenPartitions(tables.Db(), key, maxRows, lookBack, keySearch)...)
+					case "currentstate":
+						key := &typed.CurrentStateKey{}
+						keys = append(keys, tables.CurrentStateTable().GetAllKeysForGivenPartitions(tables.Db(), key, maxRows, lookBack, keySearch)...)
+					default:
+						if extraTable := tables.ExtraTable(tablename); extraTable != nil {
+							keys = append(keys, extraTable.GetAllKeysForGivenPartitions(tables.Db(), maxRows, lookBack, keySearch)...)
+						}
+					case "ingestannotation":
+						key := &typed.IngestAnnotationKey{}
+						keys = append(keys, tables.IngestAnnotationTable().GetAllKeysForGivenPartitions(tables.Db(), key, maxRows, lookBack, keySearch)...)
 					}
 				}
 				count = len(keys)
@@ -211,6 +308,7 @@ func listKeysHandler(tables typed.Tables) http.HandlerFunc {
 		//To-do: Fix the Total Size of Matched Keys and Keys Searched for Partition search
 		var result keysData
 		result.Keys = keys
+		result.ExtraTables = extraTableNames()
 		result.TotalKeys = totalCount
 		result.TotalSize = totalSize
 		result.KeysMatched = count
@@ -248,6 +346,28 @@ type keysData struct {
 	TotalKeys   int
 	TotalSize   int64
 	KeysMatched int
+	// Tables registered with typed.RegisterTable, which the form lists after the core tables
+	ExtraTables []string
+}
+
+func extraTableNames() []string {
+	names := []string{}
+	for _, table := range typed.RegisteredTables() {
+		names = append(names, table.TableName())
+	}
+	return names
+}
+
+func extraTableForKey(tables typed.Tables, key string) *typed.ProtoTable {
+	parts := strings.Split(key, "/")
+	if len(parts) < 2 {
+		return nil
+	}
+	extraTable := tables.ExtraTable(parts[1])
+	if extraTable == nil || extraTable.ValidateKey(key) != nil {
+		return nil
+	}
+	return extraTable
 }
 
 func histogramHandler(tables typed.Tables) http.HandlerFunc {
@@ -371,6 +491,82 @@ func configHandler(config string) http.HandlerFunc {
 	}
 }
 
+// Returns the status of each processing stage as json
+func processingStatusHandler() http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		writeJson(writer, request, processing.GetStageStatus())
+	}
+}
+
+// Checks the signature of every stored watch result.  This reads the whole watch table so it can take a while
+func verifySignaturesHandler(tables typed.Tables, signingKey []byte) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		if len(signingKey) == 0 {
+			http.Error(writer, "watch result signing is not configured, set watchSigningKeyFile", http.StatusNotFound)
+			return
+		}
+		report, err := typed.VerifyWatchSignatures(tables.Db(), signingKey)
+		if err != nil {
+			logWebError(err, "failed to verify signatures", request, writer)
+			return
+		}
+		writeJson(writer, request, report)
+	}
+}
+
+// Returns a typed.ChecksumReport of the tables of closed partitions whose data no longer matches their checksum
+func verifyChecksumsHandler(db badgerwrap.DB) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		report, err := typed.VerifyPartitionChecksums(db)
+		if err != nil {
+			logWebError(err, "failed to verify checksums", request, writer)
+			return
+		}
+		writeJson(writer, request, report)
+	}
+}
+
+// Params: hours (default 24).  Returns a storemanager.BudgetReport of the kinds and namespaces that use the most storage
+func budgetReportHandler(tables typed.Tables) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		window := time.Duration(numberFromParam(request, "hours", int(storemanager.BudgetReportWindow.Hours()))) * time.Hour
+		report, err := storemanager.GenerateBudgetReport(tables, common.Now(), window)
+		if err != nil {
+			logWebError(err, "failed to generate budget report", request, writer)
+			return
+		}
+		writeJson(writer, request, report)
+	}
+}
+
+// Returns the typed.RecoveryReport of every store recovery done by -recover-store, newest first
+func recoveryReportsHandler(db badgerwrap.DB) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		var reports []*typed.RecoveryReport
+		err := db.View(func(txn badgerwrap.Txn) error {
+			var err error
+			reports, err = typed.OpenRecoveryTable().ReadAll(txn)
+			return err
+		})
+		if err != nil {
+			logWebError(err, "failed to read recovery reports", request, writer)
+			return
+		}
+		writeJson(writer, request, reports)
+	}
+}
+
+// Returns the storemanager.SelfTestReport of the startup self-test, with running set until it is done
+func selfTestHandler(selfTest *storemanager.SelfTest) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		if selfTest == nil || selfTest.Report() == nil {
+			http.Error(writer, "the startup self-test is off, see -self-test-samples", http.StatusNotFound)
+			return
+		}
+		writeJson(writer, request, selfTest.Report())
+	}
+}
+
 func debugHandler() http.HandlerFunc {
 	return func(writer http.ResponseWriter, request *http.Request) {
 		debugTemplate, err := getTemplate(debugTemplateFile, _webfilesDebugHtml)
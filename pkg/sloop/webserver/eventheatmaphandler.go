@@ -0,0 +1,48 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/salesforce/sloop/pkg/sloop/queries"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+)
+
+const eventHeatMapPath = "/api/v1/eventheatmap"
+
+// Returns the event counts of each namespace by time bucket and event type, as a queries.NamespaceHeatMapOutput.
+// Params: the time range of the queries, namespace, which defaults to all of them, and bucket, a duration that is a
+// multiple of 5m.  Not queued by the query scheduler, since it reads a few precomputed keys per partition and the
+// landing page should not wait behind slow queries for it
+func eventHeatMapHandler(tables typed.Tables, maxLookBack time.Duration) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		startTime, endTime, err := queries.ComputeTimeRange(request.URL.Query(), tables, maxLookBack)
+		if err != nil {
+			writeApiError(writer, request, err, "Invalid time range")
+			return
+		}
+		bucket, err := durationFromParam(request, queries.BucketParam, 0)
+		if err != nil {
+			writeApiErrorf(writer, request, queries.ErrorCodeBadParams, "invalid %v: %v", queries.BucketParam, err)
+			return
+		}
+		namespace := request.URL.Query().Get(queries.NamespaceParam)
+		if namespace == queries.AllNamespaces {
+			namespace = ""
+		}
+
+		output, err := queries.GetNamespaceEventHeatMap(tables, startTime, endTime, bucket, namespace)
+		if err != nil {
+			writeApiError(writer, request, err, "Failed to read event heat map")
+			return
+		}
+		writeJson(writer, request, output)
+	}
+}
@@ -0,0 +1,71 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/salesforce/sloop/pkg/sloop/queries"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_eventHeatMapHandler(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	ts := time.Date(2019, 3, 1, 3, 5, 0, 0, time.UTC)
+	table := typed.OpenEventHeatMapTable()
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		for _, namespace := range []string{"ns-a", "ns-b"} {
+			record := &typed.ResourceEventCounts{MapMinToEvents: map[int64]*typed.EventCounts{ts.Unix(): {MapReasonToCount: map[string]int32{"Warning": 2}}}}
+			err := table.Set(txn, table.Key(ts, namespace), record)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+	handler := eventHeatMapHandler(tables, 14*24*time.Hour)
+
+	recorder := httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodGet, eventHeatMapPath, nil))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	rangeParams := fmt.Sprintf("start_time=%v&end_time=%v", ts.Add(-time.Hour).Unix(), ts.Add(time.Hour).Unix())
+	recorder = httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodGet, eventHeatMapPath+"?"+rangeParams+"&bucket=3m", nil))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodGet, eventHeatMapPath+"?"+rangeParams+"&namespace=_all", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	output := queries.NamespaceHeatMapOutput{}
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &output))
+	assert.Equal(t, int64(300), output.BucketSeconds)
+	assert.Len(t, output.Namespaces, 2)
+
+	recorder = httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodGet, eventHeatMapPath+"?"+rangeParams+"&namespace=ns-b&bucket=1h", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	output = queries.NamespaceHeatMapOutput{}
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &output))
+	assert.Equal(t, []queries.NamespaceHeatMapRow{{Namespace: "ns-b", Total: 2, Cells: []queries.NamespaceHeatMapCell{
+		{Timestamp: ts.Truncate(time.Hour).Unix(), Counts: map[string]int64{"Warning": 2}},
+	}}}, output.Namespaces)
+}
@@ -0,0 +1,76 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/golang/glog"
+
+	"github.com/salesforce/sloop/pkg/sloop/export"
+	"github.com/salesforce/sloop/pkg/sloop/queries"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+)
+
+const (
+	exportSpillParam = "spill"
+	anonymizeParam   = "anonymize"
+)
+
+var errNoAnonymizer = queries.NewApiError(queries.ErrorCodeBadParams, "anonymization is not configured, start sloop with -anonymization-salt-file")
+
+// Streams watch results as json lines.  Params: kind (zero or more), optional namespace, spill=true to buffer on
+// disk instead of in memory, anonymize=true to hash names and drop annotations, and the usual time range params
+func exportHandler(exporter *export.Exporter, tables typed.Tables, maxLookBack time.Duration, anonymizer *export.Anonymizer) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		err := request.ParseForm()
+		if err != nil {
+			writeApiErrorf(writer, request, queries.ErrorCodeBadParams, "failed to parse form: %v", err)
+			return
+		}
+
+		params := request.Form
+		startTime, endTime, err := queries.ComputeTimeRange(params, tables, maxLookBack)
+		if err != nil {
+			writeApiError(writer, request, err, "Invalid time range")
+			return
+		}
+
+		req := export.Request{
+			StartTime: startTime,
+			EndTime:   endTime,
+			Spill:     params.Get(exportSpillParam) == "true",
+		}
+		if params.Get(anonymizeParam) == "true" {
+			if anonymizer == nil {
+				writeApiError(writer, request, errNoAnonymizer, "Can not anonymize export")
+				return
+			}
+			req.Anonymizer = anonymizer
+		}
+		for _, kind := range params[queries.KindParam] {
+			if kind != "" && kind != queries.AllKinds {
+				req.Kinds = append(req.Kinds, kind)
+			}
+		}
+		if namespace := params.Get(queries.NamespaceParam); namespace != queries.AllNamespaces {
+			req.Namespace = namespace
+		}
+
+		setRetentionHeaders(writer, tables, params, startTime, maxLookBack)
+		writer.Header().Set("content-type", "application/x-ndjson")
+		count, err := exporter.Export(writer, req)
+		if err != nil {
+			// Part of the body might already be written so the status can not be changed
+			glog.Errorf("Export %q failed after %v watch results: %v", request.URL, count, err)
+			return
+		}
+		glog.Infof("Exported %v watch results for %q", count, request.URL)
+	}
+}
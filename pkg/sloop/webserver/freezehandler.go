@@ -0,0 +1,130 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/queries"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/storemanager"
+)
+
+const (
+	freezePath   = "/admin/freeze"
+	unfreezePath = "/admin/unfreeze"
+	reasonParam  = "reason"
+)
+
+// GET lists the freezes as storemanager.FreezeReport.  POST freezes a range of partitions so GC keeps them.  Params:
+// name, from and to partition ids or start_time and end_time in unix seconds (to defaults to from), and reason.
+// Returns a storemanager.FreezeReport
+func freezeHandler(freezer *storemanager.Freezer) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method == http.MethodGet {
+			freezes, err := freezer.List()
+			if err != nil {
+				logWebError(err, "Failed to list freezes", request, writer)
+				return
+			}
+			writeJson(writer, request, freezes)
+			return
+		}
+		if request.Method != http.MethodPost {
+			http.Error(writer, "freezes must be created with POST", http.StatusMethodNotAllowed)
+			return
+		}
+		err := request.ParseForm()
+		if err != nil {
+			logWebError(err, "Failed to parse form", request, writer)
+			return
+		}
+		name := request.Form.Get(queries.NameParam)
+		if name == "" {
+			http.Error(writer, "name is required", http.StatusBadRequest)
+			return
+		}
+		from, err := freezePartitionParam(request, "from", queries.StartTimeParam)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if from == "" {
+			http.Error(writer, "from or start_time is required", http.StatusBadRequest)
+			return
+		}
+		to, err := freezePartitionParam(request, "to", queries.EndTimeParam)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if to == "" {
+			to = from
+		}
+		if from > to {
+			http.Error(writer, "from is after to", http.StatusBadRequest)
+			return
+		}
+		report, err := freezer.Freeze(name, from, to, request.Form.Get(reasonParam), common.Now())
+		if err != nil {
+			logWebError(err, "Freeze failed", request, writer)
+			return
+		}
+		writeJson(writer, request, report)
+	}
+}
+
+// The partition id in the param, or the one of the unix seconds in timeParam.  Empty when neither is set
+func freezePartitionParam(request *http.Request, param string, timeParam string) (string, error) {
+	if partitionId := request.Form.Get(param); partitionId != "" {
+		return partitionId, storemanager.ValidatePartitionId(partitionId)
+	}
+	seconds := request.Form.Get(timeParam)
+	if seconds == "" {
+		return "", nil
+	}
+	unix, err := strconv.ParseInt(seconds, 10, 64)
+	if err != nil {
+		return "", err
+	}
+	return untyped.GetPartitionId(time.Unix(unix, 0).UTC()), nil
+}
+
+// POST only.  Params: name.  Removes the freeze so GC can collect its partitions again.  Returns the removed
+// storemanager.FreezeReport, 404 when there is no freeze with the name
+func unfreezeHandler(freezer *storemanager.Freezer) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method != http.MethodPost {
+			http.Error(writer, "unfreeze must be started with POST", http.StatusMethodNotAllowed)
+			return
+		}
+		err := request.ParseForm()
+		if err != nil {
+			logWebError(err, "Failed to parse form", request, writer)
+			return
+		}
+		name := request.Form.Get(queries.NameParam)
+		if name == "" {
+			http.Error(writer, "name is required", http.StatusBadRequest)
+			return
+		}
+		report, err := freezer.Unfreeze(name)
+		if err != nil {
+			logWebError(err, "Unfreeze failed", request, writer)
+			return
+		}
+		if report == nil {
+			http.Error(writer, "no freeze named "+name, http.StatusNotFound)
+			return
+		}
+		writeJson(writer, request, report)
+	}
+}
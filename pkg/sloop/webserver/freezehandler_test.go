@@ -0,0 +1,67 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/salesforce/sloop/pkg/sloop/storemanager"
+)
+
+func Test_freezeHandler(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	freezer := storemanager.NewFreezer(db)
+	freeze := freezeHandler(freezer)
+	unfreeze := unfreezeHandler(freezer)
+	start := time.Date(2021, 3, 4, 5, 10, 0, 0, time.UTC)
+
+	for _, query := range []string{"", "?from=000000000012", "?name=inc-42", "?name=inc-42&from=1614834000&to=x", "?name=inc-42&start_time=1614834000&end_time=1614830400"} {
+		recorder := httptest.NewRecorder()
+		freeze(recorder, httptest.NewRequest(http.MethodPost, freezePath+query, nil))
+		assert.Equal(t, http.StatusBadRequest, recorder.Code, query)
+	}
+
+	recorder := httptest.NewRecorder()
+	freeze(recorder, httptest.NewRequest(http.MethodPost, fmt.Sprintf("%v?name=inc-42&start_time=%v&end_time=%v&reason=outage", freezePath, start.Unix(), start.Add(2*time.Hour).Unix()), nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	report := storemanager.FreezeReport{}
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &report))
+	assert.Equal(t, untyped.GetPartitionId(start), report.FromPartition)
+	assert.Equal(t, untyped.GetPartitionId(start.Add(2*time.Hour)), report.ToPartition)
+	assert.Equal(t, "outage", report.Reason)
+
+	recorder = httptest.NewRecorder()
+	freeze(recorder, httptest.NewRequest(http.MethodGet, freezePath, nil))
+	freezes := []storemanager.FreezeReport{}
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &freezes))
+	assert.Len(t, freezes, 1)
+
+	recorder = httptest.NewRecorder()
+	unfreeze(recorder, httptest.NewRequest(http.MethodGet, unfreezePath+"?name=inc-42", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	unfreeze(recorder, httptest.NewRequest(http.MethodPost, unfreezePath+"?name=inc-42", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	unfreeze(recorder, httptest.NewRequest(http.MethodPost, unfreezePath+"?name=inc-42", nil))
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+}
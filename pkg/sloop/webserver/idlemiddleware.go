@@ -0,0 +1,46 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/salesforce/sloop/pkg/sloop/idle"
+)
+
+// Requests machines send all the time, which would keep sloop from ever going idle
+var idleIgnoredPaths = []string{"/healthz", "/metrics", "/debug/vars", "/sync/", "/standby/"}
+
+// Every other request counts as use and wakes sloop up from idle mode before it is served
+func idleMiddleware(monitor *idle.Monitor) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		if monitor == nil {
+			return next
+		}
+		return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			if !isIdleIgnoredPath(request.URL.Path) {
+				monitor.Touch(time.Now())
+			}
+			next.ServeHTTP(writer, request)
+		})
+	}
+}
+
+// Paths are under the cluster context, like /mycluster/healthz
+func isIdleIgnoredPath(path string) bool {
+	for _, ignored := range idleIgnoredPaths {
+		if strings.HasSuffix(path, ignored) || (strings.HasSuffix(ignored, "/") && strings.Contains(path, ignored)) {
+			return true
+		}
+	}
+	return false
+}
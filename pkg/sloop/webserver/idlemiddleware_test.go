@@ -0,0 +1,34 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/idle"
+)
+
+func Test_idleMiddleware(t *testing.T) {
+	monitor := idle.NewMonitor(time.Hour, 4, time.Now().Add(-2*time.Hour))
+	assert.True(t, monitor.Check(time.Now()))
+	handler := idleMiddleware(monitor)(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {}))
+
+	for _, path := range []string{"/ctx/healthz", "/ctx/metrics", "/ctx/sync/keys", "/ctx/standby/load"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", path, nil))
+		assert.True(t, monitor.IsIdle(), path)
+	}
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/ctx/data?query=EventHeatMap", nil))
+	assert.False(t, monitor.IsIdle())
+
+	assert.NotNil(t, idleMiddleware(nil)(handler))
+}
@@ -17,6 +17,8 @@ type indexData struct {
 	DefaultKind      string
 	LeftBarLinks     []ComputedLink
 	CurrentContext   string
+	// A query-only frontend of shards, which can merge the same resource from each shard into one row
+	Sharded bool
 }
 
 func indexHandler(config WebConfig) http.HandlerFunc {
@@ -31,6 +33,7 @@ func indexHandler(config WebConfig) http.HandlerFunc {
 		data.DefaultNamespace = config.DefaultNamespace
 		data.DefaultKind = config.DefaultResources
 		data.CurrentContext = config.CurrentContext
+		data.Sharded = len(config.ShardEndpoints) > 0
 		data.LeftBarLinks, err = makeLeftBarLinks(config.LeftBarLinks)
 		if err != nil {
 			logWebError(err, "Could not make left bar links", request, writer)
@@ -0,0 +1,112 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/queries"
+	"github.com/salesforce/sloop/pkg/sloop/storemanager"
+)
+
+const (
+	purgePath    = "/admin/purge"
+	undeletePath = "/admin/undelete"
+	softParam    = "soft"
+)
+
+// GET lists the namespaces with soft deleted keys as storemanager.PurgedNamespace.  POST purges the keys of one
+// namespace.  Params: namespace and soft, which defaults to true when soft deletes are enabled.  Returns a
+// storemanager.PurgeReport
+func purgeHandler(purger *storemanager.Purger) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method == http.MethodGet {
+			purged, err := purger.ListPurged()
+			if err != nil {
+				writeApiError(writer, request, err, "Failed to list soft deleted namespaces")
+				return
+			}
+			writeJson(writer, request, purged)
+			return
+		}
+		if request.Method != http.MethodPost {
+			writeApiErrorf(writer, request, queries.ErrorCodeMethodNotAllowed, "purges must be started with POST")
+			return
+		}
+		err := request.ParseForm()
+		if err != nil {
+			writeApiErrorf(writer, request, queries.ErrorCodeBadParams, "failed to parse form: %v", err)
+			return
+		}
+		namespace, err := purgeNamespaceParam(request)
+		if err != nil {
+			writeApiError(writer, request, err, "")
+			return
+		}
+		soft := purger.SoftDeleteEnabled()
+		if softStr := request.Form.Get(softParam); softStr != "" {
+			soft, err = strconv.ParseBool(softStr)
+			if err != nil {
+				writeApiErrorf(writer, request, queries.ErrorCodeBadParams, "invalid %v: %v", softParam, err)
+				return
+			}
+			if soft && !purger.SoftDeleteEnabled() {
+				writeApiErrorf(writer, request, queries.ErrorCodeBadParams, "soft deletes are disabled, see -purge-soft-delete-ttl")
+				return
+			}
+		}
+		report, err := purger.PurgeNamespace(namespace, soft, common.Now())
+		if err != nil {
+			writeApiError(writer, request, err, "Purge failed")
+			return
+		}
+		writeJson(writer, request, report)
+	}
+}
+
+// POST only.  Params: namespace.  Restores the soft deleted keys of the namespace and returns a
+// storemanager.PurgeReport, with no keys when there was nothing to restore
+func undeleteHandler(purger *storemanager.Purger) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method != http.MethodPost {
+			writeApiErrorf(writer, request, queries.ErrorCodeMethodNotAllowed, "undelete must be started with POST")
+			return
+		}
+		err := request.ParseForm()
+		if err != nil {
+			writeApiErrorf(writer, request, queries.ErrorCodeBadParams, "failed to parse form: %v", err)
+			return
+		}
+		namespace, err := purgeNamespaceParam(request)
+		if err != nil {
+			writeApiError(writer, request, err, "")
+			return
+		}
+		report, err := purger.Undelete(namespace)
+		if err != nil {
+			writeApiError(writer, request, err, "Undelete failed")
+			return
+		}
+		writeJson(writer, request, report)
+	}
+}
+
+// The purger keys soft deleted data by namespace, so a name with a slash would match keys of other namespaces
+func purgeNamespaceParam(request *http.Request) (string, error) {
+	namespace := request.Form.Get(queries.NamespaceParam)
+	if namespace == "" {
+		return "", queries.NewApiError(queries.ErrorCodeBadParams, "%v is required", queries.NamespaceParam)
+	}
+	if strings.Contains(namespace, "/") {
+		return "", queries.NewApiError(queries.ErrorCodeBadParams, "invalid %v %q", queries.NamespaceParam, namespace)
+	}
+	return namespace, nil
+}
@@ -0,0 +1,87 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/salesforce/sloop/pkg/sloop/queries"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/salesforce/sloop/pkg/sloop/storemanager"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_purgeHandler(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	ts := time.Now()
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		key := typed.NewWatchTableKey(untyped.GetPartitionId(ts), "Pod", "ns", "pod-a", ts)
+		return tables.WatchTable().Set(txn, key.String(), &typed.KubeWatchResult{Kind: "Pod", Payload: `{}`})
+	})
+	assert.Nil(t, err)
+	purger := storemanager.NewPurger(tables, 100, time.Hour)
+	purge := purgeHandler(purger)
+	undelete := undeleteHandler(purger)
+
+	for _, test := range []struct {
+		method string
+		url    string
+		code   queries.ErrorCode
+	}{
+		{http.MethodPost, purgePath, queries.ErrorCodeBadParams},
+		{http.MethodPost, purgePath + "?namespace=ns/other", queries.ErrorCodeBadParams},
+		{http.MethodPost, purgePath + "?namespace=ns&soft=maybe", queries.ErrorCodeBadParams},
+		{http.MethodDelete, purgePath + "?namespace=ns", queries.ErrorCodeMethodNotAllowed},
+		{http.MethodPost, undeletePath, queries.ErrorCodeBadParams},
+	} {
+		recorder := httptest.NewRecorder()
+		if test.url == undeletePath {
+			undelete(recorder, httptest.NewRequest(test.method, test.url, nil))
+		} else {
+			purge(recorder, httptest.NewRequest(test.method, test.url, nil))
+		}
+		assert.Equal(t, test.code.Status(), recorder.Code, test.url)
+		envelope := queries.ErrorEnvelope{}
+		assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &envelope), test.url)
+		assert.Equal(t, test.code, envelope.Error.Code, test.url)
+	}
+
+	recorder := httptest.NewRecorder()
+	purge(recorder, httptest.NewRequest(http.MethodPost, purgePath+"?namespace=ns", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	report := storemanager.PurgeReport{}
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &report))
+	assert.True(t, report.Soft)
+	assert.Equal(t, uint64(1), report.Keys)
+
+	recorder = httptest.NewRecorder()
+	purge(recorder, httptest.NewRequest(http.MethodGet, purgePath, nil))
+	purged := []storemanager.PurgedNamespace{}
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &purged))
+	assert.Len(t, purged, 1)
+
+	recorder = httptest.NewRecorder()
+	undelete(recorder, httptest.NewRequest(http.MethodGet, undeletePath+"?namespace=ns", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	undelete(recorder, httptest.NewRequest(http.MethodPost, undeletePath+"?namespace=ns", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &report))
+	assert.Equal(t, uint64(1), report.Keys)
+}
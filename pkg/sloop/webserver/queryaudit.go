@@ -0,0 +1,180 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/salesforce/sloop/pkg/sloop/queries"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+var metricQueryAuditFailureCount = promauto.NewCounter(prometheus.CounterOpts{Name: "sloop_query_audit_failure_count"})
+
+const defaultQueryAuditLimit = 100
+
+/*
+Records who ran each query and export, with its params, duration, status and response size, in the query audit
+table so they are still there after a restart.  The table keeps the last size records.  Requests denied by the
+tenancy checks never reach it, those are only logged
+*/
+type queryAuditLog struct {
+	lock    *sync.Mutex
+	db      badgerwrap.DB
+	table   *typed.QueryAuditTable
+	size    uint64
+	nextSeq uint64
+}
+
+// Returns nil when size is 0, and the handlers it wraps are then not audited
+func newQueryAuditLog(db badgerwrap.DB, size int) *queryAuditLog {
+	if size <= 0 {
+		return nil
+	}
+	a := &queryAuditLog{lock: &sync.Mutex{}, db: db, table: typed.OpenQueryAuditTable(), size: uint64(size)}
+	err := db.Update(func(txn badgerwrap.Txn) error {
+		err := a.table.Trim(txn, a.size)
+		if err != nil {
+			return err
+		}
+		records, err := a.table.ReadAll(txn)
+		if err != nil {
+			return err
+		}
+		if len(records) > 0 {
+			a.nextSeq = records[0].Seq + 1
+		}
+		return nil
+	})
+	if err != nil {
+		// New records start from the first slot and overwrite the old ones out of order
+		glog.Errorf("Failed to read the query audit log: %v", err)
+	}
+	return a
+}
+
+func (a *queryAuditLog) wrap(handler http.HandlerFunc) http.HandlerFunc {
+	if a == nil {
+		return handler
+	}
+	return func(writer http.ResponseWriter, request *http.Request) {
+		before := time.Now()
+		audited := &auditResponseWriter{ResponseWriter: writer, status: http.StatusOK}
+		handler(audited, request)
+
+		// Exports take their params from a form, which the handler has parsed by now
+		params := request.Form
+		if params == nil {
+			params = request.URL.Query()
+		}
+		ts, _ := ptypes.TimestampProto(before)
+		record := &typed.QueryAuditRecord{
+			Timestamp:      ts,
+			Path:           pathBelowContext(request.URL.Path),
+			Query:          params.Get(queries.QueryParam),
+			Params:         params.Encode(),
+			DurationMillis: time.Since(before).Milliseconds(),
+			ResultBytes:    audited.bytes,
+			Status:         int32(audited.status),
+			RequestId:      getRequestId(request.Context()),
+			RemoteAddr:     request.RemoteAddr,
+		}
+		if identity := IdentityFromContext(request.Context()); identity != nil {
+			record.User = identity.User
+		}
+		if tenantName, ok := request.Context().Value(tenantContextKey{}).(string); ok {
+			record.Tenant = tenantName
+		}
+		err := a.add(record)
+		if err != nil {
+			metricQueryAuditFailureCount.Inc()
+			glog.Errorf("Failed to write query audit record for %v: %v", record.RequestId, err)
+		}
+	}
+}
+
+func (a *queryAuditLog) add(record *typed.QueryAuditRecord) error {
+	a.lock.Lock()
+	record.Seq = a.nextSeq
+	a.nextSeq++
+	a.lock.Unlock()
+	return a.db.Update(func(txn badgerwrap.Txn) error {
+		return a.table.Set(txn, record.Seq%a.size, record)
+	})
+}
+
+// Newest first, optionally only the records of one user or query
+func (a *queryAuditLog) read(user string, queryName string, limit int) ([]*typed.QueryAuditRecord, error) {
+	var records []*typed.QueryAuditRecord
+	err := a.db.View(func(txn badgerwrap.Txn) error {
+		var err error
+		records, err = a.table.ReadAll(txn)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	matching := []*typed.QueryAuditRecord{}
+	for _, record := range records {
+		if len(matching) >= limit {
+			break
+		}
+		if (user == "" || record.User == user) && (queryName == "" || record.Query == queryName) {
+			matching = append(matching, record)
+		}
+	}
+	return matching, nil
+}
+
+type auditResponseWriter struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+func (w *auditResponseWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *auditResponseWriter) Write(data []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(data)
+	w.bytes += int64(n)
+	return n, err
+}
+
+// Exports stream, so flushes have to get through
+func (w *auditResponseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Params: optional user, query and limit
+func queryAuditHandler(auditLog *queryAuditLog) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		if auditLog == nil {
+			http.Error(writer, "the query audit log is off, see -query-audit-size", http.StatusNotFound)
+			return
+		}
+		records, err := auditLog.read(request.URL.Query().Get("user"), request.URL.Query().Get(queries.QueryParam), numberFromParam(request, "limit", defaultQueryAuditLimit))
+		if err != nil {
+			logWebError(err, "failed to read the query audit log", request, writer)
+			return
+		}
+		writeJson(writer, request, records)
+	}
+}
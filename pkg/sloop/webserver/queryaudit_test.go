@@ -0,0 +1,137 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+func Test_queryAuditLog_Wrap(t *testing.T) {
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	assert.Nil(t, newQueryAuditLog(db, 0))
+	auditLog := newQueryAuditLog(db, 2)
+
+	inner := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("query") == "Bad" {
+			http.Error(w, "bad query", http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte("12345"))
+	}
+	handler := auditLog.wrap(inner)
+	for _, url := range []string{"/ctx/data?query=Kinds", "/ctx/data?query=Bad", "/ctx/data?query=EventHeatMap&namespace=billing"} {
+		request := httptest.NewRequest(http.MethodGet, url, nil)
+		ctx := context.WithValue(request.Context(), identityContextKey{}, &Identity{User: "alice"})
+		ctx = context.WithValue(ctx, tenantContextKey{}, "payments")
+		handler(httptest.NewRecorder(), request.WithContext(ctx))
+	}
+
+	// Only the last two are kept
+	records, err := auditLog.read("", "", 10)
+	assert.Nil(t, err)
+	assert.Len(t, records, 2)
+	assert.Equal(t, uint64(2), records[0].Seq)
+	assert.Equal(t, "EventHeatMap", records[0].Query)
+	assert.Equal(t, "namespace=billing&query=EventHeatMap", records[0].Params)
+	assert.Equal(t, "/data", records[0].Path)
+	assert.Equal(t, "alice", records[0].User)
+	assert.Equal(t, "payments", records[0].Tenant)
+	assert.Equal(t, int64(5), records[0].ResultBytes)
+	assert.Equal(t, int32(http.StatusOK), records[0].Status)
+	assert.Equal(t, int32(http.StatusInternalServerError), records[1].Status)
+
+	records, err = auditLog.read("", "Bad", 10)
+	assert.Nil(t, err)
+	assert.Len(t, records, 1)
+	records, err = auditLog.read("bob", "", 10)
+	assert.Nil(t, err)
+	assert.Len(t, records, 0)
+
+	// Carries on from the stored records after a restart, and drops the slots of a larger log
+	auditLog = newQueryAuditLog(db, 1)
+	assert.Equal(t, uint64(3), auditLog.nextSeq)
+	records, err = auditLog.read("", "", 10)
+	assert.Nil(t, err)
+	assert.Len(t, records, 1)
+}
+
+func Test_queryAuditHandler(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	queryAuditHandler(nil)(recorder, httptest.NewRequest(http.MethodGet, "/ctx/debug/queryaudit/", nil))
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	auditLog := newQueryAuditLog(db, 10)
+	for _, user := range []string{"alice", "bob", "alice"} {
+		assert.Nil(t, auditLog.add(&typed.QueryAuditRecord{User: user}))
+	}
+	recorder = httptest.NewRecorder()
+	queryAuditHandler(auditLog)(recorder, httptest.NewRequest(http.MethodGet, "/ctx/debug/queryaudit/?user=alice&limit=1", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	records := []*typed.QueryAuditRecord{}
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &records))
+	assert.Len(t, records, 1)
+	assert.Equal(t, uint64(2), records[0].Seq)
+}
+
+func Test_auditExportHandler(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	auditExportHandler(nil, "ctx")(recorder, httptest.NewRequest(http.MethodGet, "/ctx/admin/audit/export", nil))
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	auditLog := newQueryAuditLog(db, 10)
+	first, _ := ptypes.TimestampProto(time.Date(2021, 3, 4, 10, 0, 0, 0, time.UTC))
+	second, _ := ptypes.TimestampProto(time.Date(2021, 3, 4, 11, 0, 0, 0, time.UTC))
+	third, _ := ptypes.TimestampProto(time.Date(2021, 3, 4, 12, 0, 0, 0, time.UTC))
+	assert.Nil(t, auditLog.add(&typed.QueryAuditRecord{Timestamp: first, User: "alice", Tenant: "payments", Path: "/data", Query: "EventHeatMap",
+		Params: "namespace=billing&lookback=1h&query=EventHeatMap", Status: http.StatusOK, ResultBytes: 10}))
+	assert.Nil(t, auditLog.add(&typed.QueryAuditRecord{Timestamp: second, User: "alice", Path: "/export",
+		Params: "namespace=_all&start_time=1614729600&end_time=1614733200", Status: http.StatusOK}))
+	assert.Nil(t, auditLog.add(&typed.QueryAuditRecord{Timestamp: third, User: "bob", Path: "/data", Query: "Kinds", Status: http.StatusOK}))
+
+	recorder = httptest.NewRecorder()
+	auditExportHandler(auditLog, "ctx")(recorder, httptest.NewRequest(http.MethodGet, "/ctx/admin/audit/export?user=alice", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "text/csv", recorder.Header().Get("content-type"))
+	rows, err := csv.NewReader(recorder.Body).ReadAll()
+	assert.Nil(t, err)
+	assert.Equal(t, [][]string{
+		auditExportColumns,
+		{"2021-03-04T10:00:00Z", "alice", "payments", "", "", "/data", "EventHeatMap", "billing", "2021-03-04T09:00:00Z", "2021-03-04T10:00:00Z", "200", "10"},
+		{"2021-03-04T11:00:00Z", "alice", "", "", "", "/export", "", "*", "2021-03-03T00:00:00Z", "2021-03-03T01:00:00Z", "200", "0"},
+	}, rows)
+
+	recorder = httptest.NewRecorder()
+	auditExportHandler(auditLog, "ctx")(recorder, httptest.NewRequest(http.MethodGet, "/ctx/admin/audit/export?format=jsonl&start_time=1614859200", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	row := AuditExportRow{}
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &row))
+	assert.Equal(t, "bob", row.User)
+	assert.Equal(t, "", row.HistoryStart)
+
+	recorder = httptest.NewRecorder()
+	auditExportHandler(auditLog, "ctx")(recorder, httptest.NewRequest(http.MethodGet, "/ctx/admin/audit/export?format=xml", nil))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+}
@@ -0,0 +1,169 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/salesforce/sloop/pkg/sloop/queries"
+	"github.com/salesforce/sloop/pkg/sloop/tenant"
+)
+
+var (
+	metricQueryQueued         = promauto.NewGauge(prometheus.GaugeOpts{Name: "sloop_query_queued"})
+	metricQueryRunning        = promauto.NewGauge(prometheus.GaugeOpts{Name: "sloop_query_running"})
+	metricQueryQueueWait      = promauto.NewGauge(prometheus.GaugeOpts{Name: "sloop_query_queue_wait_sec"})
+	metricQueryAbandonedCount = promauto.NewCounter(prometheus.CounterOpts{Name: "sloop_query_abandoned_count"})
+)
+
+/*
+Limits how many queries and exports run at once and hands out the slots by weighted fair queuing, so one caller
+sending a lot of heavy requests waits behind its own requests instead of in front of everybody else's.  Callers are
+the tenant of the request, or the user when there are no tenants.  Each caller has a virtual finish time that moves
+forward by 1/weight for every slot it gets, and a free slot goes to the waiting caller with the lowest one.  Callers
+that were idle start from the current virtual time, so they can not save up slots
+*/
+type queryScheduler struct {
+	lock    *sync.Mutex
+	free    int
+	virtual float64
+	flows   map[string]*queryFlow
+	tenants tenant.Map
+}
+
+type queryFlow struct {
+	finish  float64
+	waiting []chan struct{}
+}
+
+// Returns nil when maxConcurrent is 0, and the handlers it wraps then run without a limit
+func newQueryScheduler(maxConcurrent int, tenants tenant.Map) *queryScheduler {
+	if maxConcurrent <= 0 {
+		return nil
+	}
+	return &queryScheduler{lock: &sync.Mutex{}, free: maxConcurrent, flows: map[string]*queryFlow{}, tenants: tenants}
+}
+
+func (s *queryScheduler) wrap(handler http.HandlerFunc) http.HandlerFunc {
+	if s == nil {
+		return handler
+	}
+	return func(writer http.ResponseWriter, request *http.Request) {
+		before := time.Now()
+		err := s.acquire(request.Context(), queryCaller(request))
+		if err != nil {
+			metricQueryAbandonedCount.Inc()
+			writeApiErrorf(writer, request, queries.ErrorCodeOverloaded, "gave up waiting for a query slot")
+			return
+		}
+		metricQueryQueueWait.Set(time.Since(before).Seconds())
+		defer s.release()
+		handler(writer, request)
+	}
+}
+
+func queryCaller(request *http.Request) string {
+	if tenantName, ok := request.Context().Value(tenantContextKey{}).(string); ok {
+		return tenantName
+	}
+	if identity := IdentityFromContext(request.Context()); identity != nil {
+		return identity.User
+	}
+	return ""
+}
+
+// Blocks until the caller gets a slot or ctx is done
+func (s *queryScheduler) acquire(ctx context.Context, caller string) error {
+	s.lock.Lock()
+	flow := s.flows[caller]
+	if flow == nil {
+		flow = &queryFlow{}
+		s.flows[caller] = flow
+	}
+	ready := make(chan struct{})
+	flow.waiting = append(flow.waiting, ready)
+	metricQueryQueued.Inc()
+	s.dispatch()
+	s.lock.Unlock()
+
+	select {
+	case <-ready:
+		return nil
+	case <-ctx.Done():
+	}
+
+	s.lock.Lock()
+	for idx, waiting := range flow.waiting {
+		if waiting == ready {
+			flow.waiting = append(flow.waiting[:idx], flow.waiting[idx+1:]...)
+			metricQueryQueued.Dec()
+			s.lock.Unlock()
+			return ctx.Err()
+		}
+	}
+	s.lock.Unlock()
+	// The slot was handed out while ctx was done
+	s.release()
+	return ctx.Err()
+}
+
+func (s *queryScheduler) release() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.free++
+	metricQueryRunning.Dec()
+	s.dispatch()
+}
+
+// Needs the lock
+func (s *queryScheduler) dispatch() {
+	for s.free > 0 {
+		var next *queryFlow
+		nextCaller := ""
+		start := 0.0
+		for caller, flow := range s.flows {
+			if len(flow.waiting) == 0 {
+				continue
+			}
+			flowStart := flow.finish
+			if flowStart < s.virtual {
+				flowStart = s.virtual
+			}
+			if next == nil || flowStart < start || (flowStart == start && caller < nextCaller) {
+				next = flow
+				nextCaller = caller
+				start = flowStart
+			}
+		}
+		if next == nil {
+			break
+		}
+
+		s.virtual = start
+		next.finish = start + 1/float64(s.tenants.QueryWeight(nextCaller))
+		ready := next.waiting[0]
+		next.waiting = next.waiting[1:]
+		s.free--
+		metricQueryQueued.Dec()
+		metricQueryRunning.Inc()
+		close(ready)
+	}
+
+	// Idle callers behind the virtual time would start from it anyway
+	for caller, flow := range s.flows {
+		if len(flow.waiting) == 0 && flow.finish <= s.virtual {
+			delete(s.flows, caller)
+		}
+	}
+}
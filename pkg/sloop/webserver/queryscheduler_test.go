@@ -0,0 +1,99 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/tenant"
+)
+
+func helper_waitingCount(s *queryScheduler) int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	count := 0
+	for _, flow := range s.flows {
+		count += len(flow.waiting)
+	}
+	return count
+}
+
+// Queues one request per caller in order, then lets them run one at a time and returns the order in which they got
+// the slot
+func helper_schedulingOrder(t *testing.T, s *queryScheduler, callers []string) []string {
+	granted := make(chan string, len(callers))
+	for idx, caller := range callers {
+		go func(caller string) {
+			assert.Nil(t, s.acquire(context.Background(), caller))
+			granted <- caller
+		}(caller)
+		for helper_waitingCount(s) < idx+1 {
+			time.Sleep(time.Millisecond)
+		}
+	}
+	order := []string{}
+	for range callers {
+		s.release()
+		order = append(order, <-granted)
+	}
+	return order
+}
+
+func Test_queryScheduler_Fairness(t *testing.T) {
+	s := newQueryScheduler(1, nil)
+	// a already has the slot and queued three more before b came along
+	assert.Nil(t, s.acquire(context.Background(), "a"))
+	order := helper_schedulingOrder(t, s, []string{"a", "a", "a", "b", "c"})
+	assert.Equal(t, []string{"b", "c", "a", "a", "a"}, order)
+}
+
+func Test_queryScheduler_Weights(t *testing.T) {
+	s := newQueryScheduler(1, tenant.Map{"big": {Namespaces: []string{"x"}, QueryWeight: 2}})
+	assert.Nil(t, s.acquire(context.Background(), "small"))
+	order := helper_schedulingOrder(t, s, []string{"big", "big", "big", "big", "small", "small"})
+	assert.Equal(t, []string{"big", "big", "big", "small", "big", "small"}, order)
+}
+
+func Test_queryScheduler_Cancel(t *testing.T) {
+	s := newQueryScheduler(1, nil)
+	assert.Nil(t, s.acquire(context.Background(), "a"))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.NotNil(t, s.acquire(ctx, "b"))
+	assert.Equal(t, 0, helper_waitingCount(s))
+	s.release()
+	assert.Nil(t, s.acquire(context.Background(), "b"))
+	s.release()
+	assert.Equal(t, 1, s.free)
+}
+
+func Test_queryScheduler_Wrap(t *testing.T) {
+	inner := func(w http.ResponseWriter, r *http.Request) {}
+	assert.NotNil(t, (*queryScheduler)(nil).wrap(inner))
+
+	s := newQueryScheduler(1, nil)
+	handler := s.wrap(inner)
+	recorder := httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodGet, "/ctx/data", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, 1, s.free)
+
+	// Gives up when the caller goes away while the slot is taken
+	assert.Nil(t, s.acquire(context.Background(), ""))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	recorder = httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodGet, "/ctx/data", nil).WithContext(ctx))
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+}
@@ -0,0 +1,263 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const sloStatusPath = "/slo/status"
+
+// Windows compliance is reported over, the longest is how long requests are counted for
+var sloWindows = []sloWindow{{name: "1h", length: time.Hour}, {name: "24h", length: 24 * time.Hour}}
+
+type sloWindow struct {
+	name   string
+	length time.Duration
+}
+
+const sloMinutes = 24 * 60
+
+var (
+	metricQuerySloRequestCount = promauto.NewCounterVec(prometheus.CounterOpts{Name: "sloop_query_slo_request_count"}, []string{"endpoint", "query"})
+	metricQuerySloMissCount    = promauto.NewCounterVec(prometheus.CounterOpts{Name: "sloop_query_slo_miss_count"}, []string{"endpoint", "query"})
+	metricQuerySlos            = &querySloCollector{}
+)
+
+func init() {
+	prometheus.MustRegister(metricQuerySlos)
+}
+
+type QuerySlo struct {
+	// Requests that take longer than this, or fail with a server error, miss the objective
+	Latency time.Duration `json:"latency"`
+	// Fraction of requests that should not miss, like 0.99
+	Objective float64 `json:"objective"`
+}
+
+// Query name, like GetEventData, or endpoint path below the context, like /export -> SLO.  The SLO of /data is the
+// one of every query without its own.  Each query is tracked on its own either way
+type QuerySlos map[string]QuerySlo
+
+func (s QuerySlos) Validate() error {
+	for name, slo := range s {
+		if slo.Latency <= 0 {
+			return fmt.Errorf("query SLO latency of %v must be > 0", name)
+		}
+		if slo.Objective <= 0 || slo.Objective >= 1 {
+			return fmt.Errorf("query SLO objective of %v must be > 0 and < 1", name)
+		}
+	}
+	return nil
+}
+
+func (s QuerySlos) slo(endpoint string, query string) (QuerySlo, bool) {
+	if slo, ok := s[query]; ok && query != "" {
+		return slo, true
+	}
+	slo, ok := s[endpoint]
+	return slo, ok
+}
+
+type QuerySloStatus struct {
+	Endpoint      string             `json:"endpoint"`
+	Query         string             `json:"query,omitempty"`
+	LatencyMillis int64              `json:"latencyMillis"`
+	Objective     float64            `json:"objective"`
+	Windows       []QuerySloWindowed `json:"windows"`
+}
+
+type QuerySloWindowed struct {
+	// 1h or 24h
+	Window   string `json:"window"`
+	Requests int64  `json:"requests"`
+	Misses   int64  `json:"misses"`
+	// Fraction of requests that did not miss, 1 without requests
+	Compliance float64 `json:"compliance"`
+	// Misses relative to the ones the objective allows.  Above 1 uses up the error budget before the window is over
+	BurnRate float64 `json:"burnRate"`
+	Met      bool    `json:"met"`
+}
+
+type sloMinute struct {
+	// Unix minute the counts are for
+	minute   int64
+	requests int64
+	misses   int64
+}
+
+type sloSeries struct {
+	endpoint string
+	query    string
+	slo      QuerySlo
+	// A ring of the last day by minute
+	minutes [sloMinutes]sloMinute
+}
+
+func (s *sloSeries) add(now time.Time, miss bool) {
+	minute := now.Unix() / 60
+	bucket := &s.minutes[minute%sloMinutes]
+	if bucket.minute != minute {
+		*bucket = sloMinute{minute: minute}
+	}
+	bucket.requests++
+	if miss {
+		bucket.misses++
+	}
+}
+
+func (s *sloSeries) windowed(now time.Time, window sloWindow) QuerySloWindowed {
+	last := now.Unix() / 60
+	first := last - int64(window.length/time.Minute)
+	windowed := QuerySloWindowed{Window: window.name}
+	for _, bucket := range s.minutes {
+		if bucket.minute > first && bucket.minute <= last {
+			windowed.Requests += bucket.requests
+			windowed.Misses += bucket.misses
+		}
+	}
+	windowed.Compliance = 1
+	if windowed.Requests > 0 {
+		missRatio := float64(windowed.Misses) / float64(windowed.Requests)
+		windowed.Compliance = 1 - missRatio
+		windowed.BurnRate = missRatio / (1 - s.slo.Objective)
+	}
+	windowed.Met = windowed.Compliance >= s.slo.Objective
+	return windowed
+}
+
+/*
+Counts the requests of every endpoint and query with an SLO, and the ones that missed it, in the last day, so
+compliance and the burn rate of the error budget can be reported over each of sloWindows.  The latency is the one
+callers see, including the time queries wait for queryScheduler.  Counts are in memory and start over on restart
+*/
+type querySloTracker struct {
+	slos   QuerySlos
+	lock   sync.Mutex
+	series map[string]*sloSeries
+	now    func() time.Time
+}
+
+// Nil when there are no SLOs
+func newQuerySloTracker(slos QuerySlos) *querySloTracker {
+	if len(slos) == 0 {
+		return nil
+	}
+	return &querySloTracker{slos: slos, series: map[string]*sloSeries{}, now: time.Now}
+}
+
+func (t *querySloTracker) record(endpoint string, query string, latency time.Duration, status int) {
+	slo, ok := t.slos.slo(endpoint, query)
+	if !ok {
+		return
+	}
+	miss := latency > slo.Latency || status >= http.StatusInternalServerError
+	metricQuerySloRequestCount.WithLabelValues(endpoint, query).Inc()
+	if miss {
+		metricQuerySloMissCount.WithLabelValues(endpoint, query).Inc()
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	key := endpoint + "?" + query
+	series, ok := t.series[key]
+	if !ok {
+		series = &sloSeries{endpoint: endpoint, query: query, slo: slo}
+		t.series[key] = series
+	}
+	series.add(t.now(), miss)
+}
+
+// Sorted by endpoint and query
+func (t *querySloTracker) status() []QuerySloStatus {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	now := t.now()
+	statuses := []QuerySloStatus{}
+	for _, series := range t.series {
+		status := QuerySloStatus{Endpoint: series.endpoint, Query: series.query, LatencyMillis: series.slo.Latency.Milliseconds(), Objective: series.slo.Objective}
+		for _, window := range sloWindows {
+			status.Windows = append(status.Windows, series.windowed(now, window))
+		}
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].Endpoint != statuses[j].Endpoint {
+			return statuses[i].Endpoint < statuses[j].Endpoint
+		}
+		return statuses[i].Query < statuses[j].Query
+	})
+	return statuses
+}
+
+func (t *querySloTracker) middleware(handler http.Handler) http.Handler {
+	if t == nil {
+		return handler
+	}
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		before := time.Now()
+		statusWriter := &auditResponseWriter{ResponseWriter: writer, status: http.StatusOK}
+		handler.ServeHTTP(statusWriter, request)
+		endpoint, query := responseEndpoint(request)
+		t.record(endpoint, query, time.Since(before), statusWriter.status)
+	})
+}
+
+func sloStatusHandler(tracker *querySloTracker) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		statuses := []QuerySloStatus{}
+		if tracker != nil {
+			statuses = tracker.status()
+		}
+		writeJson(writer, request, statuses)
+	}
+}
+
+// Exports compliance and burn rates over sloWindows at scrape time, so they go down when requests stop missing
+type querySloCollector struct {
+	lock    sync.Mutex
+	tracker *querySloTracker
+}
+
+var (
+	querySloComplianceDesc = prometheus.NewDesc("sloop_query_slo_compliance", "", []string{"endpoint", "query", "window"}, nil)
+	querySloBurnRateDesc   = prometheus.NewDesc("sloop_query_slo_burn_rate", "", []string{"endpoint", "query", "window"}, nil)
+)
+
+func (c *querySloCollector) setTracker(tracker *querySloTracker) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.tracker = tracker
+}
+
+func (c *querySloCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- querySloComplianceDesc
+	ch <- querySloBurnRateDesc
+}
+
+func (c *querySloCollector) Collect(ch chan<- prometheus.Metric) {
+	c.lock.Lock()
+	tracker := c.tracker
+	c.lock.Unlock()
+	if tracker == nil {
+		return
+	}
+	for _, status := range tracker.status() {
+		for _, windowed := range status.Windows {
+			ch <- prometheus.MustNewConstMetric(querySloComplianceDesc, prometheus.GaugeValue, windowed.Compliance, status.Endpoint, status.Query, windowed.Window)
+			ch <- prometheus.MustNewConstMetric(querySloBurnRateDesc, prometheus.GaugeValue, windowed.BurnRate, status.Endpoint, status.Query, windowed.Window)
+		}
+	}
+}
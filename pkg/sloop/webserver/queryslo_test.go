@@ -0,0 +1,95 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_QuerySlos_Validate(t *testing.T) {
+	assert.Nil(t, QuerySlos{"/data": {Latency: time.Second, Objective: 0.99}}.Validate())
+	assert.NotNil(t, QuerySlos{"/data": {Latency: 0, Objective: 0.99}}.Validate())
+	assert.NotNil(t, QuerySlos{"GetEventData": {Latency: time.Second, Objective: 1}}.Validate())
+	assert.NotNil(t, QuerySlos{"/export": {Latency: time.Second}}.Validate())
+}
+
+func Test_querySloTracker_Windows(t *testing.T) {
+	tracker := newQuerySloTracker(QuerySlos{"/data": {Latency: time.Second, Objective: 0.9}, "GetEventData": {Latency: 5 * time.Second, Objective: 0.5}})
+	now := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+	tracker.now = func() time.Time { return now }
+
+	// Two hours ago, only in the day
+	now = now.Add(-2 * time.Hour)
+	tracker.record("/data", "Namespaces", 2*time.Second, http.StatusOK)
+	tracker.record("/data", "Namespaces", 2*time.Second, http.StatusOK)
+	now = now.Add(2 * time.Hour)
+	tracker.record("/data", "Namespaces", 10*time.Millisecond, http.StatusOK)
+	tracker.record("/data", "Namespaces", 10*time.Millisecond, http.StatusInternalServerError)
+	tracker.record("/data", "GetEventData", 2*time.Second, http.StatusOK)
+	// Endpoints without an SLO are not tracked
+	tracker.record("/export", "", time.Hour, http.StatusOK)
+
+	statuses := tracker.status()
+	assert.Len(t, statuses, 2)
+	assert.Equal(t, "GetEventData", statuses[0].Query)
+	assert.Equal(t, int64(5000), statuses[0].LatencyMillis)
+	assert.Equal(t, QuerySloWindowed{Window: "1h", Requests: 1, Compliance: 1, Met: true}, statuses[0].Windows[0])
+
+	namespaces := statuses[1]
+	assert.Equal(t, "/data", namespaces.Endpoint)
+	assert.Equal(t, "Namespaces", namespaces.Query)
+	assert.Equal(t, 0.9, namespaces.Objective)
+	hour := namespaces.Windows[0]
+	assert.Equal(t, "1h", hour.Window)
+	assert.Equal(t, int64(2), hour.Requests)
+	assert.Equal(t, int64(1), hour.Misses)
+	assert.InDelta(t, 0.5, hour.Compliance, 0.0001)
+	assert.InDelta(t, 5, hour.BurnRate, 0.0001)
+	assert.False(t, hour.Met)
+	day := namespaces.Windows[1]
+	assert.Equal(t, "24h", day.Window)
+	assert.Equal(t, int64(4), day.Requests)
+	assert.Equal(t, int64(3), day.Misses)
+
+	// A day later the old requests are out of every window
+	now = now.Add(25 * time.Hour)
+	day = tracker.status()[1].Windows[1]
+	assert.Equal(t, QuerySloWindowed{Window: "24h", Compliance: 1, Met: true}, day)
+}
+
+func Test_querySloTracker_Middleware(t *testing.T) {
+	tracker := newQuerySloTracker(QuerySlos{"/data": {Latency: time.Hour, Objective: 0.99}})
+	router := mux.NewRouter()
+	subRouter := router.PathPrefix("/{clusterContext}").Subrouter()
+	subRouter.Use(tracker.middleware)
+	subRouter.HandleFunc("/data", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	subRouter.HandleFunc(sloStatusPath, sloStatusHandler(tracker))
+
+	missesBefore := testutil.ToFloat64(metricQuerySloMissCount.WithLabelValues("/data", "EventHeatMap"))
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/ctx/data?query=EventHeatMap", nil))
+	assert.Equal(t, missesBefore+1, testutil.ToFloat64(metricQuerySloMissCount.WithLabelValues("/data", "EventHeatMap")))
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/ctx"+sloStatusPath, nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	statuses := []QuerySloStatus{}
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &statuses))
+	assert.Len(t, statuses, 1)
+	assert.Equal(t, "EventHeatMap", statuses[0].Query)
+	assert.Equal(t, int64(1), statuses[0].Windows[0].Misses)
+}
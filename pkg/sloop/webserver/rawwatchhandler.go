@@ -0,0 +1,128 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/queries"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+)
+
+const rawWatchPath = "/api/v1/resource/watch"
+
+const (
+	rawWatchFollowParam = "follow"
+	rawWatchFormatParam = "format"
+	// Server-sent events, otherwise each result is a line of json
+	rawWatchFormatSse = "sse"
+	// Sent back by EventSource when it reconnects, with the id of the last event it got
+	lastEventIdHeader = "Last-Event-ID"
+)
+
+// How often a follow checks the store for new results.  A var for tests
+var rawWatchPollInterval = 2 * time.Second
+
+/*
+Streams the stored watch results of one object oldest first, as they are in the watch table with their watch type
+and provenance, as queries.RawWatchResult.  Params: kind, name, namespace (not needed for cluster scoped kinds),
+start_time in unix seconds, which defaults to max look back, and follow=true to keep the response open and send new
+results as they are stored, like kubectl logs -f.  With format=sse or an Accept of text/event-stream the results
+are server-sent events with the timestamp as the id, so a reconnecting EventSource continues where it stopped.
+Not queued by the query scheduler, since a follow would hold its slot until the client goes away
+*/
+func rawWatchHandler(tables typed.Tables, maxLookBack time.Duration) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		kind := request.URL.Query().Get(queries.KindParam)
+		name := request.URL.Query().Get(queries.NameParam)
+		namespace := request.URL.Query().Get(queries.NamespaceParam)
+		if kind == "" || name == "" {
+			writeApiErrorf(writer, request, queries.ErrorCodeBadParams, "%v and %v are required", queries.KindParam, queries.NameParam)
+			return
+		}
+		// Just before start_time so a result at exactly start_time is included
+		after, err := timeFromUnixTimeParam(request, queries.StartTimeParam, common.Now().Add(-maxLookBack), time.Second)
+		if err != nil {
+			writeApiErrorf(writer, request, queries.ErrorCodeBadParams, "invalid %v: %v", queries.StartTimeParam, err)
+			return
+		}
+		after = after.Add(-time.Nanosecond)
+		sse := request.URL.Query().Get(rawWatchFormatParam) == rawWatchFormatSse || strings.Contains(request.Header.Get("Accept"), "text/event-stream")
+		if lastEventId := request.Header.Get(lastEventIdHeader); sse && lastEventId != "" {
+			lastNanos, err := strconv.ParseInt(lastEventId, 10, 64)
+			if err != nil {
+				writeApiErrorf(writer, request, queries.ErrorCodeBadParams, "invalid %v: %v", lastEventIdHeader, err)
+				return
+			}
+			after = time.Unix(0, lastNanos).UTC()
+		}
+		follow := request.URL.Query().Get(rawWatchFollowParam) == "true"
+
+		results, err := queries.GetRawWatchResults(tables, kind, namespace, name, after, common.Now())
+		if err != nil {
+			writeApiError(writer, request, err, "Failed to read watch results")
+			return
+		}
+		if sse {
+			writer.Header().Set("content-type", "text/event-stream")
+			writer.Header().Set("cache-control", "no-cache")
+		} else {
+			writer.Header().Set("content-type", "application/x-ndjson")
+		}
+		flusher, _ := writer.(http.Flusher)
+
+		for {
+			for _, result := range results {
+				err = writeRawWatchResult(writer, result, sse)
+				if err != nil {
+					glog.V(2).Infof("Stopped streaming watch results of %v %v/%v: %v", kind, namespace, name, err)
+					return
+				}
+				after = time.Unix(0, result.Timestamp).UTC()
+			}
+			if flusher != nil {
+				flusher.Flush()
+			}
+			if !follow {
+				return
+			}
+			select {
+			case <-request.Context().Done():
+				return
+			case <-time.After(rawWatchPollInterval):
+			}
+			results, err = queries.GetRawWatchResults(tables, kind, namespace, name, after, common.Now())
+			if err != nil {
+				// Headers are gone already, so all that is left is to end the stream
+				glog.Errorf("Failed to read watch results of %v %v/%v: %v", kind, namespace, name, err)
+				return
+			}
+		}
+	}
+}
+
+func writeRawWatchResult(writer http.ResponseWriter, result queries.RawWatchResult, sse bool) error {
+	bytes, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	if sse {
+		_, err = fmt.Fprintf(writer, "id: %v\nevent: %v\ndata: %s\n\n", result.Timestamp, result.WatchType, bytes)
+	} else {
+		_, err = fmt.Fprintf(writer, "%s\n", bytes)
+	}
+	return err
+}
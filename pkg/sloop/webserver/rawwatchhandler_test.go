@@ -0,0 +1,113 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/salesforce/sloop/pkg/sloop/queries"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+func helper_storeRawWatchResults(t *testing.T, tables typed.Tables, ts ...time.Time) {
+	err := tables.Db().Update(func(txn badgerwrap.Txn) error {
+		for i, ts := range ts {
+			watchType := typed.KubeWatchResult_UPDATE
+			if i == 0 {
+				watchType = typed.KubeWatchResult_ADD
+			}
+			key := typed.NewWatchTableKey(untyped.GetPartitionId(ts), "Pod", "ns", "pod-a", ts)
+			err := tables.WatchTable().Set(txn, key.String(), &typed.KubeWatchResult{Kind: "Pod", WatchType: watchType, Payload: fmt.Sprintf(`{"a":%v}`, i), VersionType: typed.VersionTypeChanged})
+			if err != nil {
+				return err
+			}
+		}
+		// Same name prefix, not part of pod-a
+		key := typed.NewWatchTableKey(untyped.GetPartitionId(ts[0]), "Pod", "ns", "pod-ab", ts[0])
+		return tables.WatchTable().Set(txn, key.String(), &typed.KubeWatchResult{Kind: "Pod", Payload: `{}`})
+	})
+	assert.Nil(t, err)
+}
+
+func Test_rawWatchHandler(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	ts := time.Now().Add(-3 * time.Hour).Truncate(time.Second)
+	helper_storeRawWatchResults(t, tables, ts, ts.Add(2*time.Hour), ts.Add(time.Hour))
+	handler := rawWatchHandler(tables, 24*time.Hour)
+
+	recorder := httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodGet, rawWatchPath+"?kind=Pod", nil))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodGet, rawWatchPath+"?kind=Pod&namespace=ns&name=pod-a", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	lines := strings.Split(strings.TrimSpace(recorder.Body.String()), "\n")
+	assert.Len(t, lines, 3)
+	results := []queries.RawWatchResult{}
+	for _, line := range lines {
+		result := queries.RawWatchResult{}
+		assert.Nil(t, json.Unmarshal([]byte(line), &result))
+		results = append(results, result)
+	}
+	assert.Equal(t, ts.UnixNano(), results[0].Timestamp)
+	assert.Equal(t, "ADD", results[0].WatchType)
+	assert.Equal(t, typed.VersionTypeChanged, results[0].VersionType)
+	assert.Equal(t, ts.Add(2*time.Hour).UnixNano(), results[2].Timestamp)
+
+	recorder = httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodGet, fmt.Sprintf("%v?kind=Pod&namespace=ns&name=pod-a&start_time=%v", rawWatchPath, ts.Add(time.Hour).Unix()), nil))
+	assert.Len(t, strings.Split(strings.TrimSpace(recorder.Body.String()), "\n"), 2)
+}
+
+func Test_rawWatchHandler_FollowSse(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	ts := time.Now().Add(-time.Hour)
+	helper_storeRawWatchResults(t, tables, ts, ts.Add(time.Minute))
+	rawWatchPollInterval = 10 * time.Millisecond
+	defer func() { rawWatchPollInterval = 2 * time.Second }()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	request := httptest.NewRequest(http.MethodGet, rawWatchPath+"?kind=Pod&namespace=ns&name=pod-a&follow=true&format=sse", nil).WithContext(ctx)
+	// A reconnect after the first result
+	request.Header.Set(lastEventIdHeader, fmt.Sprint(ts.UnixNano()))
+	recorder := httptest.NewRecorder()
+	done := make(chan bool)
+	go func() {
+		rawWatchHandler(tables, 24*time.Hour)(recorder, request)
+		close(done)
+	}()
+	time.Sleep(50 * time.Millisecond)
+	helper_storeRawWatchResults(t, tables, ts.Add(2*time.Minute))
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+	<-done
+
+	assert.Equal(t, "text/event-stream", recorder.Header().Get("content-type"))
+	events := strings.Split(strings.TrimSpace(recorder.Body.String()), "\n\n")
+	assert.Len(t, events, 2)
+	assert.True(t, strings.HasPrefix(events[0], fmt.Sprintf("id: %v\nevent: UPDATE\ndata: ", ts.Add(time.Minute).UnixNano())))
+	assert.True(t, strings.HasPrefix(events[1], fmt.Sprintf("id: %v\nevent: ADD\n", ts.Add(2*time.Minute).UnixNano())))
+}
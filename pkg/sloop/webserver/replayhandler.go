@@ -0,0 +1,123 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/salesforce/sloop/pkg/sloop/queries"
+	"github.com/salesforce/sloop/pkg/sloop/replay"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+)
+
+const (
+	replayWebhookParam = "webhook"
+	replaySpeedParam   = "speed"
+	replayIdParam      = "id"
+)
+
+func writeJson(writer http.ResponseWriter, request *http.Request, data interface{}) {
+	bytes, err := json.MarshalIndent(data, "", " ")
+	if err != nil {
+		writeApiError(writer, request, err, "Failed to marshal json")
+		return
+	}
+	writer.Header().Set("content-type", "application/json")
+	writer.Write(bytes)
+}
+
+// POST starts a replay of stored watch results to a webhook
+// Params: webhook, kind (one or more), optional namespace, optional speed, and the usual time range params
+func replayStartHandler(mgr *replay.Manager, tables typed.Tables, maxLookBack time.Duration) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method != http.MethodPost {
+			writeApiErrorf(writer, request, queries.ErrorCodeMethodNotAllowed, "replay must be started with POST")
+			return
+		}
+		err := request.ParseForm()
+		if err != nil {
+			writeApiErrorf(writer, request, queries.ErrorCodeBadParams, "failed to parse form: %v", err)
+			return
+		}
+
+		params := request.Form
+		startTime, endTime, err := queries.ComputeTimeRange(params, tables, maxLookBack)
+		if err != nil {
+			writeApiError(writer, request, err, "Invalid time range")
+			return
+		}
+
+		speed := 1.0
+		if speedStr := params.Get(replaySpeedParam); speedStr != "" {
+			speed, err = strconv.ParseFloat(speedStr, 64)
+			if err != nil {
+				writeApiErrorf(writer, request, queries.ErrorCodeBadParams, "invalid %v %q: %v", replaySpeedParam, speedStr, err)
+				return
+			}
+		}
+
+		req := replay.Request{
+			WebhookUrl: params.Get(replayWebhookParam),
+			Kinds:      params[queries.KindParam],
+			Namespace:  params.Get(queries.NamespaceParam),
+			StartTime:  startTime,
+			EndTime:    endTime,
+			Speed:      speed,
+		}
+		err = req.Validate()
+		if err != nil {
+			writeApiErrorf(writer, request, queries.ErrorCodeBadParams, "%v", err)
+			return
+		}
+		id, err := mgr.Start(req)
+		if err != nil {
+			writeApiError(writer, request, err, "Failed to start replay")
+			return
+		}
+
+		status, _ := mgr.Status(id)
+		writeJson(writer, request, status)
+	}
+}
+
+// Returns one replay if the id param is set, otherwise all of them
+func replayStatusHandler(mgr *replay.Manager) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		id := request.URL.Query().Get(replayIdParam)
+		if id == "" {
+			writeJson(writer, request, mgr.List())
+			return
+		}
+		status, ok := mgr.Status(id)
+		if !ok {
+			writeApiErrorf(writer, request, queries.ErrorCodeNotFound, "replay %q not found", id)
+			return
+		}
+		writeJson(writer, request, status)
+	}
+}
+
+// POST with the id param cancels that replay
+func replayCancelHandler(mgr *replay.Manager) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method != http.MethodPost {
+			writeApiErrorf(writer, request, queries.ErrorCodeMethodNotAllowed, "replay must be cancelled with POST")
+			return
+		}
+		id := request.FormValue(replayIdParam)
+		if !mgr.Cancel(id) {
+			writeApiErrorf(writer, request, queries.ErrorCodeNotFound, "replay %q not found", id)
+			return
+		}
+		status, _ := mgr.Status(id)
+		writeJson(writer, request, status)
+	}
+}
@@ -0,0 +1,64 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/queries"
+	"github.com/salesforce/sloop/pkg/sloop/replay"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+func Test_replayCancelHandler_NeedsPost(t *testing.T) {
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	handler := replayCancelHandler(replay.NewManager(typed.NewTableList(db)))
+
+	recorder := httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodGet, "/replay/cancel?id=1", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodPost, "/replay/cancel?id=1", nil))
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+}
+
+func Test_replayStartHandler_BadParams(t *testing.T) {
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	handler := replayStartHandler(replay.NewManager(tables), tables, time.Hour)
+
+	for _, test := range []struct {
+		method string
+		query  string
+		code   queries.ErrorCode
+	}{
+		{http.MethodGet, "webhook=http://localhost/hook&kind=Pod&lookback=1h", queries.ErrorCodeMethodNotAllowed},
+		{http.MethodPost, "webhook=http://localhost/hook&kind=Pod&lookback=1h&speed=fast", queries.ErrorCodeBadParams},
+		{http.MethodPost, "webhook=http://localhost/hook&kind=Pod&lookback=1h&speed=-1", queries.ErrorCodeBadParams},
+		{http.MethodPost, "webhook=http://localhost/hook&kind=Pod", queries.ErrorCodeBadParams},
+		{http.MethodPost, "kind=Pod&lookback=1h", queries.ErrorCodeBadParams},
+	} {
+		recorder := httptest.NewRecorder()
+		handler(recorder, httptest.NewRequest(test.method, "/replay?"+test.query, nil))
+		assert.Equal(t, test.code.Status(), recorder.Code, test.query)
+		envelope := queries.ErrorEnvelope{}
+		assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &envelope), test.query)
+		assert.Equal(t, test.code, envelope.Error.Code, test.query)
+	}
+}
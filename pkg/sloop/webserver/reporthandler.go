@@ -0,0 +1,91 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/queries"
+	"github.com/salesforce/sloop/pkg/sloop/report"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+)
+
+const (
+	reportPath        = "/report"
+	reportParam       = "report"
+	reportFormatParam = "format"
+	// "true" sends the report as a file attachment
+	reportDownloadParam = "download"
+)
+
+type reportListEntry struct {
+	Name     string   `json:"name"`
+	Title    string   `json:"title"`
+	Query    string   `json:"query"`
+	Formats  []string `json:"formats"`
+	Interval string   `json:"interval,omitempty"`
+}
+
+// Params: report, optional format (markdown or html), and params of the query of the report, like namespace or
+// lookback, which override the ones it has.  Without report, returns the list of reports
+func reportHandler(reports report.Map, tables typed.Tables, maxLookBack time.Duration) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		params := request.URL.Query()
+		name := params.Get(reportParam)
+		if name == "" {
+			list := []reportListEntry{}
+			for _, name := range reports.Names() {
+				def := reports[name]
+				entry := reportListEntry{Name: def.Name, Title: def.Title, Query: def.Query, Formats: def.Formats()}
+				if def.Interval > 0 {
+					entry.Interval = def.Interval.String()
+				}
+				list = append(list, entry)
+			}
+			writeJson(writer, request, list)
+			return
+		}
+		def, ok := reports[name]
+		if !ok {
+			writeApiErrorf(writer, request, queries.ErrorCodeNotFound, "unknown report %q", name)
+			return
+		}
+		format := params.Get(reportFormatParam)
+		if format == "" {
+			format = def.Formats()[0]
+		}
+		if !common.Contains(def.Formats(), format) {
+			writeApiErrorf(writer, request, queries.ErrorCodeBadParams, "report %v has no %v format", name, format)
+			return
+		}
+		download := params.Get(reportDownloadParam) == "true"
+		params.Del(reportParam)
+		params.Del(reportFormatParam)
+		params.Del(reportDownloadParam)
+		body, err := report.Render(def, format, params, tables, maxLookBack, getRequestId(request.Context()))
+		if err != nil {
+			writeApiError(writer, request, err, "Failed to render report")
+			return
+		}
+		writer.Header().Set("content-type", report.ContentType(format))
+		if download {
+			writer.Header().Set("content-disposition", fmt.Sprintf("attachment; filename=%v.%v", name, reportFileExtension(format)))
+		}
+		writer.Write(body)
+	}
+}
+
+func reportFileExtension(format string) string {
+	if format == report.FormatHtml {
+		return "html"
+	}
+	return "md"
+}
@@ -0,0 +1,61 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/salesforce/sloop/pkg/sloop/report"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_reportHandler(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	reports, err := report.NewMap([]report.Definition{{Name: "mine", Title: "Mine", Query: "GetSnapshotDiff", Markdown: "# {{.Title}} {{len .Result.added}}", Interval: time.Hour, Webhook: "http://hook"}})
+	assert.Nil(t, err)
+	handler := reportHandler(reports, typed.NewTableList(db), 24*time.Hour)
+
+	recorder := httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodGet, reportPath, nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	list := []reportListEntry{}
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &list))
+	assert.Len(t, list, 4)
+	assert.Equal(t, reportListEntry{Name: "mine", Title: "Mine", Query: "GetSnapshotDiff", Formats: []string{"markdown"}, Interval: "1h0m0s"}, list[0])
+
+	recorder = httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodGet, reportPath+"?report=mine&lookback=1h&download=true", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "# Mine 0", recorder.Body.String())
+	assert.Equal(t, report.ContentType(report.FormatMarkdown), recorder.Header().Get("content-type"))
+	assert.Equal(t, "attachment; filename=mine.md", recorder.Header().Get("content-disposition"))
+
+	recorder = httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodGet, reportPath+"?report=rollouts&format=html", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.True(t, strings.HasPrefix(recorder.Body.String(), "<html>"))
+
+	recorder = httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodGet, reportPath+"?report=mine&format=html", nil))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodGet, reportPath+"?report=nosuchreport", nil))
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+}
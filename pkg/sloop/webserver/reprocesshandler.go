@@ -0,0 +1,72 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/salesforce/sloop/pkg/sloop/processing"
+)
+
+const reprocessPath = "/admin/reprocess"
+
+const (
+	// Watch results per second, default unlimited
+	reprocessMaxRateParam = "max_rate"
+	// "true" returns once the request is checked and rebuilds in the background
+	reprocessAsyncParam = "async"
+)
+
+// GET returns the processing.ReprocessProgress of the running reprocess, or of the last one.  POST starts one, since it
+// rewrites the derived tables of every partition in the range.  Params: from and to partition ids (default the first
+// and the last closed partition), table (one or more, default all derived tables), max_rate and async.  Returns a
+// processing.ReprocessReport, or with async=true a 202 and the progress
+func reprocessHandler(runner *processing.Runner) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method == http.MethodGet {
+			writeJson(writer, request, runner.ReprocessProgress())
+			return
+		}
+		if request.Method != http.MethodPost {
+			http.Error(writer, "reprocessing must be started with POST", http.StatusMethodNotAllowed)
+			return
+		}
+		err := request.ParseForm()
+		if err != nil {
+			logWebError(err, "Failed to parse form", request, writer)
+			return
+		}
+		maxRate := 0
+		if maxRateStr := request.Form.Get(reprocessMaxRateParam); maxRateStr != "" {
+			maxRate, err = strconv.Atoi(maxRateStr)
+			if err != nil || maxRate < 0 {
+				http.Error(writer, fmt.Sprintf("invalid %v %q, it must be a number of watch results per second", reprocessMaxRateParam, maxRateStr), http.StatusBadRequest)
+				return
+			}
+		}
+
+		if request.Form.Get(reprocessAsyncParam) == "true" {
+			progress, err := runner.StartReprocess(request.Form.Get("from"), request.Form.Get("to"), request.Form["table"], maxRate)
+			if err != nil {
+				logWebError(err, "Reprocessing failed", request, writer)
+				return
+			}
+			writer.WriteHeader(http.StatusAccepted)
+			writeJson(writer, request, progress)
+			return
+		}
+		report, err := runner.Reprocess(request.Form.Get("from"), request.Form.Get("to"), request.Form["table"], maxRate)
+		if err != nil {
+			logWebError(err, "Reprocessing failed", request, writer)
+			return
+		}
+		writeJson(writer, request, report)
+	}
+}
@@ -0,0 +1,53 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/salesforce/sloop/pkg/sloop/processing"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_reprocessHandler(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	handler := reprocessHandler(processing.NewProcessing(nil, typed.NewTableList(db), false, time.Hour, 0, nil, 0, nil))
+
+	recorder := httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodPut, reprocessPath, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodGet, reprocessPath, nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	progress := processing.ReprocessProgress{}
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &progress))
+	assert.False(t, progress.Running)
+
+	recorder = httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodPost, reprocessPath+"?table=ressum&max_rate=fast", nil))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	// Nothing stored yet
+	recorder = httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodPost, reprocessPath+"?table=ressum", nil))
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	recorder = httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodPost, reprocessPath+"?table=ressum&async=true", nil))
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+}
@@ -0,0 +1,63 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/queries"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+)
+
+const resourceAtPath = "/api/v1/resource/at"
+
+// Unix seconds
+const resourceAtTimeParam = "t"
+
+// "true" adds the objects the resource refers to as they were at t, see queries.ResolveReferences
+const resourceAtResolveParam = "resolve"
+
+// Returns what one object looked like at a point in time, as a queries.ResourceAtOutput.  Params: kind, name,
+// namespace (not needed for cluster scoped kinds), t, which defaults to now, and resolve.  404 when nothing was stored
+// for the object at or before t
+func resourceAtHandler(tables typed.Tables) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		kind := request.URL.Query().Get(queries.KindParam)
+		name := request.URL.Query().Get(queries.NameParam)
+		namespace := request.URL.Query().Get(queries.NamespaceParam)
+		if kind == "" || name == "" {
+			writeApiErrorf(writer, request, queries.ErrorCodeBadParams, "%v and %v are required", queries.KindParam, queries.NameParam)
+			return
+		}
+		ts, err := timeFromUnixTimeParam(request, resourceAtTimeParam, common.Now(), time.Second)
+		if err != nil {
+			writeApiErrorf(writer, request, queries.ErrorCodeBadParams, "invalid %v: %v", resourceAtTimeParam, err)
+			return
+		}
+
+		output, err := queries.GetResourceAt(tables, kind, namespace, name, ts)
+		if err != nil {
+			writeApiError(writer, request, err, "Failed to read resource")
+			return
+		}
+		if output == nil {
+			writeApiErrorf(writer, request, queries.ErrorCodeNotFound, "no %v %v was stored at or before %v", kind, name, ts.Unix())
+			return
+		}
+		if request.URL.Query().Get(resourceAtResolveParam) == "true" {
+			output.References, err = queries.ResolveReferences(tables, output, ts)
+			if err != nil {
+				writeApiError(writer, request, err, "Failed to resolve references")
+				return
+			}
+		}
+		writeJson(writer, request, output)
+	}
+}
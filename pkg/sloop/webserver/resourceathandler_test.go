@@ -0,0 +1,68 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/salesforce/sloop/pkg/sloop/queries"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_resourceAtHandler(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	ts := time.Date(2019, 3, 1, 3, 4, 0, 0, time.UTC)
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		key := typed.NewWatchTableKey(untyped.GetPartitionId(ts), "Pod", "ns", "pod-a", ts)
+		return tables.WatchTable().Set(txn, key.String(), &typed.KubeWatchResult{Kind: "Pod", WatchType: typed.KubeWatchResult_ADD, Payload: `{"a":1}`})
+	})
+	assert.Nil(t, err)
+	handler := resourceAtHandler(tables)
+
+	recorder := httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodGet, resourceAtPath+"?kind=Pod", nil))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodGet, resourceAtPath+"?kind=Pod&namespace=ns&name=pod-a&t=abc", nil))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodGet, fmt.Sprintf("%v?kind=Pod&namespace=ns&name=pod-a&t=%v", resourceAtPath, ts.Unix()-1), nil))
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodGet, fmt.Sprintf("%v?kind=Pod&namespace=ns&name=pod-a&t=%v", resourceAtPath, ts.Unix()+60), nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	output := queries.ResourceAtOutput{}
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &output))
+	assert.Equal(t, ts.Unix(), output.Timestamp)
+	assert.JSONEq(t, `{"a":1}`, string(output.Payload))
+	assert.Nil(t, output.References)
+
+	recorder = httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodGet, fmt.Sprintf("%v?kind=Pod&namespace=ns&name=pod-a&t=%v&resolve=true", resourceAtPath, ts.Unix()+60), nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	output = queries.ResourceAtOutput{}
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &output))
+	// Only the default service account, which was not stored
+	assert.Len(t, output.References, 1)
+	assert.Nil(t, output.References[0].Resource)
+}
@@ -83,10 +83,10 @@ func resourceHandler(resLinks []ResourceLinkTemplate, currentContext string) htt
 		queryStart := d.ClickTime.Add(-1 * d.PlusMinusTime).Unix()
 		queryEnd := d.ClickTime.Add(d.PlusMinusTime).Unix()
 
-		dataParams := fmt.Sprintf("?query=%v&namespace=%v&start_time=%v&end_time=%v&kind=%v&name=%v", "GetEventData", d.Namespace, queryStart, queryEnd, d.Kind, d.Name)
+		dataParams := fmt.Sprintf("?query=%v&namespace=%v&start_time=%v&end_time=%v&kind=%v&name=%v&uuid=%v", "GetEventData", d.Namespace, queryStart, queryEnd, d.Kind, d.Name, d.Uuid)
 		d.EventsUrl = path.Join("/", currentContext, "data"+dataParams)
 
-		dataParams = fmt.Sprintf("?query=%v&namespace=%v&start_time=%v&end_time=%v&kind=%v&name=%v", "GetResPayload", d.Namespace, queryStart, queryEnd, d.Kind, d.Name)
+		dataParams = fmt.Sprintf("?query=%v&namespace=%v&start_time=%v&end_time=%v&kind=%v&name=%v&%v=%v", "GetResPayload", d.Namespace, queryStart, queryEnd, d.Kind, d.Name, queries.PayloadFormatParam, queries.PayloadFormatPatch)
 		d.PayloadUrl = path.Join("/", currentContext, "data"+dataParams)
 
 		err = resourceTemplate.Execute(writer, d)
@@ -0,0 +1,185 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/golang/glog"
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/salesforce/sloop/pkg/sloop/queries"
+)
+
+const (
+	dataEndpoint      = "/data"
+	unknownQueryLabel = "unknown"
+)
+
+var (
+	// 100 bytes to about 1.6GB
+	sizeBuckets = prometheus.ExponentialBuckets(100, 4, 13)
+
+	metricWebServerRequestBytes   = promauto.NewHistogramVec(prometheus.HistogramOpts{Name: "sloop_webserver_request_bytes", Buckets: sizeBuckets}, []string{"endpoint", "query"})
+	metricWebServerResponseBytes  = promauto.NewHistogramVec(prometheus.HistogramOpts{Name: "sloop_webserver_response_bytes", Buckets: sizeBuckets}, []string{"endpoint", "query"})
+	metricWebServerTruncatedCount = promauto.NewCounterVec(prometheus.CounterOpts{Name: "sloop_webserver_response_truncated_count"}, []string{"endpoint", "query"})
+)
+
+/*
+Records the request and response size of every endpoint, and of every query for /data, and cuts responses at their
+maximum size.  The limit of a query comes from limits by query name, then from limits["/data"] and then from
+maxQueryBytes.  Other endpoints, like exports and backups, only have the limit set for their path in limits.
+
+A response with a limit is held in memory up to the limit, so its headers can still say it was truncated.  The body
+of a truncated response is the first max bytes, with queries.TruncatedHeader set and the full size in
+queries.ResponseBytesHeader.  0 = no limit
+*/
+type responseSizes struct {
+	maxQueryBytes int64
+	limits        map[string]int64
+}
+
+func newResponseSizes(maxQueryBytes int64, limits map[string]int64) *responseSizes {
+	return &responseSizes{maxQueryBytes: maxQueryBytes, limits: limits}
+}
+
+// Endpoints are the path templates of the routes so they do not grow with the urls that are called
+func responseEndpoint(request *http.Request) (string, string) {
+	endpoint := pathBelowContext(request.URL.Path)
+	if route := mux.CurrentRoute(request); route != nil {
+		if template, err := route.GetPathTemplate(); err == nil {
+			endpoint = pathBelowContext(template)
+		}
+	}
+	if endpoint == "" {
+		endpoint = "/"
+	}
+	if endpoint != dataEndpoint {
+		return endpoint, ""
+	}
+	query := request.URL.Query().Get(queries.QueryParam)
+	if !queries.IsQuery(query) {
+		query = unknownQueryLabel
+	}
+	return endpoint, query
+}
+
+func (s *responseSizes) limit(endpoint string, query string) int64 {
+	if limit, ok := s.limits[query]; ok && query != "" {
+		return limit
+	}
+	if limit, ok := s.limits[endpoint]; ok {
+		return limit
+	}
+	if endpoint == dataEndpoint {
+		return s.maxQueryBytes
+	}
+	return 0
+}
+
+func (s *responseSizes) middleware(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		endpoint, query := responseEndpoint(request)
+		body := &countingReader{ReadCloser: request.Body}
+		if request.Body != nil {
+			request.Body = body
+		}
+		sized := &sizeResponseWriter{ResponseWriter: writer, status: http.StatusOK, limit: s.limit(endpoint, query)}
+		handler.ServeHTTP(sized, request)
+		sized.finish()
+
+		metricWebServerRequestBytes.WithLabelValues(endpoint, query).Observe(float64(int64(len(request.URL.RawQuery)) + body.bytes))
+		metricWebServerResponseBytes.WithLabelValues(endpoint, query).Observe(float64(sized.bytes))
+		if sized.truncated() {
+			metricWebServerTruncatedCount.WithLabelValues(endpoint, query).Inc()
+			glog.Warningf("reqId: %v response of %v bytes for %v %v truncated to %v bytes", getRequestId(request.Context()), sized.bytes, endpoint, query, sized.limit)
+		}
+	})
+}
+
+type countingReader struct {
+	io.ReadCloser
+	bytes int64
+}
+
+func (r *countingReader) Read(data []byte) (int, error) {
+	n, err := r.ReadCloser.Read(data)
+	r.bytes += int64(n)
+	return n, err
+}
+
+// Without a limit writes go straight through, with one they are buffered until finish
+type sizeResponseWriter struct {
+	http.ResponseWriter
+	limit  int64
+	status int
+	// Bytes written by the handler, including the ones cut off
+	bytes  int64
+	buffer bytes.Buffer
+}
+
+func (w *sizeResponseWriter) WriteHeader(status int) {
+	w.status = status
+	if w.limit <= 0 {
+		w.ResponseWriter.WriteHeader(status)
+	}
+}
+
+func (w *sizeResponseWriter) Write(data []byte) (int, error) {
+	if w.limit <= 0 {
+		n, err := w.ResponseWriter.Write(data)
+		w.bytes += int64(n)
+		return n, err
+	}
+	if room := w.limit - int64(w.buffer.Len()); room > 0 {
+		if int64(len(data)) < room {
+			room = int64(len(data))
+		}
+		w.buffer.Write(data[:room])
+	}
+	w.bytes += int64(len(data))
+	return len(data), nil
+}
+
+// Buffered responses can not be flushed before they are complete
+func (w *sizeResponseWriter) Flush() {
+	if w.limit > 0 {
+		return
+	}
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *sizeResponseWriter) truncated() bool {
+	return w.limit > 0 && w.bytes > w.limit
+}
+
+func (w *sizeResponseWriter) finish() {
+	if w.limit <= 0 {
+		return
+	}
+	if w.truncated() {
+		header := w.Header()
+		header.Del("Content-Length")
+		header.Set(queries.TruncatedHeader, "true")
+		header.Set(queries.ErrorCodeHeader, string(queries.ErrorCodeTruncated))
+		header.Set(queries.ResponseBytesHeader, strconv.FormatInt(w.bytes, 10))
+		header.Set(queries.MaxResponseBytesHeader, strconv.FormatInt(w.limit, 10))
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+	_, err := w.ResponseWriter.Write(w.buffer.Bytes())
+	if err != nil {
+		glog.Errorf("Failed to write response: %v", err)
+	}
+}
@@ -0,0 +1,87 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/queries"
+)
+
+func Test_responseSizes_middleware(t *testing.T) {
+	router := mux.NewRouter()
+	subRouter := router.PathPrefix("/{clusterContext}").Subrouter()
+	subRouter.Use(newResponseSizes(10, map[string]int64{"GetEventData": 0, "/export": 5}).middleware)
+	body := strings.Repeat("x", 20)
+	write := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		w.Write([]byte(body[:10]))
+		w.Write([]byte(body[10:]))
+	}
+	subRouter.HandleFunc("/data", write)
+	subRouter.HandleFunc("/export", write)
+	subRouter.HandleFunc("/resource", write)
+
+	serve := func(url string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, url, nil))
+		return recorder
+	}
+
+	truncatedBefore := testutil.ToFloat64(metricWebServerTruncatedCount.WithLabelValues("/data", "Namespaces"))
+	recorder := serve("/ctx/data?query=Namespaces")
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, body[:10], recorder.Body.String())
+	assert.Equal(t, "true", recorder.Header().Get(queries.TruncatedHeader))
+	assert.Equal(t, "20", recorder.Header().Get(queries.ResponseBytesHeader))
+	assert.Equal(t, "10", recorder.Header().Get(queries.MaxResponseBytesHeader))
+	assert.Equal(t, "application/json", recorder.Header().Get("content-type"))
+	assert.Equal(t, truncatedBefore+1, testutil.ToFloat64(metricWebServerTruncatedCount.WithLabelValues("/data", "Namespaces")))
+
+	// The limit of a query overrides the one of all queries
+	recorder = serve("/ctx/data?query=GetEventData")
+	assert.Equal(t, body, recorder.Body.String())
+	assert.Equal(t, "", recorder.Header().Get(queries.TruncatedHeader))
+
+	recorder = serve("/ctx/export")
+	assert.Equal(t, body[:5], recorder.Body.String())
+	assert.Equal(t, "true", recorder.Header().Get(queries.TruncatedHeader))
+
+	// Other endpoints have no limit unless they are given one
+	recorder = serve("/ctx/resource")
+	assert.Equal(t, body, recorder.Body.String())
+}
+
+func Test_responseEndpoint(t *testing.T) {
+	router := mux.NewRouter()
+	subRouter := router.PathPrefix("/{clusterContext}").Subrouter()
+	var endpoint, query string
+	record := func(w http.ResponseWriter, r *http.Request) {
+		endpoint, query = responseEndpoint(r)
+	}
+	subRouter.HandleFunc("/data", record)
+	subRouter.PathPrefix("/webfiles/").HandlerFunc(record)
+	subRouter.Handle("", http.HandlerFunc(record))
+
+	for url, expected := range map[string][]string{
+		"/ctx/data?query=EventHeatMap":   {"/data", "EventHeatMap"},
+		"/ctx/data?query=NotAQuery":      {"/data", unknownQueryLabel},
+		"/ctx/webfiles/static/style.css": {"/webfiles/", ""},
+		"/ctx":                           {"/", ""},
+	} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, url, nil))
+		assert.Equal(t, expected, []string{endpoint, query}, url)
+	}
+}
@@ -0,0 +1,111 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/queries"
+	"github.com/salesforce/sloop/pkg/sloop/storemanager"
+)
+
+const (
+	retentionOverridePath = "/admin/retention/override"
+	retentionForParam     = "for"
+)
+
+// GET lists the active overrides as storemanager.RetentionOverrideReport.  POST keeps a namespace past the retention
+// limits until the override expires.  Params: namespace, kind (empty = all kinds), for, a duration like 336h or 14d,
+// and reason.  DELETE with namespace and kind ends the override, 404 when there is none.  POST and DELETE return a
+// storemanager.RetentionOverrideReport, and need an authenticated caller so every override has who made it
+func retentionOverrideHandler(overrides *storemanager.RetentionOverrides) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method == http.MethodGet {
+			list, err := overrides.List(common.Now())
+			if err != nil {
+				logWebError(err, "Failed to list retention overrides", request, writer)
+				return
+			}
+			writeJson(writer, request, list)
+			return
+		}
+		if request.Method != http.MethodPost && request.Method != http.MethodDelete {
+			writeApiErrorf(writer, request, queries.ErrorCodeMethodNotAllowed, "retention overrides support GET, POST and DELETE")
+			return
+		}
+		identity := IdentityFromContext(request.Context())
+		if identity == nil {
+			writeApiErrorf(writer, request, queries.ErrorCodeUnauthorized, "retention overrides need an authenticated caller, see -auth-mode")
+			return
+		}
+		err := request.ParseForm()
+		if err != nil {
+			logWebError(err, "Failed to parse form", request, writer)
+			return
+		}
+		namespace := request.Form.Get(queries.NamespaceParam)
+		kind := request.Form.Get(queries.KindParam)
+		if namespace == "" {
+			writeApiErrorf(writer, request, queries.ErrorCodeBadParams, "%v is required", queries.NamespaceParam)
+			return
+		}
+
+		if request.Method == http.MethodDelete {
+			report, err := overrides.Remove(namespace, kind)
+			if err != nil {
+				logWebError(err, "Failed to remove retention override", request, writer)
+				return
+			}
+			if report == nil {
+				writeApiErrorf(writer, request, queries.ErrorCodeNotFound, "no retention override for namespace %q kind %q", namespace, kind)
+				return
+			}
+			writeJson(writer, request, report)
+			return
+		}
+
+		duration, err := parseRetentionDuration(request.Form.Get(retentionForParam))
+		if err == nil && duration <= 0 {
+			err = fmt.Errorf("%v must be positive", retentionForParam)
+		}
+		if err != nil {
+			writeApiErrorf(writer, request, queries.ErrorCodeBadParams, "%v", err)
+			return
+		}
+		report, err := overrides.Extend(namespace, kind, duration, request.Form.Get(reasonParam), identity.User, common.Now())
+		if err != nil {
+			logWebError(err, "Failed to extend retention", request, writer)
+			return
+		}
+		writeJson(writer, request, report)
+	}
+}
+
+// Go durations, plus whole days like 14d since overrides are usually that long
+func parseRetentionDuration(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, fmt.Errorf("%v is required", retentionForParam)
+	}
+	if strings.HasSuffix(value, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(value, "d"))
+		if err != nil {
+			return 0, fmt.Errorf("invalid %v %q", retentionForParam, value)
+		}
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %v %q", retentionForParam, value)
+	}
+	return duration, nil
+}
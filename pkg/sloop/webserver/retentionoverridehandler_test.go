@@ -0,0 +1,95 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/queries"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/salesforce/sloop/pkg/sloop/storemanager"
+)
+
+func helper_retentionRequest(method string, query string) *http.Request {
+	request := httptest.NewRequest(method, retentionOverridePath+query, nil)
+	return request.WithContext(context.WithValue(request.Context(), identityContextKey{}, &Identity{User: "alice"}))
+}
+
+func Test_retentionOverrideHandler(t *testing.T) {
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	handler := retentionOverrideHandler(storemanager.NewRetentionOverrides(db))
+
+	for _, query := range []string{"", "?for=14d", "?namespace=payments", "?namespace=payments&for=x", "?namespace=payments&for=-1h"} {
+		recorder := httptest.NewRecorder()
+		handler(recorder, helper_retentionRequest(http.MethodPost, query))
+		assert.Equal(t, http.StatusBadRequest, recorder.Code, query)
+	}
+
+	recorder := httptest.NewRecorder()
+	handler(recorder, helper_retentionRequest(http.MethodPost, "?namespace=payments&for=14d&reason=inc-42"))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	report := storemanager.RetentionOverrideReport{}
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &report))
+	assert.Equal(t, "payments", report.Namespace)
+	assert.Equal(t, "inc-42", report.Reason)
+	assert.Equal(t, "alice", report.CreatedBy)
+	assert.Equal(t, int64(14*24*time.Hour/time.Second), report.Expires-report.Created)
+
+	recorder = httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodGet, retentionOverridePath, nil))
+	list := []storemanager.RetentionOverrideReport{}
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &list))
+	assert.Len(t, list, 1)
+
+	recorder = httptest.NewRecorder()
+	handler(recorder, helper_retentionRequest(http.MethodDelete, "?namespace=payments"))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	handler(recorder, helper_retentionRequest(http.MethodDelete, "?namespace=payments"))
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+}
+
+func Test_retentionOverrideHandler_NeedsIdentity(t *testing.T) {
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	handler := retentionOverrideHandler(storemanager.NewRetentionOverrides(db))
+
+	for _, method := range []string{http.MethodPost, http.MethodDelete} {
+		recorder := httptest.NewRecorder()
+		handler(recorder, httptest.NewRequest(method, retentionOverridePath+"?namespace=payments&for=14d", nil))
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code, method)
+		assert.Equal(t, string(queries.ErrorCodeUnauthorized), recorder.Header().Get(queries.ErrorCodeHeader), method)
+	}
+
+	recorder := httptest.NewRecorder()
+	handler(recorder, helper_retentionRequest(http.MethodPut, ""))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+}
+
+func Test_parseRetentionDuration(t *testing.T) {
+	duration, err := parseRetentionDuration("14d")
+	assert.Nil(t, err)
+	assert.Equal(t, 14*24*time.Hour, duration)
+	duration, err = parseRetentionDuration("90m")
+	assert.Nil(t, err)
+	assert.Equal(t, 90*time.Minute, duration)
+	_, err = parseRetentionDuration("")
+	assert.NotNil(t, err)
+	_, err = parseRetentionDuration("xd")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,228 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/salesforce/sloop/pkg/sloop/queries"
+	"github.com/salesforce/sloop/pkg/sloop/shard"
+)
+
+const shardRequestTimeout = 2 * time.Minute
+
+var shardHttpClient = &http.Client{Timeout: shardRequestTimeout}
+
+var metricShardQueryFailureCount = promauto.NewCounterVec(prometheus.CounterOpts{Name: "sloop_shard_query_failure_count"}, []string{"shard"})
+
+// Picks the shards that need to see this query.  Queries about a single resource only go to the first shard that
+// owns the kind, everything else goes to all shards.
+func shardsForQuery(shardMap shard.Map, queryName string, kind string) []string {
+	if queries.IsSingleResourceQuery(queryName) && kind != "" && kind != queries.AllKinds {
+		owners := shardMap.ShardsForKind(kind)
+		if len(owners) > 0 {
+			return owners[:1]
+		}
+		return nil
+	}
+	return shardMap.ShardNames()
+}
+
+/*
+The headers of the caller that each shard needs to see, so a shard with the same auth and tenants as the frontend
+knows who is asking: the user and groups headers of proxy auth and the tenant header.  The frontend has already
+checked them, and the shards must only be reachable from the frontend the same way sloop must only be reachable
+through the proxy
+*/
+func shardForwardHeaders(authenticator Authenticator, tenantHeader string) []string {
+	forward := []string{}
+	if proxyAuth, ok := authenticator.(*ProxyHeaderAuthenticator); ok {
+		forward = append(forward, proxyAuth.UserHeader)
+		if proxyAuth.GroupsHeader != "" {
+			forward = append(forward, proxyAuth.GroupsHeader)
+		}
+	}
+	if tenantHeader != "" {
+		forward = append(forward, tenantHeader)
+	}
+	return forward
+}
+
+// Sends the query to one shard.  The endpoint is the base url of that shard's sloop including the cluster context,
+// for example http://sloop-pods:8080/mycluster
+func fetchFromShard(endpoint string, rawQuery string, requestId string, forward http.Header) ([]byte, http.Header, error) {
+	url := strings.TrimSuffix(endpoint, "/") + "/data?" + rawQuery
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to build request for %v", url)
+	}
+	for name, values := range forward {
+		req.Header[name] = values
+	}
+	req.Header.Set("X-Request-Id", requestId)
+
+	resp, err := shardHttpClient.Do(req)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to query %v", url)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to read response from %v", url)
+	}
+	if resp.StatusCode != http.StatusOK {
+		// Keep the code of a shard that rejected the query, like bad params, instead of making it internal
+		envelope := queries.ErrorEnvelope{}
+		if json.Unmarshal(body, &envelope) == nil && envelope.Error.Code != "" && envelope.Error.Code != queries.ErrorCodeInternal {
+			return nil, nil, queries.NewApiError(envelope.Error.Code, "query to %v failed: %v", endpoint, envelope.Error.Message)
+		}
+		return nil, nil, fmt.Errorf("query to %v returned status %v: %v", url, resp.StatusCode, string(body))
+	}
+	return body, resp.Header, nil
+}
+
+/*
+Splits the shard responses into the shards that answered, with their results and headers, and the ones that failed.
+One bad shard should not blank the whole timeline, so the query only fails when every shard failed, or when a shard
+rejected it with a client error like bad params, because the others would have said the same
+*/
+func splitShardResults(targets []string, results [][]byte, headers []http.Header, errs []error) ([]string, [][]byte, []http.Header, []queries.ShardErrorOutput, error) {
+	answered := []string{}
+	answeredResults := [][]byte{}
+	answeredHeaders := []http.Header{}
+	shardErrors := []queries.ShardErrorOutput{}
+	var firstErr error
+	for idx, err := range errs {
+		if err == nil {
+			answered = append(answered, targets[idx])
+			answeredResults = append(answeredResults, results[idx])
+			answeredHeaders = append(answeredHeaders, headers[idx])
+			continue
+		}
+		code := queries.ErrorCodeOf(err)
+		if code.Status() < http.StatusInternalServerError {
+			return nil, nil, nil, nil, err
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+		glog.Warningf("Shard %q failed, returning partial results: %v", targets[idx], err)
+		metricShardQueryFailureCount.WithLabelValues(targets[idx]).Inc()
+		shardErrors = append(shardErrors, queries.ShardErrorOutput{Shard: targets[idx], Code: code, Message: err.Error()})
+	}
+	if len(answered) == 0 {
+		return nil, nil, nil, nil, firstErr
+	}
+	return answered, answeredResults, answeredHeaders, shardErrors, nil
+}
+
+// Plans and per shard queries are not merged, each shard answers for its own part
+func mergePerShardResults(targets []string, results [][]byte) ([]byte, error) {
+	plans := map[string]json.RawMessage{}
+	for idx, shardName := range targets {
+		plans[shardName] = results[idx]
+	}
+	return json.MarshalIndent(plans, "", " ")
+}
+
+// Used by a query-only sloop in front of several ingest shards.  Each shard gets the same request, with the
+// forwardHeaders of the caller, and the results are merged into a single response.
+func shardQueryHandler(shardMap shard.Map, endpoints map[string]string, forwardHeaders []string) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("content-type", "application/json")
+
+		params := request.URL.Query()
+		queryName := params.Get(queries.QueryParam)
+		targets := shardsForQuery(shardMap, queryName, params.Get(queries.KindParam))
+		if len(targets) == 0 {
+			writeApiErrorf(writer, request, queries.ErrorCodeBadParams, "no shard owns kind %q", params.Get(queries.KindParam))
+			return
+		}
+
+		requestId := getRequestId(request.Context())
+		forward := http.Header{}
+		for _, name := range forwardHeaders {
+			if values := request.Header.Values(name); len(values) > 0 {
+				forward[http.CanonicalHeaderKey(name)] = values
+			}
+		}
+		results := make([][]byte, len(targets))
+		headers := make([]http.Header, len(targets))
+		errs := make([]error, len(targets))
+		wg := &sync.WaitGroup{}
+		for idx, shardName := range targets {
+			wg.Add(1)
+			go func(idx int, shardName string) {
+				defer wg.Done()
+				endpoint, ok := endpoints[shardName]
+				if !ok {
+					errs[idx] = fmt.Errorf("no endpoint configured for shard %q", shardName)
+					return
+				}
+				results[idx], headers[idx], errs[idx] = fetchFromShard(endpoint, request.URL.RawQuery, requestId, forward)
+			}(idx, shardName)
+		}
+		wg.Wait()
+
+		answered, answeredResults, answeredHeaders, shardErrors, err := splitShardResults(targets, results, headers, errs)
+		if err != nil {
+			writeApiError(writer, request, err, "Failed to run query on every shard")
+			return
+		}
+
+		var data []byte
+		if queries.IsExplain(params) || queries.IsPerShardQuery(queryName) {
+			data, err = mergePerShardResults(answered, answeredResults)
+		} else if params.Get(queries.MergeShardsParam) == "true" && queryName == "EventHeatMap" {
+			data, err = queries.MergeShardLanes(answered, answeredResults, shardErrors)
+		} else {
+			data, err = queries.MergePartialShardResults(queryName, params, answeredResults, shardErrors)
+		}
+		if err != nil {
+			writeApiError(writer, request, err, "Failed to merge shard results")
+			return
+		}
+		copyShardRetentionHeaders(writer, answeredHeaders)
+		if len(shardErrors) > 0 {
+			shardErrorsJson, _ := json.Marshal(shardErrors)
+			writer.Header().Set(queries.ShardErrorsHeader, string(shardErrorsJson))
+			writer.Header().Set(queries.ErrorCodeHeader, string(queries.ErrorCodePartial))
+		}
+		writer.Write(data)
+	}
+}
+
+// Shards purge on their own, the one that retains the least decides where the merged results have a gap
+func copyShardRetentionHeaders(writer http.ResponseWriter, headers []http.Header) {
+	var newest int64
+	for _, header := range headers {
+		if header.Get(queries.RetentionWarningHeader) == "" {
+			continue
+		}
+		retainedSince, err := strconv.ParseInt(header.Get(queries.RetainedSinceHeader), 10, 64)
+		if err != nil || retainedSince <= newest {
+			continue
+		}
+		newest = retainedSince
+		writer.Header().Set(queries.RetentionWarningHeader, header.Get(queries.RetentionWarningHeader))
+		writer.Header().Set(queries.RetainedSinceHeader, header.Get(queries.RetainedSinceHeader))
+	}
+}
@@ -0,0 +1,228 @@
+package webserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/salesforce/sloop/pkg/sloop/queries"
+	"github.com/salesforce/sloop/pkg/sloop/shard"
+	"github.com/stretchr/testify/assert"
+)
+
+func helper_fakeShard(t *testing.T, response string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/ctx/data", r.URL.Path)
+		w.Write([]byte(response))
+	}))
+}
+
+func Test_shardsForQuery(t *testing.T) {
+	shardMap := shard.Map{"a": {"Pod"}, "b": {"Node", shard.WildcardKind}}
+	assert.Equal(t, []string{"a", "b"}, shardsForQuery(shardMap, "EventHeatMap", "Pod"))
+	assert.Equal(t, []string{"a"}, shardsForQuery(shardMap, "GetResPayload", "Pod"))
+	assert.Equal(t, []string{"b"}, shardsForQuery(shardMap, "GetResPayload", "MyCrd"))
+	assert.Equal(t, []string{"a", "b"}, shardsForQuery(shardMap, "GetResPayload", "_all"))
+}
+
+func TestShardQueryHandler_MergesKinds(t *testing.T) {
+	shardA := helper_fakeShard(t, `["_all","Pod"]`)
+	defer shardA.Close()
+	shardB := helper_fakeShard(t, `["_all","Node"]`)
+	defer shardB.Close()
+
+	shardMap := shard.Map{"a": {"Pod"}, "b": {"Node"}}
+	endpoints := map[string]string{"a": shardA.URL + "/ctx", "b": shardB.URL + "/ctx/"}
+
+	req, err := http.NewRequest("GET", "/ctx/data?query=Kinds&lookback=1h", nil)
+	assert.Nil(t, err)
+	rr := httptest.NewRecorder()
+	shardQueryHandler(shardMap, endpoints, nil).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var kinds []string
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &kinds))
+	assert.Equal(t, []string{"Node", "Pod", "_all"}, kinds)
+}
+
+func TestShardQueryHandler_MissingEndpointFails(t *testing.T) {
+	shardMap := shard.Map{"a": {"Pod"}}
+	req, err := http.NewRequest("GET", "/ctx/data?query=Kinds&lookback=1h", nil)
+	assert.Nil(t, err)
+	rr := httptest.NewRecorder()
+	shardQueryHandler(shardMap, map[string]string{}, nil).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+}
+
+func TestShardQueryHandler_PartialWhenOneShardFails(t *testing.T) {
+	shardA := helper_fakeShard(t, `{"view_options":{"sort":"name"},"rows":[{"text":"pod1","kind":"Pod","namespace":"ns1","start_date":100}]}`)
+	defer shardA.Close()
+	shardB := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "partition is corrupt", http.StatusInternalServerError)
+	}))
+	defer shardB.Close()
+
+	shardMap := shard.Map{"a": {"Pod"}, "b": {"Node"}}
+	endpoints := map[string]string{"a": shardA.URL + "/ctx", "b": shardB.URL + "/ctx"}
+
+	req, err := http.NewRequest("GET", "/ctx/data?query=EventHeatMap&lookback=1h", nil)
+	assert.Nil(t, err)
+	rr := httptest.NewRecorder()
+	shardQueryHandler(shardMap, endpoints, nil).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, string(queries.ErrorCodePartial), rr.Header().Get(queries.ErrorCodeHeader))
+	var headerErrors []queries.ShardErrorOutput
+	assert.Nil(t, json.Unmarshal([]byte(rr.Header().Get(queries.ShardErrorsHeader)), &headerErrors))
+	assert.Len(t, headerErrors, 1)
+	assert.Equal(t, "b", headerErrors[0].Shard)
+	assert.Equal(t, queries.ErrorCodeInternal, headerErrors[0].Code)
+
+	var root queries.TimelineRoot
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &root))
+	assert.Len(t, root.Rows, 1)
+	assert.Equal(t, headerErrors, root.ShardErrors)
+}
+
+func TestShardQueryHandler_BadParamsFromOneShardFails(t *testing.T) {
+	shardA := helper_fakeShard(t, `["Pod"]`)
+	defer shardA.Close()
+	shardB := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":{"code":"bad_params","message":"invalid lookback"}}`))
+	}))
+	defer shardB.Close()
+
+	shardMap := shard.Map{"a": {"Pod"}, "b": {"Node"}}
+	endpoints := map[string]string{"a": shardA.URL + "/ctx", "b": shardB.URL + "/ctx"}
+
+	req, err := http.NewRequest("GET", "/ctx/data?query=Kinds&lookback=x", nil)
+	assert.Nil(t, err)
+	rr := httptest.NewRecorder()
+	shardQueryHandler(shardMap, endpoints, nil).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, "", rr.Header().Get(queries.ShardErrorsHeader))
+}
+
+func TestShardQueryHandler_MergeShardsMakesLanes(t *testing.T) {
+	row := `{"view_options":{"sort":"name"},"rows":[{"text":"web","kind":"Deployment","namespace":"shop","start_date":100,"end_date":200}]}`
+	east := helper_fakeShard(t, row)
+	defer east.Close()
+	west := helper_fakeShard(t, row)
+	defer west.Close()
+
+	shardMap := shard.Map{"east": {"Deployment"}, "west": {"Deployment"}}
+	endpoints := map[string]string{"east": east.URL + "/ctx", "west": west.URL + "/ctx"}
+
+	req, err := http.NewRequest("GET", "/ctx/data?query=EventHeatMap&lookback=1h&merge_shards=true", nil)
+	assert.Nil(t, err)
+	rr := httptest.NewRecorder()
+	shardQueryHandler(shardMap, endpoints, nil).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var root queries.TimelineRoot
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &root))
+	assert.Len(t, root.Rows, 1)
+	assert.Len(t, root.Rows[0].Lanes, 2)
+	assert.Equal(t, "east", root.Rows[0].Lanes[0].Shard)
+	assert.Equal(t, "west", root.Rows[0].Lanes[1].Shard)
+}
+
+func TestShardQueryHandler_ExplainKeyedByShard(t *testing.T) {
+	shardA := helper_fakeShard(t, `{"query":"Kinds"}`)
+	defer shardA.Close()
+	shardB := helper_fakeShard(t, `{"query":"Kinds"}`)
+	defer shardB.Close()
+
+	shardMap := shard.Map{"a": {"Pod"}, "b": {"Node"}}
+	endpoints := map[string]string{"a": shardA.URL + "/ctx", "b": shardB.URL + "/ctx"}
+
+	req, err := http.NewRequest("GET", "/ctx/data?query=Kinds&lookback=1h&explain=true", nil)
+	assert.Nil(t, err)
+	rr := httptest.NewRecorder()
+	shardQueryHandler(shardMap, endpoints, nil).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	plans := map[string]map[string]string{}
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &plans))
+	assert.Equal(t, "Kinds", plans["a"]["query"])
+	assert.Equal(t, "Kinds", plans["b"]["query"])
+}
+
+func TestShardQueryHandler_SelfFootprintKeyedByShard(t *testing.T) {
+	shardA := helper_fakeShard(t, `{"samples":[],"peak":{"rssBytes":100}}`)
+	defer shardA.Close()
+	shardB := helper_fakeShard(t, `{"samples":[],"peak":{"rssBytes":200}}`)
+	defer shardB.Close()
+
+	shardMap := shard.Map{"a": {"Pod"}, "b": {"Node"}}
+	endpoints := map[string]string{"a": shardA.URL + "/ctx", "b": shardB.URL + "/ctx"}
+
+	req, err := http.NewRequest("GET", "/ctx/data?query=GetSelfFootprint&lookback=1h", nil)
+	assert.Nil(t, err)
+	rr := httptest.NewRecorder()
+	shardQueryHandler(shardMap, endpoints, nil).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	footprints := map[string]queries.SelfFootprintOutput{}
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &footprints))
+	assert.Equal(t, int64(100), footprints["a"].Peak.RssBytes)
+	assert.Equal(t, int64(200), footprints["b"].Peak.RssBytes)
+}
+
+func TestShardQueryHandler_ForwardsAuthHeaders(t *testing.T) {
+	authenticator := &ProxyHeaderAuthenticator{UserHeader: "X-Forwarded-User", GroupsHeader: "X-Forwarded-Groups", AllowedGroups: []string{"sre"}}
+	var seen http.Header
+	shardHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = r.Header
+		w.Write([]byte(`["Pod"]`))
+	})
+	shardA := httptest.NewServer(authWrapper(shardHandler, authenticator))
+	defer shardA.Close()
+
+	shardMap := shard.Map{"a": {"Pod"}}
+	endpoints := map[string]string{"a": shardA.URL + "/ctx"}
+	forward := shardForwardHeaders(authenticator, "X-Sloop-Tenant")
+	assert.Equal(t, []string{"X-Forwarded-User", "X-Forwarded-Groups", "X-Sloop-Tenant"}, forward)
+
+	req, err := http.NewRequest("GET", "/ctx/data?query=Kinds&lookback=1h", nil)
+	assert.Nil(t, err)
+	req.Header.Set("X-Forwarded-User", "alice")
+	req.Header.Set("X-Forwarded-Groups", "dev,sre")
+	req.Header.Set("X-Sloop-Tenant", "shop")
+	req.Header.Set("Cookie", "session=secret")
+	rr := httptest.NewRecorder()
+	authWrapper(shardQueryHandler(shardMap, endpoints, forward), authenticator).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "alice", seen.Get("X-Forwarded-User"))
+	assert.Equal(t, "dev,sre", seen.Get("X-Forwarded-Groups"))
+	assert.Equal(t, "shop", seen.Get("X-Sloop-Tenant"))
+	assert.Equal(t, "", seen.Get("Cookie"))
+
+	// Without the headers the shard turns the query down like it would for any other caller
+	rr = httptest.NewRecorder()
+	shardQueryHandler(shardMap, endpoints, nil).ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+}
+
+func Test_copyShardRetentionHeaders(t *testing.T) {
+	older := http.Header{}
+	older.Set(queries.RetentionWarningHeader, "data before 2019-03-01T03:00Z has been purged")
+	older.Set(queries.RetainedSinceHeader, "1551409200")
+	newer := http.Header{}
+	newer.Set(queries.RetentionWarningHeader, "data before 2019-03-01T05:00Z has been purged")
+	newer.Set(queries.RetainedSinceHeader, "1551416400")
+
+	rr := httptest.NewRecorder()
+	copyShardRetentionHeaders(rr, []http.Header{older, newer, {}})
+	assert.Equal(t, "data before 2019-03-01T05:00Z has been purged", rr.Header().Get(queries.RetentionWarningHeader))
+	assert.Equal(t, "1551416400", rr.Header().Get(queries.RetainedSinceHeader))
+
+	rr = httptest.NewRecorder()
+	copyShardRetentionHeaders(rr, []http.Header{{}, {}})
+	assert.Equal(t, "", rr.Header().Get(queries.RetentionWarningHeader))
+}
@@ -0,0 +1,51 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/salesforce/sloop/pkg/sloop/standby"
+)
+
+func standbyStatusHandler(receiver *standby.Receiver) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		status, err := receiver.Status()
+		if err != nil {
+			logWebError(err, "Failed to read standby status", request, writer)
+			return
+		}
+		writeJson(writer, request, status)
+	}
+}
+
+// POST a badger backup from version since on, with its newest version in the standby.VersionTrailer trailer
+func standbyLoadHandler(receiver *standby.Receiver) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method != http.MethodPost {
+			http.Error(writer, "backups must be loaded with POST", http.StatusMethodNotAllowed)
+			return
+		}
+		since, err := strconv.ParseUint(request.URL.Query().Get(standby.SinceParam), 10, 64)
+		if err != nil {
+			http.Error(writer, fmt.Sprintf("invalid %v parameter: %v", standby.SinceParam, err), http.StatusBadRequest)
+			return
+		}
+		status, err := receiver.Load(since, request.Body, request.Trailer)
+		if err == standby.ErrGap {
+			http.Error(writer, fmt.Sprintf("%v, which is at version %v", err, status.Version), http.StatusConflict)
+			return
+		} else if err != nil {
+			logWebError(err, "Failed to load backup", request, writer)
+			return
+		}
+		writeJson(writer, request, status)
+	}
+}
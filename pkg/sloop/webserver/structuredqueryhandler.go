@@ -0,0 +1,69 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/salesforce/sloop/pkg/sloop/queries"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+)
+
+const (
+	structuredQueryPath  = "/api/v1/query"
+	structuredQueryParam = "q"
+)
+
+// GET or POST with q in the query language, see queries.ParseStructuredQuery, or POST a queries.StructuredQuery as json
+func structuredQueryHandler(tables typed.Tables, maxLookBack time.Duration) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		query, err := readStructuredQuery(request)
+		if err != nil {
+			writeApiErrorf(writer, request, queries.ErrorCodeBadParams, "%v", err)
+			return
+		}
+		data, err := queries.RunStructuredQuery(query, tables, maxLookBack, getRequestId(request.Context()))
+		if err != nil {
+			writeApiError(writer, request, err, "Structured query failed")
+			return
+		}
+		writer.Header().Set("content-type", "application/json")
+		writer.Write(data)
+	}
+}
+
+// Reads and validates the query of a request, an error is the reason it is a bad request
+func readStructuredQuery(request *http.Request) (queries.StructuredQuery, error) {
+	query := queries.StructuredQuery{}
+	contentType, _, _ := mime.ParseMediaType(request.Header.Get("content-type"))
+	if request.Method == http.MethodPost && contentType == "application/json" {
+		err := json.NewDecoder(request.Body).Decode(&query)
+		if err != nil {
+			return query, fmt.Errorf("invalid query: %v", err)
+		}
+	} else {
+		text := request.FormValue(structuredQueryParam)
+		if text == "" {
+			return query, fmt.Errorf("missing %v parameter", structuredQueryParam)
+		}
+		var err error
+		query, err = queries.ParseStructuredQuery(text)
+		if err != nil {
+			return query, fmt.Errorf("invalid query: %v", err)
+		}
+	}
+	err := query.Validate()
+	if err != nil {
+		return query, fmt.Errorf("invalid query: %v", err)
+	}
+	return query, nil
+}
@@ -0,0 +1,61 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/salesforce/sloop/pkg/sloop/queries"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_structuredQueryHandler(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	handler := structuredQueryHandler(typed.NewTableList(db), 24*time.Hour)
+
+	recorder := httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodGet, structuredQueryPath+"?q="+url.QueryEscape("kind=Pod | count by namespace"), nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	output := queries.StructuredQueryOutput{}
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &output))
+	assert.Equal(t, queries.StructuredSourceCurrent, output.Source)
+
+	request := httptest.NewRequest(http.MethodPost, structuredQueryPath, strings.NewReader(`{"kinds":["Pod"],"lookback":"1h","select":["name"]}`))
+	request.Header.Set("content-type", "application/json")
+	recorder = httptest.NewRecorder()
+	handler(recorder, request)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &output))
+	assert.Equal(t, queries.StructuredSourceHistory, output.Source)
+
+	for _, bad := range []*http.Request{
+		httptest.NewRequest(http.MethodGet, structuredQueryPath, nil),
+		httptest.NewRequest(http.MethodGet, structuredQueryPath+"?q=nonsense", nil),
+	} {
+		recorder = httptest.NewRecorder()
+		handler(recorder, bad)
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	}
+	request = httptest.NewRequest(http.MethodPost, structuredQueryPath, strings.NewReader(`{"groupBy":["a"],"select":["b"]}`))
+	request.Header.Set("content-type", "application/json")
+	recorder = httptest.NewRecorder()
+	handler(recorder, request)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+}
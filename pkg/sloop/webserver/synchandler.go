@@ -0,0 +1,89 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/storesync"
+)
+
+func syncPartitionsHandler(endpoint storesync.Endpoint) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		partitions, err := endpoint.Partitions()
+		if err != nil {
+			logWebError(err, "Failed to list partitions", request, writer)
+			return
+		}
+		writeJson(writer, request, storesync.PartitionList{Partitions: partitions})
+	}
+}
+
+// Params: partition
+func syncKeysHandler(endpoint storesync.Endpoint) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		partition := request.URL.Query().Get(storesync.PartitionParam)
+		if partition == "" {
+			http.Error(writer, fmt.Sprintf("missing %v parameter", storesync.PartitionParam), http.StatusBadRequest)
+			return
+		}
+		keys, err := endpoint.Keys(partition)
+		if err != nil {
+			logWebError(err, "Failed to list keys", request, writer)
+			return
+		}
+		writeJson(writer, request, storesync.KeyList{Keys: keys})
+	}
+}
+
+// POST a KeyList, returns the watch results stored under those keys
+func syncResultsHandler(endpoint storesync.Endpoint) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method != http.MethodPost {
+			http.Error(writer, "watch results must be read with POST", http.StatusMethodNotAllowed)
+			return
+		}
+		keyList := storesync.KeyList{}
+		err := json.NewDecoder(request.Body).Decode(&keyList)
+		if err != nil {
+			http.Error(writer, fmt.Sprintf("invalid key list: %v", err), http.StatusBadRequest)
+			return
+		}
+		results, err := endpoint.Results(keyList.Keys)
+		if err != nil {
+			logWebError(err, "Failed to read watch results", request, writer)
+			return
+		}
+		writeJson(writer, request, results)
+	}
+}
+
+// POST a list of watch results to process them into this store
+func syncPushHandler(endpoint storesync.Endpoint) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method != http.MethodPost {
+			http.Error(writer, "watch results must be pushed with POST", http.StatusMethodNotAllowed)
+			return
+		}
+		results := []typed.KubeWatchResult{}
+		err := json.NewDecoder(request.Body).Decode(&results)
+		if err != nil {
+			http.Error(writer, fmt.Sprintf("invalid watch results: %v", err), http.StatusBadRequest)
+			return
+		}
+		err = endpoint.Push(results)
+		if err != nil {
+			logWebError(err, "Failed to push watch results", request, writer)
+			return
+		}
+		writeJson(writer, request, storesync.PushResult{Accepted: len(results)})
+	}
+}
@@ -0,0 +1,82 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/salesforce/sloop/pkg/sloop/storesync"
+	"github.com/stretchr/testify/assert"
+)
+
+func helper_syncServer(t *testing.T, ingestChan chan typed.KubeWatchResult) (*httptest.Server, string) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+
+	ts := time.Date(2019, 3, 4, 3, 4, 0, 0, time.UTC)
+	pts, _ := ptypes.TimestampProto(ts)
+	key := typed.NewWatchTableKey(untyped.GetPartitionId(ts), "Pod", "ns", "pod1", ts).String()
+	err = tables.Db().Update(func(txn badgerwrap.Txn) error {
+		return tables.WatchTable().Set(txn, key, &typed.KubeWatchResult{Kind: "Pod", Timestamp: pts, Payload: "{}"})
+	})
+	assert.Nil(t, err)
+
+	endpoint := storesync.NewLocalEndpoint(tables, ingestChan)
+	mux := http.NewServeMux()
+	mux.HandleFunc(storesync.PartitionsPath, syncPartitionsHandler(endpoint))
+	mux.HandleFunc(storesync.KeysPath, syncKeysHandler(endpoint))
+	mux.HandleFunc(storesync.ResultsPath, syncResultsHandler(endpoint))
+	mux.HandleFunc(storesync.PushPath, syncPushHandler(endpoint))
+	return httptest.NewServer(mux), key
+}
+
+func Test_SyncHandlers_RoundTrip(t *testing.T) {
+	ingestChan := make(chan typed.KubeWatchResult, 10)
+	server, key := helper_syncServer(t, ingestChan)
+	defer server.Close()
+	remote := storesync.NewRemoteEndpoint(server.URL + "/")
+
+	partitions, err := remote.Partitions()
+	assert.Nil(t, err)
+	assert.Len(t, partitions, 1)
+	keys, err := remote.Keys(partitions[0])
+	assert.Nil(t, err)
+	assert.Equal(t, []string{key}, keys)
+	results, err := remote.Results(keys)
+	assert.Nil(t, err)
+	assert.Len(t, results, 1)
+	assert.Equal(t, "Pod", results[0].Kind)
+
+	err = remote.Push(results)
+	assert.Nil(t, err)
+	pushed := <-ingestChan
+	assert.Equal(t, results[0].Timestamp.Seconds, pushed.Timestamp.Seconds)
+}
+
+func Test_SyncHandlers_PushNotAccepted(t *testing.T) {
+	server, _ := helper_syncServer(t, nil)
+	defer server.Close()
+	remote := storesync.NewRemoteEndpoint(server.URL)
+
+	err := remote.Push([]typed.KubeWatchResult{{Kind: "Pod"}})
+	assert.NotNil(t, err)
+
+	resp, err := http.Get(server.URL + storesync.KeysPath)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
@@ -0,0 +1,225 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/queries"
+	"github.com/salesforce/sloop/pkg/sloop/tenant"
+)
+
+var metricTenantDeniedCount = promauto.NewCounterVec(prometheus.CounterOpts{Name: "sloop_tenant_denied_count"}, []string{"tenant"})
+
+// Paths below the cluster context a tenant can use.  Everything else, like backups, the debug pages and the admin
+// APIs, is only for the admin tenant
+var tenantPaths = map[string]bool{"": true, "/": true, "/data": true, "/export": true, "/resource": true, resourceAtPath: true, rawWatchPath: true}
+
+// Paths below the cluster context that need neither an identity nor a tenant, since probes and scrapers call sloop
+// directly instead of through the proxy
+var openPaths = map[string]bool{"/healthz": true, "/metrics": true}
+
+const tenantWebFilesPrefix = "/webfiles/"
+
+// Queries that do not read resources of a namespace, so they run without one
+var tenantNamespaceFreeQueries = map[string]bool{"Namespaces": true, "Kinds": true, "Queries": true}
+
+// Queries tenants can run, all of which only return rows of the namespace param, and of other_namespace for the
+// comparison.  Queries that resolve by uid, read nodes or other cluster scoped objects, or report on the whole store
+// are left out, since their rows can come from the namespaces of other tenants
+var tenantQueries = map[string]bool{
+	"EventHeatMap":            true,
+	"GetEventData":            true,
+	"GetResPayload":           true,
+	"GetResSummaryData":       true,
+	"GetPodLifecycle":         true,
+	"GetServiceBackends":      true,
+	"GetCurrentState":         true,
+	"GetNamespaceEpochs":      true,
+	"GetFlappingResources":    true,
+	"GetQuotaUtilization":     true,
+	"GetCredentialUsage":      true,
+	"GetDeploymentRollouts":   true,
+	"GetConfigImpact":         true,
+	"GetEventBreakdown":       true,
+	"GetSnapshotDiff":         true,
+	"GetApiVersionMigrations": true,
+	"GetNamespaceComparison":  true,
+	"GetEventTrend":           true,
+}
+
+/*
+Keeps callers to the namespaces of their tenant.  With an Authenticator the tenant is the first group of the caller
+that is the admin or a tenant.  Without one the tenant comes from a request header that an authenticating proxy in
+front of sloop sets and strips from client requests.  Queries and
+exports need a namespace of the tenant, and cluster scoped kinds other than Namespace are not visible to tenants.
+Does nothing when no tenants are configured
+*/
+func tenantWrapper(handler http.Handler, tenants tenant.Map, header string, admin string) http.Handler {
+	if len(tenants) == 0 {
+		return handler
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if openPaths[pathBelowContext(r.URL.Path)] {
+			handler.ServeHTTP(w, r)
+			return
+		}
+		tenantName := requestTenant(r, tenants, header, admin)
+		r = r.WithContext(context.WithValue(r.Context(), tenantContextKey{}, tenantName))
+		if admin != "" && tenantName == admin {
+			handler.ServeHTTP(w, r)
+			return
+		}
+		if _, ok := tenants[tenantName]; !ok {
+			denyTenant(w, r, tenantName, fmt.Sprintf("unknown tenant %q", tenantName))
+			return
+		}
+
+		subPath := pathBelowContext(r.URL.Path)
+		if !tenantPaths[subPath] && !strings.HasPrefix(subPath, tenantWebFilesPrefix) {
+			denyTenant(w, r, tenantName, fmt.Sprintf("path %q is not available to tenants", subPath))
+			return
+		}
+		if subPath != "/data" && subPath != "/export" && subPath != resourceAtPath && subPath != rawWatchPath {
+			handler.ServeHTTP(w, r)
+			return
+		}
+
+		// Check the same params the handlers read
+		params := r.URL.Query()
+		if subPath == "/export" {
+			err := r.ParseForm()
+			if err != nil {
+				writeApiErrorf(w, r, queries.ErrorCodeBadParams, "failed to parse form: %v", err)
+				return
+			}
+			params = r.Form
+		}
+		err := checkTenantParams(params, tenants, tenantName, subPath == "/data")
+		if err != nil {
+			denyTenant(w, r, tenantName, err.Error())
+			return
+		}
+		if subPath == "/data" && params.Get(queries.QueryParam) == "Namespaces" {
+			serveTenantList(handler, w, r, tenantName, func(namespace string) bool { return tenants.OwnsNamespace(tenantName, namespace) })
+			return
+		}
+		if subPath == "/data" && params.Get(queries.QueryParam) == "Queries" {
+			serveTenantList(handler, w, r, tenantName, func(query string) bool { return tenantQueries[query] })
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+type tenantContextKey struct{}
+
+func requestTenant(r *http.Request, tenants tenant.Map, header string, admin string) string {
+	identity := IdentityFromContext(r.Context())
+	if identity == nil {
+		return r.Header.Get(header)
+	}
+	for _, group := range identity.Groups {
+		if _, ok := tenants[group]; ok || (admin != "" && group == admin) {
+			return group
+		}
+	}
+	return ""
+}
+
+func denyTenant(w http.ResponseWriter, r *http.Request, tenantName string, reason string) {
+	metricTenantDeniedCount.WithLabelValues(tenantName).Inc()
+	glog.Warningf("Denied %q for tenant %q: %v", r.URL, tenantName, reason)
+	writeApiErrorf(w, r, queries.ErrorCodeForbidden, "%v", reason)
+}
+
+// Strips the cluster context, /mycluster/data becomes /data
+func pathBelowContext(urlPath string) string {
+	parts := strings.SplitN(strings.TrimPrefix(urlPath, "/"), "/", 2)
+	if len(parts) < 2 {
+		return ""
+	}
+	return "/" + parts[1]
+}
+
+func checkTenantParams(params url.Values, tenants tenant.Map, tenantName string, isQuery bool) error {
+	if isQuery {
+		queryName := params.Get(queries.QueryParam)
+		if queryName == "" {
+			queryName = queries.Default()
+		}
+		if tenantNamespaceFreeQueries[queryName] {
+			return nil
+		}
+		if !tenantQueries[queryName] {
+			return fmt.Errorf("query %q is not available to tenants", queryName)
+		}
+	}
+	for _, param := range []string{queries.NamespaceParam, queries.OtherNamespaceParam} {
+		namespace := params.Get(param)
+		if param == queries.OtherNamespaceParam && namespace == "" {
+			continue
+		}
+		if !tenants.OwnsNamespace(tenantName, namespace) {
+			return fmt.Errorf("namespace %q does not belong to tenant %q", namespace, tenantName)
+		}
+	}
+	for _, kind := range params[queries.KindParam] {
+		if kind != queries.AllKinds && kind != kubeextractor.NamespaceKind && kubeextractor.IsClustersScopedResource(kind) {
+			return fmt.Errorf("cluster scoped kind %q is not available to tenants", kind)
+		}
+	}
+	return nil
+}
+
+// Runs a query returning a list of strings, like Namespaces or Queries, and only returns the ones the tenant can use
+func serveTenantList(handler http.Handler, w http.ResponseWriter, r *http.Request, tenantName string, keep func(string) bool) {
+	buffered := &bufferedResponseWriter{header: http.Header{}, status: http.StatusOK}
+	handler.ServeHTTP(buffered, r)
+	values := []string{}
+	if buffered.status != http.StatusOK || json.Unmarshal(buffered.body.Bytes(), &values) != nil {
+		denyTenant(w, r, tenantName, "failed to filter the query result")
+		return
+	}
+	kept := []string{}
+	for _, value := range values {
+		if keep(value) {
+			kept = append(kept, value)
+		}
+	}
+	writeJson(w, r, kept)
+}
+
+type bufferedResponseWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (b *bufferedResponseWriter) Header() http.Header {
+	return b.header
+}
+
+func (b *bufferedResponseWriter) Write(data []byte) (int, error) {
+	return b.body.Write(data)
+}
+
+func (b *bufferedResponseWriter) WriteHeader(status int) {
+	b.status = status
+}
@@ -0,0 +1,120 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/salesforce/sloop/pkg/sloop/queries"
+	"github.com/salesforce/sloop/pkg/sloop/tenant"
+	"github.com/stretchr/testify/assert"
+)
+
+var someTenants = tenant.Map{
+	"payments": {Namespaces: []string{"billing", "pay-*"}},
+	"platform": {Namespaces: []string{"kube-system"}},
+}
+
+func helper_tenantRequest(t *testing.T, handler http.Handler, tenantName string, url string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(http.MethodGet, url, nil)
+	if tenantName != "" {
+		request.Header.Set("X-Sloop-Tenant", tenantName)
+	}
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func Test_tenantWrapper_Access(t *testing.T) {
+	inner := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`["billing","default","pay-api","_all"]`))
+	})
+	handler := tenantWrapper(inner, someTenants, "X-Sloop-Tenant", "admins")
+
+	for url, expected := range map[string]int{
+		"/ctx/data?query=EventHeatMap&namespace=billing&kind=Pod":  http.StatusOK,
+		"/ctx/data?query=EventHeatMap&namespace=pay-api&kind=_all": http.StatusOK,
+		"/ctx/data?query=EventHeatMap&namespace=kube-system":       http.StatusForbidden,
+		"/ctx/data?query=EventHeatMap&namespace=_all":              http.StatusForbidden,
+		"/ctx/data?query=EventHeatMap&namespace=billing&kind=Node": http.StatusForbidden,
+		"/ctx/data?query=Kinds":                                    http.StatusOK,
+		"/ctx/data?query=GetNodeHealth&namespace=billing":          http.StatusForbidden,
+		"/ctx/export?namespace=billing&kind=Pod":                   http.StatusOK,
+		"/ctx/export?kind=Pod":                                     http.StatusForbidden,
+		"/ctx/webfiles/filter.js":                                  http.StatusOK,
+		"/ctx":                                                     http.StatusOK,
+		"/ctx/data/backup":                                         http.StatusForbidden,
+		"/ctx/debug/listkeys/":                                     http.StatusForbidden,
+	} {
+		assert.Equal(t, expected, helper_tenantRequest(t, handler, "payments", url).Code, url)
+	}
+
+	assert.Equal(t, http.StatusForbidden, helper_tenantRequest(t, handler, "", "/ctx/data?query=Kinds").Code)
+	assert.Equal(t, http.StatusForbidden, helper_tenantRequest(t, handler, "nobody", "/ctx/data?query=Kinds").Code)
+	assert.Equal(t, http.StatusOK, helper_tenantRequest(t, handler, "admins", "/ctx/data/backup").Code)
+	// Without tenants everybody gets everything
+	assert.Equal(t, http.StatusOK, helper_tenantRequest(t, tenantWrapper(inner, nil, "X-Sloop-Tenant", ""), "", "/ctx/data/backup").Code)
+}
+
+func Test_tenantWrapper_NamespacesAreFiltered(t *testing.T) {
+	inner := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`["billing","default","pay-api","_all"]`))
+	})
+	handler := tenantWrapper(inner, someTenants, "X-Sloop-Tenant", "")
+	recorder := helper_tenantRequest(t, handler, "payments", "/ctx/data?query=Namespaces")
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	namespaces := []string{}
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &namespaces))
+	assert.Equal(t, []string{"billing", "pay-api"}, namespaces)
+}
+
+// An owner tree is resolved by uid alone, so a uid of another tenant would return its resources
+func Test_tenantWrapper_OwnerTreeOfOtherTenant(t *testing.T) {
+	served := false
+	inner := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		served = true
+		w.Write([]byte(`{}`))
+	})
+	handler := tenantWrapper(inner, someTenants, "X-Sloop-Tenant", "admins")
+
+	assert.Equal(t, http.StatusForbidden, helper_tenantRequest(t, handler, "payments", "/ctx/data?query=GetOwnerTree&namespace=kube-system&uuid=uid-of-platform").Code)
+	assert.Equal(t, http.StatusForbidden, helper_tenantRequest(t, handler, "payments", "/ctx/data?query=GetOwnerTree&namespace=billing&uuid=uid-of-platform").Code)
+	assert.Equal(t, http.StatusForbidden, helper_tenantRequest(t, handler, "payments", "/ctx/data?query=GetDeletionCascade&namespace=billing&kind=Deployment&name=x&uuid=uid-of-platform").Code)
+	assert.False(t, served)
+	assert.Equal(t, http.StatusOK, helper_tenantRequest(t, handler, "admins", "/ctx/data?query=GetOwnerTree&uuid=uid-of-platform").Code)
+}
+
+func Test_tenantWrapper_OtherNamespace(t *testing.T) {
+	inner := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	})
+	handler := tenantWrapper(inner, someTenants, "X-Sloop-Tenant", "")
+
+	assert.Equal(t, http.StatusOK, helper_tenantRequest(t, handler, "payments", "/ctx/data?query=GetNamespaceComparison&namespace=billing&other_namespace=pay-api").Code)
+	assert.Equal(t, http.StatusForbidden, helper_tenantRequest(t, handler, "payments", "/ctx/data?query=GetNamespaceComparison&namespace=billing&other_namespace=kube-system").Code)
+	assert.Equal(t, http.StatusForbidden, helper_tenantRequest(t, handler, "payments", "/ctx/data?query=GetNamespaceComparison&namespace=kube-system&other_namespace=billing").Code)
+}
+
+func Test_tenantWrapper_QueriesAreFiltered(t *testing.T) {
+	inner := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`["EventHeatMap","GetOwnerTree","GetEventData"]`))
+	})
+	handler := tenantWrapper(inner, someTenants, "X-Sloop-Tenant", "")
+	recorder := helper_tenantRequest(t, handler, "payments", "/ctx/data?query=Queries")
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	names := []string{}
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &names))
+	assert.Equal(t, []string{"EventHeatMap", "GetEventData"}, names)
+
+	for queryName := range tenantQueries {
+		assert.True(t, queries.IsQuery(queryName), queryName)
+	}
+}
@@ -0,0 +1,86 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// W3C trace context header that OpenTelemetry propagates traces with
+const traceParentHeader = "traceparent"
+
+var (
+	// 5ms to about 80s
+	latencyBuckets = prometheus.ExponentialBuckets(0.005, 2, 15)
+
+	metricQueryLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{Name: "sloop_query_latency_seconds", Buckets: latencyBuckets}, []string{"endpoint", "query"})
+)
+
+// Ids of the trace and span of a request, from a traceparent header like
+// 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01.  Empty unless the caller sampled the trace, since traces
+// that are not sampled can not be looked up
+func sampledTraceParent(request *http.Request) (string, string) {
+	parts := strings.Split(strings.TrimSpace(request.Header.Get(traceParentHeader)), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" {
+		return "", ""
+	}
+	traceId, spanId, flags := parts[1], parts[2], parts[3]
+	if !isLowerHex(traceId, 32) || !isLowerHex(spanId, 16) || !isLowerHex(flags, 2) {
+		return "", ""
+	}
+	// All zeros is not a valid id
+	if strings.Trim(traceId, "0") == "" || strings.Trim(spanId, "0") == "" {
+		return "", ""
+	}
+	flagBytes, _ := hex.DecodeString(flags)
+	if flagBytes[0]&1 == 0 {
+		return "", ""
+	}
+	return traceId, spanId
+}
+
+func isLowerHex(value string, length int) bool {
+	if len(value) != length || strings.ToLower(value) != value {
+		return false
+	}
+	_, err := hex.DecodeString(value)
+	return err == nil
+}
+
+/*
+Records the latency of every endpoint, and of every query for /data, on sloop_query_latency_seconds.  With exemplars,
+an observation of a request in a sampled trace carries the trace_id and span_id of the caller, so a latency spike on
+a dashboard links to the trace of the slow query.  Exemplars are only exposed on /metrics to scrapers that ask for
+OpenMetrics
+*/
+func queryLatencyMiddleware(exemplars bool) mux.MiddlewareFunc {
+	return func(handler http.Handler) http.Handler {
+		return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			before := time.Now()
+			handler.ServeHTTP(writer, request)
+			seconds := time.Since(before).Seconds()
+
+			endpoint, query := responseEndpoint(request)
+			observer := metricQueryLatency.WithLabelValues(endpoint, query)
+			if exemplars {
+				if traceId, spanId := sampledTraceParent(request); traceId != "" {
+					observer.(prometheus.ExemplarObserver).ObserveWithExemplar(seconds, prometheus.Labels{"trace_id": traceId, "span_id": spanId})
+					return
+				}
+			}
+			observer.Observe(seconds)
+		})
+	}
+}
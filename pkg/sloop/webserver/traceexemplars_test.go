@@ -0,0 +1,77 @@
+/*
+ * Copyright (c) 2021, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+const someTraceId = "4bf92f3577b34da6a3ce929d0e0e4736"
+const someSpanId = "00f067aa0ba902b7"
+
+func Test_sampledTraceParent(t *testing.T) {
+	for header, expected := range map[string][]string{
+		"00-" + someTraceId + "-" + someSpanId + "-01":                   {someTraceId, someSpanId},
+		"00-" + someTraceId + "-" + someSpanId + "-03":                   {someTraceId, someSpanId},
+		"00-" + someTraceId + "-" + someSpanId + "-00":                   {"", ""},
+		"00-" + "00000000000000000000000000000000-" + someSpanId + "-01": {"", ""},
+		"00-" + someTraceId + "-0000000000000000-01":                     {"", ""},
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-" + someSpanId + "-01":      {"", ""},
+		"ff-" + someTraceId + "-" + someSpanId + "-01":                   {"", ""},
+		"00-" + someTraceId + "-01":                                      {"", ""},
+		"":                                                               {"", ""},
+	} {
+		request := httptest.NewRequest(http.MethodGet, "/ctx/data", nil)
+		request.Header.Set(traceParentHeader, header)
+		traceId, spanId := sampledTraceParent(request)
+		assert.Equal(t, expected, []string{traceId, spanId}, header)
+	}
+}
+
+func helper_latencyExemplars(t *testing.T, query string) []*dto.Exemplar {
+	metric := &dto.Metric{}
+	assert.Nil(t, metricQueryLatency.WithLabelValues("/data", query).(prometheus.Metric).Write(metric))
+	exemplars := []*dto.Exemplar{}
+	for _, bucket := range metric.Histogram.Bucket {
+		if bucket.Exemplar != nil {
+			exemplars = append(exemplars, bucket.Exemplar)
+		}
+	}
+	return exemplars
+}
+
+func Test_queryLatencyMiddleware(t *testing.T) {
+	serve := func(exemplars bool, query string) {
+		router := mux.NewRouter()
+		subRouter := router.PathPrefix("/{clusterContext}").Subrouter()
+		subRouter.Use(queryLatencyMiddleware(exemplars))
+		subRouter.HandleFunc("/data", func(w http.ResponseWriter, r *http.Request) {})
+		request := httptest.NewRequest(http.MethodGet, "/ctx/data?query="+query, nil)
+		request.Header.Set(traceParentHeader, "00-"+someTraceId+"-"+someSpanId+"-01")
+		router.ServeHTTP(httptest.NewRecorder(), request)
+	}
+
+	serve(false, "Namespaces")
+	assert.Len(t, helper_latencyExemplars(t, "Namespaces"), 0)
+
+	serve(true, "Kinds")
+	exemplars := helper_latencyExemplars(t, "Kinds")
+	assert.Len(t, exemplars, 1)
+	labels := map[string]string{}
+	for _, label := range exemplars[0].Label {
+		labels[label.GetName()] = label.GetValue()
+	}
+	assert.Equal(t, map[string]string{"trace_id": someTraceId, "span_id": someSpanId}, labels)
+}
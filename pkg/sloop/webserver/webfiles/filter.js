@@ -113,6 +113,11 @@ function setFiltersAndReturnQueryUrl(defaultLookback, defaultKind, defaultNamesp
     sort =            setDropdown("sort",     "filtersort",     "start_time", false)
 
     namematch = setText("namematch", "filternamematch", "")
+    mergeShards = getUrlParam("merge_shards", "false")
+    let mergeShardsCheck = document.getElementById("filtermergeshards")
+    if (mergeShardsCheck) {
+        mergeShardsCheck.checked = (mergeShards === "true")
+    }
 
     windowLocation = window.location.pathname.toString()
     query =           populateDropdownFromQuery("query",     "filterquery",     "EventHeatMap",  windowLocation+"/data?query=Queries&lookback="+lookback);
@@ -120,6 +125,10 @@ function setFiltersAndReturnQueryUrl(defaultLookback, defaultKind, defaultNamesp
     kind =            populateDropdownFromQuery("kind",      "filterkind",      defaultKind,      windowLocation+"/data?query=Kinds&lookback="+lookback);
 
     dataQuery = windowLocation+"/data?query="+query+"&namespace="+ns+"&lookback="+lookback+"&kind="+kind+"&sort="+sort+"&namematch="+namematch+"&end_time="+selectedEndTime
+    if (mergeShards === "true") {
+        dataQuery += "&merge_shards=true"
+    }
+    heatMapQuery = windowLocation+"/api/v1/eventheatmap?namespace="+ns+"&lookback="+lookback+"&end_time="+selectedEndTime
     return dataQuery
 }
 
@@ -88,6 +88,7 @@ let cmpFn = noSortFn;
 function loadSVG() {
     payload = d3.json(dataQueryUrl);
     payload.then(function (result) {
+        renderShardErrors(result.shardErrors);
         initializeDimensions();
         svg = render(result);
         bindMouseEvents(svg);
@@ -96,6 +97,67 @@ function loadSVG() {
     });
 }
 loadSVG();
+renderNamespaceHeatMap();
+
+// Shards that failed are missing from the timeline, say which instead of showing it as complete
+function renderShardErrors(shardErrors) {
+    let banner = document.getElementById("shard_errors");
+    if (!shardErrors || shardErrors.length === 0) {
+        banner.hidden = true;
+        return;
+    }
+    banner.textContent = "Partial results, these shards failed: " +
+        shardErrors.map(e => e.shard + " (" + e.message + ")").join(", ");
+    banner.hidden = false;
+}
+
+// Busiest namespaces whose events show up in the heat map strip
+const namespaceHeatMapRows = 5;
+const namespaceHeatMapRowHeight = 10;
+
+// A strip of the busiest namespaces by time bucket above the timeline, from the precomputed event heat map.  Cells get
+// darker with more events and redder with more warnings.  Left hidden when the endpoint is not available
+function renderNamespaceHeatMap() {
+    let strip = document.getElementById("namespace_heatmap");
+    if (typeof heatMapQuery === "undefined") {
+        return;
+    }
+    d3.json(heatMapQuery).then(function (result) {
+        let rows = result.namespaces.slice(0, namespaceHeatMapRows);
+        if (rows.length === 0) {
+            strip.hidden = true;
+            return;
+        }
+        let maxCount = d3.max(rows, r => d3.max(r.cells, c => d3.sum(Object.values(c.counts))));
+        let width = document.documentElement.clientWidth - margin.left - 40;
+        let x = d3.scaleLinear().domain([result.start, result.end]).range([0, width]);
+        let cellWidth = Math.max(1, x(result.start + result.bucketSeconds) - x(result.start));
+        let severity = d3.scaleLinear().domain([0, 1]).range([palette.severity[0], palette.severity[2]]);
+
+        d3.select(strip).selectAll("*").remove();
+        let stripSvg = d3.select(strip).append("svg")
+            .attr("width", width + margin.left)
+            .attr("height", rows.length * namespaceHeatMapRowHeight);
+        let row = stripSvg.selectAll("g").data(rows).enter().append("g")
+            .attr("transform", (r, i) => "translate(0," + i * namespaceHeatMapRowHeight + ")");
+        row.append("text")
+            .attr("x", margin.left - 4)
+            .attr("y", namespaceHeatMapRowHeight - 2)
+            .attr("text-anchor", "end")
+            .text(r => r.namespace === "" ? "(cluster)" : r.namespace);
+        row.selectAll("rect").data(r => r.cells).enter().append("rect")
+            .attr("x", c => margin.left + x(c.timestamp))
+            .attr("width", cellWidth)
+            .attr("height", namespaceHeatMapRowHeight - 1)
+            .attr("fill", c => severity((c.counts.Warning || 0) / d3.sum(Object.values(c.counts))))
+            .attr("fill-opacity", c => 0.2 + 0.8 * d3.sum(Object.values(c.counts)) / maxCount)
+            .append("title")
+            .text(c => formatDateTime(new Date(c.timestamp * 1000)) + " " + JSON.stringify(c.counts));
+        strip.hidden = false;
+    }).catch(function () {
+        strip.hidden = true;
+    });
+}
 
 // Payload toggle switch on change to display payload change ticks
 function payloadChecker() {
@@ -216,35 +278,42 @@ function processAndSortResources(result) {
                     break;
             }
 
-            result = {
-                ...d,
-                start: d.start_date * 1000,
-                end: (d.start_date * 1000) + (d.duration * 1000),
-                overlays: d.overlays.map(e => {
-                    // e is the Overlay struct defined in
-                    // pkg/sloop/queries/types.go
-                    let splitText = e.text.split(" ")
-                    let worstSeverity = d3.max(splitText, text => {
-                        return severity.get(text.split(":")[1])
-                    });
-
-                    let overlay = {
-                        ...e,
-                        start: (e.start_date * 1000),
-                        end: (e.start_date * 1000) + (e.duration * 1000),
-                        severity: worstSeverity,
-                        reason: e.text,
-                        count: splitText[2],
-                    };
-                    return overlay
-                })
-            };
-            return result
+            return toTimelineBar(d)
         }).sort(cmpFn);
-        return data
+        // Rows merged across shards with merge_shards are followed by the lane of each shard
+        return data.flatMap(d => [d].concat((d.lanes || []).map(lane => toTimelineBar({
+            ...lane,
+            text: d.text + " @ " + lane.shard,
+        }))));
     }
 }
 
+function toTimelineBar(d) {
+    return {
+        ...d,
+        start: d.start_date * 1000,
+        end: (d.start_date * 1000) + (d.duration * 1000),
+        overlays: (d.overlays || []).map(e => {
+            // e is the Overlay struct defined in
+            // pkg/sloop/queries/types.go
+            let splitText = e.text.split(" ")
+            let worstSeverity = d3.max(splitText, text => {
+                return severity.get(text.split(":")[1])
+            });
+
+            let overlay = {
+                ...e,
+                start: (e.start_date * 1000),
+                end: (e.start_date * 1000) + (e.duration * 1000),
+                severity: worstSeverity,
+                reason: e.text,
+                count: splitText[2],
+            };
+            return overlay
+        })
+    };
+}
+
 function compareKind(a, b) {
     return ('' + a.kind).localeCompare(b.kind)
 }
@@ -315,6 +384,7 @@ function bindMouseEvents(svg) {
                     title: d.text,
                     kind: d.kind,
                     namespace: d.namespace,
+                    completeness: d.completeness,
                     time: theTime
                 }
             ))
@@ -379,7 +449,8 @@ function bindMouseEvents(svg) {
             let content = {
                 title: d.text,
                 time: thisChange, 
-                change: changeBool 
+                change: changeBool,
+                missed: d3.select(this).attr("id") === "missed"
             };
 
             d3.select(this).attr("x", xPos - 5).attr("width", width + 10);
@@ -426,11 +497,33 @@ function getResourceBarContent(d) {
     return `<div id="tiny-tooltip">Name: <b>${d.title}</b><br/>` +
         `Kind: <b>${d.kind}</b><br/>` +
         `Namespace: <b>${d.namespace}</b><br/>` +
+        getCompletenessContent(d.completeness) +
         `<br/>${formatDateTime(d.time)}</div>`;
 }
 
+// HistoryCompleteness in pkg/sloop/queries/completeness.go, only shown when some history may be missing
+function getCompletenessContent(c) {
+    if (c == null || c.score >= 1) {
+        return "";
+    }
+    let reasons = [];
+    if (c.missedUpdates) {
+        reasons.push(`${c.missedUpdates} missed updates`);
+    }
+    if (c.ingestGapSeconds) {
+        reasons.push(`${c.ingestGapSeconds}s of ingest problems`);
+    }
+    if (c.relists) {
+        reasons.push(`${c.relists} relists`);
+    }
+    return `History completeness: <b>${Math.round(c.score * 100)}%</b> (${reasons.join(", ")})<br/>`;
+}
+
 function getChangeContent(d) {
-    if (d.change) {
+    if (d.missed) {
+        return `<div id="tiny-tooltip">Name: <b>${d.title}</b><br/>` +
+        `Versions before the update at ${formatDateTime(d.time)} were not stored</div>`
+    } else if (d.change) {
         return `<div id="tiny-tooltip">Name: <b>${d.title}</b><br/>` +
         `Payload change at ${formatDateTime(d.time)} </div>`
     } else {
@@ -523,6 +616,22 @@ function createResourceBar(d) {
         });
     }
 
+    if (d.missedat != null) {
+        d.missedat.forEach(function (timestamp) {
+            // add orange tick mark at middle of band where versions before this update are missing
+            el
+                .append("rect")
+                .attr("x", xAxisScale(timestamp*1000))
+                .attr("y", 2 * (yAxisBand.bandwidth() / 5))
+                .attr("height", yAxisBand.bandwidth() / 5)
+                .attr("width", 2)
+                .attr("fill", "orange")
+                .attr("index", timestamp)
+                .classed("payloadChange", true)
+                .attr("id", "missed")
+        });
+    }
+
     el.append("text")
         .text(d.text)
         .attr("x", isLabelRight ? sx - 5 : sx + w + 5)
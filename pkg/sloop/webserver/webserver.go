@@ -16,6 +16,7 @@ import (
 	"log"
 	"mime"
 	"net/http"
+	"net/url"
 	"os"
 	"os/signal"
 	"path"
@@ -25,9 +26,19 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/salesforce/sloop/pkg/sloop/export"
+	"github.com/salesforce/sloop/pkg/sloop/idle"
+	"github.com/salesforce/sloop/pkg/sloop/processing"
 	"github.com/salesforce/sloop/pkg/sloop/queries"
+	"github.com/salesforce/sloop/pkg/sloop/replay"
+	"github.com/salesforce/sloop/pkg/sloop/report"
+	"github.com/salesforce/sloop/pkg/sloop/shard"
+	"github.com/salesforce/sloop/pkg/sloop/standby"
 	"github.com/salesforce/sloop/pkg/sloop/store/typed"
 	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/salesforce/sloop/pkg/sloop/storemanager"
+	"github.com/salesforce/sloop/pkg/sloop/storesync"
+	"github.com/salesforce/sloop/pkg/sloop/tenant"
 
 	"github.com/golang/glog"
 	"github.com/gorilla/mux"
@@ -50,6 +61,9 @@ const (
 	resourceTemplateFile          = "resource.html"
 )
 
+// Set to false to write a full backup even when partition checksums do not match
+const verifyParam = "verify"
+
 type WebConfig struct {
 	BindAddress      string
 	Port             int
@@ -62,6 +76,54 @@ type WebConfig struct {
 	ResourceLinks    []ResourceLinkTemplate
 	LeftBarLinks     []LinkTemplate
 	CurrentContext   string
+	ShardMap         shard.Map
+	// When set, queries are fanned out to these shards instead of being run against the local store
+	ShardEndpoints map[string]string
+	EnableReplay   bool
+	// Key to check watch result signatures with.  Empty when signing is off
+	WatchSigningKey []byte
+	EnableSync      bool
+	// Watch results pushed over the sync API are sent here for processing
+	SyncIngestChan chan typed.KubeWatchResult
+	// Serves the API a primary streams its backups to, see standby.Receiver
+	EnableStandby bool
+	// Reports served on /report
+	Reports report.Map
+	// Temporary stores of exports run with spill=true go here.  Empty uses the system temp dir
+	ExportSpillDir string
+	// Used for exports and backups requested with anonymize=true.  Nil when no salt is configured
+	Anonymizer *export.Anonymizer
+	// Serves the compaction admin API when set
+	Compactor *storemanager.Compactor
+	// Serves the reprocessing admin API when set
+	Reprocessor *processing.Runner
+	// Serves the purge and undelete admin APIs when set
+	Purger *storemanager.Purger
+	// Serves the freeze and unfreeze admin APIs when set
+	Freezer *storemanager.Freezer
+	// Serves the retention override admin API when set
+	RetentionOverrides *storemanager.RetentionOverrides
+	// The startup self-test, nil when it is off
+	SelfTest *storemanager.SelfTest
+	// When set, callers are kept to the namespaces of the tenant named in TenantHeader, see tenantWrapper
+	Tenants      tenant.Map
+	TenantHeader string
+	TenantAdmin  string
+	// When set, requests need an identity from it, and tenants come from the groups of that identity
+	Authenticator Authenticator
+	// Queries and exports running at once, shared fairly between tenants or users, see queryScheduler.  0 = unlimited
+	MaxConcurrentQueries int
+	// Queries and exports kept in the query audit log, see queryAuditLog.  0 = off
+	QueryAuditSize int
+	// Maximum response size of queries, and by query name or endpoint path, see responseSizes.  0 = unlimited
+	MaxQueryResponseBytes int64
+	ResponseLimits        map[string]int64
+	// Latency targets by query name or endpoint path, reported on /slo/status, see querySloTracker
+	QuerySlos QuerySlos
+	// Attach the trace ids of callers to query latencies, see queryLatencyMiddleware
+	TraceExemplars bool
+	// Told about every request, so sloop leaves idle mode on the next one.  Nil when idle mode is off
+	IdleMonitor *idle.Monitor
 }
 
 var (
@@ -119,8 +181,27 @@ func webFileHandler(currentContext string) http.HandlerFunc {
 // backupHandler streams a download of a backup of the database.
 // It is a simple HTTP translation of the Badger DB's built-in online backup function.
 // If the optional `since` query parameter is provided, the backup will only include versions since the version provided.
-func backupHandler(db badgerwrap.DB, currentContext string) http.HandlerFunc {
+// With `anonymize=true` the backup is a full one of an anonymized copy of the store instead, see export.AnonymizedBackup.
+// Full backups are refused when a closed partition no longer matches its checksum, unless `verify=false`.
+func backupHandler(db badgerwrap.DB, currentContext string, exporter *export.Exporter, anonymizer *export.Anonymizer, maxLookBack time.Duration) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get(anonymizeParam) == "true" {
+			if anonymizer == nil {
+				logWebError(errNoAnonymizer, "Can not anonymize backup", r, w)
+				return
+			}
+			w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=sloop-%s-anonymized.bak", currentContext))
+			w.Header().Set("Content-Type", "application/octet-stream")
+			count, err := exporter.AnonymizedBackup(w, anonymizer, maxLookBack)
+			if err != nil {
+				// Part of the body might already be written so the status can not be changed
+				glog.Errorf("Anonymized backup failed after %v watch results: %v", count, err)
+				return
+			}
+			glog.Infof("Wrote anonymized backup of %v watch results", count)
+			return
+		}
+
 		sinceStr := r.URL.Query().Get("since")
 		if sinceStr == "" {
 			sinceStr = "0"
@@ -131,6 +212,21 @@ func backupHandler(db badgerwrap.DB, currentContext string) http.HandlerFunc {
 			return
 		}
 
+		// Incremental backups only have versions written since, which are not in closed partitions
+		if since == 0 && r.URL.Query().Get(verifyParam) != "false" {
+			report, err := typed.VerifyPartitionChecksums(db)
+			if err != nil {
+				logWebError(err, "Error verifying partition checksums", r, w)
+				return
+			}
+			err = report.Err()
+			if err != nil {
+				glog.Errorf("Refusing backup: %v", err)
+				http.Error(w, fmt.Sprintf("%v, see debug/checksums/ or use %v=false to back up anyway", err, verifyParam), http.StatusConflict)
+				return
+			}
+		}
+
 		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=sloop-%s-%d.bak", currentContext, since))
 		w.Header().Set("Content-Type", "application/octet-stream")
 		w.Header().Set("Transfer-Encoding", "chunked")
@@ -157,14 +253,34 @@ func queryHandler(tables typed.Tables, maxLookBack time.Duration) http.HandlerFu
 		queryName := request.URL.Query().Get(queries.QueryParam)
 		data, err := queries.RunQuery(queryName, request.URL.Query(), tables, maxLookBack, getRequestId(request.Context()))
 		if err != nil {
-			logWebError(err, "Failed to run query", request, writer)
+			writeApiError(writer, request, err, "Failed to run query")
 			return
 		}
+		// Queries without a time range get no warning
+		startTime, _, err := queries.ComputeTimeRange(request.URL.Query(), tables, maxLookBack)
+		if err == nil {
+			setRetentionHeaders(writer, tables, request.URL.Query(), startTime, maxLookBack)
+		}
 
 		writer.Write(data)
 	}
 }
 
+// Tells the caller the time range started before the oldest retained data or was cut to the max look back, so
+// periods that were left out do not look quiet
+func setRetentionHeaders(writer http.ResponseWriter, tables typed.Tables, params url.Values, startTime time.Time, maxLookBack time.Duration) {
+	retainedSince, warning, err := queries.GetTimeRangeWarning(tables, params, startTime, maxLookBack)
+	if err != nil {
+		glog.Errorf("Failed to find the oldest retained data: %v", err)
+		return
+	}
+	if warning == "" {
+		return
+	}
+	writer.Header().Set(queries.RetentionWarningHeader, warning)
+	writer.Header().Set(queries.RetainedSinceHeader, strconv.FormatInt(retainedSince.Unix(), 10))
+}
+
 func healthHandler() http.HandlerFunc {
 	return func(writer http.ResponseWriter, request *http.Request) {
 		writer.WriteHeader(http.StatusOK)
@@ -183,15 +299,81 @@ func redirectHandler(currentContext string) http.HandlerFunc {
 // Registers paths for mux router
 func registerPaths(router *mux.Router, config WebConfig, tables typed.Tables) {
 	router.PathPrefix("/webfiles/").HandlerFunc(webFileHandler(config.CurrentContext))
-	router.HandleFunc("/data/backup", backupHandler(tables.Db(), config.CurrentContext))
-	router.HandleFunc("/data", queryHandler(tables, config.MaxLookback))
+	exporter := export.NewExporter(tables, &badgerwrap.BadgerFactory{}, config.ExportSpillDir)
+	scheduler := newQueryScheduler(config.MaxConcurrentQueries, config.Tenants)
+	auditLog := newQueryAuditLog(tables.Db(), config.QueryAuditSize)
+	router.Use(idleMiddleware(config.IdleMonitor))
+	router.Use(newResponseSizes(config.MaxQueryResponseBytes, config.ResponseLimits).middleware)
+	router.Use(queryLatencyMiddleware(config.TraceExemplars))
+	sloTracker := newQuerySloTracker(config.QuerySlos)
+	metricQuerySlos.setTracker(sloTracker)
+	router.Use(sloTracker.middleware)
+	router.HandleFunc("/data/backup", backupHandler(tables.Db(), config.CurrentContext, exporter, config.Anonymizer, config.MaxLookback))
+	if len(config.ShardEndpoints) > 0 {
+		router.HandleFunc("/data", auditLog.wrap(shardQueryHandler(config.ShardMap, config.ShardEndpoints, shardForwardHeaders(config.Authenticator, config.TenantHeader))))
+	} else {
+		router.HandleFunc("/data", auditLog.wrap(scheduler.wrap(queryHandler(tables, config.MaxLookback))))
+		router.HandleFunc(structuredQueryPath, auditLog.wrap(scheduler.wrap(structuredQueryHandler(tables, config.MaxLookback))))
+		router.HandleFunc(baselinePath, auditLog.wrap(scheduler.wrap(baselineHandler(tables, config.MaxLookback))))
+	}
 	router.HandleFunc("/resource", resourceHandler(config.ResourceLinks, config.CurrentContext))
+	router.HandleFunc(resourceAtPath, auditLog.wrap(scheduler.wrap(resourceAtHandler(tables))))
+	router.HandleFunc(rawWatchPath, auditLog.wrap(rawWatchHandler(tables, config.MaxLookback)))
+	router.HandleFunc(eventHeatMapPath, auditLog.wrap(eventHeatMapHandler(tables, config.MaxLookback)))
+	if config.EnableReplay {
+		replayMgr := replay.NewManager(tables)
+		router.HandleFunc("/replay", replayStartHandler(replayMgr, tables, config.MaxLookback))
+		router.HandleFunc("/replay/status", replayStatusHandler(replayMgr))
+		router.HandleFunc("/replay/cancel", replayCancelHandler(replayMgr))
+	}
+	router.HandleFunc("/export", auditLog.wrap(scheduler.wrap(exportHandler(exporter, tables, config.MaxLookback, config.Anonymizer))))
+	if config.EnableSync {
+		syncEndpoint := storesync.NewLocalEndpoint(tables, config.SyncIngestChan)
+		router.HandleFunc(storesync.PartitionsPath, syncPartitionsHandler(syncEndpoint))
+		router.HandleFunc(storesync.KeysPath, syncKeysHandler(syncEndpoint))
+		router.HandleFunc(storesync.ResultsPath, syncResultsHandler(syncEndpoint))
+		router.HandleFunc(storesync.PushPath, syncPushHandler(syncEndpoint))
+	}
+	if config.Reports != nil {
+		router.HandleFunc(reportPath, auditLog.wrap(scheduler.wrap(reportHandler(config.Reports, tables, config.MaxLookback))))
+	}
+	if config.EnableStandby {
+		receiver := standby.NewReceiver(tables.Db())
+		router.HandleFunc(standby.StatusPath, standbyStatusHandler(receiver))
+		router.HandleFunc(standby.LoadPath, standbyLoadHandler(receiver))
+	}
+	if config.Compactor != nil {
+		router.HandleFunc(compactPath, compactHandler(config.Compactor))
+	}
+	if config.Reprocessor != nil {
+		router.HandleFunc(reprocessPath, reprocessHandler(config.Reprocessor))
+	}
+	if config.Purger != nil {
+		router.HandleFunc(purgePath, purgeHandler(config.Purger))
+		router.HandleFunc(undeletePath, undeleteHandler(config.Purger))
+	}
+	// Exporting the audit log is a read of it, so it is audited too
+	router.HandleFunc(auditExportPath, auditLog.wrap(auditExportHandler(auditLog, config.CurrentContext)))
+	if config.Freezer != nil {
+		router.HandleFunc(freezePath, freezeHandler(config.Freezer))
+		router.HandleFunc(unfreezePath, unfreezeHandler(config.Freezer))
+	}
+	if config.RetentionOverrides != nil {
+		router.HandleFunc(retentionOverridePath, retentionOverrideHandler(config.RetentionOverrides))
+	}
 	// Debug pages
 	router.HandleFunc("/debug/listkeys/", listKeysHandler(tables))
 	router.HandleFunc("/debug/histogram/", histogramHandler(tables))
 	router.HandleFunc("/debug/tables/", debugBadgerTablesHandler(tables.Db()))
 	router.HandleFunc("/debug/view", viewKeyHandler(tables))
 	router.HandleFunc("/debug/config/", configHandler(config.ConfigYaml))
+	router.HandleFunc("/debug/processing/", processingStatusHandler())
+	router.HandleFunc("/debug/signatures/", verifySignaturesHandler(tables, config.WatchSigningKey))
+	router.HandleFunc("/debug/checksums/", verifyChecksumsHandler(tables.Db()))
+	router.HandleFunc("/debug/budget/", budgetReportHandler(tables))
+	router.HandleFunc("/debug/queryaudit/", queryAuditHandler(auditLog))
+	router.HandleFunc("/debug/recovery/", recoveryReportsHandler(tables.Db()))
+	router.HandleFunc("/debug/selftest", selfTestHandler(config.SelfTest))
 	// Badger uses the trace package, which registers /debug/requests and /debug/events
 	router.HandleFunc("/debug/requests", trace.Traces)
 	router.HandleFunc("/debug/events", trace.Events)
@@ -199,6 +381,7 @@ func registerPaths(router *mux.Router, config WebConfig, tables typed.Tables) {
 	router.HandleFunc("/debug/vars", expvar.Handler().ServeHTTP)
 	router.HandleFunc("/debug/", debugHandler())
 
+	router.HandleFunc(sloStatusPath, sloStatusHandler(sloTracker))
 	router.HandleFunc("/healthz", healthHandler())
 	router.Handle("/metrics", promhttp.HandlerFor(
 		prometheus.DefaultGatherer,
@@ -221,7 +404,7 @@ func Run(config WebConfig, tables typed.Tables) error {
 
 	h := &http.Server{
 		Addr:     addr,
-		Handler:  traceWrapper(glogWrapper(server)),
+		Handler:  traceWrapper(glogWrapper(authWrapper(tenantWrapper(server, config.Tenants, config.TenantHeader, config.TenantAdmin), config.Authenticator))),
 		ErrorLog: log.New(os.Stdout, "http: ", log.LstdFlags),
 	}
 	if config.BindAddress != "" {
@@ -1,10 +1,17 @@
 package webserver
 
 import (
+	"fmt"
+	"github.com/dgraph-io/badger/v2"
+	"github.com/salesforce/sloop/pkg/sloop/queries"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
 	"github.com/stretchr/testify/assert"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 func TestRedirectHandlerHandler(t *testing.T) {
@@ -32,3 +39,35 @@ func TestWebFileHandler(t *testing.T) {
 	assert.Equal(t, http.StatusOK, rr.Code)
 	assert.NotNil(t, rr.Body.String())
 }
+
+func TestQueryHandler_RetentionWarning(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	ts := time.Date(2019, 3, 1, 3, 4, 0, 0, time.UTC)
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		key := typed.NewWatchTableKey(untyped.GetPartitionId(ts), "Pod", "ns", "pod-a", ts)
+		return tables.WatchTable().Set(txn, key.String(), &typed.KubeWatchResult{Kind: "Pod", WatchType: typed.KubeWatchResult_ADD, Payload: `{}`})
+	})
+	assert.Nil(t, err)
+	handler := queryHandler(tables, 24*time.Hour)
+
+	rr := httptest.NewRecorder()
+	handler(rr, httptest.NewRequest(http.MethodGet, fmt.Sprintf("/data?query=Kinds&start_time=%v&end_time=%v", ts.Add(-2*time.Hour).Unix(), ts.Unix()), nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "data before 2019-03-01T03:00Z has been purged", rr.Header().Get(queries.RetentionWarningHeader))
+	assert.Equal(t, fmt.Sprint(ts.Truncate(time.Hour).Unix()), rr.Header().Get(queries.RetainedSinceHeader))
+
+	rr = httptest.NewRecorder()
+	handler(rr, httptest.NewRequest(http.MethodGet, fmt.Sprintf("/data?query=Kinds&start_time=%v&end_time=%v", ts.Unix(), ts.Add(time.Hour).Unix()), nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "", rr.Header().Get(queries.RetentionWarningHeader))
+
+	// Longer than the max look back, the start is cut and the response says so
+	rr = httptest.NewRecorder()
+	handler(rr, httptest.NewRequest(http.MethodGet, fmt.Sprintf("/data?query=Kinds&start_time=%v&end_time=%v", ts.Add(-48*time.Hour).Unix(), ts.Add(30*time.Minute).Unix()), nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "the time range was cut to the max look back of 24h0m0s, data before 2019-02-28T03:34Z is left out, and data before 2019-03-01T03:00Z has been purged", rr.Header().Get(queries.RetentionWarningHeader))
+	assert.Equal(t, fmt.Sprint(ts.Truncate(time.Hour).Unix()), rr.Header().Get(queries.RetainedSinceHeader))
+}